@@ -59,6 +59,31 @@ func (o *OriginData) BlockBytes() []byte {
 	return o.bb
 }
 
+// StateRoot returns the hash_tree_root of the downloaded BeaconState value.
+func (o *OriginData) StateRoot() [32]byte {
+	return o.sr
+}
+
+// StateSlot returns the slot of the downloaded BeaconState value.
+func (o *OriginData) StateSlot() primitives.Slot {
+	return o.st.Slot()
+}
+
+// BlockRoot returns the hash_tree_root of the downloaded ReadOnlySignedBeaconBlock value.
+func (o *OriginData) BlockRoot() [32]byte {
+	return o.br
+}
+
+// BlockSlot returns the slot of the downloaded ReadOnlySignedBeaconBlock value.
+func (o *OriginData) BlockSlot() primitives.Slot {
+	return o.b.Block().Slot()
+}
+
+// GenesisValidatorsRoot returns the genesis validators root of the downloaded BeaconState value.
+func (o *OriginData) GenesisValidatorsRoot() [32]byte {
+	return bytesutil.ToBytes32(o.st.GenesisValidatorsRoot())
+}
+
 func fname(prefix string, vu *detect.VersionedUnmarshaler, slot primitives.Slot, root [32]byte) string {
 	return fmt.Sprintf("%s_%s_%s_%d-%#x.ssz", prefix, vu.Config.ConfigName, version.String(vu.Fork), slot, root)
 }
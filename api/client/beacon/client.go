@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"text/template"
 
+	"github.com/prysmaticlabs/prysm/v4/api"
 	"github.com/prysmaticlabs/prysm/v4/api/client"
 	"github.com/prysmaticlabs/prysm/v4/network/forks"
 	v1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
@@ -27,15 +28,18 @@ import (
 )
 
 const (
-	getSignedBlockPath       = "/eth/v2/beacon/blocks"
-	getBlockRootPath         = "/eth/v1/beacon/blocks/{{.Id}}/root"
-	getForkForStatePath      = "/eth/v1/beacon/states/{{.Id}}/fork"
-	getWeakSubjectivityPath  = "/eth/v1/beacon/weak_subjectivity"
-	getForkSchedulePath      = "/eth/v1/config/fork_schedule"
-	getConfigSpecPath        = "/eth/v1/config/spec"
-	getStatePath             = "/eth/v2/debug/beacon/states"
-	getNodeVersionPath       = "/eth/v1/node/version"
-	changeBLStoExecutionPath = "/eth/v1/beacon/pool/bls_to_execution_changes"
+	getSignedBlockPath         = "/eth/v2/beacon/blocks"
+	getBlockRootPath           = "/eth/v1/beacon/blocks/{{.Id}}/root"
+	getStateRootPath           = "/eth/v1/beacon/states/{{.Id}}/root"
+	getForkForStatePath        = "/eth/v1/beacon/states/{{.Id}}/fork"
+	getWeakSubjectivityPath    = "/eth/v1/beacon/weak_subjectivity"
+	getForkSchedulePath        = "/eth/v1/config/fork_schedule"
+	getConfigSpecPath          = "/eth/v1/config/spec"
+	getStatePath               = "/eth/v2/debug/beacon/states"
+	getNodeVersionPath         = "/eth/v1/node/version"
+	getForkChoicePath          = "/eth/v1/debug/fork_choice"
+	changeBLStoExecutionPath   = "/eth/v1/beacon/pool/bls_to_execution_changes"
+	getValidatorExitStatusPath = "/prysm/states/{{.Id}}/validator_exit_status"
 )
 
 // StateOrBlockId represents the block_id / state_id parameters that several of the Eth Beacon API methods accept.
@@ -137,6 +141,30 @@ func (c *Client) GetBlockRoot(ctx context.Context, blockId StateOrBlockId) ([32]
 	return bytesutil.ToBytes32(rs), nil
 }
 
+var getStateRootTpl = idTemplate(getStateRootPath)
+
+// GetStateRoot retrieves the hash_tree_root of the BeaconState for the given state id.
+// State identifier can be one of: "head" (canonical head in node's view), "genesis", "finalized",
+// <slot>, <hex encoded stateRoot with 0x prefix>. Variables of type StateOrBlockId are exported by this package
+// for the named identifiers.
+func (c *Client) GetStateRoot(ctx context.Context, stateId StateOrBlockId) ([32]byte, error) {
+	rootPath := getStateRootTpl(stateId)
+	b, err := c.Get(ctx, rootPath)
+	if err != nil {
+		return [32]byte{}, errors.Wrapf(err, "error requesting state root by id = %s", stateId)
+	}
+	jsonr := &struct{ Data struct{ Root string } }{}
+	err = json.Unmarshal(b, jsonr)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "error decoding json data from get state root response")
+	}
+	rs, err := hexutil.Decode(jsonr.Data.Root)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, fmt.Sprintf("error decoding hex-encoded value %s", jsonr.Data.Root))
+	}
+	return bytesutil.ToBytes32(rs), nil
+}
+
 var getForkTpl = idTemplate(getForkForStatePath)
 
 // GetFork queries the Beacon Node API for the Fork from the state identified by stateId.
@@ -247,6 +275,16 @@ func (c *Client) GetState(ctx context.Context, stateId StateOrBlockId) ([]byte,
 	return b, nil
 }
 
+// GetForkChoice retrieves a dump of the fork choice store of the beacon node.
+// The return value contains the json-encoded response.
+func (c *Client) GetForkChoice(ctx context.Context) ([]byte, error) {
+	b, err := c.Get(ctx, getForkChoicePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting fork choice dump")
+	}
+	return b, nil
+}
+
 // GetWeakSubjectivity calls a proposed API endpoint that is unique to prysm
 // This api method does the following:
 // - computes weak subjectivity epoch
@@ -335,6 +373,39 @@ func (c *Client) GetBLStoExecutionChanges(ctx context.Context) (*apimiddleware.B
 	return poolResponse, nil
 }
 
+var getValidatorExitStatusTpl = idTemplate(getValidatorExitStatusPath)
+
+// GetValidatorExitStatus calls the Prysm specific endpoint returning the exit queue position, the exit and
+// withdrawable epochs and the withdrawal credentials type of the given validators, identified by index or hex
+// encoded public key, at the given state.
+func (c *Client) GetValidatorExitStatus(ctx context.Context, stateId StateOrBlockId, ids []string) (*api.ValidatorExitStatusResponse, error) {
+	u := c.BaseURL().ResolveReference(&url.URL{Path: getValidatorExitStatusTpl(stateId)})
+	body, err := json.Marshal(&api.ValidatorExitStatusRequest{Ids: ids})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal JSON")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid format, failed to create new POST request object")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.Non200Err(resp)
+	}
+	exitStatus := &api.ValidatorExitStatusResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(exitStatus); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode response JSON for %s", resp.Request.URL)
+	}
+	return exitStatus, nil
+}
+
 type forkResponse struct {
 	PreviousVersion string `json:"previous_version"`
 	CurrentVersion  string `json:"current_version"`
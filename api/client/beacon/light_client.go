@@ -0,0 +1,152 @@
+package beacon
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api"
+	"github.com/prysmaticlabs/prysm/v4/api/client"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+const (
+	getLightClientBootstrapPath      = "/eth/v1/beacon/light_client/bootstrap/{{.Id}}"
+	getLightClientUpdatesPath        = "/eth/v1/beacon/light_client/updates"
+	getLightClientFinalityUpdatePath = "/eth/v1/beacon/light_client/finality_update"
+
+	// lightClientUpdateChunkPrefixLength is the length of the response chunk length and fork digest prefixing
+	// every update of an SSZ encoded updates response.
+	lightClientUpdateChunkPrefixLength = 8 + 4
+)
+
+var getLightClientBootstrapTpl = idTemplate(getLightClientBootstrapPath)
+
+// GetLightClientBootstrap retrieves the light client bootstrap of the block with the given root. It is decoded
+// as the container of the fork named by the Eth-Consensus-Version header of the response.
+func (c *Client) GetLightClientBootstrap(ctx context.Context, blockRoot [32]byte) (*lightclient.Bootstrap, error) {
+	b, h, err := c.getLightClientData(ctx, getLightClientBootstrapTpl(IdFromRoot(blockRoot)), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting light client bootstrap of block %#x", blockRoot)
+	}
+	v, err := consensusVersion(h)
+	if err != nil {
+		return nil, err
+	}
+	bootstrap, err := lightclient.UnmarshalBootstrap(v, b)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding light client bootstrap")
+	}
+	return bootstrap, nil
+}
+
+// GetLightClientUpdatesByRange retrieves the best light client updates of up to count sync committee periods,
+// starting at startPeriod. The server stops at the first period it has no update for, so fewer updates than
+// requested may be returned. The genesis validators root is needed to find the fork of every update from the
+// fork digest it is served with.
+func (c *Client) GetLightClientUpdatesByRange(ctx context.Context, startPeriod, count uint64, genesisValidatorsRoot [32]byte) ([]*lightclient.Update, error) {
+	b, _, err := c.getLightClientData(ctx, getLightClientUpdatesPath, url.Values{
+		"start_period": []string{strconv.FormatUint(startPeriod, 10)},
+		"count":        []string{strconv.FormatUint(count, 10)},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting light client updates")
+	}
+	return decodeLightClientUpdates(b, genesisValidatorsRoot)
+}
+
+// decodeLightClientUpdates decodes an SSZ encoded updates response, made of a chunk per update holding
+// the length of the chunk, the fork digest of the update and the update itself. Every update is decoded as the
+// container of the fork with its digest, as a range of updates may span several forks.
+func decodeLightClientUpdates(b []byte, genesisValidatorsRoot [32]byte) ([]*lightclient.Update, error) {
+	var updates []*lightclient.Update
+	for len(b) > 0 {
+		if len(b) < lightClientUpdateChunkPrefixLength {
+			return nil, errors.Errorf("light client updates response chunk of %d bytes is too short", len(b))
+		}
+		chunkLen := binary.LittleEndian.Uint64(b[:8])
+		if chunkLen < 4 || chunkLen > uint64(len(b)-8) {
+			return nil, errors.Errorf("invalid light client updates response chunk length %d", chunkLen)
+		}
+		_, epoch, err := forks.RetrieveForkDataFromDigest(bytesutil.ToBytes4(b[8:lightClientUpdateChunkPrefixLength]), genesisValidatorsRoot[:])
+		if err != nil {
+			return nil, errors.Wrap(err, "unknown light client update fork digest")
+		}
+		slot, err := slots.EpochStart(epoch)
+		if err != nil {
+			return nil, err
+		}
+		update, err := lightclient.UnmarshalUpdate(lightclient.VersionAtSlot(slot), b[lightClientUpdateChunkPrefixLength:8+chunkLen])
+		if err != nil {
+			return nil, errors.Wrap(err, "error decoding light client update")
+		}
+		updates = append(updates, update)
+		b = b[8+chunkLen:]
+	}
+	return updates, nil
+}
+
+// GetLightClientFinalityUpdate retrieves the latest light client finality update. It is decoded as the
+// container of the fork named by the Eth-Consensus-Version header of the response.
+func (c *Client) GetLightClientFinalityUpdate(ctx context.Context) (*lightclient.FinalityUpdate, error) {
+	b, h, err := c.getLightClientData(ctx, getLightClientFinalityUpdatePath, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting light client finality update")
+	}
+	v, err := consensusVersion(h)
+	if err != nil {
+		return nil, err
+	}
+	update, err := lightclient.UnmarshalFinalityUpdate(v, b)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding light client finality update")
+	}
+	return update, nil
+}
+
+// getLightClientData requests the SSZ encoding of the light client data at the given path, and returns it
+// with the headers of the response.
+func (c *Client) getLightClientData(ctx context.Context, path string, query url.Values) ([]byte, http.Header, error) {
+	u := c.BaseURL().ResolveReference(&url.URL{Path: path, RawQuery: query.Encode()})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid format, failed to create new GET request object")
+	}
+	client.WithSSZEncoding()(req)
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		err = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, client.Non200Err(resp)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error reading http response body")
+	}
+	return b, resp.Header, nil
+}
+
+// consensusVersion returns the fork named by the Eth-Consensus-Version header of a response.
+func consensusVersion(h http.Header) (int, error) {
+	name := h.Get(api.VersionHeader)
+	if name == "" {
+		return 0, errors.Errorf("missing %s header", api.VersionHeader)
+	}
+	v, err := version.FromString(name)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s header", api.VersionHeader)
+	}
+	return v, nil
+}
@@ -0,0 +1,120 @@
+package beacon
+
+import (
+	"context"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/api"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// setLightClientForkEpochs schedules Altair at genesis, Bellatrix at epoch 1 and Capella at epoch 2.
+func setLightClientForkEpochs(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 1
+	cfg.CapellaForkEpoch = 2
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+}
+
+func TestGetLightClientUpdatesByRange(t *testing.T) {
+	setLightClientForkEpochs(t)
+	gvr := [32]byte{'a'}
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	// An Altair update followed by a Capella one, each prefixed with the fork digest of its attested slot.
+	var body []byte
+	for _, slot := range []primitives.Slot{1, 2 * slotsPerEpoch} {
+		u := util.HydrateLightClientUpdate(&lightclient.Update{AttestedHeader: util.NewLightClientHeader(t, slot), SignatureSlot: slot + 1})
+		m, err := u.Proto()
+		require.NoError(t, err)
+		enc, err := m.MarshalSSZ()
+		require.NoError(t, err)
+		digest, err := forks.ForkDigestFromEpoch(slots.ToEpoch(slot), gvr[:])
+		require.NoError(t, err)
+		chunkLen := make([]byte, 8)
+		binary.LittleEndian.PutUint64(chunkLen, uint64(4+len(enc)))
+		body = append(body, chunkLen...)
+		body = append(body, digest[:]...)
+		body = append(body, enc...)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != getLightClientUpdatesPath || r.URL.Query().Get("start_period") != "1" || r.URL.Query().Get("count") != "2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write(body)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+	c, err := NewClient(srv.URL)
+	require.NoError(t, err)
+
+	updates, err := c.GetLightClientUpdatesByRange(context.Background(), 1, 2, gvr)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(updates))
+	require.Equal(t, version.Altair, updates[0].Version())
+	require.Equal(t, version.Capella, updates[1].Version())
+	require.Equal(t, uint64(2*slotsPerEpoch), updates[1].AttestedHeader.Execution.BlockNumber())
+
+	_, err = c.GetLightClientUpdatesByRange(context.Background(), 2, 2, gvr)
+	require.ErrorContains(t, "404", err)
+
+	_, err = decodeLightClientUpdates(body, [32]byte{'b'})
+	require.ErrorContains(t, "unknown light client update fork digest", err)
+	_, err = decodeLightClientUpdates(body[:len(body)-1], gvr)
+	require.ErrorContains(t, "invalid light client updates response chunk length", err)
+	_, err = decodeLightClientUpdates(body[:10], gvr)
+	require.ErrorContains(t, "too short", err)
+}
+
+func TestGetLightClientFinalityUpdate(t *testing.T) {
+	setLightClientForkEpochs(t)
+	slot := 2 * params.BeaconConfig().SlotsPerEpoch
+	u := util.HydrateLightClientUpdate(&lightclient.Update{
+		AttestedHeader:  util.NewLightClientHeader(t, slot),
+		FinalizedHeader: util.NewLightClientHeader(t, slot),
+		SignatureSlot:   slot + 1,
+	})
+	m, err := lightclient.NewLightClientFinalityUpdateFromUpdate(u).Proto()
+	require.NoError(t, err)
+	enc, err := m.MarshalSSZ()
+	require.NoError(t, err)
+
+	var versionHeader string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if versionHeader != "" {
+			w.Header().Set(api.VersionHeader, versionHeader)
+		}
+		_, err := w.Write(enc)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+	c, err := NewClient(srv.URL)
+	require.NoError(t, err)
+
+	versionHeader = version.String(version.Capella)
+	update, err := c.GetLightClientFinalityUpdate(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(slot), update.FinalizedHeader.Execution.BlockNumber())
+
+	versionHeader = version.String(version.Altair)
+	_, err = c.GetLightClientFinalityUpdate(context.Background())
+	require.ErrorContains(t, "error decoding light client finality update", err)
+
+	versionHeader = ""
+	_, err = c.GetLightClientFinalityUpdate(context.Background())
+	require.ErrorContains(t, "missing Eth-Consensus-Version header", err)
+}
@@ -13,7 +13,11 @@ import (
 
 // MockClient is a mock implementation of BuilderClient.
 type MockClient struct {
+	URL            string
 	RegisteredVals map[[48]byte]bool
+	Bid            builder.SignedBid
+	Payload        interfaces.ExecutionData
+	BlobsBundle    *v1.BlobsBundle
 }
 
 // NewClient creates a new, correctly initialized mock.
@@ -22,13 +26,13 @@ func NewClient() MockClient {
 }
 
 // NodeURL --
-func (MockClient) NodeURL() string {
-	return ""
+func (m MockClient) NodeURL() string {
+	return m.URL
 }
 
 // GetHeader --
-func (MockClient) GetHeader(_ context.Context, _ primitives.Slot, _ [32]byte, _ [48]byte) (builder.SignedBid, error) {
-	return nil, nil
+func (m MockClient) GetHeader(_ context.Context, _ primitives.Slot, _ [32]byte, _ [48]byte) (builder.SignedBid, error) {
+	return m.Bid, nil
 }
 
 // RegisterValidator --
@@ -41,8 +45,8 @@ func (m MockClient) RegisterValidator(_ context.Context, svr []*ethpb.SignedVali
 }
 
 // SubmitBlindedBlock --
-func (MockClient) SubmitBlindedBlock(_ context.Context, _ interfaces.ReadOnlySignedBeaconBlock, _ []*ethpb.SignedBlindedBlobSidecar) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
-	return nil, nil, nil
+func (m MockClient) SubmitBlindedBlock(_ context.Context, _ interfaces.ReadOnlySignedBeaconBlock, _ []*ethpb.SignedBlindedBlobSidecar) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
+	return m.Payload, m.BlobsBundle, nil
 }
 
 // Status --
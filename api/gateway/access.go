@@ -0,0 +1,159 @@
+package gateway
+
+import (
+	"crypto/subtle"
+	"math"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	leakybucket "github.com/prysmaticlabs/prysm/v4/container/leaky-bucket"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+)
+
+// RateLimits defines how many requests per second a single client IP address is allowed to make, and how many
+// requests above this rate it may burst. Expensive routes, such as state and validator queries, are limited
+// separately and in addition to the general limit. A zero rate disables the corresponding limit.
+type RateLimits struct {
+	Rate           float64
+	Burst          int64
+	ExpensiveRate  float64
+	ExpensiveBurst int64
+}
+
+// expensiveRoutes match the paths of requests which are costly to serve, mostly because they read or replay states.
+var expensiveRoutes = []*regexp.Regexp{
+	regexp.MustCompile(`^/eth/v[0-9]+/debug/beacon/states/`),
+	regexp.MustCompile(`^/eth/v[0-9]+/beacon/states/[^/]+/(validators|validator_balances|committees|sync_committees)`),
+	regexp.MustCompile(`^/eth/v[0-9]+/beacon/rewards/`),
+	regexp.MustCompile(`^/prysm/states/`),
+}
+
+var rateLimitedRequestsCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "api_rate_limited_requests_total",
+	Help: "The number of API requests rejected because the client exceeded its rate limit.",
+}, []string{"class"})
+
+type rateLimiter struct {
+	general   *leakybucket.Collector
+	expensive *leakybucket.Collector
+	limits    RateLimits
+}
+
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	l := &rateLimiter{limits: limits}
+	if limits.Rate > 0 {
+		l.general = leakybucket.NewCollector(limits.Rate, burstCapacity(limits.Rate, limits.Burst), time.Second, true /* deleteEmptyBuckets */)
+	}
+	if limits.ExpensiveRate > 0 {
+		l.expensive = leakybucket.NewCollector(limits.ExpensiveRate, burstCapacity(limits.ExpensiveRate, limits.ExpensiveBurst), time.Second, true /* deleteEmptyBuckets */)
+	}
+	return l
+}
+
+// burstCapacity is the capacity of the buckets of a limit. A client must always be able to make at least one request.
+func burstCapacity(rate float64, burst int64) int64 {
+	return int64(math.Max(1, rate)) + burst
+}
+
+// allow reports whether the client may make the request. Otherwise it returns the class of the limit the client
+// exceeded and the time after which it should retry.
+func (l *rateLimiter) allow(client, path string) (bool, string, time.Duration) {
+	if l.expensive != nil && isExpensiveRoute(path) {
+		if l.expensive.Add(client, 1) == 0 {
+			return false, "expensive", retryAfter(l.limits.ExpensiveRate)
+		}
+	}
+	if l.general != nil && l.general.Add(client, 1) == 0 {
+		return false, "general", retryAfter(l.limits.Rate)
+	}
+	return true, "", 0
+}
+
+func (l *rateLimiter) free() {
+	if l.general != nil {
+		l.general.Free()
+	}
+	if l.expensive != nil {
+		l.expensive.Free()
+	}
+}
+
+func retryAfter(rate float64) time.Duration {
+	return time.Duration(math.Ceil(1/rate)) * time.Second
+}
+
+func isExpensiveRoute(path string) bool {
+	for _, r := range expensiveRoutes {
+		if r.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientAddress returns the IP address of the client of a request. Headers set by proxies are deliberately not
+// trusted, as the limits are meant to be used when the API is exposed without a reverse proxy.
+func clientAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// rateLimitMiddleware rejects requests of clients exceeding their rate limits with 429 Too Many Requests.
+func (g *Gateway) rateLimitMiddleware(h http.Handler) http.Handler {
+	if g.limiter == nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			h.ServeHTTP(w, r)
+			return
+		}
+		ok, class, retry := g.limiter.allow(clientAddress(r), r.URL.Path)
+		if !ok {
+			rateLimitedRequestsCount.WithLabelValues(class).Inc()
+			w.Header().Set("Retry-After", strconv.Itoa(int(retry.Seconds())))
+			http2.HandleError(w, "Too many requests, rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// authMiddleware rejects requests which do not carry one of the configured tokens as a bearer token
+// with 401 Unauthorized. CORS preflight requests are let through, as browsers never authenticate them.
+func (g *Gateway) authMiddleware(h http.Handler) http.Handler {
+	if len(g.cfg.authTokens) == 0 {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions || g.authorized(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http2.HandleError(w, "Unauthorized, a valid bearer token is required", http.StatusUnauthorized)
+	})
+}
+
+func (g *Gateway) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return false
+	}
+	authorized := false
+	for _, t := range g.cfg.authTokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+			authorized = true
+		}
+	}
+	return authorized
+}
@@ -0,0 +1,105 @@
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestAuthMiddleware(t *testing.T) {
+	g := &Gateway{cfg: &config{authTokens: []string{"foo", "bar"}}}
+	h := g.authMiddleware(okHandler)
+
+	t.Run("valid token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/node/version", nil)
+		req.Header.Set("Authorization", "Bearer bar")
+		writer := httptest.NewRecorder()
+		h.ServeHTTP(writer, req)
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/node/version", nil)
+		req.Header.Set("Authorization", "Bearer baz")
+		writer := httptest.NewRecorder()
+		h.ServeHTTP(writer, req)
+		assert.Equal(t, http.StatusUnauthorized, writer.Code)
+		assert.Equal(t, "Bearer", writer.Header().Get("WWW-Authenticate"))
+	})
+	t.Run("no token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/node/version", nil)
+		writer := httptest.NewRecorder()
+		h.ServeHTTP(writer, req)
+		assert.Equal(t, http.StatusUnauthorized, writer.Code)
+	})
+	t.Run("preflight request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "http://foo.example/eth/v1/node/version", nil)
+		writer := httptest.NewRecorder()
+		h.ServeHTTP(writer, req)
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("no tokens configured", func(t *testing.T) {
+		g := &Gateway{cfg: &config{}}
+		req := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/node/version", nil)
+		writer := httptest.NewRecorder()
+		g.authMiddleware(okHandler).ServeHTTP(writer, req)
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	serve := func(h http.Handler, remoteAddr, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://foo.example"+path, nil)
+		req.RemoteAddr = remoteAddr
+		writer := httptest.NewRecorder()
+		h.ServeHTTP(writer, req)
+		return writer
+	}
+
+	t.Run("general limit", func(t *testing.T) {
+		g := &Gateway{cfg: &config{}, limiter: newRateLimiter(RateLimits{Rate: 1, Burst: 1})}
+		defer g.limiter.free()
+		h := g.rateLimitMiddleware(okHandler)
+
+		assert.Equal(t, http.StatusOK, serve(h, "1.2.3.4:1000", "/eth/v1/node/version").Code)
+		assert.Equal(t, http.StatusOK, serve(h, "1.2.3.4:2000", "/eth/v1/node/version").Code)
+		writer := serve(h, "1.2.3.4:3000", "/eth/v1/node/version")
+		assert.Equal(t, http.StatusTooManyRequests, writer.Code)
+		assert.Equal(t, "1", writer.Header().Get("Retry-After"))
+		// Other clients are limited separately.
+		assert.Equal(t, http.StatusOK, serve(h, "5.6.7.8:1000", "/eth/v1/node/version").Code)
+	})
+	t.Run("expensive limit", func(t *testing.T) {
+		g := &Gateway{cfg: &config{}, limiter: newRateLimiter(RateLimits{Rate: 100, ExpensiveRate: 0.5})}
+		defer g.limiter.free()
+		h := g.rateLimitMiddleware(okHandler)
+
+		assert.Equal(t, http.StatusOK, serve(h, "1.2.3.4:1000", "/eth/v1/beacon/states/head/validators").Code)
+		writer := serve(h, "1.2.3.4:1000", "/eth/v1/debug/beacon/states/head")
+		assert.Equal(t, http.StatusTooManyRequests, writer.Code)
+		assert.Equal(t, "2", writer.Header().Get("Retry-After"))
+		assert.Equal(t, http.StatusOK, serve(h, "1.2.3.4:1000", "/eth/v1/beacon/states/head/fork").Code)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		g := &Gateway{cfg: &config{}}
+		h := g.rateLimitMiddleware(okHandler)
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, http.StatusOK, serve(h, "1.2.3.4:1000", "/eth/v1/node/version").Code)
+		}
+	})
+}
+
+func TestIsExpensiveRoute(t *testing.T) {
+	require.Equal(t, true, isExpensiveRoute("/eth/v1/beacon/states/head/validators"))
+	require.Equal(t, true, isExpensiveRoute("/eth/v1/beacon/states/0x01/validator_balances"))
+	require.Equal(t, true, isExpensiveRoute("/eth/v2/debug/beacon/states/finalized"))
+	require.Equal(t, true, isExpensiveRoute("/eth/v1/beacon/rewards/attestations/1"))
+	require.Equal(t, false, isExpensiveRoute("/eth/v1/beacon/states/head/finality_checkpoints"))
+	require.Equal(t, false, isExpensiveRoute("/eth/v1/node/version"))
+}
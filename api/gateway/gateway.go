@@ -53,6 +53,8 @@ type config struct {
 	pbHandlers                   []*PbMux
 	router                       *mux.Router
 	timeout                      time.Duration
+	authTokens                   []string
+	rateLimits                   RateLimits
 }
 
 // Gateway is the gRPC gateway to serve HTTP JSON traffic as a proxy and forward it to the gRPC server.
@@ -62,6 +64,7 @@ type Gateway struct {
 	server       *http.Server
 	cancel       context.CancelFunc
 	proxy        *apimiddleware.ApiProxyMiddleware
+	limiter      *rateLimiter
 	ctx          context.Context
 	startFailure error
 }
@@ -121,9 +124,13 @@ func (g *Gateway) Start() {
 		})
 	}
 
+	if g.cfg.rateLimits.Rate > 0 || g.cfg.rateLimits.ExpensiveRate > 0 {
+		g.limiter = newRateLimiter(g.cfg.rateLimits)
+	}
+
 	g.server = &http.Server{
 		Addr:              g.cfg.gatewayAddr,
-		Handler:           corsMux,
+		Handler:           g.timeoutMiddleware(g.rateLimitMiddleware(g.authMiddleware(corsMux))),
 		ReadHeaderTimeout: time.Second,
 	}
 
@@ -161,12 +168,41 @@ func (g *Gateway) Stop() error {
 			}
 		}
 	}
+	if g.limiter != nil {
+		g.limiter.free()
+	}
 	if g.cancel != nil {
 		g.cancel()
 	}
 	return nil
 }
 
+// timeoutMiddleware attaches the API timeout to the context of every request, except for streams. Handlers
+// registered directly on the router do not go through the grpc-gateway, which applies the timeout on its own, and
+// would otherwise keep working on expensive requests, such as state replays, long after the caller has given up.
+func (g *Gateway) timeoutMiddleware(h http.Handler) http.Handler {
+	if g.cfg.timeout == 0 {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isStreamRequest(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), g.cfg.timeout)
+		defer cancel()
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// isStreamRequest returns whether the request is for a long lived stream, such as the server-sent events of
+// /eth/v1/events or the /prysm/slasher/slashings/stream endpoint, which must not be cut by the API timeout.
+func isStreamRequest(r *http.Request) bool {
+	return r.URL.Path == "/eth/v1/events" ||
+		strings.HasSuffix(r.URL.Path, "/stream") ||
+		strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
 func (g *Gateway) corsMiddleware(h http.Handler) http.Handler {
 	c := cors.New(cors.Options{
 		AllowedOrigins:   g.cfg.allowedOrigins,
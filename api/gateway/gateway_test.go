@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/prysmaticlabs/prysm/v4/api/gateway/apimiddleware"
@@ -126,3 +127,32 @@ func TestGateway_NilHandler_NotFoundHandlerRegistered(t *testing.T) {
 	g.cfg.router.ServeHTTP(writer, &http.Request{Method: "GET", Host: "localhost", URL: &url.URL{Path: "/foo"}})
 	assert.Equal(t, http.StatusNotFound, writer.Code)
 }
+
+func TestGateway_TimeoutMiddleware(t *testing.T) {
+	var deadline time.Time
+	var hasDeadline bool
+	h := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		deadline, hasDeadline = r.Context().Deadline()
+	})
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/foo", nil)
+
+	t.Run("no timeout", func(t *testing.T) {
+		g := &Gateway{cfg: &config{}}
+		g.timeoutMiddleware(h).ServeHTTP(httptest.NewRecorder(), req)
+		assert.Equal(t, false, hasDeadline)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		g := &Gateway{cfg: &config{timeout: time.Minute}}
+		start := time.Now()
+		g.timeoutMiddleware(h).ServeHTTP(httptest.NewRecorder(), req)
+		require.Equal(t, true, hasDeadline)
+		assert.Equal(t, true, !deadline.Before(start.Add(time.Minute)))
+	})
+	t.Run("streams", func(t *testing.T) {
+		g := &Gateway{cfg: &config{timeout: time.Minute}}
+		for _, path := range []string{"/eth/v1/events?topics=head", "/prysm/slasher/slashings/stream"} {
+			g.timeoutMiddleware(h).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://localhost"+path, nil))
+			assert.Equal(t, false, hasDeadline, "Deadline set for %s", path)
+		}
+	})
+}
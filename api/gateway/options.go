@@ -86,3 +86,19 @@ func WithTimeout(seconds uint64) Option {
 		return nil
 	}
 }
+
+// WithAuthTokens requires every request to carry one of the given tokens as a bearer token.
+func WithAuthTokens(tokens []string) Option {
+	return func(g *Gateway) error {
+		g.cfg.authTokens = tokens
+		return nil
+	}
+}
+
+// WithRateLimits limits the rate of requests of every client IP address.
+func WithRateLimits(limits RateLimits) Option {
+	return func(g *Gateway) error {
+		g.cfg.rateLimits = limits
+		return nil
+	}
+}
@@ -7,3 +7,23 @@ const (
 	JsonMediaType                 = "application/json"
 	OctetStreamMediaType          = "application/octet-stream"
 )
+
+// Headers exposing the choice between the local execution payload and the builder bid of a produced block.
+const (
+	PayloadSourceHeader          = "Prysm-Payload-Source"
+	PayloadSelectionReasonHeader = "Prysm-Payload-Selection-Reason"
+	LocalPayloadValueHeader      = "Prysm-Local-Payload-Value"
+	BuilderPayloadValueHeader    = "Prysm-Builder-Payload-Value"
+)
+
+// BroadcastExitHeader requests the validator client to broadcast the voluntary exit it signs through the
+// connected beacon node.
+const BroadcastExitHeader = "Prysm-Broadcast-Exit"
+
+// PayloadDecisionHeaders are the headers exposing the payload decision of a produced block.
+var PayloadDecisionHeaders = []string{
+	PayloadSourceHeader,
+	PayloadSelectionReasonHeader,
+	LocalPayloadValueHeader,
+	BuilderPayloadValueHeader,
+}
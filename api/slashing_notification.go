@@ -0,0 +1,54 @@
+package api
+
+// SlashingNotification is the JSON representation of a slashing detected by slasher, posted to the slasher
+// webhook and sent to the subscribers of the slashings stream. The slashings use the encoding of the Ethereum
+// beacon APIs.
+type SlashingNotification struct {
+	Type             string            `json:"type"`
+	ValidatorIndices []string          `json:"validator_indices"`
+	AlreadySlashed   bool              `json:"already_slashed"`
+	AttesterSlashing *AttesterSlashing `json:"attester_slashing,omitempty"`
+	ProposerSlashing *ProposerSlashing `json:"proposer_slashing,omitempty"`
+}
+
+type AttesterSlashing struct {
+	Attestation1 *IndexedAttestation `json:"attestation_1"`
+	Attestation2 *IndexedAttestation `json:"attestation_2"`
+}
+
+type IndexedAttestation struct {
+	AttestingIndices []string         `json:"attesting_indices"`
+	Data             *AttestationData `json:"data"`
+	Signature        string           `json:"signature"`
+}
+
+type AttestationData struct {
+	Slot            string      `json:"slot"`
+	CommitteeIndex  string      `json:"index"`
+	BeaconBlockRoot string      `json:"beacon_block_root"`
+	Source          *Checkpoint `json:"source"`
+	Target          *Checkpoint `json:"target"`
+}
+
+type Checkpoint struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+type ProposerSlashing struct {
+	SignedHeader1 *SignedBeaconBlockHeader `json:"signed_header_1"`
+	SignedHeader2 *SignedBeaconBlockHeader `json:"signed_header_2"`
+}
+
+type SignedBeaconBlockHeader struct {
+	Message   *BeaconBlockHeader `json:"message"`
+	Signature string             `json:"signature"`
+}
+
+type BeaconBlockHeader struct {
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
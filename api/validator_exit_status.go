@@ -0,0 +1,26 @@
+package api
+
+// ValidatorExitStatusRequest and ValidatorExitStatusResponse are the request and response bodies of the Prysm
+// validator exit status endpoint, shared by the beacon node serving it and the clients querying it.
+type ValidatorExitStatusRequest struct {
+	Ids []string `json:"ids"`
+}
+
+type ValidatorExitStatusResponse struct {
+	ExecutionOptimistic bool                   `json:"execution_optimistic"`
+	Finalized           bool                   `json:"finalized"`
+	Data                []*ValidatorExitStatus `json:"data"`
+}
+
+type ValidatorExitStatus struct {
+	Index                      string `json:"index"`
+	Pubkey                     string `json:"pubkey"`
+	Status                     string `json:"status"`
+	ActivationEligibilityEpoch string `json:"activation_eligibility_epoch"`
+	ActivationEpoch            string `json:"activation_epoch"`
+	ExitInitiated              bool   `json:"exit_initiated"`
+	ExitQueuePosition          string `json:"exit_queue_position"`
+	ExitEpoch                  string `json:"exit_epoch"`
+	WithdrawableEpoch          string `json:"withdrawable_epoch"`
+	WithdrawalCredentialsType  string `json:"withdrawal_credentials_type"`
+}
@@ -15,7 +15,8 @@ type WorkerResults struct {
 // Scatter scatters a computation across multiple goroutines.
 // This breaks the task in to a number of chunks and executes those chunks in parallel with the function provided.
 // Results returned are collected and presented as a set of WorkerResults, which can be reassembled by the calling function.
-// Any error that occurs in the workers will be passed back to the calling function.
+// Any error that occurs in the workers will be passed back to the calling function, once all workers are done, so
+// callers can safely discard the memory written to by the workers.
 func Scatter(inputLen int, sFunc func(int, int, *sync.RWMutex) (interface{}, error)) ([]*WorkerResults, error) {
 	if inputLen <= 0 {
 		return nil, errors.New("input length must be greater than 0")
@@ -27,9 +28,7 @@ func Scatter(inputLen int, sFunc func(int, int, *sync.RWMutex) (interface{}, err
 		workers++
 	}
 	resultCh := make(chan *WorkerResults, workers)
-	defer close(resultCh)
 	errorCh := make(chan error, workers)
-	defer close(errorCh)
 	mutex := new(sync.RWMutex)
 	for worker := 0; worker < workers; worker++ {
 		offset := worker * chunkSize
@@ -51,15 +50,21 @@ func Scatter(inputLen int, sFunc func(int, int, *sync.RWMutex) (interface{}, err
 	}
 
 	// Collect results from workers
-	results := make([]*WorkerResults, workers)
+	results := make([]*WorkerResults, 0, workers)
+	var firstErr error
 	for i := 0; i < workers; i++ {
 		select {
 		case result := <-resultCh:
-			results[i] = result
+			results = append(results, result)
 		case err := <-errorCh:
-			return nil, err
+			if firstErr == nil {
+				firstErr = err
+			}
 		}
 	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
 	return results, nil
 }
 
@@ -106,3 +106,22 @@ func TestError(t *testing.T) {
 		t.Fatalf("Missing expected error")
 	}
 }
+
+func TestError_WaitsForAllWorkers(t *testing.T) {
+	totalRuns := 1024
+	var mu sync.Mutex
+	processed := 0
+	_, err := async.Scatter(totalRuns, func(offset int, entries int, _ *sync.RWMutex) (interface{}, error) {
+		for i := 0; i < entries; i++ {
+			mu.Lock()
+			processed++
+			mu.Unlock()
+		}
+		// Every worker fails.
+		return nil, errors.New("bad number")
+	})
+	require.ErrorContains(t, "bad number", err)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, totalRuns, processed)
+}
@@ -0,0 +1,131 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// attestationTimingEvaluationDelay is the number of slots after which the votes simulated for a slot are compared
+// with the canonical block of the slot, which leaves time for late blocks and reorgs to settle.
+const attestationTimingEvaluationDelay = primitives.Slot(2)
+
+// attestationTimingOffsets returns the offsets into the slot at which attestation votes are simulated: a sixth, a
+// third and half of the slot, which are 2, 4 and 6 seconds on mainnet.
+func attestationTimingOffsets() []time.Duration {
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	return []time.Duration{slotDuration / 6, slotDuration / 3, slotDuration / 2}
+}
+
+// attestationTimingSimulation holds the head block roots a validator would have voted for at every simulated
+// offset of the recent slots.
+type attestationTimingSimulation struct {
+	offsets []time.Duration
+	votes   map[primitives.Slot][][32]byte
+}
+
+func newAttestationTimingSimulation(offsets []time.Duration) *attestationTimingSimulation {
+	return &attestationTimingSimulation{
+		offsets: offsets,
+		votes:   make(map[primitives.Slot][][32]byte),
+	}
+}
+
+// recordVote records the head root seen at the offset with the given index into the slot.
+func (a *attestationTimingSimulation) recordVote(slot primitives.Slot, interval int, root [32]byte) {
+	if interval < 0 || interval >= len(a.offsets) {
+		return
+	}
+	votes, ok := a.votes[slot]
+	if !ok {
+		votes = make([][32]byte, len(a.offsets))
+		a.votes[slot] = votes
+	}
+	votes[interval] = root
+}
+
+// popVotes returns the votes recorded for the given slot, and removes them along with the votes of older slots.
+func (a *attestationTimingSimulation) popVotes(slot primitives.Slot) ([][32]byte, bool) {
+	votes, ok := a.votes[slot]
+	for s := range a.votes {
+		if s <= slot {
+			delete(a.votes, s)
+		}
+	}
+	return votes, ok
+}
+
+// logFields returns the log fields comparing the votes of a slot with its canonical block root. Offsets with no
+// recorded vote, such as those before the routine started, are left out.
+func (a *attestationTimingSimulation) logFields(slot primitives.Slot, votes [][32]byte, canonical [32]byte) logrus.Fields {
+	fields := logrus.Fields{
+		"slot":          slot,
+		"canonicalRoot": fmt.Sprintf("%#x", bytesutil.Trunc(canonical[:])),
+	}
+	for i, offset := range a.offsets {
+		if votes[i] == [32]byte{} {
+			continue
+		}
+		fields[fmt.Sprintf("correctAt%s", offset)] = votes[i] == canonical
+		if votes[i] != canonical {
+			fields[fmt.Sprintf("voteAt%s", offset)] = fmt.Sprintf("%#x", bytesutil.Trunc(votes[i][:]))
+		}
+	}
+	return fields
+}
+
+// runAttestationTimingSimulation records the head at several offsets into every slot and logs, once the slot is
+// old enough, which of these votes matches the canonical block of the slot. This is a diagnostic routine for
+// evaluating attestation timing strategies against real network traffic.
+func (s *Service) runAttestationTimingSimulation() {
+	if err := s.waitForSync(); err != nil {
+		log.WithError(err).Error("failed to wait for initial sync")
+		return
+	}
+
+	sim := newAttestationTimingSimulation(attestationTimingOffsets())
+	ticker := slots.NewSlotTickerWithIntervals(s.genesisTime, sim.offsets)
+	defer ticker.Done()
+	for {
+		select {
+		case slotInterval := <-ticker.C():
+			s.headLock.RLock()
+			root := s.headRoot()
+			s.headLock.RUnlock()
+			sim.recordVote(slotInterval.Slot, slotInterval.Interval, root)
+			if slotInterval.Interval == 0 && slotInterval.Slot >= attestationTimingEvaluationDelay {
+				s.logAttestationTiming(s.ctx, sim, slotInterval.Slot-attestationTimingEvaluationDelay)
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting routine")
+			return
+		}
+	}
+}
+
+// logAttestationTiming compares the votes simulated for the given slot with the canonical block of the slot, which
+// is the block the current head descends from at that slot.
+func (s *Service) logAttestationTiming(ctx context.Context, sim *attestationTimingSimulation, slot primitives.Slot) {
+	votes, ok := sim.popVotes(slot)
+	if !ok {
+		return
+	}
+	s.headLock.RLock()
+	head := s.headRoot()
+	s.headLock.RUnlock()
+
+	s.cfg.ForkChoiceStore.RLock()
+	canonical, err := s.cfg.ForkChoiceStore.AncestorRoot(ctx, head, slot)
+	s.cfg.ForkChoiceStore.RUnlock()
+	if err != nil {
+		log.WithError(err).WithField("slot", slot).Debug("Could not determine canonical block for attestation timing simulation")
+		return
+	}
+	log.WithFields(sim.logFields(slot, votes, canonical)).Info("Simulated attestation timing")
+}
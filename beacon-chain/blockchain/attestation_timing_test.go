@@ -0,0 +1,81 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestAttestationTimingSimulation_RecordAndPopVotes(t *testing.T) {
+	sim := newAttestationTimingSimulation([]time.Duration{time.Second, 2 * time.Second})
+	sim.recordVote(1, 0, [32]byte{'a'})
+	sim.recordVote(2, 1, [32]byte{'b'})
+	sim.recordVote(3, 0, [32]byte{'c'})
+	sim.recordVote(3, 2, [32]byte{'d'})
+
+	votes, ok := sim.popVotes(2)
+	require.Equal(t, true, ok)
+	require.DeepEqual(t, [][32]byte{{}, {'b'}}, votes)
+	// The votes of older slots are dropped as well.
+	_, ok = sim.popVotes(1)
+	assert.Equal(t, false, ok)
+	votes, ok = sim.popVotes(3)
+	require.Equal(t, true, ok)
+	require.DeepEqual(t, [][32]byte{{'c'}, {}}, votes)
+}
+
+func TestAttestationTimingSimulation_LogFields(t *testing.T) {
+	sim := newAttestationTimingSimulation([]time.Duration{2 * time.Second, 4 * time.Second, 6 * time.Second})
+	fields := sim.logFields(5, [][32]byte{{}, {'a'}, {'b'}}, [32]byte{'b'})
+	assert.Equal(t, false, fields["correctAt4s"])
+	assert.Equal(t, true, fields["correctAt6s"])
+	_, ok := fields["correctAt2s"]
+	assert.Equal(t, false, ok)
+	_, ok = fields["voteAt4s"]
+	assert.Equal(t, true, ok)
+	_, ok = fields["voteAt6s"]
+	assert.Equal(t, false, ok)
+}
+
+func TestService_logAttestationTiming(t *testing.T) {
+	ctx := context.Background()
+	hook := logTest.NewGlobal()
+	service := &Service{cfg: &config{ForkChoiceStore: doublylinkedtree.New()}}
+	ojc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	ofc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	st, blkRoot, err := prepareForkchoiceState(ctx, 0, [32]byte{}, [32]byte{}, params.BeaconConfig().ZeroHash, ojc, ofc)
+	require.NoError(t, err)
+	require.NoError(t, service.cfg.ForkChoiceStore.InsertNode(ctx, st, blkRoot))
+	st, blkRoot, err = prepareForkchoiceState(ctx, 1, [32]byte{'a'}, [32]byte{}, params.BeaconConfig().ZeroHash, ojc, ofc)
+	require.NoError(t, err)
+	require.NoError(t, service.cfg.ForkChoiceStore.InsertNode(ctx, st, blkRoot))
+	st, blkRoot, err = prepareForkchoiceState(ctx, 2, [32]byte{'b'}, [32]byte{'a'}, params.BeaconConfig().ZeroHash, ojc, ofc)
+	require.NoError(t, err)
+	require.NoError(t, service.cfg.ForkChoiceStore.InsertNode(ctx, st, blkRoot))
+	service.head = &head{root: [32]byte{'b'}, slot: 2}
+
+	// The block of slot 2 arrived between the second and the third offset.
+	sim := newAttestationTimingSimulation([]time.Duration{2 * time.Second, 4 * time.Second, 6 * time.Second})
+	sim.recordVote(2, 0, [32]byte{'a'})
+	sim.recordVote(2, 1, [32]byte{'a'})
+	sim.recordVote(2, 2, [32]byte{'b'})
+	service.logAttestationTiming(ctx, sim, 2)
+	require.LogsContain(t, hook, "Simulated attestation timing")
+	require.LogsContain(t, hook, "correctAt2s=false")
+	require.LogsContain(t, hook, "correctAt6s=true")
+	_, ok := sim.popVotes(2)
+	assert.Equal(t, false, ok)
+
+	// A skipped slot is canonical when the vote is for its closest ancestor.
+	hook.Reset()
+	sim.recordVote(3, 0, [32]byte{'b'})
+	service.logAttestationTiming(ctx, sim, 3)
+	require.LogsContain(t, hook, "correctAt2s=true")
+}
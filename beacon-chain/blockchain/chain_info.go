@@ -7,6 +7,8 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
@@ -115,6 +117,12 @@ type OptimisticModeFetcher interface {
 	IsOptimisticForRoot(ctx context.Context, root [32]byte) (bool, error)
 }
 
+// LightClientFetcher retrieves the latest light client updates computed by the node.
+type LightClientFetcher interface {
+	LightClientFinalityUpdate() *lightclient.FinalityUpdate
+	LightClientOptimisticUpdate() *lightclient.OptimisticUpdate
+}
+
 // FinalizedCheckpt returns the latest finalized checkpoint from chain store.
 func (s *Service) FinalizedCheckpt() *ethpb.Checkpoint {
 	s.cfg.ForkChoiceStore.RLock()
@@ -305,7 +313,22 @@ func (s *Service) IsCanonical(ctx context.Context, blockRoot [32]byte) (bool, er
 		return s.cfg.ForkChoiceStore.IsCanonical(blockRoot), nil
 	}
 
-	// If the block has been finalized, the block will always be part of the canonical chain.
+	// If the block has been finalized, the block will always be part of the canonical chain. Finalized history is
+	// answered by the finalized root index, which holds the canonical block of every finalized slot. The slot of the
+	// block is read from its state summary, so that the block itself does not have to be loaded.
+	summary, err := s.cfg.BeaconDB.StateSummary(ctx, blockRoot)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get state summary")
+	}
+	if summary != nil {
+		root, err := s.cfg.BeaconDB.FinalizedBlockRootForSlot(ctx, summary.Slot)
+		if err == nil {
+			return root == blockRoot, nil
+		}
+		if !errors.Is(err, db.ErrNotFound) {
+			return false, errors.Wrap(err, "could not read finalized root index")
+		}
+	}
 	return s.cfg.BeaconDB.IsFinalizedBlock(ctx, blockRoot), nil
 }
 
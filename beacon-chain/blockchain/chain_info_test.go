@@ -277,6 +277,46 @@ func TestIsCanonical_Ok(t *testing.T) {
 	assert.Equal(t, false, can)
 }
 
+func TestIsCanonical_FinalizedRootIndex(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	c := setupBeaconChain(t, beaconDB)
+
+	genesis := util.NewBeaconBlock()
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	canonical := util.NewBeaconBlock()
+	canonical.Block.Slot = 1
+	canonical.Block.ParentRoot = genesisRoot[:]
+	canonicalRoot, err := canonical.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, canonical)
+	orphaned := util.NewBeaconBlock()
+	orphaned.Block.Slot = 1
+	orphaned.Block.ParentRoot = genesisRoot[:]
+	orphaned.Block.Body.Graffiti = bytesutil.PadTo([]byte{'a'}, 32)
+	orphanedRoot, err := orphaned.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, orphaned)
+
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, st, canonicalRoot))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: canonicalRoot[:]}))
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 1, Root: canonicalRoot[:]}))
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 1, Root: orphanedRoot[:]}))
+
+	can, err := c.IsCanonical(ctx, canonicalRoot)
+	require.NoError(t, err)
+	assert.Equal(t, true, can)
+	can, err = c.IsCanonical(ctx, orphanedRoot)
+	require.NoError(t, err)
+	assert.Equal(t, false, can)
+}
+
 func TestService_HeadValidatorsIndices(t *testing.T) {
 	s, _ := util.DeterministicGenesisState(t, 10)
 	c := &Service{}
@@ -86,6 +86,7 @@ func (s *Service) saveHead(ctx context.Context, newHeadRoot [32]byte, headBlock
 		return errors.Wrap(err, "could not get old head block")
 	}
 	oldStateRoot := oldHeadBlock.Block().StateRoot()
+	wasOptimistic := s.head.optimistic
 	s.headLock.RUnlock()
 	headSlot := s.HeadSlot()
 	newHeadSlot := headBlock.Block().Slot()
@@ -174,6 +175,9 @@ func (s *Service) saveHead(ctx context.Context, newHeadRoot [32]byte, headBlock
 		if err := s.notifyNewHeadEvent(ctx, newHeadSlot, headState, newStateRoot[:], newHeadRoot[:]); err != nil {
 			log.WithError(err).Error("Could not notify event feed of new chain head")
 		}
+		if isOptimistic != wasOptimistic {
+			s.notifyOptimisticStatusChanged(newHeadSlot, newHeadRoot, isOptimistic)
+		}
 	}()
 
 	return nil
@@ -224,6 +228,20 @@ func (s *Service) setHead(newHead *head) error {
 	return nil
 }
 
+// setHeadValidated marks the cached head as no longer optimistic, if it is the given block, once the
+// execution payload of the block has been validated.
+func (s *Service) setHeadValidated(root [32]byte) {
+	s.headLock.Lock()
+	if s.head == nil || s.head.root != root || !s.head.optimistic {
+		s.headLock.Unlock()
+		return
+	}
+	s.head.optimistic = false
+	slot := s.head.slot
+	s.headLock.Unlock()
+	go s.notifyOptimisticStatusChanged(slot, root, false)
+}
+
 // This sets head view object which is used to track the head slot, root, block and state. The method
 // assumes that state being passed into the method will not be modified by any other alternate
 // caller which holds the state's reference.
@@ -374,6 +392,27 @@ func (s *Service) notifyNewHeadEvent(
 	return nil
 }
 
+// Notifies a common event feed that the head of the chain became optimistic, or is no longer optimistic.
+func (s *Service) notifyOptimisticStatusChanged(slot primitives.Slot, root [32]byte, optimistic bool) {
+	fields := logrus.Fields{
+		"slot":      slot,
+		"blockRoot": fmt.Sprintf("%#x", bytesutil.Trunc(root[:])),
+	}
+	if optimistic {
+		log.WithFields(fields).Warn("Head is optimistic, validators cannot attest or propose until its execution payload is validated")
+	} else {
+		log.WithFields(fields).Info("Head is no longer optimistic")
+	}
+	s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.OptimisticStatusChanged,
+		Data: &statefeed.OptimisticStatusChangedData{
+			Slot:       slot,
+			BlockRoot:  root,
+			Optimistic: optimistic,
+		},
+	})
+}
+
 // This saves the Attestations and BLSToExecChanges between `orphanedRoot` and the common ancestor root that is derived using `newHeadRoot`.
 // It also filters out the attestations that is one epoch older as a defense so invalid attestations don't flow into the attestation pool.
 func (s *Service) saveOrphanedOperations(ctx context.Context, orphanedRoot [32]byte, newHeadRoot [32]byte) error {
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	testDB "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/blstoexec"
@@ -216,6 +218,43 @@ func Test_notifyNewHeadEvent(t *testing.T) {
 	})
 }
 
+func TestSetHeadValidated(t *testing.T) {
+	notifier := &mock.MockStateNotifier{RecordEvents: true}
+	srv := &Service{
+		cfg: &config{
+			StateNotifier: notifier,
+		},
+		head: &head{root: [32]byte{1}, slot: 2, optimistic: true},
+	}
+	srv.setHeadValidated([32]byte{2})
+	require.Equal(t, true, srv.head.optimistic)
+	srv.setHeadValidated([32]byte{1})
+	require.Equal(t, false, srv.head.optimistic)
+}
+
+func Test_notifyOptimisticStatusChanged(t *testing.T) {
+	hook := logTest.NewGlobal()
+	notifier := &mock.MockStateNotifier{}
+	srv := &Service{
+		cfg: &config{
+			StateNotifier: notifier,
+		},
+	}
+	events := make(chan *feed.Event, 2)
+	sub := notifier.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+
+	srv.notifyOptimisticStatusChanged(2, [32]byte{1}, true)
+	srv.notifyOptimisticStatusChanged(3, [32]byte{2}, false)
+	ev := <-events
+	require.Equal(t, feed.EventType(statefeed.OptimisticStatusChanged), ev.Type)
+	require.DeepEqual(t, &statefeed.OptimisticStatusChangedData{Slot: 2, BlockRoot: [32]byte{1}, Optimistic: true}, ev.Data)
+	ev = <-events
+	require.DeepEqual(t, &statefeed.OptimisticStatusChangedData{Slot: 3, BlockRoot: [32]byte{2}, Optimistic: false}, ev.Data)
+	require.LogsContain(t, hook, "Head is optimistic")
+	require.LogsContain(t, hook, "Head is no longer optimistic")
+}
+
 func TestRetrieveHead_ReadOnly(t *testing.T) {
 	ctx := context.Background()
 	beaconDB := testDB.SetupDB(t)
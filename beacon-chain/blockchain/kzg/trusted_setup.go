@@ -14,6 +14,11 @@ var (
 	kzgContext           *GoKZG.Context
 )
 
+// Loaded returns whether the trusted setup was loaded by Start, which is required to verify blob sidecars.
+func Loaded() bool {
+	return kzgContext != nil
+}
+
 func Start() error {
 	parsedSetup := GoKZG.JSONTrustedSetup{}
 	err := json.Unmarshal(embeddedTrustedSetup, &parsedSetup)
@@ -0,0 +1,177 @@
+package blockchain
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// latestLightClientUpdates holds the most recent finality and optimistic updates served to light clients.
+type latestLightClientUpdates struct {
+	sync.RWMutex
+	finality   *lightclient.FinalityUpdate
+	optimistic *lightclient.OptimisticUpdate
+	// processLock is held while a head block is processed in the background.
+	processLock sync.Mutex
+}
+
+// LightClientFinalityUpdate returns the latest light client finality update, or nil if none was computed yet.
+func (s *Service) LightClientFinalityUpdate() *lightclient.FinalityUpdate {
+	s.lightClientUpdates.RLock()
+	defer s.lightClientUpdates.RUnlock()
+	return s.lightClientUpdates.finality
+}
+
+// LightClientOptimisticUpdate returns the latest light client optimistic update, or nil if none was computed yet.
+func (s *Service) LightClientOptimisticUpdate() *lightclient.OptimisticUpdate {
+	s.lightClientUpdates.RLock()
+	defer s.lightClientUpdates.RUnlock()
+	return s.lightClientUpdates.optimistic
+}
+
+// processLightClientUpdatesInBackground processes the light client updates of the given head block
+// without blocking the block processing path, as it regenerates the state of the parent block. The block is
+// skipped when the previous head is still being processed, so that a single goroutine runs at a time.
+func (s *Service) processLightClientUpdatesInBackground(signed interfaces.ReadOnlySignedBeaconBlock) {
+	if !s.lightClientUpdates.processLock.TryLock() {
+		log.WithField("slot", signed.Block().Slot()).Debug("Skipping light client updates of block, as the previous head is still being processed")
+		return
+	}
+	go func() {
+		defer s.lightClientUpdates.processLock.Unlock()
+		slotCtx, cancel := context.WithTimeout(s.ctx, slotDeadline)
+		defer cancel()
+		if err := s.processLightClientUpdates(slotCtx, signed); err != nil {
+			log.WithError(err).Debug("Could not process light client updates")
+		}
+	}()
+}
+
+// processLightClientUpdates computes the light client update carried by the sync aggregate of the given
+// head block. It saves the update as the best one of its sync committee period when it improves on the
+// stored one, and broadcasts the finality and optimistic updates derived from it when they are better than
+// the latest ones.
+func (s *Service) processLightClientUpdates(ctx context.Context, signed interfaces.ReadOnlySignedBeaconBlock) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.processLightClientUpdates")
+	defer span.End()
+
+	if !lightclient.IsSupportedVersion(signed.Version()) {
+		return nil
+	}
+	parentRoot := signed.Block().ParentRoot()
+	attestedBlock, err := s.getBlock(ctx, parentRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not get attested block")
+	}
+	if !lightclient.IsSupportedVersion(attestedBlock.Version()) {
+		return nil
+	}
+	attestedState, err := s.cfg.StateGen.StateByRoot(ctx, parentRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not get attested state")
+	}
+	var finalizedBlock interfaces.ReadOnlySignedBeaconBlock
+	finalizedRoot := bytesutil.ToBytes32(attestedState.FinalizedCheckpoint().Root)
+	if finalizedRoot == params.BeaconConfig().ZeroHash {
+		finalizedBlock, err = s.cfg.BeaconDB.GenesisBlock(ctx)
+	} else {
+		finalizedBlock, err = s.cfg.BeaconDB.Block(ctx, finalizedRoot)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized block")
+	}
+	update, err := lightclient.NewLightClientUpdateFromBeaconState(ctx, signed, attestedState, attestedBlock, finalizedBlock)
+	if err != nil {
+		return errors.Wrap(err, "could not create light client update")
+	}
+
+	period := lightclient.SyncCommitteePeriodAtSlot(update.AttestedHeader.Beacon.Slot)
+	best, err := s.cfg.BeaconDB.LightClientUpdate(ctx, period)
+	if err != nil {
+		return errors.Wrap(err, "could not get best light client update")
+	}
+	if best == nil || lightclient.IsBetterUpdate(update, best) {
+		if err := s.cfg.BeaconDB.SaveLightClientUpdate(ctx, period, update); err != nil {
+			return errors.Wrap(err, "could not save light client update")
+		}
+	}
+
+	var finalityUpdate *lightclient.FinalityUpdate
+	var optimisticUpdate *lightclient.OptimisticUpdate
+	s.lightClientUpdates.Lock()
+	if lightclient.IsFinalityUpdate(update) && isBetterFinalityUpdate(update, s.lightClientUpdates.finality) {
+		finalityUpdate = lightclient.NewLightClientFinalityUpdateFromUpdate(update)
+		s.lightClientUpdates.finality = finalityUpdate
+	}
+	latestOptimistic := s.lightClientUpdates.optimistic
+	if latestOptimistic == nil || update.AttestedHeader.Beacon.Slot > latestOptimistic.AttestedHeader.Beacon.Slot {
+		optimisticUpdate = lightclient.NewLightClientOptimisticUpdateFromUpdate(update)
+		s.lightClientUpdates.optimistic = optimisticUpdate
+	}
+	s.lightClientUpdates.Unlock()
+
+	if finalityUpdate != nil {
+		msg, err := finalityUpdate.Proto()
+		if err != nil {
+			return errors.Wrap(err, "could not convert light client finality update")
+		}
+		s.broadcastLightClientUpdate(msg, finalityUpdate.SignatureSlot)
+	}
+	if optimisticUpdate != nil {
+		msg, err := optimisticUpdate.Proto()
+		if err != nil {
+			return errors.Wrap(err, "could not convert light client optimistic update")
+		}
+		s.broadcastLightClientUpdate(msg, optimisticUpdate.SignatureSlot)
+	}
+	return nil
+}
+
+// isBetterFinalityUpdate returns true if the update is to be forwarded in place of the latest finality
+// update, as it either finalizes a newer header or is the first one to do so for its finalized header with
+// a supermajority of the sync committee.
+func isBetterFinalityUpdate(update *lightclient.Update, latest *lightclient.FinalityUpdate) bool {
+	if latest == nil || update.FinalizedHeader.Beacon.Slot > latest.FinalizedHeader.Beacon.Slot {
+		return true
+	}
+	return update.FinalizedHeader.Beacon.Slot == latest.FinalizedHeader.Beacon.Slot &&
+		hasSupermajority(update.SyncAggregate) && !hasSupermajority(latest.SyncAggregate)
+}
+
+func hasSupermajority(aggregate *ethpb.SyncAggregate) bool {
+	return aggregate.SyncCommitteeBits.Count()*3 >= aggregate.SyncCommitteeBits.Len()*2
+}
+
+// broadcastLightClientUpdate broadcasts the given update once a third of its signature slot elapsed, as
+// peers ignore updates received before the block of the signature slot had time to propagate.
+func (s *Service) broadcastLightClientUpdate(update proto.Message, signatureSlot primitives.Slot) {
+	slotStart := slots.StartTime(uint64(s.genesisTime.Unix()), signatureSlot)
+	delay := time.Until(slotStart.Add(slots.DivideSlotBy(int64(params.BeaconConfig().IntervalsPerSlot))))
+	if delay <= 0 {
+		if err := s.cfg.P2p.Broadcast(s.ctx, update); err != nil {
+			log.WithError(err).Debug("Could not broadcast light client update")
+		}
+		return
+	}
+	go func() {
+		select {
+		case <-time.After(delay):
+		case <-s.ctx.Done():
+			return
+		}
+		if err := s.cfg.P2p.Broadcast(s.ctx, update); err != nil {
+			log.WithError(err).Debug("Could not broadcast light client update")
+		}
+	}()
+}
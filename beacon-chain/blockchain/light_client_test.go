@@ -0,0 +1,172 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	mockExecution "github.com/prysmaticlabs/prysm/v4/beacon-chain/execution/testing"
+	p2ptesting "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestService_ProcessLightClientUpdates(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+
+	broadcaster := &p2ptesting.MockBroadcaster{}
+	service, tr := minimalTestService(t, WithP2PBroadcaster(broadcaster))
+	ctx := tr.ctx
+
+	st, keys := util.DeterministicGenesisStateAltair(t, 64)
+	processLightClientBlocks(t, service, st, keys, func(st state.BeaconState, config *util.BlockGenConfig, slot primitives.Slot) (interfaces.ReadOnlySignedBeaconBlock, error) {
+		b, err := util.GenerateFullBlockAltair(st, keys, config, slot)
+		if err != nil {
+			return nil, err
+		}
+		return consensusblocks.NewSignedBeaconBlock(b)
+	})
+
+	optimistic := service.LightClientOptimisticUpdate()
+	require.NotNil(t, optimistic)
+	assert.Equal(t, primitives.Slot(2), optimistic.AttestedHeader.Beacon.Slot)
+	assert.Equal(t, primitives.Slot(3), optimistic.SignatureSlot)
+	finality := service.LightClientFinalityUpdate()
+	require.NotNil(t, finality)
+	// Nothing is finalized yet, so the finality update points to genesis with an empty header.
+	assert.Equal(t, primitives.Slot(0), finality.FinalizedHeader.Beacon.Slot)
+
+	// The best update of the period is the one with the oldest attested header.
+	best, err := service.cfg.BeaconDB.LightClientUpdate(ctx, 0)
+	require.NoError(t, err)
+	require.NotNil(t, best)
+	assert.Equal(t, primitives.Slot(0), best.AttestedHeader.Beacon.Slot)
+
+	var finalityUpdates, optimisticUpdates int
+	for _, msg := range broadcaster.BroadcastMessages {
+		switch msg.(type) {
+		case *ethpb.LightClientFinalityUpdate:
+			finalityUpdates++
+		case *ethpb.LightClientOptimisticUpdate:
+			optimisticUpdates++
+		}
+	}
+	// The finalized header never moves, while every block attests to a newer header.
+	assert.Equal(t, 1, finalityUpdates)
+	assert.Equal(t, 3, optimisticUpdates)
+}
+
+func TestService_ProcessLightClientUpdates_Capella(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 0
+	cfg.CapellaForkEpoch = 0
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+
+	broadcaster := &p2ptesting.MockBroadcaster{}
+	service, tr := minimalTestService(t, WithP2PBroadcaster(broadcaster), WithExecutionEngineCaller(&mockExecution.EngineClient{}))
+
+	st, keys := util.DeterministicGenesisStateCapella(t, 64)
+	processLightClientBlocks(t, service, st, keys, func(st state.BeaconState, config *util.BlockGenConfig, slot primitives.Slot) (interfaces.ReadOnlySignedBeaconBlock, error) {
+		b, err := util.GenerateFullBlockCapella(st, keys, config, slot)
+		if err != nil {
+			return nil, err
+		}
+		return consensusblocks.NewSignedBeaconBlock(b)
+	})
+
+	// Light client headers carry the execution payload header of their block from Capella on.
+	optimistic := service.LightClientOptimisticUpdate()
+	require.NotNil(t, optimistic)
+	assert.Equal(t, primitives.Slot(2), optimistic.AttestedHeader.Beacon.Slot)
+	require.NotNil(t, optimistic.AttestedHeader.Execution)
+	assert.Equal(t, uint64(2), optimistic.AttestedHeader.Execution.BlockNumber())
+	best, err := service.cfg.BeaconDB.LightClientUpdate(tr.ctx, 0)
+	require.NoError(t, err)
+	require.NotNil(t, best)
+	require.NotNil(t, best.AttestedHeader.Execution)
+
+	var finalityUpdates, optimisticUpdates int
+	for _, msg := range broadcaster.BroadcastMessages {
+		switch msg.(type) {
+		case *ethpb.LightClientFinalityUpdateCapella:
+			finalityUpdates++
+		case *ethpb.LightClientOptimisticUpdateCapella:
+			optimisticUpdates++
+		}
+	}
+	assert.Equal(t, 1, finalityUpdates)
+	assert.Equal(t, 3, optimisticUpdates)
+}
+
+// processLightClientBlocks imports blocks with a full sync aggregate at slots 1 to 3 on top of the given
+// genesis state, and processes their light client updates.
+func processLightClientBlocks(
+	t *testing.T,
+	service *Service,
+	genesis state.BeaconState,
+	keys []bls.SecretKey,
+	generate func(state.BeaconState, *util.BlockGenConfig, primitives.Slot) (interfaces.ReadOnlySignedBeaconBlock, error),
+) {
+	ctx := service.ctx
+	committee, err := altair.NextSyncCommittee(ctx, genesis)
+	require.NoError(t, err)
+	require.NoError(t, genesis.SetCurrentSyncCommittee(committee))
+	require.NoError(t, service.saveGenesisData(ctx, genesis))
+
+	for i := 1; i < 4; i++ {
+		// Updates are only broadcast once a third of their signature slot elapsed.
+		driftGenesisTime(service, int64(i), -int64(params.BeaconConfig().SecondsPerSlot/2))
+		st, err := service.HeadState(ctx)
+		require.NoError(t, err)
+		config := util.DefaultBlockGenConfig()
+		config.FullSyncAggregate = true
+		wsb, err := generate(st, config, primitives.Slot(i))
+		require.NoError(t, err)
+		root, err := wsb.Block().HashTreeRoot()
+		require.NoError(t, err)
+		preState, err := service.getBlockPreState(ctx, wsb.Block())
+		require.NoError(t, err)
+		postState, err := service.validateStateTransition(ctx, preState, wsb)
+		require.NoError(t, err)
+		require.NoError(t, service.savePostStateInfo(ctx, root, wsb, postState))
+		require.NoError(t, service.postBlockProcess(ctx, wsb, root, postState, false))
+		// Light client updates are processed in the background when the feature is enabled.
+		require.NoError(t, service.processLightClientUpdates(ctx, wsb))
+	}
+}
+
+func TestIsBetterFinalityUpdate(t *testing.T) {
+	update := func(finalizedSlot primitives.Slot, participants uint64) *lightclient.Update {
+		u := util.HydrateLightClientUpdate(&lightclient.Update{})
+		u.FinalizedHeader.Beacon.Slot = finalizedSlot
+		for i := uint64(0); i < participants; i++ {
+			u.SyncAggregate.SyncCommitteeBits.SetBitAt(i, true)
+		}
+		return u
+	}
+	size := params.BeaconConfig().SyncCommitteeSize
+	latest := lightclient.NewLightClientFinalityUpdateFromUpdate(update(8, size/2))
+
+	assert.Equal(t, true, isBetterFinalityUpdate(update(8, 0), nil))
+	assert.Equal(t, true, isBetterFinalityUpdate(update(9, 0), latest))
+	assert.Equal(t, false, isBetterFinalityUpdate(update(7, size), latest))
+	assert.Equal(t, false, isBetterFinalityUpdate(update(8, size/2+1), latest))
+	assert.Equal(t, true, isBetterFinalityUpdate(update(8, size), latest))
+	latest = lightclient.NewLightClientFinalityUpdateFromUpdate(update(8, size))
+	assert.Equal(t, false, isBetterFinalityUpdate(update(8, size), latest))
+}
@@ -123,6 +123,14 @@ var (
 		Name: "beacon_failed_reorg_attempts_second_threshold",
 		Help: "Count the number of times a proposer served by this beacon attempted a late block reorg but desisted in the second threshold",
 	})
+	prunedOrphanedBlocksCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_pruned_orphaned_blocks_total",
+		Help: "Count the number of orphaned blocks deleted from the database after being finalized",
+	})
+	prunedOrphanedBlocksBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_pruned_orphaned_blocks_bytes_total",
+		Help: "Count the number of database bytes reclaimed by deleting orphaned blocks, their states and blob sidecars",
+	})
 	saveOrphanedAttCount = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "saved_orphaned_att_total",
 		Help: "Count the number of times an orphaned attestation is saved",
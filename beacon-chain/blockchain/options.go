@@ -15,6 +15,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/progress"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 )
 
@@ -36,6 +38,15 @@ func WithWeakSubjectivityCheckpoint(c *ethpb.Checkpoint) Option {
 	}
 }
 
+// WithOrphanedBlocksRetention sets the number of epochs orphaned blocks are kept for after they are finalized. Orphaned
+// blocks are never pruned if it is 0.
+func WithOrphanedBlocksRetention(e primitives.Epoch) Option {
+	return func(s *Service) error {
+		s.cfg.OrphanedBlocksRetention = e
+		return nil
+	}
+}
+
 // WithDatabase for head access.
 func WithDatabase(beaconDB db.HeadAccessDatabase) Option {
 	return func(s *Service) error {
@@ -178,3 +189,11 @@ func WithSyncComplete(c chan struct{}) Option {
 		return nil
 	}
 }
+
+// WithSyncProgress to complete the execution client sync stage once a payload is valid.
+func WithSyncProgress(t *progress.Tracker) Option {
+	return func(s *Service) error {
+		s.cfg.SyncProgress = t
+		return nil
+	}
+}
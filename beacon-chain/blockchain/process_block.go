@@ -117,6 +117,9 @@ func (s *Service) postBlockProcess(ctx context.Context, signed interfaces.ReadOn
 
 	defer reportAttestationInclusion(b)
 	if headRoot == blockRoot {
+		if features.Get().EnableLightClient {
+			s.processLightClientUpdatesInBackground(signed)
+		}
 		// Updating next slot state cache can happen in the background
 		// except in the epoch boundary in which case we lock to handle
 		// the shuffling and proposer caches updates.
@@ -530,6 +533,8 @@ func (s *Service) runLateBlockTasks() {
 }
 
 func (s *Service) isDataAvailable(ctx context.Context, root [32]byte, signed interfaces.ReadOnlySignedBeaconBlock) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.isDataAvailable")
+	defer span.End()
 	if signed.Version() < version.Deneb {
 		return nil
 	}
@@ -17,6 +17,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/time"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
 
@@ -144,10 +145,40 @@ func (s *Service) updateFinalized(ctx context.Context, cp *ethpb.Checkpoint) err
 		if err := s.cfg.StateGen.MigrateToCold(s.ctx, fRoot); err != nil {
 			log.WithError(err).Error("could not migrate to cold")
 		}
+		s.pruneOrphanedBlocks(s.ctx, currentFinalized.Epoch, cp.Epoch)
 	}()
 	return nil
 }
 
+// pruneOrphanedBlocks deletes the orphaned blocks which have moved past the retention period, now that the
+// finalized epoch advanced from prevFinalized to finalized. Nothing is pruned if no retention period is configured.
+func (s *Service) pruneOrphanedBlocks(ctx context.Context, prevFinalized, finalized primitives.Epoch) {
+	retention := s.cfg.OrphanedBlocksRetention
+	if retention == 0 || finalized <= retention {
+		return
+	}
+	start := primitives.Epoch(0)
+	if prevFinalized > retention {
+		start = prevFinalized - retention
+	}
+	end := finalized - retention
+	pruned, reclaimed, err := s.cfg.BeaconDB.PruneOrphanedBlocks(ctx, start, end)
+	if err != nil {
+		log.WithError(err).Error("Could not prune orphaned blocks")
+	}
+	prunedOrphanedBlocksCount.Add(float64(pruned))
+	prunedOrphanedBlocksBytes.Add(float64(reclaimed))
+	if pruned == 0 {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"startEpoch":     start,
+		"endEpoch":       end,
+		"prunedBlocks":   pruned,
+		"reclaimedBytes": reclaimed,
+	}).Info("Pruned orphaned blocks")
+}
+
 // This retrieves an ancestor root using DB. The look up is recursively looking up DB. Slower than `ancestorByForkChoiceStore`.
 func (s *Service) ancestorByDB(ctx context.Context, r [32]byte, slot primitives.Slot) (root [32]byte, err error) {
 	ctx, span := trace.StartSpan(ctx, "blockChain.ancestorByDB")
@@ -2106,3 +2106,53 @@ func Test_commitmentsToCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestService_pruneOrphanedBlocks(t *testing.T) {
+	ctx := context.Background()
+	hook := logTest.NewGlobal()
+	service, tr := minimalTestService(t, WithOrphanedBlocksRetention(1))
+	beaconDB := tr.db
+
+	genesis := util.NewBeaconBlock()
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+
+	// A canonical block at the start of every epoch, and an orphaned block in epoch 1.
+	parent := gRoot
+	for e := primitives.Epoch(1); e <= 3; e++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = params.BeaconConfig().SlotsPerEpoch.Mul(uint64(e))
+		b.Block.ParentRoot = parent[:]
+		util.SaveBlock(t, ctx, beaconDB, b)
+		parent, err = b.Block.HashTreeRoot()
+		require.NoError(t, err)
+	}
+	orphan := util.NewBeaconBlock()
+	orphan.Block.Slot = params.BeaconConfig().SlotsPerEpoch + 1
+	orphan.Block.ParentRoot = gRoot[:]
+	util.SaveBlock(t, ctx, beaconDB, orphan)
+	orphanRoot, err := orphan.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, st, parent))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: parent[:]}))
+
+	// Orphaned blocks are not pruned without a retention period.
+	service.cfg.OrphanedBlocksRetention = 0
+	service.pruneOrphanedBlocks(ctx, 0, 3)
+	require.Equal(t, true, beaconDB.HasBlock(ctx, orphanRoot))
+	service.cfg.OrphanedBlocksRetention = 1
+
+	// The orphaned block is kept while it is within the retention period.
+	service.pruneOrphanedBlocks(ctx, 0, 2)
+	require.Equal(t, true, beaconDB.HasBlock(ctx, orphanRoot))
+
+	service.pruneOrphanedBlocks(ctx, 2, 3)
+	require.Equal(t, false, beaconDB.HasBlock(ctx, orphanRoot))
+	require.LogsContain(t, hook, "Pruned orphaned blocks")
+	require.LogsContain(t, hook, "prunedBlocks=1")
+}
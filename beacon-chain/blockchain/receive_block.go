@@ -3,6 +3,7 @@ package blockchain
 import (
 	"bytes"
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
@@ -58,6 +59,10 @@ type SlashingReceiver interface {
 func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.ReadOnlySignedBeaconBlock, blockRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "blockChain.ReceiveBlock")
 	defer span.End()
+	span.AddAttributes(
+		trace.Int64Attribute("slot", int64(block.Block().Slot())),
+		trace.StringAttribute("blockRoot", fmt.Sprintf("%#x", blockRoot)),
+	)
 	receivedTime := time.Now()
 	s.blockBeingSynced.set(blockRoot)
 	defer s.blockBeingSynced.unset(blockRoot)
@@ -337,6 +342,8 @@ func (s *Service) checkSaveHotStateDB(ctx context.Context) error {
 // This performs the state transition function and returns the poststate or an
 // error if the block fails to verify the consensus rules
 func (s *Service) validateStateTransition(ctx context.Context, preState state.BeaconState, signed interfaces.ReadOnlySignedBeaconBlock) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "blockChain.validateStateTransition")
+	defer span.End()
 	b := signed.Block()
 	// Verify that the parent block is in forkchoice
 	parentRoot := b.ParentRoot()
@@ -428,6 +435,8 @@ func (s *Service) sendBlockAttestationsToSlasher(signed interfaces.ReadOnlySigne
 
 // validateExecutionOnBlock notifies the engine of the incoming block execution payload and returns true if the payload is valid
 func (s *Service) validateExecutionOnBlock(ctx context.Context, ver int, header interfaces.ExecutionData, signed interfaces.ReadOnlySignedBeaconBlock, blockRoot [32]byte) (bool, error) {
+	ctx, span := trace.StartSpan(ctx, "blockChain.validateExecutionOnBlock")
+	defer span.End()
 	isValidPayload, err := s.notifyNewPayload(ctx, ver, header, signed)
 	if err != nil {
 		return false, s.handleInvalidExecutionError(ctx, err, blockRoot, signed.Block().ParentRoot())
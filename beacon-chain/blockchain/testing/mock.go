@@ -16,6 +16,7 @@ import (
 	opfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
@@ -72,6 +73,8 @@ type ChainService struct {
 	OptimisticRoots             map[[32]byte]bool
 	BlockSlot                   primitives.Slot
 	SyncingRoot                 [32]byte
+	FinalityUpdate              *lightclient.FinalityUpdate
+	OptimisticUpdate            *lightclient.OptimisticUpdate
 }
 
 func (s *ChainService) Ancestor(ctx context.Context, root []byte, slot primitives.Slot) ([]byte, error) {
@@ -470,6 +473,16 @@ func (s *ChainService) IsOptimisticForRoot(_ context.Context, root [32]byte) (bo
 	return s.OptimisticRoots[root], nil
 }
 
+// LightClientFinalityUpdate mocks the same method in the chain service.
+func (s *ChainService) LightClientFinalityUpdate() *lightclient.FinalityUpdate {
+	return s.FinalityUpdate
+}
+
+// LightClientOptimisticUpdate mocks the same method in the chain service.
+func (s *ChainService) LightClientOptimisticUpdate() *lightclient.OptimisticUpdate {
+	return s.OptimisticUpdate
+}
+
 // UpdateHead mocks the same method in the chain service.
 func (s *ChainService) UpdateHead(ctx context.Context, slot primitives.Slot) {
 	ojc := &ethpb.Checkpoint{}
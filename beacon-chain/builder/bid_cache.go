@@ -0,0 +1,58 @@
+package builder
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+	lruwrpr "github.com/prysmaticlabs/prysm/v4/cache/lru"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+// bidCacheSize is the number of recent bids kept, enough for the proposals of a few epochs.
+const bidCacheSize = 64
+
+// bidRequest identifies a header request to the relays.
+type bidRequest struct {
+	slot       primitives.Slot
+	parentHash [32]byte
+	pubkey     [fieldparams.BLSPubkeyLength]byte
+}
+
+// bidCache keeps the bids selected for recent header requests, so that a repeated request for the same proposal
+// is not sent to the relays again, and the blinded block is later submitted to the relay of the selected bid.
+type bidCache struct {
+	byRequest   *lru.Cache
+	byBlockHash *lru.Cache
+}
+
+func newBidCache() *bidCache {
+	return &bidCache{
+		byRequest:   lruwrpr.New(bidCacheSize),
+		byBlockHash: lruwrpr.New(bidCacheSize),
+	}
+}
+
+// add records the bid selected for the request, which commits to the payload with the given block hash.
+func (c *bidCache) add(req bidRequest, blockHash [32]byte, rb *relayBid) {
+	c.byRequest.Add(req, rb)
+	c.byBlockHash.Add(blockHash, rb)
+}
+
+// get returns the bid selected for the request.
+func (c *bidCache) get(req bidRequest) (*relayBid, bool) {
+	v, ok := c.byRequest.Get(req)
+	if !ok {
+		return nil, false
+	}
+	rb, ok := v.(*relayBid)
+	return rb, ok
+}
+
+// getByBlockHash returns the selected bid which commits to the payload with the given block hash.
+func (c *bidCache) getByBlockHash(blockHash [32]byte) (*relayBid, bool) {
+	v, ok := c.byBlockHash.Get(blockHash)
+	if !ok {
+		return nil, false
+	}
+	rb, ok := v.(*relayBid)
+	return rb, ok
+}
@@ -27,4 +27,32 @@ var (
 			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
 		},
 	)
+	payloadMismatchCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "builder_payload_mismatch_total",
+			Help: "The number of payloads returned by builders for blinded blocks which did not match the committed header",
+		},
+	)
+	relayGetHeaderLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "builder_relay_get_header_latency_milliseconds",
+			Help:    "Captures the latency of the header requests to each relay in milliseconds",
+			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
+		},
+		[]string{"relay"},
+	)
+	relayBidValueGwei = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "builder_relay_bid_value_gwei",
+			Help: "The value of the last valid bid of each relay in gwei",
+		},
+		[]string{"relay"},
+	)
+	relayBidsCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "builder_relay_bids_total",
+			Help: "The number of bids of each relay which were valid, invalid or selected as the best bid",
+		},
+		[]string{"relay", "result"},
+	)
 )
@@ -13,25 +13,25 @@ type Option func(s *Service) error
 
 // FlagOptions for builder service flag configurations.
 func FlagOptions(c *cli.Context) ([]Option, error) {
-	endpoint := c.String(flags.MevRelayEndpoint.Name)
-	var client *builder.Client
-	if endpoint != "" {
-		var err error
-		client, err = builder.NewClient(endpoint)
+	var opts []Option
+	for _, endpoint := range c.StringSlice(flags.MevRelayEndpoint.Name) {
+		if endpoint == "" {
+			continue
+		}
+		client, err := builder.NewClient(endpoint)
 		if err != nil {
 			return nil, err
 		}
-	}
-	opts := []Option{
-		WithBuilderClient(client),
+		opts = append(opts, WithBuilderClient(client))
 	}
 	return opts, nil
 }
 
-// WithBuilderClient sets the builder client for the beacon chain builder service.
+// WithBuilderClient adds a builder relay client to the beacon chain builder service. Bids are requested from all
+// the relays added.
 func WithBuilderClient(client builder.BuilderClient) Option {
 	return func(s *Service) error {
-		s.cfg.builderClient = client
+		s.cfg.builderClients = append(s.cfg.builderClients, client)
 		return nil
 	}
 }
@@ -0,0 +1,180 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	log "github.com/sirupsen/logrus"
+)
+
+// relay is a builder relay the beacon node requests bids from.
+type relay struct {
+	client builder.BuilderClient
+	// pubkey is the public key of the relay given in its URL, which its bids must be signed with.
+	pubkey []byte
+	label  string
+}
+
+// newRelay returns the relay of the client, whose URL must carry the relay public key as in
+// https://0xpubkey@relay.example.com, so that the bids of the relay can be authenticated.
+func newRelay(c builder.BuilderClient) (*relay, error) {
+	u, err := url.Parse(c.NodeURL())
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("relay URL %q is invalid", c.NodeURL())
+	}
+	r := &relay{client: c, label: u.Host}
+	if u.User == nil || u.User.Username() == "" {
+		return nil, fmt.Errorf("relay %s has no public key in its URL", r.label)
+	}
+	pubkey, err := hexutil.Decode(u.User.Username())
+	if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+		return nil, fmt.Errorf("relay %s has an invalid public key %s in its URL", r.label, u.User.Username())
+	}
+	r.pubkey = pubkey
+	return r, nil
+}
+
+// relayBid is the bid returned by a relay.
+type relayBid struct {
+	relay   *relay
+	bid     builder.SignedBid
+	builder [fieldparams.BLSPubkeyLength]byte
+	value   *big.Int
+	err     error
+}
+
+// relaysFor returns the relays whose host is the host of one of the given relay URLs, or every relay if no URL is
+// given. Relays are matched by host, as the URL of a relay may or may not carry its public key.
+func (s *Service) relaysFor(urls []string) ([]*relay, error) {
+	if len(urls) == 0 {
+		return s.relays, nil
+	}
+	hosts := make(map[string]bool, len(urls))
+	for _, raw := range urls {
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" {
+			log.WithField("relay", raw).Debug("Ignoring invalid relay URL")
+			continue
+		}
+		hosts[u.Host] = true
+	}
+	var relays []*relay
+	for _, r := range s.relays {
+		if hosts[r.label] {
+			relays = append(relays, r)
+		}
+	}
+	if len(relays) == 0 {
+		return nil, errors.Wrapf(ErrNoConfiguredRelay, "relays %s", strings.Join(urls, ", "))
+	}
+	return relays, nil
+}
+
+func containsRelay(relays []*relay, r *relay) bool {
+	for _, rr := range relays {
+		if rr == r {
+			return true
+		}
+	}
+	return false
+}
+
+// getBestHeader requests a header from the given relays concurrently, and returns the valid bid with the highest
+// value among the bids received before the context deadline. Bids of equal value are chosen in the order of the
+// relays.
+func (s *Service) getBestHeader(ctx context.Context, relays []*relay, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte) (*relayBid, error) {
+	results := make(chan *relayBid, len(relays))
+	for _, r := range relays {
+		go func(r *relay) {
+			start := time.Now()
+			bid, err := r.client.GetHeader(ctx, slot, parentHash, pubKey)
+			relayGetHeaderLatency.WithLabelValues(r.label).Observe(float64(time.Since(start).Milliseconds()))
+			rb := &relayBid{relay: r, bid: bid, err: err}
+			if err == nil {
+				rb.builder, rb.value, rb.err = s.validateRelayBid(r, bid)
+			}
+			results <- rb
+		}(r)
+	}
+
+	bids := make(map[*relay]*relayBid)
+	var errs []string
+wait:
+	for range relays {
+		select {
+		case rb := <-results:
+			if rb.err != nil {
+				relayBidsCount.WithLabelValues(rb.relay.label, "invalid").Inc()
+				log.WithError(rb.err).WithField("relay", rb.relay.label).Debug("Could not get a valid bid from relay")
+				errs = append(errs, fmt.Sprintf("%s: %v", rb.relay.label, rb.err))
+				continue
+			}
+			relayBidsCount.WithLabelValues(rb.relay.label, "valid").Inc()
+			gwei, _ := new(big.Float).Quo(new(big.Float).SetInt(rb.value), big.NewFloat(1e9)).Float64()
+			relayBidValueGwei.WithLabelValues(rb.relay.label).Set(gwei)
+			bids[rb.relay] = rb
+		case <-ctx.Done():
+			break wait
+		}
+	}
+
+	var best *relayBid
+	for _, r := range relays {
+		rb, ok := bids[r]
+		if ok && (best == nil || rb.value.Cmp(best.value) > 0) {
+			best = rb
+		}
+	}
+	if best == nil {
+		if len(errs) == 0 {
+			return nil, errors.Wrap(ctx.Err(), "no relay returned a bid before the deadline")
+		}
+		return nil, fmt.Errorf("no relay returned a valid bid: %s", strings.Join(errs, "; "))
+	}
+	relayBidsCount.WithLabelValues(best.relay.label, "selected").Inc()
+	return best, nil
+}
+
+// validateRelayBid checks that the bid is signed by the relay, and by a builder which is not penalized for a payload
+// mismatch. It returns the public key the bid is signed with and the value of the bid in wei.
+func (s *Service) validateRelayBid(r *relay, signedBid builder.SignedBid) ([fieldparams.BLSPubkeyLength]byte, *big.Int, error) {
+	var pubkey [fieldparams.BLSPubkeyLength]byte
+	if signedBid == nil || signedBid.IsNil() {
+		return pubkey, nil, errors.New("nil bid")
+	}
+	bid, err := signedBid.Message()
+	if err != nil {
+		return pubkey, nil, errors.Wrap(err, "could not get bid")
+	}
+	if bid.IsNil() {
+		return pubkey, nil, errors.New("nil bid")
+	}
+	pubkey = bytesutil.ToBytes48(bid.Pubkey())
+	if !bytes.Equal(r.pubkey, bid.Pubkey()) {
+		return pubkey, nil, fmt.Errorf("bid public key %#x is not the relay public key %#x", bid.Pubkey(), r.pubkey)
+	}
+	d, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder, nil /* fork version */, nil /* genesis val root */)
+	if err != nil {
+		return pubkey, nil, err
+	}
+	if err := signing.VerifySigningRoot(bid, bid.Pubkey(), signedBid.Signature(), d); err != nil {
+		return pubkey, nil, errors.Wrap(err, "invalid bid signature")
+	}
+	if s.reputation.penalized(pubkey) {
+		return pubkey, nil, errors.Wrapf(ErrBuilderPenalized, "builder %#x", pubkey)
+	}
+	return pubkey, bytesutil.LittleEndianBytesToBigInt(bid.Value()), nil
+}
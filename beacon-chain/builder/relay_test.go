@@ -0,0 +1,169 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+// signedTestBid returns a deneb bid for the header with the given value in wei, signed with the secret key.
+func signedTestBid(t *testing.T, sk bls.SecretKey, header *v1.ExecutionPayloadHeaderDeneb, value uint64) builder.SignedBid {
+	msg := &eth.BuilderBidDeneb{
+		Header:             header,
+		BlindedBlobsBundle: &v1.BlindedBlobsBundle{},
+		Value:              bytesutil.PadTo(bytesutil.Uint64ToBytesLittleEndian(value), 32),
+		Pubkey:             sk.PublicKey().Marshal(),
+	}
+	d, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder, nil, nil)
+	require.NoError(t, err)
+	root, err := signing.ComputeSigningRoot(msg, d)
+	require.NoError(t, err)
+	bid, err := builder.WrappedSignedBuilderBidDeneb(&eth.SignedBuilderBidDeneb{
+		Message:   msg,
+		Signature: sk.Sign(root[:]).Marshal(),
+	})
+	require.NoError(t, err)
+	return bid
+}
+
+// testRelayURL is the URL of a relay whose bids are not checked.
+var testRelayURL = relayURL(make([]byte, fieldparams.BLSPubkeyLength), "relay.example.com")
+
+// relayURL returns the URL of the relay with the given public key and host.
+func relayURL(pubkey []byte, host string) string {
+	return fmt.Sprintf("https://%#x@%s", pubkey, host)
+}
+
+// fakeRelayClient is a builder client which returns a fixed bid and payload, and records the blinded blocks
+// submitted to it.
+type fakeRelayClient struct {
+	url       string
+	bid       builder.SignedBid
+	errHeader error
+	payload   interfaces.ExecutionData
+	bundle    *v1.BlobsBundle
+	submitted int
+}
+
+func (c *fakeRelayClient) NodeURL() string {
+	return c.url
+}
+
+func (c *fakeRelayClient) GetHeader(_ context.Context, _ primitives.Slot, _ [32]byte, _ [48]byte) (builder.SignedBid, error) {
+	return c.bid, c.errHeader
+}
+
+func (*fakeRelayClient) RegisterValidator(_ context.Context, _ []*eth.SignedValidatorRegistrationV1) error {
+	return nil
+}
+
+func (c *fakeRelayClient) SubmitBlindedBlock(_ context.Context, _ interfaces.ReadOnlySignedBeaconBlock, _ []*eth.SignedBlindedBlobSidecar) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
+	c.submitted++
+	return c.payload, c.bundle, nil
+}
+
+func (*fakeRelayClient) Status(_ context.Context) error {
+	return nil
+}
+
+func Test_newRelay(t *testing.T) {
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	pubkey := sk.PublicKey().Marshal()
+
+	r, err := newRelay(&fakeRelayClient{url: relayURL(pubkey, "relay.example.com")})
+	require.NoError(t, err)
+	assert.Equal(t, "relay.example.com", r.label)
+	assert.DeepEqual(t, pubkey, r.pubkey)
+
+	// Bids of relays without a public key cannot be authenticated.
+	_, err = newRelay(&fakeRelayClient{url: "http://localhost:18550"})
+	require.ErrorContains(t, "has no public key", err)
+	_, err = newRelay(&fakeRelayClient{url: "https://0x1234@relay.example.com"})
+	require.ErrorContains(t, "invalid public key", err)
+	_, err = newRelay(&fakeRelayClient{})
+	require.ErrorContains(t, "is invalid", err)
+}
+
+func TestService_GetHeader_BestBid(t *testing.T) {
+	ctx := context.Background()
+	b, p, bundle := testBuilderPayload(t)
+	payload, err := blocks.WrappedExecutionPayloadDeneb(p, 0)
+	require.NoError(t, err)
+	header, err := blocks.PayloadToHeaderDeneb(payload)
+	require.NoError(t, err)
+	keys := make([]bls.SecretKey, 4)
+	for i := range keys {
+		keys[i], err = bls.RandKey()
+		require.NoError(t, err)
+	}
+	invalidSig, err := builder.WrappedSignedBuilderBidDeneb(&eth.SignedBuilderBidDeneb{
+		Message: &eth.BuilderBidDeneb{
+			Header:             header,
+			BlindedBlobsBundle: &v1.BlindedBlobsBundle{},
+			Value:              bytesutil.PadTo([]byte{100}, 32),
+			Pubkey:             keys[3].PublicKey().Marshal(),
+		},
+		Signature: make([]byte, fieldparams.BLSSignatureLength),
+	})
+	require.NoError(t, err)
+
+	relays := []*fakeRelayClient{
+		{url: relayURL(keys[0].PublicKey().Marshal(), "relay-a.example.com"), bid: signedTestBid(t, keys[0], header, 2), payload: payload, bundle: bundle},
+		// The highest bids are not signed by the relay key in the URL, or not signed at all.
+		{url: relayURL(keys[0].PublicKey().Marshal(), "relay-b.example.com"), bid: signedTestBid(t, keys[1], header, 50)},
+		{url: relayURL(keys[3].PublicKey().Marshal(), "relay-c.example.com"), bid: invalidSig},
+		{url: relayURL(keys[1].PublicKey().Marshal(), "relay-d.example.com"), errHeader: errors.New("no bid")},
+		{url: relayURL(keys[2].PublicKey().Marshal(), "relay-e.example.com"), bid: signedTestBid(t, keys[2], header, 3), payload: payload, bundle: bundle},
+	}
+	opts := make([]Option, len(relays))
+	for i, r := range relays {
+		opts[i] = WithBuilderClient(r)
+	}
+	s, err := NewService(ctx, opts...)
+	require.NoError(t, err)
+
+	bid, err := s.GetHeader(ctx, b.Block().Slot(), [32]byte{}, [48]byte{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, relays[4].bid, bid)
+	// The bid is cached for the same request.
+	relays[4].bid = signedTestBid(t, keys[2], header, 4)
+	cached, err := s.GetHeader(ctx, b.Block().Slot(), [32]byte{}, [48]byte{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, bid, cached)
+
+	// The blinded block is only submitted to the relay of the selected bid.
+	_, _, err = s.SubmitBlindedBlock(ctx, b, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, relays[0].submitted)
+	assert.Equal(t, 1, relays[4].submitted)
+
+	// Only the relays configured for the proposer are requested, and the cached bid of another relay is not used.
+	bid, err = s.GetHeader(ctx, b.Block().Slot(), [32]byte{}, [48]byte{}, []string{"https://relay-a.example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, relays[0].bid, bid)
+	_, err = s.GetHeader(ctx, b.Block().Slot(), [32]byte{}, [48]byte{}, []string{"https://relay-z.example.com"})
+	require.ErrorIs(t, err, ErrNoConfiguredRelay)
+
+	// Without valid bids, the request fails.
+	for _, r := range relays {
+		r.bid = invalidSig
+	}
+	_, err = s.GetHeader(ctx, b.Block().Slot()+1, [32]byte{}, [48]byte{}, nil)
+	require.ErrorContains(t, "no relay returned a valid bid", err)
+}
@@ -0,0 +1,191 @@
+package builder
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	v1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	prysmTime "github.com/prysmaticlabs/prysm/v4/time"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// builderMismatchPenalty is how long the bids of a builder are rejected after it returned a payload which does
+	// not match the header of its bid. The penalty doubles with each further mismatch, up to maxBuilderMismatchPenalty.
+	builderMismatchPenalty    = 6 * time.Hour
+	maxBuilderMismatchPenalty = 7 * 24 * time.Hour
+)
+
+var (
+	// ErrPayloadMismatch is returned when the payload returned for a blinded block does not match its header.
+	ErrPayloadMismatch = errors.New("builder payload does not match the committed header")
+	// ErrBuilderPenalized is returned for bids of a builder which recently returned a mismatched payload.
+	ErrBuilderPenalized = errors.New("builder recently returned a payload not matching its bid")
+)
+
+// builderMismatch records the payload mismatches of a single builder.
+type builderMismatch struct {
+	count uint64
+	last  time.Time
+}
+
+// reputation is a local store of the builders which returned payloads not matching the header of their bid. Bids
+// of such builders are rejected for a while, so that the proposer falls back to a local payload instead.
+type reputation struct {
+	lock       sync.RWMutex
+	mismatches map[[fieldparams.BLSPubkeyLength]byte]*builderMismatch
+	now        func() time.Time
+}
+
+func newReputation() *reputation {
+	return &reputation{
+		mismatches: make(map[[fieldparams.BLSPubkeyLength]byte]*builderMismatch),
+		now:        prysmTime.Now,
+	}
+}
+
+// recordMismatch penalizes the builder for returning a payload which does not match the header of its bid.
+func (r *reputation) recordMismatch(builder [fieldparams.BLSPubkeyLength]byte) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	m, ok := r.mismatches[builder]
+	if !ok {
+		m = &builderMismatch{}
+		r.mismatches[builder] = m
+	}
+	m.count++
+	m.last = r.now()
+}
+
+// penalized returns true if the bids of the builder must be rejected.
+func (r *reputation) penalized(builder [fieldparams.BLSPubkeyLength]byte) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	m, ok := r.mismatches[builder]
+	if !ok {
+		return false
+	}
+	return r.now().Before(m.last.Add(mismatchPenalty(m.count)))
+}
+
+// mismatchPenalty returns how long bids are rejected after the given number of mismatches.
+func mismatchPenalty(count uint64) time.Duration {
+	penalty := builderMismatchPenalty
+	for i := uint64(1); i < count && penalty < maxBuilderMismatchPenalty; i++ {
+		penalty *= 2
+	}
+	if penalty > maxBuilderMismatchPenalty {
+		return maxBuilderMismatchPenalty
+	}
+	return penalty
+}
+
+// verifyUnblindedPayload checks that the payload and blobs bundle returned by the builder for a blinded block match
+// the header and the blob KZG commitments the proposer signed.
+func verifyUnblindedPayload(b interfaces.ReadOnlySignedBeaconBlock, payload interfaces.ExecutionData, bundle *v1.BlobsBundle) error {
+	if payload == nil || payload.IsNil() {
+		return errors.Wrap(ErrPayloadMismatch, "nil payload")
+	}
+	header, err := b.Block().Body().Execution()
+	if err != nil {
+		return errors.Wrap(err, "could not get execution header")
+	}
+	if !bytes.Equal(header.BlockHash(), payload.BlockHash()) {
+		return errors.Wrapf(ErrPayloadMismatch, "block hash %#x != %#x", payload.BlockHash(), header.BlockHash())
+	}
+
+	txs, err := payload.Transactions()
+	if err != nil {
+		return errors.Wrap(err, "could not get payload transactions")
+	}
+	txsRoot, err := ssz.TransactionsRoot(txs)
+	if err != nil {
+		return errors.Wrap(err, "could not compute transactions root")
+	}
+	wantTxsRoot, err := header.TransactionsRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not get header transactions root")
+	}
+	if !bytes.Equal(txsRoot[:], wantTxsRoot) {
+		return errors.Wrapf(ErrPayloadMismatch, "transactions root %#x != %#x", txsRoot, wantTxsRoot)
+	}
+
+	if b.Version() >= version.Capella {
+		withdrawals, err := payload.Withdrawals()
+		if err != nil {
+			return errors.Wrap(err, "could not get payload withdrawals")
+		}
+		withdrawalsRoot, err := ssz.WithdrawalSliceRoot(withdrawals, fieldparams.MaxWithdrawalsPerPayload)
+		if err != nil {
+			return errors.Wrap(err, "could not compute withdrawals root")
+		}
+		wantWithdrawalsRoot, err := header.WithdrawalsRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not get header withdrawals root")
+		}
+		if !bytes.Equal(withdrawalsRoot[:], wantWithdrawalsRoot) {
+			return errors.Wrapf(ErrPayloadMismatch, "withdrawals root %#x != %#x", withdrawalsRoot, wantWithdrawalsRoot)
+		}
+	}
+
+	if b.Version() >= version.Deneb {
+		commitments, err := b.Block().Body().BlobKzgCommitments()
+		if err != nil {
+			return errors.Wrap(err, "could not get blob kzg commitments")
+		}
+		if err := verifyBlobsBundle(commitments, bundle); err != nil {
+			return err
+		}
+	}
+
+	headerRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not get header root")
+	}
+	payloadRoot, err := payload.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not get payload root")
+	}
+	if headerRoot != payloadRoot {
+		return errors.Wrapf(ErrPayloadMismatch, "payload root %#x != %#x", payloadRoot, headerRoot)
+	}
+	return nil
+}
+
+// verifyBlobsBundle checks that the blobs bundle holds a blob for each of the KZG commitments of the block.
+func verifyBlobsBundle(commitments [][]byte, bundle *v1.BlobsBundle) error {
+	if len(commitments) == 0 {
+		return nil
+	}
+	if bundle == nil {
+		return errors.Wrapf(ErrPayloadMismatch, "missing blobs bundle for %d commitments", len(commitments))
+	}
+	if len(bundle.KzgCommitments) != len(commitments) || len(bundle.Blobs) != len(commitments) {
+		return errors.Wrapf(ErrPayloadMismatch, "blobs bundle with %d commitments and %d blobs for %d commitments",
+			len(bundle.KzgCommitments), len(bundle.Blobs), len(commitments))
+	}
+	for i, c := range commitments {
+		if !bytes.Equal(c, bundle.KzgCommitments[i]) {
+			return errors.Wrapf(ErrPayloadMismatch, "blob kzg commitment %d %#x != %#x", i, bundle.KzgCommitments[i], c)
+		}
+	}
+	return nil
+}
+
+// logPayloadMismatch reports a builder which returned a payload not matching the header of its bid.
+func logPayloadMismatch(rb *relayBid, blockHash [32]byte, err error) {
+	fields := log.Fields{"blockHash": fmt.Sprintf("%#x", blockHash)}
+	if rb != nil {
+		fields["relay"] = rb.relay.label
+		fields["builderPubKey"] = fmt.Sprintf("%#x", rb.builder)
+	}
+	log.WithError(err).WithFields(fields).Error("Builder returned a payload not matching the committed header, " +
+		"rejecting its bids for a while")
+}
@@ -0,0 +1,178 @@
+package builder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	buildertesting "github.com/prysmaticlabs/prysm/v4/api/client/builder/testing"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+// testBuilderPayload returns a blinded deneb block committing to a payload with a transaction, a withdrawal and a
+// blob, together with that payload and its blobs bundle.
+func testBuilderPayload(t *testing.T) (interfaces.ReadOnlySignedBeaconBlock, *v1.ExecutionPayloadDeneb, *v1.BlobsBundle) {
+	p := util.NewBeaconBlockDeneb().Block.Body.ExecutionPayload
+	p.BlockHash = bytesutil.PadTo([]byte("block hash"), 32)
+	p.Transactions = [][]byte{[]byte("tx")}
+	p.Withdrawals = []*v1.Withdrawal{{Index: 1, ValidatorIndex: 2, Address: make([]byte, 20), Amount: 3}}
+	wp, err := blocks.WrappedExecutionPayloadDeneb(p, 0)
+	require.NoError(t, err)
+	header, err := blocks.PayloadToHeaderDeneb(wp)
+	require.NoError(t, err)
+	commitment := bytesutil.PadTo([]byte("commitment"), fieldparams.BLSPubkeyLength)
+
+	blk := util.NewBlindedBeaconBlockDeneb()
+	blk.Message.Body.ExecutionPayloadHeader = header
+	blk.Message.Body.BlobKzgCommitments = [][]byte{commitment}
+	sb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	bundle := &v1.BlobsBundle{
+		KzgCommitments: [][]byte{commitment},
+		Proofs:         [][]byte{make([]byte, 48)},
+		Blobs:          [][]byte{make([]byte, fieldparams.BlobLength)},
+	}
+	return sb, p, bundle
+}
+
+func Test_verifyUnblindedPayload(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *v1.ExecutionPayloadDeneb, bundle *v1.BlobsBundle) *v1.BlobsBundle
+		want   string
+	}{
+		{
+			name: "matching payload",
+		},
+		{
+			name: "block hash",
+			modify: func(p *v1.ExecutionPayloadDeneb, bundle *v1.BlobsBundle) *v1.BlobsBundle {
+				p.BlockHash = bytesutil.PadTo([]byte("other"), 32)
+				return bundle
+			},
+			want: "block hash",
+		},
+		{
+			name: "transactions",
+			modify: func(p *v1.ExecutionPayloadDeneb, bundle *v1.BlobsBundle) *v1.BlobsBundle {
+				p.Transactions = [][]byte{[]byte("other tx")}
+				return bundle
+			},
+			want: "transactions root",
+		},
+		{
+			name: "withdrawals",
+			modify: func(p *v1.ExecutionPayloadDeneb, bundle *v1.BlobsBundle) *v1.BlobsBundle {
+				p.Withdrawals[0].Amount = 4
+				return bundle
+			},
+			want: "withdrawals root",
+		},
+		{
+			name: "missing blobs bundle",
+			modify: func(_ *v1.ExecutionPayloadDeneb, _ *v1.BlobsBundle) *v1.BlobsBundle {
+				return nil
+			},
+			want: "missing blobs bundle",
+		},
+		{
+			name: "missing blob",
+			modify: func(_ *v1.ExecutionPayloadDeneb, bundle *v1.BlobsBundle) *v1.BlobsBundle {
+				bundle.Blobs = nil
+				return bundle
+			},
+			want: "0 blobs for 1 commitments",
+		},
+		{
+			name: "blob commitment",
+			modify: func(_ *v1.ExecutionPayloadDeneb, bundle *v1.BlobsBundle) *v1.BlobsBundle {
+				bundle.KzgCommitments[0] = make([]byte, 48)
+				return bundle
+			},
+			want: "blob kzg commitment 0",
+		},
+		{
+			name: "other payload field",
+			modify: func(p *v1.ExecutionPayloadDeneb, bundle *v1.BlobsBundle) *v1.BlobsBundle {
+				p.GasUsed = 1
+				return bundle
+			},
+			want: "payload root",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, p, bundle := testBuilderPayload(t)
+			if tt.modify != nil {
+				bundle = tt.modify(p, bundle)
+			}
+			payload, err := blocks.WrappedExecutionPayloadDeneb(p, 0)
+			require.NoError(t, err)
+			err = verifyUnblindedPayload(b, payload, bundle)
+			if tt.want == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrPayloadMismatch)
+			require.ErrorContains(t, tt.want, err)
+		})
+	}
+}
+
+func Test_mismatchPenalty(t *testing.T) {
+	assert.Equal(t, builderMismatchPenalty, mismatchPenalty(1))
+	assert.Equal(t, 2*builderMismatchPenalty, mismatchPenalty(2))
+	assert.Equal(t, 4*builderMismatchPenalty, mismatchPenalty(3))
+	assert.Equal(t, maxBuilderMismatchPenalty, mismatchPenalty(100))
+}
+
+func TestService_SubmitBlindedBlock_PayloadMismatch(t *testing.T) {
+	ctx := context.Background()
+	b, p, bundle := testBuilderPayload(t)
+	header, err := b.Block().Body().Execution()
+	require.NoError(t, err)
+	headerProto, ok := header.Proto().(*v1.ExecutionPayloadHeaderDeneb)
+	require.Equal(t, true, ok)
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	bid := signedTestBid(t, sk, headerProto, 1)
+
+	client := buildertesting.NewClient()
+	client.URL = relayURL(sk.PublicKey().Marshal(), "relay.example.com")
+	client.Bid = bid
+	client.BlobsBundle = bundle
+	client.Payload, err = blocks.WrappedExecutionPayloadDeneb(p, 0)
+	require.NoError(t, err)
+	s, err := NewService(ctx, WithBuilderClient(&client))
+	require.NoError(t, err)
+	now := time.Now()
+	s.reputation.now = func() time.Time { return now }
+
+	_, err = s.GetHeader(ctx, b.Block().Slot(), [32]byte{}, [48]byte{}, nil)
+	require.NoError(t, err)
+	_, gotBundle, err := s.SubmitBlindedBlock(ctx, b, nil)
+	require.NoError(t, err)
+	assert.DeepEqual(t, bundle, gotBundle)
+
+	// The builder returns a payload with other transactions than in its bid.
+	p.Transactions = [][]byte{[]byte("other tx")}
+	client.Payload, err = blocks.WrappedExecutionPayloadDeneb(p, 0)
+	require.NoError(t, err)
+	_, _, err = s.SubmitBlindedBlock(ctx, b, nil)
+	require.ErrorIs(t, err, ErrPayloadMismatch)
+
+	// Its next bids are rejected until the penalty expires.
+	_, err = s.GetHeader(ctx, b.Block().Slot()+1, [32]byte{}, [48]byte{}, nil)
+	require.ErrorContains(t, ErrBuilderPenalized.Error(), err)
+	now = now.Add(builderMismatchPenalty)
+	_, err = s.GetHeader(ctx, b.Block().Slot()+2, [32]byte{}, [48]byte{}, nil)
+	require.NoError(t, err)
+}
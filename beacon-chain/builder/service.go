@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -25,10 +27,13 @@ import (
 // ErrNoBuilder is used when builder endpoint is not configured.
 var ErrNoBuilder = errors.New("builder endpoint not configured")
 
+// ErrNoConfiguredRelay is used when none of the relays configured for a proposer is a relay of the beacon node.
+var ErrNoConfiguredRelay = errors.New("none of the relays configured for the proposer is a relay of the beacon node")
+
 // BlockBuilder defines the interface for interacting with the block builder
 type BlockBuilder interface {
 	SubmitBlindedBlock(ctx context.Context, block interfaces.ReadOnlySignedBeaconBlock, blobs []*ethpb.SignedBlindedBlobSidecar) (interfaces.ExecutionData, *v1.BlobsBundle, error)
-	GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte) (builder.SignedBid, error)
+	GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte, relays []string) (builder.SignedBid, error)
 	RegisterValidator(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error
 	RegistrationByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (*ethpb.ValidatorRegistrationV1, error)
 	Configured() bool
@@ -36,45 +41,58 @@ type BlockBuilder interface {
 
 // config defines a config struct for dependencies into the service.
 type config struct {
-	builderClient builder.BuilderClient
-	beaconDB      db.HeadAccessDatabase
-	headFetcher   blockchain.HeadFetcher
+	builderClients []builder.BuilderClient
+	beaconDB       db.HeadAccessDatabase
+	headFetcher    blockchain.HeadFetcher
 }
 
 // Service defines a service that provides a client for interacting with the beacon chain and MEV relay network.
 type Service struct {
 	cfg               *config
-	c                 builder.BuilderClient
+	relays            []*relay
 	ctx               context.Context
 	cancel            context.CancelFunc
 	registrationCache *cache.RegistrationCache
+	bids              *bidCache
+	reputation        *reputation
 }
 
 // NewService instantiates a new service.
 func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	s := &Service{
-		ctx:    ctx,
-		cancel: cancel,
-		cfg:    &config{},
+		ctx:        ctx,
+		cancel:     cancel,
+		cfg:        &config{},
+		bids:       newBidCache(),
+		reputation: newReputation(),
 	}
 	for _, opt := range opts {
 		if err := opt(s); err != nil {
 			return nil, err
 		}
 	}
-	if s.cfg.builderClient != nil && !reflect.ValueOf(s.cfg.builderClient).IsNil() {
-		s.c = s.cfg.builderClient
+	for _, c := range s.cfg.builderClients {
+		if c == nil || reflect.ValueOf(c).IsNil() {
+			continue
+		}
+		r, err := newRelay(c)
+		if err != nil {
+			return nil, err
+		}
+		s.relays = append(s.relays, r)
 
 		// Is the builder up?
-		if err := s.c.Status(ctx); err != nil {
-			log.WithError(err).Error("Failed to check builder status")
+		if err := c.Status(ctx); err != nil {
+			log.WithError(err).WithField("relay", r.label).Error("Failed to check builder status")
 		} else {
-			log.WithField("endpoint", s.c.NodeURL()).Info("Builder has been configured")
-			log.Warn("Outsourcing block construction to external builders adds non-trivial delay to block propagation time.  " +
-				"Builder-constructed blocks or fallback blocks may get orphaned. Use at your own risk!")
+			log.WithField("endpoint", r.label).Info("Builder has been configured")
 		}
 	}
+	if len(s.relays) > 0 {
+		log.Warn("Outsourcing block construction to external builders adds non-trivial delay to block propagation time.  " +
+			"Builder-constructed blocks or fallback blocks may get orphaned. Use at your own risk!")
+	}
 	return s, nil
 }
 
@@ -97,38 +115,131 @@ func (s *Service) SubmitBlindedBlock(ctx context.Context, b interfaces.ReadOnlyS
 	defer func() {
 		submitBlindedBlockLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if !s.Configured() {
 		return nil, nil, ErrNoBuilder
 	}
 	if uint64(len(blobs)) > fieldparams.MaxBlobsPerBlock {
 		return nil, nil, fmt.Errorf("blob count %d beyond max limit of %d", len(blobs), fieldparams.MaxBlobsPerBlock)
 	}
+	h, err := b.Block().Body().Execution()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get execution header")
+	}
+	blockHash := bytesutil.ToBytes32(h.BlockHash())
 
-	return s.c.SubmitBlindedBlock(ctx, b, blobs)
+	// The blinded block is submitted to the relay of the selected bid, or to all relays if the bid is not known.
+	rb, known := s.bids.getByBlockHash(blockHash)
+	relays := s.relays
+	if known {
+		relays = []*relay{rb.relay}
+	}
+	payload, bundle, err := submitBlindedBlockToRelays(ctx, relays, b, blobs)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Verify the payload against the header the proposer signed before it is broadcast, and reject the further
+	// bids of a builder which does not honor its bids.
+	if err := verifyUnblindedPayload(b, payload, bundle); err != nil {
+		if !errors.Is(err, ErrPayloadMismatch) {
+			return nil, nil, err
+		}
+		if known {
+			s.reputation.recordMismatch(rb.builder)
+		} else {
+			rb = nil
+		}
+		payloadMismatchCount.Inc()
+		logPayloadMismatch(rb, blockHash, err)
+		tracing.AnnotateError(span, err)
+		return nil, nil, err
+	}
+	return payload, bundle, nil
+}
+
+// submitBlindedBlockToRelays submits the blinded block to the relays concurrently, and returns the first payload
+// returned.
+func submitBlindedBlockToRelays(ctx context.Context, relays []*relay, b interfaces.ReadOnlySignedBeaconBlock, blobs []*ethpb.SignedBlindedBlobSidecar) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
+	if len(relays) == 1 {
+		return relays[0].client.SubmitBlindedBlock(ctx, b, blobs)
+	}
+	type result struct {
+		payload interfaces.ExecutionData
+		bundle  *v1.BlobsBundle
+		err     error
+	}
+	results := make(chan *result, len(relays))
+	for _, r := range relays {
+		go func(r *relay) {
+			payload, bundle, err := r.client.SubmitBlindedBlock(ctx, b, blobs)
+			if err != nil {
+				err = errors.Wrapf(err, "relay %s", r.label)
+			}
+			results <- &result{payload: payload, bundle: bundle, err: err}
+		}(r)
+	}
+	var errs []string
+	for range relays {
+		res := <-results
+		if res.err == nil {
+			return res.payload, res.bundle, nil
+		}
+		errs = append(errs, res.err.Error())
+	}
+	return nil, nil, fmt.Errorf("could not submit blinded block to relays: %s", strings.Join(errs, "; "))
 }
 
-// GetHeader retrieves the header for a given slot and parent hash from the builder relay network.
-func (s *Service) GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte) (builder.SignedBid, error) {
+// GetHeader retrieves the header for a given slot and parent hash from the builder relay network. Only the relays
+// among the given relay URLs are requested if any is given, such as the relays configured for the proposer.
+func (s *Service) GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte, relayURLs []string) (builder.SignedBid, error) {
 	ctx, span := trace.StartSpan(ctx, "builder.GetHeader")
 	defer span.End()
 	start := time.Now()
 	defer func() {
 		getHeaderLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if !s.Configured() {
 		tracing.AnnotateError(span, ErrNoBuilder)
 		return nil, ErrNoBuilder
 	}
 
-	h, err := s.c.GetHeader(ctx, slot, parentHash, pubKey)
-	tracing.AnnotateError(span, err)
-	return h, err
+	relays, err := s.relaysFor(relayURLs)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return nil, err
+	}
+	req := bidRequest{slot: slot, parentHash: parentHash, pubkey: pubKey}
+	if rb, ok := s.bids.get(req); ok && containsRelay(relays, rb.relay) {
+		return rb.bid, nil
+	}
+	rb, err := s.getBestHeader(ctx, relays, slot, parentHash, pubKey)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return nil, err
+	}
+	bid, err := rb.bid.Message()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get bid")
+	}
+	header, err := bid.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get bid header")
+	}
+	s.bids.add(req, bytesutil.ToBytes32(header.BlockHash()), rb)
+	if len(relays) > 1 {
+		log.WithFields(log.Fields{
+			"slot":          slot,
+			"relay":         rb.relay.label,
+			"builderPubKey": fmt.Sprintf("%#x", rb.builder),
+			"value":         rb.value.String(),
+		}).Debug("Selected best bid among relays")
+	}
+	return rb.bid, nil
 }
 
 // Status retrieves the status of the builder relay network.
 func (s *Service) Status() error {
 	// Return early if builder isn't initialized in service.
-	if s.c == nil {
+	if !s.Configured() {
 		return nil
 	}
 
@@ -144,7 +255,7 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 	defer func() {
 		registerValidatorLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if !s.Configured() {
 		return ErrNoBuilder
 	}
 
@@ -169,7 +280,7 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 		valid = append(valid, r)
 		indexToRegistration[nx] = r.Message
 	}
-	if err := s.c.RegisterValidator(ctx, valid); err != nil {
+	if err := s.registerValidatorWithRelays(ctx, valid); err != nil {
 		return errors.Wrap(err, "could not register validator(s)")
 	}
 
@@ -196,9 +307,35 @@ func (s *Service) RegistrationByValidatorID(ctx context.Context, id primitives.V
 	}
 }
 
+// registerValidatorWithRelays submits the validator registrations to all relays concurrently. It only fails if no
+// relay accepted the registrations, as the bids of the other relays can still be used.
+func (s *Service) registerValidatorWithRelays(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error {
+	errs := make([]error, len(s.relays))
+	var wg sync.WaitGroup
+	for i, r := range s.relays {
+		wg.Add(1)
+		go func(i int, r *relay) {
+			defer wg.Done()
+			errs[i] = r.client.RegisterValidator(ctx, reg)
+		}(i, r)
+	}
+	wg.Wait()
+	var failed []string
+	for i, err := range errs {
+		if err != nil {
+			log.WithError(err).WithField("relay", s.relays[i].label).Warn("Could not register validators with relay")
+			failed = append(failed, fmt.Sprintf("%s: %v", s.relays[i].label, err))
+		}
+	}
+	if len(failed) == len(s.relays) {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
+}
+
 // Configured returns true if the user has configured a builder client.
 func (s *Service) Configured() bool {
-	return s.c != nil && !reflect.ValueOf(s.c).IsNil()
+	return len(s.relays) > 0
 }
 
 func (s *Service) pollRelayerStatus(ctx context.Context) {
@@ -207,9 +344,9 @@ func (s *Service) pollRelayerStatus(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			if s.c != nil {
-				if err := s.c.Status(ctx); err != nil {
-					log.WithError(err).Error("Failed to call relayer status endpoint, perhaps mev-boost or relayers are down")
+			for _, r := range s.relays {
+				if err := r.client.Status(ctx); err != nil {
+					log.WithError(err).WithField("relay", r.label).Error("Failed to call relayer status endpoint, perhaps mev-boost or relayers are down")
 				}
 			}
 		case <-ctx.Done():
@@ -15,7 +15,7 @@ import (
 )
 
 func Test_NewServiceWithBuilder(t *testing.T) {
-	s, err := NewService(context.Background(), WithBuilderClient(&buildertesting.MockClient{}))
+	s, err := NewService(context.Background(), WithBuilderClient(&buildertesting.MockClient{URL: testRelayURL}))
 	require.NoError(t, err)
 	assert.Equal(t, true, s.Configured())
 }
@@ -31,6 +31,7 @@ func Test_RegisterValidator(t *testing.T) {
 	db := dbtesting.SetupDB(t)
 	headFetcher := &blockchainTesting.ChainService{}
 	builder := buildertesting.NewClient()
+	builder.URL = testRelayURL
 	s, err := NewService(ctx, WithDatabase(db), WithHeadFetcher(headFetcher), WithBuilderClient(&builder))
 	require.NoError(t, err)
 	pubkey := bytesutil.ToBytes48([]byte("pubkey"))
@@ -43,6 +44,7 @@ func Test_RegisterValidator_WithCache(t *testing.T) {
 	ctx := context.Background()
 	headFetcher := &blockchainTesting.ChainService{}
 	builder := buildertesting.NewClient()
+	builder.URL = testRelayURL
 	s, err := NewService(ctx, WithRegistrationCache(), WithHeadFetcher(headFetcher), WithBuilderClient(&builder))
 	require.NoError(t, err)
 	pubkey := bytesutil.ToBytes48([]byte("pubkey"))
@@ -59,7 +61,7 @@ func Test_BuilderMethodsWithouClient(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, false, s.Configured())
 
-	_, err = s.GetHeader(context.Background(), 0, [32]byte{}, [48]byte{})
+	_, err = s.GetHeader(context.Background(), 0, [32]byte{}, [48]byte{}, nil)
 	assert.ErrorContains(t, ErrNoBuilder.Error(), err)
 
 	_, _, err = s.SubmitBlindedBlock(context.Background(), nil, nil)
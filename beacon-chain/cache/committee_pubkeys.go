@@ -0,0 +1,115 @@
+//go:build !fuzz
+
+package cache
+
+import (
+	"encoding/binary"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	lruwrpr "github.com/prysmaticlabs/prysm/v4/cache/lru"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+)
+
+const (
+	// maxCommitteePublicKeyCacheSize defines the max number of committee aggregate public keys can cache. This is
+	// two epochs worth of committees at the maximum number of committees per slot.
+	maxCommitteePublicKeyCacheSize = int(4096)
+)
+
+var (
+	// committeePublicKeyCacheMiss tracks the number of committee public key requests that aren't present in the cache.
+	committeePublicKeyCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "committee_public_key_cache_miss",
+		Help: "The number of committee public key requests that aren't present in the cache.",
+	})
+	// committeePublicKeyCacheHit tracks the number of committee public key requests that are in the cache.
+	committeePublicKeyCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "committee_public_key_cache_hit",
+		Help: "The number of committee public key requests that are present in the cache.",
+	})
+)
+
+// committeePublicKey is the aggregate public key of the members of a beacon committee.
+type committeePublicKey struct {
+	committee []primitives.ValidatorIndex
+	pubkey    bls.PublicKey
+}
+
+// CommitteePublicKeyCache is a struct with 1 LRU cache for looking up the aggregate public key of all the members of
+// a beacon committee by seed, slot and committee index.
+type CommitteePublicKeyCache struct {
+	cache *lru.Cache
+	lock  sync.RWMutex
+}
+
+// NewCommitteePublicKeyCache creates a new committee public key cache for storing/accessing aggregate public keys of
+// beacon committees.
+func NewCommitteePublicKeyCache() *CommitteePublicKeyCache {
+	c := &CommitteePublicKeyCache{}
+	c.Clear()
+	return c
+}
+
+// Clear resets the CommitteePublicKeyCache to its initial state.
+func (c *CommitteePublicKeyCache) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cache = lruwrpr.New(maxCommitteePublicKeyCacheSize)
+}
+
+// Add adds the aggregate public key of the members of the committee at the seed, slot and committee index.
+func (c *CommitteePublicKeyCache) Add(seed [32]byte, slot primitives.Slot, committeeIndex primitives.CommitteeIndex, committee []primitives.ValidatorIndex, pubkey bls.PublicKey) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	_ = c.cache.Add(committeePublicKeyCacheKey(seed, slot, committeeIndex), &committeePublicKey{
+		committee: committee,
+		pubkey:    pubkey,
+	})
+}
+
+// Get returns the aggregate public key of the members of the committee at the seed, slot and committee index. The
+// cached key is only returned for the exact same committee, so that changes to the validator set behind a seed never
+// return the aggregate of another committee.
+func (c *CommitteePublicKeyCache) Get(seed [32]byte, slot primitives.Slot, committeeIndex primitives.CommitteeIndex, committee []primitives.ValidatorIndex) (bls.PublicKey, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	value, exists := c.cache.Get(committeePublicKeyCacheKey(seed, slot, committeeIndex))
+	if !exists {
+		committeePublicKeyCacheMiss.Inc()
+		return nil, false
+	}
+	item, ok := value.(*committeePublicKey)
+	if !ok || !sameCommittee(item.committee, committee) {
+		committeePublicKeyCacheMiss.Inc()
+		return nil, false
+	}
+	committeePublicKeyCacheHit.Inc()
+	return item.pubkey, true
+}
+
+func sameCommittee(a, b []primitives.ValidatorIndex) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// The committee public key cache key is constructed as: seed + slot + committee_index
+func committeePublicKeyCacheKey(seed [32]byte, slot primitives.Slot, committeeIndex primitives.CommitteeIndex) string {
+	key := make([]byte, 48)
+	copy(key, seed[:])
+	binary.LittleEndian.PutUint64(key[32:], uint64(slot))
+	binary.LittleEndian.PutUint64(key[40:], uint64(committeeIndex))
+	return string(key)
+}
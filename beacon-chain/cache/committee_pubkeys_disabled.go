@@ -0,0 +1,34 @@
+//go:build fuzz
+
+package cache
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+)
+
+// FakeCommitteePublicKeyCache is a fake struct with 1 LRU cache for looking up the aggregate public key of a beacon
+// committee.
+type FakeCommitteePublicKeyCache struct {
+}
+
+// NewCommitteePublicKeyCache creates a new committee public key cache for storing/accessing aggregate public keys of
+// beacon committees.
+func NewCommitteePublicKeyCache() *FakeCommitteePublicKeyCache {
+	return &FakeCommitteePublicKeyCache{}
+}
+
+// Clear is a stub.
+func (c *FakeCommitteePublicKeyCache) Clear() {
+	return
+}
+
+// Add is a stub.
+func (c *FakeCommitteePublicKeyCache) Add(_ [32]byte, _ primitives.Slot, _ primitives.CommitteeIndex, _ []primitives.ValidatorIndex, _ bls.PublicKey) {
+	return
+}
+
+// Get returns nothing, as the cache is disabled.
+func (c *FakeCommitteePublicKeyCache) Get(_ [32]byte, _ primitives.Slot, _ primitives.CommitteeIndex, _ []primitives.ValidatorIndex) (bls.PublicKey, bool) {
+	return nil, false
+}
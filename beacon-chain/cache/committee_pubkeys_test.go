@@ -0,0 +1,38 @@
+//go:build !fuzz
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestCommitteePublicKeyCache_AddGet(t *testing.T) {
+	c := NewCommitteePublicKeyCache()
+	seed := [32]byte{'A'}
+	committee := []primitives.ValidatorIndex{1, 2, 3}
+	_, ok := c.Get(seed, 1, 2, committee)
+	assert.Equal(t, false, ok)
+
+	priv, err := bls.RandKey()
+	require.NoError(t, err)
+	c.Add(seed, 1, 2, committee, priv.PublicKey())
+	pubkey, ok := c.Get(seed, 1, 2, committee)
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, priv.PublicKey().Marshal(), pubkey.Marshal())
+
+	_, ok = c.Get(seed, 1, 3, committee)
+	assert.Equal(t, false, ok, "Committee index should be part of the key")
+	_, ok = c.Get([32]byte{'B'}, 1, 2, committee)
+	assert.Equal(t, false, ok, "Seed should be part of the key")
+	_, ok = c.Get(seed, 1, 2, []primitives.ValidatorIndex{1, 2, 4})
+	assert.Equal(t, false, ok, "Different committee should not be returned")
+
+	c.Clear()
+	_, ok = c.Get(seed, 1, 2, committee)
+	assert.Equal(t, false, ok)
+}
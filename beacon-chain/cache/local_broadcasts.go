@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+// LocalAttestation is an attestation of a validator of the node, along with the subnet it was broadcast to.
+type LocalAttestation struct {
+	Subnet      uint64
+	Attestation *ethpb.Attestation
+}
+
+// LocalBroadcastsCache keeps the attestations and blocks which the validators of the node broadcast, so that they
+// can be broadcast again when the chain does not finalize. Attestations are kept for as long as they can be
+// propagated, and blocks until they are pruned. The signed blob sidecars of blocks are kept along with them, as
+// their signatures are not saved in the database.
+type LocalBroadcastsCache struct {
+	attestations map[primitives.Slot][]*LocalAttestation
+	blocks       map[[32]byte]*localBlock
+	sync.RWMutex
+}
+
+type localBlock struct {
+	slot     primitives.Slot
+	sidecars []*ethpb.SignedBlobSidecar
+}
+
+// NewLocalBroadcastsCache creates a new local broadcasts cache.
+func NewLocalBroadcastsCache() *LocalBroadcastsCache {
+	return &LocalBroadcastsCache{
+		attestations: make(map[primitives.Slot][]*LocalAttestation),
+		blocks:       make(map[[32]byte]*localBlock),
+	}
+}
+
+// AddAttestation records an attestation broadcast to a subnet, and drops the attestations which can no longer be
+// propagated at its slot.
+func (c *LocalBroadcastsCache) AddAttestation(subnet uint64, att *ethpb.Attestation) {
+	if att == nil || att.Data == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	slot := att.Data.Slot
+	c.attestations[slot] = append(c.attestations[slot], &LocalAttestation{Subnet: subnet, Attestation: att})
+	for s := range c.attestations {
+		if s+params.BeaconNetworkConfig().AttestationPropagationSlotRange < slot {
+			delete(c.attestations, s)
+		}
+	}
+}
+
+// Attestations returns the recorded attestations from the given slot onward.
+func (c *LocalBroadcastsCache) Attestations(from primitives.Slot) []*LocalAttestation {
+	c.RLock()
+	defer c.RUnlock()
+	atts := make([]*LocalAttestation, 0)
+	for s, a := range c.attestations {
+		if s >= from {
+			atts = append(atts, a...)
+		}
+	}
+	return atts
+}
+
+// AddBlock records the root of a block at a slot, along with its blob sidecars.
+func (c *LocalBroadcastsCache) AddBlock(root [32]byte, slot primitives.Slot, sidecars []*ethpb.SignedBlobSidecar) {
+	c.Lock()
+	defer c.Unlock()
+	c.blocks[root] = &localBlock{slot: slot, sidecars: sidecars}
+}
+
+// BlobSidecars returns the recorded blob sidecars of a block.
+func (c *LocalBroadcastsCache) BlobSidecars(root [32]byte) []*ethpb.SignedBlobSidecar {
+	c.RLock()
+	defer c.RUnlock()
+	if b, ok := c.blocks[root]; ok {
+		return b.sidecars
+	}
+	return nil
+}
+
+// BlockRoots returns the recorded block roots, from the latest block to the earliest.
+func (c *LocalBroadcastsCache) BlockRoots() [][32]byte {
+	c.RLock()
+	defer c.RUnlock()
+	roots := make([][32]byte, 0, len(c.blocks))
+	for r := range c.blocks {
+		roots = append(roots, r)
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		return c.blocks[roots[i]].slot > c.blocks[roots[j]].slot
+	})
+	return roots
+}
+
+// PruneBlocks removes the blocks before the given slot.
+func (c *LocalBroadcastsCache) PruneBlocks(slot primitives.Slot) {
+	c.Lock()
+	defer c.Unlock()
+	for r, b := range c.blocks {
+		if b.slot < slot {
+			delete(c.blocks, r)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestLocalBroadcastsCache_Attestations(t *testing.T) {
+	c := NewLocalBroadcastsCache()
+	for _, slot := range []primitives.Slot{10, 20, 30} {
+		c.AddAttestation(uint64(slot), &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: slot}})
+	}
+	assert.Equal(t, 3, len(c.Attestations(0)))
+	atts := c.Attestations(25)
+	require.Equal(t, 1, len(atts))
+	assert.Equal(t, uint64(30), atts[0].Subnet)
+
+	// Attestations which can no longer be propagated are dropped.
+	c.AddAttestation(50, &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 50}})
+	assert.Equal(t, 3, len(c.Attestations(0)))
+}
+
+func TestLocalBroadcastsCache_Blocks(t *testing.T) {
+	c := NewLocalBroadcastsCache()
+	sidecars := []*ethpb.SignedBlobSidecar{{Message: &ethpb.BlobSidecar{Index: 0}}, {Message: &ethpb.BlobSidecar{Index: 1}}}
+	c.AddBlock([32]byte{'a'}, 5, sidecars)
+	c.AddBlock([32]byte{'b'}, 15, nil)
+	c.AddBlock([32]byte{'c'}, 10, nil)
+	assert.DeepEqual(t, [][32]byte{{'b'}, {'c'}, {'a'}}, c.BlockRoots())
+	assert.DeepEqual(t, sidecars, c.BlobSidecars([32]byte{'a'}))
+	assert.Equal(t, 0, len(c.BlobSidecars([32]byte{'b'})))
+
+	c.PruneBlocks(10)
+	assert.DeepEqual(t, [][32]byte{{'b'}, {'c'}}, c.BlockRoots())
+	assert.Equal(t, 0, len(c.BlobSidecars([32]byte{'a'})))
+}
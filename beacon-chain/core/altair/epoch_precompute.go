@@ -2,8 +2,10 @@ package altair
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/async"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
@@ -15,11 +17,12 @@ import (
 
 // AttDelta contains rewards and penalties for a single attestation.
 type AttDelta struct {
-	HeadReward    uint64
-	SourceReward  uint64
-	SourcePenalty uint64
-	TargetReward  uint64
-	TargetPenalty uint64
+	HeadReward        uint64
+	SourceReward      uint64
+	SourcePenalty     uint64
+	TargetReward      uint64
+	TargetPenalty     uint64
+	InactivityPenalty uint64
 }
 
 // InitializePrecomputeValidators precomputes individual validator for its attested balances and the total sum of validators attested balances of the epoch.
@@ -162,58 +165,75 @@ func ProcessEpochParticipation(
 	if err != nil {
 		return nil, nil, err
 	}
+	pp, err := beaconState.PreviousEpochParticipation()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(cp) > len(vals) || len(pp) > len(vals) {
+		return nil, nil, errors.New("epoch participation longer than validator registry")
+	}
+	if len(vals) > 0 {
+		// Validators are independent from each other, so their flags are processed by ranges in parallel.
+		if _, err := async.Scatter(len(vals), func(offset int, entries int, _ *sync.RWMutex) (interface{}, error) {
+			return nil, processParticipationFlags(vals, cp, pp, offset, offset+entries)
+		}); err != nil {
+			return nil, nil, err
+		}
+	}
+	bal = precompute.UpdateBalance(vals, bal, beaconState.Version())
+	return vals, bal, nil
+}
+
+// processParticipationFlags sets the attester flags of the validators in the [start, end) range from the current
+// and previous epoch participation.
+func processParticipationFlags(vals []*precompute.Validator, cp, pp []byte, start, end int) error {
 	cfg := params.BeaconConfig()
 	targetIdx := cfg.TimelyTargetFlagIndex
 	sourceIdx := cfg.TimelySourceFlagIndex
 	headIdx := cfg.TimelyHeadFlagIndex
-	for i, b := range cp {
-		has, err := HasValidatorFlag(b, sourceIdx)
+	for i := start; i < end && i < len(cp); i++ {
+		has, err := HasValidatorFlag(cp[i], sourceIdx)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
 		if has && vals[i].IsActiveCurrentEpoch {
 			vals[i].IsCurrentEpochAttester = true
 		}
-		has, err = HasValidatorFlag(b, targetIdx)
+		has, err = HasValidatorFlag(cp[i], targetIdx)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
 		if has && vals[i].IsActiveCurrentEpoch {
 			vals[i].IsCurrentEpochAttester = true
 			vals[i].IsCurrentEpochTargetAttester = true
 		}
 	}
-	pp, err := beaconState.PreviousEpochParticipation()
-	if err != nil {
-		return nil, nil, err
-	}
-	for i, b := range pp {
-		has, err := HasValidatorFlag(b, sourceIdx)
+	for i := start; i < end && i < len(pp); i++ {
+		has, err := HasValidatorFlag(pp[i], sourceIdx)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
 		if has && vals[i].IsActivePrevEpoch {
 			vals[i].IsPrevEpochAttester = true
 			vals[i].IsPrevEpochSourceAttester = true
 		}
-		has, err = HasValidatorFlag(b, targetIdx)
+		has, err = HasValidatorFlag(pp[i], targetIdx)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
 		if has && vals[i].IsActivePrevEpoch {
 			vals[i].IsPrevEpochAttester = true
 			vals[i].IsPrevEpochTargetAttester = true
 		}
-		has, err = HasValidatorFlag(b, headIdx)
+		has, err = HasValidatorFlag(pp[i], headIdx)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
 		if has && vals[i].IsActivePrevEpoch {
 			vals[i].IsPrevEpochHeadAttester = true
 		}
 	}
-	bal = precompute.UpdateBalance(vals, bal, beaconState.Version())
-	return vals, bal, nil
+	return nil
 }
 
 // ProcessRewardsAndPenaltiesPrecompute processes the rewards and penalties of individual validator.
@@ -241,19 +261,27 @@ func ProcessRewardsAndPenaltiesPrecompute(
 	}
 
 	balances := beaconState.Balances()
-	for i := 0; i < numOfVals; i++ {
-		vals[i].BeforeEpochTransitionBalance = balances[i]
-
-		// Compute the post balance of the validator after accounting for the
-		// attester and proposer rewards and penalties.
-		delta := attDeltas[i]
-		balances[i], err = helpers.IncreaseBalanceWithVal(balances[i], delta.HeadReward+delta.SourceReward+delta.TargetReward)
-		if err != nil {
+	if numOfVals > 0 {
+		if _, err := async.Scatter(numOfVals, func(offset int, entries int, _ *sync.RWMutex) (interface{}, error) {
+			for i := offset; i < offset+entries; i++ {
+				vals[i].BeforeEpochTransitionBalance = balances[i]
+
+				// Compute the post balance of the validator after accounting for the
+				// attester and proposer rewards and penalties.
+				delta := attDeltas[i]
+				var err error
+				balances[i], err = helpers.IncreaseBalanceWithVal(balances[i], delta.HeadReward+delta.SourceReward+delta.TargetReward)
+				if err != nil {
+					return nil, err
+				}
+				balances[i] = helpers.DecreaseBalanceWithVal(balances[i], delta.SourcePenalty+delta.TargetPenalty+delta.InactivityPenalty)
+
+				vals[i].AfterEpochTransitionBalance = balances[i]
+			}
+			return nil, nil
+		}); err != nil {
 			return nil, err
 		}
-		balances[i] = helpers.DecreaseBalanceWithVal(balances[i], delta.SourcePenalty+delta.TargetPenalty)
-
-		vals[i].AfterEpochTransitionBalance = balances[i]
 	}
 
 	if err := beaconState.SetBalances(balances); err != nil {
@@ -284,11 +312,20 @@ func AttestationsDelta(beaconState state.BeaconState, bal *precompute.Balance, v
 	}
 	inactivityDenominator := bias * inactivityPenaltyQuotient
 
-	for i, v := range vals {
-		attDeltas[i], err = attestationDelta(bal, v, baseRewardMultiplier, inactivityDenominator, leak)
-		if err != nil {
-			return nil, err
+	if len(vals) == 0 {
+		return attDeltas, nil
+	}
+	if _, err := async.Scatter(len(vals), func(offset int, entries int, _ *sync.RWMutex) (interface{}, error) {
+		for i := offset; i < offset+entries; i++ {
+			var err error
+			attDeltas[i], err = attestationDelta(bal, vals[i], baseRewardMultiplier, inactivityDenominator, leak)
+			if err != nil {
+				return nil, err
+			}
 		}
+		return nil, nil
+	}); err != nil {
+		return nil, err
 	}
 
 	return attDeltas, nil
@@ -351,7 +388,7 @@ func attestationDelta(
 		if err != nil {
 			return &AttDelta{}, err
 		}
-		attDelta.TargetPenalty += n / inactivityDenominator
+		attDelta.InactivityPenalty += n / inactivityDenominator
 	}
 
 	return attDelta, nil
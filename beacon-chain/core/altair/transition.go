@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 	e "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/profiling"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"go.opencensus.io/trace"
 )
@@ -35,35 +36,47 @@ func ProcessEpoch(ctx context.Context, state state.BeaconState) (state.BeaconSta
 	if state == nil || state.IsNil() {
 		return nil, errors.New("nil state")
 	}
-	vp, bp, err := InitializePrecomputeValidators(ctx, state)
+	stageCtx, done := profiling.EpochStage(ctx, "initialize_validators")
+	vp, bp, err := InitializePrecomputeValidators(stageCtx, state)
+	done()
 	if err != nil {
 		return nil, err
 	}
 
 	// New in Altair.
-	vp, bp, err = ProcessEpochParticipation(ctx, state, bp, vp)
+	stageCtx, done = profiling.EpochStage(ctx, "participation")
+	vp, bp, err = ProcessEpochParticipation(stageCtx, state, bp, vp)
+	done()
 	if err != nil {
 		return nil, err
 	}
 
+	_, done = profiling.EpochStage(ctx, "justification_and_finalization")
 	state, err = precompute.ProcessJustificationAndFinalizationPreCompute(state, bp)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process justification")
 	}
 
 	// New in Altair.
-	state, vp, err = ProcessInactivityScores(ctx, state, vp)
+	stageCtx, done = profiling.EpochStage(ctx, "inactivity_updates")
+	state, vp, err = ProcessInactivityScores(stageCtx, state, vp)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process inactivity updates")
 	}
 
 	// New in Altair.
+	_, done = profiling.EpochStage(ctx, "rewards_and_penalties")
 	state, err = ProcessRewardsAndPenaltiesPrecompute(state, bp, vp)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process rewards and penalties")
 	}
 
-	state, err = e.ProcessRegistryUpdates(ctx, state)
+	stageCtx, done = profiling.EpochStage(ctx, "registry_updates")
+	state, err = e.ProcessRegistryUpdates(stageCtx, state)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process registry updates")
 	}
@@ -73,39 +86,55 @@ func ProcessEpoch(ctx context.Context, state state.BeaconState) (state.BeaconSta
 	if err != nil {
 		return nil, err
 	}
+	_, done = profiling.EpochStage(ctx, "slashings")
 	state, err = e.ProcessSlashings(state, proportionalSlashingMultiplier)
+	done()
 	if err != nil {
 		return nil, err
 	}
+	_, done = profiling.EpochStage(ctx, "eth1_data_reset")
 	state, err = e.ProcessEth1DataReset(state)
+	done()
 	if err != nil {
 		return nil, err
 	}
+	_, done = profiling.EpochStage(ctx, "effective_balance_updates")
 	state, err = e.ProcessEffectiveBalanceUpdates(state)
+	done()
 	if err != nil {
 		return nil, err
 	}
+	_, done = profiling.EpochStage(ctx, "slashings_reset")
 	state, err = e.ProcessSlashingsReset(state)
+	done()
 	if err != nil {
 		return nil, err
 	}
+	_, done = profiling.EpochStage(ctx, "randao_mixes_reset")
 	state, err = e.ProcessRandaoMixesReset(state)
+	done()
 	if err != nil {
 		return nil, err
 	}
+	_, done = profiling.EpochStage(ctx, "historical_data_update")
 	state, err = e.ProcessHistoricalDataUpdate(state)
+	done()
 	if err != nil {
 		return nil, err
 	}
 
 	// New in Altair.
+	_, done = profiling.EpochStage(ctx, "participation_flag_updates")
 	state, err = ProcessParticipationFlagUpdates(state)
+	done()
 	if err != nil {
 		return nil, err
 	}
 
 	// New in Altair.
-	state, err = ProcessSyncCommitteeUpdates(ctx, state)
+	stageCtx, done = profiling.EpochStage(ctx, "sync_committee_updates")
+	state, err = ProcessSyncCommitteeUpdates(stageCtx, state)
+	done()
 	if err != nil {
 		return nil, err
 	}
@@ -8,8 +8,10 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/async"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/validators"
@@ -259,39 +261,63 @@ func ProcessEffectiveBalanceUpdates(state state.BeaconState) (state.BeaconState,
 	upwardThreshold := hysteresisInc * params.BeaconConfig().HysteresisUpwardMultiplier
 
 	bals := state.Balances()
+	numVals := state.NumValidators()
+	if numVals == 0 {
+		return state, nil
+	}
 
-	// Update effective balances with hysteresis.
-	validatorFunc := func(idx int, val *ethpb.Validator) (bool, *ethpb.Validator, error) {
-		if val == nil {
-			return false, nil, fmt.Errorf("validator %d is nil in state", idx)
-		}
-		if idx >= len(bals) {
-			return false, nil, fmt.Errorf("validator index exceeds validator length in state %d >= %d", idx, len(state.Balances()))
-		}
-		balance := bals[idx]
-
-		if balance+downwardThreshold < val.EffectiveBalance || val.EffectiveBalance+upwardThreshold < balance {
-			effectiveBal := maxEffBalance
-			if effectiveBal > balance-balance%effBalanceInc {
-				effectiveBal = balance - balance%effBalanceInc
+	// Find the validators whose effective balance changes with hysteresis. Validators are read by ranges in
+	// parallel, and the few which change are updated afterwards.
+	results, err := async.Scatter(numVals, func(offset int, entries int, _ *sync.RWMutex) (interface{}, error) {
+		var updates []effectiveBalanceUpdate
+		for idx := offset; idx < offset+entries; idx++ {
+			val, err := state.ValidatorAtIndexReadOnly(primitives.ValidatorIndex(idx))
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not get validator %d", idx)
+			}
+			if idx >= len(bals) {
+				return nil, fmt.Errorf("validator index exceeds validator length in state %d >= %d", idx, len(bals))
 			}
-			if effectiveBal != val.EffectiveBalance {
-				newVal := ethpb.CopyValidator(val)
-				newVal.EffectiveBalance = effectiveBal
-				return true, newVal, nil
+			balance := bals[idx]
+
+			if balance+downwardThreshold < val.EffectiveBalance() || val.EffectiveBalance()+upwardThreshold < balance {
+				effectiveBal := maxEffBalance
+				if effectiveBal > balance-balance%effBalanceInc {
+					effectiveBal = balance - balance%effBalanceInc
+				}
+				if effectiveBal != val.EffectiveBalance() {
+					updates = append(updates, effectiveBalanceUpdate{index: primitives.ValidatorIndex(idx), balance: effectiveBal})
+				}
 			}
-			return false, val, nil
 		}
-		return false, val, nil
+		return updates, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := state.ApplyToEveryValidator(validatorFunc); err != nil {
-		return nil, err
+	for _, result := range results {
+		for _, update := range result.Extent.([]effectiveBalanceUpdate) {
+			val, err := state.ValidatorAtIndex(update.index)
+			if err != nil {
+				return nil, err
+			}
+			val.EffectiveBalance = update.balance
+			if err := state.UpdateValidatorAtIndex(update.index, val); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return state, nil
 }
 
+// effectiveBalanceUpdate is the new effective balance of a validator.
+type effectiveBalanceUpdate struct {
+	index   primitives.ValidatorIndex
+	balance uint64
+}
+
 // ProcessSlashingsReset processes the total slashing balances updates during epoch processing.
 //
 // Spec pseudocode definition:
@@ -284,6 +284,30 @@ func TestProcessFinalUpdates_CanProcess(t *testing.T) {
 	assert.NotNil(t, currAtt, "Nil value stored in current epoch attestations instead of empty slice")
 }
 
+func TestProcessEffectiveBalanceUpdates_ManyValidators(t *testing.T) {
+	s := buildState(t, 0, 1000)
+	maxEffBalance := params.BeaconConfig().MaxEffectiveBalance
+	balances := s.Balances()
+	// Spread the validators whose effective balance changes across the registry, so they are processed by
+	// different workers.
+	for i := 0; i < len(balances); i += 97 {
+		balances[i] = 30.2 * 1e9
+	}
+	// Within the hysteresis thresholds.
+	balances[1] = 31.9 * 1e9
+	require.NoError(t, s.SetBalances(balances))
+
+	newS, err := epoch.ProcessEffectiveBalanceUpdates(s)
+	require.NoError(t, err)
+	for i, val := range newS.Validators() {
+		want := maxEffBalance
+		if i%97 == 0 {
+			want = 30 * 1e9
+		}
+		require.Equal(t, want, val.EffectiveBalance, "Unexpected effective balance of validator %d", i)
+	}
+}
+
 func TestProcessRegistryUpdates_NoRotation(t *testing.T) {
 	base := &ethpb.BeaconState{
 		Slot: 5 * params.BeaconConfig().SlotsPerEpoch,
@@ -27,6 +27,8 @@ const (
 	NewHead
 	// MissedSlot is sent when we need to notify users that a slot was missed.
 	MissedSlot
+	// OptimisticStatusChanged is sent when the head of the chain becomes optimistic, or is no longer optimistic.
+	OptimisticStatusChanged
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -43,6 +45,16 @@ type BlockProcessedData struct {
 	Optimistic bool
 }
 
+// OptimisticStatusChangedData is the data sent with OptimisticStatusChanged events.
+type OptimisticStatusChangedData struct {
+	// Slot is the slot of the head block.
+	Slot primitives.Slot
+	// BlockRoot is the root of the head block.
+	BlockRoot [32]byte
+	// Optimistic is true if the execution payload of the head block has not been validated yet.
+	Optimistic bool
+}
+
 // ChainStartedData is the data sent with ChainStarted events.
 type ChainStartedData struct {
 	// StartTime is the time at which the chain started.
@@ -6,10 +6,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	state_native "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
@@ -334,3 +336,87 @@ func TestValidateSlotTargetEpoch(t *testing.T) {
 		})
 	}
 }
+
+func TestAttestingPublicKey(t *testing.T) {
+	helpers.ClearCache()
+	beaconState, _ := util.DeterministicGenesisState(t, 256)
+	committee, err := helpers.BeaconCommitteeFromState(context.Background(), beaconState, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, true, len(committee) > 3)
+
+	tests := []struct {
+		name     string
+		attested func(i int) bool
+	}{
+		{name: "single member", attested: func(i int) bool { return i == 1 }},
+		{name: "minority", attested: func(i int) bool { return i%3 == 0 }},
+		{name: "majority", attested: func(i int) bool { return i%3 != 0 }},
+		{name: "one absent member", attested: func(i int) bool { return i != 2 }},
+		{name: "whole committee", attested: func(i int) bool { return true }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bits := bitfield.NewBitlist(uint64(len(committee)))
+			var pubkeys [][]byte
+			for i, idx := range committee {
+				if !tt.attested(i) {
+					continue
+				}
+				bits.SetBitAt(uint64(i), true)
+				pubkey := beaconState.PubkeyAtIndex(idx)
+				pubkeys = append(pubkeys, pubkey[:])
+			}
+			want, err := bls.AggregatePublicKeys(pubkeys)
+			require.NoError(t, err)
+			// The second run uses the cached public key of the committee.
+			for i := 0; i < 2; i++ {
+				got, err := helpers.AttestingPublicKey(beaconState, 1, 0, committee, bits)
+				require.NoError(t, err)
+				assert.DeepEqual(t, want.Marshal(), got.Marshal())
+			}
+		})
+	}
+
+	t.Run("no attesting members", func(t *testing.T) {
+		_, err := helpers.AttestingPublicKey(beaconState, 1, 0, committee, bitfield.NewBitlist(uint64(len(committee))))
+		require.ErrorContains(t, "no attesting validators", err)
+	})
+	t.Run("wrong bitfield length", func(t *testing.T) {
+		_, err := helpers.AttestingPublicKey(beaconState, 1, 0, committee, bitfield.NewBitlist(uint64(len(committee))+1))
+		require.ErrorContains(t, "is not equal to committee length", err)
+	})
+}
+
+func BenchmarkAttestingPublicKey(b *testing.B) {
+	beaconState, _ := util.DeterministicGenesisState(b, 16384)
+	committee, err := helpers.BeaconCommitteeFromState(context.Background(), beaconState, 1, 0)
+	require.NoError(b, err)
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	for i := range committee {
+		// Most attestations seen during peak load are aggregates missing a few members of the committee.
+		if i%16 != 0 {
+			bits.SetBitAt(uint64(i), true)
+		}
+	}
+
+	b.Run("aggregate attesting members", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pubkeys := make([][]byte, 0, len(committee))
+			for j, idx := range committee {
+				if bits.BitAt(uint64(j)) {
+					pubkey := beaconState.PubkeyAtIndex(idx)
+					pubkeys = append(pubkeys, pubkey[:])
+				}
+			}
+			_, err := bls.AggregatePublicKeys(pubkeys)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("subtract absent members", func(b *testing.B) {
+		helpers.ClearCache()
+		for i := 0; i < b.N; i++ {
+			_, err := helpers.AttestingPublicKey(beaconState, 1, 0, committee, bits)
+			require.NoError(b, err)
+		}
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/container/slice"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/math"
@@ -24,8 +25,9 @@ import (
 )
 
 var (
-	committeeCache       = cache.NewCommitteesCache()
-	proposerIndicesCache = cache.NewProposerIndicesCache()
+	committeeCache          = cache.NewCommitteesCache()
+	proposerIndicesCache    = cache.NewProposerIndicesCache()
+	committeePublicKeyCache = cache.NewCommitteePublicKeyCache()
 )
 
 // SlotCommitteeCount returns the number of beacon committees of a slot. The
@@ -98,6 +100,77 @@ func BeaconCommitteeFromState(ctx context.Context, state state.ReadOnlyBeaconSta
 	return BeaconCommittee(ctx, activeIndices, seed, slot, committeeIndex)
 }
 
+// AttestingPublicKey returns the aggregate public key of the members of the beacon committee at the given slot and
+// committee index which are set in the aggregation bits of an attestation.
+//
+// The aggregate public key of the whole committee is cached, so that when most of the committee attested, the public
+// keys of the absent members are subtracted from it instead of adding up the public keys of the attesting members.
+func AttestingPublicKey(
+	st state.ReadOnlyBeaconState,
+	slot primitives.Slot,
+	committeeIndex primitives.CommitteeIndex,
+	committee []primitives.ValidatorIndex,
+	bits bitfield.Bitlist,
+) (bls.PublicKey, error) {
+	if bits.Len() != uint64(len(committee)) {
+		return nil, fmt.Errorf("bitfield length %d is not equal to committee length %d", bits.Len(), len(committee))
+	}
+	attesting := bits.Count()
+	if attesting == 0 {
+		return nil, errors.New("no attesting validators in the bitfield")
+	}
+	absent := uint64(len(committee)) - attesting
+	if absent >= attesting {
+		return aggregateCommitteePublicKeys(st, committee, bits)
+	}
+
+	seed, err := Seed(st, slots.ToEpoch(slot), params.BeaconConfig().DomainBeaconAttester)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get seed")
+	}
+	committeeKey, ok := committeePublicKeyCache.Get(seed, slot, committeeIndex, committee)
+	if ok {
+		// Adding up the attesting public keys takes one addition less than there are attesting members.
+		pubkeyAdditionsSaved.Add(float64(attesting - 1 - absent))
+	} else {
+		committeeKey, err = aggregateCommitteePublicKeys(st, committee, nil)
+		if err != nil {
+			return nil, err
+		}
+		committeePublicKeyCache.Add(seed, slot, committeeIndex, committee, committeeKey)
+	}
+	if absent == 0 {
+		return committeeKey.Copy(), nil
+	}
+	absentKeys := make([]bls.PublicKey, 0, absent)
+	for i, idx := range committee {
+		if bits.BitAt(uint64(i)) {
+			continue
+		}
+		pubkey := st.PubkeyAtIndex(idx)
+		key, err := bls.PublicKeyFromBytes(pubkey[:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not deserialize public key of validator %d", idx)
+		}
+		absentKeys = append(absentKeys, key)
+	}
+	return bls.SubtractPublicKeys(committeeKey, absentKeys), nil
+}
+
+// aggregateCommitteePublicKeys aggregates the public keys of the members of a committee which are set in the
+// bitfield, or of all the members if the bitfield is nil.
+func aggregateCommitteePublicKeys(st state.ReadOnlyBeaconState, committee []primitives.ValidatorIndex, bits bitfield.Bitlist) (bls.PublicKey, error) {
+	pubkeys := make([][]byte, 0, len(committee))
+	for i, idx := range committee {
+		if bits != nil && !bits.BitAt(uint64(i)) {
+			continue
+		}
+		pubkey := st.PubkeyAtIndex(idx)
+		pubkeys = append(pubkeys, pubkey[:])
+	}
+	return bls.AggregatePublicKeys(pubkeys)
+}
+
 // BeaconCommittee returns the beacon committee of a given slot and committee index. The
 // validator indices and seed are provided as an argument rather than an imported implementation
 // from the spec definition. Having them as an argument allows for cheaper computation run time.
@@ -142,6 +215,34 @@ func BeaconCommittee(
 	return computeCommittee(validatorIndices, seed, indexOffset, count)
 }
 
+// EpochCommittees returns all the beacon committees of an epoch, from the active validator indices of the
+// epoch and its attester seed, shuffling the validator set once rather than once per committee. The
+// committee of a slot and committee index is committees[slot % SLOTS_PER_EPOCH][index].
+func EpochCommittees(activeIndices []primitives.ValidatorIndex, seed [32]byte) ([][][]primitives.ValidatorIndex, error) {
+	if len(activeIndices) == 0 {
+		return nil, errors.New("no active validators")
+	}
+	shuffled := make([]primitives.ValidatorIndex, len(activeIndices))
+	copy(shuffled, activeIndices)
+	shuffled, err := UnshuffleList(shuffled, seed)
+	if err != nil {
+		return nil, err
+	}
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	perSlot := SlotCommitteeCount(uint64(len(shuffled)))
+	count := perSlot * slotsPerEpoch
+	n := uint64(len(shuffled))
+	committees := make([][][]primitives.ValidatorIndex, slotsPerEpoch)
+	for s := uint64(0); s < slotsPerEpoch; s++ {
+		committees[s] = make([][]primitives.ValidatorIndex, perSlot)
+		for i := uint64(0); i < perSlot; i++ {
+			index := s*perSlot + i
+			committees[s][i] = shuffled[slice.SplitOffset(n, count, index):slice.SplitOffset(n, count, index+1)]
+		}
+	}
+	return committees, nil
+}
+
 // CommitteeAssignmentContainer represents a committee list, committee index, and to be attested slot for a given epoch.
 type CommitteeAssignmentContainer struct {
 	Committee      []primitives.ValidatorIndex
@@ -383,6 +484,7 @@ func ClearCache() {
 	proposerIndicesCache.Clear()
 	syncCommitteeCache.Clear()
 	balanceCache.Clear()
+	committeePublicKeyCache.Clear()
 }
 
 // computeCommittee returns the requested shuffled committee out of the total committees using
@@ -79,6 +79,31 @@ func TestComputeCommittee_RegressionTest(t *testing.T) {
 	require.ErrorContains(t, "index out of range", err)
 }
 
+func TestEpochCommittees(t *testing.T) {
+	ClearCache()
+	indices := make([]primitives.ValidatorIndex, 2*params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().TargetCommitteeSize)+7)
+	for i := range indices {
+		indices[i] = primitives.ValidatorIndex(3 * i)
+	}
+	seed := bytesutil.ToBytes32([]byte("seed"))
+	committees, err := EpochCommittees(indices, seed)
+	require.NoError(t, err)
+	require.Equal(t, int(params.BeaconConfig().SlotsPerEpoch), len(committees))
+	perSlot := SlotCommitteeCount(uint64(len(indices)))
+	count := perSlot * uint64(params.BeaconConfig().SlotsPerEpoch)
+	for s := range committees {
+		require.Equal(t, int(perSlot), len(committees[s]))
+		for i := range committees[s] {
+			want, err := computeCommittee(indices, seed, uint64(s)*perSlot+uint64(i), count)
+			require.NoError(t, err)
+			assert.DeepEqual(t, want, committees[s][i])
+		}
+	}
+
+	_, err = EpochCommittees(nil, seed)
+	require.ErrorContains(t, "no active validators", err)
+}
+
 func TestVerifyBitfieldLength_OK(t *testing.T) {
 	bf := bitfield.Bitlist{0xFF, 0x01}
 	committeeSize := uint64(8)
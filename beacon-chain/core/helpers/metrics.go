@@ -14,4 +14,8 @@ var (
 		Name: "attestation_too_late_total",
 		Help: "Increased when an attestation is considered too late",
 	})
+	pubkeyAdditionsSaved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "attesting_pubkey_additions_saved_total",
+		Help: "The number of public key additions saved by subtracting absent members from cached committee public keys",
+	})
 )
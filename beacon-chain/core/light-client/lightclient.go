@@ -0,0 +1,489 @@
+package light_client
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+const (
+	// nextSyncCommitteeBranchDepth is floorlog2(NEXT_SYNC_COMMITTEE_INDEX).
+	nextSyncCommitteeBranchDepth = 5
+	// finalityBranchDepth is floorlog2(FINALIZED_ROOT_INDEX).
+	finalityBranchDepth = 6
+	// executionBranchDepth is floorlog2(EXECUTION_PAYLOAD_INDEX).
+	executionBranchDepth = 4
+	// executionPayloadIndex is EXECUTION_PAYLOAD_INDEX, the generalized index of the execution payload in
+	// the block body.
+	executionPayloadIndex = 25
+)
+
+// IsSupportedVersion returns true if light client data is created for blocks of the given version,
+// which is the case from Altair on.
+func IsSupportedVersion(v int) bool {
+	return v >= version.Altair
+}
+
+// SyncCommitteePeriodAtSlot returns the sync committee period of the given slot.
+func SyncCommitteePeriodAtSlot(slot primitives.Slot) uint64 {
+	return slots.SyncCommitteePeriod(slots.ToEpoch(slot))
+}
+
+// NewLightClientBootstrapFromBeaconState creates the light client bootstrap of the given block
+// from its post-state.
+//
+// Spec pseudocode definition:
+//
+//	def create_light_client_bootstrap(state: BeaconState,
+//	                                  block: SignedBeaconBlock) -> LightClientBootstrap:
+//	    assert compute_epoch_at_slot(state.slot) >= ALTAIR_FORK_EPOCH
+//
+//	    assert state.slot == state.latest_block_header.slot
+//	    header = state.latest_block_header.copy()
+//	    header.state_root = hash_tree_root(state)
+//	    assert hash_tree_root(header) == hash_tree_root(block.message)
+//
+//	    return LightClientBootstrap(
+//	        header=block_to_light_client_header(block),
+//	        current_sync_committee=state.current_sync_committee,
+//	        current_sync_committee_branch=compute_merkle_proof_for_state(state, CURRENT_SYNC_COMMITTEE_INDEX),
+//	    )
+func NewLightClientBootstrapFromBeaconState(
+	ctx context.Context,
+	st state.BeaconState,
+	block interfaces.ReadOnlySignedBeaconBlock,
+) (*Bootstrap, error) {
+	if !IsSupportedVersion(st.Version()) || !IsSupportedVersion(block.Version()) {
+		return nil, errors.New("light client bootstrap is only supported from Altair on")
+	}
+	if st.Slot() != block.Block().Slot() {
+		return nil, errors.Errorf("state slot %d is not the block slot %d", st.Slot(), block.Block().Slot())
+	}
+	header, err := lightClientHeader(block)
+	if err != nil {
+		return nil, err
+	}
+	stateRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute state root")
+	}
+	if !bytes.Equal(stateRoot[:], header.Beacon.StateRoot) {
+		return nil, errors.New("state is not the post-state of the block")
+	}
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee")
+	}
+	branch, err := st.CurrentSyncCommitteeProof(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute current sync committee proof")
+	}
+	return &Bootstrap{
+		Header:                     header,
+		CurrentSyncCommittee:       committee,
+		CurrentSyncCommitteeBranch: branch,
+	}, nil
+}
+
+// NewLightClientUpdateFromBeaconState creates the light client update carried by the sync
+// aggregate of the given block. The attested block is the parent of the block, the attested
+// state its post-state, and the finalized block the block of the attested state's finalized
+// checkpoint, if available.
+//
+// Spec pseudocode definition:
+//
+//	def create_light_client_update(state: BeaconState,
+//	                               block: SignedBeaconBlock,
+//	                               attested_state: BeaconState,
+//	                               attested_block: SignedBeaconBlock,
+//	                               finalized_block: Optional[SignedBeaconBlock]) -> LightClientUpdate:
+//	    assert compute_epoch_at_slot(attested_state.slot) >= ALTAIR_FORK_EPOCH
+//	    assert sum(block.message.body.sync_aggregate.sync_committee_bits) >= MIN_SYNC_COMMITTEE_PARTICIPANTS
+//	    ...
+//	    update_signature_period = compute_sync_committee_period_at_slot(block.message.slot)
+//	    ...
+//	    update_attested_period = compute_sync_committee_period_at_slot(attested_block.message.slot)
+//
+//	    update = LightClientUpdate()
+//	    update.attested_header = block_to_light_client_header(attested_block)
+//
+//	    # `next_sync_committee` is only useful if the message is signed by the current sync committee
+//	    if update_attested_period == update_signature_period:
+//	        update.next_sync_committee = attested_state.next_sync_committee
+//	        update.next_sync_committee_branch = compute_merkle_proof_for_state(
+//	            attested_state, NEXT_SYNC_COMMITTEE_INDEX)
+//
+//	    # Indicate finality whenever possible
+//	    if finalized_block is not None:
+//	        if finalized_block.message.slot != GENESIS_SLOT:
+//	            update.finalized_header = block_to_light_client_header(finalized_block)
+//	            assert hash_tree_root(update.finalized_header.beacon) == attested_state.finalized_checkpoint.root
+//	        else:
+//	            assert attested_state.finalized_checkpoint.root == Bytes32()
+//	        update.finality_branch = compute_merkle_proof_for_state(
+//	            attested_state, FINALIZED_ROOT_INDEX)
+//
+//	    update.sync_aggregate = block.message.body.sync_aggregate
+//	    update.signature_slot = block.message.slot
+//
+//	    return update
+func NewLightClientUpdateFromBeaconState(
+	ctx context.Context,
+	block interfaces.ReadOnlySignedBeaconBlock,
+	attestedState state.BeaconState,
+	attestedBlock interfaces.ReadOnlySignedBeaconBlock,
+	finalizedBlock interfaces.ReadOnlySignedBeaconBlock,
+) (*Update, error) {
+	if !IsSupportedVersion(attestedState.Version()) || !IsSupportedVersion(block.Version()) ||
+		!IsSupportedVersion(attestedBlock.Version()) {
+		return nil, errors.New("light client updates are only supported from Altair on")
+	}
+	syncAggregate, err := block.Block().Body().SyncAggregate()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync aggregate")
+	}
+	if syncAggregate.SyncCommitteeBits.Count() < params.BeaconConfig().MinSyncCommitteeParticipants {
+		return nil, errors.New("not enough sync committee participants")
+	}
+	if attestedState.Slot() != attestedBlock.Block().Slot() {
+		return nil, errors.Errorf("attested state slot %d is not the attested block slot %d", attestedState.Slot(), attestedBlock.Block().Slot())
+	}
+	attestedHeader, err := lightClientHeader(attestedBlock)
+	if err != nil {
+		return nil, err
+	}
+	attestedRoot, err := attestedHeader.Beacon.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute attested header root")
+	}
+	parentRoot := block.Block().ParentRoot()
+	if attestedRoot != parentRoot {
+		return nil, errors.New("attested block is not the parent of the block")
+	}
+
+	update := &Update{
+		AttestedHeader:          attestedHeader,
+		NextSyncCommittee:       emptySyncCommittee(),
+		NextSyncCommitteeBranch: emptyBranch(nextSyncCommitteeBranchDepth),
+		FinalizedHeader:         emptyHeader(),
+		FinalityBranch:          emptyBranch(finalityBranchDepth),
+		SyncAggregate:           syncAggregate,
+		SignatureSlot:           block.Block().Slot(),
+	}
+
+	if SyncCommitteePeriodAtSlot(attestedBlock.Block().Slot()) == SyncCommitteePeriodAtSlot(block.Block().Slot()) {
+		update.NextSyncCommittee, err = attestedState.NextSyncCommittee()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get next sync committee")
+		}
+		update.NextSyncCommitteeBranch, err = attestedState.NextSyncCommitteeProof(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute next sync committee proof")
+		}
+	}
+
+	if finalizedBlock != nil && !finalizedBlock.IsNil() {
+		finalizedCheckpoint := attestedState.FinalizedCheckpoint()
+		if finalizedBlock.Block().Slot() != params.BeaconConfig().GenesisSlot {
+			update.FinalizedHeader, err = lightClientHeader(finalizedBlock)
+			if err != nil {
+				return nil, err
+			}
+			finalizedRoot, err := update.FinalizedHeader.Beacon.HashTreeRoot()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not compute finalized header root")
+			}
+			if !bytes.Equal(finalizedRoot[:], finalizedCheckpoint.Root) {
+				return nil, errors.New("finalized block is not the finalized checkpoint block of the attested state")
+			}
+		} else if !bytes.Equal(finalizedCheckpoint.Root, params.BeaconConfig().ZeroHash[:]) {
+			return nil, errors.New("finalized checkpoint of the attested state is not the genesis checkpoint")
+		}
+		update.FinalityBranch, err = attestedState.FinalizedRootProof(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute finalized root proof")
+		}
+	}
+	return update, nil
+}
+
+// NewLightClientFinalityUpdateFromUpdate returns the finality update derived from the given update.
+func NewLightClientFinalityUpdateFromUpdate(update *Update) *FinalityUpdate {
+	return &FinalityUpdate{
+		AttestedHeader:  update.AttestedHeader,
+		FinalizedHeader: update.FinalizedHeader,
+		FinalityBranch:  update.FinalityBranch,
+		SyncAggregate:   update.SyncAggregate,
+		SignatureSlot:   update.SignatureSlot,
+	}
+}
+
+// NewLightClientOptimisticUpdateFromUpdate returns the optimistic update derived from the given update.
+func NewLightClientOptimisticUpdateFromUpdate(update *Update) *OptimisticUpdate {
+	return &OptimisticUpdate{
+		AttestedHeader: update.AttestedHeader,
+		SyncAggregate:  update.SyncAggregate,
+		SignatureSlot:  update.SignatureSlot,
+	}
+}
+
+// IsSyncCommitteeUpdate returns true if the update carries the next sync committee.
+func IsSyncCommitteeUpdate(update *Update) bool {
+	return !isEmptyBranch(update.NextSyncCommitteeBranch)
+}
+
+// IsFinalityUpdate returns true if the update carries a finalized header.
+func IsFinalityUpdate(update *Update) bool {
+	return !isEmptyBranch(update.FinalityBranch)
+}
+
+// IsBetterUpdate returns true if the new update is to be preferred over the old one
+// as the best update of a sync committee period.
+//
+// Spec pseudocode definition:
+//
+//	def is_better_update(new_update: LightClientUpdate, old_update: LightClientUpdate) -> bool:
+//	    # Compare supermajority (> 2/3) sync committee participation
+//	    max_active_participants = len(new_update.sync_aggregate.sync_committee_bits)
+//	    new_num_active_participants = sum(new_update.sync_aggregate.sync_committee_bits)
+//	    old_num_active_participants = sum(old_update.sync_aggregate.sync_committee_bits)
+//	    new_has_supermajority = new_num_active_participants * 3 >= max_active_participants * 2
+//	    old_has_supermajority = old_num_active_participants * 3 >= max_active_participants * 2
+//	    if new_has_supermajority != old_has_supermajority:
+//	        return new_has_supermajority > old_has_supermajority
+//	    if not new_has_supermajority and new_num_active_participants != old_num_active_participants:
+//	        return new_num_active_participants > old_num_active_participants
+//
+//	    # Compare presence of relevant sync committee
+//	    new_has_relevant_sync_committee = is_sync_committee_update(new_update) and (
+//	        compute_sync_committee_period_at_slot(new_update.attested_header.beacon.slot)
+//	        == compute_sync_committee_period_at_slot(new_update.signature_slot)
+//	    )
+//	    old_has_relevant_sync_committee = ...
+//	    if new_has_relevant_sync_committee != old_has_relevant_sync_committee:
+//	        return new_has_relevant_sync_committee
+//
+//	    # Compare indication of any finality
+//	    new_has_finality = is_finality_update(new_update)
+//	    old_has_finality = is_finality_update(old_update)
+//	    if new_has_finality != old_has_finality:
+//	        return new_has_finality
+//
+//	    # Compare sync committee finality
+//	    if new_has_finality:
+//	        new_has_sync_committee_finality = (
+//	            compute_sync_committee_period_at_slot(new_update.finalized_header.beacon.slot)
+//	            == compute_sync_committee_period_at_slot(new_update.attested_header.beacon.slot)
+//	        )
+//	        old_has_sync_committee_finality = ...
+//	        if new_has_sync_committee_finality != old_has_sync_committee_finality:
+//	            return new_has_sync_committee_finality
+//
+//	    # Tiebreaker 1: Sync committee participation beyond supermajority
+//	    if new_num_active_participants != old_num_active_participants:
+//	        return new_num_active_participants > old_num_active_participants
+//
+//	    # Tiebreaker 2: Prefer older data (fewer changes to best)
+//	    if new_update.attested_header.beacon.slot != old_update.attested_header.beacon.slot:
+//	        return new_update.attested_header.beacon.slot < old_update.attested_header.beacon.slot
+//	    return new_update.signature_slot < old_update.signature_slot
+func IsBetterUpdate(newUpdate, oldUpdate *Update) bool {
+	maxActiveParticipants := newUpdate.SyncAggregate.SyncCommitteeBits.Len()
+	newNumActiveParticipants := newUpdate.SyncAggregate.SyncCommitteeBits.Count()
+	oldNumActiveParticipants := oldUpdate.SyncAggregate.SyncCommitteeBits.Count()
+	newHasSupermajority := newNumActiveParticipants*3 >= maxActiveParticipants*2
+	oldHasSupermajority := oldNumActiveParticipants*3 >= maxActiveParticipants*2
+	if newHasSupermajority != oldHasSupermajority {
+		return newHasSupermajority
+	}
+	if !newHasSupermajority && newNumActiveParticipants != oldNumActiveParticipants {
+		return newNumActiveParticipants > oldNumActiveParticipants
+	}
+
+	newHasRelevantSyncCommittee := hasRelevantSyncCommittee(newUpdate)
+	oldHasRelevantSyncCommittee := hasRelevantSyncCommittee(oldUpdate)
+	if newHasRelevantSyncCommittee != oldHasRelevantSyncCommittee {
+		return newHasRelevantSyncCommittee
+	}
+
+	newHasFinality := IsFinalityUpdate(newUpdate)
+	oldHasFinality := IsFinalityUpdate(oldUpdate)
+	if newHasFinality != oldHasFinality {
+		return newHasFinality
+	}
+
+	if newHasFinality {
+		newHasSyncCommitteeFinality := hasSyncCommitteeFinality(newUpdate)
+		oldHasSyncCommitteeFinality := hasSyncCommitteeFinality(oldUpdate)
+		if newHasSyncCommitteeFinality != oldHasSyncCommitteeFinality {
+			return newHasSyncCommitteeFinality
+		}
+	}
+
+	if newNumActiveParticipants != oldNumActiveParticipants {
+		return newNumActiveParticipants > oldNumActiveParticipants
+	}
+
+	if newUpdate.AttestedHeader.Beacon.Slot != oldUpdate.AttestedHeader.Beacon.Slot {
+		return newUpdate.AttestedHeader.Beacon.Slot < oldUpdate.AttestedHeader.Beacon.Slot
+	}
+	return newUpdate.SignatureSlot < oldUpdate.SignatureSlot
+}
+
+func hasRelevantSyncCommittee(update *Update) bool {
+	return IsSyncCommitteeUpdate(update) &&
+		SyncCommitteePeriodAtSlot(update.AttestedHeader.Beacon.Slot) == SyncCommitteePeriodAtSlot(update.SignatureSlot)
+}
+
+func hasSyncCommitteeFinality(update *Update) bool {
+	return SyncCommitteePeriodAtSlot(update.FinalizedHeader.Beacon.Slot) == SyncCommitteePeriodAtSlot(update.AttestedHeader.Beacon.Slot)
+}
+
+// lightClientHeader returns the light client header of the given block.
+//
+// Spec pseudocode definition:
+//
+//	def block_to_light_client_header(block: SignedBeaconBlock) -> LightClientHeader:
+//	    epoch = compute_epoch_at_slot(block.message.slot)
+//
+//	    if epoch >= CAPELLA_FORK_EPOCH:
+//	        payload = block.message.body.execution_payload
+//	        execution_header = ExecutionPayloadHeader(
+//	            parent_hash=payload.parent_hash,
+//	            ...
+//	        )
+//	        execution_branch = compute_merkle_proof_for_block_body(block.message.body, EXECUTION_PAYLOAD_INDEX)
+//	    else:
+//	        # Note that during fork transitions, `finalized_header` may still point to earlier forks.
+//	        # While Bellatrix blocks also contain an `ExecutionPayload` (minus `withdrawals_root`),
+//	        # it was not included in the corresponding light client data. To ensure compatibility
+//	        # with legacy data going through `upgrade_lc_header_to_capella`, leave out execution data.
+//	        execution_header = ExecutionPayloadHeader()
+//	        execution_branch = [Bytes32() for _ in range(floorlog2(EXECUTION_PAYLOAD_INDEX))]
+//
+//	    return LightClientHeader(
+//	        beacon=BeaconBlockHeader(...),
+//	        execution=execution_header,
+//	        execution_branch=execution_branch,
+//	    )
+func lightClientHeader(block interfaces.ReadOnlySignedBeaconBlock) (*Header, error) {
+	header, err := block.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block header")
+	}
+	if block.Version() < version.Capella {
+		return &Header{Beacon: header.Header}, nil
+	}
+	execution, err := executionPayloadHeader(block)
+	if err != nil {
+		return nil, err
+	}
+	body, err := block.Block().Body().Proto()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block body")
+	}
+	proof, err := ssz.ProveGeneralizedIndex(body, executionPayloadIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute execution payload proof")
+	}
+	branch := make([][]byte, len(proof.Branch))
+	for i := range proof.Branch {
+		branch[i] = bytesutil.SafeCopyBytes(proof.Branch[i][:])
+	}
+	return &Header{Beacon: header.Header, Execution: execution, ExecutionBranch: branch}, nil
+}
+
+// executionPayloadHeader returns the header of the execution payload of the given block, from Capella on.
+func executionPayloadHeader(block interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, error) {
+	payload, err := block.Block().Body().Execution()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get execution payload")
+	}
+	if payload.IsBlinded() {
+		return payload, nil
+	}
+	switch block.Version() {
+	case version.Capella:
+		h, err := blocks.PayloadToHeaderCapella(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute execution payload header")
+		}
+		return blocks.WrappedExecutionPayloadHeaderCapella(h, 0)
+	case version.Deneb:
+		h, err := blocks.PayloadToHeaderDeneb(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute execution payload header")
+		}
+		return blocks.WrappedExecutionPayloadHeaderDeneb(h, 0)
+	default:
+		return nil, errors.Errorf("no execution payload header for block version %s", version.String(block.Version()))
+	}
+}
+
+// emptyHeader returns the default light client header, used when an update indicates no finality.
+func emptyHeader() *Header {
+	return &Header{
+		Beacon: &ethpb.BeaconBlockHeader{
+			ParentRoot: make([]byte, fieldparams.RootLength),
+			StateRoot:  make([]byte, fieldparams.RootLength),
+			BodyRoot:   make([]byte, fieldparams.RootLength),
+		},
+	}
+}
+
+func emptyExecutionPayloadHeaderCapella() *enginev1.ExecutionPayloadHeaderCapella {
+	return &enginev1.ExecutionPayloadHeaderCapella{
+		ParentHash:       make([]byte, fieldparams.RootLength),
+		FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+		StateRoot:        make([]byte, fieldparams.RootLength),
+		ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+		LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+		PrevRandao:       make([]byte, fieldparams.RootLength),
+		ExtraData:        make([]byte, 0),
+		BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+		BlockHash:        make([]byte, fieldparams.RootLength),
+		TransactionsRoot: make([]byte, fieldparams.RootLength),
+		WithdrawalsRoot:  make([]byte, fieldparams.RootLength),
+	}
+}
+
+func emptySyncCommittee() *ethpb.SyncCommittee {
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	return &ethpb.SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+	}
+}
+
+func emptyBranch(depth int) [][]byte {
+	branch := make([][]byte, depth)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return branch
+}
+
+func isEmptyBranch(branch [][]byte) bool {
+	for _, node := range branch {
+		if !bytes.Equal(node, params.BeaconConfig().ZeroHash[:]) {
+			return false
+		}
+	}
+	return true
+}
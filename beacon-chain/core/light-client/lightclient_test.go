@@ -0,0 +1,285 @@
+package light_client_test
+
+import (
+	"context"
+	"testing"
+
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestNewLightClientBootstrapFromBeaconState(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateAltair(t, 32)
+	require.NoError(t, st.SetSlot(1))
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	blk := altairBlock(t, 1, [32]byte{}, stateRoot[:], 0)
+
+	bootstrap, err := lightclient.NewLightClientBootstrapFromBeaconState(ctx, st, blk)
+	require.NoError(t, err)
+	header, err := blk.Header()
+	require.NoError(t, err)
+	assert.DeepEqual(t, header.Header, bootstrap.Header.Beacon)
+	committee, err := st.CurrentSyncCommittee()
+	require.NoError(t, err)
+	assert.DeepEqual(t, committee, bootstrap.CurrentSyncCommittee)
+	assert.Equal(t, 5, len(bootstrap.CurrentSyncCommitteeBranch))
+
+	// The state must be the post-state of the block.
+	blk = altairBlock(t, 1, [32]byte{}, make([]byte, 32), 0)
+	_, err = lightclient.NewLightClientBootstrapFromBeaconState(ctx, st, blk)
+	require.ErrorContains(t, "state is not the post-state of the block", err)
+}
+
+func TestNewLightClientUpdateFromBeaconState(t *testing.T) {
+	setForkEpochs(t)
+	ctx := context.Background()
+	attestedState, attestedBlock := attestedStateAndBlock(t, 2)
+	attestedRoot, err := attestedBlock.Block().HashTreeRoot()
+	require.NoError(t, err)
+	blk := altairBlock(t, 3, attestedRoot, make([]byte, 32), 10)
+
+	update, err := lightclient.NewLightClientUpdateFromBeaconState(ctx, blk, attestedState, attestedBlock, nil)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(3), update.SignatureSlot)
+	assert.Equal(t, primitives.Slot(2), update.AttestedHeader.Beacon.Slot)
+	assert.Equal(t, true, lightclient.IsSyncCommitteeUpdate(update))
+	assert.Equal(t, false, lightclient.IsFinalityUpdate(update))
+	nextCommittee, err := attestedState.NextSyncCommittee()
+	require.NoError(t, err)
+	assert.DeepEqual(t, nextCommittee, update.NextSyncCommittee)
+	// The update must be SSZ serializable to be stored and served.
+	m, err := update.Proto()
+	require.NoError(t, err)
+	_, err = m.MarshalSSZ()
+	require.NoError(t, err)
+
+	// A finalized block matching the attested state's checkpoint yields a finality update.
+	finalizedBlock := altairBlock(t, 1, [32]byte{}, make([]byte, 32), 0)
+	finalizedRoot, err := finalizedBlock.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, attestedState.SetFinalizedCheckpoint(&ethpb.Checkpoint{Root: finalizedRoot[:]}))
+	attestedBlock = attestedBlockOf(t, attestedState)
+	attestedRoot, err = attestedBlock.Block().HashTreeRoot()
+	require.NoError(t, err)
+	blk = altairBlock(t, 3, attestedRoot, make([]byte, 32), 10)
+	update, err = lightclient.NewLightClientUpdateFromBeaconState(ctx, blk, attestedState, attestedBlock, finalizedBlock)
+	require.NoError(t, err)
+	assert.Equal(t, true, lightclient.IsFinalityUpdate(update))
+	assert.Equal(t, primitives.Slot(1), update.FinalizedHeader.Beacon.Slot)
+
+	finalityUpdate := lightclient.NewLightClientFinalityUpdateFromUpdate(update)
+	assert.DeepEqual(t, update.FinalizedHeader, finalityUpdate.FinalizedHeader)
+	optimisticUpdate := lightclient.NewLightClientOptimisticUpdateFromUpdate(update)
+	assert.DeepEqual(t, update.AttestedHeader, optimisticUpdate.AttestedHeader)
+
+	// The next sync committee is omitted when the update is signed in the next period.
+	nextPeriodSlot := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod))
+	blk = altairBlock(t, nextPeriodSlot, attestedRoot, make([]byte, 32), 10)
+	update, err = lightclient.NewLightClientUpdateFromBeaconState(ctx, blk, attestedState, attestedBlock, nil)
+	require.NoError(t, err)
+	assert.Equal(t, false, lightclient.IsSyncCommitteeUpdate(update))
+
+	// The block must be a child of the attested block.
+	blk = altairBlock(t, 3, [32]byte{'a'}, make([]byte, 32), 10)
+	_, err = lightclient.NewLightClientUpdateFromBeaconState(ctx, blk, attestedState, attestedBlock, nil)
+	require.ErrorContains(t, "attested block is not the parent of the block", err)
+
+	// The block must carry sync committee signatures.
+	blk = altairBlock(t, 3, attestedRoot, make([]byte, 32), 0)
+	_, err = lightclient.NewLightClientUpdateFromBeaconState(ctx, blk, attestedState, attestedBlock, nil)
+	require.ErrorContains(t, "not enough sync committee participants", err)
+}
+
+func TestNewLightClientUpdateFromBeaconState_Capella(t *testing.T) {
+	setForkEpochs(t)
+	ctx := context.Background()
+	attestedState, _ := util.DeterministicGenesisStateCapella(t, 32)
+	require.NoError(t, attestedState.SetSlot(params.BeaconConfig().SlotsPerEpoch+1))
+	attestedBlock := attestedBlockOf(t, attestedState)
+	attestedRoot, err := attestedBlock.Block().HashTreeRoot()
+	require.NoError(t, err)
+	blk := capellaBlock(t, attestedState.Slot()+1, attestedRoot, make([]byte, 32), 10)
+	genesisBlock := altairBlock(t, 0, [32]byte{}, make([]byte, 32), 0)
+
+	update, err := lightclient.NewLightClientUpdateFromBeaconState(ctx, blk, attestedState, attestedBlock, genesisBlock)
+	require.NoError(t, err)
+	assert.Equal(t, version.Capella, update.Version())
+	payload, err := attestedBlock.Block().Body().Execution()
+	require.NoError(t, err)
+	require.NotNil(t, update.AttestedHeader.Execution)
+	assert.DeepEqual(t, payload.BlockHash(), update.AttestedHeader.Execution.BlockHash())
+	assert.Equal(t, 4, len(update.AttestedHeader.ExecutionBranch))
+	// The genesis finalized header has no execution payload header.
+	assert.Equal(t, true, update.FinalizedHeader.Execution == nil)
+
+	// The update is served in the Capella container, and decodes back to the same update.
+	m, err := update.Proto()
+	require.NoError(t, err)
+	c, ok := m.(*ethpb.LightClientUpdateCapella)
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, make([]byte, 32), c.FinalizedHeader.Execution.BlockHash)
+	enc, err := m.MarshalSSZ()
+	require.NoError(t, err)
+	decoded, err := lightclient.UnmarshalUpdate(version.Capella, enc)
+	require.NoError(t, err)
+	assert.DeepEqual(t, update.AttestedHeader.ExecutionBranch, decoded.AttestedHeader.ExecutionBranch)
+	assert.Equal(t, true, decoded.FinalizedHeader.Execution == nil)
+	root, err := decoded.AttestedHeader.Execution.HashTreeRoot()
+	require.NoError(t, err)
+	wantRoot, err := update.AttestedHeader.Execution.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, root)
+}
+
+func TestIsBetterUpdate(t *testing.T) {
+	syncCommitteeSize := params.BeaconConfig().SyncCommitteeSize
+	periodSlots := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod))
+	newUpdate := func(participants uint64, attestedSlot, signatureSlot primitives.Slot, syncCommittee, finality bool) *lightclient.Update {
+		u := util.HydrateLightClientUpdate(&lightclient.Update{SignatureSlot: signatureSlot})
+		u.AttestedHeader.Beacon.Slot = attestedSlot
+		for i := uint64(0); i < participants; i++ {
+			u.SyncAggregate.SyncCommitteeBits.SetBitAt(i, true)
+		}
+		if syncCommittee {
+			u.NextSyncCommitteeBranch[0][0] = 1
+		}
+		if finality {
+			u.FinalityBranch[0][0] = 1
+		}
+		return u
+	}
+
+	tests := []struct {
+		name      string
+		newUpdate *lightclient.Update
+		oldUpdate *lightclient.Update
+		want      bool
+	}{
+		{
+			name:      "supermajority wins",
+			newUpdate: newUpdate(syncCommitteeSize, 1, 2, false, false),
+			oldUpdate: newUpdate(syncCommitteeSize/2, 1, 2, true, true),
+			want:      true,
+		},
+		{
+			name:      "more participants without supermajority",
+			newUpdate: newUpdate(10, 1, 2, false, false),
+			oldUpdate: newUpdate(20, 1, 2, true, true),
+			want:      false,
+		},
+		{
+			name:      "relevant sync committee",
+			newUpdate: newUpdate(syncCommitteeSize, 1, 2, true, false),
+			oldUpdate: newUpdate(syncCommitteeSize, 1, 2, false, true),
+			want:      true,
+		},
+		{
+			name:      "sync committee of another period is not relevant",
+			newUpdate: newUpdate(syncCommitteeSize, 1, periodSlots, true, false),
+			oldUpdate: newUpdate(syncCommitteeSize, 1, 2, false, true),
+			want:      false,
+		},
+		{
+			name:      "finality",
+			newUpdate: newUpdate(syncCommitteeSize, 1, 2, true, true),
+			oldUpdate: newUpdate(syncCommitteeSize, 1, 2, true, false),
+			want:      true,
+		},
+		{
+			name:      "more participants beyond supermajority",
+			newUpdate: newUpdate(syncCommitteeSize-1, 1, 2, true, true),
+			oldUpdate: newUpdate(syncCommitteeSize, 1, 2, true, true),
+			want:      false,
+		},
+		{
+			name:      "older attested header",
+			newUpdate: newUpdate(syncCommitteeSize, 1, 3, true, true),
+			oldUpdate: newUpdate(syncCommitteeSize, 2, 3, true, true),
+			want:      true,
+		},
+		{
+			name:      "older signature slot",
+			newUpdate: newUpdate(syncCommitteeSize, 1, 3, true, true),
+			oldUpdate: newUpdate(syncCommitteeSize, 1, 2, true, true),
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, lightclient.IsBetterUpdate(tt.newUpdate, tt.oldUpdate))
+		})
+	}
+}
+
+func attestedStateAndBlock(t *testing.T, slot primitives.Slot) (state.BeaconState, interfaces.ReadOnlySignedBeaconBlock) {
+	st, _ := util.DeterministicGenesisStateAltair(t, 32)
+	require.NoError(t, st.SetSlot(slot))
+	return st, attestedBlockOf(t, st)
+}
+
+// attestedBlockOf returns a block of the fork of the given state at its slot, committing to its current root.
+func attestedBlockOf(t *testing.T, st state.BeaconState) interfaces.ReadOnlySignedBeaconBlock {
+	stateRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	if st.Version() >= version.Capella {
+		return capellaBlock(t, st.Slot(), [32]byte{}, stateRoot[:], 0)
+	}
+	return altairBlock(t, st.Slot(), [32]byte{}, stateRoot[:], 0)
+}
+
+// setForkEpochs schedules Altair and Bellatrix at genesis, Capella at epoch 1 and Deneb at epoch 2, so that the
+// fork of the light client data of the test blocks follows their slots.
+func setForkEpochs(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 0
+	cfg.CapellaForkEpoch = 1
+	cfg.DenebForkEpoch = 2
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+}
+
+func altairBlock(
+	t *testing.T, slot primitives.Slot, parentRoot [32]byte, stateRoot []byte, participants uint64,
+) interfaces.ReadOnlySignedBeaconBlock {
+	b := util.NewBeaconBlockAltair()
+	b.Block.Slot = slot
+	b.Block.ParentRoot = parentRoot[:]
+	b.Block.StateRoot = stateRoot
+	for i := uint64(0); i < participants; i++ {
+		b.Block.Body.SyncAggregate.SyncCommitteeBits.SetBitAt(i, true)
+	}
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	return blk
+}
+
+func capellaBlock(
+	t *testing.T, slot primitives.Slot, parentRoot [32]byte, stateRoot []byte, participants uint64,
+) interfaces.ReadOnlySignedBeaconBlock {
+	b := util.NewBeaconBlockCapella()
+	b.Block.Slot = slot
+	b.Block.ParentRoot = parentRoot[:]
+	b.Block.StateRoot = stateRoot
+	b.Block.Body.ExecutionPayload.BlockNumber = uint64(slot)
+	b.Block.Body.ExecutionPayload.BlockHash = bytesutil.PadTo([]byte{byte(slot)}, 32)
+	for i := uint64(0); i < participants; i++ {
+		b.Block.Body.SyncAggregate.SyncCommitteeBits.SetBitAt(i, true)
+	}
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	return blk
+}
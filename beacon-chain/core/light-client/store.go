@@ -0,0 +1,397 @@
+package light_client
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// currentSyncCommitteeSubtreeIndex is get_subtree_index(CURRENT_SYNC_COMMITTEE_INDEX).
+	currentSyncCommitteeSubtreeIndex = 22
+	// nextSyncCommitteeSubtreeIndex is get_subtree_index(NEXT_SYNC_COMMITTEE_INDEX).
+	nextSyncCommitteeSubtreeIndex = 23
+	// finalizedRootSubtreeIndex is get_subtree_index(FINALIZED_ROOT_INDEX).
+	finalizedRootSubtreeIndex = 41
+	// executionPayloadSubtreeIndex is get_subtree_index(EXECUTION_PAYLOAD_INDEX).
+	executionPayloadSubtreeIndex = 9
+)
+
+var (
+	// ErrInvalidBootstrap is returned when a bootstrap does not match the trusted block root.
+	ErrInvalidBootstrap = errors.New("invalid light client bootstrap")
+	// ErrInvalidUpdate is returned when the proofs or the signature of an update are invalid.
+	ErrInvalidUpdate = errors.New("invalid light client update")
+	// ErrIrrelevantUpdate is returned when an update cannot advance the store, for instance because it is
+	// not signed by a supermajority or is older than the finalized header of the store.
+	ErrIrrelevantUpdate = errors.New("irrelevant light client update")
+)
+
+// Store tracks the finalized header and the sync committees of the chain, as verified from a trusted
+// bootstrap and the light client updates applied to it since. Unlike the spec store, it only follows
+// updates signed by a supermajority of the sync committee and never forces an update, as it is meant to
+// verify data served by untrusted providers rather than to follow the head.
+type Store struct {
+	finalizedHeader       *Header
+	currentSyncCommittee  *ethpb.SyncCommittee
+	nextSyncCommittee     *ethpb.SyncCommittee
+	genesisValidatorsRoot [32]byte
+}
+
+// NewStore initializes a store from the bootstrap of the block with the given trusted root.
+//
+// Spec pseudocode definition:
+//
+//	def initialize_light_client_store(trusted_block_root: Root,
+//	                                  bootstrap: LightClientBootstrap) -> LightClientStore:
+//	    assert is_valid_light_client_header(bootstrap.header)
+//	    assert hash_tree_root(bootstrap.header.beacon) == trusted_block_root
+//
+//	    assert is_valid_merkle_branch(
+//	        leaf=hash_tree_root(bootstrap.current_sync_committee),
+//	        branch=bootstrap.current_sync_committee_branch,
+//	        depth=floorlog2(CURRENT_SYNC_COMMITTEE_INDEX),
+//	        index=get_subtree_index(CURRENT_SYNC_COMMITTEE_INDEX),
+//	        root=bootstrap.header.beacon.state_root,
+//	    )
+//	    ...
+func NewStore(trustedBlockRoot [32]byte, bootstrap *Bootstrap, genesisValidatorsRoot [32]byte) (*Store, error) {
+	if err := validateHeader(bootstrap.Header); err != nil {
+		return nil, errors.Wrapf(ErrInvalidBootstrap, "invalid header: %v", err)
+	}
+	root, err := bootstrap.Header.Beacon.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute bootstrap header root")
+	}
+	if root != trustedBlockRoot {
+		return nil, errors.Wrapf(ErrInvalidBootstrap, "header root %#x is not the trusted block root %#x", root, trustedBlockRoot)
+	}
+	committeeRoot, err := bootstrap.CurrentSyncCommittee.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute current sync committee root")
+	}
+	if !trie.VerifyMerkleProof(bootstrap.Header.Beacon.StateRoot, committeeRoot[:], currentSyncCommitteeSubtreeIndex, bootstrap.CurrentSyncCommitteeBranch) {
+		return nil, errors.Wrap(ErrInvalidBootstrap, "invalid current sync committee branch")
+	}
+	return &Store{
+		finalizedHeader:       bootstrap.Header,
+		currentSyncCommittee:  bootstrap.CurrentSyncCommittee,
+		genesisValidatorsRoot: genesisValidatorsRoot,
+	}, nil
+}
+
+// FinalizedHeader returns the latest finalized header verified by the store.
+func (s *Store) FinalizedHeader() *Header {
+	return s.finalizedHeader
+}
+
+// NextSyncCommitteeKnown returns true if the store knows the sync committee of the period after the
+// one of its finalized header.
+func (s *Store) NextSyncCommitteeKnown() bool {
+	return s.nextSyncCommittee != nil
+}
+
+// ProcessUpdate validates the given update against the store, and applies it if it advances the
+// finalized header or reveals the next sync committee.
+//
+// Spec pseudocode definition:
+//
+//	def process_light_client_update(store: LightClientStore,
+//	                                update: LightClientUpdate,
+//	                                current_slot: Slot,
+//	                                genesis_validators_root: Root) -> None:
+//	    validate_light_client_update(store, update, current_slot, genesis_validators_root)
+//	    ...
+//	    # Apply update if (1) supermajority and (2) either a finality update or a sync committee update
+//	    update_has_finalized_next_sync_committee = (
+//	        not is_next_sync_committee_known(store)
+//	        and is_sync_committee_update(update) and is_finality_update(update) and (
+//	            compute_sync_committee_period_at_slot(update.finalized_header.beacon.slot)
+//	            == compute_sync_committee_period_at_slot(update.attested_header.beacon.slot)
+//	        )
+//	    )
+//	    if (
+//	        sum(sync_committee_bits) * 3 >= len(sync_committee_bits) * 2
+//	        and (
+//	            update.finalized_header.beacon.slot > store.finalized_header.beacon.slot
+//	            or update_has_finalized_next_sync_committee
+//	        )
+//	    ):
+//	        # Normal update through 2/3 threshold
+//	        apply_light_client_update(store, update)
+func (s *Store) ProcessUpdate(update *Update) error {
+	if err := s.validateUpdate(update); err != nil {
+		return err
+	}
+	hasFinalizedNextSyncCommittee := !s.NextSyncCommitteeKnown() &&
+		IsSyncCommitteeUpdate(update) && IsFinalityUpdate(update) && hasSyncCommitteeFinality(update)
+	if update.FinalizedHeader.Beacon.Slot <= s.finalizedHeader.Beacon.Slot && !hasFinalizedNextSyncCommittee {
+		return nil
+	}
+	return s.applyUpdate(update)
+}
+
+// ProcessFinalityUpdate validates the given finality update against the store, and applies it if it
+// advances the finalized header.
+func (s *Store) ProcessFinalityUpdate(update *FinalityUpdate) error {
+	return s.ProcessUpdate(&Update{
+		AttestedHeader:          update.AttestedHeader,
+		NextSyncCommittee:       emptySyncCommittee(),
+		NextSyncCommitteeBranch: emptyBranch(nextSyncCommitteeBranchDepth),
+		FinalizedHeader:         update.FinalizedHeader,
+		FinalityBranch:          update.FinalityBranch,
+		SyncAggregate:           update.SyncAggregate,
+		SignatureSlot:           update.SignatureSlot,
+	})
+}
+
+// validateUpdate checks the given update is relevant to the store, that its headers and sync committee
+// are proven by the attested state, and that it is signed by a supermajority of the sync committee.
+//
+// Spec pseudocode definition:
+//
+//	def validate_light_client_update(store: LightClientStore,
+//	                                 update: LightClientUpdate,
+//	                                 current_slot: Slot,
+//	                                 genesis_validators_root: Root) -> None:
+//	    # Verify sync committee has sufficient participants
+//	    sync_aggregate = update.sync_aggregate
+//	    assert sum(sync_aggregate.sync_committee_bits) >= MIN_SYNC_COMMITTEE_PARTICIPANTS
+//
+//	    # Verify update does not skip a sync committee period
+//	    assert is_valid_light_client_header(update.attested_header)
+//	    assert current_slot >= update.signature_slot > update_attested_slot >= update_finalized_slot
+//	    store_period = compute_sync_committee_period_at_slot(store.finalized_header.beacon.slot)
+//	    update_signature_period = compute_sync_committee_period_at_slot(update.signature_slot)
+//	    if is_next_sync_committee_known(store):
+//	        assert update_signature_period in (store_period, store_period + 1)
+//	    else:
+//	        assert update_signature_period == store_period
+//
+//	    # Verify update is relevant
+//	    update_attested_period = compute_sync_committee_period_at_slot(update_attested_slot)
+//	    update_has_next_sync_committee = not is_next_sync_committee_known(store) and (
+//	        is_sync_committee_update(update) and update_attested_period == store_period
+//	    )
+//	    assert (
+//	        update_attested_slot > store.finalized_header.beacon.slot
+//	        or update_has_next_sync_committee
+//	    )
+//	    ...
+//	    # Verify sync committee aggregate signature
+//	    if update_signature_period == store_period:
+//	        sync_committee = store.current_sync_committee
+//	    else:
+//	        sync_committee = store.next_sync_committee
+//	    participant_pubkeys = [
+//	        pubkey for (bit, pubkey) in zip(sync_aggregate.sync_committee_bits, sync_committee.pubkeys)
+//	        if bit
+//	    ]
+//	    fork_version_slot = max(update.signature_slot, Slot(1)) - Slot(1)
+//	    fork_version = compute_fork_version(compute_epoch_at_slot(fork_version_slot))
+//	    domain = compute_domain(DOMAIN_SYNC_COMMITTEE, fork_version, genesis_validators_root)
+//	    signing_root = compute_signing_root(update.attested_header.beacon, domain)
+//	    assert bls.FastAggregateVerify(participant_pubkeys, signing_root, sync_aggregate.sync_committee_signature)
+func (s *Store) validateUpdate(update *Update) error {
+	bits := update.SyncAggregate.SyncCommitteeBits
+	if bits.Count()*3 < bits.Len()*2 {
+		return errors.Wrapf(ErrIrrelevantUpdate, "sync committee participation %d/%d is below supermajority", bits.Count(), bits.Len())
+	}
+
+	if err := validateHeader(update.AttestedHeader); err != nil {
+		return errors.Wrapf(ErrInvalidUpdate, "invalid attested header: %v", err)
+	}
+	attestedSlot := update.AttestedHeader.Beacon.Slot
+	finalizedSlot := update.FinalizedHeader.Beacon.Slot
+	if update.SignatureSlot <= attestedSlot || attestedSlot < finalizedSlot {
+		return errors.Wrapf(ErrInvalidUpdate, "inconsistent signature slot %d, attested slot %d and finalized slot %d",
+			update.SignatureSlot, attestedSlot, finalizedSlot)
+	}
+	storePeriod := SyncCommitteePeriodAtSlot(s.finalizedHeader.Beacon.Slot)
+	signaturePeriod := SyncCommitteePeriodAtSlot(update.SignatureSlot)
+	if signaturePeriod != storePeriod && (!s.NextSyncCommitteeKnown() || signaturePeriod != storePeriod+1) {
+		return errors.Wrapf(ErrIrrelevantUpdate, "signature period %d skips a sync committee period after %d", signaturePeriod, storePeriod)
+	}
+
+	attestedPeriod := SyncCommitteePeriodAtSlot(attestedSlot)
+	hasNextSyncCommittee := !s.NextSyncCommitteeKnown() && IsSyncCommitteeUpdate(update) && attestedPeriod == storePeriod
+	if attestedSlot <= s.finalizedHeader.Beacon.Slot && !hasNextSyncCommittee {
+		return errors.Wrapf(ErrIrrelevantUpdate, "attested slot %d is not after the finalized slot %d", attestedSlot, s.finalizedHeader.Beacon.Slot)
+	}
+
+	attestedStateRoot := update.AttestedHeader.Beacon.StateRoot
+	if IsFinalityUpdate(update) {
+		finalizedRoot := params.BeaconConfig().ZeroHash
+		if finalizedSlot != params.BeaconConfig().GenesisSlot {
+			if err := validateHeader(update.FinalizedHeader); err != nil {
+				return errors.Wrapf(ErrInvalidUpdate, "invalid finalized header: %v", err)
+			}
+			r, err := update.FinalizedHeader.Beacon.HashTreeRoot()
+			if err != nil {
+				return errors.Wrap(err, "could not compute finalized header root")
+			}
+			finalizedRoot = r
+		}
+		if !trie.VerifyMerkleProof(attestedStateRoot, finalizedRoot[:], finalizedRootSubtreeIndex, update.FinalityBranch) {
+			return errors.Wrap(ErrInvalidUpdate, "invalid finality branch")
+		}
+	} else if finalizedSlot != params.BeaconConfig().GenesisSlot {
+		return errors.Wrap(ErrInvalidUpdate, "finalized header without finality branch")
+	}
+
+	if IsSyncCommitteeUpdate(update) {
+		if attestedPeriod == storePeriod && s.NextSyncCommitteeKnown() && !proto.Equal(update.NextSyncCommittee, s.nextSyncCommittee) {
+			return errors.Wrap(ErrInvalidUpdate, "next sync committee differs from the known one")
+		}
+		committeeRoot, err := update.NextSyncCommittee.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not compute next sync committee root")
+		}
+		if !trie.VerifyMerkleProof(attestedStateRoot, committeeRoot[:], nextSyncCommitteeSubtreeIndex, update.NextSyncCommitteeBranch) {
+			return errors.Wrap(ErrInvalidUpdate, "invalid next sync committee branch")
+		}
+	}
+
+	committee := s.currentSyncCommittee
+	if signaturePeriod != storePeriod {
+		committee = s.nextSyncCommittee
+	}
+	return s.verifySyncAggregate(update, committee)
+}
+
+// validateHeader checks the execution payload header of a header from Capella on is proven by the body root of
+// its block, and that a header of an earlier fork has none.
+//
+// Spec pseudocode definition:
+//
+//	def is_valid_light_client_header(header: LightClientHeader) -> bool:
+//	    epoch = compute_epoch_at_slot(header.beacon.slot)
+//
+//	    if epoch < DENEB_FORK_EPOCH:
+//	        if header.execution.blob_gas_used != uint64(0) or header.execution.excess_blob_gas != uint64(0):
+//	            return False
+//
+//	    if epoch < CAPELLA_FORK_EPOCH:
+//	        return (
+//	            header.execution == ExecutionPayloadHeader()
+//	            and header.execution_branch == [Bytes32() for _ in range(floorlog2(EXECUTION_PAYLOAD_INDEX))]
+//	        )
+//
+//	    return is_valid_merkle_branch(
+//	        leaf=get_lc_execution_root(header),
+//	        branch=header.execution_branch,
+//	        depth=floorlog2(EXECUTION_PAYLOAD_INDEX),
+//	        index=get_subtree_index(EXECUTION_PAYLOAD_INDEX),
+//	        root=header.beacon.body_root,
+//	    )
+func validateHeader(h *Header) error {
+	if h.Version() < version.Capella {
+		if h.Execution != nil || !isEmptyBranch(h.ExecutionBranch) {
+			return errors.New("header before Capella has an execution payload header")
+		}
+		return nil
+	}
+	if h.Execution == nil {
+		return errors.New("header has no execution payload header")
+	}
+	if _, ok := h.Execution.Proto().(*enginev1.ExecutionPayloadHeaderDeneb); ok && h.Version() < version.Deneb {
+		return errors.New("header before Deneb has a Deneb execution payload header")
+	}
+	root, err := h.Execution.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute execution payload header root")
+	}
+	if !trie.VerifyMerkleProof(h.Beacon.BodyRoot, root[:], executionPayloadSubtreeIndex, h.ExecutionBranch) {
+		return errors.New("invalid execution branch")
+	}
+	return nil
+}
+
+// verifySyncAggregate verifies the sync committee signature of the attested header of the update.
+func (s *Store) verifySyncAggregate(update *Update, committee *ethpb.SyncCommittee) error {
+	bits := update.SyncAggregate.SyncCommitteeBits
+	pubkeys := make([]bls.PublicKey, 0, bits.Count())
+	for i, pk := range committee.Pubkeys {
+		if !bits.BitAt(uint64(i)) {
+			continue
+		}
+		p, err := bls.PublicKeyFromBytes(pk)
+		if err != nil {
+			return errors.Wrap(err, "could not parse sync committee public key")
+		}
+		pubkeys = append(pubkeys, p)
+	}
+	forkVersionSlot := update.SignatureSlot
+	if forkVersionSlot > 0 {
+		forkVersionSlot--
+	}
+	fork, err := forks.Fork(slots.ToEpoch(forkVersionSlot))
+	if err != nil {
+		return errors.Wrap(err, "could not compute fork version")
+	}
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainSyncCommittee, fork.CurrentVersion, s.genesisValidatorsRoot[:])
+	if err != nil {
+		return errors.Wrap(err, "could not compute sync committee domain")
+	}
+	signingRoot, err := signing.ComputeSigningRoot(update.AttestedHeader.Beacon, domain)
+	if err != nil {
+		return errors.Wrap(err, "could not compute signing root")
+	}
+	sig, err := bls.SignatureFromBytes(update.SyncAggregate.SyncCommitteeSignature)
+	if err != nil {
+		return errors.Wrap(err, "could not parse sync committee signature")
+	}
+	if !sig.FastAggregateVerify(pubkeys, signingRoot) {
+		return errors.Wrap(ErrInvalidUpdate, "invalid sync committee signature")
+	}
+	return nil
+}
+
+// applyUpdate advances the store to the finalized header of the update, rotating the sync committees
+// when the finalized header enters the next period.
+//
+// Spec pseudocode definition:
+//
+//	def apply_light_client_update(store: LightClientStore, update: LightClientUpdate) -> None:
+//	    store_period = compute_sync_committee_period_at_slot(store.finalized_header.beacon.slot)
+//	    update_finalized_period = compute_sync_committee_period_at_slot(update.finalized_header.beacon.slot)
+//	    if not is_next_sync_committee_known(store):
+//	        assert update_finalized_period == store_period
+//	        store.next_sync_committee = update.next_sync_committee
+//	    elif update_finalized_period == store_period + 1:
+//	        store.current_sync_committee = store.next_sync_committee
+//	        store.next_sync_committee = update.next_sync_committee
+//	        ...
+//	    if update.finalized_header.beacon.slot > store.finalized_header.beacon.slot:
+//	        store.finalized_header = update.finalized_header
+//	        ...
+func (s *Store) applyUpdate(update *Update) error {
+	storePeriod := SyncCommitteePeriodAtSlot(s.finalizedHeader.Beacon.Slot)
+	finalizedPeriod := SyncCommitteePeriodAtSlot(update.FinalizedHeader.Beacon.Slot)
+	var next *ethpb.SyncCommittee
+	if IsSyncCommitteeUpdate(update) {
+		next = update.NextSyncCommittee
+	}
+	if !s.NextSyncCommitteeKnown() {
+		if finalizedPeriod != storePeriod {
+			return errors.Wrapf(ErrInvalidUpdate, "finalized period %d is not the store period %d while the next sync committee is unknown",
+				finalizedPeriod, storePeriod)
+		}
+		s.nextSyncCommittee = next
+	} else if finalizedPeriod == storePeriod+1 {
+		s.currentSyncCommittee = s.nextSyncCommittee
+		s.nextSyncCommittee = next
+	}
+	if update.FinalizedHeader.Beacon.Slot > s.finalizedHeader.Beacon.Slot {
+		s.finalizedHeader = update.FinalizedHeader
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package light_client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+func TestNewStore(t *testing.T) {
+	st, _ := syncCommitteeState(t, util.DeterministicGenesisStateAltair)
+	bootstrap, root := bootstrapAt(t, st, 1)
+	gvr := bytesutil.ToBytes32(st.GenesisValidatorsRoot())
+
+	store, err := lightclient.NewStore(root, bootstrap, gvr)
+	require.NoError(t, err)
+	assert.DeepEqual(t, bootstrap.Header, store.FinalizedHeader())
+	assert.Equal(t, false, store.NextSyncCommitteeKnown())
+
+	_, err = lightclient.NewStore([32]byte{'a'}, bootstrap, gvr)
+	require.ErrorIs(t, err, lightclient.ErrInvalidBootstrap)
+
+	bootstrap.CurrentSyncCommitteeBranch[0][0] ^= 1
+	_, err = lightclient.NewStore(root, bootstrap, gvr)
+	require.ErrorIs(t, err, lightclient.ErrInvalidBootstrap)
+}
+
+func TestStore_ProcessUpdate(t *testing.T) {
+	ctx := context.Background()
+	st, keys := syncCommitteeState(t, util.DeterministicGenesisStateAltair)
+	bootstrap, root := bootstrapAt(t, st, 1)
+	gvr := bytesutil.ToBytes32(st.GenesisValidatorsRoot())
+
+	newUpdate := func() *lightclient.Update {
+		attestedState := st.Copy()
+		require.NoError(t, attestedState.SetSlot(2))
+		attestedBlock := attestedBlockOf(t, attestedState)
+		attestedRoot, err := attestedBlock.Block().HashTreeRoot()
+		require.NoError(t, err)
+		blk := altairBlock(t, 3, attestedRoot, make([]byte, 32), 10)
+		genesisBlock := altairBlock(t, 0, [32]byte{}, make([]byte, 32), 0)
+		update, err := lightclient.NewLightClientUpdateFromBeaconState(ctx, blk, attestedState, attestedBlock, genesisBlock)
+		require.NoError(t, err)
+		signSyncAggregate(t, st, keys, update, params.BeaconConfig().SyncCommitteeSize)
+		return update
+	}
+
+	t.Run("reveals the next sync committee", func(t *testing.T) {
+		store, err := lightclient.NewStore(root, bootstrap, gvr)
+		require.NoError(t, err)
+		require.NoError(t, store.ProcessUpdate(newUpdate()))
+		assert.Equal(t, true, store.NextSyncCommitteeKnown())
+		// The update finalizes nothing newer than the bootstrap.
+		assert.DeepEqual(t, bootstrap.Header, store.FinalizedHeader())
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		store, err := lightclient.NewStore(root, bootstrap, gvr)
+		require.NoError(t, err)
+		update := newUpdate()
+		update.AttestedHeader.Beacon.ProposerIndex++
+		require.ErrorIs(t, store.ProcessUpdate(update), lightclient.ErrInvalidUpdate)
+		assert.Equal(t, false, store.NextSyncCommitteeKnown())
+	})
+	t.Run("invalid next sync committee branch", func(t *testing.T) {
+		store, err := lightclient.NewStore(root, bootstrap, gvr)
+		require.NoError(t, err)
+		update := newUpdate()
+		update.NextSyncCommitteeBranch[0][0] ^= 1
+		require.ErrorIs(t, store.ProcessUpdate(update), lightclient.ErrInvalidUpdate)
+	})
+	t.Run("invalid finality branch", func(t *testing.T) {
+		store, err := lightclient.NewStore(root, bootstrap, gvr)
+		require.NoError(t, err)
+		update := newUpdate()
+		update.FinalityBranch[0][0] ^= 1
+		require.ErrorIs(t, store.ProcessUpdate(update), lightclient.ErrInvalidUpdate)
+	})
+	t.Run("no supermajority", func(t *testing.T) {
+		store, err := lightclient.NewStore(root, bootstrap, gvr)
+		require.NoError(t, err)
+		update := newUpdate()
+		signSyncAggregate(t, st, keys, update, params.BeaconConfig().SyncCommitteeSize/2)
+		require.ErrorIs(t, store.ProcessUpdate(update), lightclient.ErrIrrelevantUpdate)
+	})
+	t.Run("signature slot skips a period", func(t *testing.T) {
+		store, err := lightclient.NewStore(root, bootstrap, gvr)
+		require.NoError(t, err)
+		update := newUpdate()
+		update.SignatureSlot = params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod))
+		require.ErrorIs(t, store.ProcessUpdate(update), lightclient.ErrIrrelevantUpdate)
+	})
+}
+
+func TestStore_CapellaHeaders(t *testing.T) {
+	setForkEpochs(t)
+	ctx := context.Background()
+	st, keys := syncCommitteeState(t, util.DeterministicGenesisStateCapella)
+	bootstrapSlot := params.BeaconConfig().SlotsPerEpoch + 1
+	bootstrap, root := bootstrapAt(t, st, bootstrapSlot)
+	gvr := bytesutil.ToBytes32(st.GenesisValidatorsRoot())
+	require.Equal(t, version.Capella, bootstrap.Version())
+
+	newUpdate := func() *lightclient.Update {
+		attestedState := st.Copy()
+		require.NoError(t, attestedState.SetSlot(bootstrapSlot+1))
+		attestedBlock := attestedBlockOf(t, attestedState)
+		attestedRoot, err := attestedBlock.Block().HashTreeRoot()
+		require.NoError(t, err)
+		blk := capellaBlock(t, bootstrapSlot+2, attestedRoot, make([]byte, 32), 10)
+		genesisBlock := altairBlock(t, 0, [32]byte{}, make([]byte, 32), 0)
+		update, err := lightclient.NewLightClientUpdateFromBeaconState(ctx, blk, attestedState, attestedBlock, genesisBlock)
+		require.NoError(t, err)
+		signSyncAggregate(t, st, keys, update, params.BeaconConfig().SyncCommitteeSize)
+		// Updates reach the store through their SSZ container.
+		m, err := update.Proto()
+		require.NoError(t, err)
+		enc, err := m.MarshalSSZ()
+		require.NoError(t, err)
+		decoded, err := lightclient.UnmarshalUpdate(version.Capella, enc)
+		require.NoError(t, err)
+		return decoded
+	}
+
+	t.Run("reveals the next sync committee", func(t *testing.T) {
+		store, err := lightclient.NewStore(root, bootstrap, gvr)
+		require.NoError(t, err)
+		require.NoError(t, store.ProcessUpdate(newUpdate()))
+		assert.Equal(t, true, store.NextSyncCommitteeKnown())
+	})
+	t.Run("invalid execution branch", func(t *testing.T) {
+		store, err := lightclient.NewStore(root, bootstrap, gvr)
+		require.NoError(t, err)
+		update := newUpdate()
+		update.AttestedHeader.ExecutionBranch[0][0] ^= 1
+		require.ErrorIs(t, store.ProcessUpdate(update), lightclient.ErrInvalidUpdate)
+	})
+	t.Run("missing execution payload header", func(t *testing.T) {
+		store, err := lightclient.NewStore(root, bootstrap, gvr)
+		require.NoError(t, err)
+		update := newUpdate()
+		update.AttestedHeader.Execution = nil
+		require.ErrorIs(t, store.ProcessUpdate(update), lightclient.ErrInvalidUpdate)
+	})
+	t.Run("invalid bootstrap execution branch", func(t *testing.T) {
+		b, r := bootstrapAt(t, st, bootstrapSlot)
+		b.Header.ExecutionBranch[0][0] ^= 1
+		_, err := lightclient.NewStore(r, b, gvr)
+		require.ErrorIs(t, err, lightclient.ErrInvalidBootstrap)
+	})
+}
+
+// syncCommitteeState returns a genesis state whose sync committees are made of its validators.
+func syncCommitteeState(
+	t *testing.T, genesis func(testing.TB, uint64) (state.BeaconState, []bls.SecretKey),
+) (state.BeaconState, []bls.SecretKey) {
+	st, keys := genesis(t, 64)
+	committee, err := altair.NextSyncCommittee(context.Background(), st)
+	require.NoError(t, err)
+	require.NoError(t, st.SetCurrentSyncCommittee(committee))
+	require.NoError(t, st.SetNextSyncCommittee(committee))
+	return st, keys
+}
+
+// bootstrapAt returns the bootstrap of a block at the given slot whose post-state is a copy of the given
+// state, and the root of the block.
+func bootstrapAt(t *testing.T, st state.BeaconState, slot primitives.Slot) (*lightclient.Bootstrap, [32]byte) {
+	ctx := context.Background()
+	st = st.Copy()
+	require.NoError(t, st.SetSlot(slot))
+	blk := attestedBlockOf(t, st)
+	bootstrap, err := lightclient.NewLightClientBootstrapFromBeaconState(ctx, st, blk)
+	require.NoError(t, err)
+	root, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+	return bootstrap, root
+}
+
+// signSyncAggregate replaces the sync aggregate of the update with the signature of its attested header
+// by the first participants members of the current sync committee of the state.
+func signSyncAggregate(t *testing.T, st state.BeaconState, keys []bls.SecretKey, update *lightclient.Update, participants uint64) {
+	committee, err := st.CurrentSyncCommittee()
+	require.NoError(t, err)
+	keysByPubkey := make(map[[48]byte]bls.SecretKey, len(keys))
+	for _, k := range keys {
+		keysByPubkey[bytesutil.ToBytes48(k.PublicKey().Marshal())] = k
+	}
+	fork, err := forks.Fork(slots.ToEpoch(update.SignatureSlot - 1))
+	require.NoError(t, err)
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainSyncCommittee, fork.CurrentVersion, st.GenesisValidatorsRoot())
+	require.NoError(t, err)
+	signingRoot, err := signing.ComputeSigningRoot(update.AttestedHeader.Beacon, domain)
+	require.NoError(t, err)
+
+	aggregate := util.HydrateLightClientUpdate(&lightclient.Update{}).SyncAggregate
+	sigs := make([]bls.Signature, 0, participants)
+	for i := uint64(0); i < participants; i++ {
+		aggregate.SyncCommitteeBits.SetBitAt(i, true)
+		sigs = append(sigs, keysByPubkey[bytesutil.ToBytes48(committee.Pubkeys[i])].Sign(signingRoot[:]))
+	}
+	aggregate.SyncCommitteeSignature = bls.AggregateSignatures(sigs).Marshal()
+	update.SyncAggregate = aggregate
+}
@@ -0,0 +1,690 @@
+package light_client
+
+import (
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"google.golang.org/protobuf/proto"
+)
+
+// Message is the SSZ container of light client data of a fork, as served to light clients.
+type Message interface {
+	proto.Message
+	ssz.Marshaler
+}
+
+type sszUnmarshaler interface {
+	proto.Message
+	UnmarshalSSZ(buf []byte) error
+}
+
+// Header is the header of a beacon block, as tracked by light clients. From Capella on, it also holds
+// the header of the execution payload of the block, proven against the root of the block body.
+type Header struct {
+	Beacon *ethpb.BeaconBlockHeader
+	// Execution is the execution payload header of the block, nil before Capella.
+	Execution interfaces.ExecutionData
+	// ExecutionBranch is the Merkle branch of the execution payload in the block body, nil before Capella.
+	ExecutionBranch [][]byte
+}
+
+// Bootstrap is the data a light client initializes its store with, from a trusted block root.
+type Bootstrap struct {
+	Header                     *Header
+	CurrentSyncCommittee       *ethpb.SyncCommittee
+	CurrentSyncCommitteeBranch [][]byte
+}
+
+// Update is an update of the header a light client follows, signed by the sync committee.
+type Update struct {
+	AttestedHeader          *Header
+	NextSyncCommittee       *ethpb.SyncCommittee
+	NextSyncCommitteeBranch [][]byte
+	FinalizedHeader         *Header
+	FinalityBranch          [][]byte
+	SyncAggregate           *ethpb.SyncAggregate
+	SignatureSlot           primitives.Slot
+}
+
+// FinalityUpdate is a light client update tracking the latest finalized header.
+type FinalityUpdate struct {
+	AttestedHeader  *Header
+	FinalizedHeader *Header
+	FinalityBranch  [][]byte
+	SyncAggregate   *ethpb.SyncAggregate
+	SignatureSlot   primitives.Slot
+}
+
+// OptimisticUpdate is a light client update tracking the latest optimistic header.
+type OptimisticUpdate struct {
+	AttestedHeader *Header
+	SyncAggregate  *ethpb.SyncAggregate
+	SignatureSlot  primitives.Slot
+}
+
+// VersionAtSlot returns the fork version active at the given slot.
+func VersionAtSlot(slot primitives.Slot) int {
+	epoch := slots.ToEpoch(slot)
+	cfg := params.BeaconConfig()
+	switch {
+	case epoch >= cfg.DenebForkEpoch:
+		return version.Deneb
+	case epoch >= cfg.CapellaForkEpoch:
+		return version.Capella
+	case epoch >= cfg.BellatrixForkEpoch:
+		return version.Bellatrix
+	case epoch >= cfg.AltairForkEpoch:
+		return version.Altair
+	default:
+		return version.Phase0
+	}
+}
+
+// Version returns the fork version of the header, the one active at the slot of its block.
+func (h *Header) Version() int {
+	return VersionAtSlot(h.Beacon.Slot)
+}
+
+// Version returns the fork version of the bootstrap, the one of its header.
+func (b *Bootstrap) Version() int {
+	return b.Header.Version()
+}
+
+// Version returns the fork version of the update, the one of its attested header.
+func (u *Update) Version() int {
+	return u.AttestedHeader.Version()
+}
+
+// Version returns the fork version of the update, the one of its attested header.
+func (u *FinalityUpdate) Version() int {
+	return u.AttestedHeader.Version()
+}
+
+// Version returns the fork version of the update, the one of its attested header.
+func (u *OptimisticUpdate) Version() int {
+	return u.AttestedHeader.Version()
+}
+
+// Proto returns the header container of the given fork version, upgrading the header when it belongs to an
+// earlier fork.
+func (h *Header) Proto(v int) (proto.Message, error) {
+	switch {
+	case v >= version.Deneb:
+		return headerToDeneb(h)
+	case v == version.Capella:
+		return headerToCapella(h)
+	case v >= version.Altair:
+		return headerToAltair(h), nil
+	default:
+		return nil, errors.Errorf("no light client header for version %s", version.String(v))
+	}
+}
+
+// Proto returns the SSZ container of the bootstrap for its fork version.
+func (b *Bootstrap) Proto() (Message, error) {
+	switch v := b.Version(); {
+	case v >= version.Deneb:
+		header, err := headerToDeneb(b.Header)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientBootstrapDeneb{
+			Header:                     header,
+			CurrentSyncCommittee:       b.CurrentSyncCommittee,
+			CurrentSyncCommitteeBranch: b.CurrentSyncCommitteeBranch,
+		}, nil
+	case v == version.Capella:
+		header, err := headerToCapella(b.Header)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientBootstrapCapella{
+			Header:                     header,
+			CurrentSyncCommittee:       b.CurrentSyncCommittee,
+			CurrentSyncCommitteeBranch: b.CurrentSyncCommitteeBranch,
+		}, nil
+	case v >= version.Altair:
+		return &ethpb.LightClientBootstrap{
+			Header:                     headerToAltair(b.Header),
+			CurrentSyncCommittee:       b.CurrentSyncCommittee,
+			CurrentSyncCommitteeBranch: b.CurrentSyncCommitteeBranch,
+		}, nil
+	default:
+		return nil, errors.Errorf("no light client bootstrap for version %s", version.String(v))
+	}
+}
+
+// Proto returns the SSZ container of the update for its fork version. Its finalized header is upgraded to
+// the fork version of the update when it belongs to an earlier fork.
+func (u *Update) Proto() (Message, error) {
+	switch v := u.Version(); {
+	case v >= version.Deneb:
+		attested, err := headerToDeneb(u.AttestedHeader)
+		if err != nil {
+			return nil, err
+		}
+		finalized, err := headerToDeneb(u.FinalizedHeader)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientUpdateDeneb{
+			AttestedHeader:          attested,
+			NextSyncCommittee:       u.NextSyncCommittee,
+			NextSyncCommitteeBranch: u.NextSyncCommitteeBranch,
+			FinalizedHeader:         finalized,
+			FinalityBranch:          u.FinalityBranch,
+			SyncAggregate:           u.SyncAggregate,
+			SignatureSlot:           u.SignatureSlot,
+		}, nil
+	case v == version.Capella:
+		attested, err := headerToCapella(u.AttestedHeader)
+		if err != nil {
+			return nil, err
+		}
+		finalized, err := headerToCapella(u.FinalizedHeader)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientUpdateCapella{
+			AttestedHeader:          attested,
+			NextSyncCommittee:       u.NextSyncCommittee,
+			NextSyncCommitteeBranch: u.NextSyncCommitteeBranch,
+			FinalizedHeader:         finalized,
+			FinalityBranch:          u.FinalityBranch,
+			SyncAggregate:           u.SyncAggregate,
+			SignatureSlot:           u.SignatureSlot,
+		}, nil
+	case v >= version.Altair:
+		return &ethpb.LightClientUpdate{
+			AttestedHeader:          headerToAltair(u.AttestedHeader),
+			NextSyncCommittee:       u.NextSyncCommittee,
+			NextSyncCommitteeBranch: u.NextSyncCommitteeBranch,
+			FinalizedHeader:         headerToAltair(u.FinalizedHeader),
+			FinalityBranch:          u.FinalityBranch,
+			SyncAggregate:           u.SyncAggregate,
+			SignatureSlot:           u.SignatureSlot,
+		}, nil
+	default:
+		return nil, errors.Errorf("no light client update for version %s", version.String(v))
+	}
+}
+
+// Proto returns the SSZ container of the update for its fork version. Its finalized header is upgraded to
+// the fork version of the update when it belongs to an earlier fork.
+func (u *FinalityUpdate) Proto() (Message, error) {
+	switch v := u.Version(); {
+	case v >= version.Deneb:
+		attested, err := headerToDeneb(u.AttestedHeader)
+		if err != nil {
+			return nil, err
+		}
+		finalized, err := headerToDeneb(u.FinalizedHeader)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientFinalityUpdateDeneb{
+			AttestedHeader:  attested,
+			FinalizedHeader: finalized,
+			FinalityBranch:  u.FinalityBranch,
+			SyncAggregate:   u.SyncAggregate,
+			SignatureSlot:   u.SignatureSlot,
+		}, nil
+	case v == version.Capella:
+		attested, err := headerToCapella(u.AttestedHeader)
+		if err != nil {
+			return nil, err
+		}
+		finalized, err := headerToCapella(u.FinalizedHeader)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientFinalityUpdateCapella{
+			AttestedHeader:  attested,
+			FinalizedHeader: finalized,
+			FinalityBranch:  u.FinalityBranch,
+			SyncAggregate:   u.SyncAggregate,
+			SignatureSlot:   u.SignatureSlot,
+		}, nil
+	case v >= version.Altair:
+		return &ethpb.LightClientFinalityUpdate{
+			AttestedHeader:  headerToAltair(u.AttestedHeader),
+			FinalizedHeader: headerToAltair(u.FinalizedHeader),
+			FinalityBranch:  u.FinalityBranch,
+			SyncAggregate:   u.SyncAggregate,
+			SignatureSlot:   u.SignatureSlot,
+		}, nil
+	default:
+		return nil, errors.Errorf("no light client finality update for version %s", version.String(v))
+	}
+}
+
+// Proto returns the SSZ container of the update for its fork version.
+func (u *OptimisticUpdate) Proto() (Message, error) {
+	switch v := u.Version(); {
+	case v >= version.Deneb:
+		attested, err := headerToDeneb(u.AttestedHeader)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientOptimisticUpdateDeneb{
+			AttestedHeader: attested,
+			SyncAggregate:  u.SyncAggregate,
+			SignatureSlot:  u.SignatureSlot,
+		}, nil
+	case v == version.Capella:
+		attested, err := headerToCapella(u.AttestedHeader)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientOptimisticUpdateCapella{
+			AttestedHeader: attested,
+			SyncAggregate:  u.SyncAggregate,
+			SignatureSlot:  u.SignatureSlot,
+		}, nil
+	case v >= version.Altair:
+		return &ethpb.LightClientOptimisticUpdate{
+			AttestedHeader: headerToAltair(u.AttestedHeader),
+			SyncAggregate:  u.SyncAggregate,
+			SignatureSlot:  u.SignatureSlot,
+		}, nil
+	default:
+		return nil, errors.Errorf("no light client optimistic update for version %s", version.String(v))
+	}
+}
+
+// BootstrapFromProto returns the bootstrap held by the SSZ container of a fork.
+func BootstrapFromProto(m proto.Message) (*Bootstrap, error) {
+	var header proto.Message
+	b := &Bootstrap{}
+	switch p := m.(type) {
+	case *ethpb.LightClientBootstrap:
+		header, b.CurrentSyncCommittee, b.CurrentSyncCommitteeBranch = p.Header, p.CurrentSyncCommittee, p.CurrentSyncCommitteeBranch
+	case *ethpb.LightClientBootstrapCapella:
+		header, b.CurrentSyncCommittee, b.CurrentSyncCommitteeBranch = p.Header, p.CurrentSyncCommittee, p.CurrentSyncCommitteeBranch
+	case *ethpb.LightClientBootstrapDeneb:
+		header, b.CurrentSyncCommittee, b.CurrentSyncCommitteeBranch = p.Header, p.CurrentSyncCommittee, p.CurrentSyncCommitteeBranch
+	default:
+		return nil, errors.Errorf("%T is not a light client bootstrap", m)
+	}
+	var err error
+	if b.Header, err = headerFromProto(header); err != nil {
+		return nil, err
+	}
+	if b.CurrentSyncCommittee == nil {
+		return nil, errors.New("light client bootstrap has no current sync committee")
+	}
+	return b, nil
+}
+
+// UpdateFromProto returns the update held by the SSZ container of a fork.
+func UpdateFromProto(m proto.Message) (*Update, error) {
+	var attested, finalized proto.Message
+	u := &Update{}
+	switch p := m.(type) {
+	case *ethpb.LightClientUpdate:
+		attested, finalized = p.AttestedHeader, p.FinalizedHeader
+		u.NextSyncCommittee, u.NextSyncCommitteeBranch, u.FinalityBranch = p.NextSyncCommittee, p.NextSyncCommitteeBranch, p.FinalityBranch
+		u.SyncAggregate, u.SignatureSlot = p.SyncAggregate, p.SignatureSlot
+	case *ethpb.LightClientUpdateCapella:
+		attested, finalized = p.AttestedHeader, p.FinalizedHeader
+		u.NextSyncCommittee, u.NextSyncCommitteeBranch, u.FinalityBranch = p.NextSyncCommittee, p.NextSyncCommitteeBranch, p.FinalityBranch
+		u.SyncAggregate, u.SignatureSlot = p.SyncAggregate, p.SignatureSlot
+	case *ethpb.LightClientUpdateDeneb:
+		attested, finalized = p.AttestedHeader, p.FinalizedHeader
+		u.NextSyncCommittee, u.NextSyncCommitteeBranch, u.FinalityBranch = p.NextSyncCommittee, p.NextSyncCommitteeBranch, p.FinalityBranch
+		u.SyncAggregate, u.SignatureSlot = p.SyncAggregate, p.SignatureSlot
+	default:
+		return nil, errors.Errorf("%T is not a light client update", m)
+	}
+	var err error
+	if u.AttestedHeader, err = headerFromProto(attested); err != nil {
+		return nil, err
+	}
+	if u.FinalizedHeader, err = headerFromProto(finalized); err != nil {
+		return nil, err
+	}
+	if u.NextSyncCommittee == nil || u.SyncAggregate == nil {
+		return nil, errors.New("light client update has no next sync committee or sync aggregate")
+	}
+	return u, nil
+}
+
+// FinalityUpdateFromProto returns the finality update held by the SSZ container of a fork.
+func FinalityUpdateFromProto(m proto.Message) (*FinalityUpdate, error) {
+	var attested, finalized proto.Message
+	u := &FinalityUpdate{}
+	switch p := m.(type) {
+	case *ethpb.LightClientFinalityUpdate:
+		attested, finalized = p.AttestedHeader, p.FinalizedHeader
+		u.FinalityBranch, u.SyncAggregate, u.SignatureSlot = p.FinalityBranch, p.SyncAggregate, p.SignatureSlot
+	case *ethpb.LightClientFinalityUpdateCapella:
+		attested, finalized = p.AttestedHeader, p.FinalizedHeader
+		u.FinalityBranch, u.SyncAggregate, u.SignatureSlot = p.FinalityBranch, p.SyncAggregate, p.SignatureSlot
+	case *ethpb.LightClientFinalityUpdateDeneb:
+		attested, finalized = p.AttestedHeader, p.FinalizedHeader
+		u.FinalityBranch, u.SyncAggregate, u.SignatureSlot = p.FinalityBranch, p.SyncAggregate, p.SignatureSlot
+	default:
+		return nil, errors.Errorf("%T is not a light client finality update", m)
+	}
+	var err error
+	if u.AttestedHeader, err = headerFromProto(attested); err != nil {
+		return nil, err
+	}
+	if u.FinalizedHeader, err = headerFromProto(finalized); err != nil {
+		return nil, err
+	}
+	if u.SyncAggregate == nil {
+		return nil, errors.New("light client finality update has no sync aggregate")
+	}
+	return u, nil
+}
+
+// OptimisticUpdateFromProto returns the optimistic update held by the SSZ container of a fork.
+func OptimisticUpdateFromProto(m proto.Message) (*OptimisticUpdate, error) {
+	var attested proto.Message
+	u := &OptimisticUpdate{}
+	switch p := m.(type) {
+	case *ethpb.LightClientOptimisticUpdate:
+		attested, u.SyncAggregate, u.SignatureSlot = p.AttestedHeader, p.SyncAggregate, p.SignatureSlot
+	case *ethpb.LightClientOptimisticUpdateCapella:
+		attested, u.SyncAggregate, u.SignatureSlot = p.AttestedHeader, p.SyncAggregate, p.SignatureSlot
+	case *ethpb.LightClientOptimisticUpdateDeneb:
+		attested, u.SyncAggregate, u.SignatureSlot = p.AttestedHeader, p.SyncAggregate, p.SignatureSlot
+	default:
+		return nil, errors.Errorf("%T is not a light client optimistic update", m)
+	}
+	var err error
+	if u.AttestedHeader, err = headerFromProto(attested); err != nil {
+		return nil, err
+	}
+	if u.SyncAggregate == nil {
+		return nil, errors.New("light client optimistic update has no sync aggregate")
+	}
+	return u, nil
+}
+
+// UnmarshalBootstrap decodes the SSZ encoded bootstrap container of the given fork version.
+func UnmarshalBootstrap(v int, enc []byte) (*Bootstrap, error) {
+	var m sszUnmarshaler
+	switch {
+	case v >= version.Deneb:
+		m = &ethpb.LightClientBootstrapDeneb{}
+	case v == version.Capella:
+		m = &ethpb.LightClientBootstrapCapella{}
+	case v >= version.Altair:
+		m = &ethpb.LightClientBootstrap{}
+	default:
+		return nil, errors.Errorf("no light client bootstrap for version %s", version.String(v))
+	}
+	if err := m.UnmarshalSSZ(enc); err != nil {
+		return nil, err
+	}
+	b, err := BootstrapFromProto(m)
+	if err != nil {
+		return nil, err
+	}
+	if containerVersion(b.Version()) != containerVersion(v) {
+		return nil, errors.Errorf("light client bootstrap of version %s decoded as %s", version.String(b.Version()), version.String(v))
+	}
+	return b, nil
+}
+
+// UnmarshalUpdate decodes the SSZ encoded update container of the given fork version.
+func UnmarshalUpdate(v int, enc []byte) (*Update, error) {
+	var m sszUnmarshaler
+	switch {
+	case v >= version.Deneb:
+		m = &ethpb.LightClientUpdateDeneb{}
+	case v == version.Capella:
+		m = &ethpb.LightClientUpdateCapella{}
+	case v >= version.Altair:
+		m = &ethpb.LightClientUpdate{}
+	default:
+		return nil, errors.Errorf("no light client update for version %s", version.String(v))
+	}
+	if err := m.UnmarshalSSZ(enc); err != nil {
+		return nil, err
+	}
+	u, err := UpdateFromProto(m)
+	if err != nil {
+		return nil, err
+	}
+	if containerVersion(u.Version()) != containerVersion(v) {
+		return nil, errors.Errorf("light client update of version %s decoded as %s", version.String(u.Version()), version.String(v))
+	}
+	return u, nil
+}
+
+// UnmarshalFinalityUpdate decodes the SSZ encoded finality update container of the given fork version.
+func UnmarshalFinalityUpdate(v int, enc []byte) (*FinalityUpdate, error) {
+	var m sszUnmarshaler
+	switch {
+	case v >= version.Deneb:
+		m = &ethpb.LightClientFinalityUpdateDeneb{}
+	case v == version.Capella:
+		m = &ethpb.LightClientFinalityUpdateCapella{}
+	case v >= version.Altair:
+		m = &ethpb.LightClientFinalityUpdate{}
+	default:
+		return nil, errors.Errorf("no light client finality update for version %s", version.String(v))
+	}
+	if err := m.UnmarshalSSZ(enc); err != nil {
+		return nil, err
+	}
+	u, err := FinalityUpdateFromProto(m)
+	if err != nil {
+		return nil, err
+	}
+	if containerVersion(u.Version()) != containerVersion(v) {
+		return nil, errors.Errorf("light client finality update of version %s decoded as %s", version.String(u.Version()), version.String(v))
+	}
+	return u, nil
+}
+
+// containerVersion returns the earliest fork version sharing the light client containers of the given one.
+func containerVersion(v int) int {
+	switch {
+	case v >= version.Deneb:
+		return version.Deneb
+	case v == version.Capella:
+		return version.Capella
+	case v >= version.Altair:
+		return version.Altair
+	default:
+		return v
+	}
+}
+
+// headerToAltair returns the header container of Altair and Bellatrix.
+func headerToAltair(h *Header) *ethpb.LightClientHeader {
+	return &ethpb.LightClientHeader{Beacon: h.Beacon}
+}
+
+// headerToCapella returns the header container of Capella, holding an empty execution payload header for
+// a header of an earlier fork.
+//
+// Spec pseudocode definition:
+//
+//	def upgrade_lc_header_to_capella(pre: bellatrix.LightClientHeader) -> LightClientHeader:
+//	    return LightClientHeader(
+//	        beacon=pre.beacon,
+//	        execution=ExecutionPayloadHeader(),
+//	        execution_branch=ExecutionBranch(),
+//	    )
+func headerToCapella(h *Header) (*ethpb.LightClientHeaderCapella, error) {
+	if h.Execution == nil {
+		return &ethpb.LightClientHeaderCapella{
+			Beacon:          h.Beacon,
+			Execution:       emptyExecutionPayloadHeaderCapella(),
+			ExecutionBranch: emptyBranch(executionBranchDepth),
+		}, nil
+	}
+	execution, ok := h.Execution.Proto().(*enginev1.ExecutionPayloadHeaderCapella)
+	if !ok {
+		return nil, errors.Errorf("cannot hold a %T execution payload header in a Capella light client header", h.Execution.Proto())
+	}
+	return &ethpb.LightClientHeaderCapella{
+		Beacon:          h.Beacon,
+		Execution:       execution,
+		ExecutionBranch: h.ExecutionBranch,
+	}, nil
+}
+
+// headerToDeneb returns the header container of Deneb, upgrading the execution payload header of a header
+// of an earlier fork.
+//
+// Spec pseudocode definition:
+//
+//	def upgrade_lc_header_to_deneb(pre: capella.LightClientHeader) -> LightClientHeader:
+//	    return LightClientHeader(
+//	        beacon=pre.beacon,
+//	        execution=ExecutionPayloadHeader(
+//	            parent_hash=pre.execution.parent_hash,
+//	            ...
+//	            withdrawals_root=pre.execution.withdrawals_root,
+//	            blob_gas_used=uint64(0),  # [New in Deneb:EIP4844]
+//	            excess_blob_gas=uint64(0),  # [New in Deneb:EIP4844]
+//	        ),
+//	        execution_branch=pre.execution_branch,
+//	    )
+func headerToDeneb(h *Header) (*ethpb.LightClientHeaderDeneb, error) {
+	if h.Execution != nil {
+		if execution, ok := h.Execution.Proto().(*enginev1.ExecutionPayloadHeaderDeneb); ok {
+			return &ethpb.LightClientHeaderDeneb{
+				Beacon:          h.Beacon,
+				Execution:       execution,
+				ExecutionBranch: h.ExecutionBranch,
+			}, nil
+		}
+	}
+	pre, err := headerToCapella(h)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.LightClientHeaderDeneb{
+		Beacon: pre.Beacon,
+		Execution: &enginev1.ExecutionPayloadHeaderDeneb{
+			ParentHash:       pre.Execution.ParentHash,
+			FeeRecipient:     pre.Execution.FeeRecipient,
+			StateRoot:        pre.Execution.StateRoot,
+			ReceiptsRoot:     pre.Execution.ReceiptsRoot,
+			LogsBloom:        pre.Execution.LogsBloom,
+			PrevRandao:       pre.Execution.PrevRandao,
+			BlockNumber:      pre.Execution.BlockNumber,
+			GasLimit:         pre.Execution.GasLimit,
+			GasUsed:          pre.Execution.GasUsed,
+			Timestamp:        pre.Execution.Timestamp,
+			ExtraData:        pre.Execution.ExtraData,
+			BaseFeePerGas:    pre.Execution.BaseFeePerGas,
+			BlockHash:        pre.Execution.BlockHash,
+			TransactionsRoot: pre.Execution.TransactionsRoot,
+			WithdrawalsRoot:  pre.Execution.WithdrawalsRoot,
+		},
+		ExecutionBranch: pre.ExecutionBranch,
+	}, nil
+}
+
+// headerFromProto returns the header held by the header container of a fork. The execution payload header is
+// converted back to the fork of the block, and must hold the default values of the fields added by later forks.
+func headerFromProto(m proto.Message) (*Header, error) {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return nil, errors.New("missing light client header")
+	}
+	var h *Header
+	var execution proto.Message
+	switch p := m.(type) {
+	case *ethpb.LightClientHeader:
+		h = &Header{Beacon: p.Beacon}
+	case *ethpb.LightClientHeaderCapella:
+		h = &Header{Beacon: p.Beacon, ExecutionBranch: p.ExecutionBranch}
+		if p.Execution != nil {
+			execution = p.Execution
+		}
+	case *ethpb.LightClientHeaderDeneb:
+		h = &Header{Beacon: p.Beacon, ExecutionBranch: p.ExecutionBranch}
+		if p.Execution != nil {
+			execution = p.Execution
+		}
+	default:
+		return nil, errors.Errorf("%T is not a light client header", m)
+	}
+	if h.Beacon == nil {
+		return nil, errors.New("light client header has no beacon block header")
+	}
+	if execution == nil {
+		if h.Version() >= version.Capella {
+			return nil, errors.Errorf("light client header of version %s has no execution payload header", version.String(h.Version()))
+		}
+		return h, nil
+	}
+
+	var err error
+	switch v := h.Version(); {
+	case v >= version.Deneb:
+		p, ok := execution.(*enginev1.ExecutionPayloadHeaderDeneb)
+		if !ok {
+			return nil, errors.Errorf("light client header of version %s holds a %T execution payload header", version.String(v), execution)
+		}
+		h.Execution, err = blocks.WrappedExecutionPayloadHeaderDeneb(p, 0)
+	case v == version.Capella:
+		var p *enginev1.ExecutionPayloadHeaderCapella
+		switch e := execution.(type) {
+		case *enginev1.ExecutionPayloadHeaderCapella:
+			p = e
+		case *enginev1.ExecutionPayloadHeaderDeneb:
+			if e.BlobGasUsed != 0 || e.ExcessBlobGas != 0 {
+				return nil, errors.New("light client header before Deneb has blob gas in its execution payload header")
+			}
+			p = downgradeExecutionPayloadHeaderDeneb(e)
+		}
+		h.Execution, err = blocks.WrappedExecutionPayloadHeaderCapella(p, 0)
+	default:
+		if !isEmptyExecutionPayloadHeader(execution) || !isEmptyBranch(h.ExecutionBranch) {
+			return nil, errors.New("light client header before Capella has an execution payload header")
+		}
+		h.ExecutionBranch = nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not wrap execution payload header")
+	}
+	return h, nil
+}
+
+func downgradeExecutionPayloadHeaderDeneb(h *enginev1.ExecutionPayloadHeaderDeneb) *enginev1.ExecutionPayloadHeaderCapella {
+	return &enginev1.ExecutionPayloadHeaderCapella{
+		ParentHash:       h.ParentHash,
+		FeeRecipient:     h.FeeRecipient,
+		StateRoot:        h.StateRoot,
+		ReceiptsRoot:     h.ReceiptsRoot,
+		LogsBloom:        h.LogsBloom,
+		PrevRandao:       h.PrevRandao,
+		BlockNumber:      h.BlockNumber,
+		GasLimit:         h.GasLimit,
+		GasUsed:          h.GasUsed,
+		Timestamp:        h.Timestamp,
+		ExtraData:        h.ExtraData,
+		BaseFeePerGas:    h.BaseFeePerGas,
+		BlockHash:        h.BlockHash,
+		TransactionsRoot: h.TransactionsRoot,
+		WithdrawalsRoot:  h.WithdrawalsRoot,
+	}
+}
+
+func isEmptyExecutionPayloadHeader(m proto.Message) bool {
+	switch p := m.(type) {
+	case *enginev1.ExecutionPayloadHeaderCapella:
+		return proto.Equal(p, emptyExecutionPayloadHeaderCapella())
+	case *enginev1.ExecutionPayloadHeaderDeneb:
+		return p.BlobGasUsed == 0 && p.ExcessBlobGas == 0 &&
+			proto.Equal(downgradeExecutionPayloadHeaderDeneb(p), emptyExecutionPayloadHeaderCapella())
+	default:
+		return false
+	}
+}
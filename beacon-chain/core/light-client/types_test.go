@@ -0,0 +1,146 @@
+package light_client_test
+
+import (
+	"testing"
+
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestVersionAtSlot(t *testing.T) {
+	setForkEpochs(t)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	assert.Equal(t, version.Bellatrix, lightclient.VersionAtSlot(0))
+	assert.Equal(t, version.Bellatrix, lightclient.VersionAtSlot(slotsPerEpoch-1))
+	assert.Equal(t, version.Capella, lightclient.VersionAtSlot(slotsPerEpoch))
+	assert.Equal(t, version.Deneb, lightclient.VersionAtSlot(2*slotsPerEpoch))
+}
+
+func TestUpdate_Proto(t *testing.T) {
+	setForkEpochs(t)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	t.Run("altair", func(t *testing.T) {
+		u := util.HydrateLightClientUpdate(&lightclient.Update{SignatureSlot: 2})
+		u.AttestedHeader.Beacon.Slot = 1
+		m, err := u.Proto()
+		require.NoError(t, err)
+		_, ok := m.(*ethpb.LightClientUpdate)
+		require.Equal(t, true, ok)
+		decoded := roundTripUpdate(t, u, version.Bellatrix)
+		assert.DeepEqual(t, u.AttestedHeader.Beacon, decoded.AttestedHeader.Beacon)
+	})
+	t.Run("deneb with a capella finalized header", func(t *testing.T) {
+		u := util.HydrateLightClientUpdate(&lightclient.Update{
+			AttestedHeader:  executionHeader(t, 2*slotsPerEpoch, version.Deneb),
+			FinalizedHeader: executionHeader(t, slotsPerEpoch, version.Capella),
+			SignatureSlot:   2*slotsPerEpoch + 1,
+		})
+		m, err := u.Proto()
+		require.NoError(t, err)
+		d, ok := m.(*ethpb.LightClientUpdateDeneb)
+		require.Equal(t, true, ok)
+		// The finalized header is upgraded to Deneb, with no blob gas.
+		assert.Equal(t, uint64(0), d.FinalizedHeader.Execution.BlobGasUsed)
+		assert.DeepEqual(t, u.FinalizedHeader.Execution.BlockHash(), d.FinalizedHeader.Execution.BlockHash)
+
+		decoded := roundTripUpdate(t, u, version.Deneb)
+		_, ok = decoded.AttestedHeader.Execution.Proto().(*enginev1.ExecutionPayloadHeaderDeneb)
+		assert.Equal(t, true, ok)
+		// The finalized header is back to the Capella execution payload header, whose root is the one proven by
+		// the branch.
+		_, ok = decoded.FinalizedHeader.Execution.Proto().(*enginev1.ExecutionPayloadHeaderCapella)
+		assert.Equal(t, true, ok)
+		want, err := u.FinalizedHeader.Execution.HashTreeRoot()
+		require.NoError(t, err)
+		got, err := decoded.FinalizedHeader.Execution.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+	t.Run("capella header without execution payload header", func(t *testing.T) {
+		u := util.HydrateLightClientUpdate(&lightclient.Update{SignatureSlot: slotsPerEpoch + 1})
+		u.AttestedHeader.Beacon.Slot = slotsPerEpoch
+		m, err := u.Proto()
+		require.NoError(t, err)
+		enc, err := m.MarshalSSZ()
+		require.NoError(t, err)
+		_, err = lightclient.UnmarshalUpdate(version.Capella, enc)
+		require.NoError(t, err)
+	})
+}
+
+func TestUnmarshalUpdate(t *testing.T) {
+	setForkEpochs(t)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	u := util.HydrateLightClientUpdate(&lightclient.Update{
+		AttestedHeader: executionHeader(t, slotsPerEpoch, version.Capella),
+		SignatureSlot:  slotsPerEpoch + 1,
+	})
+	m, err := u.Proto()
+	require.NoError(t, err)
+	enc, err := m.MarshalSSZ()
+	require.NoError(t, err)
+
+	_, err = lightclient.UnmarshalUpdate(version.Deneb, enc)
+	require.NotNil(t, err)
+	_, err = lightclient.UnmarshalUpdate(version.Phase0, enc)
+	require.ErrorContains(t, "no light client update for version phase0", err)
+
+	// A header before Capella cannot carry an execution payload header.
+	c, ok := m.(*ethpb.LightClientUpdateCapella)
+	require.Equal(t, true, ok)
+	c.FinalizedHeader.Beacon.Slot = 1
+	c.FinalizedHeader.Execution.BlockNumber = 1
+	enc, err = c.MarshalSSZ()
+	require.NoError(t, err)
+	_, err = lightclient.UnmarshalUpdate(version.Capella, enc)
+	require.ErrorContains(t, "light client header before Capella has an execution payload header", err)
+}
+
+// executionHeader returns a light client header at the given slot holding an execution payload header of the
+// given version.
+func executionHeader(t *testing.T, slot primitives.Slot, v int) *lightclient.Header {
+	h := &lightclient.Header{Beacon: util.HydrateBeaconHeader(&ethpb.BeaconBlockHeader{Slot: slot})}
+	for i := 0; i < 4; i++ {
+		h.ExecutionBranch = append(h.ExecutionBranch, make([]byte, 32))
+	}
+	switch v {
+	case version.Deneb:
+		payload := util.NewBeaconBlockDeneb().Block.Body.ExecutionPayload
+		payload.BlockNumber, payload.BlobGasUsed = uint64(slot), 1
+		wrapped, err := blocks.WrappedExecutionPayloadDeneb(payload, 0)
+		require.NoError(t, err)
+		e, err := blocks.PayloadToHeaderDeneb(wrapped)
+		require.NoError(t, err)
+		h.Execution, err = blocks.WrappedExecutionPayloadHeaderDeneb(e, 0)
+		require.NoError(t, err)
+	default:
+		payload := util.NewBeaconBlockCapella().Block.Body.ExecutionPayload
+		payload.BlockNumber = uint64(slot)
+		wrapped, err := blocks.WrappedExecutionPayloadCapella(payload, 0)
+		require.NoError(t, err)
+		e, err := blocks.PayloadToHeaderCapella(wrapped)
+		require.NoError(t, err)
+		h.Execution, err = blocks.WrappedExecutionPayloadHeaderCapella(e, 0)
+		require.NoError(t, err)
+	}
+	return h
+}
+
+func roundTripUpdate(t *testing.T, u *lightclient.Update, v int) *lightclient.Update {
+	m, err := u.Proto()
+	require.NoError(t, err)
+	enc, err := m.MarshalSSZ()
+	require.NoError(t, err)
+	decoded, err := lightclient.UnmarshalUpdate(v, enc)
+	require.NoError(t, err)
+	return decoded
+}
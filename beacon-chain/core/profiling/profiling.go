@@ -0,0 +1,53 @@
+// Package profiling times the stages of the state transition, so that a regression of its performance can be
+// attributed to a specific block operation or epoch processing stage. It is enabled with the
+// --enable-state-transition-profiling flag, and is a no-op otherwise.
+package profiling
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"go.opencensus.io/trace"
+)
+
+var (
+	blockOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "state_transition_block_operation_milliseconds",
+		Help:    "The time spent processing each type of operation of a block during the state transition.",
+		Buckets: []float64{0.1, 0.5, 1, 5, 10, 25, 50, 100, 250, 500},
+	}, []string{"operation"})
+	epochStageDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "state_transition_epoch_stage_milliseconds",
+		Help:    "The time spent in each stage of epoch processing during the state transition.",
+		Buckets: []float64{0.1, 0.5, 1, 5, 10, 25, 50, 100, 250, 500, 1000},
+	}, []string{"stage"})
+)
+
+func noop() {}
+
+// BlockOperation starts timing the processing of the given type of block operation. The returned function
+// must be called once the operations are processed.
+func BlockOperation(ctx context.Context, operation string) (context.Context, func()) {
+	return start(ctx, "stateTransition.operation."+operation, blockOperationDuration.WithLabelValues(operation))
+}
+
+// EpochStage starts timing the given stage of epoch processing. The returned function must be called once the
+// stage is processed.
+func EpochStage(ctx context.Context, stage string) (context.Context, func()) {
+	return start(ctx, "stateTransition.epoch."+stage, epochStageDuration.WithLabelValues(stage))
+}
+
+func start(ctx context.Context, name string, o prometheus.Observer) (context.Context, func()) {
+	if !features.Get().EnableTransitionProfiling {
+		return ctx, noop
+	}
+	ctx, span := trace.StartSpan(ctx, name)
+	begin := time.Now()
+	return ctx, func() {
+		o.Observe(float64(time.Since(begin).Microseconds()) / 1000)
+		span.End()
+	}
+}
@@ -0,0 +1,34 @@
+package profiling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"go.opencensus.io/trace"
+)
+
+func TestBlockOperation(t *testing.T) {
+	ctx, done := BlockOperation(context.Background(), "deposits")
+	done()
+	assert.Equal(t, true, trace.FromContext(ctx) == nil)
+
+	resetCfg := features.InitWithReset(&features.Flags{EnableTransitionProfiling: true})
+	defer resetCfg()
+	ctx, done = BlockOperation(context.Background(), "deposits")
+	done()
+	assert.Equal(t, false, trace.FromContext(ctx) == nil)
+}
+
+func TestEpochStage(t *testing.T) {
+	ctx, done := EpochStage(context.Background(), "slashings")
+	done()
+	assert.Equal(t, true, trace.FromContext(ctx) == nil)
+
+	resetCfg := features.InitWithReset(&features.Flags{EnableTransitionProfiling: true})
+	defer resetCfg()
+	ctx, done = EpochStage(context.Background(), "slashings")
+	done()
+	assert.Equal(t, false, trace.FromContext(ctx) == nil)
+}
@@ -3,6 +3,8 @@ package signing
 import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 )
 
@@ -32,3 +34,46 @@ func VerifyRegistrationSignature(
 	}
 	return nil
 }
+
+// OwnershipProofSigningRoot computes the signing root and the domain of a proof of validator key ownership over an
+// arbitrary message, such as a challenge chosen by a staking marketplace. The SHA-256 hash of the message is signed in
+// the application ownership proof domain, which is distinct from every consensus domain, so that the signature can
+// never be replayed as the signature of a consensus object. Like the application builder domain, it is computed with
+// the genesis fork version of the network and a zero genesis validators root, so a proof is only valid on the
+// network it was made for.
+func OwnershipProofSigningRoot(message []byte) ([32]byte, []byte, error) {
+	d, err := ComputeDomain(
+		params.BeaconConfig().DomainApplicationOwnershipProof,
+		nil, /* fork version */
+		nil /* genesis val root */)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	root, err := SigningData(func() ([32]byte, error) {
+		return hash.Hash(message), nil
+	}, d)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	return root, d, nil
+}
+
+// VerifyOwnershipProof verifies the signature of a proof of validator key ownership over the message.
+func VerifyOwnershipProof(pub, message, signature []byte) error {
+	publicKey, err := bls.PublicKeyFromBytes(pub)
+	if err != nil {
+		return errors.Wrap(err, "could not convert bytes to public key")
+	}
+	sig, err := bls.SignatureFromBytes(signature)
+	if err != nil {
+		return errors.Wrap(err, "could not convert bytes to signature")
+	}
+	root, _, err := OwnershipProofSigningRoot(message)
+	if err != nil {
+		return errors.Wrap(err, "could not compute signing root")
+	}
+	if !sig.Verify(publicKey, root[:]) {
+		return ErrSigFailedToVerify
+	}
+	return nil
+}
@@ -40,3 +40,25 @@ func TestVerifyRegistrationSignature(t *testing.T) {
 	sReg.Message = nil
 	require.ErrorIs(t, signing.VerifyRegistrationSignature(sReg), signing.ErrNilRegistration)
 }
+
+func TestVerifyOwnershipProof(t *testing.T) {
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	message := []byte("marketplace challenge 42")
+	root, domain, err := signing.OwnershipProofSigningRoot(message)
+	require.NoError(t, err)
+	sig := sk.Sign(root[:]).Marshal()
+	require.NoError(t, signing.VerifyOwnershipProof(sk.PublicKey().Marshal(), message, sig))
+
+	require.ErrorIs(t, signing.VerifyOwnershipProof(sk.PublicKey().Marshal(), []byte("other challenge"), sig), signing.ErrSigFailedToVerify)
+
+	// The proof is signed in its own domain, which no consensus object is signed in.
+	for name, d := range params.BeaconConfig().DomainTypes() {
+		if name == "DOMAIN_APPLICATION_OWNERSHIP_PROOF" {
+			continue
+		}
+		other, err := signing.ComputeDomain(d, nil, nil)
+		require.NoError(t, err)
+		require.NotEqual(t, string(other), string(domain), name)
+	}
+}
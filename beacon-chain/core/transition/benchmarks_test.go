@@ -121,6 +121,49 @@ func BenchmarkHashTreeRootState_FullState(b *testing.B) {
 	}
 }
 
+// BenchmarkHashTreeRoot_EpochBoundary computes the state root after processing an epoch, which changes the
+// balances and the participation of every validator. The native state rehashes its changed fields with vectorized
+// hashing, while the protobuf state is hashed from scratch.
+func BenchmarkHashTreeRoot_EpochBoundary(b *testing.B) {
+	undo, err := benchmark.SetBenchmarkConfig()
+	require.NoError(b, err)
+	defer undo()
+	beaconState, err := benchmark.PreGenstateFullEpochs()
+	require.NoError(b, err)
+	ctx := context.Background()
+
+	currentSlot := beaconState.Slot()
+	require.NoError(b, beaconState.SetSlot(beaconState.Slot()-params.BeaconConfig().SlotsPerEpoch))
+	require.NoError(b, helpers.UpdateCommitteeCache(ctx, beaconState, time.CurrentEpoch(beaconState)))
+	require.NoError(b, beaconState.SetSlot(currentSlot))
+	// Hydrate the field tries, so that only the fields changed by epoch processing are rehashed.
+	_, err = beaconState.HashTreeRoot(ctx)
+	require.NoError(b, err)
+	postState, err := coreState.ProcessEpochPrecompute(ctx, beaconState.Copy())
+	require.NoError(b, err)
+
+	b.Run("Native", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			st := postState.Copy()
+			b.StartTimer()
+			_, err := st.HashTreeRoot(ctx)
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("Proto", func(b *testing.B) {
+		pbState, err := state_native.ProtobufBeaconStatePhase0(postState.ToProtoUnsafe())
+		require.NoError(b, err)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, err := pbState.HashTreeRoot()
+			require.NoError(b, err)
+		}
+	})
+}
+
 func BenchmarkMarshalState_FullState(b *testing.B) {
 	beaconState, err := benchmark.PreGenstateFullEpochs()
 	require.NoError(b, err)
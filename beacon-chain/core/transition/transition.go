@@ -16,6 +16,7 @@ import (
 	e "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/execution"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/profiling"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/config/features"
@@ -388,36 +389,50 @@ func ProcessEpochPrecompute(ctx context.Context, state state.BeaconState) (state
 	if state == nil || state.IsNil() {
 		return nil, errors.New("nil state")
 	}
-	vp, bp, err := precompute.New(ctx, state)
+	stageCtx, done := profiling.EpochStage(ctx, "initialize_validators")
+	vp, bp, err := precompute.New(stageCtx, state)
+	done()
 	if err != nil {
 		return nil, err
 	}
-	vp, bp, err = precompute.ProcessAttestations(ctx, state, vp, bp)
+	stageCtx, done = profiling.EpochStage(ctx, "participation")
+	vp, bp, err = precompute.ProcessAttestations(stageCtx, state, vp, bp)
+	done()
 	if err != nil {
 		return nil, err
 	}
 
+	_, done = profiling.EpochStage(ctx, "justification_and_finalization")
 	state, err = precompute.ProcessJustificationAndFinalizationPreCompute(state, bp)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process justification")
 	}
 
+	_, done = profiling.EpochStage(ctx, "rewards_and_penalties")
 	state, err = precompute.ProcessRewardsAndPenaltiesPrecompute(state, bp, vp, precompute.AttestationsDelta, precompute.ProposersDelta)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process rewards and penalties")
 	}
 
-	state, err = e.ProcessRegistryUpdates(ctx, state)
+	stageCtx, done = profiling.EpochStage(ctx, "registry_updates")
+	state, err = e.ProcessRegistryUpdates(stageCtx, state)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process registry updates")
 	}
 
+	_, done = profiling.EpochStage(ctx, "slashings")
 	err = precompute.ProcessSlashingsPrecompute(state, bp)
+	done()
 	if err != nil {
 		return nil, err
 	}
 
+	_, done = profiling.EpochStage(ctx, "final_updates")
 	state, err = e.ProcessFinalUpdates(state)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process final updates")
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
 	b "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/profiling"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition/interop"
 	v "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/validators"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
@@ -300,7 +301,9 @@ func ProcessBlockForStateRoot(
 		return nil, errors.Wrap(err, "could not hash tree root beacon block body")
 	}
 	parentRoot := blk.ParentRoot()
-	state, err = b.ProcessBlockHeaderNoVerify(ctx, state, blk.Slot(), blk.ProposerIndex(), parentRoot[:], bodyRoot[:])
+	opCtx, done := profiling.BlockOperation(ctx, "block_header")
+	state, err = b.ProcessBlockHeaderNoVerify(opCtx, state, blk.Slot(), blk.ProposerIndex(), parentRoot[:], bodyRoot[:])
+	done()
 	if err != nil {
 		tracing.AnnotateError(span, err)
 		return nil, errors.Wrap(err, "could not process block header")
@@ -315,11 +318,13 @@ func ProcessBlockForStateRoot(
 		if err != nil {
 			return nil, err
 		}
+		_, done = profiling.BlockOperation(ctx, "execution_payload")
 		if blk.IsBlinded() {
 			state, err = b.ProcessPayloadHeader(state, executionData)
 		} else {
 			state, err = b.ProcessPayload(state, executionData)
 		}
+		done()
 		if err != nil {
 			return nil, errors.Wrap(err, "could not process execution data")
 		}
@@ -330,13 +335,17 @@ func ProcessBlockForStateRoot(
 	}
 
 	randaoReveal := signed.Block().Body().RandaoReveal()
+	_, done = profiling.BlockOperation(ctx, "randao")
 	state, err = b.ProcessRandaoNoVerify(state, randaoReveal[:])
+	done()
 	if err != nil {
 		tracing.AnnotateError(span, err)
 		return nil, errors.Wrap(err, "could not verify and process randao")
 	}
 
-	state, err = b.ProcessEth1DataInBlock(ctx, state, signed.Block().Body().Eth1Data())
+	opCtx, done = profiling.BlockOperation(ctx, "eth1_data")
+	state, err = b.ProcessEth1DataInBlock(opCtx, state, signed.Block().Body().Eth1Data())
+	done()
 	if err != nil {
 		tracing.AnnotateError(span, err)
 		return nil, errors.Wrap(err, "could not process eth1 data")
@@ -356,7 +365,9 @@ func ProcessBlockForStateRoot(
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get sync aggregate from block")
 	}
-	state, _, err = altair.ProcessSyncAggregate(ctx, state, sa)
+	opCtx, done = profiling.BlockOperation(ctx, "sync_aggregate")
+	state, _, err = altair.ProcessSyncAggregate(opCtx, state, sa)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "process_sync_aggregate failed")
 	}
@@ -383,25 +394,38 @@ func altairOperations(
 	ctx context.Context,
 	st state.BeaconState,
 	signedBeaconBlock interfaces.ReadOnlySignedBeaconBlock) (state.BeaconState, error) {
-	st, err := b.ProcessProposerSlashings(ctx, st, signedBeaconBlock.Block().Body().ProposerSlashings(), v.SlashValidator)
+	opCtx, done := profiling.BlockOperation(ctx, "proposer_slashings")
+	st, err := b.ProcessProposerSlashings(opCtx, st, signedBeaconBlock.Block().Body().ProposerSlashings(), v.SlashValidator)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process altair proposer slashing")
 	}
-	st, err = b.ProcessAttesterSlashings(ctx, st, signedBeaconBlock.Block().Body().AttesterSlashings(), v.SlashValidator)
+	opCtx, done = profiling.BlockOperation(ctx, "attester_slashings")
+	st, err = b.ProcessAttesterSlashings(opCtx, st, signedBeaconBlock.Block().Body().AttesterSlashings(), v.SlashValidator)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process altair attester slashing")
 	}
-	st, err = altair.ProcessAttestationsNoVerifySignature(ctx, st, signedBeaconBlock)
+	opCtx, done = profiling.BlockOperation(ctx, "attestations")
+	st, err = altair.ProcessAttestationsNoVerifySignature(opCtx, st, signedBeaconBlock)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process altair attestation")
 	}
-	if _, err := altair.ProcessDeposits(ctx, st, signedBeaconBlock.Block().Body().Deposits()); err != nil {
+	opCtx, done = profiling.BlockOperation(ctx, "deposits")
+	_, err = altair.ProcessDeposits(opCtx, st, signedBeaconBlock.Block().Body().Deposits())
+	done()
+	if err != nil {
 		return nil, errors.Wrap(err, "could not process altair deposit")
 	}
-	st, err = b.ProcessVoluntaryExits(ctx, st, signedBeaconBlock.Block().Body().VoluntaryExits())
+	opCtx, done = profiling.BlockOperation(ctx, "voluntary_exits")
+	st, err = b.ProcessVoluntaryExits(opCtx, st, signedBeaconBlock.Block().Body().VoluntaryExits())
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process voluntary exits")
 	}
+	_, done = profiling.BlockOperation(ctx, "bls_to_execution_changes")
+	defer done()
 	return b.ProcessBLSToExecutionChanges(st, signedBeaconBlock)
 }
 
@@ -410,20 +434,31 @@ func phase0Operations(
 	ctx context.Context,
 	st state.BeaconState,
 	signedBeaconBlock interfaces.ReadOnlySignedBeaconBlock) (state.BeaconState, error) {
-	st, err := b.ProcessProposerSlashings(ctx, st, signedBeaconBlock.Block().Body().ProposerSlashings(), v.SlashValidator)
+	opCtx, done := profiling.BlockOperation(ctx, "proposer_slashings")
+	st, err := b.ProcessProposerSlashings(opCtx, st, signedBeaconBlock.Block().Body().ProposerSlashings(), v.SlashValidator)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process block proposer slashings")
 	}
-	st, err = b.ProcessAttesterSlashings(ctx, st, signedBeaconBlock.Block().Body().AttesterSlashings(), v.SlashValidator)
+	opCtx, done = profiling.BlockOperation(ctx, "attester_slashings")
+	st, err = b.ProcessAttesterSlashings(opCtx, st, signedBeaconBlock.Block().Body().AttesterSlashings(), v.SlashValidator)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process block attester slashings")
 	}
-	st, err = b.ProcessAttestationsNoVerifySignature(ctx, st, signedBeaconBlock)
+	opCtx, done = profiling.BlockOperation(ctx, "attestations")
+	st, err = b.ProcessAttestationsNoVerifySignature(opCtx, st, signedBeaconBlock)
+	done()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process block attestations")
 	}
-	if _, err := b.ProcessDeposits(ctx, st, signedBeaconBlock.Block().Body().Deposits()); err != nil {
+	opCtx, done = profiling.BlockOperation(ctx, "deposits")
+	_, err = b.ProcessDeposits(opCtx, st, signedBeaconBlock.Block().Body().Deposits())
+	done()
+	if err != nil {
 		return nil, errors.Wrap(err, "could not process deposits")
 	}
-	return b.ProcessVoluntaryExits(ctx, st, signedBeaconBlock.Block().Body().VoluntaryExits())
+	opCtx, done = profiling.BlockOperation(ctx, "voluntary_exits")
+	defer done()
+	return b.ProcessVoluntaryExits(opCtx, st, signedBeaconBlock.Block().Body().VoluntaryExits())
 }
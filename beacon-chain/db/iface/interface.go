@@ -8,6 +8,7 @@ import (
 	"io"
 
 	"github.com/ethereum/go-ethereum/common"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
 	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
@@ -31,6 +32,7 @@ type ReadOnlyDatabase interface {
 	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
 	FinalizedChildBlock(ctx context.Context, blockRoot [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error)
 	HighestRootsBelowSlot(ctx context.Context, slot primitives.Slot) (primitives.Slot, [][32]byte, error)
+	FinalizedBlockRootForSlot(ctx context.Context, slot primitives.Slot) ([32]byte, error)
 	// State related methods.
 	State(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
 	StateOrError(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
@@ -54,6 +56,11 @@ type ReadOnlyDatabase interface {
 	// Fee recipients operations.
 	FeeRecipientByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (common.Address, error)
 	RegistrationByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (*ethpb.ValidatorRegistrationV1, error)
+	// Validator monitor operations.
+	MonitoredValidatorIndices(ctx context.Context) ([]primitives.ValidatorIndex, error)
+	// Light client operations.
+	LightClientUpdate(ctx context.Context, period uint64) (*lightclient.Update, error)
+	LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) (map[uint64]*lightclient.Update, error)
 
 	// Blob operations.
 	BlobSidecarsByRoot(ctx context.Context, beaconBlockRoot [32]byte, indices ...uint64) ([]*ethpb.BlobSidecar, error)
@@ -70,6 +77,7 @@ type NoHeadAccessDatabase interface {
 
 	// Block related methods.
 	DeleteBlock(ctx context.Context, root [32]byte) error
+	PruneOrphanedBlocks(ctx context.Context, startEpoch, endEpoch primitives.Epoch) (numPruned uint, reclaimed uint64, err error)
 	SaveBlock(ctx context.Context, block interfaces.ReadOnlySignedBeaconBlock) error
 	SaveBlocks(ctx context.Context, blocks []interfaces.ReadOnlySignedBeaconBlock) error
 	SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte) error
@@ -93,6 +101,11 @@ type NoHeadAccessDatabase interface {
 	// Fee recipients operations.
 	SaveFeeRecipientsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, addrs []common.Address) error
 	SaveRegistrationsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, regs []*ethpb.ValidatorRegistrationV1) error
+	// Validator monitor operations.
+	SaveMonitoredValidatorIndices(ctx context.Context, ids []primitives.ValidatorIndex) error
+	DeleteMonitoredValidatorIndices(ctx context.Context, ids []primitives.ValidatorIndex) error
+	// Light client operations.
+	SaveLightClientUpdate(ctx context.Context, period uint64, update *lightclient.Update) error
 
 	// Blob operations.
 	SaveBlobSidecar(ctx context.Context, sidecars []*ethpb.BlobSidecar) error
@@ -135,6 +148,9 @@ type SlasherDatabase interface {
 	SaveBlockProposals(
 		ctx context.Context, proposal []*slashertypes.SignedBlockHeaderWrapper,
 	) error
+	SaveSlashings(
+		ctx context.Context, attesterSlashings []*ethpb.AttesterSlashing, proposerSlashings []*ethpb.ProposerSlashing,
+	) error
 	LastEpochWrittenForValidators(
 		ctx context.Context, validatorIndices []primitives.ValidatorIndex,
 	) ([]*slashertypes.AttestedEpochForValidator, error)
@@ -159,6 +175,9 @@ type SlasherDatabase interface {
 	PruneProposalsAtEpoch(
 		ctx context.Context, maxEpoch primitives.Epoch,
 	) (numPruned uint, err error)
+	PruneSlashingsAtEpoch(
+		ctx context.Context, maxEpoch primitives.Epoch,
+	) (numPruned uint, err error)
 	HighestAttestations(
 		ctx context.Context,
 		indices []primitives.ValidatorIndex,
@@ -127,6 +127,9 @@ func (s *Store) Blocks(ctx context.Context, f *filters.QueryFilter) ([]interface
 		}
 
 		for i := 0; i < len(keys); i++ {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
 			encoded := bkt.Get(keys[i])
 			blk, err := unmarshalBlock(ctx, encoded)
 			if err != nil {
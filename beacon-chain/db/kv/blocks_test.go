@@ -539,6 +539,15 @@ func TestStore_Blocks_Retrieve_SlotRange(t *testing.T) {
 	}
 }
 
+func TestStore_Blocks_ContextCanceled(t *testing.T) {
+	db := setupDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, db.SaveBlocks(ctx, makeBlocks(t, 0, 10, genesisBlockRoot)))
+	cancel()
+	_, _, err := db.Blocks(ctx, filters.NewFilter().SetStartSlot(1).SetEndSlot(10))
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func TestStore_Blocks_Retrieve_Epoch(t *testing.T) {
 	for _, tt := range blockTests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -5,11 +5,13 @@ import (
 	"context"
 
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
@@ -74,8 +76,12 @@ func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 
 	// Walk up the ancestry chain until we reach a block root present in the finalized block roots
 	// index bucket or genesis block root.
+	var walked []finalizedRootEntry
+	complete := false
 	for {
 		if bytes.Equal(root, genesisRoot) {
+			walked = append(walked, finalizedRootEntry{slot: params.BeaconConfig().GenesisSlot, root: bytesutil.ToBytes32(root)})
+			complete = true
 			break
 		}
 
@@ -91,6 +97,7 @@ func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 		block := signedBlock.Block()
 
 		parentRoot := block.ParentRoot()
+		walked = append(walked, finalizedRootEntry{slot: block.Slot(), root: bytesutil.ToBytes32(root), parentRoot: parentRoot})
 		container := &ethpb.FinalizedBlockRootContainer{
 			ParentRoot: parentRoot[:],
 			ChildRoot:  previousRoot,
@@ -109,6 +116,7 @@ func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 		// breaking here allows the initial checkpoint root to be correctly inserted,
 		// but stops the loop from trying to search for its parent.
 		if bytes.Equal(root, initCheckpointRoot) {
+			complete = true
 			break
 		}
 
@@ -136,6 +144,20 @@ func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 		root = pr[:]
 	}
 
+	// Walked blocks are sorted by descending slot.
+	for i, j := 0, len(walked)-1; i < j; i, j = i+1, j-1 {
+		walked[i], walked[j] = walked[j], walked[i]
+	}
+	end, err := slots.EpochStart(checkpoint.Epoch)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return err
+	}
+	if err := s.updateFinalizedRootIndex(ctx, tx, walked, complete, end); err != nil {
+		tracing.AnnotateError(span, err)
+		return err
+	}
+
 	// Upsert blocks from the current finalized epoch.
 	roots, err := s.BlockRoots(ctx, filters.NewFilter().SetStartEpoch(checkpoint.Epoch).SetEndEpoch(checkpoint.Epoch+1))
 	if err != nil {
@@ -0,0 +1,238 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+var errInvalidFinalizedRootSegment = errors.New("invalid finalized root index segment")
+
+// finalizedRootEntry is a block of the canonical finalized chain.
+type finalizedRootEntry struct {
+	slot       primitives.Slot
+	root       [32]byte
+	parentRoot [32]byte
+}
+
+// finalizedRootIndex is an index of the canonical finalized chain. It maps every slot, from the slot of the lowest
+// indexed block up to the start slot of the finalized epoch, to the root of the highest canonical block with a slot
+// lower than or equal to it. Skipped slots therefore map to their closest ancestor, and ancestor-at-slot or
+// canonical checks on finalized history are a single lookup.
+//
+// The index is kept in memory as the slots and roots of the indexed blocks, sorted by ascending slot, so that a
+// lookup is a binary search that does not touch the database. It is persisted incrementally in
+// finalizedRootIndexBucket, and read back when the database is opened: every finalization appends a segment keyed
+// by the big-endian first slot it covers. A segment is encoded as uvarint(last covered slot - first covered slot),
+// followed by uvarint(slot delta to the previous block) and the block root for every block of the segment, so
+// skipped slots take no space on disk.
+type finalizedRootIndex struct {
+	sync.RWMutex
+	covered bool
+	start   primitives.Slot
+	end     primitives.Slot
+	slots   []primitives.Slot
+	roots   [][32]byte
+}
+
+func newFinalizedRootIndex() *finalizedRootIndex {
+	return &finalizedRootIndex{}
+}
+
+// covers returns whether the given slot is covered by the index.
+func (f *finalizedRootIndex) covers(slot primitives.Slot) bool {
+	f.RLock()
+	defer f.RUnlock()
+	return f.covered && slot >= f.start && slot <= f.end
+}
+
+// highest returns the highest slot covered by the index and the root of the highest indexed block, and false if
+// the index is empty.
+func (f *finalizedRootIndex) highest() (primitives.Slot, [32]byte, bool) {
+	f.RLock()
+	defer f.RUnlock()
+	if len(f.roots) == 0 {
+		return f.end, [32]byte{}, f.covered
+	}
+	return f.end, f.roots[len(f.roots)-1], f.covered
+}
+
+// rootAt returns the root of the highest indexed block with a slot lower than or equal to the given slot, and false
+// if the slot is not covered by the index.
+func (f *finalizedRootIndex) rootAt(slot primitives.Slot) ([32]byte, bool) {
+	f.RLock()
+	defer f.RUnlock()
+	if !f.covered || slot < f.start || slot > f.end {
+		return [32]byte{}, false
+	}
+	i := sort.Search(len(f.slots), func(i int) bool { return f.slots[i] > slot })
+	if i == 0 {
+		return [32]byte{}, false
+	}
+	return f.roots[i-1], true
+}
+
+// extend covers the slots from the given start slot to the end slot, which must follow the currently covered
+// slots, with the given blocks sorted by ascending slot.
+func (f *finalizedRootIndex) extend(start, end primitives.Slot, entries []finalizedRootEntry) {
+	f.Lock()
+	defer f.Unlock()
+	if !f.covered {
+		f.covered = true
+		f.start = start
+	}
+	f.end = end
+	for _, e := range entries {
+		f.slots = append(f.slots, e.slot)
+		f.roots = append(f.roots, e.root)
+	}
+}
+
+// reset empties the index.
+func (f *finalizedRootIndex) reset() {
+	f.Lock()
+	defer f.Unlock()
+	f.covered = false
+	f.start, f.end = 0, 0
+	f.slots, f.roots = nil, nil
+}
+
+// FinalizedBlockRootForSlot returns the root of the highest canonical finalized block with a slot lower than or
+// equal to the given slot, using the in-memory finalized root index. ErrNotFound is returned if the slot is not
+// covered by the index, which is the case for slots after the start of the finalized epoch, and for slots before
+// the origin checkpoint or before the first finalization following an upgrade of the database.
+func (s *Store) FinalizedBlockRootForSlot(ctx context.Context, slot primitives.Slot) ([32]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.FinalizedBlockRootForSlot")
+	defer span.End()
+
+	root, ok := s.finalizedRootIndex.rootAt(slot)
+	if !ok {
+		return [32]byte{}, errors.Wrapf(ErrNotFound, "slot %d is not covered by the finalized root index", slot)
+	}
+	return root, nil
+}
+
+// loadFinalizedRootIndex reads the finalized root index from the database into memory.
+func (s *Store) loadFinalizedRootIndex() error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(finalizedRootIndexBucket).ForEach(func(k, v []byte) error {
+			start := bytesutil.BytesToSlotBigEndian(k)
+			end, entries, err := decodeFinalizedRootSegment(start, v)
+			if err != nil {
+				return errors.Wrapf(err, "could not decode segment at slot %d", start)
+			}
+			s.finalizedRootIndex.extend(start, end, entries)
+			return nil
+		})
+	})
+}
+
+// updateFinalizedRootIndex appends the newly finalized blocks to the finalized root index, in the same transaction
+// as the update of the finalized block roots index. The walked blocks are sorted by ascending slot and start either
+// at the genesis or origin checkpoint block (complete is true), or at a block whose parent was already finalized.
+// The in-memory index is only updated once the transaction is committed.
+func (s *Store) updateFinalizedRootIndex(ctx context.Context, tx *bolt.Tx, walked []finalizedRootEntry, complete bool, end primitives.Slot) error {
+	bkt := tx.Bucket(finalizedRootIndexBucket)
+
+	reset := false
+	start := primitives.Slot(0)
+	entries := walked
+	indexEnd, ancestor, ok := s.finalizedRootIndex.highest()
+	if ok {
+		if end <= indexEnd {
+			return nil
+		}
+		start = indexEnd + 1
+		for len(entries) > 0 && entries[0].slot <= indexEnd {
+			entries = entries[1:]
+		}
+		// The first new block must descend from the highest block already in the index, which would only not be the
+		// case if the index was built from a different chain.
+		if len(entries) > 0 && entries[0].parentRoot != ancestor {
+			log.WithField("slot", entries[0].slot).Warn("Finalized root index does not match the finalized chain, rebuilding it")
+			if err := tx.DeleteBucket(finalizedRootIndexBucket); err != nil {
+				return err
+			}
+			var err error
+			if bkt, err = tx.CreateBucket(finalizedRootIndexBucket); err != nil {
+				return err
+			}
+			reset = true
+			entries = walked
+		}
+	}
+	if !ok || reset {
+		if len(entries) == 0 {
+			return nil
+		}
+		// The parent of the first walked block is needed for the index to cover the slots up to the first block.
+		if !complete {
+			parent, err := s.Block(ctx, entries[0].parentRoot)
+			if err != nil {
+				return err
+			}
+			entries = append([]finalizedRootEntry{{
+				slot:       parent.Block().Slot(),
+				root:       entries[0].parentRoot,
+				parentRoot: parent.Block().ParentRoot(),
+			}}, entries...)
+		}
+		start = entries[0].slot
+	}
+
+	if err := bkt.Put(bytesutil.SlotToBytesBigEndian(start), encodeFinalizedRootSegment(start, end, entries)); err != nil {
+		return err
+	}
+	tx.OnCommit(func() {
+		if reset {
+			s.finalizedRootIndex.reset()
+		}
+		s.finalizedRootIndex.extend(start, end, entries)
+	})
+	return nil
+}
+
+func encodeFinalizedRootSegment(start, end primitives.Slot, entries []finalizedRootEntry) []byte {
+	enc := make([]byte, 0, binary.MaxVarintLen64+len(entries)*(32+1))
+	enc = binary.AppendUvarint(enc, uint64(end-start))
+	prev := start
+	for _, e := range entries {
+		enc = binary.AppendUvarint(enc, uint64(e.slot-prev))
+		enc = append(enc, e.root[:]...)
+		prev = e.slot
+	}
+	return enc
+}
+
+func decodeFinalizedRootSegment(start primitives.Slot, enc []byte) (primitives.Slot, []finalizedRootEntry, error) {
+	span, n := binary.Uvarint(enc)
+	if n <= 0 {
+		return 0, nil, errInvalidFinalizedRootSegment
+	}
+	end := start + primitives.Slot(span)
+	enc = enc[n:]
+	var entries []finalizedRootEntry
+	prev := start
+	for len(enc) > 0 {
+		delta, n := binary.Uvarint(enc)
+		if n <= 0 || len(enc) < n+32 {
+			return 0, nil, errInvalidFinalizedRootSegment
+		}
+		e := finalizedRootEntry{slot: prev + primitives.Slot(delta)}
+		copy(e.root[:], enc[n:n+32])
+		if e.slot > end {
+			return 0, nil, errInvalidFinalizedRootSegment
+		}
+		entries = append(entries, e)
+		prev = e.slot
+		enc = enc[n+32:]
+	}
+	return end, entries, nil
+}
@@ -0,0 +1,134 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	bolt "go.etcd.io/bbolt"
+)
+
+func saveFinalizedCheckpointAt(t *testing.T, db *Store, epoch primitives.Epoch, blk interfaces.ReadOnlySignedBeaconBlock) {
+	ctx := context.Background()
+	root := bytesutil.ToBytes32(sszRootOrDie(t, blk))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, root))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: epoch, Root: root[:]}))
+}
+
+func TestStore_FinalizedBlockRootForSlot(t *testing.T) {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	ctx := context.Background()
+	dir := t.TempDir()
+	db, err := NewKVStore(ctx, dir)
+	require.NoError(t, err)
+
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	// Blocks at slots 1 to 5, then from slot 8 onwards.
+	blks := makeBlocks(t, 0, 5, genesisBlockRoot)
+	blks = append(blks, makeBlocks(t, 7, uint64(slotsPerEpoch)*3, bytesutil.ToBytes32(sszRootOrDie(t, blks[4])))...)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	blockAt := func(slot primitives.Slot) [32]byte {
+		for i := len(blks) - 1; i >= 0; i-- {
+			if blks[i].Block().Slot() <= slot {
+				return bytesutil.ToBytes32(sszRootOrDie(t, blks[i]))
+			}
+		}
+		return genesisBlockRoot
+	}
+
+	_, err = db.FinalizedBlockRootForSlot(ctx, 0)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	saveFinalizedCheckpointAt(t, db, 1, blks[slotsPerEpoch-3])
+	assertIndex := func(db *Store, end primitives.Slot) {
+		root, err := db.FinalizedBlockRootForSlot(ctx, 0)
+		require.NoError(t, err)
+		assert.Equal(t, genesisBlockRoot, root)
+		for slot := primitives.Slot(1); slot <= end; slot++ {
+			root, err := db.FinalizedBlockRootForSlot(ctx, slot)
+			require.NoError(t, err)
+			assert.Equal(t, blockAt(slot), root, "Wrong root for slot %d", slot)
+		}
+		_, err = db.FinalizedBlockRootForSlot(ctx, end+1)
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+	assertIndex(db, slotsPerEpoch)
+
+	saveFinalizedCheckpointAt(t, db, 2, blks[2*slotsPerEpoch-3])
+	assertIndex(db, 2*slotsPerEpoch)
+
+	// The index is loaded back from the database.
+	require.NoError(t, db.Close())
+	db, err = NewKVStore(ctx, dir)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	assertIndex(db, 2*slotsPerEpoch)
+}
+
+func TestStore_FinalizedBlockRootForSlot_PreexistingFinalizedIndex(t *testing.T) {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	db := setupDB(t)
+	ctx := context.Background()
+
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	blks := makeBlocks(t, 0, uint64(slotsPerEpoch)*3, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	saveFinalizedCheckpointAt(t, db, 1, blks[slotsPerEpoch-1])
+
+	// Drop the finalized root index, as in a database written before it was introduced.
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(finalizedRootIndexBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucket(finalizedRootIndexBucket)
+		return err
+	}))
+	db.finalizedRootIndex.reset()
+
+	// The index starts at the parent of the first block walked back from the new checkpoint, which is the
+	// highest block still in the finalized block roots index.
+	saveFinalizedCheckpointAt(t, db, 2, blks[2*slotsPerEpoch-1])
+	_, err := db.FinalizedBlockRootForSlot(ctx, slotsPerEpoch-2)
+	require.ErrorIs(t, err, ErrNotFound)
+	for slot := slotsPerEpoch - 1; slot <= 2*slotsPerEpoch; slot++ {
+		root, err := db.FinalizedBlockRootForSlot(ctx, slot)
+		require.NoError(t, err)
+		assert.Equal(t, bytesutil.ToBytes32(sszRootOrDie(t, blks[slot-1])), root, "Wrong root for slot %d", slot)
+	}
+}
+
+func TestFinalizedRootSegment_EncodeDecode(t *testing.T) {
+	entries := []finalizedRootEntry{
+		{slot: 10, root: [32]byte{'a'}},
+		{slot: 11, root: [32]byte{'b'}},
+		{slot: 300, root: [32]byte{'c'}},
+	}
+	enc := encodeFinalizedRootSegment(10, 320, entries)
+	// Two bytes for the span and the last delta, one byte for each of the first two deltas.
+	assert.Equal(t, 2+1+1+2+3*32, len(enc))
+
+	end, decoded, err := decodeFinalizedRootSegment(10, enc)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(320), end)
+	require.Equal(t, len(entries), len(decoded))
+	for i := range entries {
+		assert.Equal(t, entries[i].slot, decoded[i].slot)
+		assert.Equal(t, entries[i].root, decoded[i].root)
+	}
+
+	_, _, err = decodeFinalizedRootSegment(10, enc[:len(enc)-1])
+	require.ErrorIs(t, err, errInvalidFinalizedRootSegment)
+	_, _, err = decodeFinalizedRootSegment(10, encodeFinalizedRootSegment(10, 299, entries))
+	require.ErrorIs(t, err, errInvalidFinalizedRootSegment)
+}
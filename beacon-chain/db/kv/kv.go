@@ -89,6 +89,8 @@ type Store struct {
 	blockCache          *ristretto.Cache
 	validatorEntryCache *ristretto.Cache
 	stateSummaryCache   *stateSummaryCache
+	finalizedRootIndex  *finalizedRootIndex
+	stateCodec          stateCodec
 	ctx                 context.Context
 }
 
@@ -121,6 +123,7 @@ var Buckets = [][]byte{
 	stateSlotIndicesBucket,
 	blockParentRootIndicesBucket,
 	finalizedBlockRootsIndexBucket,
+	finalizedRootIndexBucket,
 	blockRootValidatorHashesBucket,
 	// State management service bucket.
 	newStateServiceCompatibleBucket,
@@ -129,6 +132,8 @@ var Buckets = [][]byte{
 
 	feeRecipientBucket,
 	registrationBucket,
+	monitoredValidatorsBucket,
+	lightClientUpdatesBucket,
 
 	blobsBucket,
 }
@@ -187,6 +192,7 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 		blockCache:          blockCache,
 		validatorEntryCache: validatorCache,
 		stateSummaryCache:   newStateSummaryCache(),
+		finalizedRootIndex:  newFinalizedRootIndex(),
 		ctx:                 ctx,
 	}
 	if err := kv.db.Update(func(tx *bolt.Tx) error {
@@ -194,6 +200,12 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 	}); err != nil {
 		return nil, err
 	}
+	if err := kv.loadStateCompressor(); err != nil {
+		return nil, errors.Wrap(err, "could not load state compression dictionary")
+	}
+	if err := kv.loadFinalizedRootIndex(); err != nil {
+		return nil, errors.Wrap(err, "could not load finalized root index")
+	}
 	if err = prometheus.Register(createBoltCollector(kv.db)); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,112 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveLightClientUpdate saves the light client update of the given sync committee period,
+// replacing any update previously stored for that period.
+func (s *Store) SaveLightClientUpdate(ctx context.Context, period uint64, update *lightclient.Update) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveLightClientUpdate")
+	defer span.End()
+	enc, err := marshalLightClientUpdate(update)
+	if err != nil {
+		return errors.Wrap(err, "could not encode light client update")
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(lightClientUpdatesBucket).Put(bytesutil.Uint64ToBytesBigEndian(period), enc)
+	})
+}
+
+// LightClientUpdate returns the light client update of the given sync committee period,
+// or nil if none is stored.
+func (s *Store) LightClientUpdate(ctx context.Context, period uint64) (*lightclient.Update, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdate")
+	defer span.End()
+	var enc []byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		enc = bytesutil.SafeCopyBytes(tx.Bucket(lightClientUpdatesBucket).Get(bytesutil.Uint64ToBytesBigEndian(period)))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if enc == nil {
+		return nil, nil
+	}
+	update, err := unmarshalLightClientUpdate(enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode light client update")
+	}
+	return update, nil
+}
+
+// LightClientUpdates returns the light client updates stored for the sync committee periods
+// in [startPeriod, endPeriod], keyed by period. Periods without an update are absent from the map.
+func (s *Store) LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) (map[uint64]*lightclient.Update, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdates")
+	defer span.End()
+	if startPeriod > endPeriod {
+		return nil, errors.Errorf("start period %d is greater than end period %d", startPeriod, endPeriod)
+	}
+	updates := make(map[uint64]*lightclient.Update)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(lightClientUpdatesBucket).Cursor()
+		end := bytesutil.Uint64ToBytesBigEndian(endPeriod)
+		for k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(startPeriod)); k != nil && bytes.Compare(k, end) <= 0; k, v = c.Next() {
+			update, err := unmarshalLightClientUpdate(v)
+			if err != nil {
+				return errors.Wrap(err, "could not decode light client update")
+			}
+			updates[bytesutil.BytesToUint64BigEndian(k)] = update
+		}
+		return nil
+	})
+	return updates, err
+}
+
+// marshalLightClientUpdate encodes the update in the container of its fork version, prefixed with the key of
+// the fork from Capella on. Altair updates are stored without a key, as they were before Capella.
+func marshalLightClientUpdate(update *lightclient.Update) ([]byte, error) {
+	m, err := update.Proto()
+	if err != nil {
+		return nil, err
+	}
+	enc, err := m.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	switch v := update.Version(); {
+	case v >= version.Deneb:
+		return snappy.Encode(nil, append(denebKey, enc...)), nil
+	case v == version.Capella:
+		return snappy.Encode(nil, append(capellaKey, enc...)), nil
+	default:
+		return snappy.Encode(nil, enc), nil
+	}
+}
+
+// unmarshalLightClientUpdate decodes an update encoded by marshalLightClientUpdate.
+func unmarshalLightClientUpdate(enc []byte) (*lightclient.Update, error) {
+	var err error
+	enc, err = snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case hasDenebKey(enc):
+		return lightclient.UnmarshalUpdate(version.Deneb, enc[len(denebKey):])
+	case hasCapellaKey(enc):
+		return lightclient.UnmarshalUpdate(version.Capella, enc[len(capellaKey):])
+	default:
+		return lightclient.UnmarshalUpdate(version.Altair, enc)
+	}
+}
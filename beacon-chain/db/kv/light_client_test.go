@@ -0,0 +1,95 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestStore_LightClientUpdates(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	update, err := db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, (*lightclient.Update)(nil), update)
+
+	updates := make(map[uint64]*lightclient.Update)
+	for _, period := range []uint64{1, 2, 4, 300} {
+		updates[period] = lightClientUpdateAt(t, 8192*1000)
+		updates[period].AttestedHeader.Beacon.ProposerIndex = 1
+		require.NoError(t, db.SaveLightClientUpdate(ctx, period, updates[period]))
+	}
+	// Saving an update for a period replaces the previous one.
+	updates[2] = lightClientUpdateAt(t, 8192*1001)
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 2, updates[2]))
+
+	update, err = db.LightClientUpdate(ctx, 2)
+	require.NoError(t, err)
+	assertLightClientUpdate(t, updates[2], update)
+
+	got, err := db.LightClientUpdates(ctx, 2, 299)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(got))
+	assertLightClientUpdate(t, updates[2], got[2])
+	assertLightClientUpdate(t, updates[4], got[4])
+
+	got, err = db.LightClientUpdates(ctx, 0, 300)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(got))
+	assertLightClientUpdate(t, updates[300], got[300])
+
+	_, err = db.LightClientUpdates(ctx, 3, 2)
+	require.ErrorContains(t, "start period 3 is greater than end period 2", err)
+}
+
+func TestStore_LightClientUpdates_Forks(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 1
+	cfg.BellatrixForkEpoch = 2
+	cfg.CapellaForkEpoch = 3
+	cfg.DenebForkEpoch = 4
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+
+	db := setupDB(t)
+	ctx := context.Background()
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	for period, v := range []int{version.Altair, version.Bellatrix, version.Capella, version.Deneb} {
+		epoch := primitives.Epoch(period + 1)
+		t.Run(version.String(v), func(t *testing.T) {
+			want := lightClientUpdateAt(t, primitives.Slot(epoch)*slotsPerEpoch)
+			require.Equal(t, v, want.Version())
+			require.NoError(t, db.SaveLightClientUpdate(ctx, uint64(period), want))
+			update, err := db.LightClientUpdate(ctx, uint64(period))
+			require.NoError(t, err)
+			assertLightClientUpdate(t, want, update)
+			if v >= version.Capella {
+				assert.Equal(t, want.AttestedHeader.Execution.BlockNumber(), update.AttestedHeader.Execution.BlockNumber())
+			}
+		})
+	}
+}
+
+func lightClientUpdateAt(t *testing.T, slot primitives.Slot) *lightclient.Update {
+	return util.HydrateLightClientUpdate(&lightclient.Update{
+		AttestedHeader: util.NewLightClientHeader(t, slot),
+		SignatureSlot:  slot + 1,
+	})
+}
+
+func assertLightClientUpdate(t *testing.T, want, got *lightclient.Update) {
+	wantProto, err := want.Proto()
+	require.NoError(t, err)
+	gotProto, err := got.Proto()
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, wantProto, gotProto)
+}
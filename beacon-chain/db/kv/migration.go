@@ -23,5 +23,5 @@ func (s *Store) RunMigrations(ctx context.Context) error {
 			return err
 		}
 	}
-	return nil
+	return s.migrateStateCompression(ctx)
 }
@@ -0,0 +1,56 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// MonitoredValidatorIndices returns the validator indices tracked by the validator monitor,
+// in ascending order.
+func (s *Store) MonitoredValidatorIndices(ctx context.Context) ([]primitives.ValidatorIndex, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.MonitoredValidatorIndices")
+	defer span.End()
+	var ids []primitives.ValidatorIndex
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(monitoredValidatorsBucket)
+		return bkt.ForEach(func(k, _ []byte) error {
+			ids = append(ids, primitives.ValidatorIndex(bytesutil.BytesToUint64BigEndian(k)))
+			return nil
+		})
+	})
+	return ids, err
+}
+
+// SaveMonitoredValidatorIndices adds the given validator indices to the set tracked by the validator monitor.
+func (s *Store) SaveMonitoredValidatorIndices(ctx context.Context, ids []primitives.ValidatorIndex) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveMonitoredValidatorIndices")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(monitoredValidatorsBucket)
+		for _, id := range ids {
+			if err := bkt.Put(bytesutil.Uint64ToBytesBigEndian(uint64(id)), []byte{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteMonitoredValidatorIndices removes the given validator indices from the set tracked by the validator monitor.
+func (s *Store) DeleteMonitoredValidatorIndices(ctx context.Context, ids []primitives.ValidatorIndex) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteMonitoredValidatorIndices")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(monitoredValidatorsBucket)
+		for _, id := range ids {
+			if err := bkt.Delete(bytesutil.Uint64ToBytesBigEndian(uint64(id))); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,30 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_MonitoredValidatorIndices(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	ids, err := db.MonitoredValidatorIndices(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(ids))
+
+	require.NoError(t, db.SaveMonitoredValidatorIndices(ctx, []primitives.ValidatorIndex{300, 2, 1}))
+	require.NoError(t, db.SaveMonitoredValidatorIndices(ctx, []primitives.ValidatorIndex{2, 15}))
+	ids, err = db.MonitoredValidatorIndices(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []primitives.ValidatorIndex{1, 2, 15, 300}, ids)
+
+	require.NoError(t, db.DeleteMonitoredValidatorIndices(ctx, []primitives.ValidatorIndex{2, 7}))
+	ids, err = db.MonitoredValidatorIndices(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []primitives.ValidatorIndex{1, 15, 300}, ids)
+}
@@ -0,0 +1,170 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// PruneOrphanedBlocks deletes the blocks in the epochs [startEpoch, endEpoch) which are not part of the
+// finalized canonical chain, together with their states, state summaries and blob sidecars. It returns the
+// number of pruned blocks and the number of bytes they used to take in the database.
+//
+// The end epoch must not be after the finalized epoch, as the finalized block roots index only tells canonical
+// blocks apart from orphaned ones before it. Blocks at or before the origin checkpoint are never pruned, as they
+// are not part of the index.
+func (s *Store) PruneOrphanedBlocks(ctx context.Context, startEpoch, endEpoch primitives.Epoch) (numPruned uint, reclaimed uint64, err error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PruneOrphanedBlocks")
+	defer span.End()
+
+	if startEpoch >= endEpoch {
+		return 0, 0, nil
+	}
+	finalized, err := s.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	if endEpoch > finalized.Epoch {
+		return 0, 0, errors.Errorf("could not prune orphaned blocks up to epoch %d after the finalized epoch %d", endEpoch, finalized.Epoch)
+	}
+	startSlot, err := slots.EpochStart(startEpoch)
+	if err != nil {
+		return 0, 0, err
+	}
+	endSlot, err := slots.EpochStart(endEpoch)
+	if err != nil {
+		return 0, 0, err
+	}
+	// Blocks after the finalized block may still descend from it, and be known to fork choice, even if they are
+	// before the finalized epoch.
+	finalizedBlock, err := s.Block(ctx, bytesutil.ToBytes32(finalized.Root))
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := blocks.BeaconBlockIsNil(finalizedBlock); err != nil {
+		return 0, 0, err
+	}
+	if endSlot > finalizedBlock.Block().Slot() {
+		endSlot = finalizedBlock.Block().Slot()
+	}
+	originSlot, err := s.originCheckpointSlot(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	if endSlot <= originSlot+1 {
+		return 0, 0, nil
+	}
+	if startSlot <= originSlot {
+		startSlot = originSlot + 1
+	}
+
+	roots, err := s.BlockRoots(ctx, filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(endSlot-1))
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not retrieve block roots")
+	}
+	for _, root := range roots {
+		if ctx.Err() != nil {
+			return numPruned, reclaimed, ctx.Err()
+		}
+		if s.IsFinalizedBlock(ctx, root) {
+			continue
+		}
+		size, err := s.pruneOrphanedBlock(ctx, root)
+		if err != nil {
+			return numPruned, reclaimed, errors.Wrapf(err, "could not prune orphaned block %#x", root)
+		}
+		numPruned++
+		reclaimed += size
+	}
+	return numPruned, reclaimed, nil
+}
+
+// originCheckpointSlot returns the slot of the origin checkpoint block, or the genesis slot if the node
+// did not start from a checkpoint.
+func (s *Store) originCheckpointSlot(ctx context.Context) (primitives.Slot, error) {
+	root, err := s.OriginCheckpointBlockRoot(ctx)
+	if errors.Is(err, ErrNotFoundOriginBlockRoot) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	b, err := s.Block(ctx, root)
+	if err != nil {
+		return 0, err
+	}
+	if err := blocks.BeaconBlockIsNil(b); err != nil {
+		return 0, err
+	}
+	return b.Block().Slot(), nil
+}
+
+// pruneOrphanedBlock deletes the block, its state, state summary, slot index entry and blob sidecars in a single
+// transaction, so that a crash cannot leave a state without its block, and returns the number of bytes they used to
+// take.
+func (s *Store) pruneOrphanedBlock(ctx context.Context, root [32]byte) (uint64, error) {
+	b, err := s.Block(ctx, root)
+	if err != nil {
+		return 0, err
+	}
+	if err := blocks.BeaconBlockIsNil(b); err != nil {
+		return 0, err
+	}
+	slot := b.Block().Slot()
+	blobPrefix := append(slotKey(slot), bytesutil.SlotToBytesBigEndian(slot)...)
+
+	var size uint64
+	s.stateSummaryCache.delete(root)
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(finalizedBlockRootsIndexBucket).Get(root[:]) != nil {
+			return ErrDeleteJustifiedAndFinalized
+		}
+		size += uint64(len(tx.Bucket(blocksBucket).Get(root[:])))
+		size += uint64(len(tx.Bucket(stateBucket).Get(root[:])))
+		size += uint64(len(tx.Bucket(stateSummaryBucket).Get(root[:])))
+
+		if err := s.deleteState(ctx, tx, root); err != nil {
+			return err
+		}
+		if err := tx.Bucket(stateSummaryBucket).Delete(root[:]); err != nil {
+			return err
+		}
+		if err := tx.Bucket(blocksBucket).Delete(root[:]); err != nil {
+			return err
+		}
+		if err := tx.Bucket(blockParentRootIndicesBucket).Delete(root[:]); err != nil {
+			return err
+		}
+		indices := createBlockIndicesFromBlock(ctx, b.Block())
+		if err := deleteValueForIndices(ctx, indices, root[:], tx); err != nil {
+			return errors.Wrap(err, "could not delete root for DB indices")
+		}
+		bkt := tx.Bucket(blobsBucket)
+		var keys [][]byte
+		c := bkt.Cursor()
+		for k, v := c.Seek(blobPrefix); k != nil && bytes.HasPrefix(k, blobPrefix); k, v = c.Next() {
+			if bytes.HasSuffix(k, root[:]) {
+				size += uint64(len(v))
+				keys = append(keys, bytesutil.SafeCopyBytes(k))
+			}
+		}
+		for _, k := range keys {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		s.blockCache.Del(string(root[:]))
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
@@ -0,0 +1,73 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestStore_PruneOrphanedBlocks(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	ctx := context.Background()
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+
+	canonical := makeBlocks(t, 0, slotsPerEpoch*3, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, canonical))
+	// A fork of two blocks in epoch 1, and a fork before the origin checkpoint.
+	orphans := makeBlocks(t, slotsPerEpoch+1, 2, bytesutil.ToBytes32(sszRootOrDie(t, canonical[slotsPerEpoch-1])))
+	preOrigin := makeBlocks(t, 4, 1, bytesutil.ToBytes32(sszRootOrDie(t, canonical[2])))
+	require.NoError(t, db.SaveBlocks(ctx, append(orphans, preOrigin...)))
+	require.NoError(t, db.SaveOriginCheckpointBlockRoot(ctx, bytesutil.ToBytes32(sszRootOrDie(t, canonical[slotsPerEpoch-1]))))
+
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	orphanRoot := bytesutil.ToBytes32(sszRootOrDie(t, orphans[0]))
+	require.NoError(t, db.SaveState(ctx, st, orphanRoot))
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: orphans[0].Block().Slot(), Root: orphanRoot[:]}))
+	sidecar := generateBlobSidecar(t, 0)
+	sidecar.Slot = orphans[0].Block().Slot()
+	sidecar.BlockRoot = orphanRoot[:]
+	require.NoError(t, db.SaveBlobSidecar(ctx, []*ethpb.BlobSidecar{sidecar}))
+
+	cp := &ethpb.Checkpoint{Epoch: 2, Root: sszRootOrDie(t, canonical[2*slotsPerEpoch-1])}
+	require.NoError(t, db.SaveState(ctx, st, bytesutil.ToBytes32(cp.Root)))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, cp))
+
+	_, _, err = db.PruneOrphanedBlocks(ctx, 0, 3)
+	require.ErrorContains(t, "after the finalized epoch", err)
+
+	pruned, reclaimed, err := db.PruneOrphanedBlocks(ctx, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), pruned)
+	assert.Equal(t, true, reclaimed > uint64(len(sidecar.Blob)))
+	for _, b := range orphans {
+		root := bytesutil.ToBytes32(sszRootOrDie(t, b))
+		assert.Equal(t, false, db.HasBlock(ctx, root))
+	}
+	assert.Equal(t, false, db.HasState(ctx, orphanRoot))
+	assert.Equal(t, false, db.HasStateSummary(ctx, orphanRoot))
+	_, err = db.BlobSidecarsByRoot(ctx, orphanRoot)
+	require.ErrorIs(t, err, ErrNotFound)
+	roots, err := db.BlockRoots(ctx, filters.NewFilter().SetStartSlot(orphans[0].Block().Slot()).SetEndSlot(orphans[1].Block().Slot()))
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(roots))
+
+	for _, b := range append(canonical, preOrigin...) {
+		root := bytesutil.ToBytes32(sszRootOrDie(t, b))
+		assert.Equal(t, true, db.HasBlock(ctx, root), "Expected block at slot %d to be kept", b.Block().Slot())
+	}
+
+	// Pruning the same epochs again is a no-op.
+	pruned, reclaimed, err = db.PruneOrphanedBlocks(ctx, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint(0), pruned)
+	assert.Equal(t, uint64(0), reclaimed)
+}
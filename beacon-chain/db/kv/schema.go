@@ -22,6 +22,12 @@ var (
 	feeRecipientBucket      = []byte("fee-recipient")
 	registrationBucket      = []byte("registration")
 
+	// Validator indices tracked by the validator monitor, configured at runtime through the API.
+	monitoredValidatorsBucket = []byte("monitored-validators")
+
+	// Best light client update of each sync committee period, keyed by period.
+	lightClientUpdatesBucket = []byte("light-client-updates")
+
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
@@ -37,6 +43,7 @@ var (
 	attestationTargetRootIndicesBucket  = []byte("attestation-target-root-indices")
 	attestationTargetEpochIndicesBucket = []byte("attestation-target-epoch-indices")
 	finalizedBlockRootsIndexBucket      = []byte("finalized-block-roots-index")
+	finalizedRootIndexBucket            = []byte("finalized-root-index")
 	blockRootValidatorHashesBucket      = []byte("block-root-validator-hashes")
 
 	// Specific item keys.
@@ -51,6 +58,8 @@ var (
 	// determined. If this value changes, the existing data is invalidated, so storing it in the db
 	// allows us to assert at runtime that the db state is still consistent with the runtime state.
 	blobRetentionEpochsKey = []byte("blob-retention-epochs")
+	// stateCompressionDictKey stores the zstd dictionary used to compress states when state compression is enabled.
+	stateCompressionDictKey = []byte("state-compression-dict")
 
 	// Below keys are used to identify objects are to be fork compatible.
 	// Objects that are only compatible with specific forks should be prefixed with such keys.
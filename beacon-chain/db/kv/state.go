@@ -131,9 +131,12 @@ func (s *Store) SaveStates(ctx context.Context, states []state.ReadOnlyBeaconSta
 		return errors.New("nil state")
 	}
 	startTime := time.Now()
+	if err := s.ensureStateCompressor(states); err != nil {
+		return err
+	}
 	multipleEncs := make([][]byte, len(states))
 	for i, st := range states {
-		stateBytes, err := marshalState(ctx, st)
+		stateBytes, err := s.marshalState(ctx, st)
 		if err != nil {
 			return err
 		}
@@ -170,6 +173,9 @@ func (s *Store) SaveStatesEfficient(ctx context.Context, states []state.ReadOnly
 	if states == nil {
 		return errors.New("nil state")
 	}
+	if err := s.ensureStateCompressor(states); err != nil {
+		return err
+	}
 	validatorKeys, validatorsEntries, err := getValidators(states)
 	if err != nil {
 		return err
@@ -235,10 +241,11 @@ func (s *Store) saveStatesEfficientInternal(ctx context.Context, tx *bolt.Tx, bl
 			}
 			valEntries := pbState.Validators
 			pbState.Validators = make([]*ethpb.Validator, 0)
-			encodedState, err := encode(ctx, pbState)
+			rawObj, err := pbState.MarshalSSZ()
 			if err != nil {
 				return err
 			}
+			encodedState := s.encodeState(rawObj)
 			pbState.Validators = valEntries
 			if err := bucket.Put(rt[:], encodedState); err != nil {
 				return err
@@ -257,7 +264,7 @@ func (s *Store) saveStatesEfficientInternal(ctx context.Context, tx *bolt.Tx, bl
 			if err != nil {
 				return err
 			}
-			encodedState := snappy.Encode(nil, append(altairKey, rawObj...))
+			encodedState := s.encodeState(append(altairKey, rawObj...))
 			if err := bucket.Put(rt[:], encodedState); err != nil {
 				return err
 			}
@@ -276,7 +283,7 @@ func (s *Store) saveStatesEfficientInternal(ctx context.Context, tx *bolt.Tx, bl
 			if err != nil {
 				return err
 			}
-			encodedState := snappy.Encode(nil, append(bellatrixKey, rawObj...))
+			encodedState := s.encodeState(append(bellatrixKey, rawObj...))
 			if err := bucket.Put(rt[:], encodedState); err != nil {
 				return err
 			}
@@ -295,7 +302,7 @@ func (s *Store) saveStatesEfficientInternal(ctx context.Context, tx *bolt.Tx, bl
 			if err != nil {
 				return err
 			}
-			encodedState := snappy.Encode(nil, append(capellaKey, rawObj...))
+			encodedState := s.encodeState(append(capellaKey, rawObj...))
 			if err := bucket.Put(rt[:], encodedState); err != nil {
 				return err
 			}
@@ -314,7 +321,7 @@ func (s *Store) saveStatesEfficientInternal(ctx context.Context, tx *bolt.Tx, bl
 			if err != nil {
 				return err
 			}
-			encodedState := snappy.Encode(nil, append(capellaKey, rawObj...))
+			encodedState := s.encodeState(append(capellaKey, rawObj...))
 			if err := bucket.Put(rt[:], encodedState); err != nil {
 				return err
 			}
@@ -435,80 +442,85 @@ func (s *Store) DeleteState(ctx context.Context, blockRoot [32]byte) error {
 	defer span.End()
 
 	return s.db.Update(func(tx *bolt.Tx) error {
-		bkt := tx.Bucket(blocksBucket)
-		genesisBlockRoot := bkt.Get(genesisBlockRootKey)
+		return s.deleteState(ctx, tx, blockRoot)
+	})
+}
 
-		bkt = tx.Bucket(checkpointBucket)
-		enc := bkt.Get(finalizedCheckpointKey)
-		finalized := &ethpb.Checkpoint{}
-		if enc == nil {
-			finalized = &ethpb.Checkpoint{Root: genesisBlockRoot}
-		} else if err := decode(ctx, enc, finalized); err != nil {
-			return err
-		}
+// deleteState deletes the state of the block root in the given transaction.
+func (s *Store) deleteState(ctx context.Context, tx *bolt.Tx, blockRoot [32]byte) error {
+	bkt := tx.Bucket(blocksBucket)
+	genesisBlockRoot := bkt.Get(genesisBlockRootKey)
 
-		enc = bkt.Get(justifiedCheckpointKey)
-		justified := &ethpb.Checkpoint{}
-		if enc == nil {
-			justified = &ethpb.Checkpoint{Root: genesisBlockRoot}
-		} else if err := decode(ctx, enc, justified); err != nil {
-			return err
-		}
+	bkt = tx.Bucket(checkpointBucket)
+	enc := bkt.Get(finalizedCheckpointKey)
+	finalized := &ethpb.Checkpoint{}
+	if enc == nil {
+		finalized = &ethpb.Checkpoint{Root: genesisBlockRoot}
+	} else if err := decode(ctx, enc, finalized); err != nil {
+		return err
+	}
 
-		bkt = tx.Bucket(stateBucket)
-		// Safeguard against deleting genesis, finalized, head state.
-		if bytes.Equal(blockRoot[:], finalized.Root) || bytes.Equal(blockRoot[:], genesisBlockRoot) || bytes.Equal(blockRoot[:], justified.Root) {
-			return ErrDeleteJustifiedAndFinalized
-		}
+	enc = bkt.Get(justifiedCheckpointKey)
+	justified := &ethpb.Checkpoint{}
+	if enc == nil {
+		justified = &ethpb.Checkpoint{Root: genesisBlockRoot}
+	} else if err := decode(ctx, enc, justified); err != nil {
+		return err
+	}
 
-		// Nothing to delete if state doesn't exist.
-		enc = bkt.Get(blockRoot[:])
-		if enc == nil {
-			return nil
-		}
+	bkt = tx.Bucket(stateBucket)
+	// Safeguard against deleting genesis, finalized, head state.
+	if bytes.Equal(blockRoot[:], finalized.Root) || bytes.Equal(blockRoot[:], genesisBlockRoot) || bytes.Equal(blockRoot[:], justified.Root) {
+		return ErrDeleteJustifiedAndFinalized
+	}
 
-		slot, err := s.slotByBlockRoot(ctx, tx, blockRoot[:])
-		if err != nil {
-			return err
-		}
-		indicesByBucket := createStateIndicesFromStateSlot(ctx, slot)
-		if err := deleteValueForIndices(ctx, indicesByBucket, blockRoot[:], tx); err != nil {
-			return errors.Wrap(err, "could not delete root for DB indices")
-		}
+	// Nothing to delete if state doesn't exist.
+	enc = bkt.Get(blockRoot[:])
+	if enc == nil {
+		return nil
+	}
 
-		ok, err := s.isStateValidatorMigrationOver()
+	slot, err := s.slotByBlockRoot(ctx, tx, blockRoot[:])
+	if err != nil {
+		return err
+	}
+	indicesByBucket := createStateIndicesFromStateSlot(ctx, slot)
+	if err := deleteValueForIndices(ctx, indicesByBucket, blockRoot[:], tx); err != nil {
+		return errors.Wrap(err, "could not delete root for DB indices")
+	}
+
+	ok, err := s.isStateValidatorMigrationOver()
+	if err != nil {
+		return err
+	}
+	if ok {
+		// remove the validator entry keys for the corresponding state.
+		idxBkt := tx.Bucket(blockRootValidatorHashesBucket)
+		compressedValidatorHashes := idxBkt.Get(blockRoot[:])
+		err = idxBkt.Delete(blockRoot[:])
 		if err != nil {
 			return err
 		}
-		if ok {
-			// remove the validator entry keys for the corresponding state.
-			idxBkt := tx.Bucket(blockRootValidatorHashesBucket)
-			compressedValidatorHashes := idxBkt.Get(blockRoot[:])
-			err = idxBkt.Delete(blockRoot[:])
-			if err != nil {
-				return err
-			}
 
-			// remove the respective validator entries from the cache.
-			if len(compressedValidatorHashes) == 0 {
-				return errors.Errorf("invalid compressed validator keys length")
-			}
-			validatorHashes, sErr := snappy.Decode(nil, compressedValidatorHashes)
-			if sErr != nil {
-				return errors.Wrap(sErr, "failed to uncompress validator keys")
-			}
-			if len(validatorHashes)%hashLength != 0 {
-				return errors.Errorf("invalid validator keys length: %d", len(validatorHashes))
-			}
-			for i := 0; i < len(validatorHashes); i += hashLength {
-				key := validatorHashes[i : i+hashLength]
-				s.validatorEntryCache.Del(key)
-				validatorEntryCacheDelete.Inc()
-			}
+		// remove the respective validator entries from the cache.
+		if len(compressedValidatorHashes) == 0 {
+			return errors.Errorf("invalid compressed validator keys length")
+		}
+		validatorHashes, sErr := snappy.Decode(nil, compressedValidatorHashes)
+		if sErr != nil {
+			return errors.Wrap(sErr, "failed to uncompress validator keys")
+		}
+		if len(validatorHashes)%hashLength != 0 {
+			return errors.Errorf("invalid validator keys length: %d", len(validatorHashes))
 		}
+		for i := 0; i < len(validatorHashes); i += hashLength {
+			key := validatorHashes[i : i+hashLength]
+			s.validatorEntryCache.Del(key)
+			validatorEntryCacheDelete.Inc()
+		}
+	}
 
-		return bkt.Delete(blockRoot[:])
-	})
+	return bkt.Delete(blockRoot[:])
 }
 
 // DeleteStates by block roots.
@@ -528,7 +540,7 @@ func (s *Store) DeleteStates(ctx context.Context, blockRoots [][32]byte) error {
 // unmarshal state from marshaled proto state bytes to versioned state struct type.
 func (s *Store) unmarshalState(_ context.Context, enc []byte, validatorEntries []*ethpb.Validator) (state.BeaconState, error) {
 	var err error
-	enc, err = snappy.Decode(nil, enc)
+	enc, err = s.decodeState(enc)
 	if err != nil {
 		return nil, err
 	}
@@ -607,14 +619,21 @@ func (s *Store) unmarshalState(_ context.Context, enc []byte, validatorEntries [
 }
 
 // marshal versioned state from struct type down to bytes.
-func marshalState(ctx context.Context, st state.ReadOnlyBeaconState) ([]byte, error) {
+func (s *Store) marshalState(ctx context.Context, st state.ReadOnlyBeaconState) ([]byte, error) {
 	switch st.ToProtoUnsafe().(type) {
 	case *ethpb.BeaconState:
 		rState, ok := st.ToProtoUnsafe().(*ethpb.BeaconState)
 		if !ok {
 			return nil, errors.New("non valid inner state")
 		}
-		return encode(ctx, rState)
+		if rState == nil {
+			return nil, errors.New("nil state")
+		}
+		rawObj, err := rState.MarshalSSZ()
+		if err != nil {
+			return nil, err
+		}
+		return s.encodeState(rawObj), nil
 	case *ethpb.BeaconStateAltair:
 		rState, ok := st.ToProtoUnsafe().(*ethpb.BeaconStateAltair)
 		if !ok {
@@ -627,7 +646,7 @@ func marshalState(ctx context.Context, st state.ReadOnlyBeaconState) ([]byte, er
 		if err != nil {
 			return nil, err
 		}
-		return snappy.Encode(nil, append(altairKey, rawObj...)), nil
+		return s.encodeState(append(altairKey, rawObj...)), nil
 	case *ethpb.BeaconStateBellatrix:
 		rState, ok := st.ToProtoUnsafe().(*ethpb.BeaconStateBellatrix)
 		if !ok {
@@ -640,7 +659,7 @@ func marshalState(ctx context.Context, st state.ReadOnlyBeaconState) ([]byte, er
 		if err != nil {
 			return nil, err
 		}
-		return snappy.Encode(nil, append(bellatrixKey, rawObj...)), nil
+		return s.encodeState(append(bellatrixKey, rawObj...)), nil
 	case *ethpb.BeaconStateCapella:
 		rState, ok := st.ToProtoUnsafe().(*ethpb.BeaconStateCapella)
 		if !ok {
@@ -653,7 +672,7 @@ func marshalState(ctx context.Context, st state.ReadOnlyBeaconState) ([]byte, er
 		if err != nil {
 			return nil, err
 		}
-		return snappy.Encode(nil, append(capellaKey, rawObj...)), nil
+		return s.encodeState(append(capellaKey, rawObj...)), nil
 	case *ethpb.BeaconStateDeneb:
 		rState, ok := st.ToProtoUnsafe().(*ethpb.BeaconStateDeneb)
 		if !ok {
@@ -666,7 +685,7 @@ func marshalState(ctx context.Context, st state.ReadOnlyBeaconState) ([]byte, er
 		if err != nil {
 			return nil, err
 		}
-		return snappy.Encode(nil, append(denebKey, rawObj...)), nil
+		return s.encodeState(append(denebKey, rawObj...)), nil
 	default:
 		return nil, errors.New("invalid inner state")
 	}
@@ -0,0 +1,423 @@
+package kv
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"encoding/binary"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// stateDictionaryMaxSize bounds the size of the zstd dictionary trained on stored states.
+	stateDictionaryMaxSize = 112 * 1024
+	// stateDictionarySamples is the number of stored states used to build the dictionary.
+	stateDictionarySamples = 8
+	// stateDictionaryID is the zstd dictionary ID written in the frame headers of compressed states.
+	stateDictionaryID = 0x707273 // "prs"
+	// stateDictionaryTrainingSize bounds the number of bytes of each sample the dictionary is trained on.
+	stateDictionaryTrainingSize = 4 * 1024 * 1024
+	// dictTrainingWindow is the size of the windows taken from samples larger than stateDictionaryTrainingSize.
+	dictTrainingWindow = 64 * 1024
+	// dictSegmentSize is the size of the segments of samples the dictionary is made of.
+	dictSegmentSize = 256
+	// dictTableBits is the number of bits of the hashes of the 8-byte sequences counted when training the dictionary.
+	dictTableBits = 22
+)
+
+var migrationStateCompressionKey = []byte("migration_state_compression")
+
+// zstdFrameMagic prefixes every zstd frame. Snappy encoded states begin with the varint
+// encoded length of the decoded state, and a state is never small enough for that varint
+// to collide with this prefix, so both encodings can live side by side in the state bucket.
+var zstdFrameMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// stateCompressor compresses and decompresses state encodings with a zstd
+// dictionary trained on previously stored states.
+type stateCompressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+// The default level of the encoder only matches the dictionary within the first block of a state, so states are
+// compressed at the better compression level, which matches it throughout the state.
+func newStateCompressor(dict []byte) (*stateCompressor, error) {
+	encoder, err := zstd.NewWriter(nil,
+		zstd.WithEncoderLevel(zstd.SpeedBetterCompression),
+		zstd.WithEncoderDictRaw(stateDictionaryID, dict),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create zstd encoder")
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderDictRaw(stateDictionaryID, dict))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create zstd decoder")
+	}
+	return &stateCompressor{encoder: encoder, decoder: decoder}, nil
+}
+
+func (c *stateCompressor) compress(raw []byte) []byte {
+	return c.encoder.EncodeAll(raw, nil)
+}
+
+func (c *stateCompressor) decompress(enc []byte) ([]byte, error) {
+	return c.decoder.DecodeAll(enc, nil)
+}
+
+// trainStateDictionary trains a raw zstd dictionary on sample state encodings, following the COVER algorithm of the
+// zstd dictionary builder. States of a network share much of their content (the validator registry, historical
+// roots, the fork data), which the compression of a single state cannot take advantage of. The dictionary is made of
+// the segments of the samples whose 8-byte sequences occur in the most samples, picked greedily so that the sequences
+// of a picked segment do not count towards the next ones. Sequences of zeros are ignored, as zstd compresses them well
+// without a dictionary. The best segments are placed at the end of the dictionary, where zstd prefers matches.
+func trainStateDictionary(samples [][]byte) []byte {
+	if len(samples) == 0 {
+		return nil
+	}
+	minOccurrences := uint16(2)
+	if len(samples) == 1 {
+		minOccurrences = 1
+	}
+	excerpts := make([][]byte, len(samples))
+	for i, sample := range samples {
+		excerpts[i] = trainingExcerpt(sample)
+	}
+
+	// Count the number of samples each sequence occurs in. Sequences are hashed into a fixed size table, so that the
+	// memory used does not depend on the size of the samples.
+	freq := make([]uint16, 1<<dictTableBits)
+	stamps := make([]uint32, 1<<dictTableBits)
+	var stamp uint32
+	for _, ex := range excerpts {
+		stamp++
+		for p := 0; p+8 <= len(ex); p++ {
+			v := binary.LittleEndian.Uint64(ex[p:])
+			if v == 0 {
+				continue
+			}
+			h := dmerHash(v)
+			if stamps[h] != stamp {
+				stamps[h] = stamp
+				freq[h]++
+			}
+		}
+	}
+	// The score of a segment is the sum of the occurrences of its distinct sequences which are shared by samples.
+	score := func(seg []byte) uint64 {
+		stamp++
+		var total uint64
+		for p := 0; p+8 <= len(seg); p++ {
+			v := binary.LittleEndian.Uint64(seg[p:])
+			if v == 0 {
+				continue
+			}
+			h := dmerHash(v)
+			if stamps[h] == stamp {
+				continue
+			}
+			stamps[h] = stamp
+			if freq[h] >= minOccurrences {
+				total += uint64(freq[h])
+			}
+		}
+		return total
+	}
+
+	segments := make(dictSegments, 0)
+	for _, ex := range excerpts {
+		for off := 0; off+dictSegmentSize <= len(ex); off += dictSegmentSize {
+			seg := ex[off : off+dictSegmentSize]
+			if sc := score(seg); sc > 0 {
+				segments = append(segments, &dictSegment{data: seg, score: sc})
+			}
+		}
+	}
+	heap.Init(&segments)
+	// Scores only decrease as segments are picked, so a segment whose updated score is still the best is picked
+	// without rescoring the others.
+	picked := make([][]byte, 0, stateDictionaryMaxSize/dictSegmentSize)
+	for segments.Len() > 0 && (len(picked)+1)*dictSegmentSize <= stateDictionaryMaxSize {
+		seg := heap.Pop(&segments).(*dictSegment)
+		seg.score = score(seg.data)
+		if seg.score == 0 {
+			continue
+		}
+		if segments.Len() > 0 && seg.score < segments[0].score {
+			heap.Push(&segments, seg)
+			continue
+		}
+		picked = append(picked, seg.data)
+		for p := 0; p+8 <= len(seg.data); p++ {
+			freq[dmerHash(binary.LittleEndian.Uint64(seg.data[p:]))] = 0
+		}
+	}
+
+	dict := make([]byte, 0, len(picked)*dictSegmentSize)
+	for i := len(picked) - 1; i >= 0; i-- {
+		dict = append(dict, picked[i]...)
+	}
+	return dict
+}
+
+// trainingExcerpt returns the part of a sample that the dictionary is trained on: the whole sample if it is small
+// enough, and evenly spaced windows of it otherwise, so that every part of the state is represented.
+func trainingExcerpt(sample []byte) []byte {
+	if len(sample) <= stateDictionaryTrainingSize {
+		return sample
+	}
+	windows := stateDictionaryTrainingSize / dictTrainingWindow
+	stride := len(sample) / windows
+	excerpt := make([]byte, 0, stateDictionaryTrainingSize)
+	for i := 0; i < windows; i++ {
+		excerpt = append(excerpt, sample[i*stride:i*stride+dictTrainingWindow]...)
+	}
+	return excerpt
+}
+
+func dmerHash(v uint64) uint64 {
+	return (v * 0x9E3779B97F4A7C15) >> (64 - dictTableBits)
+}
+
+type dictSegment struct {
+	data  []byte
+	score uint64
+}
+
+// dictSegments is a max heap of segments by score.
+type dictSegments []*dictSegment
+
+func (s dictSegments) Len() int           { return len(s) }
+func (s dictSegments) Less(i, j int) bool { return s[i].score > s[j].score }
+func (s dictSegments) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+func (s *dictSegments) Push(x interface{}) {
+	*s = append(*s, x.(*dictSegment))
+}
+
+func (s *dictSegments) Pop() interface{} {
+	old := *s
+	n := len(old)
+	seg := old[n-1]
+	*s = old[:n-1]
+	return seg
+}
+
+// stateCodec guards the state compressor of a store, which is created lazily
+// once a dictionary is available.
+type stateCodec struct {
+	lock       sync.RWMutex
+	compressor *stateCompressor
+}
+
+func (c *stateCodec) get() *stateCompressor {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.compressor
+}
+
+func (c *stateCodec) set(compressor *stateCompressor) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.compressor = compressor
+}
+
+// loadStateCompressor initializes the state compressor from the dictionary stored
+// in the database, if any. It is called on startup regardless of the feature flag, so that
+// states that were compressed before the flag got disabled can still be read.
+func (s *Store) loadStateCompressor() error {
+	var dict []byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		d := tx.Bucket(chainMetadataBucket).Get(stateCompressionDictKey)
+		if len(d) > 0 {
+			dict = make([]byte, len(d))
+			copy(dict, d)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(dict) == 0 {
+		return nil
+	}
+	compressor, err := newStateCompressor(dict)
+	if err != nil {
+		return err
+	}
+	s.stateCodec.set(compressor)
+	return nil
+}
+
+// trainStateCompressor trains a dictionary on the given raw state encodings, persists it in
+// the metadata bucket and sets up the store's compressor with it. It is a no-op if a compressor
+// already exists or if the samples have no content to train on.
+func (s *Store) trainStateCompressor(tx *bolt.Tx, samples [][]byte) error {
+	if s.stateCodec.get() != nil || len(samples) == 0 {
+		return nil
+	}
+	dict := trainStateDictionary(samples)
+	if len(dict) == 0 {
+		return nil
+	}
+	compressor, err := newStateCompressor(dict)
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(chainMetadataBucket).Put(stateCompressionDictKey, dict); err != nil {
+		return err
+	}
+	s.stateCodec.set(compressor)
+	log.WithField("dictionarySize", len(dict)).Info("Built state compression dictionary")
+	return nil
+}
+
+// ensureStateCompressor builds the compression dictionary out of the given states when state
+// compression is enabled but no dictionary exists yet, such as for a fresh database.
+func (s *Store) ensureStateCompressor(states []state.ReadOnlyBeaconState) error {
+	if !features.Get().EnableStateCompression || s.stateCodec.get() != nil {
+		return nil
+	}
+	samples := make([][]byte, 0, len(states))
+	for _, st := range states {
+		raw, err := st.MarshalSSZ()
+		if err != nil {
+			return err
+		}
+		samples = append(samples, raw)
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return s.trainStateCompressor(tx, samples)
+	})
+}
+
+// encodeState compresses the raw, fork prefixed, SSZ encoding of a state for storage.
+// States are compressed with zstd when the feature is enabled and a dictionary is available,
+// and with snappy otherwise.
+func (s *Store) encodeState(raw []byte) []byte {
+	if compressor := s.stateCodec.get(); compressor != nil && features.Get().EnableStateCompression {
+		return compressor.compress(raw)
+	}
+	return snappy.Encode(nil, raw)
+}
+
+// decodeState returns the raw encoding of a stored state, regardless of the compression used.
+func (s *Store) decodeState(enc []byte) ([]byte, error) {
+	if !bytes.HasPrefix(enc, zstdFrameMagic) {
+		return snappy.Decode(nil, enc)
+	}
+	compressor := s.stateCodec.get()
+	if compressor == nil {
+		return nil, errors.New("state is zstd compressed but no compression dictionary is stored in the database")
+	}
+	return compressor.decompress(enc)
+}
+
+// migrateStateCompression re-encodes all snappy compressed states with the zstd dictionary,
+// building the dictionary from the latest stored states first if necessary.
+func (s *Store) migrateStateCompression(ctx context.Context) error {
+	if !features.Get().EnableStateCompression {
+		return nil
+	}
+	done := false
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		done = bytes.Equal(tx.Bucket(migrationsBucket).Get(migrationStateCompressionKey), migrationCompleted)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if done {
+		return nil
+	}
+
+	var keys [][]byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		k, err := stateBucketKeys(tx.Bucket(stateBucket))
+		if err != nil {
+			return err
+		}
+		keys = k
+		return nil
+	}); err != nil {
+		return err
+	}
+	log.Infof("Performing a one-time compression of %d states in bucket %s. It will take few minutes", len(keys), stateBucket)
+
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		return s.trainStateCompressor(tx, s.stateDictionarySamples(tx))
+	}); err != nil {
+		return errors.Wrap(err, "could not build state compression dictionary")
+	}
+
+	var before, after int
+	for i := 0; i < len(keys); i += batchSize {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		end := i + batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		if err := s.db.Update(func(tx *bolt.Tx) error {
+			bkt := tx.Bucket(stateBucket)
+			for _, k := range keys[i:end] {
+				enc := bkt.Get(k)
+				if len(enc) == 0 || bytes.HasPrefix(enc, zstdFrameMagic) {
+					continue
+				}
+				raw, err := snappy.Decode(nil, enc)
+				if err != nil {
+					return err
+				}
+				compressed := s.encodeState(raw)
+				before += len(enc)
+				after += len(compressed)
+				if err := bkt.Put(k, compressed); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(migrationsBucket).Put(migrationStateCompressionKey, migrationCompleted)
+	}); err != nil {
+		return err
+	}
+	log.WithFields(map[string]interface{}{
+		"snappyBytes": before,
+		"zstdBytes":   after,
+	}).Info("Migration of state compression completed")
+	return nil
+}
+
+// stateDictionarySamples returns the raw encodings of up to stateDictionarySamples of
+// the most recent states in the state bucket, ordered from oldest to newest.
+func (s *Store) stateDictionarySamples(tx *bolt.Tx) [][]byte {
+	var samples [][]byte
+	c := tx.Bucket(stateSlotIndicesBucket).Cursor()
+	for k, v := c.Last(); k != nil && len(samples) < stateDictionarySamples; k, v = c.Prev() {
+		// Each slot index may contain multiple roots, use the first one.
+		if len(v) < hashLength {
+			continue
+		}
+		enc := tx.Bucket(stateBucket).Get(v[:hashLength])
+		if len(enc) == 0 {
+			continue
+		}
+		raw, err := s.decodeState(enc)
+		if err != nil {
+			continue
+		}
+		samples = append([][]byte{raw}, samples...)
+	}
+	return samples
+}
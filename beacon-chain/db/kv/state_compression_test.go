@@ -0,0 +1,156 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestState_CompressedSaveRetrieve(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableStateCompression: true})
+	defer resetCfg()
+	db := setupDB(t)
+	ctx := context.Background()
+
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, st.SetSlot(100))
+	r := [32]byte{'A'}
+	require.NoError(t, db.SaveState(ctx, st, r))
+
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(stateBucket).Get(r[:])
+		assert.Equal(t, true, bytes.HasPrefix(enc, zstdFrameMagic), "state was not zstd compressed")
+		assert.NotEqual(t, 0, len(tx.Bucket(chainMetadataBucket).Get(stateCompressionDictKey)), "dictionary was not saved")
+		return nil
+	}))
+
+	saved, err := db.State(ctx, r)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, st.ToProtoUnsafe(), saved.ToProtoUnsafe())
+
+	// Compressed states remain readable once the feature is disabled.
+	resetCfg()
+	r2 := [32]byte{'B'}
+	require.NoError(t, db.SaveState(ctx, st, r2))
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(stateBucket).Get(r2[:])
+		assert.Equal(t, false, bytes.HasPrefix(enc, zstdFrameMagic), "state should be snappy compressed")
+		return nil
+	}))
+	saved, err = db.State(ctx, r)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, st.ToProtoUnsafe(), saved.ToProtoUnsafe())
+}
+
+func TestStore_migrateStateCompression(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	st, _ := util.DeterministicGenesisStateBellatrix(t, 64)
+	roots := [][32]byte{{'A'}, {'B'}, {'C'}}
+	for i, r := range roots {
+		require.NoError(t, st.SetSlot(st.Slot()+1))
+		require.NoError(t, db.SaveState(ctx, st, r), "could not save state %d", i)
+	}
+
+	resetCfg := features.InitWithReset(&features.Flags{EnableStateCompression: true})
+	defer resetCfg()
+	require.NoError(t, db.RunMigrations(ctx))
+
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		for _, r := range roots {
+			enc := tx.Bucket(stateBucket).Get(r[:])
+			assert.Equal(t, true, bytes.HasPrefix(enc, zstdFrameMagic), "state %#x was not migrated", r)
+		}
+		assert.DeepEqual(t, migrationCompleted, tx.Bucket(migrationsBucket).Get(migrationStateCompressionKey))
+		return nil
+	}))
+	saved, err := db.State(ctx, roots[2])
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, st.ToProtoUnsafe(), saved.ToProtoUnsafe())
+}
+
+func TestTrainStateDictionary(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateCapella(t, 256)
+	samples := make([][]byte, 0, stateDictionarySamples)
+	for i := 0; i < stateDictionarySamples; i++ {
+		require.NoError(t, st.SetSlot(st.Slot()+1))
+		require.NoError(t, st.UpdateBalancesAtIndex(primitives.ValidatorIndex(i), uint64(i)))
+		raw, err := st.MarshalSSZ()
+		require.NoError(t, err)
+		samples = append(samples, raw)
+	}
+	dict := trainStateDictionary(samples)
+	assert.Equal(t, true, len(dict) > 0 && len(dict) <= stateDictionaryMaxSize, "unexpected dictionary size %d", len(dict))
+
+	// The dictionary holds the content shared by states, such as the validator registry, which the compression of a
+	// single state cannot take advantage of.
+	require.NoError(t, st.SetSlot(st.Slot()+1))
+	raw, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	compressor, err := newStateCompressor(dict)
+	require.NoError(t, err)
+	plain, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBetterCompression))
+	require.NoError(t, err)
+	compressed := compressor.compress(raw)
+	assert.Equal(t, true, len(compressed) < len(plain.EncodeAll(raw, nil)), "dictionary did not improve compression")
+	decompressed, err := compressor.decompress(compressed)
+	require.NoError(t, err)
+	assert.DeepEqual(t, raw, decompressed)
+
+	assert.Equal(t, 0, len(trainStateDictionary(nil)))
+	assert.Equal(t, 0, len(trainStateDictionary([][]byte{make([]byte, 1024)})))
+}
+
+func BenchmarkStateCompression(b *testing.B) {
+	st, _ := util.DeterministicGenesisStateCapella(b, 4096)
+	samples := make([][]byte, 0, stateDictionarySamples)
+	for i := 0; i < stateDictionarySamples; i++ {
+		require.NoError(b, st.SetSlot(st.Slot()+1))
+		require.NoError(b, st.UpdateBalancesAtIndex(0, uint64(i)))
+		raw, err := st.MarshalSSZ()
+		require.NoError(b, err)
+		samples = append(samples, raw)
+	}
+	compressor, err := newStateCompressor(trainStateDictionary(samples))
+	require.NoError(b, err)
+	require.NoError(b, st.SetSlot(st.Slot()+1))
+	raw, err := st.MarshalSSZ()
+	require.NoError(b, err)
+
+	b.Run("snappy", func(b *testing.B) {
+		b.ReportAllocs()
+		var size int
+		for i := 0; i < b.N; i++ {
+			size = len(snappy.Encode(nil, raw))
+		}
+		b.ReportMetric(float64(size)/float64(len(raw)), "ratio")
+	})
+	plain, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBetterCompression))
+	require.NoError(b, err)
+	b.Run("zstd-no-dictionary", func(b *testing.B) {
+		b.ReportAllocs()
+		var size int
+		for i := 0; i < b.N; i++ {
+			size = len(plain.EncodeAll(raw, nil))
+		}
+		b.ReportMetric(float64(size)/float64(len(raw)), "ratio")
+	})
+	b.Run("zstd", func(b *testing.B) {
+		b.ReportAllocs()
+		var size int
+		for i := 0; i < b.N; i++ {
+			size = len(compressor.compress(raw))
+		}
+		b.ReportMetric(float64(size)/float64(len(raw)), "ratio")
+	})
+}
@@ -0,0 +1,404 @@
+package slasherkv
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	lruwrpr "github.com/prysmaticlabs/prysm/v4/cache/lru"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+)
+
+// The min and max span chunks of slasher are stored outside of BoltDB, in flat files
+// laid out as follows:
+//
+//	<datadir>/chunks/<kind>/<segment>.chunks
+//
+// Chunks are addressed by the flat index encoded in their disk key. A segment file holds
+// chunksPerSegment consecutive chunks, each in a fixed-size slot, so a chunk is read or
+// overwritten in place without rewriting any other data:
+//
+//	segment = [magic | slot capacity | slot 0 | slot 1 | ... | slot chunksPerSegment-1]
+//	slot    = [chunk length | chunk values as little-endian uint16 ... padding]
+//
+// A chunk length of zero marks an empty slot, so resetting a chunk is O(1). Segment files
+// are memory-mapped where the platform allows it, and are synced to disk once per batch of
+// saved chunks rather than once per chunk.
+const (
+	chunksDirName          = "chunks"
+	chunkSegmentFileSuffix = ".chunks"
+	// With the default slasher parameters, a validator chunk index spans 4096 / 16 = 256 chunks,
+	// so that a segment file holds the chunks of a single validator chunk index.
+	chunksPerSegment         = 256
+	chunkSegmentMagic        = uint32(0x534c4348) // "SLCH"
+	chunkSegmentHeaderLength = 8
+	chunkSlotHeaderLength    = 4
+	// The least recently used segment files are closed past this number of open segments, which
+	// bounds the open file descriptors and memory mappings of slasher.
+	maxOpenChunkSegments = 256
+)
+
+// chunkStore is a flat-file store of the min and max span chunks of slasher.
+type chunkStore struct {
+	lock     sync.Mutex
+	dirPath  string
+	segments *lru.Cache
+	// closeErr is the first error met closing an evicted segment, returned by the next operation.
+	closeErr error
+}
+
+type chunkSegmentKey struct {
+	kind  slashertypes.ChunkKind
+	index uint64
+}
+
+// chunkSegment is an open segment file, along with its contents mapped in memory.
+type chunkSegment struct {
+	file         *os.File
+	data         []byte
+	slotCapacity int
+	dirty        bool
+	closed       bool
+}
+
+func newChunkStore(dirPath string) (*chunkStore, error) {
+	if err := file.MkdirAll(dirPath); err != nil {
+		return nil, errors.Wrap(err, "could not create chunks directory")
+	}
+	c := &chunkStore{dirPath: dirPath}
+	c.segments = lruwrpr.NewWithEvict(maxOpenChunkSegments, c.closeEvictedSegment)
+	return c, nil
+}
+
+// Syncs and closes a segment evicted from the open segments. It is called with the lock held.
+func (c *chunkStore) closeEvictedSegment(_, value interface{}) {
+	segment, ok := value.(*chunkSegment)
+	if !ok {
+		return
+	}
+	segment.closed = true
+	if err := closeChunkSegment(segment); err != nil && c.closeErr == nil {
+		c.closeErr = errors.Wrap(err, "could not close chunk segment")
+	}
+}
+
+// Returns the error met closing evicted segments, if any, and resets it.
+func (c *chunkStore) takeCloseErr() error {
+	err := c.closeErr
+	c.closeErr = nil
+	return err
+}
+
+// load retrieves the chunks of the given kind at the given disk keys, along with whether each of them exists.
+func (c *chunkStore) load(kind slashertypes.ChunkKind, diskKeys [][]byte) ([][]uint16, []bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	chunks := make([][]uint16, 0, len(diskKeys))
+	exists := make([]bool, 0, len(diskKeys))
+	for _, diskKey := range diskKeys {
+		segmentIdx, slotIdx, err := chunkPosition(diskKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		segment, err := c.segment(chunkSegmentKey{kind: kind, index: segmentIdx}, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		if segment == nil {
+			chunks = append(chunks, []uint16{})
+			exists = append(exists, false)
+			continue
+		}
+		chunk := segment.read(slotIdx)
+		if chunk == nil {
+			chunks = append(chunks, []uint16{})
+			exists = append(exists, false)
+			continue
+		}
+		chunks = append(chunks, chunk)
+		exists = append(exists, true)
+	}
+	if err := c.takeCloseErr(); err != nil {
+		return nil, nil, err
+	}
+	return chunks, exists, nil
+}
+
+// save writes the chunks of the given kind at the given disk keys, then syncs every
+// segment written to disk.
+func (c *chunkStore) save(kind slashertypes.ChunkKind, diskKeys [][]byte, chunks [][]uint16) error {
+	if len(diskKeys) != len(chunks) {
+		return fmt.Errorf("got %d disk keys for %d chunks", len(diskKeys), len(chunks))
+	}
+	for _, chunk := range chunks {
+		if len(chunk) == 0 {
+			return errors.New("cannot encode empty chunk")
+		}
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	written := make([]*chunkSegment, 0)
+	for i, diskKey := range diskKeys {
+		segmentIdx, slotIdx, err := chunkPosition(diskKey)
+		if err != nil {
+			return err
+		}
+		segment, err := c.segment(chunkSegmentKey{kind: kind, index: segmentIdx}, len(chunks[i]))
+		if err != nil {
+			return err
+		}
+		if err := segment.write(slotIdx, chunks[i]); err != nil {
+			return err
+		}
+		if !segment.dirty {
+			segment.dirty = true
+			written = append(written, segment)
+		}
+	}
+	for _, segment := range written {
+		// Segments evicted since they were written were synced when closed.
+		if segment.closed {
+			continue
+		}
+		if err := syncChunkSegment(segment); err != nil {
+			return errors.Wrap(err, "could not sync chunk segment to disk")
+		}
+		segment.dirty = false
+	}
+	return c.takeCloseErr()
+}
+
+// close syncs and closes all the open segment files.
+func (c *chunkStore) close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.segments.Purge()
+	return c.takeCloseErr()
+}
+
+// Returns the open segment for the given key, opening its file if needed. If the segment file
+// does not exist, it is created with slots of the given capacity, unless the capacity is zero,
+// in which case nil is returned.
+func (c *chunkStore) segment(key chunkSegmentKey, slotCapacity int) (*chunkSegment, error) {
+	if value, ok := c.segments.Get(key); ok {
+		segment, ok := value.(*chunkSegment)
+		if !ok {
+			return nil, errors.New("could not cast open chunk segment")
+		}
+		return segment, nil
+	}
+	segmentPath := c.segmentPath(key)
+	exists := file.FileExists(segmentPath)
+	if !exists && slotCapacity == 0 {
+		return nil, nil
+	}
+	if err := file.MkdirAll(filepath.Dir(segmentPath)); err != nil {
+		return nil, errors.Wrap(err, "could not create chunks directory")
+	}
+	f, err := os.OpenFile(segmentPath, os.O_RDWR|os.O_CREATE, params.BeaconIoConfig().ReadWritePermissions) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open chunk segment")
+	}
+	if exists {
+		header := make([]byte, chunkSegmentHeaderLength)
+		if _, err := f.ReadAt(header, 0); err != nil {
+			return nil, closeOnError(f, errors.Wrapf(err, "could not read header of chunk segment %s", segmentPath))
+		}
+		if binary.LittleEndian.Uint32(header[:4]) != chunkSegmentMagic {
+			return nil, closeOnError(f, fmt.Errorf("chunk segment %s is corrupted", segmentPath))
+		}
+		slotCapacity = int(binary.LittleEndian.Uint32(header[4:]))
+		info, err := f.Stat()
+		if err != nil {
+			return nil, closeOnError(f, errors.Wrapf(err, "could not stat chunk segment %s", segmentPath))
+		}
+		if info.Size() < int64(chunkSegmentSize(slotCapacity)) {
+			return nil, closeOnError(f, fmt.Errorf("chunk segment %s is truncated", segmentPath))
+		}
+	} else {
+		// The file is truncated to its full size upfront, so that empty slots take no disk space
+		// on file systems supporting sparse files.
+		if err := f.Truncate(int64(chunkSegmentSize(slotCapacity))); err != nil {
+			return nil, closeOnError(f, errors.Wrap(err, "could not allocate chunk segment"))
+		}
+		header := make([]byte, chunkSegmentHeaderLength)
+		binary.LittleEndian.PutUint32(header[:4], chunkSegmentMagic)
+		binary.LittleEndian.PutUint32(header[4:], uint32(slotCapacity))
+		if _, err := f.WriteAt(header, 0); err != nil {
+			return nil, closeOnError(f, errors.Wrap(err, "could not write header of chunk segment"))
+		}
+	}
+	data, err := mapChunkSegment(f, chunkSegmentSize(slotCapacity))
+	if err != nil {
+		return nil, closeOnError(f, errors.Wrapf(err, "could not map chunk segment %s", segmentPath))
+	}
+	segment := &chunkSegment{
+		file:         f,
+		data:         data,
+		slotCapacity: slotCapacity,
+	}
+	c.segments.Add(key, segment)
+	return segment, nil
+}
+
+// forEach calls f with every chunk stored, along with its disk key. Each segment is read
+// with the lock held, then its chunks are passed to f without the lock, so that f may use
+// the chunk store.
+func (c *chunkStore) forEach(
+	ctx context.Context, f func(kind slashertypes.ChunkKind, diskKey []byte, chunk []uint16) error,
+) error {
+	for _, kind := range []slashertypes.ChunkKind{slashertypes.MinSpan, slashertypes.MaxSpan} {
+		indices, err := c.segmentIndices(kind)
+		if err != nil {
+			return err
+		}
+		for _, index := range indices {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			diskKeys, chunks, err := c.segmentChunks(chunkSegmentKey{kind: kind, index: index})
+			if err != nil {
+				return err
+			}
+			for i, diskKey := range diskKeys {
+				if err := f(kind, diskKey, chunks[i]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the indices of the segment files of the given kind, in increasing order.
+func (c *chunkStore) segmentIndices(kind slashertypes.ChunkKind) ([]uint64, error) {
+	entries, err := os.ReadDir(filepath.Join(c.dirPath, chunkKindDirName(kind)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "could not list chunk segments")
+	}
+	indices := make([]uint64, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, chunkSegmentFileSuffix) {
+			continue
+		}
+		index, err := strconv.ParseUint(strings.TrimSuffix(name, chunkSegmentFileSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices, nil
+}
+
+// Returns the chunks stored in the given segment, along with their disk keys.
+func (c *chunkStore) segmentChunks(key chunkSegmentKey) ([][]byte, [][]uint16, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	segment, err := c.segment(key, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	diskKeys := make([][]byte, 0)
+	chunks := make([][]uint16, 0)
+	if segment != nil {
+		for slotIdx := 0; slotIdx < chunksPerSegment; slotIdx++ {
+			chunk := segment.read(slotIdx)
+			if chunk == nil {
+				continue
+			}
+			diskKey := make([]byte, 8)
+			binary.LittleEndian.PutUint64(diskKey, key.index*chunksPerSegment+uint64(slotIdx))
+			diskKeys = append(diskKeys, diskKey)
+			chunks = append(chunks, chunk)
+		}
+	}
+	if err := c.takeCloseErr(); err != nil {
+		return nil, nil, err
+	}
+	return diskKeys, chunks, nil
+}
+
+func (c *chunkStore) segmentPath(key chunkSegmentKey) string {
+	return filepath.Join(c.dirPath, chunkKindDirName(key.kind), strconv.FormatUint(key.index, 10)+chunkSegmentFileSuffix)
+}
+
+func chunkKindDirName(kind slashertypes.ChunkKind) string {
+	if kind == slashertypes.MaxSpan {
+		return "max"
+	}
+	return "min"
+}
+
+// Reads the chunk stored in the given slot, or nil if the slot is empty.
+func (s *chunkSegment) read(slotIdx int) []uint16 {
+	slot := s.slot(slotIdx)
+	length := int(binary.LittleEndian.Uint32(slot[:chunkSlotHeaderLength]))
+	if length == 0 || length > s.slotCapacity {
+		return nil
+	}
+	values := slot[chunkSlotHeaderLength:]
+	chunk := make([]uint16, length)
+	for i := range chunk {
+		chunk[i] = binary.LittleEndian.Uint16(values[2*i:])
+	}
+	return chunk
+}
+
+// Overwrites the given slot with the chunk.
+func (s *chunkSegment) write(slotIdx int, chunk []uint16) error {
+	if len(chunk) > s.slotCapacity {
+		return fmt.Errorf("cannot store chunk of length %d in slots of capacity %d", len(chunk), s.slotCapacity)
+	}
+	slot := s.slot(slotIdx)
+	binary.LittleEndian.PutUint32(slot[:chunkSlotHeaderLength], uint32(len(chunk)))
+	values := slot[chunkSlotHeaderLength:]
+	for i, value := range chunk {
+		binary.LittleEndian.PutUint16(values[2*i:], value)
+	}
+	return nil
+}
+
+func (s *chunkSegment) slot(slotIdx int) []byte {
+	size := chunkSlotSize(s.slotCapacity)
+	offset := chunkSegmentHeaderLength + slotIdx*size
+	return s.data[offset : offset+size]
+}
+
+// Returns the segment index and the slot index of the chunk with the given disk key.
+func chunkPosition(diskKey []byte) (uint64, int, error) {
+	if len(diskKey) != 8 {
+		return 0, 0, fmt.Errorf("invalid chunk disk key length %d", len(diskKey))
+	}
+	idx := binary.LittleEndian.Uint64(diskKey)
+	return idx / chunksPerSegment, int(idx % chunksPerSegment), nil
+}
+
+func chunkSlotSize(slotCapacity int) int {
+	return chunkSlotHeaderLength + 2*slotCapacity
+}
+
+func chunkSegmentSize(slotCapacity int) int {
+	return chunkSegmentHeaderLength + chunksPerSegment*chunkSlotSize(slotCapacity)
+}
+
+func closeOnError(f *os.File, err error) error {
+	if closeErr := f.Close(); closeErr != nil {
+		log.WithError(closeErr).Error("Could not close chunk segment")
+	}
+	return err
+}
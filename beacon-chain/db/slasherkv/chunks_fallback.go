@@ -0,0 +1,33 @@
+//go:build !linux && !darwin
+
+package slasherkv
+
+import (
+	"os"
+)
+
+// Reads the whole segment file in memory, as memory-mapping files is not supported on this platform.
+func mapChunkSegment(f *os.File, size int) ([]byte, error) {
+	data := make([]byte, size)
+	if _, err := f.ReadAt(data, 0); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Writes the in-memory segment back to its file and flushes it to disk.
+func syncChunkSegment(s *chunkSegment) error {
+	if _, err := s.file.WriteAt(s.data, 0); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+func closeChunkSegment(s *chunkSegment) error {
+	if s.dirty {
+		if err := syncChunkSegment(s); err != nil {
+			return closeOnError(s.file, err)
+		}
+	}
+	return s.file.Close()
+}
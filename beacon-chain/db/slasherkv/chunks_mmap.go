@@ -0,0 +1,29 @@
+//go:build linux || darwin
+
+package slasherkv
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// Maps the segment file in memory, so that chunks are read and written without system calls.
+func mapChunkSegment(f *os.File, size int) ([]byte, error) {
+	return unix.Mmap(int(f.Fd()), 0, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
+}
+
+// Flushes the modified pages of the mapped segment file to disk.
+func syncChunkSegment(s *chunkSegment) error {
+	return unix.Msync(s.data, unix.MS_SYNC)
+}
+
+func closeChunkSegment(s *chunkSegment) error {
+	if err := unix.Msync(s.data, unix.MS_SYNC); err != nil {
+		return closeOnError(s.file, err)
+	}
+	if err := unix.Munmap(s.data); err != nil {
+		return closeOnError(s.file, err)
+	}
+	return s.file.Close()
+}
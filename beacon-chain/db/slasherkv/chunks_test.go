@@ -0,0 +1,152 @@
+package slasherkv
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/fastssz"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestStore_SlasherChunks_PersistedAcrossRestarts(t *testing.T) {
+	ctx := context.Background()
+	dirPath := t.TempDir()
+	beaconDB, err := NewKVStore(ctx, dirPath)
+	require.NoError(t, err)
+
+	// Chunk keys span several segment files.
+	chunkKeys := make([][]byte, 0)
+	chunks := make([][]uint16, 0)
+	for _, idx := range []uint64{0, 1, chunksPerSegment - 1, chunksPerSegment, 3*chunksPerSegment + 7} {
+		chunkKeys = append(chunkKeys, ssz.MarshalUint64(make([]byte, 0), idx))
+		chunks = append(chunks, []uint16{uint16(idx), 1, 2, 3})
+	}
+	require.NoError(t, beaconDB.SaveSlasherChunks(ctx, slashertypes.MinSpan, chunkKeys, chunks))
+	require.Equal(t, true, file.FileExists(path.Join(dirPath, chunksDirName, "min", "0"+chunkSegmentFileSuffix)))
+	require.Equal(t, true, file.FileExists(path.Join(dirPath, chunksDirName, "min", "3"+chunkSegmentFileSuffix)))
+	require.Equal(t, false, file.FileExists(path.Join(dirPath, chunksDirName, "min", "2"+chunkSegmentFileSuffix)))
+	require.NoError(t, beaconDB.Close())
+
+	beaconDB, err = NewKVStore(ctx, dirPath)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, beaconDB.Close())
+	}()
+	retrievedChunks, chunksExist, err := beaconDB.LoadSlasherChunks(ctx, slashertypes.MinSpan, chunkKeys)
+	require.NoError(t, err)
+	for i, exists := range chunksExist {
+		require.Equal(t, true, exists)
+		require.DeepEqual(t, chunks[i], retrievedChunks[i])
+	}
+
+	// Chunks not written in an existing segment do not exist.
+	_, chunksExist, err = beaconDB.LoadSlasherChunks(ctx, slashertypes.MinSpan, [][]byte{
+		ssz.MarshalUint64(make([]byte, 0), 2),
+	})
+	require.NoError(t, err)
+	require.DeepEqual(t, []bool{false}, chunksExist)
+}
+
+func TestStore_SlasherChunks_OverwriteAndCapacity(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+	chunkKeys := [][]byte{ssz.MarshalUint64(make([]byte, 0), 5)}
+
+	require.NoError(t, beaconDB.SaveSlasherChunks(ctx, slashertypes.MaxSpan, chunkKeys, [][]uint16{{1, 2, 3, 4}}))
+	require.NoError(t, beaconDB.SaveSlasherChunks(ctx, slashertypes.MaxSpan, chunkKeys, [][]uint16{{5, 6}}))
+	retrievedChunks, _, err := beaconDB.LoadSlasherChunks(ctx, slashertypes.MaxSpan, chunkKeys)
+	require.NoError(t, err)
+	require.DeepEqual(t, []uint16{5, 6}, retrievedChunks[0])
+
+	// Slots of a segment have the capacity of the first chunk written to it.
+	err = beaconDB.SaveSlasherChunks(ctx, slashertypes.MaxSpan, chunkKeys, [][]uint16{{1, 2, 3, 4, 5}})
+	require.ErrorContains(t, "cannot store chunk of length 5 in slots of capacity 4", err)
+
+	err = beaconDB.SaveSlasherChunks(ctx, slashertypes.MaxSpan, [][]byte{{1}}, [][]uint16{{1}})
+	require.ErrorContains(t, "invalid chunk disk key length", err)
+}
+
+func TestChunkStore_EvictsSegments(t *testing.T) {
+	store, err := newChunkStore(t.TempDir())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.close())
+	}()
+
+	// Each chunk is written to its own segment file, more than can be open at once.
+	numSegments := maxOpenChunkSegments + 10
+	chunkKeys := make([][]byte, numSegments)
+	chunks := make([][]uint16, numSegments)
+	for i := range chunkKeys {
+		chunkKeys[i] = ssz.MarshalUint64(make([]byte, 0), uint64(i*chunksPerSegment))
+		chunks[i] = []uint16{uint16(i), 1}
+	}
+	require.NoError(t, store.save(slashertypes.MinSpan, chunkKeys, chunks))
+	require.Equal(t, maxOpenChunkSegments, store.segments.Len())
+
+	// The chunks of evicted segments were synced when closed, and are read again from their files.
+	retrievedChunks, chunksExist, err := store.load(slashertypes.MinSpan, chunkKeys)
+	require.NoError(t, err)
+	require.Equal(t, maxOpenChunkSegments, store.segments.Len())
+	for i, exists := range chunksExist {
+		require.Equal(t, true, exists)
+		require.DeepEqual(t, chunks[i], retrievedChunks[i])
+	}
+}
+
+func TestStore_SlasherChunks_MigratedFromBolt(t *testing.T) {
+	// Migrate the chunks over several batches.
+	batchSize := chunkMigrationBatchSize
+	chunkMigrationBatchSize = 3
+	defer func() {
+		chunkMigrationBatchSize = batchSize
+	}()
+	ctx := context.Background()
+	dirPath := t.TempDir()
+	beaconDB, err := NewKVStore(ctx, dirPath)
+	require.NoError(t, err)
+
+	// Write chunks the way previous versions stored them in BoltDB.
+	chunkKeys := [][]byte{
+		ssz.MarshalUint64(make([]byte, 0), 1),
+		ssz.MarshalUint64(make([]byte, 0), 300),
+	}
+	chunks := [][]uint16{{1, 2}, {3, 4}}
+	require.NoError(t, beaconDB.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(slasherChunksBucket)
+		for _, kind := range []slashertypes.ChunkKind{slashertypes.MinSpan, slashertypes.MaxSpan} {
+			for i, key := range chunkKeys {
+				enc, err := encodeSlasherChunk(chunks[i])
+				if err != nil {
+					return err
+				}
+				if err := bkt.Put(append(ssz.MarshalUint8(make([]byte, 0), uint8(kind)), key...), enc); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}))
+	require.NoError(t, beaconDB.Close())
+
+	beaconDB, err = NewKVStore(ctx, dirPath)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, beaconDB.Close())
+	}()
+	for _, kind := range []slashertypes.ChunkKind{slashertypes.MinSpan, slashertypes.MaxSpan} {
+		retrievedChunks, chunksExist, err := beaconDB.LoadSlasherChunks(ctx, kind, chunkKeys)
+		require.NoError(t, err)
+		require.DeepEqual(t, []bool{true, true}, chunksExist)
+		require.DeepEqual(t, chunks, retrievedChunks)
+	}
+	require.NoError(t, beaconDB.db.View(func(tx *bolt.Tx) error {
+		k, _ := tx.Bucket(slasherChunksBucket).Cursor().First()
+		require.Equal(t, true, k == nil)
+		return nil
+	}))
+}
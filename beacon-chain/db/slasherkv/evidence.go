@@ -0,0 +1,205 @@
+package slasherkv
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/golang/snappy"
+	ssz "github.com/prysmaticlabs/fastssz"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveSlashings saves the slashings detected by slasher, so that the detection history of
+// slasher can be exported later on. Slashings are keyed by the epoch of the offense followed
+// by their hash tree root, so that they are pruned along with the rest of the slasher data.
+func (s *Store) SaveSlashings(
+	ctx context.Context, attesterSlashings []*ethpb.AttesterSlashing, proposerSlashings []*ethpb.ProposerSlashing,
+) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveSlashings")
+	defer span.End()
+	attesterKeys, attesterValues, err := encodeSlashings(attesterSlashings, attesterSlashingEpoch)
+	if err != nil {
+		return err
+	}
+	proposerKeys, proposerValues, err := encodeSlashings(proposerSlashings, proposerSlashingEpoch)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		attesterBkt := tx.Bucket(attesterSlashingsBucket)
+		for i, key := range attesterKeys {
+			if err := attesterBkt.Put(key, attesterValues[i]); err != nil {
+				return err
+			}
+		}
+		proposerBkt := tx.Bucket(proposerSlashingsBucket)
+		for i, key := range proposerKeys {
+			if err := proposerBkt.Put(key, proposerValues[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ForEachAttesterSlashing calls f with every attester slashing saved in the database,
+// ordered by epoch.
+func (s *Store) ForEachAttesterSlashing(ctx context.Context, f func(*ethpb.AttesterSlashing) error) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ForEachAttesterSlashing")
+	defer span.End()
+	return s.forEachValue(ctx, attesterSlashingsBucket, func(_, v []byte) error {
+		slashing := &ethpb.AttesterSlashing{}
+		if err := decodeSSZRecord(v, slashing); err != nil {
+			return err
+		}
+		return f(slashing)
+	})
+}
+
+// ForEachProposerSlashing calls f with every proposer slashing saved in the database,
+// ordered by epoch.
+func (s *Store) ForEachProposerSlashing(ctx context.Context, f func(*ethpb.ProposerSlashing) error) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ForEachProposerSlashing")
+	defer span.End()
+	return s.forEachValue(ctx, proposerSlashingsBucket, func(_, v []byte) error {
+		slashing := &ethpb.ProposerSlashing{}
+		if err := decodeSSZRecord(v, slashing); err != nil {
+			return err
+		}
+		return f(slashing)
+	})
+}
+
+// ForEachAttestationRecord calls f with every attestation record saved in the database.
+func (s *Store) ForEachAttestationRecord(
+	ctx context.Context, f func(*slashertypes.IndexedAttestationWrapper) error,
+) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ForEachAttestationRecord")
+	defer span.End()
+	return s.forEachValue(ctx, attestationRecordsBucket, func(_, v []byte) error {
+		record, err := decodeAttestationRecord(v)
+		if err != nil {
+			return err
+		}
+		return f(record)
+	})
+}
+
+// ForEachBlockProposal calls f with every block proposal saved in the database.
+func (s *Store) ForEachBlockProposal(
+	ctx context.Context, f func(*slashertypes.SignedBlockHeaderWrapper) error,
+) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ForEachBlockProposal")
+	defer span.End()
+	return s.forEachValue(ctx, proposalRecordsBucket, func(_, v []byte) error {
+		proposal, err := decodeProposalRecord(v)
+		if err != nil {
+			return err
+		}
+		return f(proposal)
+	})
+}
+
+// ForEachLastEpochWritten calls f with the latest epoch written for every validator
+// saved in the database.
+func (s *Store) ForEachLastEpochWritten(
+	ctx context.Context, f func(*slashertypes.AttestedEpochForValidator) error,
+) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ForEachLastEpochWritten")
+	defer span.End()
+	return s.forEachValue(ctx, attestedEpochsByValidator, func(k, v []byte) error {
+		var epoch primitives.Epoch
+		if err := epoch.UnmarshalSSZ(v); err != nil {
+			return err
+		}
+		return f(&slashertypes.AttestedEpochForValidator{
+			ValidatorIndex: decodeValidatorIndex(k),
+			Epoch:          epoch,
+		})
+	})
+}
+
+// ForEachSlasherChunk calls f with every min and max span chunk saved by slasher, along
+// with its disk key.
+func (s *Store) ForEachSlasherChunk(
+	ctx context.Context, f func(kind slashertypes.ChunkKind, diskKey []byte, chunk []uint16) error,
+) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ForEachSlasherChunk")
+	defer span.End()
+	return s.chunks.forEach(ctx, f)
+}
+
+// Calls f with every key and value of the bucket. The bucket is read through a cursor,
+// so that its contents are never held in memory at once.
+func (s *Store) forEachValue(ctx context.Context, bucket []byte, f func(k, v []byte) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucket).Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err := f(k, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type sszRecord interface {
+	ssz.Marshaler
+	ssz.HashRoot
+}
+
+// Encodes each slashing as a snappy-compressed SSZ value, keyed by the epoch of the offense
+// followed by its hash tree root.
+func encodeSlashings[T sszRecord](slashings []T, epoch func(T) primitives.Epoch) ([][]byte, [][]byte, error) {
+	keys := make([][]byte, len(slashings))
+	values := make([][]byte, len(slashings))
+	for i, slashing := range slashings {
+		root, err := slashing.HashTreeRoot()
+		if err != nil {
+			return nil, nil, err
+		}
+		enc, err := slashing.MarshalSSZ()
+		if err != nil {
+			return nil, nil, err
+		}
+		keys[i] = append(encodeSlashingEpoch(epoch(slashing)), root[:]...)
+		values[i] = snappy.Encode(nil, enc)
+	}
+	return keys, values, nil
+}
+
+// The epoch of an attester slashing is the latest target epoch of its attestations.
+func attesterSlashingEpoch(slashing *ethpb.AttesterSlashing) primitives.Epoch {
+	epoch := slashing.GetAttestation_1().GetData().GetTarget().GetEpoch()
+	if epoch2 := slashing.GetAttestation_2().GetData().GetTarget().GetEpoch(); epoch2 > epoch {
+		epoch = epoch2
+	}
+	return epoch
+}
+
+func proposerSlashingEpoch(slashing *ethpb.ProposerSlashing) primitives.Epoch {
+	return slots.ToEpoch(slashing.GetHeader_1().GetHeader().GetSlot())
+}
+
+// Encodes the epoch prefix of slashing keys in big-endian, so that the keys are ordered by epoch.
+func encodeSlashingEpoch(epoch primitives.Epoch) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(epoch))
+	return buf
+}
+
+func decodeSSZRecord(encoded []byte, dst ssz.Unmarshaler) error {
+	dec, err := snappy.Decode(nil, encoded)
+	if err != nil {
+		return err
+	}
+	return dst.UnmarshalSSZ(dec)
+}
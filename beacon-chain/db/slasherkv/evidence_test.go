@@ -0,0 +1,129 @@
+package slasherkv
+
+import (
+	"context"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/fastssz"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_SaveSlashings(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	attesterSlashing := &ethpb.AttesterSlashing{
+		Attestation_1: createAttestationWrapper(1, 2, []uint64{1}, []byte{1}).IndexedAttestation,
+		Attestation_2: createAttestationWrapper(0, 3, []uint64{1}, []byte{2}).IndexedAttestation,
+	}
+	proposerSlashing := &ethpb.ProposerSlashing{
+		Header_1: createProposalWrapper(t, 4, 1, []byte{1}).SignedBeaconBlockHeader,
+		Header_2: createProposalWrapper(t, 4, 1, []byte{2}).SignedBeaconBlockHeader,
+	}
+
+	require.NoError(t, beaconDB.SaveSlashings(
+		ctx, []*ethpb.AttesterSlashing{attesterSlashing}, []*ethpb.ProposerSlashing{proposerSlashing},
+	))
+	// Saving the same slashings again does not duplicate them.
+	require.NoError(t, beaconDB.SaveSlashings(
+		ctx, []*ethpb.AttesterSlashing{attesterSlashing}, []*ethpb.ProposerSlashing{proposerSlashing},
+	))
+
+	attesterSlashings := make([]*ethpb.AttesterSlashing, 0)
+	require.NoError(t, beaconDB.ForEachAttesterSlashing(ctx, func(sl *ethpb.AttesterSlashing) error {
+		attesterSlashings = append(attesterSlashings, sl)
+		return nil
+	}))
+	require.Equal(t, 1, len(attesterSlashings))
+	require.DeepSSZEqual(t, attesterSlashing, attesterSlashings[0])
+	proposerSlashings := make([]*ethpb.ProposerSlashing, 0)
+	require.NoError(t, beaconDB.ForEachProposerSlashing(ctx, func(sl *ethpb.ProposerSlashing) error {
+		proposerSlashings = append(proposerSlashings, sl)
+		return nil
+	}))
+	require.Equal(t, 1, len(proposerSlashings))
+	require.DeepSSZEqual(t, proposerSlashing, proposerSlashings[0])
+}
+
+func TestStore_ForEachAttestationRecordAndBlockProposal(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	atts := []*slashertypes.IndexedAttestationWrapper{
+		createAttestationWrapper(0, 1, []uint64{0, 1}, []byte{1}),
+		createAttestationWrapper(1, 2, []uint64{2}, []byte{2}),
+	}
+	proposals := []*slashertypes.SignedBlockHeaderWrapper{
+		createProposalWrapper(t, 1, 0, []byte{1}),
+		createProposalWrapper(t, 2, 3, []byte{2}),
+	}
+	require.NoError(t, beaconDB.SaveAttestationRecordsForValidators(ctx, atts))
+	require.NoError(t, beaconDB.SaveBlockProposals(ctx, proposals))
+
+	records := make([]*slashertypes.IndexedAttestationWrapper, 0)
+	require.NoError(t, beaconDB.ForEachAttestationRecord(ctx, func(record *slashertypes.IndexedAttestationWrapper) error {
+		records = append(records, record)
+		return nil
+	}))
+	require.Equal(t, len(atts), len(records))
+	for i, record := range records {
+		require.Equal(t, atts[i].SigningRoot, record.SigningRoot)
+		require.DeepSSZEqual(t, atts[i].IndexedAttestation, record.IndexedAttestation)
+	}
+	storedProposals := make([]*slashertypes.SignedBlockHeaderWrapper, 0)
+	require.NoError(t, beaconDB.ForEachBlockProposal(ctx, func(proposal *slashertypes.SignedBlockHeaderWrapper) error {
+		storedProposals = append(storedProposals, proposal)
+		return nil
+	}))
+	require.Equal(t, len(proposals), len(storedProposals))
+	for i, proposal := range storedProposals {
+		require.Equal(t, proposals[i].SigningRoot, proposal.SigningRoot)
+		require.DeepSSZEqual(t, proposals[i].SignedBeaconBlockHeader, proposal.SignedBeaconBlockHeader)
+	}
+}
+
+func TestStore_ForEachLastEpochWritten(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	epochs := map[primitives.ValidatorIndex]primitives.Epoch{1: 3, 1 << 33: 5}
+	require.NoError(t, beaconDB.SaveLastEpochsWrittenForValidators(ctx, epochs))
+	stored := make(map[primitives.ValidatorIndex]primitives.Epoch)
+	require.NoError(t, beaconDB.ForEachLastEpochWritten(ctx, func(item *slashertypes.AttestedEpochForValidator) error {
+		stored[item.ValidatorIndex] = item.Epoch
+		return nil
+	}))
+	require.DeepEqual(t, epochs, stored)
+}
+
+func TestStore_ForEachSlasherChunk(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	minKeys := [][]byte{
+		ssz.MarshalUint64(make([]byte, 0), 2*chunksPerSegment+1),
+		ssz.MarshalUint64(make([]byte, 0), 3),
+	}
+	minChunks := [][]uint16{{1, 2}, {3, 4}}
+	maxKeys := [][]byte{ssz.MarshalUint64(make([]byte, 0), 7)}
+	maxChunks := [][]uint16{{5, 6}}
+	require.NoError(t, beaconDB.SaveSlasherChunks(ctx, slashertypes.MinSpan, minKeys, minChunks))
+	require.NoError(t, beaconDB.SaveSlasherChunks(ctx, slashertypes.MaxSpan, maxKeys, maxChunks))
+
+	kinds := make([]slashertypes.ChunkKind, 0)
+	keys := make([][]byte, 0)
+	chunks := make([][]uint16, 0)
+	require.NoError(t, beaconDB.ForEachSlasherChunk(ctx, func(kind slashertypes.ChunkKind, diskKey []byte, chunk []uint16) error {
+		kinds = append(kinds, kind)
+		keys = append(keys, diskKey)
+		chunks = append(chunks, chunk)
+		return nil
+	}))
+	// Chunks are ordered by kind, then by disk key.
+	require.DeepEqual(t, []slashertypes.ChunkKind{slashertypes.MinSpan, slashertypes.MinSpan, slashertypes.MaxSpan}, kinds)
+	require.DeepEqual(t, [][]byte{minKeys[1], minKeys[0], maxKeys[0]}, keys)
+	require.DeepEqual(t, [][]uint16{minChunks[1], minChunks[0], maxChunks[0]}, chunks)
+}
@@ -1,5 +1,6 @@
 // Package slasherkv defines a bolt-db, key-value store implementation
-// of the slasher database interface for Prysm.
+// of the slasher database interface for Prysm. The min and max span chunks
+// of slasher are stored in flat files next to the bolt-db file.
 package slasherkv
 
 import (
@@ -29,6 +30,7 @@ const (
 // using BoltDB as the underlying persistent kv-store for Ethereum consensus.
 type Store struct {
 	db           *bolt.DB
+	chunks       *chunkStore
 	databasePath string
 	ctx          context.Context
 }
@@ -62,8 +64,13 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 		return nil, err
 	}
 	boltDB.AllocSize = boltAllocSize
+	chunks, err := newChunkStore(path.Join(dirPath, chunksDirName))
+	if err != nil {
+		return nil, err
+	}
 	kv := &Store{
 		db:           boltDB,
+		chunks:       chunks,
 		databasePath: dirPath,
 		ctx:          ctx,
 	}
@@ -77,10 +84,15 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 			attestationDataRootsBucket,
 			proposalRecordsBucket,
 			slasherChunksBucket,
+			attesterSlashingsBucket,
+			proposerSlashingsBucket,
 		)
 	}); err != nil {
 		return nil, err
 	}
+	if err := kv.migrateSlasherChunks(); err != nil {
+		return nil, errors.Wrap(err, "could not migrate slasher chunks to flat files")
+	}
 
 	return kv, err
 }
@@ -93,11 +105,20 @@ func (s *Store) ClearDB() error {
 	if err := os.Remove(path.Join(s.databasePath, DatabaseFileName)); err != nil {
 		return errors.Wrap(err, "could not remove database file")
 	}
+	if err := os.RemoveAll(path.Join(s.databasePath, chunksDirName)); err != nil {
+		return errors.Wrap(err, "could not remove chunks directory")
+	}
 	return nil
 }
 
-// Close closes the underlying BoltDB database.
+// Close closes the underlying BoltDB database and the slasher chunk files.
 func (s *Store) Close() error {
+	if err := s.chunks.close(); err != nil {
+		if closeErr := s.db.Close(); closeErr != nil {
+			log.WithError(closeErr).Error("Could not close database")
+		}
+		return errors.Wrap(err, "could not close slasher chunk files")
+	}
 	return s.db.Close()
 }
 
@@ -156,6 +156,40 @@ func (s *Store) PruneProposalsAtEpoch(
 	return
 }
 
+// PruneSlashingsAtEpoch deletes all the slashings detected by slasher for offenses with epoch
+// less than or equal to the specified epoch.
+func (s *Store) PruneSlashingsAtEpoch(
+	ctx context.Context, maxEpoch primitives.Epoch,
+) (numPruned uint, err error) {
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{attesterSlashingsBucket, proposerSlashingsBucket} {
+			bkt := tx.Bucket(bucket)
+			// Slashings are keyed by their big-endian epoch, so the keys to prune come first.
+			// They are collected before being deleted, as deleting moves the cursor.
+			keys := make([][]byte, 0)
+			c := bkt.Cursor()
+			for k, _ := c.First(); k != nil; k, _ = c.Next() {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if primitives.Epoch(binary.BigEndian.Uint64(k[:8])) > maxEpoch {
+					break
+				}
+				keys = append(keys, append([]byte{}, k...))
+			}
+			for _, k := range keys {
+				if err := bkt.Delete(k); err != nil {
+					return err
+				}
+				slasherSlashingsPrunedTotal.Inc()
+				numPruned++
+			}
+		}
+		return nil
+	})
+	return
+}
+
 func slotFromProposalKey(key []byte) primitives.Slot {
 	return primitives.Slot(binary.LittleEndian.Uint64(key[:8]))
 }
@@ -8,6 +8,7 @@ import (
 	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	logTest "github.com/sirupsen/logrus/hooks/test"
@@ -216,3 +217,46 @@ func TestStore_PruneAttestations_OK(t *testing.T) {
 		}
 	})
 }
+
+func TestStore_PruneSlashingsAtEpoch(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	attesterSlashings := make([]*ethpb.AttesterSlashing, 0)
+	// Epochs past 255 check that keys are ordered by epoch rather than by their first byte.
+	for _, epoch := range []primitives.Epoch{2, 300} {
+		attesterSlashings = append(attesterSlashings, &ethpb.AttesterSlashing{
+			Attestation_1: createAttestationWrapper(0, epoch, []uint64{1}, []byte{1}).IndexedAttestation,
+			Attestation_2: createAttestationWrapper(1, epoch, []uint64{1}, []byte{2}).IndexedAttestation,
+		})
+	}
+	proposerSlashings := make([]*ethpb.ProposerSlashing, 0)
+	for _, epoch := range []primitives.Epoch{1, 5} {
+		slot := primitives.Slot(uint64(epoch) * uint64(slotsPerEpoch))
+		proposerSlashings = append(proposerSlashings, &ethpb.ProposerSlashing{
+			Header_1: createProposalWrapper(t, slot, 1, []byte{1}).SignedBeaconBlockHeader,
+			Header_2: createProposalWrapper(t, slot, 1, []byte{2}).SignedBeaconBlockHeader,
+		})
+	}
+	require.NoError(t, beaconDB.SaveSlashings(ctx, attesterSlashings, proposerSlashings))
+
+	numPruned, err := beaconDB.PruneSlashingsAtEpoch(ctx, 4)
+	require.NoError(t, err)
+	require.Equal(t, uint(2), numPruned)
+
+	remainingAttester := make([]*ethpb.AttesterSlashing, 0)
+	require.NoError(t, beaconDB.ForEachAttesterSlashing(ctx, func(sl *ethpb.AttesterSlashing) error {
+		remainingAttester = append(remainingAttester, sl)
+		return nil
+	}))
+	require.Equal(t, 1, len(remainingAttester))
+	require.DeepSSZEqual(t, attesterSlashings[1], remainingAttester[0])
+	remainingProposer := make([]*ethpb.ProposerSlashing, 0)
+	require.NoError(t, beaconDB.ForEachProposerSlashing(ctx, func(sl *ethpb.ProposerSlashing) error {
+		remainingProposer = append(remainingProposer, sl)
+		return nil
+	}))
+	require.Equal(t, 1, len(remainingProposer))
+	require.DeepSSZEqual(t, proposerSlashings[1], remainingProposer[0])
+}
@@ -13,4 +13,6 @@ var (
 	attestationDataRootsBucket = []byte("attestation-data-roots")
 	proposalRecordsBucket      = []byte("proposal-records")
 	slasherChunksBucket        = []byte("slasher-chunks")
+	attesterSlashingsBucket    = []byte("attester-slashings")
+	proposerSlashingsBucket    = []byte("proposer-slashings")
 )
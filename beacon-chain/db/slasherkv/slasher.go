@@ -255,62 +255,90 @@ func (s *Store) SaveAttestationRecordsForValidators(
 }
 
 // LoadSlasherChunks given a chunk kind and a disk keys, retrieves chunks for a validator
-// min or max span used by slasher from our database.
+// min or max span used by slasher from our flat chunk files.
 func (s *Store) LoadSlasherChunks(
 	ctx context.Context, kind slashertypes.ChunkKind, diskKeys [][]byte,
 ) ([][]uint16, []bool, error) {
-	ctx, span := trace.StartSpan(ctx, "BeaconDB.LoadSlasherChunk")
+	_, span := trace.StartSpan(ctx, "BeaconDB.LoadSlasherChunk")
 	defer span.End()
-	chunks := make([][]uint16, 0)
-	var exists []bool
-	err := s.db.View(func(tx *bolt.Tx) error {
-		bkt := tx.Bucket(slasherChunksBucket)
-		for _, diskKey := range diskKeys {
-			key := append(ssz.MarshalUint8(make([]byte, 0), uint8(kind)), diskKey...)
-			chunkBytes := bkt.Get(key)
-			if chunkBytes == nil {
-				chunks = append(chunks, []uint16{})
-				exists = append(exists, false)
-				continue
-			}
-			chunk, err := decodeSlasherChunk(chunkBytes)
-			if err != nil {
-				return err
-			}
-			chunks = append(chunks, chunk)
-			exists = append(exists, true)
-		}
-		return nil
-	})
-	return chunks, exists, err
+	return s.chunks.load(kind, diskKeys)
 }
 
 // SaveSlasherChunks given a chunk kind, list of disk keys, and list of chunks,
-// saves the chunks to our database for use by slasher in slashing detection.
+// saves the chunks to our flat chunk files for use by slasher in slashing detection.
 func (s *Store) SaveSlasherChunks(
 	ctx context.Context, kind slashertypes.ChunkKind, chunkKeys [][]byte, chunks [][]uint16,
 ) error {
-	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveSlasherChunks")
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveSlasherChunks")
 	defer span.End()
-	encodedKeys := make([][]byte, len(chunkKeys))
-	encodedChunks := make([][]byte, len(chunkKeys))
-	for i := 0; i < len(chunkKeys); i++ {
-		encodedKeys[i] = append(ssz.MarshalUint8(make([]byte, 0), uint8(kind)), chunkKeys[i]...)
-		encodedChunk, err := encodeSlasherChunk(chunks[i])
-		if err != nil {
+	return s.chunks.save(kind, chunkKeys, chunks)
+}
+
+// The number of chunks moved at once from BoltDB to the flat chunk files.
+var chunkMigrationBatchSize = 1000
+
+// Moves the slasher chunks stored in BoltDB by previous versions to the flat chunk files,
+// then empties the BoltDB bucket. The chunks are moved in batches, so that the bucket is
+// never loaded in memory at once.
+func (s *Store) migrateSlasherChunks() error {
+	var lastKey []byte
+	numMigrated := 0
+	for {
+		chunkKeys := make(map[slashertypes.ChunkKind][][]byte)
+		chunks := make(map[slashertypes.ChunkKind][][]uint16)
+		numRead := 0
+		if err := s.db.View(func(tx *bolt.Tx) error {
+			c := tx.Bucket(slasherChunksBucket).Cursor()
+			k, v := c.First()
+			if lastKey != nil {
+				// Resume after the last key of the previous batch.
+				k, v = c.Seek(lastKey)
+				if k != nil && bytes.Equal(k, lastKey) {
+					k, v = c.Next()
+				}
+			}
+			for ; k != nil && numRead < chunkMigrationBatchSize; k, v = c.Next() {
+				numRead++
+				lastKey = bytesutil.SafeCopyBytes(k)
+				if len(k) == 0 {
+					continue
+				}
+				chunk, err := decodeSlasherChunk(v)
+				if err != nil {
+					return err
+				}
+				kind := slashertypes.ChunkKind(k[0])
+				chunkKeys[kind] = append(chunkKeys[kind], bytesutil.SafeCopyBytes(k[1:]))
+				chunks[kind] = append(chunks[kind], chunk)
+			}
+			return nil
+		}); err != nil {
 			return err
 		}
-		encodedChunks[i] = encodedChunk
-	}
-	return s.db.Update(func(tx *bolt.Tx) error {
-		bkt := tx.Bucket(slasherChunksBucket)
-		for i := 0; i < len(chunkKeys); i++ {
-			if err := bkt.Put(encodedKeys[i], encodedChunks[i]); err != nil {
+		for kind, keys := range chunkKeys {
+			if err := s.chunks.save(kind, keys, chunks[kind]); err != nil {
 				return err
 			}
+			numMigrated += len(keys)
+		}
+		if numRead < chunkMigrationBatchSize {
+			break
 		}
+	}
+	if lastKey == nil {
 		return nil
-	})
+	}
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(slasherChunksBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucket(slasherChunksBucket)
+		return err
+	}); err != nil {
+		return err
+	}
+	log.WithField("numChunks", numMigrated).Info("Migrated slasher chunks from database to flat files")
+	return nil
 }
 
 // CheckDoubleBlockProposals takes in a list of proposals and for each,
@@ -598,3 +626,10 @@ func encodeValidatorIndex(index primitives.ValidatorIndex) []byte {
 	buf[4] = byte(v >> 32)
 	return buf
 }
+
+// Decodes a validator index encoded with encodeValidatorIndex.
+func decodeValidatorIndex(enc []byte) primitives.ValidatorIndex {
+	buf := make([]byte, 8)
+	copy(buf, enc)
+	return primitives.ValidatorIndex(binary.LittleEndian.Uint64(buf))
+}
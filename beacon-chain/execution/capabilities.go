@@ -0,0 +1,136 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// requiredEngineMethods lists, for every fork, the engine API methods the execution client must support.
+var requiredEngineMethods = map[int][]string{
+	version.Bellatrix: {NewPayloadMethod, ForkchoiceUpdatedMethod, GetPayloadMethod},
+	version.Capella:   {NewPayloadMethodV2, ForkchoiceUpdatedMethodV2, GetPayloadMethodV2},
+	version.Deneb:     {NewPayloadMethodV3, ForkchoiceUpdatedMethodV3, GetPayloadMethodV3},
+}
+
+// EngineCapabilitiesFetcher reports whether the execution client supports the engine API methods of a fork.
+type EngineCapabilitiesFetcher interface {
+	MissingEngineMethods(fork int) []string
+}
+
+// exchangeCapabilities exchanges the supported engine API methods with the execution client and caches the methods
+// it supports, warning about the methods it lacks for the current and the next fork.
+func (s *Service) exchangeCapabilities(ctx context.Context) {
+	methods, err := s.ExchangeCapabilities(ctx)
+	if err != nil {
+		s.capabilitiesLock.Lock()
+		s.capabilities = nil
+		s.capabilitiesLock.Unlock()
+		log.WithError(err).Warn("Could not exchange engine API capabilities with the execution client, " +
+			"assuming it supports every method")
+		return
+	}
+	capabilities := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		capabilities[m] = true
+	}
+	s.capabilitiesLock.Lock()
+	s.capabilities = capabilities
+	s.capabilitiesLock.Unlock()
+
+	current := params.BeaconConfig().GenesisEpoch
+	if s.chainStartData != nil && s.chainStartData.GenesisTime != 0 {
+		current = slots.ToEpoch(slots.CurrentSlot(s.chainStartData.GenesisTime))
+	}
+	for _, f := range currentAndNextForks(current) {
+		missing := s.missingCapabilities(requiredEngineMethods[f.version])
+		if len(missing) == 0 {
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"fork":           version.String(f.version),
+			"forkEpoch":      f.epoch,
+			"missingMethods": missing,
+		}).Warn("The execution client does not support the engine API methods required by the fork, please update it")
+	}
+}
+
+// capabilitySupported returns true if the execution client supports the engine API method. Every method is assumed
+// to be supported if the capabilities could not be exchanged, such as with execution clients which predate
+// engine_exchangeCapabilities.
+func (s *Service) capabilitySupported(method string) bool {
+	s.capabilitiesLock.RLock()
+	defer s.capabilitiesLock.RUnlock()
+	if s.capabilities == nil {
+		return true
+	}
+	return s.capabilities[method]
+}
+
+// checkEngineMethod returns an error if the execution client does not support the engine API method selected for
+// the fork, so that the method is not called only to be rejected by the client.
+func (s *Service) checkEngineMethod(method string, fork int) error {
+	if s.capabilitySupported(method) {
+		return nil
+	}
+	return errors.Wrapf(ErrUnsupportedEngineMethod, "%s is required by the %s fork", method, version.String(fork))
+}
+
+// MissingEngineMethods returns the engine API methods required by the fork which the execution client does not
+// support.
+func (s *Service) MissingEngineMethods(fork int) []string {
+	return s.missingCapabilities(requiredEngineMethods[fork])
+}
+
+// missingCapabilities returns the given methods which the execution client does not support.
+func (s *Service) missingCapabilities(methods []string) []string {
+	var missing []string
+	for _, m := range methods {
+		if !s.capabilitySupported(m) {
+			missing = append(missing, m)
+		}
+	}
+	return missing
+}
+
+type scheduledFork struct {
+	version int
+	epoch   primitives.Epoch
+}
+
+// currentAndNextForks returns the fork which is active at the given epoch and the next scheduled fork, among the
+// forks which depend on the engine API.
+func currentAndNextForks(epoch primitives.Epoch) []scheduledFork {
+	cfg := params.BeaconConfig()
+	forks := []scheduledFork{
+		{version: version.Bellatrix, epoch: cfg.BellatrixForkEpoch},
+		{version: version.Capella, epoch: cfg.CapellaForkEpoch},
+		{version: version.Deneb, epoch: cfg.DenebForkEpoch},
+	}
+	var current, next *scheduledFork
+	for i := range forks {
+		f := &forks[i]
+		if f.epoch == cfg.FarFutureEpoch {
+			break
+		}
+		if f.epoch <= epoch {
+			current = f
+			continue
+		}
+		next = f
+		break
+	}
+	var result []scheduledFork
+	if current != nil {
+		result = append(result, *current)
+	}
+	if next != nil {
+		result = append(result, *next)
+	}
+	return result
+}
@@ -0,0 +1,158 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func capabilitiesServer(t *testing.T, methods []string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		defer func() {
+			require.NoError(t, r.Body.Close())
+		}()
+		resp := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  methods,
+		}
+		if methods == nil {
+			resp = map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      1,
+				"error":   map[string]interface{}{"code": -32601, "message": "the method engine_exchangeCapabilities does not exist"},
+			}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestService_exchangeCapabilities(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.BellatrixForkEpoch = 0
+	cfg.CapellaForkEpoch = 0
+	cfg.DenebForkEpoch = 10
+	params.OverrideBeaconConfig(cfg)
+
+	t.Run("missing methods of the next fork", func(t *testing.T) {
+		hook := logTest.NewGlobal()
+		srv := capabilitiesServer(t, []string{NewPayloadMethodV2, ForkchoiceUpdatedMethodV2, GetPayloadMethodV2, GetPayloadMethodV3})
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		s := &Service{cfg: &config{}, rpcClient: rpcClient}
+
+		s.exchangeCapabilities(context.Background())
+		assert.Equal(t, true, s.capabilitySupported(NewPayloadMethodV2))
+		assert.Equal(t, false, s.capabilitySupported(NewPayloadMethodV3))
+		assert.Equal(t, false, s.capabilitySupported(GetPayloadBodiesByHashV1))
+		require.LogsContain(t, hook, "does not support the engine API methods required by the fork")
+		require.LogsContain(t, hook, "fork=deneb")
+		require.LogsContain(t, hook, "engine_newPayloadV3 engine_forkchoiceUpdatedV3")
+		require.LogsDoNotContain(t, hook, "fork=capella")
+	})
+	t.Run("all required methods", func(t *testing.T) {
+		hook := logTest.NewGlobal()
+		srv := capabilitiesServer(t, []string{
+			NewPayloadMethodV2, ForkchoiceUpdatedMethodV2, GetPayloadMethodV2,
+			NewPayloadMethodV3, ForkchoiceUpdatedMethodV3, GetPayloadMethodV3,
+		})
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		s := &Service{cfg: &config{}, rpcClient: rpcClient}
+
+		s.exchangeCapabilities(context.Background())
+		assert.Equal(t, true, s.capabilitySupported(NewPayloadMethodV3))
+		require.LogsDoNotContain(t, hook, "does not support the engine API methods required by the fork")
+	})
+	t.Run("method not supported", func(t *testing.T) {
+		hook := logTest.NewGlobal()
+		srv := capabilitiesServer(t, nil)
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		s := &Service{cfg: &config{}, rpcClient: rpcClient, capabilities: map[string]bool{}}
+
+		s.exchangeCapabilities(context.Background())
+		assert.Equal(t, true, s.capabilitySupported(NewPayloadMethodV3))
+		assert.Equal(t, true, s.capabilitySupported(GetPayloadBodiesByRangeV1))
+		require.LogsContain(t, hook, "Could not exchange engine API capabilities")
+	})
+}
+
+func TestCurrentAndNextForks(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.BellatrixForkEpoch = 5
+	cfg.CapellaForkEpoch = 10
+	cfg.DenebForkEpoch = cfg.FarFutureEpoch
+	params.OverrideBeaconConfig(cfg)
+
+	tests := []struct {
+		epoch primitives.Epoch
+		want  []scheduledFork
+	}{
+		{epoch: 0, want: []scheduledFork{{version: version.Bellatrix, epoch: 5}}},
+		{epoch: 5, want: []scheduledFork{{version: version.Bellatrix, epoch: 5}, {version: version.Capella, epoch: 10}}},
+		{epoch: 9, want: []scheduledFork{{version: version.Bellatrix, epoch: 5}, {version: version.Capella, epoch: 10}}},
+		{epoch: 10, want: []scheduledFork{{version: version.Capella, epoch: 10}}},
+		{epoch: 100, want: []scheduledFork{{version: version.Capella, epoch: 10}}},
+	}
+	for _, tt := range tests {
+		assert.DeepEqual(t, tt.want, currentAndNextForks(tt.epoch))
+	}
+}
+
+func TestService_usePayloadBodies(t *testing.T) {
+	s := &Service{}
+	assert.Equal(t, false, s.usePayloadBodies())
+
+	resetFn := features.InitWithReset(&features.Flags{
+		EnableOptionalEngineMethods: true,
+	})
+	defer resetFn()
+	assert.Equal(t, true, s.usePayloadBodies())
+
+	s.capabilities = map[string]bool{GetPayloadBodiesByRangeV1: true}
+	assert.Equal(t, false, s.usePayloadBodies())
+
+	s.capabilities[GetPayloadBodiesByHashV1] = true
+	assert.Equal(t, true, s.usePayloadBodies())
+}
+
+func TestService_checkEngineMethod(t *testing.T) {
+	s := &Service{}
+	require.NoError(t, s.checkEngineMethod(GetPayloadMethodV3, version.Deneb))
+
+	s.capabilities = map[string]bool{GetPayloadMethodV2: true}
+	require.NoError(t, s.checkEngineMethod(GetPayloadMethodV2, version.Capella))
+	err := s.checkEngineMethod(GetPayloadMethodV3, version.Deneb)
+	require.ErrorIs(t, err, ErrUnsupportedEngineMethod)
+	require.ErrorContains(t, "engine_getPayloadV3 is required by the deneb fork", err)
+}
+
+func TestService_GetPayload_UnsupportedMethod(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.CapellaForkEpoch = 0
+	cfg.DenebForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	// The execution client is not called when it does not support the method of the fork.
+	s := &Service{capabilities: map[string]bool{GetPayloadMethodV2: true}}
+	_, _, _, err := s.GetPayload(context.Background(), [8]byte{}, 1)
+	require.ErrorIs(t, err, ErrUnsupportedEngineMethod)
+}
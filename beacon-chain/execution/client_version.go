@@ -0,0 +1,122 @@
+package execution
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ClientVersionMethod request string for JSON-RPC.
+const ClientVersionMethod = "web3_clientVersion"
+
+// PrysmClientCode is the client code of Prysm, as standardized by the engine API for client identification.
+const PrysmClientCode = "PM"
+
+// clientCodes maps the lower case names reported by the execution clients in web3_clientVersion to the client
+// codes standardized by the engine API for client identification.
+var clientCodes = map[string]string{
+	"besu":        "BU",
+	"ethereumjs":  "EJ",
+	"erigon":      "EG",
+	"geth":        "GE",
+	"nethermind":  "NM",
+	"reth":        "RH",
+	"nimbus-eth1": "NB",
+}
+
+// ClientVersionFetcher retrieves the version of the connected execution client.
+type ClientVersionFetcher interface {
+	ExecutionClientVersion() *ClientVersion
+}
+
+// ClientVersion identifies an execution client from its response to web3_clientVersion, such as
+// Geth/v1.13.4-stable-3f907d6a/linux-amd64/go1.21.3.
+type ClientVersion struct {
+	// Code is the standardized two letter code of the client, empty for unknown clients.
+	Code    string
+	Name    string
+	Version string
+	// Commit is the hex encoded commit the client was built from, empty when the client does not report it.
+	Commit string
+	Raw    string
+}
+
+// ExecutionClientVersion returns the version of the connected execution client, or nil if it is not known yet.
+func (s *Service) ExecutionClientVersion() *ClientVersion {
+	s.clientVersionLock.RLock()
+	defer s.clientVersionLock.RUnlock()
+	return s.clientVersion
+}
+
+// detectClientVersion queries the version of the execution client, which may change with every new connection.
+func (s *Service) detectClientVersion(ctx context.Context) {
+	var raw string
+	if err := s.rpcClient.CallContext(ctx, &raw, ClientVersionMethod); err != nil {
+		log.WithError(err).Debug("Could not query the version of the execution client")
+		return
+	}
+	v := ParseClientVersion(raw)
+	s.clientVersionLock.Lock()
+	s.clientVersion = v
+	s.clientVersionLock.Unlock()
+	log.WithFields(logrus.Fields{
+		"client":  v.Name,
+		"version": v.Version,
+		"code":    v.Code,
+	}).Info("Detected execution client")
+}
+
+// ParseClientVersion parses the response to web3_clientVersion. The commit is the trailing hex suffix of the
+// version, separated by a dash or a plus sign, as reported by most execution clients.
+func ParseClientVersion(raw string) *ClientVersion {
+	parts := strings.Split(raw, "/")
+	v := &ClientVersion{Name: parts[0], Raw: raw}
+	v.Code = clientCodes[strings.ToLower(v.Name)]
+	if len(parts) > 1 {
+		v.Version = parts[1]
+	}
+	if i := strings.LastIndexAny(v.Version, "-+"); i >= 0 && isCommit(v.Version[i+1:]) {
+		v.Commit = strings.ToLower(v.Version[i+1:])
+	}
+	return v
+}
+
+// GraffitiCode returns the client-diversity code of the execution client and of Prysm built from clCommit, in the
+// longest of the standardized formats which fits in the given number of bytes: ELxxxxCLxxxx, ELxxCLxx, ELCL or EL,
+// where EL and CL are the client codes and xxxx the beginning of their commits. It returns an empty string if the
+// execution client is unknown or if no format fits.
+func GraffitiCode(el *ClientVersion, clCommit string, space int) string {
+	if el == nil || el.Code == "" {
+		return ""
+	}
+	if !isCommit(clCommit) {
+		clCommit = ""
+	}
+	for _, n := range []int{4, 2} {
+		if space >= 2*(len(el.Code)+n) && len(el.Commit) >= n && len(clCommit) >= n {
+			return el.Code + el.Commit[:n] + PrysmClientCode + clCommit[:n]
+		}
+	}
+	switch {
+	case space >= len(el.Code)+len(PrysmClientCode):
+		return el.Code + PrysmClientCode
+	case space >= len(el.Code):
+		return el.Code
+	default:
+		return ""
+	}
+}
+
+// isCommit returns true if s looks like an abbreviated or full git commit hash.
+func isCommit(s string) bool {
+	if len(s) < 7 {
+		return false
+	}
+	for _, c := range strings.ToLower(s) {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
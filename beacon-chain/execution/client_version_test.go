@@ -0,0 +1,106 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestParseClientVersion(t *testing.T) {
+	tests := []struct {
+		raw     string
+		code    string
+		name    string
+		version string
+		commit  string
+	}{
+		{
+			raw:     "Geth/v1.13.4-stable-3f907d6a/linux-amd64/go1.21.3",
+			code:    "GE",
+			name:    "Geth",
+			version: "v1.13.4-stable-3f907d6a",
+			commit:  "3f907d6a",
+		},
+		{
+			raw:     "Nethermind/v1.21.0+bbe7bb41/linux-x64/dotnet7.0.11",
+			code:    "NM",
+			name:    "Nethermind",
+			version: "v1.21.0+bbe7bb41",
+			commit:  "bbe7bb41",
+		},
+		{
+			raw:     "besu/v23.10.0/linux-x86_64/openjdk-java-17",
+			code:    "BU",
+			name:    "besu",
+			version: "v23.10.0",
+		},
+		{
+			raw:     "reth/v0.1.0-alpha.10-E2F43E7/x86_64-unknown-linux-gnu",
+			code:    "RH",
+			name:    "reth",
+			version: "v0.1.0-alpha.10-E2F43E7",
+			commit:  "e2f43e7",
+		},
+		{
+			raw:  "unknown",
+			name: "unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			v := ParseClientVersion(tt.raw)
+			assert.Equal(t, tt.code, v.Code)
+			assert.Equal(t, tt.name, v.Name)
+			assert.Equal(t, tt.version, v.Version)
+			assert.Equal(t, tt.commit, v.Commit)
+			assert.Equal(t, tt.raw, v.Raw)
+		})
+	}
+}
+
+func TestGraffitiCode(t *testing.T) {
+	geth := &ClientVersion{Code: "GE", Commit: "3f907d6a"}
+	clCommit := "a1b2c3d4e5f6"
+	assert.Equal(t, "GE3f90PMa1b2", GraffitiCode(geth, clCommit, 32))
+	assert.Equal(t, "GE3f90PMa1b2", GraffitiCode(geth, clCommit, 12))
+	assert.Equal(t, "GE3fPMa1", GraffitiCode(geth, clCommit, 11))
+	assert.Equal(t, "GEPM", GraffitiCode(geth, clCommit, 7))
+	assert.Equal(t, "GE", GraffitiCode(geth, clCommit, 3))
+	assert.Equal(t, "", GraffitiCode(geth, clCommit, 1))
+	assert.Equal(t, "GEPM", GraffitiCode(geth, "Local build", 32))
+	assert.Equal(t, "GEPM", GraffitiCode(&ClientVersion{Code: "GE"}, clCommit, 32))
+	assert.Equal(t, "", GraffitiCode(&ClientVersion{Name: "unknown"}, clCommit, 32))
+	assert.Equal(t, "", GraffitiCode(nil, clCommit, 32))
+}
+
+func TestService_detectClientVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		defer func() {
+			require.NoError(t, r.Body.Close())
+		}()
+		resp := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  "Geth/v1.13.4-stable-3f907d6a/linux-amd64/go1.21.3",
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+	rpcClient, err := rpc.DialHTTP(srv.URL)
+	require.NoError(t, err)
+	s := &Service{cfg: &config{}, rpcClient: rpcClient}
+
+	assert.Equal(t, (*ClientVersion)(nil), s.ExecutionClientVersion())
+	s.detectClientVersion(context.Background())
+	v := s.ExecutionClientVersion()
+	require.NotNil(t, v)
+	assert.Equal(t, "GE", v.Code)
+	assert.Equal(t, "3f907d6a", v.Commit)
+}
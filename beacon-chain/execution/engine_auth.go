@@ -0,0 +1,177 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	"github.com/prysmaticlabs/prysm/v4/network/authorization"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// jwtSecretReloadPeriod is how often the JWT secret file is checked for a new secret.
+	jwtSecretReloadPeriod = 30 * time.Second
+	// maxJWTClockSkew is the largest difference between the issued-at claim of a JWT and the clock of the
+	// execution client which execution clients accept, as defined by the engine API authentication specification.
+	maxJWTClockSkew = 60 * time.Second
+)
+
+// EngineDiagnosticsFetcher retrieves diagnostics about the engine API connection.
+type EngineDiagnosticsFetcher interface {
+	EngineDiagnostics() EngineDiagnostics
+}
+
+// EngineDiagnostics reports the outcome of the recent engine API calls and of their authentication, to debug
+// connection failures between the beacon node and the execution client.
+type EngineDiagnostics struct {
+	Endpoint             string
+	LastSuccessfulCall   time.Time
+	LastSuccessfulMethod string
+	LastFailedCall       time.Time
+	LastFailedMethod     string
+	LastError            string
+	AuthErrors           uint64
+	LastAuthError        string
+	LastAuthErrorTime    time.Time
+	// ClockSkew is the issued-at claim of the last JWT minus the time reported by the execution client in its
+	// response. It is only measured for authenticated HTTP connections.
+	ClockSkew         time.Duration
+	ClockSkewMeasured bool
+	JWTSecretLoaded   time.Time
+}
+
+// engineDiagnostics is the concurrency safe holder of the engine API diagnostics.
+type engineDiagnostics struct {
+	sync.RWMutex
+	d EngineDiagnostics
+}
+
+// EngineDiagnostics returns the diagnostics of the engine API connection.
+func (s *Service) EngineDiagnostics() EngineDiagnostics {
+	s.engineDiagnostics.RLock()
+	defer s.engineDiagnostics.RUnlock()
+	d := s.engineDiagnostics.d
+	d.Endpoint = s.currentEndpoint().Url
+	return d
+}
+
+// callEngine calls an engine API method of the execution client and records its outcome.
+func (s *Service) callEngine(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	err := s.rpcClient.CallContext(ctx, result, method, args...)
+	s.recordEngineCall(method, err)
+	return err
+}
+
+func (s *Service) recordEngineCall(method string, err error) {
+	s.engineDiagnostics.Lock()
+	defer s.engineDiagnostics.Unlock()
+	if err == nil {
+		s.engineDiagnostics.d.LastSuccessfulCall = time.Now()
+		s.engineDiagnostics.d.LastSuccessfulMethod = method
+		return
+	}
+	s.engineDiagnostics.d.LastFailedCall = time.Now()
+	s.engineDiagnostics.d.LastFailedMethod = method
+	s.engineDiagnostics.d.LastError = err.Error()
+}
+
+// observeJWT records authentication failures, and the skew between the issued-at claim of the JWTs and the clock
+// of the execution client, from the responses to the authenticated requests sent to the execution client.
+func (s *Service) observeJWT(issuedAt time.Time, resp *http.Response, err error) {
+	if err != nil || resp == nil {
+		return
+	}
+	s.engineDiagnostics.Lock()
+	defer s.engineDiagnostics.Unlock()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		s.engineDiagnostics.d.AuthErrors++
+		s.engineDiagnostics.d.LastAuthError = resp.Status
+		s.engineDiagnostics.d.LastAuthErrorTime = time.Now()
+	}
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	skew := issuedAt.Sub(date)
+	wasWithinBounds := !s.engineDiagnostics.d.ClockSkewMeasured || absDuration(s.engineDiagnostics.d.ClockSkew) <= maxJWTClockSkew
+	if absDuration(skew) > maxJWTClockSkew && wasWithinBounds {
+		log.WithFields(logrus.Fields{
+			"skew":    skew,
+			"maxSkew": maxJWTClockSkew,
+		}).Warn("The clock of the beacon node is out of sync with the execution client, which will reject " +
+			"authenticated requests. Make sure both hosts synchronize their clock")
+	}
+	s.engineDiagnostics.d.ClockSkew = skew
+	s.engineDiagnostics.d.ClockSkewMeasured = true
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// reloadJWTSecret reads the JWT secret file and, if it holds a new secret, authenticates with it on every
+// execution endpoint and reconnects to the endpoint in use. This rotates the secret without restarting the node.
+func (s *Service) reloadJWTSecret(ctx context.Context) error {
+	secret, err := ParseJWTSecretFromFile(s.cfg.jwtSecretFile)
+	if err != nil {
+		return err
+	}
+	s.endpointLock.Lock()
+	if s.cfg.currHttpEndpoint.Auth.Method == authorization.Bearer && bytes.Equal([]byte(s.cfg.currHttpEndpoint.Auth.Value), secret) {
+		s.endpointLock.Unlock()
+		return nil
+	}
+	for i := range s.cfg.httpEndpoints {
+		s.cfg.httpEndpoints[i].Auth.Method = authorization.Bearer
+		s.cfg.httpEndpoints[i].Auth.Value = string(secret)
+	}
+	s.cfg.currHttpEndpoint.Auth.Method = authorization.Bearer
+	s.cfg.currHttpEndpoint.Auth.Value = string(secret)
+	endpoint := s.cfg.currHttpEndpoint
+	s.endpointLock.Unlock()
+	s.engineDiagnostics.Lock()
+	s.engineDiagnostics.d.JWTSecretLoaded = time.Now()
+	s.engineDiagnostics.Unlock()
+
+	currClient := s.rpcClient
+	if err := s.setupExecutionClientConnections(ctx, endpoint); err != nil {
+		return errors.Wrap(err, "could not reconnect to execution client with the new JWT secret")
+	}
+	if currClient != nil {
+		currClient.Close()
+	}
+	log.WithField("file", s.cfg.jwtSecretFile).Info("Reloaded JWT secret for authenticating the execution API")
+	return nil
+}
+
+// ParseJWTSecretFromFile parses a JWT secret from a file path. The secret must be stored as a hex-encoded string,
+// optionally prefixed with 0x, of at least 256 bits.
+func ParseJWTSecretFromFile(jwtSecretFile string) ([]byte, error) {
+	enc, err := file.ReadFileAsBytes(jwtSecretFile)
+	if err != nil {
+		return nil, err
+	}
+	strData := strings.TrimSpace(string(enc))
+	if len(strData) == 0 {
+		return nil, fmt.Errorf("provided JWT secret in file %s cannot be empty", jwtSecretFile)
+	}
+	secret, err := hex.DecodeString(strings.TrimPrefix(strData, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) < 32 {
+		return nil, errors.New("provided JWT secret should be a hex string of at least 32 bytes")
+	}
+	return secret, nil
+}
@@ -0,0 +1,114 @@
+package execution
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	mockExecution "github.com/prysmaticlabs/prysm/v4/beacon-chain/execution/testing"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestService_callEngine(t *testing.T) {
+	srv, endpoint, err := mockExecution.SetupRPCServer()
+	require.NoError(t, err)
+	t.Cleanup(srv.Stop)
+
+	ctx := context.Background()
+	s := &Service{
+		cfg:       &config{beaconNodeStatsUpdater: &NopBeaconNodeStatsUpdater{}},
+		rpcClient: RPCClientEmpty{},
+	}
+	require.NotNil(t, s.callEngine(ctx, nil, ExchangeCapabilities))
+	d := s.EngineDiagnostics()
+	assert.Equal(t, ExchangeCapabilities, d.LastFailedMethod)
+	assert.Equal(t, "rpc client is not initialized", d.LastError)
+	assert.Equal(t, true, d.LastSuccessfulCall.IsZero())
+
+	require.NoError(t, WithHttpEndpoint(endpoint)(s))
+	require.NoError(t, s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint))
+	var chainID hexutil.Big
+	require.NoError(t, s.callEngine(ctx, &chainID, "eth_chainId"))
+	d = s.EngineDiagnostics()
+	assert.Equal(t, "eth_chainId", d.LastSuccessfulMethod)
+	assert.Equal(t, false, d.LastSuccessfulCall.IsZero())
+	assert.Equal(t, endpoint, d.Endpoint)
+}
+
+func TestService_observeJWT(t *testing.T) {
+	hook := logTest.NewGlobal()
+	s := &Service{cfg: &config{}}
+	issuedAt := time.Now().Truncate(time.Second)
+
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	resp.Header.Set("Date", issuedAt.Add(-2*time.Second).UTC().Format(http.TimeFormat))
+	s.observeJWT(issuedAt, resp, nil)
+	d := s.EngineDiagnostics()
+	assert.Equal(t, uint64(0), d.AuthErrors)
+	assert.Equal(t, true, d.ClockSkewMeasured)
+	assert.Equal(t, 2*time.Second, d.ClockSkew)
+	require.LogsDoNotContain(t, hook, "out of sync")
+
+	resp = &http.Response{StatusCode: http.StatusUnauthorized, Status: "401 Unauthorized", Header: http.Header{}}
+	resp.Header.Set("Date", issuedAt.Add(2*time.Minute).UTC().Format(http.TimeFormat))
+	s.observeJWT(issuedAt, resp, nil)
+	d = s.EngineDiagnostics()
+	assert.Equal(t, uint64(1), d.AuthErrors)
+	assert.Equal(t, "401 Unauthorized", d.LastAuthError)
+	assert.Equal(t, -2*time.Minute, d.ClockSkew)
+	require.LogsContain(t, hook, "out of sync")
+
+	// Failed requests are recorded by the engine calls themselves.
+	s.observeJWT(issuedAt, nil, http.ErrHandlerTimeout)
+	assert.Equal(t, uint64(1), s.EngineDiagnostics().AuthErrors)
+}
+
+func TestService_reloadJWTSecret(t *testing.T) {
+	srv, endpoint, err := mockExecution.SetupRPCServer()
+	require.NoError(t, err)
+	t.Cleanup(srv.Stop)
+
+	ctx := context.Background()
+	secretFile := filepath.Join(t.TempDir(), "jwt.hex")
+	secret := bytesutil.PadTo([]byte("foo"), 32)
+	require.NoError(t, os.WriteFile(secretFile, []byte(hex.EncodeToString(secret)), 0600))
+
+	s := &Service{
+		cfg:       &config{beaconNodeStatsUpdater: &NopBeaconNodeStatsUpdater{}},
+		rpcClient: RPCClientEmpty{},
+	}
+	require.NoError(t, WithHttpEndpointsAndJWTSecret([]string{endpoint, "http://fallback"}, secret)(s))
+	require.NoError(t, WithJWTSecretFile(secretFile)(s))
+	require.NoError(t, s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint))
+	loaded := s.EngineDiagnostics().JWTSecretLoaded
+	require.Equal(t, false, loaded.IsZero())
+
+	// The connection is kept as long as the secret does not change.
+	client := s.rpcClient
+	require.NoError(t, s.reloadJWTSecret(ctx))
+	assert.Equal(t, client, s.rpcClient)
+
+	newSecret := bytesutil.PadTo([]byte("bar"), 32)
+	require.NoError(t, os.WriteFile(secretFile, []byte("0x"+hex.EncodeToString(newSecret)), 0600))
+	require.NoError(t, s.reloadJWTSecret(ctx))
+	assert.NotEqual(t, client, s.rpcClient)
+	assert.Equal(t, string(newSecret), s.cfg.currHttpEndpoint.Auth.Value)
+	for _, e := range s.cfg.httpEndpoints {
+		assert.Equal(t, string(newSecret), e.Auth.Value)
+	}
+	assert.Equal(t, true, s.EngineDiagnostics().JWTSecretLoaded.After(loaded))
+	assert.Equal(t, true, s.ExecutionClientConnected())
+
+	// An invalid secret is not loaded.
+	require.NoError(t, os.WriteFile(secretFile, []byte("0x1234"), 0600))
+	require.ErrorContains(t, "at least 32 bytes", s.reloadJWTSecret(ctx))
+	assert.Equal(t, string(newSecret), s.cfg.currHttpEndpoint.Auth.Value)
+}
@@ -18,11 +18,13 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	consensus_types "github.com/prysmaticlabs/prysm/v4/consensus-types"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	payloadattribute "github.com/prysmaticlabs/prysm/v4/consensus-types/payload-attribute"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
 	pb "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
@@ -35,10 +37,13 @@ var (
 	supportedEngineEndpoints = []string{
 		NewPayloadMethod,
 		NewPayloadMethodV2,
+		NewPayloadMethodV3,
 		ForkchoiceUpdatedMethod,
 		ForkchoiceUpdatedMethodV2,
+		ForkchoiceUpdatedMethodV3,
 		GetPayloadMethod,
 		GetPayloadMethodV2,
+		GetPayloadMethodV3,
 		ExchangeTransitionConfigurationMethod,
 		GetPayloadBodiesByHashV1,
 		GetPayloadBodiesByRangeV1,
@@ -50,6 +55,7 @@ const (
 	NewPayloadMethod = "engine_newPayloadV1"
 	// NewPayloadMethodV2 v2 request string for JSON-RPC.
 	NewPayloadMethodV2 = "engine_newPayloadV2"
+	// NewPayloadMethodV3 v3 request string for JSON-RPC.
 	NewPayloadMethodV3 = "engine_newPayloadV3"
 	// ForkchoiceUpdatedMethod v1 request string for JSON-RPC.
 	ForkchoiceUpdatedMethod = "engine_forkchoiceUpdatedV1"
@@ -135,7 +141,10 @@ func (s *Service) NewPayload(ctx context.Context, payload interfaces.ExecutionDa
 		if !ok {
 			return nil, errors.New("execution data must be a Bellatrix or Capella execution payload")
 		}
-		err := s.rpcClient.CallContext(ctx, result, NewPayloadMethod, payloadPb)
+		if err := s.checkEngineMethod(NewPayloadMethod, version.Bellatrix); err != nil {
+			return nil, err
+		}
+		err := s.callEngine(ctx, result, NewPayloadMethod, payloadPb)
 		if err != nil {
 			return nil, handleRPCError(err)
 		}
@@ -144,7 +153,10 @@ func (s *Service) NewPayload(ctx context.Context, payload interfaces.ExecutionDa
 		if !ok {
 			return nil, errors.New("execution data must be a Capella execution payload")
 		}
-		err := s.rpcClient.CallContext(ctx, result, NewPayloadMethodV2, payloadPb)
+		if err := s.checkEngineMethod(NewPayloadMethodV2, version.Capella); err != nil {
+			return nil, err
+		}
+		err := s.callEngine(ctx, result, NewPayloadMethodV2, payloadPb)
 		if err != nil {
 			return nil, handleRPCError(err)
 		}
@@ -153,7 +165,10 @@ func (s *Service) NewPayload(ctx context.Context, payload interfaces.ExecutionDa
 		if !ok {
 			return nil, errors.New("execution data must be a Deneb execution payload")
 		}
-		err := s.rpcClient.CallContext(ctx, result, NewPayloadMethodV3, payloadPb, versionedHashes, parentBlockRoot)
+		if err := s.checkEngineMethod(NewPayloadMethodV3, version.Deneb); err != nil {
+			return nil, err
+		}
+		err := s.callEngine(ctx, result, NewPayloadMethodV3, payloadPb, versionedHashes, parentBlockRoot)
 		if err != nil {
 			return nil, handleRPCError(err)
 		}
@@ -202,7 +217,10 @@ func (s *Service) ForkchoiceUpdated(
 		if err != nil {
 			return nil, nil, err
 		}
-		err = s.rpcClient.CallContext(ctx, result, ForkchoiceUpdatedMethod, state, a)
+		if err := s.checkEngineMethod(ForkchoiceUpdatedMethod, version.Bellatrix); err != nil {
+			return nil, nil, err
+		}
+		err = s.callEngine(ctx, result, ForkchoiceUpdatedMethod, state, a)
 		if err != nil {
 			return nil, nil, handleRPCError(err)
 		}
@@ -211,7 +229,10 @@ func (s *Service) ForkchoiceUpdated(
 		if err != nil {
 			return nil, nil, err
 		}
-		err = s.rpcClient.CallContext(ctx, result, ForkchoiceUpdatedMethodV2, state, a)
+		if err := s.checkEngineMethod(ForkchoiceUpdatedMethodV2, version.Capella); err != nil {
+			return nil, nil, err
+		}
+		err = s.callEngine(ctx, result, ForkchoiceUpdatedMethodV2, state, a)
 		if err != nil {
 			return nil, nil, handleRPCError(err)
 		}
@@ -220,7 +241,10 @@ func (s *Service) ForkchoiceUpdated(
 		if err != nil {
 			return nil, nil, err
 		}
-		err = s.rpcClient.CallContext(ctx, result, ForkchoiceUpdatedMethodV3, state, a)
+		if err := s.checkEngineMethod(ForkchoiceUpdatedMethodV3, version.Deneb); err != nil {
+			return nil, nil, err
+		}
+		err = s.callEngine(ctx, result, ForkchoiceUpdatedMethodV3, state, a)
 		if err != nil {
 			return nil, nil, handleRPCError(err)
 		}
@@ -241,6 +265,9 @@ func (s *Service) ForkchoiceUpdated(
 	case pb.PayloadStatus_INVALID:
 		return nil, resp.LatestValidHash, ErrInvalidPayloadStatus
 	case pb.PayloadStatus_VALID:
+		if result.PayloadId != nil {
+			s.markPayloadBuilding()
+		}
 		return result.PayloadId, resp.LatestValidHash, nil
 	default:
 		return nil, nil, ErrUnknownPayloadStatus
@@ -256,14 +283,18 @@ func (s *Service) GetPayload(ctx context.Context, payloadId [8]byte, slot primit
 	defer func() {
 		getPayloadLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
+	defer s.clearPayloadBuilding()
 
-	d := time.Now().Add(defaultEngineTimeout)
+	d := time.Now().Add(time.Duration(params.BeaconConfig().ExecutionEngineGetPayloadTimeoutValue) * time.Second)
 	ctx, cancel := context.WithDeadline(ctx, d)
 	defer cancel()
 
 	if slots.ToEpoch(slot) >= params.BeaconConfig().DenebForkEpoch {
 		result := &pb.ExecutionPayloadDenebWithValueAndBlobsBundle{}
-		err := s.rpcClient.CallContext(ctx, result, GetPayloadMethodV3, pb.PayloadIDBytes(payloadId))
+		if err := s.checkEngineMethod(GetPayloadMethodV3, version.Deneb); err != nil {
+			return nil, nil, false, err
+		}
+		err := s.callEngine(ctx, result, GetPayloadMethodV3, pb.PayloadIDBytes(payloadId))
 		if err != nil {
 			return nil, nil, false, handleRPCError(err)
 		}
@@ -276,7 +307,10 @@ func (s *Service) GetPayload(ctx context.Context, payloadId [8]byte, slot primit
 
 	if slots.ToEpoch(slot) >= params.BeaconConfig().CapellaForkEpoch {
 		result := &pb.ExecutionPayloadCapellaWithValue{}
-		err := s.rpcClient.CallContext(ctx, result, GetPayloadMethodV2, pb.PayloadIDBytes(payloadId))
+		if err := s.checkEngineMethod(GetPayloadMethodV2, version.Capella); err != nil {
+			return nil, nil, false, err
+		}
+		err := s.callEngine(ctx, result, GetPayloadMethodV2, pb.PayloadIDBytes(payloadId))
 		if err != nil {
 			return nil, nil, false, handleRPCError(err)
 		}
@@ -287,8 +321,11 @@ func (s *Service) GetPayload(ctx context.Context, payloadId [8]byte, slot primit
 		return ed, nil, false, nil
 	}
 
+	if err := s.checkEngineMethod(GetPayloadMethod, version.Bellatrix); err != nil {
+		return nil, nil, false, err
+	}
 	result := &pb.ExecutionPayload{}
-	err := s.rpcClient.CallContext(ctx, result, GetPayloadMethod, pb.PayloadIDBytes(payloadId))
+	err := s.callEngine(ctx, result, GetPayloadMethod, pb.PayloadIDBytes(payloadId))
 	if err != nil {
 		return nil, nil, false, handleRPCError(err)
 	}
@@ -313,7 +350,7 @@ func (s *Service) ExchangeTransitionConfiguration(
 	ctx, cancel := context.WithDeadline(ctx, d)
 	defer cancel()
 	result := &pb.TransitionConfiguration{}
-	if err := s.rpcClient.CallContext(ctx, result, ExchangeTransitionConfigurationMethod, cfg); err != nil {
+	if err := s.callEngine(ctx, result, ExchangeTransitionConfigurationMethod, cfg); err != nil {
 		return handleRPCError(err)
 	}
 
@@ -348,13 +385,15 @@ func (s *Service) ExchangeCapabilities(ctx context.Context) ([]string, error) {
 	ctx, span := trace.StartSpan(ctx, "powchain.engine-api-client.ExchangeCapabilities")
 	defer span.End()
 
-	result := &pb.ExchangeCapabilities{}
-	err := s.rpcClient.CallContext(ctx, &result, ExchangeCapabilities, supportedEngineEndpoints)
+	var result []string
+	if err := s.callEngine(ctx, &result, ExchangeCapabilities, supportedEngineEndpoints); err != nil {
+		return nil, handleRPCError(err)
+	}
 
 	var unsupported []string
 	for _, s1 := range supportedEngineEndpoints {
 		supported := false
-		for _, s2 := range result.SupportedMethods {
+		for _, s2 := range result {
 			if s1 == s2 {
 				supported = true
 				break
@@ -365,9 +404,9 @@ func (s *Service) ExchangeCapabilities(ctx context.Context) ([]string, error) {
 		}
 	}
 	if len(unsupported) != 0 {
-		log.Warnf("Please update client, detected the following unsupported engine methods: %s", unsupported)
+		log.WithField("methods", unsupported).Debug("Execution client does not support some engine methods")
 	}
-	return result.SupportedMethods, handleRPCError(err)
+	return result, nil
 }
 
 // GetTerminalBlockHash returns the valid terminal block hash based on total difficulty.
@@ -540,7 +579,7 @@ func (s *Service) GetPayloadBodiesByHash(ctx context.Context, executionBlockHash
 	defer span.End()
 
 	result := make([]*pb.ExecutionPayloadBodyV1, 0)
-	err := s.rpcClient.CallContext(ctx, &result, GetPayloadBodiesByHashV1, executionBlockHashes)
+	err := s.callEngine(ctx, &result, GetPayloadBodiesByHashV1, executionBlockHashes)
 
 	for i, item := range result {
 		if item == nil {
@@ -559,7 +598,7 @@ func (s *Service) GetPayloadBodiesByRange(ctx context.Context, start, count uint
 	defer span.End()
 
 	result := make([]*pb.ExecutionPayloadBodyV1, 0)
-	err := s.rpcClient.CallContext(ctx, &result, GetPayloadBodiesByRangeV1, start, count)
+	err := s.callEngine(ctx, &result, GetPayloadBodiesByRangeV1, hexutil.Uint64(start), hexutil.Uint64(count))
 
 	for i, item := range result {
 		if item == nil {
@@ -623,6 +662,7 @@ func (s *Service) ReconstructFullBellatrixBlockBatch(
 		return []interfaces.SignedBeaconBlock{}, nil
 	}
 	executionHashes := []common.Hash{}
+	executionHeaders := []interfaces.ExecutionData{}
 	validExecPayloads := []int{}
 	zeroExecPayloads := []int{}
 	for i, b := range blindedBlocks {
@@ -647,9 +687,10 @@ func (s *Service) ReconstructFullBellatrixBlockBatch(
 			executionBlockHash := common.BytesToHash(header.BlockHash())
 			validExecPayloads = append(validExecPayloads, i)
 			executionHashes = append(executionHashes, executionBlockHash)
+			executionHeaders = append(executionHeaders, header)
 		}
 	}
-	fullBlocks, err := s.retrievePayloadsFromExecutionHashes(ctx, executionHashes, validExecPayloads, blindedBlocks)
+	fullBlocks, err := s.retrievePayloadsFromExecutionHashes(ctx, executionHashes, executionHeaders, validExecPayloads, blindedBlocks)
 	if err != nil {
 		return nil, err
 	}
@@ -672,7 +713,7 @@ func (s *Service) ReconstructFullBellatrixBlockBatch(
 }
 
 func (s *Service) retrievePayloadFromExecutionHash(ctx context.Context, executionBlockHash common.Hash, header interfaces.ExecutionData, version int) (interfaces.ExecutionData, error) {
-	if features.Get().EnableOptionalEngineMethods {
+	if s.usePayloadBodies() {
 		pBodies, err := s.GetPayloadBodiesByHash(ctx, []common.Hash{executionBlockHash})
 		if err != nil {
 			return nil, fmt.Errorf("could not get payload body by hash %#x: %v", executionBlockHash, err)
@@ -699,19 +740,27 @@ func (s *Service) retrievePayloadFromExecutionHash(ctx context.Context, executio
 	return fullPayloadFromExecutionBlock(version, header, executionBlock)
 }
 
+// usePayloadBodies returns true if the payloads of blinded blocks are reconstructed from payload bodies, which
+// requires the optional engine methods to be enabled and supported by the execution client.
+func (s *Service) usePayloadBodies() bool {
+	return features.Get().EnableOptionalEngineMethods && s.capabilitySupported(GetPayloadBodiesByHashV1)
+}
+
 func (s *Service) retrievePayloadsFromExecutionHashes(
 	ctx context.Context,
 	executionHashes []common.Hash,
+	executionHeaders []interfaces.ExecutionData,
 	validExecPayloads []int,
 	blindedBlocks []interfaces.ReadOnlySignedBeaconBlock) ([]interfaces.SignedBeaconBlock, error) {
 	fullBlocks := make([]interfaces.SignedBeaconBlock, len(blindedBlocks))
 	var execBlocks []*pb.ExecutionBlock
 	var payloadBodies []*pb.ExecutionPayloadBodyV1
 	var err error
-	if features.Get().EnableOptionalEngineMethods {
-		payloadBodies, err = s.GetPayloadBodiesByHash(ctx, executionHashes)
+	usePayloadBodies := s.usePayloadBodies()
+	if usePayloadBodies {
+		payloadBodies, err = s.payloadBodies(ctx, executionHashes, executionHeaders)
 		if err != nil {
-			return nil, fmt.Errorf("could not fetch payload bodies by hash %#x: %v", executionHashes, err)
+			return nil, err
 		}
 	} else {
 		execBlocks, err = s.ExecutionBlocksByHashes(ctx, executionHashes, true /* with txs*/)
@@ -725,7 +774,7 @@ func (s *Service) retrievePayloadsFromExecutionHashes(
 	for sliceIdx, realIdx := range validExecPayloads {
 		var payload interfaces.ExecutionData
 		bblock := blindedBlocks[realIdx]
-		if features.Get().EnableOptionalEngineMethods {
+		if usePayloadBodies {
 			b := payloadBodies[sliceIdx]
 			if b == nil {
 				return nil, fmt.Errorf("received nil payload body for request by hash %#x", executionHashes[sliceIdx])
@@ -761,6 +810,91 @@ func (s *Service) retrievePayloadsFromExecutionHashes(
 	return fullBlocks, nil
 }
 
+// payloadBodies retrieves the payload bodies of the given execution blocks. A batch of consecutive blocks, such as
+// the blocks of a by range request, is fetched with a single engine_getPayloadBodiesByRangeV1 call, which execution
+// clients serve from their canonical chain without a hash lookup per block. As that chain may not be the chain of
+// the blocks, the bodies are checked against the transactions and withdrawals roots of the payload headers. Any
+// other batch, or a range response that does not match every requested block, is fetched by hash.
+func (s *Service) payloadBodies(ctx context.Context, executionHashes []common.Hash, executionHeaders []interfaces.ExecutionData) ([]*pb.ExecutionPayloadBodyV1, error) {
+	numbers := make([]uint64, len(executionHeaders))
+	for i, header := range executionHeaders {
+		numbers[i] = header.BlockNumber()
+	}
+	if isConsecutive(numbers) && s.capabilitySupported(GetPayloadBodiesByRangeV1) {
+		bodies, err := s.GetPayloadBodiesByRange(ctx, numbers[0], uint64(len(numbers)))
+		if err == nil && len(bodies) != len(numbers) {
+			err = errors.Errorf("wanted %d payload bodies but got %d", len(numbers), len(bodies))
+		}
+		for i := 0; err == nil && i < len(bodies); i++ {
+			err = verifyPayloadBody(executionHeaders[i], bodies[i])
+		}
+		if err == nil {
+			return bodies, nil
+		}
+		log.WithError(err).WithFields(logrus.Fields{
+			"start": numbers[0],
+			"count": len(numbers),
+		}).Debug("Could not retrieve payload bodies by range, retrieving them by hash")
+	}
+	bodies, err := s.GetPayloadBodiesByHash(ctx, executionHashes)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch payload bodies by hash %#x: %v", executionHashes, err)
+	}
+	if len(bodies) != len(executionHashes) {
+		return nil, errors.Errorf("could not retrieve the correct number of payload bodies: wanted %d but got %d", len(executionHashes), len(bodies))
+	}
+	return bodies, nil
+}
+
+// verifyPayloadBody checks that the transactions and withdrawals of the payload body are the ones committed to by
+// the payload header.
+func verifyPayloadBody(header interfaces.ExecutionData, body *pb.ExecutionPayloadBodyV1) error {
+	if body == nil {
+		return errors.Errorf("nil payload body for block %d", header.BlockNumber())
+	}
+	wantTxsRoot, err := header.TransactionsRoot()
+	if err != nil {
+		return err
+	}
+	txsRoot, err := ssz.TransactionsRoot(body.Transactions)
+	if err != nil {
+		return errors.Wrap(err, "could not compute transactions root")
+	}
+	if !bytes.Equal(wantTxsRoot, txsRoot[:]) {
+		return errors.Errorf("transactions root %#x of the payload body of block %d does not match the header", txsRoot, header.BlockNumber())
+	}
+	wantWithdrawalsRoot, err := header.WithdrawalsRoot()
+	if errors.Is(err, consensus_types.ErrUnsupportedField) {
+		// Payloads before Capella have no withdrawals.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	withdrawalsRoot, err := ssz.WithdrawalSliceRoot(body.Withdrawals, fieldparams.MaxWithdrawalsPerPayload)
+	if err != nil {
+		return errors.Wrap(err, "could not compute withdrawals root")
+	}
+	if !bytes.Equal(wantWithdrawalsRoot, withdrawalsRoot[:]) {
+		return errors.Errorf("withdrawals root %#x of the payload body of block %d does not match the header", withdrawalsRoot, header.BlockNumber())
+	}
+	return nil
+}
+
+// isConsecutive returns true if the given execution block numbers are strictly increasing by one, and there are
+// at least two of them.
+func isConsecutive(numbers []uint64) bool {
+	if len(numbers) < 2 {
+		return false
+	}
+	for i := 1; i < len(numbers); i++ {
+		if numbers[i] != numbers[i-1]+1 {
+			return false
+		}
+	}
+	return true
+}
+
 func fullPayloadFromExecutionBlock(
 	blockVersion int, header interfaces.ExecutionData, block *pb.ExecutionBlock,
 ) (interfaces.ExecutionData, error) {
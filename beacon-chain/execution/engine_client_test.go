@@ -1006,6 +1006,124 @@ func TestReconstructFullBellatrixBlockBatch(t *testing.T) {
 	})
 }
 
+func TestReconstructFullBellatrixBlockBatch_PayloadBodies(t *testing.T) {
+	resetFn := features.InitWithReset(&features.Flags{
+		EnableOptionalEngineMethods: true,
+	})
+	defer resetFn()
+	ctx := context.Background()
+
+	blindedBlocks := func(numbers ...uint64) []interfaces.ReadOnlySignedBeaconBlock {
+		blks := make([]interfaces.ReadOnlySignedBeaconBlock, len(numbers))
+		for i, n := range numbers {
+			payload := util.NewBeaconBlockBellatrix().Block.Body.ExecutionPayload
+			payload.BlockNumber = n
+			payload.BlockHash = bytesutil.PadTo([]byte{byte(n)}, 32)
+			payload.Transactions = [][]byte{{byte(n)}}
+			wrappedPayload, err := blocks.WrappedExecutionPayload(payload)
+			require.NoError(t, err)
+			header, err := blocks.PayloadToHeader(wrappedPayload)
+			require.NoError(t, err)
+			b := util.NewBlindedBeaconBlockBellatrix()
+			b.Block.Body.ExecutionPayloadHeader = header
+			wrapped, err := blocks.NewSignedBeaconBlock(b)
+			require.NoError(t, err)
+			blks[i] = wrapped
+		}
+		return blks
+	}
+	// The server returns one body per requested block with the block number as its only transaction, and the
+	// given number of bodies for range requests. The range bodies of a forked server are the bodies of other blocks.
+	newService := func(t *testing.T, rangeCount int, forked bool, methods *[]string) *Service {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			defer func() {
+				require.NoError(t, r.Body.Close())
+			}()
+			req := struct {
+				ID     int               `json:"id"`
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+			}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			*methods = append(*methods, req.Method)
+			var bodies []*pb.ExecutionPayloadBodyV1
+			switch req.Method {
+			case GetPayloadBodiesByRangeV1:
+				var start hexutil.Uint64
+				require.NoError(t, json.Unmarshal(req.Params[0], &start))
+				for i := 0; i < rangeCount; i++ {
+					tx := byte(uint64(start) + uint64(i))
+					if forked {
+						tx += 100
+					}
+					bodies = append(bodies, &pb.ExecutionPayloadBodyV1{Transactions: [][]byte{{tx}}})
+				}
+			case GetPayloadBodiesByHashV1:
+				var hashes []common.Hash
+				require.NoError(t, json.Unmarshal(req.Params[0], &hashes))
+				for _, h := range hashes {
+					bodies = append(bodies, &pb.ExecutionPayloadBodyV1{Transactions: [][]byte{{h[0]}}})
+				}
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+				"result":  bodies,
+			}))
+		}))
+		t.Cleanup(srv.Close)
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		t.Cleanup(rpcClient.Close)
+		return &Service{rpcClient: rpcClient}
+	}
+	assertReconstructed := func(t *testing.T, reconstructed []interfaces.SignedBeaconBlock, numbers ...uint64) {
+		require.Equal(t, len(numbers), len(reconstructed))
+		for i, n := range numbers {
+			payload, err := reconstructed[i].Block().Body().Execution()
+			require.NoError(t, err)
+			assert.Equal(t, n, payload.BlockNumber())
+			txs, err := payload.Transactions()
+			require.NoError(t, err)
+			require.DeepEqual(t, [][]byte{{byte(n)}}, txs)
+		}
+	}
+
+	t.Run("consecutive blocks by range", func(t *testing.T) {
+		var methods []string
+		service := newService(t, 3, false, &methods)
+		reconstructed, err := service.ReconstructFullBellatrixBlockBatch(ctx, blindedBlocks(5, 6, 7))
+		require.NoError(t, err)
+		assertReconstructed(t, reconstructed, 5, 6, 7)
+		require.DeepEqual(t, []string{GetPayloadBodiesByRangeV1}, methods)
+	})
+	t.Run("incomplete range falls back to hashes", func(t *testing.T) {
+		var methods []string
+		service := newService(t, 1, false, &methods)
+		reconstructed, err := service.ReconstructFullBellatrixBlockBatch(ctx, blindedBlocks(5, 6, 7))
+		require.NoError(t, err)
+		assertReconstructed(t, reconstructed, 5, 6, 7)
+		require.DeepEqual(t, []string{GetPayloadBodiesByRangeV1, GetPayloadBodiesByHashV1}, methods)
+	})
+	t.Run("range of another chain falls back to hashes", func(t *testing.T) {
+		var methods []string
+		service := newService(t, 3, true, &methods)
+		reconstructed, err := service.ReconstructFullBellatrixBlockBatch(ctx, blindedBlocks(5, 6, 7))
+		require.NoError(t, err)
+		assertReconstructed(t, reconstructed, 5, 6, 7)
+		require.DeepEqual(t, []string{GetPayloadBodiesByRangeV1, GetPayloadBodiesByHashV1}, methods)
+	})
+	t.Run("non consecutive blocks by hash", func(t *testing.T) {
+		var methods []string
+		service := newService(t, 3, false, &methods)
+		reconstructed, err := service.ReconstructFullBellatrixBlockBatch(ctx, blindedBlocks(5, 7, 8))
+		require.NoError(t, err)
+		assertReconstructed(t, reconstructed, 5, 7, 8)
+		require.DeepEqual(t, []string{GetPayloadBodiesByHashV1}, methods)
+	})
+}
+
 func TestServer_getPowBlockHashAtTerminalTotalDifficulty(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -2655,11 +2773,10 @@ func Test_ExchangeCapabilities(t *testing.T) {
 			defer func() {
 				require.NoError(t, r.Body.Close())
 			}()
-			exchangeCapabilities := &pb.ExchangeCapabilities{}
 			resp := map[string]interface{}{
 				"jsonrpc": "2.0",
 				"id":      1,
-				"result":  exchangeCapabilities,
+				"result":  []string{},
 			}
 			err := json.NewEncoder(w).Encode(resp)
 			require.NoError(t, err)
@@ -2680,7 +2797,7 @@ func Test_ExchangeCapabilities(t *testing.T) {
 		for _, item := range results {
 			require.NotNil(t, item)
 		}
-		assert.LogsContain(t, logHook, "Please update client, detected the following unsupported engine methods:")
+		assert.LogsContain(t, logHook, "Execution client does not support some engine methods")
 	})
 	t.Run("list of items", func(t *testing.T) {
 		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -2688,14 +2805,10 @@ func Test_ExchangeCapabilities(t *testing.T) {
 			defer func() {
 				require.NoError(t, r.Body.Close())
 			}()
-			exchangeCapabilities := &pb.ExchangeCapabilities{
-				SupportedMethods: []string{"A", "B", "C"},
-			}
-
 			resp := map[string]interface{}{
 				"jsonrpc": "2.0",
 				"id":      1,
-				"result":  exchangeCapabilities,
+				"result":  []string{"A", "B", "C"},
 			}
 			err := json.NewEncoder(w).Encode(resp)
 			require.NoError(t, err)
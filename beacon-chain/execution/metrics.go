@@ -31,6 +31,10 @@ var (
 			Buckets: []float64{25, 50, 100, 200, 500, 1000, 2000, 4000},
 		},
 	)
+	endpointFailoversCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "execution_endpoint_failovers_total",
+		Help: "The number of times the execution endpoint in use was switched to a fallback endpoint",
+	})
 	errParseCount = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "execution_parse_error_count",
 		Help: "The number of errors that occurred while parsing execution payload",
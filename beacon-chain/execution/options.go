@@ -1,12 +1,16 @@
 package execution
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/progress"
 	"github.com/prysmaticlabs/prysm/v4/network"
 	"github.com/prysmaticlabs/prysm/v4/network/authorization"
 )
@@ -15,24 +19,62 @@ type Option func(s *Service) error
 
 // WithHttpEndpoint parse http endpoint for the powchain service to use.
 func WithHttpEndpoint(endpointString string) Option {
+	return WithHttpEndpoints([]string{endpointString})
+}
+
+// WithHttpEndpoints parse http endpoints for the powchain service to use. The first endpoint is the primary one,
+// the others are fallen back to, in order, when the endpoint in use cannot be reached.
+func WithHttpEndpoints(endpointStrings []string) Option {
 	return func(s *Service) error {
-		s.cfg.currHttpEndpoint = network.HttpEndpoint(endpointString)
+		endpointStrings = dedupEndpoints(endpointStrings)
+		if len(endpointStrings) == 0 {
+			return errors.New("no execution endpoint provided")
+		}
+		endpoints := make([]network.Endpoint, len(endpointStrings))
+		for i, e := range endpointStrings {
+			endpoints[i] = network.HttpEndpoint(e)
+		}
+		s.cfg.httpEndpoints = endpoints
+		s.cfg.currHttpEndpoint = endpoints[0]
 		return nil
 	}
 }
 
 // WithHttpEndpointAndJWTSecret for authenticating the execution node JSON-RPC endpoint.
 func WithHttpEndpointAndJWTSecret(endpointString string, secret []byte) Option {
+	return WithHttpEndpointsAndJWTSecret([]string{endpointString}, secret)
+}
+
+// WithHttpEndpointsAndJWTSecret for authenticating the execution nodes JSON-RPC endpoints.
+func WithHttpEndpointsAndJWTSecret(endpointStrings []string, secret []byte) Option {
 	return func(s *Service) error {
 		if len(secret) == 0 {
 			return nil
 		}
+		endpointStrings = dedupEndpoints(endpointStrings)
+		if len(endpointStrings) == 0 {
+			return errors.New("no execution endpoint provided")
+		}
 		// Overwrite authorization type for all endpoints to be of a bearer type.
-		hEndpoint := network.HttpEndpoint(endpointString)
-		hEndpoint.Auth.Method = authorization.Bearer
-		hEndpoint.Auth.Value = string(secret)
+		endpoints := make([]network.Endpoint, len(endpointStrings))
+		for i, e := range endpointStrings {
+			hEndpoint := network.HttpEndpoint(e)
+			hEndpoint.Auth.Method = authorization.Bearer
+			hEndpoint.Auth.Value = string(secret)
+			endpoints[i] = hEndpoint
+		}
+		s.cfg.httpEndpoints = endpoints
+		s.cfg.currHttpEndpoint = endpoints[0]
+		s.engineDiagnostics.d.JWTSecretLoaded = time.Now()
+		return nil
+	}
+}
 
-		s.cfg.currHttpEndpoint = hEndpoint
+// WithJWTSecretFile sets the file the JWT secret was read from, which is periodically reloaded to rotate the
+// secret without restarting the node.
+func WithJWTSecretFile(jwtSecretFile string) Option {
+	return func(s *Service) error {
+		s.cfg.jwtSecretFile = jwtSecretFile
 		return nil
 	}
 }
@@ -108,3 +150,11 @@ func WithFinalizedStateAtStartup(st state.BeaconState) Option {
 		return nil
 	}
 }
+
+// WithSyncProgress to report the progress of the execution client sync.
+func WithSyncProgress(t *progress.Tracker) Option {
+	return func(s *Service) error {
+		s.cfg.syncProgress = t
+		return nil
+	}
+}
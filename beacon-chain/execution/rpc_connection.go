@@ -46,6 +46,8 @@ func (s *Service) setupExecutionClientConnections(ctx context.Context, currEndpo
 	}
 	s.updateConnectedETH1(true)
 	s.runError = nil
+	s.exchangeCapabilities(ctx)
+	s.detectClientVersion(ctx)
 	return nil
 }
 
@@ -66,17 +68,19 @@ func (s *Service) pollConnectionStatus(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			log.Debugf("Trying to dial endpoint: %s", logs.MaskCredentialsLogging(s.cfg.currHttpEndpoint.Url))
+			endpoint := s.currentEndpoint()
+			log.Debugf("Trying to dial endpoint: %s", logs.MaskCredentialsLogging(endpoint.Url))
 			currClient := s.rpcClient
-			if err := s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint); err != nil {
+			if err := s.setupExecutionClientConnections(ctx, endpoint); err != nil {
 				errorLogger(err, "Could not connect to execution client endpoint")
+				s.fallbackToNextEndpoint()
 				continue
 			}
 			// Close previous client, if connection was successful.
 			if currClient != nil {
 				currClient.Close()
 			}
-			log.Infof("Connected to new endpoint: %s", logs.MaskCredentialsLogging(s.cfg.currHttpEndpoint.Url))
+			log.Infof("Connected to new endpoint: %s", logs.MaskCredentialsLogging(endpoint.Url))
 			return
 		case <-s.ctx.Done():
 			log.Debug("Received cancelled context,closing existing powchain service")
@@ -92,8 +96,9 @@ func (s *Service) retryExecutionClientConnection(ctx context.Context, err error)
 	// Back off for a while before redialing.
 	time.Sleep(backOffPeriod)
 	currClient := s.rpcClient
-	if err := s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint); err != nil {
+	if err := s.setupExecutionClientConnections(ctx, s.currentEndpoint()); err != nil {
 		s.runError = errors.Wrap(err, "setupExecutionClientConnections")
+		s.fallbackToNextEndpoint()
 		return
 	}
 	// Close previous client, if connection was successful.
@@ -104,9 +109,115 @@ func (s *Service) retryExecutionClientConnection(ctx context.Context, err error)
 	s.runError = nil
 }
 
+// currentEndpoint returns the execution endpoint in use.
+func (s *Service) currentEndpoint() network.Endpoint {
+	s.endpointLock.RLock()
+	defer s.endpointLock.RUnlock()
+	return s.cfg.currHttpEndpoint
+}
+
+// Switches the current endpoint to the next one of the configured endpoints, wrapping around
+// to the primary endpoint after the last one.
+func (s *Service) fallbackToNextEndpoint() {
+	s.endpointLock.Lock()
+	defer s.endpointLock.Unlock()
+	totalEndpoints := len(s.cfg.httpEndpoints)
+	if totalEndpoints <= 1 {
+		return
+	}
+	currIndex := 0
+	for i, endpoint := range s.cfg.httpEndpoints {
+		if endpoint.Equals(s.cfg.currHttpEndpoint) {
+			currIndex = i
+			break
+		}
+	}
+	nextIndex := (currIndex + 1) % totalEndpoints
+	s.cfg.currHttpEndpoint = s.cfg.httpEndpoints[nextIndex]
+	endpointFailoversCount.Inc()
+	log.Infof("Falling back to alternative execution endpoint: %s", logs.MaskCredentialsLogging(s.cfg.currHttpEndpoint.Url))
+}
+
+// Switches back to the primary endpoint once it is healthy again, when running on a fallback endpoint.
+// Payloads can only be retrieved from the execution client they were requested from, so the switch
+// is deferred while a payload is being built.
+func (s *Service) checkDefaultEndpoint(ctx context.Context) {
+	s.endpointLock.RLock()
+	if len(s.cfg.httpEndpoints) == 0 {
+		s.endpointLock.RUnlock()
+		return
+	}
+	primaryEndpoint := s.cfg.httpEndpoints[0]
+	currEndpoint := s.cfg.currHttpEndpoint
+	s.endpointLock.RUnlock()
+	// Return early if we are running on our primary endpoint.
+	if currEndpoint.Equals(primaryEndpoint) {
+		return
+	}
+	if s.isBuildingPayload() {
+		log.Debug("Payload is being built, not switching back to the primary execution endpoint yet")
+		return
+	}
+	if err := s.checkEndpointHealth(ctx, primaryEndpoint); err != nil {
+		log.WithError(err).Debug("Primary execution endpoint not ready")
+		return
+	}
+	currClient := s.rpcClient
+	if err := s.setupExecutionClientConnections(ctx, primaryEndpoint); err != nil {
+		log.WithError(err).Error("Could not switch back to the primary execution endpoint")
+		// The failed attempt may have replaced the client of the current endpoint, so reconnect to it.
+		if err := s.setupExecutionClientConnections(ctx, currEndpoint); err != nil {
+			s.retryExecutionClientConnection(ctx, err)
+		}
+	} else {
+		s.endpointLock.Lock()
+		s.cfg.currHttpEndpoint = primaryEndpoint
+		s.endpointLock.Unlock()
+		log.Infof("Primary execution endpoint ready again, switched back to: %s", logs.MaskCredentialsLogging(primaryEndpoint.Url))
+	}
+	// Close previous client, as a new one was set up.
+	if currClient != nil {
+		currClient.Close()
+	}
+}
+
+// Dials the endpoint and ensures it serves the expected execution chain, without using it.
+func (s *Service) checkEndpointHealth(ctx context.Context, endpoint network.Endpoint) error {
+	client, err := s.newRPCClientWithAuth(ctx, endpoint)
+	if err != nil {
+		return errors.Wrap(err, "could not dial execution node")
+	}
+	defer client.Close()
+	return ensureCorrectExecutionChain(ctx, ethclient.NewClient(client))
+}
+
+// Records that a payload build was started on the current execution client.
+func (s *Service) markPayloadBuilding() {
+	s.payloadBuildLock.Lock()
+	defer s.payloadBuildLock.Unlock()
+	s.payloadBuildStart = time.Now()
+}
+
+// Records that the payload being built was retrieved.
+func (s *Service) clearPayloadBuilding() {
+	s.payloadBuildLock.Lock()
+	defer s.payloadBuildLock.Unlock()
+	s.payloadBuildStart = time.Time{}
+}
+
+// A payload is considered to be built until it is retrieved, or for at most one slot.
+func (s *Service) isBuildingPayload() bool {
+	s.payloadBuildLock.Lock()
+	defer s.payloadBuildLock.Unlock()
+	if s.payloadBuildStart.IsZero() {
+		return false
+	}
+	return time.Since(s.payloadBuildStart) < time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second
+}
+
 // Initializes an RPC connection with authentication headers.
 func (s *Service) newRPCClientWithAuth(ctx context.Context, endpoint network.Endpoint) (*gethRPC.Client, error) {
-	client, err := network.NewExecutionRPCClient(ctx, endpoint)
+	client, err := network.NewExecutionRPCClientWithObserver(ctx, endpoint, s.observeJWT)
 	if err != nil {
 		return nil, err
 	}
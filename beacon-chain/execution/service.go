@@ -5,6 +5,7 @@ package execution
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -30,6 +31,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	native "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/progress"
 	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/container/trie"
@@ -127,8 +129,11 @@ type config struct {
 	eth1HeaderReqLimit      uint64
 	beaconNodeStatsUpdater  BeaconNodeStatsUpdater
 	currHttpEndpoint        network.Endpoint
+	httpEndpoints           []network.Endpoint
 	headers                 []string
+	jwtSecretFile           string
 	finalizedStateAtStartup state.BeaconState
+	syncProgress            *progress.Tracker
 }
 
 // Service fetches important information about the canonical
@@ -156,6 +161,14 @@ type Service struct {
 	lastReceivedMerkleIndex int64 // Keeps track of the last received index to prevent log spam.
 	runError                error
 	preGenesisState         state.BeaconState
+	payloadBuildLock        sync.Mutex
+	payloadBuildStart       time.Time
+	engineDiagnostics       engineDiagnostics
+	capabilities            map[string]bool
+	capabilitiesLock        sync.RWMutex
+	clientVersion           *ClientVersion
+	clientVersionLock       sync.RWMutex
+	endpointLock            sync.RWMutex // guards cfg.currHttpEndpoint and cfg.httpEndpoints
 }
 
 // NewService sets up a new instance with an ethclient when given a web3 endpoint as a string in the config.
@@ -224,12 +237,12 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 
 // Start the powchain service's main event loop.
 func (s *Service) Start() {
-	if err := s.setupExecutionClientConnections(s.ctx, s.cfg.currHttpEndpoint); err != nil {
+	if err := s.setupExecutionClientConnections(s.ctx, s.currentEndpoint()); err != nil {
 		log.WithError(err).Error("Could not connect to execution endpoint")
 	}
 	// If the chain has not started already and we don't have access to eth1 nodes, we will not be
 	// able to generate the genesis state.
-	if !s.chainStartData.Chainstarted && s.cfg.currHttpEndpoint.Url == "" {
+	if !s.chainStartData.Chainstarted && s.currentEndpoint().Url == "" {
 		// check for genesis state before shutting down the node,
 		// if a genesis state exists, we can continue on.
 		genState, err := s.cfg.beaconDB.GenesisState(s.ctx)
@@ -297,7 +310,7 @@ func (s *Service) ExecutionClientConnected() bool {
 
 // ExecutionClientEndpoint returns the URL of the current, connected execution client.
 func (s *Service) ExecutionClientEndpoint() string {
-	return s.cfg.currHttpEndpoint.Url
+	return s.currentEndpoint().Url
 }
 
 // ExecutionClientConnectionErr returns the error (if any) of the current connection.
@@ -318,6 +331,41 @@ func (s *Service) updateConnectedETH1(state bool) {
 	s.updateBeaconNodeStats()
 }
 
+// syncingStatus is the result of eth_syncing while the execution client is syncing.
+type syncingStatus struct {
+	CurrentBlock hexutil.Uint64 `json:"currentBlock"`
+	HighestBlock hexutil.Uint64 `json:"highestBlock"`
+}
+
+// updateSyncProgress reports the sync of the execution client, in execution block numbers, as
+// returned by eth_syncing. The stage starts when the client reports it is syncing and completes
+// once it no longer does.
+func (s *Service) updateSyncProgress(ctx context.Context) {
+	if s.cfg.syncProgress == nil {
+		return
+	}
+	var raw json.RawMessage
+	if err := s.rpcClient.CallContext(ctx, &raw, "eth_syncing"); err != nil {
+		log.WithError(err).Debug("Could not fetch execution client sync status")
+		return
+	}
+	if string(raw) == "false" {
+		s.cfg.syncProgress.Complete(progress.ExecutionSync)
+		return
+	}
+	var status syncingStatus
+	if err := json.Unmarshal(raw, &status); err != nil {
+		log.WithError(err).Debug("Could not decode execution client sync status")
+		return
+	}
+	if !s.cfg.syncProgress.Active(progress.ExecutionSync) {
+		s.cfg.syncProgress.Start(progress.ExecutionSync, uint64(status.CurrentBlock), uint64(status.HighestBlock))
+		return
+	}
+	s.cfg.syncProgress.SetTarget(progress.ExecutionSync, uint64(status.HighestBlock))
+	s.cfg.syncProgress.Update(progress.ExecutionSync, uint64(status.CurrentBlock))
+}
+
 // refers to the latest eth1 block which follows the condition: eth1_timestamp +
 // SECONDS_PER_ETH1_BLOCK * ETH1_FOLLOW_DISTANCE <= current_unix_time
 func (s *Service) followedBlockHeight(ctx context.Context) (uint64, error) {
@@ -593,6 +641,12 @@ func (s *Service) run(done <-chan struct{}) {
 
 	chainstartTicker := time.NewTicker(logPeriod)
 	defer chainstartTicker.Stop()
+	var jwtSecretReload <-chan time.Time
+	if s.cfg.jwtSecretFile != "" {
+		jwtSecretTicker := time.NewTicker(jwtSecretReloadPeriod)
+		defer jwtSecretTicker.Stop()
+		jwtSecretReload = jwtSecretTicker.C
+	}
 
 	for {
 		select {
@@ -611,13 +665,19 @@ func (s *Service) run(done <-chan struct{}) {
 				continue
 			}
 			s.processBlockHeader(head)
+			s.updateSyncProgress(s.ctx)
 			s.handleETH1FollowDistance()
+			s.checkDefaultEndpoint(s.ctx)
 		case <-chainstartTicker.C:
 			if s.chainStartData.Chainstarted {
 				chainstartTicker.Stop()
 				continue
 			}
 			s.logTillChainStart(context.Background())
+		case <-jwtSecretReload:
+			if err := s.reloadJWTSecret(s.ctx); err != nil {
+				log.WithError(err).Error("Could not reload JWT secret")
+			}
 		}
 	}
 }
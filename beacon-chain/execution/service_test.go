@@ -2,6 +2,7 @@ package execution
 
 import (
 	"context"
+	"encoding/json"
 	"math/big"
 	"strings"
 	"testing"
@@ -21,6 +22,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution/types"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/progress"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/container/trie"
 	contracts "github.com/prysmaticlabs/prysm/v4/contracts/deposit"
@@ -727,6 +729,75 @@ func TestETH1Endpoints(t *testing.T) {
 	assert.Equal(t, firstEndpoint, s1.ExecutionClientEndpoint(), "Unexpected http endpoint")
 }
 
+func TestService_fallbackToNextEndpoint(t *testing.T) {
+	s := &Service{cfg: &config{}}
+	require.NoError(t, WithHttpEndpoints([]string{"http://primary", "http://fallback1", "http://primary", "http://fallback2"})(s))
+	require.Equal(t, 3, len(s.cfg.httpEndpoints))
+	assert.Equal(t, "http://primary", s.ExecutionClientEndpoint())
+
+	s.fallbackToNextEndpoint()
+	assert.Equal(t, "http://fallback1", s.ExecutionClientEndpoint())
+	s.fallbackToNextEndpoint()
+	assert.Equal(t, "http://fallback2", s.ExecutionClientEndpoint())
+	s.fallbackToNextEndpoint()
+	assert.Equal(t, "http://primary", s.ExecutionClientEndpoint())
+
+	// A single endpoint is kept.
+	require.NoError(t, WithHttpEndpoint("http://primary")(s))
+	s.fallbackToNextEndpoint()
+	assert.Equal(t, "http://primary", s.ExecutionClientEndpoint())
+}
+
+func TestService_checkDefaultEndpoint(t *testing.T) {
+	primaryServer, primary, err := mockExecution.SetupRPCServer()
+	require.NoError(t, err)
+	t.Cleanup(primaryServer.Stop)
+	fallbackServer, fallback, err := mockExecution.SetupRPCServer()
+	require.NoError(t, err)
+	t.Cleanup(fallbackServer.Stop)
+
+	ctx := context.Background()
+	s := &Service{
+		cfg:       &config{beaconNodeStatsUpdater: &NopBeaconNodeStatsUpdater{}},
+		rpcClient: RPCClientEmpty{},
+	}
+	require.NoError(t, WithHttpEndpoints([]string{primary, fallback})(s))
+	s.fallbackToNextEndpoint()
+	require.NoError(t, s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint))
+	require.Equal(t, fallback, s.ExecutionClientEndpoint())
+
+	// The switch back is deferred while a payload is being built.
+	s.markPayloadBuilding()
+	s.checkDefaultEndpoint(ctx)
+	assert.Equal(t, fallback, s.ExecutionClientEndpoint())
+
+	s.clearPayloadBuilding()
+	s.checkDefaultEndpoint(ctx)
+	assert.Equal(t, primary, s.ExecutionClientEndpoint())
+	assert.Equal(t, true, s.ExecutionClientConnected())
+}
+
+func TestService_checkDefaultEndpoint_PrimaryUnhealthy(t *testing.T) {
+	fallbackServer, fallback, err := mockExecution.SetupRPCServer()
+	require.NoError(t, err)
+	t.Cleanup(fallbackServer.Stop)
+
+	ctx := context.Background()
+	s := &Service{
+		cfg:       &config{beaconNodeStatsUpdater: &NopBeaconNodeStatsUpdater{}},
+		rpcClient: RPCClientEmpty{},
+	}
+	require.NoError(t, WithHttpEndpoints([]string{"http://127.0.0.1:1", fallback})(s))
+	s.fallbackToNextEndpoint()
+	require.NoError(t, s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint))
+	client := s.rpcClient
+
+	s.checkDefaultEndpoint(ctx)
+	assert.Equal(t, fallback, s.ExecutionClientEndpoint())
+	assert.Equal(t, client, s.rpcClient)
+	assert.Equal(t, true, s.ExecutionClientConnected())
+}
+
 func TestService_CacheBlockHeaders(t *testing.T) {
 	rClient := &slowRPCClient{limit: 1000}
 	s := &Service{
@@ -775,6 +846,52 @@ func TestService_FollowBlock(t *testing.T) {
 	assert.Equal(t, uint64(2283), h)
 }
 
+func TestService_UpdateSyncProgress(t *testing.T) {
+	tracker := progress.NewTracker()
+	client := &syncingRPCClient{}
+	s := &Service{cfg: &config{syncProgress: tracker}, rpcClient: client}
+	ctx := context.Background()
+
+	client.result = "false"
+	s.updateSyncProgress(ctx)
+	assert.Equal(t, (*progress.Status)(nil), tracker.Status(progress.ExecutionSync))
+
+	client.result = `{"startingBlock":"0x0","currentBlock":"0x64","highestBlock":"0x3e8"}`
+	s.updateSyncProgress(ctx)
+	st := tracker.Status(progress.ExecutionSync)
+	require.NotNil(t, st)
+	assert.Equal(t, uint64(100), st.Current)
+	assert.Equal(t, uint64(1000), st.Target)
+
+	client.result = `{"startingBlock":"0x0","currentBlock":"0x1f4","highestBlock":"0x3f2"}`
+	s.updateSyncProgress(ctx)
+	st = tracker.Status(progress.ExecutionSync)
+	assert.Equal(t, uint64(100), st.Start)
+	assert.Equal(t, uint64(500), st.Current)
+	assert.Equal(t, uint64(1010), st.Target)
+
+	client.result = "false"
+	s.updateSyncProgress(ctx)
+	assert.Equal(t, true, tracker.Status(progress.ExecutionSync).Complete)
+}
+
+type syncingRPCClient struct {
+	result string
+}
+
+func (*syncingRPCClient) Close() {}
+
+func (*syncingRPCClient) BatchCall([]rpc.BatchElem) error {
+	return errors.New("not implemented")
+}
+
+func (c *syncingRPCClient) CallContext(_ context.Context, obj interface{}, method string, _ ...interface{}) error {
+	if method != "eth_syncing" {
+		return errors.Errorf("unexpected method %s", method)
+	}
+	return json.Unmarshal([]byte(c.result), obj)
+}
+
 type slowRPCClient struct {
 	limit      int
 	numOfCalls int
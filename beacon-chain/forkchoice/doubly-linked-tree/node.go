@@ -11,10 +11,6 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 )
 
-// orphanLateBlockFirstThreshold is the number of seconds after which we
-// consider a block to be late, and thus a candidate to being reorged.
-const orphanLateBlockFirstThreshold = 4
-
 // ProcessAttestationsThreshold  is the number of seconds after which we
 // process attestations for the current slot
 const ProcessAttestationsThreshold = 10
@@ -131,13 +127,13 @@ func (n *Node) setNodeAndParentValidated(ctx context.Context) error {
 }
 
 // arrivedEarly returns whether this node was inserted before the first
-// threshold to orphan a block.
+// threshold to orphan a block, given by the ReorgLateBlockCutoff config value.
 // Note that genesisTime has seconds granularity, therefore we use a strict
 // inequality < here. For example a block that arrives 3.9999 seconds into the
 // slot will have secs = 3 below.
 func (n *Node) arrivedEarly(genesisTime uint64) (bool, error) {
 	secs, err := slots.SecondsSinceSlotStart(n.slot, genesisTime, n.timestamp)
-	return secs < orphanLateBlockFirstThreshold, err
+	return secs < params.BeaconConfig().ReorgLateBlockCutoff, err
 }
 
 // arrivedAfterOrphanCheck returns whether this block was inserted after the
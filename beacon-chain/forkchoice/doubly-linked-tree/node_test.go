@@ -278,7 +278,7 @@ func TestNode_TimeStampsChecks(t *testing.T) {
 	require.Equal(t, false, late)
 
 	// late block
-	driftGenesisTime(f, 2, orphanLateBlockFirstThreshold+1)
+	driftGenesisTime(f, 2, params.BeaconConfig().ReorgLateBlockCutoff+1)
 	root = [32]byte{'b'}
 	state, blkRoot, err = prepareForkchoiceState(ctx, 2, root, [32]byte{'a'}, [32]byte{'B'}, 0, 0)
 	require.NoError(t, err)
@@ -293,6 +293,17 @@ func TestNode_TimeStampsChecks(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, false, late)
 
+	// the same block is early with a later cutoff
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.ReorgLateBlockCutoff += 2
+	params.OverrideBeaconConfig(cfg)
+	early, err = f.store.headNode.arrivedEarly(f.store.genesisTime)
+	require.NoError(t, err)
+	require.Equal(t, true, early)
+	cfg.ReorgLateBlockCutoff -= 2
+	params.OverrideBeaconConfig(cfg)
+
 	// very late block
 	driftGenesisTime(f, 3, ProcessAttestationsThreshold+1)
 	root = [32]byte{'c'}
@@ -8,10 +8,6 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 )
 
-// orphanLateBlockProposingEarly determines the maximum threshold that we
-// consider the node is proposing early and sure to receive proposer boost
-const orphanLateBlockProposingEarly = 2
-
 // ShouldOverrideFCU returns whether the current forkchoice head is weak
 // and thus may be reorged when proposing the next block.
 // This function should only be called if the following two conditions are
@@ -34,6 +30,9 @@ const orphanLateBlockProposingEarly = 2
 // proposal time by calling GetProposerHead.
 func (f *ForkChoice) ShouldOverrideFCU() (override bool) {
 	override = false
+	if features.Get().DisableReorgLateBlocks {
+		return
+	}
 
 	// We only need to override FCU if our current head is from the current
 	// slot. This differs from the spec implementation in that we assume
@@ -73,10 +72,10 @@ func (f *ForkChoice) ShouldOverrideFCU() (override bool) {
 	if head.slot > parent.slot+1 {
 		return
 	}
-	// Do not orphan a block that has higher justification than the parent
-	// if head.unrealizedJustifiedEpoch > parent.unrealizedJustifiedEpoch {
-	//		return
-	// }
+	// Only orphan a block whose justification is competitive with its parent
+	if head.unrealizedJustifiedEpoch != parent.unrealizedJustifiedEpoch {
+		return
+	}
 
 	// Only orphan a block if the head LMD vote is weak
 	if head.weight*100 > f.store.committeeWeight*params.BeaconConfig().ReorgWeightThreshold {
@@ -136,6 +135,10 @@ func (f *ForkChoice) GetProposerHead() [32]byte {
 	if head.slot > parent.slot+1 {
 		return head.root
 	}
+	// Only orphan a block whose justification is competitive with its parent
+	if head.unrealizedJustifiedEpoch != parent.unrealizedJustifiedEpoch {
+		return head.root
+	}
 
 	// Only orphan a block if the head LMD vote is weak
 	if head.weight*100 > f.store.committeeWeight*params.BeaconConfig().ReorgWeightThreshold {
@@ -147,13 +150,13 @@ func (f *ForkChoice) GetProposerHead() [32]byte {
 		return head.root
 	}
 
-	// Only reorg if we are proposing early
+	// Only reorg if we are proposing early enough to be sure to receive the proposer boost
 	secs, err := slots.SecondsSinceSlotStart(head.slot+1, f.store.genesisTime, uint64(time.Now().Unix()))
 	if err != nil {
 		log.WithError(err).Error("could not check if proposing early")
 		return head.root
 	}
-	if secs >= orphanLateBlockProposingEarly {
+	if secs >= params.BeaconConfig().ReorgProposingEarlyCutoff {
 		return head.root
 	}
 	return parent.root
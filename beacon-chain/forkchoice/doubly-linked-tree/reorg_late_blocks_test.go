@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 )
@@ -28,7 +29,7 @@ func TestForkChoice_ShouldOverrideFCU(t *testing.T) {
 	}
 	f.ProcessAttestation(ctx, attesters, root, 0)
 
-	driftGenesisTime(f, 2, orphanLateBlockFirstThreshold+1)
+	driftGenesisTime(f, 2, params.BeaconConfig().ReorgLateBlockCutoff+1)
 	st, root, err = prepareForkchoiceState(ctx, 2, [32]byte{'b'}, [32]byte{'a'}, [32]byte{'B'}, 0, 0)
 	require.NoError(t, err)
 	require.NoError(t, f.InsertNode(ctx, st, root))
@@ -48,14 +49,14 @@ func TestForkChoice_ShouldOverrideFCU(t *testing.T) {
 	t.Run("head is not from current slot", func(t *testing.T) {
 		driftGenesisTime(f, 3, 0)
 		require.Equal(t, false, f.ShouldOverrideFCU())
-		driftGenesisTime(f, 2, orphanLateBlockFirstThreshold+1)
+		driftGenesisTime(f, 2, params.BeaconConfig().ReorgLateBlockCutoff+1)
 	})
 	t.Run("head is from epoch boundary", func(t *testing.T) {
 		saved := f.store.headNode.slot
 		driftGenesisTime(f, params.BeaconConfig().SlotsPerEpoch-1, 0)
 		f.store.headNode.slot = params.BeaconConfig().SlotsPerEpoch - 1
 		require.Equal(t, false, f.ShouldOverrideFCU())
-		driftGenesisTime(f, 2, orphanLateBlockFirstThreshold+1)
+		driftGenesisTime(f, 2, params.BeaconConfig().ReorgLateBlockCutoff+1)
 		f.store.headNode.slot = saved
 	})
 	t.Run("head is early", func(t *testing.T) {
@@ -67,10 +68,10 @@ func TestForkChoice_ShouldOverrideFCU(t *testing.T) {
 	t.Run("chain not finalizing", func(t *testing.T) {
 		saved := f.store.headNode.slot
 		f.store.headNode.slot = 97
-		driftGenesisTime(f, 97, orphanLateBlockFirstThreshold+1)
+		driftGenesisTime(f, 97, params.BeaconConfig().ReorgLateBlockCutoff+1)
 		require.Equal(t, false, f.ShouldOverrideFCU())
 		f.store.headNode.slot = saved
-		driftGenesisTime(f, 2, orphanLateBlockFirstThreshold+1)
+		driftGenesisTime(f, 2, params.BeaconConfig().ReorgLateBlockCutoff+1)
 	})
 	t.Run("Not single block reorg", func(t *testing.T) {
 		saved := f.store.headNode.parent.slot
@@ -84,6 +85,16 @@ func TestForkChoice_ShouldOverrideFCU(t *testing.T) {
 		require.Equal(t, false, f.ShouldOverrideFCU())
 		f.store.headNode.parent = saved
 	})
+	t.Run("head has better justification", func(t *testing.T) {
+		f.store.headNode.unrealizedJustifiedEpoch = 1
+		require.Equal(t, false, f.ShouldOverrideFCU())
+		f.store.headNode.unrealizedJustifiedEpoch = 0
+	})
+	t.Run("reorgs disabled", func(t *testing.T) {
+		resetCfg := features.InitWithReset(&features.Flags{DisableReorgLateBlocks: true})
+		defer resetCfg()
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
 	t.Run("parent is weak", func(t *testing.T) {
 		saved := f.store.headNode.parent.weight
 		f.store.headNode.parent.weight = 0
@@ -125,7 +136,7 @@ func TestForkChoice_GetProposerHead(t *testing.T) {
 	headRoot, err := f.Head(ctx)
 	require.NoError(t, err)
 	require.Equal(t, root, headRoot)
-	f.store.headNode.timestamp -= params.BeaconConfig().SecondsPerSlot - orphanLateBlockFirstThreshold
+	f.store.headNode.timestamp -= params.BeaconConfig().SecondsPerSlot - params.BeaconConfig().ReorgLateBlockCutoff
 	t.Run("head is weak", func(t *testing.T) {
 		require.Equal(t, parentRoot, f.GetProposerHead())
 
@@ -175,6 +186,11 @@ func TestForkChoice_GetProposerHead(t *testing.T) {
 		require.Equal(t, childRoot, f.GetProposerHead())
 		f.store.headNode.parent = saved
 	})
+	t.Run("head has better justification", func(t *testing.T) {
+		f.store.headNode.unrealizedJustifiedEpoch = 1
+		require.Equal(t, childRoot, f.GetProposerHead())
+		f.store.headNode.unrealizedJustifiedEpoch = 0
+	})
 	t.Run("parent is weak", func(t *testing.T) {
 		saved := f.store.headNode.parent.weight
 		f.store.headNode.parent.weight = 0
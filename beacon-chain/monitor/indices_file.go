@@ -0,0 +1,157 @@
+package monitor
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// readIndicesFile parses a file listing validators to track, by index or by 0x prefixed public key,
+// separated by new lines or commas. Text following a # on a line is a comment.
+func readIndicesFile(path string) ([]primitives.ValidatorIndex, [][fieldparams.BLSPubkeyLength]byte, error) {
+	content, err := os.ReadFile(path) // #nosec G304 -- the path is given by the node operator.
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not read file")
+	}
+	var indices []primitives.ValidatorIndex
+	var pubkeys [][fieldparams.BLSPubkeyLength]byte
+	for n, line := range strings.Split(string(content), "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		for _, entry := range strings.Split(line, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			if strings.HasPrefix(entry, "0x") {
+				pubkey, err := hexutil.Decode(entry)
+				if err != nil {
+					return nil, nil, errors.Wrapf(err, "invalid public key %s on line %d", entry, n+1)
+				}
+				if len(pubkey) != fieldparams.BLSPubkeyLength {
+					return nil, nil, errors.Errorf("invalid public key %s on line %d: wrong length", entry, n+1)
+				}
+				pubkeys = append(pubkeys, bytesutil.ToBytes48(pubkey))
+				continue
+			}
+			idx, err := strconv.ParseUint(entry, 10, 64)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "invalid validator index %s on line %d", entry, n+1)
+			}
+			indices = append(indices, primitives.ValidatorIndex(idx))
+		}
+	}
+	return indices, pubkeys, nil
+}
+
+// reloadIndicesFileAtSlot reloads the indices file once per epoch, on the first block of the epoch.
+func (s *Service) reloadIndicesFileAtSlot(ctx context.Context, slot primitives.Slot) {
+	if s.config.IndicesFile == "" {
+		return
+	}
+	epoch := slots.ToEpoch(slot)
+	s.RLock()
+	reloaded := epoch <= s.fileReloadedEpoch
+	s.RUnlock()
+	if reloaded {
+		return
+	}
+	st, err := s.config.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get head state")
+		return
+	}
+	if st == nil {
+		log.Error("Head state is nil")
+		return
+	}
+	s.reloadIndicesFile(ctx, st, epoch)
+}
+
+// reloadIndicesFile tracks the validators listed in the indices file if it was modified since it was
+// last read, or if some of its public keys were not found in the state. Validators which are no longer
+// listed stop being tracked, unless they were also given by flag or through the API.
+func (s *Service) reloadIndicesFile(ctx context.Context, st state.ReadOnlyBeaconState, epoch primitives.Epoch) {
+	if s.config.IndicesFile == "" {
+		return
+	}
+	info, err := os.Stat(s.config.IndicesFile)
+	if err != nil {
+		log.WithError(err).WithField("Path", s.config.IndicesFile).Error("Could not read validator indices file")
+		return
+	}
+	s.RLock()
+	unchanged := info.ModTime().Equal(s.fileModTime) && !s.filePendingPubkeys
+	s.RUnlock()
+	if unchanged {
+		s.Lock()
+		s.fileReloadedEpoch = epoch
+		s.Unlock()
+		return
+	}
+
+	indices, pubkeys, err := readIndicesFile(s.config.IndicesFile)
+	if err != nil {
+		log.WithError(err).WithField("Path", s.config.IndicesFile).Error("Could not read validator indices file")
+		return
+	}
+	listed := make(map[primitives.ValidatorIndex]bool, len(indices)+len(pubkeys))
+	for _, idx := range indices {
+		listed[idx] = true
+	}
+	pending := 0
+	for _, pubkey := range pubkeys {
+		idx, ok := st.ValidatorIndexByPubkey(pubkey)
+		if !ok {
+			pending++
+			continue
+		}
+		listed[idx] = true
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	s.fileModTime = info.ModTime()
+	s.filePendingPubkeys = pending > 0
+	s.fileReloadedEpoch = epoch
+
+	var unlisted []primitives.ValidatorIndex
+	for idx := range s.fileTracked {
+		if !listed[idx] {
+			unlisted = append(unlisted, idx)
+		}
+	}
+	s.untrackValidators(unlisted)
+
+	wanted := make([]primitives.ValidatorIndex, 0, len(listed))
+	for idx := range listed {
+		wanted = append(wanted, idx)
+	}
+	sort.Slice(wanted, func(i, j int) bool { return wanted[i] < wanted[j] })
+	added, err := s.trackValidators(ctx, wanted)
+	for _, idx := range added {
+		s.fileTracked[idx] = true
+	}
+	if err != nil {
+		log.WithError(err).Error("Could not initialize validators of the indices file")
+	}
+	log.WithFields(logrus.Fields{
+		"Path":            s.config.IndicesFile,
+		"Validators":      len(listed),
+		"UnknownPubkeys":  pending,
+		"NewlyTracked":    len(added),
+		"NoLongerTracked": len(unlisted),
+	}).Info("Loaded validator indices file")
+}
@@ -0,0 +1,71 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func writeIndicesFile(t *testing.T, path string, content string, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestReadIndicesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "indices")
+	pubkey := bytesutil.PadTo([]byte{1, 2, 3}, fieldparams.BLSPubkeyLength)
+	writeIndicesFile(t, path, fmt.Sprintf("1, 2\n# comment\n%#x # operator A\n\n3,", pubkey), time.Now())
+
+	indices, pubkeys, err := readIndicesFile(path)
+	require.NoError(t, err)
+	require.DeepEqual(t, []primitives.ValidatorIndex{1, 2, 3}, indices)
+	require.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{bytesutil.ToBytes48(pubkey)}, pubkeys)
+
+	writeIndicesFile(t, path, "1\n0x0102", time.Now())
+	_, _, err = readIndicesFile(path)
+	require.ErrorContains(t, "invalid public key 0x0102 on line 2", err)
+
+	writeIndicesFile(t, path, "1\nfoo", time.Now())
+	_, _, err = readIndicesFile(path)
+	require.ErrorContains(t, "invalid validator index foo on line 2", err)
+}
+
+func TestReloadIndicesFile(t *testing.T) {
+	ctx := context.Background()
+	s := setupService(t)
+	path := filepath.Join(t.TempDir(), "indices")
+	s.config.IndicesFile = path
+	st, err := s.config.HeadFetcher.HeadState(ctx)
+	require.NoError(t, err)
+
+	modTime := time.Now()
+	unknown := bytesutil.PadTo([]byte{1}, fieldparams.BLSPubkeyLength)
+	writeIndicesFile(t, path, fmt.Sprintf("20\n%#x\n%#x", st.Validators()[30].PublicKey, unknown), modTime)
+	s.reloadIndicesFile(ctx, st, 0)
+	require.DeepEqual(t, []primitives.ValidatorIndex{1, 2, 12, 15, 20, 30}, s.TrackedValidatorIndices())
+	require.DeepEqual(t, map[primitives.ValidatorIndex]bool{20: true, 30: true}, s.fileTracked)
+	require.Equal(t, true, s.filePendingPubkeys)
+
+	// Validators given by flag are not removed with the file.
+	modTime = modTime.Add(time.Second)
+	writeIndicesFile(t, path, "1\n20", modTime)
+	s.reloadIndicesFile(ctx, st, 1)
+	require.DeepEqual(t, []primitives.ValidatorIndex{1, 2, 12, 15, 20}, s.TrackedValidatorIndices())
+	require.DeepEqual(t, map[primitives.ValidatorIndex]bool{20: true}, s.fileTracked)
+	require.Equal(t, false, s.filePendingPubkeys)
+	require.Equal(t, primitives.Epoch(1), s.fileReloadedEpoch)
+
+	modTime = modTime.Add(time.Second)
+	writeIndicesFile(t, path, "", modTime)
+	s.reloadIndicesFile(ctx, st, 2)
+	require.DeepEqual(t, []primitives.ValidatorIndex{1, 2, 12, 15}, s.TrackedValidatorIndices())
+	require.Equal(t, 0, len(s.fileTracked))
+}
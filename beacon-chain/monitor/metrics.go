@@ -1,8 +1,11 @@
 package monitor
 
 import (
+	"fmt"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/sirupsen/logrus"
 )
 
@@ -90,4 +93,141 @@ var (
 			"validator_index",
 		},
 	)
+	// includedAttestationsCounter used to track included attestations
+	includedAttestationsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "monitor",
+			Name:      "included_attestations_total",
+			Help:      "Number of attestations included in blocks",
+		},
+		[]string{
+			"validator_index",
+		},
+	)
+	// inclusionDistanceCounter used to track the sum of inclusion distances, the
+	// average distance is its rate over the rate of included attestations
+	inclusionDistanceCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "monitor",
+			Name:      "inclusion_distance_slots_total",
+			Help:      "Sum of the inclusion distances, in slots, of the included attestations",
+		},
+		[]string{
+			"validator_index",
+		},
+	)
+	// inclusionDistanceHistogram used to track the distribution of inclusion distances, which unlike the
+	// inclusion slot is meaningful for validators aggregated into buckets
+	inclusionDistanceHistogram = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "monitor",
+			Name:      "inclusion_distance_slots",
+			Help:      "Inclusion distance, in slots, of the included attestations",
+			Buckets:   []float64{1, 2, 3, 4, 8, 16, 32},
+		},
+		[]string{
+			"validator_index",
+		},
+	)
+	// missedAttestationsCounter used to track epochs without a timely attestation
+	missedAttestationsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "monitor",
+			Name:      "missed_attestations_total",
+			Help:      "Number of epochs in which an active validator had no attestation included",
+		},
+		[]string{
+			"validator_index",
+		},
+	)
+	// missedProposalsCounter used to track skipped slots of tracked proposers
+	missedProposalsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "monitor",
+			Name:      "missed_proposals_total",
+			Help:      "Number of slots without a block whose proposer was a tracked validator",
+		},
+		[]string{
+			"validator_index",
+		},
+	)
+	// balanceGauge used to track balances
+	balanceGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "monitor",
+			Name:      "balance_gwei",
+			Help:      "Balance at the start of the epoch",
+		},
+		[]string{
+			"validator_index",
+		},
+	)
+	// balanceChangeGauge used to track rewards
+	balanceChangeGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "monitor",
+			Name:      "epoch_balance_change_gwei",
+			Help:      "Change of the balance over the last epoch, which are the rewards net of penalties unless a deposit or withdrawal was processed",
+		},
+		[]string{
+			"validator_index",
+		},
+	)
+
+	validatorMetrics = []interface {
+		DeleteLabelValues(...string) bool
+		Reset()
+	}{
+		inclusionSlotGauge,
+		timelyHeadCounter,
+		timelyTargetCounter,
+		timelySourceCounter,
+		proposedSlotsCounter,
+		aggregationCounter,
+		syncCommitteeContributionCounter,
+		includedAttestationsCounter,
+		inclusionDistanceCounter,
+		inclusionDistanceHistogram,
+		missedAttestationsCounter,
+		missedProposalsCounter,
+		balanceGauge,
+		balanceChangeGauge,
+	}
 )
+
+// metricLabel returns the validator_index label of the validator. When more validators are tracked than
+// MaxMetricsKeys, validators are aggregated into MaxMetricsKeys buckets so that the cardinality of the
+// metrics stays bounded, and the inclusion slot, which is only meaningful per validator, is no longer
+// reported. It assumes the caller holds the service Lock.
+func (s *Service) metricLabel(idx primitives.ValidatorIndex) string {
+	if s.aggregateMetrics {
+		return fmt.Sprintf("bucket_%d", uint64(idx)%uint64(s.config.MaxMetricsKeys))
+	}
+	return fmt.Sprintf("%d", idx)
+}
+
+// updateMetricsAggregation switches between per validator and aggregated metrics when the number of
+// tracked validators crosses MaxMetricsKeys. It assumes the caller holds the service Lock.
+func (s *Service) updateMetricsAggregation() {
+	aggregate := s.config.MaxMetricsKeys > 0 && len(s.TrackedValidators) > s.config.MaxMetricsKeys
+	if aggregate == s.aggregateMetrics {
+		return
+	}
+	s.aggregateMetrics = aggregate
+	for _, m := range validatorMetrics {
+		m.Reset()
+	}
+	if aggregate {
+		log.WithFields(logrus.Fields{
+			"TrackedValidators": len(s.TrackedValidators),
+			"Buckets":           s.config.MaxMetricsKeys,
+		}).Info("Aggregating the metrics of tracked validators into buckets")
+	}
+}
+
+// deleteValidatorMetrics drops the series of a validator which is no longer tracked.
+func deleteValidatorMetrics(label string) {
+	for _, m := range validatorMetrics {
+		m.DeleteLabelValues(label)
+	}
+}
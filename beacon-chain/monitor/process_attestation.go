@@ -90,8 +90,15 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 			latestPerf.balance = balance
 			latestPerf.attestedSlot = att.Data.Slot
 			latestPerf.inclusionSlot = state.Slot()
-			inclusionSlotGauge.WithLabelValues(fmt.Sprintf("%d", idx)).Set(float64(latestPerf.inclusionSlot))
-			aggregatedPerf.totalDistance += uint64(latestPerf.inclusionSlot - latestPerf.attestedSlot)
+			label := s.metricLabel(primitives.ValidatorIndex(idx))
+			distance := uint64(latestPerf.inclusionSlot - latestPerf.attestedSlot)
+			if !s.aggregateMetrics {
+				inclusionSlotGauge.WithLabelValues(label).Set(float64(latestPerf.inclusionSlot))
+			}
+			includedAttestationsCounter.WithLabelValues(label).Inc()
+			inclusionDistanceCounter.WithLabelValues(label).Add(float64(distance))
+			inclusionDistanceHistogram.WithLabelValues(label).Observe(float64(distance))
+			aggregatedPerf.totalDistance += distance
 
 			if state.Version() == version.Altair {
 				targetIdx := params.BeaconConfig().TimelyTargetFlagIndex
@@ -134,15 +141,15 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 				latestPerf.timelyTarget = hasFlag
 
 				if latestPerf.timelySource {
-					timelySourceCounter.WithLabelValues(fmt.Sprintf("%d", idx)).Inc()
+					timelySourceCounter.WithLabelValues(label).Inc()
 					aggregatedPerf.totalCorrectSource++
 				}
 				if latestPerf.timelyHead {
-					timelyHeadCounter.WithLabelValues(fmt.Sprintf("%d", idx)).Inc()
+					timelyHeadCounter.WithLabelValues(label).Inc()
 					aggregatedPerf.totalCorrectHead++
 				}
 				if latestPerf.timelyTarget {
-					timelyTargetCounter.WithLabelValues(fmt.Sprintf("%d", idx)).Inc()
+					timelyTargetCounter.WithLabelValues(label).Inc()
 					aggregatedPerf.totalCorrectTarget++
 				}
 			}
@@ -202,7 +209,7 @@ func (s *Service) processAggregatedAttestation(ctx context.Context, att *ethpb.A
 		aggregatedPerf := s.aggregatedPerformance[att.AggregatorIndex]
 		aggregatedPerf.totalAggregations++
 		s.aggregatedPerformance[att.AggregatorIndex] = aggregatedPerf
-		aggregationCounter.WithLabelValues(fmt.Sprintf("%d", att.AggregatorIndex)).Inc()
+		aggregationCounter.WithLabelValues(s.metricLabel(att.AggregatorIndex)).Inc()
 	}
 
 	var root [32]byte
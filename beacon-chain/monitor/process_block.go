@@ -5,10 +5,12 @@ import (
 	"fmt"
 
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"github.com/sirupsen/logrus"
@@ -47,6 +49,7 @@ func (s *Service) processBlock(ctx context.Context, b interfaces.ReadOnlySignedB
 	currEpoch := slots.ToEpoch(blk.Slot())
 	s.RLock()
 	lastSyncedEpoch := s.lastSyncedEpoch
+	lastSummaryEpoch := s.lastSummaryEpoch
 	s.RUnlock()
 
 	if currEpoch != lastSyncedEpoch &&
@@ -54,6 +57,15 @@ func (s *Service) processBlock(ctx context.Context, b interfaces.ReadOnlySignedB
 		s.updateSyncCommitteeTrackedVals(st)
 	}
 
+	parentRoot := blk.ParentRoot()
+	parent := s.config.StateGen.StateByRootIfCachedNoCopy(parentRoot)
+	if parent != nil {
+		s.processMissedProposals(ctx, parent, st, blk.Slot())
+	}
+	if currEpoch > lastSummaryEpoch {
+		s.processEpochSummary(parent, st)
+	}
+
 	s.processSyncAggregate(st, blk)
 	s.processProposedBlock(st, root, blk)
 	s.processAttestations(ctx, st, blk)
@@ -69,7 +81,7 @@ func (s *Service) processProposedBlock(state state.BeaconState, root [32]byte, b
 	defer s.Unlock()
 	if s.trackedIndex(blk.ProposerIndex()) {
 		// update metrics
-		proposedSlotsCounter.WithLabelValues(fmt.Sprintf("%d", blk.ProposerIndex())).Inc()
+		proposedSlotsCounter.WithLabelValues(s.metricLabel(blk.ProposerIndex())).Inc()
 
 		// update the performance map
 		balance, err := state.BalanceAtIndex(blk.ProposerIndex())
@@ -101,6 +113,51 @@ func (s *Service) processProposedBlock(state state.BeaconState, root [32]byte, b
 	}
 }
 
+// processMissedProposals reports the tracked validators which were expected to propose a block in the
+// slots skipped between the parent block and the block at the given slot.
+func (s *Service) processMissedProposals(ctx context.Context, parent, st state.ReadOnlyBeaconState, slot primitives.Slot) {
+	for missed := parent.Slot() + 1; missed < slot; missed++ {
+		// The proposer of a slot depends on the effective balances of its epoch, which are known from
+		// the parent state or the state of the block only.
+		proposerState := st
+		if slots.ToEpoch(missed) != slots.ToEpoch(slot) {
+			if slots.ToEpoch(missed) != slots.ToEpoch(parent.Slot()) {
+				continue
+			}
+			proposerState = parent
+		}
+		proposer, err := proposerIndexAtSlot(ctx, proposerState, missed)
+		if err != nil {
+			log.WithError(err).WithField("Slot", missed).Debug("Could not compute proposer of skipped slot")
+			continue
+		}
+		s.Lock()
+		if s.trackedIndex(proposer) {
+			missedProposalsCounter.WithLabelValues(s.metricLabel(proposer)).Inc()
+			log.WithFields(logrus.Fields{
+				"ProposerIndex": proposer,
+				"Slot":          missed,
+			}).Info("Proposal was missed")
+		}
+		s.Unlock()
+	}
+}
+
+// proposerIndexAtSlot computes the proposer of a slot of the current epoch of the state.
+func proposerIndexAtSlot(ctx context.Context, st state.ReadOnlyBeaconState, slot primitives.Slot) (primitives.ValidatorIndex, error) {
+	epoch := slots.ToEpoch(slot)
+	seed, err := helpers.Seed(st, epoch, params.BeaconConfig().DomainBeaconProposer)
+	if err != nil {
+		return 0, err
+	}
+	indices, err := helpers.ActiveValidatorIndices(ctx, st, epoch)
+	if err != nil {
+		return 0, err
+	}
+	seedWithSlot := append(seed[:], bytesutil.Bytes8(uint64(slot))...)
+	return helpers.ComputeProposerIndex(st, indices, hash.Hash(seedWithSlot))
+}
+
 // processSlashings logs the event when tracked validators was slashed
 func (s *Service) processSlashings(blk interfaces.ReadOnlyBeaconBlock) {
 	s.RLock()
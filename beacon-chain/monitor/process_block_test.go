@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
@@ -184,6 +185,34 @@ func TestProcessProposedBlock(t *testing.T) {
 
 }
 
+func TestProcessMissedProposals(t *testing.T) {
+	hook := logTest.NewGlobal()
+	helpers.ClearCache()
+	ctx := context.Background()
+	s := setupService(t)
+	parent, _ := util.DeterministicGenesisState(t, 256)
+	st := parent.Copy()
+	require.NoError(t, st.SetSlot(4))
+
+	proposers := make(map[primitives.Slot]primitives.ValidatorIndex)
+	for slot := primitives.Slot(1); slot < 4; slot++ {
+		slotState := parent.Copy()
+		require.NoError(t, slotState.SetSlot(slot))
+		proposer, err := helpers.BeaconProposerIndex(ctx, slotState)
+		require.NoError(t, err)
+		proposers[slot] = proposer
+	}
+	s.TrackedValidators = map[primitives.ValidatorIndex]bool{proposers[2]: true}
+
+	s.processMissedProposals(ctx, parent, st, 4)
+	require.LogsContain(t, hook, fmt.Sprintf("\"Proposal was missed\" ProposerIndex=%d Slot=2 prefix=monitor", proposers[2]))
+	for _, slot := range []primitives.Slot{1, 3} {
+		if proposers[slot] != proposers[2] {
+			require.LogsDoNotContain(t, hook, fmt.Sprintf("Slot=%d prefix=monitor", slot))
+		}
+	}
+}
+
 func TestProcessBlock_AllEventsTrackedVals(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ctx := context.Background()
@@ -0,0 +1,79 @@
+package monitor
+
+import (
+	"sort"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// processEpochSummary reports, on the first block of an epoch, the balances of the tracked validators,
+// their change over the last epoch, and the validators which missed their attestation two epochs ago.
+// The participation of that epoch is final in the parent state when it belongs to the previous epoch.
+func (s *Service) processEpochSummary(parent, st state.ReadOnlyBeaconState) {
+	epoch := slots.ToEpoch(st.Slot())
+	s.Lock()
+	defer s.Unlock()
+	s.lastSummaryEpoch = epoch
+
+	balances := make(map[string]uint64)
+	changes := make(map[string]int64)
+	for idx := range s.TrackedValidators {
+		balance, err := st.BalanceAtIndex(idx)
+		if err != nil {
+			continue
+		}
+		label := s.metricLabel(idx)
+		balances[label] += balance
+		if previous, ok := s.epochStartBalances[idx]; ok {
+			changes[label] += int64(balance) - int64(previous)
+		}
+		s.epochStartBalances[idx] = balance
+	}
+	for label, balance := range balances {
+		balanceGauge.WithLabelValues(label).Set(float64(balance))
+	}
+	for label, change := range changes {
+		balanceChangeGauge.WithLabelValues(label).Set(float64(change))
+	}
+
+	if parent == nil || parent.Version() < version.Altair || epoch < 2 || slots.ToEpoch(parent.Slot())+1 != epoch {
+		return
+	}
+	participation, err := parent.PreviousEpochParticipation()
+	if err != nil {
+		log.WithError(err).Error("Could not get previous epoch participation")
+		return
+	}
+	attestedEpoch := epoch - 2
+	var missed []primitives.ValidatorIndex
+	for idx := range s.TrackedValidators {
+		if uint64(idx) >= uint64(len(participation)) {
+			continue
+		}
+		v, err := parent.ValidatorAtIndexReadOnly(idx)
+		if err != nil || !helpers.IsActiveValidatorUsingTrie(v, attestedEpoch) {
+			continue
+		}
+		if participation[idx] == 0 {
+			missedAttestationsCounter.WithLabelValues(s.metricLabel(idx)).Inc()
+			missed = append(missed, idx)
+		}
+	}
+	if len(missed) == 0 {
+		return
+	}
+	sort.Slice(missed, func(i, j int) bool { return missed[i] < missed[j] })
+	fields := logrus.Fields{
+		"Epoch":  attestedEpoch,
+		"Missed": len(missed),
+	}
+	if !s.aggregateMetrics {
+		fields["ValidatorIndices"] = missed
+	}
+	log.WithFields(fields).Info("Attestations were missed")
+}
@@ -0,0 +1,45 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestProcessEpochSummary(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	s := setupService(t)
+	st, err := s.config.HeadFetcher.HeadState(ctx)
+	require.NoError(t, err)
+	parent := st.Copy()
+
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	require.NoError(t, parent.SetSlot(2*slotsPerEpoch-1))
+	participation := make([]byte, parent.NumValidators())
+	participation[1] = 1
+	participation[12] = 7
+	require.NoError(t, parent.SetPreviousParticipationBits(participation))
+	require.NoError(t, st.SetSlot(2*slotsPerEpoch))
+
+	s.processEpochSummary(parent, st)
+	require.LogsContain(t, hook, "\"Attestations were missed\" Epoch=0 Missed=2 ValidatorIndices=\"[2 15]\"")
+	require.Equal(t, primitives.Epoch(2), s.lastSummaryEpoch)
+	require.Equal(t, uint64(32000000000), s.epochStartBalances[15])
+}
+
+func TestMetricLabel(t *testing.T) {
+	s := setupService(t)
+	require.Equal(t, "15", s.metricLabel(15))
+
+	s.config.MaxMetricsKeys = 3
+	s.updateMetricsAggregation()
+	require.Equal(t, "bucket_0", s.metricLabel(15))
+
+	s.untrackValidators([]primitives.ValidatorIndex{1})
+	require.Equal(t, "15", s.metricLabel(15))
+}
@@ -1,8 +1,6 @@
 package monitor
 
 import (
-	"fmt"
-
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
@@ -66,7 +64,7 @@ func (s *Service) processSyncAggregate(state state.BeaconState, blk interfaces.R
 			s.aggregatedPerformance[validatorIdx] = aggPerf
 
 			syncCommitteeContributionCounter.WithLabelValues(
-				fmt.Sprintf("%d", validatorIdx)).Add(float64(contrib))
+				s.metricLabel(validatorIdx)).Add(float64(contrib))
 
 			log.WithFields(logrus.Fields{
 				"ValidatorIndex":       validatorIdx,
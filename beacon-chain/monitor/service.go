@@ -2,16 +2,18 @@ package monitor
 
 import (
 	"context"
-	"errors"
 	"sort"
 	"sync"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/async/event"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
@@ -52,13 +54,28 @@ type ValidatorAggregatedPerformance struct {
 
 // ValidatorMonitorConfig contains the list of validator indices that the
 // monitor service tracks, and the event feed notifier that the
-// monitor needs to subscribe.
+// monitor needs to subscribe. When DB is set, validators added or removed
+// at runtime are persisted so that they survive restarts. Validators listed
+// in IndicesFile are tracked as long as they remain in the file, and are not
+// persisted. When more than MaxMetricsKeys validators are tracked, their
+// metrics are aggregated into MaxMetricsKeys buckets.
 type ValidatorMonitorConfig struct {
 	StateNotifier       statefeed.Notifier
 	AttestationNotifier operation.Notifier
 	HeadFetcher         blockchain.HeadFetcher
 	StateGen            stategen.StateManager
 	InitialSyncComplete chan struct{}
+	DB                  db.NoHeadAccessDatabase
+	IndicesFile         string
+	MaxMetricsKeys      int
+}
+
+// TrackedValidatorsManager allows the set of validators tracked by the monitor
+// to be inspected and updated at runtime.
+type TrackedValidatorsManager interface {
+	TrackedValidatorIndices() []primitives.ValidatorIndex
+	AddTrackedValidators(ctx context.Context, indices []primitives.ValidatorIndex) error
+	RemoveTrackedValidators(ctx context.Context, indices []primitives.ValidatorIndex) error
 }
 
 // Service is the main structure that tracks validators and reports logs and
@@ -70,7 +87,8 @@ type Service struct {
 	isLogging bool
 
 	// Locks access to TrackedValidators, latestPerformance, aggregatedPerformance,
-	// trackedSyncedCommitteeIndices and lastSyncedEpoch
+	// trackedSyncedCommitteeIndices, lastSyncedEpoch, the indices file state,
+	// the epoch summary state and aggregateMetrics
 	sync.RWMutex
 
 	TrackedValidators           map[primitives.ValidatorIndex]bool
@@ -78,6 +96,15 @@ type Service struct {
 	aggregatedPerformance       map[primitives.ValidatorIndex]ValidatorAggregatedPerformance
 	trackedSyncCommitteeIndices map[primitives.ValidatorIndex][]primitives.CommitteeIndex
 	lastSyncedEpoch             primitives.Epoch
+
+	// fileTracked holds the validators tracked because they are listed in the indices file.
+	fileTracked        map[primitives.ValidatorIndex]bool
+	fileModTime        time.Time
+	filePendingPubkeys bool
+	fileReloadedEpoch  primitives.Epoch
+	lastSummaryEpoch   primitives.Epoch
+	epochStartBalances map[primitives.ValidatorIndex]uint64
+	aggregateMetrics   bool
 }
 
 // NewService sets up a new validator monitor service instance when given a list of validator indices to track.
@@ -91,11 +118,14 @@ func NewService(ctx context.Context, config *ValidatorMonitorConfig, tracked []p
 		latestPerformance:           make(map[primitives.ValidatorIndex]ValidatorLatestPerformance),
 		aggregatedPerformance:       make(map[primitives.ValidatorIndex]ValidatorAggregatedPerformance),
 		trackedSyncCommitteeIndices: make(map[primitives.ValidatorIndex][]primitives.CommitteeIndex),
+		fileTracked:                 make(map[primitives.ValidatorIndex]bool),
+		epochStartBalances:          make(map[primitives.ValidatorIndex]uint64),
 		isLogging:                   false,
 	}
 	for _, idx := range tracked {
 		r.TrackedValidators[idx] = true
 	}
+	r.updateMetricsAggregation()
 	return r, nil
 }
 
@@ -104,14 +134,8 @@ func (s *Service) Start() {
 	s.Lock()
 	defer s.Unlock()
 
-	tracked := make([]primitives.ValidatorIndex, 0, len(s.TrackedValidators))
-	for idx := range s.TrackedValidators {
-		tracked = append(tracked, idx)
-	}
-	sort.Slice(tracked, func(i, j int) bool { return tracked[i] < tracked[j] })
-
 	log.WithFields(logrus.Fields{
-		"ValidatorIndices": tracked,
+		"ValidatorIndices": s.sortedTrackedIndices(),
 	}).Info("Starting service")
 
 	go s.run()
@@ -135,17 +159,18 @@ func (s *Service) run() {
 
 	epoch := slots.ToEpoch(st.Slot())
 	log.WithField("Epoch", epoch).Info("Synced to head epoch, starting reporting performance")
+	s.reloadIndicesFile(s.ctx, st, epoch)
 
+	// Validators added at runtime are initialized by AddTrackedValidators once the service
+	// is logging, so both steps happen under the same lock.
 	s.Lock()
 	s.initializePerformanceStructures(st, epoch)
+	s.lastSummaryEpoch = epoch
+	s.isLogging = true
 	s.Unlock()
 
 	s.updateSyncCommitteeTrackedVals(st)
 
-	s.Lock()
-	s.isLogging = true
-	s.Unlock()
-
 	stateChannel := make(chan *feed.Event, 1)
 	stateSub := s.config.StateNotifier.StateFeed().Subscribe(stateChannel)
 	s.monitorRoutine(stateChannel, stateSub)
@@ -155,20 +180,138 @@ func (s *Service) run() {
 // and validatorAggregatedPerformance for each tracked validator.
 func (s *Service) initializePerformanceStructures(state state.BeaconState, epoch primitives.Epoch) {
 	for idx := range s.TrackedValidators {
-		balance, err := state.BalanceAtIndex(idx)
-		if err != nil {
-			log.WithError(err).WithField("ValidatorIndex", idx).Error(
-				"Could not fetch starting balance, skipping aggregated logs.")
-			balance = 0
+		s.initializeValidatorPerformance(state, epoch, idx)
+	}
+}
+
+// initializeValidatorPerformance initializes the performance structures of a single
+// tracked validator. It assumes the caller holds the service Lock.
+func (s *Service) initializeValidatorPerformance(state state.BeaconState, epoch primitives.Epoch, idx primitives.ValidatorIndex) {
+	balance, err := state.BalanceAtIndex(idx)
+	if err != nil {
+		log.WithError(err).WithField("ValidatorIndex", idx).Error(
+			"Could not fetch starting balance, skipping aggregated logs.")
+		balance = 0
+	}
+	s.aggregatedPerformance[idx] = ValidatorAggregatedPerformance{
+		startEpoch:   epoch,
+		startBalance: balance,
+	}
+	s.latestPerformance[idx] = ValidatorLatestPerformance{
+		balance: balance,
+	}
+}
+
+// TrackedValidatorIndices returns the validator indices tracked by the service in ascending order.
+func (s *Service) TrackedValidatorIndices() []primitives.ValidatorIndex {
+	s.RLock()
+	defer s.RUnlock()
+	return s.sortedTrackedIndices()
+}
+
+// AddTrackedValidators starts tracking the given validator indices. If the service is already
+// reporting, the performance structures and sync committee assignments of the new validators
+// are initialized from the head state.
+func (s *Service) AddTrackedValidators(ctx context.Context, indices []primitives.ValidatorIndex) error {
+	if s.config.DB != nil {
+		if err := s.config.DB.SaveMonitoredValidatorIndices(ctx, indices); err != nil {
+			return errors.Wrap(err, "could not save monitored validator indices")
 		}
-		s.aggregatedPerformance[idx] = ValidatorAggregatedPerformance{
-			startEpoch:   epoch,
-			startBalance: balance,
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	// Validators added through the API stay tracked when they are removed from the indices file.
+	for _, idx := range indices {
+		delete(s.fileTracked, idx)
+	}
+	_, err := s.trackValidators(ctx, indices)
+	return err
+}
+
+// RemoveTrackedValidators stops tracking the given validator indices and drops their metrics.
+func (s *Service) RemoveTrackedValidators(ctx context.Context, indices []primitives.ValidatorIndex) error {
+	if s.config.DB != nil {
+		if err := s.config.DB.DeleteMonitoredValidatorIndices(ctx, indices); err != nil {
+			return errors.Wrap(err, "could not delete monitored validator indices")
 		}
-		s.latestPerformance[idx] = ValidatorLatestPerformance{
-			balance: balance,
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	s.untrackValidators(indices)
+	return nil
+}
+
+// trackValidators starts tracking the given validator indices and returns the ones which were
+// not already tracked. It assumes the caller holds the service Lock.
+func (s *Service) trackValidators(ctx context.Context, indices []primitives.ValidatorIndex) ([]primitives.ValidatorIndex, error) {
+	added := make([]primitives.ValidatorIndex, 0, len(indices))
+	for _, idx := range indices {
+		if s.trackedIndex(idx) {
+			continue
+		}
+		s.TrackedValidators[idx] = true
+		added = append(added, idx)
+	}
+	if len(added) == 0 {
+		return added, nil
+	}
+	s.updateMetricsAggregation()
+	log.WithField("ValidatorIndices", added).Info("Started tracking validators")
+	if !s.isLogging {
+		return added, nil
+	}
+
+	st, err := s.config.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return added, errors.Wrap(err, "could not get head state")
+	}
+	if st == nil {
+		return added, errors.New("head state is nil")
+	}
+	epoch := slots.ToEpoch(st.Slot())
+	for _, idx := range added {
+		s.initializeValidatorPerformance(st, epoch, idx)
+		s.updateSyncCommitteeTrackedVal(st, idx)
+	}
+	return added, nil
+}
+
+// untrackValidators stops tracking the given validator indices and drops their metrics.
+// It assumes the caller holds the service Lock.
+func (s *Service) untrackValidators(indices []primitives.ValidatorIndex) {
+	removed := make([]primitives.ValidatorIndex, 0, len(indices))
+	for _, idx := range indices {
+		if !s.trackedIndex(idx) {
+			continue
+		}
+		if !s.aggregateMetrics {
+			deleteValidatorMetrics(s.metricLabel(idx))
 		}
+		delete(s.TrackedValidators, idx)
+		delete(s.latestPerformance, idx)
+		delete(s.aggregatedPerformance, idx)
+		delete(s.trackedSyncCommitteeIndices, idx)
+		delete(s.fileTracked, idx)
+		delete(s.epochStartBalances, idx)
+		removed = append(removed, idx)
+	}
+	if len(removed) > 0 {
+		s.updateMetricsAggregation()
+		log.WithField("ValidatorIndices", removed).Info("Stopped tracking validators")
+	}
+}
+
+// sortedTrackedIndices returns the tracked validator indices in ascending order.
+// It assumes the caller holds the service Lock.
+func (s *Service) sortedTrackedIndices() []primitives.ValidatorIndex {
+	tracked := make([]primitives.ValidatorIndex, 0, len(s.TrackedValidators))
+	for idx := range s.TrackedValidators {
+		tracked = append(tracked, idx)
 	}
+	sort.Slice(tracked, func(i, j int) bool { return tracked[i] < tracked[j] })
+	return tracked
 }
 
 // Status retrieves the status of the service.
@@ -211,16 +354,25 @@ func (s *Service) monitorRoutine(stateChannel chan *feed.Event, stateSub event.S
 	for {
 		select {
 		case e := <-stateChannel:
-			if e.Type == statefeed.BlockProcessed {
-				data, ok := e.Data.(*statefeed.BlockProcessedData)
-				if !ok {
-					log.Error("Event feed data is not of type *statefeed.BlockProcessedData")
-				} else if data.Verified {
-					// We only process blocks that have been verified
-					s.processBlock(s.ctx, data.SignedBlock)
-				}
+			if e.Type != statefeed.BlockProcessed {
+				continue
+			}
+			data, ok := e.Data.(*statefeed.BlockProcessedData)
+			if !ok {
+				log.Error("Event feed data is not of type *statefeed.BlockProcessedData")
+				continue
+			}
+			// The indices file is reloaded even when no validators are tracked yet, as it may
+			// list public keys of validators which were not yet deposited.
+			s.reloadIndicesFileAtSlot(s.ctx, data.Slot)
+			// We only process blocks that have been verified
+			if s.hasTrackedValidators() && data.Verified {
+				s.processBlock(s.ctx, data.SignedBlock)
 			}
 		case e := <-opChannel:
+			if !s.hasTrackedValidators() {
+				continue
+			}
 			switch e.Type {
 			case operation.UnaggregatedAttReceived:
 				data, ok := e.Data.(*operation.UnAggregatedAttReceivedData)
@@ -261,6 +413,14 @@ func (s *Service) monitorRoutine(stateChannel chan *feed.Event, stateSub event.S
 	}
 }
 
+// hasTrackedValidators returns true if at least one validator is tracked. Events are
+// not processed while the service runs without tracked validators.
+func (s *Service) hasTrackedValidators() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.TrackedValidators) > 0
+}
+
 // TrackedIndex returns true if input  validator index exists in tracked validator list.
 // It assumes the caller holds the service Lock
 func (s *Service) trackedIndex(idx primitives.ValidatorIndex) bool {
@@ -274,16 +434,22 @@ func (s *Service) updateSyncCommitteeTrackedVals(state state.BeaconState) {
 	s.Lock()
 	defer s.Unlock()
 	for idx := range s.TrackedValidators {
-		syncIdx, err := helpers.CurrentPeriodSyncSubcommitteeIndices(state, idx)
-		if err != nil {
-			log.WithError(err).WithField("ValidatorIndex", idx).Error(
-				"Sync committee assignments will not be reported")
-			delete(s.trackedSyncCommitteeIndices, idx)
-		} else if len(syncIdx) == 0 {
-			delete(s.trackedSyncCommitteeIndices, idx)
-		} else {
-			s.trackedSyncCommitteeIndices[idx] = syncIdx
-		}
+		s.updateSyncCommitteeTrackedVal(state, idx)
 	}
 	s.lastSyncedEpoch = slots.ToEpoch(state.Slot())
 }
+
+// updateSyncCommitteeTrackedVal updates the sync committee assignments of a single
+// tracked validator. It assumes the caller holds the service Lock.
+func (s *Service) updateSyncCommitteeTrackedVal(state state.BeaconState, idx primitives.ValidatorIndex) {
+	syncIdx, err := helpers.CurrentPeriodSyncSubcommitteeIndices(state, idx)
+	if err != nil {
+		log.WithError(err).WithField("ValidatorIndex", idx).Error(
+			"Sync committee assignments will not be reported")
+		delete(s.trackedSyncCommitteeIndices, idx)
+	} else if len(syncIdx) == 0 {
+		delete(s.trackedSyncCommitteeIndices, idx)
+	} else {
+		s.trackedSyncCommitteeIndices[idx] = syncIdx
+	}
+}
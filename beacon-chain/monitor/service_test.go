@@ -94,6 +94,7 @@ func setupService(t *testing.T) *Service {
 			HeadFetcher:         chainService,
 			AttestationNotifier: chainService.OperationNotifier(),
 			InitialSyncComplete: make(chan struct{}),
+			DB:                  beaconDB,
 		},
 
 		ctx:                         context.Background(),
@@ -102,6 +103,8 @@ func setupService(t *testing.T) *Service {
 		aggregatedPerformance:       aggregatedPerformance,
 		trackedSyncCommitteeIndices: trackedSyncCommitteeIndices,
 		lastSyncedEpoch:             0,
+		fileTracked:                 make(map[primitives.ValidatorIndex]bool),
+		epochStartBalances:          make(map[primitives.ValidatorIndex]uint64),
 	}
 }
 
@@ -242,6 +245,46 @@ func TestMonitorRoutine(t *testing.T) {
 
 }
 
+func TestAddTrackedValidators(t *testing.T) {
+	ctx := context.Background()
+	s := setupService(t)
+
+	// Before the service is logging, new validators are only recorded.
+	require.NoError(t, s.AddTrackedValidators(ctx, []primitives.ValidatorIndex{2, 3}))
+	require.DeepEqual(t, []primitives.ValidatorIndex{1, 2, 3, 12, 15}, s.TrackedValidatorIndices())
+	_, ok := s.latestPerformance[3]
+	require.Equal(t, false, ok)
+
+	s.isLogging = true
+	require.NoError(t, s.AddTrackedValidators(ctx, []primitives.ValidatorIndex{0}))
+	require.DeepEqual(t, ValidatorLatestPerformance{balance: 32000000000}, s.latestPerformance[0])
+	require.DeepEqual(t, ValidatorAggregatedPerformance{startBalance: 32000000000}, s.aggregatedPerformance[0])
+	require.DeepEqual(t, []primitives.CommitteeIndex{0}, s.trackedSyncCommitteeIndices[0])
+
+	persisted, err := s.config.DB.MonitoredValidatorIndices(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, []primitives.ValidatorIndex{0, 2, 3}, persisted)
+}
+
+func TestRemoveTrackedValidators(t *testing.T) {
+	ctx := context.Background()
+	s := setupService(t)
+	require.NoError(t, s.config.DB.SaveMonitoredValidatorIndices(ctx, []primitives.ValidatorIndex{1, 15}))
+
+	require.NoError(t, s.RemoveTrackedValidators(ctx, []primitives.ValidatorIndex{1, 12, 20}))
+	require.DeepEqual(t, []primitives.ValidatorIndex{2, 15}, s.TrackedValidatorIndices())
+	_, ok := s.latestPerformance[1]
+	require.Equal(t, false, ok)
+	_, ok = s.aggregatedPerformance[12]
+	require.Equal(t, false, ok)
+	_, ok = s.trackedSyncCommitteeIndices[1]
+	require.Equal(t, false, ok)
+
+	persisted, err := s.config.DB.MonitoredValidatorIndices(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, []primitives.ValidatorIndex{15}, persisted)
+}
+
 func TestWaitForSync(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	s := &Service{ctx: ctx}
@@ -10,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	tracing2 "github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
 
@@ -20,6 +21,8 @@ func configureTracing(cliCtx *cli.Context) error {
 		cliCtx.String(cmd.TracingEndpointFlag.Name),
 		cliCtx.Float64(cmd.TraceSampleFractionFlag.Name),
 		cliCtx.Bool(cmd.EnableTracingFlag.Name),
+		cliCtx.String(cmd.TracingOTLPEndpointFlag.Name),
+		cliCtx.StringSlice(cmd.TracingOTLPHeadersFlag.Name),
 	)
 }
 
@@ -67,6 +70,13 @@ func configureBuilderCircuitBreaker(cliCtx *cli.Context) error {
 			return err
 		}
 	}
+	if cliCtx.IsSet(flags.MinBuilderEpochParticipation.Name) {
+		c := params.BeaconConfig().Copy()
+		c.MinBuilderEpochParticipation = cliCtx.Uint64(flags.MinBuilderEpochParticipation.Name)
+		if err := params.SetActive(c); err != nil {
+			return err
+		}
+	}
 	if cliCtx.IsSet(flags.LocalBlockValueBoost.Name) {
 		c := params.BeaconConfig().Copy()
 		c.LocalBlockValueBoost = cliCtx.Uint64(flags.LocalBlockValueBoost.Name)
@@ -108,6 +118,12 @@ func configureEth1Config(cliCtx *cli.Context) error {
 			return err
 		}
 	}
+	if cliCtx.IsSet(flags.EngineGetPayloadTimeoutSeconds.Name) {
+		c.ExecutionEngineGetPayloadTimeoutValue = cliCtx.Uint64(flags.EngineGetPayloadTimeoutSeconds.Name)
+		if err := params.SetActive(c); err != nil {
+			return err
+		}
+	}
 	if cliCtx.IsSet(flags.DepositContractFlag.Name) {
 		c.DepositContractAddress = cliCtx.String(flags.DepositContractFlag.Name)
 		if err := params.SetActive(c); err != nil {
@@ -117,6 +133,55 @@ func configureEth1Config(cliCtx *cli.Context) error {
 	return nil
 }
 
+// configureForkChoice applies the fork choice parameters set by flags, for devnets experimenting with fork choice,
+// and checks that the fork choice parameters of the chain config are sensible.
+func configureForkChoice(cliCtx *cli.Context) error {
+	c := params.BeaconConfig().Copy()
+	overrides := logrus.Fields{}
+	if cliCtx.IsSet(flags.ProposerScoreBoost.Name) {
+		c.ProposerScoreBoost = cliCtx.Uint64(flags.ProposerScoreBoost.Name)
+		overrides["proposerScoreBoost"] = c.ProposerScoreBoost
+	}
+	if cliCtx.IsSet(flags.ReorgWeightThreshold.Name) {
+		c.ReorgWeightThreshold = cliCtx.Uint64(flags.ReorgWeightThreshold.Name)
+		overrides["reorgWeightThreshold"] = c.ReorgWeightThreshold
+	}
+	if cliCtx.IsSet(flags.ReorgParentWeightThreshold.Name) {
+		c.ReorgParentWeightThreshold = cliCtx.Uint64(flags.ReorgParentWeightThreshold.Name)
+		overrides["reorgParentWeightThreshold"] = c.ReorgParentWeightThreshold
+	}
+	if cliCtx.IsSet(flags.ReorgMaxEpochsSinceFinalization.Name) {
+		c.ReorgMaxEpochsSinceFinalization = primitives.Epoch(cliCtx.Uint64(flags.ReorgMaxEpochsSinceFinalization.Name))
+		overrides["reorgMaxEpochsSinceFinalization"] = c.ReorgMaxEpochsSinceFinalization
+	}
+	if cliCtx.IsSet(flags.ReorgLateBlockCutoff.Name) {
+		c.ReorgLateBlockCutoff = cliCtx.Uint64(flags.ReorgLateBlockCutoff.Name)
+		overrides["reorgLateBlockCutoff"] = c.ReorgLateBlockCutoff
+	}
+	if cliCtx.IsSet(flags.ReorgProposingEarlyCutoff.Name) {
+		c.ReorgProposingEarlyCutoff = cliCtx.Uint64(flags.ReorgProposingEarlyCutoff.Name)
+		overrides["reorgProposingEarlyCutoff"] = c.ReorgProposingEarlyCutoff
+	}
+
+	if c.ProposerScoreBoost > 100 {
+		return fmt.Errorf("proposer score boost %d is more than 100%% of the committee weight", c.ProposerScoreBoost)
+	}
+	if c.ReorgWeightThreshold > 100 {
+		return fmt.Errorf("reorg weight threshold %d is more than 100%% of the committee weight", c.ReorgWeightThreshold)
+	}
+	if c.ReorgLateBlockCutoff >= c.SecondsPerSlot {
+		return fmt.Errorf("reorg late block cutoff of %d seconds is not within a slot of %d seconds", c.ReorgLateBlockCutoff, c.SecondsPerSlot)
+	}
+	if c.ReorgProposingEarlyCutoff >= c.SecondsPerSlot {
+		return fmt.Errorf("reorg proposing early cutoff of %d seconds is not within a slot of %d seconds", c.ReorgProposingEarlyCutoff, c.SecondsPerSlot)
+	}
+	if len(overrides) == 0 {
+		return nil
+	}
+	log.WithFields(overrides).Warn("Fork choice parameters overridden, do not use outside of devnets")
+	return params.SetActive(c)
+}
+
 func configureNetwork(cliCtx *cli.Context) {
 	if len(cliCtx.StringSlice(cmd.BootstrapNode.Name)) > 0 {
 		c := params.BeaconNetworkConfig()
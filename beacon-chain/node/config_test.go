@@ -73,6 +73,33 @@ func TestConfigureProofOfWork(t *testing.T) {
 	assert.Equal(t, "deposit-contract", params.BeaconConfig().DepositContractAddress)
 }
 
+func TestConfigureForkChoice(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	hook := logTest.NewGlobal()
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.Uint64(flags.ProposerScoreBoost.Name, 0, "")
+	set.Uint64(flags.ReorgMaxEpochsSinceFinalization.Name, 0, "")
+	set.Uint64(flags.ReorgLateBlockCutoff.Name, 0, "")
+	require.NoError(t, set.Set(flags.ProposerScoreBoost.Name, strconv.Itoa(70)))
+	require.NoError(t, set.Set(flags.ReorgMaxEpochsSinceFinalization.Name, strconv.Itoa(5)))
+	require.NoError(t, set.Set(flags.ReorgLateBlockCutoff.Name, strconv.Itoa(3)))
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	require.NoError(t, configureForkChoice(cliCtx))
+	assert.Equal(t, uint64(70), params.BeaconConfig().ProposerScoreBoost)
+	assert.Equal(t, primitives.Epoch(5), params.BeaconConfig().ReorgMaxEpochsSinceFinalization)
+	assert.Equal(t, uint64(3), params.BeaconConfig().ReorgLateBlockCutoff)
+	assert.Equal(t, uint64(160), params.BeaconConfig().ReorgParentWeightThreshold)
+	assert.LogsContain(t, hook, "Fork choice parameters overridden")
+
+	require.NoError(t, set.Set(flags.ReorgLateBlockCutoff.Name, strconv.Itoa(12)))
+	cliCtx = cli.NewContext(&app, set, nil)
+	require.ErrorContains(t, "is not within a slot", configureForkChoice(cliCtx))
+	assert.Equal(t, uint64(3), params.BeaconConfig().ReorgLateBlockCutoff)
+}
+
 func TestConfigureExecutionSetting(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	hook := logTest.NewGlobal()
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
@@ -51,6 +52,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/checkpoint"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/genesis"
 	initialsync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/progress"
 	"github.com/prysmaticlabs/prysm/v4/cmd"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v4/config/features"
@@ -58,9 +60,14 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/container/slice"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/era"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/prometheus"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/resources"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
 	"github.com/prysmaticlabs/prysm/v4/runtime"
 	"github.com/prysmaticlabs/prysm/v4/runtime/debug"
+	"github.com/prysmaticlabs/prysm/v4/runtime/logging"
 	"github.com/prysmaticlabs/prysm/v4/runtime/prereqs"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/sirupsen/logrus"
@@ -69,6 +76,9 @@ import (
 
 const testSkipPowFlag = "test-skip-pow"
 
+// tracingShutdownTimeout bounds the time spent exporting the last trace spans when the node stops.
+const tracingShutdownTimeout = 5 * time.Second
+
 // Used as a struct to keep cli flag options for configuring services
 // for the beacon node. We keep this as a separate struct to not pollute the actual BeaconNode
 // struct, as it is merely used to pass down configuration options into the appropriate services.
@@ -97,6 +107,7 @@ type BeaconNode struct {
 	blsToExecPool           blstoexec.PoolManager
 	depositCache            cache.DepositCache
 	proposerIdsCache        *cache.ProposerPayloadIDsCache
+	localBroadcasts         *cache.LocalBroadcastsCache
 	stateFeed               *event.Feed
 	blockFeed               *event.Feed
 	opFeed                  *event.Feed
@@ -104,6 +115,7 @@ type BeaconNode struct {
 	collector               *bcnodeCollector
 	slasherBlockHeadersFeed *event.Feed
 	slasherAttestationsFeed *event.Feed
+	slasherBlobSidecarsFeed *event.Feed
 	finalizedStateAtStartUp state.BeaconState
 	serviceFlagOpts         *serviceFlagOpts
 	GenesisInitializer      genesis.Initializer
@@ -111,6 +123,10 @@ type BeaconNode struct {
 	forkChoicer             forkchoice.ForkChoicer
 	clockWaiter             startup.ClockWaiter
 	initialSyncComplete     chan struct{}
+	eraStore                *era.Store
+	syncProgress            *progress.Tracker
+	router                  *mux.Router
+	resourceMonitor         *resources.Service
 }
 
 // New creates a new node instance, sets up configuration options, and registers
@@ -146,6 +162,9 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 	if err := configureEth1Config(cliCtx); err != nil {
 		return nil, err
 	}
+	if err := configureForkChoice(cliCtx); err != nil {
+		return nil, err
+	}
 	configureNetwork(cliCtx)
 	if err := configureInteropConfig(cliCtx); err != nil {
 		return nil, err
@@ -177,8 +196,11 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		blsToExecPool:           blstoexec.NewPool(),
 		slasherBlockHeadersFeed: new(event.Feed),
 		slasherAttestationsFeed: new(event.Feed),
+		slasherBlobSidecarsFeed: new(event.Feed),
 		serviceFlagOpts:         &serviceFlagOpts{},
 		proposerIdsCache:        cache.NewProposerPayloadIDsCache(),
+		localBroadcasts:         cache.NewLocalBroadcastsCache(),
+		syncProgress:            progress.NewTracker(),
 	}
 
 	beacon.initialSyncComplete = make(chan struct{})
@@ -201,6 +223,18 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if dirs := cliCtx.StringSlice(flags.EraDirFlag.Name); len(dirs) > 0 {
+		log.WithField("paths", dirs).Info("Serving historical blocks from era files")
+		if beacon.eraStore, err = era.NewStore(dirs...); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Debugln("Registering Resource Monitor Service")
+	if err := beacon.registerResourceMonitorService(cliCtx); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Starting Slashing DB")
 	if err := beacon.startSlasherDB(cliCtx); err != nil {
 		return nil, err
@@ -210,6 +244,7 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 	if err := bfs.Reload(ctx); err != nil {
 		return nil, errors.Wrap(err, "backfill status initialization error")
 	}
+	bfs.TrackProgress(beacon.syncProgress)
 
 	log.Debugln("Starting State Gen")
 	if err := beacon.startStateGen(ctx, bfs, beacon.forkChoicer); err != nil {
@@ -266,8 +301,14 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Validator Monitoring Service")
+	if err := beacon.registerValidatorMonitorService(beacon.initialSyncComplete); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Registering RPC Service")
 	router := mux.NewRouter()
+	beacon.router = router
 	if err := beacon.registerRPCService(router); err != nil {
 		return nil, err
 	}
@@ -277,11 +318,6 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
-	log.Debugln("Registering Validator Monitoring Service")
-	if err := beacon.registerValidatorMonitorService(beacon.initialSyncComplete); err != nil {
-		return nil, err
-	}
-
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
 		log.Debugln("Registering Prometheus Service")
 		if err := beacon.registerPrometheusService(cliCtx); err != nil {
@@ -325,6 +361,9 @@ func (b *BeaconNode) Start() {
 	}).Info("Starting beacon node")
 
 	b.services.StartAll()
+	if logrus.IsLevelEnabled(logrus.DebugLevel) {
+		b.logUnimplementedEndpoints()
+	}
 
 	stop := b.stop
 	b.lock.Unlock()
@@ -357,9 +396,19 @@ func (b *BeaconNode) Close() {
 
 	log.Info("Stopping beacon node")
 	b.services.StopAll()
+	tracingCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	if err := tracing.Shutdown(tracingCtx); err != nil {
+		log.WithError(err).Error("Failed to export the remaining trace spans")
+	}
+	cancel()
 	if err := b.db.Close(); err != nil {
 		log.WithError(err).Error("Failed to close database")
 	}
+	if b.eraStore != nil {
+		if err := b.eraStore.Close(); err != nil {
+			log.WithError(err).Error("Failed to close era files")
+		}
+	}
 	b.collector.unregister()
 	b.cancel()
 	close(b.stop)
@@ -435,9 +484,11 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 	}
 
 	if b.CheckpointInitializer != nil {
+		b.syncProgress.Start(progress.CheckpointSync, 0, 1)
 		if err := b.CheckpointInitializer.Initialize(b.ctx, d); err != nil {
 			return err
 		}
+		b.syncProgress.Complete(progress.CheckpointSync)
 	}
 
 	knownContract, err := b.db.DepositContractAddress(b.ctx)
@@ -509,7 +560,7 @@ func (b *BeaconNode) startSlasherDB(cliCtx *cli.Context) error {
 }
 
 func (b *BeaconNode) startStateGen(ctx context.Context, bfs *backfill.Status, fc forkchoice.ForkChoicer) error {
-	opts := []stategen.StateGenOption{stategen.WithBackfillStatus(bfs)}
+	opts := []stategen.StateGenOption{stategen.WithBackfillStatus(bfs), stategen.WithLowDiskChecker(b.resourceMonitor)}
 	sg := stategen.New(b.db, fc, opts...)
 
 	cp, err := b.db.FinalizedCheckpoint(ctx)
@@ -634,6 +685,7 @@ func (b *BeaconNode) registerBlockchainService(fc forkchoice.ForkChoicer, gs *st
 		blockchain.WithProposerIdsCache(b.proposerIdsCache),
 		blockchain.WithClockSynchronizer(gs),
 		blockchain.WithSyncComplete(syncComplete),
+		blockchain.WithSyncProgress(b.syncProgress),
 	)
 
 	blockchainService, err := blockchain.NewService(b.ctx, opts...)
@@ -666,6 +718,7 @@ func (b *BeaconNode) registerPOWChainService() error {
 		execution.WithStateGen(b.stateGen),
 		execution.WithBeaconNodeStatsUpdater(bs),
 		execution.WithFinalizedStateAtStartup(b.finalizedStateAtStartUp),
+		execution.WithSyncProgress(b.syncProgress),
 	)
 	web3Service, err := execution.NewService(b.ctx, opts...)
 	if err != nil {
@@ -691,6 +744,11 @@ func (b *BeaconNode) registerSyncService(initialSyncComplete chan struct{}) erro
 		return err
 	}
 
+	var peerController *p2p.Service
+	if err := b.services.FetchService(&peerController); err != nil {
+		return err
+	}
+
 	rs := regularsync.NewService(
 		b.ctx,
 		regularsync.WithDatabase(b.db),
@@ -708,9 +766,13 @@ func (b *BeaconNode) registerSyncService(initialSyncComplete chan struct{}) erro
 		regularsync.WithStateGen(b.stateGen),
 		regularsync.WithSlasherAttestationsFeed(b.slasherAttestationsFeed),
 		regularsync.WithSlasherBlockHeadersFeed(b.slasherBlockHeadersFeed),
+		regularsync.WithSlasherBlobSidecarsFeed(b.slasherBlobSidecarsFeed),
 		regularsync.WithExecutionPayloadReconstructor(web3Service),
 		regularsync.WithClockWaiter(b.clockWaiter),
 		regularsync.WithInitialSyncComplete(initialSyncComplete),
+		regularsync.WithEraStore(b.eraStore),
+		regularsync.WithLocalBroadcastsCache(b.localBroadcasts),
+		regularsync.WithPeerController(peerController),
 	)
 	return b.services.RegisterService(rs)
 }
@@ -729,6 +791,7 @@ func (b *BeaconNode) registerInitialSyncService(complete chan struct{}) error {
 		BlockNotifier:       b,
 		ClockWaiter:         b.clockWaiter,
 		InitialSyncComplete: complete,
+		Progress:            b.syncProgress,
 	})
 	return b.services.RegisterService(is)
 }
@@ -749,6 +812,7 @@ func (b *BeaconNode) registerSlasherService() error {
 	slasherSrv, err := slasher.New(b.ctx, &slasher.ServiceConfig{
 		IndexedAttestationsFeed: b.slasherAttestationsFeed,
 		BeaconBlockHeadersFeed:  b.slasherBlockHeadersFeed,
+		BlobSidecarsFeed:        b.slasherBlobSidecarsFeed,
 		Database:                b.slasherDB,
 		StateNotifier:           b,
 		AttestationStateFetcher: chainService,
@@ -757,6 +821,7 @@ func (b *BeaconNode) registerSlasherService() error {
 		SyncChecker:             syncService,
 		HeadStateFetcher:        chainService,
 		ClockWaiter:             b.clockWaiter,
+		WebhookURL:              b.cliCtx.String(flags.SlasherWebhookURLFlag.Name),
 	})
 	if err != nil {
 		return err
@@ -780,6 +845,11 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		return err
 	}
 
+	var regularSyncService *regularsync.Service
+	if err := b.services.FetchService(&regularSyncService); err != nil {
+		return err
+	}
+
 	var slasherService *slasher.Service
 	if features.Get().EnableSlasher {
 		if err := b.services.FetchService(&slasherService); err != nil {
@@ -787,6 +857,16 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		}
 	}
 
+	var monitorService *monitor.Service
+	if err := b.services.FetchService(&monitorService); err != nil {
+		return err
+	}
+
+	var peerController *p2p.Service
+	if err := b.services.FetchService(&peerController); err != nil {
+		return err
+	}
+
 	genesisValidators := b.cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name)
 	var depositFetcher cache.DepositFetcher
 	var chainStartFetcher execution.ChainStartFetcher
@@ -812,6 +892,14 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 
 	maxMsgSize := b.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name)
 	enableDebugRPCEndpoints := b.cliCtx.Bool(flags.EnableDebugRPCEndpoints.Name)
+	var adminTokens []string
+	if tokenFile := b.cliCtx.String(flags.AdminAPITokenFile.Name); tokenFile != "" {
+		tokens, err := readAPIAuthTokens(tokenFile)
+		if err != nil {
+			return err
+		}
+		adminTokens = tokens
+	}
 
 	p2pService := b.fetchP2P()
 	rpcService := rpc.NewService(b.ctx, &rpc.Config{
@@ -824,9 +912,11 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		CertFlag:                      cert,
 		KeyFlag:                       key,
 		BeaconDB:                      b.db,
+		EraStore:                      b.eraStore,
 		Broadcaster:                   p2pService,
 		PeersFetcher:                  p2pService,
 		PeerManager:                   p2pService,
+		PeerController:                peerController,
 		MetadataProvider:              p2pService,
 		ChainInfoFetcher:              chainService,
 		HeadFetcher:                   chainService,
@@ -839,14 +929,21 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		GenesisTimeFetcher:            chainService,
 		GenesisFetcher:                chainService,
 		OptimisticModeFetcher:         chainService,
+		LightClientFetcher:            chainService,
 		AttestationsPool:              b.attestationPool,
 		ExitPool:                      b.exitPool,
 		SlashingsPool:                 b.slashingsPool,
 		BLSChangesPool:                b.blsToExecPool,
 		SlashingChecker:               slasherService,
+		SlashingsSubscriber:           slasherService,
 		SyncCommitteeObjectPool:       b.syncCommitteePool,
 		ExecutionChainService:         web3Service,
 		ExecutionChainInfoFetcher:     web3Service,
+		EngineDiagnosticsFetcher:      web3Service,
+		EngineCapabilitiesFetcher:     web3Service,
+		ClientVersionFetcher:          web3Service,
+		PendingQueueFetcher:           regularSyncService,
+		GraffitiClientInfo:            b.cliCtx.Bool(flags.GraffitiClientInfo.Name),
 		ChainStartFetcher:             chainStartFetcher,
 		MockEth1Votes:                 mockEth1DataVotes,
 		SyncService:                   syncService,
@@ -859,9 +956,13 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		EnableDebugRPCEndpoints:       enableDebugRPCEndpoints,
 		MaxMsgSize:                    maxMsgSize,
 		ProposerIdsCache:              b.proposerIdsCache,
+		LocalBroadcasts:               b.localBroadcasts,
 		BlockBuilder:                  b.fetchBuilderService(),
 		Router:                        router,
 		ClockWaiter:                   b.clockWaiter,
+		SyncProgress:                  b.syncProgress,
+		ValidatorMonitor:              monitorService,
+		AdminTokens:                   adminTokens,
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -885,8 +986,7 @@ func (b *BeaconNode) registerPrometheusService(_ *cli.Context) error {
 		b.services,
 		additionalHandlers...,
 	)
-	hook := prometheus.NewLogrusCollector()
-	logrus.AddHook(hook)
+	logrus.AddHook(&logging.ModuleHook{Hook: prometheus.NewLogrusCollector()})
 	return b.services.RegisterService(service)
 }
 
@@ -929,6 +1029,19 @@ func (b *BeaconNode) registerGRPCGateway(router *mux.Router) error {
 	if flags.EnableHTTPEthAPI(httpModules) {
 		opts = append(opts, apigateway.WithApiMiddleware(&apimiddleware.BeaconEndpointFactory{}))
 	}
+	if tokenFile := b.cliCtx.String(flags.APIAuthTokenFile.Name); tokenFile != "" {
+		tokens, err := readAPIAuthTokens(tokenFile)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, apigateway.WithAuthTokens(tokens))
+	}
+	opts = append(opts, apigateway.WithRateLimits(apigateway.RateLimits{
+		Rate:           b.cliCtx.Float64(flags.APIRateLimit.Name),
+		Burst:          b.cliCtx.Int64(flags.APIRateLimitBurst.Name),
+		ExpensiveRate:  b.cliCtx.Float64(flags.APIExpensiveRateLimit.Name),
+		ExpensiveBurst: b.cliCtx.Int64(flags.APIExpensiveRateLimitBurst.Name),
+	}))
 	g, err := apigateway.New(b.ctx, opts...)
 	if err != nil {
 		return err
@@ -936,6 +1049,41 @@ func (b *BeaconNode) registerGRPCGateway(router *mux.Router) error {
 	return b.services.RegisterService(g)
 }
 
+// readAPIAuthTokens reads the bearer tokens accepted by the gateway or the admin endpoints, one per line, from a file.
+func readAPIAuthTokens(path string) ([]string, error) {
+	enc, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read API token file")
+	}
+	tokens := make([]string, 0)
+	for _, line := range strings.Split(string(enc), "\n") {
+		if t := strings.TrimSpace(line); t != "" {
+			tokens = append(tokens, t)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no tokens found in API token file %s", path)
+	}
+	return tokens, nil
+}
+
+// logUnimplementedEndpoints reports the routes of the Beacon API specification the node does not serve. The routes
+// still implemented in gRPC are served through the API middleware of the gateway, so nothing is reported when the
+// gateway or the Ethereum HTTP API is disabled.
+func (b *BeaconNode) logUnimplementedEndpoints() {
+	if b.cliCtx.Bool(flags.DisableGRPCGateway.Name) || !flags.EnableHTTPEthAPI(b.cliCtx.String(flags.HTTPModules.Name)) {
+		return
+	}
+	missing, err := rpc.UnimplementedStandardEndpoints(b.router)
+	if err != nil {
+		log.WithError(err).Debug("Could not list unimplemented Beacon API endpoints")
+		return
+	}
+	for _, e := range missing {
+		log.WithField("endpoint", e).Debug("Beacon API endpoint is not implemented")
+	}
+}
+
 func (b *BeaconNode) registerDeterministicGenesisService() error {
 	genesisTime := b.cliCtx.Uint64(flags.InteropGenesisTimeFlag.Name)
 	genesisValidators := b.cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name)
@@ -962,15 +1110,21 @@ func (b *BeaconNode) registerDeterministicGenesisService() error {
 	return nil
 }
 
+// registerValidatorMonitorService registers the validator monitor. The service is always
+// registered so that validators can be tracked at runtime through the API. It starts
+// with the indices given by flag along with the ones added through the API and
+// persisted in the database, and tracks the validators listed in the indices file.
 func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan struct{}) error {
 	cliSlice := b.cliCtx.IntSlice(cmd.ValidatorMonitorIndicesFlag.Name)
-	if cliSlice == nil {
-		return nil
+	persisted, err := b.db.MonitoredValidatorIndices(b.ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not load monitored validator indices")
 	}
-	tracked := make([]primitives.ValidatorIndex, len(cliSlice))
-	for i := range tracked {
-		tracked[i] = primitives.ValidatorIndex(cliSlice[i])
+	tracked := make([]primitives.ValidatorIndex, 0, len(cliSlice)+len(persisted))
+	for _, idx := range cliSlice {
+		tracked = append(tracked, primitives.ValidatorIndex(idx))
 	}
+	tracked = append(tracked, persisted...)
 
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
@@ -982,6 +1136,9 @@ func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan st
 		StateGen:            b.stateGen,
 		HeadFetcher:         chainService,
 		InitialSyncComplete: initialSyncComplete,
+		DB:                  b.db,
+		IndicesFile:         b.cliCtx.String(cmd.ValidatorMonitorIndicesFileFlag.Name),
+		MaxMetricsKeys:      b.cliCtx.Int(cmd.ValidatorMonitorMaxMetricsKeysFlag.Name),
 	}
 	svc, err := monitor.NewService(b.ctx, monitorConfig, tracked)
 	if err != nil {
@@ -990,6 +1147,20 @@ func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan st
 	return b.services.RegisterService(svc)
 }
 
+// registerResourceMonitorService registers the monitor of the disk, memory and file descriptors of the node.
+// It is registered before the other services so that they can check whether the disk is low.
+func (b *BeaconNode) registerResourceMonitorService(cliCtx *cli.Context) error {
+	svc := resources.New(b.ctx, &resources.Config{
+		DataDir:          cliCtx.String(cmd.DataDirFlag.Name),
+		DBPath:           b.db.DatabasePath(),
+		WarnFreeDisk:     cliCtx.Uint64(flags.DiskWarningThresholdFlag.Name) << 30,
+		CriticalFreeDisk: cliCtx.Uint64(flags.DiskCriticalThresholdFlag.Name) << 30,
+		PauseOnLowDisk:   cliCtx.Bool(flags.PauseOnLowDiskFlag.Name),
+	})
+	b.resourceMonitor = svc
+	return b.services.RegisterService(svc)
+}
+
 func (b *BeaconNode) registerBuilderService(cliCtx *cli.Context) error {
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/builder"
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
 	mockExecution "github.com/prysmaticlabs/prysm/v4/beacon-chain/execution/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor"
@@ -21,6 +22,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/runtime"
 	"github.com/prysmaticlabs/prysm/v4/runtime/interop"
@@ -148,10 +150,11 @@ func TestClearDB(t *testing.T) {
 	set.String("suggested-fee-recipient", "0x6e35733c5af9B61374A128e6F85f553aF09ff89A", "fee recipient")
 	require.NoError(t, set.Set("suggested-fee-recipient", "0x6e35733c5af9B61374A128e6F85f553aF09ff89A"))
 	context := cli.NewContext(&app, set, nil)
-	_, err = New(context, WithExecutionChainOptions([]execution.Option{
+	node, err := New(context, WithExecutionChainOptions([]execution.Option{
 		execution.WithHttpEndpoint(endpoint),
 	}))
 	require.NoError(t, err)
+	node.Close()
 
 	require.LogsContain(t, hook, "Removing database")
 }
@@ -162,7 +165,9 @@ func TestMonitor_RegisteredCorrectly(t *testing.T) {
 	require.NoError(t, cmd.ValidatorMonitorIndicesFlag.Apply(set))
 	cliCtx := cli.NewContext(&app, set, nil)
 	require.NoError(t, cliCtx.Set(cmd.ValidatorMonitorIndicesFlag.Name, "1,2"))
-	n := &BeaconNode{ctx: context.Background(), cliCtx: cliCtx, services: runtime.NewServiceRegistry()}
+	beaconDB := dbtest.SetupDB(t)
+	require.NoError(t, beaconDB.SaveMonitoredValidatorIndices(context.Background(), []primitives.ValidatorIndex{2, 7}))
+	n := &BeaconNode{ctx: context.Background(), cliCtx: cliCtx, db: beaconDB, services: runtime.NewServiceRegistry()}
 	require.NoError(t, n.services.RegisterService(&blockchain.Service{}))
 	require.NoError(t, n.registerValidatorMonitorService(make(chan struct{})))
 
@@ -170,6 +175,7 @@ func TestMonitor_RegisteredCorrectly(t *testing.T) {
 	require.NoError(t, n.services.FetchService(&mService))
 	require.Equal(t, true, mService.TrackedValidators[1])
 	require.Equal(t, true, mService.TrackedValidators[2])
+	require.Equal(t, true, mService.TrackedValidators[7])
 	require.Equal(t, false, mService.TrackedValidators[100])
 }
 
@@ -168,20 +168,20 @@ func (c *AttCaches) SaveAggregatedAttestation(att *ethpb.Attestation) error {
 		return errors.Wrap(err, "could not tree hash attestation")
 	}
 	copiedAtt := ethpb.CopyAttestation(att)
-	c.aggregatedAttLock.Lock()
-	defer c.aggregatedAttLock.Unlock()
-	atts, ok := c.aggregatedAtt[r]
-	if !ok {
-		atts := []*ethpb.Attestation{copiedAtt}
-		c.aggregatedAtt[r] = atts
-		return nil
-	}
-
-	atts, err = attaggregation.Aggregate(append(atts, copiedAtt))
+	_, err = c.aggregatedAtt.update(att.Data.Slot, att.Data.CommitteeIndex, r,
+		func(atts []*ethpb.Attestation, exists bool) ([]*ethpb.Attestation, bool, error) {
+			if !exists {
+				return []*ethpb.Attestation{copiedAtt}, true, nil
+			}
+			atts, err := attaggregation.Aggregate(append(atts, copiedAtt))
+			return atts, true, err
+		},
+	)
 	if err != nil {
 		return err
 	}
-	c.aggregatedAtt[r] = atts
+	// Aggregating with the stored attestations may also grow the number of attestations held.
+	c.evictAggregatedAttestations()
 
 	return nil
 }
@@ -201,14 +201,13 @@ func (c *AttCaches) SaveAggregatedAttestations(atts []*ethpb.Attestation) error
 
 // AggregatedAttestations returns the aggregated attestations in cache.
 func (c *AttCaches) AggregatedAttestations() []*ethpb.Attestation {
-	c.aggregatedAttLock.RLock()
-	defer c.aggregatedAttLock.RUnlock()
-
 	atts := make([]*ethpb.Attestation, 0)
 
-	for _, a := range c.aggregatedAtt {
+	// The callback never fails.
+	_ = c.aggregatedAtt.forEach(func(_ [32]byte, a []*ethpb.Attestation) error {
 		atts = append(atts, a...)
-	}
+		return nil
+	})
 
 	return atts
 }
@@ -220,14 +219,9 @@ func (c *AttCaches) AggregatedAttestationsBySlotIndex(ctx context.Context, slot
 	defer span.End()
 
 	atts := make([]*ethpb.Attestation, 0)
-
-	c.aggregatedAttLock.RLock()
-	defer c.aggregatedAttLock.RUnlock()
-	for _, a := range c.aggregatedAtt {
-		if slot == a[0].Data.Slot && committeeIndex == a[0].Data.CommitteeIndex {
-			atts = append(atts, a...)
-		}
-	}
+	c.aggregatedAtt.committee(slot, committeeIndex, func(a []*ethpb.Attestation) {
+		atts = append(atts, a...)
+	})
 
 	return atts
 }
@@ -249,28 +243,23 @@ func (c *AttCaches) DeleteAggregatedAttestation(att *ethpb.Attestation) error {
 		return err
 	}
 
-	c.aggregatedAttLock.Lock()
-	defer c.aggregatedAttLock.Unlock()
-	attList, ok := c.aggregatedAtt[r]
-	if !ok {
+	if _, ok := c.aggregatedAtt.get(att.Data.Slot, att.Data.CommitteeIndex, r); !ok {
 		return nil
 	}
-
-	filtered := make([]*ethpb.Attestation, 0)
-	for _, a := range attList {
-		if c, err := att.AggregationBits.Contains(a.AggregationBits); err != nil {
-			return err
-		} else if !c {
-			filtered = append(filtered, a)
-		}
-	}
-	if len(filtered) == 0 {
-		delete(c.aggregatedAtt, r)
-	} else {
-		c.aggregatedAtt[r] = filtered
-	}
-
-	return nil
+	_, err = c.aggregatedAtt.update(att.Data.Slot, att.Data.CommitteeIndex, r,
+		func(attList []*ethpb.Attestation, _ bool) ([]*ethpb.Attestation, bool, error) {
+			filtered := make([]*ethpb.Attestation, 0)
+			for _, a := range attList {
+				if c, err := att.AggregationBits.Contains(a.AggregationBits); err != nil {
+					return nil, false, err
+				} else if !c {
+					filtered = append(filtered, a)
+				}
+			}
+			return filtered, len(filtered) > 0, nil
+		},
+	)
+	return err
 }
 
 // HasAggregatedAttestation checks if the input attestations has already existed in cache.
@@ -283,9 +272,7 @@ func (c *AttCaches) HasAggregatedAttestation(att *ethpb.Attestation) (bool, erro
 		return false, errors.Wrap(err, "could not tree hash attestation")
 	}
 
-	c.aggregatedAttLock.RLock()
-	defer c.aggregatedAttLock.RUnlock()
-	if atts, ok := c.aggregatedAtt[r]; ok {
+	if atts, ok := c.aggregatedAtt.get(att.Data.Slot, att.Data.CommitteeIndex, r); ok {
 		for _, a := range atts {
 			if c, err := a.AggregationBits.Contains(att.AggregationBits); err != nil {
 				return false, err
@@ -312,7 +299,15 @@ func (c *AttCaches) HasAggregatedAttestation(att *ethpb.Attestation) (bool, erro
 
 // AggregatedAttestationCount returns the number of aggregated attestations key in the pool.
 func (c *AttCaches) AggregatedAttestationCount() int {
-	c.aggregatedAttLock.RLock()
-	defer c.aggregatedAttLock.RUnlock()
-	return len(c.aggregatedAtt)
+	return c.aggregatedAtt.len()
+}
+
+// DeleteAggregatedAttestationsBefore deletes the aggregated attestations of all slots lower than
+// the given one. Returns number of attestations deleted.
+func (c *AttCaches) DeleteAggregatedAttestationsBefore(slot primitives.Slot) int {
+	deleted := 0
+	c.aggregatedAtt.deleteBefore(slot, func(atts []*ethpb.Attestation) {
+		deleted += len(atts)
+	})
+	return deleted
 }
@@ -107,7 +107,7 @@ func TestKV_Aggregated_SaveAggregatedAttestation(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			cache := NewAttCaches()
 			cache.seenAtt.Set(string(r[:]), []bitfield.Bitlist{{0xff}}, c.DefaultExpiration)
-			assert.Equal(t, 0, len(cache.unAggregatedAtt), "Invalid start pool, atts: %d", len(cache.unAggregatedAtt))
+			assert.Equal(t, 0, cache.unAggregatedAtt.len(), "Invalid start pool, atts: %d", cache.unAggregatedAtt.len())
 
 			err := cache.SaveAggregatedAttestation(tt.att)
 			if tt.wantErrString != "" {
@@ -115,7 +115,7 @@ func TestKV_Aggregated_SaveAggregatedAttestation(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 			}
-			assert.Equal(t, tt.count, len(cache.aggregatedAtt), "Wrong attestation count")
+			assert.Equal(t, tt.count, cache.aggregatedAtt.len(), "Wrong attestation count")
 			assert.Equal(t, tt.count, cache.AggregatedAttestationCount(), "Wrong attestation count")
 		})
 	}
@@ -143,14 +143,14 @@ func TestKV_Aggregated_SaveAggregatedAttestations(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cache := NewAttCaches()
-			assert.Equal(t, 0, len(cache.aggregatedAtt), "Invalid start pool, atts: %d", len(cache.unAggregatedAtt))
+			assert.Equal(t, 0, cache.aggregatedAtt.len(), "Invalid start pool, atts: %d", cache.unAggregatedAtt.len())
 			err := cache.SaveAggregatedAttestations(tt.atts)
 			if tt.wantErrString != "" {
 				assert.ErrorContains(t, tt.wantErrString, err)
 			} else {
 				assert.NoError(t, err)
 			}
-			assert.Equal(t, tt.count, len(cache.aggregatedAtt), "Wrong attestation count")
+			assert.Equal(t, tt.count, cache.aggregatedAtt.len(), "Wrong attestation count")
 			assert.Equal(t, tt.count, cache.AggregatedAttestationCount(), "Wrong attestation count")
 		})
 	}
@@ -178,14 +178,14 @@ func TestKV_Aggregated_SaveAggregatedAttestations_SomeGoodSomeBad(t *testing.T)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cache := NewAttCaches()
-			assert.Equal(t, 0, len(cache.aggregatedAtt), "Invalid start pool, atts: %d", len(cache.unAggregatedAtt))
+			assert.Equal(t, 0, cache.aggregatedAtt.len(), "Invalid start pool, atts: %d", cache.unAggregatedAtt.len())
 			err := cache.SaveAggregatedAttestations(tt.atts)
 			if tt.wantErrString != "" {
 				assert.ErrorContains(t, tt.wantErrString, err)
 			} else {
 				assert.NoError(t, err)
 			}
-			assert.Equal(t, tt.count, len(cache.aggregatedAtt), "Wrong attestation count")
+			assert.Equal(t, tt.count, cache.aggregatedAtt.len(), "Wrong attestation count")
 			assert.Equal(t, tt.count, cache.AggregatedAttestationCount(), "Wrong attestation count")
 		})
 	}
@@ -0,0 +1,37 @@
+package kv
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/priority"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+// evictUnaggregatedAttestations removes the unaggregated attestations of the oldest slots until the
+// pool is back within its limit.
+func (c *AttCaches) evictUnaggregatedAttestations() {
+	evicted := 0
+	for priority.Attestation.Exceeds(c.unAggregatedAtt.attestations()) > 0 {
+		oldest, ok := c.unAggregatedAtt.oldestSlot()
+		if !ok {
+			break
+		}
+		evicted += c.unAggregatedAtt.deleteBefore(oldest+1, nil)
+	}
+	priority.RecordEvictions(priority.Attestation, evicted)
+}
+
+// evictAggregatedAttestations removes the aggregated attestations of the oldest slots until the
+// pool is back within its limit. Both the limit and the eviction metric count attestations, rather
+// than the attestation data roots they are stored under.
+func (c *AttCaches) evictAggregatedAttestations() {
+	evicted := 0
+	for priority.Attestation.Exceeds(c.aggregatedAtt.attestations()) > 0 {
+		oldest, ok := c.aggregatedAtt.oldestSlot()
+		if !ok {
+			break
+		}
+		c.aggregatedAtt.deleteBefore(oldest+1, func(atts []*ethpb.Attestation) {
+			evicted += len(atts)
+		})
+	}
+	priority.RecordEvictions(priority.Attestation, evicted)
+}
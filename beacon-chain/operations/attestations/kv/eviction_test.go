@@ -0,0 +1,81 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestKV_Unaggregated_EvictsOldestSlots(t *testing.T) {
+	resetCfg := flags.Get()
+	flags.Init(&flags.GlobalFlags{MaxPoolAttestations: 3})
+	defer flags.Init(resetCfg)
+
+	cache := NewAttCaches()
+	for _, slot := range []primitives.Slot{5, 3, 3, 4} {
+		att := util.HydrateAttestation(&ethpb.Attestation{
+			Data:            &ethpb.AttestationData{Slot: slot, CommitteeIndex: primitives.CommitteeIndex(cache.UnaggregatedAttestationCount())},
+			AggregationBits: bitfield.Bitlist{0b101},
+		})
+		require.NoError(t, cache.SaveUnaggregatedAttestation(att))
+	}
+	// Both attestations of slot 3 are evicted when the fourth attestation overflows the pool.
+	atts, err := cache.UnaggregatedAttestations()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(atts))
+	for _, att := range atts {
+		assert.NotEqual(t, primitives.Slot(3), att.Data.Slot)
+	}
+}
+
+func TestKV_Aggregated_EvictsOldestSlots(t *testing.T) {
+	resetCfg := flags.Get()
+	flags.Init(&flags.GlobalFlags{MaxPoolAttestations: 2})
+	defer flags.Init(resetCfg)
+
+	cache := NewAttCaches()
+	for _, slot := range []primitives.Slot{2, 1, 3} {
+		att := util.HydrateAttestation(&ethpb.Attestation{
+			Data:            &ethpb.AttestationData{Slot: slot},
+			AggregationBits: bitfield.Bitlist{0b1101},
+		})
+		require.NoError(t, cache.SaveAggregatedAttestation(att))
+	}
+	atts := cache.AggregatedAttestations()
+	require.Equal(t, 2, len(atts))
+	for _, att := range atts {
+		assert.NotEqual(t, primitives.Slot(1), att.Data.Slot)
+	}
+}
+
+func TestKV_Aggregated_LimitCountsAttestations(t *testing.T) {
+	resetCfg := flags.Get()
+	flags.Init(&flags.GlobalFlags{MaxPoolAttestations: 2})
+	defer flags.Init(resetCfg)
+
+	cache := NewAttCaches()
+	// Two attestations of slot 1 with overlapping bits are stored under the same data root.
+	for _, bits := range []bitfield.Bitlist{{0b1101}, {0b1011}} {
+		att := util.HydrateAttestation(&ethpb.Attestation{
+			Data:            &ethpb.AttestationData{Slot: 1},
+			AggregationBits: bits,
+		})
+		require.NoError(t, cache.SaveAggregatedAttestation(att))
+	}
+	require.Equal(t, 2, len(cache.AggregatedAttestations()))
+
+	att := util.HydrateAttestation(&ethpb.Attestation{
+		Data:            &ethpb.AttestationData{Slot: 2},
+		AggregationBits: bitfield.Bitlist{0b1101},
+	})
+	require.NoError(t, cache.SaveAggregatedAttestation(att))
+	atts := cache.AggregatedAttestations()
+	require.Equal(t, 1, len(atts))
+	assert.Equal(t, primitives.Slot(2), atts[0].Data.Slot)
+}
@@ -18,16 +18,16 @@ var hashFn = hash.HashProto
 // AttCaches defines the caches used to satisfy attestation pool interface.
 // These caches are KV store for various attestations
 // such are unaggregated, aggregated or attestations within a block.
+//
+// Aggregated and unaggregated attestations are sharded by slot and committee index, see attShards.
 type AttCaches struct {
-	aggregatedAttLock  sync.RWMutex
-	aggregatedAtt      map[[32]byte][]*ethpb.Attestation
-	unAggregateAttLock sync.RWMutex
-	unAggregatedAtt    map[[32]byte]*ethpb.Attestation
-	forkchoiceAttLock  sync.RWMutex
-	forkchoiceAtt      map[[32]byte]*ethpb.Attestation
-	blockAttLock       sync.RWMutex
-	blockAtt           map[[32]byte][]*ethpb.Attestation
-	seenAtt            *cache.Cache
+	aggregatedAtt     *attShards[[]*ethpb.Attestation]
+	unAggregatedAtt   *attShards[*ethpb.Attestation]
+	forkchoiceAttLock sync.RWMutex
+	forkchoiceAtt     map[[32]byte]*ethpb.Attestation
+	blockAttLock      sync.RWMutex
+	blockAtt          map[[32]byte][]*ethpb.Attestation
+	seenAtt           *cache.Cache
 }
 
 // NewAttCaches initializes a new attestation pool consists of multiple KV store in cache for
@@ -36,8 +36,8 @@ func NewAttCaches() *AttCaches {
 	secsInEpoch := time.Duration(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot))
 	c := cache.New(secsInEpoch*time.Second, 2*secsInEpoch*time.Second)
 	pool := &AttCaches{
-		unAggregatedAtt: make(map[[32]byte]*ethpb.Attestation),
-		aggregatedAtt:   make(map[[32]byte][]*ethpb.Attestation),
+		unAggregatedAtt: newAttShards(func(*ethpb.Attestation) int { return 1 }),
+		aggregatedAtt:   newAttShards(func(atts []*ethpb.Attestation) int { return len(atts) }),
 		forkchoiceAtt:   make(map[[32]byte]*ethpb.Attestation),
 		blockAtt:        make(map[[32]byte][]*ethpb.Attestation),
 		seenAtt:         c,
@@ -0,0 +1,260 @@
+package kv
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+// attShards stores attestation pool entries sharded by slot, and by committee index within a slot.
+//
+// The slot index is an immutable map replaced on every shard creation or removal, so readers load it
+// atomically and never contend on a pool-wide lock. Every shard has its own lock, which means writers
+// of one slot do not block readers of another one, such as a proposer packing attestations of older
+// slots while attestations of the current slot are arriving. The number of entries is tracked
+// atomically, so bounding the pool does not require scanning it.
+type attShards[V any] struct {
+	// indexLock serializes the writers of the slot index.
+	indexLock sync.Mutex
+	index     atomic.Pointer[map[primitives.Slot]*attShard[V]]
+	count     atomic.Int64
+	// weight returns the number of attestations held by an entry, and total tracks their sum.
+	weight func(V) int
+	total  atomic.Int64
+}
+
+// attShard holds the entries of a single slot.
+type attShard[V any] struct {
+	lock       sync.RWMutex
+	committees map[primitives.CommitteeIndex]map[[32]byte]V
+	// removed is set once the shard is dropped from the index, after which it must not be written to.
+	removed bool
+}
+
+// newAttShards returns an empty pool whose entries hold the number of attestations given by weight.
+func newAttShards[V any](weight func(V) int) *attShards[V] {
+	s := &attShards[V]{weight: weight}
+	index := make(map[primitives.Slot]*attShard[V])
+	s.index.Store(&index)
+	return s
+}
+
+// len returns the number of entries across all shards.
+func (s *attShards[V]) len() int {
+	return int(s.count.Load())
+}
+
+// attestations returns the number of attestations held by the entries across all shards.
+func (s *attShards[V]) attestations() int {
+	return int(s.total.Load())
+}
+
+// slots returns the current slot index. The returned map must not be modified.
+func (s *attShards[V]) slots() map[primitives.Slot]*attShard[V] {
+	return *s.index.Load()
+}
+
+// shard returns the shard of the given slot, creating it if needed.
+func (s *attShards[V]) shard(slot primitives.Slot) *attShard[V] {
+	if sh, ok := s.slots()[slot]; ok {
+		return sh
+	}
+	s.indexLock.Lock()
+	defer s.indexLock.Unlock()
+	old := s.slots()
+	if sh, ok := old[slot]; ok {
+		return sh
+	}
+	sh := &attShard[V]{committees: make(map[primitives.CommitteeIndex]map[[32]byte]V)}
+	index := make(map[primitives.Slot]*attShard[V], len(old)+1)
+	for k, v := range old {
+		index[k] = v
+	}
+	index[slot] = sh
+	s.index.Store(&index)
+	return sh
+}
+
+// update calls fn with the entry stored under the given key, if any, while holding the lock of its shard.
+// The entry is replaced with the returned value when keep is true, and deleted otherwise. It returns
+// whether a new entry was added.
+func (s *attShards[V]) update(
+	slot primitives.Slot, committee primitives.CommitteeIndex, key [32]byte, fn func(old V, exists bool) (V, bool, error),
+) (bool, error) {
+	for {
+		sh := s.shard(slot)
+		sh.lock.Lock()
+		if sh.removed {
+			// The shard was pruned between the index lookup and locking it, look it up again.
+			sh.lock.Unlock()
+			continue
+		}
+		entries := sh.committees[committee]
+		old, exists := entries[key]
+		v, keep, err := fn(old, exists)
+		if err != nil {
+			sh.lock.Unlock()
+			return false, err
+		}
+		if keep {
+			if entries == nil {
+				entries = make(map[[32]byte]V)
+				sh.committees[committee] = entries
+			}
+			entries[key] = v
+			if !exists {
+				s.count.Add(1)
+			}
+			s.total.Add(int64(s.weight(v)))
+		} else if exists {
+			delete(entries, key)
+			if len(entries) == 0 {
+				delete(sh.committees, committee)
+			}
+			s.count.Add(-1)
+		}
+		if exists {
+			s.total.Add(int64(-s.weight(old)))
+		}
+		sh.lock.Unlock()
+		return keep && !exists, nil
+	}
+}
+
+// put stores the entry under the given key, returning whether it was not present yet.
+func (s *attShards[V]) put(slot primitives.Slot, committee primitives.CommitteeIndex, key [32]byte, v V) bool {
+	// The callback never fails.
+	added, _ := s.update(slot, committee, key, func(V, bool) (V, bool, error) {
+		return v, true, nil
+	})
+	return added
+}
+
+// get returns the entry stored under the given key.
+func (s *attShards[V]) get(slot primitives.Slot, committee primitives.CommitteeIndex, key [32]byte) (V, bool) {
+	var v V
+	sh, ok := s.slots()[slot]
+	if !ok {
+		return v, false
+	}
+	sh.lock.RLock()
+	defer sh.lock.RUnlock()
+	v, ok = sh.committees[committee][key]
+	return v, ok
+}
+
+// delete removes the entry stored under the given key.
+func (s *attShards[V]) delete(slot primitives.Slot, committee primitives.CommitteeIndex, key [32]byte) {
+	sh, ok := s.slots()[slot]
+	if !ok {
+		return
+	}
+	sh.lock.Lock()
+	defer sh.lock.Unlock()
+	entries, ok := sh.committees[committee]
+	if !ok {
+		return
+	}
+	v, ok := entries[key]
+	if !ok {
+		return
+	}
+	delete(entries, key)
+	if len(entries) == 0 {
+		delete(sh.committees, committee)
+	}
+	s.count.Add(-1)
+	s.total.Add(int64(-s.weight(v)))
+}
+
+// committee calls fn for every entry of the given slot and committee index.
+func (s *attShards[V]) committee(slot primitives.Slot, committee primitives.CommitteeIndex, fn func(V)) {
+	sh, ok := s.slots()[slot]
+	if !ok {
+		return
+	}
+	sh.lock.RLock()
+	defer sh.lock.RUnlock()
+	for _, v := range sh.committees[committee] {
+		fn(v)
+	}
+}
+
+// forEach calls fn for every entry in the pool. Shards are locked one at a time, so fn may observe
+// writes to shards it has not visited yet. fn must not modify the pool.
+func (s *attShards[V]) forEach(fn func(key [32]byte, v V) error) error {
+	for _, sh := range s.slots() {
+		if err := sh.forEach(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sh *attShard[V]) forEach(fn func(key [32]byte, v V) error) error {
+	sh.lock.RLock()
+	defer sh.lock.RUnlock()
+	for _, entries := range sh.committees {
+		for k, v := range entries {
+			if err := fn(k, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// oldestSlot returns the lowest slot with a shard in the pool.
+func (s *attShards[V]) oldestSlot() (primitives.Slot, bool) {
+	found := false
+	var oldest primitives.Slot
+	for slot := range s.slots() {
+		if !found || slot < oldest {
+			oldest = slot
+			found = true
+		}
+	}
+	return oldest, found
+}
+
+// deleteBefore drops the shards of all slots lower than the given one, calling fn for every entry
+// removed. It returns the number of entries removed.
+func (s *attShards[V]) deleteBefore(slot primitives.Slot, fn func(V)) int {
+	s.indexLock.Lock()
+	defer s.indexLock.Unlock()
+	old := s.slots()
+	index := make(map[primitives.Slot]*attShard[V], len(old))
+	var pruned []*attShard[V]
+	for k, v := range old {
+		if k < slot {
+			pruned = append(pruned, v)
+			continue
+		}
+		index[k] = v
+	}
+	if len(pruned) == 0 {
+		return 0
+	}
+	s.index.Store(&index)
+
+	removed, weight := 0, 0
+	for _, sh := range pruned {
+		sh.lock.Lock()
+		sh.removed = true
+		for _, entries := range sh.committees {
+			for _, v := range entries {
+				if fn != nil {
+					fn(v)
+				}
+				removed++
+				weight += s.weight(v)
+			}
+		}
+		sh.committees = nil
+		sh.lock.Unlock()
+	}
+	s.count.Add(int64(-removed))
+	s.total.Add(int64(-weight))
+	return removed
+}
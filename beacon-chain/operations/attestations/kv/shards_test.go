@@ -0,0 +1,118 @@
+package kv
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestAttShards(t *testing.T) {
+	s := newAttShards(func(int) int { return 1 })
+	assert.Equal(t, true, s.put(1, 0, [32]byte{'a'}, 1))
+	assert.Equal(t, false, s.put(1, 0, [32]byte{'a'}, 2))
+	assert.Equal(t, true, s.put(1, 1, [32]byte{'b'}, 3))
+	assert.Equal(t, true, s.put(2, 0, [32]byte{'c'}, 4))
+	assert.Equal(t, 3, s.len())
+
+	v, ok := s.get(1, 0, [32]byte{'a'})
+	require.Equal(t, true, ok)
+	assert.Equal(t, 2, v)
+	_, ok = s.get(1, 1, [32]byte{'a'})
+	assert.Equal(t, false, ok)
+
+	var committee []int
+	s.committee(1, 1, func(v int) { committee = append(committee, v) })
+	assert.DeepEqual(t, []int{3}, committee)
+
+	oldest, ok := s.oldestSlot()
+	require.Equal(t, true, ok)
+	assert.Equal(t, primitives.Slot(1), oldest)
+
+	s.delete(1, 0, [32]byte{'a'})
+	s.delete(1, 0, [32]byte{'a'})
+	assert.Equal(t, 2, s.len())
+
+	removed := s.deleteBefore(2, nil)
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, 1, s.len())
+	_, ok = s.slots()[1]
+	assert.Equal(t, false, ok)
+
+	// Writing to a pruned slot creates a new shard.
+	assert.Equal(t, true, s.put(1, 0, [32]byte{'a'}, 5))
+	assert.Equal(t, 2, s.len())
+}
+
+func TestAttShards_Attestations(t *testing.T) {
+	s := newAttShards(func(v int) int { return v })
+	s.put(1, 0, [32]byte{'a'}, 2)
+	s.put(1, 0, [32]byte{'b'}, 3)
+	s.put(2, 0, [32]byte{'c'}, 4)
+	assert.Equal(t, 9, s.attestations())
+
+	s.put(1, 0, [32]byte{'a'}, 5)
+	assert.Equal(t, 12, s.attestations())
+	s.delete(1, 0, [32]byte{'b'})
+	assert.Equal(t, 9, s.attestations())
+	s.deleteBefore(2, nil)
+	assert.Equal(t, 4, s.attestations())
+	assert.Equal(t, 1, s.len())
+}
+
+func TestAttShards_Concurrent(t *testing.T) {
+	s := newAttShards(func(int) int { return 1 })
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.put(primitives.Slot(j%4), primitives.CommitteeIndex(i), [32]byte{byte(i), byte(j)}, j)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				require.NoError(t, s.forEach(func([32]byte, int) error { return nil }))
+				s.deleteBefore(primitives.Slot(j%4), nil)
+			}
+		}()
+	}
+	wg.Wait()
+
+	counted := 0
+	require.NoError(t, s.forEach(func([32]byte, int) error {
+		counted++
+		return nil
+	}))
+	assert.Equal(t, counted, s.len())
+}
+
+func TestKV_DeleteAttestationsBefore(t *testing.T) {
+	cache := NewAttCaches()
+	for _, slot := range []primitives.Slot{1, 2, 3} {
+		require.NoError(t, cache.SaveUnaggregatedAttestation(util.HydrateAttestation(&ethpb.Attestation{
+			Data:            &ethpb.AttestationData{Slot: slot},
+			AggregationBits: bitfield.Bitlist{0b101},
+		})))
+		require.NoError(t, cache.SaveAggregatedAttestation(util.HydrateAttestation(&ethpb.Attestation{
+			Data:            &ethpb.AttestationData{Slot: slot},
+			AggregationBits: bitfield.Bitlist{0b1101},
+		})))
+	}
+
+	assert.Equal(t, 2, cache.DeleteUnaggregatedAttestationsBefore(3))
+	assert.Equal(t, 2, cache.DeleteAggregatedAttestationsBefore(3))
+	atts, err := cache.UnaggregatedAttestations()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(atts))
+	assert.Equal(t, primitives.Slot(3), atts[0].Data.Slot)
+	require.Equal(t, 1, len(cache.AggregatedAttestations()))
+	assert.Equal(t, primitives.Slot(3), cache.AggregatedAttestations()[0].Data.Slot)
+}
@@ -32,9 +32,9 @@ func (c *AttCaches) SaveUnaggregatedAttestation(att *ethpb.Attestation) error {
 		return errors.Wrap(err, "could not tree hash attestation")
 	}
 	att = ethpb.CopyAttestation(att) // Copied.
-	c.unAggregateAttLock.Lock()
-	defer c.unAggregateAttLock.Unlock()
-	c.unAggregatedAtt[r] = att
+	if c.unAggregatedAtt.put(att.GetData().GetSlot(), att.GetData().GetCommitteeIndex(), r, att) {
+		c.evictUnaggregatedAttestations()
+	}
 
 	return nil
 }
@@ -52,18 +52,19 @@ func (c *AttCaches) SaveUnaggregatedAttestations(atts []*ethpb.Attestation) erro
 
 // UnaggregatedAttestations returns all the unaggregated attestations in cache.
 func (c *AttCaches) UnaggregatedAttestations() ([]*ethpb.Attestation, error) {
-	c.unAggregateAttLock.RLock()
-	defer c.unAggregateAttLock.RUnlock()
-	unAggregatedAtts := c.unAggregatedAtt
-	atts := make([]*ethpb.Attestation, 0, len(unAggregatedAtts))
-	for _, att := range unAggregatedAtts {
+	atts := make([]*ethpb.Attestation, 0, c.unAggregatedAtt.len())
+	err := c.unAggregatedAtt.forEach(func(_ [32]byte, att *ethpb.Attestation) error {
 		seen, err := c.hasSeenBit(att)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		if !seen {
 			atts = append(atts, ethpb.CopyAttestation(att) /* Copied */)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return atts, nil
 }
@@ -75,16 +76,9 @@ func (c *AttCaches) UnaggregatedAttestationsBySlotIndex(ctx context.Context, slo
 	defer span.End()
 
 	atts := make([]*ethpb.Attestation, 0)
-
-	c.unAggregateAttLock.RLock()
-	defer c.unAggregateAttLock.RUnlock()
-
-	unAggregatedAtts := c.unAggregatedAtt
-	for _, a := range unAggregatedAtts {
-		if slot == a.Data.Slot && committeeIndex == a.Data.CommitteeIndex {
-			atts = append(atts, a)
-		}
-	}
+	c.unAggregatedAtt.committee(slot, committeeIndex, func(a *ethpb.Attestation) {
+		atts = append(atts, a)
+	})
 
 	return atts
 }
@@ -107,9 +101,7 @@ func (c *AttCaches) DeleteUnaggregatedAttestation(att *ethpb.Attestation) error
 		return errors.Wrap(err, "could not tree hash attestation")
 	}
 
-	c.unAggregateAttLock.Lock()
-	defer c.unAggregateAttLock.Unlock()
-	delete(c.unAggregatedAtt, r)
+	c.unAggregatedAtt.delete(att.Data.Slot, att.Data.CommitteeIndex, r)
 
 	return nil
 }
@@ -117,29 +109,32 @@ func (c *AttCaches) DeleteUnaggregatedAttestation(att *ethpb.Attestation) error
 // DeleteSeenUnaggregatedAttestations deletes the unaggregated attestations in cache
 // that have been already processed once. Returns number of attestations deleted.
 func (c *AttCaches) DeleteSeenUnaggregatedAttestations() (int, error) {
-	c.unAggregateAttLock.Lock()
-	defer c.unAggregateAttLock.Unlock()
-
-	count := 0
-	for _, att := range c.unAggregatedAtt {
+	seenAtts := make(map[[32]byte]*ethpb.Attestation)
+	err := c.unAggregatedAtt.forEach(func(r [32]byte, att *ethpb.Attestation) error {
 		if att == nil || helpers.IsAggregated(att) {
-			continue
+			return nil
 		}
 		if seen, err := c.hasSeenBit(att); err == nil && seen {
-			r, err := hashFn(att)
-			if err != nil {
-				return count, errors.Wrap(err, "could not tree hash attestation")
-			}
-			delete(c.unAggregatedAtt, r)
-			count++
+			seenAtts[r] = att
 		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
-	return count, nil
+	for r, att := range seenAtts {
+		c.unAggregatedAtt.delete(att.GetData().GetSlot(), att.GetData().GetCommitteeIndex(), r)
+	}
+	return len(seenAtts), nil
+}
+
+// DeleteUnaggregatedAttestationsBefore deletes the unaggregated attestations of all slots lower than
+// the given one. Returns number of attestations deleted.
+func (c *AttCaches) DeleteUnaggregatedAttestationsBefore(slot primitives.Slot) int {
+	return c.unAggregatedAtt.deleteBefore(slot, nil)
 }
 
 // UnaggregatedAttestationCount returns the number of unaggregated attestations key in the pool.
 func (c *AttCaches) UnaggregatedAttestationCount() int {
-	c.unAggregateAttLock.RLock()
-	defer c.unAggregateAttLock.RUnlock()
-	return len(c.unAggregatedAtt)
+	return c.unAggregatedAtt.len()
 }
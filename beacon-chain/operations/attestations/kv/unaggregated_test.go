@@ -64,7 +64,7 @@ func TestKV_Unaggregated_SaveUnaggregatedAttestation(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			cache := NewAttCaches()
 			cache.seenAtt.Set(string(r[:]), []bitfield.Bitlist{{0xff}}, c.DefaultExpiration)
-			assert.Equal(t, 0, len(cache.unAggregatedAtt), "Invalid start pool, atts: %d", len(cache.unAggregatedAtt))
+			assert.Equal(t, 0, cache.unAggregatedAtt.len(), "Invalid start pool, atts: %d", cache.unAggregatedAtt.len())
 
 			if tt.att != nil && tt.att.Signature == nil {
 				tt.att.Signature = make([]byte, fieldparams.BLSSignatureLength)
@@ -76,7 +76,7 @@ func TestKV_Unaggregated_SaveUnaggregatedAttestation(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 			}
-			assert.Equal(t, tt.count, len(cache.unAggregatedAtt), "Wrong attestation count")
+			assert.Equal(t, tt.count, cache.unAggregatedAtt.len(), "Wrong attestation count")
 			assert.Equal(t, tt.count, cache.UnaggregatedAttestationCount(), "Wrong attestation count")
 		})
 	}
@@ -113,7 +113,7 @@ func TestKV_Unaggregated_SaveUnaggregatedAttestations(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cache := NewAttCaches()
-			assert.Equal(t, 0, len(cache.unAggregatedAtt), "Invalid start pool, atts: %d", len(cache.unAggregatedAtt))
+			assert.Equal(t, 0, cache.unAggregatedAtt.len(), "Invalid start pool, atts: %d", cache.unAggregatedAtt.len())
 
 			err := cache.SaveUnaggregatedAttestations(tt.atts)
 			if tt.wantErrString != "" {
@@ -121,7 +121,7 @@ func TestKV_Unaggregated_SaveUnaggregatedAttestations(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 			}
-			assert.Equal(t, tt.count, len(cache.unAggregatedAtt), "Wrong attestation count")
+			assert.Equal(t, tt.count, cache.unAggregatedAtt.len(), "Wrong attestation count")
 			assert.Equal(t, tt.count, cache.UnaggregatedAttestationCount(), "Wrong attestation count")
 		})
 	}
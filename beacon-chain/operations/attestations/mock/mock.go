@@ -53,6 +53,11 @@ func (*PoolMock) HasAggregatedAttestation(_ *ethpb.Attestation) (bool, error) {
 	panic("implement me")
 }
 
+// DeleteAggregatedAttestationsBefore --
+func (*PoolMock) DeleteAggregatedAttestationsBefore(_ primitives.Slot) int {
+	panic("implement me")
+}
+
 // AggregatedAttestationCount --
 func (*PoolMock) AggregatedAttestationCount() int {
 	panic("implement me")
@@ -88,6 +93,11 @@ func (*PoolMock) DeleteSeenUnaggregatedAttestations() (int, error) {
 	panic("implement me")
 }
 
+// DeleteUnaggregatedAttestationsBefore --
+func (*PoolMock) DeleteUnaggregatedAttestationsBefore(_ primitives.Slot) int {
+	panic("implement me")
+}
+
 // UnaggregatedAttestationCount --
 func (*PoolMock) UnaggregatedAttestationCount() int {
 	panic("implement me")
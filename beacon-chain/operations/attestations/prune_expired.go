@@ -26,32 +26,14 @@ func (s *Service) pruneAttsPool() {
 
 // This prunes expired attestations from the pool.
 func (s *Service) pruneExpiredAtts() {
-	aggregatedAtts := s.cfg.Pool.AggregatedAttestations()
-	for _, att := range aggregatedAtts {
-		if s.expired(att.Data.Slot) {
-			if err := s.cfg.Pool.DeleteAggregatedAttestation(att); err != nil {
-				log.WithError(err).Error("Could not delete expired aggregated attestation")
-			}
-			expiredAggregatedAtts.Inc()
-		}
-	}
+	// Aggregated and unaggregated attestations are stored by slot, so all expired slots are dropped at once.
+	expirySlot := s.firstUnexpiredSlot()
+	expiredAggregatedAtts.Add(float64(s.cfg.Pool.DeleteAggregatedAttestationsBefore(expirySlot)))
 
 	if _, err := s.cfg.Pool.DeleteSeenUnaggregatedAttestations(); err != nil {
 		log.WithError(err).Error("Cannot delete seen attestations")
 	}
-	unAggregatedAtts, err := s.cfg.Pool.UnaggregatedAttestations()
-	if err != nil {
-		log.WithError(err).Error("Could not get unaggregated attestations")
-		return
-	}
-	for _, att := range unAggregatedAtts {
-		if s.expired(att.Data.Slot) {
-			if err := s.cfg.Pool.DeleteUnaggregatedAttestation(att); err != nil {
-				log.WithError(err).Error("Could not delete expired unaggregated attestation")
-			}
-			expiredUnaggregatedAtts.Inc()
-		}
-	}
+	expiredUnaggregatedAtts.Add(float64(s.cfg.Pool.DeleteUnaggregatedAttestationsBefore(expirySlot)))
 
 	blockAtts := s.cfg.Pool.BlockAttestations()
 	for _, att := range blockAtts {
@@ -64,6 +46,19 @@ func (s *Service) pruneExpiredAtts() {
 	}
 }
 
+// firstUnexpiredSlot returns the lowest slot whose attestations have not expired yet.
+func (s *Service) firstUnexpiredSlot() primitives.Slot {
+	currentTime := uint64(prysmTime.Now().Unix())
+	if currentTime < s.genesisTime {
+		return 0
+	}
+	currentSlot := primitives.Slot((currentTime - s.genesisTime) / params.BeaconConfig().SecondsPerSlot)
+	if currentSlot < params.BeaconConfig().SlotsPerEpoch {
+		return 0
+	}
+	return currentSlot - params.BeaconConfig().SlotsPerEpoch + 1
+}
+
 // Return true if the input slot has been expired.
 // Expired is defined as one epoch behind than current time.
 func (s *Service) expired(slot primitives.Slot) bool {
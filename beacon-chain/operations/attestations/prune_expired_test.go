@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/async"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
@@ -127,3 +128,17 @@ func TestPruneExpired_Expired(t *testing.T) {
 	assert.Equal(t, true, s.expired(0), "Should be expired")
 	assert.Equal(t, false, s.expired(1), "Should not be expired")
 }
+
+func TestPruneExpired_FirstUnexpiredSlot(t *testing.T) {
+	s, err := NewService(context.Background(), &Config{Pool: NewPool()})
+	require.NoError(t, err)
+
+	s.genesisTime = uint64(prysmTime.Now().Unix())
+	assert.Equal(t, primitives.Slot(0), s.firstUnexpiredSlot())
+
+	// Rewind back two epochs worth of time.
+	s.genesisTime = uint64(prysmTime.Now().Unix()) - 2*uint64(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot))
+	first := s.firstUnexpiredSlot()
+	assert.Equal(t, true, s.expired(first-1), "Should be expired")
+	assert.Equal(t, false, s.expired(first), "Should not be expired")
+}
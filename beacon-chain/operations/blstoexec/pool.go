@@ -7,6 +7,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/priority"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
@@ -113,7 +114,7 @@ func (p *Pool) BLSToExecChangesForInclusion(st state.ReadOnlyBeaconState) ([]*et
 	return result, nil
 }
 
-// InsertBLSToExecChange inserts an object into the pool.
+// InsertBLSToExecChange inserts an object into the pool. The object is dropped if the pool is full.
 func (p *Pool) InsertBLSToExecChange(change *ethpb.SignedBLSToExecutionChange) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -122,6 +123,11 @@ func (p *Pool) InsertBLSToExecChange(change *ethpb.SignedBLSToExecutionChange) {
 	if exists {
 		return
 	}
+	// Pending changes are never evicted, new ones are dropped instead when the pool is full.
+	if priority.BLSToExecutionChange.Full(p.numPending()) {
+		priority.RecordRejection(priority.BLSToExecutionChange)
+		return
+	}
 
 	p.pending.Append(doublylinkedlist.NewNode(change))
 	p.m[change.Message.ValidatorIndex] = p.pending.Last()
@@ -6,6 +6,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	state_native "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
@@ -237,6 +238,28 @@ func TestInsertBLSToExecChange(t *testing.T) {
 		require.NoError(t, err)
 		assert.DeepEqual(t, old, v)
 	})
+	t.Run("pool full", func(t *testing.T) {
+		resetCfg := flags.Get()
+		flags.Init(&flags.GlobalFlags{MaxPoolBLSToExecutionChanges: 1})
+		defer flags.Init(resetCfg)
+
+		pool := NewPool()
+		old := &eth.SignedBLSToExecutionChange{
+			Message: &eth.BLSToExecutionChange{
+				ValidatorIndex: primitives.ValidatorIndex(0),
+			},
+		}
+		change := &eth.SignedBLSToExecutionChange{
+			Message: &eth.BLSToExecutionChange{
+				ValidatorIndex: primitives.ValidatorIndex(1),
+			},
+		}
+		pool.InsertBLSToExecChange(old)
+		pool.InsertBLSToExecChange(change)
+		require.Equal(t, 1, pool.pending.Len())
+		assert.Equal(t, true, pool.ValidatorExists(0))
+		assert.Equal(t, false, pool.ValidatorExists(1))
+	})
 }
 
 func TestMarkIncluded(t *testing.T) {
@@ -0,0 +1,9 @@
+// Package priority defines the priority classes of the operations held in the beacon node's
+// operation pools. Each class has its own size limit, so a flood of low value operations such as
+// attestations can never displace the operations which are critical for the chain or profitable
+// for proposers, such as slashings, voluntary exits and BLS to execution changes.
+//
+// Only attestations are evicted when their pool is full, starting with the oldest ones. Operations of
+// the other classes are never evicted: once their pool reaches its limit new operations are rejected
+// instead, and slashings are never limited at all.
+package priority
@@ -0,0 +1,23 @@
+package priority
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	evictedOperations = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "operation_pool_evicted_total",
+			Help: "The number of operations evicted from a full operation pool, by priority class.",
+		},
+		[]string{"class"},
+	)
+	rejectedOperations = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "operation_pool_rejected_total",
+			Help: "The number of operations not added to a full operation pool, by priority class.",
+		},
+		[]string{"class"},
+	)
+)
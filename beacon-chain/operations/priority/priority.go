@@ -0,0 +1,84 @@
+package priority
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+)
+
+// Class is the priority class of an operation, from the lowest to the highest priority.
+type Class int
+
+const (
+	// Attestation is the class of aggregated and unaggregated attestations.
+	Attestation Class = iota
+	// BLSToExecutionChange is the class of signed BLS to execution changes.
+	BLSToExecutionChange
+	// VoluntaryExit is the class of signed voluntary exits.
+	VoluntaryExit
+	// Slashing is the class of proposer and attester slashings.
+	Slashing
+)
+
+// String returns the name of the class, as used in metric labels.
+func (c Class) String() string {
+	switch c {
+	case Attestation:
+		return "attestation"
+	case BLSToExecutionChange:
+		return "bls_to_execution_change"
+	case VoluntaryExit:
+		return "voluntary_exit"
+	case Slashing:
+		return "slashing"
+	default:
+		return "unknown"
+	}
+}
+
+// Evictable returns true if operations of the class may be evicted from a full pool to make room for
+// newer ones.
+func (c Class) Evictable() bool {
+	return c == Attestation
+}
+
+// Limit returns the maximum number of operations of the class a pool holds. Zero means no limit.
+func (c Class) Limit() int {
+	switch c {
+	case Attestation:
+		return flags.Get().MaxPoolAttestations
+	case BLSToExecutionChange:
+		return flags.Get().MaxPoolBLSToExecutionChanges
+	case VoluntaryExit:
+		return flags.Get().MaxPoolVoluntaryExits
+	default:
+		return 0
+	}
+}
+
+// Full returns true if a pool of the class holding the given number of operations has reached its limit.
+func (c Class) Full(size int) bool {
+	limit := c.Limit()
+	return limit > 0 && size >= limit
+}
+
+// Exceeds returns the number of operations by which a pool of the class holding the given number of
+// operations exceeds its limit.
+func (c Class) Exceeds(size int) int {
+	limit := c.Limit()
+	if limit == 0 || size <= limit {
+		return 0
+	}
+	return size - limit
+}
+
+// RecordEvictions records the eviction of operations of the class from a full pool.
+func RecordEvictions(c Class, n int) {
+	if n == 0 {
+		return
+	}
+	evictedOperations.WithLabelValues(c.String()).Add(float64(n))
+}
+
+// RecordRejection records that an operation of the class was not added to its pool because the pool was full.
+func RecordRejection(c Class) {
+	rejectedOperations.WithLabelValues(c.String()).Inc()
+}
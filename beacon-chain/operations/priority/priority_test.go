@@ -0,0 +1,33 @@
+package priority
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+)
+
+func TestClass_Limits(t *testing.T) {
+	resetCfg := flags.Get()
+	flags.Init(&flags.GlobalFlags{
+		MaxPoolAttestations:          4,
+		MaxPoolVoluntaryExits:        2,
+		MaxPoolBLSToExecutionChanges: 0,
+	})
+	defer flags.Init(resetCfg)
+
+	assert.Equal(t, false, Attestation.Full(3))
+	assert.Equal(t, true, Attestation.Full(4))
+	assert.Equal(t, 0, Attestation.Exceeds(4))
+	assert.Equal(t, 3, Attestation.Exceeds(7))
+	assert.Equal(t, true, VoluntaryExit.Full(2))
+	// A zero limit and the slashing class are unbounded.
+	assert.Equal(t, false, BLSToExecutionChange.Full(1<<20))
+	assert.Equal(t, false, Slashing.Full(1<<20))
+	assert.Equal(t, 0, Slashing.Exceeds(1<<20))
+
+	assert.Equal(t, true, Attestation.Evictable())
+	assert.Equal(t, false, VoluntaryExit.Evictable())
+	assert.Equal(t, false, BLSToExecutionChange.Evictable())
+	assert.Equal(t, false, Slashing.Evictable())
+}
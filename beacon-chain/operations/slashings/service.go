@@ -29,7 +29,8 @@ func NewPool() *Pool {
 
 // PendingAttesterSlashings returns attester slashings that are able to be included into a block.
 // This method will return the amount of pending attester slashings for a block transition unless parameter `noLimit` is true
-// to indicate the request is for noLimit pending items.
+// to indicate the request is for noLimit pending items. Slashings are returned by decreasing effective balance of the
+// validators they would slash, and the slashings of validators which can no longer be slashed are dropped from the pool.
 func (p *Pool) PendingAttesterSlashings(ctx context.Context, state state.ReadOnlyBeaconState, noLimit bool) []*ethpb.AttesterSlashing {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -39,36 +40,57 @@ func (p *Pool) PendingAttesterSlashings(ctx context.Context, state state.ReadOnl
 	// Update prom metric.
 	numPendingAttesterSlashings.Set(float64(len(p.pendingAttesterSlashing)))
 
-	included := make(map[primitives.ValidatorIndex]bool)
+	candidates := make([]*attesterSlashingCandidate, 0, len(p.pendingAttesterSlashing))
+	for i := 0; i < len(p.pendingAttesterSlashing); i++ {
+		slashing := p.pendingAttesterSlashing[i]
+		valid, err := p.validatorSlashingPreconditionCheck(state, slashing.validatorToSlash)
+		if err != nil {
+			log.WithError(err).Error("could not validate attester slashing")
+			continue
+		}
+		if !valid {
+			p.pendingAttesterSlashing = append(p.pendingAttesterSlashing[:i], p.pendingAttesterSlashing[i+1:]...)
+			i--
+			continue
+		}
+		candidate, err := p.newAttesterSlashingCandidate(state, slashing)
+		if err != nil {
+			log.WithError(err).Error("could not compute slashable balance of attester slashing")
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+	// The pool is sorted by validator index, so that the slashings of equal balance keep this order.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].balance > candidates[j].balance
+	})
 
 	// Allocate pending slice with a capacity of maxAttesterSlashings or len(p.pendingAttesterSlashing)) depending on the request.
 	maxSlashings := params.BeaconConfig().MaxAttesterSlashings
 	if noLimit {
 		maxSlashings = uint64(len(p.pendingAttesterSlashing))
 	}
+	included := make(map[primitives.ValidatorIndex]bool)
 	pending := make([]*ethpb.AttesterSlashing, 0, maxSlashings)
-	for i := 0; i < len(p.pendingAttesterSlashing); i++ {
+	for _, candidate := range candidates {
 		if uint64(len(pending)) >= maxSlashings {
 			break
 		}
-		slashing := p.pendingAttesterSlashing[i]
-		valid, err := p.validatorSlashingPreconditionCheck(state, slashing.validatorToSlash)
-		if err != nil {
-			log.WithError(err).Error("could not validate attester slashing")
-			continue
+		// Skip the slashings which would not slash any validator on top of the slashings already selected.
+		newlySlashed := false
+		for _, idx := range candidate.slashable {
+			if !included[idx] {
+				newlySlashed = true
+				break
+			}
 		}
-		if included[slashing.validatorToSlash] || !valid {
-			p.pendingAttesterSlashing = append(p.pendingAttesterSlashing[:i], p.pendingAttesterSlashing[i+1:]...)
-			i--
+		if !newlySlashed {
 			continue
 		}
-		attSlashing := slashing.attesterSlashing
-		slashedVal := slice.IntersectionUint64(attSlashing.Attestation_1.AttestingIndices, attSlashing.Attestation_2.AttestingIndices)
-		for _, idx := range slashedVal {
-			included[primitives.ValidatorIndex(idx)] = true
+		for _, idx := range candidate.slashable {
+			included[idx] = true
 		}
-
-		pending = append(pending, attSlashing)
+		pending = append(pending, candidate.slashing.attesterSlashing)
 	}
 
 	return pending
@@ -76,7 +98,8 @@ func (p *Pool) PendingAttesterSlashings(ctx context.Context, state state.ReadOnl
 
 // PendingProposerSlashings returns proposer slashings that are able to be included into a block.
 // This method will return the amount of pending proposer slashings for a block transition unless the `noLimit` parameter
-// is set to true to indicate the request is for noLimit pending items.
+// is set to true to indicate the request is for noLimit pending items. Slashings are returned by decreasing effective
+// balance of the proposer, and the slashings of proposers which can no longer be slashed are dropped from the pool.
 func (p *Pool) PendingProposerSlashings(ctx context.Context, state state.ReadOnlyBeaconState, noLimit bool) []*ethpb.ProposerSlashing {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -86,16 +109,9 @@ func (p *Pool) PendingProposerSlashings(ctx context.Context, state state.ReadOnl
 	// Update prom metric.
 	numPendingProposerSlashings.Set(float64(len(p.pendingProposerSlashing)))
 
-	// Allocate pending slice with a capacity of len(p.pendingProposerSlashing) or maxProposerSlashings depending on the request.
-	maxSlashings := params.BeaconConfig().MaxProposerSlashings
-	if noLimit {
-		maxSlashings = uint64(len(p.pendingProposerSlashing))
-	}
-	pending := make([]*ethpb.ProposerSlashing, 0, maxSlashings)
+	candidates := make([]*ethpb.ProposerSlashing, 0, len(p.pendingProposerSlashing))
+	balances := make(map[*ethpb.ProposerSlashing]uint64, len(p.pendingProposerSlashing))
 	for i := 0; i < len(p.pendingProposerSlashing); i++ {
-		if uint64(len(pending)) >= maxSlashings {
-			break
-		}
 		slashing := p.pendingProposerSlashing[i]
 		valid, err := p.validatorSlashingPreconditionCheck(state, slashing.Header_1.Header.ProposerIndex)
 		if err != nil {
@@ -107,10 +123,26 @@ func (p *Pool) PendingProposerSlashings(ctx context.Context, state state.ReadOnl
 			i--
 			continue
 		}
+		proposer, err := state.ValidatorAtIndexReadOnly(slashing.Header_1.Header.ProposerIndex)
+		if err != nil {
+			log.WithError(err).Error("could not compute slashable balance of proposer slashing")
+			continue
+		}
+		balances[slashing] = proposer.EffectiveBalance()
+		candidates = append(candidates, slashing)
+	}
+	// The pool is sorted by validator index, so that the slashings of equal balance keep this order.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return balances[candidates[i]] > balances[candidates[j]]
+	})
 
-		pending = append(pending, slashing)
+	// Allocate pending slice with a capacity of len(p.pendingProposerSlashing) or maxProposerSlashings depending on the request.
+	maxSlashings := params.BeaconConfig().MaxProposerSlashings
+	if noLimit || uint64(len(candidates)) < maxSlashings {
+		maxSlashings = uint64(len(candidates))
 	}
-	return pending
+	pending := make([]*ethpb.ProposerSlashing, 0, maxSlashings)
+	return append(pending, candidates[:maxSlashings]...)
 }
 
 // InsertAttesterSlashing into the pool. This method is a no-op if the attester slashing already exists in the pool,
@@ -261,6 +293,38 @@ func (p *Pool) MarkIncludedProposerSlashing(ps *ethpb.ProposerSlashing) {
 	numProposerSlashingsIncluded.Inc()
 }
 
+// Returns the candidate for inclusion of an attester slashing, along with the validators it would slash
+// and the sum of their effective balances.
+// Note: this method requires caller to hold the lock.
+func (p *Pool) newAttesterSlashingCandidate(
+	state state.ReadOnlyBeaconState,
+	slashing *PendingAttesterSlashing,
+) (*attesterSlashingCandidate, error) {
+	attSlashing := slashing.attesterSlashing
+	slashedVal := slice.IntersectionUint64(attSlashing.Attestation_1.AttestingIndices, attSlashing.Attestation_2.AttestingIndices)
+	candidate := &attesterSlashingCandidate{
+		slashing:  slashing,
+		slashable: make([]primitives.ValidatorIndex, 0, len(slashedVal)),
+	}
+	for _, val := range slashedVal {
+		idx := primitives.ValidatorIndex(val)
+		ok, err := p.validatorSlashingPreconditionCheck(state, idx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		validator, err := state.ValidatorAtIndexReadOnly(idx)
+		if err != nil {
+			return nil, err
+		}
+		candidate.slashable = append(candidate.slashable, idx)
+		candidate.balance += validator.EffectiveBalance()
+	}
+	return candidate, nil
+}
+
 // this function checks a few items about a validator before proceeding with inserting
 // a proposer/attester slashing into the pool. First, it checks if the validator
 // has been recently included in the pool, then it checks if the validator is slashable.
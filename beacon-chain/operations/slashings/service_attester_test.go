@@ -606,3 +606,67 @@ func TestPool_PendingAttesterSlashings_NoDuplicates(t *testing.T) {
 	}
 	assert.DeepEqual(t, slashings[0:2], p.PendingAttesterSlashings(context.Background(), beaconState, false /*noLimit*/))
 }
+
+func TestPool_PendingAttesterSlashings_PrioritizesSlashableBalance(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	conf := params.BeaconConfig()
+	conf.MaxAttesterSlashings = 2
+	params.OverrideBeaconConfig(conf)
+	beaconState, privKeys := util.DeterministicGenesisState(t, 64)
+	// Validators 0 and 1 have a lower effective balance than validators 2 and 3, and validator 4 is already slashed.
+	for i, balance := range []uint64{16, 17, 32, 31} {
+		val, err := beaconState.ValidatorAtIndex(primitives.ValidatorIndex(i))
+		require.NoError(t, err)
+		val.EffectiveBalance = balance * params.BeaconConfig().GweiPerEth
+		require.NoError(t, beaconState.UpdateValidatorAtIndex(primitives.ValidatorIndex(i), val))
+	}
+	pendingSlashings := make([]*PendingAttesterSlashing, 5)
+	slashings := make([]*ethpb.AttesterSlashing, 5)
+	for i := 0; i < len(pendingSlashings); i++ {
+		sl, err := util.GenerateAttesterSlashingForValidator(beaconState, privKeys[i], primitives.ValidatorIndex(i))
+		require.NoError(t, err)
+		pendingSlashings[i] = &PendingAttesterSlashing{
+			attesterSlashing: sl,
+			validatorToSlash: primitives.ValidatorIndex(i),
+		}
+		slashings[i] = sl
+	}
+	val, err := beaconState.ValidatorAtIndex(4)
+	require.NoError(t, err)
+	val.Slashed = true
+	require.NoError(t, beaconState.UpdateValidatorAtIndex(4, val))
+
+	p := &Pool{pendingAttesterSlashing: pendingSlashings}
+	assert.DeepEqual(t, []*ethpb.AttesterSlashing{slashings[2], slashings[3]}, p.PendingAttesterSlashings(context.Background(), beaconState, false /*noLimit*/))
+	assert.DeepEqual(
+		t,
+		[]*ethpb.AttesterSlashing{slashings[2], slashings[3], slashings[1], slashings[0]},
+		p.PendingAttesterSlashings(context.Background(), beaconState, true /*noLimit*/),
+	)
+	// The slashing of the already slashed validator is dropped from the pool.
+	require.Equal(t, 4, len(p.pendingAttesterSlashing))
+	for _, pending := range p.pendingAttesterSlashing {
+		assert.NotEqual(t, primitives.ValidatorIndex(4), pending.validatorToSlash)
+	}
+}
+
+func TestPool_PendingAttesterSlashings_PrioritizesMultipleValidators(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	conf := params.BeaconConfig()
+	conf.MaxAttesterSlashings = 1
+	params.OverrideBeaconConfig(conf)
+	beaconState, privKeys := util.DeterministicGenesisState(t, 64)
+	single := validAttesterSlashingForValIdx(t, beaconState, privKeys, 0)
+	double := validAttesterSlashingForValIdx(t, beaconState, privKeys, 1, 2)
+	p := &Pool{
+		pendingAttesterSlashing: []*PendingAttesterSlashing{
+			{attesterSlashing: single, validatorToSlash: 0},
+			{attesterSlashing: double, validatorToSlash: 1},
+			{attesterSlashing: double, validatorToSlash: 2},
+		},
+	}
+	// The slashing of two validators is worth more to the proposer than the slashing of a single one.
+	assert.DeepEqual(t, []*ethpb.AttesterSlashing{double}, p.PendingAttesterSlashings(context.Background(), beaconState, false /*noLimit*/))
+	// The slashing of two validators is returned once.
+	assert.DeepEqual(t, []*ethpb.AttesterSlashing{double, single}, p.PendingAttesterSlashings(context.Background(), beaconState, true /*noLimit*/))
+}
@@ -437,3 +437,31 @@ func TestPool_PendingProposerSlashings_Slashed(t *testing.T) {
 		})
 	}
 }
+
+func TestPool_PendingProposerSlashings_PrioritizesSlashableBalance(t *testing.T) {
+	beaconState, privKeys := util.DeterministicGenesisState(t, 64)
+	slashings := make([]*ethpb.ProposerSlashing, 4)
+	for i := 0; i < len(slashings); i++ {
+		sl, err := util.GenerateProposerSlashingForValidator(beaconState, privKeys[i], primitives.ValidatorIndex(i))
+		require.NoError(t, err)
+		slashings[i] = sl
+	}
+	// Validator 1 has a lower effective balance than the others, and validator 2 is already slashed.
+	val, err := beaconState.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	val.EffectiveBalance = 16 * params.BeaconConfig().GweiPerEth
+	require.NoError(t, beaconState.UpdateValidatorAtIndex(1, val))
+	val, err = beaconState.ValidatorAtIndex(2)
+	require.NoError(t, err)
+	val.Slashed = true
+	require.NoError(t, beaconState.UpdateValidatorAtIndex(2, val))
+
+	p := &Pool{pendingProposerSlashing: append([]*ethpb.ProposerSlashing{}, slashings...)}
+	assert.DeepEqual(
+		t,
+		[]*ethpb.ProposerSlashing{slashings[0], slashings[3], slashings[1]},
+		p.PendingProposerSlashings(context.Background(), beaconState, false /*noLimit*/),
+	)
+	// The slashing of the already slashed proposer is dropped from the pool.
+	assert.DeepEqual(t, []*ethpb.ProposerSlashing{slashings[0], slashings[1], slashings[3]}, p.pendingProposerSlashing)
+}
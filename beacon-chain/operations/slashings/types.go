@@ -47,3 +47,11 @@ type PendingAttesterSlashing struct {
 	attesterSlashing *ethpb.AttesterSlashing
 	validatorToSlash primitives.ValidatorIndex
 }
+
+// attesterSlashingCandidate is a pending attester slashing considered for inclusion into a block,
+// along with the validators it would slash and the sum of their effective balances.
+type attesterSlashingCandidate struct {
+	slashing  *PendingAttesterSlashing
+	slashable []primitives.ValidatorIndex
+	balance   uint64
+}
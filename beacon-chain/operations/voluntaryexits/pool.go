@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/priority"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	types "github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
@@ -109,7 +110,7 @@ func (p *Pool) ExitsForInclusion(state state.ReadOnlyBeaconState, slot types.Slo
 	return result, nil
 }
 
-// InsertVoluntaryExit into the pool.
+// InsertVoluntaryExit into the pool. The exit is dropped if the pool is full.
 func (p *Pool) InsertVoluntaryExit(exit *ethpb.SignedVoluntaryExit) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -118,6 +119,11 @@ func (p *Pool) InsertVoluntaryExit(exit *ethpb.SignedVoluntaryExit) {
 	if exists {
 		return
 	}
+	// Pending exits are never evicted, new ones are dropped instead when the pool is full.
+	if priority.VoluntaryExit.Full(p.pending.Len()) {
+		priority.RecordRejection(priority.VoluntaryExit)
+		return
+	}
 
 	p.pending.Append(doublylinkedlist.NewNode(exit))
 	p.m[exit.Exit.ValidatorIndex] = p.pending.Last()
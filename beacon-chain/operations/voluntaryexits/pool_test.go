@@ -6,6 +6,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	state_native "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	types "github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
@@ -218,6 +219,30 @@ func TestInsertExit(t *testing.T) {
 		require.NoError(t, err)
 		assert.DeepEqual(t, old, v)
 	})
+	t.Run("pool full", func(t *testing.T) {
+		resetCfg := flags.Get()
+		flags.Init(&flags.GlobalFlags{MaxPoolVoluntaryExits: 1})
+		defer flags.Init(resetCfg)
+
+		pool := NewPool()
+		old := &ethpb.SignedVoluntaryExit{
+			Exit: &ethpb.VoluntaryExit{
+				ValidatorIndex: types.ValidatorIndex(0),
+			},
+		}
+		exit := &ethpb.SignedVoluntaryExit{
+			Exit: &ethpb.VoluntaryExit{
+				ValidatorIndex: types.ValidatorIndex(1),
+			},
+		}
+		pool.InsertVoluntaryExit(old)
+		pool.InsertVoluntaryExit(exit)
+		require.Equal(t, 1, pool.pending.Len())
+		_, ok := pool.m[0]
+		require.Equal(t, true, ok)
+		_, ok = pool.m[1]
+		require.Equal(t, false, ok)
+	})
 }
 
 func TestMarkIncluded(t *testing.T) {
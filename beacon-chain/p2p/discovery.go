@@ -2,8 +2,10 @@ package p2p
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/discover"
@@ -125,6 +127,56 @@ func (s *Service) listenForNewNodes() {
 	}
 }
 
+// DiscoverPeers runs a discovery round on demand, dialing up to limit of the dialable nodes found in the
+// discovery table before the context is done. It returns the peers which could be connected to. Unlike the
+// background search for peers, it does not stop at the peer limit, as excess peers are pruned later on.
+func (s *Service) DiscoverPeers(ctx context.Context, limit int) ([]peer.ID, error) {
+	if s.dv5Listener == nil {
+		return nil, errors.New("discovery is disabled")
+	}
+	iterator := enode.Filter(s.dv5Listener.RandomNodes(), s.filterPeer)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		// Closing the iterator unblocks a pending call to Next.
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		iterator.Close()
+	}()
+
+	infos := make([]*peer.AddrInfo, 0, limit)
+	for len(infos) < limit && iterator.Next() {
+		info, _, err := convertToAddrInfo(iterator.Node())
+		if err != nil {
+			log.WithError(err).Debug("Could not convert to peer info")
+			continue
+		}
+		s.Peers().RandomizeBackOff(info.ID)
+		infos = append(infos, info)
+	}
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	connected := make([]peer.ID, 0, len(infos))
+	for _, info := range infos {
+		wg.Add(1)
+		go func(info *peer.AddrInfo) {
+			defer wg.Done()
+			if err := s.connectWithPeer(s.ctx, *info); err != nil {
+				log.WithError(err).Tracef("Could not connect with peer %s", info.String())
+				return
+			}
+			lock.Lock()
+			connected = append(connected, info.ID)
+			lock.Unlock()
+		}(info)
+	}
+	wg.Wait()
+	return connected, nil
+}
+
 func (s *Service) createListener(
 	ipAddr net.IP,
 	privKey *ecdsa.PrivateKey,
@@ -9,6 +9,8 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/network/forks"
 	pb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	prysmTime "github.com/prysmaticlabs/prysm/v4/time"
@@ -48,10 +50,11 @@ func (s *Service) compareForkENR(record *enr.Record) error {
 	// and next_fork_epoch that match local values.
 	if !bytes.Equal(peerForkENR.CurrentForkDigest, currentForkENR.CurrentForkDigest) {
 		return fmt.Errorf(
-			"fork digest of peer with ENR %s: %v, does not match local value: %v",
+			"fork digest of peer with ENR %s: %#x, does not match local value: %#x, %s",
 			enrString,
 			peerForkENR.CurrentForkDigest,
 			currentForkENR.CurrentForkDigest,
+			s.describeForkDigest(bytesutil.ToBytes4(peerForkENR.CurrentForkDigest)),
 		)
 	}
 	// Clients MAY connect to peers with the same current_fork_version but a
@@ -64,6 +67,9 @@ func (s *Service) compareForkENR(record *enr.Record) error {
 			"peerNextForkEpoch": peerForkENR.NextForkEpoch,
 			"peerENR":           enrString,
 		}).Trace("Peer matches fork digest but has different next fork epoch")
+		if bytes.Equal(peerForkENR.NextForkVersion, currentForkENR.NextForkVersion) {
+			s.reportForkScheduleMismatch(bytesutil.ToBytes4(peerForkENR.NextForkVersion), peerForkENR.NextForkEpoch, currentForkENR.NextForkEpoch)
+		}
 	}
 	if !bytes.Equal(peerForkENR.NextForkVersion, currentForkENR.NextForkVersion) {
 		log.WithFields(logrus.Fields{
@@ -74,6 +80,41 @@ func (s *Service) compareForkENR(record *enr.Record) error {
 	return nil
 }
 
+// describeForkDigest explains a fork digest which does not match the local one: either it belongs to another
+// fork of the local schedule, in which case the peer and this node disagree on when that fork activates, or
+// the peer is on another network.
+func (s *Service) describeForkDigest(digest [4]byte) string {
+	v, epoch, err := forks.RetrieveForkDataFromDigest(digest, s.genesisValidatorsRoot)
+	if err != nil {
+		return "the peer is on another network or uses other fork versions"
+	}
+	name, ok := params.BeaconConfig().ForkVersionNames[v]
+	if !ok {
+		name = fmt.Sprintf("%#x", v)
+	}
+	return fmt.Sprintf("the peer is on the %s fork, which is scheduled at epoch %d in the local chain config", name, epoch)
+}
+
+// reportForkScheduleMismatch logs an error the first time peers schedule the next fork at another epoch than
+// this node. Such peers are kept, but they will be unable to interact with this node from the earlier of the
+// two epochs, which means the chain config of this node does not match the network.
+func (s *Service) reportForkScheduleMismatch(version [4]byte, peerEpoch, localEpoch primitives.Epoch) {
+	s.forkMismatchLock.Lock()
+	defer s.forkMismatchLock.Unlock()
+	if s.reportedForkMismatches == nil {
+		s.reportedForkMismatches = make(map[[4]byte]primitives.Epoch)
+	}
+	if reported, ok := s.reportedForkMismatches[version]; ok && reported == peerEpoch {
+		return
+	}
+	s.reportedForkMismatches[version] = peerEpoch
+	log.WithFields(logrus.Fields{
+		"forkVersion": fmt.Sprintf("%#x", version),
+		"peerEpoch":   peerEpoch,
+		"localEpoch":  localEpoch,
+	}).Error("Peers schedule the next fork at another epoch, check that the chain config matches the network")
+}
+
 // Adds a fork entry as an ENR record under the Ethereum consensus EnrKey for
 // the local node. The fork entry is an ssz-encoded enrForkID type
 // which takes into account the current fork version from the current
@@ -111,6 +152,11 @@ func addForkEntry(
 	return node, nil
 }
 
+// ENRForkID retrieves the fork ID advertised in an ENR record.
+func ENRForkID(record *enr.Record) (*pb.ENRForkID, error) {
+	return forkEntry(record)
+}
+
 // Retrieves an enrForkID from an ENR record by key lookup
 // under the Ethereum consensus EnrKey
 func forkEntry(record *enr.Record) (*pb.ENRForkID, error) {
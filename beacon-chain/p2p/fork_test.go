@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"os"
 	"path"
@@ -276,3 +277,37 @@ func TestAddForkEntry_Genesis(t *testing.T) {
 		params.BeaconConfig().GenesisForkVersion, forkEntry.NextForkVersion,
 		"Wanted Next Fork Version to be equal to genesis fork version")
 }
+
+func TestDescribeForkDigest(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MainnetConfig().Copy())
+	genesisValidatorsRoot := make([]byte, fieldparams.RootLength)
+	s := &Service{genesisValidatorsRoot: genesisValidatorsRoot}
+
+	digest, err := signing.ComputeForkDigest(params.BeaconConfig().AltairForkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	want := fmt.Sprintf("the peer is on the altair fork, which is scheduled at epoch %d in the local chain config",
+		params.BeaconConfig().AltairForkEpoch)
+	assert.Equal(t, want, s.describeForkDigest(digest))
+	assert.Equal(t, "the peer is on another network or uses other fork versions", s.describeForkDigest([4]byte{1, 2, 3, 4}))
+}
+
+func TestReportForkScheduleMismatch(t *testing.T) {
+	hook := logTest.NewGlobal()
+	s := &Service{}
+	version := [4]byte{'A', 'B', 'C', 'D'}
+	s.reportForkScheduleMismatch(version, 10, 20)
+	s.reportForkScheduleMismatch(version, 10, 20)
+	count := func() int {
+		n := 0
+		for _, e := range hook.AllEntries() {
+			if e.Message == "Peers schedule the next fork at another epoch, check that the chain config matches the network" {
+				n++
+			}
+		}
+		return n
+	}
+	assert.Equal(t, 1, count())
+	s.reportForkScheduleMismatch(version, 11, 20)
+	assert.Equal(t, 2, count())
+}
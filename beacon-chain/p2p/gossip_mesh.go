@@ -0,0 +1,74 @@
+package p2p
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// gossipMesh keeps track of the peers in the gossipsub mesh of each joined topic,
+// as the router does not expose its mesh. It is updated from the events reported to the tracer.
+type gossipMesh struct {
+	sync.RWMutex
+	topics map[string]map[peer.ID]bool
+}
+
+func newGossipMesh() *gossipMesh {
+	return &gossipMesh{topics: make(map[string]map[peer.ID]bool)}
+}
+
+func (m *gossipMesh) join(topic string) {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.topics[topic]; !ok {
+		m.topics[topic] = make(map[peer.ID]bool)
+	}
+}
+
+// leave drops the mesh of the topic, the router does not prune its peers one by one.
+func (m *gossipMesh) leave(topic string) {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.topics, topic)
+}
+
+func (m *gossipMesh) graft(p peer.ID, topic string) {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.topics[topic]; !ok {
+		m.topics[topic] = make(map[peer.ID]bool)
+	}
+	m.topics[topic][p] = true
+}
+
+func (m *gossipMesh) prune(p peer.ID, topic string) {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.topics[topic], p)
+}
+
+// removePeer drops a disconnected peer from every mesh, the router does not report it as pruned.
+func (m *gossipMesh) removePeer(p peer.ID) {
+	m.Lock()
+	defer m.Unlock()
+	for _, peers := range m.topics {
+		delete(peers, p)
+	}
+}
+
+// peers returns the sorted mesh peers of every joined topic.
+func (m *gossipMesh) peers() map[string][]peer.ID {
+	m.RLock()
+	defer m.RUnlock()
+	mesh := make(map[string][]peer.ID, len(m.topics))
+	for topic, peers := range m.topics {
+		ids := make([]peer.ID, 0, len(peers))
+		for p := range peers {
+			ids = append(ids, p)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		mesh[topic] = ids
+	}
+	return mesh
+}
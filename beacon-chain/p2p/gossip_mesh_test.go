@@ -0,0 +1,46 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestGossipMesh(t *testing.T) {
+	m := newGossipMesh()
+	tracer := gossipTracer{mesh: m}
+	a, b, c := peer.ID("a"), peer.ID("b"), peer.ID("c")
+
+	tracer.Join("blocks")
+	tracer.Join("attestations")
+	tracer.Graft(b, "blocks")
+	tracer.Graft(a, "blocks")
+	tracer.Graft(c, "attestations")
+	tracer.Graft(a, "attestations")
+	mesh := m.peers()
+	require.Equal(t, 2, len(mesh))
+	assert.DeepEqual(t, []peer.ID{a, b}, mesh["blocks"])
+	assert.DeepEqual(t, []peer.ID{a, c}, mesh["attestations"])
+
+	tracer.Prune(b, "blocks")
+	tracer.RemovePeer(a)
+	mesh = m.peers()
+	assert.Equal(t, 0, len(mesh["blocks"]))
+	assert.DeepEqual(t, []peer.ID{c}, mesh["attestations"])
+
+	tracer.Leave("attestations")
+	mesh = m.peers()
+	_, ok := mesh["attestations"]
+	assert.Equal(t, false, ok)
+	_, ok = mesh["blocks"]
+	assert.Equal(t, true, ok)
+}
+
+func TestService_DiscoverPeers_DiscoveryDisabled(t *testing.T) {
+	s := &Service{}
+	_, err := s.DiscoverPeers(context.Background(), 10)
+	assert.ErrorContains(t, "discovery is disabled", err)
+}
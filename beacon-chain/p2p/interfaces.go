@@ -87,6 +87,13 @@ type PeerManager interface {
 	AddPingMethod(reqFunc func(ctx context.Context, id peer.ID) error)
 }
 
+// PeerController allows operators to connect to peers, inspect the gossip mesh and search for new peers on demand.
+type PeerController interface {
+	Connect(pi peer.AddrInfo) error
+	GossipMesh() map[string][]peer.ID
+	DiscoverPeers(ctx context.Context, limit int) ([]peer.ID, error)
+}
+
 // Sender abstracts the sending functionality from libp2p.
 type Sender interface {
 	Send(context.Context, interface{}, string, peer.ID) (network.Stream, error)
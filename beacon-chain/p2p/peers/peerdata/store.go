@@ -42,6 +42,7 @@ type Store struct {
 	config       *StoreConfig
 	peers        map[peer.ID]*PeerData
 	trustedPeers map[peer.ID]bool
+	bannedPeers  map[peer.ID]bool
 }
 
 // PeerData aggregates protocol and application level info about a single peer.
@@ -74,6 +75,7 @@ func NewStore(ctx context.Context, config *StoreConfig) *Store {
 		config:       config,
 		peers:        make(map[peer.ID]*PeerData),
 		trustedPeers: make(map[peer.ID]bool),
+		bannedPeers:  make(map[peer.ID]bool),
 	}
 }
 
@@ -133,6 +135,32 @@ func (s *Store) DeleteTrustedPeers(peers []peer.ID) {
 	}
 }
 
+// SetBannedPeers adds peers to the set of peers banned by the operator.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) SetBannedPeers(peers []peer.ID) {
+	for _, p := range peers {
+		s.bannedPeers[p] = true
+	}
+}
+
+// GetBannedPeers gets the ids of the peers banned by the operator.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) GetBannedPeers() []peer.ID {
+	peers := []peer.ID{}
+	for p := range s.bannedPeers {
+		peers = append(peers, p)
+	}
+	return peers
+}
+
+// DeleteBannedPeers removes peers from the banned peer set.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) DeleteBannedPeers(peers []peer.ID) {
+	for _, p := range peers {
+		delete(s.bannedPeers, p)
+	}
+}
+
 // Peers returns map of peer data objects.
 // Important: it is assumed that store mutex is locked when calling this method.
 func (s *Store) Peers() map[peer.ID]*PeerData {
@@ -145,6 +173,12 @@ func (s *Store) IsTrustedPeer(p peer.ID) bool {
 	return s.trustedPeers[p]
 }
 
+// IsBannedPeer checks that the provided peer
+// is in our banned peer set.
+func (s *Store) IsBannedPeer(p peer.ID) bool {
+	return s.bannedPeers[p]
+}
+
 // Config exposes store configuration params.
 func (s *Store) Config() *StoreConfig {
 	return s.config
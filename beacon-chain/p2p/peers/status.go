@@ -335,6 +335,9 @@ func (p *Status) IsBad(pid peer.ID) bool {
 
 // isBad is the lock-free version of IsBad.
 func (p *Status) isBad(pid peer.ID) bool {
+	if p.store.IsBannedPeer(pid) {
+		return true
+	}
 	// Do not disconnect from trusted peers.
 	if p.store.IsTrustedPeer(pid) {
 		return false
@@ -946,6 +949,36 @@ func (p *Status) isTrustedPeers(pid peer.ID) bool {
 	return p.store.IsTrustedPeer(pid)
 }
 
+// BanPeers marks peers as bad until they are unbanned, regardless of their score.
+// Banned peers are no longer trusted.
+func (p *Status) BanPeers(peers []peer.ID) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.store.DeleteTrustedPeers(peers)
+	p.store.SetBannedPeers(peers)
+}
+
+// UnbanPeers removes peers from the banned peer set.
+func (p *Status) UnbanPeers(peers []peer.ID) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.store.DeleteBannedPeers(peers)
+}
+
+// GetBannedPeers returns a list of all banned peers' ids.
+func (p *Status) GetBannedPeers() []peer.ID {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.store.GetBannedPeers()
+}
+
+// IsBanned returns if given peer is banned.
+func (p *Status) IsBanned(pid peer.ID) bool {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.store.IsBannedPeer(pid)
+}
+
 // this method assumes the store lock is acquired before
 // executing the method.
 func (p *Status) isfromBadIP(pid peer.ID) bool {
@@ -379,6 +379,36 @@ func TestPeerBadResponses(t *testing.T) {
 	assert.Equal(t, true, p.IsBad(id), "Peer not marked as bad when it should be")
 }
 
+func TestBannedPeers(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold: 2,
+			},
+		},
+	})
+
+	id, err := peer.Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	require.NoError(t, err)
+	address, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err, "Failed to create address")
+	p.Add(new(enr.Record), id, address, network.DirInbound)
+	p.SetTrustedPeers([]peer.ID{id})
+	assert.Equal(t, false, p.IsBad(id), "Peer marked as bad when should be good")
+
+	p.BanPeers([]peer.ID{id})
+	assert.Equal(t, true, p.IsBanned(id))
+	assert.Equal(t, true, p.IsBad(id), "Banned peer not marked as bad")
+	assert.Equal(t, false, p.IsTrustedPeers(id), "Banned peer still trusted")
+	assert.DeepEqual(t, []peer.ID{id}, p.GetBannedPeers())
+
+	p.UnbanPeers([]peer.ID{id})
+	assert.Equal(t, false, p.IsBanned(id))
+	assert.Equal(t, false, p.IsBad(id), "Unbanned peer marked as bad")
+	assert.Equal(t, 0, len(p.GetBannedPeers()))
+}
+
 func TestAddMetaData(t *testing.T) {
 	maxBadResponses := 2
 	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
@@ -14,6 +14,7 @@ var _ = pubsub.RawTracer(gossipTracer{})
 // and broadcasted through gossipsub.
 type gossipTracer struct {
 	host host.Host
+	mesh *gossipMesh
 }
 
 // AddPeer .
@@ -23,27 +24,31 @@ func (g gossipTracer) AddPeer(p peer.ID, proto protocol.ID) {
 
 // RemovePeer .
 func (g gossipTracer) RemovePeer(p peer.ID) {
-	// no-op
+	g.mesh.removePeer(p)
 }
 
 // Join .
 func (g gossipTracer) Join(topic string) {
 	pubsubTopicsActive.WithLabelValues(topic).Set(1)
+	g.mesh.join(topic)
 }
 
 // Leave .
 func (g gossipTracer) Leave(topic string) {
 	pubsubTopicsActive.WithLabelValues(topic).Set(0)
+	g.mesh.leave(topic)
 }
 
 // Graft .
 func (g gossipTracer) Graft(p peer.ID, topic string) {
 	pubsubTopicsGraft.WithLabelValues(topic).Inc()
+	g.mesh.graft(p, topic)
 }
 
 // Prune .
 func (g gossipTracer) Prune(p peer.ID, topic string) {
 	pubsubTopicsPrune.WithLabelValues(topic).Inc()
+	g.mesh.prune(p, topic)
 }
 
 // ValidateMessage .
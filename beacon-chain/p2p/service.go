@@ -25,6 +25,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers/scorers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	leakybucket "github.com/prysmaticlabs/prysm/v4/container/leaky-bucket"
 	prysmnetwork "github.com/prysmaticlabs/prysm/v4/network"
 	"github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/metadata"
@@ -68,6 +69,7 @@ type Service struct {
 	privKey               *ecdsa.PrivateKey
 	metaData              metadata.Metadata
 	pubsub                *pubsub.PubSub
+	mesh                  *gossipMesh
 	joinedTopics          map[string]*pubsub.Topic
 	joinedTopicsLock      sync.Mutex
 	subnetsLock           map[uint64]*sync.RWMutex
@@ -80,6 +82,9 @@ type Service struct {
 	genesisTime           time.Time
 	genesisValidatorsRoot []byte
 	activeValidatorCount  uint64
+	// reportedForkMismatches holds the next fork epochs of peers which differ from the local one, by fork version.
+	reportedForkMismatches map[[4]byte]primitives.Epoch
+	forkMismatchLock       sync.Mutex
 }
 
 // NewService initializes a new p2p service compatible with shared.Service interface. No
@@ -94,6 +99,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		cancel:       cancel,
 		cfg:          cfg,
 		isPreGenesis: true,
+		mesh:         newGossipMesh(),
 		joinedTopics: make(map[string]*pubsub.Topic, len(gossipTopicMappings)),
 		subnetsLock:  make(map[uint64]*sync.RWMutex),
 	}
@@ -326,6 +332,11 @@ func (s *Service) Connect(pi peer.AddrInfo) error {
 	return s.host.Connect(s.ctx, pi)
 }
 
+// GossipMesh returns the peers in the gossipsub mesh of each joined topic.
+func (s *Service) GossipMesh() map[string][]peer.ID {
+	return s.mesh.peers()
+}
+
 // Peers returns the peer status interface.
 func (s *Service) Peers() *peers.Status {
 	return s.peers
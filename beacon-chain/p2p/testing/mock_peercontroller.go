@@ -0,0 +1,38 @@
+package testing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// MockPeerController is mock of the PeerController interface.
+type MockPeerController struct {
+	Connected   []peer.AddrInfo
+	Mesh        map[string][]peer.ID
+	Discovered  []peer.ID
+	FailConnect bool
+}
+
+// Connect .
+func (m *MockPeerController) Connect(pi peer.AddrInfo) error {
+	if m.FailConnect {
+		return errors.New("failed to connect")
+	}
+	m.Connected = append(m.Connected, pi)
+	return nil
+}
+
+// GossipMesh .
+func (m *MockPeerController) GossipMesh() map[string][]peer.ID {
+	return m.Mesh
+}
+
+// DiscoverPeers .
+func (m *MockPeerController) DiscoverPeers(_ context.Context, limit int) ([]peer.ID, error) {
+	if len(m.Discovered) > limit {
+		return m.Discovered[:limit], nil
+	}
+	return m.Discovered, nil
+}
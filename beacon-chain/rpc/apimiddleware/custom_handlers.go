@@ -164,7 +164,7 @@ func handleGetSSZ(
 }
 
 func handlePostSSZ(m *apimiddleware.ApiProxyMiddleware, endpoint apimiddleware.Endpoint, w http.ResponseWriter, req *http.Request) (handled bool) {
-	if !sszPosted(req) {
+	if !http2.SszPosted(req) {
 		return false
 	}
 
@@ -204,17 +204,6 @@ func handlePostSSZ(m *apimiddleware.ApiProxyMiddleware, endpoint apimiddleware.E
 	return true
 }
 
-func sszPosted(req *http.Request) bool {
-	ct, ok := req.Header["Content-Type"]
-	if !ok {
-		return false
-	}
-	if len(ct) != 1 {
-		return false
-	}
-	return ct[0] == api.OctetStreamMediaType
-}
-
 func prepareSSZRequestForProxying(m *apimiddleware.ApiProxyMiddleware, endpoint apimiddleware.Endpoint, req *http.Request) apimiddleware.ErrorJson {
 	req.URL.Scheme = "http"
 	req.URL.Host = m.GatewayAddress
@@ -383,6 +372,8 @@ func receiveEvents(eventChan <-chan *sse.Event, w http.ResponseWriter, req *http
 					return apimiddleware.InternalServerError(err)
 				}
 				switch dataSubset.Version {
+				case version.String(version.Deneb):
+					data = &EventPayloadAttributeStreamV3Json{}
 				case version.String(version.Capella):
 					data = &EventPayloadAttributeStreamV2Json{}
 				case version.String(version.Bellatrix):
@@ -750,6 +750,7 @@ func prepareForkChoiceResponse(response interface{}) (apimiddleware.RunDefault,
 				Balance:                  n.Balance,
 				ExecutionOptimistic:      n.ExecutionOptimistic,
 				TimeStamp:                n.TimeStamp,
+				ProposerBoost:            n.BlockRoot == dump.ProposerBoostRoot,
 			},
 		}
 	}
@@ -1157,7 +1157,7 @@ func TestPrepareForkChoiceResponse(t *testing.T) {
 			Epoch: "unrealized_finalized",
 			Root:  "unrealized_finalized",
 		},
-		ProposerBoostRoot:         "proposer_boost_root",
+		ProposerBoostRoot:         "node2_block_root",
 		PreviousProposerBoostRoot: "previous_proposer_boost_root",
 		HeadRoot:                  "head_root",
 		ForkChoiceNodes: []*ForkChoiceNodeJson{
@@ -1209,7 +1209,7 @@ func TestPrepareForkChoiceResponse(t *testing.T) {
 	assert.Equal(t, "unrealized_justified", result.ExtraData.UnrealizedJustifiedCheckpoint.Root)
 	assert.Equal(t, "unrealized_finalized", result.ExtraData.UnrealizedFinalizedCheckpoint.Epoch)
 	assert.Equal(t, "unrealized_finalized", result.ExtraData.UnrealizedFinalizedCheckpoint.Root)
-	assert.Equal(t, "proposer_boost_root", result.ExtraData.ProposerBoostRoot)
+	assert.Equal(t, "node2_block_root", result.ExtraData.ProposerBoostRoot)
 	assert.Equal(t, "previous_proposer_boost_root", result.ExtraData.PreviousProposerBoostRoot)
 	assert.Equal(t, "head_root", result.ExtraData.HeadRoot)
 	require.Equal(t, 2, len(result.ForkChoiceNodes))
@@ -1228,6 +1228,7 @@ func TestPrepareForkChoiceResponse(t *testing.T) {
 	assert.Equal(t, "node1_execution_block_hash", node1.ExecutionBlockHash)
 	assert.Equal(t, "node1_time_stamp", node1.ExtraData.TimeStamp)
 	assert.Equal(t, "node1_validity", node1.Validity)
+	assert.Equal(t, false, node1.ExtraData.ProposerBoost)
 	node2 := result.ForkChoiceNodes[1]
 	require.NotNil(t, node2)
 	assert.Equal(t, "node2_slot", node2.Slot)
@@ -1243,4 +1244,5 @@ func TestPrepareForkChoiceResponse(t *testing.T) {
 	assert.Equal(t, "node2_execution_block_hash", node2.ExecutionBlockHash)
 	assert.Equal(t, "node2_time_stamp", node2.ExtraData.TimeStamp)
 	assert.Equal(t, "node2_validity", node2.Validity)
+	assert.Equal(t, true, node2.ExtraData.ProposerBoost)
 }
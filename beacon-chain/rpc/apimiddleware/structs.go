@@ -87,10 +87,6 @@ type BLSToExecutionChangesPoolResponseJson struct {
 	Data []*SignedBLSToExecutionChangeJson `json:"data"`
 }
 
-type IdentityResponseJson struct {
-	Data *IdentityJson `json:"data"`
-}
-
 type PeersResponseJson struct {
 	Data []*PeerJson `json:"data"`
 }
@@ -197,6 +193,7 @@ type ForkChoiceNodeExtraDataJson struct {
 	Balance                  string `json:"balance"`
 	ExecutionOptimistic      bool   `json:"execution_optimistic"`
 	TimeStamp                string `json:"timestamp"`
+	ProposerBoost            bool   `json:"proposer_boost"`
 }
 
 type ForkChoiceResponseJson struct {
@@ -734,19 +731,6 @@ type VoluntaryExitJson struct {
 	ValidatorIndex string `json:"validator_index"`
 }
 
-type IdentityJson struct {
-	PeerId             string        `json:"peer_id"`
-	Enr                string        `json:"enr"`
-	P2PAddresses       []string      `json:"p2p_addresses"`
-	DiscoveryAddresses []string      `json:"discovery_addresses"`
-	Metadata           *MetadataJson `json:"metadata"`
-}
-
-type MetadataJson struct {
-	SeqNumber string `json:"seq_number"`
-	Attnets   string `json:"attnets" hex:"true"`
-}
-
 type PeerJson struct {
 	PeerId    string `json:"peer_id"`
 	Enr       string `json:"enr"`
@@ -1145,6 +1129,11 @@ type EventPayloadAttributeStreamV2Json struct {
 	Data    *EventPayloadAttributeV2Json `json:"data"`
 }
 
+type EventPayloadAttributeStreamV3Json struct {
+	Version string                       `json:"version"`
+	Data    *EventPayloadAttributeV3Json `json:"data"`
+}
+
 type EventPayloadAttributeV1Json struct {
 	ProposerIndex     string                   `json:"proposer_index"`
 	ProposalSlot      string                   `json:"proposal_slot"`
@@ -1163,6 +1152,15 @@ type EventPayloadAttributeV2Json struct {
 	PayloadAttributes *PayloadAttributesV2Json `json:"payload_attributes"`
 }
 
+type EventPayloadAttributeV3Json struct {
+	ProposerIndex     string                   `json:"proposer_index"`
+	ProposalSlot      string                   `json:"proposal_slot"`
+	ParentBlockNumber string                   `json:"parent_block_number"`
+	ParentBlockRoot   string                   `json:"parent_block_root" hex:"true"`
+	ParentBlockHash   string                   `json:"parent_block_hash" hex:"true"`
+	PayloadAttributes *PayloadAttributesV3Json `json:"payload_attributes"`
+}
+
 type PayloadAttributesV1Json struct {
 	Timestamp             string `json:"timestamp"`
 	Random                string `json:"prev_randao" hex:"true"`
@@ -1176,6 +1174,14 @@ type PayloadAttributesV2Json struct {
 	Withdrawals           []*WithdrawalJson `json:"withdrawals"`
 }
 
+type PayloadAttributesV3Json struct {
+	Timestamp             string            `json:"timestamp"`
+	Random                string            `json:"prev_randao" hex:"true"`
+	SuggestedFeeRecipient string            `json:"suggested_fee_recipient" hex:"true"`
+	Withdrawals           []*WithdrawalJson `json:"withdrawals"`
+	ParentBeaconBlockRoot string            `json:"parent_beacon_block_root" hex:"true"`
+}
+
 // ---------------
 // Error handling.
 // ---------------
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// HealthThresholds are the optional criteria, on top of the sync status, which a node must meet to be
+// reported as healthy. Zero values disable a criterion.
+type HealthThresholds struct {
+	// SyncingStatus is the HTTP status code returned while the node is syncing.
+	SyncingStatus int
+	// MaxHeadLag is the maximum number of slots between the head and the wall clock.
+	MaxHeadLag primitives.Slot
+	// MaxFinalityLag is the maximum number of epochs between the finalized checkpoint and the wall clock.
+	MaxFinalityLag primitives.Epoch
+	// MinPeers is the minimum number of connected peers.
+	MinPeers int
+	// RequireExecution requires the execution client to be connected.
+	RequireExecution bool
+	// RejectOptimistic requires the head to be fully verified by the execution client.
+	RejectOptimistic bool
+}
+
+// HealthThresholdsFromRequest reads the syncing_status, max_head_lag, max_finality_lag, min_peers,
+// require_execution and reject_optimistic query parameters of the request.
+func HealthThresholdsFromRequest(r *http.Request) (*HealthThresholds, error) {
+	q := r.URL.Query()
+	t := &HealthThresholds{SyncingStatus: http.StatusPartialContent}
+	if v := q.Get("syncing_status"); v != "" {
+		code, err := strconv.Atoi(v)
+		if err != nil || code < 100 || code > 599 {
+			return nil, fmt.Errorf("invalid syncing_status %s", v)
+		}
+		t.SyncingStatus = code
+	}
+	if v := q.Get("max_head_lag"); v != "" {
+		lag, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid max_head_lag")
+		}
+		t.MaxHeadLag = primitives.Slot(lag)
+	}
+	if v := q.Get("max_finality_lag"); v != "" {
+		lag, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid max_finality_lag")
+		}
+		t.MaxFinalityLag = primitives.Epoch(lag)
+	}
+	if v := q.Get("min_peers"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid min_peers %s", v)
+		}
+		t.MinPeers = n
+	}
+	if v := q.Get("require_execution"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid require_execution")
+		}
+		t.RequireExecution = b
+	}
+	if v := q.Get("reject_optimistic"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid reject_optimistic")
+		}
+		t.RejectOptimistic = b
+	}
+	return t, nil
+}
+
+// NodeHealth summarizes the sync status, peers, finality and execution client connectivity of the node.
+type NodeHealth struct {
+	Initialized        bool
+	Syncing            bool
+	Synced             bool
+	Optimistic         bool
+	HeadSlot           primitives.Slot
+	CurrentSlot        primitives.Slot
+	HeadLag            primitives.Slot
+	FinalizedEpoch     primitives.Epoch
+	FinalityLag        primitives.Epoch
+	InboundPeers       int
+	OutboundPeers      int
+	ExecutionConnected bool
+	ExecutionError     string
+	// Issues lists the health criteria which the node does not meet.
+	Issues []string
+}
+
+// Healthy returns true when the node meets every requested health criterion.
+func (h *NodeHealth) Healthy() bool {
+	return len(h.Issues) == 0
+}
+
+// StatusCode returns the HTTP status code of the health: 503 when the node does not meet a criterion, 200
+// when it is synced and the syncing status of the thresholds, 206 by default, when it is syncing.
+func (h *NodeHealth) StatusCode(t *HealthThresholds) int {
+	if !h.Healthy() {
+		return http.StatusServiceUnavailable
+	}
+	if h.Synced {
+		return http.StatusOK
+	}
+	if t == nil || t.SyncingStatus == 0 {
+		return http.StatusPartialContent
+	}
+	return t.SyncingStatus
+}
+
+// NodeHealth computes the health of the node against the given thresholds.
+func (s *Service) NodeHealth(ctx context.Context, t *HealthThresholds) (*NodeHealth, *RpcError) {
+	h := &NodeHealth{
+		Initialized: s.SyncChecker.Initialized(),
+		Syncing:     s.SyncChecker.Syncing(),
+		Synced:      s.SyncChecker.Synced(),
+		HeadSlot:    s.HeadFetcher.HeadSlot(),
+		CurrentSlot: s.GenesisTimeFetcher.CurrentSlot(),
+	}
+	if h.CurrentSlot > h.HeadSlot {
+		h.HeadLag = h.CurrentSlot - h.HeadSlot
+	}
+	if s.FinalizationFetcher != nil {
+		if cp := s.FinalizationFetcher.FinalizedCheckpt(); cp != nil {
+			h.FinalizedEpoch = cp.Epoch
+		}
+		if current := slots.ToEpoch(h.CurrentSlot); current > h.FinalizedEpoch {
+			h.FinalityLag = current - h.FinalizedEpoch
+		}
+	}
+	if s.OptimisticModeFetcher != nil {
+		optimistic, err := s.OptimisticModeFetcher.IsOptimistic(ctx)
+		if err != nil {
+			return nil, &RpcError{Err: errors.Wrap(err, "could not check optimistic status"), Reason: Unavailable}
+		}
+		h.Optimistic = optimistic
+	}
+	if s.PeersFetcher != nil {
+		h.InboundPeers = len(s.PeersFetcher.Peers().InboundConnected())
+		h.OutboundPeers = len(s.PeersFetcher.Peers().OutboundConnected())
+	}
+	if s.ExecutionChainInfoFetcher != nil {
+		h.ExecutionConnected = s.ExecutionChainInfoFetcher.ExecutionClientConnected()
+		if err := s.ExecutionChainInfoFetcher.ExecutionClientConnectionErr(); err != nil {
+			h.ExecutionError = err.Error()
+		}
+	}
+
+	if !h.Initialized && !h.Syncing && !h.Synced {
+		h.Issues = append(h.Issues, "node not initialized")
+	}
+	if t == nil {
+		return h, nil
+	}
+	if t.MaxHeadLag > 0 && h.HeadLag > t.MaxHeadLag {
+		h.Issues = append(h.Issues, fmt.Sprintf("head is %d slots behind the wall clock", h.HeadLag))
+	}
+	if t.MaxFinalityLag > 0 && h.FinalityLag > t.MaxFinalityLag {
+		h.Issues = append(h.Issues, fmt.Sprintf("finalized checkpoint is %d epochs behind the wall clock", h.FinalityLag))
+	}
+	if t.MinPeers > 0 && h.InboundPeers+h.OutboundPeers < t.MinPeers {
+		h.Issues = append(h.Issues, fmt.Sprintf("%d connected peers", h.InboundPeers+h.OutboundPeers))
+	}
+	if t.RequireExecution && !h.ExecutionConnected {
+		h.Issues = append(h.Issues, "execution client not connected")
+	}
+	if t.RejectOptimistic && h.Optimistic {
+		h.Issues = append(h.Issues, "head is optimistic")
+	}
+	return h, nil
+}
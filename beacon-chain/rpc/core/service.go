@@ -4,6 +4,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
 	opfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
@@ -11,13 +12,17 @@ import (
 )
 
 type Service struct {
-	HeadFetcher        blockchain.HeadFetcher
-	GenesisTimeFetcher blockchain.TimeFetcher
-	SyncChecker        sync.Checker
-	Broadcaster        p2p.Broadcaster
-	SyncCommitteePool  synccommittee.Pool
-	OperationNotifier  opfeed.Notifier
-	AttestationCache   *cache.AttestationCache
-	StateGen           stategen.StateManager
-	P2P                p2p.Broadcaster
+	HeadFetcher               blockchain.HeadFetcher
+	GenesisTimeFetcher        blockchain.TimeFetcher
+	SyncChecker               sync.Checker
+	Broadcaster               p2p.Broadcaster
+	SyncCommitteePool         synccommittee.Pool
+	OperationNotifier         opfeed.Notifier
+	AttestationCache          *cache.AttestationCache
+	StateGen                  stategen.StateManager
+	P2P                       p2p.Broadcaster
+	FinalizationFetcher       blockchain.FinalizationFetcher
+	OptimisticModeFetcher     blockchain.OptimisticModeFetcher
+	PeersFetcher              p2p.PeersProvider
+	ExecutionChainInfoFetcher execution.ChainInfoFetcher
 }
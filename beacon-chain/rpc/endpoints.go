@@ -0,0 +1,135 @@
+package rpc
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/gorilla/mux"
+)
+
+type endpoint struct {
+	method string
+	path   string
+}
+
+// standardEndpoints are the routes of the Beacon API specification, https://ethereum.github.io/beacon-APIs/,
+// as of v2.4.2, the version of @beacon_api_spec. Deprecated routes are left out. TestStandardEndpoints_MatchSpec
+// checks the list against the specification.
+var standardEndpoints = []endpoint{
+	{http.MethodGet, "/eth/v1/beacon/genesis"},
+	{http.MethodGet, "/eth/v1/beacon/states/{state_id}/root"},
+	{http.MethodGet, "/eth/v1/beacon/states/{state_id}/fork"},
+	{http.MethodGet, "/eth/v1/beacon/states/{state_id}/finality_checkpoints"},
+	{http.MethodGet, "/eth/v1/beacon/states/{state_id}/validators"},
+	{http.MethodPost, "/eth/v1/beacon/states/{state_id}/validators"},
+	{http.MethodGet, "/eth/v1/beacon/states/{state_id}/validators/{validator_id}"},
+	{http.MethodGet, "/eth/v1/beacon/states/{state_id}/validator_balances"},
+	{http.MethodPost, "/eth/v1/beacon/states/{state_id}/validator_balances"},
+	{http.MethodGet, "/eth/v1/beacon/states/{state_id}/committees"},
+	{http.MethodGet, "/eth/v1/beacon/states/{state_id}/sync_committees"},
+	{http.MethodGet, "/eth/v1/beacon/states/{state_id}/randao"},
+	{http.MethodGet, "/eth/v1/beacon/headers"},
+	{http.MethodGet, "/eth/v1/beacon/headers/{block_id}"},
+	{http.MethodPost, "/eth/v1/beacon/blinded_blocks"},
+	{http.MethodPost, "/eth/v2/beacon/blinded_blocks"},
+	{http.MethodPost, "/eth/v1/beacon/blocks"},
+	{http.MethodPost, "/eth/v2/beacon/blocks"},
+	{http.MethodGet, "/eth/v2/beacon/blocks/{block_id}"},
+	{http.MethodGet, "/eth/v1/beacon/blocks/{block_id}/root"},
+	{http.MethodGet, "/eth/v1/beacon/blocks/{block_id}/attestations"},
+	{http.MethodGet, "/eth/v1/beacon/blob_sidecars/{block_id}"},
+	{http.MethodPost, "/eth/v1/beacon/rewards/sync_committee/{block_id}"},
+	{http.MethodGet, "/eth/v1/beacon/deposit_snapshot"},
+	{http.MethodGet, "/eth/v1/beacon/rewards/blocks/{block_id}"},
+	{http.MethodPost, "/eth/v1/beacon/rewards/attestations/{epoch}"},
+	{http.MethodGet, "/eth/v1/beacon/blinded_blocks/{block_id}"},
+	{http.MethodGet, "/eth/v1/beacon/light_client/bootstrap/{block_root}"},
+	{http.MethodGet, "/eth/v1/beacon/light_client/updates"},
+	{http.MethodGet, "/eth/v1/beacon/light_client/finality_update"},
+	{http.MethodGet, "/eth/v1/beacon/light_client/optimistic_update"},
+	{http.MethodGet, "/eth/v1/beacon/pool/attestations"},
+	{http.MethodPost, "/eth/v1/beacon/pool/attestations"},
+	{http.MethodGet, "/eth/v1/beacon/pool/attester_slashings"},
+	{http.MethodPost, "/eth/v1/beacon/pool/attester_slashings"},
+	{http.MethodGet, "/eth/v1/beacon/pool/proposer_slashings"},
+	{http.MethodPost, "/eth/v1/beacon/pool/proposer_slashings"},
+	{http.MethodPost, "/eth/v1/beacon/pool/sync_committees"},
+	{http.MethodGet, "/eth/v1/beacon/pool/voluntary_exits"},
+	{http.MethodPost, "/eth/v1/beacon/pool/voluntary_exits"},
+	{http.MethodGet, "/eth/v1/beacon/pool/bls_to_execution_changes"},
+	{http.MethodPost, "/eth/v1/beacon/pool/bls_to_execution_changes"},
+	{http.MethodGet, "/eth/v1/builder/states/{state_id}/expected_withdrawals"},
+	{http.MethodGet, "/eth/v1/config/fork_schedule"},
+	{http.MethodGet, "/eth/v1/config/spec"},
+	{http.MethodGet, "/eth/v1/config/deposit_contract"},
+	{http.MethodGet, "/eth/v2/debug/beacon/states/{state_id}"},
+	{http.MethodGet, "/eth/v2/debug/beacon/heads"},
+	{http.MethodGet, "/eth/v1/debug/fork_choice"},
+	{http.MethodGet, "/eth/v1/events"},
+	{http.MethodGet, "/eth/v1/node/identity"},
+	{http.MethodGet, "/eth/v1/node/peers"},
+	{http.MethodGet, "/eth/v1/node/peers/{peer_id}"},
+	{http.MethodGet, "/eth/v1/node/peer_count"},
+	{http.MethodGet, "/eth/v1/node/version"},
+	{http.MethodGet, "/eth/v1/node/syncing"},
+	{http.MethodGet, "/eth/v1/node/health"},
+	{http.MethodPost, "/eth/v1/validator/duties/attester/{epoch}"},
+	{http.MethodGet, "/eth/v1/validator/duties/proposer/{epoch}"},
+	{http.MethodPost, "/eth/v1/validator/duties/sync/{epoch}"},
+	{http.MethodGet, "/eth/v2/validator/blocks/{slot}"},
+	{http.MethodGet, "/eth/v3/validator/blocks/{slot}"},
+	{http.MethodGet, "/eth/v1/validator/blinded_blocks/{slot}"},
+	{http.MethodGet, "/eth/v1/validator/attestation_data"},
+	{http.MethodGet, "/eth/v1/validator/aggregate_attestation"},
+	{http.MethodPost, "/eth/v1/validator/aggregate_and_proofs"},
+	{http.MethodPost, "/eth/v1/validator/beacon_committee_subscriptions"},
+	{http.MethodPost, "/eth/v1/validator/sync_committee_subscriptions"},
+	{http.MethodPost, "/eth/v1/validator/beacon_committee_selections"},
+	{http.MethodGet, "/eth/v1/validator/sync_committee_contribution"},
+	{http.MethodPost, "/eth/v1/validator/sync_committee_selections"},
+	{http.MethodPost, "/eth/v1/validator/contribution_and_proofs"},
+	{http.MethodPost, "/eth/v1/validator/prepare_beacon_proposer"},
+	{http.MethodPost, "/eth/v1/validator/register_validator"},
+	{http.MethodPost, "/eth/v1/validator/liveness/{epoch}"},
+}
+
+var pathParam = regexp.MustCompile(`{[^}]*}`)
+
+// UnimplementedStandardEndpoints walks the routes registered on the router, and returns the routes of the
+// Beacon API specification none of them serves, formatted as "METHOD path". Path parameters are matched
+// regardless of their names, and a route registered without methods, such as the routes of the API
+// middleware, serves every method.
+func UnimplementedStandardEndpoints(router *mux.Router) ([]string, error) {
+	served := make(map[endpoint]bool)
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			// The route does not match on its path.
+			return nil
+		}
+		path := pathParam.ReplaceAllString(tpl, "{}")
+		methods, err := route.GetMethods()
+		if err != nil {
+			served[endpoint{path: path}] = true
+			return nil
+		}
+		for _, m := range methods {
+			served[endpoint{method: m, path: path}] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, e := range standardEndpoints {
+		path := pathParam.ReplaceAllString(e.path, "{}")
+		if !served[endpoint{path: path}] && !served[endpoint{method: e.method, path: path}] {
+			missing = append(missing, e.method+" "+e.path)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
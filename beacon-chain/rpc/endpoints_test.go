@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bazelbuild/rules_go/go/tools/bazel"
+	"github.com/gorilla/mux"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	mockExecution "github.com/prysmaticlabs/prysm/v4/beacon-chain/execution/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	mockSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestUnimplementedStandardEndpoints(t *testing.T) {
+	h := func(http.ResponseWriter, *http.Request) {}
+	router := mux.NewRouter()
+	// Served regardless of the name of the path parameter.
+	router.HandleFunc("/eth/v1/beacon/headers/{id}", h).Methods(http.MethodGet)
+	// Served for the registered method only.
+	router.HandleFunc("/eth/v1/beacon/pool/attestations", h).Methods(http.MethodGet)
+	// Served for every method.
+	router.HandleFunc("/eth/v1/beacon/pool/voluntary_exits", h)
+	router.PathPrefix("/eth/v1/").HandlerFunc(h)
+
+	missing, err := UnimplementedStandardEndpoints(router)
+	require.NoError(t, err)
+	assert.Equal(t, len(standardEndpoints)-4, len(missing))
+	missingSet := make(map[string]bool, len(missing))
+	for _, m := range missing {
+		missingSet[m] = true
+	}
+	assert.Equal(t, false, missingSet["GET /eth/v1/beacon/headers/{block_id}"])
+	assert.Equal(t, false, missingSet["GET /eth/v1/beacon/pool/attestations"])
+	assert.Equal(t, true, missingSet["POST /eth/v1/beacon/pool/attestations"])
+	assert.Equal(t, false, missingSet["GET /eth/v1/beacon/pool/voluntary_exits"])
+	assert.Equal(t, false, missingSet["POST /eth/v1/beacon/pool/voluntary_exits"])
+	assert.Equal(t, true, missingSet["GET /eth/v1/node/version"])
+}
+
+func TestStandardEndpointsImplemented(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableLightClient: true})
+	defer resetCfg()
+
+	chainService := &mock.ChainService{
+		Genesis: time.Now(),
+	}
+	router := mux.NewRouter()
+	rpcService := NewService(context.Background(), &Config{
+		Port:                  "7349",
+		SyncService:           &mockSync.Sync{IsSyncing: false},
+		BlockReceiver:         chainService,
+		AttestationReceiver:   chainService,
+		HeadFetcher:           chainService,
+		GenesisTimeFetcher:    chainService,
+		ExecutionChainService: &mockExecution.Chain{},
+		StateNotifier:         chainService.StateNotifier(),
+		Router:                router,
+	})
+	rpcService.Start()
+	defer func() {
+		assert.NoError(t, rpcService.Stop())
+	}()
+	// The API middleware serves the endpoints still implemented in gRPC.
+	for _, p := range (&apimiddleware.BeaconEndpointFactory{}).Paths() {
+		router.HandleFunc(p, func(http.ResponseWriter, *http.Request) {})
+	}
+
+	missing, err := UnimplementedStandardEndpoints(router)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string(nil), missing)
+}
+
+func TestStandardEndpoints_MatchSpec(t *testing.T) {
+	specDir, err := bazel.Runfile("external/beacon_api_spec")
+	require.NoError(t, err)
+	spec := specEndpoints(t, specDir)
+	inSpec := make(map[endpoint]bool, len(spec))
+	for e := range spec {
+		inSpec[withoutParamNames(e)] = true
+	}
+
+	listed := make(map[endpoint]bool, len(standardEndpoints))
+	for _, e := range standardEndpoints {
+		listed[withoutParamNames(e)] = true
+		if !inSpec[withoutParamNames(e)] {
+			t.Errorf("%s %s is not a route of the specification", e.method, e.path)
+		}
+	}
+	for e, deprecated := range spec {
+		if !deprecated && !listed[withoutParamNames(e)] {
+			t.Errorf("%s %s of the specification is missing from standardEndpoints", e.method, e.path)
+		}
+	}
+}
+
+// withoutParamNames strips the names of the path parameters of the endpoint, which the specification may name
+// differently in different versions.
+func withoutParamNames(e endpoint) endpoint {
+	return endpoint{method: e.method, path: pathParam.ReplaceAllString(e.path, "{}")}
+}
+
+// specEndpoints returns the routes of the Beacon API OpenAPI specification in the given directory, and whether
+// they are deprecated.
+func specEndpoints(t *testing.T, specDir string) map[endpoint]bool {
+	var oapi struct {
+		Paths map[string]map[string]interface{} `yaml:"paths"`
+	}
+	enc, err := os.ReadFile(filepath.Join(specDir, "beacon-node-oapi.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(enc, &oapi))
+	require.NotEqual(t, 0, len(oapi.Paths))
+
+	endpoints := make(map[endpoint]bool)
+	for path, item := range oapi.Paths {
+		// Path items are defined in their own file.
+		if ref, ok := item["$ref"].(string); ok {
+			enc, err := os.ReadFile(filepath.Join(specDir, strings.Split(ref, "#")[0]))
+			require.NoError(t, err)
+			item = make(map[string]interface{})
+			require.NoError(t, yaml.Unmarshal(enc, &item))
+		}
+		for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete} {
+			op, ok := item[strings.ToLower(method)].(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			deprecated, _ := op["deprecated"].(bool)
+			endpoints[endpoint{method: method, path: path}] = deprecated
+		}
+	}
+	return endpoints
+}
@@ -106,6 +106,8 @@ func TestGetSpec(t *testing.T) {
 	config.MaxWithdrawalsPerPayload = 74
 	config.MaxBlsToExecutionChanges = 75
 	config.MaxValidatorsPerWithdrawalsSweep = 76
+	config.MaxBlobsPerBlock = 4
+	config.FieldElementsPerBlob = 77
 
 	var dbp [4]byte
 	copy(dbp[:], []byte{'0', '0', '0', '1'})
@@ -141,7 +143,7 @@ func TestGetSpec(t *testing.T) {
 	resp, err := server.GetSpec(context.Background(), &emptypb.Empty{})
 	require.NoError(t, err)
 
-	assert.Equal(t, 111, len(resp.Data))
+	assert.Equal(t, 113, len(resp.Data))
 	for k, v := range resp.Data {
 		switch k {
 		case "CONFIG_NAME":
@@ -280,6 +282,8 @@ func TestGetSpec(t *testing.T) {
 			assert.Equal(t, "52", v)
 		case "MAX_BLOBS_PER_BLOCK":
 			assert.Equal(t, "4", v)
+		case "FIELD_ELEMENTS_PER_BLOB":
+			assert.Equal(t, "77", v)
 		case "TIMELY_HEAD_FLAG_INDEX":
 			assert.Equal(t, "0x35", v)
 		case "TIMELY_SOURCE_FLAG_INDEX":
@@ -14,6 +14,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/api"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
 	corehelpers "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
@@ -54,8 +55,7 @@ func (bs *Server) PublishBlindedBlockV2(w http.ResponseWriter, r *http.Request)
 	if shared.IsSyncing(r.Context(), w, bs.SyncChecker, bs.HeadFetcher, bs.TimeFetcher, bs.OptimisticModeFetcher) {
 		return
 	}
-	isSSZ, err := http2.SszRequested(r)
-	if isSSZ && err == nil {
+	if http2.SszPosted(r) {
 		publishBlindedBlockV2SSZ(ctx, bs, w, r)
 	} else {
 		publishBlindedBlockV2(ctx, bs, w, r)
@@ -68,8 +68,9 @@ func publishBlindedBlockV2SSZ(ctx context.Context, bs *Server, w http.ResponseWr
 		http2.HandleError(w, "Could not read request body: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	versionHeader := r.Header.Get(api.VersionHeader)
 	denebBlockContents := &eth.SignedBlindedBeaconBlockAndBlobsDeneb{}
-	if err := denebBlockContents.UnmarshalSSZ(body); err == nil {
+	if versionAllowed(versionHeader, version.Deneb) && denebBlockContents.UnmarshalSSZ(body) == nil {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_BlindedDeneb{
 				BlindedDeneb: denebBlockContents,
@@ -83,7 +84,7 @@ func publishBlindedBlockV2SSZ(ctx context.Context, bs *Server, w http.ResponseWr
 		return
 	}
 	capellaBlock := &eth.SignedBlindedBeaconBlockCapella{}
-	if err := capellaBlock.UnmarshalSSZ(body); err == nil {
+	if versionAllowed(versionHeader, version.Capella) && capellaBlock.UnmarshalSSZ(body) == nil {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_BlindedCapella{
 				BlindedCapella: capellaBlock,
@@ -97,7 +98,7 @@ func publishBlindedBlockV2SSZ(ctx context.Context, bs *Server, w http.ResponseWr
 		return
 	}
 	bellatrixBlock := &eth.SignedBlindedBeaconBlockBellatrix{}
-	if err := bellatrixBlock.UnmarshalSSZ(body); err == nil {
+	if versionAllowed(versionHeader, version.Bellatrix) && bellatrixBlock.UnmarshalSSZ(body) == nil {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_BlindedBellatrix{
 				BlindedBellatrix: bellatrixBlock,
@@ -113,7 +114,7 @@ func publishBlindedBlockV2SSZ(ctx context.Context, bs *Server, w http.ResponseWr
 
 	// blinded is not supported before bellatrix hardfork
 	altairBlock := &eth.SignedBeaconBlockAltair{}
-	if err := altairBlock.UnmarshalSSZ(body); err == nil {
+	if versionAllowed(versionHeader, version.Altair) && altairBlock.UnmarshalSSZ(body) == nil {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_Altair{
 				Altair: altairBlock,
@@ -127,7 +128,7 @@ func publishBlindedBlockV2SSZ(ctx context.Context, bs *Server, w http.ResponseWr
 		return
 	}
 	phase0Block := &eth.SignedBeaconBlock{}
-	if err := phase0Block.UnmarshalSSZ(body); err == nil {
+	if versionAllowed(versionHeader, version.Phase0) && phase0Block.UnmarshalSSZ(body) == nil {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_Phase0{
 				Phase0: phase0Block,
@@ -140,7 +141,11 @@ func publishBlindedBlockV2SSZ(ctx context.Context, bs *Server, w http.ResponseWr
 		bs.proposeBlock(ctx, w, genericBlock)
 		return
 	}
-	http2.HandleError(w, "Body does not represent a valid block type", http.StatusBadRequest)
+	var blockVersionError string
+	if versionHeader != "" {
+		blockVersionError = fmt.Sprintf(": could not decode %s request body into consensus block", versionHeader)
+	}
+	http2.HandleError(w, "Body does not represent a valid block type"+blockVersionError, http.StatusBadRequest)
 }
 
 func publishBlindedBlockV2(ctx context.Context, bs *Server, w http.ResponseWriter, r *http.Request) {
@@ -248,8 +253,7 @@ func (bs *Server) PublishBlockV2(w http.ResponseWriter, r *http.Request) {
 	if shared.IsSyncing(r.Context(), w, bs.SyncChecker, bs.HeadFetcher, bs.TimeFetcher, bs.OptimisticModeFetcher) {
 		return
 	}
-	isSSZ, err := http2.SszRequested(r)
-	if isSSZ && err == nil {
+	if http2.SszPosted(r) {
 		publishBlockV2SSZ(ctx, bs, w, r)
 	} else {
 		publishBlockV2(ctx, bs, w, r)
@@ -262,8 +266,9 @@ func publishBlockV2SSZ(ctx context.Context, bs *Server, w http.ResponseWriter, r
 		http2.HandleError(w, "Could not read request body", http.StatusInternalServerError)
 		return
 	}
+	versionHeader := r.Header.Get(api.VersionHeader)
 	denebBlockContents := &eth.SignedBeaconBlockAndBlobsDeneb{}
-	if err := denebBlockContents.UnmarshalSSZ(body); err == nil {
+	if versionAllowed(versionHeader, version.Deneb) && denebBlockContents.UnmarshalSSZ(body) == nil {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_Deneb{
 				Deneb: denebBlockContents,
@@ -277,7 +282,7 @@ func publishBlockV2SSZ(ctx context.Context, bs *Server, w http.ResponseWriter, r
 		return
 	}
 	capellaBlock := &eth.SignedBeaconBlockCapella{}
-	if err := capellaBlock.UnmarshalSSZ(body); err == nil {
+	if versionAllowed(versionHeader, version.Capella) && capellaBlock.UnmarshalSSZ(body) == nil {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_Capella{
 				Capella: capellaBlock,
@@ -291,7 +296,7 @@ func publishBlockV2SSZ(ctx context.Context, bs *Server, w http.ResponseWriter, r
 		return
 	}
 	bellatrixBlock := &eth.SignedBeaconBlockBellatrix{}
-	if err := bellatrixBlock.UnmarshalSSZ(body); err == nil {
+	if versionAllowed(versionHeader, version.Bellatrix) && bellatrixBlock.UnmarshalSSZ(body) == nil {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_Bellatrix{
 				Bellatrix: bellatrixBlock,
@@ -305,7 +310,7 @@ func publishBlockV2SSZ(ctx context.Context, bs *Server, w http.ResponseWriter, r
 		return
 	}
 	altairBlock := &eth.SignedBeaconBlockAltair{}
-	if err := altairBlock.UnmarshalSSZ(body); err == nil {
+	if versionAllowed(versionHeader, version.Altair) && altairBlock.UnmarshalSSZ(body) == nil {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_Altair{
 				Altair: altairBlock,
@@ -319,7 +324,7 @@ func publishBlockV2SSZ(ctx context.Context, bs *Server, w http.ResponseWriter, r
 		return
 	}
 	phase0Block := &eth.SignedBeaconBlock{}
-	if err := phase0Block.UnmarshalSSZ(body); err == nil {
+	if versionAllowed(versionHeader, version.Phase0) && phase0Block.UnmarshalSSZ(body) == nil {
 		genericBlock := &eth.GenericSignedBeaconBlock{
 			Block: &eth.GenericSignedBeaconBlock_Phase0{
 				Phase0: phase0Block,
@@ -332,7 +337,11 @@ func publishBlockV2SSZ(ctx context.Context, bs *Server, w http.ResponseWriter, r
 		bs.proposeBlock(ctx, w, genericBlock)
 		return
 	}
-	http2.HandleError(w, "Body does not represent a valid block type", http.StatusBadRequest)
+	var blockVersionError string
+	if versionHeader != "" {
+		blockVersionError = fmt.Sprintf(": could not decode %s request body into consensus block", versionHeader)
+	}
+	http2.HandleError(w, "Body does not represent a valid block type"+blockVersionError, http.StatusBadRequest)
 }
 
 func publishBlockV2(ctx context.Context, bs *Server, w http.ResponseWriter, r *http.Request) {
@@ -432,6 +441,12 @@ func (bs *Server) proposeBlock(ctx context.Context, w http.ResponseWriter, blk *
 	}
 }
 
+// versionAllowed returns true if a request body may be decoded as a block of the given version,
+// which is the case when the request's consensus version header is either absent or matches it.
+func versionAllowed(versionHeader string, v int) bool {
+	return versionHeader == "" || versionHeader == version.String(v)
+}
+
 func unmarshalStrict(data []byte, v interface{}) error {
 	dec := json.NewDecoder(bytes.NewReader(data))
 	dec.DisallowUnknownFields()
@@ -981,3 +996,53 @@ func (s *Server) GetGenesis(w http.ResponseWriter, r *http.Request) {
 	}
 	http2.WriteJson(w, resp)
 }
+
+// GetDepositSnapshot retrieves the EIP-4881 deposit tree snapshot of the finalized deposits, from which a
+// deposit tree can be rebuilt without replaying the deposit logs. Snapshots are only available when the
+// node keeps its finalized deposits in an EIP-4881 deposit tree.
+func (s *Server) GetDepositSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetDepositSnapshot")
+	defer span.End()
+
+	if s.DepositFetcher == nil {
+		http2.HandleError(w, "Deposit snapshot is not available", http.StatusNotFound)
+		return
+	}
+	finalized, err := s.DepositFetcher.FinalizedDeposits(ctx)
+	if err != nil {
+		http2.HandleError(w, "Could not get finalized deposits: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if finalized == nil {
+		http2.HandleError(w, "Deposit snapshot is not available", http.StatusNotFound)
+		return
+	}
+	tree, ok := finalized.Deposits().(*depositsnapshot.DepositTree)
+	if !ok || tree == nil {
+		http2.HandleError(w, "Deposit snapshot is not available, the EIP-4881 deposit tree is not enabled", http.StatusNotFound)
+		return
+	}
+	snapshot, err := tree.ToProto()
+	if err != nil {
+		http2.HandleError(w, "Could not compute deposit snapshot: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if snapshot.DepositCount == 0 {
+		http2.HandleError(w, "Deposit snapshot is not available, no deposit is finalized yet", http.StatusNotFound)
+		return
+	}
+
+	finalizedRoots := make([]string, len(snapshot.Finalized))
+	for i, f := range snapshot.Finalized {
+		finalizedRoots[i] = hexutil.Encode(f)
+	}
+	http2.WriteJson(w, &GetDepositSnapshotResponse{
+		Data: &DepositSnapshot{
+			Finalized:            finalizedRoots,
+			DepositRoot:          hexutil.Encode(snapshot.DepositRoot),
+			DepositCount:         strconv.FormatUint(snapshot.DepositCount, 10),
+			ExecutionBlockHash:   hexutil.Encode(snapshot.ExecutionHash),
+			ExecutionBlockHeight: strconv.FormatUint(snapshot.ExecutionDepth, 10),
+		},
+	})
+}
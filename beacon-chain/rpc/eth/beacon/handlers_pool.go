@@ -143,6 +143,8 @@ func (s *Server) SubmitAttestations(w http.ResponseWriter, r *http.Request) {
 		if err = s.Broadcaster.BroadcastAttestation(ctx, subnet, att); err != nil {
 			failedBroadcasts = append(failedBroadcasts, strconv.Itoa(i))
 			log.WithError(err).Errorf("could not broadcast attestation at index %d", i)
+		} else if s.LocalBroadcasts != nil {
+			s.LocalBroadcasts.AddAttestation(subnet, att)
 		}
 
 		if corehelpers.IsAggregated(att) {
@@ -250,6 +252,15 @@ func (s *Server) SubmitVoluntaryExit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Broadcast the voluntary exit on a feed to notify other services in the beacon node
+	// of a received voluntary exit.
+	s.OperationNotifier.OperationFeed().Send(&feed.Event{
+		Type: operation.ExitReceived,
+		Data: &operation.ExitReceivedData{
+			Exit: exit,
+		},
+	})
+
 	s.VoluntaryExitsPool.InsertVoluntaryExit(exit)
 	if err = s.Broadcaster.Broadcast(ctx, exit); err != nil {
 		http2.HandleError(w, "Could not broadcast exit: "+err.Error(), http.StatusInternalServerError)
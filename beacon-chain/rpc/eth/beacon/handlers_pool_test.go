@@ -363,6 +363,7 @@ func TestSubmitVoluntaryExit(t *testing.T) {
 			ChainInfoFetcher:   &blockchainmock.ChainService{State: bs},
 			VoluntaryExitsPool: &mock.PoolMock{},
 			Broadcaster:        broadcaster,
+			OperationNotifier:  &blockchainmock.MockOperationNotifier{},
 		}
 
 		var body bytes.Buffer
@@ -395,6 +396,7 @@ func TestSubmitVoluntaryExit(t *testing.T) {
 			ChainInfoFetcher:   &blockchainmock.ChainService{State: bs},
 			VoluntaryExitsPool: &mock.PoolMock{},
 			Broadcaster:        broadcaster,
+			OperationNotifier:  &blockchainmock.MockOperationNotifier{},
 		}
 
 		var body bytes.Buffer
@@ -18,6 +18,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/api"
 	chainMock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	dbTest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
@@ -227,7 +229,7 @@ func TestPublishBlockV2SSZ(t *testing.T) {
 		sszvalue, err := genericBlock.GetBellatrix().MarshalSSZ()
 		require.NoError(t, err)
 		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
-		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set("Content-Type", api.OctetStreamMediaType)
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
 		server.PublishBlockV2(writer, request)
@@ -252,7 +254,7 @@ func TestPublishBlockV2SSZ(t *testing.T) {
 		sszvalue, err := genericBlock.GetCapella().MarshalSSZ()
 		require.NoError(t, err)
 		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
-		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set("Content-Type", api.OctetStreamMediaType)
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
 		server.PublishBlockV2(writer, request)
@@ -279,7 +281,7 @@ func TestPublishBlockV2SSZ(t *testing.T) {
 		sszvalue, err := v2block.MarshalSSZ()
 		require.NoError(t, err)
 		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
-		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set("Content-Type", api.OctetStreamMediaType)
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
 		server.PublishBlockV2(writer, request)
@@ -297,6 +299,27 @@ func TestPublishBlockV2SSZ(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, writer.Code)
 		assert.Equal(t, true, strings.Contains(writer.Body.String(), "Body does not represent a valid block type"))
 	})
+	t.Run("wrong version header", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		var bellablock shared.SignedBeaconBlockBellatrix
+		err := json.Unmarshal([]byte(rpctesting.BellatrixBlock), &bellablock)
+		require.NoError(t, err)
+		genericBlock, err := bellablock.ToGeneric()
+		require.NoError(t, err)
+		sszvalue, err := genericBlock.GetBellatrix().MarshalSSZ()
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
+		request.Header.Set("Content-Type", api.OctetStreamMediaType)
+		request.Header.Set(api.VersionHeader, version.String(version.Capella))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "could not decode capella request body into consensus block", writer.Body.String())
+	})
 }
 
 func TestPublishBlindedBlockV2(t *testing.T) {
@@ -483,7 +506,7 @@ func TestPublishBlindedBlockV2SSZ(t *testing.T) {
 		sszvalue, err := genericBlock.GetBlindedBellatrix().MarshalSSZ()
 		require.NoError(t, err)
 		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
-		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set("Content-Type", api.OctetStreamMediaType)
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
 		server.PublishBlindedBlockV2(writer, request)
@@ -508,7 +531,7 @@ func TestPublishBlindedBlockV2SSZ(t *testing.T) {
 		sszvalue, err := genericBlock.GetBlindedCapella().MarshalSSZ()
 		require.NoError(t, err)
 		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
-		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set("Content-Type", api.OctetStreamMediaType)
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
 		server.PublishBlindedBlockV2(writer, request)
@@ -535,7 +558,7 @@ func TestPublishBlindedBlockV2SSZ(t *testing.T) {
 		sszvalue, err := v1block.MarshalSSZ()
 		require.NoError(t, err)
 		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
-		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set("Content-Type", api.OctetStreamMediaType)
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
 		server.PublishBlindedBlockV2(writer, request)
@@ -1654,3 +1677,65 @@ func TestGetGenesis(t *testing.T) {
 		assert.StringContains(t, "Chain genesis info is not yet known", e.Message)
 	})
 }
+
+func TestGetDepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ok", func(t *testing.T) {
+		dc, err := depositsnapshot.New()
+		require.NoError(t, err)
+		for i := 0; i < 3; i++ {
+			d := &eth.Deposit{
+				Data: &eth.Deposit_Data{
+					PublicKey:             bytesutil.PadTo([]byte{byte(i)}, 48),
+					WithdrawalCredentials: make([]byte, 32),
+					Signature:             make([]byte, 96),
+				},
+			}
+			require.NoError(t, dc.InsertDeposit(ctx, d, uint64(10+i), int64(i), [32]byte{}))
+		}
+		executionHash := [32]byte{'a'}
+		require.NoError(t, dc.InsertFinalizedDeposits(ctx, 1, executionHash, 11))
+		s := &Server{DepositFetcher: dc}
+
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/deposit_snapshot", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetDepositSnapshot(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetDepositSnapshotResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "2", resp.Data.DepositCount)
+		assert.Equal(t, hexutil.Encode(executionHash[:]), resp.Data.ExecutionBlockHash)
+		assert.Equal(t, "11", resp.Data.ExecutionBlockHeight)
+		assert.Equal(t, 1, len(resp.Data.Finalized))
+	})
+	t.Run("no finalized deposit", func(t *testing.T) {
+		dc, err := depositsnapshot.New()
+		require.NoError(t, err)
+		s := &Server{DepositFetcher: dc}
+
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/deposit_snapshot", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetDepositSnapshot(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("EIP-4881 deposit tree not enabled", func(t *testing.T) {
+		dc, err := depositcache.New()
+		require.NoError(t, err)
+		s := &Server{DepositFetcher: dc}
+
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/deposit_snapshot", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetDepositSnapshot(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+		e := &http2.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "EIP-4881", e.Message)
+	})
+}
@@ -1,7 +1,9 @@
 package beacon
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -21,7 +23,8 @@ import (
 	"go.opencensus.io/trace"
 )
 
-// GetValidators returns filterable list of validators with their balance, status and index.
+// GetValidators returns filterable list of validators with their balance, status and index. The IDs and statuses to
+// filter on are taken from the query of a GET request, and from the body of a POST request.
 func (s *Server) GetValidators(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "beacon.GetValidators")
 	defer span.End()
@@ -31,6 +34,17 @@ func (s *Server) GetValidators(w http.ResponseWriter, r *http.Request) {
 		http2.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
 		return
 	}
+	var rawIds, statuses []string
+	if r.Method == http.MethodPost {
+		var req GetValidatorsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			http2.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		rawIds, statuses = req.Ids, req.Statuses
+	} else {
+		rawIds, statuses = r.URL.Query()["id"], r.URL.Query()["status"]
+	}
 	st, err := s.Stater.State(ctx, []byte(stateId))
 	if err != nil {
 		shared.WriteStateFetchError(w, err)
@@ -49,7 +63,6 @@ func (s *Server) GetValidators(w http.ResponseWriter, r *http.Request) {
 	}
 	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
 
-	rawIds := r.URL.Query()["id"]
 	ids, ok := decodeIds(w, st, rawIds, true /* ignore unknown */)
 	if !ok {
 		return
@@ -72,7 +85,6 @@ func (s *Server) GetValidators(w http.ResponseWriter, r *http.Request) {
 	epoch := slots.ToEpoch(st.Slot())
 	allBalances := st.Balances()
 
-	statuses := r.URL.Query()["status"]
 	for i, ss := range statuses {
 		statuses[i] = strings.ToLower(ss)
 	}
@@ -206,7 +218,8 @@ func (s *Server) GetValidator(w http.ResponseWriter, r *http.Request) {
 	http2.WriteJson(w, resp)
 }
 
-// GetValidatorBalances returns a filterable list of validator balances.
+// GetValidatorBalances returns a filterable list of validator balances. The IDs to filter on are taken from the
+// query of a GET request, and from the body of a POST request.
 func (bs *Server) GetValidatorBalances(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "beacon.GetValidatorBalances")
 	defer span.End()
@@ -216,6 +229,15 @@ func (bs *Server) GetValidatorBalances(w http.ResponseWriter, r *http.Request) {
 		http2.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
 		return
 	}
+	var rawIds []string
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&rawIds); err != nil && err != io.EOF {
+			http2.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	} else {
+		rawIds = r.URL.Query()["id"]
+	}
 	st, err := bs.Stater.State(ctx, []byte(stateId))
 	if err != nil {
 		shared.WriteStateFetchError(w, err)
@@ -234,7 +256,6 @@ func (bs *Server) GetValidatorBalances(w http.ResponseWriter, r *http.Request) {
 	}
 	isFinalized := bs.FinalizationFetcher.IsFinalized(ctx, blockRoot)
 
-	rawIds := r.URL.Query()["id"]
 	ids, ok := decodeIds(w, st, rawIds, true /* ignore unknown */)
 	if !ok {
 		return
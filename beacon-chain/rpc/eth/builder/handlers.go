@@ -89,18 +89,12 @@ func (s *Server) ExpectedWithdrawals(w http.ResponseWriter, r *http.Request) {
 	// Advance state forward to proposal slot
 	st, err = transition.ProcessSlots(r.Context(), st, proposalSlot)
 	if err != nil {
-		http2.WriteError(w, &http2.DefaultErrorJson{
-			Message: "could not process slots",
-			Code:    http.StatusInternalServerError,
-		})
+		http2.WriteError(w, handleWrapError(err, "could not process slots", http.StatusInternalServerError))
 		return
 	}
 	withdrawals, err := st.ExpectedWithdrawals()
 	if err != nil {
-		http2.WriteError(w, &http2.DefaultErrorJson{
-			Message: "could not get expected withdrawals",
-			Code:    http.StatusInternalServerError,
-		})
+		http2.WriteError(w, handleWrapError(err, "could not get expected withdrawals", http.StatusInternalServerError))
 		return
 	}
 	http2.WriteJson(w, &ExpectedWithdrawalsResponse{
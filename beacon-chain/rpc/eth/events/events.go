@@ -3,8 +3,11 @@ package events
 import (
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	gwpb "github.com/grpc-ecosystem/grpc-gateway/v2/proto/gateway"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
@@ -12,6 +15,9 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
 	ethpbservice "github.com/prysmaticlabs/prysm/v4/proto/eth/service"
@@ -49,6 +55,14 @@ const (
 	BlobSidecarTopic = "blob_sidecar"
 )
 
+// eventQueueSize is the number of events buffered for every event stream.
+const eventQueueSize = 1024
+
+var droppedEventsCount = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "event_stream_dropped_events_total",
+	Help: "The number of events dropped because an event stream consumer could not keep up.",
+})
+
 var casesHandled = map[string]bool{
 	HeadTopic:                      true,
 	BlockTopic:                     true,
@@ -93,14 +107,23 @@ func (s *Server) StreamEvents(
 	defer opsSub.Unsubscribe()
 	defer stateSub.Unsubscribe()
 
+	// Events are buffered between the feeds and the stream, so that a slow consumer
+	// never blocks the senders of the feeds, which are shared by the whole node.
+	done := make(chan struct{})
+	defer close(done)
+	opsQueue := make(chan *feed.Event, eventQueueSize)
+	stateQueue := make(chan *feed.Event, eventQueueSize)
+	go queueEvents(opsChan, opsQueue, done)
+	go queueEvents(stateChan, stateQueue, done)
+
 	// Handle each event received and context cancelation.
 	for {
 		select {
-		case event := <-opsChan:
+		case event := <-opsQueue:
 			if err := handleBlockOperationEvents(stream, requestedTopics, event); err != nil {
 				return status.Errorf(codes.Internal, "Could not handle block operations event: %v", err)
 			}
-		case event := <-stateChan:
+		case event := <-stateQueue:
 			if err := s.handleStateEvents(stream, requestedTopics, event); err != nil {
 				return status.Errorf(codes.Internal, "Could not handle state event: %v", err)
 			}
@@ -112,6 +135,28 @@ func (s *Server) StreamEvents(
 	}
 }
 
+// queueEvents moves events received from a feed subscription into the queue of an event stream until done is closed.
+// Events are dropped when the queue is full rather than waiting for the stream to catch up.
+func queueEvents(events <-chan *feed.Event, queue chan<- *feed.Event, done <-chan struct{}) {
+	warned := false
+	for {
+		select {
+		case event := <-events:
+			select {
+			case queue <- event:
+			default:
+				droppedEventsCount.Inc()
+				if !warned {
+					log.Warn("Event stream consumer is too slow, dropping events")
+					warned = true
+				}
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
 func handleBlockOperationEvents(
 	stream ethpbservice.Events_StreamEventsServer, requestedTopics map[string]bool, event *feed.Event,
 ) error {
@@ -197,17 +242,16 @@ func (s *Server) handleStateEvents(
 	switch event.Type {
 	case statefeed.NewHead:
 		if _, ok := requestedTopics[HeadTopic]; ok {
-			head, ok := event.Data.(*ethpb.EventHead)
-			if !ok {
-				return nil
+			if head, ok := event.Data.(*ethpb.EventHead); ok {
+				if err := streamData(stream, HeadTopic, head); err != nil {
+					return err
+				}
 			}
-			return streamData(stream, HeadTopic, head)
 		}
 		if _, ok := requestedTopics[PayloadAttributesTopic]; ok {
 			if err := s.streamPayloadAttributes(stream); err != nil {
 				log.WithError(err).Error("Unable to obtain stream payload attributes")
 			}
-			return nil
 		}
 		return nil
 	case statefeed.MissedSlot:
@@ -306,6 +350,11 @@ func (s *Server) streamPayloadAttributes(stream ethpbservice.Events_StreamEvents
 		return err
 	}
 
+	feeRecipient, err := s.proposerFeeRecipient(proposerIndex)
+	if err != nil {
+		return err
+	}
+
 	switch headState.Version() {
 	case version.Bellatrix:
 		return streamData(stream, PayloadAttributesTopic, &ethpb.EventPayloadAttributeV1{
@@ -319,11 +368,11 @@ func (s *Server) streamPayloadAttributes(stream ethpbservice.Events_StreamEvents
 				PayloadAttributes: &enginev1.PayloadAttributes{
 					Timestamp:             uint64(t.Unix()),
 					PrevRandao:            prevRando,
-					SuggestedFeeRecipient: headPayload.FeeRecipient(),
+					SuggestedFeeRecipient: feeRecipient[:],
 				},
 			},
 		})
-	case version.Capella, version.Deneb:
+	case version.Capella:
 		withdrawals, err := headState.ExpectedWithdrawals()
 		if err != nil {
 			return err
@@ -339,8 +388,30 @@ func (s *Server) streamPayloadAttributes(stream ethpbservice.Events_StreamEvents
 				PayloadAttributes: &enginev1.PayloadAttributesV2{
 					Timestamp:             uint64(t.Unix()),
 					PrevRandao:            prevRando,
-					SuggestedFeeRecipient: headPayload.FeeRecipient(),
+					SuggestedFeeRecipient: feeRecipient[:],
+					Withdrawals:           withdrawals,
+				},
+			},
+		})
+	case version.Deneb:
+		withdrawals, err := headState.ExpectedWithdrawals()
+		if err != nil {
+			return err
+		}
+		return streamData(stream, PayloadAttributesTopic, &ethpb.EventPayloadAttributeV3{
+			Version: version.String(headState.Version()),
+			Data: &ethpb.EventPayloadAttributeV3_BasePayloadAttribute{
+				ProposerIndex:     proposerIndex,
+				ProposalSlot:      headState.Slot(),
+				ParentBlockNumber: headPayload.BlockNumber(),
+				ParentBlockRoot:   headRoot,
+				ParentBlockHash:   headPayload.BlockHash(),
+				PayloadAttributes: &enginev1.PayloadAttributesV3{
+					Timestamp:             uint64(t.Unix()),
+					PrevRandao:            prevRando,
+					SuggestedFeeRecipient: feeRecipient[:],
 					Withdrawals:           withdrawals,
+					ParentBeaconBlockRoot: headRoot,
 				},
 			},
 		})
@@ -349,6 +420,23 @@ func (s *Server) streamPayloadAttributes(stream ethpbservice.Events_StreamEvents
 	}
 }
 
+// proposerFeeRecipient returns the fee recipient registered by the proposer, falling back to the default fee
+// recipient of the node when the proposer has not registered one.
+func (s *Server) proposerFeeRecipient(proposerIndex primitives.ValidatorIndex) (common.Address, error) {
+	if s.BeaconDB == nil {
+		return params.BeaconConfig().DefaultFeeRecipient, nil
+	}
+	recipient, err := s.BeaconDB.FeeRecipientByValidatorID(s.Ctx, proposerIndex)
+	switch {
+	case err == nil:
+		return recipient, nil
+	case errors.Is(err, kv.ErrNotFoundFeeRecipient):
+		return params.BeaconConfig().DefaultFeeRecipient, nil
+	default:
+		return common.Address{}, errors.Wrap(err, "could not get fee recipient")
+	}
+}
+
 func streamData(stream ethpbservice.Events_StreamEventsServer, name string, data proto.Message) error {
 	returnData, err := anypb.New(data)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/mock/gomock"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/proto/gateway"
@@ -18,8 +19,11 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	prysmtime "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	dbTest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
@@ -30,6 +34,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/testing/mock"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	logTest "github.com/sirupsen/logrus/hooks/test"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
@@ -52,6 +57,22 @@ func TestStreamEvents_Preconditions(t *testing.T) {
 	})
 }
 
+func TestQueueEvents_DropsWhenFull(t *testing.T) {
+	hook := logTest.NewGlobal()
+	events := make(chan *feed.Event)
+	queue := make(chan *feed.Event, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go queueEvents(events, queue, done)
+
+	// None of the sends blocks even though nothing reads from the queue.
+	for i := 0; i < 3; i++ {
+		events <- &feed.Event{Type: statefeed.NewHead}
+	}
+	require.Equal(t, 1, len(queue))
+	require.LogsContain(t, hook, "Event stream consumer is too slow, dropping events")
+}
+
 func TestStreamEvents_OperationsEvents(t *testing.T) {
 	t.Run("attestation_unaggregated", func(t *testing.T) {
 		ctx := context.Background()
@@ -410,6 +431,26 @@ func TestStreamEvents_StateEvents(t *testing.T) {
 			},
 			feed: srv.StateNotifier.StateFeed(),
 		})
+
+		// Both events are streamed when the head and payload attributes topics are requested.
+		wantedHead := &ethpb.EventHead{
+			Slot:                      1,
+			Block:                     make([]byte, 32),
+			State:                     make([]byte, 32),
+			PreviousDutyDependentRoot: make([]byte, 32),
+			CurrentDutyDependentRoot:  make([]byte, 32),
+		}
+		headResponse, err := anypb.New(wantedHead)
+		require.NoError(t, err)
+		gomock.InOrder(
+			mockStream.EXPECT().Send(&gateway.EventSource{Event: HeadTopic, Data: headResponse}),
+			mockStream.EXPECT().Send(wantedMessage),
+		)
+		requestedTopics := map[string]bool{HeadTopic: true, PayloadAttributesTopic: true}
+		require.NoError(t, srv.handleStateEvents(mockStream, requestedTopics, &feed.Event{
+			Type: statefeed.NewHead,
+			Data: wantedHead,
+		}))
 	})
 	t.Run(PayloadAttributesTopic+"_capella", func(t *testing.T) {
 		ctx := context.Background()
@@ -480,6 +521,9 @@ func TestStreamEvents_StateEvents(t *testing.T) {
 
 		srv.HeadFetcher = fetcher
 		srv.ChainInfoFetcher = fetcher
+		srv.BeaconDB = dbTest.SetupDB(t)
+		feeRecipient := common.HexToAddress("0x8ba1f109551bD432803012645Ac136ddd64DBA72")
+		require.NoError(t, srv.BeaconDB.(db.Database).SaveFeeRecipientsByValidatorIDs(ctx, []primitives.ValidatorIndex{0}, []common.Address{feeRecipient}))
 
 		prevRando, err := helpers.RandaoMix(beaconState, prysmtime.CurrentEpoch(beaconState))
 		require.NoError(t, err)
@@ -495,8 +539,121 @@ func TestStreamEvents_StateEvents(t *testing.T) {
 				PayloadAttributes: &enginev1.PayloadAttributesV2{
 					Timestamp:             24,
 					PrevRandao:            prevRando,
-					SuggestedFeeRecipient: make([]byte, 20),
+					SuggestedFeeRecipient: feeRecipient.Bytes(),
+					Withdrawals:           withdrawals,
+				},
+			},
+		}
+		genericResponse, err := anypb.New(wantedPayload)
+		require.NoError(t, err)
+		wantedMessage := &gateway.EventSource{
+			Event: PayloadAttributesTopic,
+			Data:  genericResponse,
+		}
+
+		assertFeedSendAndReceive(ctx, &assertFeedArgs{
+			t:             t,
+			srv:           srv,
+			topics:        []string{PayloadAttributesTopic},
+			stream:        mockStream,
+			shouldReceive: wantedMessage,
+			itemToSend: &feed.Event{
+				Type: statefeed.NewHead,
+				Data: wantedPayload,
+			},
+			feed: srv.StateNotifier.StateFeed(),
+		})
+	})
+	t.Run(PayloadAttributesTopic+"_deneb", func(t *testing.T) {
+		ctx := context.Background()
+		beaconState, _ := util.DeterministicGenesisStateDeneb(t, 1)
+		validator, err := beaconState.ValidatorAtIndex(0)
+		require.NoError(t, err, "Could not get validator")
+		by, err := hexutil.Decode("0x010000000000000000000000a94f5374fce5edbc8e2a8697c15331677e6ebf0b")
+		require.NoError(t, err)
+		validator.WithdrawalCredentials = by
+		err = beaconState.UpdateValidatorAtIndex(0, validator)
+		require.NoError(t, err)
+		err = beaconState.SetSlot(2)
+		require.NoError(t, err, "Count not set slot")
+		err = beaconState.SetNextWithdrawalValidatorIndex(0)
+		require.NoError(t, err, "Could not set withdrawal index")
+		err = beaconState.SetBalances([]uint64{33000000000})
+		require.NoError(t, err, "Could not set validator balance")
+		stateRoot, err := beaconState.HashTreeRoot(ctx)
+		require.NoError(t, err, "Could not hash genesis state")
+
+		genesis := b.NewGenesisBlock(stateRoot[:])
+
+		parentRoot, err := genesis.Block.HashTreeRoot()
+		require.NoError(t, err, "Could not get signing root")
+
+		withdrawals, err := beaconState.ExpectedWithdrawals()
+		require.NoError(t, err, "Could get expected withdrawals")
+		require.NotEqual(t, len(withdrawals), 0)
+		var scBits [fieldparams.SyncAggregateSyncCommitteeBytesLength]byte
+		blk := &eth.SignedBeaconBlockDeneb{
+			Block: &eth.BeaconBlockDeneb{
+				ProposerIndex: 0,
+				Slot:          1,
+				ParentRoot:    parentRoot[:],
+				StateRoot:     genesis.Block.StateRoot,
+				Body: &eth.BeaconBlockBodyDeneb{
+					RandaoReveal:  genesis.Block.Body.RandaoReveal,
+					Graffiti:      genesis.Block.Body.Graffiti,
+					Eth1Data:      genesis.Block.Body.Eth1Data,
+					SyncAggregate: &eth.SyncAggregate{SyncCommitteeBits: scBits[:], SyncCommitteeSignature: make([]byte, 96)},
+					ExecutionPayload: &enginev1.ExecutionPayloadDeneb{
+						BlockNumber:   1,
+						ParentHash:    make([]byte, fieldparams.RootLength),
+						FeeRecipient:  make([]byte, fieldparams.FeeRecipientLength),
+						StateRoot:     make([]byte, fieldparams.RootLength),
+						ReceiptsRoot:  make([]byte, fieldparams.RootLength),
+						LogsBloom:     make([]byte, fieldparams.LogsBloomLength),
+						PrevRandao:    make([]byte, fieldparams.RootLength),
+						BaseFeePerGas: make([]byte, fieldparams.RootLength),
+						BlockHash:     make([]byte, fieldparams.RootLength),
+						Withdrawals:   withdrawals,
+					},
+				},
+			},
+			Signature: genesis.Signature,
+		}
+		signedBlk, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		srv, ctrl, mockStream := setupServer(ctx, t)
+		defer ctrl.Finish()
+		fetcher := &mockChain.ChainService{
+			Genesis:        time.Now(),
+			State:          beaconState,
+			Block:          signedBlk,
+			Root:           make([]byte, 32),
+			ValidatorsRoot: [32]byte{},
+		}
+
+		srv.HeadFetcher = fetcher
+		srv.ChainInfoFetcher = fetcher
+		srv.BeaconDB = dbTest.SetupDB(t)
+		feeRecipient := common.HexToAddress("0x8ba1f109551bD432803012645Ac136ddd64DBA72")
+		require.NoError(t, srv.BeaconDB.(db.Database).SaveFeeRecipientsByValidatorIDs(ctx, []primitives.ValidatorIndex{0}, []common.Address{feeRecipient}))
+
+		prevRando, err := helpers.RandaoMix(beaconState, prysmtime.CurrentEpoch(beaconState))
+		require.NoError(t, err)
+
+		wantedPayload := &ethpb.EventPayloadAttributeV3{
+			Version: version.String(version.Deneb),
+			Data: &ethpb.EventPayloadAttributeV3_BasePayloadAttribute{
+				ProposerIndex:     0,
+				ProposalSlot:      2,
+				ParentBlockNumber: 1,
+				ParentBlockRoot:   make([]byte, 32),
+				ParentBlockHash:   make([]byte, 32),
+				PayloadAttributes: &enginev1.PayloadAttributesV3{
+					Timestamp:             24,
+					PrevRandao:            prevRando,
+					SuggestedFeeRecipient: feeRecipient.Bytes(),
 					Withdrawals:           withdrawals,
+					ParentBeaconBlockRoot: make([]byte, 32),
 				},
 			},
 		}
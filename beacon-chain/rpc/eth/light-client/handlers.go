@@ -0,0 +1,277 @@
+package lightclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v4/api"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// maxRequestLightClientUpdates is MAX_REQUEST_LIGHT_CLIENT_UPDATES, the maximum number of updates
+// served in a single request.
+const maxRequestLightClientUpdates = 128
+
+// GetBootstrap returns the light client bootstrap of the trusted block with the given root. Bootstraps are
+// only served for finalized blocks and justified checkpoints, as creating one regenerates the state of the block.
+func (s *Server) GetBootstrap(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "lightclient.GetBootstrap")
+	defer span.End()
+
+	root, valid := shared.ValidateHex(w, "block_root", mux.Vars(r)["block_root"], fieldparams.RootLength)
+	if !valid {
+		return
+	}
+	blockRoot := bytesutil.ToBytes32(root)
+	if !s.isCheckpointRoot(ctx, blockRoot) {
+		http2.HandleError(w, fmt.Sprintf("Block %#x is neither finalized nor a justified checkpoint", root), http.StatusNotFound)
+		return
+	}
+	blk, err := s.BeaconDB.Block(ctx, blockRoot)
+	if err != nil {
+		http2.HandleError(w, "Could not retrieve block: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := blocks.BeaconBlockIsNil(blk); err != nil {
+		http2.HandleError(w, fmt.Sprintf("Could not find block %#x", root), http.StatusNotFound)
+		return
+	}
+	if !lightclient.IsSupportedVersion(blk.Version()) {
+		http2.HandleError(w, "Light client bootstrap is only available from Altair on", http.StatusNotFound)
+		return
+	}
+	st, err := s.StateGen.StateByRoot(ctx, blockRoot)
+	if err != nil {
+		http2.HandleError(w, "Could not retrieve state: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	bootstrap, err := lightclient.NewLightClientBootstrapFromBeaconState(ctx, st, blk)
+	if err != nil {
+		http2.HandleError(w, "Could not create light client bootstrap: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	v := version.String(bootstrap.Version())
+	w.Header().Set(api.VersionHeader, v)
+	isSSZ, err := http2.SszRequested(r)
+	if err != nil {
+		http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if isSSZ {
+		m, err := bootstrap.Proto()
+		if err != nil {
+			http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sszResp, err := m.MarshalSSZ()
+		if err != nil {
+			http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		http2.WriteSsz(w, sszResp, "light_client_bootstrap.ssz")
+		return
+	}
+	data, err := lightClientBootstrapFromConsensus(bootstrap)
+	if err != nil {
+		http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http2.WriteJson(w, &BootstrapResponse{Version: v, Data: data})
+}
+
+// GetUpdatesByRange returns the best light client updates of the sync committee periods starting at
+// start_period, up to count periods and the period of the head. The response stops at the first period
+// without an update.
+func (s *Server) GetUpdatesByRange(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "lightclient.GetUpdatesByRange")
+	defer span.End()
+
+	startPeriod, valid := shared.ValidateUint(w, "start_period", r.URL.Query().Get("start_period"))
+	if !valid {
+		return
+	}
+	count, valid := shared.ValidateUint(w, "count", r.URL.Query().Get("count"))
+	if !valid {
+		return
+	}
+	if count == 0 {
+		http2.HandleError(w, "count must be greater than 0", http.StatusBadRequest)
+		return
+	}
+	if count > maxRequestLightClientUpdates {
+		count = maxRequestLightClientUpdates
+	}
+	headPeriod := lightclient.SyncCommitteePeriodAtSlot(s.HeadFetcher.HeadSlot())
+	if startPeriod > headPeriod {
+		http2.HandleError(w, fmt.Sprintf("start_period %d is after the head sync committee period %d", startPeriod, headPeriod), http.StatusBadRequest)
+		return
+	}
+	endPeriod := headPeriod
+	if startPeriod+count-1 < endPeriod {
+		endPeriod = startPeriod + count - 1
+	}
+	stored, err := s.BeaconDB.LightClientUpdates(ctx, startPeriod, endPeriod)
+	if err != nil {
+		http2.HandleError(w, "Could not retrieve light client updates: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	isSSZ, err := http2.SszRequested(r)
+	if err != nil {
+		http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	updates := make([]*UpdateResponse, 0, len(stored))
+	sszResp := &bytes.Buffer{}
+	gvr := s.HeadFetcher.HeadGenesisValidatorsRoot()
+	for period := startPeriod; period <= endPeriod; period++ {
+		update, ok := stored[period]
+		if !ok {
+			break
+		}
+		if !isSSZ {
+			data, err := lightClientUpdateFromConsensus(update)
+			if err != nil {
+				http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			updates = append(updates, &UpdateResponse{Version: version.String(update.Version()), Data: data})
+			continue
+		}
+		// Every SSZ response chunk is prefixed with its length and the fork digest of the update.
+		m, err := update.Proto()
+		if err != nil {
+			http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		enc, err := m.MarshalSSZ()
+		if err != nil {
+			http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		digest, err := forks.ForkDigestFromEpoch(slots.ToEpoch(update.AttestedHeader.Beacon.Slot), gvr[:])
+		if err != nil {
+			http2.HandleError(w, "Could not compute fork digest: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		chunkLen := make([]byte, 8)
+		binary.LittleEndian.PutUint64(chunkLen, uint64(len(digest)+len(enc)))
+		sszResp.Write(chunkLen)
+		sszResp.Write(digest[:])
+		sszResp.Write(enc)
+	}
+
+	if isSSZ {
+		http2.WriteSsz(w, sszResp.Bytes(), "light_client_updates.ssz")
+		return
+	}
+	http2.WriteJson(w, updates)
+}
+
+// GetFinalityUpdate returns the latest light client finality update.
+func (s *Server) GetFinalityUpdate(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "lightclient.GetFinalityUpdate")
+	defer span.End()
+
+	update := s.LightClientFetcher.LightClientFinalityUpdate()
+	if update == nil {
+		http2.HandleError(w, "No light client finality update is available", http.StatusNotFound)
+		return
+	}
+	v := version.String(update.Version())
+	w.Header().Set(api.VersionHeader, v)
+	isSSZ, err := http2.SszRequested(r)
+	if err != nil {
+		http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if isSSZ {
+		m, err := update.Proto()
+		if err != nil {
+			http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sszResp, err := m.MarshalSSZ()
+		if err != nil {
+			http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		http2.WriteSsz(w, sszResp, "light_client_finality_update.ssz")
+		return
+	}
+	data, err := lightClientFinalityUpdateFromConsensus(update)
+	if err != nil {
+		http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http2.WriteJson(w, &FinalityUpdateResponse{Version: v, Data: data})
+}
+
+// GetOptimisticUpdate returns the latest light client optimistic update.
+func (s *Server) GetOptimisticUpdate(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "lightclient.GetOptimisticUpdate")
+	defer span.End()
+
+	update := s.LightClientFetcher.LightClientOptimisticUpdate()
+	if update == nil {
+		http2.HandleError(w, "No light client optimistic update is available", http.StatusNotFound)
+		return
+	}
+	v := version.String(update.Version())
+	w.Header().Set(api.VersionHeader, v)
+	isSSZ, err := http2.SszRequested(r)
+	if err != nil {
+		http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if isSSZ {
+		m, err := update.Proto()
+		if err != nil {
+			http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sszResp, err := m.MarshalSSZ()
+		if err != nil {
+			http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		http2.WriteSsz(w, sszResp, "light_client_optimistic_update.ssz")
+		return
+	}
+	data, err := lightClientOptimisticUpdateFromConsensus(update)
+	if err != nil {
+		http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http2.WriteJson(w, &OptimisticUpdateResponse{Version: v, Data: data})
+}
+
+// isCheckpointRoot returns true if the block root is finalized, or is the root of a justified checkpoint.
+func (s *Server) isCheckpointRoot(ctx context.Context, root [32]byte) bool {
+	if s.FinalizationFetcher.IsFinalized(ctx, root) {
+		return true
+	}
+	for _, cp := range []*ethpb.Checkpoint{
+		s.FinalizationFetcher.CurrentJustifiedCheckpt(),
+		s.FinalizationFetcher.PreviousJustifiedCheckpt(),
+	} {
+		if cp != nil && bytesutil.ToBytes32(cp.Root) == root {
+			return true
+		}
+	}
+	return false
+}
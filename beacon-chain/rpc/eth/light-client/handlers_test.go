@@ -0,0 +1,288 @@
+package lightclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v4/api"
+	mockChain "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	testDB "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	mockstategen "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen/mock"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestGetBootstrap(t *testing.T) {
+	setForkEpochs(t, version.Altair)
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	st, _ := util.DeterministicGenesisStateAltair(t, 16)
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	b := util.NewBeaconBlockAltair()
+	b.Block.StateRoot = stateRoot[:]
+	util.SaveBlock(t, ctx, db, b)
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	sg := mockstategen.NewMockService()
+	sg.StatesByRoot[root] = st
+
+	chain := &mockChain.ChainService{FinalizedRoots: map[[32]byte]bool{root: true}}
+	s := &Server{BeaconDB: db, StateGen: sg, FinalizationFetcher: chain}
+
+	t.Run("ok", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/bootstrap/{block_root}", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_root": hexutil.Encode(root[:])})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBootstrap(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "altair", writer.Header().Get(api.VersionHeader))
+		resp := &BootstrapResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "altair", resp.Version)
+		assert.Equal(t, hexutil.Encode(stateRoot[:]), resp.Data.Header.Beacon.StateRoot)
+		assert.Equal(t, 5, len(resp.Data.CurrentSyncCommitteeBranch))
+	})
+	t.Run("justified checkpoint", func(t *testing.T) {
+		justified := &Server{BeaconDB: db, StateGen: sg, FinalizationFetcher: &mockChain.ChainService{
+			CurrentJustifiedCheckPoint: &ethpb.Checkpoint{Root: root[:]},
+		}}
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/bootstrap/{block_root}", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_root": hexutil.Encode(root[:])})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		justified.GetBootstrap(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("not a checkpoint", func(t *testing.T) {
+		// The state of a block that is neither finalized nor justified is never regenerated.
+		notCheckpoint := &Server{BeaconDB: db, StateGen: sg, FinalizationFetcher: &mockChain.ChainService{}}
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/bootstrap/{block_root}", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_root": hexutil.Encode(root[:])})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		notCheckpoint.GetBootstrap(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+		e := &http2.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "neither finalized nor a justified checkpoint", e.Message)
+	})
+	t.Run("unknown block", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/bootstrap/{block_root}", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_root": hexutil.Encode(make([]byte, 32))})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBootstrap(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("invalid root", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/bootstrap/{block_root}", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_root": "foo"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBootstrap(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
+func TestGetUpdatesByRange(t *testing.T) {
+	setForkEpochs(t, version.Altair)
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	periodSlots := primitives.Slot(params.BeaconConfig().EpochsPerSyncCommitteePeriod) * params.BeaconConfig().SlotsPerEpoch
+	// Periods 0, 1 and 3 have an update, period 2 does not.
+	for _, period := range []uint64{0, 1, 3} {
+		slot := primitives.Slot(period) * periodSlots
+		u := util.HydrateLightClientUpdate(&lightclient.Update{AttestedHeader: util.NewLightClientHeader(t, slot), SignatureSlot: slot + 1})
+		require.NoError(t, db.SaveLightClientUpdate(ctx, period, u))
+	}
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(4*periodSlots))
+
+	s := &Server{BeaconDB: db, HeadFetcher: &mockChain.ChainService{State: st}}
+
+	t.Run("stops at the first gap", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/updates?start_period=0&count=4", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetUpdatesByRange(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		var resp []*UpdateResponse
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &resp))
+		require.Equal(t, 2, len(resp))
+		assert.Equal(t, "altair", resp[0].Version)
+		assert.Equal(t, "0", resp[0].Data.AttestedHeader.Beacon.Slot)
+	})
+	t.Run("ssz", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/updates?start_period=3&count=10", nil)
+		request.Header.Add("Accept", "application/octet-stream")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetUpdatesByRange(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		body := writer.Body.Bytes()
+		require.Equal(t, true, len(body) > 12)
+		chunkLen := binary.LittleEndian.Uint64(body[:8])
+		require.Equal(t, uint64(len(body)-8), chunkLen)
+		update := &ethpb.LightClientUpdate{}
+		require.NoError(t, update.UnmarshalSSZ(body[12:]))
+		assert.Equal(t, 3*periodSlots, update.AttestedHeader.Beacon.Slot)
+	})
+	t.Run("start period after head", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/updates?start_period=5&count=1", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetUpdatesByRange(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("zero count", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/updates?start_period=0&count=0", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetUpdatesByRange(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &http2.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "count must be greater than 0", e.Message)
+	})
+}
+
+func TestGetFinalityUpdate(t *testing.T) {
+	setForkEpochs(t, version.Altair)
+	t.Run("not available", func(t *testing.T) {
+		s := &Server{LightClientFetcher: &mockChain.ChainService{}}
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/finality_update", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetFinalityUpdate(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("ok", func(t *testing.T) {
+		u := util.HydrateLightClientUpdate(&lightclient.Update{SignatureSlot: 9})
+		u.FinalizedHeader.Beacon.Slot = 1
+		s := &Server{LightClientFetcher: &mockChain.ChainService{FinalityUpdate: lightclient.NewLightClientFinalityUpdateFromUpdate(u)}}
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/finality_update", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetFinalityUpdate(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &FinalityUpdateResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "1", resp.Data.FinalizedHeader.Beacon.Slot)
+		assert.Equal(t, "9", resp.Data.SignatureSlot)
+		assert.Equal(t, 0, len(resp.Data.AttestedHeader.Execution))
+	})
+	t.Run("capella", func(t *testing.T) {
+		setForkEpochs(t, version.Capella)
+		u := util.HydrateLightClientUpdate(&lightclient.Update{
+			AttestedHeader:  util.NewLightClientHeader(t, 8),
+			FinalizedHeader: util.NewLightClientHeader(t, 1),
+			SignatureSlot:   9,
+		})
+		s := &Server{LightClientFetcher: &mockChain.ChainService{FinalityUpdate: lightclient.NewLightClientFinalityUpdateFromUpdate(u)}}
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/finality_update", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetFinalityUpdate(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "capella", writer.Header().Get(api.VersionHeader))
+		resp := &FinalityUpdateResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		execution := &shared.ExecutionPayloadHeaderCapella{}
+		require.NoError(t, json.Unmarshal(resp.Data.AttestedHeader.Execution, execution))
+		assert.Equal(t, "8", execution.BlockNumber)
+		require.NoError(t, json.Unmarshal(resp.Data.FinalizedHeader.Execution, execution))
+		assert.Equal(t, "1", execution.BlockNumber)
+		assert.Equal(t, 4, len(resp.Data.FinalizedHeader.ExecutionBranch))
+	})
+}
+
+func TestGetOptimisticUpdate(t *testing.T) {
+	setForkEpochs(t, version.Altair)
+	t.Run("not available", func(t *testing.T) {
+		s := &Server{LightClientFetcher: &mockChain.ChainService{}}
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/optimistic_update", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetOptimisticUpdate(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("ssz", func(t *testing.T) {
+		u := util.HydrateLightClientUpdate(&lightclient.Update{SignatureSlot: 9})
+		u.AttestedHeader.Beacon.Slot = 8
+		optimistic := lightclient.NewLightClientOptimisticUpdateFromUpdate(u)
+		s := &Server{LightClientFetcher: &mockChain.ChainService{OptimisticUpdate: optimistic}}
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/optimistic_update", nil)
+		request.Header.Add("Accept", "application/octet-stream")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetOptimisticUpdate(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		expected := &ethpb.LightClientOptimisticUpdate{}
+		require.NoError(t, expected.UnmarshalSSZ(writer.Body.Bytes()))
+		assert.Equal(t, primitives.Slot(8), expected.AttestedHeader.Beacon.Slot)
+	})
+	t.Run("deneb ssz", func(t *testing.T) {
+		setForkEpochs(t, version.Deneb)
+		u := util.HydrateLightClientUpdate(&lightclient.Update{AttestedHeader: util.NewLightClientHeader(t, 8), SignatureSlot: 9})
+		s := &Server{LightClientFetcher: &mockChain.ChainService{OptimisticUpdate: lightclient.NewLightClientOptimisticUpdateFromUpdate(u)}}
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/light_client/optimistic_update", nil)
+		request.Header.Add("Accept", "application/octet-stream")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetOptimisticUpdate(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "deneb", writer.Header().Get(api.VersionHeader))
+		update := &ethpb.LightClientOptimisticUpdateDeneb{}
+		require.NoError(t, update.UnmarshalSSZ(writer.Body.Bytes()))
+		assert.Equal(t, uint64(8), update.AttestedHeader.Execution.BlockNumber)
+	})
+}
+
+// setForkEpochs schedules the forks up to the given version at genesis.
+func setForkEpochs(t *testing.T, v int) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	if v >= version.Capella {
+		cfg.BellatrixForkEpoch = 0
+		cfg.CapellaForkEpoch = 0
+	}
+	if v >= version.Deneb {
+		cfg.DenebForkEpoch = 0
+	}
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+}
@@ -0,0 +1,15 @@
+package lightclient
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+)
+
+type Server struct {
+	HeadFetcher         blockchain.HeadFetcher
+	FinalizationFetcher blockchain.FinalizationFetcher
+	LightClientFetcher  blockchain.LightClientFetcher
+	BeaconDB            db.ReadOnlyDatabase
+	StateGen            stategen.StateManager
+}
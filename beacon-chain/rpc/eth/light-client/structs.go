@@ -0,0 +1,195 @@
+package lightclient
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+type BootstrapResponse struct {
+	Version string                `json:"version"`
+	Data    *LightClientBootstrap `json:"data"`
+}
+
+type UpdateResponse struct {
+	Version string             `json:"version"`
+	Data    *LightClientUpdate `json:"data"`
+}
+
+type FinalityUpdateResponse struct {
+	Version string                     `json:"version"`
+	Data    *LightClientFinalityUpdate `json:"data"`
+}
+
+type OptimisticUpdateResponse struct {
+	Version string                       `json:"version"`
+	Data    *LightClientOptimisticUpdate `json:"data"`
+}
+
+type LightClientHeader struct {
+	Beacon          *shared.BeaconBlockHeader `json:"beacon"`
+	Execution       json.RawMessage           `json:"execution,omitempty"` // represents the execution payload header based on the version
+	ExecutionBranch []string                  `json:"execution_branch,omitempty"`
+}
+
+type SyncCommittee struct {
+	Pubkeys         []string `json:"pubkeys"`
+	AggregatePubkey string   `json:"aggregate_pubkey"`
+}
+
+type LightClientBootstrap struct {
+	Header                     *LightClientHeader `json:"header"`
+	CurrentSyncCommittee       *SyncCommittee     `json:"current_sync_committee"`
+	CurrentSyncCommitteeBranch []string           `json:"current_sync_committee_branch"`
+}
+
+type LightClientUpdate struct {
+	AttestedHeader          *LightClientHeader    `json:"attested_header"`
+	NextSyncCommittee       *SyncCommittee        `json:"next_sync_committee"`
+	NextSyncCommitteeBranch []string              `json:"next_sync_committee_branch"`
+	FinalizedHeader         *LightClientHeader    `json:"finalized_header"`
+	FinalityBranch          []string              `json:"finality_branch"`
+	SyncAggregate           *shared.SyncAggregate `json:"sync_aggregate"`
+	SignatureSlot           string                `json:"signature_slot"`
+}
+
+type LightClientFinalityUpdate struct {
+	AttestedHeader  *LightClientHeader    `json:"attested_header"`
+	FinalizedHeader *LightClientHeader    `json:"finalized_header"`
+	FinalityBranch  []string              `json:"finality_branch"`
+	SyncAggregate   *shared.SyncAggregate `json:"sync_aggregate"`
+	SignatureSlot   string                `json:"signature_slot"`
+}
+
+type LightClientOptimisticUpdate struct {
+	AttestedHeader *LightClientHeader    `json:"attested_header"`
+	SyncAggregate  *shared.SyncAggregate `json:"sync_aggregate"`
+	SignatureSlot  string                `json:"signature_slot"`
+}
+
+// lightClientHeaderFromConsensus returns the header in the container of the given fork version.
+func lightClientHeaderFromConsensus(h *lightclient.Header, v int) (*LightClientHeader, error) {
+	m, err := h.Proto(v)
+	if err != nil {
+		return nil, err
+	}
+	var execution interface{}
+	var branch [][]byte
+	switch p := m.(type) {
+	case *ethpb.LightClientHeader:
+		return &LightClientHeader{Beacon: shared.BeaconBlockHeaderFromConsensus(p.Beacon)}, nil
+	case *ethpb.LightClientHeaderCapella:
+		execution, err = shared.ExecutionPayloadHeaderCapellaFromConsensus(p.Execution)
+		branch = p.ExecutionBranch
+	case *ethpb.LightClientHeaderDeneb:
+		execution, err = shared.ExecutionPayloadHeaderDenebFromConsensus(p.Execution)
+		branch = p.ExecutionBranch
+	default:
+		return nil, errors.Errorf("unsupported light client header %T", m)
+	}
+	if err != nil {
+		return nil, err
+	}
+	enc, err := json.Marshal(execution)
+	if err != nil {
+		return nil, err
+	}
+	return &LightClientHeader{
+		Beacon:          shared.BeaconBlockHeaderFromConsensus(h.Beacon),
+		Execution:       enc,
+		ExecutionBranch: branchFromConsensus(branch),
+	}, nil
+}
+
+func syncCommitteeFromConsensus(c *ethpb.SyncCommittee) *SyncCommittee {
+	pubkeys := make([]string, len(c.Pubkeys))
+	for i, pubkey := range c.Pubkeys {
+		pubkeys[i] = hexutil.Encode(pubkey)
+	}
+	return &SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: hexutil.Encode(c.AggregatePubkey),
+	}
+}
+
+func syncAggregateFromConsensus(a *ethpb.SyncAggregate) *shared.SyncAggregate {
+	return &shared.SyncAggregate{
+		SyncCommitteeBits:      hexutil.Encode(a.SyncCommitteeBits),
+		SyncCommitteeSignature: hexutil.Encode(a.SyncCommitteeSignature),
+	}
+}
+
+func branchFromConsensus(branch [][]byte) []string {
+	b := make([]string, len(branch))
+	for i, node := range branch {
+		b[i] = hexutil.Encode(node)
+	}
+	return b
+}
+
+func lightClientBootstrapFromConsensus(b *lightclient.Bootstrap) (*LightClientBootstrap, error) {
+	header, err := lightClientHeaderFromConsensus(b.Header, b.Version())
+	if err != nil {
+		return nil, err
+	}
+	return &LightClientBootstrap{
+		Header:                     header,
+		CurrentSyncCommittee:       syncCommitteeFromConsensus(b.CurrentSyncCommittee),
+		CurrentSyncCommitteeBranch: branchFromConsensus(b.CurrentSyncCommitteeBranch),
+	}, nil
+}
+
+func lightClientUpdateFromConsensus(u *lightclient.Update) (*LightClientUpdate, error) {
+	attested, err := lightClientHeaderFromConsensus(u.AttestedHeader, u.Version())
+	if err != nil {
+		return nil, err
+	}
+	finalized, err := lightClientHeaderFromConsensus(u.FinalizedHeader, u.Version())
+	if err != nil {
+		return nil, err
+	}
+	return &LightClientUpdate{
+		AttestedHeader:          attested,
+		NextSyncCommittee:       syncCommitteeFromConsensus(u.NextSyncCommittee),
+		NextSyncCommitteeBranch: branchFromConsensus(u.NextSyncCommitteeBranch),
+		FinalizedHeader:         finalized,
+		FinalityBranch:          branchFromConsensus(u.FinalityBranch),
+		SyncAggregate:           syncAggregateFromConsensus(u.SyncAggregate),
+		SignatureSlot:           strconv.FormatUint(uint64(u.SignatureSlot), 10),
+	}, nil
+}
+
+func lightClientFinalityUpdateFromConsensus(u *lightclient.FinalityUpdate) (*LightClientFinalityUpdate, error) {
+	attested, err := lightClientHeaderFromConsensus(u.AttestedHeader, u.Version())
+	if err != nil {
+		return nil, err
+	}
+	finalized, err := lightClientHeaderFromConsensus(u.FinalizedHeader, u.Version())
+	if err != nil {
+		return nil, err
+	}
+	return &LightClientFinalityUpdate{
+		AttestedHeader:  attested,
+		FinalizedHeader: finalized,
+		FinalityBranch:  branchFromConsensus(u.FinalityBranch),
+		SyncAggregate:   syncAggregateFromConsensus(u.SyncAggregate),
+		SignatureSlot:   strconv.FormatUint(uint64(u.SignatureSlot), 10),
+	}, nil
+}
+
+func lightClientOptimisticUpdateFromConsensus(u *lightclient.OptimisticUpdate) (*LightClientOptimisticUpdate, error) {
+	attested, err := lightClientHeaderFromConsensus(u.AttestedHeader, u.Version())
+	if err != nil {
+		return nil, err
+	}
+	return &LightClientOptimisticUpdate{
+		AttestedHeader: attested,
+		SyncAggregate:  syncAggregateFromConsensus(u.SyncAggregate),
+		SignatureSlot:  strconv.FormatUint(uint64(u.SignatureSlot), 10),
+	}, nil
+}
@@ -4,10 +4,95 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/core"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/progress"
 	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"go.opencensus.io/trace"
 )
 
+// Identity retrieves data about the node's network presence. It serves /eth/v1/node/identity in place of
+// the gRPC gateway route of GetIdentity, which is kept for gRPC clients, and additionally reports the
+// sync committee subnets of the node and the fork it advertises in its ENR.
+func (s *Server) Identity(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.Identity")
+	defer span.End()
+
+	peerId := s.PeerManager.PeerID().Pretty()
+
+	serializedEnr, err := p2p.SerializeENR(s.PeerManager.ENR())
+	if err != nil {
+		http2.HandleError(w, "Could not obtain enr: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sourcep2p := s.PeerManager.Host().Addrs()
+	p2pAddresses := make([]string, len(sourcep2p))
+	for i := range sourcep2p {
+		p2pAddresses[i] = sourcep2p[i].String() + "/p2p/" + peerId
+	}
+
+	sourceDisc, err := s.PeerManager.DiscoveryAddresses()
+	if err != nil {
+		http2.HandleError(w, "Could not obtain discovery address: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	discoveryAddresses := make([]string, len(sourceDisc))
+	for i := range sourceDisc {
+		discoveryAddresses[i] = sourceDisc[i].String()
+	}
+
+	md := s.MetadataProvider.Metadata()
+	meta := &Metadata{
+		SeqNumber: strconv.FormatUint(s.MetadataProvider.MetadataSeq(), 10),
+		Attnets:   hexutil.Encode(md.AttnetsBitfield()),
+	}
+	if md.Version() >= version.Altair {
+		meta.Syncnets = hexutil.Encode(md.MetadataObjV1().Syncnets)
+	}
+
+	identity := &Identity{
+		PeerId:             peerId,
+		Enr:                "enr:" + serializedEnr,
+		P2PAddresses:       p2pAddresses,
+		DiscoveryAddresses: discoveryAddresses,
+		Metadata:           meta,
+	}
+	// The fork entry is missing from the ENR before genesis is known.
+	if forkID, err := p2p.ENRForkID(s.PeerManager.ENR()); err == nil {
+		identity.EnrForkId = &EnrForkId{
+			CurrentForkDigest: hexutil.Encode(forkID.CurrentForkDigest),
+			NextForkVersion:   hexutil.Encode(forkID.NextForkVersion),
+			NextForkEpoch:     strconv.FormatUint(uint64(forkID.NextForkEpoch), 10),
+		}
+	}
+	http2.WriteJson(w, &GetIdentityResponse{Data: identity})
+}
+
+// Health returns the health of the node as an HTTP status code: 200 when the node is synced, the status
+// given by the syncing_status query parameter, 206 by default, when it is syncing and 503 when it is not
+// initialized. It serves /eth/v1/node/health in place of the gRPC gateway route of GetHealth. As a Prysm
+// extension, the max_head_lag, max_finality_lag, min_peers, require_execution and reject_optimistic query
+// parameters report the node as unavailable when it does not meet the given criteria.
+func (s *Server) Health(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "node.Health")
+	defer span.End()
+
+	thresholds, err := core.HealthThresholdsFromRequest(r)
+	if err != nil {
+		http2.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	health, rpcErr := s.CoreService.NodeHealth(ctx, thresholds)
+	if rpcErr != nil {
+		http2.HandleError(w, rpcErr.Err.Error(), core.ErrorReasonToHTTP(rpcErr.Reason))
+		return
+	}
+	w.WriteHeader(health.StatusCode(thresholds))
+}
+
 // GetSyncStatus requests the beacon node to describe if it's currently syncing or not, and
 // if it is, what block it is up to.
 func (s *Server) GetSyncStatus(w http.ResponseWriter, r *http.Request) {
@@ -28,7 +113,27 @@ func (s *Server) GetSyncStatus(w http.ResponseWriter, r *http.Request) {
 			IsSyncing:    s.SyncChecker.Syncing(),
 			IsOptimistic: isOptimistic,
 			ElOffline:    !s.ExecutionChainInfoFetcher.ExecutionClientConnected(),
+			Stages:       syncStages(s.SyncProgress.Statuses()),
 		},
 	}
 	http2.WriteJson(w, response)
 }
+
+func syncStages(statuses []*progress.Status) []*SyncStage {
+	if len(statuses) == 0 {
+		return nil
+	}
+	stages := make([]*SyncStage, len(statuses))
+	for i, st := range statuses {
+		stages[i] = &SyncStage{
+			Name:       string(st.Stage),
+			IsComplete: st.Complete,
+			Current:    strconv.FormatUint(st.Current, 10),
+			Target:     strconv.FormatUint(st.Target, 10),
+			Rate:       strconv.FormatFloat(st.Rate, 'f', 2, 64),
+			EtaSeconds: strconv.FormatInt(int64(st.ETA.Seconds()), 10),
+			StartedAt:  strconv.FormatInt(st.Started.Unix(), 10),
+		}
+	}
+	return stages
+}
@@ -2,15 +2,28 @@ package node
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	mockp2p "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/core"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
 	syncmock "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/progress"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/wrapper"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	pb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
@@ -26,6 +39,11 @@ func TestSyncStatus(t *testing.T) {
 	chainService := &mock.ChainService{Slot: currentSlot, State: state, Optimistic: true}
 	syncChecker := &syncmock.Sync{}
 	syncChecker.IsSyncing = true
+	tracker := progress.NewTracker()
+	tracker.Start(progress.CheckpointSync, 0, 1)
+	tracker.Complete(progress.CheckpointSync)
+	tracker.Start(progress.InitialSync, 20, 110)
+	tracker.Update(progress.InitialSync, 100)
 
 	s := &Server{
 		HeadFetcher:               chainService,
@@ -33,6 +51,7 @@ func TestSyncStatus(t *testing.T) {
 		OptimisticModeFetcher:     chainService,
 		SyncChecker:               syncChecker,
 		ExecutionChainInfoFetcher: &testutil.MockExecutionChainInfoFetcher{},
+		SyncProgress:              tracker,
 	}
 
 	request := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
@@ -49,4 +68,140 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, true, resp.Data.IsSyncing)
 	assert.Equal(t, true, resp.Data.IsOptimistic)
 	assert.Equal(t, false, resp.Data.ElOffline)
+	require.Equal(t, 2, len(resp.Data.Stages))
+	assert.Equal(t, "checkpoint_sync", resp.Data.Stages[0].Name)
+	assert.Equal(t, true, resp.Data.Stages[0].IsComplete)
+	assert.Equal(t, "initial_sync", resp.Data.Stages[1].Name)
+	assert.Equal(t, false, resp.Data.Stages[1].IsComplete)
+	assert.Equal(t, "100", resp.Data.Stages[1].Current)
+	assert.Equal(t, "110", resp.Data.Stages[1].Target)
+}
+
+func TestIdentity(t *testing.T) {
+	p2pAddr, err := ma.NewMultiaddr("/ip4/7.7.7.7/udp/30303")
+	require.NoError(t, err)
+	discAddr, err := ma.NewMultiaddr("/ip4/7.7.7.7/udp/30303/p2p/QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N")
+	require.NoError(t, err)
+	forkID := &pb.ENRForkID{
+		CurrentForkDigest: []byte{1, 2, 3, 4},
+		NextForkVersion:   []byte{5, 6, 7, 8},
+		NextForkEpoch:     9,
+	}
+	enc, err := forkID.MarshalSSZ()
+	require.NoError(t, err)
+	enrRecord := &enr.Record{}
+	enrRecord.Set(enr.IPv4{7, 7, 7, 7})
+	enrRecord.Set(enr.WithEntry("eth2", enc))
+	require.NoError(t, enrRecord.SetSig(dummyIdentity{}, []byte{}))
+	attnets := bitfield.NewBitvector64()
+	attnets.SetBitAt(1, true)
+	syncnets := bitfield.NewBitvector4()
+	syncnets.SetBitAt(2, true)
+
+	t.Run("OK", func(t *testing.T) {
+		s := &Server{
+			PeerManager: &mockp2p.MockPeerManager{
+				Enr:           enrRecord,
+				PID:           "foo",
+				BHost:         &mockp2p.MockHost{Addresses: []ma.Multiaddr{p2pAddr}},
+				DiscoveryAddr: []ma.Multiaddr{discAddr},
+			},
+			MetadataProvider: &mockp2p.MockMetadataProvider{
+				Data: wrapper.WrappedMetadataV1(&pb.MetaDataV1{SeqNumber: 1, Attnets: attnets, Syncnets: syncnets}),
+			},
+		}
+
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/node/identity", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.Identity(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetIdentityResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		expectedID := peer.ID("foo").Pretty()
+		assert.Equal(t, expectedID, resp.Data.PeerId)
+		expectedEnr, err := p2p.SerializeENR(enrRecord)
+		require.NoError(t, err)
+		assert.Equal(t, "enr:"+expectedEnr, resp.Data.Enr)
+		assert.DeepEqual(t, []string{p2pAddr.String() + "/p2p/" + expectedID}, resp.Data.P2PAddresses)
+		assert.DeepEqual(t, []string{discAddr.String()}, resp.Data.DiscoveryAddresses)
+		assert.Equal(t, "1", resp.Data.Metadata.SeqNumber)
+		assert.Equal(t, "0x0200000000000000", resp.Data.Metadata.Attnets)
+		assert.Equal(t, "0x04", resp.Data.Metadata.Syncnets)
+		require.NotNil(t, resp.Data.EnrForkId)
+		assert.Equal(t, "0x01020304", resp.Data.EnrForkId.CurrentForkDigest)
+		assert.Equal(t, "0x05060708", resp.Data.EnrForkId.NextForkVersion)
+		assert.Equal(t, "9", resp.Data.EnrForkId.NextForkEpoch)
+	})
+	t.Run("ENR failure", func(t *testing.T) {
+		s := &Server{
+			PeerManager: &mockp2p.MockPeerManager{
+				Enr:   &enr.Record{},
+				PID:   "foo",
+				BHost: &mockp2p.MockHost{Addresses: []ma.Multiaddr{p2pAddr}},
+			},
+			MetadataProvider: &mockp2p.MockMetadataProvider{Data: wrapper.WrappedMetadataV0(&pb.MetaDataV0{SeqNumber: 1, Attnets: attnets})},
+		}
+
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/node/identity", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.Identity(writer, request)
+		assert.Equal(t, http.StatusInternalServerError, writer.Code)
+		e := &http2.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Could not obtain enr", e.Message)
+	})
+}
+
+func TestHealth(t *testing.T) {
+	currentSlot := primitives.Slot(100)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(90))
+	chainService := &mock.ChainService{Slot: &currentSlot, State: st, FinalizedCheckPoint: &pb.Checkpoint{Epoch: 1}}
+	checker := &syncmock.Sync{}
+	s := &Server{CoreService: &core.Service{
+		HeadFetcher:               chainService,
+		GenesisTimeFetcher:        chainService,
+		SyncChecker:               checker,
+		FinalizationFetcher:       chainService,
+		OptimisticModeFetcher:     chainService,
+		PeersFetcher:              &mockp2p.MockPeersProvider{},
+		ExecutionChainInfoFetcher: &testutil.MockExecutionChainInfoFetcher{},
+	}}
+	health := func(query string) int {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/node/health"+query, nil)
+		writer := httptest.NewRecorder()
+		s.Health(writer, request)
+		return writer.Code
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, health(""))
+	checker.IsInitialized = true
+	assert.Equal(t, http.StatusPartialContent, health(""))
+	assert.Equal(t, http.StatusOK, health("?syncing_status=200"))
+	assert.Equal(t, http.StatusBadRequest, health("?syncing_status=abc"))
+	checker.IsSynced = true
+	assert.Equal(t, http.StatusOK, health(""))
+	assert.Equal(t, http.StatusOK, health("?max_head_lag=10&max_finality_lag=2&require_execution=true"))
+	assert.Equal(t, http.StatusServiceUnavailable, health("?max_head_lag=9"))
+	assert.Equal(t, http.StatusServiceUnavailable, health("?max_finality_lag=1"))
+	assert.Equal(t, http.StatusServiceUnavailable, health("?min_peers=1"))
+	assert.Equal(t, http.StatusBadRequest, health("?min_peers=-1"))
+	chainService.Optimistic = true
+	assert.Equal(t, http.StatusOK, health(""))
+	assert.Equal(t, http.StatusServiceUnavailable, health("?reject_optimistic=true"))
+	s.CoreService.OptimisticModeFetcher = &failingOptimisticFetcher{}
+	assert.Equal(t, http.StatusServiceUnavailable, health(""))
+}
+
+type failingOptimisticFetcher struct {
+	mock.ChainService
+}
+
+func (*failingOptimisticFetcher) IsOptimistic(context.Context) (bool, error) {
+	return false, errors.New("no head")
 }
@@ -1,5 +1,31 @@
 package node
 
+type GetIdentityResponse struct {
+	Data *Identity `json:"data"`
+}
+
+type Identity struct {
+	PeerId             string    `json:"peer_id"`
+	Enr                string    `json:"enr"`
+	P2PAddresses       []string  `json:"p2p_addresses"`
+	DiscoveryAddresses []string  `json:"discovery_addresses"`
+	Metadata           *Metadata `json:"metadata"`
+	// EnrForkId is a Prysm extension detailing the fork the node advertises in its ENR.
+	EnrForkId *EnrForkId `json:"enr_fork_id,omitempty"`
+}
+
+type Metadata struct {
+	SeqNumber string `json:"seq_number"`
+	Attnets   string `json:"attnets"`
+	Syncnets  string `json:"syncnets,omitempty"`
+}
+
+type EnrForkId struct {
+	CurrentForkDigest string `json:"current_fork_digest"`
+	NextForkVersion   string `json:"next_fork_version"`
+	NextForkEpoch     string `json:"next_fork_epoch"`
+}
+
 type SyncStatusResponse struct {
 	Data *SyncStatusResponseData `json:"data"`
 }
@@ -10,4 +36,16 @@ type SyncStatusResponseData struct {
 	IsSyncing    bool   `json:"is_syncing"`
 	IsOptimistic bool   `json:"is_optimistic"`
 	ElOffline    bool   `json:"el_offline"`
+	// Stages is a Prysm extension detailing the progress of each sync stage the node went through.
+	Stages []*SyncStage `json:"stages,omitempty"`
+}
+
+type SyncStage struct {
+	Name       string `json:"name"`
+	IsComplete bool   `json:"is_complete"`
+	Current    string `json:"current"`
+	Target     string `json:"target"`
+	Rate       string `json:"rate"`
+	EtaSeconds string `json:"eta_seconds"`
+	StartedAt  string `json:"started_at"`
 }
@@ -177,7 +177,7 @@ func (s *Server) BlockRewards(w http.ResponseWriter, r *http.Request) {
 
 // AttestationRewards retrieves attestation reward info for validators specified by array of public keys or validator index.
 // If no array is provided, return reward info for every validator.
-// TODO: Inclusion delay
+// Inclusion delay rewards only exist in Phase 0, for which attestation rewards are not supported.
 func (s *Server) AttestationRewards(w http.ResponseWriter, r *http.Request) {
 	st, ok := s.attRewardsState(w, r)
 	if !ok {
@@ -446,7 +446,7 @@ func idealAttRewards(
 	increment := params.BeaconConfig().EffectiveBalanceIncrement
 	for i := minIdealBalance; i <= maxIdealBalance; i++ {
 		for _, v := range vals {
-			if v.CurrentEpochEffectiveBalance/1e9 == i {
+			if v.CurrentEpochEffectiveBalance/increment == i {
 				effectiveBalance := i * increment
 				idealVals = append(idealVals, &precompute.Validator{
 					IsActivePrevEpoch:            true,
@@ -482,6 +482,11 @@ func idealAttRewards(
 		} else {
 			idealRewards[i].Target = strconv.FormatUint(d.TargetReward, 10)
 		}
+		if d.InactivityPenalty > 0 {
+			idealRewards[i].Inactivity = fmt.Sprintf("-%s", strconv.FormatUint(d.InactivityPenalty, 10))
+		} else {
+			idealRewards[i].Inactivity = "0"
+		}
 	}
 	return idealRewards, true
 }
@@ -518,6 +523,11 @@ func totalAttRewards(
 		} else {
 			totalRewards[i].Target = strconv.FormatUint(d.TargetReward, 10)
 		}
+		if d.InactivityPenalty > 0 {
+			totalRewards[i].Inactivity = fmt.Sprintf("-%s", strconv.FormatUint(d.InactivityPenalty, 10))
+		} else {
+			totalRewards[i].Inactivity = "0"
+		}
 	}
 	return totalRewards, true
 }
@@ -349,7 +349,9 @@ func TestAttestationRewards(t *testing.T) {
 		validators[63].Slashed = true
 		require.NoError(t, st.SetValidators(validators))
 		require.NoError(t, st.SetBalances(balances))
-		require.NoError(t, st.SetInactivityScores(make([]uint64, len(validators))))
+		inactivityScores := make([]uint64, len(validators))
+		inactivityScores[63] = 100
+		require.NoError(t, st.SetInactivityScores(inactivityScores))
 		participation := make([]byte, len(validators))
 		for i := range participation {
 			participation[i] = 0b111
@@ -385,6 +387,7 @@ func TestAttestationRewards(t *testing.T) {
 		assert.Equal(t, "0", resp.Data.TotalRewards[0].Head)
 		assert.Equal(t, "-432270", resp.Data.TotalRewards[0].Source)
 		assert.Equal(t, "-802788", resp.Data.TotalRewards[0].Target)
+		assert.Equal(t, "-46938", resp.Data.TotalRewards[0].Inactivity)
 	})
 	t.Run("invalid validator index/pubkey", func(t *testing.T) {
 		url := "http://only.the.epoch.number.at.the.end.is.important/1"
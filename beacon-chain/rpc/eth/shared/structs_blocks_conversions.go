@@ -2004,6 +2004,154 @@ func BeaconBlockHeaderFromConsensus(h *eth.BeaconBlockHeader) *BeaconBlockHeader
 	}
 }
 
+func (h *BeaconBlockHeader) ToConsensus() (*eth.BeaconBlockHeader, error) {
+	slot, err := strconv.ParseUint(h.Slot, 10, 64)
+	if err != nil {
+		return nil, NewDecodeError(err, "Slot")
+	}
+	proposerIndex, err := strconv.ParseUint(h.ProposerIndex, 10, 64)
+	if err != nil {
+		return nil, NewDecodeError(err, "ProposerIndex")
+	}
+	parentRoot, err := DecodeHexWithLength(h.ParentRoot, fieldparams.RootLength)
+	if err != nil {
+		return nil, NewDecodeError(err, "ParentRoot")
+	}
+	stateRoot, err := DecodeHexWithLength(h.StateRoot, fieldparams.RootLength)
+	if err != nil {
+		return nil, NewDecodeError(err, "StateRoot")
+	}
+	bodyRoot, err := DecodeHexWithLength(h.BodyRoot, fieldparams.RootLength)
+	if err != nil {
+		return nil, NewDecodeError(err, "BodyRoot")
+	}
+	return &eth.BeaconBlockHeader{
+		Slot:          primitives.Slot(slot),
+		ProposerIndex: primitives.ValidatorIndex(proposerIndex),
+		ParentRoot:    parentRoot,
+		StateRoot:     stateRoot,
+		BodyRoot:      bodyRoot,
+	}, nil
+}
+
+func SignedBeaconBlockHeaderFromConsensus(h *eth.SignedBeaconBlockHeader) *SignedBeaconBlockHeader {
+	return &SignedBeaconBlockHeader{
+		Message:   BeaconBlockHeaderFromConsensus(h.Header),
+		Signature: hexutil.Encode(h.Signature),
+	}
+}
+
+func (h *SignedBeaconBlockHeader) ToConsensus() (*eth.SignedBeaconBlockHeader, error) {
+	if h.Message == nil {
+		return nil, NewDecodeError(errNilValue, "Message")
+	}
+	msg, err := h.Message.ToConsensus()
+	if err != nil {
+		return nil, NewDecodeError(err, "Message")
+	}
+	sig, err := DecodeHexWithLength(h.Signature, fieldparams.BLSSignatureLength)
+	if err != nil {
+		return nil, NewDecodeError(err, "Signature")
+	}
+	return &eth.SignedBeaconBlockHeader{
+		Header:    msg,
+		Signature: sig,
+	}, nil
+}
+
+func ExecutionPayloadHeaderCapellaFromConsensus(h *enginev1.ExecutionPayloadHeaderCapella) (*ExecutionPayloadHeaderCapella, error) {
+	baseFeePerGas, err := sszBytesToUint256String(h.BaseFeePerGas)
+	if err != nil {
+		return nil, err
+	}
+	return &ExecutionPayloadHeaderCapella{
+		ParentHash:       hexutil.Encode(h.ParentHash),
+		FeeRecipient:     hexutil.Encode(h.FeeRecipient),
+		StateRoot:        hexutil.Encode(h.StateRoot),
+		ReceiptsRoot:     hexutil.Encode(h.ReceiptsRoot),
+		LogsBloom:        hexutil.Encode(h.LogsBloom),
+		PrevRandao:       hexutil.Encode(h.PrevRandao),
+		BlockNumber:      fmt.Sprintf("%d", h.BlockNumber),
+		GasLimit:         fmt.Sprintf("%d", h.GasLimit),
+		GasUsed:          fmt.Sprintf("%d", h.GasUsed),
+		Timestamp:        fmt.Sprintf("%d", h.Timestamp),
+		ExtraData:        hexutil.Encode(h.ExtraData),
+		BaseFeePerGas:    baseFeePerGas,
+		BlockHash:        hexutil.Encode(h.BlockHash),
+		TransactionsRoot: hexutil.Encode(h.TransactionsRoot),
+		WithdrawalsRoot:  hexutil.Encode(h.WithdrawalsRoot),
+	}, nil
+}
+
+func ExecutionPayloadHeaderDenebFromConsensus(h *enginev1.ExecutionPayloadHeaderDeneb) (*ExecutionPayloadHeaderDeneb, error) {
+	baseFeePerGas, err := sszBytesToUint256String(h.BaseFeePerGas)
+	if err != nil {
+		return nil, err
+	}
+	return &ExecutionPayloadHeaderDeneb{
+		ParentHash:       hexutil.Encode(h.ParentHash),
+		FeeRecipient:     hexutil.Encode(h.FeeRecipient),
+		StateRoot:        hexutil.Encode(h.StateRoot),
+		ReceiptsRoot:     hexutil.Encode(h.ReceiptsRoot),
+		LogsBloom:        hexutil.Encode(h.LogsBloom),
+		PrevRandao:       hexutil.Encode(h.PrevRandao),
+		BlockNumber:      fmt.Sprintf("%d", h.BlockNumber),
+		GasLimit:         fmt.Sprintf("%d", h.GasLimit),
+		GasUsed:          fmt.Sprintf("%d", h.GasUsed),
+		Timestamp:        fmt.Sprintf("%d", h.Timestamp),
+		ExtraData:        hexutil.Encode(h.ExtraData),
+		BaseFeePerGas:    baseFeePerGas,
+		BlockHash:        hexutil.Encode(h.BlockHash),
+		TransactionsRoot: hexutil.Encode(h.TransactionsRoot),
+		WithdrawalsRoot:  hexutil.Encode(h.WithdrawalsRoot),
+		BlobGasUsed:      fmt.Sprintf("%d", h.BlobGasUsed),
+		ExcessBlobGas:    fmt.Sprintf("%d", h.ExcessBlobGas),
+	}, nil
+}
+
+func IndexedAttestationFromConsensus(a *eth.IndexedAttestation) *IndexedAttestation {
+	attestingIndices := make([]string, len(a.AttestingIndices))
+	for i, ix := range a.AttestingIndices {
+		attestingIndices[i] = strconv.FormatUint(ix, 10)
+	}
+	return &IndexedAttestation{
+		AttestingIndices: attestingIndices,
+		Data:             AttestationDataFromConsensus(a.Data),
+		Signature:        hexutil.Encode(a.Signature),
+	}
+}
+
+func (a *IndexedAttestation) ToConsensus() (*eth.IndexedAttestation, error) {
+	if a.Data == nil {
+		return nil, NewDecodeError(errNilValue, "Data")
+	}
+	err := VerifyMaxLength(a.AttestingIndices, 2048)
+	if err != nil {
+		return nil, NewDecodeError(err, "AttestingIndices")
+	}
+	attestingIndices := make([]uint64, len(a.AttestingIndices))
+	for i, ix := range a.AttestingIndices {
+		attestingIndex, err := strconv.ParseUint(ix, 10, 64)
+		if err != nil {
+			return nil, NewDecodeError(err, fmt.Sprintf("AttestingIndices[%d]", i))
+		}
+		attestingIndices[i] = attestingIndex
+	}
+	data, err := a.Data.ToConsensus()
+	if err != nil {
+		return nil, NewDecodeError(err, "Data")
+	}
+	sig, err := DecodeHexWithLength(a.Signature, fieldparams.BLSSignatureLength)
+	if err != nil {
+		return nil, NewDecodeError(err, "Signature")
+	}
+	return &eth.IndexedAttestation{
+		AttestingIndices: attestingIndices,
+		Data:             data,
+		Signature:        sig,
+	}, nil
+}
+
 func BeaconBlockFromConsensus(b *eth.BeaconBlock) (*BeaconBlock, error) {
 	proposerSlashings, err := ProposerSlashingsFromConsensus(b.Body.ProposerSlashings)
 	if err != nil {
@@ -582,6 +582,18 @@ func (s *Server) RegisterValidator(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// SubmitBeaconCommitteeSelections is served by the middleware of a distributed validator cluster, which combines
+// the partial selection proofs of the cluster. As the specification requires, a beacon node responds with 501.
+func (_ *Server) SubmitBeaconCommitteeSelections(w http.ResponseWriter, _ *http.Request) {
+	http2.HandleError(w, "Beacon committee selections are only served by distributed validator middleware", http.StatusNotImplemented)
+}
+
+// SubmitSyncCommitteeSelections is served by the middleware of a distributed validator cluster, which combines
+// the partial selection proofs of the cluster. As the specification requires, a beacon node responds with 501.
+func (_ *Server) SubmitSyncCommitteeSelections(w http.ResponseWriter, _ *http.Request) {
+	http2.HandleError(w, "Sync committee selections are only served by distributed validator middleware", http.StatusNotImplemented)
+}
+
 // PrepareBeaconProposer endpoint saves the fee recipient given a validator index, this is used when proposing a block.
 func (s *Server) PrepareBeaconProposer(w http.ResponseWriter, r *http.Request) {
 	ctx, span := trace.StartSpan(r.Context(), "validator.PrepareBeaconProposer")
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/api"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
@@ -17,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	log "github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
+	"google.golang.org/grpc"
 )
 
 // ProduceBlockV3 Requests a beacon node to produce a valid block, which can then be signed by a validator. The
@@ -79,13 +81,20 @@ func (s *Server) produceBlockV3(ctx context.Context, w http.ResponseWriter, r *h
 		log.WithError(err).Error("Checking for SSZ failed, defaulting to JSON")
 		isSSZ = false
 	}
-	v1alpha1resp, err := s.V1Alpha1Server.GetBeaconBlock(ctx, v1alpha1req)
+	// The block is produced through the v1alpha1 server, which sets the payload decision in the gRPC headers.
+	stream := &runtime.ServerTransportStream{}
+	v1alpha1resp, err := s.V1Alpha1Server.GetBeaconBlock(grpc.NewContextWithServerTransportStream(ctx, stream), v1alpha1req)
 	if err != nil {
 		http2.HandleError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	w.Header().Set(api.ExecutionPayloadBlindedHeader, fmt.Sprintf("%v", v1alpha1resp.IsBlinded))
 	w.Header().Set(api.ExecutionPayloadValueHeader, fmt.Sprintf("%d", v1alpha1resp.PayloadValue))
+	for _, h := range api.PayloadDecisionHeaders {
+		if v := stream.Header().Get(h); len(v) > 0 {
+			w.Header().Set(h, v[0])
+		}
+	}
 	phase0Block, ok := v1alpha1resp.Block.(*eth.GenericBeaconBlock_Phase0)
 	if ok {
 		handleProducePhase0V3(ctx, w, isSSZ, phase0Block, v1alpha1resp.PayloadValue)
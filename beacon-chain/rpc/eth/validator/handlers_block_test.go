@@ -2,6 +2,7 @@ package validator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -19,6 +20,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	mock2 "github.com/prysmaticlabs/prysm/v4/testing/mock"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestProduceBlockV3(t *testing.T) {
@@ -317,6 +320,36 @@ func TestProduceBlockV3(t *testing.T) {
 		assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
 		assert.Equal(t, true, strings.Contains(writer.Body.String(), "Beacon node is currently syncing and not serving request on that endpoint"))
 	})
+	t.Run("payload decision headers", func(t *testing.T) {
+		var block *shared.SignedBeaconBlock
+		err := json.Unmarshal([]byte(rpctesting.Phase0Block), &block)
+		require.NoError(t, err)
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().GetBeaconBlock(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ *eth.BlockRequest) (*eth.GenericBeaconBlock, error) {
+				require.NoError(t, grpc.SetHeader(ctx, metadata.Pairs(
+					api.PayloadSourceHeader, "builder",
+					api.PayloadSelectionReasonHeader, "builder bid above boosted local value",
+					api.LocalPayloadValueHeader, "1",
+					api.BuilderPayloadValueHeader, "2",
+				)))
+				return block.Message.ToGeneric()
+			})
+		server := &Server{
+			V1Alpha1Server: v1alpha1Server,
+			SyncChecker:    &mockSync.Sync{IsSyncing: false},
+		}
+		rr := "0x1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505"
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://foo.example/eth/v3/validator/blocks/1?randao_reveal=%s", rr), nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.ProduceBlockV3(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "builder", writer.Header().Get(api.PayloadSourceHeader))
+		assert.Equal(t, "builder bid above boosted local value", writer.Header().Get(api.PayloadSelectionReasonHeader))
+		assert.Equal(t, "1", writer.Header().Get(api.LocalPayloadValueHeader))
+		assert.Equal(t, "2", writer.Header().Get(api.BuilderPayloadValueHeader))
+	})
 }
 
 func TestProduceBlockV3SSZ(t *testing.T) {
@@ -2353,6 +2353,22 @@ func TestGetSyncCommitteeDuties(t *testing.T) {
 	})
 }
 
+func TestSubmitSelections(t *testing.T) {
+	s := &Server{}
+
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/beacon_committee_selections", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.SubmitBeaconCommitteeSelections(writer, request)
+	assert.Equal(t, http.StatusNotImplemented, writer.Code)
+
+	request = httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/validator/sync_committee_selections", nil)
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.SubmitSyncCommitteeSelections(writer, request)
+	assert.Equal(t, http.StatusNotImplemented, writer.Code)
+}
+
 func TestPrepareBeaconProposer(t *testing.T) {
 	tests := []struct {
 		name    string
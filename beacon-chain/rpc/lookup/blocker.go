@@ -10,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/era"
 )
 
 // BlockIdParseError represents an error scenario where a block ID could not be parsed.
@@ -34,10 +35,12 @@ type Blocker interface {
 	Block(ctx context.Context, id []byte) (interfaces.ReadOnlySignedBeaconBlock, error)
 }
 
-// BeaconDbBlocker is an implementation of Blocker. It retrieves blocks from the beacon chain database.
+// BeaconDbBlocker is an implementation of Blocker. It retrieves blocks from the beacon chain database,
+// falling back to the era files of the optional era store for historical blocks missing from the database.
 type BeaconDbBlocker struct {
 	BeaconDB         db.ReadOnlyDatabase
 	ChainInfoFetcher blockchain.ChainInfoFetcher
+	EraStore         *era.Store
 }
 
 // Block returns the beacon block for a given identifier. The identifier can be one of:
@@ -88,10 +91,6 @@ func (p *BeaconDbBlocker) Block(ctx context.Context, id []byte) (interfaces.Read
 			if err != nil {
 				return nil, errors.Wrapf(err, "could not retrieve block roots for slot %d", slot)
 			}
-			numBlks := len(blks)
-			if numBlks == 0 {
-				return nil, nil
-			}
 			for i, b := range blks {
 				canonical, err := p.ChainInfoFetcher.IsCanonical(ctx, roots[i])
 				if err != nil {
@@ -102,7 +101,24 @@ func (p *BeaconDbBlocker) Block(ctx context.Context, id []byte) (interfaces.Read
 					break
 				}
 			}
+			if blk == nil {
+				// Historical blocks may be missing from the database, or only their orphaned siblings may be kept.
+				return p.eraBlock(primitives.Slot(slot))
+			}
 		}
 	}
 	return blk, nil
 }
+
+// eraBlock returns the block at the given slot from the era store, if any. Era files only hold finalized
+// canonical blocks, so the block needs no canonical check.
+func (p *BeaconDbBlocker) eraBlock(slot primitives.Slot) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	if p.EraStore == nil || !p.EraStore.HasBlock(slot) {
+		return nil, nil
+	}
+	blk, err := p.EraStore.Block(slot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not retrieve block for slot %d from era files", slot)
+	}
+	return blk, nil
+}
@@ -3,7 +3,10 @@ package lookup
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strconv"
 	"testing"
 
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
@@ -11,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/era"
 	ethpbalpha "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
@@ -142,3 +146,56 @@ func TestGetBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlock_EraFallback(t *testing.T) {
+	beaconDB := dbtesting.SetupDB(t)
+	ctx := context.Background()
+
+	// An era file with blocks at the start of era 1, which are missing from the database.
+	start := era.StartSlot(1)
+	f, err := os.Create(filepath.Join(t.TempDir(), era.Filename("mainnet", 1, [32]byte{})))
+	require.NoError(t, err)
+	w, err := era.NewWriter(f, 1)
+	require.NoError(t, err)
+	want := util.NewBeaconBlock()
+	want.Block.Slot = start + 1
+	wsb, err := blocks.NewSignedBeaconBlock(want)
+	require.NoError(t, err)
+	require.NoError(t, w.AddBlock(wsb))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(era.StateSlot(1)))
+	require.NoError(t, w.Finalize(st))
+	require.NoError(t, f.Close())
+	store, err := era.NewStore(filepath.Dir(f.Name()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+
+	// An orphaned block is kept in the database at the slot of the era block.
+	orphan := util.NewBeaconBlock()
+	orphan.Block.Slot = start + 1
+	orphan.Block.ParentRoot = bytesutil.PadTo([]byte{1}, 32)
+	util.SaveBlock(t, ctx, beaconDB, orphan)
+
+	fetcher := &BeaconDbBlocker{
+		BeaconDB:         beaconDB,
+		ChainInfoFetcher: &mock.ChainService{DB: beaconDB, CanonicalRoots: map[[32]byte]bool{}},
+		EraStore:         store,
+	}
+	result, err := fetcher.Block(ctx, []byte(strconv.FormatUint(uint64(start+1), 10)))
+	require.NoError(t, err)
+	pbBlock, err := result.PbPhase0Block()
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, pbBlock)
+
+	result, err = fetcher.Block(ctx, []byte(strconv.FormatUint(uint64(start+2), 10)))
+	require.NoError(t, err)
+	assert.Equal(t, nil, result)
+
+	fetcher.EraStore = nil
+	result, err = fetcher.Block(ctx, []byte(strconv.FormatUint(uint64(start+1), 10)))
+	require.NoError(t, err)
+	assert.Equal(t, nil, result)
+}
@@ -0,0 +1,233 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corenet "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"github.com/prysmaticlabs/prysm/v4/runtime/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// GetLogLevels returns the log level of the node and the levels of the modules which override it.
+func (*Server) GetLogLevels(w http.ResponseWriter, _ *http.Request) {
+	http2.WriteJson(w, logLevelsResponse())
+}
+
+// SetLogLevel changes the log level of a single module, identified by its log prefix, or of the whole node
+// when no module is given. An empty level makes the module log at the node's level again.
+func (*Server) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req SetLogLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http2.HandleError(w, errors.Wrap(err, "Could not decode request body").Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Level == "" {
+		if req.Module == "" {
+			http2.HandleError(w, "Level is required when no module is specified", http.StatusBadRequest)
+			return
+		}
+		logging.ResetModuleLevel(req.Module)
+		log.WithField("module", req.Module).Warn("Reset module log level")
+		http2.WriteJson(w, logLevelsResponse())
+		return
+	}
+	level, err := logrus.ParseLevel(req.Level)
+	if err != nil {
+		http2.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Module == "" {
+		logging.SetLevel(level)
+	} else {
+		logging.SetModuleLevel(req.Module, level)
+	}
+	log.WithFields(logrus.Fields{"module": req.Module, "level": level}).Warn("Changed log level")
+	http2.WriteJson(w, logLevelsResponse())
+}
+
+func logLevelsResponse() *LogLevelsResponse {
+	base, modules := logging.Levels()
+	resp := &LogLevelsResponse{Data: &LogLevels{Level: base.String(), Modules: make(map[string]string, len(modules))}}
+	for m, l := range modules {
+		resp.Data.Modules[m] = l.String()
+	}
+	return resp
+}
+
+// GetFeatures returns the feature flags which can be changed at runtime and whether they are set.
+func (*Server) GetFeatures(w http.ResponseWriter, _ *http.Request) {
+	http2.WriteJson(w, &FeaturesResponse{Data: features.RuntimeToggles()})
+}
+
+// SetFeature sets or unsets a feature flag, as if the node had been started with or without it.
+func (*Server) SetFeature(w http.ResponseWriter, r *http.Request) {
+	var req SetFeatureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http2.HandleError(w, errors.Wrap(err, "Could not decode request body").Error(), http.StatusBadRequest)
+		return
+	}
+	if err := features.SetRuntimeToggle(req.Name, req.Set); err != nil {
+		http2.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http2.WriteJson(w, &FeaturesResponse{Data: features.RuntimeToggles()})
+}
+
+const (
+	defaultDiscoveryLimit = 16
+	maxDiscoveryLimit     = 64
+	discoveryTimeout      = 10 * time.Second
+)
+
+// AddPeer connects to the peer with the given multiaddress, which has to include the peer ID.
+func (s *Server) AddPeer(w http.ResponseWriter, r *http.Request) {
+	var req AddPeerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http2.HandleError(w, errors.Wrap(err, "Could not decode request body").Error(), http.StatusBadRequest)
+		return
+	}
+	info, err := peer.AddrInfoFromString(req.Addr)
+	if err != nil {
+		http2.HandleError(w, errors.Wrap(err, "Could not derive peer info from multiaddress").Error(), http.StatusBadRequest)
+		return
+	}
+	if len(info.Addrs) == 0 {
+		http2.HandleError(w, "Multiaddress has no transport address to connect to", http.StatusBadRequest)
+		return
+	}
+	if s.PeersFetcher.Peers().IsBanned(info.ID) {
+		http2.HandleError(w, "Peer is banned", http.StatusBadRequest)
+		return
+	}
+	// Keep the direction of a known peer, which is unknown for a new one until it connects.
+	direction, err := s.PeersFetcher.Peers().Direction(info.ID)
+	if err != nil {
+		direction = corenet.DirUnknown
+	}
+	s.PeersFetcher.Peers().Add(nil, info.ID, info.Addrs[0], direction)
+	if err := s.PeerController.Connect(*info); err != nil {
+		http2.HandleError(w, errors.Wrap(err, "Could not connect to peer").Error(), http.StatusInternalServerError)
+		return
+	}
+	log.WithField("peer", info.ID).Info("Connected to peer on request")
+	w.WriteHeader(http.StatusOK)
+}
+
+// DisconnectPeer closes the connections to a peer. The peer may connect again later on, unless it is banned.
+func (s *Server) DisconnectPeer(w http.ResponseWriter, r *http.Request) {
+	id, ok := peerIdFromPath(w, r)
+	if !ok {
+		return
+	}
+	if err := s.PeerManager.Disconnect(id); err != nil {
+		http2.HandleError(w, errors.Wrap(err, "Could not disconnect from peer").Error(), http.StatusInternalServerError)
+		return
+	}
+	log.WithField("peer", id).Info("Disconnected from peer on request")
+	w.WriteHeader(http.StatusOK)
+}
+
+// ListBannedPeers returns the IDs of the peers banned through BanPeer.
+func (s *Server) ListBannedPeers(w http.ResponseWriter, _ *http.Request) {
+	banned := s.PeersFetcher.Peers().GetBannedPeers()
+	ids := make([]string, len(banned))
+	for i, id := range banned {
+		ids[i] = id.String()
+	}
+	sort.Strings(ids)
+	http2.WriteJson(w, &PeerIdsResponse{Data: ids})
+}
+
+// BanPeer disconnects from a peer and refuses any further connection with it until it is unbanned.
+// Bans are not persisted and are lifted when the node restarts.
+func (s *Server) BanPeer(w http.ResponseWriter, r *http.Request) {
+	var req BanPeerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http2.HandleError(w, errors.Wrap(err, "Could not decode request body").Error(), http.StatusBadRequest)
+		return
+	}
+	id, err := peer.Decode(req.PeerId)
+	if err != nil {
+		http2.HandleError(w, errors.Wrap(err, "Could not decode peer id").Error(), http.StatusBadRequest)
+		return
+	}
+	s.PeersFetcher.Peers().BanPeers([]peer.ID{id})
+	if err := s.PeerManager.Disconnect(id); err != nil {
+		http2.HandleError(w, errors.Wrap(err, "Could not disconnect from peer").Error(), http.StatusInternalServerError)
+		return
+	}
+	log.WithField("peer", id).Warn("Banned peer on request")
+	w.WriteHeader(http.StatusOK)
+}
+
+// UnbanPeer allows connections with a banned peer again.
+func (s *Server) UnbanPeer(w http.ResponseWriter, r *http.Request) {
+	id, ok := peerIdFromPath(w, r)
+	if !ok {
+		return
+	}
+	s.PeersFetcher.Peers().UnbanPeers([]peer.ID{id})
+	log.WithField("peer", id).Info("Unbanned peer on request")
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetGossipMesh returns the peers in the gossipsub mesh of every topic the node is subscribed to.
+func (s *Server) GetGossipMesh(w http.ResponseWriter, _ *http.Request) {
+	mesh := s.PeerController.GossipMesh()
+	topics := make([]*TopicMesh, 0, len(mesh))
+	for topic, peers := range mesh {
+		ids := make([]string, len(peers))
+		for i, id := range peers {
+			ids[i] = id.String()
+		}
+		topics = append(topics, &TopicMesh{Topic: topic, Peers: ids})
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Topic < topics[j].Topic })
+	http2.WriteJson(w, &GossipMeshResponse{Data: topics})
+}
+
+// DiscoverPeers runs a discovery round and dials the nodes it finds, up to the number given by the limit query
+// parameter. It returns the IDs of the peers which could be connected to.
+func (s *Server) DiscoverPeers(w http.ResponseWriter, r *http.Request) {
+	limit := defaultDiscoveryLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		l, err := strconv.Atoi(raw)
+		if err != nil || l <= 0 || l > maxDiscoveryLimit {
+			http2.HandleError(w, "Limit must be a number between 1 and "+strconv.Itoa(maxDiscoveryLimit), http.StatusBadRequest)
+			return
+		}
+		limit = l
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), discoveryTimeout)
+	defer cancel()
+	connected, err := s.PeerController.DiscoverPeers(ctx, limit)
+	if err != nil {
+		http2.HandleError(w, errors.Wrap(err, "Could not discover peers").Error(), http.StatusInternalServerError)
+		return
+	}
+	ids := make([]string, len(connected))
+	for i, id := range connected {
+		ids[i] = id.String()
+	}
+	http2.WriteJson(w, &PeerIdsResponse{Data: ids})
+}
+
+func peerIdFromPath(w http.ResponseWriter, r *http.Request) (peer.ID, bool) {
+	segments := strings.Split(r.URL.Path, "/")
+	id, err := peer.Decode(segments[len(segments)-1])
+	if err != nil {
+		http2.HandleError(w, errors.Wrap(err, "Could not decode peer id").Error(), http.StatusBadRequest)
+		return "", false
+	}
+	return id, true
+}
@@ -0,0 +1,281 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corenet "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	mockp2p "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"github.com/prysmaticlabs/prysm/v4/runtime/logging"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/sirupsen/logrus"
+)
+
+func TestAuthenticate(t *testing.T) {
+	s := &Server{Tokens: []string{"foo", "bar"}}
+	h := s.Authenticate(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/admin/features", nil)
+		request.Header.Set("Authorization", "Bearer bar")
+		writer := httptest.NewRecorder()
+		h(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/admin/features", nil)
+		request.Header.Set("Authorization", "Bearer baz")
+		writer := httptest.NewRecorder()
+		h(writer, request)
+		assert.Equal(t, http.StatusUnauthorized, writer.Code)
+	})
+	t.Run("no token", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/admin/features", nil)
+		writer := httptest.NewRecorder()
+		h(writer, request)
+		assert.Equal(t, http.StatusUnauthorized, writer.Code)
+		assert.Equal(t, "Bearer", writer.Header().Get("WWW-Authenticate"))
+	})
+}
+
+func TestSetLogLevel(t *testing.T) {
+	prevLevel, _ := logging.Levels()
+	defer func() {
+		logging.SetLevel(prevLevel)
+		logging.ResetModuleLevel("sync")
+	}()
+	logging.SetLevel(logrus.InfoLevel)
+	s := &Server{}
+
+	t.Run("module", func(t *testing.T) {
+		body, err := json.Marshal(&SetLogLevelRequest{Module: "sync", Level: "debug"})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/log_levels", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.SetLogLevel(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &LogLevelsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "info", resp.Data.Level)
+		assert.Equal(t, "debug", resp.Data.Modules["sync"])
+	})
+	t.Run("reset module", func(t *testing.T) {
+		body, err := json.Marshal(&SetLogLevelRequest{Module: "sync"})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/log_levels", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.SetLogLevel(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &LogLevelsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, 0, len(resp.Data.Modules))
+	})
+	t.Run("base level", func(t *testing.T) {
+		body, err := json.Marshal(&SetLogLevelRequest{Level: "warn"})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/log_levels", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.SetLogLevel(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, logrus.WarnLevel, logrus.GetLevel())
+	})
+	t.Run("invalid level", func(t *testing.T) {
+		body, err := json.Marshal(&SetLogLevelRequest{Module: "sync", Level: "loud"})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/log_levels", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.SetLogLevel(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("no module and level", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/log_levels", bytes.NewReader([]byte("{}")))
+		writer := httptest.NewRecorder()
+		s.SetLogLevel(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &http2.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Level is required", e.Message)
+	})
+}
+
+func TestSetFeature(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{})
+	defer resetCfg()
+	s := &Server{}
+
+	t.Run("OK", func(t *testing.T) {
+		body, err := json.Marshal(&SetFeatureRequest{Name: "enable-verbose-sig-verification", Set: true})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/features", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.SetFeature(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &FeaturesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.Data["enable-verbose-sig-verification"])
+		assert.Equal(t, true, features.Get().EnableVerboseSigVerification)
+	})
+	t.Run("not changeable", func(t *testing.T) {
+		body, err := json.Marshal(&SetFeatureRequest{Name: "slasher", Set: true})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/features", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.SetFeature(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.Equal(t, false, features.Get().EnableSlasher)
+	})
+}
+
+func TestAddPeer(t *testing.T) {
+	peersProvider := &mockp2p.MockPeersProvider{}
+	peersProvider.ClearPeers()
+	controller := &mockp2p.MockPeerController{}
+	s := &Server{PeersFetcher: peersProvider, PeerController: controller}
+	addr := "/ip4/127.0.0.1/tcp/30303/p2p/16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ"
+
+	t.Run("OK", func(t *testing.T) {
+		body, err := json.Marshal(&AddPeerRequest{Addr: addr})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/peers", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.AddPeer(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, 1, len(controller.Connected))
+		assert.Equal(t, "16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ", controller.Connected[0].ID.String())
+		_, err = peersProvider.Peers().Address(controller.Connected[0].ID)
+		require.NoError(t, err)
+	})
+	t.Run("banned", func(t *testing.T) {
+		id, err := peer.Decode("16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ")
+		require.NoError(t, err)
+		peersProvider.Peers().BanPeers([]peer.ID{id})
+		defer peersProvider.Peers().UnbanPeers([]peer.ID{id})
+		body, err := json.Marshal(&AddPeerRequest{Addr: addr})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/peers", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.AddPeer(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("invalid address", func(t *testing.T) {
+		body, err := json.Marshal(&AddPeerRequest{Addr: "/ip4/127.0.0.1/tcp/30303"})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/peers", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.AddPeer(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("no transport address", func(t *testing.T) {
+		body, err := json.Marshal(&AddPeerRequest{Addr: "/p2p/16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ"})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/peers", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.AddPeer(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("known peer keeps its direction", func(t *testing.T) {
+		id, err := peer.Decode("16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ")
+		require.NoError(t, err)
+		ma, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/30303")
+		require.NoError(t, err)
+		peersProvider.Peers().Add(nil, id, ma, corenet.DirInbound)
+		body, err := json.Marshal(&AddPeerRequest{Addr: addr})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/peers", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.AddPeer(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		direction, err := peersProvider.Peers().Direction(id)
+		require.NoError(t, err)
+		assert.Equal(t, corenet.DirInbound, direction)
+	})
+}
+
+func TestBanAndUnbanPeer(t *testing.T) {
+	peersProvider := &mockp2p.MockPeersProvider{}
+	peersProvider.ClearPeers()
+	s := &Server{PeersFetcher: peersProvider, PeerManager: &mockp2p.MockPeerManager{}}
+	id := "16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ"
+
+	body, err := json.Marshal(&BanPeerRequest{PeerId: id})
+	require.NoError(t, err)
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/peers/banned", bytes.NewReader(body))
+	writer := httptest.NewRecorder()
+	s.BanPeer(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+
+	request = httptest.NewRequest(http.MethodGet, "http://example.com/prysm/admin/peers/banned", nil)
+	writer = httptest.NewRecorder()
+	s.ListBannedPeers(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &PeerIdsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.DeepEqual(t, []string{id}, resp.Data)
+
+	request = httptest.NewRequest(http.MethodDelete, "http://example.com/prysm/admin/peers/banned/"+id, nil)
+	writer = httptest.NewRecorder()
+	s.UnbanPeer(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, 0, len(peersProvider.Peers().GetBannedPeers()))
+
+	request = httptest.NewRequest(http.MethodDelete, "http://example.com/prysm/admin/peers/banned/foo", nil)
+	writer = httptest.NewRecorder()
+	s.UnbanPeer(writer, request)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestDisconnectPeer(t *testing.T) {
+	s := &Server{PeerManager: &mockp2p.MockPeerManager{}}
+	request := httptest.NewRequest(http.MethodDelete, "http://example.com/prysm/admin/peers/16Uiu2HAm1n583t4huDMMqEUUBuQs6bLts21mxCfX3tiqu9JfHvRJ", nil)
+	writer := httptest.NewRecorder()
+	s.DisconnectPeer(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+}
+
+func TestGetGossipMesh(t *testing.T) {
+	s := &Server{PeerController: &mockp2p.MockPeerController{Mesh: map[string][]peer.ID{
+		"/eth2/b/beacon_block/ssz_snappy":     {"a", "b"},
+		"/eth2/a/beacon_aggregate/ssz_snappy": {},
+	}}}
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/admin/gossip/mesh", nil)
+	writer := httptest.NewRecorder()
+	s.GetGossipMesh(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &GossipMeshResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 2, len(resp.Data))
+	assert.Equal(t, "/eth2/a/beacon_aggregate/ssz_snappy", resp.Data[0].Topic)
+	assert.Equal(t, 0, len(resp.Data[0].Peers))
+	assert.Equal(t, "/eth2/b/beacon_block/ssz_snappy", resp.Data[1].Topic)
+	assert.DeepEqual(t, []string{peer.ID("a").String(), peer.ID("b").String()}, resp.Data[1].Peers)
+}
+
+func TestDiscoverPeers(t *testing.T) {
+	s := &Server{PeerController: &mockp2p.MockPeerController{Discovered: []peer.ID{"a", "b", "c"}}}
+
+	t.Run("OK", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/peers/discover?limit=2", nil)
+		writer := httptest.NewRecorder()
+		s.DiscoverPeers(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &PeerIdsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, 2, len(resp.Data))
+	})
+	t.Run("invalid limit", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/admin/peers/discover?limit=1000", nil)
+		writer := httptest.NewRecorder()
+		s.DiscoverPeers(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
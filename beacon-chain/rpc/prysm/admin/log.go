@@ -0,0 +1,5 @@
+package admin
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "rpc/admin")
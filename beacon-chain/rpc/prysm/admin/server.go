@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+)
+
+// Server serves the endpoints which change the behavior of the running node. Every request
+// must carry one of the admin tokens as a bearer token.
+type Server struct {
+	Tokens         []string
+	PeersFetcher   p2p.PeersProvider
+	PeerManager    p2p.PeerManager
+	PeerController p2p.PeerController
+}
+
+// Authenticate wraps a handler so that it is only called for requests carrying one of the admin tokens.
+func (s *Server) Authenticate(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorized(r) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http2.HandleError(w, "Unauthorized, a valid admin token is required", http.StatusUnauthorized)
+			return
+		}
+		h(w, r)
+	}
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return false
+	}
+	authorized := false
+	for _, t := range s.Tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+			authorized = true
+		}
+	}
+	return authorized
+}
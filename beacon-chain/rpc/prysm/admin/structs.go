@@ -0,0 +1,45 @@
+package admin
+
+type LogLevelsResponse struct {
+	Data *LogLevels `json:"data"`
+}
+
+type LogLevels struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules"`
+}
+
+type SetLogLevelRequest struct {
+	Module string `json:"module"`
+	Level  string `json:"level"`
+}
+
+type FeaturesResponse struct {
+	Data map[string]bool `json:"data"`
+}
+
+type SetFeatureRequest struct {
+	Name string `json:"name"`
+	Set  bool   `json:"set"`
+}
+
+type AddPeerRequest struct {
+	Addr string `json:"addr"`
+}
+
+type BanPeerRequest struct {
+	PeerId string `json:"peer_id"`
+}
+
+type PeerIdsResponse struct {
+	Data []string `json:"data"`
+}
+
+type GossipMeshResponse struct {
+	Data []*TopicMesh `json:"data"`
+}
+
+type TopicMesh struct {
+	Topic string   `json:"topic"`
+	Peers []string `json:"peers"`
+}
@@ -0,0 +1,180 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"go.opencensus.io/trace"
+)
+
+// GetStateProof is a HTTP handler that serves the GET /prysm/v1/beacon/states/{state_id}/proof endpoint. It returns
+// a Merkle proof of the node of the state at the generalized index given by the gindex query parameter, or at the
+// path given by the path query parameter, such as historical_roots.12, validators.5.effective_balance or
+// latest_execution_payload_header.block_hash.
+//
+// Example usage:
+//
+//	GET /prysm/v1/beacon/states/head/proof?path=finalized_checkpoint.root
+func (s *Server) GetStateProof(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetStateProof")
+	defer span.End()
+
+	stateID := mux.Vars(r)["state_id"]
+	if stateID == "" {
+		http2.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	st, err := s.Stater.State(ctx, []byte(stateID))
+	if err != nil {
+		shared.WriteStateFetchError(w, err)
+		return
+	}
+	gindex, ok := generalizedIndex(w, r, st.ToProtoUnsafe())
+	if !ok {
+		return
+	}
+	proof, err := st.GeneralizedIndexProof(ctx, gindex)
+	if err != nil {
+		http2.HandleError(w, "Could not prove generalized index: "+err.Error(), proofErrorStatus(err))
+		return
+	}
+	root, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		http2.HandleError(w, "Could not hash state: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	optimistic, finalized, err := s.stateStatus(ctx, stateID, st)
+	if err != nil {
+		http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http2.WriteJson(w, &ProofResponse{
+		Data:                proofJson(proof, root),
+		ExecutionOptimistic: optimistic,
+		Finalized:           finalized,
+	})
+}
+
+// GetBlockProof is a HTTP handler that serves the GET /prysm/v1/beacon/blocks/{block_id}/proof endpoint. It returns
+// a Merkle proof of the node of the block at the generalized index given by the gindex query parameter, or at the
+// path given by the path query parameter, such as body.execution_payload.block_hash. The root of the proof is the
+// block root.
+//
+// Example usage:
+//
+//	GET /prysm/v1/beacon/blocks/head/proof?path=state_root
+func (s *Server) GetBlockProof(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetBlockProof")
+	defer span.End()
+
+	blockID := mux.Vars(r)["block_id"]
+	if blockID == "" {
+		http2.HandleError(w, "block_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	blk, err := s.Blocker.Block(ctx, []byte(blockID))
+	if !shared.WriteBlockFetchError(w, blk, err) {
+		return
+	}
+	pb, err := blk.Block().Proto()
+	if err != nil {
+		http2.HandleError(w, "Could not get block: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	gindex, ok := generalizedIndex(w, r, pb)
+	if !ok {
+		return
+	}
+	proof, err := ssz.ProveGeneralizedIndex(pb, gindex)
+	if err != nil {
+		http2.HandleError(w, "Could not prove generalized index: "+err.Error(), proofErrorStatus(err))
+		return
+	}
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		http2.HandleError(w, "Could not hash block: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	optimistic, err := s.OptimisticModeFetcher.IsOptimisticForRoot(ctx, root)
+	if err != nil {
+		http2.HandleError(w, "Could not check if block is optimistic: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http2.WriteJson(w, &ProofResponse{
+		Data:                proofJson(proof, root),
+		ExecutionOptimistic: optimistic,
+		Finalized:           s.FinalizationFetcher.IsFinalized(ctx, root),
+	})
+}
+
+// stateStatus returns whether the state of the state ID is optimistic and whether it is finalized.
+func (s *Server) stateStatus(ctx context.Context, stateID string, st state.BeaconState) (bool, bool, error) {
+	optimistic, err := helpers.IsOptimistic(ctx, []byte(stateID), s.OptimisticModeFetcher, s.Stater, s.ChainInfoFetcher, s.BeaconDB)
+	if err != nil {
+		return false, false, errors.Wrap(err, "Could not check if state is optimistic")
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		return false, false, errors.Wrap(err, "Could not calculate root of latest block header")
+	}
+	return optimistic, s.FinalizationFetcher.IsFinalized(ctx, blockRoot), nil
+}
+
+// generalizedIndex returns the generalized index requested by the gindex or the path query parameter.
+func generalizedIndex(w http.ResponseWriter, r *http.Request, obj interface{}) (uint64, bool) {
+	rawGindex, path := r.URL.Query().Get("gindex"), r.URL.Query().Get("path")
+	switch {
+	case rawGindex != "" && path != "":
+		http2.HandleError(w, "Only one of gindex and path can be specified", http.StatusBadRequest)
+		return 0, false
+	case rawGindex != "":
+		gindex, err := strconv.ParseUint(rawGindex, 10, 64)
+		if err != nil || gindex == 0 {
+			http2.HandleError(w, fmt.Sprintf("Invalid generalized index %s", rawGindex), http.StatusBadRequest)
+			return 0, false
+		}
+		return gindex, true
+	case path != "":
+		gindex, err := ssz.GeneralizedIndex(obj, path)
+		if err != nil {
+			http2.HandleError(w, "Invalid path: "+err.Error(), http.StatusBadRequest)
+			return 0, false
+		}
+		return gindex, true
+	default:
+		http2.HandleError(w, "One of gindex and path is required in query params", http.StatusBadRequest)
+		return 0, false
+	}
+}
+
+// proofErrorStatus returns the HTTP status code of a proof error: 400 when the generalized index does not designate
+// a node, 500 otherwise.
+func proofErrorStatus(err error) int {
+	if errors.Is(err, ssz.ErrInvalidGeneralizedIndex) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func proofJson(proof *ssz.Proof, root [32]byte) *Proof {
+	branch := make([]string, len(proof.Branch))
+	for i := range proof.Branch {
+		branch[i] = hexutil.Encode(proof.Branch[i][:])
+	}
+	return &Proof{
+		GeneralizedIndex: strconv.FormatUint(proof.GeneralizedIndex, 10),
+		Leaf:             hexutil.Encode(proof.Leaf[:]),
+		Branch:           branch,
+		Root:             hexutil.Encode(root[:]),
+	}
+}
@@ -0,0 +1,150 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	mockChain "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+// decodeProof decodes the proof of a response, and checks it against the root of the response.
+func decodeProof(t *testing.T, p *Proof) *ssz.Proof {
+	gindex, err := strconv.ParseUint(p.GeneralizedIndex, 10, 64)
+	require.NoError(t, err)
+	leaf, err := hexutil.Decode(p.Leaf)
+	require.NoError(t, err)
+	root, err := hexutil.Decode(p.Root)
+	require.NoError(t, err)
+	proof := &ssz.Proof{GeneralizedIndex: gindex, Leaf: bytesutil.ToBytes32(leaf)}
+	for _, b := range p.Branch {
+		node, err := hexutil.Decode(b)
+		require.NoError(t, err)
+		proof.Branch = append(proof.Branch, bytesutil.ToBytes32(node))
+	}
+	require.Equal(t, true, proof.Verify(bytesutil.ToBytes32(root)))
+	return proof
+}
+
+func TestGetStateProof(t *testing.T) {
+	st, err := util.NewBeaconStateCapella(func(st *eth.BeaconStateCapella) error {
+		st.HistoricalRoots = [][]byte{bytesutil.PadTo([]byte{1}, 32), bytesutil.PadTo([]byte{2}, 32)}
+		return nil
+	})
+	require.NoError(t, err)
+	stateRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	chain := &mockChain.ChainService{Optimistic: true}
+	s := &Server{
+		Stater:                &testutil.MockStater{BeaconState: st},
+		OptimisticModeFetcher: chain,
+		FinalizationFetcher:   chain,
+	}
+
+	t.Run("path", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/states/head/proof?path=historical_roots.1", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetStateProof(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ProofResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, hexutil.Encode(stateRoot[:]), resp.Data.Root)
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+		proof := decodeProof(t, resp.Data)
+		assert.Equal(t, uint64(78<<24|1), proof.GeneralizedIndex)
+		assert.Equal(t, bytesutil.ToBytes32(bytesutil.PadTo([]byte{2}, 32)), proof.Leaf)
+	})
+	t.Run("gindex", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/states/head/proof?gindex=105", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetStateProof(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ProofResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		proof := decodeProof(t, resp.Data)
+		assert.Equal(t, bytesutil.ToBytes32(st.FinalizedCheckpoint().Root), proof.Leaf)
+		assert.Equal(t, 6, len(proof.Branch))
+	})
+	t.Run("invalid requests", func(t *testing.T) {
+		tests := []struct {
+			query string
+			err   string
+		}{
+			{query: "", err: "One of gindex and path is required in query params"},
+			{query: "?gindex=1&path=slot", err: "Only one of gindex and path can be specified"},
+			{query: "?gindex=0", err: "Invalid generalized index 0"},
+			{query: "?path=foo", err: "Invalid path: unknown field foo"},
+			{query: "?gindex=68", err: "Could not prove generalized index"},
+		}
+		for _, tt := range tests {
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/states/head/proof"+tt.query, nil)
+			request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.GetStateProof(writer, request)
+			require.Equal(t, http.StatusBadRequest, writer.Code)
+			e := &http2.DefaultErrorJson{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			assert.StringContains(t, tt.err, e.Message)
+		}
+	})
+}
+
+func TestProofErrorStatus(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, proofErrorStatus(errors.Wrap(ssz.ErrInvalidGeneralizedIndex, "goes below a basic value")))
+	assert.Equal(t, http.StatusInternalServerError, proofErrorStatus(errors.New("could not reproduce the root of a composite value")))
+}
+
+func TestGetBlockProof(t *testing.T) {
+	b := util.NewBeaconBlockCapella()
+	b.Block.Slot = 123
+	b.Block.Body.ExecutionPayload.BlockHash = bytesutil.PadTo([]byte{0xb1}, 32)
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	blockRoot, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+	chain := &mockChain.ChainService{FinalizedRoots: map[[32]byte]bool{blockRoot: true}}
+	s := &Server{
+		Blocker:               &testutil.MockBlocker{BlockToReturn: blk},
+		OptimisticModeFetcher: chain,
+		FinalizationFetcher:   chain,
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/blocks/head/proof?path=body.execution_payload.block_hash", nil)
+	request = mux.SetURLVars(request, map[string]string{"block_id": "head"})
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetBlockProof(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &ProofResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, hexutil.Encode(blockRoot[:]), resp.Data.Root)
+	assert.Equal(t, false, resp.ExecutionOptimistic)
+	assert.Equal(t, true, resp.Finalized)
+	proof := decodeProof(t, resp.Data)
+	assert.Equal(t, bytesutil.ToBytes32(b.Block.Body.ExecutionPayload.BlockHash), proof.Leaf)
+}
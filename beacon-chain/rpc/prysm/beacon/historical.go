@@ -0,0 +1,230 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"go.opencensus.io/trace"
+)
+
+// GetHistoricalSummaries is a HTTP handler that serves the GET /prysm/v1/beacon/states/{state_id}/historical_summaries
+// endpoint. It returns the historical summaries of the state, which hold the roots of the block roots and state roots
+// of each period of SLOTS_PER_HISTORICAL_ROOT slots since Capella.
+func (s *Server) GetHistoricalSummaries(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetHistoricalSummaries")
+	defer span.End()
+
+	stateID := mux.Vars(r)["state_id"]
+	if stateID == "" {
+		http2.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	st, err := s.Stater.State(ctx, []byte(stateID))
+	if err != nil {
+		shared.WriteStateFetchError(w, err)
+		return
+	}
+	if st.Version() < version.Capella {
+		http2.HandleError(w, "Historical summaries are not supported before Capella", http.StatusBadRequest)
+		return
+	}
+	summaries, err := st.HistoricalSummaries()
+	if err != nil {
+		http2.HandleError(w, "Could not get historical summaries: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*HistoricalSummary, len(summaries))
+	for i, summary := range summaries {
+		data[i] = &HistoricalSummary{
+			BlockSummaryRoot: hexutil.Encode(summary.BlockSummaryRoot),
+			StateSummaryRoot: hexutil.Encode(summary.StateSummaryRoot),
+		}
+	}
+	optimistic, finalized, err := s.stateStatus(ctx, stateID, st)
+	if err != nil {
+		http2.HandleError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http2.WriteJson(w, &HistoricalSummariesResponse{
+		Data:                data,
+		ExecutionOptimistic: optimistic,
+		Finalized:           finalized,
+	})
+}
+
+// GetBlockRootProof is a HTTP handler that serves the GET /prysm/v1/beacon/blocks/{block_id}/block_root_proof
+// endpoint. It returns a Merkle proof of the block root at the slot given by the slot query parameter against the
+// root of the requested block, such as a beacon block root made available to smart contracts by EIP-4788.
+//
+// The proof goes from the block to its state root, then to the block roots of the state for the last
+// SLOTS_PER_HISTORICAL_ROOT slots, or to the historical summary or historical root of the period of older slots and
+// down the block roots of that period. The block root of a slot without a block is the root of the latest block
+// before it.
+//
+// Example usage:
+//
+//	GET /prysm/v1/beacon/blocks/0x4a5f.../block_root_proof?slot=6000000
+func (s *Server) GetBlockRootProof(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetBlockRootProof")
+	defer span.End()
+
+	blockID := mux.Vars(r)["block_id"]
+	if blockID == "" {
+		http2.HandleError(w, "block_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	rawSlot := r.URL.Query().Get("slot")
+	slot, err := strconv.ParseUint(rawSlot, 10, 64)
+	if err != nil {
+		http2.HandleError(w, fmt.Sprintf("Invalid slot %s", rawSlot), http.StatusBadRequest)
+		return
+	}
+	blk, err := s.Blocker.Block(ctx, []byte(blockID))
+	if !shared.WriteBlockFetchError(w, blk, err) {
+		return
+	}
+	blockRoot, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		http2.HandleError(w, "Could not hash block: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if primitives.Slot(slot) > blk.Block().Slot() {
+		http2.HandleError(w, fmt.Sprintf("Slot %d is after the slot %d of the block", slot, blk.Block().Slot()), http.StatusBadRequest)
+		return
+	}
+
+	proof := &ssz.Proof{GeneralizedIndex: 1, Leaf: blockRoot}
+	if primitives.Slot(slot) < blk.Block().Slot() {
+		proof, err = s.historicalBlockRootProof(ctx, blk.Block(), primitives.Slot(slot))
+		if err != nil {
+			var reqErr *blockRootRequestError
+			if errors.As(err, &reqErr) {
+				http2.HandleError(w, "Could not prove block root: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			http2.HandleError(w, "Could not prove block root: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if !proof.Verify(blockRoot) {
+		http2.HandleError(w, "Could not reproduce the block root from the historical states", http.StatusInternalServerError)
+		return
+	}
+	optimistic, err := s.OptimisticModeFetcher.IsOptimisticForRoot(ctx, blockRoot)
+	if err != nil {
+		http2.HandleError(w, "Could not check if block is optimistic: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http2.WriteJson(w, &ProofResponse{
+		Data:                proofJson(proof, blockRoot),
+		ExecutionOptimistic: optimistic,
+		Finalized:           s.FinalizationFetcher.IsFinalized(ctx, blockRoot),
+	})
+}
+
+// blockRootRequestError is returned for block roots which cannot be proven against the requested block.
+type blockRootRequestError struct {
+	msg string
+}
+
+func (e *blockRootRequestError) Error() string {
+	return e.msg
+}
+
+// historicalBlockRootProof proves the block root at a slot before the slot of the block against the block root.
+func (s *Server) historicalBlockRootProof(ctx context.Context, blk interfaces.ReadOnlyBeaconBlock, slot primitives.Slot) (*ssz.Proof, error) {
+	pb, err := blk.Proto()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block")
+	}
+	stateRootIndex, err := ssz.GeneralizedIndex(pb, "state_root")
+	if err != nil {
+		return nil, err
+	}
+	blockProof, err := ssz.ProveGeneralizedIndex(pb, stateRootIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not prove the state root of the block")
+	}
+	stateRoot := blk.StateRoot()
+	st, err := s.Stater.State(ctx, stateRoot[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get the state of the block")
+	}
+
+	rootsPerPeriod := params.BeaconConfig().SlotsPerHistoricalRoot
+	idx := uint64(slot % rootsPerPeriod)
+	if blk.Slot()-slot <= rootsPerPeriod {
+		// The block roots of the state still hold the block root of the slot.
+		stateProof, err := proveStatePath(ctx, st, fmt.Sprintf("block_roots.%d", idx))
+		if err != nil {
+			return nil, err
+		}
+		return ssz.ConcatProofs(blockProof, stateProof), nil
+	}
+
+	// The block roots of older periods are summarized in the historical summaries since Capella, and in the
+	// historical roots before. The block roots of a period are those of the state at the start of the next period.
+	period := uint64(slot / rootsPerPeriod)
+	periodState, err := s.Stater.StateBySlot(ctx, primitives.Slot(period+1)*rootsPerPeriod)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get the state at the end of period %d", period)
+	}
+	periodProof, err := proveStatePath(ctx, periodState, fmt.Sprintf("block_roots.%d", idx))
+	if err != nil {
+		return nil, err
+	}
+	// The first levels of the proof go through the tree of the block roots vector.
+	depth := bits.Len64(uint64(rootsPerPeriod)) - 1
+	rootsProof := &ssz.Proof{
+		GeneralizedIndex: uint64(rootsPerPeriod) + idx,
+		Leaf:             periodProof.Leaf,
+		Branch:           periodProof.Branch[:depth],
+	}
+
+	capellaPeriod := uint64(params.BeaconConfig().CapellaForkEpoch) * uint64(params.BeaconConfig().SlotsPerEpoch) / uint64(rootsPerPeriod)
+	if st.Version() >= version.Capella && period >= capellaPeriod {
+		stateProof, err := proveStatePath(ctx, st, fmt.Sprintf("historical_summaries.%d.block_summary_root", period-capellaPeriod))
+		if err != nil {
+			return nil, err
+		}
+		return ssz.ConcatProofs(blockProof, stateProof, rootsProof), nil
+	}
+
+	// Historical roots are the roots of historical batches, whose block roots are next to their state roots.
+	stateProof, err := proveStatePath(ctx, st, fmt.Sprintf("historical_roots.%d", period))
+	if err != nil {
+		return nil, err
+	}
+	stateRootsProof, err := proveStatePath(ctx, periodState, "state_roots")
+	if err != nil {
+		return nil, err
+	}
+	batchProof := &ssz.Proof{
+		GeneralizedIndex: 2,
+		Branch:           [][32]byte{stateRootsProof.Leaf},
+	}
+	return ssz.ConcatProofs(blockProof, stateProof, batchProof, rootsProof), nil
+}
+
+// proveStatePath proves the node at a path of the state against the state root.
+func proveStatePath(ctx context.Context, st state.BeaconState, path string) (*ssz.Proof, error) {
+	gindex, err := ssz.GeneralizedIndex(st.ToProtoUnsafe(), path)
+	if err != nil {
+		return nil, &blockRootRequestError{msg: fmt.Sprintf("state at slot %d has no %s: %v", st.Slot(), path, err)}
+	}
+	return st.GeneralizedIndexProof(ctx, gindex)
+}
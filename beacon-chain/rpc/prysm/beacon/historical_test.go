@@ -0,0 +1,173 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	mockChain "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func blockRoots(seed byte) [][]byte {
+	roots := make([][]byte, fieldparams.BlockRootsLength)
+	for i := range roots {
+		roots[i] = bytesutil.PadTo([]byte{seed, byte(i), byte(i >> 8)}, 32)
+	}
+	return roots
+}
+
+func TestGetHistoricalSummaries(t *testing.T) {
+	st, err := util.NewBeaconStateCapella(func(st *eth.BeaconStateCapella) error {
+		st.HistoricalSummaries = []*eth.HistoricalSummary{
+			{BlockSummaryRoot: bytesutil.PadTo([]byte{1}, 32), StateSummaryRoot: bytesutil.PadTo([]byte{2}, 32)},
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	chain := &mockChain.ChainService{Optimistic: true}
+	s := &Server{
+		Stater:                &testutil.MockStater{BeaconState: st},
+		OptimisticModeFetcher: chain,
+		FinalizationFetcher:   chain,
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/states/head/historical_summaries", nil)
+	request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetHistoricalSummaries(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &HistoricalSummariesResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data))
+	assert.Equal(t, true, resp.ExecutionOptimistic)
+	assert.Equal(t, hexutil.Encode(bytesutil.PadTo([]byte{1}, 32)), resp.Data[0].BlockSummaryRoot)
+	assert.Equal(t, hexutil.Encode(bytesutil.PadTo([]byte{2}, 32)), resp.Data[0].StateSummaryRoot)
+}
+
+func TestGetBlockRootProof(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.CapellaForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+	ctx := context.Background()
+	rootsPerPeriod := params.BeaconConfig().SlotsPerHistoricalRoot
+
+	// The state at the end of the first period, whose block roots are summarized in the state of the block.
+	periodState, err := util.NewBeaconStateCapella(func(st *eth.BeaconStateCapella) error {
+		st.Slot = rootsPerPeriod
+		st.BlockRoots = blockRoots(1)
+		return nil
+	})
+	require.NoError(t, err)
+	summaryRoot, err := proveStatePath(ctx, periodState, "block_roots")
+	require.NoError(t, err)
+
+	blockSlot := 3*rootsPerPeriod + 5
+	blockState, err := util.NewBeaconStateCapella(func(st *eth.BeaconStateCapella) error {
+		st.Slot = blockSlot
+		st.BlockRoots = blockRoots(2)
+		st.HistoricalSummaries = []*eth.HistoricalSummary{
+			{BlockSummaryRoot: summaryRoot.Leaf[:], StateSummaryRoot: make([]byte, 32)},
+			{BlockSummaryRoot: make([]byte, 32), StateSummaryRoot: make([]byte, 32)},
+			{BlockSummaryRoot: make([]byte, 32), StateSummaryRoot: make([]byte, 32)},
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	stateRoot, err := blockState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	b := util.NewBeaconBlockCapella()
+	b.Block.Slot = blockSlot
+	b.Block.StateRoot = stateRoot[:]
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	blockRoot, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	chain := &mockChain.ChainService{}
+	s := &Server{
+		Blocker:               &testutil.MockBlocker{BlockToReturn: blk},
+		OptimisticModeFetcher: chain,
+		FinalizationFetcher:   chain,
+		Stater: &testutil.MockStater{
+			// Like the beacon DB stater, 32 raw bytes are a state root and anything else a slot.
+			StateProviderFunc: func(_ context.Context, id []byte) (state.BeaconState, error) {
+				if len(id) == 32 {
+					if bytesutil.ToBytes32(id) != stateRoot {
+						return nil, errors.New("state not found")
+					}
+					return blockState, nil
+				}
+				slot, err := strconv.ParseUint(string(id), 10, 64)
+				if err != nil {
+					return nil, errors.Wrap(err, "invalid state ID")
+				}
+				if slot != uint64(blockSlot) {
+					return nil, errors.New("state not found")
+				}
+				return blockState, nil
+			},
+			StatesBySlot: map[primitives.Slot]state.BeaconState{rootsPerPeriod: periodState},
+		},
+	}
+
+	tests := []struct {
+		name string
+		slot primitives.Slot
+		leaf []byte
+	}{
+		{name: "block", slot: blockSlot, leaf: blockRoot[:]},
+		{name: "recent", slot: blockSlot - 10, leaf: blockRoots(2)[(blockSlot-10)%rootsPerPeriod]},
+		{name: "historical summary", slot: 100, leaf: blockRoots(1)[100]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/blocks/head/block_root_proof?slot="+strconv.FormatUint(uint64(tt.slot), 10), nil)
+			request = mux.SetURLVars(request, map[string]string{"block_id": "head"})
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.GetBlockRootProof(writer, request)
+			require.Equal(t, http.StatusOK, writer.Code)
+			resp := &ProofResponse{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+			assert.Equal(t, hexutil.Encode(blockRoot[:]), resp.Data.Root)
+			proof := decodeProof(t, resp.Data)
+			assert.DeepEqual(t, bytesutil.ToBytes32(tt.leaf), proof.Leaf)
+		})
+	}
+
+	t.Run("slot after the block", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/blocks/head/block_root_proof?slot="+strconv.FormatUint(uint64(blockSlot+1), 10), nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "head"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetBlockRootProof(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &http2.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "is after the slot", e.Message)
+	})
+}
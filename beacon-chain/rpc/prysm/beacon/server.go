@@ -0,0 +1,18 @@
+package beacon
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
+)
+
+// Server defines a server implementation for HTTP endpoints, providing
+// Merkle proofs of the beacon state and beacon blocks.
+type Server struct {
+	Stater                lookup.Stater
+	Blocker               lookup.Blocker
+	BeaconDB              db.ReadOnlyDatabase
+	ChainInfoFetcher      blockchain.ChainInfoFetcher
+	FinalizationFetcher   blockchain.FinalizationFetcher
+	OptimisticModeFetcher blockchain.OptimisticModeFetcher
+}
@@ -0,0 +1,25 @@
+package beacon
+
+type ProofResponse struct {
+	Data                *Proof `json:"data"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+	Finalized           bool   `json:"finalized"`
+}
+
+type Proof struct {
+	GeneralizedIndex string   `json:"gindex"`
+	Leaf             string   `json:"leaf"`
+	Branch           []string `json:"branch"`
+	Root             string   `json:"root"`
+}
+
+type HistoricalSummariesResponse struct {
+	Data                []*HistoricalSummary `json:"data"`
+	ExecutionOptimistic bool                 `json:"execution_optimistic"`
+	Finalized           bool                 `json:"finalized"`
+}
+
+type HistoricalSummary struct {
+	BlockSummaryRoot string `json:"block_summary_root"`
+	StateSummaryRoot string `json:"state_summary_root"`
+}
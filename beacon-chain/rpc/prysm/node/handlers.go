@@ -4,16 +4,24 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	corenet "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/core"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/io/logs"
 	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"go.opencensus.io/trace"
 )
 
 // ListTrustedPeer retrieves data about the node's trusted peers.
@@ -118,6 +126,106 @@ func (s *Server) RemoveTrustedPeer(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// GetEngineDiagnostics reports the outcome of the recent engine API calls and of their authentication, to debug
+// the connection between the beacon node and the execution client.
+func (s *Server) GetEngineDiagnostics(w http.ResponseWriter, _ *http.Request) {
+	d := s.EngineDiagnosticsFetcher.EngineDiagnostics()
+	data := &EngineDiagnostics{
+		Endpoint:             logs.MaskCredentialsLogging(d.Endpoint),
+		LastSuccessfulCall:   formatTime(d.LastSuccessfulCall),
+		LastSuccessfulMethod: d.LastSuccessfulMethod,
+		LastFailedCall:       formatTime(d.LastFailedCall),
+		LastFailedMethod:     d.LastFailedMethod,
+		LastError:            d.LastError,
+		AuthErrors:           strconv.FormatUint(d.AuthErrors, 10),
+		LastAuthError:        d.LastAuthError,
+		LastAuthErrorTime:    formatTime(d.LastAuthErrorTime),
+		JwtSecretLoaded:      formatTime(d.JWTSecretLoaded),
+	}
+	if d.ClockSkewMeasured {
+		data.ClockSkewMillis = strconv.FormatInt(d.ClockSkew.Milliseconds(), 10)
+	}
+	http2.WriteJson(w, &EngineDiagnosticsResponse{Data: data})
+}
+
+// GetClientInfo reports the execution client detected through web3_clientVersion and the version of this node, along
+// with the client-diversity code appended to the graffiti of the proposed blocks when --graffiti-client-info is set.
+func (s *Server) GetClientInfo(w http.ResponseWriter, _ *http.Request) {
+	commit := version.GitCommit()
+	data := &ClientInfo{
+		Consensus: &ClientVersion{
+			Code:    execution.PrysmClientCode,
+			Name:    "Prysm",
+			Version: version.SemanticVersion(),
+			Commit:  commit,
+		},
+		GraffitiEnabled: s.GraffitiClientInfo,
+	}
+	if el := s.ClientVersionFetcher.ExecutionClientVersion(); el != nil {
+		data.Execution = &ClientVersion{
+			Code:    el.Code,
+			Name:    el.Name,
+			Version: el.Version,
+			Commit:  el.Commit,
+			Raw:     el.Raw,
+		}
+		data.GraffitiCode = execution.GraffitiCode(el, commit, fieldparams.RootLength)
+	}
+	http2.WriteJson(w, &ClientInfoResponse{Data: data})
+}
+
+// GetHealth summarizes the sync status, finality lag, peers and execution client connectivity of the node for load
+// balancers and orchestration systems. The status code is the one of /eth/v1/node/health for the same query
+// parameters: 200 when the node is synced, 206 or syncing_status when it is syncing and 503 when it does not meet
+// the max_head_lag, max_finality_lag, min_peers, require_execution or reject_optimistic criteria.
+func (s *Server) GetHealth(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "node.GetHealth")
+	defer span.End()
+
+	thresholds, err := core.HealthThresholdsFromRequest(r)
+	if err != nil {
+		http2.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h, rpcErr := s.CoreService.NodeHealth(ctx, thresholds)
+	if rpcErr != nil {
+		http2.HandleError(w, rpcErr.Err.Error(), core.ErrorReasonToHTTP(rpcErr.Reason))
+		return
+	}
+	issues := h.Issues
+	if issues == nil {
+		issues = []string{}
+	}
+	resp := &HealthResponse{Data: &Health{
+		Healthy:        h.Healthy(),
+		Issues:         issues,
+		IsSyncing:      !h.Synced,
+		IsOptimistic:   h.Optimistic,
+		HeadSlot:       strconv.FormatUint(uint64(h.HeadSlot), 10),
+		CurrentSlot:    strconv.FormatUint(uint64(h.CurrentSlot), 10),
+		HeadLag:        strconv.FormatUint(uint64(h.HeadLag), 10),
+		FinalizedEpoch: strconv.FormatUint(uint64(h.FinalizedEpoch), 10),
+		FinalityLag:    strconv.FormatUint(uint64(h.FinalityLag), 10),
+		Peers: &HealthPeers{
+			Inbound:  strconv.Itoa(h.InboundPeers),
+			Outbound: strconv.Itoa(h.OutboundPeers),
+		},
+		Execution: &HealthExecution{
+			Connected: h.ExecutionConnected,
+			Error:     h.ExecutionError,
+		},
+	}}
+	http2.WriteJsonWithCode(w, resp, h.StatusCode(thresholds))
+}
+
+// formatTime formats the time in RFC 3339, or returns an empty string for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
 // httpPeerInfo does the same thing as peerInfo function in node.go but returns the
 // http peer response.
 func httpPeerInfo(peerStatus *peers.Status, id peer.ID) (*Peer, error) {
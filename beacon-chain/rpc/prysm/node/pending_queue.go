@@ -0,0 +1,38 @@
+package node
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+)
+
+// GetPendingQueue reports the blocks and attestations held by the sync service until the blocks they depend on are
+// known, along with why each block is pending and which peer it was received from.
+func (s *Server) GetPendingQueue(w http.ResponseWriter, _ *http.Request) {
+	blks := s.PendingQueueFetcher.PendingBlocks()
+	atts := s.PendingQueueFetcher.PendingAttestations()
+	data := &PendingQueue{
+		Blocks:       make([]*PendingBlock, len(blks)),
+		Attestations: make([]*PendingAttestations, len(atts)),
+	}
+	for i, b := range blks {
+		data.Blocks[i] = &PendingBlock{
+			Root:       hexutil.Encode(b.Root[:]),
+			Slot:       strconv.FormatUint(uint64(b.Slot), 10),
+			ParentRoot: hexutil.Encode(b.ParentRoot[:]),
+			Reason:     b.Reason,
+			Peer:       b.Peer.String(),
+			Added:      formatTime(b.Added),
+		}
+	}
+	for i, a := range atts {
+		data.Attestations[i] = &PendingAttestations{
+			BlockRoot: hexutil.Encode(a.BlockRoot[:]),
+			Count:     strconv.Itoa(a.Count),
+			Peer:      a.Peer.String(),
+		}
+	}
+	http2.WriteJson(w, &PendingQueueResponse{Data: data})
+}
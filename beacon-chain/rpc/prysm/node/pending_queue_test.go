@@ -0,0 +1,60 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+type mockPendingQueue struct {
+	blocks       []*sync.PendingBlock
+	attestations []*sync.PendingAttestations
+}
+
+func (m *mockPendingQueue) PendingBlocks() []*sync.PendingBlock {
+	return m.blocks
+}
+
+func (m *mockPendingQueue) PendingAttestations() []*sync.PendingAttestations {
+	return m.attestations
+}
+
+func TestGetPendingQueue(t *testing.T) {
+	s := &Server{PendingQueueFetcher: &mockPendingQueue{
+		blocks: []*sync.PendingBlock{
+			{Root: [32]byte{'a'}, Slot: 10, ParentRoot: [32]byte{'b'}, Reason: "missing_parent", Peer: peer.ID("foo")},
+		},
+		attestations: []*sync.PendingAttestations{
+			{BlockRoot: [32]byte{'b'}, Count: 3},
+		},
+	}}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/pending_queue", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetPendingQueue(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &PendingQueueResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.NotNil(t, resp.Data)
+	require.Equal(t, 1, len(resp.Data.Blocks))
+	b := resp.Data.Blocks[0]
+	assert.Equal(t, hexutil.Encode([]byte{'a', 31: 0}), b.Root)
+	assert.Equal(t, "10", b.Slot)
+	assert.Equal(t, hexutil.Encode([]byte{'b', 31: 0}), b.ParentRoot)
+	assert.Equal(t, "missing_parent", b.Reason)
+	assert.Equal(t, peer.ID("foo").String(), b.Peer)
+	assert.Equal(t, "", b.Added)
+	require.Equal(t, 1, len(resp.Data.Attestations))
+	assert.Equal(t, hexutil.Encode([]byte{'b', 31: 0}), resp.Data.Attestations[0].BlockRoot)
+	assert.Equal(t, "3", resp.Data.Attestations[0].Count)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/core"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
 )
 
@@ -18,4 +19,10 @@ type Server struct {
 	GenesisTimeFetcher        blockchain.TimeFetcher
 	HeadFetcher               blockchain.HeadFetcher
 	ExecutionChainInfoFetcher execution.ChainInfoFetcher
+	EngineDiagnosticsFetcher  execution.EngineDiagnosticsFetcher
+	EngineCapabilitiesFetcher execution.EngineCapabilitiesFetcher
+	ClientVersionFetcher      execution.ClientVersionFetcher
+	PendingQueueFetcher       sync.PendingQueueFetcher
+	GraffitiClientInfo        bool
+	CoreService               *core.Service
 }
@@ -15,3 +15,122 @@ type Peer struct {
 	State              string `json:"state"`
 	Direction          string `json:"direction"`
 }
+
+type EngineDiagnosticsResponse struct {
+	Data *EngineDiagnostics `json:"data"`
+}
+
+type EngineDiagnostics struct {
+	Endpoint             string `json:"endpoint"`
+	LastSuccessfulCall   string `json:"last_successful_call"`
+	LastSuccessfulMethod string `json:"last_successful_method"`
+	LastFailedCall       string `json:"last_failed_call"`
+	LastFailedMethod     string `json:"last_failed_method"`
+	LastError            string `json:"last_error"`
+	AuthErrors           string `json:"auth_errors"`
+	LastAuthError        string `json:"last_auth_error"`
+	LastAuthErrorTime    string `json:"last_auth_error_time"`
+	ClockSkewMillis      string `json:"clock_skew_ms"`
+	JwtSecretLoaded      string `json:"jwt_secret_loaded"`
+}
+
+type ClientInfoResponse struct {
+	Data *ClientInfo `json:"data"`
+}
+
+type ClientInfo struct {
+	Execution       *ClientVersion `json:"execution"`
+	Consensus       *ClientVersion `json:"consensus"`
+	GraffitiCode    string         `json:"graffiti_code"`
+	GraffitiEnabled bool           `json:"graffiti_enabled"`
+}
+
+type ClientVersion struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Raw     string `json:"raw,omitempty"`
+}
+
+type VersionResponse struct {
+	Data *Version `json:"data"`
+}
+
+type Version struct {
+	Version         string         `json:"version"`
+	SemanticVersion string         `json:"semantic_version"`
+	Commit          string         `json:"commit"`
+	BuildDate       string         `json:"build_date"`
+	GoVersion       string         `json:"go_version"`
+	Platform        string         `json:"platform"`
+	SupportedForks  []string       `json:"supported_forks"`
+	ForkSchedule    []*Fork        `json:"fork_schedule"`
+	CurrentFork     string         `json:"current_fork"`
+	NextFork        *ForkReadiness `json:"next_fork"`
+}
+
+type Fork struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Epoch   string `json:"epoch"`
+}
+
+type ForkReadiness struct {
+	Name   string   `json:"name"`
+	Epoch  string   `json:"epoch"`
+	Ready  bool     `json:"ready"`
+	Issues []string `json:"issues"`
+}
+
+type HealthResponse struct {
+	Data *Health `json:"data"`
+}
+
+type Health struct {
+	Healthy        bool             `json:"healthy"`
+	Issues         []string         `json:"issues"`
+	IsSyncing      bool             `json:"is_syncing"`
+	IsOptimistic   bool             `json:"is_optimistic"`
+	HeadSlot       string           `json:"head_slot"`
+	CurrentSlot    string           `json:"current_slot"`
+	HeadLag        string           `json:"head_lag"`
+	FinalizedEpoch string           `json:"finalized_epoch"`
+	FinalityLag    string           `json:"finality_lag"`
+	Peers          *HealthPeers     `json:"peers"`
+	Execution      *HealthExecution `json:"execution"`
+}
+
+type HealthPeers struct {
+	Inbound  string `json:"inbound"`
+	Outbound string `json:"outbound"`
+}
+
+type HealthExecution struct {
+	Connected bool   `json:"connected"`
+	Error     string `json:"error,omitempty"`
+}
+
+type PendingQueueResponse struct {
+	Data *PendingQueue `json:"data"`
+}
+
+type PendingQueue struct {
+	Blocks       []*PendingBlock        `json:"blocks"`
+	Attestations []*PendingAttestations `json:"attestations"`
+}
+
+type PendingBlock struct {
+	Root       string `json:"root"`
+	Slot       string `json:"slot"`
+	ParentRoot string `json:"parent_root"`
+	Reason     string `json:"reason"`
+	Peer       string `json:"peer"`
+	Added      string `json:"added"`
+}
+
+type PendingAttestations struct {
+	BlockRoot string `json:"block_root"`
+	Count     string `json:"count"`
+	Peer      string `json:"peer"`
+}
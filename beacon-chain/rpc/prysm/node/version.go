@@ -0,0 +1,103 @@
+package node
+
+import (
+	"net/http"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// GetVersion reports the build of the node, the forks it supports and the fork schedule of its config, and whether
+// the node is ready for the next scheduled fork, so that fleets of nodes can be audited before a fork.
+func (s *Server) GetVersion(w http.ResponseWriter, _ *http.Request) {
+	cfg := params.BeaconConfig()
+	forks := sortedForks(cfg)
+
+	versions := append([]int{}, version.All()...)
+	sort.Ints(versions)
+	supported := make([]string, len(versions))
+	for i, v := range versions {
+		supported[i] = version.String(v)
+	}
+	data := &Version{
+		Version:         version.BuildData(),
+		SemanticVersion: version.SemanticVersion(),
+		Commit:          version.GitCommit(),
+		BuildDate:       version.BuildDate(),
+		GoVersion:       runtime.Version(),
+		Platform:        runtime.GOOS + "/" + runtime.GOARCH,
+		SupportedForks:  supported,
+		ForkSchedule:    make([]*Fork, 0, len(forks)),
+	}
+
+	current := slots.ToEpoch(s.GenesisTimeFetcher.CurrentSlot())
+	for _, f := range forks {
+		if f.epoch == cfg.FarFutureEpoch {
+			continue
+		}
+		data.ForkSchedule = append(data.ForkSchedule, &Fork{
+			Name:    version.String(f.version),
+			Version: hexutil.Encode(f.forkVersion[:]),
+			Epoch:   strconv.FormatUint(uint64(f.epoch), 10),
+		})
+		if f.epoch <= current {
+			data.CurrentFork = version.String(f.version)
+		} else if data.NextFork == nil {
+			data.NextFork = s.forkReadiness(f.version, f.epoch)
+		}
+	}
+	http2.WriteJson(w, &VersionResponse{Data: data})
+}
+
+// forkReadiness checks that the node has what the fork requires from it.
+func (s *Server) forkReadiness(fork int, epoch primitives.Epoch) *ForkReadiness {
+	issues := []string{}
+	if fork >= version.Deneb {
+		if !kzg.Loaded() {
+			issues = append(issues, "KZG trusted setup is not loaded")
+		}
+		if params.BeaconConfig().BlobsidecarSubnetCount == 0 {
+			issues = append(issues, "Blob sidecar subnets are not configured")
+		}
+	}
+	if missing := s.EngineCapabilitiesFetcher.MissingEngineMethods(fork); len(missing) > 0 {
+		issues = append(issues, "Execution client does not support "+strings.Join(missing, ", "))
+	}
+	return &ForkReadiness{
+		Name:   version.String(fork),
+		Epoch:  strconv.FormatUint(uint64(epoch), 10),
+		Ready:  len(issues) == 0,
+		Issues: issues,
+	}
+}
+
+type scheduledFork struct {
+	version     int
+	forkVersion [4]byte
+	epoch       primitives.Epoch
+}
+
+// sortedForks returns the forks of the config in the order of their versions.
+func sortedForks(cfg *params.BeaconChainConfig) []scheduledFork {
+	forks := make([]scheduledFork, 0, len(cfg.ForkVersionSchedule))
+	for fv, v := range params.ConfigForkVersions(cfg) {
+		epoch, ok := cfg.ForkVersionSchedule[fv]
+		if !ok {
+			continue
+		}
+		forks = append(forks, scheduledFork{version: v, forkVersion: fv, epoch: epoch})
+	}
+	sort.Slice(forks, func(i, j int) bool {
+		return forks[i].version < forks[j].version
+	})
+	return forks
+}
@@ -0,0 +1,73 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+type mockEngineCapabilities struct {
+	missing map[int][]string
+}
+
+func (m *mockEngineCapabilities) MissingEngineMethods(fork int) []string {
+	return m.missing[fork]
+}
+
+func TestGetVersion(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 1
+	cfg.CapellaForkEpoch = 2
+	cfg.DenebForkEpoch = 10
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+
+	currentSlot := primitives.Slot(5 * cfg.SlotsPerEpoch)
+	s := &Server{
+		GenesisTimeFetcher: &mock.ChainService{Slot: &currentSlot},
+		EngineCapabilitiesFetcher: &mockEngineCapabilities{missing: map[int][]string{
+			version.Deneb: {"engine_getPayloadV3"},
+		}},
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/version", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.GetVersion(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &VersionResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.NotNil(t, resp.Data)
+	assert.Equal(t, version.SemanticVersion(), resp.Data.SemanticVersion)
+	assert.Equal(t, len(version.All()), len(resp.Data.SupportedForks))
+	assert.Equal(t, "phase0", resp.Data.SupportedForks[0])
+	require.Equal(t, 5, len(resp.Data.ForkSchedule))
+	assert.Equal(t, "deneb", resp.Data.ForkSchedule[4].Name)
+	assert.Equal(t, "10", resp.Data.ForkSchedule[4].Epoch)
+	assert.Equal(t, "capella", resp.Data.CurrentFork)
+
+	next := resp.Data.NextFork
+	require.NotNil(t, next)
+	assert.Equal(t, "deneb", next.Name)
+	assert.Equal(t, "10", next.Epoch)
+	assert.Equal(t, false, next.Ready)
+	found := false
+	for _, issue := range next.Issues {
+		if issue == "Execution client does not support engine_getPayloadV3" {
+			found = true
+		}
+	}
+	assert.Equal(t, true, found)
+}
@@ -0,0 +1,63 @@
+package slasher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	slasherservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+)
+
+// StreamSlashings streams the attester and proposer slashings detected by slasher as server-sent events,
+// including the ones for offenses already slashed on chain.
+func (s *Server) StreamSlashings(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http2.HandleError(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	slashingsChan := make(chan *slasherservice.DetectedSlashing, 16)
+	sub := s.SlashingsSubscriber.SubscribeDetectedSlashings(slashingsChan)
+	defer sub.Unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case slashing := <-slashingsChan:
+			if err := writeSlashingEvent(w, slashing); err != nil {
+				log.WithError(err).Error("Could not stream detected slashing")
+				return
+			}
+			flusher.Flush()
+		case <-sub.Err():
+			return
+		case <-r.Context().Done():
+			return
+		case <-s.Ctx.Done():
+			return
+		}
+	}
+}
+
+func writeSlashingEvent(w http.ResponseWriter, slashing *slasherservice.DetectedSlashing) error {
+	notification, err := slasherservice.NewSlashingNotification(slashing)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal slashing notification")
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", notification.Type, data); err != nil {
+		return errors.Wrap(err, "could not write event")
+	}
+	return nil
+}
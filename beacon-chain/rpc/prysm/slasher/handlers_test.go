@@ -0,0 +1,63 @@
+package slasher
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/async/event"
+	slasherservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+type mockSlashingsSubscriber struct {
+	feed event.Feed
+}
+
+func (m *mockSlashingsSubscriber) SubscribeDetectedSlashings(ch chan<- *slasherservice.DetectedSlashing) event.Subscription {
+	return m.feed.Subscribe(ch)
+}
+
+func TestStreamSlashings(t *testing.T) {
+	subscriber := &mockSlashingsSubscriber{}
+	s := &Server{
+		Ctx:                 context.Background(),
+		SlashingsSubscriber: subscriber,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(s.StreamSlashings))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	header := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{})
+	header.Header.ProposerIndex = 3
+	sent := subscriber.feed.Send(&slasherservice.DetectedSlashing{
+		ProposerSlashing: &ethpb.ProposerSlashing{Header_1: header, Header_2: header},
+		AlreadySlashed:   true,
+	})
+	require.Equal(t, 1, sent)
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "event: proposer_slashing\n", line)
+	line, err = reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, true, strings.HasPrefix(line, `data: {"type":"proposer_slashing","validator_indices":["3"],"already_slashed":true,`))
+}
@@ -0,0 +1,14 @@
+package slasher
+
+import (
+	"context"
+
+	slasherservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher"
+)
+
+// Server defines a server implementation for HTTP endpoints, providing
+// access to the slashings detected by slasher.
+type Server struct {
+	Ctx                 context.Context
+	SlashingsSubscriber slasherservice.SlashingsSubscriber
+}
@@ -25,12 +25,20 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// syncSubnetLookahead is the number of epochs before the start of a sync committee period from which the members
+// of the next period sync committee are subscribed to their subnets.
+const syncSubnetLookahead = primitives.Epoch(4)
+
 // GetDuties returns the duties assigned to a list of validators specified
 // in the request object.
 func (vs *Server) GetDuties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
 	if vs.SyncChecker.Syncing() {
 		return nil, status.Error(codes.Unavailable, "Syncing to latest head, not ready to respond")
 	}
+	// Duties are computed from the head state, which cannot be trusted while the head is optimistic.
+	if err := vs.optimisticStatus(ctx); err != nil {
+		return nil, err
+	}
 	return vs.duties(ctx, req)
 }
 
@@ -41,6 +49,9 @@ func (vs *Server) StreamDuties(req *ethpb.DutiesRequest, stream ethpb.BeaconNode
 	if vs.SyncChecker.Syncing() {
 		return status.Error(codes.Unavailable, "Syncing to latest head, not ready to respond")
 	}
+	if err := vs.optimisticStatus(stream.Context()); err != nil {
+		return err
+	}
 
 	// If we are post-genesis time, then set the current epoch to
 	// the number epochs since the genesis time, otherwise 0 by default.
@@ -72,6 +83,10 @@ func (vs *Server) StreamDuties(req *ethpb.DutiesRequest, stream ethpb.BeaconNode
 		select {
 		// Ticks every epoch to submit assignments to connected validator clients.
 		case slot := <-epochTicker.C():
+			// Duties are withheld while the head is optimistic, and sent once it is validated.
+			if err := vs.optimisticStatus(stream.Context()); err != nil {
+				continue
+			}
 			req.Epoch = primitives.Epoch(slot)
 			res, err := vs.duties(stream.Context(), req)
 			if err != nil {
@@ -89,6 +104,28 @@ func (vs *Server) StreamDuties(req *ethpb.DutiesRequest, stream ethpb.BeaconNode
 				if !ok {
 					return status.Errorf(codes.Internal, "Received incorrect data type over reorg feed: %v", data)
 				}
+				if err := vs.optimisticStatus(stream.Context()); err != nil {
+					continue
+				}
+				req.Epoch = currentEpoch
+				res, err := vs.duties(stream.Context(), req)
+				if err != nil {
+					return status.Errorf(codes.Internal, "Could not compute validator duties: %v", err)
+				}
+				if err := stream.Send(res); err != nil {
+					return status.Errorf(codes.Internal, "Could not send response over stream: %v", err)
+				}
+			}
+			// Duties are computed from the head state, which is only trusted once the head is no longer
+			// optimistic, so they are sent again when the head is validated.
+			if ev.Type == statefeed.OptimisticStatusChanged {
+				data, ok := ev.Data.(*statefeed.OptimisticStatusChangedData)
+				if !ok {
+					return status.Errorf(codes.Internal, "Received incorrect data type over optimistic status feed: %v", data)
+				}
+				if data.Optimistic {
+					continue
+				}
 				req.Epoch = currentEpoch
 				res, err := vs.duties(stream.Context(), req)
 				if err != nil {
@@ -213,21 +250,26 @@ func (vs *Server) duties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.
 			}
 			nextAssignment.IsSyncCommittee = assignment.IsSyncCommittee
 
-			// Next epoch sync committee duty is assigned with next period sync committee only during
-			// sync period epoch boundary (ie. EPOCHS_PER_SYNC_COMMITTEE_PERIOD - 1). Else wise
-			// next epoch sync committee duty is the same as current epoch.
-			nextSlotToEpoch := slots.ToEpoch(s.Slot() + 1)
-			currentEpoch := coreTime.CurrentEpoch(s)
-			if slots.SyncCommitteePeriod(nextSlotToEpoch) == slots.SyncCommitteePeriod(currentEpoch)+1 {
-				nextAssignment.IsSyncCommittee, err = helpers.IsNextPeriodSyncCommittee(s, idx)
+			// The next period sync committee is known one period in advance. Its members are subscribed to their
+			// sync committee subnets ahead of the period boundary, so that the beacon node has peers on these subnets
+			// by the first slot of the period. When the next epoch starts a new period, the next epoch sync committee
+			// duty is assigned with the next period sync committee. Else wise it is the same as the current epoch one.
+			currentPeriod := slots.SyncCommitteePeriod(req.Epoch)
+			nextPeriodStartEpoch := primitives.Epoch(currentPeriod+1) * params.BeaconConfig().EpochsPerSyncCommitteePeriod
+			if slots.SyncCommitteePeriod(coreTime.CurrentEpoch(s)) == currentPeriod &&
+				req.Epoch+syncSubnetLookahead >= nextPeriodStartEpoch {
+				inNextPeriodSyncCommittee, err := helpers.IsNextPeriodSyncCommittee(s, idx)
 				if err != nil {
-					return nil, status.Errorf(codes.Internal, "Could not determine next epoch sync committee: %v", err)
+					return nil, status.Errorf(codes.Internal, "Could not determine next period sync committee: %v", err)
 				}
-				if nextAssignment.IsSyncCommittee {
+				if inNextPeriodSyncCommittee {
 					if err := registerSyncSubnetNextPeriod(s, req.Epoch, pubKey, nextAssignment.Status); err != nil {
 						return nil, err
 					}
 				}
+				if req.Epoch+1 == nextPeriodStartEpoch {
+					nextAssignment.IsSyncCommittee = inNextPeriodSyncCommittee
+				}
 			}
 		}
 
@@ -286,7 +328,7 @@ func registerSyncSubnet(currEpoch primitives.Epoch, syncPeriod uint64, pubkey []
 	if ok && expTime.After(prysmTime.Now()) {
 		return
 	}
-	firstValidEpoch, err := startEpoch.SafeSub(params.BeaconConfig().SyncCommitteeSubnetCount)
+	firstValidEpoch, err := startEpoch.SafeSub(uint64(syncSubnetLookahead))
 	if err != nil {
 		firstValidEpoch = 0
 	}
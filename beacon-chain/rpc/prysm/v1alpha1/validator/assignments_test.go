@@ -204,6 +204,100 @@ func TestGetAltairDuties_SyncCommitteeOK(t *testing.T) {
 	}
 }
 
+func TestGetAltairDuties_NextPeriodSyncCommittee(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = primitives.Epoch(0)
+	params.OverrideBeaconConfig(cfg)
+	helpers.ClearCache()
+	cache.SyncSubnetIDs.EmptyAllCaches()
+	defer cache.SyncSubnetIDs.EmptyAllCaches()
+
+	genesis := util.NewBeaconBlock()
+	deposits, _, err := util.DeterministicDepositsAndKeys(params.BeaconConfig().SyncCommitteeSize)
+	require.NoError(t, err)
+	eth1Data, err := util.DeterministicEth1Data(len(deposits))
+	require.NoError(t, err)
+	bs, err := util.GenesisBeaconState(context.Background(), deposits, 0, eth1Data)
+	require.NoError(t, err, "Could not setup genesis bs")
+	require.NoError(t, bs.SetLatestBlockHeader(&ethpb.BeaconBlockHeader{
+		StateRoot:  bytesutil.PadTo([]byte{'a'}, fieldparams.RootLength),
+		ParentRoot: bytesutil.PadTo([]byte{'b'}, fieldparams.RootLength),
+		BodyRoot:   bytesutil.PadTo([]byte{'c'}, fieldparams.RootLength),
+	}))
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err, "Could not get signing root")
+
+	syncCommittee, err := altair.NextSyncCommittee(context.Background(), bs)
+	require.NoError(t, err)
+	require.NoError(t, bs.SetCurrentSyncCommittee(syncCommittee))
+	// Only the second validator is in the next period sync committee.
+	nextPubKeys := make([][]byte, params.BeaconConfig().SyncCommitteeSize)
+	for i := range nextPubKeys {
+		nextPubKeys[i] = deposits[1].Data.PublicKey
+	}
+	require.NoError(t, bs.SetNextSyncCommittee(&ethpb.SyncCommittee{
+		Pubkeys:         nextPubKeys,
+		AggregatePubkey: syncCommittee.AggregatePubkey,
+	}))
+	periodStartEpoch := params.BeaconConfig().EpochsPerSyncCommitteePeriod
+	require.NoError(t, bs.SetSlot(params.BeaconConfig().SlotsPerEpoch*primitives.Slot(periodStartEpoch)-1))
+	require.NoError(t, helpers.UpdateSyncCommitteeCache(bs))
+
+	slot := uint64(params.BeaconConfig().SlotsPerEpoch) * uint64(periodStartEpoch) * params.BeaconConfig().SecondsPerSlot
+	chain := &mockChain.ChainService{
+		Root: genesisRoot[:], Genesis: time.Now().Add(time.Duration(-1*int64(slot-1)) * time.Second),
+	}
+	vs := &Server{
+		HeadFetcher:            chain,
+		TimeFetcher:            chain,
+		Eth1InfoFetcher:        &mockExecution.Chain{},
+		SyncChecker:            &mockSync.Sync{IsSyncing: false},
+		ProposerSlotIndexCache: cache.NewProposerPayloadIDsCache(),
+	}
+	pubKeys := [][]byte{deposits[0].Data.PublicKey, deposits[1].Data.PublicKey}
+
+	// Out of the subscription window, the next period sync committee subnets are not subscribed.
+	// Computing the duties updates the slot of the head state.
+	chain.State = bs.Copy()
+	_, err = vs.GetDuties(context.Background(), &ethpb.DutiesRequest{
+		PublicKeys: pubKeys,
+		Epoch:      periodStartEpoch - syncSubnetLookahead - 1,
+	})
+	require.NoError(t, err)
+	_, _, ok, _ := cache.SyncSubnetIDs.GetSyncCommitteeSubnets(pubKeys[1], periodStartEpoch)
+	assert.Equal(t, false, ok)
+
+	// Within the subscription window, the next period sync committee members are subscribed to their subnets,
+	// while the next epoch duties are still the current period ones.
+	chain.State = bs.Copy()
+	res, err := vs.GetDuties(context.Background(), &ethpb.DutiesRequest{
+		PublicKeys: pubKeys,
+		Epoch:      periodStartEpoch - syncSubnetLookahead,
+	})
+	require.NoError(t, err)
+	_, _, ok, _ = cache.SyncSubnetIDs.GetSyncCommitteeSubnets(pubKeys[0], periodStartEpoch)
+	assert.Equal(t, false, ok)
+	subnets, _, ok, _ := cache.SyncSubnetIDs.GetSyncCommitteeSubnets(pubKeys[1], periodStartEpoch)
+	require.Equal(t, true, ok)
+	// The validator holds every position of the next period sync committee.
+	assert.Equal(t, params.BeaconConfig().SyncCommitteeSize, uint64(len(subnets)))
+	for i := range pubKeys {
+		assert.Equal(t, true, res.CurrentEpochDuties[i].IsSyncCommittee)
+		assert.Equal(t, true, res.NextEpochDuties[i].IsSyncCommittee)
+	}
+
+	// One epoch before the period boundary, the next epoch duties are the next period ones.
+	chain.State = bs.Copy()
+	res, err = vs.GetDuties(context.Background(), &ethpb.DutiesRequest{
+		PublicKeys: pubKeys,
+		Epoch:      periodStartEpoch - 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, false, res.NextEpochDuties[0].IsSyncCommittee)
+	assert.Equal(t, true, res.NextEpochDuties[1].IsSyncCommittee)
+}
+
 func TestGetBellatrixDuties_SyncCommitteeOK(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	cfg := params.BeaconConfig().Copy()
@@ -254,6 +348,7 @@ func TestGetBellatrixDuties_SyncCommitteeOK(t *testing.T) {
 	vs := &Server{
 		HeadFetcher:            chain,
 		TimeFetcher:            chain,
+		OptimisticModeFetcher:  chain,
 		Eth1InfoFetcher:        &mockExecution.Chain{},
 		SyncChecker:            &mockSync.Sync{IsSyncing: false},
 		ProposerSlotIndexCache: cache.NewProposerPayloadIDsCache(),
@@ -469,6 +564,22 @@ func TestGetDuties_SyncNotReady(t *testing.T) {
 	assert.ErrorContains(t, "Syncing to latest head", err)
 }
 
+func TestGetDuties_Optimistic(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.BellatrixForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	chain := &mockChain.ChainService{Genesis: time.Now(), Optimistic: true}
+	vs := &Server{
+		SyncChecker:           &mockSync.Sync{IsSyncing: false},
+		TimeFetcher:           chain,
+		OptimisticModeFetcher: chain,
+	}
+	_, err := vs.GetDuties(context.Background(), &ethpb.DutiesRequest{})
+	assert.ErrorContains(t, errOptimisticMode.Error(), err)
+}
+
 func TestStreamDuties_SyncNotReady(t *testing.T) {
 	vs := &Server{
 		SyncChecker: &mockSync.Sync{IsSyncing: true},
@@ -604,6 +715,73 @@ func TestStreamDuties_OK_ChainReorg(t *testing.T) {
 	cancel()
 }
 
+func TestStreamDuties_OK_OptimisticStatusChanged(t *testing.T) {
+	genesis := util.NewBeaconBlock()
+	depChainStart := params.BeaconConfig().MinGenesisActiveValidatorCount
+	deposits, _, err := util.DeterministicDepositsAndKeys(depChainStart)
+	require.NoError(t, err)
+	eth1Data, err := util.DeterministicEth1Data(len(deposits))
+	require.NoError(t, err)
+	bs, err := transition.GenesisBeaconState(context.Background(), deposits, 0, eth1Data)
+	require.NoError(t, err, "Could not setup genesis bs")
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err, "Could not get signing root")
+
+	pubKeys := make([][]byte, len(deposits))
+	indices := make([]uint64, len(deposits))
+	for i := 0; i < len(deposits); i++ {
+		pubKeys[i] = deposits[i].Data.PublicKey
+		indices[i] = uint64(i)
+	}
+
+	pubkeysAs48ByteType := make([][fieldparams.BLSPubkeyLength]byte, len(pubKeys))
+	for i, pk := range pubKeys {
+		pubkeysAs48ByteType[i] = bytesutil.ToBytes48(pk)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &mockChain.ChainService{
+		Genesis: time.Now(),
+	}
+	vs := &Server{
+		Ctx:                    ctx,
+		HeadFetcher:            &mockChain.ChainService{State: bs, Root: genesisRoot[:]},
+		SyncChecker:            &mockSync.Sync{IsSyncing: false},
+		TimeFetcher:            c,
+		StateNotifier:          &mockChain.MockStateNotifier{},
+		ProposerSlotIndexCache: cache.NewProposerPayloadIDsCache(),
+	}
+
+	// Test the first validator in registry.
+	req := &ethpb.DutiesRequest{
+		PublicKeys: [][]byte{deposits[0].Data.PublicKey},
+	}
+	wantedRes, err := vs.duties(ctx, req)
+	require.NoError(t, err)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	exitRoutine := make(chan bool)
+	mockStream := mock.NewMockBeaconNodeValidator_StreamDutiesServer(ctrl)
+	mockStream.EXPECT().Send(wantedRes).Return(nil)
+	mockStream.EXPECT().Send(wantedRes).Do(func(arg0 interface{}) {
+		exitRoutine <- true
+	})
+	mockStream.EXPECT().Context().Return(ctx).AnyTimes()
+	go func(tt *testing.T) {
+		assert.ErrorContains(t, "context canceled", vs.StreamDuties(req, mockStream))
+	}(t)
+	// Fire an event for the head being validated. This needs to trigger
+	// a recomputation and resending of duties over the stream.
+	for sent := 0; sent == 0; {
+		sent = vs.StateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.OptimisticStatusChanged,
+			Data: &statefeed.OptimisticStatusChangedData{BlockRoot: genesisRoot, Optimistic: false},
+		})
+	}
+	<-exitRoutine
+	cancel()
+}
+
 func TestAssignValidatorToSubnet(t *testing.T) {
 	k := pubKey(3)
 
@@ -661,8 +839,10 @@ func BenchmarkCommitteeAssignment(b *testing.B) {
 		indices[i] = uint64(i)
 	}
 
+	chain := &mockChain.ChainService{State: bs, Root: genesisRoot[:], Genesis: time.Now()}
 	vs := &Server{
-		HeadFetcher: &mockChain.ChainService{State: bs, Root: genesisRoot[:]},
+		HeadFetcher: chain,
+		TimeFetcher: chain,
 		SyncChecker: &mockSync.Sync{IsSyncing: false},
 	}
 
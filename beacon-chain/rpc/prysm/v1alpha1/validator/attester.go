@@ -80,6 +80,9 @@ func (vs *Server) ProposeAttestation(ctx context.Context, att *ethpb.Attestation
 	if err := vs.P2P.BroadcastAttestation(ctx, subnet, att); err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not broadcast attestation: %v", err)
 	}
+	if vs.LocalBroadcasts != nil {
+		vs.LocalBroadcasts.AddAttestation(subnet, att)
+	}
 
 	go func() {
 		ctx = trace.NewContext(context.Background(), trace.FromContext(ctx))
@@ -19,6 +19,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
@@ -51,15 +52,23 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 	ctx, span := trace.StartSpan(ctx, "ProposerServer.GetBeaconBlock")
 	defer span.End()
 	span.AddAttributes(trace.Int64Attribute("slot", int64(req.Slot)))
+	return vs.buildBeaconBlock(ctx, req, false)
+}
 
+// buildBeaconBlock builds the block of the request. A simulated block is built without side effects on the node: the
+// head is not updated, no block production metrics or logs are recorded, no deadline is set on its stages and the
+// builder is not asked for a payload, so that the block is built with the local payload.
+func (vs *Server) buildBeaconBlock(ctx context.Context, req *ethpb.BlockRequest, simulate bool) (*ethpb.GenericBeaconBlock, error) {
 	t, err := slots.ToTime(uint64(vs.TimeFetcher.GenesisTime().Unix()), req.Slot)
 	if err != nil {
 		log.WithError(err).Error("Could not convert slot to time")
 	}
-	log.WithFields(logrus.Fields{
-		"slot":               req.Slot,
-		"sinceSlotStartTime": time.Since(t),
-	}).Info("Begin building block")
+	if !simulate {
+		log.WithFields(logrus.Fields{
+			"slot":               req.Slot,
+			"sinceSlotStartTime": time.Since(t),
+		}).Info("Begin building block")
+	}
 
 	// A syncing validator should not produce a block.
 	if vs.SyncChecker.Syncing() {
@@ -67,11 +76,18 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 	}
 
 	// process attestations and update head in forkchoice
-	vs.ForkchoiceFetcher.UpdateHead(ctx, vs.TimeFetcher.CurrentSlot())
+	if !simulate {
+		vs.ForkchoiceFetcher.UpdateHead(ctx, vs.TimeFetcher.CurrentSlot())
+	}
 	headRoot := vs.ForkchoiceFetcher.CachedHeadRoot()
 	parentRoot := vs.ForkchoiceFetcher.GetProposerHead()
-	if parentRoot != headRoot {
+	if parentRoot != headRoot && !simulate {
 		blockchain.LateBlockAttemptedReorgCount.Inc()
+		log.WithFields(logrus.Fields{
+			"slot":       req.Slot,
+			"headRoot":   fmt.Sprintf("%#x", headRoot),
+			"parentRoot": fmt.Sprintf("%#x", parentRoot),
+		}).Info("Attempting to reorg late block by building on its parent")
 	}
 
 	// An optimistic validator MUST NOT produce a block (i.e., sign across the DOMAIN_BEACON_PROPOSER domain).
@@ -81,6 +97,10 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 		}
 	}
 
+	var budget *proposalBudget
+	if !simulate {
+		budget = vs.proposalBudget(req.Slot)
+	}
 	sBlk, err := getEmptyBlock(req.Slot)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not prepare block: %v", err)
@@ -93,10 +113,11 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not process slots up to %d: %v", req.Slot, err)
 	}
+	budget.end(proposalStageState)
 
 	// Set slot, graffiti, randao reveal, and parent root.
 	sBlk.SetSlot(req.Slot)
-	sBlk.SetGraffiti(req.Graffiti)
+	sBlk.SetGraffiti(vs.graffiti(req.Graffiti))
 	sBlk.SetRandaoReveal(req.RandaoReveal)
 	sBlk.SetParentRoot(parentRoot[:])
 
@@ -110,7 +131,7 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 	var blobBundle *enginev1.BlobsBundle
 	var blindBlobBundle *enginev1.BlindedBlobsBundle
 	if features.Get().BuildBlockParallel {
-		blindBlobBundle, blobBundle, err = vs.BuildBlockParallel(ctx, sBlk, head)
+		blindBlobBundle, blobBundle, err = vs.BuildBlockParallel(ctx, sBlk, head, budget, req.Relays, simulate)
 		if err != nil {
 			return nil, errors.Wrap(err, "could not build block in parallel")
 		}
@@ -124,7 +145,7 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 		sBlk.SetEth1Data(eth1Data)
 
 		// Set deposit and attestation.
-		deposits, atts, err := vs.packDepositsAndAttestations(ctx, head, eth1Data) // TODO: split attestations and deposits
+		deposits, atts, err := vs.packDepositsAndAttestations(ctx, head, eth1Data, budget) // TODO: split attestations and deposits
 		if err != nil {
 			sBlk.SetDeposits([]*ethpb.Deposit{})
 			sBlk.SetAttestations([]*ethpb.Attestation{})
@@ -133,6 +154,7 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 			sBlk.SetDeposits(deposits)
 			sBlk.SetAttestations(atts)
 		}
+		budget.end(proposalStageAttestations)
 
 		// Set slashings.
 		validProposerSlashings, validAttSlashings := vs.getSlashings(ctx, head)
@@ -149,21 +171,31 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 		var overrideBuilder bool
 		var localPayload interfaces.ExecutionData
 		localPayload, blobBundle, overrideBuilder, err = vs.getLocalPayloadAndBlobs(ctx, sBlk.Block(), head)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not get local payload: %v", err)
-		}
-		// There's no reason to try to get a builder bid if local override is true.
-		var builderPayload interfaces.ExecutionData
-		if !overrideBuilder {
-			builderPayload, blindBlobBundle, err = vs.getBuilderPayloadAndBlobs(ctx, sBlk.Block().Slot(), sBlk.Block().ProposerIndex())
+		switch {
+		case errors.Is(err, execution.ErrAcceptedSyncingPayloadStatus) && simulate:
+			return nil, status.Errorf(codes.Unavailable, "Could not get local payload: %v", err)
+		case errors.Is(err, execution.ErrAcceptedSyncingPayloadStatus):
+			blindBlobBundle, err = vs.setBuilderExecutionWhenSyncing(ctx, sBlk, req.Relays)
 			if err != nil {
-				builderGetPayloadMissCount.Inc()
-				log.WithError(err).Error("Could not get builder payload")
+				return nil, err
+			}
+		case err != nil:
+			return nil, status.Errorf(codes.Internal, "Could not get local payload: %v", err)
+		default:
+			// There's no reason to try to get a builder bid if local override is true.
+			var builderPayload interfaces.ExecutionData
+			if !overrideBuilder && !simulate {
+				builderPayload, blindBlobBundle, err = vs.getBuilderPayloadWithinBudget(ctx, budget, sBlk.Block().Slot(), sBlk.Block().ProposerIndex(), req.Relays)
+				if err != nil {
+					builderGetPayloadMissCount.Inc()
+					log.WithError(err).Error("Could not get builder payload")
+				}
+			}
+			if err := setExecutionData(ctx, sBlk, localPayload, builderPayload); err != nil {
+				return nil, status.Errorf(codes.Internal, "Could not set execution data: %v", err)
 			}
 		}
-		if err := setExecutionData(ctx, sBlk, localPayload, builderPayload); err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not set execution data: %v", err)
-		}
+		budget.end(proposalStagePayload)
 
 		// Set bls to execution change. New in Capella.
 		vs.setBlsToExecData(sBlk, head)
@@ -188,11 +220,14 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 		return nil, status.Errorf(codes.Internal, "Could not convert blind blobs bundle to sidecar: %v", err)
 	}
 
-	log.WithFields(logrus.Fields{
-		"slot":               req.Slot,
-		"sinceSlotStartTime": time.Since(t),
-		"validator":          sBlk.Block().ProposerIndex(),
-	}).Info("Finished building block")
+	if !simulate {
+		log.WithFields(logrus.Fields{
+			"slot":               req.Slot,
+			"sinceSlotStartTime": time.Since(t),
+			"validator":          sBlk.Block().ProposerIndex(),
+		}).Info("Finished building block")
+	}
+	budget.handOff()
 
 	pb, err := sBlk.Block().Proto()
 	if err != nil {
@@ -232,7 +267,7 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 	return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Phase0{Phase0: pb.(*ethpb.BeaconBlock)}, IsBlinded: false, PayloadValue: 0}, nil
 }
 
-func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.SignedBeaconBlock, head state.BeaconState) (*enginev1.BlindedBlobsBundle, *enginev1.BlobsBundle, error) {
+func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.SignedBeaconBlock, head state.BeaconState, budget *proposalBudget, relays []string, simulate bool) (*enginev1.BlindedBlobsBundle, *enginev1.BlobsBundle, error) {
 	// Build consensus fields in background
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -248,7 +283,7 @@ func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.Signed
 		sBlk.SetEth1Data(eth1Data)
 
 		// Set deposit and attestation.
-		deposits, atts, err := vs.packDepositsAndAttestations(ctx, head, eth1Data) // TODO: split attestations and deposits
+		deposits, atts, err := vs.packDepositsAndAttestations(ctx, head, eth1Data, budget) // TODO: split attestations and deposits
 		if err != nil {
 			sBlk.SetDeposits([]*ethpb.Deposit{})
 			sBlk.SetAttestations([]*ethpb.Attestation{})
@@ -257,6 +292,7 @@ func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.Signed
 			sBlk.SetDeposits(deposits)
 			sBlk.SetAttestations(atts)
 		}
+		budget.end(proposalStageAttestations)
 
 		// Set slashings.
 		validProposerSlashings, validAttSlashings := vs.getSlashings(ctx, head)
@@ -273,25 +309,34 @@ func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.Signed
 		vs.setBlsToExecData(sBlk, head)
 	}()
 
-	localPayload, blobsBundle, overrideBuilder, err := vs.getLocalPayloadAndBlobs(ctx, sBlk.Block(), head)
-	if err != nil {
-		return nil, nil, status.Errorf(codes.Internal, "Could not get local payload: %v", err)
-	}
-
-	// There's no reason to try to get a builder bid if local override is true.
-	var builderPayload interfaces.ExecutionData
 	var blindBlobsBundle *enginev1.BlindedBlobsBundle
-	if !overrideBuilder {
-		builderPayload, blindBlobsBundle, err = vs.getBuilderPayloadAndBlobs(ctx, sBlk.Block().Slot(), sBlk.Block().ProposerIndex())
+	localPayload, blobsBundle, overrideBuilder, err := vs.getLocalPayloadAndBlobs(ctx, sBlk.Block(), head)
+	switch {
+	case errors.Is(err, execution.ErrAcceptedSyncingPayloadStatus) && simulate:
+		return nil, nil, status.Errorf(codes.Unavailable, "Could not get local payload: %v", err)
+	case errors.Is(err, execution.ErrAcceptedSyncingPayloadStatus):
+		blindBlobsBundle, err = vs.setBuilderExecutionWhenSyncing(ctx, sBlk, relays)
 		if err != nil {
-			builderGetPayloadMissCount.Inc()
-			log.WithError(err).Error("Could not get builder payload")
+			return nil, nil, err
+		}
+	case err != nil:
+		return nil, nil, status.Errorf(codes.Internal, "Could not get local payload: %v", err)
+	default:
+		// There's no reason to try to get a builder bid if local override is true.
+		var builderPayload interfaces.ExecutionData
+		if !overrideBuilder && !simulate {
+			builderPayload, blindBlobsBundle, err = vs.getBuilderPayloadWithinBudget(ctx, budget, sBlk.Block().Slot(), sBlk.Block().ProposerIndex(), relays)
+			if err != nil {
+				builderGetPayloadMissCount.Inc()
+				log.WithError(err).Error("Could not get builder payload")
+			}
 		}
-	}
 
-	if err := setExecutionData(ctx, sBlk, localPayload, builderPayload); err != nil {
-		return nil, nil, status.Errorf(codes.Internal, "Could not set execution data: %v", err)
+		if err := setExecutionData(ctx, sBlk, localPayload, builderPayload); err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "Could not set execution data: %v", err)
+		}
 	}
+	budget.end(proposalStagePayload)
 
 	if err := setKzgCommitments(sBlk, blobsBundle, blindBlobsBundle); err != nil {
 		return nil, nil, status.Errorf(codes.Internal, "Could not set kzg commitment: %v", err)
@@ -377,6 +422,9 @@ func (vs *Server) ProposeBeaconBlock(ctx context.Context, req *ethpb.GenericSign
 	if err := vs.BlockReceiver.ReceiveBlock(ctx, blk, root); err != nil {
 		return nil, fmt.Errorf("could not process beacon block: %v", err)
 	}
+	if vs.LocalBroadcasts != nil {
+		vs.LocalBroadcasts.AddBlock(root, blk.Block().Slot(), scs)
+	}
 
 	log.WithField("slot", blk.Block().Slot()).Debugf(
 		"Block proposal received via RPC")
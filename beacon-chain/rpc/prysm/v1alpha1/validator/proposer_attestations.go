@@ -3,6 +3,7 @@ package validator
 import (
 	"context"
 	"sort"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
@@ -19,7 +20,7 @@ import (
 
 type proposerAtts []*ethpb.Attestation
 
-func (vs *Server) packAttestations(ctx context.Context, latestState state.BeaconState) ([]*ethpb.Attestation, error) {
+func (vs *Server) packAttestations(ctx context.Context, latestState state.BeaconState, budget *proposalBudget) ([]*ethpb.Attestation, error) {
 	ctx, span := trace.StartSpan(ctx, "ProposerServer.packAttestations")
 	defer span.End()
 
@@ -71,8 +72,21 @@ func (vs *Server) packAttestations(ctx context.Context, latestState state.Beacon
 	if err != nil {
 		return nil, err
 	}
-	atts = sorted.limitToMaxAttestations()
-	return atts, nil
+	// Once the attestations stage of the proposal budget is over, the attestations are not packed for maximal reward
+	// so that the block is not delayed further.
+	packingBudget := budget.timeout(proposalStageAttestations, attestationPackingBudget)
+	if packingBudget == 0 {
+		budget.degrade("skip_attestation_packing")
+		return sorted.limitToMaxAttestations(), nil
+	}
+	start := time.Now()
+	packed, stats, err := sorted.packForBlock(ctx, latestState, packingBudget)
+	if err != nil {
+		log.WithError(err).Error("Could not pack attestations for maximal reward, using profitability order")
+		return sorted.limitToMaxAttestations(), nil
+	}
+	stats.log(latestState.Slot(), len(packed), time.Since(start))
+	return packed, nil
 }
 
 // filter separates attestation list into two groups: valid and invalid attestations.
@@ -0,0 +1,317 @@
+package validator
+
+import (
+	"container/heap"
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	coreTime "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// attestationPackingBudget bounds the time spent selecting the attestations of a block. Once it is exceeded,
+// the remaining attestations are taken in profitability order.
+var attestationPackingBudget = 200 * time.Millisecond
+
+var (
+	attestationPackingEfficiency = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "proposer_attestation_packing_efficiency",
+		Help: "The share of the reward available from the attestation pool that is collected by the attestations packed in the last produced block.",
+	})
+	attestationPackingVotes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "proposer_attestation_packing_new_votes",
+		Help: "The number of rewarded attester votes included by the attestations packed in the last produced block.",
+	})
+	attestationPackingRedundantBits = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "proposer_attestation_packing_redundant_bits",
+		Help: "The number of aggregation bits of the attestations packed in the last produced block which did not earn any reward.",
+	})
+	attestationPackingDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "proposer_attestation_packing_milliseconds",
+		Help:    "The time spent selecting the attestations of a block.",
+		Buckets: []float64{1, 5, 10, 25, 50, 100, 200, 500},
+	})
+	attestationPackingTimeouts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "proposer_attestation_packing_timeouts_total",
+		Help: "The number of blocks for which attestation selection exceeded its time budget.",
+	})
+)
+
+// packingCandidate is an attestation considered for inclusion, with the reward weight of each of its set bits.
+type packingCandidate struct {
+	att *ethpb.Attestation
+	key committeeKey
+	// bits are the set aggregation bits of the attestation and weights the reward earned by including each of them.
+	bits    []int
+	weights []uint64
+	// gain is the reward added by the candidate, given the candidates selected when it was last evaluated.
+	gain  uint64
+	index int
+}
+
+type committeeKey struct {
+	slot      primitives.Slot
+	committee primitives.CommitteeIndex
+}
+
+// packingStats describes the attestations selected for a block.
+type packingStats struct {
+	reward        uint64
+	maxReward     uint64
+	newVotes      int
+	redundantBits int
+	timedOut      bool
+}
+
+// packForBlock selects up to MAX_ATTESTATIONS attestations for a block built on the given state, maximizing
+// the proposer reward. It runs a weighted greedy max-cover over the aggregation bits of all attestations, where
+// a bit is worth the reward weight of the participation flags its validator earns by being included, and bits
+// already covered by a selected attestation or already credited in the state are worth nothing. Attestations
+// adding no reward are dropped. Validators are assumed to have the same effective balance.
+//
+// The attestations must be ordered by profitability: once the time budget is exceeded, the remaining space is
+// filled in that order.
+func (a proposerAtts) packForBlock(ctx context.Context, st state.BeaconState, budget time.Duration) (proposerAtts, *packingStats, error) {
+	ctx, span := trace.StartSpan(ctx, "ProposerServer.packForBlock")
+	defer span.End()
+
+	deadline := time.Now().Add(budget)
+	candidates, err := packingCandidates(ctx, st, a)
+	if err != nil {
+		return nil, nil, err
+	}
+	stats := &packingStats{maxReward: maxPackingReward(candidates)}
+	covered := make(map[committeeKey]map[int]bool)
+	limit := int(params.BeaconConfig().MaxAttestations)
+
+	// Gains only decrease as candidates are selected, so a candidate whose refreshed gain is still the highest
+	// one in the queue is the best choice, without refreshing the others.
+	q := make(candidateQueue, len(candidates))
+	copy(q, candidates)
+	heap.Init(&q)
+	selected := make(proposerAtts, 0, limit)
+	isSelected := make(map[int]bool, limit)
+	for len(selected) < limit && q.Len() > 0 {
+		if time.Now().After(deadline) {
+			stats.timedOut = true
+			break
+		}
+		c := q[0]
+		gain := c.marginalGain(covered[c.key])
+		if gain == 0 {
+			heap.Pop(&q)
+			continue
+		}
+		if gain < c.gain {
+			c.gain = gain
+			heap.Fix(&q, 0)
+			continue
+		}
+		heap.Pop(&q)
+		selected = append(selected, c.att)
+		isSelected[c.index] = true
+		stats.reward += gain
+		seen := covered[c.key]
+		if seen == nil {
+			seen = make(map[int]bool, len(c.bits))
+			covered[c.key] = seen
+		}
+		for i, bit := range c.bits {
+			if seen[bit] {
+				stats.redundantBits++
+				continue
+			}
+			seen[bit] = true
+			if c.weights[i] > 0 {
+				stats.newVotes++
+			} else {
+				stats.redundantBits++
+			}
+		}
+	}
+	if stats.timedOut {
+		for _, c := range candidates {
+			if len(selected) >= limit {
+				break
+			}
+			if !isSelected[c.index] {
+				selected = append(selected, c.att)
+			}
+		}
+	}
+	return selected, stats, nil
+}
+
+// packingCandidates computes the reward weight of every set bit of the attestations.
+func packingCandidates(ctx context.Context, st state.BeaconState, atts proposerAtts) ([]*packingCandidate, error) {
+	var currentParticipation, previousParticipation []byte
+	if st.Version() >= version.Altair {
+		var err error
+		currentParticipation, err = st.CurrentEpochParticipation()
+		if err != nil {
+			return nil, err
+		}
+		previousParticipation, err = st.PreviousEpochParticipation()
+		if err != nil {
+			return nil, err
+		}
+	}
+	currentEpoch := coreTime.CurrentEpoch(st)
+	flagWeights := participationFlagWeights()
+
+	candidates := make([]*packingCandidate, 0, len(atts))
+	for i, att := range atts {
+		c := &packingCandidate{
+			att:   att,
+			key:   committeeKey{slot: att.Data.Slot, committee: att.Data.CommitteeIndex},
+			bits:  att.AggregationBits.BitIndices(),
+			index: i,
+		}
+		c.weights = make([]uint64, len(c.bits))
+		if st.Version() < version.Altair {
+			// Before Altair, the proposer is rewarded for every attester included for the first time.
+			for j := range c.weights {
+				c.weights[j] = 1
+			}
+		} else {
+			flags, err := altair.AttestationParticipationFlagIndices(st, att.Data, st.Slot()-att.Data.Slot)
+			if err != nil {
+				// The attestation does not earn any reward.
+				candidates = append(candidates, c)
+				continue
+			}
+			committee, err := helpers.BeaconCommitteeFromState(ctx, st, att.Data.Slot, att.Data.CommitteeIndex)
+			if err != nil {
+				return nil, err
+			}
+			participation := previousParticipation
+			if att.Data.Target.Epoch == currentEpoch {
+				participation = currentParticipation
+			}
+			for j, bit := range c.bits {
+				if bit >= len(committee) || uint64(committee[bit]) >= uint64(len(participation)) {
+					return nil, errors.Errorf("aggregation bit %d out of range of committee %d at slot %d", bit, att.Data.CommitteeIndex, att.Data.Slot)
+				}
+				for flag, weight := range flagWeights {
+					if !flags[flag] {
+						continue
+					}
+					has, err := altair.HasValidatorFlag(participation[committee[bit]], flag)
+					if err != nil {
+						return nil, err
+					}
+					if !has {
+						c.weights[j] += weight
+					}
+				}
+			}
+		}
+		c.gain = c.marginalGain(nil)
+		candidates = append(candidates, c)
+	}
+	return candidates, nil
+}
+
+func participationFlagWeights() map[uint8]uint64 {
+	cfg := params.BeaconConfig()
+	return map[uint8]uint64{
+		cfg.TimelySourceFlagIndex: cfg.TimelySourceWeight,
+		cfg.TimelyTargetFlagIndex: cfg.TimelyTargetWeight,
+		cfg.TimelyHeadFlagIndex:   cfg.TimelyHeadWeight,
+	}
+}
+
+// maxPackingReward returns the reward earned by including all candidates.
+func maxPackingReward(candidates []*packingCandidate) uint64 {
+	best := make(map[committeeKey]map[int]uint64)
+	for _, c := range candidates {
+		weights := best[c.key]
+		if weights == nil {
+			weights = make(map[int]uint64)
+			best[c.key] = weights
+		}
+		for i, bit := range c.bits {
+			if c.weights[i] > weights[bit] {
+				weights[bit] = c.weights[i]
+			}
+		}
+	}
+	var total uint64
+	for _, weights := range best {
+		for _, w := range weights {
+			total += w
+		}
+	}
+	return total
+}
+
+// marginalGain returns the reward added by the candidate, given the bits of its committee already covered.
+func (c *packingCandidate) marginalGain(covered map[int]bool) uint64 {
+	var gain uint64
+	for i, bit := range c.bits {
+		if !covered[bit] {
+			gain += c.weights[i]
+		}
+	}
+	return gain
+}
+
+// candidateQueue is a max-heap of candidates ordered by gain. Ties are broken by the profitability order of
+// the candidates.
+type candidateQueue []*packingCandidate
+
+func (q candidateQueue) Len() int { return len(q) }
+
+func (q candidateQueue) Less(i, j int) bool {
+	if q[i].gain != q[j].gain {
+		return q[i].gain > q[j].gain
+	}
+	return q[i].index < q[j].index
+}
+
+func (q candidateQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *candidateQueue) Push(x any) { *q = append(*q, x.(*packingCandidate)) }
+
+func (q *candidateQueue) Pop() any {
+	old := *q
+	n := len(old)
+	c := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return c
+}
+
+func (s *packingStats) log(slot primitives.Slot, atts int, duration time.Duration) {
+	efficiency := float64(1)
+	if s.maxReward > 0 {
+		efficiency = float64(s.reward) / float64(s.maxReward)
+	}
+	attestationPackingEfficiency.Set(efficiency)
+	attestationPackingVotes.Set(float64(s.newVotes))
+	attestationPackingRedundantBits.Set(float64(s.redundantBits))
+	attestationPackingDuration.Observe(float64(duration.Milliseconds()))
+	if s.timedOut {
+		attestationPackingTimeouts.Inc()
+	}
+	log.WithFields(logrus.Fields{
+		"slot":          slot,
+		"attestations":  atts,
+		"newVotes":      s.newVotes,
+		"redundantBits": s.redundantBits,
+		"efficiency":    efficiency,
+		"timedOut":      s.timedOut,
+		"duration":      duration,
+	}).Debug("Packed attestations")
+}
@@ -0,0 +1,116 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func packingAtt(t *testing.T, data *ethpb.AttestationData, size uint64, bits ...uint64) *ethpb.Attestation {
+	b := bitfield.NewBitlist(size)
+	for _, i := range bits {
+		b.SetBitAt(i, true)
+	}
+	att := util.HydrateAttestation(&ethpb.Attestation{AggregationBits: b})
+	if data != nil {
+		att.Data = data
+	}
+	return att
+}
+
+func TestProposer_ProposerAtts_packForBlock(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisState(t, 64)
+
+	t.Run("overlapping bits", func(t *testing.T) {
+		atts := proposerAtts{
+			packingAtt(t, nil, 8, 1, 2, 3, 4, 5),
+			packingAtt(t, nil, 8, 0, 1, 2, 3),
+			packingAtt(t, nil, 8, 4, 5, 6, 7),
+			packingAtt(t, nil, 8, 5, 6),
+		}
+		packed, stats, err := atts.packForBlock(ctx, st, attestationPackingBudget)
+		require.NoError(t, err)
+		// The largest attestation is picked first, then the one adding the most new bits. The last one
+		// adds nothing and is dropped.
+		assert.DeepEqual(t, []*ethpb.Attestation{atts[0], atts[2], atts[1]}, []*ethpb.Attestation(packed))
+		assert.Equal(t, uint64(8), stats.reward)
+		assert.Equal(t, uint64(8), stats.maxReward)
+		assert.Equal(t, 8, stats.newVotes)
+		assert.Equal(t, 5, stats.redundantBits)
+		assert.Equal(t, false, stats.timedOut)
+	})
+
+	t.Run("limited to max attestations", func(t *testing.T) {
+		var atts proposerAtts
+		for i := uint64(0); i < params.BeaconConfig().MaxAttestations+10; i++ {
+			atts = append(atts, packingAtt(t, nil, 512, i))
+		}
+		packed, stats, err := atts.packForBlock(ctx, st, attestationPackingBudget)
+		require.NoError(t, err)
+		assert.Equal(t, int(params.BeaconConfig().MaxAttestations), len(packed))
+		assert.Equal(t, params.BeaconConfig().MaxAttestations, stats.reward)
+		assert.Equal(t, params.BeaconConfig().MaxAttestations+10, stats.maxReward)
+	})
+
+	t.Run("time budget exceeded", func(t *testing.T) {
+		atts := proposerAtts{
+			packingAtt(t, nil, 8, 0, 1),
+			packingAtt(t, nil, 8, 0, 1, 2),
+		}
+		packed, stats, err := atts.packForBlock(ctx, st, 0)
+		require.NoError(t, err)
+		assert.Equal(t, true, stats.timedOut)
+		// The attestations are kept in the given order.
+		assert.DeepEqual(t, []*ethpb.Attestation(atts), []*ethpb.Attestation(packed))
+	})
+}
+
+func TestProposer_ProposerAtts_packForBlock_Participation(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateAltair(t, 256)
+	require.NoError(t, st.SetSlot(1))
+	blockRoot := st.BlockRoots()[0]
+	data := &ethpb.AttestationData{
+		BeaconBlockRoot: blockRoot,
+		Source:          st.CurrentJustifiedCheckpoint(),
+		Target:          &ethpb.Checkpoint{Root: blockRoot},
+	}
+	committee, err := helpers.BeaconCommitteeFromState(ctx, st, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, true, len(committee) >= 5)
+
+	// The first three validators of the committee already have all their flags credited.
+	participation, err := st.CurrentEpochParticipation()
+	require.NoError(t, err)
+	for _, idx := range committee[:3] {
+		participation[idx] = 0b111
+	}
+	require.NoError(t, st.SetCurrentParticipationBits(participation))
+
+	size := uint64(len(committee))
+	atts := proposerAtts{
+		packingAtt(t, data, size, 0, 1, 2),
+		packingAtt(t, data, size, 2, 3),
+		packingAtt(t, data, size, 4),
+	}
+	packed, stats, err := atts.packForBlock(ctx, st, attestationPackingBudget)
+	require.NoError(t, err)
+	// The first attestation does not earn any reward.
+	require.Equal(t, 2, len(packed))
+	assert.DeepEqual(t, atts[1], packed[0])
+	assert.DeepEqual(t, atts[2], packed[1])
+	cfg := params.BeaconConfig()
+	weight := cfg.TimelySourceWeight + cfg.TimelyTargetWeight + cfg.TimelyHeadWeight
+	assert.Equal(t, 2*weight, stats.reward)
+	assert.Equal(t, 2*weight, stats.maxReward)
+	assert.Equal(t, 2, stats.newVotes)
+	assert.Equal(t, 1, stats.redundantBits)
+}
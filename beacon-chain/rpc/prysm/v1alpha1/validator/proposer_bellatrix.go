@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v4/api"
 	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
@@ -25,6 +27,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 // builderGetPayloadMissCount tracks the number of misses when validator tries to get a payload from builder
@@ -33,6 +37,12 @@ var builderGetPayloadMissCount = promauto.NewCounter(prometheus.CounterOpts{
 	Help: "The number of get payload misses for validator requests to builder",
 })
 
+// payloadDecisionCount tracks the choices between the local execution payload and the builder bid of the proposals.
+var payloadDecisionCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "proposer_payload_decision_total",
+	Help: "The number of proposals using the local execution payload or the builder bid, by source and reason",
+}, []string{"source", "reason"})
+
 // emptyTransactionsRoot represents the returned value of ssz.TransactionsRoot([][]byte{}) and
 // can be used as a constant to avoid recomputing this value in every call.
 var emptyTransactionsRoot = [32]byte{127, 254, 36, 30, 166, 1, 135, 253, 176, 24, 123, 250, 34, 222, 53, 209, 249, 190, 215, 171, 6, 29, 148, 1, 253, 71, 227, 74, 84, 251, 237, 225}
@@ -41,9 +51,23 @@ var emptyTransactionsRoot = [32]byte{127, 254, 36, 30, 166, 1, 135, 253, 176, 24
 // block request. This value is known as `BUILDER_PROPOSAL_DELAY_TOLERANCE` in builder spec.
 const blockBuilderTimeout = 1 * time.Second
 
+const (
+	payloadSourceLocal   = "local"
+	payloadSourceBuilder = "builder"
+)
+
+// payloadDecision is the outcome of the choice between the local execution payload and the builder bid for a proposal.
+type payloadDecision struct {
+	source           string
+	reason           string
+	localValueGwei   uint64
+	builderValueGwei uint64
+	boost            uint64
+}
+
 // Sets the execution data for the block. Execution data can come from local EL client or remote builder depends on validator registration and circuit breaker conditions.
 func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, localPayload, builderPayload interfaces.ExecutionData) error {
-	_, span := trace.StartSpan(ctx, "ProposerServer.setExecutionData")
+	ctx, span := trace.StartSpan(ctx, "ProposerServer.setExecutionData")
 	defer span.End()
 
 	slot := blk.Block().Slot()
@@ -55,76 +79,115 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 		return errors.New("local payload is nil")
 	}
 
-	// Use local payload if builder payload is nil.
-	if builderPayload == nil {
-		return blk.SetExecution(localPayload)
+	d, err := choosePayload(blk.Version(), localPayload, builderPayload)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return err
 	}
-
-	switch {
-	case blk.Version() >= version.Capella:
-		// Compare payload values between local and builder. Default to the local value if it is higher.
-		localValueGwei, err := localPayload.ValueInGwei()
-		if err != nil {
-			return errors.Wrap(err, "failed to get local payload value")
-		}
-		builderValueGwei, err := builderPayload.ValueInGwei()
-		if err != nil {
-			log.WithError(err).Warn("Proposer: failed to get builder payload value") // Default to local if can't get builder value.
-			return blk.SetExecution(localPayload)
-		}
-
-		withdrawalsMatched, err := matchingWithdrawalsRoot(localPayload, builderPayload)
-		if err != nil {
-			tracing.AnnotateError(span, err)
-			log.WithError(err).Warn("Proposer: failed to match withdrawals root")
-			return blk.SetExecution(localPayload)
-		}
-
-		// Use builder payload if the following in true:
-		// builder_bid_value * 100 > local_block_value * (local-block-value-boost + 100)
-		boost := params.BeaconConfig().LocalBlockValueBoost
-		higherValueBuilder := builderValueGwei*100 > localValueGwei*(100+boost)
-
-		// If we can't get the builder value, just use local block.
-		if higherValueBuilder && withdrawalsMatched { // Builder value is higher and withdrawals match.
-			blk.SetBlinded(true)
-			if err := blk.SetExecution(builderPayload); err != nil {
-				log.WithError(err).Warn("Proposer: failed to set builder payload")
-				blk.SetBlinded(false)
-				return blk.SetExecution(localPayload)
-			} else {
-				return nil
-			}
-		}
-		if !higherValueBuilder {
-			log.WithFields(logrus.Fields{
-				"localGweiValue":       localValueGwei,
-				"localBoostPercentage": boost,
-				"builderGweiValue":     builderValueGwei,
-			}).Warn("Proposer: using local execution payload because higher value")
-		}
-		span.AddAttributes(
-			trace.BoolAttribute("higherValueBuilder", higherValueBuilder),
-			trace.Int64Attribute("localGweiValue", int64(localValueGwei)),     // lint:ignore uintcast -- This is OK for tracing.
-			trace.Int64Attribute("localBoostPercentage", int64(boost)),        // lint:ignore uintcast -- This is OK for tracing.
-			trace.Int64Attribute("builderGweiValue", int64(builderValueGwei)), // lint:ignore uintcast -- This is OK for tracing.
-		)
-		return blk.SetExecution(localPayload)
-	default: // Bellatrix case.
+	if d.source == payloadSourceBuilder {
 		blk.SetBlinded(true)
 		if err := blk.SetExecution(builderPayload); err != nil {
 			log.WithError(err).Warn("Proposer: failed to set builder payload")
 			blk.SetBlinded(false)
-			return blk.SetExecution(localPayload)
+			d.source = payloadSourceLocal
+			d.reason = "invalid builder payload"
 		} else {
+			recordPayloadDecision(ctx, span, slot, d)
 			return nil
 		}
 	}
+	recordPayloadDecision(ctx, span, slot, d)
+	return blk.SetExecution(localPayload)
+}
+
+// choosePayload chooses between the local execution payload and the builder bid. From Capella, the builder bid is
+// only chosen if its value exceeds the local value boosted by the local block value boost percentage, that is if
+// builder_bid_value * 100 > local_block_value * (local-block-value-boost + 100).
+func choosePayload(v int, localPayload, builderPayload interfaces.ExecutionData) (*payloadDecision, error) {
+	d := &payloadDecision{source: payloadSourceLocal, boost: params.BeaconConfig().LocalBlockValueBoost}
+	// Use local payload if builder payload is nil.
+	if builderPayload == nil {
+		d.reason = "no builder bid"
+		return d, nil
+	}
+	if v < version.Capella {
+		// Payload values are not compared before Capella.
+		d.source = payloadSourceBuilder
+		d.reason = "builder bid before capella"
+		return d, nil
+	}
+
+	// Compare payload values between local and builder. Default to the local value if it is higher.
+	localValueGwei, err := localPayload.ValueInGwei()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get local payload value")
+	}
+	d.localValueGwei = localValueGwei
+	builderValueGwei, err := builderPayload.ValueInGwei()
+	if err != nil {
+		log.WithError(err).Warn("Proposer: failed to get builder payload value") // Default to local if can't get builder value.
+		d.reason = "builder bid value unavailable"
+		return d, nil
+	}
+	d.builderValueGwei = builderValueGwei
+
+	withdrawalsMatched, err := matchingWithdrawalsRoot(localPayload, builderPayload)
+	if err != nil {
+		log.WithError(err).Warn("Proposer: failed to match withdrawals root")
+		d.reason = "builder withdrawals unavailable"
+		return d, nil
+	}
+
+	higherValueBuilder := builderValueGwei*100 > localValueGwei*(100+d.boost)
+	switch {
+	case !higherValueBuilder:
+		d.reason = "builder bid below boosted local value"
+	case !withdrawalsMatched:
+		d.reason = "builder withdrawals mismatch"
+	default:
+		d.source = payloadSourceBuilder
+		d.reason = "builder bid above boosted local value"
+	}
+	return d, nil
+}
+
+// recordPayloadDecision exposes the payload decision of a proposal through the logs, the metrics, the trace and
+// the response headers of the block production APIs.
+func recordPayloadDecision(ctx context.Context, span *trace.Span, slot primitives.Slot, d *payloadDecision) {
+	payloadDecisionCount.WithLabelValues(d.source, d.reason).Inc()
+	span.AddAttributes(
+		trace.StringAttribute("payloadSource", d.source),
+		trace.StringAttribute("reason", d.reason),
+		trace.Int64Attribute("localGweiValue", int64(d.localValueGwei)),     // lint:ignore uintcast -- This is OK for tracing.
+		trace.Int64Attribute("localBoostPercentage", int64(d.boost)),        // lint:ignore uintcast -- This is OK for tracing.
+		trace.Int64Attribute("builderGweiValue", int64(d.builderValueGwei)), // lint:ignore uintcast -- This is OK for tracing.
+	)
+	if err := grpc.SetHeader(ctx, metadata.Pairs(
+		api.PayloadSourceHeader, d.source,
+		api.PayloadSelectionReasonHeader, d.reason,
+		api.LocalPayloadValueHeader, strconv.FormatUint(d.localValueGwei, 10),
+		api.BuilderPayloadValueHeader, strconv.FormatUint(d.builderValueGwei, 10),
+	)); err != nil {
+		// The block is not requested through a gRPC or an HTTP API call.
+		log.WithError(err).Debug("Could not set payload decision headers")
+	}
+	if d.reason == "no builder bid" {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"slot":                 slot,
+		"payloadSource":        d.source,
+		"reason":               d.reason,
+		"localGweiValue":       d.localValueGwei,
+		"localBoostPercentage": d.boost,
+		"builderGweiValue":     d.builderValueGwei,
+	}).Info("Proposer: chose execution payload")
 }
 
-// This function retrieves the payload header given the slot number and the validator index.
+// This function retrieves the payload header given the slot number and the validator index, from the given relays
+// configured for the proposer or from every relay if none is given.
 // It's a no-op if the latest head block is not versioned bellatrix.
-func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitives.Slot, idx primitives.ValidatorIndex) (interfaces.ExecutionData, *enginev1.BlindedBlobsBundle, error) {
+func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitives.Slot, idx primitives.ValidatorIndex, relays []string) (interfaces.ExecutionData, *enginev1.BlindedBlobsBundle, error) {
 	ctx, span := trace.StartSpan(ctx, "ProposerServer.getPayloadHeaderFromBuilder")
 	defer span.End()
 
@@ -149,7 +212,7 @@ func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitiv
 	ctx, cancel := context.WithTimeout(ctx, blockBuilderTimeout)
 	defer cancel()
 
-	signedBid, err := vs.BlockBuilder.GetHeader(ctx, slot, bytesutil.ToBytes32(h.BlockHash()), pk)
+	signedBid, err := vs.BlockBuilder.GetHeader(ctx, slot, bytesutil.ToBytes32(h.BlockHash()), pk, relays)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,179 @@
+package validator
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// The stages of block production which are given a deadline by the proposal budget.
+const (
+	proposalStageState        = "state"
+	proposalStagePayload      = "payload"
+	proposalStageAttestations = "attestations"
+	proposalStageSigning      = "signing"
+)
+
+// proposalStageShares are the shares of the proposal window, in basis points, given to the stages of block
+// production. The proposal window is the part of the slot within which the block should be broadcast: 4 seconds on
+// mainnet. The validator client signs and broadcasts the block in the time left.
+var proposalStageShares = map[string]int64{
+	proposalStageState:        2500,
+	proposalStagePayload:      2500,
+	proposalStageAttestations: 3750,
+}
+
+// proposalStageCutoffs returns the ends of the stages of block production, in basis points of the proposal window.
+// The payload and the attestations are prepared once the state is ready: in parallel when the block is built in
+// parallel, and otherwise the attestations first.
+func proposalStageCutoffs(parallel bool) map[string]int64 {
+	cutoffs := map[string]int64{
+		proposalStageState:   proposalStageShares[proposalStageState],
+		proposalStageSigning: 10000,
+	}
+	cutoffs[proposalStageAttestations] = cutoffs[proposalStageState] + proposalStageShares[proposalStageAttestations]
+	payloadStart := cutoffs[proposalStageState]
+	if !parallel {
+		payloadStart = cutoffs[proposalStageAttestations]
+	}
+	cutoffs[proposalStagePayload] = payloadStart + proposalStageShares[proposalStagePayload]
+	return cutoffs
+}
+
+// minSigningTime is the share of the proposal window, in basis points, that the validator client should be left with
+// to sign and broadcast the block.
+const minSigningTime = 1250
+
+var (
+	proposalBudgetOverruns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "proposal_budget_overruns_total",
+		Help: "The number of proposals for which a stage of block production ended after its deadline.",
+	}, []string{"stage"})
+	proposalBudgetDegradations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "proposal_budget_degradations_total",
+		Help: "The number of proposals for which a stage of block production was cut short to keep within the budget.",
+	}, []string{"action"})
+)
+
+// proposalBudget gives each stage of the production of the block of the current slot a deadline, so that the block
+// can still be broadcast within the proposal window when earlier stages take too long: the builder is skipped once
+// the payload stage is over, and the attestations are taken in profitability order once the attestations stage is.
+//
+// A nil budget sets no deadline. Blocks for other slots than the current one are not given a budget.
+type proposalBudget struct {
+	slot    primitives.Slot
+	start   time.Time
+	window  time.Duration
+	cutoffs map[string]int64
+}
+
+// proposalBudget returns the budget of the block of a slot, or nil if the slot is not the current slot.
+func (vs *Server) proposalBudget(slot primitives.Slot) *proposalBudget {
+	if slot != vs.TimeFetcher.CurrentSlot() {
+		return nil
+	}
+	start, err := slots.ToTime(uint64(vs.TimeFetcher.GenesisTime().Unix()), slot)
+	if err != nil {
+		log.WithError(err).Error("Could not get the start time of the slot")
+		return nil
+	}
+	return &proposalBudget{
+		slot:    slot,
+		start:   start,
+		window:  slots.DivideSlotBy(int64(params.BeaconConfig().IntervalsPerSlot)),
+		cutoffs: proposalStageCutoffs(features.Get().BuildBlockParallel),
+	}
+}
+
+// deadline returns the time by which a stage should end.
+func (b *proposalBudget) deadline(stage string) time.Time {
+	return b.start.Add(b.window * time.Duration(b.cutoffs[stage]) / 10000)
+}
+
+// overrun returns whether the deadline of a stage has passed.
+func (b *proposalBudget) overrun(stage string) bool {
+	if b == nil {
+		return false
+	}
+	return time.Now().After(b.deadline(stage))
+}
+
+// timeout returns the time left before the deadline of a stage, capped at limit.
+func (b *proposalBudget) timeout(stage string, limit time.Duration) time.Duration {
+	if b == nil {
+		return limit
+	}
+	left := time.Until(b.deadline(stage))
+	if left < 0 {
+		return 0
+	}
+	if left > limit {
+		return limit
+	}
+	return left
+}
+
+// stageContext returns a context which is done at the deadline of a stage.
+func (b *proposalBudget) stageContext(ctx context.Context, stage string) (context.Context, context.CancelFunc) {
+	if b == nil {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, b.deadline(stage))
+}
+
+// end records the end of a stage, and reports it if it ended after its deadline.
+func (b *proposalBudget) end(stage string) {
+	if !b.overrun(stage) {
+		return
+	}
+	proposalBudgetOverruns.WithLabelValues(stage).Inc()
+	log.WithFields(logrus.Fields{
+		"slot":               b.slot,
+		"stage":              stage,
+		"sinceSlotStartTime": time.Since(b.start),
+	}).Warn("Block production stage ended after its deadline")
+}
+
+// handOff records that the block is handed to the validator client, and reports it if too little of the proposal
+// window is left to sign and broadcast it.
+func (b *proposalBudget) handOff() {
+	if b == nil || b.timeout(proposalStageSigning, b.window) >= b.window*time.Duration(minSigningTime)/10000 {
+		return
+	}
+	proposalBudgetOverruns.WithLabelValues(proposalStageSigning).Inc()
+	log.WithFields(logrus.Fields{
+		"slot":               b.slot,
+		"sinceSlotStartTime": time.Since(b.start),
+	}).Warn("Block produced with too little time left to sign and broadcast it within the proposal window")
+}
+
+// degrade records that a stage was cut short to keep within the budget.
+func (b *proposalBudget) degrade(action string) {
+	proposalBudgetDegradations.WithLabelValues(action).Inc()
+	log.WithFields(logrus.Fields{
+		"slot":               b.slot,
+		"action":             action,
+		"sinceSlotStartTime": time.Since(b.start),
+	}).Warn("Block production is behind its budget, cutting a stage short")
+}
+
+// getBuilderPayloadWithinBudget gets the payload of the builder, unless the payload stage of the budget is over, in
+// which case the local payload is used. The request to the builder is cut short at the end of the stage.
+func (vs *Server) getBuilderPayloadWithinBudget(ctx context.Context, budget *proposalBudget, slot primitives.Slot, idx primitives.ValidatorIndex, relays []string) (interfaces.ExecutionData, *enginev1.BlindedBlobsBundle, error) {
+	if budget.overrun(proposalStagePayload) {
+		budget.degrade("skip_builder")
+		return nil, nil, nil
+	}
+	ctx, cancel := budget.stageContext(ctx, proposalStagePayload)
+	defer cancel()
+	return vs.getBuilderPayloadAndBlobs(ctx, slot, idx, relays)
+}
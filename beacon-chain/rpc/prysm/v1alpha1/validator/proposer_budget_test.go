@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestServer_ProposalBudget(t *testing.T) {
+	secondsPerSlot := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	vs := &Server{TimeFetcher: &testutil.MockGenesisTimeFetcher{Genesis: time.Now().Add(-10 * secondsPerSlot)}}
+
+	assert.Equal(t, (*proposalBudget)(nil), vs.proposalBudget(11))
+	budget := vs.proposalBudget(10)
+	require.NotNil(t, budget)
+	window := secondsPerSlot / time.Duration(params.BeaconConfig().IntervalsPerSlot)
+	assert.Equal(t, window, budget.window)
+	assert.Equal(t, budget.start.Add(window/4), budget.deadline(proposalStageState))
+	assert.Equal(t, budget.start.Add(window), budget.deadline(proposalStageSigning))
+
+	// The deadlines follow the order in which the stages are run.
+	resetCfg := features.InitWithReset(&features.Flags{BuildBlockParallel: true})
+	assert.Equal(t, budget.start.Add(window/2), vs.proposalBudget(10).deadline(proposalStagePayload))
+	resetCfg()
+	resetCfg = features.InitWithReset(&features.Flags{BuildBlockParallel: false})
+	defer resetCfg()
+	assert.Equal(t, budget.start.Add(window*7/8), vs.proposalBudget(10).deadline(proposalStagePayload))
+}
+
+func TestProposalStageCutoffs(t *testing.T) {
+	parallel := proposalStageCutoffs(true)
+	assert.Equal(t, int64(2500), parallel[proposalStageState])
+	assert.Equal(t, int64(5000), parallel[proposalStagePayload])
+	assert.Equal(t, int64(6250), parallel[proposalStageAttestations])
+	assert.Equal(t, int64(10000), parallel[proposalStageSigning])
+
+	// Without parallel building, the attestations are packed before the payload is prepared, so the payload stage
+	// starts at the end of the attestations stage.
+	sequential := proposalStageCutoffs(false)
+	assert.Equal(t, int64(2500), sequential[proposalStageState])
+	assert.Equal(t, int64(6250), sequential[proposalStageAttestations])
+	assert.Equal(t, int64(8750), sequential[proposalStagePayload])
+	assert.Equal(t, int64(10000), sequential[proposalStageSigning])
+	assert.Equal(t, true, sequential[proposalStagePayload]+minSigningTime <= sequential[proposalStageSigning])
+}
+
+func TestProposalBudget_Deadlines(t *testing.T) {
+	budget := &proposalBudget{start: time.Now().Add(-3 * time.Second), window: 4 * time.Second, cutoffs: proposalStageCutoffs(true)}
+
+	assert.Equal(t, true, budget.overrun(proposalStageState))
+	assert.Equal(t, true, budget.overrun(proposalStagePayload))
+	assert.Equal(t, false, budget.overrun(proposalStageSigning))
+	assert.Equal(t, time.Duration(0), budget.timeout(proposalStageAttestations, time.Second))
+	assert.Equal(t, 100*time.Millisecond, budget.timeout(proposalStageSigning, 100*time.Millisecond))
+
+	var noBudget *proposalBudget
+	assert.Equal(t, false, noBudget.overrun(proposalStagePayload))
+	assert.Equal(t, time.Second, noBudget.timeout(proposalStageAttestations, time.Second))
+	noBudget.end(proposalStageState)
+	noBudget.handOff()
+}
+
+func TestServer_GetBuilderPayloadWithinBudget_SkipsBuilderWhenLate(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.BellatrixForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	budget := &proposalBudget{slot: 1, start: time.Now().Add(-3 * time.Second), window: 4 * time.Second, cutoffs: proposalStageCutoffs(true)}
+	payload, bundle, err := (&Server{}).getBuilderPayloadWithinBudget(context.Background(), budget, 1, primitives.ValidatorIndex(0), nil)
+	require.NoError(t, err)
+	assert.Equal(t, true, payload == nil)
+	assert.Equal(t, true, bundle == nil)
+}
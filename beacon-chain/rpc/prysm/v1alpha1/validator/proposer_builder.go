@@ -2,13 +2,19 @@ package validator
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
@@ -23,7 +29,7 @@ func (vs *Server) canUseBuilder(ctx context.Context, slot primitives.Slot, idx p
 	if !vs.BlockBuilder.Configured() {
 		return false, nil
 	}
-	activated, err := vs.circuitBreakBuilder(slot)
+	activated, err := vs.circuitBreakBuilder(ctx, slot)
 	span.AddAttributes(trace.BoolAttribute("circuitBreakerActivated", activated))
 	if err != nil {
 		tracing.AnnotateError(span, err)
@@ -51,7 +57,7 @@ func (vs *Server) validatorRegistered(ctx context.Context, id primitives.Validat
 }
 
 // circuitBreakBuilder returns true if the builder is not allowed to be used due to circuit breaker conditions.
-func (vs *Server) circuitBreakBuilder(s primitives.Slot) (bool, error) {
+func (vs *Server) circuitBreakBuilder(ctx context.Context, s primitives.Slot) (bool, error) {
 	if vs.ForkchoiceFetcher == nil {
 		return true, errors.New("no fork choicer configured")
 	}
@@ -96,5 +102,97 @@ func (vs *Server) circuitBreakBuilder(s primitives.Slot) (bool, error) {
 		return true, nil
 	}
 
+	// Circuit breaker is active if the target participation of the previous epoch is lower than `MinBuilderEpochParticipation`.
+	minParticipation := params.BeaconConfig().MinBuilderEpochParticipation
+	if minParticipation == 0 || vs.HeadFetcher == nil {
+		return false, nil
+	}
+	participation, ok, err := vs.previousEpochTargetParticipation(ctx)
+	if err != nil {
+		return true, err
+	}
+	if ok && participation < minParticipation {
+		log.WithFields(logrus.Fields{
+			"participation":    participation,
+			"minParticipation": minParticipation,
+		}).Warn("Circuit breaker activated due to low participation last epoch. Ignore if mev-boost is not used")
+		return true, nil
+	}
+
 	return false, nil
 }
+
+// participationCache holds the target participation of the previous epoch, which is computed once per epoch as it
+// requires iterating over every validator.
+type participationCache struct {
+	sync.Mutex
+	epoch         primitives.Epoch
+	participation uint64
+	ok            bool
+	set           bool
+}
+
+// previousEpochTargetParticipation returns the percentage of active balance which attested to the correct target
+// in the previous epoch of the head state. The boolean is false if participation is not tracked by the head state.
+// The participation is computed at the first proposal of an epoch, later attestations to the target of the previous
+// epoch are not accounted for.
+func (vs *Server) previousEpochTargetParticipation(ctx context.Context) (uint64, bool, error) {
+	st, err := vs.HeadFetcher.HeadStateReadOnly(ctx)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "could not get head state")
+	}
+	if st == nil || st.IsNil() || st.Version() < version.Altair {
+		return 0, false, nil
+	}
+	currentEpoch := slots.ToEpoch(st.Slot())
+	if currentEpoch == 0 {
+		return 0, false, nil
+	}
+	prevEpoch := currentEpoch - 1
+
+	vs.participationCache.Lock()
+	defer vs.participationCache.Unlock()
+	if vs.participationCache.set && vs.participationCache.epoch == prevEpoch {
+		return vs.participationCache.participation, vs.participationCache.ok, nil
+	}
+	participation, ok, err := targetParticipation(st, prevEpoch)
+	if err != nil {
+		return 0, false, err
+	}
+	vs.participationCache.epoch = prevEpoch
+	vs.participationCache.participation = participation
+	vs.participationCache.ok = ok
+	vs.participationCache.set = true
+	return participation, ok, nil
+}
+
+// targetParticipation returns the percentage of active balance which attested to the correct target in the previous
+// epoch of the state.
+func targetParticipation(st state.ReadOnlyBeaconState, prevEpoch primitives.Epoch) (uint64, bool, error) {
+	flags, err := st.PreviousEpochParticipation()
+	if err != nil {
+		return 0, false, errors.Wrap(err, "could not get previous epoch participation")
+	}
+	targetFlagIndex := params.BeaconConfig().TimelyTargetFlagIndex
+	var activeBalance, targetBalance uint64
+	if err := st.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+		if !helpers.IsActiveNonSlashedValidatorUsingTrie(val, prevEpoch) || idx >= len(flags) {
+			return nil
+		}
+		activeBalance += val.EffectiveBalance()
+		hasTarget, err := altair.HasValidatorFlag(flags[idx], targetFlagIndex)
+		if err != nil {
+			return err
+		}
+		if hasTarget {
+			targetBalance += val.EffectiveBalance()
+		}
+		return nil
+	}); err != nil {
+		return 0, false, err
+	}
+	if activeBalance == 0 {
+		return 0, false, nil
+	}
+	return targetBalance * 100 / activeBalance, true, nil
+}
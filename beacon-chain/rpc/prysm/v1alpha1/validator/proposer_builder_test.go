@@ -19,18 +19,20 @@ import (
 	v1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 )
 
 func TestServer_circuitBreakBuilder(t *testing.T) {
 	hook := logTest.NewGlobal()
+	ctx := context.Background()
 	s := &Server{}
-	_, err := s.circuitBreakBuilder(0)
+	_, err := s.circuitBreakBuilder(context.Background(), 0)
 	require.ErrorContains(t, "no fork choicer configured", err)
 
 	s.ForkchoiceFetcher = &blockchainTest.ChainService{ForkChoiceStore: doublylinkedtree.New()}
 	s.ForkchoiceFetcher.SetForkChoiceGenesisTime(uint64(time.Now().Unix()))
-	b, err := s.circuitBreakBuilder(params.BeaconConfig().MaxBuilderConsecutiveMissedSlots + 1)
+	b, err := s.circuitBreakBuilder(ctx, params.BeaconConfig().MaxBuilderConsecutiveMissedSlots+1)
 	require.NoError(
 		t,
 		err,
@@ -40,11 +42,10 @@ func TestServer_circuitBreakBuilder(t *testing.T) {
 
 	ojc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
 	ofc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
-	ctx := context.Background()
 	st, blkRoot, err := createState(1, [32]byte{'a'}, [32]byte{}, params.BeaconConfig().ZeroHash, ojc, ofc)
 	require.NoError(t, err)
 	require.NoError(t, s.ForkchoiceFetcher.InsertNode(ctx, st, blkRoot))
-	b, err = s.circuitBreakBuilder(params.BeaconConfig().MaxBuilderConsecutiveMissedSlots)
+	b, err = s.circuitBreakBuilder(ctx, params.BeaconConfig().MaxBuilderConsecutiveMissedSlots)
 	require.NoError(t, err)
 	require.Equal(t, false, b)
 
@@ -55,7 +56,7 @@ func TestServer_circuitBreakBuilder(t *testing.T) {
 	st, blkRoot, err = createState(params.BeaconConfig().SlotsPerEpoch, [32]byte{'b'}, [32]byte{'a'}, params.BeaconConfig().ZeroHash, ojc, ofc)
 	require.NoError(t, err)
 	require.NoError(t, s.ForkchoiceFetcher.InsertNode(ctx, st, blkRoot))
-	b, err = s.circuitBreakBuilder(params.BeaconConfig().SlotsPerEpoch + 1)
+	b, err = s.circuitBreakBuilder(ctx, params.BeaconConfig().SlotsPerEpoch+1)
 	require.NoError(t, err)
 	require.Equal(t, true, b)
 	require.LogsContain(t, hook, "Circuit breaker activated due to missing enough slots last epoch. Ignore if mev-boost is not used")
@@ -66,7 +67,55 @@ func TestServer_circuitBreakBuilder(t *testing.T) {
 		require.NoError(t, err)
 		require.NoError(t, s.ForkchoiceFetcher.InsertNode(ctx, st, blkRoot))
 	}
-	b, err = s.circuitBreakBuilder(params.BeaconConfig().SlotsPerEpoch + 1)
+	b, err = s.circuitBreakBuilder(ctx, params.BeaconConfig().SlotsPerEpoch+1)
+	require.NoError(t, err)
+	require.Equal(t, false, b)
+}
+
+func TestServer_circuitBreakBuilder_Participation(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.MinBuilderEpochParticipation = 50
+	cfg.MaxBuilderEpochMissedSlots = cfg.SlotsPerEpoch + 1
+	params.OverrideBeaconConfig(cfg)
+
+	headState, _ := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, headState.SetSlot(params.BeaconConfig().SlotsPerEpoch))
+	participation := make([]byte, headState.NumValidators())
+	for i := 0; i < len(participation)/4; i++ {
+		participation[i] = 1 << params.BeaconConfig().TimelyTargetFlagIndex
+	}
+	require.NoError(t, headState.SetPreviousParticipationBits(participation))
+
+	s := &Server{
+		ForkchoiceFetcher: &blockchainTest.ChainService{ForkChoiceStore: doublylinkedtree.New()},
+		HeadFetcher:       &blockchainTest.ChainService{State: headState},
+	}
+	s.ForkchoiceFetcher.SetForkChoiceGenesisTime(uint64(time.Now().Unix()))
+	slot := params.BeaconConfig().SlotsPerEpoch * 2
+	ojc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	ofc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	st, blkRoot, err := createState(slot, [32]byte{'a'}, [32]byte{}, params.BeaconConfig().ZeroHash, ojc, ofc)
+	require.NoError(t, err)
+	require.NoError(t, s.ForkchoiceFetcher.InsertNode(ctx, st, blkRoot))
+	b, err := s.circuitBreakBuilder(ctx, slot)
+	require.NoError(t, err)
+	require.Equal(t, true, b)
+	require.LogsContain(t, hook, "Circuit breaker activated due to low participation last epoch. Ignore if mev-boost is not used")
+
+	for i := range participation {
+		participation[i] = 1 << params.BeaconConfig().TimelyTargetFlagIndex
+	}
+	require.NoError(t, headState.SetPreviousParticipationBits(participation))
+	// The participation of the previous epoch is cached until the head state advances to the next epoch.
+	b, err = s.circuitBreakBuilder(ctx, slot)
+	require.NoError(t, err)
+	require.Equal(t, true, b)
+
+	require.NoError(t, headState.SetSlot(params.BeaconConfig().SlotsPerEpoch*2))
+	b, err = s.circuitBreakBuilder(ctx, slot)
 	require.NoError(t, err)
 	require.Equal(t, false, b)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	consensusblocks "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
@@ -26,6 +27,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -39,6 +42,12 @@ var (
 		Name: "payload_id_cache_hit",
 		Help: "The number of payload id get requests that are present in the cache.",
 	})
+	// executionSyncingProposals tracks the block proposals attempted while the execution client is syncing,
+	// by whether a builder payload was proposed or the proposal was skipped.
+	executionSyncingProposals = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "proposer_execution_syncing_total",
+		Help: "The number of block proposals attempted while the execution client is syncing.",
+	}, []string{"outcome"})
 )
 
 // This returns the local execution payload of a given slot. The function has full awareness of pre and post merge.
@@ -206,6 +215,34 @@ func (vs *Server) getLocalPayloadAndBlobs(ctx context.Context, blk interfaces.Re
 	return payload, blobsBundle, overrideBuilder, nil
 }
 
+// setBuilderExecutionWhenSyncing sets the execution data of a block proposed while the execution client is
+// syncing, and cannot provide a payload built on the head. A local payload would be empty or invalid and get
+// the block orphaned, so the payload of the builder is used if allowed by the
+// --builder-proposal-when-execution-syncing flag, otherwise the proposal is skipped.
+func (vs *Server) setBuilderExecutionWhenSyncing(ctx context.Context, blk interfaces.SignedBeaconBlock, relays []string) (*enginev1.BlindedBlobsBundle, error) {
+	slot := blk.Block().Slot()
+	if !features.Get().BuilderProposalWhenExecutionSyncing {
+		executionSyncingProposals.WithLabelValues("skipped").Inc()
+		return nil, status.Errorf(codes.Unavailable, "Execution client is syncing, not proposing a block at slot %d", slot)
+	}
+	builderPayload, blindBlobsBundle, err := vs.getBuilderPayloadAndBlobs(ctx, slot, blk.Block().ProposerIndex(), relays)
+	if err != nil {
+		builderGetPayloadMissCount.Inc()
+		log.WithError(err).Error("Could not get builder payload")
+	}
+	if builderPayload == nil || builderPayload.IsNil() {
+		executionSyncingProposals.WithLabelValues("skipped").Inc()
+		return nil, status.Errorf(codes.Unavailable, "Execution client is syncing and no builder payload is available, not proposing a block at slot %d", slot)
+	}
+	blk.SetBlinded(true)
+	if err := blk.SetExecution(builderPayload); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not set builder payload: %v", err)
+	}
+	executionSyncingProposals.WithLabelValues("builder").Inc()
+	log.WithField("slot", slot).Warn("Execution client is syncing, proposing a block with the builder payload")
+	return blindBlobsBundle, nil
+}
+
 // warnIfFeeRecipientDiffers logs a warning if the fee recipient in the included payload does not
 // match the requested one.
 func warnIfFeeRecipientDiffers(payload interfaces.ExecutionData, feeRecipient common.Address) {
@@ -252,7 +289,8 @@ func (vs *Server) getTerminalBlockHashIfExists(ctx context.Context, transitionTi
 
 func (vs *Server) getBuilderPayloadAndBlobs(ctx context.Context,
 	slot primitives.Slot,
-	vIdx primitives.ValidatorIndex) (interfaces.ExecutionData, *enginev1.BlindedBlobsBundle, error) {
+	vIdx primitives.ValidatorIndex,
+	relays []string) (interfaces.ExecutionData, *enginev1.BlindedBlobsBundle, error) {
 	ctx, span := trace.StartSpan(ctx, "ProposerServer.getBuilderPayloadAndBlobs")
 	defer span.End()
 
@@ -268,7 +306,7 @@ func (vs *Server) getBuilderPayloadAndBlobs(ctx context.Context,
 		return nil, nil, nil
 	}
 
-	return vs.getPayloadHeaderFromBuilder(ctx, slot, vIdx)
+	return vs.getPayloadHeaderFromBuilder(ctx, slot, vIdx, relays)
 }
 
 // activationEpochNotReached returns true if activation epoch has not been reach.
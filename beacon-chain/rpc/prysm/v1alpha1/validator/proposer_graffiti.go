@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"bytes"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+)
+
+// graffiti returns the graffiti of the block, which is the graffiti requested by the validator followed, if enabled,
+// by the client-diversity code of the execution client and of Prysm in the longest format which fits after it.
+func (vs *Server) graffiti(requested []byte) []byte {
+	if !vs.GraffitiClientInfo || vs.ClientVersionFetcher == nil {
+		return requested
+	}
+	trimmed := bytes.TrimRight(requested, "\x00")
+	space := fieldparams.RootLength - len(trimmed)
+	if len(trimmed) > 0 {
+		// Separate the code from the graffiti of the validator.
+		space--
+	}
+	code := execution.GraffitiCode(vs.ClientVersionFetcher.ExecutionClientVersion(), version.GitCommit(), space)
+	if code == "" {
+		return requested
+	}
+	g := make([]byte, 0, fieldparams.RootLength)
+	g = append(g, trimmed...)
+	if len(trimmed) > 0 {
+		g = append(g, ' ')
+	}
+	g = append(g, code...)
+	return bytesutil.PadTo(g, fieldparams.RootLength)
+}
@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+)
+
+type mockClientVersionFetcher struct {
+	v *execution.ClientVersion
+}
+
+func (m *mockClientVersionFetcher) ExecutionClientVersion() *execution.ClientVersion {
+	return m.v
+}
+
+func TestServer_graffiti(t *testing.T) {
+	fetcher := &mockClientVersionFetcher{v: &execution.ClientVersion{Code: "GE", Commit: "3f907d6a"}}
+	requested := bytesutil.PadTo([]byte("hello"), 32)
+
+	t.Run("disabled", func(t *testing.T) {
+		vs := &Server{ClientVersionFetcher: fetcher}
+		assert.DeepEqual(t, requested, vs.graffiti(requested))
+	})
+	t.Run("appended", func(t *testing.T) {
+		vs := &Server{ClientVersionFetcher: fetcher, GraffitiClientInfo: true}
+		g := string(vs.graffiti(requested))
+		assert.Equal(t, 32, len(g))
+		assert.Equal(t, true, strings.HasPrefix(g, "hello GE"), g)
+	})
+	t.Run("empty graffiti", func(t *testing.T) {
+		vs := &Server{ClientVersionFetcher: fetcher, GraffitiClientInfo: true}
+		g := string(vs.graffiti(make([]byte, 32)))
+		assert.Equal(t, true, strings.HasPrefix(g, "GE"), g)
+	})
+	t.Run("full graffiti", func(t *testing.T) {
+		vs := &Server{ClientVersionFetcher: fetcher, GraffitiClientInfo: true}
+		full := []byte(strings.Repeat("a", 31))
+		full = append(full, 0)
+		assert.DeepEqual(t, full, vs.graffiti(full))
+	})
+	t.Run("unknown client", func(t *testing.T) {
+		vs := &Server{ClientVersionFetcher: &mockClientVersionFetcher{}, GraffitiClientInfo: true}
+		assert.DeepEqual(t, requested, vs.graffiti(requested))
+	})
+}
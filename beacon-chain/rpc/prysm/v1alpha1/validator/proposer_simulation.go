@@ -0,0 +1,133 @@
+package validator
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	v "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/validators"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// BlockSimulation is a block produced by SimulateBeaconBlock, along with statistics on how it was packed.
+type BlockSimulation struct {
+	Block                  interfaces.ReadOnlyBeaconBlock
+	Blinded                bool
+	PayloadValue           uint64
+	AttestationsConsidered int
+	ProposerReward         uint64
+	BuilderConfigured      bool
+	BuilderBidValue        uint64
+	BuilderErr             error
+	Duration               time.Duration
+}
+
+// SimulateBeaconBlock produces the block the proposer of the slot would be given, without signing or broadcasting
+// it, so that the packing of blocks and the connectivity to the builder can be checked at any time. The randao
+// reveal of the block is the point at infinity, so its state root does not match the state root of a signed block.
+// The block is built without the side effects of block production: the head is not updated, and no block
+// production metrics or logs are recorded.
+//
+// The block is built with the local payload. When a builder is configured, it is asked for a bid separately, which
+// is not otherwise done for validators that have not registered with it, and whose errors would otherwise only be
+// logged.
+func (vs *Server) SimulateBeaconBlock(ctx context.Context, slot primitives.Slot, graffiti []byte) (*BlockSimulation, error) {
+	ctx, span := trace.StartSpan(ctx, "ProposerServer.SimulateBeaconBlock")
+	defer span.End()
+
+	considered := vs.AttPool.AggregatedAttestationCount() + vs.AttPool.UnaggregatedAttestationCount()
+	start := time.Now()
+	resp, err := vs.buildBeaconBlock(ctx, &ethpb.BlockRequest{Slot: slot, RandaoReveal: primitives.PointAtInfinity, Graffiti: graffiti}, true)
+	if err != nil {
+		return nil, err
+	}
+	sim := &BlockSimulation{
+		Blinded:                resp.IsBlinded,
+		PayloadValue:           resp.PayloadValue,
+		AttestationsConsidered: considered,
+		Duration:               time.Since(start),
+	}
+	sim.Block, err = consensusblocks.NewBeaconBlock(resp.Block)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read block")
+	}
+
+	head, err := vs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+	parentRoot := sim.Block.ParentRoot()
+	st, err := transition.ProcessSlotsUsingNextSlotCache(ctx, head, parentRoot[:], slot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not process slots up to %d", slot)
+	}
+	sim.ProposerReward, err = proposerReward(ctx, st, sim.Block)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute proposer reward")
+	}
+
+	if vs.BlockBuilder != nil && vs.BlockBuilder.Configured() && slots.ToEpoch(slot) >= params.BeaconConfig().BellatrixForkEpoch {
+		sim.BuilderConfigured = true
+		bid, _, err := vs.getPayloadHeaderFromBuilder(ctx, slot, sim.Block.ProposerIndex(), nil)
+		if err != nil {
+			sim.BuilderErr = err
+		} else {
+			sim.BuilderBidValue, sim.BuilderErr = bid.ValueInGwei()
+		}
+	}
+	return sim, nil
+}
+
+// proposerReward returns the reward of the proposer for the operations of the block, applied to the state at the
+// slot of the block. Proposers of phase 0 blocks are only rewarded at the end of the epoch.
+func proposerReward(ctx context.Context, st state.BeaconState, blk interfaces.ReadOnlyBeaconBlock) (uint64, error) {
+	if blk.Version() == version.Phase0 {
+		return 0, nil
+	}
+	sBlk, err := consensusblocks.BuildSignedBeaconBlock(blk, make([]byte, fieldparams.BLSSignatureLength))
+	if err != nil {
+		return 0, err
+	}
+	idx := blk.ProposerIndex()
+	before, err := st.BalanceAtIndex(idx)
+	if err != nil {
+		return 0, err
+	}
+	st, err = altair.ProcessAttestationsNoVerifySignature(ctx, st, sBlk)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not process attestations")
+	}
+	st, err = blocks.ProcessAttesterSlashings(ctx, st, blk.Body().AttesterSlashings(), v.SlashValidator)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not process attester slashings")
+	}
+	st, err = blocks.ProcessProposerSlashings(ctx, st, blk.Body().ProposerSlashings(), v.SlashValidator)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not process proposer slashings")
+	}
+	after, err := st.BalanceAtIndex(idx)
+	if err != nil {
+		return 0, err
+	}
+	sa, err := blk.Body().SyncAggregate()
+	if err != nil {
+		return 0, err
+	}
+	_, syncReward, err := altair.ProcessSyncAggregate(ctx, st, sa)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not process sync aggregate")
+	}
+	return after - before + syncReward, nil
+}
@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	dbutil "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestServer_SimulateBeaconBlock(t *testing.T) {
+	hook := logTest.NewGlobal()
+	db := dbutil.SetupDB(t)
+	ctx := context.Background()
+
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+	beaconState, privKeys := util.DeterministicGenesisState(t, 64)
+
+	stateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := util.NewBeaconBlock()
+	genesis.Block.StateRoot = stateRoot[:]
+	util.SaveBlock(t, ctx, db, genesis)
+	parentRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, beaconState, parentRoot))
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, parentRoot))
+
+	altairSlot, err := slots.EpochStart(params.BeaconConfig().AltairForkEpoch)
+	require.NoError(t, err)
+	slot := altairSlot + 1
+
+	// Slash a validator other than the proposer, so that the proposer is only rewarded as the whistleblower.
+	st, err := transition.ProcessSlots(ctx, beaconState.Copy(), slot)
+	require.NoError(t, err)
+	proposer, err := helpers.BeaconProposerIndex(ctx, st)
+	require.NoError(t, err)
+	slashed := (proposer + 1) % primitives.ValidatorIndex(len(privKeys))
+
+	proposerServer := getProposerServer(db, beaconState, parentRoot[:])
+	proposerSlashing, err := util.GenerateProposerSlashingForValidator(beaconState, privKeys[slashed], slashed)
+	require.NoError(t, err)
+	require.NoError(t, proposerServer.SlashingsPool.InsertProposerSlashing(ctx, beaconState, proposerSlashing))
+
+	graffiti := bytesutil.ToBytes32([]byte("simulation"))
+	sim, err := proposerServer.SimulateBeaconBlock(ctx, slot, graffiti[:])
+	require.NoError(t, err)
+
+	assert.Equal(t, slot, sim.Block.Slot())
+	assert.Equal(t, proposer, sim.Block.ProposerIndex())
+	assert.DeepEqual(t, parentRoot, sim.Block.ParentRoot())
+	assert.DeepEqual(t, graffiti, sim.Block.Body().Graffiti())
+	require.Equal(t, 1, len(sim.Block.Body().ProposerSlashings()))
+	slashedVal, err := beaconState.ValidatorAtIndexReadOnly(slashed)
+	require.NoError(t, err)
+	assert.Equal(t, slashedVal.EffectiveBalance()/params.BeaconConfig().WhistleBlowerRewardQuotient, sim.ProposerReward)
+	assert.Equal(t, false, sim.Blinded)
+	assert.Equal(t, false, sim.BuilderConfigured)
+
+	// The simulation does not update the head, nor log the production of the block.
+	chain, ok := proposerServer.ForkchoiceFetcher.(*mock.ChainService)
+	require.Equal(t, true, ok)
+	assert.Equal(t, 0, chain.ForkChoiceStore.NodeCount())
+	require.LogsDoNotContain(t, hook, "Begin building block")
+	require.LogsDoNotContain(t, hook, "Finished building block")
+}
+
+func TestProposerReward_Phase0(t *testing.T) {
+	beaconState, _ := util.DeterministicGenesisState(t, 64)
+	blk, err := consensusblocks.NewBeaconBlock(util.NewBeaconBlock().Block)
+	require.NoError(t, err)
+	reward, err := proposerReward(context.Background(), beaconState, blk)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), reward)
+}
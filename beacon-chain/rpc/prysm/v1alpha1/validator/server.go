@@ -44,6 +44,7 @@ import (
 type Server struct {
 	Ctx                    context.Context
 	ProposerSlotIndexCache *cache.ProposerPayloadIDsCache
+	LocalBroadcasts        *cache.LocalBroadcastsCache
 	HeadFetcher            blockchain.HeadFetcher
 	ForkFetcher            blockchain.ForkFetcher
 	ForkchoiceFetcher      blockchain.ForkchoiceFetcher
@@ -76,6 +77,9 @@ type Server struct {
 	BLSChangesPool         blstoexec.PoolManager
 	ClockWaiter            startup.ClockWaiter
 	CoreService            *core.Service
+	ClientVersionFetcher   execution.ClientVersionFetcher
+	GraffitiClientInfo     bool
+	participationCache     participationCache
 }
 
 // WaitForActivation checks if a validator public key exists in the active validator registry of the current
@@ -0,0 +1,163 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	validatorv1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/validator"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BlockSimulator produces blocks without signing or broadcasting them.
+type BlockSimulator interface {
+	SimulateBeaconBlock(ctx context.Context, slot primitives.Slot, graffiti []byte) (*validatorv1alpha1.BlockSimulation, error)
+}
+
+type BlockSimulationResponse struct {
+	Data *BlockSimulation `json:"data"`
+}
+
+type BlockSimulation struct {
+	Version                    string             `json:"version"`
+	Slot                       string             `json:"slot"`
+	ProposerIndex              string             `json:"proposer_index"`
+	ParentRoot                 string             `json:"parent_root"`
+	ExecutionPayloadBlinded    bool               `json:"execution_payload_blinded"`
+	ExecutionPayloadValue      string             `json:"execution_payload_value"`
+	ProposerReward             string             `json:"proposer_reward"`
+	AttestationsConsidered     string             `json:"attestations_considered"`
+	AttestationsIncluded       string             `json:"attestations_included"`
+	Deposits                   string             `json:"deposits"`
+	ProposerSlashings          string             `json:"proposer_slashings"`
+	AttesterSlashings          string             `json:"attester_slashings"`
+	VoluntaryExits             string             `json:"voluntary_exits"`
+	BlsToExecutionChanges      string             `json:"bls_to_execution_changes"`
+	SyncAggregateParticipation string             `json:"sync_aggregate_participation"`
+	BlobKzgCommitments         string             `json:"blob_kzg_commitments"`
+	Builder                    *BuilderSimulation `json:"builder,omitempty"`
+	DurationMs                 string             `json:"duration_ms"`
+}
+
+type BuilderSimulation struct {
+	BidValue string `json:"bid_value"`
+	Error    string `json:"error,omitempty"`
+}
+
+// SimulateBlock is a HTTP handler that serves the GET /prysm/v1/validator/blocks/{slot}/simulate endpoint. It
+// produces the block the proposer of the slot would be given, without signing or broadcasting it, and reports how
+// it was packed: the attestations considered and included, the reward of the proposer and the value of the
+// execution payload, in Gwei. When a builder is configured, it also reports the bid of the builder for the slot, or
+// the error it returned.
+// The endpoint is only served with --enable-debug-rpc-endpoints.
+//
+// Example usage:
+//
+//	GET /prysm/v1/validator/blocks/7000000/simulate?graffiti=0x...
+func (s *Server) SimulateBlock(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.SimulateBlock")
+	defer span.End()
+
+	rawSlot := mux.Vars(r)["slot"]
+	rawSlotUint, valid := shared.ValidateUint(w, "slot", rawSlot)
+	if !valid {
+		return
+	}
+	slot := primitives.Slot(rawSlotUint)
+	if headSlot := s.HeadFetcher.HeadSlot(); slot <= headSlot {
+		http2.HandleError(w, fmt.Sprintf("Slot %d is not after the head slot %d", slot, headSlot), http.StatusBadRequest)
+		return
+	}
+	if maxSlot := s.GenesisTimeFetcher.CurrentSlot() + params.BeaconConfig().SlotsPerEpoch; slot > maxSlot {
+		http2.HandleError(w, fmt.Sprintf("Slot %d is more than an epoch after the current slot", slot), http.StatusBadRequest)
+		return
+	}
+	var graffiti []byte
+	if rawGraffiti := r.URL.Query().Get("graffiti"); rawGraffiti != "" {
+		g, err := shared.DecodeHexWithLength(rawGraffiti, 32)
+		if err != nil {
+			http2.HandleError(w, errors.Wrap(err, "Unable to decode graffiti").Error(), http.StatusBadRequest)
+			return
+		}
+		graffiti = g
+	}
+
+	sim, err := s.BlockSimulator.SimulateBeaconBlock(ctx, slot, graffiti)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if status.Code(err) == codes.Unavailable {
+			code = http.StatusServiceUnavailable
+		}
+		http2.HandleError(w, "Could not simulate block: "+err.Error(), code)
+		return
+	}
+	data, err := blockSimulationJson(sim)
+	if err != nil {
+		http2.HandleError(w, "Could not read simulated block: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http2.WriteJson(w, &BlockSimulationResponse{Data: data})
+}
+
+func blockSimulationJson(sim *validatorv1alpha1.BlockSimulation) (*BlockSimulation, error) {
+	blk := sim.Block
+	body := blk.Body()
+	parentRoot := blk.ParentRoot()
+	data := &BlockSimulation{
+		Version:                 version.String(blk.Version()),
+		Slot:                    strconv.FormatUint(uint64(blk.Slot()), 10),
+		ProposerIndex:           strconv.FormatUint(uint64(blk.ProposerIndex()), 10),
+		ParentRoot:              hexutil.Encode(parentRoot[:]),
+		ExecutionPayloadBlinded: sim.Blinded,
+		ExecutionPayloadValue:   strconv.FormatUint(sim.PayloadValue, 10),
+		ProposerReward:          strconv.FormatUint(sim.ProposerReward, 10),
+		AttestationsConsidered:  strconv.Itoa(sim.AttestationsConsidered),
+		AttestationsIncluded:    strconv.Itoa(len(body.Attestations())),
+		Deposits:                strconv.Itoa(len(body.Deposits())),
+		ProposerSlashings:       strconv.Itoa(len(body.ProposerSlashings())),
+		AttesterSlashings:       strconv.Itoa(len(body.AttesterSlashings())),
+		VoluntaryExits:          strconv.Itoa(len(body.VoluntaryExits())),
+		BlsToExecutionChanges:   "0",
+		BlobKzgCommitments:      "0",
+		DurationMs:              strconv.FormatInt(sim.Duration.Milliseconds(), 10),
+	}
+	if blk.Version() >= version.Altair {
+		sa, err := body.SyncAggregate()
+		if err != nil {
+			return nil, err
+		}
+		data.SyncAggregateParticipation = strconv.FormatUint(sa.SyncCommitteeBits.Count(), 10)
+	}
+	if blk.Version() >= version.Capella {
+		changes, err := body.BLSToExecutionChanges()
+		if err != nil {
+			return nil, err
+		}
+		data.BlsToExecutionChanges = strconv.Itoa(len(changes))
+	}
+	if blk.Version() >= version.Deneb {
+		commitments, err := body.BlobKzgCommitments()
+		if err != nil {
+			return nil, err
+		}
+		data.BlobKzgCommitments = strconv.Itoa(len(commitments))
+	}
+	if sim.BuilderConfigured {
+		data.Builder = &BuilderSimulation{BidValue: strconv.FormatUint(sim.BuilderBidValue, 10)}
+		if sim.BuilderErr != nil {
+			data.Builder.Error = sim.BuilderErr.Error()
+		}
+	}
+	return data, nil
+}
@@ -0,0 +1,111 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	chainMock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	validatorv1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/validator"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockBlockSimulator struct {
+	sim      *validatorv1alpha1.BlockSimulation
+	err      error
+	slot     primitives.Slot
+	graffiti []byte
+}
+
+func (m *mockBlockSimulator) SimulateBeaconBlock(_ context.Context, slot primitives.Slot, graffiti []byte) (*validatorv1alpha1.BlockSimulation, error) {
+	m.slot, m.graffiti = slot, graffiti
+	return m.sim, m.err
+}
+
+func TestSimulateBlock(t *testing.T) {
+	b := util.NewBeaconBlockCapella()
+	b.Block.Slot = 33
+	b.Block.ProposerIndex = 7
+	b.Block.Body.Attestations = []*eth.Attestation{util.HydrateAttestation(&eth.Attestation{}), util.HydrateAttestation(&eth.Attestation{})}
+	b.Block.Body.SyncAggregate.SyncCommitteeBits.SetBitAt(3, true)
+	blk, err := blocks.NewBeaconBlock(b.Block)
+	require.NoError(t, err)
+
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(32))
+	currentSlot := primitives.Slot(33)
+	chain := &chainMock.ChainService{State: st, Slot: &currentSlot}
+
+	t.Run("ok", func(t *testing.T) {
+		simulator := &mockBlockSimulator{sim: &validatorv1alpha1.BlockSimulation{
+			Block:                  blk,
+			PayloadValue:           100,
+			AttestationsConsidered: 5,
+			ProposerReward:         2000,
+			BuilderConfigured:      true,
+			BuilderErr:             errors.New("no bid"),
+			Duration:               time.Second,
+		}}
+		s := &Server{HeadFetcher: chain, GenesisTimeFetcher: chain, BlockSimulator: simulator}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validator/blocks/33/simulate?graffiti=0x0102030400000000000000000000000000000000000000000000000000000000", nil)
+		request = mux.SetURLVars(request, map[string]string{"slot": "33"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SimulateBlock(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, primitives.Slot(33), simulator.slot)
+		assert.Equal(t, byte(4), simulator.graffiti[3])
+		resp := &BlockSimulationResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "capella", resp.Data.Version)
+		assert.Equal(t, "7", resp.Data.ProposerIndex)
+		assert.Equal(t, "100", resp.Data.ExecutionPayloadValue)
+		assert.Equal(t, "2000", resp.Data.ProposerReward)
+		assert.Equal(t, "5", resp.Data.AttestationsConsidered)
+		assert.Equal(t, "2", resp.Data.AttestationsIncluded)
+		assert.Equal(t, "1", resp.Data.SyncAggregateParticipation)
+		assert.Equal(t, "1000", resp.Data.DurationMs)
+		require.NotNil(t, resp.Data.Builder)
+		assert.Equal(t, "no bid", resp.Data.Builder.Error)
+	})
+	t.Run("slot not after head", func(t *testing.T) {
+		s := &Server{HeadFetcher: chain, GenesisTimeFetcher: chain, BlockSimulator: &mockBlockSimulator{}}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validator/blocks/32/simulate", nil)
+		request = mux.SetURLVars(request, map[string]string{"slot": "32"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SimulateBlock(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &http2.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "is not after the head slot", e.Message)
+	})
+	t.Run("syncing", func(t *testing.T) {
+		simulator := &mockBlockSimulator{err: status.Error(codes.Unavailable, "Syncing to latest head, not ready to respond")}
+		s := &Server{HeadFetcher: chain, GenesisTimeFetcher: chain, BlockSimulator: simulator}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validator/blocks/33/simulate", nil)
+		request = mux.SetURLVars(request, map[string]string{"slot": "33"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SimulateBlock(writer, request)
+		require.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	})
+}
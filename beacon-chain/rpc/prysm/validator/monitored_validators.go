@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+)
+
+type MonitoredValidatorsRequest struct {
+	Indices []primitives.ValidatorIndex `json:"indices"`
+}
+
+type MonitoredValidatorsResponse struct {
+	Indices []primitives.ValidatorIndex `json:"indices"`
+}
+
+// GetMonitoredValidators returns the validator indices tracked by the beacon node's validator monitor.
+func (vs *Server) GetMonitoredValidators(w http.ResponseWriter, _ *http.Request) {
+	if vs.ValidatorMonitor == nil {
+		handleHTTPError(w, "Validator monitor is not available", http.StatusServiceUnavailable)
+		return
+	}
+	http2.WriteJson(w, &MonitoredValidatorsResponse{Indices: vs.ValidatorMonitor.TrackedValidatorIndices()})
+}
+
+// AddMonitoredValidators starts tracking the requested validator indices in the beacon node's
+// validator monitor. The indices are persisted, so they remain tracked after a restart.
+func (vs *Server) AddMonitoredValidators(w http.ResponseWriter, r *http.Request) {
+	req, ok := vs.decodeMonitoredValidatorsRequest(w, r)
+	if !ok {
+		return
+	}
+	if err := vs.ValidatorMonitor.AddTrackedValidators(r.Context(), req.Indices); err != nil {
+		handleHTTPError(w, "Could not add monitored validators: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// RemoveMonitoredValidators stops tracking the requested validator indices in the beacon node's
+// validator monitor. Indices which are not tracked are ignored. Indices given with the
+// --monitor-indices flag are tracked again after a restart.
+func (vs *Server) RemoveMonitoredValidators(w http.ResponseWriter, r *http.Request) {
+	req, ok := vs.decodeMonitoredValidatorsRequest(w, r)
+	if !ok {
+		return
+	}
+	if err := vs.ValidatorMonitor.RemoveTrackedValidators(r.Context(), req.Indices); err != nil {
+		handleHTTPError(w, "Could not remove monitored validators: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (vs *Server) decodeMonitoredValidatorsRequest(w http.ResponseWriter, r *http.Request) (*MonitoredValidatorsRequest, bool) {
+	if vs.ValidatorMonitor == nil {
+		handleHTTPError(w, "Validator monitor is not available", http.StatusServiceUnavailable)
+		return nil, false
+	}
+	if r.Body == http.NoBody {
+		handleHTTPError(w, "No data submitted", http.StatusBadRequest)
+		return nil, false
+	}
+	var req MonitoredValidatorsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleHTTPError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	if len(req.Indices) == 0 {
+		handleHTTPError(w, "No validator indices provided", http.StatusBadRequest)
+		return nil, false
+	}
+	return &req, true
+}
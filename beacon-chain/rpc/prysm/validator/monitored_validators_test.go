@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+type mockValidatorMonitor struct {
+	tracked map[primitives.ValidatorIndex]bool
+}
+
+func (m *mockValidatorMonitor) TrackedValidatorIndices() []primitives.ValidatorIndex {
+	indices := make([]primitives.ValidatorIndex, 0, len(m.tracked))
+	for idx := range m.tracked {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
+
+func (m *mockValidatorMonitor) AddTrackedValidators(_ context.Context, indices []primitives.ValidatorIndex) error {
+	for _, idx := range indices {
+		m.tracked[idx] = true
+	}
+	return nil
+}
+
+func (m *mockValidatorMonitor) RemoveTrackedValidators(_ context.Context, indices []primitives.ValidatorIndex) error {
+	for _, idx := range indices {
+		delete(m.tracked, idx)
+	}
+	return nil
+}
+
+func TestServer_MonitoredValidators(t *testing.T) {
+	m := &mockValidatorMonitor{tracked: map[primitives.ValidatorIndex]bool{5: true}}
+	vs := &Server{ValidatorMonitor: m}
+
+	body, err := json.Marshal(&MonitoredValidatorsRequest{Indices: []primitives.ValidatorIndex{1, 9}})
+	require.NoError(t, err)
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/validators/monitored", bytes.NewReader(body))
+	writer := httptest.NewRecorder()
+	vs.AddMonitoredValidators(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	body, err = json.Marshal(&MonitoredValidatorsRequest{Indices: []primitives.ValidatorIndex{5}})
+	require.NoError(t, err)
+	request = httptest.NewRequest(http.MethodDelete, "http://example.com/prysm/validators/monitored", bytes.NewReader(body))
+	writer = httptest.NewRecorder()
+	vs.RemoveMonitoredValidators(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+
+	request = httptest.NewRequest(http.MethodGet, "http://example.com/prysm/validators/monitored", nil)
+	writer = httptest.NewRecorder()
+	vs.GetMonitoredValidators(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp := &MonitoredValidatorsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.DeepEqual(t, []primitives.ValidatorIndex{1, 9}, resp.Indices)
+}
+
+func TestServer_AddMonitoredValidators_Errors(t *testing.T) {
+	t.Run("no indices", func(t *testing.T) {
+		vs := &Server{ValidatorMonitor: &mockValidatorMonitor{tracked: map[primitives.ValidatorIndex]bool{}}}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/validators/monitored", bytes.NewReader([]byte(`{"indices":[]}`)))
+		writer := httptest.NewRecorder()
+		vs.AddMonitoredValidators(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &http2.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "No validator indices provided", e.Message)
+	})
+	t.Run("monitor unavailable", func(t *testing.T) {
+		vs := &Server{}
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/validators/monitored", bytes.NewReader([]byte(`{"indices":[1]}`)))
+		writer := httptest.NewRecorder()
+		vs.AddMonitoredValidators(writer, request)
+		assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	})
+}
@@ -3,6 +3,7 @@ package validator
 import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/core"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
@@ -20,4 +21,6 @@ type Server struct {
 	ChainInfoFetcher      blockchain.ChainInfoFetcher
 	BeaconDB              db.ReadOnlyDatabase
 	FinalizationFetcher   blockchain.FinalizationFetcher
+	ValidatorMonitor      monitor.TrackedValidatorsManager
+	BlockSimulator        BlockSimulator
 }
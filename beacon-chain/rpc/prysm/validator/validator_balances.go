@@ -0,0 +1,172 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/pagination"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v4/cmd"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"go.opencensus.io/trace"
+)
+
+type ValidatorBalancesRequest struct {
+	Indices []string `json:"indices"`
+}
+
+type ValidatorBalancesResponse struct {
+	ExecutionOptimistic bool                `json:"execution_optimistic"`
+	Finalized           bool                `json:"finalized"`
+	Data                []*ValidatorBalance `json:"data"`
+	NextPageToken       string              `json:"next_page_token"`
+	TotalSize           string              `json:"total_size"`
+}
+
+type ValidatorBalance struct {
+	Index            string `json:"index"`
+	Balance          string `json:"balance"`
+	EffectiveBalance string `json:"effective_balance"`
+}
+
+// GetValidatorBalances is a HTTP handler that serves the POST /prysm/states/{state_id}/validator_balances endpoint.
+// It returns the balance and effective balance of the requested validator indices at the given state. Only the
+// requested validators are read from the state, which keeps the endpoint cheap for callers tracking large sets
+// of validators. When no indices are provided, all validators in the state are returned.
+//
+// The response is paginated with the page_size and page_token query parameters. Indices unknown to the state
+// are skipped.
+//
+// Example usage:
+//
+//	POST /prysm/states/head/validator_balances?page_size=2
+//	{"indices": ["1", "2", "3"]}
+//
+// The above request will return a JSON response like:
+//
+//	{
+//		"execution_optimistic": false,
+//		"finalized": false,
+//		"data": [
+//			{"index": "1", "balance": "32000000000", "effective_balance": "32000000000"},
+//			{"index": "2", "balance": "31999990000", "effective_balance": "32000000000"}
+//		],
+//		"next_page_token": "1",
+//		"total_size": "3"
+//	}
+func (vs *Server) GetValidatorBalances(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetValidatorBalances")
+	defer span.End()
+
+	stateID := mux.Vars(r)["state_id"]
+	if stateID == "" {
+		handleHTTPError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+
+	// An empty body requests the balances of all validators.
+	var req ValidatorBalancesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		handleHTTPError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	pageSize := 0
+	if rawPageSize := r.URL.Query().Get("page_size"); rawPageSize != "" {
+		size, err := strconv.Atoi(rawPageSize)
+		if err != nil || size < 0 {
+			handleHTTPError(w, fmt.Sprintf("Invalid page size %s", rawPageSize), http.StatusBadRequest)
+			return
+		}
+		pageSize = size
+	}
+	if pageSize > cmd.Get().MaxRPCPageSize {
+		handleHTTPError(
+			w,
+			fmt.Sprintf("Requested page size %d can not be greater than max size %d", pageSize, cmd.Get().MaxRPCPageSize),
+			http.StatusBadRequest,
+		)
+		return
+	}
+
+	st, err := vs.Stater.State(ctx, []byte(stateID))
+	if err != nil {
+		shared.WriteStateFetchError(w, err)
+		return
+	}
+	isOptimistic, err := helpers.IsOptimistic(ctx, []byte(stateID), vs.OptimisticModeFetcher, vs.Stater, vs.ChainInfoFetcher, vs.BeaconDB)
+	if err != nil {
+		handleHTTPError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		handleHTTPError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	isFinalized := vs.FinalizationFetcher.IsFinalized(ctx, blockRoot)
+
+	numVals := uint64(st.NumValidators())
+	var indices []primitives.ValidatorIndex
+	if len(req.Indices) == 0 {
+		indices = make([]primitives.ValidatorIndex, numVals)
+		for i := range indices {
+			indices[i] = primitives.ValidatorIndex(i)
+		}
+	} else {
+		indices = make([]primitives.ValidatorIndex, 0, len(req.Indices))
+		for _, rawIndex := range req.Indices {
+			index, err := strconv.ParseUint(rawIndex, 10, 64)
+			if err != nil {
+				handleHTTPError(w, fmt.Sprintf("Invalid validator index %s", rawIndex), http.StatusBadRequest)
+				return
+			}
+			if index >= numVals {
+				continue
+			}
+			indices = append(indices, primitives.ValidatorIndex(index))
+		}
+	}
+
+	resp := &ValidatorBalancesResponse{
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data:                []*ValidatorBalance{},
+		TotalSize:           strconv.Itoa(len(indices)),
+	}
+	if len(indices) == 0 {
+		http2.WriteJson(w, resp)
+		return
+	}
+	start, end, nextPageToken, err := pagination.StartAndEndPage(r.URL.Query().Get("page_token"), pageSize, len(indices))
+	if err != nil {
+		handleHTTPError(w, "Could not paginate results: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp.NextPageToken = nextPageToken
+	resp.Data = make([]*ValidatorBalance, 0, end-start)
+	for _, index := range indices[start:end] {
+		balance, err := st.BalanceAtIndex(index)
+		if err != nil {
+			handleHTTPError(w, fmt.Sprintf("Could not get balance of validator %d: %v", index, err), http.StatusInternalServerError)
+			return
+		}
+		val, err := st.ValidatorAtIndexReadOnly(index)
+		if err != nil {
+			handleHTTPError(w, fmt.Sprintf("Could not get validator %d: %v", index, err), http.StatusInternalServerError)
+			return
+		}
+		resp.Data = append(resp.Data, &ValidatorBalance{
+			Index:            strconv.FormatUint(uint64(index), 10),
+			Balance:          strconv.FormatUint(balance, 10),
+			EffectiveBalance: strconv.FormatUint(val.EffectiveBalance(), 10),
+		})
+	}
+	http2.WriteJson(w, resp)
+}
@@ -0,0 +1,88 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	chainMock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestGetValidatorBalances(t *testing.T) {
+	st, _ := util.DeterministicGenesisState(t, 8)
+	require.NoError(t, st.UpdateBalancesAtIndex(3, 31000000000))
+	chainService := &chainMock.ChainService{Optimistic: false, FinalizedRoots: make(map[[32]byte]bool)}
+	server := &Server{
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+		Stater:                &testutil.MockStater{BeaconState: st},
+	}
+
+	call := func(t *testing.T, query string, body string) (*httptest.ResponseRecorder, *ValidatorBalancesResponse) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/states/head/validator_balances"+query, bytes.NewReader([]byte(body)))
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		server.GetValidatorBalances(writer, request)
+		resp := &ValidatorBalancesResponse{}
+		if writer.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		}
+		return writer, resp
+	}
+
+	t.Run("requested indices", func(t *testing.T) {
+		writer, resp := call(t, "", `{"indices":["3","1","100"]}`)
+		require.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, 2, len(resp.Data))
+		assert.DeepEqual(t, &ValidatorBalance{Index: "3", Balance: "31000000000", EffectiveBalance: "32000000000"}, resp.Data[0])
+		assert.DeepEqual(t, &ValidatorBalance{Index: "1", Balance: "32000000000", EffectiveBalance: "32000000000"}, resp.Data[1])
+		assert.Equal(t, "2", resp.TotalSize)
+		assert.Equal(t, "", resp.NextPageToken)
+	})
+	t.Run("paginated", func(t *testing.T) {
+		writer, resp := call(t, "?page_size=3", ``)
+		require.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, 3, len(resp.Data))
+		assert.Equal(t, "0", resp.Data[0].Index)
+		assert.Equal(t, "8", resp.TotalSize)
+		assert.Equal(t, "1", resp.NextPageToken)
+
+		writer, resp = call(t, "?page_size=3&page_token=2", ``)
+		require.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "6", resp.Data[0].Index)
+		assert.Equal(t, "", resp.NextPageToken)
+	})
+	t.Run("unknown indices only", func(t *testing.T) {
+		writer, resp := call(t, "", `{"indices":["100"]}`)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, 0, len(resp.Data))
+		assert.Equal(t, "0", resp.TotalSize)
+	})
+	t.Run("invalid index", func(t *testing.T) {
+		writer, _ := call(t, "", `{"indices":["foo"]}`)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &http2.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Invalid validator index foo", e.Message)
+	})
+	t.Run("page size too large", func(t *testing.T) {
+		writer, _ := call(t, "?page_size=100000", ``)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &http2.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "can not be greater than max size", e.Message)
+	})
+	t.Run("page token out of range", func(t *testing.T) {
+		writer, _ := call(t, "?page_size=3&page_token=5", ``)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
@@ -0,0 +1,243 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v4/api"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	rpchelpers "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"go.opencensus.io/trace"
+)
+
+const (
+	blsWithdrawalCredentialsType       = "bls"
+	executionWithdrawalCredentialsType = "execution"
+	unknownWithdrawalCredentialsType   = "unknown"
+)
+
+// exitQueue is the exit queue of a state: the validators whose exit was initiated but who did not exit yet.
+type exitQueue struct {
+	epoch primitives.Epoch
+	// pending are the exit epochs of the validators in the queue, by validator index.
+	pending map[primitives.ValidatorIndex]primitives.Epoch
+	// queueEpoch is the exit epoch of a validator initiating its exit in the current epoch.
+	queueEpoch primitives.Epoch
+}
+
+func newExitQueue(st state.ReadOnlyBeaconState, activeCount uint64) (*exitQueue, error) {
+	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
+	q := &exitQueue{
+		epoch:   slots.ToEpoch(st.Slot()),
+		pending: make(map[primitives.ValidatorIndex]primitives.Epoch),
+	}
+	q.queueEpoch = helpers.ActivationExitEpoch(q.epoch)
+	var maxExitEpochChurn uint64
+	if err := st.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+		e := val.ExitEpoch()
+		if e == farFutureEpoch {
+			return nil
+		}
+		if e > q.epoch {
+			q.pending[primitives.ValidatorIndex(idx)] = e
+		}
+		if e > q.queueEpoch {
+			q.queueEpoch = e
+			maxExitEpochChurn = 1
+		} else if e == q.queueEpoch {
+			maxExitEpochChurn++
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	churn, err := helpers.ValidatorChurnLimit(activeCount)
+	if err != nil {
+		return nil, err
+	}
+	if maxExitEpochChurn >= churn {
+		q.queueEpoch++
+	}
+	return q, nil
+}
+
+// position returns the number of validators ahead of the given validator in the exit queue. Validators exiting in
+// the same epoch are ordered by index.
+func (q *exitQueue) position(index primitives.ValidatorIndex, exitEpoch primitives.Epoch) int {
+	position := 0
+	for i, e := range q.pending {
+		if e < exitEpoch || (e == exitEpoch && i < index) {
+			position++
+		}
+	}
+	return position
+}
+
+// estimatedExitEpoch returns the exit epoch of an active validator submitting a voluntary exit now, or as soon as
+// it has been active long enough to exit.
+func (q *exitQueue) estimatedExitEpoch(val state.ReadOnlyValidator) primitives.Epoch {
+	exitEpoch := q.queueEpoch
+	earliest := helpers.ActivationExitEpoch(val.ActivationEpoch() + params.BeaconConfig().ShardCommitteePeriod)
+	if earliest > exitEpoch {
+		exitEpoch = earliest
+	}
+	return exitEpoch
+}
+
+func withdrawalCredentialsType(credentials []byte) string {
+	if len(credentials) == 0 {
+		return unknownWithdrawalCredentialsType
+	}
+	switch credentials[0] {
+	case params.BeaconConfig().BLSWithdrawalPrefixByte:
+		return blsWithdrawalCredentialsType
+	case params.BeaconConfig().ETH1AddressWithdrawalPrefixByte:
+		return executionWithdrawalCredentialsType
+	default:
+		return unknownWithdrawalCredentialsType
+	}
+}
+
+// GetValidatorExitStatus is a HTTP handler that serves the POST /prysm/states/{state_id}/validator_exit_status
+// endpoint. It returns, for each requested validator, its activation epochs, its position in the exit queue, its
+// exit and withdrawable epochs and the type of its withdrawal credentials. For the active validators which did not
+// initiate their exit, the exit and withdrawable epochs are estimated for a voluntary exit submitted now, and the
+// exit queue position is the number of validators currently in the exit queue.
+//
+// Validators are requested by index or by hex encoded public key. Validators unknown to the state are skipped.
+//
+// Example usage:
+//
+//	POST /prysm/states/head/validator_exit_status
+//	{"ids": ["1", "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"]}
+func (vs *Server) GetValidatorExitStatus(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetValidatorExitStatus")
+	defer span.End()
+
+	stateID := mux.Vars(r)["state_id"]
+	if stateID == "" {
+		handleHTTPError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	var req api.ValidatorExitStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleHTTPError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.Ids) == 0 {
+		handleHTTPError(w, "No validator ids provided", http.StatusBadRequest)
+		return
+	}
+
+	st, err := vs.Stater.State(ctx, []byte(stateID))
+	if err != nil {
+		shared.WriteStateFetchError(w, err)
+		return
+	}
+	isOptimistic, err := rpchelpers.IsOptimistic(ctx, []byte(stateID), vs.OptimisticModeFetcher, vs.Stater, vs.ChainInfoFetcher, vs.BeaconDB)
+	if err != nil {
+		handleHTTPError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		handleHTTPError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	isFinalized := vs.FinalizationFetcher.IsFinalized(ctx, blockRoot)
+
+	indices := make([]primitives.ValidatorIndex, 0, len(req.Ids))
+	numVals := uint64(st.NumValidators())
+	for _, id := range req.Ids {
+		if strings.HasPrefix(id, "0x") {
+			pubkey, err := hexutil.Decode(id)
+			if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+				handleHTTPError(w, fmt.Sprintf("Invalid validator public key %s", id), http.StatusBadRequest)
+				return
+			}
+			if index, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey)); ok {
+				indices = append(indices, index)
+			}
+			continue
+		}
+		index, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			handleHTTPError(w, fmt.Sprintf("Invalid validator index %s", id), http.StatusBadRequest)
+			return
+		}
+		if index < numVals {
+			indices = append(indices, primitives.ValidatorIndex(index))
+		}
+	}
+
+	epoch := slots.ToEpoch(st.Slot())
+	activeCount, err := helpers.ActiveValidatorCount(ctx, st, epoch)
+	if err != nil {
+		handleHTTPError(w, "Could not get active validator count: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	queue, err := newExitQueue(st, activeCount)
+	if err != nil {
+		handleHTTPError(w, "Could not compute exit queue: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
+	data := make([]*api.ValidatorExitStatus, 0, len(indices))
+	for _, index := range indices {
+		val, err := st.ValidatorAtIndexReadOnly(index)
+		if err != nil {
+			handleHTTPError(w, fmt.Sprintf("Could not get validator %d: %v", index, err), http.StatusInternalServerError)
+			return
+		}
+		status, err := rpchelpers.ValidatorSubStatus(val, epoch)
+		if err != nil {
+			handleHTTPError(w, fmt.Sprintf("Could not get status of validator %d: %v", index, err), http.StatusInternalServerError)
+			return
+		}
+		pubkey := val.PublicKey()
+		exitInitiated := val.ExitEpoch() != farFutureEpoch
+		exitEpoch, withdrawableEpoch := val.ExitEpoch(), val.WithdrawableEpoch()
+		position := 0
+		switch {
+		case exitInitiated:
+			if exitEpoch > epoch {
+				position = queue.position(index, exitEpoch)
+			}
+		case val.ActivationEpoch() <= epoch:
+			exitEpoch = queue.estimatedExitEpoch(val)
+			withdrawableEpoch = exitEpoch + params.BeaconConfig().MinValidatorWithdrawabilityDelay
+			position = len(queue.pending)
+		}
+		data = append(data, &api.ValidatorExitStatus{
+			Index:                      strconv.FormatUint(uint64(index), 10),
+			Pubkey:                     hexutil.Encode(pubkey[:]),
+			Status:                     status.String(),
+			ActivationEligibilityEpoch: strconv.FormatUint(uint64(val.ActivationEligibilityEpoch()), 10),
+			ActivationEpoch:            strconv.FormatUint(uint64(val.ActivationEpoch()), 10),
+			ExitInitiated:              exitInitiated,
+			ExitQueuePosition:          strconv.Itoa(position),
+			ExitEpoch:                  strconv.FormatUint(uint64(exitEpoch), 10),
+			WithdrawableEpoch:          strconv.FormatUint(uint64(withdrawableEpoch), 10),
+			WithdrawalCredentialsType:  withdrawalCredentialsType(val.WithdrawalCredentials()),
+		})
+	}
+	http2.WriteJson(w, &api.ValidatorExitStatusResponse{
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data:                data,
+	})
+}
@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v4/api"
+	chainMock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+func TestGetValidatorExitStatus(t *testing.T) {
+	st, _ := util.DeterministicGenesisState(t, 64)
+	// The validators have been active for long enough to exit, and the exit queue epoch is 305.
+	epoch := params.BeaconConfig().ShardCommitteePeriod + 44
+	slot, err := slots.EpochStart(epoch)
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(slot))
+	for index, exitEpoch := range map[primitives.ValidatorIndex]primitives.Epoch{2: 306, 5: 305, 7: 100} {
+		val, err := st.ValidatorAtIndex(index)
+		require.NoError(t, err)
+		val.ExitEpoch = exitEpoch
+		val.WithdrawableEpoch = exitEpoch + params.BeaconConfig().MinValidatorWithdrawabilityDelay
+		require.NoError(t, st.UpdateValidatorAtIndex(index, val))
+	}
+	val, err := st.ValidatorAtIndex(3)
+	require.NoError(t, err)
+	val.WithdrawalCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+	require.NoError(t, st.UpdateValidatorAtIndex(3, val))
+
+	chainService := &chainMock.ChainService{Optimistic: false, FinalizedRoots: make(map[[32]byte]bool)}
+	server := &Server{
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+		Stater:                &testutil.MockStater{BeaconState: st},
+	}
+	call := func(t *testing.T, body string) (*httptest.ResponseRecorder, *api.ValidatorExitStatusResponse) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/states/head/validator_exit_status", bytes.NewReader([]byte(body)))
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		server.GetValidatorExitStatus(writer, request)
+		resp := &api.ValidatorExitStatusResponse{}
+		if writer.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		}
+		return writer, resp
+	}
+
+	t.Run("exit queue", func(t *testing.T) {
+		pubkey := st.PubkeyAtIndex(0)
+		writer, resp := call(t, `{"ids":["`+hexutil.Encode(pubkey[:])+`","2","5","7","3","1000"]}`)
+		require.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, 5, len(resp.Data))
+
+		// An active validator exiting now is queued after the validators already in the exit queue.
+		assert.DeepEqual(t, &api.ValidatorExitStatus{
+			Index:                      "0",
+			Pubkey:                     hexutil.Encode(pubkey[:]),
+			Status:                     "active_ongoing",
+			ActivationEligibilityEpoch: "0",
+			ActivationEpoch:            "0",
+			ExitInitiated:              false,
+			ExitQueuePosition:          "2",
+			ExitEpoch:                  "306",
+			WithdrawableEpoch:          "562",
+			WithdrawalCredentialsType:  "bls",
+		}, resp.Data[0])
+		assert.Equal(t, true, resp.Data[1].ExitInitiated)
+		assert.Equal(t, "active_exiting", resp.Data[1].Status)
+		assert.Equal(t, "1", resp.Data[1].ExitQueuePosition)
+		assert.Equal(t, "306", resp.Data[1].ExitEpoch)
+		assert.Equal(t, "0", resp.Data[2].ExitQueuePosition)
+		assert.Equal(t, "305", resp.Data[2].ExitEpoch)
+		// An exited validator is not in the exit queue anymore.
+		assert.Equal(t, "exited_unslashed", resp.Data[3].Status)
+		assert.Equal(t, "0", resp.Data[3].ExitQueuePosition)
+		assert.Equal(t, "100", resp.Data[3].ExitEpoch)
+		assert.Equal(t, "execution", resp.Data[4].WithdrawalCredentialsType)
+	})
+	t.Run("no ids", func(t *testing.T) {
+		writer, _ := call(t, `{"ids":[]}`)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("invalid id", func(t *testing.T) {
+		writer, _ := call(t, `{"ids":["0x01"]}`)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &http2.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Invalid validator public key 0x01", e.Message)
+	})
+}
@@ -24,6 +24,7 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/blstoexec"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/slashings"
@@ -36,11 +37,15 @@ import (
 	rpcBuilder "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/builder"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/debug"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/events"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/light-client"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/node"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/rewards"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/validator"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
+	adminprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/admin"
+	beaconprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/beacon"
 	nodeprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/node"
+	slasherprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/slasher"
 	beaconv1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/beacon"
 	debugv1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/debug"
 	nodev1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/node"
@@ -50,8 +55,10 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
 	chainSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/progress"
 	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/encoding/era"
 	"github.com/prysmaticlabs/prysm/v4/io/logs"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
 	ethpbservice "github.com/prysmaticlabs/prysm/v4/proto/eth/service"
@@ -89,6 +96,7 @@ type Config struct {
 	BeaconMonitoringHost          string
 	BeaconMonitoringPort          int
 	BeaconDB                      db.HeadAccessDatabase
+	EraStore                      *era.Store
 	ChainInfoFetcher              blockchain.ChainInfoFetcher
 	HeadFetcher                   blockchain.HeadFetcher
 	CanonicalFetcher              blockchain.CanonicalFetcher
@@ -100,6 +108,11 @@ type Config struct {
 	ExecutionChainService         execution.Chain
 	ChainStartFetcher             execution.ChainStartFetcher
 	ExecutionChainInfoFetcher     execution.ChainInfoFetcher
+	EngineDiagnosticsFetcher      execution.EngineDiagnosticsFetcher
+	EngineCapabilitiesFetcher     execution.EngineCapabilitiesFetcher
+	ClientVersionFetcher          execution.ClientVersionFetcher
+	GraffitiClientInfo            bool
+	PendingQueueFetcher           chainSync.PendingQueueFetcher
 	GenesisTimeFetcher            blockchain.TimeFetcher
 	GenesisFetcher                blockchain.GenesisFetcher
 	EnableDebugRPCEndpoints       bool
@@ -108,12 +121,14 @@ type Config struct {
 	ExitPool                      voluntaryexits.PoolManager
 	SlashingsPool                 slashings.PoolManager
 	SlashingChecker               slasherservice.SlashingChecker
+	SlashingsSubscriber           slasherservice.SlashingsSubscriber
 	SyncCommitteeObjectPool       synccommittee.Pool
 	BLSChangesPool                blstoexec.PoolManager
 	SyncService                   chainSync.Checker
 	Broadcaster                   p2p.Broadcaster
 	PeersFetcher                  p2p.PeersProvider
 	PeerManager                   p2p.PeerManager
+	PeerController                p2p.PeerController
 	MetadataProvider              p2p.MetadataProvider
 	DepositFetcher                cache.DepositFetcher
 	PendingDepositFetcher         depositcache.PendingDepositsFetcher
@@ -124,10 +139,15 @@ type Config struct {
 	MaxMsgSize                    int
 	ExecutionEngineCaller         execution.EngineCaller
 	ProposerIdsCache              *cache.ProposerPayloadIDsCache
+	LocalBroadcasts               *cache.LocalBroadcastsCache
 	OptimisticModeFetcher         blockchain.OptimisticModeFetcher
+	LightClientFetcher            blockchain.LightClientFetcher
 	BlockBuilder                  builder.BlockBuilder
 	Router                        *mux.Router
 	ClockWaiter                   startup.ClockWaiter
+	SyncProgress                  *progress.Tracker
+	ValidatorMonitor              monitor.TrackedValidatorsManager
+	AdminTokens                   []string
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -210,6 +230,7 @@ func (s *Service) Start() {
 	blocker := &lookup.BeaconDbBlocker{
 		BeaconDB:         s.cfg.BeaconDB,
 		ChainInfoFetcher: s.cfg.ChainInfoFetcher,
+		EraStore:         s.cfg.EraStore,
 	}
 
 	rewardsServer := &rewards.Server{
@@ -239,16 +260,34 @@ func (s *Service) Start() {
 	}
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/blob_sidecars/{block_id}", blobServer.Blobs).Methods(http.MethodGet)
 
+	if features.Get().EnableLightClient {
+		lightClientServer := &lightclient.Server{
+			HeadFetcher:         s.cfg.HeadFetcher,
+			FinalizationFetcher: s.cfg.FinalizationFetcher,
+			LightClientFetcher:  s.cfg.LightClientFetcher,
+			BeaconDB:            s.cfg.BeaconDB,
+			StateGen:            s.cfg.StateGen,
+		}
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/bootstrap/{block_root}", lightClientServer.GetBootstrap).Methods(http.MethodGet)
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/updates", lightClientServer.GetUpdatesByRange).Methods(http.MethodGet)
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/finality_update", lightClientServer.GetFinalityUpdate).Methods(http.MethodGet)
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/optimistic_update", lightClientServer.GetOptimisticUpdate).Methods(http.MethodGet)
+	}
+
 	coreService := &core.Service{
-		HeadFetcher:        s.cfg.HeadFetcher,
-		GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
-		SyncChecker:        s.cfg.SyncService,
-		Broadcaster:        s.cfg.Broadcaster,
-		SyncCommitteePool:  s.cfg.SyncCommitteeObjectPool,
-		OperationNotifier:  s.cfg.OperationNotifier,
-		AttestationCache:   cache.NewAttestationCache(),
-		StateGen:           s.cfg.StateGen,
-		P2P:                s.cfg.Broadcaster,
+		HeadFetcher:               s.cfg.HeadFetcher,
+		GenesisTimeFetcher:        s.cfg.GenesisTimeFetcher,
+		SyncChecker:               s.cfg.SyncService,
+		Broadcaster:               s.cfg.Broadcaster,
+		SyncCommitteePool:         s.cfg.SyncCommitteeObjectPool,
+		OperationNotifier:         s.cfg.OperationNotifier,
+		AttestationCache:          cache.NewAttestationCache(),
+		StateGen:                  s.cfg.StateGen,
+		P2P:                       s.cfg.Broadcaster,
+		FinalizationFetcher:       s.cfg.FinalizationFetcher,
+		OptimisticModeFetcher:     s.cfg.OptimisticModeFetcher,
+		PeersFetcher:              s.cfg.PeersFetcher,
+		ExecutionChainInfoFetcher: s.cfg.ExecutionChainInfoFetcher,
 	}
 
 	validatorServer := &validatorv1alpha1.Server{
@@ -282,10 +321,13 @@ func (s *Service) Start() {
 		ExecutionEngineCaller:  s.cfg.ExecutionEngineCaller,
 		BeaconDB:               s.cfg.BeaconDB,
 		ProposerSlotIndexCache: s.cfg.ProposerIdsCache,
+		LocalBroadcasts:        s.cfg.LocalBroadcasts,
 		BlockBuilder:           s.cfg.BlockBuilder,
 		BLSChangesPool:         s.cfg.BLSChangesPool,
 		ClockWaiter:            s.cfg.ClockWaiter,
 		CoreService:            coreService,
+		ClientVersionFetcher:   s.cfg.ClientVersionFetcher,
+		GraffitiClientInfo:     s.cfg.GraffitiClientInfo,
 	}
 	validatorServerV1 := &validator.Server{
 		HeadFetcher:            s.cfg.HeadFetcher,
@@ -314,6 +356,8 @@ func (s *Service) Start() {
 	s.cfg.Router.HandleFunc("/eth/v1/validator/beacon_committee_subscriptions", validatorServerV1.SubmitBeaconCommitteeSubscription).Methods(http.MethodPost)
 	s.cfg.Router.HandleFunc("/eth/v1/validator/attestation_data", validatorServerV1.GetAttestationData).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/eth/v1/validator/register_validator", validatorServerV1.RegisterValidator).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/eth/v1/validator/beacon_committee_selections", validatorServerV1.SubmitBeaconCommitteeSelections).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/eth/v1/validator/sync_committee_selections", validatorServerV1.SubmitSyncCommitteeSelections).Methods(http.MethodPost)
 	s.cfg.Router.HandleFunc("/eth/v1/validator/duties/attester/{epoch}", validatorServerV1.GetAttesterDuties).Methods(http.MethodPost)
 	s.cfg.Router.HandleFunc("/eth/v1/validator/duties/proposer/{epoch}", validatorServerV1.GetProposerDuties).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/eth/v1/validator/duties/sync/{epoch}", validatorServerV1.GetSyncCommitteeDuties).Methods(http.MethodPost)
@@ -347,9 +391,13 @@ func (s *Service) Start() {
 		MetadataProvider:          s.cfg.MetadataProvider,
 		HeadFetcher:               s.cfg.HeadFetcher,
 		ExecutionChainInfoFetcher: s.cfg.ExecutionChainInfoFetcher,
+		SyncProgress:              s.cfg.SyncProgress,
+		CoreService:               coreService,
 	}
 
 	s.cfg.Router.HandleFunc("/eth/v1/node/syncing", nodeServerEth.GetSyncStatus).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/eth/v1/node/identity", nodeServerEth.Identity).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/eth/v1/node/health", nodeServerEth.Health).Methods(http.MethodGet)
 
 	nodeServerPrysm := &nodeprysm.Server{
 		BeaconDB:                  s.cfg.BeaconDB,
@@ -361,11 +409,62 @@ func (s *Service) Start() {
 		MetadataProvider:          s.cfg.MetadataProvider,
 		HeadFetcher:               s.cfg.HeadFetcher,
 		ExecutionChainInfoFetcher: s.cfg.ExecutionChainInfoFetcher,
+		EngineDiagnosticsFetcher:  s.cfg.EngineDiagnosticsFetcher,
+		EngineCapabilitiesFetcher: s.cfg.EngineCapabilitiesFetcher,
+		ClientVersionFetcher:      s.cfg.ClientVersionFetcher,
+		PendingQueueFetcher:       s.cfg.PendingQueueFetcher,
+		GraffitiClientInfo:        s.cfg.GraffitiClientInfo,
+		CoreService:               coreService,
 	}
 
 	s.cfg.Router.HandleFunc("/prysm/node/trusted_peers", nodeServerPrysm.ListTrustedPeer).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/prysm/node/trusted_peers", nodeServerPrysm.AddTrustedPeer).Methods(http.MethodPost)
 	s.cfg.Router.HandleFunc("/prysm/node/trusted_peers/{peer_id}", nodeServerPrysm.RemoveTrustedPeer).Methods(http.MethodDelete)
+	s.cfg.Router.HandleFunc("/prysm/node/engine_diagnostics", nodeServerPrysm.GetEngineDiagnostics).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/node/client_info", nodeServerPrysm.GetClientInfo).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/v1/node/health", nodeServerPrysm.GetHealth).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/v1/node/version", nodeServerPrysm.GetVersion).Methods(http.MethodGet)
+
+	beaconServerPrysm := &beaconprysm.Server{
+		Stater:                stater,
+		Blocker:               blocker,
+		BeaconDB:              s.cfg.BeaconDB,
+		ChainInfoFetcher:      s.cfg.ChainInfoFetcher,
+		FinalizationFetcher:   s.cfg.FinalizationFetcher,
+		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
+	}
+	s.cfg.Router.HandleFunc("/prysm/v1/beacon/states/{state_id}/proof", beaconServerPrysm.GetStateProof).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/v1/beacon/blocks/{block_id}/proof", beaconServerPrysm.GetBlockProof).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/v1/beacon/states/{state_id}/historical_summaries", beaconServerPrysm.GetHistoricalSummaries).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/v1/beacon/blocks/{block_id}/block_root_proof", beaconServerPrysm.GetBlockRootProof).Methods(http.MethodGet)
+
+	if len(s.cfg.AdminTokens) > 0 {
+		adminServerPrysm := &adminprysm.Server{
+			Tokens:         s.cfg.AdminTokens,
+			PeersFetcher:   s.cfg.PeersFetcher,
+			PeerManager:    s.cfg.PeerManager,
+			PeerController: s.cfg.PeerController,
+		}
+		s.cfg.Router.HandleFunc("/prysm/admin/log_levels", adminServerPrysm.Authenticate(adminServerPrysm.GetLogLevels)).Methods(http.MethodGet)
+		s.cfg.Router.HandleFunc("/prysm/admin/log_levels", adminServerPrysm.Authenticate(adminServerPrysm.SetLogLevel)).Methods(http.MethodPost)
+		s.cfg.Router.HandleFunc("/prysm/admin/features", adminServerPrysm.Authenticate(adminServerPrysm.GetFeatures)).Methods(http.MethodGet)
+		s.cfg.Router.HandleFunc("/prysm/admin/features", adminServerPrysm.Authenticate(adminServerPrysm.SetFeature)).Methods(http.MethodPost)
+		s.cfg.Router.HandleFunc("/prysm/admin/peers", adminServerPrysm.Authenticate(adminServerPrysm.AddPeer)).Methods(http.MethodPost)
+		s.cfg.Router.HandleFunc("/prysm/admin/peers/banned", adminServerPrysm.Authenticate(adminServerPrysm.ListBannedPeers)).Methods(http.MethodGet)
+		s.cfg.Router.HandleFunc("/prysm/admin/peers/banned", adminServerPrysm.Authenticate(adminServerPrysm.BanPeer)).Methods(http.MethodPost)
+		s.cfg.Router.HandleFunc("/prysm/admin/peers/banned/{peer_id}", adminServerPrysm.Authenticate(adminServerPrysm.UnbanPeer)).Methods(http.MethodDelete)
+		s.cfg.Router.HandleFunc("/prysm/admin/peers/discover", adminServerPrysm.Authenticate(adminServerPrysm.DiscoverPeers)).Methods(http.MethodPost)
+		s.cfg.Router.HandleFunc("/prysm/admin/peers/{peer_id}", adminServerPrysm.Authenticate(adminServerPrysm.DisconnectPeer)).Methods(http.MethodDelete)
+		s.cfg.Router.HandleFunc("/prysm/admin/gossip/mesh", adminServerPrysm.Authenticate(adminServerPrysm.GetGossipMesh)).Methods(http.MethodGet)
+	}
+
+	if features.Get().EnableSlasher && s.cfg.SlashingsSubscriber != nil {
+		slasherServerPrysm := &slasherprysm.Server{
+			Ctx:                 s.ctx,
+			SlashingsSubscriber: s.cfg.SlashingsSubscriber,
+		}
+		s.cfg.Router.HandleFunc("/prysm/slasher/slashings/stream", slasherServerPrysm.StreamSlashings).Methods(http.MethodGet)
+	}
 
 	beaconChainServer := &beaconv1alpha1.Server{
 		Ctx:                         s.ctx,
@@ -416,6 +515,8 @@ func (s *Service) Start() {
 		FinalizationFetcher:           s.cfg.FinalizationFetcher,
 		ForkchoiceFetcher:             s.cfg.ForkchoiceFetcher,
 		CoreService:                   coreService,
+		DepositFetcher:                s.cfg.DepositFetcher,
+		LocalBroadcasts:               s.cfg.LocalBroadcasts,
 	}
 	httpServer := &httpserver.Server{
 		GenesisTimeFetcher:    s.cfg.GenesisTimeFetcher,
@@ -427,8 +528,15 @@ func (s *Service) Start() {
 		ChainInfoFetcher:      s.cfg.ChainInfoFetcher,
 		BeaconDB:              s.cfg.BeaconDB,
 		FinalizationFetcher:   s.cfg.FinalizationFetcher,
+		ValidatorMonitor:      s.cfg.ValidatorMonitor,
+		BlockSimulator:        validatorServer,
 	}
 	s.cfg.Router.HandleFunc("/prysm/validators/performance", httpServer.GetValidatorPerformance).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/validators/monitored", httpServer.GetMonitoredValidators).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/validators/monitored", httpServer.AddMonitoredValidators).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/validators/monitored", httpServer.RemoveMonitoredValidators).Methods(http.MethodDelete)
+	s.cfg.Router.HandleFunc("/prysm/states/{state_id}/validator_balances", httpServer.GetValidatorBalances).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/states/{state_id}/validator_exit_status", httpServer.GetValidatorExitStatus).Methods(http.MethodPost)
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/states/{state_id}/validator_count", httpServer.GetValidatorCount).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/states/{state_id}/committees", beaconChainServerV1.GetCommittees).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/states/{state_id}/fork", beaconChainServerV1.GetStateFork).Methods(http.MethodGet)
@@ -444,10 +552,11 @@ func (s *Service) Start() {
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/headers/{block_id}", beaconChainServerV1.GetBlockHeader).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/eth/v1/config/deposit_contract", beaconChainServerV1.GetDepositContract).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/genesis", beaconChainServerV1.GetGenesis).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/deposit_snapshot", beaconChainServerV1.GetDepositSnapshot).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/states/{state_id}/finality_checkpoints", beaconChainServerV1.GetFinalityCheckpoints).Methods(http.MethodGet)
-	s.cfg.Router.HandleFunc("/eth/v1/beacon/states/{state_id}/validators", beaconChainServerV1.GetValidators).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/states/{state_id}/validators", beaconChainServerV1.GetValidators).Methods(http.MethodGet, http.MethodPost)
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/states/{state_id}/validators/{validator_id}", beaconChainServerV1.GetValidator).Methods(http.MethodGet)
-	s.cfg.Router.HandleFunc("/eth/v1/beacon/states/{state_id}/validator_balances", beaconChainServerV1.GetValidatorBalances).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/states/{state_id}/validator_balances", beaconChainServerV1.GetValidatorBalances).Methods(http.MethodGet, http.MethodPost)
 
 	ethpbv1alpha1.RegisterNodeServer(s.grpcServer, nodeServer)
 	ethpbservice.RegisterBeaconNodeServer(s.grpcServer, nodeServerEth)
@@ -460,6 +569,7 @@ func (s *Service) Start() {
 		OperationNotifier: s.cfg.OperationNotifier,
 		HeadFetcher:       s.cfg.HeadFetcher,
 		ChainInfoFetcher:  s.cfg.ChainInfoFetcher,
+		BeaconDB:          s.cfg.BeaconDB,
 	})
 	if s.cfg.EnableDebugRPCEndpoints {
 		log.Info("Enabled debug gRPC endpoints")
@@ -484,6 +594,8 @@ func (s *Service) Start() {
 		}
 		ethpbv1alpha1.RegisterDebugServer(s.grpcServer, debugServer)
 		ethpbservice.RegisterBeaconDebugServer(s.grpcServer, debugServerV1)
+		s.cfg.Router.HandleFunc("/prysm/v1/debug/pending_queue", nodeServerPrysm.GetPendingQueue).Methods(http.MethodGet)
+		s.cfg.Router.HandleFunc("/prysm/v1/validator/blocks/{slot}/simulate", httpServer.SimulateBlock).Methods(http.MethodGet)
 	}
 	ethpbv1alpha1.RegisterBeaconNodeValidatorServer(s.grpcServer, validatorServer)
 	ethpbservice.RegisterBeaconValidatorServer(s.grpcServer, validatorServerV1)
@@ -0,0 +1,180 @@
+package slasher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// A proposer signs at most one blob sidecar per blob index for a given slot.
+type blobSidecarKey struct {
+	slot          primitives.Slot
+	proposerIndex primitives.ValidatorIndex
+	blobIndex     uint64
+}
+
+// The part of a blob sidecar kept to detect equivocations: the root of the block header it references and
+// its signature. Signatures being deterministic, sidecars with different contents have different signatures.
+type blobSidecarRecord struct {
+	blockRoot [fieldparams.RootLength]byte
+	signature [fieldparams.BLSSignatureLength]byte
+}
+
+// Struct for handling a thread-safe record of the first blob sidecar seen
+// for every (slot, proposer index, blob index) tuple.
+type blobSidecarRecords struct {
+	lock  sync.Mutex
+	items map[blobSidecarKey]blobSidecarRecord
+}
+
+func newBlobSidecarRecords() *blobSidecarRecords {
+	return &blobSidecarRecords{
+		items: make(map[blobSidecarKey]blobSidecarRecord),
+	}
+}
+
+// Records the blob sidecar if none was seen for its tuple yet, otherwise returns
+// the previously recorded sidecar when its signature differs from the incoming one.
+func (r *blobSidecarRecords) checkAndRecord(sidecar *slashertypes.SignedBlobSidecarWrapper) (blobSidecarRecord, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	msg := sidecar.SignedBlobSidecar.Message
+	key := blobSidecarKey{slot: msg.Slot, proposerIndex: msg.ProposerIndex, blobIndex: msg.Index}
+	record := blobSidecarRecord{
+		blockRoot: bytesutil.ToBytes32(msg.BlockRoot),
+		signature: bytesutil.ToBytes96(sidecar.SignedBlobSidecar.Signature),
+	}
+	existing, ok := r.items[key]
+	if !ok {
+		r.items[key] = record
+		return blobSidecarRecord{}, false
+	}
+	if existing == record {
+		return blobSidecarRecord{}, false
+	}
+	return existing, true
+}
+
+// Deletes the records of blob sidecars at or before the given epoch and returns the number of records deleted.
+func (r *blobSidecarRecords) prune(maxEpoch primitives.Epoch) uint {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var numPruned uint
+	for key := range r.items {
+		if slots.ToEpoch(key.slot) <= maxEpoch {
+			delete(r.items, key)
+			numPruned++
+		}
+	}
+	return numPruned
+}
+
+// detectBlobSidecarEquivocations takes in signed blob sidecar wrappers and returns the equivocations detected,
+// that is, sidecars signed by the same proposer for the same slot and blob index but with different contents.
+// Sidecars referencing different blocks are evidence of a double proposal: when the headers of both blocks
+// are known, either from the given proposed blocks or from the slasher database, the equivocation comes with
+// a proposer slashing.
+func (s *Service) detectBlobSidecarEquivocations(
+	ctx context.Context,
+	proposedBlocks []*slashertypes.SignedBlockHeaderWrapper,
+	sidecars []*slashertypes.SignedBlobSidecarWrapper,
+) ([]*slashertypes.BlobSidecarEquivocation, error) {
+	ctx, span := trace.StartSpan(ctx, "slasher.detectBlobSidecarEquivocations")
+	defer span.End()
+	if len(sidecars) == 0 {
+		return nil, nil
+	}
+	headersByRoot := make(map[[32]byte]*ethpb.SignedBeaconBlockHeader, len(proposedBlocks))
+	for _, proposal := range proposedBlocks {
+		headersByRoot[proposal.SigningRoot] = proposal.SignedBeaconBlockHeader
+	}
+	equivocations := make([]*slashertypes.BlobSidecarEquivocation, 0)
+	for _, sidecar := range sidecars {
+		existing, equivocated := s.blobSidecars.checkAndRecord(sidecar)
+		if !equivocated {
+			continue
+		}
+		blobSidecarEquivocationsTotal.Inc()
+		msg := sidecar.SignedBlobSidecar.Message
+		equivocation := &slashertypes.BlobSidecarEquivocation{
+			Slot:           msg.Slot,
+			ValidatorIndex: msg.ProposerIndex,
+			BlobIndex:      msg.Index,
+			PrevBlockRoot:  existing.blockRoot,
+			PrevSignature:  existing.signature[:],
+			SidecarWrapper: sidecar,
+		}
+		if existing.blockRoot != bytesutil.ToBytes32(msg.BlockRoot) {
+			slashing, err := s.blobSidecarProposerSlashing(ctx, headersByRoot, msg.ProposerIndex, msg.Slot, existing.blockRoot, msg.BlockRoot)
+			if err != nil {
+				return nil, err
+			}
+			equivocation.ProposerSlashing = slashing
+		}
+		logBlobSidecarEquivocation(equivocation)
+		equivocations = append(equivocations, equivocation)
+	}
+	return equivocations, nil
+}
+
+// Builds a proposer slashing out of the headers of the two blocks referenced by equivocating blob sidecars,
+// or returns nil if any of the two headers is unknown.
+func (s *Service) blobSidecarProposerSlashing(
+	ctx context.Context,
+	headersByRoot map[[32]byte]*ethpb.SignedBeaconBlockHeader,
+	proposerIndex primitives.ValidatorIndex,
+	slot primitives.Slot,
+	prevBlockRoot [32]byte,
+	blockRoot []byte,
+) (*ethpb.ProposerSlashing, error) {
+	storedProposal, err := s.serviceCfg.Database.BlockProposalForValidator(ctx, proposerIndex, slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block proposal from disk")
+	}
+	if storedProposal != nil {
+		if _, ok := headersByRoot[storedProposal.SigningRoot]; !ok {
+			headersByRoot[storedProposal.SigningRoot] = storedProposal.SignedBeaconBlockHeader
+		}
+	}
+	header1, ok := headersByRoot[prevBlockRoot]
+	if !ok {
+		return nil, nil
+	}
+	header2, ok := headersByRoot[bytesutil.ToBytes32(blockRoot)]
+	if !ok {
+		return nil, nil
+	}
+	return &ethpb.ProposerSlashing{
+		Header_1: header1,
+		Header_2: header2,
+	}, nil
+}
+
+// Returns the proposer slashings attached to blob sidecar equivocations, leaving out the proposers
+// already slashed by the given proposer slashings.
+func equivocationProposerSlashings(
+	equivocations []*slashertypes.BlobSidecarEquivocation,
+	proposerSlashings []*ethpb.ProposerSlashing,
+) []*ethpb.ProposerSlashing {
+	slashedProposers := make(map[primitives.ValidatorIndex]bool, len(proposerSlashings))
+	for _, slashing := range proposerSlashings {
+		slashedProposers[slashing.Header_1.Header.ProposerIndex] = true
+	}
+	slashings := make([]*ethpb.ProposerSlashing, 0)
+	for _, equivocation := range equivocations {
+		if equivocation.ProposerSlashing == nil || slashedProposers[equivocation.ValidatorIndex] {
+			continue
+		}
+		slashedProposers[equivocation.ValidatorIndex] = true
+		slashings = append(slashings, equivocation.ProposerSlashing)
+	}
+	return slashings
+}
@@ -0,0 +1,166 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func Test_detectBlobSidecarEquivocations(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	s := &Service{
+		serviceCfg: &ServiceConfig{
+			Database: dbtest.SetupSlasherDB(t),
+		},
+		blobSidecars: newBlobSidecarRecords(),
+	}
+	block1 := createProposalWrapper(t, 4, 1, []byte{1})
+	block2 := createProposalWrapper(t, 4, 1, []byte{2})
+
+	t.Run("identical sidecars", func(t *testing.T) {
+		equivocations, err := s.detectBlobSidecarEquivocations(ctx, nil, []*slashertypes.SignedBlobSidecarWrapper{
+			createBlobSidecarWrapper(t, 4, 1, 0, block1.SigningRoot[:], []byte{1}),
+			createBlobSidecarWrapper(t, 4, 1, 0, block1.SigningRoot[:], []byte{1}),
+			createBlobSidecarWrapper(t, 4, 2, 0, block1.SigningRoot[:], []byte{2}),
+			createBlobSidecarWrapper(t, 4, 1, 1, block1.SigningRoot[:], []byte{2}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(equivocations))
+	})
+	t.Run("same block, different contents", func(t *testing.T) {
+		equivocations, err := s.detectBlobSidecarEquivocations(ctx, nil, []*slashertypes.SignedBlobSidecarWrapper{
+			createBlobSidecarWrapper(t, 4, 1, 0, block1.SigningRoot[:], []byte{3}),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(equivocations))
+		assert.Equal(t, primitives.Slot(4), equivocations[0].Slot)
+		assert.Equal(t, primitives.ValidatorIndex(1), equivocations[0].ValidatorIndex)
+		assert.Equal(t, uint64(0), equivocations[0].BlobIndex)
+		assert.Equal(t, block1.SigningRoot, equivocations[0].PrevBlockRoot)
+		prev := createBlobSidecarWrapper(t, 4, 1, 0, block1.SigningRoot[:], []byte{1})
+		assert.DeepEqual(t, prev.SignedBlobSidecar.Signature, equivocations[0].PrevSignature)
+		assert.Equal(t, true, equivocations[0].ProposerSlashing == nil)
+		require.LogsContain(t, hook, "Blob sidecar equivocation detected")
+	})
+	t.Run("different blocks, unknown headers", func(t *testing.T) {
+		equivocations, err := s.detectBlobSidecarEquivocations(ctx, nil, []*slashertypes.SignedBlobSidecarWrapper{
+			createBlobSidecarWrapper(t, 4, 1, 0, block2.SigningRoot[:], []byte{1}),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(equivocations))
+		assert.Equal(t, true, equivocations[0].ProposerSlashing == nil)
+	})
+	t.Run("different blocks, known headers", func(t *testing.T) {
+		equivocations, err := s.detectBlobSidecarEquivocations(
+			ctx,
+			[]*slashertypes.SignedBlockHeaderWrapper{block1, block2},
+			[]*slashertypes.SignedBlobSidecarWrapper{
+				createBlobSidecarWrapper(t, 4, 1, 0, block2.SigningRoot[:], []byte{1}),
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(equivocations))
+		require.NotNil(t, equivocations[0].ProposerSlashing)
+		assert.DeepEqual(t, block1.SignedBeaconBlockHeader, equivocations[0].ProposerSlashing.Header_1)
+		assert.DeepEqual(t, block2.SignedBeaconBlockHeader, equivocations[0].ProposerSlashing.Header_2)
+	})
+}
+
+func Test_detectBlobSidecarEquivocations_HeaderOnDisk(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	s := &Service{
+		serviceCfg: &ServiceConfig{
+			Database: slasherDB,
+		},
+		blobSidecars: newBlobSidecarRecords(),
+	}
+	block1 := createProposalWrapper(t, 4, 1, []byte{1})
+	block2 := createProposalWrapper(t, 4, 1, []byte{2})
+	require.NoError(t, slasherDB.SaveBlockProposals(ctx, []*slashertypes.SignedBlockHeaderWrapper{block1}))
+
+	equivocations, err := s.detectBlobSidecarEquivocations(
+		ctx,
+		[]*slashertypes.SignedBlockHeaderWrapper{block2},
+		[]*slashertypes.SignedBlobSidecarWrapper{
+			createBlobSidecarWrapper(t, 4, 1, 0, block1.SigningRoot[:], []byte{1}),
+			createBlobSidecarWrapper(t, 4, 1, 0, block2.SigningRoot[:], []byte{1}),
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(equivocations))
+	require.NotNil(t, equivocations[0].ProposerSlashing)
+	assert.DeepEqual(t, block1.SignedBeaconBlockHeader, equivocations[0].ProposerSlashing.Header_1)
+	assert.DeepEqual(t, block2.SignedBeaconBlockHeader, equivocations[0].ProposerSlashing.Header_2)
+}
+
+func Test_equivocationProposerSlashings(t *testing.T) {
+	slashing := func(proposerIndex primitives.ValidatorIndex) *ethpb.ProposerSlashing {
+		return &ethpb.ProposerSlashing{
+			Header_1: createProposalWrapper(t, 4, proposerIndex, []byte{1}).SignedBeaconBlockHeader,
+			Header_2: createProposalWrapper(t, 4, proposerIndex, []byte{2}).SignedBeaconBlockHeader,
+		}
+	}
+	equivocations := []*slashertypes.BlobSidecarEquivocation{
+		{ValidatorIndex: 1, ProposerSlashing: slashing(1)},
+		{ValidatorIndex: 2, ProposerSlashing: slashing(2)},
+		{ValidatorIndex: 2, ProposerSlashing: slashing(2)},
+		{ValidatorIndex: 3},
+	}
+	slashings := equivocationProposerSlashings(equivocations, []*ethpb.ProposerSlashing{slashing(1)})
+	require.Equal(t, 1, len(slashings))
+	assert.Equal(t, primitives.ValidatorIndex(2), slashings[0].Header_1.Header.ProposerIndex)
+}
+
+func Test_blobSidecarRecords_prune(t *testing.T) {
+	records := newBlobSidecarRecords()
+	for _, slot := range []primitives.Slot{0, 31, 32, 64} {
+		_, equivocated := records.checkAndRecord(createBlobSidecarWrapper(t, slot, 1, 0, nil, nil))
+		require.Equal(t, false, equivocated)
+	}
+	assert.Equal(t, uint(2), records.prune(0))
+	assert.Equal(t, 2, len(records.items))
+	assert.Equal(t, uint(0), records.prune(0))
+}
+
+func createBlobSidecarWrapper(
+	t *testing.T,
+	slot primitives.Slot,
+	proposerIndex primitives.ValidatorIndex,
+	blobIndex uint64,
+	blockRoot []byte,
+	blob []byte,
+) *slashertypes.SignedBlobSidecarWrapper {
+	sidecar := &ethpb.BlobSidecar{
+		BlockRoot:       bytesutil.PadTo(blockRoot, fieldparams.RootLength),
+		Index:           blobIndex,
+		Slot:            slot,
+		BlockParentRoot: make([]byte, fieldparams.RootLength),
+		ProposerIndex:   proposerIndex,
+		Blob:            bytesutil.PadTo(blob, fieldparams.BlobLength),
+		KzgCommitment:   make([]byte, 48),
+		KzgProof:        make([]byte, 48),
+	}
+	signRoot, err := sidecar.HashTreeRoot()
+	require.NoError(t, err)
+	// Signatures are deterministic, so sidecars with different contents get different fake signatures.
+	fakeSig := make([]byte, fieldparams.BLSSignatureLength)
+	copy(fakeSig, signRoot[:])
+	return &slashertypes.SignedBlobSidecarWrapper{
+		SignedBlobSidecar: &ethpb.SignedBlobSidecar{
+			Message:   sidecar,
+			Signature: fakeSig,
+		},
+		SigningRoot: signRoot,
+	}
+}
@@ -66,8 +66,10 @@ func Test_processQueuedBlocks_DetectsDoubleProposals(t *testing.T) {
 			SlashingPoolInserter: &slashingsmock.PoolMock{},
 			ClockWaiter:          startup.NewClockSynchronizer(),
 		},
-		params:    DefaultParams(),
-		blksQueue: newBlocksQueue(),
+		params:            DefaultParams(),
+		blksQueue:         newBlocksQueue(),
+		blobSidecarsQueue: newBlobSidecarsQueue(),
+		blobSidecars:      newBlobSidecarRecords(),
 	}
 
 	parentRoot := bytesutil.ToBytes32([]byte("parent"))
@@ -133,8 +135,10 @@ func Test_processQueuedBlocks_NotSlashable(t *testing.T) {
 			HeadStateFetcher: mockChain,
 			ClockWaiter:      startup.NewClockSynchronizer(),
 		},
-		params:    DefaultParams(),
-		blksQueue: newBlocksQueue(),
+		params:            DefaultParams(),
+		blksQueue:         newBlocksQueue(),
+		blobSidecarsQueue: newBlobSidecarsQueue(),
+		blobSidecars:      newBlobSidecarRecords(),
 	}
 	currentSlotChan := make(chan primitives.Slot)
 	exitChan := make(chan struct{})
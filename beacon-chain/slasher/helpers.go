@@ -2,6 +2,7 @@ package slasher
 
 import (
 	"bytes"
+	"fmt"
 	"strconv"
 
 	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
@@ -9,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/container/slice"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/sirupsen/logrus"
 )
@@ -119,6 +121,19 @@ func validateBlockHeaderIntegrity(header *ethpb.SignedBeaconBlockHeader) bool {
 	return true
 }
 
+// Validates the signed blob sidecar for sanity before we perform equivocation detection on it.
+func validateBlobSidecarIntegrity(sidecar *ethpb.SignedBlobSidecar) bool {
+	// If a signed blob sidecar is malformed, we drop it.
+	if sidecar == nil ||
+		sidecar.Message == nil ||
+		len(sidecar.Message.BlockRoot) != fieldparams.RootLength ||
+		len(sidecar.Signature) != fieldparams.BLSSignatureLength ||
+		bytes.Equal(sidecar.Signature, make([]byte, fieldparams.BLSSignatureLength)) {
+		return false
+	}
+	return true
+}
+
 func logAttesterSlashing(slashing *ethpb.AttesterSlashing) {
 	indices := slice.IntersectionUint64(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices)
 	log.WithFields(logrus.Fields{
@@ -137,6 +152,17 @@ func logProposerSlashing(slashing *ethpb.ProposerSlashing) {
 	}).Info("Proposer slashing detected")
 }
 
+func logBlobSidecarEquivocation(equivocation *slashertypes.BlobSidecarEquivocation) {
+	log.WithFields(logrus.Fields{
+		"validatorIndex": equivocation.ValidatorIndex,
+		"slot":           equivocation.Slot,
+		"blobIndex":      equivocation.BlobIndex,
+		"prevBlockRoot":  fmt.Sprintf("%#x", bytesutil.Trunc(equivocation.PrevBlockRoot[:])),
+		"blockRoot":      fmt.Sprintf("%#x", bytesutil.Trunc(equivocation.SidecarWrapper.SignedBlobSidecar.Message.BlockRoot)),
+		"withSlashing":   equivocation.ProposerSlashing != nil,
+	}).Info("Blob sidecar equivocation detected")
+}
+
 // Turns a uint64 value to a string representation.
 func uintToString(val uint64) string {
 	return strconv.FormatUint(val, 10)
@@ -41,6 +41,18 @@ var (
 		Name: "slasher_double_proposals_total",
 		Help: "Total slashable proposals successfully detected by slasher",
 	})
+	receivedBlobSidecarsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_blob_sidecars_received_total",
+		Help: "Total number of blob sidecars received by slasher",
+	})
+	blobSidecarEquivocationsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_blob_sidecar_equivocations_total",
+		Help: "Total blob sidecar equivocations successfully detected by slasher",
+	})
+	droppedSlashingNotificationsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_dropped_slashing_notifications_total",
+		Help: "Total detected slashings not sent to subscribers because the notifications queue was full",
+	})
 	doubleVotesTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "slasher_double_votes_total",
 		Help: "Total slashable double votes successfully detected by slasher",
@@ -0,0 +1,227 @@
+package slasher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api"
+	"github.com/prysmaticlabs/prysm/v4/async/event"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/container/slice"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+const (
+	// AttesterSlashingNotification is the type of notifications of detected attester slashings.
+	AttesterSlashingNotification = "attester_slashing"
+	// ProposerSlashingNotification is the type of notifications of detected proposer slashings.
+	ProposerSlashingNotification = "proposer_slashing"
+
+	webhookTimeout = 5 * time.Second
+	// Number of detected slashings waiting to be sent to subscribers before new ones are dropped.
+	detectedSlashingsQueueSize = 1024
+)
+
+// DetectedSlashing is an attester or a proposer slashing detected by slasher.
+type DetectedSlashing struct {
+	AttesterSlashing *ethpb.AttesterSlashing
+	ProposerSlashing *ethpb.ProposerSlashing
+	// AlreadySlashed is set when all the offending validators are already slashed in the
+	// head state, that is when a slashing for the offense is already included on chain.
+	AlreadySlashed bool
+}
+
+// SlashingsSubscriber is an interface for subscribing to the slashings detected by slasher.
+type SlashingsSubscriber interface {
+	SubscribeDetectedSlashings(ch chan<- *DetectedSlashing) event.Subscription
+}
+
+// NewSlashingNotification converts a detected slashing to its JSON representation.
+func NewSlashingNotification(slashing *DetectedSlashing) (*api.SlashingNotification, error) {
+	n := &api.SlashingNotification{AlreadySlashed: slashing.AlreadySlashed}
+	switch {
+	case slashing.AttesterSlashing != nil:
+		n.Type = AttesterSlashingNotification
+		n.AttesterSlashing = &api.AttesterSlashing{
+			Attestation1: indexedAttestationJson(slashing.AttesterSlashing.Attestation_1),
+			Attestation2: indexedAttestationJson(slashing.AttesterSlashing.Attestation_2),
+		}
+		for _, idx := range attesterSlashingIndices(slashing.AttesterSlashing) {
+			n.ValidatorIndices = append(n.ValidatorIndices, strconv.FormatUint(uint64(idx), 10))
+		}
+	case slashing.ProposerSlashing != nil:
+		n.Type = ProposerSlashingNotification
+		n.ProposerSlashing = &api.ProposerSlashing{
+			SignedHeader1: signedHeaderJson(slashing.ProposerSlashing.Header_1),
+			SignedHeader2: signedHeaderJson(slashing.ProposerSlashing.Header_2),
+		}
+		n.ValidatorIndices = []string{strconv.FormatUint(uint64(slashing.ProposerSlashing.Header_1.Header.ProposerIndex), 10)}
+	default:
+		return nil, errors.New("detected slashing is empty")
+	}
+	return n, nil
+}
+
+func indexedAttestationJson(att *ethpb.IndexedAttestation) *api.IndexedAttestation {
+	indices := make([]string, len(att.AttestingIndices))
+	for i, idx := range att.AttestingIndices {
+		indices[i] = strconv.FormatUint(idx, 10)
+	}
+	return &api.IndexedAttestation{
+		AttestingIndices: indices,
+		Data: &api.AttestationData{
+			Slot:            strconv.FormatUint(uint64(att.Data.Slot), 10),
+			CommitteeIndex:  strconv.FormatUint(uint64(att.Data.CommitteeIndex), 10),
+			BeaconBlockRoot: hexutil.Encode(att.Data.BeaconBlockRoot),
+			Source:          checkpointJson(att.Data.Source),
+			Target:          checkpointJson(att.Data.Target),
+		},
+		Signature: hexutil.Encode(att.Signature),
+	}
+}
+
+func checkpointJson(cp *ethpb.Checkpoint) *api.Checkpoint {
+	return &api.Checkpoint{
+		Epoch: strconv.FormatUint(uint64(cp.Epoch), 10),
+		Root:  hexutil.Encode(cp.Root),
+	}
+}
+
+func signedHeaderJson(header *ethpb.SignedBeaconBlockHeader) *api.SignedBeaconBlockHeader {
+	return &api.SignedBeaconBlockHeader{
+		Message: &api.BeaconBlockHeader{
+			Slot:          strconv.FormatUint(uint64(header.Header.Slot), 10),
+			ProposerIndex: strconv.FormatUint(uint64(header.Header.ProposerIndex), 10),
+			ParentRoot:    hexutil.Encode(header.Header.ParentRoot),
+			StateRoot:     hexutil.Encode(header.Header.StateRoot),
+			BodyRoot:      hexutil.Encode(header.Header.BodyRoot),
+		},
+		Signature: hexutil.Encode(header.Signature),
+	}
+}
+
+// SubscribeDetectedSlashings subscribes to the attester and proposer slashings detected by slasher,
+// including the ones for offenses already slashed on chain.
+func (s *Service) SubscribeDetectedSlashings(ch chan<- *DetectedSlashing) event.Subscription {
+	return s.slashingsFeed.Subscribe(ch)
+}
+
+// Queues the detected slashing for the subscribers of detected slashings.
+func (s *Service) notifyAttesterSlashing(beaconState state.ReadOnlyBeaconState, slashing *ethpb.AttesterSlashing) {
+	s.queueDetectedSlashing(&DetectedSlashing{
+		AttesterSlashing: slashing,
+		AlreadySlashed:   allSlashed(beaconState, attesterSlashingIndices(slashing)),
+	})
+}
+
+// Queues the detected slashing for the subscribers of detected slashings.
+func (s *Service) notifyProposerSlashing(beaconState state.ReadOnlyBeaconState, slashing *ethpb.ProposerSlashing) {
+	s.queueDetectedSlashing(&DetectedSlashing{
+		ProposerSlashing: slashing,
+		AlreadySlashed:   allSlashed(beaconState, []primitives.ValidatorIndex{slashing.Header_1.Header.ProposerIndex}),
+	})
+}
+
+// Queues the detected slashing without blocking, so that a slow subscriber
+// cannot stall slashing detection. The slashing is dropped when the queue is full.
+func (s *Service) queueDetectedSlashing(slashing *DetectedSlashing) {
+	select {
+	case s.detectedSlashings <- slashing:
+	default:
+		droppedSlashingNotificationsTotal.Inc()
+		log.Warn("Detected slashings queue is full, dropping slashing notification")
+	}
+}
+
+// Sends the queued detected slashings to the subscribers until the context is canceled.
+func (s *Service) sendDetectedSlashings(ctx context.Context) {
+	for {
+		select {
+		case slashing := <-s.detectedSlashings:
+			s.slashingsFeed.Send(slashing)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Posts every detected slashing to the configured webhook until the context is canceled.
+func (s *Service) runWebhookNotifier(ctx context.Context) {
+	slashingsChan := make(chan *DetectedSlashing, 16)
+	sub := s.SubscribeDetectedSlashings(slashingsChan)
+	defer sub.Unsubscribe()
+	client := &http.Client{Timeout: webhookTimeout}
+	for {
+		select {
+		case slashing := <-slashingsChan:
+			if err := postSlashingNotification(ctx, client, s.serviceCfg.WebhookURL, slashing); err != nil {
+				log.WithError(err).Error("Could not notify webhook of detected slashing")
+			}
+		case err := <-sub.Err():
+			log.WithError(err).Debug("Subscriber closed with error")
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func postSlashingNotification(ctx context.Context, client *http.Client, url string, slashing *DetectedSlashing) error {
+	notification, err := NewSlashingNotification(slashing)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal slashing notification")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "could not create webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not send webhook request")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close webhook response body")
+		}
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func attesterSlashingIndices(slashing *ethpb.AttesterSlashing) []primitives.ValidatorIndex {
+	indices := slice.IntersectionUint64(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices)
+	validatorIndices := make([]primitives.ValidatorIndex, len(indices))
+	for i, idx := range indices {
+		validatorIndices[i] = primitives.ValidatorIndex(idx)
+	}
+	return validatorIndices
+}
+
+// Returns whether all the given validators are slashed in the state.
+func allSlashed(beaconState state.ReadOnlyBeaconState, indices []primitives.ValidatorIndex) bool {
+	if beaconState == nil || len(indices) == 0 {
+		return false
+	}
+	for _, idx := range indices {
+		val, err := beaconState.ValidatorAtIndexReadOnly(idx)
+		if err != nil || !val.Slashed() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+package slasher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/api"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestService_SubscribeDetectedSlashings(t *testing.T) {
+	beaconState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, beaconState.SetValidators([]*ethpb.Validator{
+		{PublicKey: make([]byte, 48), WithdrawalCredentials: make([]byte, 32), Slashed: true},
+		{PublicKey: make([]byte, 48), WithdrawalCredentials: make([]byte, 32)},
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &Service{detectedSlashings: make(chan *DetectedSlashing, detectedSlashingsQueueSize)}
+	go s.sendDetectedSlashings(ctx)
+	slashingsChan := make(chan *DetectedSlashing, 4)
+	sub := s.SubscribeDetectedSlashings(slashingsChan)
+	defer sub.Unsubscribe()
+
+	attesterSlashing := &ethpb.AttesterSlashing{
+		Attestation_1: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{0, 1}}),
+		Attestation_2: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{0}}),
+	}
+	s.notifyAttesterSlashing(beaconState, attesterSlashing)
+	detected := <-slashingsChan
+	assert.Equal(t, attesterSlashing, detected.AttesterSlashing)
+	assert.Equal(t, true, detected.AlreadySlashed)
+
+	proposerSlashing := &ethpb.ProposerSlashing{
+		Header_1: createProposalWrapper(t, 4, 1, []byte{1}).SignedBeaconBlockHeader,
+		Header_2: createProposalWrapper(t, 4, 1, []byte{2}).SignedBeaconBlockHeader,
+	}
+	s.notifyProposerSlashing(beaconState, proposerSlashing)
+	detected = <-slashingsChan
+	assert.Equal(t, proposerSlashing, detected.ProposerSlashing)
+	assert.Equal(t, false, detected.AlreadySlashed)
+}
+
+func TestService_NotifySlashing_QueueFull(t *testing.T) {
+	s := &Service{detectedSlashings: make(chan *DetectedSlashing, 1)}
+	first := &ethpb.ProposerSlashing{
+		Header_1: createProposalWrapper(t, 4, 1, []byte{1}).SignedBeaconBlockHeader,
+		Header_2: createProposalWrapper(t, 4, 1, []byte{2}).SignedBeaconBlockHeader,
+	}
+	second := &ethpb.ProposerSlashing{
+		Header_1: createProposalWrapper(t, 5, 2, []byte{1}).SignedBeaconBlockHeader,
+		Header_2: createProposalWrapper(t, 5, 2, []byte{2}).SignedBeaconBlockHeader,
+	}
+	s.notifyProposerSlashing(nil, first)
+	// The queue is full, the second slashing is dropped instead of blocking detection.
+	s.notifyProposerSlashing(nil, second)
+	require.Equal(t, 1, len(s.detectedSlashings))
+	queued := <-s.detectedSlashings
+	assert.Equal(t, first, queued.ProposerSlashing)
+}
+
+func TestNewSlashingNotification(t *testing.T) {
+	n, err := NewSlashingNotification(&DetectedSlashing{
+		AttesterSlashing: &ethpb.AttesterSlashing{
+			Attestation_1: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1, 2, 3}}),
+			Attestation_2: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{2, 3, 4}}),
+		},
+		AlreadySlashed: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, AttesterSlashingNotification, n.Type)
+	assert.DeepEqual(t, []string{"2", "3"}, n.ValidatorIndices)
+	assert.Equal(t, true, n.AlreadySlashed)
+	require.NotNil(t, n.AttesterSlashing)
+	assert.Equal(t, true, n.ProposerSlashing == nil)
+
+	n, err = NewSlashingNotification(&DetectedSlashing{
+		ProposerSlashing: &ethpb.ProposerSlashing{
+			Header_1: createProposalWrapper(t, 4, 7, []byte{1}).SignedBeaconBlockHeader,
+			Header_2: createProposalWrapper(t, 4, 7, []byte{2}).SignedBeaconBlockHeader,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ProposerSlashingNotification, n.Type)
+	assert.DeepEqual(t, []string{"7"}, n.ValidatorIndices)
+	require.NotNil(t, n.ProposerSlashing)
+	assert.Equal(t, "4", n.ProposerSlashing.SignedHeader1.Message.Slot)
+
+	_, err = NewSlashingNotification(&DetectedSlashing{})
+	require.ErrorContains(t, "detected slashing is empty", err)
+}
+
+func TestService_runWebhookNotifier(t *testing.T) {
+	received := make(chan *api.SlashingNotification, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		n := &api.SlashingNotification{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(n))
+		received <- n
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{
+		serviceCfg: &ServiceConfig{
+			WebhookURL: srv.URL,
+		},
+	}
+	exitChan := make(chan struct{})
+	go func() {
+		s.runWebhookNotifier(ctx)
+		exitChan <- struct{}{}
+	}()
+	detected := &DetectedSlashing{
+		ProposerSlashing: &ethpb.ProposerSlashing{
+			Header_1: createProposalWrapper(t, 4, 7, []byte{1}).SignedBeaconBlockHeader,
+			Header_2: createProposalWrapper(t, 4, 7, []byte{2}).SignedBeaconBlockHeader,
+		},
+		AlreadySlashed: true,
+	}
+	// Wait for the notifier to subscribe.
+	for s.slashingsFeed.Send(detected) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	n := <-received
+	cancel()
+	<-exitChan
+	assert.Equal(t, ProposerSlashingNotification, n.Type)
+	assert.DeepEqual(t, []string{"7"}, n.ValidatorIndices)
+	assert.Equal(t, true, n.AlreadySlashed)
+}
+
+func TestPostSlashingNotification_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	err := postSlashingNotification(context.Background(), srv.Client(), srv.URL, &DetectedSlashing{
+		ProposerSlashing: &ethpb.ProposerSlashing{
+			Header_1: createProposalWrapper(t, 4, 7, []byte{1}).SignedBeaconBlockHeader,
+			Header_2: createProposalWrapper(t, 4, 7, []byte{2}).SignedBeaconBlockHeader,
+		},
+	})
+	require.ErrorContains(t, "webhook responded with status code 500", err)
+}
@@ -20,6 +20,7 @@ func (s *Service) processAttesterSlashings(ctx context.Context, slashings []*eth
 			return err
 		}
 	}
+	verified := make([]*ethpb.AttesterSlashing, 0, len(slashings))
 	for _, sl := range slashings {
 		if err := s.verifyAttSignature(ctx, sl.Attestation_1); err != nil {
 			log.WithError(err).WithField("a", sl.Attestation_1).Warn(
@@ -34,14 +35,22 @@ func (s *Service) processAttesterSlashings(ctx context.Context, slashings []*eth
 			continue
 		}
 
+		verified = append(verified, sl)
+
 		// Log the slashing event and insert into the beacon node's operations pool.
 		logAttesterSlashing(sl)
+		s.notifyAttesterSlashing(beaconState, sl)
 		if err := s.serviceCfg.SlashingPoolInserter.InsertAttesterSlashing(
 			ctx, beaconState, sl,
 		); err != nil {
 			log.WithError(err).Error("Could not insert attester slashing into operations pool")
 		}
 	}
+	if len(verified) > 0 {
+		if err := s.serviceCfg.Database.SaveSlashings(ctx, verified, nil); err != nil {
+			log.WithError(err).Error("Could not save attester slashings to the slasher database")
+		}
+	}
 	return nil
 }
 
@@ -56,6 +65,7 @@ func (s *Service) processProposerSlashings(ctx context.Context, slashings []*eth
 			return err
 		}
 	}
+	verified := make([]*ethpb.ProposerSlashing, 0, len(slashings))
 	for _, sl := range slashings {
 		if err := s.verifyBlockSignature(ctx, sl.Header_1); err != nil {
 			log.WithError(err).WithField("a", sl.Header_1).Warn(
@@ -69,12 +79,20 @@ func (s *Service) processProposerSlashings(ctx context.Context, slashings []*eth
 			)
 			continue
 		}
+		verified = append(verified, sl)
+
 		// Log the slashing event and insert into the beacon node's operations pool.
 		logProposerSlashing(sl)
+		s.notifyProposerSlashing(beaconState, sl)
 		if err := s.serviceCfg.SlashingPoolInserter.InsertProposerSlashing(ctx, beaconState, sl); err != nil {
 			log.WithError(err).Error("Could not insert proposer slashing into operations pool")
 		}
 	}
+	if len(verified) > 0 {
+		if err := s.serviceCfg.Database.SaveSlashings(ctx, nil, verified); err != nil {
+			log.WithError(err).Error("Could not save proposer slashings to the slasher database")
+		}
+	}
 	return nil
 }
 
@@ -18,6 +18,12 @@ type blocksQueue struct {
 	items []*slashertypes.SignedBlockHeaderWrapper
 }
 
+// Struct for handling a thread-safe list of blob sidecar wrappers.
+type blobSidecarsQueue struct {
+	lock  sync.RWMutex
+	items []*slashertypes.SignedBlobSidecarWrapper
+}
+
 func newAttestationsQueue() *attestationsQueue {
 	return &attestationsQueue{
 		items: make([]*slashertypes.IndexedAttestationWrapper, 0),
@@ -81,3 +87,35 @@ func (q *blocksQueue) extend(blks []*slashertypes.SignedBlockHeaderWrapper) {
 	defer q.lock.Unlock()
 	q.items = append(q.items, blks...)
 }
+
+func newBlobSidecarsQueue() *blobSidecarsQueue {
+	return &blobSidecarsQueue{
+		items: make([]*slashertypes.SignedBlobSidecarWrapper, 0),
+	}
+}
+
+func (q *blobSidecarsQueue) push(sidecar *slashertypes.SignedBlobSidecarWrapper) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.items = append(q.items, sidecar)
+}
+
+func (q *blobSidecarsQueue) dequeue() []*slashertypes.SignedBlobSidecarWrapper {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	items := q.items
+	q.items = make([]*slashertypes.SignedBlobSidecarWrapper, 0)
+	return items
+}
+
+func (q *blobSidecarsQueue) size() int {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return len(q.items)
+}
+
+func (q *blobSidecarsQueue) extend(sidecars []*slashertypes.SignedBlobSidecarWrapper) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.items = append(q.items, sidecars...)
+}
@@ -72,6 +72,34 @@ func (s *Service) receiveBlocks(ctx context.Context, beaconBlockHeadersChan chan
 	}
 }
 
+// Receive signed blob sidecars from some source event feed.
+func (s *Service) receiveBlobSidecars(ctx context.Context, blobSidecarsChan chan *ethpb.SignedBlobSidecar) {
+	sub := s.serviceCfg.BlobSidecarsFeed.Subscribe(blobSidecarsChan)
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case sidecar := <-blobSidecarsChan:
+			if !validateBlobSidecarIntegrity(sidecar) {
+				continue
+			}
+			signingRoot, err := sidecar.Message.HashTreeRoot()
+			if err != nil {
+				log.WithError(err).Error("Could not get hash tree root of signed blob sidecar")
+				continue
+			}
+			s.blobSidecarsQueue.push(&slashertypes.SignedBlobSidecarWrapper{
+				SignedBlobSidecar: sidecar,
+				SigningRoot:       signingRoot,
+			})
+		case err := <-sub.Err():
+			log.WithError(err).Debug("Subscriber closed with error")
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // Process queued attestations every time a slot ticker fires. We retrieve
 // these attestations from a queue, then group them all by validator chunk index.
 // This grouping will allow us to perform detection on batches of attestations
@@ -161,6 +189,20 @@ func (s *Service) processQueuedBlocks(ctx context.Context, slotTicker <-chan pri
 				continue
 			}
 
+			// Check for blob sidecar equivocations, which come with a proposer slashing
+			// whenever the headers of both equivocating blocks are known.
+			sidecars := s.blobSidecarsQueue.dequeue()
+			receivedBlobSidecarsTotal.Add(float64(len(sidecars)))
+			equivocations, err := s.detectBlobSidecarEquivocations(ctx, blocks, sidecars)
+			if err != nil {
+				log.WithError(err).Error("Could not detect blob sidecar equivocations")
+				continue
+			}
+			if err := s.processProposerSlashings(ctx, equivocationProposerSlashings(equivocations, slashings)); err != nil {
+				log.WithError(err).Error("Could not process proposer slashings from blob sidecar equivocations")
+				continue
+			}
+
 			log.WithField("elapsed", time.Since(start)).Debug("Done checking slashable blocks")
 
 			processedBlocksTotal.Add(float64(len(blocks)))
@@ -216,6 +258,13 @@ func (s *Service) pruneSlasherDataWithinSlidingWindow(ctx context.Context, curre
 	if err != nil {
 		return errors.Wrap(err, "Could not prune proposals")
 	}
+	numPrunedSlashings, err := s.serviceCfg.Database.PruneSlashingsAtEpoch(
+		ctx, maxPruningEpoch,
+	)
+	if err != nil {
+		return errors.Wrap(err, "Could not prune slashings")
+	}
+	numPrunedBlobSidecars := s.blobSidecars.prune(maxPruningEpoch)
 	fields := logrus.Fields{}
 	if numPrunedAtts > 0 {
 		fields["numPrunedAtts"] = numPrunedAtts
@@ -223,6 +272,12 @@ func (s *Service) pruneSlasherDataWithinSlidingWindow(ctx context.Context, curre
 	if numPrunedProposals > 0 {
 		fields["numPrunedProposals"] = numPrunedProposals
 	}
+	if numPrunedSlashings > 0 {
+		fields["numPrunedSlashings"] = numPrunedSlashings
+	}
+	if numPrunedBlobSidecars > 0 {
+		fields["numPrunedBlobSidecars"] = numPrunedBlobSidecars
+	}
 	fields["elapsed"] = time.Since(start)
 	log.WithFields(fields).Info("Done pruning old attestations and proposals for slasher")
 	return nil
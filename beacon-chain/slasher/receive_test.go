@@ -61,7 +61,8 @@ func TestService_pruneSlasherDataWithinSlidingWindow_AttestationsPruned(t *testi
 		serviceCfg: &ServiceConfig{
 			Database: slasherDB,
 		},
-		params: params,
+		params:       params,
+		blobSidecars: newBlobSidecarRecords(),
 	}
 
 	// Setup attestations for 2 validators at each epoch for epochs 0, 1, 2, 3.
@@ -140,7 +141,8 @@ func TestService_pruneSlasherDataWithinSlidingWindow_ProposalsPruned(t *testing.
 		serviceCfg: &ServiceConfig{
 			Database: slasherDB,
 		},
-		params: params,
+		params:       params,
+		blobSidecars: newBlobSidecarRecords(),
 	}
 
 	// Setup block proposals for 2 validators at each epoch for epochs 0, 1, 2, 3.
@@ -273,6 +275,36 @@ func TestSlasher_receiveBlocks_OK(t *testing.T) {
 	require.DeepEqual(t, wanted, s.blksQueue.dequeue())
 }
 
+func TestSlasher_receiveBlobSidecars_OK(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{
+		serviceCfg: &ServiceConfig{
+			BlobSidecarsFeed: new(event.Feed),
+		},
+		blobSidecarsQueue: newBlobSidecarsQueue(),
+	}
+	blobSidecarsChan := make(chan *ethpb.SignedBlobSidecar)
+	defer close(blobSidecarsChan)
+	exitChan := make(chan struct{})
+	go func() {
+		s.receiveBlobSidecars(ctx, blobSidecarsChan)
+		exitChan <- struct{}{}
+	}()
+
+	sidecar1 := createBlobSidecarWrapper(t, 0, 1, 0, []byte{1}, nil)
+	sidecar2 := createBlobSidecarWrapper(t, 0, 2, 0, []byte{2}, nil)
+	// A sidecar without a signature is dropped.
+	unsigned := createBlobSidecarWrapper(t, 0, 3, 0, []byte{3}, nil).SignedBlobSidecar
+	unsigned.Signature = nil
+	blobSidecarsChan <- sidecar1.SignedBlobSidecar
+	blobSidecarsChan <- unsigned
+	blobSidecarsChan <- sidecar2.SignedBlobSidecar
+	cancel()
+	<-exitChan
+	wanted := []*slashertypes.SignedBlobSidecarWrapper{sidecar1, sidecar2}
+	require.DeepEqual(t, wanted, s.blobSidecarsQueue.dequeue())
+}
+
 func TestService_processQueuedBlocks(t *testing.T) {
 	hook := logTest.NewGlobal()
 	slasherDB := dbtest.SetupSlasherDB(t)
@@ -294,7 +326,9 @@ func TestService_processQueuedBlocks(t *testing.T) {
 			HeadStateFetcher: mockChain,
 			ClockWaiter:      startup.NewClockSynchronizer(),
 		},
-		blksQueue: newBlocksQueue(),
+		blksQueue:         newBlocksQueue(),
+		blobSidecarsQueue: newBlobSidecarsQueue(),
+		blobSidecars:      newBlobSidecarRecords(),
 	}
 	s.blksQueue.extend([]*slashertypes.SignedBlockHeaderWrapper{
 		createProposalWrapper(t, 0, 1, nil),
@@ -32,6 +32,7 @@ const (
 type ServiceConfig struct {
 	IndexedAttestationsFeed *event.Feed
 	BeaconBlockHeadersFeed  *event.Feed
+	BlobSidecarsFeed        *event.Feed
 	Database                db.SlasherDatabase
 	StateNotifier           statefeed.Notifier
 	AttestationStateFetcher blockchain.AttestationStateFetcher
@@ -40,6 +41,7 @@ type ServiceConfig struct {
 	HeadStateFetcher        blockchain.HeadFetcher
 	SyncChecker             sync.Checker
 	ClockWaiter             startup.ClockWaiter
+	WebhookURL              string
 }
 
 // SlashingChecker is an interface for defining services that the beacon node may interact with to provide slashing data.
@@ -60,6 +62,8 @@ type Service struct {
 	beaconBlockHeadersChan         chan *ethpb.SignedBeaconBlockHeader
 	attsQueue                      *attestationsQueue
 	blksQueue                      *blocksQueue
+	blobSidecarsQueue              *blobSidecarsQueue
+	blobSidecars                   *blobSidecarRecords
 	ctx                            context.Context
 	cancel                         context.CancelFunc
 	genesisTime                    time.Time
@@ -67,6 +71,8 @@ type Service struct {
 	blocksSlotTicker               *slots.SlotTicker
 	pruningSlotTicker              *slots.SlotTicker
 	latestEpochWrittenForValidator map[primitives.ValidatorIndex]primitives.Epoch
+	slashingsFeed                  event.Feed
+	detectedSlashings              chan *DetectedSlashing
 }
 
 // New instantiates a new slasher from configuration values.
@@ -79,9 +85,12 @@ func New(ctx context.Context, srvCfg *ServiceConfig) (*Service, error) {
 		beaconBlockHeadersChan:         make(chan *ethpb.SignedBeaconBlockHeader, 1),
 		attsQueue:                      newAttestationsQueue(),
 		blksQueue:                      newBlocksQueue(),
+		blobSidecarsQueue:              newBlobSidecarsQueue(),
+		blobSidecars:                   newBlobSidecarRecords(),
 		ctx:                            ctx,
 		cancel:                         cancel,
 		latestEpochWrittenForValidator: make(map[primitives.ValidatorIndex]primitives.Epoch),
+		detectedSlashings:              make(chan *DetectedSlashing, detectedSlashingsQueueSize),
 	}, nil
 }
 
@@ -128,6 +137,14 @@ func (s *Service) run() {
 	beaconBlockHeadersChan := make(chan *ethpb.SignedBeaconBlockHeader, 1)
 	go s.receiveAttestations(s.ctx, indexedAttsChan)
 	go s.receiveBlocks(s.ctx, beaconBlockHeadersChan)
+	go s.sendDetectedSlashings(s.ctx)
+	if s.serviceCfg.WebhookURL != "" {
+		go s.runWebhookNotifier(s.ctx)
+	}
+	if s.serviceCfg.BlobSidecarsFeed != nil {
+		blobSidecarsChan := make(chan *ethpb.SignedBlobSidecar, 1)
+		go s.receiveBlobSidecars(s.ctx, blobSidecarsChan)
+	}
 
 	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
 	s.attsSlotTicker = slots.NewSlotTicker(s.genesisTime, secondsPerSlot)
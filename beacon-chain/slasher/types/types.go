@@ -45,6 +45,28 @@ type SignedBlockHeaderWrapper struct {
 	SigningRoot             [32]byte
 }
 
+// SignedBlobSidecarWrapper contains a signed blob sidecar with its
+// signing root to reduce duplicated computation.
+type SignedBlobSidecarWrapper struct {
+	SignedBlobSidecar *ethpb.SignedBlobSidecar
+	SigningRoot       [32]byte
+}
+
+// BlobSidecarEquivocation represents two signed blob sidecars from the same proposer,
+// for the same slot and blob index, whose contents differ. Of the previous sidecar, only
+// the root of the block it references and its signature are kept. When the headers of the
+// blocks referenced by both sidecars are known, the equivocation comes with a proposer
+// slashing as evidence.
+type BlobSidecarEquivocation struct {
+	Slot             primitives.Slot
+	ValidatorIndex   primitives.ValidatorIndex
+	BlobIndex        uint64
+	PrevBlockRoot    [32]byte
+	PrevSignature    []byte
+	SidecarWrapper   *SignedBlobSidecarWrapper
+	ProposerSlashing *ethpb.ProposerSlashing
+}
+
 // AttestedEpochForValidator encapsulates a previously attested epoch
 // for a validator index.
 type AttestedEpochForValidator struct {
@@ -249,6 +249,27 @@ func (f *FieldTrie) Empty() bool {
 	return f == nil || len(f.fieldLayers) == 0 || f.isTransferred
 }
 
+// Layers returns a copy of the layers of the trie, from its leaves
+// up to its root before the length of lists is mixed in, or nil
+// when the trie is empty. The nodes are shared with the trie, which
+// replaces rather than modifies them when it is recomputed.
+func (f *FieldTrie) Layers() [][]*[32]byte {
+	if f == nil {
+		return nil
+	}
+	f.RLock()
+	defer f.RUnlock()
+	if f.Empty() {
+		return nil
+	}
+	layers := make([][]*[32]byte, len(f.fieldLayers))
+	for i, layer := range f.fieldLayers {
+		layers[i] = make([]*[32]byte, len(layer))
+		copy(layers[i], layer)
+	}
+	return layers
+}
+
 // InsertFieldLayer manually inserts a field layer. This method
 // bypasses the normal method of field computation, it is only
 // meant to be used in tests.
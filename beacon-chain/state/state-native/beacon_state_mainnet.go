@@ -33,18 +33,18 @@ type BeaconState struct {
 	eth1DataVotes                       []*ethpb.Eth1Data
 	eth1DepositIndex                    uint64
 	validators                          []*ethpb.Validator
-	balances                            []uint64
+	balances                            *stateutil.ChunkedList[uint64]
 	randaoMixes                         *customtypes.RandaoMixes
 	slashings                           []uint64
 	previousEpochAttestations           []*ethpb.PendingAttestation
 	currentEpochAttestations            []*ethpb.PendingAttestation
-	previousEpochParticipation          []byte
-	currentEpochParticipation           []byte
+	previousEpochParticipation          *stateutil.ChunkedList[byte]
+	currentEpochParticipation           *stateutil.ChunkedList[byte]
 	justificationBits                   bitfield.Bitvector4
 	previousJustifiedCheckpoint         *ethpb.Checkpoint
 	currentJustifiedCheckpoint          *ethpb.Checkpoint
 	finalizedCheckpoint                 *ethpb.Checkpoint
-	inactivityScores                    []uint64
+	inactivityScores                    *stateutil.ChunkedList[uint64]
 	currentSyncCommittee                *ethpb.SyncCommittee
 	nextSyncCommittee                   *ethpb.SyncCommittee
 	latestExecutionPayloadHeader        *enginev1.ExecutionPayloadHeader
@@ -114,18 +114,18 @@ func (b *BeaconState) MarshalJSON() ([]byte, error) {
 		Eth1DataVotes:                       b.eth1DataVotes,
 		Eth1DepositIndex:                    b.eth1DepositIndex,
 		Validators:                          b.validators,
-		Balances:                            b.balances,
+		Balances:                            b.balances.Values(),
 		RandaoMixes:                         b.randaoMixes,
 		Slashings:                           b.slashings,
 		PreviousEpochAttestations:           b.previousEpochAttestations,
 		CurrentEpochAttestations:            b.currentEpochAttestations,
-		PreviousEpochParticipation:          b.previousEpochParticipation,
-		CurrentEpochParticipation:           b.currentEpochParticipation,
+		PreviousEpochParticipation:          b.previousEpochParticipation.Values(),
+		CurrentEpochParticipation:           b.currentEpochParticipation.Values(),
 		JustificationBits:                   b.justificationBits,
 		PreviousJustifiedCheckpoint:         b.previousJustifiedCheckpoint,
 		CurrentJustifiedCheckpoint:          b.currentJustifiedCheckpoint,
 		FinalizedCheckpoint:                 b.finalizedCheckpoint,
-		InactivityScores:                    b.inactivityScores,
+		InactivityScores:                    b.inactivityScores.Values(),
 		CurrentSyncCommittee:                b.currentSyncCommittee,
 		NextSyncCommittee:                   b.nextSyncCommittee,
 		LatestExecutionPayloadHeader:        b.latestExecutionPayloadHeader,
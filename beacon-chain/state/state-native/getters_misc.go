@@ -82,11 +82,7 @@ func (b *BeaconState) HistoricalRoots() ([][]byte, error) {
 // balancesLength returns the length of the balances slice.
 // This assumes that a lock is already held on BeaconState.
 func (b *BeaconState) balancesLength() int {
-	if b.balances == nil {
-		return 0
-	}
-
-	return len(b.balances)
+	return b.balances.Len()
 }
 
 // HistoricalSummaries of the beacon state.
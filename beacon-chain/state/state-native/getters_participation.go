@@ -50,11 +50,11 @@ func (b *BeaconState) UnrealizedCheckpointBalances() (uint64, uint64, uint64, er
 	b.lock.RLock()
 	defer b.lock.RUnlock()
 
-	cp := b.currentEpochParticipation
-	pp := b.previousEpochParticipation
-	if cp == nil || pp == nil {
+	if b.currentEpochParticipation == nil || b.previousEpochParticipation == nil {
 		return 0, 0, 0, ErrNilParticipation
 	}
+	cp := b.currentEpochParticipation.Values()
+	pp := b.previousEpochParticipation.Values()
 
 	return stateutil.UnrealizedCheckpointBalances(cp, pp, b.validators, currentEpoch)
 }
@@ -62,15 +62,11 @@ func (b *BeaconState) UnrealizedCheckpointBalances() (uint64, uint64, uint64, er
 // currentEpochParticipationVal corresponding to participation bits on the beacon chain.
 // This assumes that a lock is already held on BeaconState.
 func (b *BeaconState) currentEpochParticipationVal() []byte {
-	tmp := make([]byte, len(b.currentEpochParticipation))
-	copy(tmp, b.currentEpochParticipation)
-	return tmp
+	return b.currentEpochParticipation.Values()
 }
 
 // previousEpochParticipationVal corresponding to participation bits on the beacon chain.
 // This assumes that a lock is already held on BeaconState.
 func (b *BeaconState) previousEpochParticipationVal() []byte {
-	tmp := make([]byte, len(b.previousEpochParticipation))
-	copy(tmp, b.previousEpochParticipation)
-	return tmp
+	return b.previousEpochParticipation.Values()
 }
@@ -32,7 +32,7 @@ func (b *BeaconState) ToProtoUnsafe() interface{} {
 			Eth1DataVotes:               b.eth1DataVotes,
 			Eth1DepositIndex:            b.eth1DepositIndex,
 			Validators:                  b.validators,
-			Balances:                    b.balances,
+			Balances:                    b.balances.Values(),
 			RandaoMixes:                 b.randaoMixes.Slice(),
 			Slashings:                   b.slashings,
 			PreviousEpochAttestations:   b.previousEpochAttestations,
@@ -56,16 +56,16 @@ func (b *BeaconState) ToProtoUnsafe() interface{} {
 			Eth1DataVotes:               b.eth1DataVotes,
 			Eth1DepositIndex:            b.eth1DepositIndex,
 			Validators:                  b.validators,
-			Balances:                    b.balances,
+			Balances:                    b.balances.Values(),
 			RandaoMixes:                 b.randaoMixes.Slice(),
 			Slashings:                   b.slashings,
-			PreviousEpochParticipation:  b.previousEpochParticipation,
-			CurrentEpochParticipation:   b.currentEpochParticipation,
+			PreviousEpochParticipation:  b.previousEpochParticipation.Values(),
+			CurrentEpochParticipation:   b.currentEpochParticipation.Values(),
 			JustificationBits:           b.justificationBits,
 			PreviousJustifiedCheckpoint: b.previousJustifiedCheckpoint,
 			CurrentJustifiedCheckpoint:  b.currentJustifiedCheckpoint,
 			FinalizedCheckpoint:         b.finalizedCheckpoint,
-			InactivityScores:            b.inactivityScores,
+			InactivityScores:            b.inactivityScores.Values(),
 			CurrentSyncCommittee:        b.currentSyncCommittee,
 			NextSyncCommittee:           b.nextSyncCommittee,
 		}
@@ -83,16 +83,16 @@ func (b *BeaconState) ToProtoUnsafe() interface{} {
 			Eth1DataVotes:                b.eth1DataVotes,
 			Eth1DepositIndex:             b.eth1DepositIndex,
 			Validators:                   b.validators,
-			Balances:                     b.balances,
+			Balances:                     b.balances.Values(),
 			RandaoMixes:                  b.randaoMixes.Slice(),
 			Slashings:                    b.slashings,
-			PreviousEpochParticipation:   b.previousEpochParticipation,
-			CurrentEpochParticipation:    b.currentEpochParticipation,
+			PreviousEpochParticipation:   b.previousEpochParticipation.Values(),
+			CurrentEpochParticipation:    b.currentEpochParticipation.Values(),
 			JustificationBits:            b.justificationBits,
 			PreviousJustifiedCheckpoint:  b.previousJustifiedCheckpoint,
 			CurrentJustifiedCheckpoint:   b.currentJustifiedCheckpoint,
 			FinalizedCheckpoint:          b.finalizedCheckpoint,
-			InactivityScores:             b.inactivityScores,
+			InactivityScores:             b.inactivityScores.Values(),
 			CurrentSyncCommittee:         b.currentSyncCommittee,
 			NextSyncCommittee:            b.nextSyncCommittee,
 			LatestExecutionPayloadHeader: b.latestExecutionPayloadHeader,
@@ -111,16 +111,16 @@ func (b *BeaconState) ToProtoUnsafe() interface{} {
 			Eth1DataVotes:                b.eth1DataVotes,
 			Eth1DepositIndex:             b.eth1DepositIndex,
 			Validators:                   b.validators,
-			Balances:                     b.balances,
+			Balances:                     b.balances.Values(),
 			RandaoMixes:                  b.randaoMixes.Slice(),
 			Slashings:                    b.slashings,
-			PreviousEpochParticipation:   b.previousEpochParticipation,
-			CurrentEpochParticipation:    b.currentEpochParticipation,
+			PreviousEpochParticipation:   b.previousEpochParticipation.Values(),
+			CurrentEpochParticipation:    b.currentEpochParticipation.Values(),
 			JustificationBits:            b.justificationBits,
 			PreviousJustifiedCheckpoint:  b.previousJustifiedCheckpoint,
 			CurrentJustifiedCheckpoint:   b.currentJustifiedCheckpoint,
 			FinalizedCheckpoint:          b.finalizedCheckpoint,
-			InactivityScores:             b.inactivityScores,
+			InactivityScores:             b.inactivityScores.Values(),
 			CurrentSyncCommittee:         b.currentSyncCommittee,
 			NextSyncCommittee:            b.nextSyncCommittee,
 			LatestExecutionPayloadHeader: b.latestExecutionPayloadHeaderCapella,
@@ -142,16 +142,16 @@ func (b *BeaconState) ToProtoUnsafe() interface{} {
 			Eth1DataVotes:                b.eth1DataVotes,
 			Eth1DepositIndex:             b.eth1DepositIndex,
 			Validators:                   b.validators,
-			Balances:                     b.balances,
+			Balances:                     b.balances.Values(),
 			RandaoMixes:                  b.randaoMixes.Slice(),
 			Slashings:                    b.slashings,
-			PreviousEpochParticipation:   b.previousEpochParticipation,
-			CurrentEpochParticipation:    b.currentEpochParticipation,
+			PreviousEpochParticipation:   b.previousEpochParticipation.Values(),
+			CurrentEpochParticipation:    b.currentEpochParticipation.Values(),
 			JustificationBits:            b.justificationBits,
 			PreviousJustifiedCheckpoint:  b.previousJustifiedCheckpoint,
 			CurrentJustifiedCheckpoint:   b.currentJustifiedCheckpoint,
 			FinalizedCheckpoint:          b.finalizedCheckpoint,
-			InactivityScores:             b.inactivityScores,
+			InactivityScores:             b.inactivityScores.Values(),
 			CurrentSyncCommittee:         b.currentSyncCommittee,
 			NextSyncCommittee:            b.nextSyncCommittee,
 			LatestExecutionPayloadHeader: b.latestExecutionPayloadHeaderDeneb,
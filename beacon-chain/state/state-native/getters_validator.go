@@ -191,13 +191,7 @@ func (b *BeaconState) Balances() []uint64 {
 // balancesVal of validators participating in consensus on the beacon chain.
 // This assumes that a lock is already held on BeaconState.
 func (b *BeaconState) balancesVal() []uint64 {
-	if b.balances == nil {
-		return nil
-	}
-
-	res := make([]uint64, len(b.balances))
-	copy(res, b.balances)
-	return res
+	return b.balances.Values()
 }
 
 // BalanceAtIndex of validator with the provided index.
@@ -209,10 +203,10 @@ func (b *BeaconState) BalanceAtIndex(idx primitives.ValidatorIndex) (uint64, err
 	b.lock.RLock()
 	defer b.lock.RUnlock()
 
-	if uint64(len(b.balances)) <= uint64(idx) {
+	if uint64(b.balances.Len()) <= uint64(idx) {
 		return 0, fmt.Errorf("index of %d does not exist", idx)
 	}
-	return b.balances[idx], nil
+	return b.balances.At(int(idx)), nil
 }
 
 // BalancesLength returns the length of the balances slice.
@@ -270,11 +264,5 @@ func (b *BeaconState) InactivityScores() ([]uint64, error) {
 // inactivityScoresVal of validators participating in consensus on the beacon chain.
 // This assumes that a lock is already held on BeaconState.
 func (b *BeaconState) inactivityScoresVal() []uint64 {
-	if b.inactivityScores == nil {
-		return nil
-	}
-
-	res := make([]uint64, len(b.inactivityScores))
-	copy(res, b.inactivityScores)
-	return res
+	return b.inactivityScores.Values()
 }
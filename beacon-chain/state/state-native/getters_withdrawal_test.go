@@ -3,6 +3,7 @@ package state_native
 import (
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stateutil"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
@@ -93,10 +94,10 @@ func TestExpectedWithdrawals(t *testing.T) {
 		s := BeaconState{
 			version:    version.Capella,
 			validators: make([]*ethpb.Validator, 100),
-			balances:   make([]uint64, 100),
+			balances:   stateutil.NewChunkedList(make([]uint64, 100)),
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -113,11 +114,11 @@ func TestExpectedWithdrawals(t *testing.T) {
 		s := BeaconState{
 			version:                      version.Capella,
 			validators:                   make([]*ethpb.Validator, 100),
-			balances:                     make([]uint64, 100),
+			balances:                     stateutil.NewChunkedList(make([]uint64, 100)),
 			nextWithdrawalValidatorIndex: 20,
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -134,7 +135,7 @@ func TestExpectedWithdrawals(t *testing.T) {
 			Index:          0,
 			ValidatorIndex: 3,
 			Address:        s.validators[3].WithdrawalCredentials[12:],
-			Amount:         s.balances[3],
+			Amount:         s.balances.At(3),
 		}
 		require.DeepEqual(t, withdrawal, expected[0])
 	})
@@ -142,10 +143,10 @@ func TestExpectedWithdrawals(t *testing.T) {
 		s := BeaconState{
 			version:    version.Capella,
 			validators: make([]*ethpb.Validator, 100),
-			balances:   make([]uint64, 100),
+			balances:   stateutil.NewChunkedList(make([]uint64, 100)),
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -154,7 +155,7 @@ func TestExpectedWithdrawals(t *testing.T) {
 			val.WithdrawalCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
 			s.validators[i] = val
 		}
-		s.balances[3] += params.BeaconConfig().MinDepositAmount
+		require.NoError(t, s.balances.Set(3, s.balances.At(3)+params.BeaconConfig().MinDepositAmount))
 		expected, err := s.ExpectedWithdrawals()
 		require.NoError(t, err)
 		require.Equal(t, 1, len(expected))
@@ -170,10 +171,10 @@ func TestExpectedWithdrawals(t *testing.T) {
 		s := BeaconState{
 			version:    version.Capella,
 			validators: make([]*ethpb.Validator, 100),
-			balances:   make([]uint64, 100),
+			balances:   stateutil.NewChunkedList(make([]uint64, 100)),
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -183,7 +184,7 @@ func TestExpectedWithdrawals(t *testing.T) {
 			val.WithdrawalCredentials[31] = byte(i)
 			s.validators[i] = val
 		}
-		s.balances[3] += params.BeaconConfig().MinDepositAmount
+		require.NoError(t, s.balances.Set(3, s.balances.At(3)+params.BeaconConfig().MinDepositAmount))
 		s.validators[7].WithdrawableEpoch = primitives.Epoch(0)
 		expected, err := s.ExpectedWithdrawals()
 		require.NoError(t, err)
@@ -193,7 +194,7 @@ func TestExpectedWithdrawals(t *testing.T) {
 			Index:          1,
 			ValidatorIndex: 7,
 			Address:        s.validators[7].WithdrawalCredentials[12:],
-			Amount:         s.balances[7],
+			Amount:         s.balances.At(7),
 		}
 		withdrawalPartial := &enginev1.Withdrawal{
 			Index:          0,
@@ -208,10 +209,10 @@ func TestExpectedWithdrawals(t *testing.T) {
 		s := BeaconState{
 			version:    version.Capella,
 			validators: make([]*ethpb.Validator, 100),
-			balances:   make([]uint64, 100),
+			balances:   stateutil.NewChunkedList(make([]uint64, 100)),
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance + 1
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance+1))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -235,10 +236,10 @@ func TestExpectedWithdrawals(t *testing.T) {
 		s := BeaconState{
 			version:    version.Capella,
 			validators: make([]*ethpb.Validator, 100),
-			balances:   make([]uint64, 100),
+			balances:   stateutil.NewChunkedList(make([]uint64, 100)),
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -262,10 +263,10 @@ func TestExpectedWithdrawals(t *testing.T) {
 		s := BeaconState{
 			version:    version.Capella,
 			validators: make([]*ethpb.Validator, 100),
-			balances:   make([]uint64, 100),
+			balances:   stateutil.NewChunkedList(make([]uint64, 100)),
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance + 1
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance+1))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -289,11 +290,11 @@ func TestExpectedWithdrawals(t *testing.T) {
 		s := BeaconState{
 			version:                      version.Capella,
 			validators:                   make([]*ethpb.Validator, 100),
-			balances:                     make([]uint64, 100),
+			balances:                     stateutil.NewChunkedList(make([]uint64, 100)),
 			nextWithdrawalValidatorIndex: 20,
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -303,7 +304,7 @@ func TestExpectedWithdrawals(t *testing.T) {
 			s.validators[i] = val
 		}
 		s.validators[3].WithdrawableEpoch = primitives.Epoch(0)
-		s.balances[3] = 0
+		require.NoError(t, s.balances.Set(3, 0))
 		expected, err := s.ExpectedWithdrawals()
 		require.NoError(t, err)
 		require.Equal(t, 0, len(expected))
@@ -312,10 +313,10 @@ func TestExpectedWithdrawals(t *testing.T) {
 		s := BeaconState{
 			version:    version.Capella,
 			validators: make([]*ethpb.Validator, 100),
-			balances:   make([]uint64, 100),
+			balances:   stateutil.NewChunkedList(make([]uint64, 100)),
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -324,8 +325,8 @@ func TestExpectedWithdrawals(t *testing.T) {
 			val.WithdrawalCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
 			s.validators[i] = val
 		}
-		s.balances[3] += params.BeaconConfig().MinDepositAmount
-		s.balances[10] += params.BeaconConfig().MinDepositAmount
+		require.NoError(t, s.balances.Set(3, s.balances.At(3)+params.BeaconConfig().MinDepositAmount))
+		require.NoError(t, s.balances.Set(10, s.balances.At(10)+params.BeaconConfig().MinDepositAmount))
 		saved := params.BeaconConfig().MaxValidatorsPerWithdrawalsSweep
 		params.BeaconConfig().MaxValidatorsPerWithdrawalsSweep = 10
 		expected, err := s.ExpectedWithdrawals()
@@ -347,10 +348,10 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 		s := BeaconState{
 			version:    version.Deneb,
 			validators: make([]*ethpb.Validator, 100),
-			balances:   make([]uint64, 100),
+			balances:   stateutil.NewChunkedList(make([]uint64, 100)),
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -367,11 +368,11 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 		s := BeaconState{
 			version:                      version.Deneb,
 			validators:                   make([]*ethpb.Validator, 100),
-			balances:                     make([]uint64, 100),
+			balances:                     stateutil.NewChunkedList(make([]uint64, 100)),
 			nextWithdrawalValidatorIndex: 20,
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -388,7 +389,7 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 			Index:          0,
 			ValidatorIndex: 3,
 			Address:        s.validators[3].WithdrawalCredentials[12:],
-			Amount:         s.balances[3],
+			Amount:         s.balances.At(3),
 		}
 		require.DeepEqual(t, withdrawal, expected[0])
 	})
@@ -396,10 +397,10 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 		s := BeaconState{
 			version:    version.Deneb,
 			validators: make([]*ethpb.Validator, 100),
-			balances:   make([]uint64, 100),
+			balances:   stateutil.NewChunkedList(make([]uint64, 100)),
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -408,7 +409,7 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 			val.WithdrawalCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
 			s.validators[i] = val
 		}
-		s.balances[3] += params.BeaconConfig().MinDepositAmount
+		require.NoError(t, s.balances.Set(3, s.balances.At(3)+params.BeaconConfig().MinDepositAmount))
 		expected, err := s.ExpectedWithdrawals()
 		require.NoError(t, err)
 		require.Equal(t, 1, len(expected))
@@ -424,10 +425,10 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 		s := BeaconState{
 			version:    version.Deneb,
 			validators: make([]*ethpb.Validator, 100),
-			balances:   make([]uint64, 100),
+			balances:   stateutil.NewChunkedList(make([]uint64, 100)),
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -437,7 +438,7 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 			val.WithdrawalCredentials[31] = byte(i)
 			s.validators[i] = val
 		}
-		s.balances[3] += params.BeaconConfig().MinDepositAmount
+		require.NoError(t, s.balances.Set(3, s.balances.At(3)+params.BeaconConfig().MinDepositAmount))
 		s.validators[7].WithdrawableEpoch = primitives.Epoch(0)
 		expected, err := s.ExpectedWithdrawals()
 		require.NoError(t, err)
@@ -447,7 +448,7 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 			Index:          1,
 			ValidatorIndex: 7,
 			Address:        s.validators[7].WithdrawalCredentials[12:],
-			Amount:         s.balances[7],
+			Amount:         s.balances.At(7),
 		}
 		withdrawalPartial := &enginev1.Withdrawal{
 			Index:          0,
@@ -462,10 +463,10 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 		s := BeaconState{
 			version:    version.Deneb,
 			validators: make([]*ethpb.Validator, 100),
-			balances:   make([]uint64, 100),
+			balances:   stateutil.NewChunkedList(make([]uint64, 100)),
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance + 1
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance+1))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -489,10 +490,10 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 		s := BeaconState{
 			version:    version.Deneb,
 			validators: make([]*ethpb.Validator, 100),
-			balances:   make([]uint64, 100),
+			balances:   stateutil.NewChunkedList(make([]uint64, 100)),
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -516,10 +517,10 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 		s := BeaconState{
 			version:    version.Deneb,
 			validators: make([]*ethpb.Validator, 100),
-			balances:   make([]uint64, 100),
+			balances:   stateutil.NewChunkedList(make([]uint64, 100)),
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance + 1
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance+1))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -543,11 +544,11 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 		s := BeaconState{
 			version:                      version.Deneb,
 			validators:                   make([]*ethpb.Validator, 100),
-			balances:                     make([]uint64, 100),
+			balances:                     stateutil.NewChunkedList(make([]uint64, 100)),
 			nextWithdrawalValidatorIndex: 20,
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -557,7 +558,7 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 			s.validators[i] = val
 		}
 		s.validators[3].WithdrawableEpoch = primitives.Epoch(0)
-		s.balances[3] = 0
+		require.NoError(t, s.balances.Set(3, 0))
 		expected, err := s.ExpectedWithdrawals()
 		require.NoError(t, err)
 		require.Equal(t, 0, len(expected))
@@ -566,10 +567,10 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 		s := BeaconState{
 			version:    version.Deneb,
 			validators: make([]*ethpb.Validator, 100),
-			balances:   make([]uint64, 100),
+			balances:   stateutil.NewChunkedList(make([]uint64, 100)),
 		}
 		for i := range s.validators {
-			s.balances[i] = params.BeaconConfig().MaxEffectiveBalance
+			require.NoError(t, s.balances.Set(i, params.BeaconConfig().MaxEffectiveBalance))
 			val := &ethpb.Validator{
 				WithdrawalCredentials: make([]byte, 32),
 				EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
@@ -578,8 +579,8 @@ func TestExpectedWithdrawals_Deneb(t *testing.T) {
 			val.WithdrawalCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
 			s.validators[i] = val
 		}
-		s.balances[3] += params.BeaconConfig().MinDepositAmount
-		s.balances[10] += params.BeaconConfig().MinDepositAmount
+		require.NoError(t, s.balances.Set(3, s.balances.At(3)+params.BeaconConfig().MinDepositAmount))
+		require.NoError(t, s.balances.Set(10, s.balances.At(10)+params.BeaconConfig().MinDepositAmount))
 		saved := params.BeaconConfig().MaxValidatorsPerWithdrawalsSweep
 		params.BeaconConfig().MaxValidatorsPerWithdrawalsSweep = 10
 		expected, err := s.ExpectedWithdrawals()
@@ -3,10 +3,13 @@ package state_native
 import (
 	"context"
 	"encoding/binary"
+	"math/bits"
 
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/fieldtrie"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native/types"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 )
 
@@ -106,3 +109,72 @@ func (b *BeaconState) FinalizedRootProof(ctx context.Context) ([][]byte, error)
 	proof = append(proof, branch...)
 	return proof, nil
 }
+
+// GeneralizedIndexProof crafts a Merkle proof for the node at a generalized index of the beacon state, such as a
+// field, a validator record or a field of the latest execution payload header. The field roots are taken from the
+// state's Merkle trie representation, and the tree of the field on the path to the node from its field trie when it
+// has one, such as the validators, so that only the elements on the path to the node are hashed.
+func (b *BeaconState) GeneralizedIndexProof(ctx context.Context, gindex uint64) (*ssz.Proof, error) {
+	b.lock.Lock()
+	if err := b.initializeMerkleLayers(ctx); err != nil {
+		b.lock.Unlock()
+		return nil, err
+	}
+	if err := b.recomputeDirtyFields(ctx); err != nil {
+		b.lock.Unlock()
+		return nil, err
+	}
+	var fieldCount int
+	switch b.version {
+	case version.Phase0:
+		fieldCount = params.BeaconConfig().BeaconStateFieldCount
+	case version.Altair:
+		fieldCount = params.BeaconConfig().BeaconStateAltairFieldCount
+	case version.Bellatrix:
+		fieldCount = params.BeaconConfig().BeaconStateBellatrixFieldCount
+	case version.Capella:
+		fieldCount = params.BeaconConfig().BeaconStateCapellaFieldCount
+	case version.Deneb:
+		fieldCount = params.BeaconConfig().BeaconStateDenebFieldCount
+	}
+	fieldRoots := make([][32]byte, fieldCount)
+	for i := range fieldRoots {
+		fieldRoots[i] = bytesutil.ToBytes32(b.merkleLayers[0][i])
+	}
+	fieldLayers, err := b.fieldTrieLayers(ctx, gindex, fieldCount)
+	if err != nil {
+		b.lock.Unlock()
+		return nil, err
+	}
+	b.lock.Unlock()
+
+	// A field which changes in the meantime no longer matches its root, which fails proofs going through it.
+	return ssz.ProveGeneralizedIndexWithFieldRoots(b.ToProtoUnsafe(), fieldRoots, fieldLayers, gindex)
+}
+
+// fieldTrieLayers returns the layers of the field trie of the field below which the generalized index lies, keyed
+// by the position of the field, building the trie if needed. It returns nil when the generalized index does not lie
+// below a field with a trie. It assumes the caller holds the state lock, with the dirty fields recomputed.
+func (b *BeaconState) fieldTrieLayers(ctx context.Context, gindex uint64, fieldCount int) (map[int][][]*[32]byte, error) {
+	depth := bits.Len64(uint64(fieldCount - 1))
+	below := bits.Len64(gindex) - 1 - depth
+	if below <= 0 {
+		return nil, nil
+	}
+	position := int(gindex>>uint(below)) - 1<<uint(depth)
+	for field, fTrie := range b.stateFieldLeaves {
+		if field.RealPosition() != position {
+			continue
+		}
+		if b.rebuildTrie[field] || fTrie.Empty() {
+			if _, err := b.rootSelector(ctx, field); err != nil {
+				return nil, err
+			}
+		}
+		if layers := b.stateFieldLeaves[field].Layers(); layers != nil {
+			return map[int][][]*[32]byte{position: layers}, nil
+		}
+		return nil, nil
+	}
+	return nil, nil
+}
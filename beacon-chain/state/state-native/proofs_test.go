@@ -7,6 +7,9 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	statenative "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
 	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
 )
@@ -164,3 +167,66 @@ func TestBeaconStateMerkleProofs_bellatrix(t *testing.T) {
 		require.Equal(t, true, valid)
 	})
 }
+
+func TestBeaconStateMerkleProofs_generalizedIndex(t *testing.T) {
+	ctx := context.Background()
+	st, err := util.NewBeaconStateCapella(func(st *ethpb.BeaconStateCapella) error {
+		st.Validators = []*ethpb.Validator{
+			{PublicKey: make([]byte, 48), WithdrawalCredentials: make([]byte, 32), EffectiveBalance: 32e9},
+			{PublicKey: make([]byte, 48), WithdrawalCredentials: make([]byte, 32), EffectiveBalance: 31e9},
+		}
+		st.Balances = []uint64{32e9, 31e9}
+		return nil
+	})
+	require.NoError(t, err)
+	gindex, err := ssz.GeneralizedIndex(st.ToProtoUnsafe(), "validators.1.effective_balance")
+	require.NoError(t, err)
+
+	htr, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	proof, err := st.GeneralizedIndexProof(ctx, gindex)
+	require.NoError(t, err)
+	require.Equal(t, ssz.Uint64Root(31e9), proof.Leaf)
+	require.Equal(t, true, proof.Verify(htr))
+
+	t.Run("recomputes root on dirty fields", func(t *testing.T) {
+		v, err := st.ValidatorAtIndex(1)
+		require.NoError(t, err)
+		v.EffectiveBalance = 30e9
+		require.NoError(t, st.UpdateValidatorAtIndex(1, v))
+
+		proof, err := st.GeneralizedIndexProof(ctx, gindex)
+		require.NoError(t, err)
+		require.Equal(t, ssz.Uint64Root(30e9), proof.Leaf)
+		require.Equal(t, false, proof.Verify(htr))
+		newRoot, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		require.Equal(t, true, proof.Verify(newRoot))
+	})
+
+	t.Run("proves elements of fields with tries", func(t *testing.T) {
+		require.NoError(t, st.UpdateRandaoMixesAtIndex(3, bytesutil.PadTo([]byte{'r'}, 32)))
+		require.NoError(t, st.UpdateBlockRootAtIndex(2, [32]byte{'b'}))
+		root, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		for path, leaf := range map[string][32]byte{
+			"randao_mixes.3":                  {'r'},
+			"block_roots.2":                   {'b'},
+			"validators.0.effective_balance":  ssz.Uint64Root(32e9),
+			"validators.1.effective_balance":  ssz.Uint64Root(30e9),
+			"validators.1.withdrawable_epoch": {},
+			"historical_summaries":            {},
+			"latest_block_header.parent_root": {},
+		} {
+			gindex, err := ssz.GeneralizedIndex(st.ToProtoUnsafe(), path)
+			require.NoError(t, err)
+			// A copy of the state rebuilds its field tries.
+			proof, err := st.Copy().GeneralizedIndexProof(ctx, gindex)
+			require.NoError(t, err, path)
+			require.Equal(t, true, proof.Verify(root), path)
+			if path != "historical_summaries" {
+				require.Equal(t, leaf, proof.Leaf, path)
+			}
+		}
+	})
+}
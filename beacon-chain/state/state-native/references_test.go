@@ -1,6 +1,7 @@
 package state_native
 
 import (
+	"context"
 	"reflect"
 	"runtime"
 	"runtime/debug"
@@ -9,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native/types"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stateutil"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
@@ -1044,3 +1046,57 @@ func assertValNotFound(t *testing.T, vals [][]byte, val []byte) {
 		}
 	}
 }
+
+func TestChunkedListReferences_RemainsConsistent_Altair(t *testing.T) {
+	n := 5000
+	s, err := InitializeFromProtoUnsafeAltair(&ethpb.BeaconStateAltair{
+		Balances:                   make([]uint64, n),
+		InactivityScores:           make([]uint64, n),
+		PreviousEpochParticipation: make([]byte, n),
+		CurrentEpochParticipation:  make([]byte, n),
+	})
+	require.NoError(t, err)
+	a, ok := s.(*BeaconState)
+	require.Equal(t, true, ok)
+	rootA, err := a.rootSelector(context.Background(), types.Balances)
+	require.NoError(t, err)
+
+	copied := a.Copy()
+	b, ok := copied.(*BeaconState)
+	require.Equal(t, true, ok)
+
+	require.NoError(t, b.UpdateBalancesAtIndex(10, 32))
+	require.NoError(t, b.AppendBalance(64))
+	require.NoError(t, b.AppendInactivityScore(1))
+	require.NoError(t, b.ModifyCurrentParticipationBits(func(val []byte) ([]byte, error) {
+		val[4000] = 7
+		return val, nil
+	}))
+
+	assert.Equal(t, uint64(0), a.Balances()[10])
+	assert.Equal(t, n, a.BalancesLength())
+	scores, err := a.InactivityScores()
+	require.NoError(t, err)
+	assert.Equal(t, n, len(scores))
+	participation, err := a.CurrentEpochParticipation()
+	require.NoError(t, err)
+	assert.Equal(t, byte(0), participation[4000])
+	participation, err = b.CurrentEpochParticipation()
+	require.NoError(t, err)
+	assert.Equal(t, byte(7), participation[4000])
+
+	// Field roots are computed from the cached chunk roots of each copy.
+	root, err := a.rootSelector(context.Background(), types.Balances)
+	require.NoError(t, err)
+	assert.Equal(t, rootA, root)
+	root, err = b.rootSelector(context.Background(), types.Balances)
+	require.NoError(t, err)
+	want, err := stateutil.Uint64ListRootWithRegistryLimit(b.Balances())
+	require.NoError(t, err)
+	assert.Equal(t, want, root)
+	root, err = b.rootSelector(context.Background(), types.CurrentEpochParticipationBits)
+	require.NoError(t, err)
+	want, err = stateutil.ParticipationBitsRoot(participation)
+	require.NoError(t, err)
+	assert.Equal(t, want, root)
+}
@@ -16,12 +16,8 @@ func (b *BeaconState) SetPreviousParticipationBits(val []byte) error {
 		return errNotSupported("SetPreviousParticipationBits", b.version)
 	}
 
-	b.sharedFieldReferences[types.PreviousEpochParticipationBits].MinusRef()
-	b.sharedFieldReferences[types.PreviousEpochParticipationBits] = stateutil.NewRef(1)
-
-	b.previousEpochParticipation = val
+	b.previousEpochParticipation = replaceChunkedList(b.previousEpochParticipation, val)
 	b.markFieldAsDirty(types.PreviousEpochParticipationBits)
-	b.rebuildTrie[types.PreviousEpochParticipationBits] = true
 	return nil
 }
 
@@ -35,12 +31,8 @@ func (b *BeaconState) SetCurrentParticipationBits(val []byte) error {
 		return errNotSupported("SetCurrentParticipationBits", b.version)
 	}
 
-	b.sharedFieldReferences[types.CurrentEpochParticipationBits].MinusRef()
-	b.sharedFieldReferences[types.CurrentEpochParticipationBits] = stateutil.NewRef(1)
-
-	b.currentEpochParticipation = val
+	b.currentEpochParticipation = replaceChunkedList(b.currentEpochParticipation, val)
 	b.markFieldAsDirty(types.CurrentEpochParticipationBits)
-	b.rebuildTrie[types.CurrentEpochParticipationBits] = true
 	return nil
 }
 
@@ -54,18 +46,11 @@ func (b *BeaconState) AppendCurrentParticipationBits(val byte) error {
 		return errNotSupported("AppendCurrentParticipationBits", b.version)
 	}
 
-	participation := b.currentEpochParticipation
-	if b.sharedFieldReferences[types.CurrentEpochParticipationBits].Refs() > 1 {
-		// Copy elements in underlying array by reference.
-		participation = make([]byte, len(b.currentEpochParticipation))
-		copy(participation, b.currentEpochParticipation)
-		b.sharedFieldReferences[types.CurrentEpochParticipationBits].MinusRef()
-		b.sharedFieldReferences[types.CurrentEpochParticipationBits] = stateutil.NewRef(1)
+	if b.currentEpochParticipation == nil {
+		b.currentEpochParticipation = stateutil.NewChunkedList([]byte{})
 	}
-
-	b.currentEpochParticipation = append(participation, val)
+	b.currentEpochParticipation.Append(val)
 	b.markFieldAsDirty(types.CurrentEpochParticipationBits)
-	b.addDirtyIndices(types.CurrentEpochParticipationBits, []uint64{uint64(len(b.currentEpochParticipation) - 1)})
 	return nil
 }
 
@@ -79,17 +64,11 @@ func (b *BeaconState) AppendPreviousParticipationBits(val byte) error {
 		return errNotSupported("AppendPreviousParticipationBits", b.version)
 	}
 
-	bits := b.previousEpochParticipation
-	if b.sharedFieldReferences[types.PreviousEpochParticipationBits].Refs() > 1 {
-		bits = make([]byte, len(b.previousEpochParticipation))
-		copy(bits, b.previousEpochParticipation)
-		b.sharedFieldReferences[types.PreviousEpochParticipationBits].MinusRef()
-		b.sharedFieldReferences[types.PreviousEpochParticipationBits] = stateutil.NewRef(1)
+	if b.previousEpochParticipation == nil {
+		b.previousEpochParticipation = stateutil.NewChunkedList([]byte{})
 	}
-
-	b.previousEpochParticipation = append(bits, val)
+	b.previousEpochParticipation.Append(val)
 	b.markFieldAsDirty(types.PreviousEpochParticipationBits)
-	b.addDirtyIndices(types.PreviousEpochParticipationBits, []uint64{uint64(len(b.previousEpochParticipation) - 1)})
 
 	return nil
 }
@@ -104,14 +83,13 @@ func (b *BeaconState) ModifyPreviousParticipationBits(mutator func(val []byte) (
 		return errNotSupported("ModifyPreviousParticipationBits", b.version)
 	}
 
-	participation := b.previousEpochParticipation
-	if b.sharedFieldReferences[types.PreviousEpochParticipationBits].Refs() > 1 {
-		// Copy elements in underlying array by reference.
-		participation = make([]byte, len(b.previousEpochParticipation))
-		copy(participation, b.previousEpochParticipation)
-		b.sharedFieldReferences[types.PreviousEpochParticipationBits].MinusRef()
-		b.sharedFieldReferences[types.PreviousEpochParticipationBits] = stateutil.NewRef(1)
+	// The mutator works on a contiguous view of the bits. Only the chunks
+	// it changes stop being shared with other copies of the state.
+	if b.previousEpochParticipation == nil {
+		b.previousEpochParticipation = stateutil.NewChunkedList([]byte{})
 	}
+	bits := b.previousEpochParticipation
+	participation := bits.View()
 	// Lock is released so that mutator can
 	// acquire it.
 	b.lock.Unlock()
@@ -123,9 +101,9 @@ func (b *BeaconState) ModifyPreviousParticipationBits(mutator func(val []byte) (
 	}
 	b.lock.Lock()
 	defer b.lock.Unlock()
-	b.previousEpochParticipation = participation
+	bits.Restore(participation)
+	b.previousEpochParticipation = bits
 	b.markFieldAsDirty(types.PreviousEpochParticipationBits)
-	b.rebuildTrie[types.PreviousEpochParticipationBits] = true
 	return nil
 }
 
@@ -139,14 +117,13 @@ func (b *BeaconState) ModifyCurrentParticipationBits(mutator func(val []byte) ([
 		return errNotSupported("ModifyCurrentParticipationBits", b.version)
 	}
 
-	participation := b.currentEpochParticipation
-	if b.sharedFieldReferences[types.CurrentEpochParticipationBits].Refs() > 1 {
-		// Copy elements in underlying array by reference.
-		participation = make([]byte, len(b.currentEpochParticipation))
-		copy(participation, b.currentEpochParticipation)
-		b.sharedFieldReferences[types.CurrentEpochParticipationBits].MinusRef()
-		b.sharedFieldReferences[types.CurrentEpochParticipationBits] = stateutil.NewRef(1)
+	// The mutator works on a contiguous view of the bits. Only the chunks
+	// it changes stop being shared with other copies of the state.
+	if b.currentEpochParticipation == nil {
+		b.currentEpochParticipation = stateutil.NewChunkedList([]byte{})
 	}
+	bits := b.currentEpochParticipation
+	participation := bits.View()
 	// Lock is released so that mutator can
 	// acquire it.
 	b.lock.Unlock()
@@ -158,8 +135,8 @@ func (b *BeaconState) ModifyCurrentParticipationBits(mutator func(val []byte) ([
 	}
 	b.lock.Lock()
 	defer b.lock.Unlock()
-	b.currentEpochParticipation = participation
+	bits.Restore(participation)
+	b.currentEpochParticipation = bits
 	b.markFieldAsDirty(types.CurrentEpochParticipationBits)
-	b.rebuildTrie[types.CurrentEpochParticipationBits] = true
 	return nil
 }
@@ -88,35 +88,24 @@ func (b *BeaconState) SetBalances(val []uint64) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	b.sharedFieldReferences[types.Balances].MinusRef()
-	b.sharedFieldReferences[types.Balances] = stateutil.NewRef(1)
-
-	b.balances = val
+	b.balances = replaceChunkedList(b.balances, val)
 	b.markFieldAsDirty(types.Balances)
-	b.rebuildTrie[types.Balances] = true
 	return nil
 }
 
 // UpdateBalancesAtIndex for the beacon state. This method updates the balance
 // at a specific index to a new value.
 func (b *BeaconState) UpdateBalancesAtIndex(idx primitives.ValidatorIndex, val uint64) error {
-	if uint64(len(b.balances)) <= uint64(idx) {
-		return errors.Errorf("invalid index provided %d", idx)
-	}
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	bals := b.balances
-	if b.sharedFieldReferences[types.Balances].Refs() > 1 {
-		bals = b.balancesVal()
-		b.sharedFieldReferences[types.Balances].MinusRef()
-		b.sharedFieldReferences[types.Balances] = stateutil.NewRef(1)
+	if uint64(b.balances.Len()) <= uint64(idx) {
+		return errors.Errorf("invalid index provided %d", idx)
+	}
+	if err := b.balances.Set(int(idx), val); err != nil {
+		return err
 	}
-
-	bals[idx] = val
-	b.balances = bals
 	b.markFieldAsDirty(types.Balances)
-	b.addDirtyIndices(types.Balances, []uint64{uint64(idx)})
 	return nil
 }
 
@@ -188,17 +177,11 @@ func (b *BeaconState) AppendBalance(bal uint64) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	bals := b.balances
-	if b.sharedFieldReferences[types.Balances].Refs() > 1 {
-		bals = b.balancesVal()
-		b.sharedFieldReferences[types.Balances].MinusRef()
-		b.sharedFieldReferences[types.Balances] = stateutil.NewRef(1)
+	if b.balances == nil {
+		b.balances = stateutil.NewChunkedList([]uint64{})
 	}
-
-	b.balances = append(bals, bal)
-	balIdx := len(b.balances) - 1
+	b.balances.Append(bal)
 	b.markFieldAsDirty(types.Balances)
-	b.addDirtyIndices(types.Balances, []uint64{uint64(balIdx)})
 	return nil
 }
 
@@ -211,14 +194,10 @@ func (b *BeaconState) AppendInactivityScore(s uint64) error {
 		return errNotSupported("AppendInactivityScore", b.version)
 	}
 
-	scores := b.inactivityScores
-	if b.sharedFieldReferences[types.InactivityScores].Refs() > 1 {
-		scores = b.inactivityScoresVal()
-		b.sharedFieldReferences[types.InactivityScores].MinusRef()
-		b.sharedFieldReferences[types.InactivityScores] = stateutil.NewRef(1)
+	if b.inactivityScores == nil {
+		b.inactivityScores = stateutil.NewChunkedList([]uint64{})
 	}
-
-	b.inactivityScores = append(scores, s)
+	b.inactivityScores.Append(s)
 	b.markFieldAsDirty(types.InactivityScores)
 	return nil
 }
@@ -233,10 +212,7 @@ func (b *BeaconState) SetInactivityScores(val []uint64) error {
 		return errNotSupported("SetInactivityScores", b.version)
 	}
 
-	b.sharedFieldReferences[types.InactivityScores].MinusRef()
-	b.sharedFieldReferences[types.InactivityScores] = stateutil.NewRef(1)
-
-	b.inactivityScores = val
+	b.inactivityScores = replaceChunkedList(b.inactivityScores, val)
 	b.markFieldAsDirty(types.InactivityScores)
 	return nil
 }
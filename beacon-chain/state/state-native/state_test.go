@@ -382,14 +382,14 @@ func TestCopyAllTries(t *testing.T) {
 	st, ok := newState.(*BeaconState)
 	require.Equal(t, true, ok)
 
-	obj := st.stateFieldLeaves[types.Balances]
+	obj := st.stateFieldLeaves[types.BlockRoots]
 
 	fieldAddr := fmt.Sprintf("%p", obj)
 
 	nState, ok := st.Copy().(*BeaconState)
 	require.Equal(t, true, ok)
 
-	obj = nState.stateFieldLeaves[types.Balances]
+	obj = nState.stateFieldLeaves[types.BlockRoots]
 
 	newFieldAddr := fmt.Sprintf("%p", obj)
 	assert.Equal(t, fieldAddr, newFieldAddr)
@@ -397,21 +397,21 @@ func TestCopyAllTries(t *testing.T) {
 
 	nState.CopyAllTries()
 
-	obj = nState.stateFieldLeaves[types.Balances]
+	obj = nState.stateFieldLeaves[types.BlockRoots]
 	updatedFieldAddr := fmt.Sprintf("%p", obj)
 
 	assert.NotEqual(t, fieldAddr, updatedFieldAddr)
 	assert.Equal(t, 1, int(obj.FieldReference().Refs()))
 
-	assert.NoError(t, nState.UpdateBalancesAtIndex(20, 10000))
+	assert.NoError(t, nState.UpdateBlockRootAtIndex(20, [32]byte{'b'}))
 
 	_, err = nState.HashTreeRoot(context.Background())
 	assert.NoError(t, err)
 
-	rt, err := st.stateFieldLeaves[types.Balances].TrieRoot()
+	rt, err := st.stateFieldLeaves[types.BlockRoots].TrieRoot()
 	assert.NoError(t, err)
 
-	newRt, err := nState.stateFieldLeaves[types.Balances].TrieRoot()
+	newRt, err := nState.stateFieldLeaves[types.BlockRoots].TrieRoot()
 	assert.NoError(t, err)
 	assert.NotEqual(t, rt, newRt)
 }
@@ -92,11 +92,11 @@ var denebFields = append(
 )
 
 const (
-	phase0SharedFieldRefCount    = 10
-	altairSharedFieldRefCount    = 11
-	bellatrixSharedFieldRefCount = 12
-	capellaSharedFieldRefCount   = 14
-	denebSharedFieldRefCount     = 14
+	phase0SharedFieldRefCount    = 9
+	altairSharedFieldRefCount    = 7
+	bellatrixSharedFieldRefCount = 8
+	capellaSharedFieldRefCount   = 10
+	denebSharedFieldRefCount     = 10
 )
 
 // InitializeFromProtoPhase0 the beacon state from a protobuf representation.
@@ -163,7 +163,7 @@ func InitializeFromProtoUnsafePhase0(st *ethpb.BeaconState) (state.BeaconState,
 		eth1DataVotes:               st.Eth1DataVotes,
 		eth1DepositIndex:            st.Eth1DepositIndex,
 		validators:                  st.Validators,
-		balances:                    st.Balances,
+		balances:                    newChunkedList(st.Balances),
 		randaoMixes:                 &mixes,
 		slashings:                   st.Slashings,
 		previousEpochAttestations:   st.PreviousEpochAttestations,
@@ -198,7 +198,6 @@ func InitializeFromProtoUnsafePhase0(st *ethpb.BeaconState) (state.BeaconState,
 	b.sharedFieldReferences[types.HistoricalRoots] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Eth1DataVotes] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Validators] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.Balances] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.RandaoMixes] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Slashings] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.PreviousEpochAttestations] = stateutil.NewRef(1)
@@ -249,16 +248,16 @@ func InitializeFromProtoUnsafeAltair(st *ethpb.BeaconStateAltair) (state.BeaconS
 		eth1DataVotes:               st.Eth1DataVotes,
 		eth1DepositIndex:            st.Eth1DepositIndex,
 		validators:                  st.Validators,
-		balances:                    st.Balances,
+		balances:                    newChunkedList(st.Balances),
 		randaoMixes:                 &mixes,
 		slashings:                   st.Slashings,
-		previousEpochParticipation:  st.PreviousEpochParticipation,
-		currentEpochParticipation:   st.CurrentEpochParticipation,
+		previousEpochParticipation:  newChunkedList(st.PreviousEpochParticipation),
+		currentEpochParticipation:   newChunkedList(st.CurrentEpochParticipation),
 		justificationBits:           st.JustificationBits,
 		previousJustifiedCheckpoint: st.PreviousJustifiedCheckpoint,
 		currentJustifiedCheckpoint:  st.CurrentJustifiedCheckpoint,
 		finalizedCheckpoint:         st.FinalizedCheckpoint,
-		inactivityScores:            st.InactivityScores,
+		inactivityScores:            newChunkedList(st.InactivityScores),
 		currentSyncCommittee:        st.CurrentSyncCommittee,
 		nextSyncCommittee:           st.NextSyncCommittee,
 
@@ -287,12 +286,8 @@ func InitializeFromProtoUnsafeAltair(st *ethpb.BeaconStateAltair) (state.BeaconS
 	b.sharedFieldReferences[types.HistoricalRoots] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Eth1DataVotes] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Validators] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.Balances] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.RandaoMixes] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Slashings] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.PreviousEpochParticipationBits] = stateutil.NewRef(1) // New in Altair.
-	b.sharedFieldReferences[types.CurrentEpochParticipationBits] = stateutil.NewRef(1)  // New in Altair.
-	b.sharedFieldReferences[types.InactivityScores] = stateutil.NewRef(1)               // New in Altair.
 
 	state.StateCount.Inc()
 	// Finalizer runs when dst is being destroyed in garbage collection.
@@ -339,16 +334,16 @@ func InitializeFromProtoUnsafeBellatrix(st *ethpb.BeaconStateBellatrix) (state.B
 		eth1DataVotes:                st.Eth1DataVotes,
 		eth1DepositIndex:             st.Eth1DepositIndex,
 		validators:                   st.Validators,
-		balances:                     st.Balances,
+		balances:                     newChunkedList(st.Balances),
 		randaoMixes:                  &mixes,
 		slashings:                    st.Slashings,
-		previousEpochParticipation:   st.PreviousEpochParticipation,
-		currentEpochParticipation:    st.CurrentEpochParticipation,
+		previousEpochParticipation:   newChunkedList(st.PreviousEpochParticipation),
+		currentEpochParticipation:    newChunkedList(st.CurrentEpochParticipation),
 		justificationBits:            st.JustificationBits,
 		previousJustifiedCheckpoint:  st.PreviousJustifiedCheckpoint,
 		currentJustifiedCheckpoint:   st.CurrentJustifiedCheckpoint,
 		finalizedCheckpoint:          st.FinalizedCheckpoint,
-		inactivityScores:             st.InactivityScores,
+		inactivityScores:             newChunkedList(st.InactivityScores),
 		currentSyncCommittee:         st.CurrentSyncCommittee,
 		nextSyncCommittee:            st.NextSyncCommittee,
 		latestExecutionPayloadHeader: st.LatestExecutionPayloadHeader,
@@ -378,12 +373,8 @@ func InitializeFromProtoUnsafeBellatrix(st *ethpb.BeaconStateBellatrix) (state.B
 	b.sharedFieldReferences[types.HistoricalRoots] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Eth1DataVotes] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Validators] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.Balances] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.RandaoMixes] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Slashings] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.PreviousEpochParticipationBits] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.CurrentEpochParticipationBits] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.InactivityScores] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.LatestExecutionPayloadHeader] = stateutil.NewRef(1) // New in Bellatrix.
 
 	state.StateCount.Inc()
@@ -431,16 +422,16 @@ func InitializeFromProtoUnsafeCapella(st *ethpb.BeaconStateCapella) (state.Beaco
 		eth1DataVotes:                       st.Eth1DataVotes,
 		eth1DepositIndex:                    st.Eth1DepositIndex,
 		validators:                          st.Validators,
-		balances:                            st.Balances,
+		balances:                            newChunkedList(st.Balances),
 		randaoMixes:                         &mixes,
 		slashings:                           st.Slashings,
-		previousEpochParticipation:          st.PreviousEpochParticipation,
-		currentEpochParticipation:           st.CurrentEpochParticipation,
+		previousEpochParticipation:          newChunkedList(st.PreviousEpochParticipation),
+		currentEpochParticipation:           newChunkedList(st.CurrentEpochParticipation),
 		justificationBits:                   st.JustificationBits,
 		previousJustifiedCheckpoint:         st.PreviousJustifiedCheckpoint,
 		currentJustifiedCheckpoint:          st.CurrentJustifiedCheckpoint,
 		finalizedCheckpoint:                 st.FinalizedCheckpoint,
-		inactivityScores:                    st.InactivityScores,
+		inactivityScores:                    newChunkedList(st.InactivityScores),
 		currentSyncCommittee:                st.CurrentSyncCommittee,
 		nextSyncCommittee:                   st.NextSyncCommittee,
 		latestExecutionPayloadHeaderCapella: st.LatestExecutionPayloadHeader,
@@ -473,12 +464,8 @@ func InitializeFromProtoUnsafeCapella(st *ethpb.BeaconStateCapella) (state.Beaco
 	b.sharedFieldReferences[types.HistoricalRoots] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Eth1DataVotes] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Validators] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.Balances] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.RandaoMixes] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Slashings] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.PreviousEpochParticipationBits] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.CurrentEpochParticipationBits] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.InactivityScores] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.LatestExecutionPayloadHeaderCapella] = stateutil.NewRef(1) // New in Capella.
 	b.sharedFieldReferences[types.HistoricalSummaries] = stateutil.NewRef(1)                 // New in Capella.
 
@@ -527,16 +514,16 @@ func InitializeFromProtoUnsafeDeneb(st *ethpb.BeaconStateDeneb) (state.BeaconSta
 		eth1DataVotes:                     st.Eth1DataVotes,
 		eth1DepositIndex:                  st.Eth1DepositIndex,
 		validators:                        st.Validators,
-		balances:                          st.Balances,
+		balances:                          newChunkedList(st.Balances),
 		randaoMixes:                       &mixes,
 		slashings:                         st.Slashings,
-		previousEpochParticipation:        st.PreviousEpochParticipation,
-		currentEpochParticipation:         st.CurrentEpochParticipation,
+		previousEpochParticipation:        newChunkedList(st.PreviousEpochParticipation),
+		currentEpochParticipation:         newChunkedList(st.CurrentEpochParticipation),
 		justificationBits:                 st.JustificationBits,
 		previousJustifiedCheckpoint:       st.PreviousJustifiedCheckpoint,
 		currentJustifiedCheckpoint:        st.CurrentJustifiedCheckpoint,
 		finalizedCheckpoint:               st.FinalizedCheckpoint,
-		inactivityScores:                  st.InactivityScores,
+		inactivityScores:                  newChunkedList(st.InactivityScores),
 		currentSyncCommittee:              st.CurrentSyncCommittee,
 		nextSyncCommittee:                 st.NextSyncCommittee,
 		latestExecutionPayloadHeaderDeneb: st.LatestExecutionPayloadHeader,
@@ -569,12 +556,8 @@ func InitializeFromProtoUnsafeDeneb(st *ethpb.BeaconStateDeneb) (state.BeaconSta
 	b.sharedFieldReferences[types.HistoricalRoots] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Eth1DataVotes] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Validators] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.Balances] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.RandaoMixes] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.Slashings] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.PreviousEpochParticipationBits] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.CurrentEpochParticipationBits] = stateutil.NewRef(1)
-	b.sharedFieldReferences[types.InactivityScores] = stateutil.NewRef(1)
 	b.sharedFieldReferences[types.LatestExecutionPayloadHeaderDeneb] = stateutil.NewRef(1) // New in Deneb.
 	b.sharedFieldReferences[types.HistoricalSummaries] = stateutil.NewRef(1)               // New in Capella.
 
@@ -623,13 +606,15 @@ func (b *BeaconState) Copy() state.BeaconState {
 		slashings:                 b.slashings,
 
 		// Large arrays, increases over time.
-		balances:                   b.balances,
-		historicalRoots:            b.historicalRoots,
-		historicalSummaries:        b.historicalSummaries,
-		validators:                 b.validators,
-		previousEpochParticipation: b.previousEpochParticipation,
-		currentEpochParticipation:  b.currentEpochParticipation,
-		inactivityScores:           b.inactivityScores,
+		historicalRoots:     b.historicalRoots,
+		historicalSummaries: b.historicalSummaries,
+		validators:          b.validators,
+
+		// Large lists indexed by validator, copied chunk by chunk on write.
+		balances:                   b.balances.Copy(),
+		previousEpochParticipation: b.previousEpochParticipation.Copy(),
+		currentEpochParticipation:  b.currentEpochParticipation.Copy(),
+		inactivityScores:           b.inactivityScores.Copy(),
 
 		// Everything else, too small to be concerned about, constant size.
 		genesisValidatorsRoot:               b.genesisValidatorsRoot,
@@ -876,15 +861,7 @@ func (b *BeaconState) rootSelector(ctx context.Context, field types.FieldIndex)
 		}
 		return b.recomputeFieldTrie(11, b.validators)
 	case types.Balances:
-		if b.rebuildTrie[field] {
-			err := b.resetFieldTrie(field, b.balances, stateutil.ValidatorLimitForBalancesChunks())
-			if err != nil {
-				return [32]byte{}, err
-			}
-			delete(b.rebuildTrie, field)
-			return b.stateFieldLeaves[field].TrieRoot()
-		}
-		return b.recomputeFieldTrie(12, b.balances)
+		return b.balances.HashTreeRoot(stateutil.ValidatorLimitForBalancesChunks())
 	case types.RandaoMixes:
 		if b.rebuildTrie[field] {
 			err := b.resetFieldTrie(field, b.randaoMixes, fieldparams.RandaoMixesLength)
@@ -926,9 +903,9 @@ func (b *BeaconState) rootSelector(ctx context.Context, field types.FieldIndex)
 		}
 		return b.recomputeFieldTrie(field, b.currentEpochAttestations)
 	case types.PreviousEpochParticipationBits:
-		return stateutil.ParticipationBitsRoot(b.previousEpochParticipation)
+		return b.previousEpochParticipation.HashTreeRoot(stateutil.ValidatorLimitForParticipationChunks())
 	case types.CurrentEpochParticipationBits:
-		return stateutil.ParticipationBitsRoot(b.currentEpochParticipation)
+		return b.currentEpochParticipation.HashTreeRoot(stateutil.ValidatorLimitForParticipationChunks())
 	case types.JustificationBits:
 		return bytesutil.ToBytes32(b.justificationBits), nil
 	case types.PreviousJustifiedCheckpoint:
@@ -938,7 +915,7 @@ func (b *BeaconState) rootSelector(ctx context.Context, field types.FieldIndex)
 	case types.FinalizedCheckpoint:
 		return ssz.CheckpointRoot(b.finalizedCheckpoint)
 	case types.InactivityScores:
-		return stateutil.Uint64ListRootWithRegistryLimit(b.inactivityScores)
+		return b.inactivityScores.HashTreeRoot(stateutil.ValidatorLimitForBalancesChunks())
 	case types.CurrentSyncCommittee:
 		return stateutil.SyncCommitteeRoot(b.currentSyncCommittee)
 	case types.NextSyncCommittee:
@@ -1030,6 +1007,26 @@ func (b *BeaconState) resetFieldTrie(index types.FieldIndex, elements interface{
 	return nil
 }
 
+// newChunkedList wraps the values of a list field in a chunked list. A nil list stays nil so that
+// the state round-trips to the same protobuf representation.
+func newChunkedList[V stateutil.Packable](vals []V) *stateutil.ChunkedList[V] {
+	if vals == nil {
+		return nil
+	}
+	return stateutil.NewChunkedList(vals)
+}
+
+// replaceChunkedList overwrites the contents of a chunked list field, keeping the chunks that did
+// not change shared with other copies of the state.
+func replaceChunkedList[V stateutil.Packable](l *stateutil.ChunkedList[V], vals []V) *stateutil.ChunkedList[V] {
+	if l == nil || vals == nil {
+		l.Release()
+		return newChunkedList(vals)
+	}
+	l.Replace(vals)
+	return l
+}
+
 func finalizerCleanup(b *BeaconState) {
 	b.lock.Lock()
 	defer b.lock.Unlock()
@@ -1039,6 +1036,10 @@ func finalizerCleanup(b *BeaconState) {
 			b.stateFieldLeaves[field].FieldReference().MinusRef()
 		}
 	}
+	b.balances.Release()
+	b.previousEpochParticipation.Release()
+	b.currentEpochParticipation.Release()
+	b.inactivityScores.Release()
 	for i := range b.dirtyFields {
 		delete(b.dirtyFields, i)
 	}
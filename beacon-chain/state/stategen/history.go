@@ -50,6 +50,15 @@ func (c *CanonicalHistory) BlockRootForSlot(ctx context.Context, target primitiv
 		return [32]byte{}, errors.Wrap(ErrFutureSlotRequested, fmt.Sprintf("requested=%d, current=%d", target, currentSlot))
 	}
 
+	// Finalized history is answered by the finalized root index without any canonical check.
+	r, err := c.h.FinalizedBlockRootForSlot(ctx, target)
+	if err == nil {
+		return r, nil
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return [32]byte{}, errors.Wrap(err, "error reading finalized root index")
+	}
+
 	slotAbove := target + 1
 	// don't bother searching for candidate roots when we know the target slot is genesis
 	for slotAbove > 1 {
@@ -142,6 +142,32 @@ func TestCanonicalBlockForSlotHappy(t *testing.T) {
 	}
 }
 
+func TestCanonicalBlockForSlotFinalizedIndex(t *testing.T) {
+	ctx := context.Background()
+	var begin, middle, end primitives.Slot = 100, 150, 155
+	specs := []mockHistorySpec{
+		{slot: begin},
+		{slot: middle, savedState: true},
+		{slot: end, canonicalBlock: true},
+	}
+	hist := newMockHistory(t, specs, end+1)
+	ch := &CanonicalHistory{h: hist, cc: hist, cs: hist}
+
+	// The finalized root index is trusted without checking whether the block is canonical.
+	hist.finalized = map[primitives.Slot][32]byte{end - 1: hist.slotMap[middle]}
+	r, err := ch.BlockRootForSlot(ctx, end-1)
+	require.NoError(t, err)
+	require.Equal(t, hist.slotMap[middle], r)
+	// Slots that are not in the index fall back to the canonical checker.
+	r, err = ch.BlockRootForSlot(ctx, middle)
+	require.NoError(t, err)
+	require.Equal(t, hist.slotMap[0], r)
+
+	hist.finalizedErr = errors.New("index failure")
+	_, err = ch.BlockRootForSlot(ctx, end-1)
+	require.ErrorContains(t, "index failure", err)
+}
+
 func TestCanonicalBlockForSlotNonHappy(t *testing.T) {
 	ctx := context.Background()
 	var begin, middle, end primitives.Slot = 100, 150, 155
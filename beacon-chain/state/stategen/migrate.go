@@ -74,6 +74,11 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 				// There's no need to generate the state if the state already exists in the DB.
 				// We can skip saving the state.
 				if !s.beaconDB.HasState(ctx, aRoot) {
+					// Do not regenerate a state which could not be saved.
+					if s.lowDiskPaused() {
+						log.WithField("slot", slot).Warn("Skipped saving archived state as disk space is low")
+						continue
+					}
 					aState, err = s.StateByRoot(ctx, aRoot)
 					if err != nil {
 						return err
@@ -98,6 +103,10 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 				continue
 			}
 
+			if s.lowDiskPaused() {
+				log.WithField("slot", aState.Slot()).Warn("Skipped saving archived state as disk space is low")
+				continue
+			}
 			if err := s.beaconDB.SaveState(ctx, aState, aRoot); err != nil {
 				return err
 			}
@@ -120,3 +129,8 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 
 	return nil
 }
+
+// lowDiskPaused returns true when archived states should not be saved because the disk is low.
+func (s *State) lowDiskPaused() bool {
+	return s.lowDisk != nil && s.lowDisk.LowDisk()
+}
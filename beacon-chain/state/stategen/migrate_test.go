@@ -63,6 +63,60 @@ func TestMigrateToCold_HappyPath(t *testing.T) {
 	require.LogsContain(t, hook, "Saved state in DB")
 }
 
+type lowDisk bool
+
+func (l lowDisk) LowDisk() bool {
+	return bool(l)
+}
+
+func TestMigrateToCold_LowDisk(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	service := New(beaconDB, doublylinkedtree.New(), WithLowDiskChecker(lowDisk(true)))
+	service.slotsPerArchivedPoint = 1
+	beaconState, _ := util.DeterministicGenesisState(t, 32)
+	require.NoError(t, beaconState.SetSlot(1))
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 2
+	fRoot, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, service.beaconDB, b)
+	require.NoError(t, service.epochBoundaryStateCache.put(fRoot, beaconState))
+	require.NoError(t, service.MigrateToCold(ctx, fRoot))
+
+	assert.Equal(t, false, service.beaconDB.HasState(ctx, fRoot), "Saved state while disk is low")
+	assert.Equal(t, fRoot, service.finalizedInfo.root, "Did not update finalized info")
+	require.LogsContain(t, hook, "Skipped saving archived state as disk space is low")
+}
+
+func TestMigrateToCold_LowDiskSkipsRegeneration(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	service := New(beaconDB, doublylinkedtree.New(), WithLowDiskChecker(lowDisk(true)))
+	service.slotsPerArchivedPoint = 1
+	beaconState, pks := util.DeterministicGenesisState(t, 32)
+	b1, err := util.GenerateFullBlock(beaconState, pks, util.DefaultBlockGenConfig(), 1)
+	require.NoError(t, err)
+	r1, err := b1.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, service.beaconDB, b1)
+	require.NoError(t, service.beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 1, Root: r1[:]}))
+	b2, err := util.GenerateFullBlock(beaconState, pks, util.DefaultBlockGenConfig(), 2)
+	require.NoError(t, err)
+	r2, err := b2.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, service.beaconDB, b2)
+
+	// No state is saved to regenerate the archived state from, so regenerating it would fail.
+	require.NoError(t, service.MigrateToCold(ctx, r2))
+	assert.Equal(t, false, service.beaconDB.HasState(ctx, r1), "Saved state while disk is low")
+	require.LogsContain(t, hook, "Skipped saving archived state as disk space is low")
+}
+
 func TestMigrateToCold_RegeneratePath(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ctx := context.Background()
@@ -81,6 +81,8 @@ type mockHistory struct {
 	states                         map[[32]byte]state.BeaconState
 	hiddenStates                   map[[32]byte]state.BeaconState
 	current                        primitives.Slot
+	finalized                      map[primitives.Slot][32]byte
+	finalizedErr                   error
 	overrideHighestSlotBlocksBelow func(context.Context, primitives.Slot) (primitives.Slot, [][32]byte, error)
 }
 
@@ -100,6 +102,17 @@ func (m slotList) Swap(i, j int) {
 
 var errFallThroughOverride = errors.New("override yielding control back to real HighestRootsBelowSlot")
 
+func (m *mockHistory) FinalizedBlockRootForSlot(_ context.Context, slot primitives.Slot) ([32]byte, error) {
+	if m.finalizedErr != nil {
+		return [32]byte{}, m.finalizedErr
+	}
+	r, ok := m.finalized[slot]
+	if !ok {
+		return [32]byte{}, db.ErrNotFound
+	}
+	return r, nil
+}
+
 func (m *mockHistory) HighestRootsBelowSlot(_ context.Context, slot primitives.Slot) (primitives.Slot, [][32]byte, error) {
 	if m.overrideHighestSlotBlocksBelow != nil {
 		s, r, err := m.overrideHighestSlotBlocksBelow(context.Background(), slot)
@@ -196,6 +196,10 @@ func ReplayProcessSlots(ctx context.Context, state state.BeaconState, slot primi
 
 	var err error
 	for state.Slot() < slot {
+		if ctx.Err() != nil {
+			tracing.AnnotateError(span, ctx.Err())
+			return nil, ctx.Err()
+		}
 		state, err = transition.ProcessSlot(ctx, state)
 		if err != nil {
 			return nil, errors.Wrap(err, "could not process slot")
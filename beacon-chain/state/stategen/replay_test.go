@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	consensusblocks "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
@@ -711,3 +712,12 @@ func TestLoadFinalizedBlocks(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 10, len(filteredBlocks))
 }
+
+func TestReplayProcessSlots_ContextCanceled(t *testing.T) {
+	beaconState, _ := util.DeterministicGenesisState(t, 32)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := ReplayProcessSlots(ctx, beaconState, params.BeaconConfig().SlotsPerEpoch)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, primitives.Slot(0), beaconState.Slot())
+}
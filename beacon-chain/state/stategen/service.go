@@ -18,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/resources"
 	"go.opencensus.io/trace"
 )
 
@@ -54,6 +55,7 @@ type State struct {
 	backfillStatus          *backfill.Status
 	migrationLock           *sync.Mutex
 	fc                      forkchoice.ForkChoicer
+	lowDisk                 resources.LowDiskChecker
 }
 
 // This tracks the config in the event of long non-finality,
@@ -84,6 +86,14 @@ func WithBackfillStatus(bfs *backfill.Status) StateGenOption {
 	}
 }
 
+// WithLowDiskChecker skips saving the states of archived points while the disk is low. The skipped states
+// are regenerated from the closest saved state when requested.
+func WithLowDiskChecker(c resources.LowDiskChecker) StateGenOption {
+	return func(sg *State) {
+		sg.lowDisk = c
+	}
+}
+
 // New returns a new state management object.
 func New(beaconDB db.NoHeadAccessDatabase, fc forkchoice.ForkChoicer, opts ...StateGenOption) *State {
 	s := &State{
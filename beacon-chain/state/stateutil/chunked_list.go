@@ -0,0 +1,417 @@
+package stateutil
+
+import (
+	"encoding/binary"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	"github.com/prysmaticlabs/prysm/v4/crypto/hash/htr"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+)
+
+// chunkDepth is the depth of the subtree covered by a single chunk of a ChunkedList.
+// Each chunk holds 2^chunkDepth packed 32 byte leaves, which is 1024 uint64 values
+// or 8192 byte values.
+const chunkDepth = 8
+
+const leavesPerChunk = 1 << chunkDepth
+
+// maxDirtyLeaves is the number of modified leaves of a chunk up to which only the branches of
+// these leaves are rehashed. Rehashing a branch takes chunkDepth hashes, while rehashing the whole
+// chunk takes leavesPerChunk-1 hashes.
+const maxDirtyLeaves = leavesPerChunk / chunkDepth
+
+// Packable is the set of basic SSZ types which can be stored in a ChunkedList.
+type Packable interface {
+	uint64 | byte
+}
+
+// ChunkedList is a copy-on-write list of packed basic values, such as balances or participation
+// flags. The list is split into fixed size chunks which carry their own Reference, so copying the
+// list only copies chunk pointers and updating a value only copies the chunk it lives in. Every
+// chunk caches the root of its subtree, which lets copies of a state share hashing work for the
+// parts of the list that did not change. Once hashed, a chunk also keeps the inner nodes of its
+// subtree, so that updating a few values of the chunk only rehashes the branches of their leaves.
+//
+// A ChunkedList is not safe for concurrent mutation; the owning state is expected to serialize
+// access. Chunks shared between lists are never written to.
+type ChunkedList[V Packable] struct {
+	chunks []*listChunk[V]
+	length int
+	// flat is the last view handed back with Restore. It mirrors the chunks, is only kept by
+	// lists which are modified in bulk, and is never shared between copies.
+	flat []V
+}
+
+type listChunk[V Packable] struct {
+	refs  *Reference
+	items []V
+
+	lock      sync.Mutex
+	root      [32]byte
+	rootValid bool
+	// layers holds the inner nodes of the subtree of the chunk, from the parents of the leaves up
+	// to the root. It is nil until the chunk is hashed.
+	layers [][][32]byte
+	// dirtyLeaves lists the leaves modified since the chunk was last hashed, unless rehashAll is
+	// set because too many leaves were modified to rehash them one branch at a time.
+	dirtyLeaves []int
+	rehashAll   bool
+}
+
+// NewChunkedList splits the provided values into chunks. The list takes ownership of the
+// values, which must not be modified by the caller afterwards.
+func NewChunkedList[V Packable](vals []V) *ChunkedList[V] {
+	l := &ChunkedList[V]{length: len(vals)}
+	size := chunkSize[V]()
+	l.chunks = make([]*listChunk[V], 0, (len(vals)+size-1)/size)
+	for i := 0; i < len(vals); i += size {
+		j := i + size
+		if j > len(vals) {
+			j = len(vals)
+		}
+		// Cap the chunk so that appending to it never writes into the next chunk.
+		l.chunks = append(l.chunks, &listChunk[V]{refs: NewRef(1), items: vals[i:j:j]})
+	}
+	return l
+}
+
+// Copy returns a list sharing all chunks with the receiver.
+func (l *ChunkedList[V]) Copy() *ChunkedList[V] {
+	if l == nil {
+		return nil
+	}
+	chunks := make([]*listChunk[V], len(l.chunks))
+	for i, c := range l.chunks {
+		c.refs.AddRef()
+		chunks[i] = c
+	}
+	return &ChunkedList[V]{chunks: chunks, length: l.length}
+}
+
+// Release gives up the references held by the list. It should be called once the list is no
+// longer used, so that the remaining owners of its chunks may modify them in place.
+func (l *ChunkedList[V]) Release() {
+	if l == nil {
+		return
+	}
+	for _, c := range l.chunks {
+		c.refs.MinusRef()
+	}
+}
+
+// Len returns the number of values in the list.
+func (l *ChunkedList[V]) Len() int {
+	if l == nil {
+		return 0
+	}
+	return l.length
+}
+
+// At returns the value at the given index. The index must be within bounds.
+func (l *ChunkedList[V]) At(idx int) V {
+	size := chunkSize[V]()
+	return l.chunks[idx/size].items[idx%size]
+}
+
+// Set updates the value at the given index, copying the chunk holding it if it is shared.
+func (l *ChunkedList[V]) Set(idx int, val V) error {
+	if idx < 0 || idx >= l.Len() {
+		return errors.Errorf("index %d out of range for list of length %d", idx, l.Len())
+	}
+	size := chunkSize[V]()
+	c := l.writableChunk(idx / size)
+	c.items[idx%size] = val
+	c.markDirty(idx % size)
+	if l.flat != nil {
+		l.flat[idx] = val
+	}
+	return nil
+}
+
+// Append adds a value to the end of the list.
+func (l *ChunkedList[V]) Append(val V) {
+	size := chunkSize[V]()
+	if l.length%size == 0 {
+		items := make([]V, 0, size)
+		l.chunks = append(l.chunks, &listChunk[V]{refs: NewRef(1), items: append(items, val)})
+		l.length++
+		l.flat = nil
+		return
+	}
+	c := l.writableChunk(len(l.chunks) - 1)
+	c.items = append(c.items, val)
+	c.markDirty(len(c.items) - 1)
+	l.length++
+	l.flat = nil
+}
+
+// Values returns a copy of all values in the list.
+func (l *ChunkedList[V]) Values() []V {
+	if l == nil {
+		return nil
+	}
+	vals := make([]V, 0, l.length)
+	for _, c := range l.chunks {
+		vals = append(vals, c.items...)
+	}
+	return vals
+}
+
+// View returns a contiguous copy of the list which the caller may modify and hand back with
+// Restore. The list keeps the last restored view, so repeated bulk updates of the same list do
+// not allocate. The list does not depend on the view until it is restored.
+func (l *ChunkedList[V]) View() []V {
+	if l.flat == nil {
+		return l.Values()
+	}
+	view := l.flat
+	l.flat = nil
+	return view
+}
+
+// Restore stores the contents of a view obtained from View, keeping the view for the next call
+// to View. Only the chunks holding changed values are copied.
+func (l *ChunkedList[V]) Restore(view []V) {
+	l.Replace(view)
+	l.flat = view
+}
+
+// Replace overwrites the contents of the list with the provided values. Chunks whose contents
+// did not change are kept, so they remain shared with other copies of the list, and only the
+// chunks holding changed values are copied.
+func (l *ChunkedList[V]) Replace(vals []V) {
+	size := chunkSize[V]()
+	chunks := make([]*listChunk[V], 0, (len(vals)+size-1)/size)
+	for i := 0; i < len(vals); i += size {
+		j := i + size
+		if j > len(vals) {
+			j = len(vals)
+		}
+		k := i / size
+		if k < len(l.chunks) && len(l.chunks[k].items) == j-i {
+			if !equalItems(l.chunks[k].items, vals[i:j]) {
+				c := l.writableChunk(k)
+				for n, v := range vals[i:j] {
+					if c.items[n] != v {
+						c.items[n] = v
+						c.markDirty(n)
+					}
+				}
+			}
+			chunks = append(chunks, l.chunks[k])
+			continue
+		}
+		if k < len(l.chunks) {
+			l.chunks[k].refs.MinusRef()
+		}
+		// New chunks get their own backing array, so that they do not keep all of vals alive.
+		items := make([]V, j-i, size)
+		copy(items, vals[i:j])
+		chunks = append(chunks, &listChunk[V]{refs: NewRef(1), items: items})
+	}
+	for k := len(chunks); k < len(l.chunks); k++ {
+		l.chunks[k].refs.MinusRef()
+	}
+	l.chunks = chunks
+	l.length = len(vals)
+	l.flat = nil
+}
+
+// HashTreeRoot computes the SSZ root of the list, given the limit of the list expressed in
+// 32 byte leaves. Only chunks modified since their root was last computed are rehashed. A nil
+// list hashes as an empty list.
+func (l *ChunkedList[V]) HashTreeRoot(limit uint64) ([32]byte, error) {
+	if uint64(l.Len()) > limit*uint64(32/elementSize[V]()) {
+		return [32]byte{}, errors.New("merkleizing list that is too large, over limit")
+	}
+	depth := ssz.Depth(limit)
+	if depth < chunkDepth {
+		return [32]byte{}, errors.Errorf("list limit %d is smaller than a single chunk", limit)
+	}
+	var roots [][32]byte
+	if l != nil {
+		roots = make([][32]byte, len(l.chunks))
+		for i, c := range l.chunks {
+			roots[i] = c.hashTreeRoot()
+		}
+	}
+	var root [32]byte
+	if len(roots) == 0 {
+		root = trie.ZeroHashes[depth]
+	} else {
+		for i := uint8(chunkDepth); i < depth; i++ {
+			if len(roots)%2 == 1 {
+				roots = append(roots, trie.ZeroHashes[i])
+			}
+			roots = htr.VectorizedSha256(roots)
+		}
+		root = roots[0]
+	}
+	lengthRoot := make([]byte, 32)
+	binary.LittleEndian.PutUint64(lengthRoot[:8], uint64(l.Len()))
+	return ssz.MixInLength(root, lengthRoot), nil
+}
+
+// writableChunk returns the chunk at the given index, replacing it with a private copy first
+// if it is shared with another list.
+func (l *ChunkedList[V]) writableChunk(k int) *listChunk[V] {
+	c := l.chunks[k]
+	if c.refs.Refs() <= 1 {
+		return c
+	}
+	items := make([]V, len(c.items), chunkSize[V]())
+	copy(items, c.items)
+	cp := &listChunk[V]{refs: NewRef(1), items: items}
+	// The hashes of the chunk are copied along, as copying them is cheaper than rehashing the
+	// chunk. The lock guards against the chunk being hashed by another owner at the same time.
+	c.lock.Lock()
+	cp.root, cp.rootValid, cp.rehashAll = c.root, c.rootValid, c.rehashAll
+	if c.layers != nil {
+		cp.layers = make([][][32]byte, len(c.layers))
+		for i := range c.layers {
+			cp.layers[i] = append([][32]byte{}, c.layers[i]...)
+		}
+	}
+	cp.dirtyLeaves = append([]int{}, c.dirtyLeaves...)
+	c.lock.Unlock()
+	c.refs.MinusRef()
+	l.chunks[k] = cp
+	return cp
+}
+
+// markDirty records that the item at the given index of the chunk was modified.
+func (c *listChunk[V]) markDirty(item int) {
+	c.rootValid = false
+	if c.layers == nil || c.rehashAll {
+		return
+	}
+	if len(c.dirtyLeaves) >= maxDirtyLeaves {
+		c.rehashAll = true
+		c.dirtyLeaves = nil
+		return
+	}
+	c.dirtyLeaves = append(c.dirtyLeaves, item*elementSize[V]()/32)
+}
+
+func (c *listChunk[V]) hashTreeRoot() [32]byte {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.rootValid {
+		return c.root
+	}
+	if c.layers == nil || c.rehashAll {
+		c.rehash()
+	} else {
+		c.rehashLeaves()
+	}
+	c.root = c.layers[chunkDepth-1][0]
+	c.rootValid = true
+	c.dirtyLeaves = nil
+	c.rehashAll = false
+	return c.root
+}
+
+// rehash computes all the inner nodes of the subtree of the chunk.
+func (c *listChunk[V]) rehash() {
+	c.layers = make([][][32]byte, chunkDepth)
+	layer := packChunk(c.items)
+	for i := range c.layers {
+		layer = htr.VectorizedSha256(layer)
+		c.layers[i] = layer
+	}
+}
+
+// rehashLeaves recomputes the inner nodes of the branches of the dirty leaves of the chunk.
+func (c *listChunk[V]) rehashLeaves() {
+	nodes := c.dirtyLeaves
+	sort.Ints(nodes)
+	for depth := 0; depth < chunkDepth; depth++ {
+		parents := make([]int, 0, len(nodes))
+		children := make([][32]byte, 0, 2*len(nodes))
+		for _, n := range nodes {
+			p := n / 2
+			if len(parents) > 0 && parents[len(parents)-1] == p {
+				continue
+			}
+			parents = append(parents, p)
+			if depth == 0 {
+				children = append(children, packLeaf(c.items, 2*p), packLeaf(c.items, 2*p+1))
+			} else {
+				children = append(children, c.layers[depth-1][2*p], c.layers[depth-1][2*p+1])
+			}
+		}
+		for i, root := range htr.VectorizedSha256(children) {
+			c.layers[depth][parents[i]] = root
+		}
+		nodes = parents
+	}
+}
+
+// packChunk packs the items of a chunk into its leavesPerChunk leaves, padded with zero leaves.
+func packChunk[V Packable](items []V) [][32]byte {
+	size := elementSize[V]()
+	perLeaf := 32 / size
+	leaves := make([][32]byte, leavesPerChunk)
+	switch vals := any(items).(type) {
+	case []uint64:
+		for i, v := range vals {
+			binary.LittleEndian.PutUint64(leaves[i/perLeaf][(i%perLeaf)*size:], v)
+		}
+	case []byte:
+		for i := 0; i*perLeaf < len(vals); i++ {
+			copy(leaves[i][:], vals[i*perLeaf:])
+		}
+	}
+	return leaves
+}
+
+// packLeaf packs the items of the leaf at the given index of a chunk.
+func packLeaf[V Packable](items []V, leaf int) [32]byte {
+	var out [32]byte
+	size := elementSize[V]()
+	perLeaf := 32 / size
+	start := leaf * perLeaf
+	if start >= len(items) {
+		return out
+	}
+	end := start + perLeaf
+	if end > len(items) {
+		end = len(items)
+	}
+	switch vals := any(items[start:end]).(type) {
+	case []uint64:
+		for i, v := range vals {
+			binary.LittleEndian.PutUint64(out[i*size:], v)
+		}
+	case []byte:
+		copy(out[:], vals)
+	}
+	return out
+}
+
+func equalItems[V Packable](a, b []V) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func elementSize[V Packable]() int {
+	var v V
+	if _, ok := any(v).(uint64); ok {
+		return 8
+	}
+	return 1
+}
+
+func chunkSize[V Packable]() int {
+	return leavesPerChunk * 32 / elementSize[V]()
+}
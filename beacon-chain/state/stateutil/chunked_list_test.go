@@ -0,0 +1,177 @@
+package stateutil
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestChunkedList_HashTreeRoot(t *testing.T) {
+	participationLimit := ValidatorLimitForParticipationChunks()
+	for _, n := range []int{0, 1, 31, 33, 1024, 1025, 8192, 20000} {
+		vals := make([]uint64, n)
+		bits := make([]byte, n)
+		for i := range vals {
+			vals[i] = uint64(i) * 3
+			bits[i] = byte(i % 7)
+		}
+
+		want, err := Uint64ListRootWithRegistryLimit(vals)
+		require.NoError(t, err)
+		got, err := NewChunkedList(append([]uint64{}, vals...)).HashTreeRoot(ValidatorLimitForBalancesChunks())
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "uint64 list of length %d", n)
+
+		want, err = ParticipationBitsRoot(bits)
+		require.NoError(t, err)
+		got, err = NewChunkedList(append([]byte{}, bits...)).HashTreeRoot(participationLimit)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "byte list of length %d", n)
+	}
+}
+
+func TestChunkedList_CopyOnWrite(t *testing.T) {
+	vals := make([]uint64, 3000)
+	for i := range vals {
+		vals[i] = uint64(i)
+	}
+	a := NewChunkedList(vals)
+	b := a.Copy()
+	for i := range a.chunks {
+		assert.Equal(t, a.chunks[i], b.chunks[i])
+		assert.Equal(t, uint(2), a.chunks[i].refs.Refs())
+	}
+
+	require.NoError(t, b.Set(1500, 42))
+	assert.Equal(t, uint64(1500), a.At(1500))
+	assert.Equal(t, uint64(42), b.At(1500))
+	// Only the chunk holding the updated value is copied.
+	assert.Equal(t, a.chunks[0], b.chunks[0])
+	assert.NotEqual(t, a.chunks[1], b.chunks[1])
+	assert.Equal(t, a.chunks[2], b.chunks[2])
+	assert.Equal(t, uint(1), a.chunks[1].refs.Refs())
+
+	b.Append(7)
+	assert.Equal(t, 3000, a.Len())
+	assert.Equal(t, 3001, b.Len())
+	assert.Equal(t, uint64(7), b.At(3000))
+	assert.NotEqual(t, a.chunks[2], b.chunks[2])
+
+	b.Release()
+	for _, c := range a.chunks {
+		assert.Equal(t, uint(1), c.refs.Refs())
+	}
+	require.ErrorContains(t, "out of range", a.Set(3000, 1))
+}
+
+func TestChunkedList_Replace(t *testing.T) {
+	vals := make([]byte, 3*8192)
+	a := NewChunkedList(vals)
+	b := a.Copy()
+
+	updated := b.Values()
+	updated[8192] = 1
+	updated = append(updated, 2)
+	b.Replace(updated)
+	assert.Equal(t, a.chunks[0], b.chunks[0])
+	assert.NotEqual(t, a.chunks[1], b.chunks[1])
+	assert.Equal(t, a.chunks[2], b.chunks[2])
+	assert.Equal(t, 4, len(b.chunks))
+	assert.DeepEqual(t, updated, b.Values())
+	assert.Equal(t, byte(0), a.At(8192))
+
+	b.Replace(nil)
+	assert.Equal(t, 0, b.Len())
+	for _, c := range a.chunks {
+		assert.Equal(t, uint(1), c.refs.Refs())
+	}
+}
+
+func TestChunkedList_ViewRestore(t *testing.T) {
+	a := NewChunkedList(make([]byte, 3*8192))
+	b := a.Copy()
+
+	view := b.View()
+	view[100] = 1
+	b.Restore(view)
+	assert.NotEqual(t, a.chunks[0], b.chunks[0])
+	assert.Equal(t, a.chunks[1], b.chunks[1])
+	assert.Equal(t, byte(0), a.At(100))
+	assert.Equal(t, byte(1), b.At(100))
+
+	// The restored view is reused and kept in sync with single value updates.
+	require.NoError(t, b.Set(9000, 2))
+	view2 := b.View()
+	assert.Equal(t, &view[0], &view2[0])
+	assert.Equal(t, byte(2), view2[9000])
+	// A view which is not restored leaves the list untouched.
+	view2[200] = 3
+	assert.Equal(t, byte(0), b.At(200))
+	assert.NotEqual(t, &view[0], &b.View()[0])
+}
+
+func TestChunkedList_HashTreeRoot_Incremental(t *testing.T) {
+	vals := make([]uint64, 5000)
+	for i := range vals {
+		vals[i] = uint64(i)
+	}
+	a := NewChunkedList(append([]uint64{}, vals...))
+	_, err := a.HashTreeRoot(ValidatorLimitForBalancesChunks())
+	require.NoError(t, err)
+	b := a.Copy()
+
+	// Few updates only rehash the branches of their leaves, many updates rehash the whole chunk.
+	for _, updates := range []int{1, 3, maxDirtyLeaves + 1, 1024} {
+		for i := 0; i < updates; i++ {
+			idx := (i * 37) % len(vals)
+			vals[idx] += uint64(updates)
+			require.NoError(t, b.Set(idx, vals[idx]))
+		}
+		vals = append(vals, uint64(updates))
+		b.Append(uint64(updates))
+
+		want, err := Uint64ListRootWithRegistryLimit(vals)
+		require.NoError(t, err)
+		got, err := b.HashTreeRoot(ValidatorLimitForBalancesChunks())
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "%d updates", updates)
+	}
+	for _, c := range b.chunks {
+		assert.Equal(t, 0, len(c.dirtyLeaves))
+	}
+
+	// The list the copy was made from is not affected by the updates of the copy.
+	want, err := Uint64ListRootWithRegistryLimit(a.Values())
+	require.NoError(t, err)
+	got, err := a.HashTreeRoot(ValidatorLimitForBalancesChunks())
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func BenchmarkChunkedList_HashTreeRoot(b *testing.B) {
+	// The balances of 500,000 validators, of which a block updates a few hundred.
+	vals := make([]uint64, 500000)
+	for i := range vals {
+		vals[i] = 32_000_000_000 + uint64(i)
+	}
+	l := NewChunkedList(vals)
+	_, err := l.HashTreeRoot(ValidatorLimitForBalancesChunks())
+	require.NoError(b, err)
+
+	for _, updates := range []int{512, 500000} {
+		b.Run(fmt.Sprintf("%d updates", updates), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				for j := 0; j < updates; j++ {
+					idx := (i*7919 + j*104729) % len(vals)
+					require.NoError(b, l.Set(idx, l.At(idx)+1))
+				}
+				b.StartTimer()
+				_, err := l.HashTreeRoot(ValidatorLimitForBalancesChunks())
+				require.NoError(b, err)
+			}
+		})
+	}
+}
@@ -16,9 +16,7 @@ func ParticipationBitsRoot(bits []byte) ([32]byte, error) {
 		return [32]byte{}, err
 	}
 
-	limit := (uint64(fieldparams.ValidatorRegistryLimit + 31)) / 32
-
-	bytesRoot, err := ssz.BitwiseMerkleize(chunkedRoots, uint64(len(chunkedRoots)), limit)
+	bytesRoot, err := ssz.BitwiseMerkleize(chunkedRoots, uint64(len(chunkedRoots)), ValidatorLimitForParticipationChunks())
 	if err != nil {
 		return [32]byte{}, errors.Wrap(err, "could not compute merkleization")
 	}
@@ -28,6 +26,12 @@ func ParticipationBitsRoot(bits []byte) ([32]byte, error) {
 	return ssz.MixInLength(bytesRoot, bytesRootBufRoot), nil
 }
 
+// ValidatorLimitForParticipationChunks returns the limit of participation bits after going through
+// the chunking process.
+func ValidatorLimitForParticipationChunks() uint64 {
+	return (uint64(fieldparams.ValidatorRegistryLimit) + 31) / 32
+}
+
 // packParticipationBits into chunks. It'll pad the last chunk with zero bytes if
 // it does not have length bytes per chunk.
 func packParticipationBits(bytes []byte) ([][32]byte, error) {
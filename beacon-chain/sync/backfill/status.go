@@ -5,6 +5,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/progress"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
@@ -27,6 +28,20 @@ type Status struct {
 	end         primitives.Slot
 	store       BackfillDB
 	genesisSync bool
+	progress    *progress.Tracker
+}
+
+// TrackProgress reports the backfill progress, as the number of slots backfilled below the origin
+// checkpoint, to the given tracker. It must be called after Reload.
+func (s *Status) TrackProgress(t *progress.Tracker) {
+	s.progress = t
+	if s.genesisSync {
+		return
+	}
+	s.progress.Start(progress.BlockBackfill, uint64(s.end-s.start), uint64(s.end))
+	if s.start == 0 {
+		s.progress.Complete(progress.BlockBackfill)
+	}
 }
 
 // SlotCovered uses StartGap() and EndGap() to determine if the given slot is covered by the current chain history.
@@ -63,6 +78,10 @@ func (s *Status) Advance(ctx context.Context, upTo primitives.Slot, root [32]byt
 		return errors.Wrapf(ErrAdvancePastOrigin, "advance slot=%d, origin slot=%d", upTo, s.end)
 	}
 	s.start = upTo
+	s.progress.Update(progress.BlockBackfill, uint64(s.end-upTo))
+	if upTo == 0 {
+		s.progress.Complete(progress.BlockBackfill)
+	}
 	return s.store.SaveBackfillBlockRoot(ctx, root)
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/progress"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
@@ -136,6 +137,33 @@ func TestAdvance(t *testing.T) {
 	require.Equal(t, 1, len(saveBackfillBuf))
 }
 
+func TestTrackProgress(t *testing.T) {
+	ctx := context.Background()
+	mdb := &mockBackfillDB{
+		saveBackfillBlockRoot: func(ctx context.Context, root [32]byte) error {
+			return nil
+		},
+	}
+	s := &Status{start: 100, end: 100, store: mdb}
+	tracker := progress.NewTracker()
+	s.TrackProgress(tracker)
+	st := tracker.Status(progress.BlockBackfill)
+	require.NotNil(t, st)
+	require.Equal(t, uint64(0), st.Current)
+	require.Equal(t, uint64(100), st.Target)
+
+	require.NoError(t, s.Advance(ctx, 40, [32]byte{}))
+	require.Equal(t, uint64(60), tracker.Status(progress.BlockBackfill).Current)
+	require.NoError(t, s.Advance(ctx, 0, [32]byte{}))
+	require.Equal(t, true, tracker.Status(progress.BlockBackfill).Complete)
+
+	// Nodes synced from genesis have nothing to backfill.
+	s = &Status{genesisSync: true}
+	tracker = progress.NewTracker()
+	s.TrackProgress(tracker)
+	require.Equal(t, 0, len(tracker.Statuses()))
+}
+
 func goodBlockRoot(root [32]byte) func(ctx context.Context) ([32]byte, error) {
 	return func(ctx context.Context) ([32]byte, error) {
 		return root, nil
@@ -2,6 +2,7 @@ package sync
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -15,6 +16,8 @@ const signatureVerificationInterval = 50 * time.Millisecond
 
 const verifierLimit = 50
 
+var errVerifierQueueFull = errors.New("signature verification queue is full")
+
 type signatureVerifier struct {
 	set     *bls.SignatureBatch
 	resChan chan error
@@ -30,6 +33,9 @@ func (s *Service) verifierRoutine() {
 		case <-s.ctx.Done():
 			// Clean up currently utilised resources.
 			ticker.Stop()
+			if s.attVerifierQueue != nil {
+				verifierBatch = append(verifierBatch, s.attVerifierQueue.pop(s.attVerifierQueue.len())...)
+			}
 			for i := 0; i < len(verifierBatch); i++ {
 				verifierBatch[i].resChan <- s.ctx.Err()
 			}
@@ -40,7 +46,12 @@ func (s *Service) verifierRoutine() {
 				verifyBatch(verifierBatch)
 				verifierBatch = []*signatureVerifier{}
 			}
+		case <-s.attVerifierQueue.notifyChan():
+			verifierBatch = s.verifyQueuedAttestations(verifierBatch)
 		case <-ticker.C:
+			if s.attVerifierQueue != nil {
+				verifierBatch = s.verifyQueuedAttestations(verifierBatch)
+			}
 			if len(verifierBatch) > 0 {
 				verifyBatch(verifierBatch)
 				verifierBatch = []*signatureVerifier{}
@@ -49,13 +60,60 @@ func (s *Service) verifierRoutine() {
 	}
 }
 
+// verifyQueuedAttestations adds the queued attestation signature sets to the batch, verifying
+// every batch which reaches the limit. It returns the remaining partial batch.
+func (s *Service) verifyQueuedAttestations(verifierBatch []*signatureVerifier) []*signatureVerifier {
+	for {
+		verifierBatch = append(verifierBatch, s.attVerifierQueue.pop(verifierLimit-len(verifierBatch))...)
+		if len(verifierBatch) < verifierLimit {
+			return verifierBatch
+		}
+		verifyBatch(verifierBatch)
+		verifierBatch = []*signatureVerifier{}
+	}
+}
+
 func (s *Service) validateWithBatchVerifier(ctx context.Context, message string, set *bls.SignatureBatch) (pubsub.ValidationResult, error) {
-	ctx, span := trace.StartSpan(ctx, "sync.validateWithBatchVerifier")
+	_, span := trace.StartSpan(ctx, "sync.validateWithBatchVerifier")
 	defer span.End()
 
+	return s.verifyWithBatchVerifier(span, message, set, func(v *signatureVerifier) bool {
+		s.signatureChan <- v
+		return true
+	})
+}
+
+// validateAttestationWithBatchVerifier verifies the signature set of an unaggregated attestation
+// received on the given subnet. The set waits for verification in the queue of its subnet, which
+// prevents a flood of attestations on other subnets from delaying it.
+func (s *Service) validateAttestationWithBatchVerifier(
+	ctx context.Context, subnet uint64, aggregator bool, set *bls.SignatureBatch,
+) (pubsub.ValidationResult, error) {
+	if s.attVerifierQueue == nil {
+		return s.validateWithBatchVerifier(ctx, "attestation", set)
+	}
+	_, span := trace.StartSpan(ctx, "sync.validateAttestationWithBatchVerifier")
+	defer span.End()
+
+	res, err := s.verifyWithBatchVerifier(span, "attestation", set, func(v *signatureVerifier) bool {
+		return s.attVerifierQueue.push(subnet, aggregator, v)
+	})
+	if errors.Is(err, errVerifierQueueFull) {
+		subnetVerifierQueueFullCounter.WithLabelValues(strconv.FormatUint(subnet, 10)).Inc()
+	}
+	return res, err
+}
+
+// verifyWithBatchVerifier hands the signature set to the batch verifier with submit, and waits for
+// the verification result. Sets which can not be submitted are ignored.
+func (s *Service) verifyWithBatchVerifier(
+	span *trace.Span, message string, set *bls.SignatureBatch, submit func(*signatureVerifier) bool,
+) (pubsub.ValidationResult, error) {
 	resChan := make(chan error)
 	verificationSet := &signatureVerifier{set: set.Copy(), resChan: resChan}
-	s.signatureChan <- verificationSet
+	if !submit(verificationSet) {
+		return pubsub.ValidationIgnore, errors.Wrapf(errVerifierQueueFull, "could not queue %s", message)
+	}
 
 	resErr := <-resChan
 	close(resChan)
@@ -12,16 +12,19 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/era"
 )
 
 // blockRangeBatcher encapsulates the logic for splitting up a block range request into fixed-size batches of
 // blocks that are retrieved from the database, ensured to be canonical, sequential and unique.
 // If a non-nil value for ticker is set, it will be used to pause between batches lookups, as a rate-limiter.
+// If a non-nil era store is set, batches of blocks missing from the database are read from era files.
 type blockRangeBatcher struct {
 	start   primitives.Slot
 	end     primitives.Slot
 	size    uint64
 	db      db.NoHeadAccessDatabase
+	era     *era.Store
 	limiter *limiter
 	ticker  *time.Ticker
 
@@ -91,6 +94,18 @@ func (bb *blockRangeBatcher) next(ctx context.Context, stream libp2pcore.Stream)
 	if err != nil {
 		return blockBatch{err: errors.Wrap(err, "Could not retrieve blocks")}, false
 	}
+	if len(blks) == 0 && bb.era != nil && bb.era.HasBlock(nb.start) && bb.era.HasBlock(nb.end) {
+		// Blocks that are not in the db, because they precede the backfill origin or have been pruned,
+		// are served from era files, which only hold finalized canonical blocks.
+		rob, err := bb.eraBlocks(nb.start, nb.end)
+		if err != nil {
+			return blockBatch{err: errors.Wrap(err, "could not retrieve blocks from era files")}, false
+		}
+		nb.lin, nb.nonlin = bb.cf.linear(rob)
+		bb.limiter.add(stream, int64(1+nb.end.SubSlot(nb.start)))
+		bb.current = &nb
+		return *bb.current, true
+	}
 
 	rob := make([]blocks.ROBlock, 0)
 	if nb.start == 0 {
@@ -117,6 +132,22 @@ func (bb *blockRangeBatcher) next(ctx context.Context, stream libp2pcore.Stream)
 	return *bb.current, true
 }
 
+func (bb *blockRangeBatcher) eraBlocks(start, end primitives.Slot) ([]blocks.ROBlock, error) {
+	blks, err := bb.era.Blocks(start, end)
+	if err != nil {
+		return nil, err
+	}
+	rob := make([]blocks.ROBlock, 0, len(blks))
+	for _, b := range blks {
+		rb, err := blocks.NewROBlock(b)
+		if err != nil {
+			return nil, err
+		}
+		rob = append(rob, rb)
+	}
+	return rob, nil
+}
+
 func (bb *blockRangeBatcher) genesisBlock(ctx context.Context) (blocks.ROBlock, error) {
 	b, err := bb.db.GenesisBlock(ctx)
 	if err != nil {
@@ -196,24 +227,45 @@ func (cf *canonicalFilter) filter(ctx context.Context, blks []blocks.ROBlock) ([
 		if !cb {
 			continue
 		}
-		// prevRoot will be the zero value until we find the first canonical block in the stream seen by an instance
-		// of canonicalFilter. filter is called in batches; prevRoot can be the last root from the previous batch.
-		first := cf.prevRoot == [32]byte{}
-		// We assume blocks are processed in order, so the previous canonical root should be the parent of the next.
-		if !first && cf.prevRoot != b.Block().ParentRoot() {
+		if !cf.extends(b) {
 			// If the current block isn't descended from the last, something is wrong. Append everything remaining
 			// to the list of non-linear blocks, and stop building the canonical list.
 			nseq = append(nseq, blks[i:]...)
 			break
 		}
 		seq = append(seq, blks[i])
-		// Set the previous root as the
-		// newly added block's root
-		cf.prevRoot = b.Root()
 	}
 	return seq, nseq, nil
 }
 
+// linear splits blocks that are known to be canonical, such as the ones read from era files,
+// into their linear prefix and non-linear tail.
+func (cf *canonicalFilter) linear(blks []blocks.ROBlock) ([]blocks.ROBlock, []blocks.ROBlock) {
+	blks = sortedUniqueBlocks(blks)
+	for i, b := range blks {
+		if !cf.extends(b) {
+			return blks[:i], blks[i:]
+		}
+	}
+	return blks, nil
+}
+
+// extends checks that the block descends from the previous canonical block and, if so, records it as the
+// new previous canonical block.
+func (cf *canonicalFilter) extends(b blocks.ROBlock) bool {
+	// prevRoot will be the zero value until we find the first canonical block in the stream seen by an instance
+	// of canonicalFilter. filter is called in batches; prevRoot can be the last root from the previous batch.
+	first := cf.prevRoot == [32]byte{}
+	// We assume blocks are processed in order, so the previous canonical root should be the parent of the next.
+	if !first && cf.prevRoot != b.Block().ParentRoot() {
+		return false
+	}
+	// Set the previous root as the
+	// newly added block's root
+	cf.prevRoot = b.Root()
+	return true
+}
+
 // returns a copy of the []ROBlock list in sorted order with duplicates removed
 func sortedUniqueBlocks(blks []blocks.ROBlock) []blocks.ROBlock {
 	// Remove duplicate blocks received
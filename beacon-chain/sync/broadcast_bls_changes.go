@@ -27,6 +27,7 @@ func (s *Service) broadcastBLSChanges(currSlot types.Slot) {
 	changes, err := s.cfg.blsToExecPool.PendingBLSToExecChanges()
 	if err != nil {
 		log.WithError(err).Error("could not get BLS to execution changes")
+		return
 	}
 	if len(changes) == 0 {
 		return
@@ -74,6 +75,7 @@ func (s *Service) rateBLSChanges(ctx context.Context, changes []*ethpb.SignedBLS
 		return
 	}
 	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-s.ctx.Done():
@@ -2,28 +2,72 @@ package checkpoint
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	log "github.com/sirupsen/logrus"
 )
 
+var errStateRootMismatch = errors.New("checkpoint sync state root mismatch")
+
 // APIInitializer manages initializing the beacon node using checkpoint sync, retrieving the checkpoint state and root
 // from the remote beacon node api.
 type APIInitializer struct {
-	c *beacon.Client
+	c             *beacon.Client
+	verifiers     []*beacon.Client
+	expectedRoot  [32]byte
+	checkExpected bool
+
+	lightClientHosts       []*beacon.Client
+	lightClientTrustedRoot [32]byte
+}
+
+// APIInitializerOption is a functional option to configure the verification done by an APIInitializer.
+type APIInitializerOption func(*APIInitializer) error
+
+// WithVerificationHosts configures additional beacon node api hosts which are asked for the root of the
+// downloaded checkpoint state. The checkpoint is only used if every host agrees on the state root.
+func WithVerificationHosts(hosts ...string) APIInitializerOption {
+	return func(dl *APIInitializer) error {
+		for _, h := range hosts {
+			c, err := beacon.NewClient(h)
+			if err != nil {
+				return errors.Wrapf(err, "unable to parse beacon node url or hostname - %s", h)
+			}
+			dl.verifiers = append(dl.verifiers, c)
+		}
+		return nil
+	}
+}
+
+// WithExpectedStateRoot configures a state root, obtained out of band, which the downloaded checkpoint state
+// must match.
+func WithExpectedStateRoot(root [32]byte) APIInitializerOption {
+	return func(dl *APIInitializer) error {
+		dl.expectedRoot = root
+		dl.checkExpected = true
+		return nil
+	}
 }
 
 // NewAPIInitializer creates an APIInitializer, handling the set up of a beacon node api client
 // using the provided host string.
-func NewAPIInitializer(beaconNodeHost string) (*APIInitializer, error) {
+func NewAPIInitializer(beaconNodeHost string, opts ...APIInitializerOption) (*APIInitializer, error) {
 	c, err := beacon.NewClient(beaconNodeHost)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to parse beacon node url or hostname - %s", beaconNodeHost)
 	}
-	return &APIInitializer{c: c}, nil
+	dl := &APIInitializer{c: c}
+	for _, o := range opts {
+		if err := o(dl); err != nil {
+			return nil, err
+		}
+	}
+	return dl, nil
 }
 
 // Initialize downloads origin state and block for checkpoint sync and initializes database records to
@@ -42,5 +86,35 @@ func (dl *APIInitializer) Initialize(ctx context.Context, d db.Database) error {
 	if err != nil {
 		return errors.Wrap(err, "Error retrieving checkpoint origin state and block")
 	}
+	if err := dl.verify(ctx, od.StateRoot(), od.StateSlot()); err != nil {
+		return err
+	}
+	if err := dl.verifyWithLightClients(ctx, od); err != nil {
+		return err
+	}
 	return d.SaveOrigin(ctx, od.StateBytes(), od.BlockBytes())
 }
+
+// verify checks the root of the downloaded state against the expected root and the roots reported by the
+// verification hosts. The hosts are asked for the state at the slot of the downloaded state, rather than for
+// their finalized state, so that finality advancing between requests does not cause spurious mismatches.
+func (dl *APIInitializer) verify(ctx context.Context, sr [32]byte, slot primitives.Slot) error {
+	if dl.checkExpected && sr != dl.expectedRoot {
+		return errors.Wrapf(errStateRootMismatch, "downloaded state root %#x, expected %#x", sr, dl.expectedRoot)
+	}
+	for _, v := range dl.verifiers {
+		r, err := v.GetStateRoot(ctx, beacon.IdFromSlot(slot))
+		if err != nil {
+			return errors.Wrapf(err, "could not verify checkpoint state root with %s", v.NodeURL())
+		}
+		if r != sr {
+			return errors.Wrapf(errStateRootMismatch, "downloaded state root %#x at slot %d, %s reported %#x", sr, slot, v.NodeURL(), r)
+		}
+	}
+	if dl.checkExpected || len(dl.verifiers) > 0 {
+		log.WithField("stateRoot", fmt.Sprintf("%#x", sr)).
+			WithField("verificationHosts", len(dl.verifiers)).
+			Info("Verified checkpoint sync state root")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func stateRootServer(t *testing.T, slot primitives.Slot, root [32]byte) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != fmt.Sprintf("/eth/v1/beacon/states/%d/root", slot) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := fmt.Fprintf(w, `{"data":{"root":"%#x"}}`, root)
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestAPIInitializer_Verify(t *testing.T) {
+	ctx := context.Background()
+	slot := primitives.Slot(64)
+	root := bytesutil.ToBytes32([]byte("root"))
+	other := bytesutil.ToBytes32([]byte("other"))
+	good := stateRootServer(t, slot, root)
+	bad := stateRootServer(t, slot, other)
+
+	t.Run("no verification", func(t *testing.T) {
+		dl, err := NewAPIInitializer("http://localhost:3500")
+		require.NoError(t, err)
+		require.NoError(t, dl.verify(ctx, root, slot))
+	})
+	t.Run("expected root matches", func(t *testing.T) {
+		dl, err := NewAPIInitializer("http://localhost:3500", WithExpectedStateRoot(root))
+		require.NoError(t, err)
+		require.NoError(t, dl.verify(ctx, root, slot))
+	})
+	t.Run("expected root mismatch", func(t *testing.T) {
+		dl, err := NewAPIInitializer("http://localhost:3500", WithExpectedStateRoot(other))
+		require.NoError(t, err)
+		require.ErrorIs(t, dl.verify(ctx, root, slot), errStateRootMismatch)
+	})
+	t.Run("verification hosts agree", func(t *testing.T) {
+		dl, err := NewAPIInitializer("http://localhost:3500", WithVerificationHosts(good.URL, good.URL))
+		require.NoError(t, err)
+		require.NoError(t, dl.verify(ctx, root, slot))
+	})
+	t.Run("verification host mismatch", func(t *testing.T) {
+		dl, err := NewAPIInitializer("http://localhost:3500", WithVerificationHosts(good.URL, bad.URL))
+		require.NoError(t, err)
+		require.ErrorIs(t, dl.verify(ctx, root, slot), errStateRootMismatch)
+	})
+	t.Run("verification host unavailable", func(t *testing.T) {
+		dl, err := NewAPIInitializer("http://localhost:3500", WithVerificationHosts(good.URL))
+		require.NoError(t, err)
+		require.ErrorContains(t, "could not verify checkpoint state root", dl.verify(ctx, root, slot+1))
+	})
+}
@@ -0,0 +1,135 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz/detect"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxRequestLightClientUpdates is MAX_REQUEST_LIGHT_CLIENT_UPDATES, the maximum number of updates served
+// in a single request.
+const maxRequestLightClientUpdates = 128
+
+var errLightClientMismatch = errors.New("light client finalized block does not descend from the checkpoint sync block")
+
+// WithLightClientVerification configures hosts against which a light client sync is run, starting from the
+// block with the given trusted root. The checkpoint is only used if every light client sync arrives at the
+// downloaded checkpoint block as the latest finalized block. Unlike WithVerificationHosts, the hosts are not
+// trusted to report the right root: every header is proven by the signature of the sync committee.
+func WithLightClientVerification(trustedRoot [32]byte, hosts ...string) APIInitializerOption {
+	return func(dl *APIInitializer) error {
+		for _, h := range hosts {
+			c, err := beacon.NewClient(h)
+			if err != nil {
+				return errors.Wrapf(err, "unable to parse beacon node url or hostname - %s", h)
+			}
+			dl.lightClientHosts = append(dl.lightClientHosts, c)
+		}
+		dl.lightClientTrustedRoot = trustedRoot
+		return nil
+	}
+}
+
+// verifyWithLightClients runs a light client sync against every light client host, and checks the finalized
+// header each of them arrives at is the downloaded checkpoint block or one of its descendants, as hosts may have
+// finalized a later checkpoint. The genesis validators root is taken from the downloaded state: a wrong one would
+// fail the verification of the sync committee signatures.
+func (dl *APIInitializer) verifyWithLightClients(ctx context.Context, od *beacon.OriginData) error {
+	br := od.BlockRoot()
+	for _, c := range dl.lightClientHosts {
+		header, err := lightClientFinalizedHeader(ctx, c, dl.lightClientTrustedRoot, od.GenesisValidatorsRoot())
+		if err != nil {
+			return errors.Wrapf(err, "could not run light client sync with %s", c.NodeURL())
+		}
+		r, err := header.Beacon.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not compute light client finalized header root")
+		}
+		if err := verifyDescendant(ctx, c, r, br, od.BlockSlot()); err != nil {
+			return errors.Wrapf(err, "checkpoint block root %#x, %s light client finalized block root %#x at slot %d",
+				br, c.NodeURL(), r, header.Beacon.Slot)
+		}
+	}
+	if len(dl.lightClientHosts) > 0 {
+		log.WithField("blockRoot", fmt.Sprintf("%#x", br)).
+			WithField("lightClientHosts", len(dl.lightClientHosts)).
+			Info("Verified checkpoint sync block with light client sync")
+	}
+	return nil
+}
+
+// lightClientFinalizedHeader bootstraps a light client store from the block with the trusted root, applies
+// the best updates of every sync committee period served by the host, then the latest finality update, and
+// returns the resulting finalized header.
+func lightClientFinalizedHeader(ctx context.Context, c *beacon.Client, trustedRoot, gvr [32]byte) (*lightclient.Header, error) {
+	bootstrap, err := c.GetLightClientBootstrap(ctx, trustedRoot)
+	if err != nil {
+		return nil, err
+	}
+	store, err := lightclient.NewStore(trustedRoot, bootstrap, gvr)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		finalizedSlot, nextKnown := store.FinalizedHeader().Beacon.Slot, store.NextSyncCommitteeKnown()
+		period := lightclient.SyncCommitteePeriodAtSlot(finalizedSlot)
+		updates, err := c.GetLightClientUpdatesByRange(ctx, period, maxRequestLightClientUpdates, gvr)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range updates {
+			if err := store.ProcessUpdate(u); err != nil && !errors.Is(err, lightclient.ErrIrrelevantUpdate) {
+				return nil, err
+			}
+		}
+		if store.FinalizedHeader().Beacon.Slot == finalizedSlot && store.NextSyncCommitteeKnown() == nextKnown {
+			break
+		}
+	}
+	finality, err := c.GetLightClientFinalityUpdate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.ProcessFinalityUpdate(finality); err != nil && !errors.Is(err, lightclient.ErrIrrelevantUpdate) {
+		return nil, err
+	}
+	return store.FinalizedHeader(), nil
+}
+
+// verifyDescendant checks that the block with the given root is the ancestor block, or descends from it. Blocks are
+// requested from the host from the given root back to the slot of the ancestor, and each of them is checked against
+// the root it was requested with, so the host cannot forge the chain of parents.
+func verifyDescendant(ctx context.Context, c *beacon.Client, root, ancestor [32]byte, ancestorSlot primitives.Slot) error {
+	for root != ancestor {
+		bb, err := c.GetBlock(ctx, beacon.IdFromRoot(root))
+		if err != nil {
+			return err
+		}
+		vu, err := detect.FromBlock(bb)
+		if err != nil {
+			return errors.Wrapf(err, "could not detect the fork of block %#x", root)
+		}
+		b, err := vu.UnmarshalBeaconBlock(bb)
+		if err != nil {
+			return errors.Wrapf(err, "could not unmarshal block %#x", root)
+		}
+		r, err := b.Block().HashTreeRoot()
+		if err != nil {
+			return errors.Wrapf(err, "could not compute the root of block %#x", root)
+		}
+		if r != root {
+			return fmt.Errorf("host served block %#x when requesting block %#x", r, root)
+		}
+		if b.Block().Slot() <= ancestorSlot {
+			return errLightClientMismatch
+		}
+		root = b.Block().ParentRoot()
+	}
+	return nil
+}
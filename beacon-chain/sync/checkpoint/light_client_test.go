@@ -0,0 +1,314 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/api"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// lightClientServer serves the given bootstrap for the given root, the given updates, each prefixed with the
+// fork digest of its attested slot, and the given finality update.
+func lightClientServer(
+	t *testing.T, root [32]byte, bootstrap *lightclient.Bootstrap, gvr [32]byte, updates []*lightclient.Update, finality *lightclient.FinalityUpdate,
+) *httptest.Server {
+	bootstrapMsg, err := bootstrap.Proto()
+	require.NoError(t, err)
+	finalityMsg, err := finality.Proto()
+	require.NoError(t, err)
+	var updatesResp []byte
+	for _, u := range updates {
+		m, err := u.Proto()
+		require.NoError(t, err)
+		enc, err := m.MarshalSSZ()
+		require.NoError(t, err)
+		digest, err := forks.ForkDigestFromEpoch(slots.ToEpoch(u.AttestedHeader.Beacon.Slot), gvr[:])
+		require.NoError(t, err)
+		chunkLen := make([]byte, 8)
+		binary.LittleEndian.PutUint64(chunkLen, uint64(len(digest)+len(enc)))
+		updatesResp = append(append(append(updatesResp, chunkLen...), digest[:]...), enc...)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var enc []byte
+		var err error
+		switch r.URL.Path {
+		case fmt.Sprintf("/eth/v1/beacon/light_client/bootstrap/%#x", root):
+			w.Header().Set(api.VersionHeader, version.String(bootstrap.Version()))
+			enc, err = bootstrapMsg.MarshalSSZ()
+		case "/eth/v1/beacon/light_client/updates":
+			enc = updatesResp
+		case "/eth/v1/beacon/light_client/finality_update":
+			w.Header().Set(api.VersionHeader, version.String(finality.Version()))
+			enc, err = finalityMsg.MarshalSSZ()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, err)
+		_, err = w.Write(enc)
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestLightClientFinalizedHeader(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateAltair(t, 32)
+	require.NoError(t, st.SetSlot(1))
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	b := util.NewBeaconBlockAltair()
+	b.Block.Slot = 1
+	b.Block.StateRoot = stateRoot[:]
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	bootstrap, err := lightclient.NewLightClientBootstrapFromBeaconState(ctx, st, blk)
+	require.NoError(t, err)
+	gvr := bytesutil.ToBytes32(st.GenesisValidatorsRoot())
+	// A finality update without sync committee participation, which is ignored.
+	finality := lightclient.NewLightClientFinalityUpdateFromUpdate(util.HydrateLightClientUpdate(&lightclient.Update{SignatureSlot: 2}))
+	srv := lightClientServer(t, root, bootstrap, gvr, nil, finality)
+	c, err := beacon.NewClient(srv.URL)
+	require.NoError(t, err)
+
+	t.Run("trusted block remains finalized", func(t *testing.T) {
+		header, err := lightClientFinalizedHeader(ctx, c, root, gvr)
+		require.NoError(t, err)
+		assert.DeepEqual(t, bootstrap.Header, header)
+	})
+	t.Run("bootstrap does not match the trusted root", func(t *testing.T) {
+		other := bytesutil.ToBytes32([]byte("other"))
+		srv := lightClientServer(t, other, bootstrap, gvr, nil, finality)
+		c, err := beacon.NewClient(srv.URL)
+		require.NoError(t, err)
+		_, err = lightClientFinalizedHeader(ctx, c, other, gvr)
+		require.ErrorIs(t, err, lightclient.ErrInvalidBootstrap)
+	})
+	t.Run("bootstrap unavailable", func(t *testing.T) {
+		_, err := lightClientFinalizedHeader(ctx, c, bytesutil.ToBytes32([]byte("unknown")), gvr)
+		require.ErrorContains(t, "error requesting light client bootstrap", err)
+	})
+}
+
+func TestLightClientFinalizedHeader_Capella(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 0
+	cfg.CapellaForkEpoch = 1
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	st, keys := util.DeterministicGenesisStateCapella(t, 64)
+	committee, err := altair.NextSyncCommittee(ctx, st)
+	require.NoError(t, err)
+	require.NoError(t, st.SetCurrentSyncCommittee(committee))
+	require.NoError(t, st.SetNextSyncCommittee(committee))
+	gvr := bytesutil.ToBytes32(st.GenesisValidatorsRoot())
+
+	// The trusted block is the first block of the Capella fork.
+	trustedSlot := params.BeaconConfig().SlotsPerEpoch
+	trustedState := st.Copy()
+	require.NoError(t, trustedState.SetSlot(trustedSlot))
+	trustedBlock := capellaBlockOf(t, trustedState, [32]byte{})
+	root, err := trustedBlock.Block().HashTreeRoot()
+	require.NoError(t, err)
+	bootstrap, err := lightclient.NewLightClientBootstrapFromBeaconState(ctx, trustedState, trustedBlock)
+	require.NoError(t, err)
+	require.Equal(t, version.Capella, bootstrap.Version())
+
+	// The update reveals the next sync committee, and is signed by the whole current one in the next block.
+	attestedState := st.Copy()
+	require.NoError(t, attestedState.SetSlot(trustedSlot+1))
+	attestedBlock := capellaBlockOf(t, attestedState, root)
+	attestedRoot, err := attestedBlock.Block().HashTreeRoot()
+	require.NoError(t, err)
+	signatureState := st.Copy()
+	require.NoError(t, signatureState.SetSlot(trustedSlot+2))
+	signatureBlock := capellaBlockOf(t, signatureState, attestedRoot)
+	genesisBlock, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockAltair())
+	require.NoError(t, err)
+	newUpdate := func() *lightclient.Update {
+		update, err := lightclient.NewLightClientUpdateFromBeaconState(ctx, signatureBlock, attestedState, attestedBlock, genesisBlock)
+		require.NoError(t, err)
+		signLightClientUpdate(t, st, keys, update)
+		return update
+	}
+	update := newUpdate()
+	finality := lightclient.NewLightClientFinalityUpdateFromUpdate(update)
+
+	t.Run("trusted block remains finalized", func(t *testing.T) {
+		c, err := beacon.NewClient(lightClientServer(t, root, bootstrap, gvr, []*lightclient.Update{update}, finality).URL)
+		require.NoError(t, err)
+		header, err := lightClientFinalizedHeader(ctx, c, root, gvr)
+		require.NoError(t, err)
+		r, err := header.Beacon.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, root, r)
+		assert.Equal(t, version.Capella, header.Version())
+		assert.Equal(t, uint64(trustedSlot), header.Execution.BlockNumber())
+	})
+	t.Run("invalid execution branch", func(t *testing.T) {
+		invalid := newUpdate()
+		invalid.AttestedHeader.ExecutionBranch[0][0] ^= 1
+		c, err := beacon.NewClient(lightClientServer(t, root, bootstrap, gvr, []*lightclient.Update{invalid}, finality).URL)
+		require.NoError(t, err)
+		_, err = lightClientFinalizedHeader(ctx, c, root, gvr)
+		require.ErrorIs(t, err, lightclient.ErrInvalidUpdate)
+	})
+}
+
+// capellaBlockOf returns a Capella block with the given parent root, whose post-state is the given state, and
+// whose sync aggregate has the participation of the whole sync committee.
+func capellaBlockOf(t *testing.T, st state.BeaconState, parentRoot [32]byte) interfaces.ReadOnlySignedBeaconBlock {
+	stateRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	b := util.NewBeaconBlockCapella()
+	b.Block.Slot = st.Slot()
+	b.Block.ParentRoot = parentRoot[:]
+	b.Block.StateRoot = stateRoot[:]
+	b.Block.Body.ExecutionPayload.BlockNumber = uint64(st.Slot())
+	for i := uint64(0); i < params.BeaconConfig().SyncCommitteeSize; i++ {
+		b.Block.Body.SyncAggregate.SyncCommitteeBits.SetBitAt(i, true)
+	}
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	return blk
+}
+
+// signLightClientUpdate replaces the sync aggregate of the update with the signature of its attested header by
+// the whole current sync committee of the state.
+func signLightClientUpdate(t *testing.T, st state.BeaconState, keys []bls.SecretKey, update *lightclient.Update) {
+	committee, err := st.CurrentSyncCommittee()
+	require.NoError(t, err)
+	keysByPubkey := make(map[[48]byte]bls.SecretKey, len(keys))
+	for _, k := range keys {
+		keysByPubkey[bytesutil.ToBytes48(k.PublicKey().Marshal())] = k
+	}
+	fork, err := forks.Fork(slots.ToEpoch(update.SignatureSlot - 1))
+	require.NoError(t, err)
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainSyncCommittee, fork.CurrentVersion, st.GenesisValidatorsRoot())
+	require.NoError(t, err)
+	signingRoot, err := signing.ComputeSigningRoot(update.AttestedHeader.Beacon, domain)
+	require.NoError(t, err)
+	sigs := make([]bls.Signature, 0, len(committee.Pubkeys))
+	for i, pubkey := range committee.Pubkeys {
+		update.SyncAggregate.SyncCommitteeBits.SetBitAt(uint64(i), true)
+		sigs = append(sigs, keysByPubkey[bytesutil.ToBytes48(pubkey)].Sign(signingRoot[:]))
+	}
+	update.SyncAggregate.SyncCommitteeSignature = bls.AggregateSignatures(sigs).Marshal()
+}
+
+// blockServer serves the given blocks by root.
+func blockServer(t *testing.T, blks ...*ethpb.SignedBeaconBlock) *httptest.Server {
+	byPath := make(map[string][]byte)
+	for _, b := range blks {
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		enc, err := b.MarshalSSZ()
+		require.NoError(t, err)
+		byPath[fmt.Sprintf("/eth/v2/beacon/blocks/%#x", root)] = enc
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		enc, ok := byPath[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write(enc)
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestVerifyDescendant(t *testing.T) {
+	ctx := context.Background()
+	checkpoint := util.NewBeaconBlock()
+	checkpoint.Block.Slot = 8
+	checkpointRoot, err := checkpoint.Block.HashTreeRoot()
+	require.NoError(t, err)
+	child := util.NewBeaconBlock()
+	child.Block.Slot = 9
+	child.Block.ParentRoot = checkpointRoot[:]
+	childRoot, err := child.Block.HashTreeRoot()
+	require.NoError(t, err)
+	descendant := util.NewBeaconBlock()
+	descendant.Block.Slot = 16
+	descendant.Block.ParentRoot = childRoot[:]
+	descendantRoot, err := descendant.Block.HashTreeRoot()
+	require.NoError(t, err)
+	sibling := util.NewBeaconBlock()
+	sibling.Block.Slot = 8
+	sibling.Block.ParentRoot = bytesutil.PadTo([]byte("other"), 32)
+	siblingRoot, err := sibling.Block.HashTreeRoot()
+	require.NoError(t, err)
+	fork := util.NewBeaconBlock()
+	fork.Block.Slot = 16
+	fork.Block.ParentRoot = siblingRoot[:]
+	forkRoot, err := fork.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	c, err := beacon.NewClient(blockServer(t, checkpoint, child, descendant, sibling, fork).URL)
+	require.NoError(t, err)
+
+	t.Run("checkpoint block", func(t *testing.T) {
+		require.NoError(t, verifyDescendant(ctx, c, checkpointRoot, checkpointRoot, checkpoint.Block.Slot))
+	})
+	t.Run("later finalized block", func(t *testing.T) {
+		require.NoError(t, verifyDescendant(ctx, c, descendantRoot, checkpointRoot, checkpoint.Block.Slot))
+	})
+	t.Run("block on another branch", func(t *testing.T) {
+		err := verifyDescendant(ctx, c, forkRoot, checkpointRoot, checkpoint.Block.Slot)
+		require.ErrorIs(t, err, errLightClientMismatch)
+	})
+	t.Run("earlier finalized block", func(t *testing.T) {
+		err := verifyDescendant(ctx, c, checkpointRoot, descendantRoot, descendant.Block.Slot)
+		require.ErrorIs(t, err, errLightClientMismatch)
+	})
+	t.Run("forged block", func(t *testing.T) {
+		// The host serves the checkpoint block when the descendant is requested.
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			enc, err := checkpoint.MarshalSSZ()
+			require.NoError(t, err)
+			_, err = w.Write(enc)
+			require.NoError(t, err)
+		}))
+		t.Cleanup(srv.Close)
+		forged, err := beacon.NewClient(srv.URL)
+		require.NoError(t, err)
+		err = verifyDescendant(ctx, forged, descendantRoot, checkpointRoot, checkpoint.Block.Slot)
+		require.ErrorContains(t, "when requesting block", err)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"google.golang.org/protobuf/proto"
 )
@@ -50,11 +51,22 @@ func (s *Service) decodePubsubMessage(msg *pubsub.Message) (ssz.Unmarshaler, err
 		return nil, errors.Errorf("message of %T does not support marshaller interface", base)
 	}
 	// Handle different message types across forks.
-	if topic == p2p.BlockSubnetTopicFormat {
+	switch topic {
+	case p2p.BlockSubnetTopicFormat:
 		m, err = extractBlockDataType(fDigest[:], s.cfg.clock)
 		if err != nil {
 			return nil, err
 		}
+	case p2p.LightClientFinalityUpdateTopicFormat, p2p.LightClientOptimisticUpdateTopicFormat:
+		vRoot := s.cfg.clock.GenesisValidatorsRoot()
+		_, epoch, err := forks.RetrieveForkDataFromDigest(fDigest, vRoot[:])
+		if err != nil {
+			return nil, err
+		}
+		m, ok = proto.Clone(p2p.GossipTopicMappings(topic, epoch)).(ssz.Unmarshaler)
+		if !ok {
+			return nil, errors.Errorf("message of %T does not support marshaller interface", base)
+		}
 	}
 	if err := s.cfg.p2p.Encoding().DecodeGossip(msg.Data, m); err != nil {
 		return nil, err
@@ -30,6 +30,7 @@ func (s *Service) forkWatcher() {
 			}
 			// Broadcast BLS changes at the Capella fork boundary
 			s.broadcastBLSChanges(currSlot)
+			s.rebroadcastOnLateFinality(currSlot)
 
 		case <-s.ctx.Done():
 			log.Debug("Context closed, exiting goroutine")
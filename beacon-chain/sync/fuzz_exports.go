@@ -22,7 +22,9 @@ func NewRegularSyncFuzz(opts ...Option) *Service {
 		cancel:               cancel,
 		slotToPendingBlocks:  gcache.New(time.Second, 2*time.Second),
 		seenPendingBlocks:    make(map[[32]byte]bool),
+		pendingBlockInfo:     make(map[[32]byte]*pendingBlockInfo),
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
+		pendingAttsPeers:     make(map[[32]byte]peer.ID),
 	}
 	r.rateLimiter = newRateLimiter(r.cfg.p2p)
 
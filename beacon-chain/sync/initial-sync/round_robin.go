@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/progress"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
@@ -52,12 +53,23 @@ func (s *Service) roundRobinSync(genesis time.Time) error {
 
 	// Already at head, no need for 2nd phase.
 	if s.cfg.Chain.HeadSlot() == slots.Since(genesis) {
+		s.completeProgress()
 		return nil
 	}
 
 	// Step 2 - sync to head from majority of peers (from no less than MinimumSyncPeers*2 peers)
 	// having the same world view on non-finalized epoch.
-	return s.syncToNonFinalizedEpoch(ctx, genesis)
+	if err := s.syncToNonFinalizedEpoch(ctx, genesis); err != nil {
+		return err
+	}
+	s.completeProgress()
+	return nil
+}
+
+// completeProgress marks the stages driven by initial sync as complete.
+func (s *Service) completeProgress() {
+	s.cfg.Progress.Complete(progress.InitialSync)
+	s.cfg.Progress.Complete(progress.BlobBackfill)
 }
 
 // syncToFinalizedEpoch sync from head to best known finalized epoch.
@@ -71,6 +83,7 @@ func (s *Service) syncToFinalizedEpoch(ctx context.Context, genesis time.Time) e
 		log.Debug("Already synced to finalized epoch")
 		return nil
 	}
+	s.cfg.Progress.Start(progress.InitialSync, uint64(s.cfg.Chain.HeadSlot()), uint64(highestFinalizedSlot))
 
 	vr := s.clock.GenesisValidatorsRoot()
 	ctxMap, err := sync.ContextByteVersionsForValRoot(vr)
@@ -111,6 +124,11 @@ func (s *Service) syncToFinalizedEpoch(ctx context.Context, genesis time.Time) e
 // syncToNonFinalizedEpoch sync from head to best known non-finalized epoch supported by majority
 // of peers (no less than MinimumSyncPeers*2 peers).
 func (s *Service) syncToNonFinalizedEpoch(ctx context.Context, genesis time.Time) error {
+	if s.cfg.Progress.Active(progress.InitialSync) {
+		s.cfg.Progress.SetTarget(progress.InitialSync, uint64(slots.Since(genesis)))
+	} else {
+		s.cfg.Progress.Start(progress.InitialSync, uint64(s.cfg.Chain.HeadSlot()), uint64(slots.Since(genesis)))
+	}
 	vr := s.clock.GenesisValidatorsRoot()
 	ctxMap, err := sync.ContextByteVersionsForValRoot(vr)
 	if err != nil {
@@ -216,12 +234,13 @@ func (s *Service) logSyncStatus(genesis time.Time, blk interfaces.ReadOnlyBeacon
 	if rate == 0 {
 		rate = 1
 	}
+	s.updateProgress(blk.Slot())
 	if slots.IsEpochStart(blk.Slot()) {
 		timeRemaining := time.Duration(float64(slots.Since(genesis)-blk.Slot())/rate) * time.Second
-		log.WithFields(logrus.Fields{
+		log.WithFields(s.progressFields(logrus.Fields{
 			"peers":           len(s.cfg.P2P.Peers().Connected()),
 			"blocksPerSecond": fmt.Sprintf("%.1f", rate),
-		}).Infof(
+		})).Infof(
 			"Processing block %s %d/%d - estimated time remaining %s",
 			fmt.Sprintf("0x%s...", hex.EncodeToString(blkRoot[:])[:8]),
 			blk.Slot(), slots.Since(genesis), timeRemaining,
@@ -237,17 +256,45 @@ func (s *Service) logBatchSyncStatus(genesis time.Time, firstBlk blocks.ROBlock,
 		rate = 1
 	}
 	firstRoot := firstBlk.Root()
+	s.updateProgress(firstBlk.Block().Slot())
 	timeRemaining := time.Duration(float64(slots.Since(genesis)-firstBlk.Block().Slot())/rate) * time.Second
-	log.WithFields(logrus.Fields{
+	log.WithFields(s.progressFields(logrus.Fields{
 		"peers":           len(s.cfg.P2P.Peers().Connected()),
 		"blocksPerSecond": fmt.Sprintf("%.1f", rate),
-	}).Infof(
+	})).Infof(
 		"Processing block batch of size %d starting from  %s %d/%d - estimated time remaining %s",
 		nBlocks, fmt.Sprintf("0x%s...", hex.EncodeToString(firstRoot[:])[:8]),
 		firstBlk.Block().Slot(), slots.Since(genesis), timeRemaining,
 	)
 }
 
+// updateProgress records the slot reached by initial sync, and the progress through the blob
+// retention window once that slot falls within it.
+func (s *Service) updateProgress(slot primitives.Slot) {
+	s.cfg.Progress.Update(progress.InitialSync, uint64(slot))
+	current := s.clock.CurrentSlot()
+	windowStart, err := sync.BlobsByRangeMinStartSlot(current)
+	if err != nil || slot < windowStart {
+		return
+	}
+	if !s.cfg.Progress.Active(progress.BlobBackfill) {
+		s.cfg.Progress.Start(progress.BlobBackfill, uint64(slot), uint64(current))
+	}
+	s.cfg.Progress.SetTarget(progress.BlobBackfill, uint64(current))
+	s.cfg.Progress.Update(progress.BlobBackfill, uint64(slot))
+}
+
+// progressFields adds the completion percentage of the active initial sync stages to the log fields.
+func (s *Service) progressFields(fields logrus.Fields) logrus.Fields {
+	if st := s.cfg.Progress.Status(progress.InitialSync); st != nil && !st.Complete {
+		fields["syncProgress"] = fmt.Sprintf("%.1f%%", st.Percent())
+	}
+	if st := s.cfg.Progress.Status(progress.BlobBackfill); st != nil && !st.Complete {
+		fields["blobProgress"] = fmt.Sprintf("%.1f%%", st.Percent())
+	}
+	return fields
+}
+
 // processBlock performs basic checks on incoming block, and triggers receiver function.
 func (s *Service) processBlock(
 	ctx context.Context,
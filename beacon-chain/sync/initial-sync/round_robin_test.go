@@ -11,6 +11,7 @@ import (
 	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	p2pt "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/progress"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
@@ -630,7 +631,7 @@ func TestService_syncToFinalizedEpoch(t *testing.T) {
 	}
 	s := &Service{
 		ctx:          context.Background(),
-		cfg:          &Config{Chain: mc, P2P: p, DB: beaconDB},
+		cfg:          &Config{Chain: mc, P2P: p, DB: beaconDB, Progress: progress.NewTracker()},
 		synced:       abool.New(),
 		chainStarted: abool.NewBool(true),
 		counter:      ratecounter.NewRateCounter(counterSeconds * time.Second),
@@ -661,6 +662,10 @@ func TestService_syncToFinalizedEpoch(t *testing.T) {
 		t.Errorf("Missing blocks at slots %v", missing)
 	}
 	assert.LogsDoNotContain(t, hook, "Already synced to finalized epoch")
+	ps := s.cfg.Progress.Status(progress.InitialSync)
+	require.NotNil(t, ps)
+	assert.Equal(t, uint64(192), ps.Target)
+	assert.Equal(t, true, ps.Current > 0, "initial sync progress was not updated")
 
 	// Try to re-sync, should be exited immediately (node is already synced to finalized epoch).
 	hook.Reset()
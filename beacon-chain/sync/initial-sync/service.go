@@ -16,8 +16,10 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/progress"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/runtime"
 	prysmTime "github.com/prysmaticlabs/prysm/v4/time"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
@@ -41,6 +43,7 @@ type Config struct {
 	BlockNotifier       blockfeed.Notifier
 	ClockWaiter         startup.ClockWaiter
 	InitialSyncComplete chan struct{}
+	Progress            *progress.Tracker
 }
 
 // Service service.
@@ -114,6 +117,14 @@ func (s *Service) Start() {
 		s.markSynced()
 		return
 	}
+	if flags.Get().FastResume && canFastResume(s.cfg.Chain.HeadSlot(), currentSlot) {
+		log.WithFields(logrus.Fields{
+			"headSlot":    s.cfg.Chain.HeadSlot(),
+			"currentSlot": currentSlot,
+		}).Info("Head is close to the current slot - resuming regular sync without initial sync")
+		s.markSynced()
+		return
+	}
 	s.waitForMinimumPeers()
 	if err := s.roundRobinSync(gt); err != nil {
 		if errors.Is(s.ctx.Err(), context.Canceled) {
@@ -194,6 +205,16 @@ func (s *Service) waitForMinimumPeers() {
 	}
 }
 
+// fastResumeSlotDistance is the maximum number of slots the head may trail the current slot by to skip initial sync.
+const fastResumeSlotDistance = 2
+
+// canFastResume returns true if the head is close enough to the current slot for regular sync to request the
+// few missing blocks by root. Further behind, validators would perform duties on a stale head, so initial sync
+// runs to completion first.
+func canFastResume(headSlot, currentSlot primitives.Slot) bool {
+	return headSlot+fastResumeSlotDistance >= currentSlot
+}
+
 // markSynced marks node as synced and notifies feed listeners.
 func (s *Service) markSynced() {
 	s.synced.Set()
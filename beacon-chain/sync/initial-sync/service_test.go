@@ -185,6 +185,76 @@ func TestService_InitStartStop(t *testing.T) {
 	}
 }
 
+func TestService_FastResume(t *testing.T) {
+	hook := logTest.NewGlobal()
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{
+		MinimumSyncPeers: 1,
+		FastResume:       true,
+	})
+	defer func() {
+		flags.Init(resetFlags)
+	}()
+
+	currentSlot := primitives.Slot(27329)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	// The head is at the last slot of the previous epoch, two slots behind the current slot.
+	headSlot := primitives.Slot(slots.ToEpoch(currentSlot))*params.BeaconConfig().SlotsPerEpoch - 1
+	require.NoError(t, st.SetSlot(headSlot))
+	genesis := makeGenesisTime(currentSlot)
+	mc := &mock.ChainService{
+		State:   st,
+		Genesis: genesis,
+	}
+	gs := startup.NewClockSynchronizer()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := NewService(ctx, &Config{
+		P2P:                 p2pt.NewTestP2P(t),
+		Chain:               mc,
+		ClockWaiter:         gs,
+		StateNotifier:       &mock.MockStateNotifier{},
+		InitialSyncComplete: make(chan struct{}),
+	})
+	require.NoError(t, gs.SetClock(startup.NewClock(genesis, [32]byte{})))
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		s.Start()
+		wg.Done()
+	}()
+	if util.WaitTimeout(wg, time.Second*4) {
+		t.Fatalf("Test should have exited by now, timed out")
+	}
+	assert.LogsContain(t, hook, "resuming regular sync without initial sync")
+	assert.Equal(t, true, s.Synced())
+	select {
+	case <-s.cfg.InitialSyncComplete:
+	default:
+		t.Fatal("Initial sync complete channel was not closed")
+	}
+}
+
+func TestCanFastResume(t *testing.T) {
+	spe := params.BeaconConfig().SlotsPerEpoch
+	tests := []struct {
+		head    primitives.Slot
+		current primitives.Slot
+		want    bool
+	}{
+		{head: 10 * spe, current: 10 * spe, want: true},
+		{head: 10*spe - 1, current: 10*spe + 1, want: true},
+		{head: 10*spe - 1, current: 10*spe + 2, want: false},
+		// A checkpoint synced head trails the current slot by at least an epoch.
+		{head: 10 * spe, current: 12 * spe, want: false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, canFastResume(tt.head, tt.current), "head %d current %d", tt.head, tt.current)
+	}
+}
+
 func TestService_waitForStateInitialization(t *testing.T) {
 	hook := logTest.NewGlobal()
 	newService := func(ctx context.Context, mc *mock.ChainService) (*Service, *startup.ClockSynchronizer) {
@@ -56,6 +56,33 @@ var (
 		},
 		[]string{"topic"},
 	)
+	rebroadcastMessageCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "late_finality_rebroadcast_total",
+			Help: "Count of the messages of local validators broadcast again while the chain was not finalizing.",
+		},
+		[]string{"type"},
+	)
+	subnetPeerSearchCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_subnet_peer_searches_total",
+			Help: "Count of the searches for peers in subnets with upcoming duties and fewer peers than their target.",
+		},
+		[]string{"topic"},
+	)
+	equivocatingBlocksCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "gossip_equivocating_blocks_total",
+			Help: "Count of the second distinct blocks signed by a proposer for a slot ignored over gossip.",
+		},
+	)
+	pendingQueueDroppedCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pending_queue_dropped_total",
+			Help: "Count of the blocks and attestations dropped because their pending queue was full.",
+		},
+		[]string{"type"},
+	)
 	numberOfTimesResyncedCounter = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "number_of_times_resynced",
@@ -68,6 +95,13 @@ var (
 			Help: "Count the number of times a duplicate signature set has been removed.",
 		},
 	)
+	subnetVerifierQueueFullCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "subnet_verifier_queue_full_total",
+			Help: "Count the number of attestations ignored because the signature verification queue of their subnet was full.",
+		},
+		[]string{"subnet"},
+	)
 	numberOfSetsAggregated = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "number_of_sets_aggregated",
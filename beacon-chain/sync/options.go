@@ -2,6 +2,7 @@ package sync
 
 import (
 	"github.com/prysmaticlabs/prysm/v4/async/event"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
 	blockfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
@@ -14,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/encoding/era"
 )
 
 type Option func(s *Service) error
@@ -123,6 +125,13 @@ func WithSlasherBlockHeadersFeed(slasherBlockHeadersFeed *event.Feed) Option {
 	}
 }
 
+func WithSlasherBlobSidecarsFeed(slasherBlobSidecarsFeed *event.Feed) Option {
+	return func(s *Service) error {
+		s.cfg.slasherBlobSidecarsFeed = slasherBlobSidecarsFeed
+		return nil
+	}
+}
+
 func WithExecutionPayloadReconstructor(r execution.ExecutionPayloadReconstructor) Option {
 	return func(s *Service) error {
 		s.cfg.executionPayloadReconstructor = r
@@ -143,3 +152,28 @@ func WithInitialSyncComplete(c chan struct{}) Option {
 		return nil
 	}
 }
+
+// WithEraStore sets the era files used to serve blocks that are missing from the database.
+func WithEraStore(store *era.Store) Option {
+	return func(s *Service) error {
+		s.cfg.eraStore = store
+		return nil
+	}
+}
+
+// WithLocalBroadcastsCache sets the messages of the validators of the node which are broadcast again when the chain
+// does not finalize.
+func WithLocalBroadcastsCache(c *cache.LocalBroadcastsCache) Option {
+	return func(s *Service) error {
+		s.cfg.localBroadcasts = c
+		return nil
+	}
+}
+
+// WithPeerController sets the controller used to look for new peers when the chain does not finalize.
+func WithPeerController(pc p2p.PeerController) Option {
+	return func(s *Service) error {
+		s.cfg.peerController = pc
+		return nil
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/prysmaticlabs/prysm/v4/async"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
@@ -73,6 +74,7 @@ func (s *Service) processPendingAtts(ctx context.Context) error {
 			// Delete the missing block root key from pending attestation queue so a node will not request for the block again.
 			s.pendingAttsLock.Lock()
 			delete(s.blkRootToPendingAtts, bRoot)
+			delete(s.pendingAttsPeers, bRoot)
 			s.pendingAttsLock.Unlock()
 		} else {
 			// Pending attestation's missing block has not arrived yet.
@@ -101,7 +103,7 @@ func (s *Service) processAttestations(ctx context.Context, attestations []*ethpb
 				log.WithError(err).Debug("Pending aggregated attestation failed validation")
 			}
 			aggValid := pubsub.ValidationAccept == valRes
-			if s.validateBlockInAttestation(ctx, signedAtt) && aggValid {
+			if s.validateBlockInAttestation(ctx, signedAtt, "") && aggValid {
 				if err := s.cfg.attPool.SaveAggregatedAttestation(att.Aggregate); err != nil {
 					log.WithError(err).Debug("Could not save aggregate attestation")
 					continue
@@ -159,8 +161,9 @@ func (s *Service) processAttestations(ctx context.Context, attestations []*ethpb
 
 // This defines how pending attestations is saved in the map. The key is the
 // root of the missing block. The value is the list of pending attestations
-// that voted for that block root.
-func (s *Service) savePendingAtt(att *ethpb.SignedAggregateAttestationAndProof) {
+// that voted for that block root. The first peer which sent an attestation
+// for the missing block is recorded, to be asked for the block.
+func (s *Service) savePendingAtt(att *ethpb.SignedAggregateAttestationAndProof, pid peer.ID) {
 	root := bytesutil.ToBytes32(att.Message.Aggregate.Data.BeaconBlockRoot)
 
 	s.pendingAttsLock.Lock()
@@ -172,12 +175,17 @@ func (s *Service) savePendingAtt(att *ethpb.SignedAggregateAttestationAndProof)
 	}
 	// Exit early if we exceed the pending attestations limit.
 	if numOfPendingAtts >= pendingAttsLimit {
+		pendingQueueDroppedCount.WithLabelValues("attestation").Inc()
 		return
 	}
 
 	_, ok := s.blkRootToPendingAtts[root]
 	if !ok {
 		s.blkRootToPendingAtts[root] = []*ethpb.SignedAggregateAttestationAndProof{att}
+		if s.pendingAttsPeers == nil {
+			s.pendingAttsPeers = make(map[[32]byte]peer.ID)
+		}
+		s.pendingAttsPeers[root] = pid
 		return
 	}
 
@@ -215,6 +223,7 @@ func (s *Service) validatePendingAtts(ctx context.Context, slot primitives.Slot)
 		// a node will remove the key from the map to avoid dangling keys.
 		if len(s.blkRootToPendingAtts[bRoot]) == 0 {
 			delete(s.blkRootToPendingAtts, bRoot)
+			delete(s.pendingAttsPeers, bRoot)
 		}
 	}
 }
@@ -366,17 +366,17 @@ func TestValidatePendingAtts_CanPruneOldAtts(t *testing.T) {
 			Message: &ethpb.AggregateAttestationAndProof{
 				AggregatorIndex: primitives.ValidatorIndex(i),
 				Aggregate: &ethpb.Attestation{
-					Data: &ethpb.AttestationData{Slot: i, BeaconBlockRoot: r1[:]}}}})
+					Data: &ethpb.AttestationData{Slot: i, BeaconBlockRoot: r1[:]}}}}, "")
 		s.savePendingAtt(&ethpb.SignedAggregateAttestationAndProof{
 			Message: &ethpb.AggregateAttestationAndProof{
 				AggregatorIndex: primitives.ValidatorIndex(i*2 + i),
 				Aggregate: &ethpb.Attestation{
-					Data: &ethpb.AttestationData{Slot: i, BeaconBlockRoot: r2[:]}}}})
+					Data: &ethpb.AttestationData{Slot: i, BeaconBlockRoot: r2[:]}}}}, "")
 		s.savePendingAtt(&ethpb.SignedAggregateAttestationAndProof{
 			Message: &ethpb.AggregateAttestationAndProof{
 				AggregatorIndex: primitives.ValidatorIndex(i*3 + i),
 				Aggregate: &ethpb.Attestation{
-					Data: &ethpb.AttestationData{Slot: i, BeaconBlockRoot: r3[:]}}}})
+					Data: &ethpb.AttestationData{Slot: i, BeaconBlockRoot: r3[:]}}}}, "")
 	}
 
 	assert.Equal(t, 100, len(s.blkRootToPendingAtts[r1]), "Did not save pending atts")
@@ -410,17 +410,17 @@ func TestValidatePendingAtts_NoDuplicatingAggregatorIndex(t *testing.T) {
 		Message: &ethpb.AggregateAttestationAndProof{
 			AggregatorIndex: 1,
 			Aggregate: &ethpb.Attestation{
-				Data: &ethpb.AttestationData{Slot: 1, BeaconBlockRoot: r1[:]}}}})
+				Data: &ethpb.AttestationData{Slot: 1, BeaconBlockRoot: r1[:]}}}}, "")
 	s.savePendingAtt(&ethpb.SignedAggregateAttestationAndProof{
 		Message: &ethpb.AggregateAttestationAndProof{
 			AggregatorIndex: 2,
 			Aggregate: &ethpb.Attestation{
-				Data: &ethpb.AttestationData{Slot: 2, BeaconBlockRoot: r2[:]}}}})
+				Data: &ethpb.AttestationData{Slot: 2, BeaconBlockRoot: r2[:]}}}}, "")
 	s.savePendingAtt(&ethpb.SignedAggregateAttestationAndProof{
 		Message: &ethpb.AggregateAttestationAndProof{
 			AggregatorIndex: 2,
 			Aggregate: &ethpb.Attestation{
-				Data: &ethpb.AttestationData{Slot: 3, BeaconBlockRoot: r2[:]}}}})
+				Data: &ethpb.AttestationData{Slot: 3, BeaconBlockRoot: r2[:]}}}}, "")
 
 	assert.Equal(t, 1, len(s.blkRootToPendingAtts[r1]), "Did not save pending atts")
 	assert.Equal(t, 1, len(s.blkRootToPendingAtts[r2]), "Did not save pending atts")
@@ -436,7 +436,7 @@ func TestSavePendingAtts_BeyondLimit(t *testing.T) {
 			Message: &ethpb.AggregateAttestationAndProof{
 				AggregatorIndex: primitives.ValidatorIndex(i),
 				Aggregate: &ethpb.Attestation{
-					Data: &ethpb.AttestationData{Slot: 1, BeaconBlockRoot: bytesutil.Bytes32(uint64(i))}}}})
+					Data: &ethpb.AttestationData{Slot: 1, BeaconBlockRoot: bytesutil.Bytes32(uint64(i))}}}}, "")
 	}
 	r1 := [32]byte(bytesutil.Bytes32(0))
 	r2 := [32]byte(bytesutil.Bytes32(uint64(pendingAttsLimit) - 1))
@@ -449,7 +449,7 @@ func TestSavePendingAtts_BeyondLimit(t *testing.T) {
 			Message: &ethpb.AggregateAttestationAndProof{
 				AggregatorIndex: primitives.ValidatorIndex(i),
 				Aggregate: &ethpb.Attestation{
-					Data: &ethpb.AttestationData{Slot: 1, BeaconBlockRoot: bytesutil.Bytes32(uint64(i))}}}})
+					Data: &ethpb.AttestationData{Slot: 1, BeaconBlockRoot: bytesutil.Bytes32(uint64(i))}}}}, "")
 	}
 
 	r1 = [32]byte(bytesutil.Bytes32(uint64(pendingAttsLimit)))
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/async"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
@@ -135,6 +136,11 @@ func (s *Service) processPendingBlocks(ctx context.Context) error {
 			parentRoot := b.Block().ParentRoot()
 			parentInDb := s.cfg.beaconDB.HasBlock(ctx, parentRoot)
 			hasPeer := len(pids) != 0
+			if !parentInDb {
+				s.pendingQueueLock.Lock()
+				s.setPendingReason(blkRoot, pendingMissingParent)
+				s.pendingQueueLock.Unlock()
+			}
 
 			// Only request for missing parent block if it's not in beaconDB, not in pending cache
 			// and has peer in the peer list.
@@ -256,6 +262,11 @@ func (s *Service) sendBatchRootRequest(ctx context.Context, roots [][32]byte, ra
 	if len(roots) == 0 {
 		return nil
 	}
+	// Ask the peers which sent the blocks and attestations depending on the roots first, as they have them.
+	roots = s.requestFromHintPeers(ctx, roots)
+	if len(roots) == 0 {
+		return nil
+	}
 	cp := s.cfg.chain.FinalizedCheckpt()
 	_, bestPeers := s.cfg.p2p.Peers().BestFinalized(maxPeerRequest, cp.Epoch)
 	if len(bestPeers) == 0 {
@@ -322,6 +333,7 @@ func (s *Service) validatePendingSlots() error {
 	if s.slotToPendingBlocks == nil {
 		return errors.New("slotToPendingBlocks cache can't be nil")
 	}
+	s.prunePendingBlockInfo()
 	items := s.slotToPendingBlocks.Items()
 	for k := range items {
 		slot := cacheKeyToSlot(k)
@@ -361,6 +373,7 @@ func (s *Service) clearPendingSlots() {
 	defer s.pendingQueueLock.Unlock()
 	s.slotToPendingBlocks.Flush()
 	s.seenPendingBlocks = make(map[[32]byte]bool)
+	s.pendingBlockInfo = make(map[[32]byte]*pendingBlockInfo)
 }
 
 // Delete block from the list from the pending queue using the slot as key.
@@ -396,6 +409,7 @@ func (s *Service) deleteBlockFromPendingQueue(slot primitives.Slot, b interfaces
 	if len(newBlks) == 0 {
 		s.slotToPendingBlocks.Delete(slotToCacheKey(slot))
 		delete(s.seenPendingBlocks, r)
+		delete(s.pendingBlockInfo, r)
 		return nil
 	}
 
@@ -405,23 +419,40 @@ func (s *Service) deleteBlockFromPendingQueue(slot primitives.Slot, b interfaces
 		return err
 	}
 	delete(s.seenPendingBlocks, r)
+	delete(s.pendingBlockInfo, r)
 	return nil
 }
 
-// Insert block to the list in the pending queue using the slot as key.
+// Insert block to the list in the pending queue using the slot as key, along with the reason it is pending and the
+// peer it was received from. When the queue is full, the blocks of its oldest slot are evicted to make room for the
+// block, unless the block is not newer than them, in which case it is dropped.
 // Note: this helper is not thread safe.
-func (s *Service) insertBlockToPendingQueue(_ primitives.Slot, b interfaces.ReadOnlySignedBeaconBlock, r [32]byte) error {
+func (s *Service) insertBlockToPendingQueue(_ primitives.Slot, b interfaces.ReadOnlySignedBeaconBlock, r [32]byte, reason pendingReason, pid peer.ID) error {
 	mutexasserts.AssertRWMutexLocked(&s.pendingQueueLock)
 
 	if s.seenPendingBlocks[r] {
 		return nil
 	}
+	if len(s.pendingBlockInfo) >= maxPendingBlocks && !s.evictOldestPendingSlot(b.Block().Slot()) {
+		pendingQueueDroppedCount.WithLabelValues("block").Inc()
+		return nil
+	}
 
 	if err := s.addPendingBlockToCache(b); err != nil {
 		return err
 	}
 
 	s.seenPendingBlocks[r] = true
+	if s.pendingBlockInfo == nil {
+		s.pendingBlockInfo = make(map[[32]byte]*pendingBlockInfo)
+	}
+	s.pendingBlockInfo[r] = &pendingBlockInfo{
+		slot:       b.Block().Slot(),
+		parentRoot: b.Block().ParentRoot(),
+		reason:     reason,
+		peer:       pid,
+		added:      time.Now(),
+	}
 	return nil
 }
 
@@ -0,0 +1,207 @@
+package sync
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	p2ptypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/trailofbits/go-mutexasserts"
+)
+
+// pendingReason is the reason why a block is held in the pending blocks queue.
+type pendingReason string
+
+const (
+	// pendingMissingParent is for blocks whose parent is not known yet.
+	pendingMissingParent pendingReason = "missing_parent"
+	// pendingMissingBlobs is for blocks whose blob sidecars could not be retrieved yet.
+	pendingMissingBlobs pendingReason = "missing_blobs"
+	// pendingFutureSlot is for blocks received before the start of their slot.
+	pendingFutureSlot pendingReason = "future_slot"
+	// pendingAwaitingProcessing is for blocks requested by root, which are processed in the next run of the queue.
+	pendingAwaitingProcessing pendingReason = "awaiting_processing"
+)
+
+// maxPendingBlocks bounds the number of blocks held in the pending blocks queue. Once it is full, the blocks of its
+// oldest slot are evicted for newer blocks, as blocks near the head are the most likely to become canonical. Dropped
+// blocks are requested again by root if another block or attestation depends on them.
+const maxPendingBlocks = 1024
+
+// pendingBlockInfo records why a block is pending and which peer it was received from. Gossip peers only forward
+// blocks whose parent they know, so that peer is asked first for the missing ancestors of the block.
+type pendingBlockInfo struct {
+	slot       primitives.Slot
+	parentRoot [32]byte
+	reason     pendingReason
+	peer       peer.ID
+	added      time.Time
+}
+
+// PendingBlock describes a block held in the pending blocks queue.
+type PendingBlock struct {
+	Root       [32]byte
+	Slot       primitives.Slot
+	ParentRoot [32]byte
+	Reason     string
+	Peer       peer.ID
+	Added      time.Time
+}
+
+// PendingAttestations describes the attestations held in the pending attestations queue for a block which is not
+// known yet.
+type PendingAttestations struct {
+	BlockRoot [32]byte
+	Count     int
+	Peer      peer.ID
+}
+
+// PendingQueueFetcher gives access to the contents of the pending queues.
+type PendingQueueFetcher interface {
+	PendingBlocks() []*PendingBlock
+	PendingAttestations() []*PendingAttestations
+}
+
+// PendingBlocks returns the blocks held in the pending blocks queue, ordered by slot.
+func (s *Service) PendingBlocks() []*PendingBlock {
+	s.pendingQueueLock.RLock()
+	defer s.pendingQueueLock.RUnlock()
+	blks := make([]*PendingBlock, 0, len(s.pendingBlockInfo))
+	for r, info := range s.pendingBlockInfo {
+		blks = append(blks, &PendingBlock{
+			Root:       r,
+			Slot:       info.slot,
+			ParentRoot: info.parentRoot,
+			Reason:     string(info.reason),
+			Peer:       info.peer,
+			Added:      info.added,
+		})
+	}
+	sort.Slice(blks, func(i, j int) bool {
+		return blks[i].Slot < blks[j].Slot
+	})
+	return blks
+}
+
+// PendingAttestations returns the attestations held in the pending attestations queue, grouped by the block they
+// vote for.
+func (s *Service) PendingAttestations() []*PendingAttestations {
+	s.pendingAttsLock.RLock()
+	defer s.pendingAttsLock.RUnlock()
+	atts := make([]*PendingAttestations, 0, len(s.blkRootToPendingAtts))
+	for r, a := range s.blkRootToPendingAtts {
+		atts = append(atts, &PendingAttestations{BlockRoot: r, Count: len(a), Peer: s.pendingAttsPeers[r]})
+	}
+	sort.Slice(atts, func(i, j int) bool {
+		return atts[i].Count > atts[j].Count
+	})
+	return atts
+}
+
+// setPendingReason records a new reason for a block to be pending.
+// Note: this helper is not thread safe.
+func (s *Service) setPendingReason(r [32]byte, reason pendingReason) {
+	mutexasserts.AssertRWMutexLocked(&s.pendingQueueLock)
+
+	if info, ok := s.pendingBlockInfo[r]; ok {
+		info.reason = reason
+	}
+}
+
+// prunePendingBlockInfo removes the records of the blocks which expired from the pending blocks queue.
+// Note: this helper is not thread safe.
+func (s *Service) prunePendingBlockInfo() {
+	mutexasserts.AssertRWMutexLocked(&s.pendingQueueLock)
+
+	for r, info := range s.pendingBlockInfo {
+		if _, ok := s.slotToPendingBlocks.Get(slotToCacheKey(info.slot)); !ok {
+			delete(s.pendingBlockInfo, r)
+			delete(s.seenPendingBlocks, r)
+		}
+	}
+}
+
+// evictOldestPendingSlot evicts the blocks of the oldest slot of the pending blocks queue, if it is older than the
+// given slot. It returns whether blocks were evicted.
+// Note: this helper is not thread safe.
+func (s *Service) evictOldestPendingSlot(slot primitives.Slot) bool {
+	mutexasserts.AssertRWMutexLocked(&s.pendingQueueLock)
+
+	oldest := slot
+	for _, info := range s.pendingBlockInfo {
+		if info.slot < oldest {
+			oldest = info.slot
+		}
+	}
+	if oldest == slot {
+		return false
+	}
+	s.slotToPendingBlocks.Delete(slotToCacheKey(oldest))
+	for r, info := range s.pendingBlockInfo {
+		if info.slot == oldest {
+			delete(s.pendingBlockInfo, r)
+			delete(s.seenPendingBlocks, r)
+			pendingQueueDroppedCount.WithLabelValues("block").Inc()
+		}
+	}
+	return true
+}
+
+// rootsByHintPeer groups the given missing block roots by the active peer which sent a pending block or attestation
+// depending on them, if any.
+func (s *Service) rootsByHintPeer(roots [][32]byte) map[peer.ID][][32]byte {
+	missing := make(map[[32]byte]bool, len(roots))
+	for _, r := range roots {
+		missing[r] = true
+	}
+	hints := make(map[[32]byte]peer.ID, len(roots))
+	s.pendingQueueLock.RLock()
+	for _, info := range s.pendingBlockInfo {
+		if missing[info.parentRoot] && info.peer != "" {
+			hints[info.parentRoot] = info.peer
+		}
+	}
+	s.pendingQueueLock.RUnlock()
+	s.pendingAttsLock.RLock()
+	for _, r := range roots {
+		if pid, ok := s.pendingAttsPeers[r]; ok && pid != "" && hints[r] == "" {
+			hints[r] = pid
+		}
+	}
+	s.pendingAttsLock.RUnlock()
+
+	byPeer := make(map[peer.ID][][32]byte)
+	for r, pid := range hints {
+		if !s.cfg.p2p.Peers().IsActive(pid) {
+			continue
+		}
+		byPeer[pid] = append(byPeer[pid], r)
+	}
+	return byPeer
+}
+
+// requestFromHintPeers requests the missing block roots from the peers which sent the blocks and attestations
+// depending on them, and returns the roots which are still missing.
+func (s *Service) requestFromHintPeers(ctx context.Context, roots [][32]byte) [][32]byte {
+	for pid, hinted := range s.rootsByHintPeer(roots) {
+		req := p2ptypes.BeaconBlockByRootsReq(hinted)
+		if len(hinted) > int(params.BeaconNetworkConfig().MaxRequestBlocks) {
+			req = hinted[:params.BeaconNetworkConfig().MaxRequestBlocks]
+		}
+		if err := s.sendRecentBeaconBlocksRequest(ctx, &req, pid); err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not request missing blocks from hint peer")
+		}
+	}
+	missing := make([][32]byte, 0, len(roots))
+	s.pendingQueueLock.RLock()
+	defer s.pendingQueueLock.RUnlock()
+	for _, r := range roots {
+		if !s.seenPendingBlocks[r] {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,125 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	gcache "github.com/patrickmn/go-cache"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
+	p2ptest "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestService_PendingBlocks(t *testing.T) {
+	r := &Service{
+		slotToPendingBlocks: gcache.New(time.Second, 2*time.Second),
+		seenPendingBlocks:   make(map[[32]byte]bool),
+	}
+
+	b1 := util.NewBeaconBlock()
+	b1.Block.Slot = 2
+	b1.Block.ParentRoot = bytesutil.PadTo([]byte{'a'}, 32)
+	wsb, err := blocks.NewSignedBeaconBlock(b1)
+	require.NoError(t, err)
+	require.NoError(t, r.insertBlockToPendingQueue(2, wsb, [32]byte{1}, pendingFutureSlot, "foo"))
+	b2 := util.NewBeaconBlock()
+	b2.Block.Slot = 1
+	wsb, err = blocks.NewSignedBeaconBlock(b2)
+	require.NoError(t, err)
+	require.NoError(t, r.insertBlockToPendingQueue(1, wsb, [32]byte{2}, pendingAwaitingProcessing, "bar"))
+	r.setPendingReason([32]byte{2}, pendingMissingBlobs)
+
+	blks := r.PendingBlocks()
+	require.Equal(t, 2, len(blks))
+	assert.Equal(t, [32]byte{2}, blks[0].Root)
+	assert.Equal(t, primitives.Slot(1), blks[0].Slot)
+	assert.Equal(t, string(pendingMissingBlobs), blks[0].Reason)
+	assert.Equal(t, peer.ID("bar"), blks[0].Peer)
+	assert.Equal(t, [32]byte{1}, blks[1].Root)
+	assert.Equal(t, [32]byte{'a'}, blks[1].ParentRoot)
+	assert.Equal(t, string(pendingFutureSlot), blks[1].Reason)
+
+	wsb, err = blocks.NewSignedBeaconBlock(b1)
+	require.NoError(t, err)
+	require.NoError(t, r.deleteBlockFromPendingQueue(2, wsb, [32]byte{1}))
+	assert.Equal(t, 1, len(r.PendingBlocks()))
+}
+
+func TestService_InsertBlockToPendingQueue_Full(t *testing.T) {
+	r := &Service{
+		slotToPendingBlocks: gcache.New(time.Second, 2*time.Second),
+		seenPendingBlocks:   make(map[[32]byte]bool),
+		pendingBlockInfo:    make(map[[32]byte]*pendingBlockInfo),
+	}
+	for i := 0; i < maxPendingBlocks; i++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = primitives.Slot(10 + i)
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, r.insertBlockToPendingQueue(b.Block.Slot, wsb, [32]byte{byte(i), byte(i >> 8)}, pendingMissingParent, ""))
+	}
+	require.Equal(t, maxPendingBlocks, len(r.pendingBlockInfo))
+	dropped := testutil.ToFloat64(pendingQueueDroppedCount.WithLabelValues("block"))
+
+	// A block older than the queued ones is dropped.
+	stale := util.NewBeaconBlock()
+	stale.Block.Slot = 5
+	wsb, err := blocks.NewSignedBeaconBlock(stale)
+	require.NoError(t, err)
+	require.NoError(t, r.insertBlockToPendingQueue(5, wsb, [32]byte{'a'}, pendingMissingParent, ""))
+	assert.Equal(t, false, r.seenPendingBlocks[[32]byte{'a'}])
+	assert.Equal(t, 0, len(r.pendingBlocksInCache(5)))
+	assert.Equal(t, maxPendingBlocks, len(r.pendingBlockInfo))
+	assert.Equal(t, dropped+1, testutil.ToFloat64(pendingQueueDroppedCount.WithLabelValues("block")))
+
+	// A newer block evicts the blocks of the oldest slot.
+	recent := util.NewBeaconBlock()
+	recent.Block.Slot = 2000
+	wsb, err = blocks.NewSignedBeaconBlock(recent)
+	require.NoError(t, err)
+	require.NoError(t, r.insertBlockToPendingQueue(2000, wsb, [32]byte{'b'}, pendingMissingParent, ""))
+	assert.Equal(t, true, r.seenPendingBlocks[[32]byte{'b'}])
+	assert.Equal(t, 1, len(r.pendingBlocksInCache(2000)))
+	assert.Equal(t, false, r.seenPendingBlocks[[32]byte{0, 0}])
+	assert.Equal(t, 0, len(r.pendingBlocksInCache(10)))
+	assert.Equal(t, maxPendingBlocks, len(r.pendingBlockInfo))
+	assert.Equal(t, dropped+2, testutil.ToFloat64(pendingQueueDroppedCount.WithLabelValues("block")))
+}
+
+func TestService_RootsByHintPeer(t *testing.T) {
+	p := p2ptest.NewTestP2P(t)
+	active, inactive, attester := peer.ID("active"), peer.ID("inactive"), peer.ID("attester")
+	for _, pid := range []peer.ID{active, inactive, attester} {
+		p.Peers().Add(nil, pid, nil, network.DirOutbound)
+	}
+	p.Peers().SetConnectionState(active, peers.PeerConnected)
+	p.Peers().SetConnectionState(attester, peers.PeerConnected)
+	r := &Service{
+		cfg: &config{p2p: p},
+		pendingBlockInfo: map[[32]byte]*pendingBlockInfo{
+			{1}: {parentRoot: [32]byte{'a'}, peer: active},
+			{2}: {parentRoot: [32]byte{'b'}, peer: inactive},
+		},
+		blkRootToPendingAtts: map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof{},
+	}
+	r.savePendingAtt(&ethpb.SignedAggregateAttestationAndProof{
+		Message: &ethpb.AggregateAttestationAndProof{
+			Aggregate: &ethpb.Attestation{
+				Data: &ethpb.AttestationData{BeaconBlockRoot: bytesutil.PadTo([]byte{'c'}, 32)}}}}, attester)
+
+	byPeer := r.rootsByHintPeer([][32]byte{{'a'}, {'b'}, {'c'}, {'d'}})
+	assert.Equal(t, 2, len(byPeer))
+	assert.DeepEqual(t, [][32]byte{{'a'}}, byPeer[active])
+	assert.DeepEqual(t, [][32]byte{{'c'}}, byPeer[attester])
+	assert.Equal(t, 1, len(r.PendingAttestations()))
+	assert.Equal(t, attester, r.PendingAttestations()[0].Peer)
+}
@@ -0,0 +1,5 @@
+package progress
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "sync-progress")
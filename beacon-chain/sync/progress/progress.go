@@ -0,0 +1,208 @@
+// Package progress keeps track of the individual stages a beacon node goes through while
+// syncing, such as fetching a checkpoint, backfilling blocks or waiting for the execution
+// client, so that the progress of each one can be reported through logs and the node API.
+package progress
+
+import (
+	"sync"
+	"time"
+
+	prysmTime "github.com/prysmaticlabs/prysm/v4/time"
+	"github.com/sirupsen/logrus"
+)
+
+// Stage identifies one of the steps of syncing a beacon node.
+type Stage string
+
+const (
+	// CheckpointSync is the download of the checkpoint state and block the node starts from.
+	CheckpointSync Stage = "checkpoint_sync"
+	// BlockBackfill is the download of the blocks preceding the checkpoint the node started from.
+	BlockBackfill Stage = "block_backfill"
+	// InitialSync is the download and processing of batches of blocks up to the head of the chain.
+	InitialSync Stage = "initial_sync"
+	// BlobBackfill is the download of the blob sidecars within the data availability window.
+	BlobBackfill Stage = "blob_backfill"
+	// ExecutionSync is the sync of the execution client, in execution block numbers, as reported by eth_syncing.
+	ExecutionSync Stage = "execution_sync"
+)
+
+// Stages lists every stage in the order in which a syncing node usually goes through them.
+var Stages = []Stage{CheckpointSync, BlockBackfill, InitialSync, BlobBackfill, ExecutionSync}
+
+// Status is a snapshot of the progress of a single stage. Current and Target are expressed in
+// the unit of the stage, usually slots, and Target is zero when it is not known.
+type Status struct {
+	Stage    Stage
+	Complete bool
+	Start    uint64
+	Current  uint64
+	Target   uint64
+	Started  time.Time
+	Updated  time.Time
+	// Rate is the average number of units processed per second since the stage started.
+	Rate float64
+	// ETA is the estimated time until the target is reached, zero when it can not be estimated.
+	ETA time.Duration
+}
+
+// Percent returns how far the stage is between its start and its target, or 0 when the
+// target is unknown.
+func (s *Status) Percent() float64 {
+	if s.Complete {
+		return 100
+	}
+	if s.Target <= s.Start || s.Current < s.Start {
+		return 0
+	}
+	return 100 * float64(s.Current-s.Start) / float64(s.Target-s.Start)
+}
+
+// Tracker records the progress of the sync stages. It is safe for concurrent use, and all of
+// its methods are no-ops on a nil Tracker so that services can be run without one.
+type Tracker struct {
+	lock   sync.RWMutex
+	stages map[Stage]*Status
+	now    func() time.Time
+}
+
+// NewTracker returns a Tracker with no stage started.
+func NewTracker() *Tracker {
+	return &Tracker{
+		stages: make(map[Stage]*Status),
+		now:    prysmTime.Now,
+	}
+}
+
+// Start marks the stage as in progress from current towards target, resetting any
+// previous progress of the stage.
+func (t *Tracker) Start(stage Stage, current, target uint64) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.now()
+	t.stages[stage] = &Status{
+		Stage:   stage,
+		Start:   current,
+		Current: current,
+		Target:  target,
+		Started: now,
+		Updated: now,
+	}
+	log.WithFields(logrus.Fields{
+		"stage":   stage,
+		"current": current,
+		"target":  target,
+	}).Info("Sync stage started")
+}
+
+// Update records the current position of a stage in progress.
+func (t *Tracker) Update(stage Stage, current uint64) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	s, ok := t.stages[stage]
+	if !ok || s.Complete {
+		return
+	}
+	s.Current = current
+	s.Updated = t.now()
+}
+
+// SetTarget changes the target of a stage in progress, for stages whose end moves while they run.
+func (t *Tracker) SetTarget(stage Stage, target uint64) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	s, ok := t.stages[stage]
+	if !ok || s.Complete {
+		return
+	}
+	s.Target = target
+}
+
+// Complete marks a stage in progress as done. Stages which were never started are left untouched.
+func (t *Tracker) Complete(stage Stage) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	s, ok := t.stages[stage]
+	if !ok || s.Complete {
+		return
+	}
+	s.Complete = true
+	s.Updated = t.now()
+	if s.Target > s.Current {
+		s.Current = s.Target
+	}
+	log.WithFields(logrus.Fields{
+		"stage":   stage,
+		"elapsed": s.Updated.Sub(s.Started).Round(time.Second),
+	}).Info("Sync stage complete")
+}
+
+// Active returns true if the stage was started and is not complete yet.
+func (t *Tracker) Active(stage Stage) bool {
+	if t == nil {
+		return false
+	}
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	s, ok := t.stages[stage]
+	return ok && !s.Complete
+}
+
+// Status returns the progress of the given stage, or nil if it was never started.
+func (t *Tracker) Status(stage Stage) *Status {
+	if t == nil {
+		return nil
+	}
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	s, ok := t.stages[stage]
+	if !ok {
+		return nil
+	}
+	return t.snapshot(s)
+}
+
+// Statuses returns the progress of every stage started so far, in the order of Stages.
+func (t *Tracker) Statuses() []*Status {
+	if t == nil {
+		return nil
+	}
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	statuses := make([]*Status, 0, len(t.stages))
+	for _, stage := range Stages {
+		if s, ok := t.stages[stage]; ok {
+			statuses = append(statuses, t.snapshot(s))
+		}
+	}
+	return statuses
+}
+
+// snapshot copies the status and computes its rate and ETA. The caller must hold the lock.
+func (t *Tracker) snapshot(s *Status) *Status {
+	cp := *s
+	end := cp.Updated
+	if !cp.Complete {
+		end = t.now()
+	}
+	elapsed := end.Sub(cp.Started).Seconds()
+	if elapsed > 0 && cp.Current > cp.Start {
+		cp.Rate = float64(cp.Current-cp.Start) / elapsed
+	}
+	if !cp.Complete && cp.Rate > 0 && cp.Target > cp.Current {
+		cp.ETA = time.Duration(float64(cp.Target-cp.Current)/cp.Rate) * time.Second
+	}
+	return &cp
+}
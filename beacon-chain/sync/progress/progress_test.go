@@ -0,0 +1,71 @@
+package progress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestTracker_Progress(t *testing.T) {
+	tr := NewTracker()
+	now := time.Unix(1000, 0)
+	tr.now = func() time.Time { return now }
+
+	require.Equal(t, (*Status)(nil), tr.Status(InitialSync))
+	tr.Start(InitialSync, 100, 1100)
+	assert.Equal(t, true, tr.Active(InitialSync))
+
+	now = now.Add(10 * time.Second)
+	tr.Update(InitialSync, 300)
+	s := tr.Status(InitialSync)
+	assert.Equal(t, uint64(300), s.Current)
+	assert.Equal(t, float64(20), s.Rate)
+	assert.Equal(t, 40*time.Second, s.ETA)
+	assert.Equal(t, float64(20), s.Percent())
+
+	tr.SetTarget(InitialSync, 1300)
+	assert.Equal(t, 50*time.Second, tr.Status(InitialSync).ETA)
+
+	tr.Complete(InitialSync)
+	s = tr.Status(InitialSync)
+	assert.Equal(t, true, s.Complete)
+	assert.Equal(t, false, tr.Active(InitialSync))
+	assert.Equal(t, uint64(1300), s.Current)
+	assert.Equal(t, time.Duration(0), s.ETA)
+	assert.Equal(t, float64(100), s.Percent())
+
+	// Updates after completion are ignored.
+	tr.Update(InitialSync, 1400)
+	assert.Equal(t, uint64(1300), tr.Status(InitialSync).Current)
+}
+
+func TestTracker_UnknownTarget(t *testing.T) {
+	tr := NewTracker()
+	tr.Start(ExecutionSync, 5, 0)
+	tr.Update(ExecutionSync, 10)
+	s := tr.Status(ExecutionSync)
+	assert.Equal(t, time.Duration(0), s.ETA)
+	assert.Equal(t, float64(0), s.Percent())
+}
+
+func TestTracker_Statuses(t *testing.T) {
+	tr := NewTracker()
+	tr.Complete(BlockBackfill)
+	tr.Start(ExecutionSync, 0, 0)
+	tr.Start(CheckpointSync, 0, 1)
+	statuses := tr.Statuses()
+	require.Equal(t, 2, len(statuses))
+	assert.Equal(t, CheckpointSync, statuses[0].Stage)
+	assert.Equal(t, ExecutionSync, statuses[1].Stage)
+}
+
+func TestTracker_Nil(t *testing.T) {
+	var tr *Tracker
+	tr.Start(InitialSync, 0, 10)
+	tr.Update(InitialSync, 5)
+	tr.Complete(InitialSync)
+	assert.Equal(t, false, tr.Active(InitialSync))
+	assert.Equal(t, 0, len(tr.Statuses()))
+}
@@ -0,0 +1,146 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxRebroadcastBlocks is the number of the latest canonical blocks of the validators of the node which are
+	// broadcast again in each epoch without finality.
+	maxRebroadcastBlocks = 16
+	// rebroadcastDiscoveryLimit is the number of new peers which are looked for in each epoch without finality.
+	rebroadcastDiscoveryLimit   = 16
+	rebroadcastDiscoveryTimeout = 30 * time.Second
+)
+
+// rebroadcastOnLateFinality helps the network recover from partitions while the chain does not finalize. At the start
+// of each epoch after the number of epochs without finality given by --late-finality-rebroadcast-epochs, it broadcasts
+// again the attestations of the validators of the node which can still be propagated and their latest canonical
+// blocks, so that they reach the peers which missed them, and looks for new peers.
+//
+// Messages which gossipsub has seen recently are not published again, so an attestation is only broadcast again
+// once the seen cache of gossipsub has expired, which takes about an epoch.
+func (s *Service) rebroadcastOnLateFinality(currSlot primitives.Slot) {
+	if s.cfg.localBroadcasts == nil || !slots.IsEpochStart(currSlot) {
+		return
+	}
+	finalized := s.cfg.chain.FinalizedCheckpt()
+	finalizedSlot, err := slots.EpochStart(finalized.Epoch)
+	if err != nil {
+		log.WithError(err).Error("Could not get the start slot of the finalized epoch")
+		return
+	}
+	s.cfg.localBroadcasts.PruneBlocks(finalizedSlot)
+
+	delay := flags.Get().LateFinalityRebroadcastEpochs
+	currEpoch := slots.ToEpoch(currSlot)
+	if delay == 0 || currEpoch <= finalized.Epoch+primitives.Epoch(delay) {
+		return
+	}
+	if s.cfg.initialSync.Syncing() {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"epoch":          currEpoch,
+		"finalizedEpoch": finalized.Epoch,
+	}).Warn("Chain is not finalizing, broadcasting the messages of local validators again and looking for new peers")
+
+	go func() {
+		atts, blks := s.rebroadcastLocalMessages(s.ctx, currSlot)
+		rebroadcastMessageCount.WithLabelValues("attestation").Add(float64(atts))
+		rebroadcastMessageCount.WithLabelValues("block").Add(float64(blks))
+		log.WithFields(logrus.Fields{
+			"attestations": atts,
+			"blocks":       blks,
+		}).Info("Broadcast the messages of local validators again")
+	}()
+	if s.cfg.peerController != nil {
+		go func() {
+			ctx, cancel := context.WithTimeout(s.ctx, rebroadcastDiscoveryTimeout)
+			defer cancel()
+			connected, err := s.cfg.peerController.DiscoverPeers(ctx, rebroadcastDiscoveryLimit)
+			if err != nil {
+				log.WithError(err).Debug("Could not discover new peers")
+				return
+			}
+			log.WithField("peers", len(connected)).Debug("Connected to new peers")
+		}()
+	}
+}
+
+// rebroadcastLocalMessages broadcasts the recorded attestations which can still be propagated at the slot, and the
+// latest recorded blocks which are canonical, along with their blob sidecars. It returns the number of attestations
+// and blocks broadcast.
+func (s *Service) rebroadcastLocalMessages(ctx context.Context, currSlot primitives.Slot) (int, int) {
+	var from primitives.Slot
+	if propagationRange := params.BeaconNetworkConfig().AttestationPropagationSlotRange; currSlot > propagationRange {
+		from = currSlot - propagationRange
+	}
+	var attCount int
+	for _, a := range s.cfg.localBroadcasts.Attestations(from) {
+		if err := s.cfg.p2p.BroadcastAttestation(ctx, a.Subnet, a.Attestation); err != nil {
+			log.WithError(err).Debug("Could not broadcast attestation again")
+			continue
+		}
+		attCount++
+	}
+
+	var blkCount int
+	for _, root := range s.cfg.localBroadcasts.BlockRoots() {
+		if blkCount == maxRebroadcastBlocks {
+			break
+		}
+		if err := s.rebroadcastBlock(ctx, root); err != nil {
+			log.WithError(err).WithField("blockRoot", fmt.Sprintf("%#x", root)).Debug("Could not broadcast block again")
+			continue
+		}
+		blkCount++
+	}
+	return attCount, blkCount
+}
+
+func (s *Service) rebroadcastBlock(ctx context.Context, root [32]byte) error {
+	canonical, err := s.cfg.chain.IsCanonical(ctx, root)
+	if err != nil {
+		return errors.Wrap(err, "could not check if block is canonical")
+	}
+	if !canonical {
+		return errors.New("block is not canonical")
+	}
+	blk, err := s.cfg.beaconDB.Block(ctx, root)
+	if err != nil {
+		return errors.Wrap(err, "could not get block")
+	}
+	if blk == nil || blk.IsNil() {
+		return errors.New("block is not in the database")
+	}
+	if blk.IsBlinded() {
+		blk, err = s.cfg.executionPayloadReconstructor.ReconstructFullBlock(ctx, blk)
+		if err != nil {
+			return errors.Wrap(err, "could not reconstruct full block")
+		}
+	}
+	pb, err := blk.Proto()
+	if err != nil {
+		return err
+	}
+	if err := s.cfg.p2p.Broadcast(ctx, pb); err != nil {
+		return err
+	}
+	// Peers which missed the block can only import it along with its blob sidecars.
+	for _, sc := range s.cfg.localBroadcasts.BlobSidecars(root) {
+		if err := s.cfg.p2p.BroadcastBlob(ctx, sc.Message.Index, sc); err != nil {
+			return errors.Wrapf(err, "could not broadcast blob sidecar %d", sc.Message.Index)
+		}
+	}
+	return nil
+}
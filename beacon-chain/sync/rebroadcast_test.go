@@ -0,0 +1,90 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	mockChain "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
+	testingdb "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	mockp2p "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	mockSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestRebroadcastLocalMessages(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testingdb.SetupDB(t)
+	canonical := util.NewBeaconBlock()
+	canonical.Block.Slot = 40
+	canonicalRoot, err := canonical.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, canonical)
+	orphaned := util.NewBeaconBlock()
+	orphaned.Block.Slot = 41
+	orphanedRoot, err := orphaned.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, orphaned)
+
+	sidecars := []*ethpb.SignedBlobSidecar{{Message: &ethpb.BlobSidecar{Index: 0}}, {Message: &ethpb.BlobSidecar{Index: 1}}}
+	localBroadcasts := cache.NewLocalBroadcastsCache()
+	localBroadcasts.AddBlock(canonicalRoot, 40, sidecars)
+	localBroadcasts.AddBlock(orphanedRoot, 41, sidecars)
+	localBroadcasts.AddAttestation(1, util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 10}}))
+	localBroadcasts.AddAttestation(2, util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 40}}))
+
+	p := &blobRecordingP2P{TestP2P: mockp2p.NewTestP2P(t)}
+	chainService := &mockChain.ChainService{CanonicalRoots: map[[32]byte]bool{canonicalRoot: true}}
+	s := NewService(ctx,
+		WithP2P(p),
+		WithDatabase(beaconDB),
+		WithInitialSync(&mockSync.Sync{IsSyncing: false}),
+		WithChainService(chainService),
+		WithLocalBroadcastsCache(localBroadcasts),
+	)
+
+	// The attestation at slot 10 can no longer be propagated at slot 64, and the block at slot 41 is not canonical.
+	atts, blks := s.rebroadcastLocalMessages(ctx, 64)
+	assert.Equal(t, 1, atts)
+	assert.Equal(t, 1, blks)
+	assert.Equal(t, true, p.BroadcastCalled)
+	// The blob sidecars of the canonical block are broadcast along with it.
+	assert.DeepEqual(t, []uint64{0, 1}, p.blobSubnets)
+}
+
+// blobRecordingP2P records the subnets of the blob sidecars it broadcasts.
+type blobRecordingP2P struct {
+	*mockp2p.TestP2P
+	blobSubnets []uint64
+}
+
+func (p *blobRecordingP2P) BroadcastBlob(_ context.Context, subnet uint64, _ *ethpb.SignedBlobSidecar) error {
+	p.blobSubnets = append(p.blobSubnets, subnet)
+	return nil
+}
+
+func TestRebroadcastOnLateFinality_FinalizingChain(t *testing.T) {
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{LateFinalityRebroadcastEpochs: 4})
+	defer flags.Init(resetFlags)
+
+	localBroadcasts := cache.NewLocalBroadcastsCache()
+	localBroadcasts.AddBlock([32]byte{'a'}, 40, nil)
+	localBroadcasts.AddBlock([32]byte{'b'}, 100, nil)
+	p := mockp2p.NewTestP2P(t)
+	s := NewService(context.Background(),
+		WithP2P(p),
+		WithInitialSync(&mockSync.Sync{IsSyncing: false}),
+		WithChainService(&mockChain.ChainService{FinalizedCheckPoint: &ethpb.Checkpoint{Epoch: 2}}),
+		WithLocalBroadcastsCache(localBroadcasts),
+	)
+
+	// Blocks before the finalized checkpoint are pruned, and nothing is broadcast while the chain finalizes.
+	s.rebroadcastOnLateFinality(6 * 32)
+	assert.DeepEqual(t, [][32]byte{{'b'}}, localBroadcasts.BlockRoots())
+	assert.Equal(t, false, p.BroadcastCalled)
+}
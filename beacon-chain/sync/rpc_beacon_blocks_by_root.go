@@ -28,7 +28,7 @@ func (s *Service) sendRecentBeaconBlocksRequest(ctx context.Context, blockRoots
 		}
 		s.pendingQueueLock.Lock()
 		defer s.pendingQueueLock.Unlock()
-		if err := s.insertBlockToPendingQueue(blk.Block().Slot(), blk, blkRoot); err != nil {
+		if err := s.insertBlockToPendingQueue(blk.Block().Slot(), blk, blkRoot, pendingAwaitingProcessing, id); err != nil {
 			return err
 		}
 		return nil
@@ -44,6 +44,9 @@ func (s *Service) sendRecentBeaconBlocksRequest(ctx context.Context, blockRoots
 			return err
 		}
 		if err := s.requestPendingBlobs(ctx, blk.Block(), blkRoot[:], id); err != nil {
+			s.pendingQueueLock.Lock()
+			s.setPendingReason(blkRoot, pendingMissingBlobs)
+			s.pendingQueueLock.Unlock()
 			return err
 		}
 	}
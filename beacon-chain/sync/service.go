@@ -20,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/async/abool"
 	"github.com/prysmaticlabs/prysm/v4/async/event"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
 	blockfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
@@ -33,8 +34,10 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
 	lruwrpr "github.com/prysmaticlabs/prysm/v4/cache/lru"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	leakybucket "github.com/prysmaticlabs/prysm/v4/container/leaky-bucket"
+	"github.com/prysmaticlabs/prysm/v4/encoding/era"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/runtime"
 	prysmTime "github.com/prysmaticlabs/prysm/v4/time"
@@ -53,6 +56,7 @@ const seenSyncContributionSize = 512 // Maximum of SYNC_COMMITTEE_SIZE as specif
 const seenExitSize = 100
 const seenProposerSlashingSize = 100
 const badBlockSize = 1000
+const gossipSpanSize = 10000
 const syncMetricsInterval = 10 * time.Second
 
 var (
@@ -87,7 +91,11 @@ type config struct {
 	stateGen                      *stategen.State
 	slasherAttestationsFeed       *event.Feed
 	slasherBlockHeadersFeed       *event.Feed
+	slasherBlobSidecarsFeed       *event.Feed
 	clock                         *startup.Clock
+	eraStore                      *era.Store
+	localBroadcasts               *cache.LocalBroadcastsCache
+	peerController                p2p.PeerController
 }
 
 // This defines the interface for interacting with block chain service
@@ -104,6 +112,7 @@ type blockchainService interface {
 	blockchain.OptimisticModeFetcher
 	blockchain.SlashingReceiver
 	blockchain.ForkchoiceFetcher
+	blockchain.LightClientFetcher
 }
 
 // Service is responsible for handling all run time p2p related operations as the
@@ -114,7 +123,9 @@ type Service struct {
 	cancel                           context.CancelFunc
 	slotToPendingBlocks              *gcache.Cache
 	seenPendingBlocks                map[[32]byte]bool
+	pendingBlockInfo                 map[[32]byte]*pendingBlockInfo
 	blkRootToPendingAtts             map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof
+	pendingAttsPeers                 map[[32]byte]peer.ID
 	subHandler                       *subTopicHandler
 	pendingAttsLock                  sync.RWMutex
 	pendingQueueLock                 sync.RWMutex
@@ -133,6 +144,8 @@ type Service struct {
 	seenExitCache                    *lru.Cache
 	seenProposerSlashingLock         sync.RWMutex
 	seenProposerSlashingCache        *lru.Cache
+	subnetSearchLock                 sync.Mutex
+	subnetSearches                   map[string]bool
 	seenAttesterSlashingLock         sync.RWMutex
 	seenAttesterSlashingCache        map[uint64]bool
 	seenSyncMessageLock              sync.RWMutex
@@ -143,7 +156,9 @@ type Service struct {
 	badBlockLock                     sync.RWMutex
 	syncContributionBitsOverlapLock  sync.RWMutex
 	syncContributionBitsOverlapCache *lru.Cache
+	gossipSpanCache                  *lru.Cache
 	signatureChan                    chan *signatureVerifier
+	attVerifierQueue                 *subnetVerifierQueue
 	clockWaiter                      startup.ClockWaiter
 	initialSyncComplete              chan struct{}
 }
@@ -159,8 +174,11 @@ func NewService(ctx context.Context, opts ...Option) *Service {
 		cfg:                  &config{clock: startup.NewClock(time.Unix(0, 0), [32]byte{})},
 		slotToPendingBlocks:  c,
 		seenPendingBlocks:    make(map[[32]byte]bool),
+		pendingBlockInfo:     make(map[[32]byte]*pendingBlockInfo),
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
+		pendingAttsPeers:     make(map[[32]byte]peer.ID),
 		signatureChan:        make(chan *signatureVerifier, verifierLimit),
+		attVerifierQueue:     newSubnetVerifierQueue(),
 	}
 	for _, opt := range opts {
 		if err := opt(r); err != nil {
@@ -238,6 +256,7 @@ func (s *Service) initCaches() {
 	s.seenAttesterSlashingCache = make(map[uint64]bool)
 	s.seenProposerSlashingCache = lruwrpr.New(seenProposerSlashingSize)
 	s.badBlockCache = lruwrpr.New(badBlockSize)
+	s.gossipSpanCache = lruwrpr.New(gossipSpanSize)
 }
 
 func (s *Service) waitForChainStart() {
@@ -261,17 +280,17 @@ func (s *Service) waitForChainStart() {
 
 func (s *Service) registerHandlers() {
 	s.waitForChainStart()
+	// With fast resume, gossip subscriptions start as soon as the head state is loaded. Gossip validators
+	// ignore messages until initial sync is complete, but the node is part of the topic meshes by then.
+	if flags.Get().FastResume {
+		log.Debug("Subscribing to gossip topics before initial sync completes")
+		s.registerGossipHandlers()
+		return
+	}
 	select {
 	case <-s.initialSyncComplete:
 		// Register respective pubsub handlers at state synced event.
-		digest, err := s.currentForkDigest()
-		if err != nil {
-			log.WithError(err).Error("Could not retrieve current fork digest")
-			return
-		}
-		currentEpoch := slots.ToEpoch(slots.CurrentSlot(uint64(s.cfg.clock.GenesisTime().Unix())))
-		s.registerSubscribers(currentEpoch, digest)
-		go s.forkWatcher()
+		s.registerGossipHandlers()
 		return
 	case <-s.ctx.Done():
 		log.Debug("Context closed, exiting goroutine")
@@ -279,6 +298,18 @@ func (s *Service) registerHandlers() {
 	}
 }
 
+// registerGossipHandlers subscribes to the gossip topics of the current epoch and starts watching for forks.
+func (s *Service) registerGossipHandlers() {
+	digest, err := s.currentForkDigest()
+	if err != nil {
+		log.WithError(err).Error("Could not retrieve current fork digest")
+		return
+	}
+	currentEpoch := slots.ToEpoch(slots.CurrentSlot(uint64(s.cfg.clock.GenesisTime().Unix())))
+	s.registerSubscribers(currentEpoch, digest)
+	go s.forkWatcher()
+}
+
 func (s *Service) writeErrorResponseToStream(responseCode byte, reason string, stream libp2pcore.Stream) {
 	writeErrorResponseToStream(responseCode, reason, stream, s.cfg.p2p)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
 	state_native "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
 	mockSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
@@ -177,6 +178,50 @@ func TestSyncHandlers_WaitTillSynced(t *testing.T) {
 	assert.NoError(t, ctx.Err())
 }
 
+func TestSyncHandlers_FastResume(t *testing.T) {
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{FastResume: true})
+	defer func() {
+		flags.Init(resetFlags)
+	}()
+	p2p := p2ptest.NewTestP2P(t)
+	chainService := &mockChain.ChainService{
+		Genesis:        time.Now(),
+		ValidatorsRoot: [32]byte{'A'},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gs := startup.NewClockSynchronizer()
+	r := Service{
+		ctx: ctx,
+		cfg: &config{
+			p2p:         p2p,
+			chain:       chainService,
+			initialSync: &mockSync.Sync{IsSyncing: true},
+		},
+		chainStarted:        abool.New(),
+		subHandler:          newSubTopicHandler(),
+		clockWaiter:         gs,
+		initialSyncComplete: make(chan struct{}),
+	}
+
+	done := make(chan struct{})
+	go func() {
+		r.registerHandlers()
+		close(done)
+	}()
+	var vr [32]byte
+	require.NoError(t, gs.SetClock(startup.NewClock(time.Now(), vr)))
+
+	// Gossip topics are subscribed to without waiting for initial sync to complete.
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Did not register gossip handlers before initial sync completed")
+	}
+	require.NotEqual(t, 0, len(r.cfg.p2p.PubSub().GetTopics()))
+}
+
 func TestSyncService_StopCleanly(t *testing.T) {
 	p2p := p2ptest.NewTestP2P(t)
 	chainService := &mockChain.ChainService{
@@ -0,0 +1,84 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// attSubnetPeerTarget returns the number of peers the node looks for in an attestation subnet. Subnets in which the
+// validators of the node aggregate are given more peers, as the aggregates are built from the attestations received
+// from them.
+func attSubnetPeerTarget(aggregator bool) int {
+	target := flags.Get().MinimumPeersPerSubnet
+	if aggregator && flags.Get().AggregatorPeersPerSubnet > target {
+		target = flags.Get().AggregatorPeersPerSubnet
+	}
+	return target
+}
+
+// searchUpcomingSubnetPeers looks for peers in the subnets in which the validators of the node have duties in the
+// current or next epoch and which have fewer peers than their target: the attestation subnets in which they aggregate,
+// and the sync committee subnets of the next epoch. The discovery queries filter the nodes on the attnets and syncnets
+// fields of their ENR.
+//
+// Searches run in the background and are bounded to a slot, and a subnet is searched again once its previous search
+// ended, so that the node keeps looking for peers while the subnet is short of them.
+func (s *Service) searchUpcomingSubnetPeers(digest [4]byte, currentSlot primitives.Slot) {
+	attTopic := p2p.GossipTypeMapping[reflect.TypeOf(&ethpb.Attestation{})]
+	for _, idx := range s.aggregatorSubnetIndices(currentSlot) {
+		s.searchSubnetPeers(fmt.Sprintf(attTopic, digest, idx), idx, attSubnetPeerTarget(true))
+	}
+	syncTopic := p2p.GossipTypeMapping[reflect.TypeOf(&ethpb.SyncCommitteeMessage{})]
+	for _, idx := range cache.SyncSubnetIDs.GetAllSubnets(slots.ToEpoch(currentSlot) + 1) {
+		s.searchSubnetPeers(fmt.Sprintf(syncTopic, digest, idx), idx, flags.Get().MinimumPeersPerSubnet)
+	}
+}
+
+// searchSubnetPeers starts a search for peers in a subnet if it has fewer peers than the target, and no search for it
+// is running.
+func (s *Service) searchSubnetPeers(subnetTopic string, idx uint64, target int) {
+	peers := len(s.cfg.p2p.PubSub().ListPeers(subnetTopic + s.cfg.p2p.Encoding().ProtocolSuffix()))
+	if peers >= target {
+		return
+	}
+	s.subnetSearchLock.Lock()
+	if s.subnetSearches == nil {
+		s.subnetSearches = make(map[string]bool)
+	}
+	if s.subnetSearches[subnetTopic] {
+		s.subnetSearchLock.Unlock()
+		return
+	}
+	s.subnetSearches[subnetTopic] = true
+	s.subnetSearchLock.Unlock()
+
+	subnetPeerSearchCount.WithLabelValues(subnetTopic).Inc()
+	log.WithFields(logrus.Fields{
+		"topic":  subnetTopic,
+		"peers":  peers,
+		"target": target,
+	}).Debug("Searching network for peers subscribed to subnet with upcoming duties")
+	go func() {
+		defer func() {
+			s.subnetSearchLock.Lock()
+			delete(s.subnetSearches, subnetTopic)
+			s.subnetSearchLock.Unlock()
+		}()
+		ctx, cancel := context.WithTimeout(s.ctx, time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second)
+		defer cancel()
+		if _, err := s.cfg.p2p.FindPeersWithSubnet(ctx, subnetTopic, idx, target); err != nil {
+			log.WithError(err).Debug("Could not find enough peers for subnet")
+		}
+	}()
+}
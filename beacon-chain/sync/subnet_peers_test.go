@@ -0,0 +1,51 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	p2ptest "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+)
+
+func TestAttSubnetPeerTarget(t *testing.T) {
+	resetFlags := flags.Get()
+	defer flags.Init(resetFlags)
+
+	flags.Init(&flags.GlobalFlags{MinimumPeersPerSubnet: 6, AggregatorPeersPerSubnet: 10})
+	assert.Equal(t, 6, attSubnetPeerTarget(false))
+	assert.Equal(t, 10, attSubnetPeerTarget(true))
+
+	flags.Init(&flags.GlobalFlags{MinimumPeersPerSubnet: 6, AggregatorPeersPerSubnet: 2})
+	assert.Equal(t, 6, attSubnetPeerTarget(true))
+}
+
+func TestSearchSubnetPeers(t *testing.T) {
+	s := &Service{
+		ctx: context.Background(),
+		cfg: &config{p2p: p2ptest.NewTestP2P(t)},
+	}
+
+	// Subnets with enough peers are not searched.
+	s.searchSubnetPeers("/eth2/%x/beacon_attestation_1", 1, 0)
+	assert.Equal(t, 0, len(s.subnetSearches))
+
+	// A subnet is not searched again while its search runs.
+	s.subnetSearches = map[string]bool{"/eth2/%x/beacon_attestation_2": true}
+	s.searchSubnetPeers("/eth2/%x/beacon_attestation_2", 2, 1)
+	assert.Equal(t, true, s.subnetSearches["/eth2/%x/beacon_attestation_2"])
+
+	s.searchSubnetPeers("/eth2/%x/beacon_attestation_3", 3, 1)
+	for i := 0; i < 100; i++ {
+		s.subnetSearchLock.Lock()
+		running := s.subnetSearches["/eth2/%x/beacon_attestation_3"]
+		s.subnetSearchLock.Unlock()
+		if !running {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Search did not end")
+}
@@ -0,0 +1,109 @@
+package sync
+
+import (
+	"sync"
+)
+
+// subnetVerifierQueueLimit is the maximum number of attestation signature sets waiting for
+// verification on a single subnet.
+const subnetVerifierQueueLimit = 512
+
+// subnetVerifierQueue holds attestation signature sets waiting for batch verification, with a
+// separate queue per attestation subnet. Batches are filled by taking one set from every subnet
+// in turn, so a flood of attestations on a few subnets only delays the verification of those
+// subnets. Subnets on which our validators aggregate are served before all other ones, as their
+// attestations are needed to produce aggregates in time.
+type subnetVerifierQueue struct {
+	lock sync.Mutex
+	// tiers holds the aggregator subnets first and all other subnets second.
+	tiers  [2]roundRobinQueue
+	notify chan struct{}
+}
+
+// roundRobinQueue holds queued signature sets by subnet. order lists the subnets with queued sets,
+// in the order in which they are served.
+type roundRobinQueue struct {
+	queues map[uint64][]*signatureVerifier
+	order  []uint64
+}
+
+func newSubnetVerifierQueue() *subnetVerifierQueue {
+	q := &subnetVerifierQueue{notify: make(chan struct{}, 1)}
+	for i := range q.tiers {
+		q.tiers[i].queues = make(map[uint64][]*signatureVerifier)
+	}
+	return q
+}
+
+// push queues the signature set of an attestation received on the given subnet. It returns false
+// if the queue of the subnet is full.
+func (q *subnetVerifierQueue) push(subnet uint64, aggregator bool, v *signatureVerifier) bool {
+	tier := &q.tiers[1]
+	if aggregator {
+		tier = &q.tiers[0]
+	}
+	q.lock.Lock()
+	queue := tier.queues[subnet]
+	if len(queue) >= subnetVerifierQueueLimit {
+		q.lock.Unlock()
+		return false
+	}
+	if len(queue) == 0 {
+		tier.order = append(tier.order, subnet)
+	}
+	tier.queues[subnet] = append(queue, v)
+	q.lock.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// pop removes up to n signature sets from the queue.
+func (q *subnetVerifierQueue) pop(n int) []*signatureVerifier {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	var sets []*signatureVerifier
+	for i := range q.tiers {
+		tier := &q.tiers[i]
+		for len(sets) < n && len(tier.order) > 0 {
+			subnet := tier.order[0]
+			queue := tier.queues[subnet]
+			sets = append(sets, queue[0])
+			queue[0] = nil
+			tier.order = tier.order[1:]
+			if len(queue) == 1 {
+				delete(tier.queues, subnet)
+				continue
+			}
+			tier.queues[subnet] = queue[1:]
+			// Move the subnet to the back of the line.
+			tier.order = append(tier.order, subnet)
+		}
+	}
+	return sets
+}
+
+// len returns the number of queued signature sets.
+func (q *subnetVerifierQueue) len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	n := 0
+	for i := range q.tiers {
+		for _, queue := range q.tiers[i].queues {
+			n += len(queue)
+		}
+	}
+	return n
+}
+
+// notifyChan returns a channel signalled when signature sets are queued. A nil queue returns a
+// nil channel, which is never ready.
+func (q *subnetVerifierQueue) notifyChan() <-chan struct{} {
+	if q == nil {
+		return nil
+	}
+	return q.notify
+}
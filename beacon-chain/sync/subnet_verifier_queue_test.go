@@ -0,0 +1,92 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestSubnetVerifierQueue_RoundRobin(t *testing.T) {
+	q := newSubnetVerifierQueue()
+	sets := make(map[*signatureVerifier]uint64)
+	push := func(subnet uint64, aggregator bool) {
+		v := &signatureVerifier{}
+		sets[v] = subnet
+		require.Equal(t, true, q.push(subnet, aggregator, v))
+	}
+	// A flood on subnet 1, and a single attestation on subnets 2 and 3.
+	for i := 0; i < 10; i++ {
+		push(1, false)
+	}
+	push(2, false)
+	push(3, true)
+	assert.Equal(t, 12, q.len())
+
+	var subnets []uint64
+	for _, v := range q.pop(4) {
+		subnets = append(subnets, sets[v])
+	}
+	// The aggregator subnet comes first, followed by one set of every other subnet in turn.
+	assert.DeepEqual(t, []uint64{3, 1, 2, 1}, subnets)
+	assert.Equal(t, 8, q.len())
+	assert.Equal(t, 8, len(q.pop(100)))
+	assert.Equal(t, 0, q.len())
+	assert.Equal(t, 0, len(q.pop(100)))
+}
+
+func TestSubnetVerifierQueue_Limit(t *testing.T) {
+	q := newSubnetVerifierQueue()
+	for i := 0; i < subnetVerifierQueueLimit; i++ {
+		require.Equal(t, true, q.push(1, false, &signatureVerifier{}))
+	}
+	assert.Equal(t, false, q.push(1, false, &signatureVerifier{}))
+	// Other subnets are unaffected.
+	assert.Equal(t, true, q.push(2, false, &signatureVerifier{}))
+	assert.Equal(t, true, q.push(1, true, &signatureVerifier{}))
+}
+
+func TestValidateAttestationWithBatchVerifier(t *testing.T) {
+	_, keys, err := util.DeterministicDepositsAndKeys(2)
+	require.NoError(t, err)
+	set := func(key bls.SecretKey) *bls.SignatureBatch {
+		return &bls.SignatureBatch{
+			Messages:     [][32]byte{{}},
+			PublicKeys:   []bls.PublicKey{keys[0].PublicKey()},
+			Signatures:   [][]byte{key.Sign(make([]byte, 32)).Marshal()},
+			Descriptions: []string{signing.UnknownSignature},
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc := &Service{
+		ctx:              ctx,
+		cancel:           cancel,
+		signatureChan:    make(chan *signatureVerifier, verifierLimit),
+		attVerifierQueue: newSubnetVerifierQueue(),
+	}
+	go svc.verifierRoutine()
+
+	res, err := svc.validateAttestationWithBatchVerifier(ctx, 1, false, set(keys[0]))
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationAccept, res)
+	res, err = svc.validateAttestationWithBatchVerifier(ctx, 2, true, set(keys[1]))
+	require.NotNil(t, err)
+	assert.Equal(t, pubsub.ValidationReject, res)
+
+	// A full subnet queue ignores further attestations of the subnet.
+	full := newSubnetVerifierQueue()
+	for i := 0; i < subnetVerifierQueueLimit; i++ {
+		require.Equal(t, true, full.push(1, false, &signatureVerifier{}))
+	}
+	svc = &Service{attVerifierQueue: full}
+	res, err = svc.validateAttestationWithBatchVerifier(ctx, 1, false, set(keys[0]))
+	require.ErrorIs(t, err, errVerifierQueueFull)
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+}
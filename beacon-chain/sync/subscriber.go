@@ -123,6 +123,20 @@ func (s *Service) registerSubscribers(epoch primitives.Epoch, digest [4]byte) {
 				digest,
 			)
 		}
+		if features.Get().EnableLightClient {
+			s.subscribe(
+				p2p.LightClientFinalityUpdateTopicFormat,
+				s.validateLightClientFinalityUpdate,
+				s.lightClientUpdateSubscriber,
+				digest,
+			)
+			s.subscribe(
+				p2p.LightClientOptimisticUpdateTopicFormat,
+				s.validateLightClientOptimisticUpdate,
+				s.lightClientUpdateSubscriber,
+				digest,
+			)
+		}
 	}
 
 	// New Gossip Topic in Capella
@@ -195,7 +209,13 @@ func (s *Service) subscribeWithBase(topic string, validator wrappedVal, handle s
 	pipeline := func(msg *pubsub.Message) {
 		ctx, cancel := context.WithTimeout(s.ctx, pubsubMessageTimeout)
 		defer cancel()
-		ctx, span := trace.StartSpan(ctx, "sync.pubsub")
+		var span *trace.Span
+		if parent, ok := s.gossipSpan(msg.ID); ok {
+			// Continue the trace of the receipt and validation of the message.
+			ctx, span = trace.StartSpanWithRemoteParent(ctx, "sync.pubsub", parent)
+		} else {
+			ctx, span = trace.StartSpan(ctx, "sync.pubsub")
+		}
 		defer span.End()
 
 		defer func() {
@@ -260,6 +280,9 @@ func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, p
 		res = pubsub.ValidationIgnore // Default: ignore any message that panics.
 		ctx, cancel := context.WithTimeout(ctx, pubsubMessageTimeout)
 		defer cancel()
+		ctx, span := trace.StartSpan(ctx, "sync.gossip")
+		defer span.End()
+		span.AddAttributes(trace.StringAttribute("topic", topic), trace.StringAttribute("peer", pid.String()))
 		messageReceivedCounter.WithLabelValues(topic).Inc()
 		if msg.Topic == nil {
 			messageFailedValidationCounter.WithLabelValues(topic).Inc()
@@ -311,10 +334,36 @@ func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, p
 			}
 			messageIgnoredValidationCounter.WithLabelValues(topic).Inc()
 		}
+		if b == pubsub.ValidationAccept {
+			s.setGossipSpan(msg.ID, span.SpanContext())
+		}
 		return b
 	}
 }
 
+// setGossipSpan records the span of the validation of a sampled gossip message, so that the handling of the
+// message is traced as part of the same trace.
+func (s *Service) setGossipSpan(msgID string, sc trace.SpanContext) {
+	if s.gossipSpanCache == nil || !sc.IsSampled() {
+		return
+	}
+	s.gossipSpanCache.Add(msgID, sc)
+}
+
+// gossipSpan returns the span of the validation of a gossip message, if it was sampled.
+func (s *Service) gossipSpan(msgID string) (trace.SpanContext, bool) {
+	if s.gossipSpanCache == nil {
+		return trace.SpanContext{}, false
+	}
+	v, ok := s.gossipSpanCache.Get(msgID)
+	if !ok {
+		return trace.SpanContext{}, false
+	}
+	s.gossipSpanCache.Remove(msgID)
+	sc, ok := v.(trace.SpanContext)
+	return sc, ok
+}
+
 // subscribe to a static subnet  with the given topic and index.A given validator and subscription handler is
 // used to handle messages from the subnet. The base protobuf message is used to initialize new messages for decoding.
 func (s *Service) subscribeStaticWithSubnets(topic string, validator wrappedVal, handle subHandler, digest [4]byte, subnetCount uint64) {
@@ -440,6 +489,8 @@ func (s *Service) subscribeDynamicWithSubnets(
 				for _, idx := range attesterSubs {
 					s.lookupAttesterSubnets(digest, idx)
 				}
+				// look for more peers in the subnets with upcoming duties.
+				s.searchUpcomingSubnetPeers(digest, currentSlot)
 			}
 		}
 	}()
@@ -706,6 +757,10 @@ func (s *Service) filterNeededPeers(pids []peer.ID) []peer.ID {
 	currSlot := s.cfg.clock.CurrentSlot()
 	wantedSubs := s.retrievePersistentSubs(currSlot)
 	wantedSubs = slice.SetUint64(append(wantedSubs, s.attesterSubnetIndices(currSlot)...))
+	aggregatorSubs := make(map[uint64]bool)
+	for _, sub := range s.aggregatorSubnetIndices(currSlot) {
+		aggregatorSubs[sub] = true
+	}
 	topic := p2p.GossipTypeMapping[reflect.TypeOf(&ethpb.Attestation{})]
 
 	// Map of peers in subnets
@@ -714,10 +769,10 @@ func (s *Service) filterNeededPeers(pids []peer.ID) []peer.ID {
 	for _, sub := range wantedSubs {
 		subnetTopic := fmt.Sprintf(topic, digest, sub) + s.cfg.p2p.Encoding().ProtocolSuffix()
 		ps := s.cfg.p2p.PubSub().ListPeers(subnetTopic)
-		if len(ps) > flags.Get().MinimumPeersPerSubnet {
-			// In the event we have more than the minimum, we can
+		if target := attSubnetPeerTarget(aggregatorSubs[sub]); len(ps) > target {
+			// In the event we have more than the target, we can
 			// mark the remaining as viable for pruning.
-			ps = ps[:flags.Get().MinimumPeersPerSubnet]
+			ps = ps[:target]
 		}
 		// Add peer to peer map.
 		for _, p := range ps {
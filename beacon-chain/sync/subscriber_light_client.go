@@ -0,0 +1,22 @@
+package sync
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+// lightClientUpdateSubscriber only relays light client updates, as the node serves the updates it computes
+// from the blocks it imports.
+func (s *Service) lightClientUpdateSubscriber(_ context.Context, msg proto.Message) error {
+	switch msg.(type) {
+	case *ethpb.LightClientFinalityUpdate, *ethpb.LightClientOptimisticUpdate,
+		*ethpb.LightClientFinalityUpdateCapella, *ethpb.LightClientOptimisticUpdateCapella,
+		*ethpb.LightClientFinalityUpdateDeneb, *ethpb.LightClientOptimisticUpdateDeneb:
+		return nil
+	default:
+		return errors.Errorf("incorrect type of message received, wanted a light client update but got %T", msg)
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	logTest "github.com/sirupsen/logrus/hooks/test"
+	"go.opencensus.io/trace"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -457,6 +458,31 @@ func Test_wrapAndReportValidation(t *testing.T) {
 	}
 }
 
+func TestService_gossipSpan(t *testing.T) {
+	s := &Service{gossipSpanCache: lruwrpr.New(10)}
+	sampled := trace.SpanContext{
+		TraceID:      trace.TraceID{1},
+		SpanID:       trace.SpanID{2},
+		TraceOptions: trace.TraceOptions(1),
+	}
+	s.setGossipSpan("sampled", sampled)
+	s.setGossipSpan("unsampled", trace.SpanContext{TraceID: trace.TraceID{3}, SpanID: trace.SpanID{4}})
+
+	sc, ok := s.gossipSpan("sampled")
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, sampled, sc)
+	_, ok = s.gossipSpan("sampled")
+	assert.Equal(t, false, ok, "Span was not removed once retrieved")
+	_, ok = s.gossipSpan("unsampled")
+	assert.Equal(t, false, ok, "Unsampled span was recorded")
+
+	// Services created without caches do not record spans.
+	s = &Service{}
+	s.setGossipSpan("sampled", sampled)
+	_, ok = s.gossipSpan("sampled")
+	assert.Equal(t, false, ok)
+}
+
 func TestFilterSubnetPeers(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	cfg := params.MainnetConfig().Copy()
@@ -98,6 +98,11 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 		return pubsub.ValidationReject, err
 	}
 
+	// Ignore a second distinct block signed by the proposer for the slot.
+	if err := s.validateNoEquivocation(ctx, pid, blk); err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+
 	// Verify the block is the first block received for the proposer for the slot.
 	if s.hasSeenBlockIndexSlot(blk.Block().Slot(), blk.Block().ProposerIndex()) {
 		return pubsub.ValidationIgnore, nil
@@ -157,7 +162,7 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 	// Otherwise queue it for processing in the right slot.
 	if isBlockQueueable(genesisTime, blk.Block().Slot(), receivedTime) {
 		s.pendingQueueLock.Lock()
-		if err := s.insertBlockToPendingQueue(blk.Block().Slot(), blk, blockRoot); err != nil {
+		if err := s.insertBlockToPendingQueue(blk.Block().Slot(), blk, blockRoot, pendingFutureSlot, pid); err != nil {
 			s.pendingQueueLock.Unlock()
 			log.WithError(err).WithFields(getBlockFields(blk)).Debug("Could not insert block to pending queue")
 			return pubsub.ValidationIgnore, err
@@ -171,7 +176,7 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 	// Handle block when the parent is unknown.
 	if !s.cfg.chain.HasBlock(ctx, blk.Block().ParentRoot()) {
 		s.pendingQueueLock.Lock()
-		if err := s.insertBlockToPendingQueue(blk.Block().Slot(), blk, blockRoot); err != nil {
+		if err := s.insertBlockToPendingQueue(blk.Block().Slot(), blk, blockRoot, pendingMissingParent, pid); err != nil {
 			s.pendingQueueLock.Unlock()
 			log.WithError(err).WithFields(getBlockFields(blk)).Debug("Could not insert block to pending queue")
 			return pubsub.ValidationIgnore, err
@@ -200,6 +205,7 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 		return pubsub.ValidationIgnore, err
 	}
 	msg.ValidatorData = blkPb // Used in downstream subscriber
+	s.setProposerSlotBlock(blk, blockRoot, pid)
 
 	// Log the arrival time of the accepted block
 	startTime, err := slots.ToTime(genesisTime, blk.Block().Slot())
@@ -351,12 +357,13 @@ func (s *Service) hasSeenBlockIndexSlot(slot primitives.Slot, proposerIdx primit
 	return seen
 }
 
-// Set block proposer index and slot as seen for incoming blocks.
+// Set block proposer index and slot as seen for incoming blocks. The first block recorded by setProposerSlotBlock
+// for the proposer and the slot is kept.
 func (s *Service) setSeenBlockIndexSlot(slot primitives.Slot, proposerIdx primitives.ValidatorIndex) {
 	s.seenBlockLock.Lock()
 	defer s.seenBlockLock.Unlock()
 	b := append(bytesutil.Bytes32(uint64(slot)), bytesutil.Bytes32(uint64(proposerIdx))...)
-	s.seenBlockCache.Add(string(b), true)
+	s.seenBlockCache.ContainsOrAdd(string(b), true)
 }
 
 // Returns true if the block is marked as a bad block.
@@ -12,6 +12,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
@@ -104,6 +105,13 @@ func (s *Service) validateBlob(ctx context.Context, pid peer.ID, msg *pubsub.Mes
 		return pubsub.ValidationReject, err
 	}
 
+	// Feed the signed blob sidecar to slasher if enabled, before dropping sidecars already seen for
+	// their block root and index, so that equivocating sidecars are not missed. This action is done
+	// in the background to avoid adding more load to this critical code path.
+	if features.Get().EnableSlasher && s.cfg.slasherBlobSidecarsFeed != nil {
+		go s.cfg.slasherBlobSidecarsFeed.Send(sBlob)
+	}
+
 	// [IGNORE] The sidecar is the only sidecar with valid signature received for the tuple (sidecar.block_root, sidecar.index).
 	if s.hasSeenBlobIndex(blob.BlockRoot, blob.Index) {
 		return pubsub.ValidationIgnore, nil
@@ -0,0 +1,136 @@
+package sync
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+var errEquivocatingBlock = errors.New("proposer equivocated with a second block for the slot")
+
+// proposerSlotBlock is the root and the signed header of the first valid block received over gossip for a proposer
+// and a slot, along with the peers which forwarded a block for the proposer and the slot. It is kept in the seen
+// block cache.
+type proposerSlotBlock struct {
+	root   [32]byte
+	header *ethpb.SignedBeaconBlockHeader
+	peers  map[peer.ID]bool
+}
+
+// validateNoEquivocation ignores a block if a distinct block signed by the same proposer for the same slot was
+// already accepted, as the p2p specification requires. The proposer slashing made of the two blocks is saved to the
+// slashing pool, to be included in the next blocks proposed by the node, and broadcast. Peers which forward more than one block for
+// the proposer and the slot are penalized, so that equivocating proposers cannot use the network to amplify their
+// blocks, while honest peers which forwarded the other block first are not.
+//
+// The signature of the block is checked first against the read-only head state, so that blocks which are not signed
+// by the proposer cost a single signature verification. They are not equivocations, and are left to the block
+// validation.
+func (s *Service) validateNoEquivocation(ctx context.Context, pid peer.ID, blk interfaces.ReadOnlySignedBeaconBlock) error {
+	first, ok := s.proposerSlotBlock(blk.Block().Slot(), blk.Block().ProposerIndex())
+	if !ok {
+		return nil
+	}
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil || root == first.root {
+		return nil
+	}
+	headState, err := s.cfg.chain.HeadStateReadOnly(ctx)
+	if err != nil {
+		return nil
+	}
+	if err := blocks.VerifyBlockSignatureUsingCurrentFork(headState, blk); err != nil {
+		return nil
+	}
+	header, err := interfaces.SignedBeaconBlockHeaderFromBlockInterface(blk)
+	if err != nil {
+		return nil
+	}
+	slashing := &ethpb.ProposerSlashing{Header_1: first.header, Header_2: header}
+	if err := blocks.VerifyProposerSlashing(headState, slashing); err != nil {
+		log.WithError(err).WithFields(getBlockFields(blk)).Debug("Second block for the proposer and slot is not an equivocation")
+		return nil
+	}
+
+	equivocatingBlocksCount.Inc()
+	if s.addProposerSlotPeer(first, pid) {
+		s.cfg.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+	}
+	log.WithFields(logrus.Fields{
+		"slot":          blk.Block().Slot(),
+		"proposerIndex": blk.Block().ProposerIndex(),
+		"firstRoot":     first.root,
+		"secondRoot":    root,
+		"peer":          pid,
+	}).Warn("Ignored equivocating block")
+	if !s.hasSeenProposerSlashingIndex(blk.Block().ProposerIndex()) {
+		s.insertAndBroadcastProposerSlashing(ctx, headState, slashing)
+	}
+	return errEquivocatingBlock
+}
+
+// Inserts the proposer slashing into the pool and broadcasts it. The slashing is only marked as seen once broadcast,
+// so that the same slashing received over gossip is still forwarded otherwise.
+func (s *Service) insertAndBroadcastProposerSlashing(ctx context.Context, st state.ReadOnlyBeaconState, slashing *ethpb.ProposerSlashing) {
+	if err := s.cfg.slashingPool.InsertProposerSlashing(ctx, st, slashing); err != nil {
+		log.WithError(err).Error("Could not insert proposer slashing into pool")
+		return
+	}
+	if features.Get().DisableBroadcastSlashings {
+		return
+	}
+	if err := s.cfg.p2p.Broadcast(ctx, slashing); err != nil {
+		log.WithError(err).Error("Could not broadcast proposer slashing")
+		return
+	}
+	s.setProposerSlashingIndexSeen(slashing.Header_1.Header.ProposerIndex)
+}
+
+// Returns the first valid block received over gossip for the proposer for the slot. Blocks marked as seen otherwise,
+// such as the ones from the pending queue, are not returned.
+func (s *Service) proposerSlotBlock(slot primitives.Slot, proposerIdx primitives.ValidatorIndex) (*proposerSlotBlock, bool) {
+	s.seenBlockLock.RLock()
+	defer s.seenBlockLock.RUnlock()
+	b := append(bytesutil.Bytes32(uint64(slot)), bytesutil.Bytes32(uint64(proposerIdx))...)
+	v, seen := s.seenBlockCache.Get(string(b))
+	if !seen {
+		return nil, false
+	}
+	psb, ok := v.(*proposerSlotBlock)
+	return psb, ok
+}
+
+// Set the block as the first valid block received for its proposer for its slot, forwarded by the peer. This marks
+// the proposer and the slot as seen.
+func (s *Service) setProposerSlotBlock(blk interfaces.ReadOnlySignedBeaconBlock, root [32]byte, pid peer.ID) {
+	header, err := interfaces.SignedBeaconBlockHeaderFromBlockInterface(blk)
+	if err != nil {
+		log.WithError(err).WithFields(getBlockFields(blk)).Debug("Could not get signed block header")
+		return
+	}
+	s.seenBlockLock.Lock()
+	defer s.seenBlockLock.Unlock()
+	b := append(bytesutil.Bytes32(uint64(blk.Block().Slot())), bytesutil.Bytes32(uint64(blk.Block().ProposerIndex()))...)
+	s.seenBlockCache.ContainsOrAdd(string(b), &proposerSlotBlock{root: root, header: header, peers: map[peer.ID]bool{pid: true}})
+}
+
+// Records that the peer forwarded a block for the proposer and the slot of the first block, and returns whether it
+// had already forwarded another one.
+func (s *Service) addProposerSlotPeer(first *proposerSlotBlock, pid peer.ID) bool {
+	s.seenBlockLock.Lock()
+	defer s.seenBlockLock.Unlock()
+	if first.peers[pid] {
+		return true
+	}
+	first.peers[pid] = true
+	return false
+}
@@ -0,0 +1,85 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/slashings"
+	p2ptest "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	lruwrpr "github.com/prysmaticlabs/prysm/v4/cache/lru"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestValidateNoEquivocation(t *testing.T) {
+	ctx := context.Background()
+	beaconState, privKeys := util.DeterministicGenesisState(t, 64)
+	p := p2ptest.NewTestP2P(t)
+	pid, other := peer.ID("forwarder"), peer.ID("other")
+	p.Peers().Add(nil, pid, nil, network.DirOutbound)
+	p.Peers().Add(nil, other, nil, network.DirOutbound)
+	pool := slashings.NewPool()
+	r := &Service{
+		cfg: &config{
+			p2p:          p,
+			chain:        &mock.ChainService{State: beaconState},
+			slashingPool: pool,
+		},
+		seenBlockCache:            lruwrpr.New(10),
+		seenProposerSlashingCache: lruwrpr.New(10),
+	}
+
+	signedBlock := func(graffiti string, signer int) interfaces.ReadOnlySignedBeaconBlock {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = 1
+		b.Block.ProposerIndex = 3
+		b.Block.Body.Graffiti = bytesutil.PadTo([]byte(graffiti), 32)
+		var err error
+		b.Signature, err = signing.ComputeDomainAndSign(beaconState, 0, b.Block, params.BeaconConfig().DomainBeaconProposer, privKeys[signer])
+		require.NoError(t, err)
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		return wsb
+	}
+	first := signedBlock("first", 3)
+	require.NoError(t, r.validateNoEquivocation(ctx, pid, first))
+	root, err := first.Block().HashTreeRoot()
+	require.NoError(t, err)
+	r.setProposerSlotBlock(first, root, pid)
+	// Processing the block keeps the first block of the proposer for the slot.
+	r.setSeenBlockIndexSlot(first.Block().Slot(), first.Block().ProposerIndex())
+	require.Equal(t, true, r.hasSeenBlockIndexSlot(first.Block().Slot(), first.Block().ProposerIndex()))
+
+	// The same block again, and a block not signed by the proposer, are not equivocations.
+	require.NoError(t, r.validateNoEquivocation(ctx, pid, first))
+	require.NoError(t, r.validateNoEquivocation(ctx, pid, signedBlock("forged", 4)))
+	assert.Equal(t, 0, len(pool.PendingProposerSlashings(ctx, beaconState, true)))
+
+	// A peer which only forwards the second block is not penalized.
+	require.ErrorIs(t, r.validateNoEquivocation(ctx, other, signedBlock("second", 3)), errEquivocatingBlock)
+	pending := pool.PendingProposerSlashings(ctx, beaconState, true)
+	require.Equal(t, 1, len(pending))
+	assert.Equal(t, first.Block().ProposerIndex(), pending[0].Header_1.Header.ProposerIndex)
+	// The slashing is broadcast before it is marked as seen.
+	assert.Equal(t, true, p.BroadcastCalled)
+	assert.Equal(t, true, r.hasSeenProposerSlashingIndex(first.Block().ProposerIndex()))
+	count, err := p.Peers().Scorers().BadResponsesScorer().Count(other)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// A peer which forwards both blocks is.
+	require.ErrorIs(t, r.validateNoEquivocation(ctx, pid, signedBlock("third", 3)), errEquivocatingBlock)
+	assert.Equal(t, 1, len(pool.PendingProposerSlashings(ctx, beaconState, true)))
+	count, err = p.Peers().Scorers().BadResponsesScorer().Count(pid)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,122 @@
+package sync
+
+import (
+	"context"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// validateLightClientFinalityUpdate ignores finality updates other than the one computed locally, or received
+// before a third of their signature slot elapsed. Peers only ever forward the latest finality update, which the
+// node computes itself from the blocks it imports.
+func (s *Service) validateLightClientFinalityUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	ctx, span := trace.StartSpan(ctx, "sync.validateLightClientFinalityUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	received, ok := m.(proto.Message)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	update, err := lightclient.FinalityUpdateFromProto(received)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	if !s.lightClientUpdateIsDue(update.SignatureSlot) {
+		return pubsub.ValidationIgnore, nil
+	}
+	local := s.cfg.chain.LightClientFinalityUpdate()
+	if local == nil {
+		return pubsub.ValidationIgnore, nil
+	}
+	// The local update is compared in the container of its fork, so that an update sent on the topic of
+	// another fork is ignored.
+	want, err := local.Proto()
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	if !proto.Equal(want, received) {
+		return pubsub.ValidationIgnore, nil
+	}
+	msg.ValidatorData = received // Used in downstream subscriber
+	return pubsub.ValidationAccept, nil
+}
+
+// validateLightClientOptimisticUpdate ignores optimistic updates other than the one computed locally, or received
+// before a third of their signature slot elapsed.
+func (s *Service) validateLightClientOptimisticUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	ctx, span := trace.StartSpan(ctx, "sync.validateLightClientOptimisticUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	received, ok := m.(proto.Message)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	update, err := lightclient.OptimisticUpdateFromProto(received)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	if !s.lightClientUpdateIsDue(update.SignatureSlot) {
+		return pubsub.ValidationIgnore, nil
+	}
+	local := s.cfg.chain.LightClientOptimisticUpdate()
+	if local == nil {
+		return pubsub.ValidationIgnore, nil
+	}
+	// The local update is compared in the container of its fork, so that an update sent on the topic of
+	// another fork is ignored.
+	want, err := local.Proto()
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	if !proto.Equal(want, received) {
+		return pubsub.ValidationIgnore, nil
+	}
+	msg.ValidatorData = received // Used in downstream subscriber
+	return pubsub.ValidationAccept, nil
+}
+
+// lightClientUpdateIsDue returns true once a third of the signature slot of an update elapsed, allowing for
+// the maximum gossip clock disparity.
+func (s *Service) lightClientUpdateIsDue(signatureSlot primitives.Slot) bool {
+	slotStart := slots.StartTime(uint64(s.cfg.clock.GenesisTime().Unix()), signatureSlot)
+	due := slotStart.Add(slots.DivideSlotBy(int64(params.BeaconConfig().IntervalsPerSlot)))
+	return !s.cfg.clock.Now().Add(params.BeaconNetworkConfig().MaximumGossipClockDisparity).Before(due)
+}
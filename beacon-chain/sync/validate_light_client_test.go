@@ -0,0 +1,141 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	ssz "github.com/prysmaticlabs/fastssz"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	mockSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestValidateLightClientOptimisticUpdate(t *testing.T) {
+	for _, v := range []int{version.Altair, version.Capella, version.Deneb} {
+		t.Run(version.String(v), func(t *testing.T) {
+			setLightClientForkEpochs(t, v)
+			update := func(signatureSlot primitives.Slot) *lightclient.OptimisticUpdate {
+				u := util.HydrateLightClientUpdate(&lightclient.Update{
+					AttestedHeader: util.NewLightClientHeader(t, signatureSlot-1),
+					SignatureSlot:  signatureSlot,
+				})
+				return lightclient.NewLightClientOptimisticUpdateFromUpdate(u)
+			}
+			tests := []struct {
+				name   string
+				local  *lightclient.OptimisticUpdate
+				update *lightclient.OptimisticUpdate
+				want   pubsub.ValidationResult
+			}{
+				{name: "matches local update", local: update(9), update: update(9), want: pubsub.ValidationAccept},
+				{name: "no local update", update: update(9), want: pubsub.ValidationIgnore},
+				{name: "differs from local update", local: update(8), update: update(9), want: pubsub.ValidationIgnore},
+				{name: "received too early in the signature slot", local: update(10), update: update(10), want: pubsub.ValidationIgnore},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					m, err := tt.update.Proto()
+					require.NoError(t, err)
+					s, msg := setupLightClientValidation(t, m, &mock.ChainService{OptimisticUpdate: tt.local})
+					res, err := s.validateLightClientOptimisticUpdate(context.Background(), "", msg)
+					require.NoError(t, err)
+					assert.Equal(t, tt.want, res)
+				})
+			}
+		})
+	}
+}
+
+func TestValidateLightClientFinalityUpdate(t *testing.T) {
+	for _, v := range []int{version.Altair, version.Capella, version.Deneb} {
+		t.Run(version.String(v), func(t *testing.T) {
+			setLightClientForkEpochs(t, v)
+			update := func(finalizedSlot primitives.Slot) *lightclient.FinalityUpdate {
+				u := util.HydrateLightClientUpdate(&lightclient.Update{
+					AttestedHeader:  util.NewLightClientHeader(t, 8),
+					FinalizedHeader: util.NewLightClientHeader(t, finalizedSlot),
+					SignatureSlot:   9,
+				})
+				return lightclient.NewLightClientFinalityUpdateFromUpdate(u)
+			}
+			tests := []struct {
+				name   string
+				local  *lightclient.FinalityUpdate
+				update *lightclient.FinalityUpdate
+				want   pubsub.ValidationResult
+			}{
+				{name: "matches local update", local: update(1), update: update(1), want: pubsub.ValidationAccept},
+				{name: "no local update", update: update(1), want: pubsub.ValidationIgnore},
+				{name: "differs from local update", local: update(2), update: update(1), want: pubsub.ValidationIgnore},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					m, err := tt.update.Proto()
+					require.NoError(t, err)
+					s, msg := setupLightClientValidation(t, m, &mock.ChainService{FinalityUpdate: tt.local})
+					res, err := s.validateLightClientFinalityUpdate(context.Background(), "", msg)
+					require.NoError(t, err)
+					assert.Equal(t, tt.want, res)
+				})
+			}
+		})
+	}
+}
+
+// setLightClientForkEpochs schedules the forks up to the given version at genesis.
+func setLightClientForkEpochs(t *testing.T, v int) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	if v >= version.Capella {
+		cfg.BellatrixForkEpoch = 0
+		cfg.CapellaForkEpoch = 0
+	}
+	if v >= version.Deneb {
+		cfg.DenebForkEpoch = 0
+	}
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+}
+
+// setupLightClientValidation returns a service whose clock is at the start of slot 10, and the gossip message
+// carrying the given update.
+func setupLightClientValidation(t *testing.T, update ssz.Marshaler, chain *mock.ChainService) (*Service, *pubsub.Message) {
+	p := p2ptest.NewTestP2P(t)
+	genesis := time.Now().Add(-10 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	s := &Service{
+		cfg: &config{
+			p2p:         p,
+			chain:       chain,
+			clock:       startup.NewClock(genesis, [32]byte{}),
+			initialSync: &mockSync.Sync{IsSyncing: false},
+		},
+	}
+	buf := new(bytes.Buffer)
+	_, err := p.Encoding().EncodeGossip(buf, update)
+	require.NoError(t, err)
+	topic := p2p.GossipTypeMapping[reflect.TypeOf(update)]
+	d, err := s.currentForkDigest()
+	require.NoError(t, err)
+	topic = s.addDigestToTopic(topic, d)
+	return s, &pubsub.Message{
+		Message: &pubsubpb.Message{
+			Data:  buf.Bytes(),
+			Topic: &topic,
+		},
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/cmd"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/urfave/cli/v2"
 )
 
@@ -19,6 +20,7 @@ func FlagOptions(c *cli.Context) ([]blockchain.Option, error) {
 	opts := []blockchain.Option{
 		blockchain.WithMaxGoroutines(maxRoutines),
 		blockchain.WithWeakSubjectivityCheckpoint(wsCheckpt),
+		blockchain.WithOrphanedBlocksRetention(primitives.Epoch(c.Uint64(flags.OrphanedBlocksRetentionEpochs.Name))),
 	}
 	return opts, nil
 }
@@ -1,21 +1,19 @@
 package execution
 
 import (
-	"encoding/hex"
 	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
-	"github.com/prysmaticlabs/prysm/v4/io/file"
 	log "github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
 
 // FlagOptions for execution service flag configurations.
 func FlagOptions(c *cli.Context) ([]execution.Option, error) {
-	endpoint, err := parseExecutionChainEndpoint(c)
+	endpoints, err := parseExecutionChainEndpoints(c)
 	if err != nil {
 		return nil, err
 	}
@@ -25,12 +23,15 @@ func FlagOptions(c *cli.Context) ([]execution.Option, error) {
 	}
 	headers := strings.Split(c.String(flags.ExecutionEngineHeaders.Name), ",")
 	opts := []execution.Option{
-		execution.WithHttpEndpoint(endpoint),
+		execution.WithHttpEndpoints(endpoints),
 		execution.WithEth1HeaderRequestLimit(c.Uint64(flags.Eth1HeaderReqLimit.Name)),
 		execution.WithHeaders(headers),
 	}
 	if len(jwtSecret) > 0 {
-		opts = append(opts, execution.WithHttpEndpointAndJWTSecret(endpoint, jwtSecret))
+		opts = append(opts,
+			execution.WithHttpEndpointsAndJWTSecret(endpoints, jwtSecret),
+			execution.WithJWTSecretFile(c.String(flags.ExecutionJWTSecretFlag.Name)),
+		)
 	}
 	return opts, nil
 }
@@ -43,33 +44,32 @@ func FlagOptions(c *cli.Context) ([]execution.Option, error) {
 // The secret must be stored as a hex-encoded string within a file in the filesystem.
 // If the --jwt-secret flag is provided to Prysm, but the file cannot be read, or does not contain a hex-encoded
 // key of at least 256 bits, the client should treat this as an error and abort the startup.
+// The file is then periodically read again by the execution service, so that the secret can be rotated without
+// restarting the node.
 func parseJWTSecretFromFile(c *cli.Context) ([]byte, error) {
 	jwtSecretFile := c.String(flags.ExecutionJWTSecretFlag.Name)
 	if jwtSecretFile == "" {
 		return nil, nil
 	}
-	enc, err := file.ReadFileAsBytes(jwtSecretFile)
+	secret, err := execution.ParseJWTSecretFromFile(jwtSecretFile)
 	if err != nil {
 		return nil, err
 	}
-	strData := strings.TrimSpace(string(enc))
-	if len(strData) == 0 {
-		return nil, fmt.Errorf("provided JWT secret in file %s cannot be empty", jwtSecretFile)
-	}
-	secret, err := hex.DecodeString(strings.TrimPrefix(strData, "0x"))
-	if err != nil {
-		return nil, err
-	}
-	if len(secret) < 32 {
-		return nil, errors.New("provided JWT secret should be a hex string of at least 32 bytes")
-	}
 	log.Infof("Finished reading JWT secret from %s", jwtSecretFile)
 	return secret, nil
 }
 
-func parseExecutionChainEndpoint(c *cli.Context) (string, error) {
-	if c.String(flags.ExecutionEngineEndpoint.Name) == "" {
-		return "", fmt.Errorf(
+// Parses the comma separated execution endpoints. The first endpoint is the primary one, the others
+// are fallen back to when the endpoint in use cannot be reached.
+func parseExecutionChainEndpoints(c *cli.Context) ([]string, error) {
+	var endpoints []string
+	for _, e := range strings.Split(c.String(flags.ExecutionEngineEndpoint.Name), ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			endpoints = append(endpoints, e)
+		}
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf(
 			"you need to specify %s to provide a connection endpoint to an Ethereum execution client "+
 				"for your Prysm beacon node. This is a requirement for running a node. You can read more about "+
 				"how to configure this execution client connection in our docs here "+
@@ -77,5 +77,5 @@ func parseExecutionChainEndpoint(c *cli.Context) (string, error) {
 			flags.ExecutionEngineEndpoint.Name,
 		)
 	}
-	return c.String(flags.ExecutionEngineEndpoint.Name), nil
+	return endpoints, nil
 }
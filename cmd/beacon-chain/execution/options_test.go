@@ -20,9 +20,20 @@ func TestExecutionchainCmd(t *testing.T) {
 	set.String(flags.ExecutionEngineEndpoint.Name, "primary", "")
 	ctx := cli.NewContext(&app, set, nil)
 
-	endpoints, err := parseExecutionChainEndpoint(ctx)
+	endpoints, err := parseExecutionChainEndpoints(ctx)
 	require.NoError(t, err)
-	assert.Equal(t, "primary", endpoints)
+	assert.DeepEqual(t, []string{"primary"}, endpoints)
+}
+
+func TestExecutionchainCmd_MultipleEndpoints(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(flags.ExecutionEngineEndpoint.Name, "primary, fallback1,,fallback2", "")
+	ctx := cli.NewContext(&app, set, nil)
+
+	endpoints, err := parseExecutionChainEndpoints(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{"primary", "fallback1", "fallback2"}, endpoints)
 }
 
 func Test_parseJWTSecretFromFile(t *testing.T) {
@@ -96,6 +107,6 @@ func TestPowchainPreregistration_EmptyWeb3Provider(t *testing.T) {
 	set := flag.NewFlagSet("test", 0)
 	set.String(flags.ExecutionEngineEndpoint.Name, "", "")
 	ctx := cli.NewContext(&app, set, nil)
-	_, err := parseExecutionChainEndpoint(ctx)
+	_, err := parseExecutionChainEndpoints(ctx)
 	assert.ErrorContains(t, "you need to specify", err)
 }
@@ -10,10 +10,11 @@ import (
 
 var (
 	// MevRelayEndpoint provides an HTTP access endpoint to a MEV builder network.
-	MevRelayEndpoint = &cli.StringFlag{
-		Name:  "http-mev-relay",
-		Usage: "A MEV builder relay string http endpoint, this wil be used to interact MEV builder network using API defined in: https://ethereum.github.io/builder-specs/#/Builder",
-		Value: "",
+	MevRelayEndpoint = &cli.StringSliceFlag{
+		Name: "http-mev-relay",
+		Usage: "A MEV builder relay string http endpoint, this wil be used to interact MEV builder network using API defined in: https://ethereum.github.io/builder-specs/#/Builder. " +
+			"The flag can be repeated to request bids from several relays concurrently, in which case the best valid bid is used. " +
+			"The endpoint must include the relay public key, as in https://0xpubkey@relay.example.com, and bids must be signed with it.",
 	}
 	MaxBuilderConsecutiveMissedSlots = &cli.IntFlag{
 		Name:  "max-builder-consecutive-missed-slots",
@@ -25,16 +26,29 @@ var (
 		Usage: "Number of total skip slot to fallback from using relay/builder to local execution engine for block construction in last epoch rolling window",
 		Value: 8,
 	}
+	MinBuilderEpochParticipation = &cli.Uint64Flag{
+		Name:  "min-builder-epoch-participation",
+		Usage: "Minimum percentage of active balance attesting to the correct target last epoch to use relay/builder for block construction, falls back to local execution engine below it. Zero disables the check",
+	}
 	// LocalBlockValueBoost sets a percentage boost for local block construction while using a custom builder.
 	LocalBlockValueBoost = &cli.Uint64Flag{
 		Name: "local-block-value-boost",
-		Usage: "A percentage boost for local block construction as a Uint64. This is used to prioritize local block construction over relay/builder block construction" +
-			"Boost is an additional percentage to multiple local block value. Use builder block if: builder_bid_value * 100 > local_block_value * (local-block-value-boost + 100)",
+		Usage: "A percentage boost for local block construction as a Uint64. This is used to prioritize local block construction over relay/builder block construction. " +
+			"Boost is an additional percentage to multiple local block value. Use builder block if: builder_bid_value * 100 > local_block_value * (local-block-value-boost + 100). " +
+			"The decision of every proposal is logged, counted in the proposer_payload_decision_total metric and returned in the Prysm-Payload-* headers of the block production API",
+	}
+	// GraffitiClientInfo appends the client-diversity code of the execution and consensus clients to block graffiti.
+	GraffitiClientInfo = &cli.BoolFlag{
+		Name: "graffiti-client-info",
+		Usage: "Appends the standardized code of the execution client and of Prysm, such as GE3f90PMa1b2, to the graffiti " +
+			"of the proposed blocks, in the longest format which fits after the graffiti of the validator.",
 	}
 	// ExecutionEngineEndpoint provides an HTTP access endpoint to connect to an execution client on the execution layer
 	ExecutionEngineEndpoint = &cli.StringFlag{
-		Name:  "execution-endpoint",
-		Usage: "An execution client http endpoint. Can contain auth header as well in the format",
+		Name: "execution-endpoint",
+		Usage: "An execution client http endpoint, or a comma separated list of endpoints. The first endpoint is used " +
+			"as long as it is healthy, the others are fallen back to in order when the endpoint in use cannot be reached. " +
+			"Can contain auth header as well in the format",
 		Value: "http://localhost:8551",
 	}
 	// ExecutionEngineHeaders defines a list of HTTP headers to send with all execution client requests.
@@ -52,6 +66,7 @@ var (
 			"a hex-encoded string representing a 32 byte secret used for authentication with an execution node via " +
 			"HTTP. If this is not set, all requests to execution nodes via HTTP for consensus-related calls will " +
 			"fail, which will prevent your validators from performing their duties. " +
+			"The file is periodically read again, so that the secret can be rotated without a restart. " +
 			"This is not required if using an IPC connection.",
 		Value: "",
 	}
@@ -119,6 +134,43 @@ var (
 			"(browser enforced). This flag has no effect if not used with --grpc-gateway-port.",
 		Value: "http://localhost:4200,http://localhost:7500,http://127.0.0.1:4200,http://127.0.0.1:7500,http://0.0.0.0:4200,http://0.0.0.0:7500,http://localhost:3000,http://0.0.0.0:3000,http://127.0.0.1:3000",
 	}
+	// APIAuthTokenFile specifies a file of bearer tokens, one of which must be presented by every gateway request.
+	APIAuthTokenFile = &cli.StringFlag{
+		Name: "api-auth-token-file",
+		Usage: "Path to a file containing one bearer token per line. When set, every request to the gateway " +
+			"must carry one of the tokens in its Authorization header.",
+	}
+	// APIRateLimit specifies how many gateway requests per second a single IP address may make.
+	APIRateLimit = &cli.Float64Flag{
+		Name:  "api-rate-limit",
+		Usage: "The number of requests per second a single IP address may make to the gateway. 0 disables the limit.",
+	}
+	// APIRateLimitBurst specifies how many gateway requests above the rate limit a single IP address may burst.
+	APIRateLimitBurst = &cli.Int64Flag{
+		Name:  "api-rate-limit-burst",
+		Usage: "The number of requests above --api-rate-limit a single IP address may burst.",
+		Value: 10,
+	}
+	// APIExpensiveRateLimit specifies how many requests per second to expensive gateway routes a single IP address may make.
+	APIExpensiveRateLimit = &cli.Float64Flag{
+		Name: "api-expensive-rate-limit",
+		Usage: "The number of requests per second a single IP address may make to expensive gateway routes, " +
+			"such as state and validator queries. 0 disables the limit.",
+	}
+	// APIExpensiveRateLimitBurst specifies how many requests to expensive gateway routes above the rate limit
+	// a single IP address may burst.
+	APIExpensiveRateLimitBurst = &cli.Int64Flag{
+		Name:  "api-expensive-rate-limit-burst",
+		Usage: "The number of requests above --api-expensive-rate-limit a single IP address may burst.",
+		Value: 2,
+	}
+	// AdminAPITokenFile specifies a file of bearer tokens, one of which must be presented by every request to the admin endpoints.
+	AdminAPITokenFile = &cli.StringFlag{
+		Name: "admin-api-token-file",
+		Usage: "Path to a file containing one bearer token per line. When set, the /prysm/admin endpoints, which manage the " +
+			"log levels, feature flags and peers of the running node, are served to requests carrying one of the tokens in their " +
+			"Authorization header. When --api-auth-token-file is also set, the admin tokens must be listed in both files.",
+	}
 	// MinSyncPeers specifies the required number of successful peer handshakes in order
 	// to start syncing with external peers.
 	MinSyncPeers = &cli.IntFlag{
@@ -126,6 +178,13 @@ var (
 		Usage: "The required number of valid peers to connect with before syncing.",
 		Value: 3,
 	}
+	// FastResume starts gossip and validator duties before initial sync when the head is close to the current slot.
+	FastResume = &cli.BoolFlag{
+		Name: "fast-resume",
+		Usage: "Subscribes to gossip topics as soon as the checkpoint or database head state is loaded, and skips " +
+			"initial sync when that head is within two slots of the current slot, so that validator duties resume " +
+			"right away. The missing blocks and blob sidecars are then requested from peers in the background.",
+	}
 	// ContractDeploymentBlock is the block in which the eth1 deposit contract was deployed.
 	ContractDeploymentBlock = &cli.IntFlag{
 		Name:  "contract-deployment-block",
@@ -165,6 +224,12 @@ var (
 		Usage: "The factor by which block batch limit may increase on burst.",
 		Value: 2,
 	}
+	// EraDirFlag specifies the directories of era files used to serve historical blocks missing from the database.
+	EraDirFlag = &cli.StringSliceFlag{
+		Name: "era-dir",
+		Usage: "Directory of era files from which historical blocks that are not in the database are served to peers " +
+			"and through the beacon API. Multiple directories can be passed by using the flag multiple times.",
+	}
 	// BlobBatchLimit specifies the requested blob batch size.
 	BlobBatchLimit = &cli.IntFlag{
 		Name:  "blob-batch-limit",
@@ -177,6 +242,30 @@ var (
 		Usage: "The factor by which blob batch limit may increase on burst.",
 		Value: 2,
 	}
+	// MaxPoolAttestations specifies the maximum number of attestations held in each of the aggregated and unaggregated attestation pools.
+	MaxPoolAttestations = &cli.IntFlag{
+		Name:  "max-pool-attestations",
+		Usage: "The maximum number of attestations held in each of the aggregated and unaggregated attestation pools. The oldest attestations are evicted when a pool is full. 0 disables the limit.",
+		Value: 1 << 17,
+	}
+	// MaxPoolVoluntaryExits specifies the maximum number of voluntary exits held in the pool.
+	MaxPoolVoluntaryExits = &cli.IntFlag{
+		Name:  "max-pool-voluntary-exits",
+		Usage: "The maximum number of voluntary exits held in the pool. New exits are rejected when the pool is full. 0 disables the limit.",
+	}
+	// MaxPoolBLSToExecutionChanges specifies the maximum number of BLS to execution changes held in the pool.
+	MaxPoolBLSToExecutionChanges = &cli.IntFlag{
+		Name:  "max-pool-bls-to-execution-changes",
+		Usage: "The maximum number of BLS to execution changes held in the pool. New changes are rejected when the pool is full. 0 disables the limit.",
+	}
+	// LateFinalityRebroadcastEpochs specifies the number of epochs without finality after which the node rebroadcasts
+	// the messages of its validators and looks for new peers.
+	LateFinalityRebroadcastEpochs = &cli.Uint64Flag{
+		Name: "late-finality-rebroadcast-epochs",
+		Usage: "The number of epochs without finality after which the node broadcasts again, once per epoch, the recent attestations " +
+			"and canonical blocks of its validators and looks for new peers, to help the network recover from partitions. 0 disables it.",
+		Value: 4,
+	}
 	// EnableDebugRPCEndpoints as /v1/beacon/state.
 	EnableDebugRPCEndpoints = &cli.BoolFlag{
 		Name:  "enable-debug-rpc-endpoints",
@@ -209,6 +298,42 @@ var (
 		Name:  "engine-endpoint-timeout-seconds",
 		Usage: "Sets the execution engine timeout (seconds) for execution payload semantics (forkchoiceUpdated, newPayload)",
 	}
+	// EngineGetPayloadTimeoutSeconds defines the seconds to wait before timing out the engine getPayload endpoint.
+	EngineGetPayloadTimeoutSeconds = &cli.Uint64Flag{
+		Name:  "engine-get-payload-timeout-seconds",
+		Usage: "Sets the execution engine timeout (seconds) for getPayload when proposing a block",
+	}
+	// ProposerScoreBoost overrides the proposer boost of fork choice.
+	ProposerScoreBoost = &cli.Uint64Flag{
+		Name:  "proposer-score-boost",
+		Usage: "(Devnets only) Overrides the proposer boost of fork choice, as a percentage of the committee weight",
+	}
+	// ReorgWeightThreshold overrides the weight below which a late head block may be orphaned.
+	ReorgWeightThreshold = &cli.Uint64Flag{
+		Name:  "reorg-weight-threshold",
+		Usage: "(Devnets only) Overrides the weight, as a percentage of the committee weight, below which a late head block may be orphaned",
+	}
+	// ReorgParentWeightThreshold overrides the weight above which the parent of a late head block is strong enough to orphan it.
+	ReorgParentWeightThreshold = &cli.Uint64Flag{
+		Name: "reorg-parent-weight-threshold",
+		Usage: "(Devnets only) Overrides the weight, as a percentage of the committee weight, above which the parent of a " +
+			"late head block is strong enough to orphan it",
+	}
+	// ReorgMaxEpochsSinceFinalization overrides the number of epochs without finality after which late blocks are not orphaned.
+	ReorgMaxEpochsSinceFinalization = &cli.Uint64Flag{
+		Name:  "reorg-max-epochs-since-finalization",
+		Usage: "(Devnets only) Overrides the number of epochs without finality after which late blocks are no longer orphaned",
+	}
+	// ReorgLateBlockCutoff overrides the seconds into its slot after which a block is late.
+	ReorgLateBlockCutoff = &cli.Uint64Flag{
+		Name:  "reorg-late-block-cutoff-seconds",
+		Usage: "(Devnets only) Overrides the seconds into its slot after which a block is late and a candidate to being orphaned",
+	}
+	// ReorgProposingEarlyCutoff overrides the seconds into its slot before which a proposer may orphan a late block.
+	ReorgProposingEarlyCutoff = &cli.Uint64Flag{
+		Name:  "reorg-proposing-early-cutoff-seconds",
+		Usage: "(Devnets only) Overrides the seconds into its slot before which a proposer may orphan a late parent block",
+	}
 	// Eth1HeaderReqLimit defines a flag to set the maximum number of headers that a deposit log query can fetch. If none is set, 1000 will be the limit.
 	Eth1HeaderReqLimit = &cli.Uint64Flag{
 		Name:  "eth1-header-req-limit",
@@ -224,12 +349,27 @@ var (
 			"If such a sync is not possible, the node will treat it as a critical and irrecoverable failure",
 		Value: "",
 	}
+	// OrphanedBlocksRetentionEpochs defines the number of epochs orphaned blocks are kept for after they are finalized.
+	OrphanedBlocksRetentionEpochs = &cli.Uint64Flag{
+		Name: "orphaned-blocks-retention-epochs",
+		Usage: "The number of epochs non-canonical blocks, their states and blob sidecars are kept in the database for " +
+			"after finality passes them, before being deleted. Orphaned blocks are never deleted when unset or 0.",
+		Value: 0,
+	}
 	// MinPeersPerSubnet defines a flag to set the minimum number of peers that a node will attempt to peer with for a subnet.
 	MinPeersPerSubnet = &cli.Uint64Flag{
 		Name:  "minimum-peers-per-subnet",
 		Usage: "Sets the minimum number of peers that a node will attempt to peer with that are subscribed to a subnet.",
 		Value: 6,
 	}
+	// AggregatorPeersPerSubnet defines a flag to set the number of peers that a node will attempt to peer with for a
+	// subnet in which its validators aggregate.
+	AggregatorPeersPerSubnet = &cli.Uint64Flag{
+		Name: "aggregator-peers-per-subnet",
+		Usage: "Sets the number of peers that a node will attempt to peer with that are subscribed to an attestation subnet " +
+			"in which its validators aggregate in the current or next epoch. It is raised to --minimum-peers-per-subnet if lower.",
+		Value: 10,
+	}
 	// SuggestedFeeRecipient specifies the fee recipient for the transaction fees.
 	SuggestedFeeRecipient = &cli.StringFlag{
 		Name:  "suggested-fee-recipient",
@@ -263,4 +403,28 @@ var (
 		Usage: "Directory for the slasher database",
 		Value: cmd.DefaultDataDir(),
 	}
+	// SlasherWebhookURLFlag defines a URL to which the slashings detected by slasher are posted.
+	SlasherWebhookURLFlag = &cli.StringFlag{
+		Name:  "slasher-webhook-url",
+		Usage: "URL to which the slasher posts a JSON notification for every slashing it detects, including those already included on chain",
+	}
+	// DiskWarningThresholdFlag specifies the free disk space below which a warning is logged.
+	DiskWarningThresholdFlag = &cli.Uint64Flag{
+		Name:  "disk-warning-threshold",
+		Usage: "Free disk space of the data directory, in GB, below which a warning is logged. 0 disables the warning.",
+		Value: 50,
+	}
+	// DiskCriticalThresholdFlag specifies the free disk space below which the disk is considered critically low.
+	DiskCriticalThresholdFlag = &cli.Uint64Flag{
+		Name: "disk-critical-threshold",
+		Usage: "Free disk space of the data directory, in GB, below which the disk is considered critically low " +
+			"and non-critical work is paused if --pause-on-low-disk is set. 0 disables the check.",
+		Value: 10,
+	}
+	// PauseOnLowDiskFlag pauses non-critical work writing to disk while the disk is critically low.
+	PauseOnLowDiskFlag = &cli.BoolFlag{
+		Name: "pause-on-low-disk",
+		Usage: "Pauses non-critical work writing to disk, such as saving the states of archived points, while the free disk space " +
+			"is below --disk-critical-threshold. Historical states which are not saved are regenerated when requested.",
+	}
 )
@@ -10,11 +10,19 @@ import (
 type GlobalFlags struct {
 	SubscribeToAllSubnets      bool
 	MinimumSyncPeers           int
+	FastResume                 bool
 	MinimumPeersPerSubnet      int
+	AggregatorPeersPerSubnet   int
 	BlockBatchLimit            int
 	BlockBatchLimitBurstFactor int
 	BlobBatchLimit             int
 	BlobBatchLimitBurstFactor  int
+
+	MaxPoolAttestations          int
+	MaxPoolVoluntaryExits        int
+	MaxPoolBLSToExecutionChanges int
+
+	LateFinalityRebroadcastEpochs uint64
 }
 
 var globalConfig *GlobalFlags
@@ -40,11 +48,20 @@ func ConfigureGlobalFlags(ctx *cli.Context) {
 		log.Warn("Subscribing to All Attestation Subnets")
 		cfg.SubscribeToAllSubnets = true
 	}
+	if ctx.Bool(FastResume.Name) {
+		log.Warn("Resuming gossip and validator duties before initial sync completes")
+		cfg.FastResume = true
+	}
 	cfg.BlockBatchLimit = ctx.Int(BlockBatchLimit.Name)
 	cfg.BlockBatchLimitBurstFactor = ctx.Int(BlockBatchLimitBurstFactor.Name)
 	cfg.BlobBatchLimit = ctx.Int(BlobBatchLimit.Name)
 	cfg.BlobBatchLimitBurstFactor = ctx.Int(BlobBatchLimitBurstFactor.Name)
 	cfg.MinimumPeersPerSubnet = ctx.Int(MinPeersPerSubnet.Name)
+	cfg.AggregatorPeersPerSubnet = ctx.Int(AggregatorPeersPerSubnet.Name)
+	cfg.MaxPoolAttestations = ctx.Int(MaxPoolAttestations.Name)
+	cfg.MaxPoolVoluntaryExits = ctx.Int(MaxPoolVoluntaryExits.Name)
+	cfg.MaxPoolBLSToExecutionChanges = ctx.Int(MaxPoolBLSToExecutionChanges.Name)
+	cfg.LateFinalityRebroadcastEpochs = ctx.Uint64(LateFinalityRebroadcastEpochs.Name)
 	configureMinimumPeers(ctx, cfg)
 
 	Init(cfg)
@@ -26,6 +26,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/monitoring/journald"
 	"github.com/prysmaticlabs/prysm/v4/runtime/debug"
 	"github.com/prysmaticlabs/prysm/v4/runtime/fdlimits"
+	"github.com/prysmaticlabs/prysm/v4/runtime/logging"
 	prefixed "github.com/prysmaticlabs/prysm/v4/runtime/logging/logrus-prefixed-formatter"
 	_ "github.com/prysmaticlabs/prysm/v4/runtime/maxprocs"
 	"github.com/prysmaticlabs/prysm/v4/runtime/tos"
@@ -48,13 +49,28 @@ var appFlags = []cli.Flag{
 	flags.GRPCGatewayHost,
 	flags.GRPCGatewayPort,
 	flags.GPRCGatewayCorsDomain,
+	flags.APIAuthTokenFile,
+	flags.APIRateLimit,
+	flags.APIRateLimitBurst,
+	flags.APIExpensiveRateLimit,
+	flags.APIExpensiveRateLimitBurst,
+	flags.AdminAPITokenFile,
 	flags.MinSyncPeers,
+	flags.FastResume,
 	flags.ContractDeploymentBlock,
 	flags.SetGCPercent,
 	flags.BlockBatchLimit,
 	flags.BlockBatchLimitBurstFactor,
+	flags.EraDirFlag,
+	flags.DiskWarningThresholdFlag,
+	flags.DiskCriticalThresholdFlag,
+	flags.PauseOnLowDiskFlag,
 	flags.BlobBatchLimit,
 	flags.BlobBatchLimitBurstFactor,
+	flags.MaxPoolAttestations,
+	flags.MaxPoolVoluntaryExits,
+	flags.MaxPoolBLSToExecutionChanges,
+	flags.LateFinalityRebroadcastEpochs,
 	flags.InteropMockEth1DataVotesFlag,
 	flags.InteropNumValidatorsFlag,
 	flags.InteropGenesisTimeFlag,
@@ -65,8 +81,10 @@ var appFlags = []cli.Flag{
 	flags.ChainID,
 	flags.NetworkID,
 	flags.WeakSubjectivityCheckpoint,
+	flags.OrphanedBlocksRetentionEpochs,
 	flags.Eth1HeaderReqLimit,
 	flags.MinPeersPerSubnet,
+	flags.AggregatorPeersPerSubnet,
 	flags.SuggestedFeeRecipient,
 	flags.TerminalTotalDifficultyOverride,
 	flags.TerminalBlockHashOverride,
@@ -74,8 +92,17 @@ var appFlags = []cli.Flag{
 	flags.MevRelayEndpoint,
 	flags.MaxBuilderEpochMissedSlots,
 	flags.MaxBuilderConsecutiveMissedSlots,
+	flags.MinBuilderEpochParticipation,
 	flags.EngineEndpointTimeoutSeconds,
+	flags.EngineGetPayloadTimeoutSeconds,
+	flags.ProposerScoreBoost,
+	flags.ReorgWeightThreshold,
+	flags.ReorgParentWeightThreshold,
+	flags.ReorgMaxEpochsSinceFinalization,
+	flags.ReorgLateBlockCutoff,
+	flags.ReorgProposingEarlyCutoff,
 	flags.LocalBlockValueBoost,
+	flags.GraffitiClientInfo,
 	cmd.BackupWebhookOutputDir,
 	cmd.MinimalConfigFlag,
 	cmd.E2EConfigFlag,
@@ -100,6 +127,8 @@ var appFlags = []cli.Flag{
 	cmd.EnableTracingFlag,
 	cmd.TracingProcessNameFlag,
 	cmd.TracingEndpointFlag,
+	cmd.TracingOTLPEndpointFlag,
+	cmd.TracingOTLPHeadersFlag,
 	cmd.TraceSampleFractionFlag,
 	cmd.MonitoringHostFlag,
 	flags.MonitoringPortFlag,
@@ -125,13 +154,21 @@ var appFlags = []cli.Flag{
 	cmd.RestoreSourceFileFlag,
 	cmd.RestoreTargetDirFlag,
 	cmd.ValidatorMonitorIndicesFlag,
+	cmd.ValidatorMonitorIndicesFileFlag,
+	cmd.ValidatorMonitorMaxMetricsKeysFlag,
 	cmd.ApiTimeoutFlag,
+	cmd.BLSMaxProcsFlag,
 	checkpoint.BlockPath,
 	checkpoint.StatePath,
 	checkpoint.RemoteURL,
+	checkpoint.VerificationURLs,
+	checkpoint.ExpectedStateRoot,
+	checkpoint.LightClientURLs,
+	checkpoint.LightClientTrustedRoot,
 	genesis.StatePath,
 	genesis.BeaconAPIURL,
 	flags.SlasherDirFlag,
+	flags.SlasherWebhookURLFlag,
 }
 
 func init() {
@@ -187,6 +224,8 @@ func main() {
 		default:
 			return fmt.Errorf("unknown log format %s", format)
 		}
+		// Allow changing the log level of single modules at runtime.
+		logrus.SetFormatter(&logging.ModuleFormatter{Formatter: logrus.StandardLogger().Formatter})
 
 		logFileName := ctx.String(cmd.LogFileName.Name)
 		if logFileName != "" {
@@ -200,6 +239,7 @@ func main() {
 		if ctx.IsSet(flags.SetGCPercent.Name) {
 			runtimeDebug.SetGCPercent(ctx.Int(flags.SetGCPercent.Name))
 		}
+		cmd.ConfigureBLS(ctx)
 		if err := debug.Setup(ctx); err != nil {
 			return err
 		}
@@ -239,7 +279,7 @@ func startNode(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	logrus.SetLevel(level)
+	logging.SetLevel(level)
 	// Set libp2p logger to only panic logs for the info level.
 	golog.SetAllLoggers(golog.LevelPanic)
 
@@ -3,9 +3,12 @@ package checkpoint
 import (
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/node"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/checkpoint"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/urfave/cli/v2"
 )
 
@@ -28,6 +31,34 @@ var (
 			"As an additional safety measure, it is strongly recommended to only use this option in conjunction with " +
 			"--weak-subjectivity-checkpoint flag",
 	}
+	// VerificationURLs defines additional beacon nodes used to cross-verify the state downloaded via RemoteURL.
+	VerificationURLs = &cli.StringSliceFlag{
+		Name: "checkpoint-verification-url",
+		Usage: "URL of an additional synced beacon node which is asked for the root of the checkpoint state downloaded " +
+			"from --checkpoint-sync-url. The beacon node refuses to start if any of them reports a different root. " +
+			"This flag can be used multiple times to verify against several providers.",
+	}
+	// ExpectedStateRoot defines a hex-encoded state root the state downloaded via RemoteURL must match.
+	ExpectedStateRoot = &cli.StringFlag{
+		Name: "checkpoint-state-root",
+		Usage: "Hex-encoded root of the checkpoint state, obtained from a trusted source. The beacon node refuses to " +
+			"start if the state downloaded from --checkpoint-sync-url has a different root.",
+	}
+	// LightClientURLs defines beacon nodes serving the light client api, used to verify the block downloaded via
+	// RemoteURL with a light client sync.
+	LightClientURLs = &cli.StringSliceFlag{
+		Name: "checkpoint-light-client-url",
+		Usage: "URL of a beacon node serving the light client api. Starting from --checkpoint-light-client-trusted-root, " +
+			"the beacon node runs a light client sync against it, and refuses to start unless the latest finalized block " +
+			"verified by the sync committee is the block downloaded from --checkpoint-sync-url. " +
+			"This flag can be used multiple times to verify against several providers.",
+	}
+	// LightClientTrustedRoot defines the hex-encoded root of the block light client syncs start from.
+	LightClientTrustedRoot = &cli.StringFlag{
+		Name: "checkpoint-light-client-trusted-root",
+		Usage: "Hex-encoded root of a recent finalized block, obtained from a trusted source, from which light client " +
+			"syncs against --checkpoint-light-client-url start. It must be less than a weak subjectivity period old.",
+	}
 )
 
 // BeaconNodeOptions is responsible for determining if the checkpoint sync options have been used, and if so,
@@ -38,9 +69,13 @@ func BeaconNodeOptions(c *cli.Context) (node.Option, error) {
 	statePath := c.Path(StatePath.Name)
 	remoteURL := c.String(RemoteURL.Name)
 	if remoteURL != "" {
+		opts, err := verificationOptions(c)
+		if err != nil {
+			return nil, err
+		}
 		return func(node *node.BeaconNode) error {
 			var err error
-			node.CheckpointInitializer, err = checkpoint.NewAPIInitializer(remoteURL)
+			node.CheckpointInitializer, err = checkpoint.NewAPIInitializer(remoteURL, opts...)
 			if err != nil {
 				return errors.Wrap(err, "error while constructing beacon node api client for checkpoint sync")
 			}
@@ -48,6 +83,10 @@ func BeaconNodeOptions(c *cli.Context) (node.Option, error) {
 		}, nil
 	}
 
+	if c.IsSet(VerificationURLs.Name) || c.IsSet(ExpectedStateRoot.Name) || c.IsSet(LightClientURLs.Name) {
+		return nil, fmt.Errorf("--%s, --%s and --%s require --%s",
+			VerificationURLs.Name, ExpectedStateRoot.Name, LightClientURLs.Name, RemoteURL.Name)
+	}
 	if blockPath == "" && statePath == "" {
 		return nil, nil
 	}
@@ -66,3 +105,40 @@ func BeaconNodeOptions(c *cli.Context) (node.Option, error) {
 		return nil
 	}, nil
 }
+
+func verificationOptions(c *cli.Context) ([]checkpoint.APIInitializerOption, error) {
+	var opts []checkpoint.APIInitializerOption
+	if hosts := c.StringSlice(VerificationURLs.Name); len(hosts) > 0 {
+		opts = append(opts, checkpoint.WithVerificationHosts(hosts...))
+	}
+	if rs := c.String(ExpectedStateRoot.Name); rs != "" {
+		r, err := decodeRoot(ExpectedStateRoot.Name, rs)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, checkpoint.WithExpectedStateRoot(r))
+	}
+	hosts, rs := c.StringSlice(LightClientURLs.Name), c.String(LightClientTrustedRoot.Name)
+	if (len(hosts) > 0) != (rs != "") {
+		return nil, fmt.Errorf("--%s and --%s must be used together", LightClientURLs.Name, LightClientTrustedRoot.Name)
+	}
+	if len(hosts) > 0 {
+		r, err := decodeRoot(LightClientTrustedRoot.Name, rs)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, checkpoint.WithLightClientVerification(r, hosts...))
+	}
+	return opts, nil
+}
+
+func decodeRoot(flag, rs string) ([32]byte, error) {
+	r, err := hexutil.Decode(rs)
+	if err != nil {
+		return [32]byte{}, errors.Wrapf(err, "could not decode --%s value %s", flag, rs)
+	}
+	if len(r) != fieldparams.RootLength {
+		return [32]byte{}, fmt.Errorf("--%s value %s is not a %d byte root", flag, rs, fieldparams.RootLength)
+	}
+	return bytesutil.ToBytes32(r), nil
+}
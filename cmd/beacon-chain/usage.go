@@ -59,6 +59,8 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.EnableTracingFlag,
 			cmd.TracingProcessNameFlag,
 			cmd.TracingEndpointFlag,
+			cmd.TracingOTLPEndpointFlag,
+			cmd.TracingOTLPHeadersFlag,
 			cmd.TraceSampleFractionFlag,
 			cmd.MonitoringHostFlag,
 			cmd.BackupWebhookOutputDir,
@@ -74,7 +76,10 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.RestoreSourceFileFlag,
 			cmd.RestoreTargetDirFlag,
 			cmd.ValidatorMonitorIndicesFlag,
+			cmd.ValidatorMonitorIndicesFileFlag,
+			cmd.ValidatorMonitorMaxMetricsKeysFlag,
 			cmd.ApiTimeoutFlag,
+			cmd.BLSMaxProcsFlag,
 		},
 	},
 	{
@@ -105,6 +110,12 @@ var appHelpFlagGroups = []flagGroup{
 			flags.GRPCGatewayHost,
 			flags.GRPCGatewayPort,
 			flags.GPRCGatewayCorsDomain,
+			flags.APIAuthTokenFile,
+			flags.APIRateLimit,
+			flags.APIRateLimitBurst,
+			flags.APIExpensiveRateLimit,
+			flags.APIExpensiveRateLimitBurst,
+			flags.AdminAPITokenFile,
 			flags.ExecutionEngineEndpoint,
 			flags.ExecutionEngineHeaders,
 			flags.ExecutionJWTSecretFlag,
@@ -112,25 +123,49 @@ var appHelpFlagGroups = []flagGroup{
 			flags.SlotsPerArchivedPoint,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
+			flags.EraDirFlag,
+			flags.DiskWarningThresholdFlag,
+			flags.DiskCriticalThresholdFlag,
+			flags.PauseOnLowDiskFlag,
 			flags.BlobBatchLimit,
 			flags.BlobBatchLimitBurstFactor,
+			flags.MaxPoolAttestations,
+			flags.MaxPoolVoluntaryExits,
+			flags.MaxPoolBLSToExecutionChanges,
+			flags.LateFinalityRebroadcastEpochs,
 			flags.EnableDebugRPCEndpoints,
 			flags.SubscribeToAllSubnets,
 			flags.HistoricalSlasherNode,
 			flags.ChainID,
 			flags.NetworkID,
 			flags.WeakSubjectivityCheckpoint,
+			flags.OrphanedBlocksRetentionEpochs,
 			flags.Eth1HeaderReqLimit,
 			flags.MinPeersPerSubnet,
+			flags.AggregatorPeersPerSubnet,
 			flags.MevRelayEndpoint,
 			flags.MaxBuilderEpochMissedSlots,
 			flags.MaxBuilderConsecutiveMissedSlots,
+			flags.MinBuilderEpochParticipation,
 			flags.EngineEndpointTimeoutSeconds,
+			flags.EngineGetPayloadTimeoutSeconds,
+			flags.ProposerScoreBoost,
+			flags.ReorgWeightThreshold,
+			flags.ReorgParentWeightThreshold,
+			flags.ReorgMaxEpochsSinceFinalization,
+			flags.ReorgLateBlockCutoff,
+			flags.ReorgProposingEarlyCutoff,
 			flags.SlasherDirFlag,
+			flags.SlasherWebhookURLFlag,
 			flags.LocalBlockValueBoost,
+			flags.GraffitiClientInfo,
 			checkpoint.BlockPath,
 			checkpoint.StatePath,
 			checkpoint.RemoteURL,
+			checkpoint.VerificationURLs,
+			checkpoint.ExpectedStateRoot,
+			checkpoint.LightClientURLs,
+			checkpoint.LightClientTrustedRoot,
 			genesis.StatePath,
 			genesis.BeaconAPIURL,
 		},
@@ -159,6 +194,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.StaticPeers,
 			cmd.EnableUPnPFlag,
 			flags.MinSyncPeers,
+			flags.FastResume,
 		},
 	},
 	{
@@ -66,6 +66,17 @@ var (
 		Usage: "Tracing endpoint defines where beacon chain traces are exposed to Jaeger.",
 		Value: "http://127.0.0.1:14268/api/traces",
 	}
+	// TracingOTLPEndpointFlag defines the OTLP/HTTP endpoint of an OpenTelemetry collector to export traces to.
+	TracingOTLPEndpointFlag = &cli.StringFlag{
+		Name: "tracing-otlp-endpoint",
+		Usage: "The OTLP/HTTP endpoint of an OpenTelemetry collector, such as http://127.0.0.1:4318, to which traces " +
+			"are exported instead of --tracing-endpoint.",
+	}
+	// TracingOTLPHeadersFlag defines the headers sent along with the traces to the OTLP endpoint.
+	TracingOTLPHeadersFlag = &cli.StringSliceFlag{
+		Name:  "tracing-otlp-headers",
+		Usage: "Headers, formatted as key=value, sent along with the traces to --tracing-otlp-endpoint, such as the credentials of the tracing backend.",
+	}
 	// TraceSampleFractionFlag defines a flag to indicate what fraction of p2p
 	// messages are sampled for tracing.
 	TraceSampleFractionFlag = &cli.Float64Flag{
@@ -240,6 +251,21 @@ var (
 		Name:  "monitor-indices",
 		Usage: "List of validator indices to track performance",
 	}
+	// ValidatorMonitorIndicesFileFlag specifies a file of validators to track
+	// for performance updates
+	ValidatorMonitorIndicesFileFlag = &cli.StringFlag{
+		Name: "monitor-indices-file",
+		Usage: "Path to a file of validators to track performance, given by index or by 0x prefixed public key, " +
+			"one per line or separated by commas. Changes to the file are applied at the start of each epoch",
+	}
+	// ValidatorMonitorMaxMetricsKeysFlag caps the cardinality of the validator
+	// monitor metrics
+	ValidatorMonitorMaxMetricsKeysFlag = &cli.IntFlag{
+		Name: "monitor-max-metrics-keys",
+		Usage: "Maximum number of tracked validators reported individually in the metrics. When more validators " +
+			"are tracked, their metrics are aggregated into this many buckets. 0 reports every validator individually",
+		Value: 64,
+	}
 
 	// RestoreSourceFileFlag specifies the filepath to the backed-up database file
 	// which will be used to restore the database.
@@ -259,6 +285,12 @@ var (
 		Usage: "Specifies the timeout value for API requests in seconds",
 		Value: 120,
 	}
+	// BLSMaxProcsFlag specifies the maximum number of threads used by the BLS backend.
+	BLSMaxProcsFlag = &cli.IntFlag{
+		Name: "bls-max-procs",
+		Usage: "Maximum number of threads used to verify BLS signatures in parallel. " +
+			"0 uses all but one of the available cores",
+	}
 	// JwtOutputFileFlag specifies the JWT file path that gets generated into when invoked by generate-jwt-secret.
 	JwtOutputFileFlag = &cli.StringFlag{
 		Name:    "output-file",
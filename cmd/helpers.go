@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v4/io/file"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
@@ -102,3 +103,24 @@ func ExpandSingleEndpointIfFile(ctx *cli.Context, flag *cli.StringFlag) error {
 	}
 	return nil
 }
+
+// ConfigureBLS sets the number of threads of the BLS backend from the command line, and logs the CPU features its
+// fast paths rely on, as signature verification throughput varies widely across hosts.
+func ConfigureBLS(cliCtx *cli.Context) {
+	maxProcs := cliCtx.Int(BLSMaxProcsFlag.Name)
+	bls.SetMaxProcs(maxProcs)
+
+	fields := logrus.Fields{}
+	for feature, supported := range bls.CPUFeatures() {
+		fields[feature] = supported
+	}
+	if maxProcs > 0 {
+		fields["maxProcs"] = maxProcs
+	}
+	if !bls.HasFastPaths() {
+		log.WithFields(fields).Warn("CPU does not support the ADX and BMI2 instructions used by the fast paths of " +
+			"the BLS backend, signature verification will run in degraded mode and may not keep up with peak attestation load")
+		return
+	}
+	log.WithFields(fields).Info("Detected CPU features of the BLS backend")
+}
@@ -0,0 +1,182 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+var diffFlags = struct {
+	BeaconNodeHost  string
+	Timeout         time.Duration
+	ChainConfigFile string
+	MaxEntries      int
+}{}
+
+var flags = []cli.Flag{
+	&cli.StringFlag{
+		Name:        "beacon-node-host",
+		Usage:       "host:port of a beacon node to download the objects from, when the arguments are state or block ids instead of files",
+		Destination: &diffFlags.BeaconNodeHost,
+	},
+	&cli.DurationFlag{
+		Name:        "http-timeout",
+		Usage:       "timeout for http requests made to beacon-node-host (uses duration format, ex: 2m31s). default: 4m",
+		Destination: &diffFlags.Timeout,
+		Value:       time.Minute * 4,
+	},
+	&cli.StringFlag{
+		Name:        "chain-config-file",
+		Usage:       "path to the chain config of the network, if it is not mainnet",
+		Destination: &diffFlags.ChainConfigFile,
+	},
+	&cli.IntFlag{
+		Name:        "max-entries",
+		Usage:       "maximum number of differing entries printed per list, such as validators or balances. 0 prints all of them",
+		Destination: &diffFlags.MaxEntries,
+		Value:       20,
+	},
+}
+
+var Commands = []*cli.Command{
+	{
+		Name:  "diff",
+		Usage: "commands to compare consensus objects field by field, to debug consensus failures",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "state",
+				Usage:     "Print the differences between two beacon states, given as SSZ files or state ids",
+				ArgsUsage: "<state> <state>",
+				Flags:     flags,
+				Action: func(cliCtx *cli.Context) error {
+					if err := diffAction(cliCtx, loadState); err != nil {
+						log.WithError(err).Fatal("Could not compare states")
+					}
+					return nil
+				},
+			},
+			{
+				Name:      "block",
+				Usage:     "Print the differences between two signed beacon blocks, given as SSZ files or block ids",
+				ArgsUsage: "<block> <block>",
+				Flags:     flags,
+				Action: func(cliCtx *cli.Context) error {
+					if err := diffAction(cliCtx, loadBlock); err != nil {
+						log.WithError(err).Fatal("Could not compare blocks")
+					}
+					return nil
+				},
+			},
+		},
+	},
+}
+
+// loader loads a consensus object from an SSZ file or, given a beacon node client, by id.
+type loader func(ctx context.Context, c *beacon.Client, arg string) (proto.Message, error)
+
+func diffAction(cliCtx *cli.Context, load loader) error {
+	f := diffFlags
+	if cliCtx.NArg() != 2 {
+		return errors.New("exactly two objects to compare must be provided")
+	}
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return err
+		}
+	}
+	var c *beacon.Client
+	if f.BeaconNodeHost != "" {
+		var err error
+		c, err = beacon.NewClient(f.BeaconNodeHost, client.WithTimeout(f.Timeout))
+		if err != nil {
+			return err
+		}
+	}
+	a, err := load(cliCtx.Context, c, cliCtx.Args().Get(0))
+	if err != nil {
+		return errors.Wrapf(err, "could not load %s", cliCtx.Args().Get(0))
+	}
+	b, err := load(cliCtx.Context, c, cliCtx.Args().Get(1))
+	if err != nil {
+		return errors.Wrapf(err, "could not load %s", cliCtx.Args().Get(1))
+	}
+	printDiff(os.Stdout, a, b, f.MaxEntries)
+	return nil
+}
+
+// printDiff prints the differences between a and b to w, one field per line.
+func printDiff(w io.Writer, a, b proto.Message, maxEntries int) {
+	d := &differ{w: w, maxEntries: maxEntries}
+	d.messages("", a.ProtoReflect(), b.ProtoReflect())
+	if d.count == 0 {
+		_, _ = fmt.Fprintln(w, "no differences")
+	}
+}
+
+func loadState(ctx context.Context, c *beacon.Client, arg string) (proto.Message, error) {
+	b, err := readOrFetch(arg, c, func(id beacon.StateOrBlockId) ([]byte, error) {
+		return c.GetState(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	vu, err := detect.FromState(b)
+	if err != nil {
+		return nil, err
+	}
+	st, err := vu.UnmarshalBeaconState(b)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := st.ToProtoUnsafe().(proto.Message)
+	if !ok {
+		return nil, errors.New("state is not backed by a protobuf message")
+	}
+	return m, nil
+}
+
+func loadBlock(ctx context.Context, c *beacon.Client, arg string) (proto.Message, error) {
+	b, err := readOrFetch(arg, c, func(id beacon.StateOrBlockId) ([]byte, error) {
+		return c.GetBlock(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	vu, err := detect.FromBlock(b)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := vu.UnmarshalBeaconBlock(b)
+	if err != nil {
+		// Blocks saved from a builder flow are blinded.
+		var blindedErr error
+		blk, blindedErr = vu.UnmarshalBlindedBeaconBlock(b)
+		if blindedErr != nil {
+			return nil, err
+		}
+	}
+	return blk.Proto()
+}
+
+// readOrFetch reads the file at arg if it exists, and otherwise fetches the object with arg as its id.
+func readOrFetch(arg string, c *beacon.Client, fetch func(id beacon.StateOrBlockId) ([]byte, error)) ([]byte, error) {
+	if file.FileExists(arg) {
+		return file.ReadFileAsBytes(arg)
+	}
+	if c == nil {
+		return nil, errors.New("no such file, use --beacon-node-host to download objects by id")
+	}
+	return fetch(beacon.StateOrBlockId(arg))
+}
@@ -0,0 +1,151 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// differ prints the differences between two protobuf messages field by field. Fields are named as in the consensus
+// specs, and fields of different message types are matched by name, so that objects of different forks can be
+// compared. At most maxEntries differing entries of a list are printed, a non-positive value printing all of them.
+type differ struct {
+	w          io.Writer
+	maxEntries int
+	count      int
+}
+
+func (d *differ) printf(format string, args ...interface{}) {
+	d.count++
+	_, _ = fmt.Fprintf(d.w, format, args...)
+}
+
+func (d *differ) messages(path string, a, b protoreflect.Message) {
+	fa, fb := a.Descriptor().Fields(), b.Descriptor().Fields()
+	for i := 0; i < fa.Len(); i++ {
+		f := fa.Get(i)
+		p := fieldPath(path, string(f.Name()))
+		g := fb.ByName(f.Name())
+		if g == nil {
+			d.printf("%s: only in first\n", p)
+			continue
+		}
+		if f.IsList() != g.IsList() || f.Kind() != g.Kind() {
+			d.printf("%s: types differ\n", p)
+			continue
+		}
+		switch {
+		case f.IsList():
+			d.lists(p, f, a.Get(f).List(), b.Get(g).List())
+		case f.Kind() == protoreflect.MessageKind:
+			d.messages(p, a.Get(f).Message(), b.Get(g).Message())
+		default:
+			d.scalars(p, f, a.Get(f), b.Get(g))
+		}
+	}
+	for i := 0; i < fb.Len(); i++ {
+		if g := fb.Get(i); fa.ByName(g.Name()) == nil {
+			d.printf("%s: only in second\n", fieldPath(path, string(g.Name())))
+		}
+	}
+}
+
+// lists prints the differing entries of two lists, followed by a summary of the changes when the list is long.
+// Entries only present in one of the lists are reported as removed or added.
+func (d *differ) lists(path string, f protoreflect.FieldDescriptor, a, b protoreflect.List) {
+	if a.Len() != b.Len() {
+		d.printf("%s: length %d -> %d\n", path, a.Len(), b.Len())
+	}
+	n := a.Len()
+	if b.Len() < n {
+		n = b.Len()
+	}
+	changed, shown := 0, 0
+	var delta int64
+	for i := 0; i < n; i++ {
+		va, vb := a.Get(i), b.Get(i)
+		if equal(f, va, vb) {
+			continue
+		}
+		changed++
+		if isUnsigned(f) {
+			delta += int64(vb.Uint() - va.Uint())
+		}
+		if d.maxEntries > 0 && shown >= d.maxEntries {
+			continue
+		}
+		shown++
+		p := fmt.Sprintf("%s[%d]", path, i)
+		if f.Kind() == protoreflect.MessageKind {
+			d.messages(p, va.Message(), vb.Message())
+		} else {
+			d.scalars(p, f, va, vb)
+		}
+	}
+	for i := n; i < a.Len() && (d.maxEntries <= 0 || shown < d.maxEntries); i++ {
+		shown++
+		d.printf("%s[%d]: removed\n", path, i)
+	}
+	for i := n; i < b.Len() && (d.maxEntries <= 0 || shown < d.maxEntries); i++ {
+		shown++
+		if f.Kind() == protoreflect.MessageKind {
+			d.printf("%s[%d]: added\n", path, i)
+		} else {
+			d.printf("%s[%d]: added %s\n", path, i, format(f, b.Get(i)))
+		}
+	}
+	if hidden := changed + a.Len() + b.Len() - 2*n - shown; hidden > 0 {
+		d.printf("%s: %d more differences not shown\n", path, hidden)
+	}
+	if changed > 1 && isUnsigned(f) {
+		d.printf("%s: %d entries changed, total %+d\n", path, changed, delta)
+	}
+}
+
+func (d *differ) scalars(path string, f protoreflect.FieldDescriptor, a, b protoreflect.Value) {
+	if equal(f, a, b) {
+		return
+	}
+	if isUnsigned(f) && a.Uint() != math.MaxUint64 && b.Uint() != math.MaxUint64 {
+		d.printf("%s: %s -> %s (%+d)\n", path, format(f, a), format(f, b), int64(b.Uint()-a.Uint()))
+		return
+	}
+	d.printf("%s: %s -> %s\n", path, format(f, a), format(f, b))
+}
+
+func equal(f protoreflect.FieldDescriptor, a, b protoreflect.Value) bool {
+	switch f.Kind() {
+	case protoreflect.MessageKind:
+		return proto.Equal(a.Message().Interface(), b.Message().Interface())
+	case protoreflect.BytesKind:
+		return bytes.Equal(a.Bytes(), b.Bytes())
+	default:
+		return a.Interface() == b.Interface()
+	}
+}
+
+func isUnsigned(f protoreflect.FieldDescriptor) bool {
+	return f.Kind() == protoreflect.Uint64Kind || f.Kind() == protoreflect.Uint32Kind
+}
+
+func format(f protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if f.Kind() != protoreflect.BytesKind {
+		return fmt.Sprintf("%v", v.Interface())
+	}
+	// Bit vectors are easier to compare bit by bit, the lowest bit being the first one.
+	if f.Name() == "justification_bits" && len(v.Bytes()) == 1 {
+		return fmt.Sprintf("0b%04b", v.Bytes()[0]&0x0f)
+	}
+	return fmt.Sprintf("%#x", v.Bytes())
+}
+
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,92 @@
+package diff
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPrintDiff_States(t *testing.T) {
+	a, _ := util.DeterministicGenesisStateCapella(t, 64)
+	b := a.Copy()
+	require.NoError(t, b.SetSlot(10))
+	require.NoError(t, b.UpdateBalancesAtIndex(1, params.BeaconConfig().MaxEffectiveBalance+5))
+	require.NoError(t, b.UpdateBalancesAtIndex(2, params.BeaconConfig().MaxEffectiveBalance-2))
+	val, err := b.ValidatorAtIndex(3)
+	require.NoError(t, err)
+	val.ExitEpoch = 100
+	require.NoError(t, b.UpdateValidatorAtIndex(3, val))
+	require.NoError(t, b.SetJustificationBits([]byte{0x03}))
+	header, err := blocks.WrappedExecutionPayloadHeaderCapella(&enginev1.ExecutionPayloadHeaderCapella{
+		ParentHash:       make([]byte, fieldparams.RootLength),
+		FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+		StateRoot:        make([]byte, fieldparams.RootLength),
+		ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+		LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+		PrevRandao:       make([]byte, fieldparams.RootLength),
+		BlockNumber:      7,
+		BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+		BlockHash:        make([]byte, fieldparams.RootLength),
+		TransactionsRoot: make([]byte, fieldparams.RootLength),
+		WithdrawalsRoot:  make([]byte, fieldparams.RootLength),
+	}, 0)
+	require.NoError(t, err)
+	require.NoError(t, b.SetLatestExecutionPayloadHeader(header))
+	require.NoError(t, b.AppendValidator(&ethpb.Validator{PublicKey: make([]byte, fieldparams.BLSPubkeyLength)}))
+
+	var out bytes.Buffer
+	printDiff(&out, a.ToProtoUnsafe().(proto.Message), b.ToProtoUnsafe().(proto.Message), 20)
+	lines := out.String()
+	assert.Equal(t, true, strings.Contains(lines, "slot: 0 -> 10 (+10)\n"), lines)
+	assert.Equal(t, true, strings.Contains(lines, "balances[1]: 32000000000 -> 32000000005 (+5)\n"), lines)
+	assert.Equal(t, true, strings.Contains(lines, "balances[2]: 32000000000 -> 31999999998 (-2)\n"), lines)
+	assert.Equal(t, true, strings.Contains(lines, "balances: 2 entries changed, total +3\n"), lines)
+	assert.Equal(t, true, strings.Contains(lines, "validators[3].exit_epoch: 18446744073709551615 -> 100\n"), lines)
+	assert.Equal(t, true, strings.Contains(lines, "validators: length 64 -> 65\n"), lines)
+	assert.Equal(t, true, strings.Contains(lines, "validators[64]: added\n"), lines)
+	assert.Equal(t, true, strings.Contains(lines, "justification_bits: 0b0000 -> 0b0011\n"), lines)
+	assert.Equal(t, true, strings.Contains(lines, "latest_execution_payload_header.block_number: 0 -> 7 (+7)\n"), lines)
+}
+
+func TestPrintDiff_MaxEntries(t *testing.T) {
+	a, _ := util.DeterministicGenesisStateCapella(t, 8)
+	b := a.Copy()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, b.UpdateBalancesAtIndex(primitives.ValidatorIndex(i), 1))
+	}
+
+	var out bytes.Buffer
+	printDiff(&out, a.ToProtoUnsafe().(proto.Message), b.ToProtoUnsafe().(proto.Message), 2)
+	lines := out.String()
+	assert.Equal(t, true, strings.Contains(lines, "balances[1]: 32000000000 -> 1 (-31999999999)\n"), lines)
+	assert.Equal(t, false, strings.Contains(lines, "balances[2]"), lines)
+	assert.Equal(t, true, strings.Contains(lines, "balances: 3 more differences not shown\n"), lines)
+}
+
+func TestPrintDiff_Blocks(t *testing.T) {
+	a := util.NewBeaconBlockCapella()
+	var out bytes.Buffer
+	printDiff(&out, a, a, 20)
+	assert.Equal(t, "no differences\n", out.String())
+
+	// Blocks of different forks are compared by field name.
+	b := util.NewBeaconBlockBellatrix()
+	b.Block.Slot = 3
+	out.Reset()
+	printDiff(&out, a, b, 20)
+	lines := out.String()
+	assert.Equal(t, true, strings.Contains(lines, "block.slot: 0 -> 3 (+3)\n"), lines)
+	assert.Equal(t, true, strings.Contains(lines, "block.body.bls_to_execution_changes: only in first\n"), lines)
+	assert.Equal(t, true, strings.Contains(lines, "block.body.execution_payload.withdrawals: only in first\n"), lines)
+}
@@ -0,0 +1,15 @@
+package era
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "era",
+		Usage: "commands to work with era archive files",
+		Subcommands: []*cli.Command{
+			exportCmd,
+			importCmd,
+			verifyCmd,
+		},
+	},
+}
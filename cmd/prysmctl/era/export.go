@@ -0,0 +1,217 @@
+package era
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/era"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var exportFlags = struct {
+	Path            string
+	OutputDir       string
+	ChainConfigFile string
+	StartEra        uint64
+	EndEra          uint64
+}{}
+
+var exportCmd = &cli.Command{
+	Name:  "export",
+	Usage: "Export finalized blocks and states from a beacon node database to era files",
+	Action: func(cliCtx *cli.Context) error {
+		if err := exportAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not export era files")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &exportFlags.Path,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "output-dir",
+			Usage:       "directory in which era files are written",
+			Destination: &exportFlags.OutputDir,
+			Value:       ".",
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "path to the chain config of the network, if it is not mainnet",
+			Destination: &exportFlags.ChainConfigFile,
+		},
+		&cli.Uint64Flag{
+			Name:        "start-era",
+			Usage:       "first era to export",
+			Destination: &exportFlags.StartEra,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-era",
+			Usage:       "last era to export, defaults to the last era that is finalized in the database",
+			Destination: &exportFlags.EndEra,
+		},
+	},
+}
+
+func exportAction(cliCtx *cli.Context) error {
+	f := exportFlags
+	ctx := cliCtx.Context
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return err
+		}
+	}
+	db, err := kv.NewKVStore(ctx, f.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open database at %s", f.Path)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	if err := os.MkdirAll(f.OutputDir, 0750); err != nil {
+		return err
+	}
+
+	finalized, err := db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	fBlock, err := db.Block(ctx, bytesutil.ToBytes32(finalized.Root))
+	if err != nil {
+		return err
+	}
+	if fBlock == nil || fBlock.IsNil() {
+		return errors.New("could not find the finalized block in the database")
+	}
+	// Only eras whose state is finalized can be exported.
+	lastEra := uint64(fBlock.Block().Slot() / params.BeaconConfig().SlotsPerHistoricalRoot)
+	endEra := f.EndEra
+	if endEra == 0 || endEra > lastEra {
+		endEra = lastEra
+	}
+	if f.StartEra > endEra {
+		return errors.Errorf("start era %d is after the last finalized era %d", f.StartEra, endEra)
+	}
+	for e := f.StartEra; e <= endEra; e++ {
+		p, err := exportEra(ctx, db, e, f.OutputDir)
+		if err != nil {
+			return errors.Wrapf(err, "could not export era %d", e)
+		}
+		log.WithField("era", e).WithField("path", p).Info("Exported era file")
+	}
+	return nil
+}
+
+// exportEra writes the era file of the given era to the output directory and returns its path.
+func exportEra(ctx context.Context, db *kv.Store, e uint64, outDir string) (string, error) {
+	st, err := eraState(ctx, db, e)
+	if err != nil {
+		return "", err
+	}
+	root := bytesutil.ToBytes32(st.GenesisValidatorsRoot())
+	if e > 0 {
+		root, err = era.HistoricalRoot(st)
+		if err != nil {
+			return "", err
+		}
+	}
+	p := filepath.Join(outDir, era.Filename(params.BeaconConfig().ConfigName, e, root))
+	tmp := p + ".tmp"
+	file, err := os.Create(tmp) // #nosec G304
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := file.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+			log.WithError(err).Error("Could not close era file")
+		}
+	}()
+	w, err := era.NewWriter(file, e)
+	if err != nil {
+		return "", err
+	}
+	if e > 0 {
+		blks, err := eraBlocks(ctx, db, e)
+		if err != nil {
+			return "", err
+		}
+		for _, b := range blks {
+			if err := w.AddBlock(b); err != nil {
+				return "", err
+			}
+		}
+	}
+	if err := w.Finalize(st); err != nil {
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	return p, os.Rename(tmp, p)
+}
+
+// eraState returns the state at the end of the era, advancing the latest stored state
+// before the era boundary through empty slots if necessary.
+func eraState(ctx context.Context, db *kv.Store, e uint64) (state.BeaconState, error) {
+	slot := era.StateSlot(e)
+	if e == 0 {
+		return db.GenesisState(ctx)
+	}
+	_, roots, err := db.HighestRootsBelowSlot(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range roots {
+		if !db.IsFinalizedBlock(ctx, r) {
+			continue
+		}
+		st, err := db.State(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		if st == nil || st.IsNil() {
+			return nil, errors.Errorf("no state stored for block %#x, exporting era files requires an archive node "+
+				"whose --slots-per-archive-point divides SLOTS_PER_HISTORICAL_ROOT", r)
+		}
+		if st.Slot() < slot {
+			return transition.ProcessSlots(ctx, st, slot)
+		}
+		return st, nil
+	}
+	return nil, errors.Errorf("no finalized block found before slot %d", slot)
+}
+
+// eraBlocks returns the finalized blocks of the era, in increasing slot order.
+func eraBlocks(ctx context.Context, db *kv.Store, e uint64) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	filter := filters.NewFilter().SetStartSlot(era.StartSlot(e)).SetEndSlot(era.StateSlot(e) - 1)
+	blks, roots, err := db.Blocks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	canonical := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(blks))
+	for i, b := range blks {
+		if b.Block().Slot() == 0 || db.IsFinalizedBlock(ctx, roots[i]) {
+			canonical = append(canonical, b)
+		}
+	}
+	sort.Slice(canonical, func(i, j int) bool {
+		return canonical[i].Block().Slot() < canonical[j].Block().Slot()
+	})
+	return canonical, nil
+}
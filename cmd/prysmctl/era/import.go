@@ -0,0 +1,120 @@
+package era
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/encoding/era"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var importFlags = struct {
+	Path            string
+	EraDir          string
+	ChainConfigFile string
+}{}
+
+var importCmd = &cli.Command{
+	Name:  "import",
+	Usage: "Import the blocks of era files into a beacon node database, after verifying them against the era states",
+	Action: func(cliCtx *cli.Context) error {
+		if err := importAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not import era files")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &importFlags.Path,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "era-dir",
+			Usage:       "directory containing the era files to import",
+			Destination: &importFlags.EraDir,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "path to the chain config of the network, if it is not mainnet",
+			Destination: &importFlags.ChainConfigFile,
+		},
+	},
+}
+
+func importAction(cliCtx *cli.Context) error {
+	f := importFlags
+	ctx := cliCtx.Context
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return err
+		}
+	}
+	paths, err := filepath.Glob(filepath.Join(f.EraDir, "*"+era.FileExtension))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return errors.Errorf("no era file found in %s", f.EraDir)
+	}
+	// Era numbers are zero padded in file names, so that sorting the names sorts the eras.
+	sort.Strings(paths)
+	db, err := kv.NewKVStore(ctx, f.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open database at %s", f.Path)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	for _, p := range paths {
+		n, err := importEra(ctx, db, p)
+		if err != nil {
+			return errors.Wrapf(err, "could not import %s", p)
+		}
+		log.WithField("path", p).WithField("blocks", n).Info("Imported era file")
+	}
+	return nil
+}
+
+// importEra saves the blocks of an era file in the database, or the genesis state for era 0,
+// and returns the number of imported blocks.
+func importEra(ctx context.Context, db *kv.Store, p string) (int, error) {
+	r, err := era.Open(p)
+	if err != nil {
+		return 0, err
+	}
+	defer closeReader(r)
+	st, err := r.State()
+	if err != nil {
+		return 0, err
+	}
+	if r.Era() == 0 {
+		enc, err := st.MarshalSSZ()
+		if err != nil {
+			return 0, err
+		}
+		return 0, db.LoadGenesis(ctx, enc)
+	}
+	genesis, err := db.GenesisState(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if genesis != nil && !genesis.IsNil() && !bytes.Equal(genesis.GenesisValidatorsRoot(), st.GenesisValidatorsRoot()) {
+		return 0, errors.New("era file belongs to a different network than the database")
+	}
+	blks, err := verifiedEraBlocks(r, st)
+	if err != nil {
+		return 0, err
+	}
+	return len(blks), db.SaveBlocks(ctx, blks)
+}
@@ -0,0 +1,124 @@
+package era
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/era"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var verifyFlags = struct {
+	ChainConfigFile string
+}{}
+
+var verifyCmd = &cli.Command{
+	Name:      "verify",
+	Usage:     "Verify the content of era and era1 files",
+	ArgsUsage: "<file>...",
+	Action: func(cliCtx *cli.Context) error {
+		if err := verifyAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not verify era files")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "path to the chain config of the network, if it is not mainnet",
+			Destination: &verifyFlags.ChainConfigFile,
+		},
+	},
+}
+
+func verifyAction(cliCtx *cli.Context) error {
+	if verifyFlags.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(verifyFlags.ChainConfigFile, nil); err != nil {
+			return err
+		}
+	}
+	if cliCtx.NArg() == 0 {
+		return errors.New("no file to verify")
+	}
+	for _, p := range cliCtx.Args().Slice() {
+		var err error
+		switch filepath.Ext(p) {
+		case era.FileExtension:
+			err = verifyEraFile(p)
+		case era.Era1FileExtension:
+			err = verifyEra1File(p)
+		default:
+			err = errors.New("unknown file extension")
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not verify %s", p)
+		}
+		log.WithField("path", p).Info("Verified era file")
+	}
+	return nil
+}
+
+func verifyEraFile(p string) error {
+	r, err := era.Open(p)
+	if err != nil {
+		return err
+	}
+	defer closeReader(r)
+	st, err := r.State()
+	if err != nil {
+		return err
+	}
+	_, err = verifiedEraBlocks(r, st)
+	return err
+}
+
+func verifyEra1File(p string) error {
+	r, err := era.OpenEra1(p)
+	if err != nil {
+		return err
+	}
+	defer closeReader(r)
+	return r.Verify()
+}
+
+// verifiedEraBlocks returns the blocks of an era file, in increasing slot order, after checking
+// them against the block roots of the state stored at the end of the era.
+func verifiedEraBlocks(r *era.Reader, st state.ReadOnlyBeaconState) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	if st.Slot() != era.StateSlot(r.Era()) {
+		return nil, errors.Errorf("state slot %d does not match era %d", st.Slot(), r.Era())
+	}
+	if r.Era() == 0 {
+		return nil, nil
+	}
+	roots := st.BlockRoots()
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+	for slot := era.StartSlot(r.Era()); slot < st.Slot(); slot++ {
+		b, err := r.Block(slot)
+		if err != nil {
+			return nil, err
+		}
+		if b == nil {
+			continue
+		}
+		root, err := b.Block().HashTreeRoot()
+		if err != nil {
+			return nil, err
+		}
+		if want := roots[slot%params.BeaconConfig().SlotsPerHistoricalRoot]; root != bytesutil.ToBytes32(want) {
+			return nil, errors.Errorf("root %#x of block at slot %d does not match the era state block root %#x", root, slot, want)
+		}
+		blks = append(blks, b)
+	}
+	return blks, nil
+}
+
+func closeReader(r interface{ Close() error }) {
+	if err := r.Close(); err != nil {
+		log.WithError(err).Error("Could not close era file")
+	}
+}
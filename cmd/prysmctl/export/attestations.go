@@ -0,0 +1,272 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var attestationsFlags = struct {
+	Path            string
+	Output          string
+	ChainConfigFile string
+	StartSlot       uint64
+	EndSlot         uint64
+}{}
+
+var attestationsCmd = &cli.Command{
+	Name: "attestations",
+	Usage: "Export the metadata of the attestations included in finalized blocks over a slot range to CSV, " +
+		"with one row per attestation. Stored blocks are replayed to evaluate the correctness of every vote.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := attestationsAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not export attestations")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &attestationsFlags.Path,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "path of the CSV file to write. The rows are written to stdout when not set.",
+			Destination: &attestationsFlags.Output,
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "path to the chain config of the network, if it is not mainnet",
+			Destination: &attestationsFlags.ChainConfigFile,
+		},
+		&cli.Uint64Flag{
+			Name:        "start-slot",
+			Usage:       "slot of the first block to export attestations from",
+			Destination: &attestationsFlags.StartSlot,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-slot",
+			Usage:       "slot of the last block to export attestations from, defaults to the finalized slot",
+			Destination: &attestationsFlags.EndSlot,
+		},
+	},
+}
+
+// attestationColumns is the header of the exported CSV.
+var attestationColumns = []string{
+	"inclusion_slot",
+	"slot",
+	"committee_index",
+	"inclusion_delay",
+	"target_epoch",
+	"attesters",
+	"committee_size",
+	"source_correct",
+	"target_correct",
+	"head_correct",
+}
+
+func attestationsAction(cliCtx *cli.Context) error {
+	f := attestationsFlags
+	ctx := cliCtx.Context
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return err
+		}
+	}
+	db, err := kv.NewKVStore(ctx, f.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open database at %s", f.Path)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+
+	finalized, err := db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	fBlock, err := db.Block(ctx, bytesutil.ToBytes32(finalized.Root))
+	if err != nil {
+		return err
+	}
+	if fBlock == nil || fBlock.IsNil() {
+		return errors.New("could not find the finalized block in the database")
+	}
+	start, end := primitives.Slot(f.StartSlot), primitives.Slot(f.EndSlot)
+	if end == 0 || end > fBlock.Block().Slot() {
+		end = fBlock.Block().Slot()
+	}
+	if start > end {
+		return errors.Errorf("start slot %d is after the end slot %d", start, end)
+	}
+
+	var out io.Writer = os.Stdout
+	if f.Output != "" {
+		file, err := os.Create(f.Output) // #nosec G304
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := file.Close(); err != nil {
+				log.WithError(err).Error("Could not close output file")
+			}
+		}()
+		out = file
+	}
+	n, err := exportAttestations(ctx, db, start, end, out)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"startSlot":    start,
+		"endSlot":      end,
+		"attestations": n,
+	}).Info("Exported attestations")
+	return nil
+}
+
+// exportAttestations replays the finalized blocks up to the end slot, starting from the latest stored state
+// before the start slot, and writes a row for every attestation included in the blocks of the slot range.
+// It returns the number of rows written.
+func exportAttestations(ctx context.Context, db *kv.Store, start, end primitives.Slot, out io.Writer) (int, error) {
+	st, err := replayStartState(ctx, db, start)
+	if err != nil {
+		return 0, err
+	}
+	blks, err := finalizedBlocks(ctx, db, st.Slot()+1, end)
+	if err != nil {
+		return 0, err
+	}
+
+	w := csv.NewWriter(out)
+	if err := w.Write(attestationColumns); err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, b := range blks {
+		if b.Block().Slot() > st.Slot() {
+			st, err = transition.ProcessSlots(ctx, st, b.Block().Slot())
+			if err != nil {
+				return 0, errors.Wrapf(err, "could not process slots up to %d", b.Block().Slot())
+			}
+		}
+		if b.Block().Slot() >= start {
+			rows, err := attestationRows(st, b)
+			if err != nil {
+				return 0, errors.Wrapf(err, "could not export attestations of block at slot %d", b.Block().Slot())
+			}
+			if err := w.WriteAll(rows); err != nil {
+				return 0, err
+			}
+			n += len(rows)
+		}
+		_, st, err = transition.ProcessBlockNoVerifyAnySig(ctx, st, b)
+		if err != nil {
+			return 0, errors.Wrapf(err, "could not process block at slot %d", b.Block().Slot())
+		}
+	}
+	w.Flush()
+	return n, w.Error()
+}
+
+// attestationRows returns a row for every attestation of the block, evaluated against the state at the slot
+// of the block, before the block is applied.
+func attestationRows(st state.BeaconState, b interfaces.ReadOnlySignedBeaconBlock) ([][]string, error) {
+	currentEpoch := time.CurrentEpoch(st)
+	atts := b.Block().Body().Attestations()
+	rows := make([][]string, 0, len(atts))
+	for _, att := range atts {
+		data := att.Data
+		justified := st.PreviousJustifiedCheckpoint()
+		if data.Target.Epoch == currentEpoch {
+			justified = st.CurrentJustifiedCheckpoint()
+		}
+		source, target, head, err := altair.MatchingStatus(st, data, justified)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, []string{
+			strconv.FormatUint(uint64(b.Block().Slot()), 10),
+			strconv.FormatUint(uint64(data.Slot), 10),
+			strconv.FormatUint(uint64(data.CommitteeIndex), 10),
+			strconv.FormatUint(uint64(b.Block().Slot()-data.Slot), 10),
+			strconv.FormatUint(uint64(data.Target.Epoch), 10),
+			strconv.FormatUint(att.AggregationBits.Count(), 10),
+			strconv.FormatUint(att.AggregationBits.Len(), 10),
+			strconv.FormatBool(source),
+			strconv.FormatBool(target),
+			strconv.FormatBool(head),
+		})
+	}
+	return rows, nil
+}
+
+// replayStartState returns the latest state stored for a finalized block before the given slot.
+func replayStartState(ctx context.Context, db *kv.Store, slot primitives.Slot) (state.BeaconState, error) {
+	if slot == 0 {
+		return db.GenesisState(ctx)
+	}
+	for {
+		highest, roots, err := db.HighestRootsBelowSlot(ctx, slot)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range roots {
+			if highest != 0 && !db.IsFinalizedBlock(ctx, r) {
+				continue
+			}
+			if !db.HasState(ctx, r) {
+				continue
+			}
+			return db.State(ctx, r)
+		}
+		if highest == 0 {
+			return nil, fmt.Errorf("no state stored before slot %d", slot)
+		}
+		slot = highest
+	}
+}
+
+// finalizedBlocks returns the finalized blocks of the slot range, in increasing slot order.
+func finalizedBlocks(ctx context.Context, db *kv.Store, start, end primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	if start > end {
+		return nil, nil
+	}
+	blks, roots, err := db.Blocks(ctx, filters.NewFilter().SetStartSlot(start).SetEndSlot(end))
+	if err != nil {
+		return nil, err
+	}
+	canonical := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(blks))
+	for i, b := range blks {
+		if db.IsFinalizedBlock(ctx, roots[i]) {
+			canonical = append(canonical, b)
+		}
+	}
+	sort.Slice(canonical, func(i, j int) bool {
+		return canonical[i].Block().Slot() < canonical[j].Block().Slot()
+	})
+	return canonical, nil
+}
@@ -0,0 +1,58 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strconv"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestExportAttestations(t *testing.T) {
+	ctx := context.Background()
+	db, err := kv.NewKVStore(ctx, t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	st, keys := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, db.SaveGenesisData(ctx, st))
+	var root [32]byte
+	for slot := 1; slot <= 3; slot++ {
+		b, err := util.GenerateFullBlock(st, keys, &util.BlockGenConfig{NumAttestations: 1}, 1+st.Slot())
+		require.NoError(t, err)
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		require.NoError(t, db.SaveBlock(ctx, wsb))
+		root, err = wsb.Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: wsb.Block().Slot(), Root: root[:]}))
+	}
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Root: root[:]}))
+
+	var out bytes.Buffer
+	n, err := exportAttestations(ctx, db, 2, 3, &out)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	rows, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(rows))
+	assert.DeepEqual(t, attestationColumns, rows[0])
+	for i, row := range rows[1:] {
+		// Every block includes an attestation from the previous slot, which votes for the canonical chain.
+		assert.DeepEqual(t, []string{
+			strconv.Itoa(i + 2), strconv.Itoa(i + 1), "0", "1", "0", row[5], row[6], "true", "true", "true",
+		}, row)
+	}
+}
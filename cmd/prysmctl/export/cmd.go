@@ -0,0 +1,13 @@
+package export
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "export",
+		Usage: "commands to export chain data from a beacon node database for offline analysis",
+		Subcommands: []*cli.Command{
+			attestationsCmd,
+		},
+	},
+}
@@ -0,0 +1,13 @@
+package fork
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "fork",
+		Usage: "commands to prepare a beacon node for an upcoming fork",
+		Subcommands: []*cli.Command{
+			rehearseCmd,
+		},
+	},
+}
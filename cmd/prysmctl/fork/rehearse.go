@@ -0,0 +1,252 @@
+package fork
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var rehearseFlags = struct {
+	BeaconNodeHost  string
+	Timeout         time.Duration
+	Path            string
+	ChainConfigFile string
+	ForkEpoch       uint64
+	Epochs          uint64
+}{}
+
+var rehearseCmd = &cli.Command{
+	Name: "rehearse",
+	Usage: "Dry run the upcoming fork on the head state of a beacon node. The state is upgraded with the fork's " +
+		"upgrade function and advanced through a few epochs of empty slots, without modifying the node.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := rehearseAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Fork rehearsal failed")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port of a running beacon node to download the head state from",
+			Destination: &rehearseFlags.BeaconNodeHost,
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-host (uses duration format, ex: 2m31s). default: 4m",
+			Destination: &rehearseFlags.Timeout,
+			Value:       time.Minute * 4,
+		},
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing the beaconchain.db of a stopped beacon node, used instead of beacon-node-host",
+			Destination: &rehearseFlags.Path,
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "path to the chain config of the network, if it is not mainnet",
+			Destination: &rehearseFlags.ChainConfigFile,
+		},
+		&cli.Uint64Flag{
+			Name:        "fork-epoch",
+			Usage:       "epoch at which to rehearse the upcoming fork, defaults to the epoch scheduled in the chain config",
+			Destination: &rehearseFlags.ForkEpoch,
+		},
+		&cli.Uint64Flag{
+			Name:        "epochs",
+			Usage:       "number of epochs of empty slots to process after the fork",
+			Destination: &rehearseFlags.Epochs,
+			Value:       2,
+		},
+	},
+}
+
+func rehearseAction(cliCtx *cli.Context) error {
+	f := rehearseFlags
+	ctx := cliCtx.Context
+	if (f.BeaconNodeHost == "") == (f.Path == "") {
+		return errors.New("exactly one of --beacon-node-host or --path must be set")
+	}
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return err
+		}
+	}
+	st, err := headState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not load head state")
+	}
+	log.WithField("slot", st.Slot()).WithField("fork", version.String(st.Version())).Info("Loaded head state")
+
+	v, epoch, err := upcomingFork(st, primitives.Epoch(f.ForkEpoch))
+	if err != nil {
+		return err
+	}
+	if err := rehearse(ctx, st, v, epoch, primitives.Epoch(f.Epochs)); err != nil {
+		return err
+	}
+	log.WithField("fork", version.String(v)).Info("Fork rehearsal succeeded")
+	return nil
+}
+
+// headState loads the head state from the beacon node API or from the database, depending on the flags.
+func headState(ctx context.Context) (state.BeaconState, error) {
+	f := rehearseFlags
+	if f.BeaconNodeHost != "" {
+		c, err := beacon.NewClient(f.BeaconNodeHost, client.WithTimeout(f.Timeout))
+		if err != nil {
+			return nil, err
+		}
+		b, err := c.GetState(ctx, beacon.IdHead)
+		if err != nil {
+			return nil, err
+		}
+		vu, err := detect.FromState(b)
+		if err != nil {
+			return nil, err
+		}
+		return vu.UnmarshalBeaconState(b)
+	}
+
+	db, err := kv.NewKVStore(ctx, f.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not open database at %s", f.Path)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	head, err := db.HeadBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if head == nil || head.IsNil() {
+		return nil, errors.New("no head block in the database")
+	}
+	root, err := head.Block().HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	st, err := db.State(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	if st != nil && !st.IsNil() {
+		return st, nil
+	}
+	// The head state is only stored when the node shuts down, fall back to the finalized state otherwise.
+	finalized, err := db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	st, err = db.State(ctx, bytesutil.ToBytes32(finalized.Root))
+	if err != nil {
+		return nil, err
+	}
+	if st == nil || st.IsNil() {
+		return nil, errors.New("neither the head nor the finalized state is stored in the database")
+	}
+	log.WithField("headSlot", head.Block().Slot()).Warn("Head state is not stored in the database, using the finalized state")
+	return st, nil
+}
+
+// upcomingFork returns the version and epoch of the fork following the one of the given state. A non-zero
+// epoch overrides the epoch of the fork in the chain config.
+func upcomingFork(st state.ReadOnlyBeaconState, epoch primitives.Epoch) (int, primitives.Epoch, error) {
+	cfg := params.BeaconConfig().Copy()
+	next := st.Version() + 1
+	var forkEpoch *primitives.Epoch
+	switch next {
+	case version.Altair:
+		forkEpoch = &cfg.AltairForkEpoch
+	case version.Bellatrix:
+		forkEpoch = &cfg.BellatrixForkEpoch
+	case version.Capella:
+		forkEpoch = &cfg.CapellaForkEpoch
+	case version.Deneb:
+		forkEpoch = &cfg.DenebForkEpoch
+	default:
+		return 0, 0, errors.Errorf("no fork is known after %s", version.String(st.Version()))
+	}
+	if epoch != 0 {
+		*forkEpoch = epoch
+		cfg.InitializeForkSchedule()
+		params.OverrideBeaconConfig(cfg)
+	}
+	if *forkEpoch == cfg.FarFutureEpoch {
+		return 0, 0, errors.Errorf("the %s fork is not scheduled, use --fork-epoch to rehearse it at a given epoch", version.String(next))
+	}
+	if current := slots.ToEpoch(st.Slot()); *forkEpoch <= current {
+		return 0, 0, errors.Errorf("the %s fork epoch %d is not after the epoch %d of the state", version.String(next), *forkEpoch, current)
+	}
+	return next, *forkEpoch, nil
+}
+
+// rehearse processes empty slots on the state up to the given fork epoch, checks that the state is upgraded
+// to the fork, then processes the given number of epochs of empty slots after it.
+func rehearse(ctx context.Context, st state.BeaconState, v int, forkEpoch, epochs primitives.Epoch) error {
+	forkSlot, err := slots.EpochStart(forkEpoch)
+	if err != nil {
+		return err
+	}
+	log.WithField("forkSlot", forkSlot).WithField("fork", version.String(v)).Info("Processing empty slots up to the fork")
+	// Process the slots epoch by epoch to report progress, as the fork may be far ahead.
+	for st.Slot()+params.BeaconConfig().SlotsPerEpoch < forkSlot {
+		st, err = transition.ProcessSlots(ctx, st, st.Slot()+params.BeaconConfig().SlotsPerEpoch)
+		if err != nil {
+			return errors.Wrapf(err, "could not process slots before the fork")
+		}
+		log.WithField("slot", st.Slot()).Debug("Processed epoch of empty slots")
+	}
+
+	start := time.Now()
+	st, err = transition.ProcessSlots(ctx, st, forkSlot)
+	if err != nil {
+		return errors.Wrapf(err, "could not process the fork transition at slot %d", forkSlot)
+	}
+	if st.Version() != v {
+		return errors.Errorf("state was not upgraded at slot %d: got %s, wanted %s", forkSlot, version.String(st.Version()), version.String(v))
+	}
+	root, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not compute the root of the upgraded state")
+	}
+	log.WithFields(log.Fields{
+		"slot":      st.Slot(),
+		"stateRoot": root,
+		"duration":  time.Since(start),
+	}).Infof("Upgraded state to %s", version.String(v))
+
+	for i := primitives.Epoch(1); i <= epochs; i++ {
+		start := time.Now()
+		st, err = transition.ProcessSlots(ctx, st, forkSlot+primitives.Slot(i)*params.BeaconConfig().SlotsPerEpoch)
+		if err != nil {
+			return errors.Wrapf(err, "could not process epoch %d after the fork", forkEpoch+i)
+		}
+		root, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "could not compute the state root at epoch %d", forkEpoch+i)
+		}
+		log.WithFields(log.Fields{
+			"epoch":     forkEpoch + i,
+			"stateRoot": root,
+			"duration":  time.Since(start),
+		}).Info("Processed epoch after the fork")
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package fork
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestUpcomingFork(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = cfg.FarFutureEpoch
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+
+	st, _ := util.DeterministicGenesisState(t, 64)
+	_, _, err := upcomingFork(st, 0)
+	require.ErrorContains(t, "fork is not scheduled", err)
+
+	v, epoch, err := upcomingFork(st, 3)
+	require.NoError(t, err)
+	assert.Equal(t, version.Altair, v)
+	assert.Equal(t, primitives.Epoch(3), epoch)
+	assert.Equal(t, primitives.Epoch(3), params.BeaconConfig().AltairForkEpoch)
+
+	stDeneb, _ := util.DeterministicGenesisStateDeneb(t, 64)
+	_, _, err = upcomingFork(stDeneb, 0)
+	require.ErrorContains(t, "no fork is known after deneb", err)
+}
+
+func TestRehearse(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 2
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+
+	st, _ := util.DeterministicGenesisState(t, 64)
+	v, epoch, err := upcomingFork(st, 0)
+	require.NoError(t, err)
+	require.NoError(t, rehearse(context.Background(), st, v, epoch, 2))
+
+	// Rehearsing the wrong fork fails.
+	st, _ = util.DeterministicGenesisState(t, 64)
+	require.ErrorContains(t, "state was not upgraded", rehearse(context.Background(), st, version.Bellatrix, epoch, 0))
+}
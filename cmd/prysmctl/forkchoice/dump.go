@@ -0,0 +1,97 @@
+package forkchoice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var dumpFlags = struct {
+	BeaconNodeHost string
+	Timeout        time.Duration
+	Output         string
+}{}
+
+var dumpCmd = &cli.Command{
+	Name:  "dump",
+	Usage: "Download a dump of the fork choice store of a beacon node, including weights, proposer boost and optimistic status of every node.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionDump(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not dump fork choice")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node connection",
+			Destination: &dumpFlags.BeaconNodeHost,
+			Value:       "localhost:3500",
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-url (uses duration format, ex: 2m31s). default: 1m",
+			Destination: &dumpFlags.Timeout,
+			Value:       time.Minute,
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "path of the file to write the json-encoded dump to. The dump is written to stdout when not set.",
+			Destination: &dumpFlags.Output,
+		},
+	},
+}
+
+func cliActionDump(_ *cli.Context) error {
+	ctx := context.Background()
+	f := dumpFlags
+
+	opts := []client.ClientOpt{client.WithTimeout(f.Timeout)}
+	c, err := beacon.NewClient(f.BeaconNodeHost, opts...)
+	if err != nil {
+		return err
+	}
+	raw, err := c.GetForkChoice(ctx)
+	if err != nil {
+		return err
+	}
+	dump := &apimiddleware.ForkChoiceResponseJson{}
+	if err := json.Unmarshal(raw, dump); err != nil {
+		return errors.Wrap(err, "could not decode fork choice dump")
+	}
+	summary := log.WithField("nodes", len(dump.ForkChoiceNodes))
+	if dump.JustifiedCheckpoint != nil && dump.FinalizedCheckpoint != nil {
+		summary = summary.
+			WithField("justifiedEpoch", dump.JustifiedCheckpoint.Epoch).
+			WithField("finalizedEpoch", dump.FinalizedCheckpoint.Epoch)
+	}
+	if dump.ExtraData != nil {
+		summary = summary.WithField("headRoot", dump.ExtraData.HeadRoot)
+	}
+	summary.Info("Downloaded fork choice dump")
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, raw, "", "  "); err != nil {
+		return errors.Wrap(err, "could not format fork choice dump")
+	}
+	out.WriteByte('\n')
+	if f.Output == "" {
+		_, err = os.Stdout.Write(out.Bytes())
+		return err
+	}
+	if err := file.WriteFile(f.Output, out.Bytes()); err != nil {
+		return err
+	}
+	log.Printf("saved fork choice dump to %s", f.Output)
+	return nil
+}
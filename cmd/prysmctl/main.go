@@ -6,7 +6,14 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/checkpointsync"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/db"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/deprecated"
+	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/diff"
+	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/era"
+	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/export"
+	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/fork"
+	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/forkchoice"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/p2p"
+	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/slashing"
+	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/supportbundle"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/testnet"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/validator"
 	"github.com/prysmaticlabs/prysm/v4/cmd/prysmctl/weaksubjectivity"
@@ -33,7 +40,14 @@ func init() {
 
 	prysmctlCommands = append(prysmctlCommands, checkpointsync.Commands...)
 	prysmctlCommands = append(prysmctlCommands, db.Commands...)
+	prysmctlCommands = append(prysmctlCommands, diff.Commands...)
+	prysmctlCommands = append(prysmctlCommands, era.Commands...)
+	prysmctlCommands = append(prysmctlCommands, export.Commands...)
+	prysmctlCommands = append(prysmctlCommands, fork.Commands...)
+	prysmctlCommands = append(prysmctlCommands, forkchoice.Commands...)
 	prysmctlCommands = append(prysmctlCommands, p2p.Commands...)
+	prysmctlCommands = append(prysmctlCommands, slashing.Commands...)
+	prysmctlCommands = append(prysmctlCommands, supportbundle.Commands...)
 	prysmctlCommands = append(prysmctlCommands, testnet.Commands...)
 	prysmctlCommands = append(prysmctlCommands, weaksubjectivity.Commands...)
 	prysmctlCommands = append(prysmctlCommands, validator.Commands...)
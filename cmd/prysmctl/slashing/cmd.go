@@ -0,0 +1,14 @@
+package slashing
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "slashing",
+		Usage: "commands to export and import the evidence and detected slashings of a slasher database",
+		Subcommands: []*cli.Command{
+			exportCmd,
+			importCmd,
+		},
+	},
+}
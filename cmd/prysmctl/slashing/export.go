@@ -0,0 +1,136 @@
+package slashing
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/slasherkv"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var exportFlags = struct {
+	Path   string
+	Output string
+	Format string
+}{}
+
+var exportCmd = &cli.Command{
+	Name: "export",
+	Usage: "Export the attestation records, block proposals, span chunks and detected slashings of a slasher database, " +
+		"to move them to another slasher machine or to share them with other clients.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := exportAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not export slasher evidence")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing slasher.db",
+			Destination: &exportFlags.Path,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "path of the file to write. The evidence is written to stdout when not set.",
+			Destination: &exportFlags.Output,
+		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "format of the exported evidence, json or ssz",
+			Value:       formatJSON,
+			Destination: &exportFlags.Format,
+		},
+	},
+}
+
+func exportAction(cliCtx *cli.Context) error {
+	if err := validateFormat(exportFlags.Format); err != nil {
+		return err
+	}
+	var w io.Writer = os.Stdout
+	if exportFlags.Output != "" {
+		f, err := os.Create(exportFlags.Output)
+		if err != nil {
+			return errors.Wrap(err, "could not create output file")
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				log.WithError(err).Error("Could not close output file")
+			}
+		}()
+		w = f
+	}
+	db, err := slasherkv.NewKVStore(cliCtx.Context, exportFlags.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open slasher database at %s", exportFlags.Path)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close slasher database")
+		}
+	}()
+	counts, err := exportEvidence(cliCtx.Context, db, w, exportFlags.Format)
+	if err != nil {
+		return err
+	}
+	log.WithFields(counts.fields()).Info("Exported slasher evidence")
+	return nil
+}
+
+// exportEvidence writes all the evidence of the slasher database in the given format. The
+// database is read one record at a time, as the evidence is written.
+func exportEvidence(ctx context.Context, db *slasherkv.Store, w io.Writer, format string) (*evidenceCounts, error) {
+	ew, err := newEvidenceWriter(w, format)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not write evidence")
+	}
+	counts := &evidenceCounts{}
+	write := func(r *record) error {
+		if err := ew.write(r); err != nil {
+			return errors.Wrap(err, "could not write evidence")
+		}
+		counts.add(r.kind)
+		return nil
+	}
+	if err := db.ForEachAttestationRecord(ctx, func(att *slashertypes.IndexedAttestationWrapper) error {
+		return write(&record{kind: recordAttestation, attestation: att})
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not export attestation records")
+	}
+	if err := db.ForEachBlockProposal(ctx, func(proposal *slashertypes.SignedBlockHeaderWrapper) error {
+		return write(&record{kind: recordProposal, proposal: proposal})
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not export block proposals")
+	}
+	if err := db.ForEachAttesterSlashing(ctx, func(sl *ethpb.AttesterSlashing) error {
+		return write(&record{kind: recordAttesterSlashing, attesterSlashing: sl})
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not export attester slashings")
+	}
+	if err := db.ForEachProposerSlashing(ctx, func(sl *ethpb.ProposerSlashing) error {
+		return write(&record{kind: recordProposerSlashing, proposerSlashing: sl})
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not export proposer slashings")
+	}
+	if err := db.ForEachSlasherChunk(ctx, func(kind slashertypes.ChunkKind, diskKey []byte, chunk []uint16) error {
+		return write(&record{kind: recordSpanChunk, spanChunk: &spanChunk{kind: kind, diskKey: diskKey, chunk: chunk}})
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not export span chunks")
+	}
+	if err := db.ForEachLastEpochWritten(ctx, func(item *slashertypes.AttestedEpochForValidator) error {
+		return write(&record{kind: recordLastEpochWritten, lastEpochWritten: item})
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not export last epochs written")
+	}
+	if err := ew.close(); err != nil {
+		return nil, errors.Wrap(err, "could not write evidence")
+	}
+	return counts, nil
+}
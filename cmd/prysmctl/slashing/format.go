@@ -0,0 +1,664 @@
+// Package slashing implements the prysmctl commands moving the evidence of a slasher database,
+// along with the slashings it detected, from one machine or client to another.
+//
+// Evidence is made of the attestation records and block proposals slasher checks incoming
+// messages against for double votes and double proposals, and of the min and max span chunks,
+// along with the latest epoch written for each validator, it checks incoming attestations
+// against for surround votes. The span chunks are laid out for the default slasher parameters,
+// and are merged with the chunks of the database they are imported into, keeping the lowest min
+// spans and the highest max spans.
+//
+// Two formats are supported. The JSON format is a single document, using the encoding of the
+// Ethereum beacon APIs for the consensus objects. The version comes first, and any of the
+// lists may be missing:
+//
+//	{
+//	  "version": 1,
+//	  "attestations": [{"signing_root": "0x...", "attestation": IndexedAttestation}],
+//	  "proposals": [{"signing_root": "0x...", "header": SignedBeaconBlockHeader}],
+//	  "attester_slashings": [AttesterSlashing],
+//	  "proposer_slashings": [ProposerSlashing],
+//	  "span_chunks": [{"kind": "min" or "max", "index": "1", "chunk": [65535, 2, ...]}],
+//	  "last_epochs_written": [{"validator_index": "1", "epoch": "1"}]
+//	}
+//
+// The SSZ format is a stream of records following a header, with integers encoded in little-endian:
+//
+//	header = [magic "PSLE" | version (uint32)]
+//	record = [kind (uint8) | payload length (uint32) | payload]
+//
+// where the payload of each kind of record is:
+//
+//	1: attestation        = [signing root (32 bytes) | SSZ IndexedAttestation]
+//	2: proposal           = [signing root (32 bytes) | SSZ SignedBeaconBlockHeader]
+//	3: attester slashing  = SSZ AttesterSlashing
+//	4: proposer slashing  = SSZ ProposerSlashing
+//	5: span chunk         = [kind (uint8, 0 for min and 1 for max) | chunk index (uint64) | values (uint16) ...]
+//	6: last epoch written = [validator index (uint64) | epoch (uint64)]
+//
+// Both formats are written and read one record at a time, so that neither the database nor
+// the evidence is ever held in memory at once.
+package slashing
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	formatJSON = "json"
+	formatSSZ  = "ssz"
+
+	evidenceVersion = 1
+	// Caps the payload length of an SSZ record, which is far larger than any consensus object it holds.
+	maxRecordLength = 1 << 24
+)
+
+var sszMagic = [4]byte{'P', 'S', 'L', 'E'}
+
+const (
+	recordAttestation uint8 = iota + 1
+	recordProposal
+	recordAttesterSlashing
+	recordProposerSlashing
+	recordSpanChunk
+	recordLastEpochWritten
+)
+
+// Keys of the lists of each kind of record in the JSON format.
+var jsonRecordKeys = map[uint8]string{
+	recordAttestation:      "attestations",
+	recordProposal:         "proposals",
+	recordAttesterSlashing: "attester_slashings",
+	recordProposerSlashing: "proposer_slashings",
+	recordSpanChunk:        "span_chunks",
+	recordLastEpochWritten: "last_epochs_written",
+}
+
+// record is a single piece of evidence moved by the export and import commands. The field
+// matching its kind is set.
+type record struct {
+	kind             uint8
+	attestation      *slashertypes.IndexedAttestationWrapper
+	proposal         *slashertypes.SignedBlockHeaderWrapper
+	attesterSlashing *ethpb.AttesterSlashing
+	proposerSlashing *ethpb.ProposerSlashing
+	spanChunk        *spanChunk
+	lastEpochWritten *slashertypes.AttestedEpochForValidator
+}
+
+// spanChunk is a min or max span chunk of slasher, along with its disk key.
+type spanChunk struct {
+	kind    slashertypes.ChunkKind
+	diskKey []byte
+	chunk   []uint16
+}
+
+// evidenceCounts counts the records of each kind moved by the export and import commands.
+type evidenceCounts struct {
+	attestations      int
+	proposals         int
+	attesterSlashings int
+	proposerSlashings int
+	spanChunks        int
+	lastEpochsWritten int
+}
+
+func (c *evidenceCounts) add(kind uint8) {
+	switch kind {
+	case recordAttestation:
+		c.attestations++
+	case recordProposal:
+		c.proposals++
+	case recordAttesterSlashing:
+		c.attesterSlashings++
+	case recordProposerSlashing:
+		c.proposerSlashings++
+	case recordSpanChunk:
+		c.spanChunks++
+	case recordLastEpochWritten:
+		c.lastEpochsWritten++
+	}
+}
+
+func (c *evidenceCounts) fields() log.Fields {
+	return log.Fields{
+		"attestations":      c.attestations,
+		"proposals":         c.proposals,
+		"attesterSlashings": c.attesterSlashings,
+		"proposerSlashings": c.proposerSlashings,
+		"spanChunks":        c.spanChunks,
+		"lastEpochsWritten": c.lastEpochsWritten,
+	}
+}
+
+type attestationRecordJSON struct {
+	SigningRoot string                     `json:"signing_root"`
+	Attestation *shared.IndexedAttestation `json:"attestation"`
+}
+
+type proposalRecordJSON struct {
+	SigningRoot string                          `json:"signing_root"`
+	Header      *shared.SignedBeaconBlockHeader `json:"header"`
+}
+
+type spanChunkJSON struct {
+	Kind  string   `json:"kind"`
+	Index string   `json:"index"`
+	Chunk []uint16 `json:"chunk"`
+}
+
+type lastEpochWrittenJSON struct {
+	ValidatorIndex string `json:"validator_index"`
+	Epoch          string `json:"epoch"`
+}
+
+func validateFormat(format string) error {
+	if format != formatJSON && format != formatSSZ {
+		return fmt.Errorf("unsupported format %q, must be %s or %s", format, formatJSON, formatSSZ)
+	}
+	return nil
+}
+
+// evidenceWriter writes evidence one record at a time.
+type evidenceWriter interface {
+	write(r *record) error
+	// close completes the evidence. It does not close the underlying writer.
+	close() error
+}
+
+func newEvidenceWriter(w io.Writer, format string) (evidenceWriter, error) {
+	bw := bufio.NewWriter(w)
+	if format == formatSSZ {
+		header := make([]byte, 8)
+		copy(header, sszMagic[:])
+		binary.LittleEndian.PutUint32(header[4:], evidenceVersion)
+		if _, err := bw.Write(header); err != nil {
+			return nil, err
+		}
+		return &sszEvidenceWriter{w: bw}, nil
+	}
+	if _, err := fmt.Fprintf(bw, "{\"version\":%d", evidenceVersion); err != nil {
+		return nil, err
+	}
+	return &jsonEvidenceWriter{w: bw}, nil
+}
+
+// readEvidence reads evidence in the given format, calling f with every record as it is read.
+func readEvidence(r io.Reader, format string, f func(*record) error) error {
+	if format == formatSSZ {
+		return readEvidenceSSZ(r, f)
+	}
+	return readEvidenceJSON(r, f)
+}
+
+// jsonEvidenceWriter writes the records of the same kind in a list of the JSON document.
+type jsonEvidenceWriter struct {
+	w *bufio.Writer
+	// kind of the records of the open list, zero when no list is open.
+	kind uint8
+}
+
+func (j *jsonEvidenceWriter) write(r *record) error {
+	sep := ",\n"
+	if r.kind != j.kind {
+		if j.kind != 0 {
+			if _, err := j.w.WriteString("\n]"); err != nil {
+				return err
+			}
+		}
+		key, ok := jsonRecordKeys[r.kind]
+		if !ok {
+			return fmt.Errorf("unknown record kind %d", r.kind)
+		}
+		if _, err := fmt.Fprintf(j.w, ",\n%q:[", key); err != nil {
+			return err
+		}
+		j.kind = r.kind
+		sep = "\n"
+	}
+	value, err := recordToJSON(r)
+	if err != nil {
+		return err
+	}
+	enc, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if _, err := j.w.WriteString(sep); err != nil {
+		return err
+	}
+	_, err = j.w.Write(enc)
+	return err
+}
+
+func (j *jsonEvidenceWriter) close() error {
+	if j.kind != 0 {
+		if _, err := j.w.WriteString("\n]"); err != nil {
+			return err
+		}
+	}
+	if _, err := j.w.WriteString("\n}\n"); err != nil {
+		return err
+	}
+	return j.w.Flush()
+}
+
+func recordToJSON(r *record) (interface{}, error) {
+	switch r.kind {
+	case recordAttestation:
+		return &attestationRecordJSON{
+			SigningRoot: hexutil.Encode(r.attestation.SigningRoot[:]),
+			Attestation: shared.IndexedAttestationFromConsensus(r.attestation.IndexedAttestation),
+		}, nil
+	case recordProposal:
+		return &proposalRecordJSON{
+			SigningRoot: hexutil.Encode(r.proposal.SigningRoot[:]),
+			Header:      shared.SignedBeaconBlockHeaderFromConsensus(r.proposal.SignedBeaconBlockHeader),
+		}, nil
+	case recordAttesterSlashing:
+		converted, err := shared.AttesterSlashingsFromConsensus([]*ethpb.AttesterSlashing{r.attesterSlashing})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert attester slashing")
+		}
+		return converted[0], nil
+	case recordProposerSlashing:
+		converted, err := shared.ProposerSlashingsFromConsensus([]*ethpb.ProposerSlashing{r.proposerSlashing})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert proposer slashing")
+		}
+		return converted[0], nil
+	case recordSpanChunk:
+		kind := "min"
+		if r.spanChunk.kind == slashertypes.MaxSpan {
+			kind = "max"
+		}
+		return &spanChunkJSON{
+			Kind:  kind,
+			Index: strconv.FormatUint(binary.LittleEndian.Uint64(r.spanChunk.diskKey), 10),
+			Chunk: r.spanChunk.chunk,
+		}, nil
+	case recordLastEpochWritten:
+		return &lastEpochWrittenJSON{
+			ValidatorIndex: strconv.FormatUint(uint64(r.lastEpochWritten.ValidatorIndex), 10),
+			Epoch:          strconv.FormatUint(uint64(r.lastEpochWritten.Epoch), 10),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown record kind %d", r.kind)
+	}
+}
+
+func readEvidenceJSON(r io.Reader, f func(*record) error) error {
+	dec := json.NewDecoder(r)
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		return errors.Wrap(err, "could not decode JSON evidence")
+	}
+	versionRead := false
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return errors.Wrap(err, "could not decode JSON evidence")
+		}
+		key, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("could not decode JSON evidence: unexpected %v", token)
+		}
+		if key == "version" {
+			var version uint64
+			if err := dec.Decode(&version); err != nil {
+				return errors.Wrap(err, "could not decode version")
+			}
+			if version != evidenceVersion {
+				return fmt.Errorf("unsupported evidence version %d, want %d", version, evidenceVersion)
+			}
+			versionRead = true
+			continue
+		}
+		if !versionRead {
+			return errors.New("the version of JSON evidence must come first")
+		}
+		kind, ok := jsonRecordKind(key)
+		if !ok {
+			return fmt.Errorf("unknown field %q in JSON evidence", key)
+		}
+		if err := expectJSONDelim(dec, '['); err != nil {
+			return errors.Wrapf(err, "could not decode %s", key)
+		}
+		for i := 0; dec.More(); i++ {
+			rec, err := decodeJSONRecord(dec, kind)
+			if err != nil {
+				return errors.Wrapf(err, "could not decode %s[%d]", key, i)
+			}
+			if err := f(rec); err != nil {
+				return err
+			}
+		}
+		if err := expectJSONDelim(dec, ']'); err != nil {
+			return errors.Wrapf(err, "could not decode %s", key)
+		}
+	}
+	if err := expectJSONDelim(dec, '}'); err != nil {
+		return errors.Wrap(err, "could not decode JSON evidence")
+	}
+	if !versionRead {
+		return errors.New("JSON evidence has no version")
+	}
+	return nil
+}
+
+func expectJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v, got %v", delim, token)
+	}
+	return nil
+}
+
+func jsonRecordKind(key string) (uint8, bool) {
+	for kind, k := range jsonRecordKeys {
+		if k == key {
+			return kind, true
+		}
+	}
+	return 0, false
+}
+
+func decodeJSONRecord(dec *json.Decoder, kind uint8) (*record, error) {
+	switch kind {
+	case recordAttestation:
+		value := &attestationRecordJSON{}
+		if err := dec.Decode(value); err != nil {
+			return nil, err
+		}
+		if value.Attestation == nil {
+			return nil, errors.New("attestation is empty")
+		}
+		signingRoot, err := shared.DecodeHexWithLength(value.SigningRoot, fieldparams.RootLength)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode signing_root")
+		}
+		att, err := value.Attestation.ToConsensus()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode attestation")
+		}
+		return &record{kind: kind, attestation: &slashertypes.IndexedAttestationWrapper{
+			IndexedAttestation: att,
+			SigningRoot:        bytesutil.ToBytes32(signingRoot),
+		}}, nil
+	case recordProposal:
+		value := &proposalRecordJSON{}
+		if err := dec.Decode(value); err != nil {
+			return nil, err
+		}
+		if value.Header == nil {
+			return nil, errors.New("header is empty")
+		}
+		signingRoot, err := shared.DecodeHexWithLength(value.SigningRoot, fieldparams.RootLength)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode signing_root")
+		}
+		header, err := value.Header.ToConsensus()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode header")
+		}
+		return &record{kind: kind, proposal: &slashertypes.SignedBlockHeaderWrapper{
+			SignedBeaconBlockHeader: header,
+			SigningRoot:             bytesutil.ToBytes32(signingRoot),
+		}}, nil
+	case recordAttesterSlashing:
+		value := &shared.AttesterSlashing{}
+		if err := dec.Decode(value); err != nil {
+			return nil, err
+		}
+		converted, err := shared.AttesterSlashingsToConsensus([]*shared.AttesterSlashing{value})
+		if err != nil {
+			return nil, err
+		}
+		return &record{kind: kind, attesterSlashing: converted[0]}, nil
+	case recordProposerSlashing:
+		value := &shared.ProposerSlashing{}
+		if err := dec.Decode(value); err != nil {
+			return nil, err
+		}
+		converted, err := shared.ProposerSlashingsToConsensus([]*shared.ProposerSlashing{value})
+		if err != nil {
+			return nil, err
+		}
+		return &record{kind: kind, proposerSlashing: converted[0]}, nil
+	case recordSpanChunk:
+		value := &spanChunkJSON{}
+		if err := dec.Decode(value); err != nil {
+			return nil, err
+		}
+		var chunkKind slashertypes.ChunkKind
+		switch value.Kind {
+		case "min":
+			chunkKind = slashertypes.MinSpan
+		case "max":
+			chunkKind = slashertypes.MaxSpan
+		default:
+			return nil, fmt.Errorf("unknown span chunk kind %q", value.Kind)
+		}
+		index, err := strconv.ParseUint(value.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode index")
+		}
+		if len(value.Chunk) == 0 {
+			return nil, errors.New("chunk is empty")
+		}
+		diskKey := make([]byte, 8)
+		binary.LittleEndian.PutUint64(diskKey, index)
+		return &record{kind: kind, spanChunk: &spanChunk{kind: chunkKind, diskKey: diskKey, chunk: value.Chunk}}, nil
+	case recordLastEpochWritten:
+		value := &lastEpochWrittenJSON{}
+		if err := dec.Decode(value); err != nil {
+			return nil, err
+		}
+		validatorIndex, err := strconv.ParseUint(value.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode validator_index")
+		}
+		epoch, err := strconv.ParseUint(value.Epoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode epoch")
+		}
+		return &record{kind: kind, lastEpochWritten: &slashertypes.AttestedEpochForValidator{
+			ValidatorIndex: primitives.ValidatorIndex(validatorIndex),
+			Epoch:          primitives.Epoch(epoch),
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unknown record kind %d", kind)
+	}
+}
+
+// sszEvidenceWriter writes every record as a kind and length prefixed SSZ payload.
+type sszEvidenceWriter struct {
+	w *bufio.Writer
+}
+
+func (s *sszEvidenceWriter) write(r *record) error {
+	payload, err := recordToSSZ(r)
+	if err != nil {
+		return err
+	}
+	prefix := make([]byte, 5)
+	prefix[0] = r.kind
+	binary.LittleEndian.PutUint32(prefix[1:], uint32(len(payload)))
+	if _, err := s.w.Write(prefix); err != nil {
+		return err
+	}
+	_, err = s.w.Write(payload)
+	return err
+}
+
+func (s *sszEvidenceWriter) close() error {
+	return s.w.Flush()
+}
+
+func recordToSSZ(r *record) ([]byte, error) {
+	switch r.kind {
+	case recordAttestation:
+		enc, err := r.attestation.IndexedAttestation.MarshalSSZ()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not marshal attestation")
+		}
+		return append(r.attestation.SigningRoot[:], enc...), nil
+	case recordProposal:
+		enc, err := r.proposal.SignedBeaconBlockHeader.MarshalSSZ()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not marshal block header")
+		}
+		return append(r.proposal.SigningRoot[:], enc...), nil
+	case recordAttesterSlashing:
+		enc, err := r.attesterSlashing.MarshalSSZ()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not marshal attester slashing")
+		}
+		return enc, nil
+	case recordProposerSlashing:
+		enc, err := r.proposerSlashing.MarshalSSZ()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not marshal proposer slashing")
+		}
+		return enc, nil
+	case recordSpanChunk:
+		payload := make([]byte, 9+2*len(r.spanChunk.chunk))
+		payload[0] = uint8(r.spanChunk.kind)
+		copy(payload[1:9], r.spanChunk.diskKey)
+		for i, value := range r.spanChunk.chunk {
+			binary.LittleEndian.PutUint16(payload[9+2*i:], value)
+		}
+		return payload, nil
+	case recordLastEpochWritten:
+		payload := make([]byte, 16)
+		binary.LittleEndian.PutUint64(payload[:8], uint64(r.lastEpochWritten.ValidatorIndex))
+		binary.LittleEndian.PutUint64(payload[8:], uint64(r.lastEpochWritten.Epoch))
+		return payload, nil
+	default:
+		return nil, fmt.Errorf("unknown record kind %d", r.kind)
+	}
+}
+
+func readEvidenceSSZ(r io.Reader, f func(*record) error) error {
+	br := bufio.NewReader(r)
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return errors.Wrap(err, "could not read SSZ evidence header")
+	}
+	if !bytes.Equal(header[:4], sszMagic[:]) {
+		return errors.New("input is not SSZ slasher evidence")
+	}
+	if version := binary.LittleEndian.Uint32(header[4:]); version != evidenceVersion {
+		return fmt.Errorf("unsupported evidence version %d, want %d", version, evidenceVersion)
+	}
+	prefix := make([]byte, 5)
+	for n := 0; ; n++ {
+		if _, err := io.ReadFull(br, prefix); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return errors.Wrapf(err, "could not read record %d", n)
+		}
+		length := binary.LittleEndian.Uint32(prefix[1:])
+		if length > maxRecordLength {
+			return fmt.Errorf("record %d has length %d, larger than the maximum of %d", n, length, maxRecordLength)
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(br, payload); err != nil {
+			return errors.Wrapf(err, "could not read record %d", n)
+		}
+		rec, err := decodeSSZRecord(prefix[0], payload)
+		if err != nil {
+			return errors.Wrapf(err, "could not decode record %d", n)
+		}
+		if err := f(rec); err != nil {
+			return err
+		}
+	}
+}
+
+func decodeSSZRecord(kind uint8, payload []byte) (*record, error) {
+	switch kind {
+	case recordAttestation:
+		if len(payload) < fieldparams.RootLength {
+			return nil, errors.New("attestation record is too short")
+		}
+		att := &ethpb.IndexedAttestation{}
+		if err := att.UnmarshalSSZ(payload[fieldparams.RootLength:]); err != nil {
+			return nil, err
+		}
+		return &record{kind: kind, attestation: &slashertypes.IndexedAttestationWrapper{
+			IndexedAttestation: att,
+			SigningRoot:        bytesutil.ToBytes32(payload[:fieldparams.RootLength]),
+		}}, nil
+	case recordProposal:
+		if len(payload) < fieldparams.RootLength {
+			return nil, errors.New("proposal record is too short")
+		}
+		header := &ethpb.SignedBeaconBlockHeader{}
+		if err := header.UnmarshalSSZ(payload[fieldparams.RootLength:]); err != nil {
+			return nil, err
+		}
+		return &record{kind: kind, proposal: &slashertypes.SignedBlockHeaderWrapper{
+			SignedBeaconBlockHeader: header,
+			SigningRoot:             bytesutil.ToBytes32(payload[:fieldparams.RootLength]),
+		}}, nil
+	case recordAttesterSlashing:
+		sl := &ethpb.AttesterSlashing{}
+		if err := sl.UnmarshalSSZ(payload); err != nil {
+			return nil, err
+		}
+		return &record{kind: kind, attesterSlashing: sl}, nil
+	case recordProposerSlashing:
+		sl := &ethpb.ProposerSlashing{}
+		if err := sl.UnmarshalSSZ(payload); err != nil {
+			return nil, err
+		}
+		return &record{kind: kind, proposerSlashing: sl}, nil
+	case recordSpanChunk:
+		if len(payload) <= 9 || (len(payload)-9)%2 != 0 {
+			return nil, fmt.Errorf("span chunk record has invalid length %d", len(payload))
+		}
+		chunkKind := slashertypes.ChunkKind(payload[0])
+		if chunkKind != slashertypes.MinSpan && chunkKind != slashertypes.MaxSpan {
+			return nil, fmt.Errorf("unknown span chunk kind %d", payload[0])
+		}
+		chunk := make([]uint16, (len(payload)-9)/2)
+		for i := range chunk {
+			chunk[i] = binary.LittleEndian.Uint16(payload[9+2*i:])
+		}
+		return &record{kind: kind, spanChunk: &spanChunk{
+			kind:    chunkKind,
+			diskKey: bytesutil.SafeCopyBytes(payload[1:9]),
+			chunk:   chunk,
+		}}, nil
+	case recordLastEpochWritten:
+		if len(payload) != 16 {
+			return nil, fmt.Errorf("last epoch written record has invalid length %d", len(payload))
+		}
+		return &record{kind: kind, lastEpochWritten: &slashertypes.AttestedEpochForValidator{
+			ValidatorIndex: primitives.ValidatorIndex(binary.LittleEndian.Uint64(payload[:8])),
+			Epoch:          primitives.Epoch(binary.LittleEndian.Uint64(payload[8:])),
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unknown record kind %d", kind)
+	}
+}
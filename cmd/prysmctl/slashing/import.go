@@ -0,0 +1,229 @@
+package slashing
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/slasherkv"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// Number of records saved in a single database transaction.
+const importBatchSize = 1024
+
+var importFlags = struct {
+	Path   string
+	Input  string
+	Format string
+}{}
+
+var importCmd = &cli.Command{
+	Name: "import",
+	Usage: "Import attestation records, block proposals, span chunks and detected slashings exported with " +
+		"`prysmctl slashing export` into a slasher database. The beacon node using the database must be stopped.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := importAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not import slasher evidence")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing slasher.db, which is created if it does not exist",
+			Destination: &importFlags.Path,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "input",
+			Usage:       "path of the file to read. The evidence is read from stdin when not set.",
+			Destination: &importFlags.Input,
+		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "format of the imported evidence, json or ssz",
+			Value:       formatJSON,
+			Destination: &importFlags.Format,
+		},
+	},
+}
+
+func importAction(cliCtx *cli.Context) error {
+	if err := validateFormat(importFlags.Format); err != nil {
+		return err
+	}
+	var r io.Reader = os.Stdin
+	if importFlags.Input != "" {
+		f, err := os.Open(importFlags.Input)
+		if err != nil {
+			return errors.Wrap(err, "could not open input file")
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				log.WithError(err).Error("Could not close input file")
+			}
+		}()
+		r = f
+	}
+	db, err := slasherkv.NewKVStore(cliCtx.Context, importFlags.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open slasher database at %s", importFlags.Path)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close slasher database")
+		}
+	}()
+	counts, err := importEvidence(cliCtx.Context, db, r, importFlags.Format)
+	if err != nil {
+		return err
+	}
+	log.WithFields(counts.fields()).Info("Imported slasher evidence")
+	return nil
+}
+
+// importEvidence reads evidence in the given format, and saves it to the slasher database in
+// batches as it is read, so that the evidence is never held in memory at once. The records
+// read before a malformed one are saved, so a failed import should be run again.
+func importEvidence(ctx context.Context, db *slasherkv.Store, r io.Reader, format string) (*evidenceCounts, error) {
+	im := &evidenceImporter{
+		db:                db,
+		counts:            &evidenceCounts{},
+		chunkKeys:         make(map[slashertypes.ChunkKind][][]byte),
+		chunks:            make(map[slashertypes.ChunkKind][][]uint16),
+		lastEpochsWritten: make(map[primitives.ValidatorIndex]primitives.Epoch),
+	}
+	if err := readEvidence(r, format, func(rec *record) error {
+		return im.add(ctx, rec)
+	}); err != nil {
+		return nil, err
+	}
+	if err := im.flush(ctx); err != nil {
+		return nil, err
+	}
+	return im.counts, nil
+}
+
+// evidenceImporter buffers the imported records, and saves them to the slasher database
+// once a batch of records of the same kind is read.
+type evidenceImporter struct {
+	db                *slasherkv.Store
+	counts            *evidenceCounts
+	attestations      []*slashertypes.IndexedAttestationWrapper
+	proposals         []*slashertypes.SignedBlockHeaderWrapper
+	attesterSlashings []*ethpb.AttesterSlashing
+	proposerSlashings []*ethpb.ProposerSlashing
+	chunkKeys         map[slashertypes.ChunkKind][][]byte
+	chunks            map[slashertypes.ChunkKind][][]uint16
+	lastEpochsWritten map[primitives.ValidatorIndex]primitives.Epoch
+}
+
+func (im *evidenceImporter) add(ctx context.Context, rec *record) error {
+	im.counts.add(rec.kind)
+	switch rec.kind {
+	case recordAttestation:
+		im.attestations = append(im.attestations, rec.attestation)
+	case recordProposal:
+		im.proposals = append(im.proposals, rec.proposal)
+	case recordAttesterSlashing:
+		im.attesterSlashings = append(im.attesterSlashings, rec.attesterSlashing)
+	case recordProposerSlashing:
+		im.proposerSlashings = append(im.proposerSlashings, rec.proposerSlashing)
+	case recordSpanChunk:
+		kind := rec.spanChunk.kind
+		im.chunkKeys[kind] = append(im.chunkKeys[kind], rec.spanChunk.diskKey)
+		im.chunks[kind] = append(im.chunks[kind], rec.spanChunk.chunk)
+	case recordLastEpochWritten:
+		im.lastEpochsWritten[rec.lastEpochWritten.ValidatorIndex] = rec.lastEpochWritten.Epoch
+	}
+	full := len(im.attestations) >= importBatchSize ||
+		len(im.proposals) >= importBatchSize ||
+		len(im.attesterSlashings)+len(im.proposerSlashings) >= importBatchSize ||
+		len(im.chunkKeys[slashertypes.MinSpan])+len(im.chunkKeys[slashertypes.MaxSpan]) >= importBatchSize ||
+		len(im.lastEpochsWritten) >= importBatchSize
+	if !full {
+		return nil
+	}
+	return im.flush(ctx)
+}
+
+// flush saves all the buffered records to the database.
+func (im *evidenceImporter) flush(ctx context.Context) error {
+	if len(im.attestations) > 0 {
+		if err := im.db.SaveAttestationRecordsForValidators(ctx, im.attestations); err != nil {
+			return errors.Wrap(err, "could not save attestation records")
+		}
+		im.attestations = nil
+	}
+	if len(im.proposals) > 0 {
+		if err := im.db.SaveBlockProposals(ctx, im.proposals); err != nil {
+			return errors.Wrap(err, "could not save block proposals")
+		}
+		im.proposals = nil
+	}
+	if len(im.attesterSlashings) > 0 || len(im.proposerSlashings) > 0 {
+		if err := im.db.SaveSlashings(ctx, im.attesterSlashings, im.proposerSlashings); err != nil {
+			return errors.Wrap(err, "could not save slashings")
+		}
+		im.attesterSlashings = nil
+		im.proposerSlashings = nil
+	}
+	for kind, keys := range im.chunkKeys {
+		chunks, err := im.mergeWithSavedChunks(ctx, kind, keys, im.chunks[kind])
+		if err != nil {
+			return err
+		}
+		if err := im.db.SaveSlasherChunks(ctx, kind, keys, chunks); err != nil {
+			return errors.Wrap(err, "could not save span chunks")
+		}
+		delete(im.chunkKeys, kind)
+		delete(im.chunks, kind)
+	}
+	if len(im.lastEpochsWritten) > 0 {
+		if err := im.db.SaveLastEpochsWrittenForValidators(ctx, im.lastEpochsWritten); err != nil {
+			return errors.Wrap(err, "could not save last epochs written")
+		}
+		im.lastEpochsWritten = make(map[primitives.ValidatorIndex]primitives.Epoch)
+	}
+	return nil
+}
+
+// mergeWithSavedChunks merges the imported span chunks with the chunks already saved under the same
+// disk keys, keeping the lowest min spans and the highest max spans, so that the surround votes the
+// local slasher can detect are kept.
+func (im *evidenceImporter) mergeWithSavedChunks(
+	ctx context.Context, kind slashertypes.ChunkKind, keys [][]byte, chunks [][]uint16,
+) ([][]uint16, error) {
+	saved, exists, err := im.db.LoadSlasherChunks(ctx, kind, keys)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load saved span chunks")
+	}
+	merged := make([][]uint16, len(chunks))
+	for i, chunk := range chunks {
+		if !exists[i] {
+			merged[i] = chunk
+			continue
+		}
+		if len(saved[i]) != len(chunk) {
+			return nil, errors.Errorf("imported span chunk has %d values, the saved one has %d", len(chunk), len(saved[i]))
+		}
+		merged[i] = make([]uint16, len(chunk))
+		for j, value := range chunk {
+			keepSaved := value > saved[i][j]
+			if kind == slashertypes.MaxSpan {
+				keepSaved = value < saved[i][j]
+			}
+			if keepSaved {
+				value = saved[i][j]
+			}
+			merged[i][j] = value
+		}
+	}
+	return merged, nil
+}
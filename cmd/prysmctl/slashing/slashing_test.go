@@ -0,0 +1,235 @@
+package slashing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/slasherkv"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	for _, format := range []string{formatJSON, formatSSZ} {
+		t.Run(format, func(t *testing.T) {
+			ctx := context.Background()
+			src := setupDB(t)
+			want := testEvidence(t)
+			want.save(t, src)
+
+			buf := &bytes.Buffer{}
+			exported, err := exportEvidence(ctx, src, buf, format)
+			require.NoError(t, err)
+			require.Equal(t, 2, exported.attestations)
+			require.Equal(t, 2, exported.proposals)
+			require.Equal(t, 3, exported.spanChunks)
+			require.Equal(t, 2, exported.lastEpochsWritten)
+
+			dst := setupDB(t)
+			imported, err := importEvidence(ctx, dst, buf, format)
+			require.NoError(t, err)
+			require.DeepEqual(t, exported, imported)
+
+			got := readTestEvidence(t, dst)
+			require.Equal(t, len(want.attestations), len(got.attestations))
+			// Attestation records are stored by signing root.
+			wantAtts := make(map[[32]byte]*ethpb.IndexedAttestation)
+			for _, att := range want.attestations {
+				wantAtts[att.SigningRoot] = att.IndexedAttestation
+			}
+			for _, att := range got.attestations {
+				require.DeepSSZEqual(t, wantAtts[att.SigningRoot], att.IndexedAttestation)
+			}
+			require.Equal(t, len(want.proposals), len(got.proposals))
+			for i, proposal := range got.proposals {
+				require.Equal(t, want.proposals[i].SigningRoot, proposal.SigningRoot)
+				require.DeepSSZEqual(t, want.proposals[i].SignedBeaconBlockHeader, proposal.SignedBeaconBlockHeader)
+			}
+			require.Equal(t, 1, len(got.attesterSlashings))
+			require.DeepSSZEqual(t, want.attesterSlashings[0], got.attesterSlashings[0])
+			require.Equal(t, 1, len(got.proposerSlashings))
+			require.DeepSSZEqual(t, want.proposerSlashings[0], got.proposerSlashings[0])
+			require.DeepEqual(t, want.spanChunks, got.spanChunks)
+			require.DeepEqual(t, want.lastEpochsWritten, got.lastEpochsWritten)
+
+			// The imported attestations are checked for double votes like any other.
+			doubleVote := attestationWrapper(t, 1, 2, []uint64{1}, 3)
+			doubleVotes, err := dst.CheckAttesterDoubleVotes(ctx, []*slashertypes.IndexedAttestationWrapper{doubleVote})
+			require.NoError(t, err)
+			require.Equal(t, 1, len(doubleVotes))
+		})
+	}
+}
+
+func TestImport_MergesSpanChunks(t *testing.T) {
+	ctx := context.Background()
+	src := setupDB(t)
+	testEvidence(t).save(t, src)
+	buf := &bytes.Buffer{}
+	_, err := exportEvidence(ctx, src, buf, formatJSON)
+	require.NoError(t, err)
+
+	dst := setupDB(t)
+	local := []*spanChunk{
+		{kind: slashertypes.MinSpan, diskKey: ssz.MarshalUint64(nil, 1), chunk: []uint16{4, 65535, 0}},
+		{kind: slashertypes.MaxSpan, diskKey: ssz.MarshalUint64(nil, 1), chunk: []uint16{3, 0, 1}},
+	}
+	for _, c := range local {
+		require.NoError(t, dst.SaveSlasherChunks(ctx, c.kind, [][]byte{c.diskKey}, [][]uint16{c.chunk}))
+	}
+	_, err = importEvidence(ctx, dst, buf, formatJSON)
+	require.NoError(t, err)
+
+	// The lowest min spans and the highest max spans of the local and imported chunks are kept.
+	got := readTestEvidence(t, dst)
+	require.DeepEqual(t, []*spanChunk{
+		{kind: slashertypes.MinSpan, diskKey: ssz.MarshalUint64(nil, 1), chunk: []uint16{4, 2, 0}},
+		{kind: slashertypes.MinSpan, diskKey: ssz.MarshalUint64(nil, 300), chunk: []uint16{3, 65535, 65535}},
+		{kind: slashertypes.MaxSpan, diskKey: ssz.MarshalUint64(nil, 1), chunk: []uint16{3, 1, 2}},
+	}, got.spanChunks)
+}
+
+func TestImport_MalformedInput(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	_, err := importEvidence(ctx, db, bytes.NewBufferString(`{"version":2}`), formatJSON)
+	require.ErrorContains(t, "unsupported evidence version 2", err)
+	_, err = importEvidence(ctx, db, bytes.NewBufferString(`{"proposals":[],"version":1}`), formatJSON)
+	require.ErrorContains(t, "version of JSON evidence must come first", err)
+	_, err = importEvidence(ctx, db, bytes.NewBufferString(`{"version":1,"blocks":[]}`), formatJSON)
+	require.ErrorContains(t, `unknown field "blocks"`, err)
+	_, err = importEvidence(ctx, db, bytes.NewBufferString(`{"version":1}`), formatSSZ)
+	require.ErrorContains(t, "not SSZ slasher evidence", err)
+
+	// A truncated SSZ record fails the whole import.
+	src := setupDB(t)
+	testEvidence(t).save(t, src)
+	buf := &bytes.Buffer{}
+	_, err = exportEvidence(ctx, src, buf, formatSSZ)
+	require.NoError(t, err)
+	_, err = importEvidence(ctx, db, bytes.NewReader(buf.Bytes()[:buf.Len()-1]), formatSSZ)
+	require.ErrorContains(t, "could not read record", err)
+	require.Equal(t, 0, len(readTestEvidence(t, db).attestations))
+}
+
+func setupDB(t *testing.T) *slasherkv.Store {
+	db, err := slasherkv.NewKVStore(context.Background(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	return db
+}
+
+// testRecords is the content of a slasher database, as saved and read by the tests.
+type testRecords struct {
+	attestations      []*slashertypes.IndexedAttestationWrapper
+	proposals         []*slashertypes.SignedBlockHeaderWrapper
+	attesterSlashings []*ethpb.AttesterSlashing
+	proposerSlashings []*ethpb.ProposerSlashing
+	spanChunks        []*spanChunk
+	lastEpochsWritten map[primitives.ValidatorIndex]primitives.Epoch
+}
+
+func testEvidence(t *testing.T) *testRecords {
+	att1 := attestationWrapper(t, 1, 2, []uint64{1, 2}, 1)
+	att2 := attestationWrapper(t, 0, 3, []uint64{1}, 2)
+	header1 := proposalWrapper(t, 4, 1, 1)
+	header2 := proposalWrapper(t, 4, 1, 2)
+	return &testRecords{
+		attestations: []*slashertypes.IndexedAttestationWrapper{att1, att2},
+		proposals:    []*slashertypes.SignedBlockHeaderWrapper{header1, proposalWrapper(t, 5, 2, 3)},
+		attesterSlashings: []*ethpb.AttesterSlashing{{
+			Attestation_1: att1.IndexedAttestation,
+			Attestation_2: att2.IndexedAttestation,
+		}},
+		proposerSlashings: []*ethpb.ProposerSlashing{{
+			Header_1: header1.SignedBeaconBlockHeader,
+			Header_2: header2.SignedBeaconBlockHeader,
+		}},
+		spanChunks: []*spanChunk{
+			{kind: slashertypes.MinSpan, diskKey: ssz.MarshalUint64(nil, 1), chunk: []uint16{65535, 2, 1}},
+			{kind: slashertypes.MinSpan, diskKey: ssz.MarshalUint64(nil, 300), chunk: []uint16{3, 65535, 65535}},
+			{kind: slashertypes.MaxSpan, diskKey: ssz.MarshalUint64(nil, 1), chunk: []uint16{0, 1, 2}},
+		},
+		lastEpochsWritten: map[primitives.ValidatorIndex]primitives.Epoch{1: 3, 2: 2},
+	}
+}
+
+func (r *testRecords) save(t *testing.T, db *slasherkv.Store) {
+	ctx := context.Background()
+	require.NoError(t, db.SaveAttestationRecordsForValidators(ctx, r.attestations))
+	require.NoError(t, db.SaveBlockProposals(ctx, r.proposals))
+	require.NoError(t, db.SaveSlashings(ctx, r.attesterSlashings, r.proposerSlashings))
+	for _, c := range r.spanChunks {
+		require.NoError(t, db.SaveSlasherChunks(ctx, c.kind, [][]byte{c.diskKey}, [][]uint16{c.chunk}))
+	}
+	require.NoError(t, db.SaveLastEpochsWrittenForValidators(ctx, r.lastEpochsWritten))
+}
+
+func readTestEvidence(t *testing.T, db *slasherkv.Store) *testRecords {
+	ctx := context.Background()
+	r := &testRecords{lastEpochsWritten: make(map[primitives.ValidatorIndex]primitives.Epoch)}
+	require.NoError(t, db.ForEachAttestationRecord(ctx, func(att *slashertypes.IndexedAttestationWrapper) error {
+		r.attestations = append(r.attestations, att)
+		return nil
+	}))
+	require.NoError(t, db.ForEachBlockProposal(ctx, func(proposal *slashertypes.SignedBlockHeaderWrapper) error {
+		r.proposals = append(r.proposals, proposal)
+		return nil
+	}))
+	require.NoError(t, db.ForEachAttesterSlashing(ctx, func(sl *ethpb.AttesterSlashing) error {
+		r.attesterSlashings = append(r.attesterSlashings, sl)
+		return nil
+	}))
+	require.NoError(t, db.ForEachProposerSlashing(ctx, func(sl *ethpb.ProposerSlashing) error {
+		r.proposerSlashings = append(r.proposerSlashings, sl)
+		return nil
+	}))
+	require.NoError(t, db.ForEachSlasherChunk(ctx, func(kind slashertypes.ChunkKind, diskKey []byte, chunk []uint16) error {
+		r.spanChunks = append(r.spanChunks, &spanChunk{kind: kind, diskKey: diskKey, chunk: chunk})
+		return nil
+	}))
+	require.NoError(t, db.ForEachLastEpochWritten(ctx, func(item *slashertypes.AttestedEpochForValidator) error {
+		r.lastEpochsWritten[item.ValidatorIndex] = item.Epoch
+		return nil
+	}))
+	return r
+}
+
+func attestationWrapper(
+	t *testing.T, source, target primitives.Epoch, indices []uint64, blockRoot byte,
+) *slashertypes.IndexedAttestationWrapper {
+	att := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{
+		AttestingIndices: indices,
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: bytes.Repeat([]byte{blockRoot}, 32),
+			Source:          &ethpb.Checkpoint{Epoch: source},
+			Target:          &ethpb.Checkpoint{Epoch: target},
+		},
+	})
+	root, err := att.Data.HashTreeRoot()
+	require.NoError(t, err)
+	return &slashertypes.IndexedAttestationWrapper{IndexedAttestation: att, SigningRoot: root}
+}
+
+func proposalWrapper(
+	t *testing.T, slot primitives.Slot, proposerIndex primitives.ValidatorIndex, bodyRoot byte,
+) *slashertypes.SignedBlockHeaderWrapper {
+	header := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: proposerIndex,
+			BodyRoot:      bytes.Repeat([]byte{bodyRoot}, 32),
+		},
+	})
+	root, err := header.Header.HashTreeRoot()
+	require.NoError(t, err)
+	return &slashertypes.SignedBlockHeaderWrapper{SignedBeaconBlockHeader: header, SigningRoot: root}
+}
@@ -0,0 +1,251 @@
+package supportbundle
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	bolt "go.etcd.io/bbolt"
+)
+
+var bundleFlags = struct {
+	BeaconNodeHost string
+	MetricsHost    string
+	Timeout        time.Duration
+	LogFile        string
+	LogLines       int
+	Path           string
+	Output         string
+}{}
+
+// collector gathers the content of one file of the bundle.
+type collector struct {
+	name    string
+	collect func(ctx context.Context) ([]byte, error)
+}
+
+// bundleFile is a file of the bundle, after redaction.
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+func cliActionBundle(_ *cli.Context) error {
+	ctx := context.Background()
+	f := bundleFlags
+
+	output := f.Output
+	if output == "" {
+		output = fmt.Sprintf("prysm-support-bundle-%s.tar.gz", time.Now().UTC().Format("20060102T150405Z"))
+	}
+	opts := []client.ClientOpt{client.WithTimeout(f.Timeout)}
+	bc, err := beacon.NewClient(f.BeaconNodeHost, opts...)
+	if err != nil {
+		return err
+	}
+	mc, err := client.NewClient(f.MetricsHost, opts...)
+	if err != nil {
+		return err
+	}
+	collectors := []collector{
+		apiCollector(bc, "node/version.json", "/eth/v1/node/version"),
+		apiCollector(bc, "node/syncing.json", "/eth/v1/node/syncing"),
+		apiCollector(bc, "node/identity.json", "/eth/v1/node/identity"),
+		apiCollector(bc, "node/peers.json", "/eth/v1/node/peers"),
+		apiCollector(bc, "config/spec.json", "/eth/v1/config/spec"),
+		apiCollector(bc, "config/fork_schedule.json", "/eth/v1/config/fork_schedule"),
+		apiCollector(bc, "forkchoice.json", "/eth/v1/debug/fork_choice"),
+		{
+			name: "metrics.txt",
+			collect: func(ctx context.Context) ([]byte, error) {
+				return mc.Get(ctx, "/metrics")
+			},
+		},
+	}
+	if f.LogFile != "" {
+		collectors = append(collectors, collector{
+			name: "beacon.log",
+			collect: func(context.Context) ([]byte, error) {
+				return tailLines(f.LogFile, f.LogLines)
+			},
+		})
+	}
+	if f.Path != "" {
+		collectors = append(collectors, collector{
+			name: "db_stats.txt",
+			collect: func(context.Context) ([]byte, error) {
+				return dbStats(filepath.Join(f.Path, kv.DatabaseFileName))
+			},
+		})
+	}
+
+	files := collectBundle(ctx, collectors)
+	out, err := os.OpenFile(filepath.Clean(output), os.O_CREATE|os.O_EXCL|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions)
+	if err != nil {
+		return errors.Wrap(err, "could not create support bundle")
+	}
+	if err := writeBundle(out, files); err != nil {
+		if closeErr := out.Close(); closeErr != nil {
+			log.WithError(closeErr).Error("Could not close support bundle")
+		}
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	log.WithField("files", len(files)).Printf("saved support bundle to %s", output)
+	return nil
+}
+
+func apiCollector(c *beacon.Client, name, path string) collector {
+	return collector{
+		name: name,
+		collect: func(ctx context.Context) ([]byte, error) {
+			raw, err := c.Get(ctx, path)
+			if err != nil {
+				return nil, err
+			}
+			var out bytes.Buffer
+			if err := json.Indent(&out, raw, "", "  "); err != nil {
+				return nil, errors.Wrapf(err, "could not format response of %s", path)
+			}
+			out.WriteByte('\n')
+			return out.Bytes(), nil
+		},
+	}
+}
+
+// collectBundle runs every collector and redacts the collected files. Collectors failing do not prevent the
+// others from running, so a bundle can be created from a partially working node: the failures are listed in the
+// manifest of the bundle instead.
+func collectBundle(ctx context.Context, collectors []collector) []bundleFile {
+	files := make([]bundleFile, 0, len(collectors)+1)
+	var manifest strings.Builder
+	fmt.Fprintf(&manifest, "created: %s\n", time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(&manifest, "prysmctl: %s\n", version.Version())
+	for _, c := range collectors {
+		data, err := c.collect(ctx)
+		if err != nil {
+			log.WithError(err).WithField("file", c.name).Warn("Could not collect support bundle file")
+			fmt.Fprintf(&manifest, "failed: %s: %v\n", c.name, err)
+			continue
+		}
+		fmt.Fprintf(&manifest, "collected: %s\n", c.name)
+		files = append(files, bundleFile{name: c.name, data: redact(data)})
+	}
+	return append(files, bundleFile{name: "manifest.txt", data: redact([]byte(manifest.String()))})
+}
+
+// writeBundle writes the files as a gzipped tar archive.
+func writeBundle(w io.Writer, files []bundleFile) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	for _, f := range files {
+		hdr := &tar.Header{
+			Name:    f.name,
+			Mode:    int64(params.BeaconIoConfig().ReadWritePermissions),
+			Size:    int64(len(f.data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return errors.Wrapf(err, "could not write header of %s", f.name)
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return errors.Wrapf(err, "could not write %s", f.name)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// tailLines returns the last n lines of the file.
+func tailLines(path string, n int) ([]byte, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close log file")
+		}
+	}()
+	if n <= 0 {
+		return nil, nil
+	}
+	lines := make([]string, 0, n)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(lines) == n {
+			lines = lines[1:]
+		}
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "could not read log file")
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+// dbStats returns the size of the database and the number of keys of every bucket.
+func dbStats(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{
+		ReadOnly: true,
+		Timeout:  1 * time.Second,
+	})
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return nil, errors.New("cannot obtain database lock, stop the beacon node to collect database statistics")
+		}
+		return nil, err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "size: %d\n", info.Size())
+	if err := db.View(func(tx *bolt.Tx) error {
+		for _, name := range kv.Buckets {
+			b := tx.Bucket(name)
+			if b == nil {
+				fmt.Fprintf(&out, "%s: missing\n", name)
+				continue
+			}
+			fmt.Fprintf(&out, "%s: %d keys\n", name, b.Stats().KeyN)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return []byte(out.String()), nil
+}
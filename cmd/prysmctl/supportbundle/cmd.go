@@ -0,0 +1,62 @@
+package supportbundle
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var Commands = []*cli.Command{
+	{
+		Name:  "support-bundle",
+		Usage: "Collect logs, metrics, fork choice, peers, config and database statistics of a beacon node into a single redacted archive to attach to issue reports.",
+		Action: func(cliCtx *cli.Context) error {
+			if err := cliActionBundle(cliCtx); err != nil {
+				log.WithError(err).Fatal("Could not create support bundle")
+			}
+			return nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "beacon-node-host",
+				Usage:       "host:port for beacon node connection",
+				Destination: &bundleFlags.BeaconNodeHost,
+				Value:       "localhost:3500",
+			},
+			&cli.StringFlag{
+				Name:        "metrics-host",
+				Usage:       "host:port of the prometheus metrics server of the beacon node",
+				Destination: &bundleFlags.MetricsHost,
+				Value:       "localhost:8080",
+			},
+			&cli.DurationFlag{
+				Name:        "http-timeout",
+				Usage:       "timeout for http requests made to the beacon node (uses duration format, ex: 2m31s). default: 1m",
+				Destination: &bundleFlags.Timeout,
+				Value:       time.Minute,
+			},
+			&cli.StringFlag{
+				Name:        "log-file",
+				Usage:       "path of the log file of the beacon node, as set with --log-file. Logs are not collected when not set.",
+				Destination: &bundleFlags.LogFile,
+			},
+			&cli.IntFlag{
+				Name:        "log-lines",
+				Usage:       "number of most recent log lines to collect",
+				Destination: &bundleFlags.LogLines,
+				Value:       10000,
+			},
+			&cli.StringFlag{
+				Name:        "path",
+				Usage:       "path to directory containing beaconchain.db. Database statistics are not collected when not set. The beacon node must be stopped to read them.",
+				Destination: &bundleFlags.Path,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Usage:       "path of the archive to write. Defaults to prysm-support-bundle-<time>.tar.gz in the working directory.",
+				Destination: &bundleFlags.Output,
+			},
+		},
+	},
+}
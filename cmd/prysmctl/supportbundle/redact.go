@@ -0,0 +1,25 @@
+package supportbundle
+
+import "regexp"
+
+// The patterns of the data identifying the operator of the node, which are removed from the bundle: IP
+// addresses and host names, including the ones of multiaddrs, ENRs, which embed them, libp2p peer IDs
+// and the credentials of URLs.
+var (
+	ipv4Pattern     = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	ipv6Pattern     = regexp.MustCompile(`/ip6/[0-9a-fA-F:.]+`)
+	dnsPattern      = regexp.MustCompile(`/(dns|dns4|dns6|dnsaddr)/[^/\s"']+`)
+	enrPattern      = regexp.MustCompile(`enr:[A-Za-z0-9_\-]+`)
+	peerIDPattern   = regexp.MustCompile(`\b(?:16Uiu2HA|12D3KooW|Qm)[1-9A-HJ-NP-Za-km-z]+`)
+	userinfoPattern = regexp.MustCompile(`([A-Za-z][A-Za-z0-9+.\-]*://)[^/\s"'@]+@`)
+)
+
+// redact removes the IP addresses, host names of multiaddrs, ENRs, peer IDs and URL credentials from the data.
+func redact(data []byte) []byte {
+	data = enrPattern.ReplaceAll(data, []byte("enr:<redacted>"))
+	data = userinfoPattern.ReplaceAll(data, []byte("${1}<redacted>@"))
+	data = peerIDPattern.ReplaceAll(data, []byte("<redacted>"))
+	data = dnsPattern.ReplaceAll(data, []byte("/$1/<redacted>"))
+	data = ipv6Pattern.ReplaceAll(data, []byte("/ip6/<redacted>"))
+	return ipv4Pattern.ReplaceAll(data, []byte("<redacted>"))
+}
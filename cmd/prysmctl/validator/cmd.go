@@ -65,6 +65,11 @@ var (
 		Usage:   "default fee recipient used for proposer-settings, only used with --output-proposer-settings-path",
 	}
 
+	ValidatorIdsFlag = &cli.StringSliceFlag{
+		Name:  "validators",
+		Usage: "indices or 0x prefixed public keys of the validators to display the status of",
+	}
+
 	TokenFlag = &cli.StringFlag{
 		Name:    "token",
 		Aliases: []string{"t"},
@@ -123,6 +128,24 @@ var Commands = []*cli.Command{
 					return nil
 				},
 			},
+			{
+				Name:  "status",
+				Usage: "Display the exit queue position, the (estimated) exit and withdrawable epochs and the withdrawal credentials type of validators.",
+				Flags: []cli.Flag{
+					BeaconHostFlag,
+					ValidatorIdsFlag,
+					cmd.ConfigFileFlag,
+				},
+				Before: func(cliCtx *cli.Context) error {
+					return cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+				},
+				Action: func(cliCtx *cli.Context) error {
+					if err := printValidatorStatus(cliCtx, os.Stdout); err != nil {
+						log.WithError(err).Fatal("Could not get validator status")
+					}
+					return nil
+				},
+			},
 			{
 				Name:    "proposer-settings",
 				Aliases: []string{"w"},
@@ -156,6 +179,10 @@ var Commands = []*cli.Command{
 					flags.BeaconRPCProviderFlag,
 					flags.Web3SignerURLFlag,
 					flags.Web3SignerPublicValidatorKeysFlag,
+					flags.Web3SignerTimeoutFlag,
+					flags.Web3SignerMaxRetriesFlag,
+					flags.Web3SignerBackupURLFlag,
+					flags.Web3SignerFailoverThresholdFlag,
 					flags.InteropNumValidators,
 					flags.InteropStartIndex,
 					cmd.GrpcMaxCallRecvMsgSizeFlag,
@@ -166,6 +193,10 @@ var Commands = []*cli.Command{
 					flags.ExitAllFlag,
 					flags.ForceExitFlag,
 					flags.VoluntaryExitJSONOutputPath,
+					flags.OfflineExitFlag,
+					flags.ExitEpochFlag,
+					flags.GenesisValidatorsRootFlag,
+					flags.ValidatorIndicesPathFlag,
 					features.Mainnet,
 					features.PraterTestnet,
 					features.SepoliaTestnet,
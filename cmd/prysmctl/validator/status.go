@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/urfave/cli/v2"
+	"go.opencensus.io/trace"
+)
+
+// printValidatorStatus prints the exit queue position, the exit and withdrawable epochs and the withdrawal
+// credentials type of the validators given by index or public key, as computed by the beacon node at its head.
+func printValidatorStatus(c *cli.Context, w io.Writer) error {
+	ctx, span := trace.StartSpan(c.Context, "validator.printValidatorStatus")
+	defer span.End()
+	ids := c.StringSlice(ValidatorIdsFlag.Name)
+	if len(ids) == 0 {
+		return fmt.Errorf("no --%s flag value was provided", ValidatorIdsFlag.Name)
+	}
+	client, err := beacon.NewClient(c.String(BeaconHostFlag.Name))
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetValidatorExitStatus(ctx, "head", ids)
+	if err != nil {
+		return errors.Wrap(err, "could not get validator exit status")
+	}
+	if len(resp.Data) == 0 {
+		return errors.New("none of the validators were found by the beacon node")
+	}
+
+	farFutureEpoch := fmt.Sprintf("%d", params.BeaconConfig().FarFutureEpoch)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "INDEX\tPUBLIC KEY\tSTATUS\tELIGIBLE\tACTIVATION\tEXIT QUEUE\tEXIT EPOCH\tWITHDRAWABLE\tCREDENTIALS"); err != nil {
+		return err
+	}
+	epoch := func(e string) string {
+		if e == farFutureEpoch {
+			return "-"
+		}
+		return e
+	}
+	for _, v := range resp.Data {
+		exitEpoch, withdrawableEpoch := epoch(v.ExitEpoch), epoch(v.WithdrawableEpoch)
+		// Epochs not set in the state are estimated for a voluntary exit submitted now.
+		if !v.ExitInitiated && exitEpoch != "-" {
+			exitEpoch = "~" + exitEpoch
+			withdrawableEpoch = "~" + withdrawableEpoch
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", v.Index, v.Pubkey, v.Status,
+			epoch(v.ActivationEligibilityEpoch), epoch(v.ActivationEpoch), v.ExitQueuePosition, exitEpoch,
+			withdrawableEpoch, v.WithdrawalCredentialsType); err != nil {
+			return err
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, "Epochs prefixed with ~ are estimated for a voluntary exit submitted now.")
+	return err
+}
@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/api"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestPrintValidatorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/prysm/states/head/validator_exit_status", r.URL.Path)
+		req := &api.ValidatorExitStatusRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		assert.DeepEqual(t, []string{"1", "2"}, req.Ids)
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(&api.ValidatorExitStatusResponse{
+			Data: []*api.ValidatorExitStatus{
+				{
+					Index:                      "1",
+					Pubkey:                     "0xaa",
+					Status:                     "active_ongoing",
+					ActivationEligibilityEpoch: "0",
+					ActivationEpoch:            "0",
+					ExitQueuePosition:          "3",
+					ExitEpoch:                  "306",
+					WithdrawableEpoch:          "562",
+					WithdrawalCredentialsType:  "execution",
+				},
+				{
+					Index:                      "2",
+					Pubkey:                     "0xbb",
+					Status:                     "active_exiting",
+					ActivationEligibilityEpoch: "0",
+					ActivationEpoch:            "0",
+					ExitInitiated:              true,
+					ExitQueuePosition:          "1",
+					ExitEpoch:                  "305",
+					WithdrawableEpoch:          "561",
+					WithdrawalCredentialsType:  "bls",
+				},
+			},
+		}))
+	}))
+	defer srv.Close()
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(BeaconHostFlag.Name, srv.URL, "")
+	ids := cli.NewStringSlice("1", "2")
+	set.Var(ids, ValidatorIdsFlag.Name, "")
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, printValidatorStatus(cliCtx, out))
+	assert.StringContains(t, "1      0xaa        active_ongoing  0         0           3           ~306", out.String())
+	assert.StringContains(t, "~562          execution", out.String())
+	assert.StringContains(t, "2      0xbb        active_exiting  0         0           1           305", out.String())
+}
+
+func TestPrintValidatorStatus_NoValidators(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	cliCtx := cli.NewContext(&app, set, nil)
+	require.ErrorContains(t, "no --validators flag value was provided", printValidatorStatus(cliCtx, &bytes.Buffer{}))
+}
@@ -161,6 +161,10 @@ var Commands = &cli.Command{
 				flags.BeaconRPCProviderFlag,
 				flags.Web3SignerURLFlag,
 				flags.Web3SignerPublicValidatorKeysFlag,
+				flags.Web3SignerTimeoutFlag,
+				flags.Web3SignerMaxRetriesFlag,
+				flags.Web3SignerBackupURLFlag,
+				flags.Web3SignerFailoverThresholdFlag,
 				flags.InteropNumValidators,
 				flags.InteropStartIndex,
 				cmd.GrpcMaxCallRecvMsgSizeFlag,
@@ -171,6 +175,10 @@ var Commands = &cli.Command{
 				flags.ExitAllFlag,
 				flags.ForceExitFlag,
 				flags.VoluntaryExitJSONOutputPath,
+				flags.OfflineExitFlag,
+				flags.ExitEpochFlag,
+				flags.GenesisValidatorsRootFlag,
+				flags.ValidatorIndicesPathFlag,
 				features.Mainnet,
 				features.PraterTestnet,
 				features.SepoliaTestnet,
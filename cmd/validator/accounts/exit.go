@@ -4,11 +4,14 @@ import (
 	"io"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	grpcutil "github.com/prysmaticlabs/prysm/v4/api/grpc"
 	"github.com/prysmaticlabs/prysm/v4/cmd"
 	"github.com/prysmaticlabs/prysm/v4/cmd/validator/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/validator/accounts"
 	"github.com/prysmaticlabs/prysm/v4/validator/accounts/wallet"
@@ -40,6 +43,13 @@ func AccountsExit(c *cli.Context, r io.Reader) error {
 			flags.Web3SignerPublicValidatorKeysFlag,
 		)
 	}
+	var offlineExit *accounts.OfflineExitCfg
+	if c.Bool(flags.OfflineExitFlag.Name) {
+		offlineExit, err = offlineExitConfig(c)
+		if err != nil {
+			return err
+		}
+	}
 	if c.IsSet(flags.InteropNumValidators.Name) {
 		km, err = local.NewInteropKeymanager(c.Context, c.Uint64(flags.InteropStartIndex.Name), c.Uint64(flags.InteropNumValidators.Name))
 		if err != nil {
@@ -47,24 +57,30 @@ func AccountsExit(c *cli.Context, r io.Reader) error {
 		}
 		w = &wallet.Wallet{}
 	} else if c.IsSet(flags.Web3SignerURLFlag.Name) {
-		ctx := grpcutil.AppendHeaders(c.Context, grpcHeaders)
-		conn, err := grpc.DialContext(ctx, beaconRPCProvider, dialOpts...)
-		if err != nil {
-			return errors.Wrapf(err, "could not dial endpoint %s", beaconRPCProvider)
-		}
-		nodeClient := ethpb.NewNodeClient(conn)
-		resp, err := nodeClient.GetGenesis(c.Context, &empty.Empty{})
-		if err != nil {
-			return errors.Wrapf(err, "failed to get genesis info")
-		}
-		if err := conn.Close(); err != nil {
-			log.WithError(err).Error("Failed to close connection")
+		var genesisValidatorsRoot []byte
+		if offlineExit != nil {
+			genesisValidatorsRoot = offlineExit.GenesisValidatorsRoot
+		} else {
+			ctx := grpcutil.AppendHeaders(c.Context, grpcHeaders)
+			conn, err := grpc.DialContext(ctx, beaconRPCProvider, dialOpts...)
+			if err != nil {
+				return errors.Wrapf(err, "could not dial endpoint %s", beaconRPCProvider)
+			}
+			nodeClient := ethpb.NewNodeClient(conn)
+			resp, err := nodeClient.GetGenesis(c.Context, &empty.Empty{})
+			if err != nil {
+				return errors.Wrapf(err, "failed to get genesis info")
+			}
+			if err := conn.Close(); err != nil {
+				log.WithError(err).Error("Failed to close connection")
+			}
+			genesisValidatorsRoot = resp.GenesisValidatorsRoot
 		}
 		config, err := node.Web3SignerConfig(c)
 		if err != nil {
 			return errors.Wrapf(err, "could not configure web3signer")
 		}
-		config.GenesisValidatorsRoot = resp.GenesisValidatorsRoot
+		config.GenesisValidatorsRoot = genesisValidatorsRoot
 		w, km, err = walletWithWeb3SignerKeymanager(c, config)
 		if err != nil {
 			return err
@@ -84,6 +100,7 @@ func AccountsExit(c *cli.Context, r io.Reader) error {
 		accounts.WithBeaconRESTApiProvider(c.String(flags.BeaconRESTApiProviderFlag.Name)),
 		accounts.WithGRPCHeaders(grpcHeaders),
 		accounts.WithExitJSONOutputPath(c.String(flags.VoluntaryExitJSONOutputPath.Name)),
+		accounts.WithOfflineExit(offlineExit),
 	}
 	// Get full set of public keys from the keymanager.
 	validatingPublicKeys, err := km.FetchValidatingPublicKeys(c.Context)
@@ -106,3 +123,28 @@ func AccountsExit(c *cli.Context, r io.Reader) error {
 	}
 	return acc.Exit(c.Context)
 }
+
+// offlineExitConfig reads the configuration of voluntary exits signed without a beacon node from the CLI flags.
+func offlineExitConfig(c *cli.Context) (*accounts.OfflineExitCfg, error) {
+	for _, f := range []cli.Flag{flags.VoluntaryExitJSONOutputPath, flags.ExitEpochFlag, flags.GenesisValidatorsRootFlag, flags.ValidatorIndicesPathFlag} {
+		if !c.IsSet(f.Names()[0]) {
+			return nil, errors.Errorf("--%s is required to sign voluntary exits offline", f.Names()[0])
+		}
+	}
+	genesisValidatorsRoot, err := hexutil.Decode(c.String(flags.GenesisValidatorsRootFlag.Name))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid genesis validators root")
+	}
+	if len(genesisValidatorsRoot) != fieldparams.RootLength {
+		return nil, errors.Errorf("genesis validators root must be %d bytes long", fieldparams.RootLength)
+	}
+	indices, err := accounts.ValidatorIndicesFromFile(c.String(flags.ValidatorIndicesPathFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	return &accounts.OfflineExitCfg{
+		Epoch:                 primitives.Epoch(c.Uint64(flags.ExitEpochFlag.Name)),
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		ValidatorIndices:      indices,
+	}, nil
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"github.com/prysmaticlabs/prysm/v4/cmd"
+	"github.com/prysmaticlabs/prysm/v4/cmd/validator/flags"
 	"github.com/prysmaticlabs/prysm/v4/runtime/tos"
 	validatordb "github.com/prysmaticlabs/prysm/v4/validator/db"
 	"github.com/sirupsen/logrus"
@@ -31,6 +32,21 @@ var Commands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name:        "prune",
+			Description: `prunes the attestation history of the slashing protection database and compacts it`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				flags.SlashingProtectionPruningEpochsFlag,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := validatordb.Prune(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not prune database")
+				}
+				return nil
+			},
+		},
 		{
 			Name:     "migrate",
 			Category: "db",
@@ -30,8 +30,10 @@ var (
 	}
 	// BeaconRPCProviderFlag defines a beacon node RPC endpoint.
 	BeaconRPCProviderFlag = &cli.StringFlag{
-		Name:  "beacon-rpc-provider",
-		Usage: "Beacon node RPC provider endpoint",
+		Name: "beacon-rpc-provider",
+		Usage: "Beacon node RPC provider endpoint. Several comma-separated endpoints can be given, in which case " +
+			"the duties are performed with the healthiest beacon node, and the produced blocks, attestations and " +
+			"sync committee messages are broadcast to all of them",
 		Value: "127.0.0.1:4000",
 	}
 	// BeaconRPCGatewayProviderFlag defines a beacon node JSON-RPC endpoint.
@@ -236,6 +238,29 @@ var (
 			"files. If this flag is provided, voluntary exits will be written to the provided " +
 			"directory and will not be broadcasted.",
 	}
+	// OfflineExitFlag signs voluntary exits without connecting to a beacon node.
+	OfflineExitFlag = &cli.BoolFlag{
+		Name: "offline-exit",
+		Usage: "Sign voluntary exits without connecting to a beacon node. The signed exits are written to " +
+			"--exit-json-output-dir for later broadcast, and --exit-epoch, --genesis-validators-root and " +
+			"--validator-indices-path must be provided",
+	}
+	// ExitEpochFlag defines the epoch of voluntary exits signed offline.
+	ExitEpochFlag = &cli.Uint64Flag{
+		Name:  "exit-epoch",
+		Usage: "The epoch of voluntary exits signed with --offline-exit. It must not be later than the current epoch when the exits are broadcast",
+	}
+	// GenesisValidatorsRootFlag defines the genesis validators root of the network voluntary exits are signed for offline.
+	GenesisValidatorsRootFlag = &cli.StringFlag{
+		Name:  "genesis-validators-root",
+		Usage: "The 0x prefixed genesis validators root of the network voluntary exits signed with --offline-exit are meant for",
+	}
+	// ValidatorIndicesPathFlag defines the file the indices of validators are read from when signing exits offline.
+	ValidatorIndicesPathFlag = &cli.StringFlag{
+		Name: "validator-indices-path",
+		Usage: "Path to a JSON file with the indices of the validators to exit with --offline-exit, in the format of " +
+			"a response of the /eth/v1/beacon/states/{state_id}/validators Beacon API endpoint",
+	}
 	// BackupPasswordFile for encrypting accounts a user wishes to back up.
 	BackupPasswordFile = &cli.StringFlag{
 		Name:  "backup-password-file",
@@ -297,6 +322,30 @@ var (
 		Name:  "validators-external-signer-public-keys",
 		Usage: "comma separated list of public keys OR an external url endpoint for the validator to retrieve public keys from for usage with web3signer",
 	}
+	// Web3SignerTimeoutFlag defines the timeout of a single request to web3signer.
+	Web3SignerTimeoutFlag = &cli.DurationFlag{
+		Name:  "validators-external-signer-timeout",
+		Usage: "Timeout of a single request to web3signer, such as 2s. No timeout if not set",
+	}
+	// Web3SignerMaxRetriesFlag defines how many times a sign request to web3signer is retried.
+	Web3SignerMaxRetriesFlag = &cli.Uint64Flag{
+		Name:  "validators-external-signer-max-retries",
+		Usage: "Number of times a sign request to web3signer is retried after a network or server error",
+		Value: 0,
+	}
+	// Web3SignerBackupURLFlag defines the URL of a backup web3signer.
+	Web3SignerBackupURLFlag = &cli.StringFlag{
+		Name: "validators-external-signer-backup-url",
+		Usage: "URL of a backup web3signer, switched to when --validators-external-signer-failover-threshold consecutive " +
+			"sign requests failed due to a network or server error or a timeout",
+	}
+	// Web3SignerFailoverThresholdFlag defines after how many consecutive failed sign requests the backup web3signer is used.
+	Web3SignerFailoverThresholdFlag = &cli.Uint64Flag{
+		Name: "validators-external-signer-failover-threshold",
+		Usage: "Number of consecutive sign requests failed due to a network or server error or a timeout after which " +
+			"the validator client switches between the web3signer and the backup web3signer",
+		Value: 3,
+	}
 
 	// KeymanagerKindFlag defines the kind of keymanager desired by a user during wallet creation.
 	KeymanagerKindFlag = &cli.StringFlag{
@@ -328,6 +377,20 @@ var (
 		Name:  "graffiti-file",
 		Usage: "The path to a YAML file with graffiti values",
 	}
+	// GraffitiProviderURLFlag specifies the URL of an external graffiti provider.
+	GraffitiProviderURLFlag = &cli.StringFlag{
+		Name: "graffiti-provider-url",
+		Usage: "URL of an HTTP endpoint supplying the graffiti of block proposals. It is called with the pubkey and slot " +
+			"query parameters of each proposal and answers with {\"graffiti\": \"...\"}. Takes priority over the other " +
+			"graffiti sources, which are used when it does not answer in time or answers with an empty graffiti",
+	}
+	// GraffitiProviderTimeoutFlag specifies the time given to the graffiti provider to answer.
+	GraffitiProviderTimeoutFlag = &cli.DurationFlag{
+		Name: "graffiti-provider-timeout",
+		Usage: "Time given to the graffiti provider to answer. When it does not answer in time, the graffiti of the other " +
+			"sources is used",
+		Value: 500 * time.Millisecond,
+	}
 	// ProposerSettingsFlag defines the path or URL to a file with proposer config.
 	ProposerSettingsFlag = &cli.StringFlag{
 		Name:  "proposer-settings-file",
@@ -364,6 +427,95 @@ var (
 		Usage: "Sets gas limit for the builder to use for constructing a payload for all the validators",
 		Value: fmt.Sprint(params.BeaconConfig().DefaultBuilderGasLimit),
 	}
+
+	// ValidatorsRegistrationBatchSizeFlag sets the maximum number of validator registrations submitted in one request.
+	ValidatorsRegistrationBatchSizeFlag = &cli.IntFlag{
+		Name: "validators-registration-batch-size",
+		Usage: "Sets the maximum number of validator registrations submitted in one request to the beacon node and to each relay. " +
+			"Use a non-positive value to submit all the registrations in one request.",
+		Value: 0,
+	}
+
+	// DistributedFlag enables the compatibility with distributed validator middlewares.
+	DistributedFlag = &cli.BoolFlag{
+		Name: "distributed",
+		Usage: "Runs the validator client as a node of a distributed validator cluster, behind a distributed validator " +
+			"middleware such as Charon or SSV which it connects to as its beacon node. Aggregators are selected with the " +
+			"selection proofs combined by the middleware. Doppelganger protection and remote slasher protection are skipped, " +
+			"while the local slashing protection still applies to the key share. Requires --enable-beacon-rest-api.",
+	}
+	// DistributedAttestationOffsetFlag delays the attestation duties in distributed mode.
+	DistributedAttestationOffsetFlag = &cli.DurationFlag{
+		Name: "distributed-attestation-offset",
+		Usage: "Delays the attestation and sync committee message duties past one third of the slot by this duration, " +
+			"to give the distributed validator middleware more time to reach consensus. Requires --distributed.",
+	}
+	// DistributedAggregationOffsetFlag delays the aggregation duties in distributed mode.
+	DistributedAggregationOffsetFlag = &cli.DurationFlag{
+		Name: "distributed-aggregation-offset",
+		Usage: "Delays the aggregation and sync committee contribution duties past two thirds of the slot by this duration, " +
+			"to give the distributed validator middleware more time to reach consensus. Requires --distributed.",
+	}
+	// DoppelgangerEpochsFlag sets the number of epochs the liveness of the keys is checked for by doppelganger protection.
+	DoppelgangerEpochsFlag = &cli.Uint64Flag{
+		Name: "doppelganger-epochs",
+		Usage: "Number of epochs a validator key has to be found not live on the network for, with the liveness endpoint " +
+			"of the beacon API or the validator performance over gRPC, before it performs duties when doppelganger " +
+			"protection is enabled. Set to 0 to only check for doppelgangers once at startup.",
+		Value: 2,
+	}
+	// PerformanceLogFileFlag sets the file the performance of the validating keys is logged to at the end of each epoch.
+	PerformanceLogFileFlag = &cli.StringFlag{
+		Name: "performance-log-file",
+		Usage: "File the performance of each validating key (attestation correctness and inclusion, proposals and sync " +
+			"committee messages) is appended to at the end of each epoch, as one JSON object per line. Ignored with " +
+			"--disable-rewards-penalties-logging.",
+	}
+	// AlertWebhookURLFlag sets the webhooks alerts about missed duties and decreasing balances are posted to.
+	AlertWebhookURLFlag = &cli.StringSliceFlag{
+		Name: "alert-webhook-url",
+		Usage: "URL alerts are posted to, as a JSON object, when a validating key misses a block proposal, misses " +
+			"attestations in a row or loses balance over several epochs. May be used multiple times. Attestation and " +
+			"balance alerts are ignored with --disable-rewards-penalties-logging.",
+	}
+	// AlertPagerDutyRoutingKeyFlag sets the PagerDuty integration key alerts are triggered with.
+	AlertPagerDutyRoutingKeyFlag = &cli.StringFlag{
+		Name:  "alert-pagerduty-routing-key",
+		Usage: "Routing key of a PagerDuty Events API v2 integration alerts are triggered with, in addition to the webhooks.",
+	}
+	// AlertMissedAttestationsFlag sets the number of attestations missed in a row which triggers an alert.
+	AlertMissedAttestationsFlag = &cli.Uint64Flag{
+		Name:  "alert-missed-attestations",
+		Usage: "Number of attestations a validating key misses in a row before an alert is sent. Set to 0 to disable.",
+		Value: 3,
+	}
+	// AlertBalanceDecreaseEpochsFlag sets the number of epochs over which a decreasing balance triggers an alert.
+	AlertBalanceDecreaseEpochsFlag = &cli.Uint64Flag{
+		Name:  "alert-balance-decrease-epochs",
+		Usage: "Number of epochs over which the rewards net of penalties of a validating key must be negative before an alert is sent. Withdrawals and deposits are not counted. Set to 0 to disable.",
+		Value: 4,
+	}
+	// KeystoresWatchDirFlag sets the directory of keystores imported and deleted while the validator client runs.
+	KeystoresWatchDirFlag = &cli.StringFlag{
+		Name: "keystores-watch-dir",
+		Usage: "Directory of EIP-2335 keystores to watch. At the start of each epoch, the keystores added to the " +
+			"directory are imported into the wallet in the background and the keys whose keystore was removed are " +
+			"deleted, without restarting the validator client. Added keys are checked for doppelgangers when " +
+			"doppelganger protection is enabled. Requires --keystores-watch-password-file and a local wallet.",
+	}
+	// KeystoresWatchPasswordFileFlag sets the file containing the password of the watched keystores.
+	KeystoresWatchPasswordFileFlag = &cli.StringFlag{
+		Name:  "keystores-watch-password-file",
+		Usage: "File containing the password of the keystores in --keystores-watch-dir.",
+	}
+	// SlashingProtectionPruningEpochsFlag sets the number of epochs of attestation history kept in the slashing protection database.
+	SlashingProtectionPruningEpochsFlag = &cli.Uint64Flag{
+		Name: "slashing-protection-pruning-epochs",
+		Usage: "Number of epochs of attestation history kept in the slashing protection database when it is pruned, " +
+			"either with --enable-slashing-protection-history-pruning or with the validator db prune command. The lowest " +
+			"signed source and target epochs of each key are raised to the oldest history kept, as required by EIP-3076.",
+		Value: uint64(params.BeaconConfig().SlashingProtectionPruningEpochs),
+	}
 )
 
 // DefaultValidatorDir returns OS-specific default validator directory.
@@ -71,14 +71,33 @@ var appFlags = []cli.Flag{
 	flags.WalletDirFlag,
 	flags.EnableWebFlag,
 	flags.GraffitiFileFlag,
+	flags.GraffitiProviderURLFlag,
+	flags.GraffitiProviderTimeoutFlag,
 	// Consensys' Web3Signer flags
 	flags.Web3SignerURLFlag,
 	flags.Web3SignerPublicValidatorKeysFlag,
+	flags.Web3SignerTimeoutFlag,
+	flags.Web3SignerMaxRetriesFlag,
+	flags.Web3SignerBackupURLFlag,
+	flags.Web3SignerFailoverThresholdFlag,
 	flags.SuggestedFeeRecipientFlag,
 	flags.ProposerSettingsURLFlag,
 	flags.ProposerSettingsFlag,
 	flags.EnableBuilderFlag,
 	flags.BuilderGasLimitFlag,
+	flags.ValidatorsRegistrationBatchSizeFlag,
+	flags.DistributedFlag,
+	flags.DistributedAttestationOffsetFlag,
+	flags.DistributedAggregationOffsetFlag,
+	flags.DoppelgangerEpochsFlag,
+	flags.SlashingProtectionPruningEpochsFlag,
+	flags.PerformanceLogFileFlag,
+	flags.AlertWebhookURLFlag,
+	flags.AlertPagerDutyRoutingKeyFlag,
+	flags.AlertMissedAttestationsFlag,
+	flags.AlertBalanceDecreaseEpochsFlag,
+	flags.KeystoresWatchDirFlag,
+	flags.KeystoresWatchPasswordFileFlag,
 	////////////////////
 	cmd.DisableMonitoringFlag,
 	cmd.MonitoringHostFlag,
@@ -93,6 +112,8 @@ var appFlags = []cli.Flag{
 	cmd.EnableTracingFlag,
 	cmd.TracingProcessNameFlag,
 	cmd.TracingEndpointFlag,
+	cmd.TracingOTLPEndpointFlag,
+	cmd.TracingOTLPHeadersFlag,
 	cmd.TraceSampleFractionFlag,
 	cmd.LogFormat,
 	cmd.LogFileName,
@@ -100,6 +121,7 @@ var appFlags = []cli.Flag{
 	cmd.ChainConfigFileFlag,
 	cmd.GrpcMaxCallRecvMsgSizeFlag,
 	cmd.ApiTimeoutFlag,
+	cmd.BLSMaxProcsFlag,
 	debug.PProfFlag,
 	debug.PProfAddrFlag,
 	debug.PProfPortFlag,
@@ -182,6 +204,7 @@ func main() {
 			log.WithError(err).Error("Cannot update data directory")
 		}
 
+		cmd.ConfigureBLS(ctx)
 		if err := debug.Setup(ctx); err != nil {
 			return err
 		}
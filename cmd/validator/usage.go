@@ -55,6 +55,8 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.EnableTracingFlag,
 			cmd.TracingProcessNameFlag,
 			cmd.TracingEndpointFlag,
+			cmd.TracingOTLPEndpointFlag,
+			cmd.TracingOTLPHeadersFlag,
 			cmd.TraceSampleFractionFlag,
 			cmd.MonitoringHostFlag,
 			flags.MonitoringPortFlag,
@@ -66,6 +68,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.GrpcMaxCallRecvMsgSizeFlag,
 			cmd.AcceptTosFlag,
 			cmd.ApiTimeoutFlag,
+			cmd.BLSMaxProcsFlag,
 		},
 	},
 	{
@@ -106,13 +109,32 @@ var appHelpFlagGroups = []flagGroup{
 			flags.WalletDirFlag,
 			flags.WalletPasswordFileFlag,
 			flags.GraffitiFileFlag,
+			flags.GraffitiProviderURLFlag,
+			flags.GraffitiProviderTimeoutFlag,
 			flags.Web3SignerURLFlag,
 			flags.Web3SignerPublicValidatorKeysFlag,
+			flags.Web3SignerTimeoutFlag,
+			flags.Web3SignerMaxRetriesFlag,
+			flags.Web3SignerBackupURLFlag,
+			flags.Web3SignerFailoverThresholdFlag,
 			flags.ProposerSettingsFlag,
 			flags.ProposerSettingsURLFlag,
 			flags.SuggestedFeeRecipientFlag,
 			flags.EnableBuilderFlag,
 			flags.BuilderGasLimitFlag,
+			flags.ValidatorsRegistrationBatchSizeFlag,
+			flags.DistributedFlag,
+			flags.DistributedAttestationOffsetFlag,
+			flags.DistributedAggregationOffsetFlag,
+			flags.DoppelgangerEpochsFlag,
+			flags.SlashingProtectionPruningEpochsFlag,
+			flags.PerformanceLogFileFlag,
+			flags.AlertWebhookURLFlag,
+			flags.AlertPagerDutyRoutingKeyFlag,
+			flags.AlertMissedAttestationsFlag,
+			flags.AlertBalanceDecreaseEpochsFlag,
+			flags.KeystoresWatchDirFlag,
+			flags.KeystoresWatchPasswordFileFlag,
 		},
 	},
 	{
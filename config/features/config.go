@@ -44,6 +44,7 @@ type Flags struct {
 	WriteWalletPasswordOnWebOnboarding  bool // WriteWalletPasswordOnWebOnboarding writes the password to disk after Prysm web signup.
 	EnableDoppelGanger                  bool // EnableDoppelGanger enables doppelganger protection on startup for the validator.
 	EnableHistoricalSpaceRepresentation bool // EnableHistoricalSpaceRepresentation enables the saving of registry validators in separate buckets to save space
+	EnableStateCompression              bool // EnableStateCompression compresses states in the database with a zstd dictionary trained on stored states.
 	EnableBeaconRESTApi                 bool // EnableBeaconRESTApi enables experimental usage of the beacon REST API by the validator when querying a beacon node
 	// Logging related toggles.
 	DisableGRPCConnectionLogs bool // Disables logging when a new grpc client has connected.
@@ -67,8 +68,12 @@ type Flags struct {
 	EnableVerboseSigVerification bool // EnableVerboseSigVerification specifies whether to verify individual signature if batch verification fails
 	EnableOptionalEngineMethods  bool // EnableOptionalEngineMethods specifies whether to activate capella specific engine methods
 	EnableEIP4881                bool // EnableEIP4881 specifies whether to use the deposit tree from EIP4881
+	EnableTransitionProfiling    bool // EnableTransitionProfiling times the stages of the state transition.
+	SimulateAttestationTiming    bool // SimulateAttestationTiming logs the attestation votes at several offsets into every slot.
+	EnableLightClient            bool // EnableLightClient serves the light client data through the API and gossip.
 
-	PrepareAllPayloads bool // PrepareAllPayloads informs the engine to prepare a block on every slot.
+	PrepareAllPayloads                  bool // PrepareAllPayloads informs the engine to prepare a block on every slot.
+	BuilderProposalWhenExecutionSyncing bool // BuilderProposalWhenExecutionSyncing proposes with a builder payload when the execution client is syncing.
 
 	BuildBlockParallel bool // BuildBlockParallel builds beacon block for proposer in parallel.
 	AggregateParallel  bool // AggregateParallel aggregates attestations in parallel.
@@ -209,6 +214,10 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		log.WithField(enableHistoricalSpaceRepresentation.Name, enableHistoricalSpaceRepresentation.Usage).Warn(enabledFeatureFlag)
 		cfg.EnableHistoricalSpaceRepresentation = true
 	}
+	if ctx.Bool(enableStateCompression.Name) {
+		logEnabled(enableStateCompression)
+		cfg.EnableStateCompression = true
+	}
 	if ctx.Bool(disableStakinContractCheck.Name) {
 		logEnabled(disableStakinContractCheck)
 		cfg.DisableStakinContractCheck = true
@@ -237,6 +246,10 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		logEnabled(prepareAllPayloads)
 		cfg.PrepareAllPayloads = true
 	}
+	if ctx.IsSet(builderProposalWhenExecutionSyncing.Name) {
+		logEnabled(builderProposalWhenExecutionSyncing)
+		cfg.BuilderProposalWhenExecutionSyncing = true
+	}
 	cfg.BuildBlockParallel = true
 	if ctx.IsSet(disableBuildBlockParallel.Name) {
 		logEnabled(disableBuildBlockParallel)
@@ -255,6 +268,18 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		logEnabled(enableEIP4881)
 		cfg.EnableEIP4881 = true
 	}
+	if ctx.IsSet(enableTransitionProfiling.Name) {
+		logEnabled(enableTransitionProfiling)
+		cfg.EnableTransitionProfiling = true
+	}
+	if ctx.IsSet(simulateAttestationTiming.Name) {
+		logEnabled(simulateAttestationTiming)
+		cfg.SimulateAttestationTiming = true
+	}
+	if ctx.IsSet(enableLightClient.Name) {
+		logEnabled(enableLightClient)
+		cfg.EnableLightClient = true
+	}
 	cfg.AggregateIntervals = [3]time.Duration{aggregateFirstInterval.Value, aggregateSecondInterval.Value, aggregateThirdInterval.Value}
 	Init(cfg)
 	return nil
@@ -115,6 +115,12 @@ var (
 			" (Warning): Once enabled, this feature migrates your database in to a new schema and " +
 			"there is no going back. At worst, your entire database might get corrupted.",
 	}
+	enableStateCompression = &cli.BoolFlag{
+		Name: "enable-state-compression",
+		Usage: "Compresses states saved in the database with a zstd dictionary trained on previously stored states, " +
+			"which substantially reduces the size of archive node databases. Existing states are migrated on startup " +
+			"and remain readable if the flag is later removed.",
+	}
 	enableStartupOptimistic = &cli.BoolFlag{
 		Name:   "startup-optimistic",
 		Usage:  "Treats every block as optimistically synced at launch. Use with caution",
@@ -139,12 +145,17 @@ var (
 	}
 	enableOptionalEngineMethods = &cli.BoolFlag{
 		Name:  "enable-optional-engine-methods",
-		Usage: "Enables the optional engine methods",
+		Usage: "Enables the optional engine methods, such as engine_getPayloadBodiesByHashV1 and engine_getPayloadBodiesByRangeV1 to reconstruct blocks stored without their execution payload",
 	}
 	prepareAllPayloads = &cli.BoolFlag{
 		Name:  "prepare-all-payloads",
 		Usage: "Informs the engine to prepare all local payloads. Useful for relayers and builders",
 	}
+	builderProposalWhenExecutionSyncing = &cli.BoolFlag{
+		Name: "builder-proposal-when-execution-syncing",
+		Usage: "Proposes a block with a payload from the builder when the execution client is syncing, " +
+			"instead of refusing to propose",
+	}
 	disableBuildBlockParallel = &cli.BoolFlag{
 		Name:  "disable-build-block-parallel",
 		Usage: "Disables building a beacon block in parallel for consensus and execution",
@@ -153,6 +164,20 @@ var (
 		Name:  "enable-eip-4881",
 		Usage: "Enables the deposit tree specified in EIP4881",
 	}
+	enableTransitionProfiling = &cli.BoolFlag{
+		Name:  "enable-state-transition-profiling",
+		Usage: "Times every block operation and epoch processing stage of the state transition, reporting them as metrics and trace spans",
+	}
+	simulateAttestationTiming = &cli.BoolFlag{
+		Name: "simulate-attestation-timing",
+		Usage: "Logs, for every slot, the head a validator would have voted for at several offsets into the slot " +
+			"and whether it matches the canonical block of the slot, to evaluate attestation timing strategies",
+	}
+	enableLightClient = &cli.BoolFlag{
+		Name: "enable-lightclient",
+		Usage: "Computes and stores light client updates, serving them through the light client API endpoints " +
+			"and gossip topics",
+	}
 	disableResourceManager = &cli.BoolFlag{
 		Name:  "disable-resource-manager",
 		Usage: "Disables running the libp2p resource manager",
@@ -210,6 +235,7 @@ var BeaconChainFlags = append(deprecatedBeaconFlags, append(deprecatedFlags, []c
 	disableBroadcastSlashingFlag,
 	enableSlasherFlag,
 	enableHistoricalSpaceRepresentation,
+	enableStateCompression,
 	disableStakinContractCheck,
 	disableReorgLateBlocks,
 	SaveFullExecutionPayloads,
@@ -218,11 +244,15 @@ var BeaconChainFlags = append(deprecatedBeaconFlags, append(deprecatedFlags, []c
 	enableVerboseSigVerification,
 	enableOptionalEngineMethods,
 	prepareAllPayloads,
+	builderProposalWhenExecutionSyncing,
 	disableBuildBlockParallel,
 	aggregateFirstInterval,
 	aggregateSecondInterval,
 	aggregateThirdInterval,
 	enableEIP4881,
+	enableTransitionProfiling,
+	simulateAttestationTiming,
+	enableLightClient,
 	disableResourceManager,
 	DisableRegistrationCache,
 	disableAggregateParallel,
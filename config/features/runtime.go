@@ -0,0 +1,73 @@
+package features
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// runtimeToggle is a feature flag which can safely be flipped while the node is running, because the
+// code it gates reads it again each time, rather than once at startup.
+type runtimeToggle struct {
+	flag   cli.Flag
+	field  func(*Flags) *bool
+	invert bool // invert is set for flags which disable a feature, i.e. the flag is set when the field is false.
+}
+
+var runtimeToggles = []runtimeToggle{
+	{flag: writeSSZStateTransitionsFlag, field: func(f *Flags) *bool { return &f.WriteSSZStateTransitions }},
+	{flag: disableGRPCConnectionLogging, field: func(f *Flags) *bool { return &f.DisableGRPCConnectionLogs }},
+	{flag: disableReorgLateBlocks, field: func(f *Flags) *bool { return &f.DisableReorgLateBlocks }},
+	{flag: disableBroadcastSlashingFlag, field: func(f *Flags) *bool { return &f.DisableBroadcastSlashings }},
+	{flag: enableFullSSZDataLogging, field: func(f *Flags) *bool { return &f.EnableFullSSZDataLogging }},
+	{flag: enableVerboseSigVerification, field: func(f *Flags) *bool { return &f.EnableVerboseSigVerification }},
+	{flag: enableTransitionProfiling, field: func(f *Flags) *bool { return &f.EnableTransitionProfiling }},
+	{flag: prepareAllPayloads, field: func(f *Flags) *bool { return &f.PrepareAllPayloads }},
+	{flag: builderProposalWhenExecutionSyncing, field: func(f *Flags) *bool { return &f.BuilderProposalWhenExecutionSyncing }},
+	{flag: disableBuildBlockParallel, field: func(f *Flags) *bool { return &f.BuildBlockParallel }, invert: true},
+	{flag: disableAggregateParallel, field: func(f *Flags) *bool { return &f.AggregateParallel }, invert: true},
+}
+
+func findRuntimeToggle(name string) (runtimeToggle, bool) {
+	for _, t := range runtimeToggles {
+		if t.flag.Names()[0] == name {
+			return t, true
+		}
+	}
+	return runtimeToggle{}, false
+}
+
+// RuntimeToggles returns the feature flags which can be set or unset at runtime, keyed by
+// their command line name, along with whether they are currently set.
+func RuntimeToggles() map[string]bool {
+	cfg := Get()
+	toggles := make(map[string]bool, len(runtimeToggles))
+	for _, t := range runtimeToggles {
+		toggles[t.flag.Names()[0]] = *t.field(cfg) != t.invert
+	}
+	return toggles
+}
+
+// SetRuntimeToggle sets or unsets the feature flag with the given command line name, as if the node had
+// been started with or without it. Only the flags returned by RuntimeToggles can be changed.
+func SetRuntimeToggle(name string, set bool) error {
+	t, ok := findRuntimeToggle(name)
+	if !ok {
+		return fmt.Errorf("feature flag %s cannot be changed at runtime", name)
+	}
+
+	featureConfigLock.Lock()
+	defer featureConfigLock.Unlock()
+
+	// The config is replaced rather than modified in place, as callers of Get keep reading the config it
+	// returned after the read lock is released.
+	cfg := &Flags{}
+	if featureConfig != nil {
+		*cfg = *featureConfig
+	}
+	*t.field(cfg) = set != t.invert
+	featureConfig = cfg
+
+	log.WithField("flag", name).WithField("set", set).Warn("Changed feature flag at runtime")
+	return nil
+}
@@ -0,0 +1,36 @@
+package features
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestSetRuntimeToggle(t *testing.T) {
+	resetCfg := InitWithReset(&Flags{EnableSlasher: true, BuildBlockParallel: true})
+	defer resetCfg()
+
+	before := Get()
+	require.NoError(t, SetRuntimeToggle(enableVerboseSigVerification.Name, true))
+	assert.Equal(t, true, Get().EnableVerboseSigVerification)
+	assert.Equal(t, true, Get().EnableSlasher, "Unrelated flags must be kept")
+	assert.Equal(t, false, before.EnableVerboseSigVerification, "Previously retrieved config must not be modified")
+
+	// Setting a disable flag unsets the feature it gates.
+	require.NoError(t, SetRuntimeToggle(disableBuildBlockParallel.Name, true))
+	assert.Equal(t, false, Get().BuildBlockParallel)
+	assert.Equal(t, true, RuntimeToggles()[disableBuildBlockParallel.Name])
+	require.NoError(t, SetRuntimeToggle(disableBuildBlockParallel.Name, false))
+	assert.Equal(t, true, Get().BuildBlockParallel)
+	assert.Equal(t, false, RuntimeToggles()[disableBuildBlockParallel.Name])
+}
+
+func TestSetRuntimeToggle_NotAllowed(t *testing.T) {
+	resetCfg := InitWithReset(&Flags{})
+	defer resetCfg()
+
+	assert.ErrorContains(t, "cannot be changed at runtime", SetRuntimeToggle(enableSlasherFlag.Name, true))
+	assert.ErrorContains(t, "cannot be changed at runtime", SetRuntimeToggle("foo", true))
+	assert.Equal(t, false, Get().EnableSlasher)
+}
@@ -74,6 +74,8 @@ type BeaconChainConfig struct {
 	ReorgParentWeightThreshold      uint64           `yaml:"REORG_PARENT_WEIGHT_THRESHOLD" spec:"true"`       // ReorgParentWeightThreshold defines a value that is a % of the committee weight to consider a parent block strong and subject its child to being orphaned.
 	ReorgMaxEpochsSinceFinalization primitives.Epoch `yaml:"REORG_MAX_EPOCHS_SINCE_FINALIZATION" spec:"true"` // This defines a limit to consider safe to orphan a block if the network is finalizing
 	IntervalsPerSlot                uint64           `yaml:"INTERVALS_PER_SLOT" spec:"true"`                  // IntervalsPerSlot defines the number of fork choice intervals in a slot defined in the fork choice spec.
+	ReorgLateBlockCutoff            uint64           `yaml:"REORG_LATE_BLOCK_CUTOFF"`                         // ReorgLateBlockCutoff defines the seconds into its slot after which a block is considered late and a candidate to being orphaned.
+	ReorgProposingEarlyCutoff       uint64           `yaml:"REORG_PROPOSING_EARLY_CUTOFF"`                    // ReorgProposingEarlyCutoff defines the seconds into its slot before which a proposer is sure to receive the proposer boost, and thus may orphan a late block.
 
 	// Ethereum PoW parameters.
 	DepositChainID         uint64 `yaml:"DEPOSIT_CHAIN_ID" spec:"true"`         // DepositChainID of the eth1 network. This used for replay protection.
@@ -121,6 +123,7 @@ type BeaconChainConfig struct {
 	DomainContributionAndProof        [4]byte `yaml:"DOMAIN_CONTRIBUTION_AND_PROOF" spec:"true"`         // DomainAggregateAndProof defines the BLS signature domain for contribution and proof.
 	DomainApplicationMask             [4]byte `yaml:"DOMAIN_APPLICATION_MASK" spec:"true"`               // DomainApplicationMask defines the BLS signature domain for application mask.
 	DomainApplicationBuilder          [4]byte `yaml:"DOMAIN_APPLICATION_BUILDER" spec:"true"`            // DomainApplicationBuilder defines the BLS signature domain for application builder.
+	DomainApplicationOwnershipProof   [4]byte `yaml:"DOMAIN_APPLICATION_OWNERSHIP_PROOF"`                // DomainApplicationOwnershipProof defines the BLS signature domain for proofs of validator key ownership.
 	DomainBLSToExecutionChange        [4]byte `yaml:"DOMAIN_BLS_TO_EXECUTION_CHANGE" spec:"true"`        // DomainBLSToExecutionChange defines the BLS signature domain to change withdrawal addresses to ETH1 prefix
 	DomainBlobSidecar                 [4]byte `yaml:"DOMAIN_BLOB_SIDECAR" spec:"true"`                   // DomainBlobSidecar defines the BLS signature domain for blob sidecar.
 
@@ -214,10 +217,16 @@ type BeaconChainConfig struct {
 	// Mev-boost circuit breaker
 	MaxBuilderConsecutiveMissedSlots primitives.Slot // MaxBuilderConsecutiveMissedSlots defines the number of consecutive skip slot to fallback from using relay/builder to local execution engine for block construction.
 	MaxBuilderEpochMissedSlots       primitives.Slot // MaxBuilderEpochMissedSlots is defines the number of total skip slot (per epoch rolling windows) to fallback from using relay/builder to local execution engine for block construction.
+	MinBuilderEpochParticipation     uint64          // MinBuilderEpochParticipation defines the minimum percentage of active balance attesting to the correct target last epoch below which local execution engine is used for block construction. Zero disables the check.
 	LocalBlockValueBoost             uint64          // LocalBlockValueBoost is the value boost for local block construction. This is used to prioritize local block construction over relay/builder block construction.
 
+	// Deneb
+	MaxBlobsPerBlock     uint64 `yaml:"MAX_BLOBS_PER_BLOCK" spec:"true"`     // MaxBlobsPerBlock is the maximum number of blobs a block can commit to.
+	FieldElementsPerBlob uint64 `yaml:"FIELD_ELEMENTS_PER_BLOB" spec:"true"` // FieldElementsPerBlob is the number of field elements in a blob.
+
 	// Execution engine timeout value
-	ExecutionEngineTimeoutValue uint64 // ExecutionEngineTimeoutValue defines the seconds to wait before timing out engine endpoints with execution payload execution semantics (newPayload, forkchoiceUpdated).
+	ExecutionEngineTimeoutValue           uint64 // ExecutionEngineTimeoutValue defines the seconds to wait before timing out engine endpoints with execution payload execution semantics (newPayload, forkchoiceUpdated).
+	ExecutionEngineGetPayloadTimeoutValue uint64 // ExecutionEngineGetPayloadTimeoutValue defines the seconds to wait before timing out the engine getPayload endpoint.
 
 	// Subnet value
 	BlobsidecarSubnetCount uint64 `yaml:"BLOB_SIDECAR_SUBNET_COUNT"` // BlobsidecarSubnetCount is the number of blobsidecar subnets used in the gossipsub protocol.
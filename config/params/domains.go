@@ -0,0 +1,99 @@
+package params
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var errDomainTypeOverride = errors.New("domain types of a public network config cannot be modified")
+var errDomainTypeCollision = errors.New("domain types must be distinct")
+
+// publicNetworks are the names of the configs whose domain types must match the mainnet ones.
+var publicNetworks = map[string]bool{
+	MainnetName: true,
+	PraterName:  true,
+	GoerliName:  true,
+	SepoliaName: true,
+	HoleskyName: true,
+}
+
+// DomainTypes returns the signature domain types of the config, keyed by their yaml name. The application mask,
+// which is not the domain of any signature, is not included.
+func (b *BeaconChainConfig) DomainTypes() map[string][4]byte {
+	domains := make(map[string][4]byte)
+	v := reflect.ValueOf(b).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !strings.HasPrefix(f.Name, "Domain") || f.Name == "DomainApplicationMask" {
+			continue
+		}
+		d, ok := v.Field(i).Interface().([4]byte)
+		if !ok {
+			continue
+		}
+		domains[f.Tag.Get("yaml")] = d
+	}
+	return domains
+}
+
+// customDomainTypes returns the yaml names of the domain types of the config which differ from the mainnet ones,
+// in sorted order.
+func customDomainTypes(b *BeaconChainConfig) []string {
+	mainnet := MainnetConfig().DomainTypes()
+	var custom []string
+	for name, d := range b.DomainTypes() {
+		if mainnet[name] != d {
+			custom = append(custom, name)
+		}
+	}
+	sort.Strings(custom)
+	return custom
+}
+
+// validateDomainTypes checks the domain types of the config. Application specific networks may use their own
+// domain types, for instance to repurpose the attestation or blob containers, but the domain types of public
+// networks cannot be modified, and signatures of different containers must not share a domain type, which would
+// make them valid for each other.
+func validateDomainTypes(b *BeaconChainConfig) error {
+	custom := customDomainTypes(b)
+	if len(custom) > 0 && publicNetworks[b.ConfigName] {
+		return errors.Wrapf(errDomainTypeOverride, "config %s overrides %s", b.ConfigName, strings.Join(custom, ", "))
+	}
+	byDomain := make(map[[4]byte]string)
+	domains := b.DomainTypes()
+	names := make([]string, 0, len(domains))
+	for name := range domains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		d := domains[name]
+		if other, ok := byDomain[d]; ok {
+			return errors.Wrapf(errDomainTypeCollision, "%s and %s are both %#x", other, name, d)
+		}
+		byDomain[d] = name
+	}
+	if len(custom) > 0 {
+		log.WithField("domainTypes", strings.Join(custom, ",")).Warn("Using custom signature domain types, " +
+			"signatures will not be valid on networks using the standard ones")
+	}
+	return nil
+}
+
+// domainTypesToYaml returns the yaml lines of the domain types of the config which differ from the mainnet ones.
+func domainTypesToYaml(b *BeaconChainConfig) []string {
+	domains := b.DomainTypes()
+	custom := customDomainTypes(b)
+	lines := make([]string, 0, len(custom))
+	for _, name := range custom {
+		d := domains[name]
+		lines = append(lines, fmt.Sprintf("%s: %#x", name, d))
+	}
+	return lines
+}
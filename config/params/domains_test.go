@@ -0,0 +1,41 @@
+package params_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestDomainTypes(t *testing.T) {
+	domains := params.MainnetConfig().DomainTypes()
+	assert.Equal(t, params.MainnetConfig().DomainBeaconAttester, domains["DOMAIN_BEACON_ATTESTER"])
+	assert.Equal(t, params.MainnetConfig().DomainBlobSidecar, domains["DOMAIN_BLOB_SIDECAR"])
+	_, ok := domains["DOMAIN_APPLICATION_MASK"]
+	assert.Equal(t, false, ok)
+}
+
+func TestUnmarshalConfig_CustomDomainTypes(t *testing.T) {
+	t.Run("devnet", func(t *testing.T) {
+		c := params.MinimalSpecConfig().Copy()
+		c.ConfigName = "custom"
+		c.DomainBeaconAttester = [4]byte{0x01, 0x00, 0x00, 0x10}
+		c.DomainBlobSidecar = [4]byte{0x0b, 0x00, 0x00, 0x10}
+		cfg, err := params.UnmarshalConfig(params.ConfigToYaml(c), nil)
+		require.NoError(t, err)
+		assert.Equal(t, c.DomainBeaconAttester, cfg.DomainBeaconAttester)
+		assert.Equal(t, c.DomainBlobSidecar, cfg.DomainBlobSidecar)
+		assert.Equal(t, params.MainnetConfig().DomainBeaconProposer, cfg.DomainBeaconProposer)
+	})
+	t.Run("public network", func(t *testing.T) {
+		yaml := "CONFIG_NAME: 'mainnet'\nDOMAIN_BEACON_ATTESTER: 0x01000010\n"
+		_, err := params.UnmarshalConfig([]byte(yaml), nil)
+		require.ErrorContains(t, "domain types of a public network config cannot be modified", err)
+	})
+	t.Run("collision", func(t *testing.T) {
+		yaml := "CONFIG_NAME: 'custom'\nDOMAIN_BLOB_SIDECAR: 0x01000000\n"
+		_, err := params.UnmarshalConfig([]byte(yaml), nil)
+		require.ErrorContains(t, "DOMAIN_BEACON_ATTESTER and DOMAIN_BLOB_SIDECAR are both 0x01000000", err)
+	})
+}
@@ -0,0 +1,87 @@
+package params
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"gopkg.in/yaml.v2"
+)
+
+var errForkVersionCollision = errors.New("fork versions must be distinct")
+var errForkEpochOrder = errors.New("forks must be scheduled in order")
+var errPresetMismatch = errors.New("config value does not match the preset this binary was built with")
+
+type scheduledFork struct {
+	version int
+	fv      [fieldparams.VersionLength]byte
+	epoch   primitives.Epoch
+}
+
+// scheduledForks returns the forks of the config in the order they activate on the network.
+func scheduledForks(b *BeaconChainConfig) []scheduledFork {
+	return []scheduledFork{
+		{version.Phase0, bytesutil.ToBytes4(b.GenesisForkVersion), b.GenesisEpoch},
+		{version.Altair, bytesutil.ToBytes4(b.AltairForkVersion), b.AltairForkEpoch},
+		{version.Bellatrix, bytesutil.ToBytes4(b.BellatrixForkVersion), b.BellatrixForkEpoch},
+		{version.Capella, bytesutil.ToBytes4(b.CapellaForkVersion), b.CapellaForkEpoch},
+		{version.Deneb, bytesutil.ToBytes4(b.DenebForkVersion), b.DenebForkEpoch},
+	}
+}
+
+// validateForkSchedule checks that the forks of a config loaded at runtime can make up a fork schedule: each
+// fork has its own version, and no fork activates before the one it upgrades. Unscheduled forks have the far
+// future epoch, so they sort last.
+func validateForkSchedule(b *BeaconChainConfig) error {
+	forks := scheduledForks(b)
+	byVersion := make(map[[fieldparams.VersionLength]byte]int)
+	for i, f := range forks {
+		if other, ok := byVersion[f.fv]; ok {
+			return errors.Wrapf(errForkVersionCollision, "%s and %s are both %#x",
+				version.String(other), version.String(f.version), f.fv)
+		}
+		byVersion[f.fv] = f.version
+		if i > 0 && f.epoch < forks[i-1].epoch {
+			return errors.Wrapf(errForkEpochOrder, "%s at epoch %d activates before %s at epoch %d",
+				version.String(f.version), f.epoch, version.String(forks[i-1].version), forks[i-1].epoch)
+		}
+	}
+	return nil
+}
+
+// presetValues are the preset values of the config which size consensus containers. Unlike the rest of the
+// config, they are compiled into the binary.
+var presetValues = map[string]struct {
+	value    func(b *BeaconChainConfig) uint64
+	compiled uint64
+}{
+	"MAX_BLOBS_PER_BLOCK":     {func(b *BeaconChainConfig) uint64 { return b.MaxBlobsPerBlock }, fieldparams.MaxBlobsPerBlock},
+	"FIELD_ELEMENTS_PER_BLOB": {func(b *BeaconChainConfig) uint64 { return b.FieldElementsPerBlob }, fieldparams.FieldElementsPerBlob},
+}
+
+// validatePresetValues checks that the preset values which a config file sets match the preset this binary
+// was built with, as a config which changes them requires building with another preset.
+func validatePresetValues(yamlFile []byte, b *BeaconChainConfig) error {
+	keys := make(map[string]interface{})
+	if err := yaml.Unmarshal(yamlFile, &keys); err != nil {
+		return errors.Wrap(err, "could not parse chain config yaml file")
+	}
+	names := make([]string, 0, len(presetValues))
+	for name := range presetValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := keys[name]; !ok {
+			continue
+		}
+		p := presetValues[name]
+		if v := p.value(b); v != p.compiled {
+			return errors.Wrapf(errPresetMismatch, "%s is %d, the %s preset has %d", name, v, fieldparams.Preset, p.compiled)
+		}
+	}
+	return nil
+}
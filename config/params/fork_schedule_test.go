@@ -0,0 +1,57 @@
+package params_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+// devnetSchedule is the fork schedule of a devnet, with fork versions which do not collide with known networks.
+const devnetSchedule = "GENESIS_FORK_VERSION: 0x10000070\n" +
+	"ALTAIR_FORK_VERSION: 0x20000070\nALTAIR_FORK_EPOCH: 0\n" +
+	"BELLATRIX_FORK_VERSION: 0x30000070\nBELLATRIX_FORK_EPOCH: 0\n" +
+	"CAPELLA_FORK_VERSION: 0x40000070\nCAPELLA_FORK_EPOCH: 10\n" +
+	"DENEB_FORK_VERSION: 0x50000070\nDENEB_FORK_EPOCH: 20\n"
+
+func TestUnmarshalConfig_ForkSchedule(t *testing.T) {
+	t.Run("custom schedule", func(t *testing.T) {
+		yaml := "CONFIG_NAME: 'devnet-7'\n" + devnetSchedule
+		cfg, err := params.UnmarshalConfig([]byte(yaml), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "devnet-7", cfg.ConfigName)
+		assert.DeepEqual(t, []byte{0x50, 0, 0, 0x70}, cfg.DenebForkVersion)
+		assert.Equal(t, params.MainnetConfig().MaxBlobsPerBlock, cfg.MaxBlobsPerBlock)
+		cfg.InitializeForkSchedule()
+		assert.Equal(t, 5, len(cfg.ForkVersionSchedule))
+	})
+	t.Run("version collision", func(t *testing.T) {
+		yaml := "CONFIG_NAME: 'custom'\nCAPELLA_FORK_VERSION: 0x04000000\n"
+		_, err := params.UnmarshalConfig([]byte(yaml), nil)
+		require.ErrorContains(t, "capella and deneb are both 0x04000000", err)
+	})
+	t.Run("out of order", func(t *testing.T) {
+		yaml := "CONFIG_NAME: 'custom'\nALTAIR_FORK_EPOCH: 0\nBELLATRIX_FORK_EPOCH: 0\nCAPELLA_FORK_EPOCH: 20\nDENEB_FORK_EPOCH: 10\n"
+		_, err := params.UnmarshalConfig([]byte(yaml), nil)
+		require.ErrorContains(t, "deneb at epoch 10 activates before capella at epoch 20", err)
+	})
+}
+
+func TestLoadChainConfigFile_PresetValues(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	dir := t.TempDir()
+
+	f := filepath.Join(dir, "matching.yaml")
+	require.NoError(t, os.WriteFile(f, []byte("CONFIG_NAME: 'devnet-8'\nMAX_BLOBS_PER_BLOCK: 6\n"+devnetSchedule), 0600))
+	require.NoError(t, params.LoadChainConfigFile(f, nil))
+	assert.Equal(t, "devnet-8", params.BeaconConfig().ConfigName)
+
+	f = filepath.Join(dir, "mismatching.yaml")
+	require.NoError(t, os.WriteFile(f, []byte("CONFIG_NAME: 'devnet-9'\nMAX_BLOBS_PER_BLOCK: 3\n"+devnetSchedule), 0600))
+	err := params.LoadChainConfigFile(f, nil)
+	require.ErrorContains(t, "MAX_BLOBS_PER_BLOCK is 3", err)
+	assert.Equal(t, "devnet-8", params.BeaconConfig().ConfigName)
+}
@@ -64,6 +64,12 @@ func UnmarshalConfig(yamlFile []byte, conf *BeaconChainConfig) (*BeaconChainConf
 	}
 	// recompute SqrRootSlotsPerEpoch constant to handle non-standard values of SlotsPerEpoch
 	conf.SqrRootSlotsPerEpoch = primitives.Slot(math.IntegerSquareRoot(uint64(conf.SlotsPerEpoch)))
+	if err := validateDomainTypes(conf); err != nil {
+		return nil, errors.Wrap(err, "invalid domain types")
+	}
+	if err := validateForkSchedule(conf); err != nil {
+		return nil, errors.Wrap(err, "invalid fork schedule")
+	}
 	log.Debugf("Config file values: %+v", conf)
 	return conf, nil
 }
@@ -79,11 +85,28 @@ func UnmarshalConfigFile(path string, conf *BeaconChainConfig) (*BeaconChainConf
 // LoadChainConfigFile load, convert hex values into valid param yaml format,
 // unmarshal , and apply beacon chain config file.
 func LoadChainConfigFile(path string, conf *BeaconChainConfig) error {
-	c, err := UnmarshalConfigFile(path, conf)
+	yamlFile, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return errors.Wrap(err, "Failed to read chain config file.")
+	}
+	c, err := UnmarshalConfig(yamlFile, conf)
 	if err != nil {
 		return err
 	}
-	return SetActive(c)
+	if err := validatePresetValues(yamlFile, c); err != nil {
+		return errors.Wrap(err, "chain config file cannot be used with this binary")
+	}
+	if err := SetActive(c); err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"configName":     c.ConfigName,
+		"altairEpoch":    c.AltairForkEpoch,
+		"bellatrixEpoch": c.BellatrixForkEpoch,
+		"capellaEpoch":   c.CapellaForkEpoch,
+		"denebEpoch":     c.DenebForkEpoch,
+	}).Info("Loaded chain config file")
+	return nil
 }
 
 // ReplaceHexStringWithYAMLFormat will replace hex strings that the yaml parser will understand.
@@ -210,6 +233,7 @@ func ConfigToYaml(cfg *BeaconChainConfig) []byte {
 		fmt.Sprintf("DENEB_FORK_EPOCH: %d", cfg.DenebForkEpoch),
 		fmt.Sprintf("DENEB_FORK_VERSION: %#x", cfg.DenebForkVersion),
 	}
+	lines = append(lines, domainTypesToYaml(cfg)...)
 
 	yamlFile := []byte(strings.Join(lines, "\n"))
 	return yamlFile
@@ -34,7 +34,6 @@ var placeholderFields = []string{
 	"EPOCHS_PER_SUBNET_SUBSCRIPTION",
 	"GOSSIP_MAX_SIZE",
 	"MAXIMUM_GOSSIP_CLOCK_DISPARITY",
-	"MAX_BLOBS_PER_BLOCK",
 	"MAX_CHUNK_SIZE",
 	"MAX_REQUEST_BLOB_SIDECARS",
 	"MAX_REQUEST_BLOCKS",
@@ -160,6 +159,8 @@ func assertEqualConfigs(t *testing.T, name string, fields []string, expected, ac
 	assert.DeepEqual(t, expected.BellatrixForkVersion, actual.BellatrixForkVersion, "%s: BellatrixForkVersion", name)
 	assert.DeepEqual(t, expected.CapellaForkVersion, actual.CapellaForkVersion, "%s: CapellaForkVersion", name)
 	assert.DeepEqual(t, expected.DenebForkVersion, actual.DenebForkVersion, "%s: DenebForkVersion", name)
+	assert.Equal(t, expected.MaxBlobsPerBlock, actual.MaxBlobsPerBlock, "%s: MaxBlobsPerBlock", name)
+	assert.Equal(t, expected.FieldElementsPerBlob, actual.FieldElementsPerBlob, "%s: FieldElementsPerBlob", name)
 
 	assertYamlFieldsMatch(t, name, fields, expected, actual)
 }
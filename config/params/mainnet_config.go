@@ -121,6 +121,8 @@ var mainnetBeaconConfig = &BeaconChainConfig{
 	ReorgParentWeightThreshold:      160,
 	ReorgMaxEpochsSinceFinalization: 2,
 	IntervalsPerSlot:                3,
+	ReorgLateBlockCutoff:            4,
+	ReorgProposingEarlyCutoff:       2,
 
 	// Ethereum PoW parameters.
 	DepositChainID:         1, // Chain ID of eth1 mainnet.
@@ -176,6 +178,7 @@ var mainnetBeaconConfig = &BeaconChainConfig{
 	DomainContributionAndProof:        bytesutil.Uint32ToBytes4(0x09000000),
 	DomainApplicationMask:             bytesutil.Uint32ToBytes4(0x00000001),
 	DomainApplicationBuilder:          bytesutil.Uint32ToBytes4(0x00000001),
+	DomainApplicationOwnershipProof:   bytesutil.Uint32ToBytes4(0x00000101),
 	DomainBLSToExecutionChange:        bytesutil.Uint32ToBytes4(0x0A000000),
 	DomainBlobSidecar:                 bytesutil.Uint32ToBytes4(0x0B000000),
 
@@ -262,11 +265,16 @@ var mainnetBeaconConfig = &BeaconChainConfig{
 	EthBurnAddressHex:                "0x0000000000000000000000000000000000000000",
 	DefaultBuilderGasLimit:           uint64(30000000),
 
+	// Deneb
+	MaxBlobsPerBlock:     6,
+	FieldElementsPerBlob: 4096,
+
 	// Mevboost circuit breaker
 	MaxBuilderConsecutiveMissedSlots: 3,
 	MaxBuilderEpochMissedSlots:       5,
 	// Execution engine timeout value
-	ExecutionEngineTimeoutValue: 8, // 8 seconds default based on: https://github.com/ethereum/execution-apis/blob/main/src/engine/specification.md#core
+	ExecutionEngineTimeoutValue:           8, // 8 seconds default based on: https://github.com/ethereum/execution-apis/blob/main/src/engine/specification.md#core
+	ExecutionEngineGetPayloadTimeoutValue: 1,
 
 	// Subnet value
 	BlobsidecarSubnetCount: 6,
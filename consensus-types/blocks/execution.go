@@ -870,6 +870,27 @@ func IsEmptyExecutionData(data interfaces.ExecutionData) (bool, error) {
 	if data.Timestamp() != 0 {
 		return false, nil
 	}
+
+	blobGasUsed, err := data.BlobGasUsed()
+	switch {
+	case errors.Is(err, consensus_types.ErrUnsupportedField):
+	case err != nil:
+		return false, err
+	default:
+		if blobGasUsed != 0 {
+			return false, nil
+		}
+	}
+	excessBlobGas, err := data.ExcessBlobGas()
+	switch {
+	case errors.Is(err, consensus_types.ErrUnsupportedField):
+	case err != nil:
+		return false, err
+	default:
+		if excessBlobGas != 0 {
+			return false, nil
+		}
+	}
 	return true, nil
 }
 
@@ -1015,12 +1036,12 @@ func (e executionPayloadHeaderDeneb) WithdrawalsRoot() ([]byte, error) {
 	return e.p.WithdrawalsRoot, nil
 }
 
-// BlobGasUsed
+// BlobGasUsed --
 func (e executionPayloadHeaderDeneb) BlobGasUsed() (uint64, error) {
 	return e.p.BlobGasUsed, nil
 }
 
-// ExcessBlobGas
+// ExcessBlobGas --
 func (e executionPayloadHeaderDeneb) ExcessBlobGas() (uint64, error) {
 	return e.p.ExcessBlobGas, nil
 }
@@ -1186,10 +1207,12 @@ func (e executionPayloadDeneb) WithdrawalsRoot() ([]byte, error) {
 	return nil, consensus_types.ErrUnsupportedField
 }
 
+// BlobGasUsed --
 func (e executionPayloadDeneb) BlobGasUsed() (uint64, error) {
 	return e.p.BlobGasUsed, nil
 }
 
+// ExcessBlobGas --
 func (e executionPayloadDeneb) ExcessBlobGas() (uint64, error) {
 	return e.p.ExcessBlobGas, nil
 }
@@ -350,6 +350,38 @@ func TestWrapExecutionPayloadHeaderDeneb_SSZ(t *testing.T) {
 	assert.NoError(t, payload.UnmarshalSSZ(encoded))
 }
 
+func TestIsEmptyExecutionData_Deneb(t *testing.T) {
+	empty, err := blocks.IsEmptyExecutionData(createWrappedPayloadDeneb(t))
+	require.NoError(t, err)
+	assert.Equal(t, true, empty)
+
+	empty, err = blocks.IsEmptyExecutionData(createWrappedPayloadHeaderDeneb(t))
+	require.NoError(t, err)
+	assert.Equal(t, true, empty)
+
+	empty, err = blocks.IsEmptyExecutionData(createWrappedPayloadCapella(t))
+	require.NoError(t, err)
+	assert.Equal(t, true, empty)
+
+	p, ok := createWrappedPayloadDeneb(t).Proto().(*enginev1.ExecutionPayloadDeneb)
+	require.Equal(t, true, ok)
+	p.BlobGasUsed = 1
+	payload, err := blocks.WrappedExecutionPayloadDeneb(p, 0)
+	require.NoError(t, err)
+	empty, err = blocks.IsEmptyExecutionData(payload)
+	require.NoError(t, err)
+	assert.Equal(t, false, empty)
+
+	h, ok := createWrappedPayloadHeaderDeneb(t).Proto().(*enginev1.ExecutionPayloadHeaderDeneb)
+	require.Equal(t, true, ok)
+	h.ExcessBlobGas = 1
+	header, err := blocks.WrappedExecutionPayloadHeaderDeneb(h, 0)
+	require.NoError(t, err)
+	empty, err = blocks.IsEmptyExecutionData(header)
+	require.NoError(t, err)
+	assert.Equal(t, false, empty)
+}
+
 func createWrappedPayload(t testing.TB) interfaces.ExecutionData {
 	wsb, err := blocks.WrappedExecutionPayload(&enginev1.ExecutionPayload{
 		ParentHash:    make([]byte, fieldparams.RootLength),
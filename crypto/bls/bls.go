@@ -51,6 +51,11 @@ func AggregateMultiplePubkeys(pubs []PublicKey) PublicKey {
 	return blst.AggregateMultiplePubkeys(pubs)
 }
 
+// SubtractPublicKeys removes the provided decompressed keys from an aggregate key.
+func SubtractPublicKeys(agg PublicKey, pubs []PublicKey) PublicKey {
+	return blst.SubtractPublicKeys(agg, pubs)
+}
+
 // AggregateSignatures converts a list of signatures into a single, aggregated sig.
 func AggregateSignatures(sigs []common.Signature) common.Signature {
 	return blst.AggregateSignatures(sigs)
@@ -80,3 +85,9 @@ func NewAggregateSignature() common.Signature {
 func RandKey() (common.SecretKey, error) {
 	return blst.RandKey()
 }
+
+// SetMaxProcs sets the maximum number of threads used by the BLS backend. Zero or less restores the default of all
+// but one of the available cores.
+func SetMaxProcs(maxProcs int) {
+	blst.SetMaxProcs(maxProcs)
+}
@@ -28,3 +28,12 @@ func TestDisallowZeroPublicKeys_AggregatePubkeys(t *testing.T) {
 		require.Equal(t, common.ErrInfinitePubKey, err)
 	})
 }
+
+func TestHasFastPaths(t *testing.T) {
+	features := CPUFeatures()
+	if len(features) == 0 {
+		require.Equal(t, true, HasFastPaths())
+		return
+	}
+	require.Equal(t, features["adx"] && features["bmi2"], HasFastPaths())
+}
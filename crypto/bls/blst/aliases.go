@@ -9,3 +9,4 @@ type blstPublicKey = blst.P1Affine
 type blstSignature = blst.P2Affine
 type blstAggregateSignature = blst.P2Aggregate
 type blstAggregatePublicKey = blst.P1Aggregate
+type blstPublicKeyPoint = blst.P1
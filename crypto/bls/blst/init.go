@@ -12,12 +12,7 @@ import (
 )
 
 func init() {
-	// Reserve 1 core for general application work
-	maxProcs := runtime.GOMAXPROCS(0) - 1
-	if maxProcs <= 0 {
-		maxProcs = 1
-	}
-	blst.SetMaxProcs(maxProcs)
+	SetMaxProcs(0)
 	onEvict := func(_ [48]byte, _ common.PublicKey) {}
 	keysCache, err := nonblocking.NewLRU(maxKeys, onEvict)
 	if err != nil {
@@ -25,3 +20,15 @@ func init() {
 	}
 	pubkeyCache = keysCache
 }
+
+// SetMaxProcs sets the maximum number of threads blst uses to verify signatures and aggregate keys in parallel.
+// Zero or less restores the default, which reserves 1 core for general application work.
+func SetMaxProcs(maxProcs int) {
+	if maxProcs <= 0 {
+		maxProcs = runtime.GOMAXPROCS(0) - 1
+	}
+	if maxProcs <= 0 {
+		maxProcs = 1
+	}
+	blst.SetMaxProcs(maxProcs)
+}
@@ -67,6 +67,18 @@ func AggregatePublicKeys(pubs [][]byte) (common.PublicKey, error) {
 	return &PublicKey{p: agg.ToAffine()}, nil
 }
 
+// SubtractPublicKeys removes the provided decompressed keys from an aggregate key. Removing the keys of the
+// validators which did not sign from the aggregate key of a whole committee takes fewer operations than
+// aggregating the keys of the validators which did, when most of them did.
+func SubtractPublicKeys(agg common.PublicKey, pubkeys []common.PublicKey) common.PublicKey {
+	acc := new(blstPublicKeyPoint)
+	acc.FromAffine(agg.(*PublicKey).p)
+	for _, pubkey := range pubkeys {
+		acc.SubAssign(pubkey.(*PublicKey).p)
+	}
+	return &PublicKey{p: acc.ToAffine()}
+}
+
 // Marshal a public key into a LittleEndian byte slice.
 func (p *PublicKey) Marshal() []byte {
 	return p.p.Compress()
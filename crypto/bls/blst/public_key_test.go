@@ -92,6 +92,23 @@ func TestPublicKey_Aggregate(t *testing.T) {
 	require.DeepEqual(t, resKey.Marshal(), aggKey.Marshal(), "Pubkey does not match up")
 }
 
+func TestSubtractPublicKeys(t *testing.T) {
+	pubkeys := make([]common.PublicKey, 5)
+	for i := range pubkeys {
+		priv, err := blst.RandKey()
+		require.NoError(t, err)
+		pubkeys[i] = priv.PublicKey()
+	}
+	agg := blst.AggregateMultiplePubkeys(pubkeys)
+
+	resKey := blst.SubtractPublicKeys(agg, []common.PublicKey{pubkeys[1], pubkeys[3]})
+	aggKey := blst.AggregateMultiplePubkeys([]common.PublicKey{pubkeys[0], pubkeys[2], pubkeys[4]})
+	require.DeepEqual(t, aggKey.Marshal(), resKey.Marshal(), "Pubkey does not match up")
+
+	resKey = blst.SubtractPublicKeys(agg, nil)
+	require.DeepEqual(t, agg.Marshal(), resKey.Marshal(), "Pubkey does not match up")
+}
+
 func TestPublicKeysEmpty(t *testing.T) {
 	var pubs [][]byte
 	_, err := blst.AggregatePublicKeys(pubs)
@@ -128,6 +128,11 @@ func AggregateMultiplePubkeys(pubs []common.PublicKey) common.PublicKey {
 	panic(err)
 }
 
+// SubtractPublicKeys -- stub
+func SubtractPublicKeys(_ common.PublicKey, _ []common.PublicKey) common.PublicKey {
+	panic(err)
+}
+
 // AggregateCompressedSignatures -- stub
 func AggregateCompressedSignatures(multiSigs [][]byte) (common.Signature, error) {
 	panic(err)
@@ -152,3 +157,6 @@ func RandKey() (common.SecretKey, error) {
 func VerifyCompressed(_, _, _ []byte) bool {
 	panic(err)
 }
+
+// SetMaxProcs -- stub
+func SetMaxProcs(_ int) {}
@@ -0,0 +1,30 @@
+package bls
+
+import (
+	"runtime"
+
+	"golang.org/x/sys/cpu"
+)
+
+// CPUFeatures reports the instruction set extensions of the CPU which the BLS backend uses when available. On
+// x86-64, field multiplications rely on ADX and BMI2, and hashing to the curve benefits from AVX2.
+func CPUFeatures() map[string]bool {
+	if runtime.GOARCH != "amd64" {
+		return map[string]bool{}
+	}
+	return map[string]bool{
+		"adx":  cpu.X86.HasADX,
+		"bmi2": cpu.X86.HasBMI2,
+		"avx":  cpu.X86.HasAVX,
+		"avx2": cpu.X86.HasAVX2,
+	}
+}
+
+// HasFastPaths returns whether the CPU supports the fast field arithmetic of the BLS backend. Without ADX and BMI2,
+// an x86-64 CPU falls back to portable code which verifies signatures significantly slower.
+func HasFastPaths() bool {
+	if runtime.GOARCH != "amd64" {
+		return true
+	}
+	return cpu.X86.HasADX && cpu.X86.HasBMI2
+}
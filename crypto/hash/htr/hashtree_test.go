@@ -25,3 +25,22 @@ func Test_VectorizedSha256(t *testing.T) {
 		require.Equal(t, r, hash2[i])
 	}
 }
+
+func BenchmarkVectorizedSha256(b *testing.B) {
+	// The balances of 500,000 validators fill 125,000 chunks, which are all rehashed at epoch boundaries.
+	chunks := make([][32]byte, 125000)
+	for i := range chunks {
+		chunks[i][0], chunks[i][1] = byte(i), byte(i>>8)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		layer := chunks
+		for len(layer) > 1 {
+			if len(layer)%2 == 1 {
+				layer = append(layer, [32]byte{})
+			}
+			layer = VectorizedSha256(layer)
+		}
+	}
+}
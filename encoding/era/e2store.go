@@ -0,0 +1,152 @@
+package era
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// headerSize is the size of an e2store entry header: a 2 byte type, a 4 byte little endian
+// length and 2 reserved bytes that must be zero.
+const headerSize = 8
+
+// EntryType identifies the content of an e2store entry.
+type EntryType [2]byte
+
+// Entry types defined by the e2store and era file specifications.
+var (
+	TypeEmpty                       = EntryType{0x00, 0x00}
+	TypeVersion                     = EntryType{0x65, 0x32}
+	TypeCompressedSignedBeaconBlock = EntryType{0x01, 0x00}
+	TypeCompressedBeaconState       = EntryType{0x02, 0x00}
+	TypeSlotIndex                   = EntryType{0x69, 0x32}
+	TypeCompressedHeader            = EntryType{0x03, 0x00}
+	TypeCompressedBody              = EntryType{0x04, 0x00}
+	TypeCompressedReceipts          = EntryType{0x05, 0x00}
+	TypeTotalDifficulty             = EntryType{0x06, 0x00}
+	TypeAccumulator                 = EntryType{0x07, 0x00}
+	TypeBlockIndex                  = EntryType{0x66, 0x32}
+)
+
+var errInvalidHeader = errors.New("invalid e2store entry header")
+
+// Entry is a single record of an e2store file.
+type Entry struct {
+	Type EntryType
+	Data []byte
+}
+
+// e2Writer writes e2store entries while keeping track of the offset of each one.
+type e2Writer struct {
+	w      io.Writer
+	offset int64
+}
+
+// write appends an entry to the underlying writer and returns the offset at which it begins.
+func (w *e2Writer) write(t EntryType, data []byte) (int64, error) {
+	var header [headerSize]byte
+	copy(header[:2], t[:])
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(data)))
+	start := w.offset
+	if _, err := w.w.Write(header[:]); err != nil {
+		return 0, err
+	}
+	if _, err := w.w.Write(data); err != nil {
+		return 0, err
+	}
+	w.offset += int64(headerSize + len(data))
+	return start, nil
+}
+
+// readHeader reads the header of the entry beginning at the given offset.
+func readHeader(r io.ReaderAt, off int64) (EntryType, uint32, error) {
+	var header [headerSize]byte
+	if _, err := r.ReadAt(header[:], off); err != nil {
+		return EntryType{}, 0, errors.Wrapf(err, "could not read entry header at offset %d", off)
+	}
+	if header[6] != 0 || header[7] != 0 {
+		return EntryType{}, 0, errors.Wrapf(errInvalidHeader, "non zero reserved bytes at offset %d", off)
+	}
+	var t EntryType
+	copy(t[:], header[:2])
+	return t, binary.LittleEndian.Uint32(header[2:6]), nil
+}
+
+// ReadEntry reads the e2store entry beginning at the given offset.
+func ReadEntry(r io.ReaderAt, off int64) (*Entry, error) {
+	t, length, err := readHeader(r, off)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, length)
+	if _, err := r.ReadAt(data, off+headerSize); err != nil {
+		return nil, errors.Wrapf(err, "could not read entry data at offset %d", off)
+	}
+	return &Entry{Type: t, Data: data}, nil
+}
+
+// slotIndex maps the slots of an era, or the block numbers of an era1 file, to the offsets of
+// their entries, relative to the beginning of the index entry itself. A zero offset denotes an
+// empty slot.
+type slotIndex struct {
+	startSlot uint64
+	offsets   []int64
+}
+
+func (s *slotIndex) marshal() []byte {
+	data := make([]byte, 16+8*len(s.offsets))
+	binary.LittleEndian.PutUint64(data[:8], s.startSlot)
+	for i, o := range s.offsets {
+		binary.LittleEndian.PutUint64(data[8+8*i:], uint64(o))
+	}
+	binary.LittleEndian.PutUint64(data[len(data)-8:], uint64(len(s.offsets)))
+	return data
+}
+
+func unmarshalSlotIndex(data []byte) (*slotIndex, error) {
+	if len(data) < 16 || len(data)%8 != 0 {
+		return nil, errors.Errorf("invalid slot index size %d", len(data))
+	}
+	count := binary.LittleEndian.Uint64(data[len(data)-8:])
+	if uint64(len(data)) != 16+8*count {
+		return nil, errors.Errorf("slot index count %d does not match its size %d", count, len(data))
+	}
+	s := &slotIndex{
+		startSlot: binary.LittleEndian.Uint64(data[:8]),
+		offsets:   make([]int64, count),
+	}
+	for i := range s.offsets {
+		s.offsets[i] = int64(binary.LittleEndian.Uint64(data[8+8*i:]))
+	}
+	return s, nil
+}
+
+// readIndexEndingAt reads the index entry of the given type whose last byte precedes the given offset.
+func readIndexEndingAt(r io.ReaderAt, end int64, t EntryType) (*slotIndex, int64, error) {
+	if end < headerSize+16 {
+		return nil, 0, errors.New("file too small to contain an index")
+	}
+	var countBytes [8]byte
+	if _, err := r.ReadAt(countBytes[:], end-8); err != nil {
+		return nil, 0, errors.Wrap(err, "could not read index count")
+	}
+	count := binary.LittleEndian.Uint64(countBytes[:])
+	size := int64(headerSize + 16 + 8*count)
+	if count > uint64(end) || size > end {
+		return nil, 0, errors.Errorf("index count %d exceeds file size", count)
+	}
+	start := end - size
+	e, err := ReadEntry(r, start)
+	if err != nil {
+		return nil, 0, err
+	}
+	if e.Type != t {
+		return nil, 0, errors.Errorf("expected index entry of type %#x at offset %d, got type %#x", t, start, e.Type)
+	}
+	idx, err := unmarshalSlotIndex(e.Data)
+	if err != nil {
+		return nil, 0, err
+	}
+	return idx, start, nil
+}
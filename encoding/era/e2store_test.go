@@ -0,0 +1,47 @@
+package era
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestE2Store_WriteRead(t *testing.T) {
+	var buf bytes.Buffer
+	w := &e2Writer{w: &buf}
+	off, err := w.write(TypeVersion, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), off)
+	off, err = w.write(TypeCompressedSignedBeaconBlock, []byte("block"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(headerSize), off)
+	assert.Equal(t, int64(2*headerSize+5), w.offset)
+
+	r := bytes.NewReader(buf.Bytes())
+	e, err := ReadEntry(r, 0)
+	require.NoError(t, err)
+	assert.Equal(t, TypeVersion, e.Type)
+	assert.Equal(t, 0, len(e.Data))
+	e, err = ReadEntry(r, headerSize)
+	require.NoError(t, err)
+	assert.Equal(t, TypeCompressedSignedBeaconBlock, e.Type)
+	assert.DeepEqual(t, []byte("block"), e.Data)
+}
+
+func TestE2Store_ReservedBytes(t *testing.T) {
+	enc := []byte{0x65, 0x32, 0, 0, 0, 0, 1, 0}
+	_, err := ReadEntry(bytes.NewReader(enc), 0)
+	require.ErrorIs(t, err, errInvalidHeader)
+}
+
+func TestSlotIndex_MarshalRoundTrip(t *testing.T) {
+	idx := &slotIndex{startSlot: 8192, offsets: []int64{-100, 0, -20}}
+	got, err := unmarshalSlotIndex(idx.marshal())
+	require.NoError(t, err)
+	assert.DeepEqual(t, idx, got)
+
+	_, err = unmarshalSlotIndex(idx.marshal()[8:])
+	require.ErrorContains(t, "does not match its size", err)
+}
@@ -0,0 +1,316 @@
+// Package era implements reading and writing of era files, the archive format for beacon chain
+// history shared between consensus clients. An era file is an e2store file holding the
+// blocks of SLOTS_PER_HISTORICAL_ROOT consecutive slots, the state at the end of that
+// period and slot indices allowing random access to both. See
+// https://github.com/status-im/nimbus-eth2/blob/stable/docs/e2store.md for the specification.
+package era
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// FileExtension of era files.
+const FileExtension = ".era"
+
+// StartSlot returns the first slot whose block is stored in the given era.
+func StartSlot(era uint64) primitives.Slot {
+	if era == 0 {
+		return 0
+	}
+	return primitives.Slot(era-1) * params.BeaconConfig().SlotsPerHistoricalRoot
+}
+
+// StateSlot returns the slot of the state stored in the given era.
+func StateSlot(era uint64) primitives.Slot {
+	return primitives.Slot(era) * params.BeaconConfig().SlotsPerHistoricalRoot
+}
+
+// EraForSlot returns the era in which the block at the given slot is stored.
+func EraForSlot(slot primitives.Slot) uint64 {
+	return uint64(slot/params.BeaconConfig().SlotsPerHistoricalRoot) + 1
+}
+
+// Filename returns the standard name of an era file, made of the network name, the era number
+// and the first 4 bytes of the historical root of the era, which is the genesis validators
+// root for era 0.
+func Filename(network string, era uint64, historicalRoot [32]byte) string {
+	return fmt.Sprintf("%s-%05d-%x%s", network, era, historicalRoot[:4], FileExtension)
+}
+
+// HistoricalRoot computes the historical root of the era ending at the given state, i.e. the
+// root of the historical batch of its block and state roots, which is also the root of the
+// corresponding historical summary after Capella.
+func HistoricalRoot(st state.ReadOnlyBeaconState) ([32]byte, error) {
+	batch := &ethpb.HistoricalBatch{
+		BlockRoots: st.BlockRoots(),
+		StateRoots: st.StateRoots(),
+	}
+	return batch.HashTreeRoot()
+}
+
+// Writer produces an era file.
+type Writer struct {
+	e2           *e2Writer
+	era          uint64
+	startSlot    primitives.Slot
+	blockOffsets []int64
+	lastSlot     primitives.Slot
+	hasBlocks    bool
+	finalized    bool
+}
+
+// NewWriter writes the version entry of an era file for the given era to w, and returns a
+// Writer for its content.
+func NewWriter(w io.Writer, era uint64) (*Writer, error) {
+	e2 := &e2Writer{w: w}
+	if _, err := e2.write(TypeVersion, nil); err != nil {
+		return nil, err
+	}
+	wr := &Writer{e2: e2, era: era, startSlot: StartSlot(era)}
+	if era > 0 {
+		wr.blockOffsets = make([]int64, params.BeaconConfig().SlotsPerHistoricalRoot)
+	}
+	return wr, nil
+}
+
+// AddBlock appends a block to the era file. Blocks must be added in increasing slot order.
+func (w *Writer) AddBlock(blk interfaces.ReadOnlySignedBeaconBlock) error {
+	if w.finalized {
+		return errors.New("era file is already finalized")
+	}
+	if blk == nil || blk.IsNil() {
+		return errors.New("nil block")
+	}
+	if blk.IsBlinded() {
+		return errors.New("era files require full blocks, got a blinded block")
+	}
+	slot := blk.Block().Slot()
+	if slot < w.startSlot || uint64(slot-w.startSlot) >= uint64(len(w.blockOffsets)) {
+		return errors.Errorf("block slot %d is outside of era %d", slot, w.era)
+	}
+	if w.hasBlocks && slot <= w.lastSlot {
+		return errors.Errorf("block slot %d is not after previous block slot %d", slot, w.lastSlot)
+	}
+	enc, err := blk.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	data, err := compress(enc)
+	if err != nil {
+		return err
+	}
+	off, err := w.e2.write(TypeCompressedSignedBeaconBlock, data)
+	if err != nil {
+		return err
+	}
+	w.blockOffsets[slot-w.startSlot] = off
+	w.lastSlot = slot
+	w.hasBlocks = true
+	return nil
+}
+
+// Finalize writes the era state and the slot indices, completing the era file.
+func (w *Writer) Finalize(st state.ReadOnlyBeaconState) error {
+	if w.finalized {
+		return errors.New("era file is already finalized")
+	}
+	if st == nil || st.IsNil() {
+		return errors.New("nil state")
+	}
+	if st.Slot() != StateSlot(w.era) {
+		return errors.Errorf("state slot %d does not match era %d state slot %d", st.Slot(), w.era, StateSlot(w.era))
+	}
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	data, err := compress(enc)
+	if err != nil {
+		return err
+	}
+	stateOffset, err := w.e2.write(TypeCompressedBeaconState, data)
+	if err != nil {
+		return err
+	}
+	if w.era > 0 {
+		idx := &slotIndex{startSlot: uint64(w.startSlot), offsets: make([]int64, len(w.blockOffsets))}
+		for i, o := range w.blockOffsets {
+			if o != 0 {
+				idx.offsets[i] = o - w.e2.offset
+			}
+		}
+		if _, err := w.e2.write(TypeSlotIndex, idx.marshal()); err != nil {
+			return err
+		}
+	}
+	idx := &slotIndex{startSlot: uint64(st.Slot()), offsets: []int64{stateOffset - w.e2.offset}}
+	if _, err := w.e2.write(TypeSlotIndex, idx.marshal()); err != nil {
+		return err
+	}
+	w.finalized = true
+	return nil
+}
+
+// Reader provides random access to the content of an era file.
+type Reader struct {
+	r          io.ReaderAt
+	closer     io.Closer
+	blockIndex *slotIndex
+	blockStart int64
+	stateIndex *slotIndex
+	stateStart int64
+}
+
+// Open opens the era file at the given path.
+func Open(path string) (*Reader, error) {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	r, err := NewReader(f, info.Size())
+	if err != nil {
+		if closeErr := f.Close(); closeErr != nil {
+			return nil, errors.Wrap(closeErr, err.Error())
+		}
+		return nil, errors.Wrapf(err, "could not read era file %s", path)
+	}
+	r.closer = f
+	return r, nil
+}
+
+// NewReader reads the indices of an era file of the given size.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	version, err := ReadEntry(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if version.Type != TypeVersion {
+		return nil, errors.New("era file does not begin with a version entry")
+	}
+	stateIndex, stateStart, err := readIndexEndingAt(r, size, TypeSlotIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read state index")
+	}
+	if len(stateIndex.offsets) != 1 {
+		return nil, errors.Errorf("state index has %d entries, expected 1", len(stateIndex.offsets))
+	}
+	rd := &Reader{r: r, stateIndex: stateIndex, stateStart: stateStart}
+	if stateIndex.startSlot == 0 {
+		// Era 0 only holds the genesis state.
+		return rd, nil
+	}
+	blockIndex, blockStart, err := readIndexEndingAt(r, stateStart, TypeSlotIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read block index")
+	}
+	rd.blockIndex = blockIndex
+	rd.blockStart = blockStart
+	return rd, nil
+}
+
+// Close closes the underlying file, if the reader was created with Open.
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// Era returns the era number of the file.
+func (r *Reader) Era() uint64 {
+	return r.stateIndex.startSlot / uint64(params.BeaconConfig().SlotsPerHistoricalRoot)
+}
+
+// Contains returns true if the era file covers the block at the given slot.
+func (r *Reader) Contains(slot primitives.Slot) bool {
+	if r.blockIndex == nil {
+		return false
+	}
+	return uint64(slot) >= r.blockIndex.startSlot && uint64(slot)-r.blockIndex.startSlot < uint64(len(r.blockIndex.offsets))
+}
+
+// Block returns the block at the given slot, or nil if the slot is empty.
+func (r *Reader) Block(slot primitives.Slot) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	if !r.Contains(slot) {
+		return nil, errors.Errorf("slot %d is not part of era %d", slot, r.Era())
+	}
+	rel := r.blockIndex.offsets[uint64(slot)-r.blockIndex.startSlot]
+	if rel == 0 {
+		return nil, nil
+	}
+	e, err := ReadEntry(r.r, r.blockStart+rel)
+	if err != nil {
+		return nil, err
+	}
+	if e.Type != TypeCompressedSignedBeaconBlock {
+		return nil, errors.Errorf("expected block entry for slot %d, got type %#x", slot, e.Type)
+	}
+	enc, err := decompress(e.Data)
+	if err != nil {
+		return nil, err
+	}
+	ver, err := forks.NewOrderedSchedule(params.BeaconConfig()).VersionForEpoch(slots.ToEpoch(slot))
+	if err != nil {
+		return nil, err
+	}
+	unmarshaler, err := detect.FromForkVersion(ver)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshaler.UnmarshalBeaconBlock(enc)
+}
+
+// State returns the state stored at the end of the era.
+func (r *Reader) State() (state.BeaconState, error) {
+	e, err := ReadEntry(r.r, r.stateStart+r.stateIndex.offsets[0])
+	if err != nil {
+		return nil, err
+	}
+	if e.Type != TypeCompressedBeaconState {
+		return nil, errors.Errorf("expected state entry, got type %#x", e.Type)
+	}
+	enc, err := decompress(e.Data)
+	if err != nil {
+		return nil, err
+	}
+	unmarshaler, err := detect.FromState(enc)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshaler.UnmarshalBeaconState(enc)
+}
+
+// compress encodes data with the snappy framing format, as required for era entries.
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := snappy.NewBufferedWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(data []byte) ([]byte, error) {
+	return io.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+}
@@ -0,0 +1,215 @@
+package era
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+)
+
+// Era1FileExtension of era1 files, which hold the history of the execution chain before the merge.
+const Era1FileExtension = ".era1"
+
+// MaxEra1Blocks is the maximum number of blocks held by an era1 file, which is also the limit of
+// the list of header records whose root is the accumulator of the file.
+const MaxEra1Blocks = 8192
+
+// Era1Filename returns the standard name of an era1 file, made of the network name, the era
+// number and the first 4 bytes of the accumulator root of the file.
+func Era1Filename(network string, era uint64, accumulator [32]byte) string {
+	return fmt.Sprintf("%s-%05d-%x%s", network, era, accumulator[:4], Era1FileExtension)
+}
+
+// ExecutionBlock is a pre-merge execution block read from an era1 file. Its header, body and
+// receipts are RLP encoded.
+type ExecutionBlock struct {
+	Number          uint64
+	Header          []byte
+	Body            []byte
+	Receipts        []byte
+	TotalDifficulty *big.Int
+}
+
+// Hash returns the hash of the block, which is the keccak256 hash of its encoded header.
+func (b *ExecutionBlock) Hash() common.Hash {
+	return crypto.Keccak256Hash(b.Header)
+}
+
+// Era1Reader provides random access to the content of an era1 file.
+type Era1Reader struct {
+	r          io.ReaderAt
+	closer     io.Closer
+	index      *slotIndex
+	indexStart int64
+}
+
+// OpenEra1 opens the era1 file at the given path.
+func OpenEra1(path string) (*Era1Reader, error) {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	r, err := NewEra1Reader(f, info.Size())
+	if err != nil {
+		if closeErr := f.Close(); closeErr != nil {
+			return nil, errors.Wrap(closeErr, err.Error())
+		}
+		return nil, errors.Wrapf(err, "could not read era1 file %s", path)
+	}
+	r.closer = f
+	return r, nil
+}
+
+// NewEra1Reader reads the block index of an era1 file of the given size.
+func NewEra1Reader(r io.ReaderAt, size int64) (*Era1Reader, error) {
+	version, err := ReadEntry(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if version.Type != TypeVersion {
+		return nil, errors.New("era1 file does not begin with a version entry")
+	}
+	index, indexStart, err := readIndexEndingAt(r, size, TypeBlockIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read block index")
+	}
+	if len(index.offsets) == 0 || len(index.offsets) > MaxEra1Blocks {
+		return nil, errors.Errorf("block index has %d entries, expected between 1 and %d", len(index.offsets), MaxEra1Blocks)
+	}
+	return &Era1Reader{r: r, index: index, indexStart: indexStart}, nil
+}
+
+// Close closes the underlying file, if the reader was created with OpenEra1.
+func (r *Era1Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// StartNumber returns the number of the first block of the file.
+func (r *Era1Reader) StartNumber() uint64 {
+	return r.index.startSlot
+}
+
+// Count returns the number of blocks in the file.
+func (r *Era1Reader) Count() uint64 {
+	return uint64(len(r.index.offsets))
+}
+
+// Block returns the block with the given number.
+func (r *Era1Reader) Block(number uint64) (*ExecutionBlock, error) {
+	if number < r.StartNumber() || number-r.StartNumber() >= r.Count() {
+		return nil, errors.Errorf("block %d is not part of the era1 file starting at block %d", number, r.StartNumber())
+	}
+	off := r.indexStart + r.index.offsets[number-r.StartNumber()]
+	b := &ExecutionBlock{Number: number}
+	// The header, body, receipts and total difficulty of a block are stored in consecutive entries.
+	for _, part := range []struct {
+		t   EntryType
+		dst *[]byte
+	}{
+		{t: TypeCompressedHeader, dst: &b.Header},
+		{t: TypeCompressedBody, dst: &b.Body},
+		{t: TypeCompressedReceipts, dst: &b.Receipts},
+	} {
+		e, err := ReadEntry(r.r, off)
+		if err != nil {
+			return nil, err
+		}
+		if e.Type != part.t {
+			return nil, errors.Errorf("expected entry of type %#x for block %d, got type %#x", part.t, number, e.Type)
+		}
+		if *part.dst, err = decompress(e.Data); err != nil {
+			return nil, err
+		}
+		off += int64(headerSize + len(e.Data))
+	}
+	e, err := ReadEntry(r.r, off)
+	if err != nil {
+		return nil, err
+	}
+	if e.Type != TypeTotalDifficulty || len(e.Data) != 32 {
+		return nil, errors.Errorf("invalid total difficulty entry for block %d", number)
+	}
+	b.TotalDifficulty = new(big.Int).SetBytes(bytesutil.ReverseByteOrder(e.Data))
+	return b, nil
+}
+
+// Accumulator returns the accumulator root stored in the file.
+func (r *Era1Reader) Accumulator() ([32]byte, error) {
+	// The accumulator entry immediately precedes the block index.
+	e, err := ReadEntry(r.r, r.indexStart-headerSize-32)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if e.Type != TypeAccumulator || len(e.Data) != 32 {
+		return [32]byte{}, errors.New("invalid accumulator entry")
+	}
+	return bytesutil.ToBytes32(e.Data), nil
+}
+
+// Verify checks that the blocks of the file form a chain and match the accumulator root
+// stored in the file.
+func (r *Era1Reader) Verify() error {
+	records := make([][32]byte, 0, r.Count())
+	var parent common.Hash
+	for n := r.StartNumber(); n < r.StartNumber()+r.Count(); n++ {
+		b, err := r.Block(n)
+		if err != nil {
+			return err
+		}
+		h := new(types.Header)
+		if err := rlp.DecodeBytes(b.Header, h); err != nil {
+			return errors.Wrapf(err, "could not decode header of block %d", n)
+		}
+		if h.Number == nil || h.Number.Uint64() != n {
+			return errors.Errorf("header of block %d has number %v", n, h.Number)
+		}
+		if n > r.StartNumber() && h.ParentHash != parent {
+			return errors.Errorf("parent hash %#x of block %d does not match block %d hash %#x", h.ParentHash, n, n-1, parent)
+		}
+		parent = b.Hash()
+		records = append(records, headerRecordRoot(parent, b.TotalDifficulty))
+	}
+	want, err := r.Accumulator()
+	if err != nil {
+		return err
+	}
+	if got := accumulatorRoot(records); got != want {
+		return errors.Errorf("accumulator root %#x does not match the stored root %#x", got, want)
+	}
+	return nil
+}
+
+// headerRecordRoot is the root of the header record container of a block, made of its hash and
+// its total difficulty as a little endian uint256.
+func headerRecordRoot(blockHash common.Hash, td *big.Int) [32]byte {
+	var chunks [64]byte
+	copy(chunks[:32], blockHash[:])
+	td.FillBytes(chunks[32:])
+	copy(chunks[32:], bytesutil.ReverseByteOrder(chunks[32:]))
+	return hash.Hash(chunks[:])
+}
+
+// accumulatorRoot is the root of the list of the header records of an era1 file.
+func accumulatorRoot(records [][32]byte) [32]byte {
+	root := ssz.MerkleizeVector(records, MaxEra1Blocks)
+	length := make([]byte, 32)
+	binary.LittleEndian.PutUint64(length, uint64(len(records)))
+	return ssz.MixInLength(root, length)
+}
@@ -0,0 +1,100 @@
+package era
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+// testEra1File builds an era1 file of count chained blocks starting at the given number, and
+// returns it along with the hashes of its blocks.
+func testEra1File(t *testing.T, start uint64, count int, accumulator func([][32]byte) [32]byte) ([]byte, []common.Hash) {
+	var buf bytes.Buffer
+	w := &e2Writer{w: &buf}
+	_, err := w.write(TypeVersion, nil)
+	require.NoError(t, err)
+	offsets := make([]int64, count)
+	hashes := make([]common.Hash, count)
+	records := make([][32]byte, count)
+	var parent common.Hash
+	for i := 0; i < count; i++ {
+		h := &types.Header{
+			ParentHash: parent,
+			Number:     new(big.Int).SetUint64(start + uint64(i)),
+			Difficulty: big.NewInt(131072),
+			GasLimit:   5000,
+		}
+		enc, err := rlp.EncodeToBytes(h)
+		require.NoError(t, err)
+		body, err := rlp.EncodeToBytes(&types.Body{})
+		require.NoError(t, err)
+		receipts, err := rlp.EncodeToBytes([]*types.ReceiptForStorage{})
+		require.NoError(t, err)
+		for j, data := range [][]byte{enc, body, receipts} {
+			c, err := compress(data)
+			require.NoError(t, err)
+			off, err := w.write([]EntryType{TypeCompressedHeader, TypeCompressedBody, TypeCompressedReceipts}[j], c)
+			require.NoError(t, err)
+			if j == 0 {
+				offsets[i] = off
+			}
+		}
+		td := big.NewInt(int64(131072 * (i + 1)))
+		_, err = w.write(TypeTotalDifficulty, bytesutil.ReverseByteOrder(td.FillBytes(make([]byte, 32))))
+		require.NoError(t, err)
+		parent = h.Hash()
+		hashes[i] = parent
+		records[i] = headerRecordRoot(parent, td)
+	}
+	root := accumulator(records)
+	_, err = w.write(TypeAccumulator, root[:])
+	require.NoError(t, err)
+	idx := &slotIndex{startSlot: start, offsets: make([]int64, count)}
+	for i, o := range offsets {
+		idx.offsets[i] = o - w.offset
+	}
+	_, err = w.write(TypeBlockIndex, idx.marshal())
+	require.NoError(t, err)
+	return buf.Bytes(), hashes
+}
+
+func TestEra1_Read(t *testing.T) {
+	enc, hashes := testEra1File(t, 8192, 3, accumulatorRoot)
+	r, err := NewEra1Reader(bytes.NewReader(enc), int64(len(enc)))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8192), r.StartNumber())
+	assert.Equal(t, uint64(3), r.Count())
+
+	b, err := r.Block(8193)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8193), b.Number)
+	assert.Equal(t, hashes[1], b.Hash())
+	assert.Equal(t, 0, b.TotalDifficulty.Cmp(big.NewInt(2*131072)))
+	h := new(types.Header)
+	require.NoError(t, rlp.DecodeBytes(b.Header, h))
+	assert.Equal(t, hashes[0], h.ParentHash)
+
+	_, err = r.Block(8195)
+	require.ErrorContains(t, "is not part of the era1 file", err)
+	require.NoError(t, r.Verify())
+}
+
+func TestEra1_VerifyAccumulatorMismatch(t *testing.T) {
+	enc, _ := testEra1File(t, 0, 2, func([][32]byte) [32]byte { return [32]byte{'a'} })
+	r, err := NewEra1Reader(bytes.NewReader(enc), int64(len(enc)))
+	require.NoError(t, err)
+	require.ErrorContains(t, "does not match the stored root", r.Verify())
+}
+
+func TestEra1_NotAnEra1File(t *testing.T) {
+	enc, _ := testEraFile(t, 1)
+	_, err := NewEra1Reader(bytes.NewReader(enc), int64(len(enc)))
+	require.ErrorContains(t, "could not read block index", err)
+}
@@ -0,0 +1,145 @@
+package era
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func testEraFile(t *testing.T, era uint64, blockSlots ...primitives.Slot) ([]byte, []interfaces.ReadOnlySignedBeaconBlock) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, era)
+	require.NoError(t, err)
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(blockSlots))
+	for _, slot := range blockSlots {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		sb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, w.AddBlock(sb))
+		blks = append(blks, sb)
+	}
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(StateSlot(era)))
+	require.NoError(t, w.Finalize(st))
+	return buf.Bytes(), blks
+}
+
+func TestEra_WriteRead(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	start := StartSlot(1)
+	enc, blks := testEraFile(t, 1, start, start+3, start+100)
+
+	r, err := NewReader(bytes.NewReader(enc), int64(len(enc)))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), r.Era())
+	assert.Equal(t, true, r.Contains(start))
+	assert.Equal(t, false, r.Contains(StateSlot(1)))
+
+	for _, want := range blks {
+		got, err := r.Block(want.Block().Slot())
+		require.NoError(t, err)
+		wantRoot, err := want.Block().HashTreeRoot()
+		require.NoError(t, err)
+		gotRoot, err := got.Block().HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, wantRoot, gotRoot)
+	}
+	empty, err := r.Block(start + 1)
+	require.NoError(t, err)
+	assert.Equal(t, nil, empty)
+
+	st, err := r.State()
+	require.NoError(t, err)
+	assert.Equal(t, StateSlot(1), st.Slot())
+}
+
+func TestEra_GenesisEra(t *testing.T) {
+	enc, _ := testEraFile(t, 0)
+	r, err := NewReader(bytes.NewReader(enc), int64(len(enc)))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), r.Era())
+	assert.Equal(t, false, r.Contains(0))
+	st, err := r.State()
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(0), st.Slot())
+}
+
+func TestWriter_AddBlock(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, 2)
+	require.NoError(t, err)
+
+	b := util.NewBeaconBlock()
+	b.Block.Slot = StartSlot(1)
+	sb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	require.ErrorContains(t, "outside of era 2", w.AddBlock(sb))
+
+	b.Block.Slot = StartSlot(2) + 5
+	sb, err = blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	require.NoError(t, w.AddBlock(sb))
+	require.ErrorContains(t, "is not after previous block slot", w.AddBlock(sb))
+}
+
+func TestStore_Blocks(t *testing.T) {
+	dir := t.TempDir()
+	start := StartSlot(1)
+	enc, _ := testEraFile(t, 1, start+1, start+2, start+4)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, Filename("mainnet", 1, [32]byte{0xab})), enc, 0600))
+
+	s, err := NewStore(dir)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+	assert.Equal(t, true, s.HasBlock(start))
+	assert.Equal(t, false, s.HasBlock(StateSlot(1)))
+	blks, err := s.Blocks(start, start+3)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(blks))
+	assert.Equal(t, start+1, blks[0].Block().Slot())
+	assert.Equal(t, start+2, blks[1].Block().Slot())
+
+	blks, err = s.Blocks(StateSlot(1), StateSlot(1)+1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(blks))
+}
+
+func TestNewStore_MultipleDirectories(t *testing.T) {
+	dirs := []string{t.TempDir(), t.TempDir()}
+	enc, _ := testEraFile(t, 1, StartSlot(1))
+	require.NoError(t, os.WriteFile(filepath.Join(dirs[0], Filename("mainnet", 1, [32]byte{0xab})), enc, 0600))
+	enc, _ = testEraFile(t, 2, StartSlot(2))
+	require.NoError(t, os.WriteFile(filepath.Join(dirs[1], Filename("mainnet", 2, [32]byte{0xcd})), enc, 0600))
+
+	s, err := NewStore(dirs...)
+	require.NoError(t, err)
+	assert.Equal(t, true, s.HasBlock(StartSlot(1)))
+	assert.Equal(t, true, s.HasBlock(StartSlot(2)))
+	assert.Equal(t, false, s.HasBlock(StartSlot(3)))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dirs[1], Filename("mainnet", 1, [32]byte{0xef})), enc, 0600))
+	_, err = NewStore(dirs...)
+	require.ErrorContains(t, "duplicate files", err)
+}
+
+func TestFilename(t *testing.T) {
+	assert.Equal(t, "mainnet-00012-abcdef01.era", Filename("mainnet", 12, [32]byte{0xab, 0xcd, 0xef, 0x01, 0x02}))
+	era, err := parseEra("mainnet-00012-abcdef01.era")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12), era)
+	_, err = parseEra("mainnet.era")
+	require.ErrorContains(t, "invalid era file name", err)
+}
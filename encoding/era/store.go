@@ -0,0 +1,125 @@
+package era
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+// Store gives access to the blocks of one or more directories of era files, keeping readers of
+// the files it has already accessed open.
+type Store struct {
+	paths   map[uint64]string
+	lock    sync.Mutex
+	readers map[uint64]*Reader
+}
+
+// NewStore indexes the era files found in the given directories by their era number.
+func NewStore(dirs ...string) (*Store, error) {
+	s := &Store{
+		paths:   make(map[uint64]string),
+		readers: make(map[uint64]*Reader),
+	}
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read era directory %s", dir)
+		}
+		for _, e := range entries {
+			if e.IsDir() || filepath.Ext(e.Name()) != FileExtension {
+				continue
+			}
+			era, err := parseEra(e.Name())
+			if err != nil {
+				return nil, err
+			}
+			p := filepath.Join(dir, e.Name())
+			if existing, ok := s.paths[era]; ok {
+				return nil, errors.Errorf("duplicate files %s and %s for era %d", existing, p, era)
+			}
+			s.paths[era] = p
+		}
+	}
+	return s, nil
+}
+
+// parseEra extracts the era number from a file named <network>-<era>-<root>.era.
+func parseEra(name string) (uint64, error) {
+	parts := strings.Split(strings.TrimSuffix(name, FileExtension), "-")
+	if len(parts) < 3 {
+		return 0, errors.Errorf("invalid era file name %s", name)
+	}
+	era, err := strconv.ParseUint(parts[len(parts)-2], 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid era number in file name %s", name)
+	}
+	return era, nil
+}
+
+// HasBlock returns true if the store has the era file which covers the given slot.
+func (s *Store) HasBlock(slot primitives.Slot) bool {
+	_, ok := s.paths[EraForSlot(slot)]
+	return ok
+}
+
+// Block returns the block at the given slot, or nil if the slot is empty or not covered by the store.
+func (s *Store) Block(slot primitives.Slot) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	r, err := s.reader(EraForSlot(slot))
+	if err != nil || r == nil {
+		return nil, err
+	}
+	return r.Block(slot)
+}
+
+// Blocks returns the blocks stored for the slots in the inclusive range [start, end], in increasing slot order.
+func (s *Store) Blocks(start, end primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+	for slot := start; slot <= end; slot++ {
+		b, err := s.Block(slot)
+		if err != nil {
+			return nil, err
+		}
+		if b != nil {
+			blks = append(blks, b)
+		}
+	}
+	return blks, nil
+}
+
+func (s *Store) reader(era uint64) (*Reader, error) {
+	p, ok := s.paths[era]
+	if !ok {
+		return nil, nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if r, ok := s.readers[era]; ok {
+		return r, nil
+	}
+	r, err := Open(p)
+	if err != nil {
+		return nil, err
+	}
+	s.readers[era] = r
+	return r, nil
+}
+
+// Close closes all the era files opened by the store.
+func (s *Store) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var err error
+	for era, r := range s.readers {
+		if closeErr := r.Close(); closeErr != nil {
+			err = closeErr
+		}
+		delete(s.readers, era)
+	}
+	return err
+}
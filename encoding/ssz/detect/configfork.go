@@ -158,6 +158,21 @@ func slotFromBlock(marshaled []byte) (primitives.Slot, error) {
 	return primitives.Slot(slot), nil
 }
 
+// FromBlock uses the slot of a marshaled ReadOnlySignedBeaconBlock and the fork schedule of the active beacon config
+// to obtain the VersionedUnmarshaler of the block. Unlike a BeaconState, a block does not identify the network it
+// belongs to, so the right chain config must already be loaded.
+func FromBlock(marshaled []byte) (*VersionedUnmarshaler, error) {
+	slot, err := slotFromBlock(marshaled)
+	if err != nil {
+		return nil, err
+	}
+	cv, err := forks.NewOrderedSchedule(params.BeaconConfig()).VersionForEpoch(slots.ToEpoch(slot))
+	if err != nil {
+		return nil, err
+	}
+	return FromForkVersion(cv)
+}
+
 var errBlockForkMismatch = errors.New("fork or config detected in unmarshaler is different than block")
 
 // UnmarshalBeaconBlock uses internal knowledge in the VersionedUnmarshaler to pick the right concrete ReadOnlySignedBeaconBlock type,
@@ -338,6 +338,39 @@ func TestUnmarshalBlock(t *testing.T) {
 	}
 }
 
+func TestFromBlock(t *testing.T) {
+	undo, err := hackDenebMaxuint()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, undo())
+	}()
+	altairS, err := slots.EpochStart(params.BeaconConfig().AltairForkEpoch)
+	require.NoError(t, err)
+	capellaS, err := slots.EpochStart(params.BeaconConfig().CapellaForkEpoch)
+	require.NoError(t, err)
+	cases := []struct {
+		name string
+		b    func(*testing.T, primitives.Slot) interfaces.ReadOnlySignedBeaconBlock
+		slot primitives.Slot
+		fork int
+	}{
+		{name: "genesis", b: signedTestBlockGenesis, fork: version.Phase0},
+		{name: "altair", b: signedTestBlockAltair, slot: altairS, fork: version.Altair},
+		{name: "capella", b: signedTestBlockCapella, slot: capellaS + 1, fork: version.Capella},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			marshaled, err := c.b(t, c.slot).MarshalSSZ()
+			require.NoError(t, err)
+			cf, err := FromBlock(marshaled)
+			require.NoError(t, err)
+			require.Equal(t, c.fork, cf.Fork)
+			_, err = cf.UnmarshalBeaconBlock(marshaled)
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestUnmarshalBlindedBlock(t *testing.T) {
 	undo, err := hackDenebMaxuint()
 	require.NoError(t, err)
@@ -0,0 +1,554 @@
+package ssz
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
+)
+
+// ErrInvalidGeneralizedIndex is returned when a generalized index does not designate a node of an SSZ object.
+var ErrInvalidGeneralizedIndex = errors.New("invalid generalized index")
+
+// Proof is a Merkle proof of the node at a generalized index of an SSZ object, as defined in
+// https://github.com/ethereum/consensus-specs/blob/dev/ssz/merkle-proofs.md.
+type Proof struct {
+	GeneralizedIndex uint64
+	// Leaf is the root of the proven node.
+	Leaf [32]byte
+	// Branch holds the sibling of each node on the path from the leaf to the root, starting with the sibling of the leaf.
+	Branch [][32]byte
+}
+
+// Verify checks the proof against the root of the SSZ object.
+func (p *Proof) Verify(root [32]byte) bool {
+	if p.GeneralizedIndex == 0 || len(p.Branch) != bits.Len64(p.GeneralizedIndex)-1 {
+		return false
+	}
+	node := p.Leaf
+	for i, sibling := range p.Branch {
+		if (p.GeneralizedIndex>>uint(i))&1 == 1 {
+			node = hash.Hash(append(sibling[:], node[:]...))
+		} else {
+			node = hash.Hash(append(node[:], sibling[:]...))
+		}
+	}
+	return node == root
+}
+
+// ConcatGeneralizedIndices returns the generalized index of a path through nested trees, given the generalized
+// index of the root of each tree in its parent, starting with the outermost tree.
+func ConcatGeneralizedIndices(indices ...uint64) uint64 {
+	o := uint64(1)
+	for _, i := range indices {
+		depth := uint(bits.Len64(i) - 1)
+		o = o<<depth | (i ^ 1<<depth)
+	}
+	return o
+}
+
+// ConcatProofs joins proofs through nested trees, starting with the outermost tree, whose leaves are the roots of
+// the next trees. The leaf of the joined proof is the leaf of the innermost proof.
+func ConcatProofs(proofs ...*Proof) *Proof {
+	joined := &Proof{GeneralizedIndex: 1}
+	indices := make([]uint64, len(proofs))
+	for i := len(proofs) - 1; i >= 0; i-- {
+		indices[i] = proofs[i].GeneralizedIndex
+		joined.Branch = append(joined.Branch, proofs[i].Branch...)
+	}
+	if len(proofs) > 0 {
+		joined.GeneralizedIndex = ConcatGeneralizedIndices(indices...)
+		joined.Leaf = proofs[len(proofs)-1].Leaf
+	}
+	return joined
+}
+
+// ProveGeneralizedIndex returns a Merkle proof of the node at the generalized index of an SSZ object. The object
+// must be a generated SSZ container, such as a block or a state.
+func ProveGeneralizedIndex(obj interface{}, gindex uint64) (*Proof, error) {
+	t, err := treeBuilder{}.objectTree(obj)
+	if err != nil {
+		return nil, err
+	}
+	return t.prove(gindex)
+}
+
+// ProveGeneralizedIndexWithFieldRoots is like ProveGeneralizedIndex, for a container whose field roots are already
+// known, which saves hashing the fields which are not on the path to the proven node. The Merkle tree layers of list
+// and vector fields, from their chunks up to their root before the length is mixed in, may be given as well, such as
+// the field tries of the beacon state, so that a proof going through such a field does not hash its elements.
+func ProveGeneralizedIndexWithFieldRoots(
+	obj interface{}, fieldRoots [][32]byte, fieldLayers map[int][][]*[32]byte, gindex uint64,
+) (*Proof, error) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%T is not an SSZ container", obj)
+	}
+	t, err := treeBuilder{}.containerTree(v, fieldRoots, fieldLayers)
+	if err != nil {
+		return nil, err
+	}
+	return t.prove(gindex)
+}
+
+// GeneralizedIndex returns the generalized index of the node of an SSZ object at a path of field names, as named
+// in the consensus specs, and of list or vector indices, separated by dots. For instance,
+// validators.12.effective_balance or body.execution_payload.transactions.__len__ for the length of a list.
+// Elements of lists and vectors of basic values share their chunk, so the path ends at the chunk of the element.
+func GeneralizedIndex(obj interface{}, path string) (uint64, error) {
+	t, err := treeBuilder{shapeOnly: true}.objectTree(obj)
+	if err != nil {
+		return 0, err
+	}
+	gindex := uint64(1)
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		if t == nil {
+			return 0, fmt.Errorf("%s is a basic value", strings.Join(segments[:i], "."))
+		}
+		if bits.Len64(gindex)+int(t.depth)+1 > 64 {
+			return 0, fmt.Errorf("path %s is too deep", path)
+		}
+		switch {
+		case t.names != nil:
+			idx := -1
+			for j, name := range t.names {
+				if name == segment {
+					idx = j
+					break
+				}
+			}
+			if idx < 0 {
+				return 0, fmt.Errorf("unknown field %s", segment)
+			}
+			gindex = gindex<<t.depth | uint64(idx)
+			if t, err = t.child(idx); err != nil {
+				return 0, err
+			}
+		case segment == "__len__":
+			if t.mixin == nil {
+				return 0, fmt.Errorf("%s is not a list", strings.Join(segments[:i], "."))
+			}
+			if i != len(segments)-1 {
+				return 0, errors.New("__len__ must end the path")
+			}
+			return gindex<<1 | 1, nil
+		default:
+			idx, err := strconv.ParseUint(segment, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid index %s", segment)
+			}
+			if idx >= t.length {
+				return 0, fmt.Errorf("index %d is out of range, length is %d", idx, t.length)
+			}
+			if t.mixin != nil {
+				gindex <<= 1
+			}
+			if t.elemBits > 0 {
+				if i != len(segments)-1 {
+					return 0, fmt.Errorf("%s is a basic value", strings.Join(segments[:i+1], "."))
+				}
+				return gindex<<t.depth | idx*t.elemBits/(8*bytesPerChunk), nil
+			}
+			gindex = gindex<<t.depth | idx
+			if t, err = t.child(int(idx)); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return gindex, nil
+}
+
+// merkleTree is the Merkle tree of an SSZ value. The trees of composite elements or fields are only built when
+// a proof or a path goes through them.
+type merkleTree struct {
+	// layers hold the populated nodes of each level of the tree, from the chunks up to the root.
+	layers [][][32]byte
+	// refLayers replace layers for trees taken from the field tries of the beacon state.
+	refLayers [][]*[32]byte
+	depth     uint
+	// mixin is the length chunk mixed in the root of lists.
+	mixin *[32]byte
+	// length is the number of elements of lists and vectors.
+	length uint64
+	// elemBits is the size in bits of the elements of lists and vectors of basic values, which are packed in chunks.
+	elemBits uint64
+	// names are the spec names of the fields of containers.
+	names []string
+	// child returns the tree of a composite element or field, or nil for basic values.
+	child func(i int) (*merkleTree, error)
+}
+
+// treeBuilder builds the Merkle trees of SSZ values. Trees built for their shape only, to compute generalized
+// indices, are not hashed. Trees built with layers take their nodes from the layers rather than hashing their
+// chunks, while the trees of their elements are still built when needed.
+type treeBuilder struct {
+	shapeOnly bool
+	layers    [][]*[32]byte
+}
+
+// elements returns the builder of the trees of the elements or fields of a value.
+func (tb treeBuilder) elements() treeBuilder {
+	return treeBuilder{shapeOnly: tb.shapeOnly}
+}
+
+func (tb treeBuilder) newMerkleTree(chunks [][32]byte, limit uint64) (*merkleTree, error) {
+	if uint64(len(chunks)) > limit {
+		return nil, fmt.Errorf("%d chunks exceed the limit of %d", len(chunks), limit)
+	}
+	depth := uint(0)
+	if limit > 1 {
+		depth = uint(bits.Len64(limit - 1))
+	}
+	if tb.layers != nil {
+		if uint(len(tb.layers)) != depth+1 {
+			return nil, fmt.Errorf("%d layers for a tree of depth %d", len(tb.layers), depth)
+		}
+		return &merkleTree{refLayers: tb.layers, depth: depth, child: noChild}, nil
+	}
+	layers := make([][][32]byte, depth+1)
+	layers[0] = chunks
+	for l := uint(0); l < depth && !tb.shapeOnly; l++ {
+		below := layers[l]
+		layer := make([][32]byte, (len(below)+1)/2)
+		for j := range layer {
+			right := trie.ZeroHashes[l]
+			if 2*j+1 < len(below) {
+				right = below[2*j+1]
+			}
+			layer[j] = hash.Hash(append(below[2*j][:], right[:]...))
+		}
+		layers[l+1] = layer
+	}
+	return &merkleTree{layers: layers, depth: depth, child: noChild}, nil
+}
+
+func noChild(int) (*merkleTree, error) {
+	return nil, nil
+}
+
+// node returns the node at the level, counted from the chunks, and the position of the tree.
+func (t *merkleTree) node(level uint, idx uint64) [32]byte {
+	if t.refLayers != nil {
+		if idx < uint64(len(t.refLayers[level])) && t.refLayers[level][idx] != nil {
+			return *t.refLayers[level][idx]
+		}
+		return trie.ZeroHashes[level]
+	}
+	if idx < uint64(len(t.layers[level])) {
+		return t.layers[level][idx]
+	}
+	return trie.ZeroHashes[level]
+}
+
+// chunkCount returns the number of populated chunks of the tree.
+func (t *merkleTree) chunkCount() uint64 {
+	if t.refLayers != nil {
+		return uint64(len(t.refLayers[0]))
+	}
+	return uint64(len(t.layers[0]))
+}
+
+func (t *merkleTree) root() [32]byte {
+	r := t.node(t.depth, 0)
+	if t.mixin != nil {
+		return hash.Hash(append(r[:], t.mixin[:]...))
+	}
+	return r
+}
+
+func (t *merkleTree) prove(gindex uint64) (*Proof, error) {
+	if gindex == 0 {
+		return nil, errors.Wrap(ErrInvalidGeneralizedIndex, "generalized index must be positive")
+	}
+	proof := &Proof{GeneralizedIndex: gindex}
+	// The path to the node is given by the bits of the generalized index following its leading bit.
+	remaining := uint(bits.Len64(gindex) - 1)
+	bit := func() uint64 {
+		remaining--
+		return (gindex >> remaining) & 1
+	}
+	var branch [][32]byte
+	for {
+		if remaining == 0 {
+			proof.Leaf = t.root()
+			break
+		}
+		if t.mixin != nil {
+			if bit() == 1 {
+				if remaining > 0 {
+					return nil, errors.Wrapf(ErrInvalidGeneralizedIndex, "generalized index %d goes below the length of a list", gindex)
+				}
+				branch = append(branch, t.node(t.depth, 0))
+				proof.Leaf = *t.mixin
+				break
+			}
+			branch = append(branch, *t.mixin)
+		}
+		level, idx := t.depth, uint64(0)
+		for level > 0 && remaining > 0 {
+			b := bit()
+			level--
+			branch = append(branch, t.node(level, 2*idx+(1-b)))
+			idx = 2*idx + b
+		}
+		if remaining == 0 {
+			proof.Leaf = t.node(level, idx)
+			break
+		}
+		if idx >= t.chunkCount() {
+			return nil, errors.Wrapf(ErrInvalidGeneralizedIndex, "generalized index %d goes below an empty chunk", gindex)
+		}
+		child, err := t.child(int(idx))
+		if err != nil {
+			return nil, err
+		}
+		if child == nil {
+			return nil, errors.Wrapf(ErrInvalidGeneralizedIndex, "generalized index %d goes below a basic value", gindex)
+		}
+		if child.root() != t.node(0, idx) {
+			return nil, errors.New("could not reproduce the root of a composite value")
+		}
+		t = child
+	}
+	proof.Branch = make([][32]byte, len(branch))
+	for i := range branch {
+		proof.Branch[i] = branch[len(branch)-1-i]
+	}
+	return proof, nil
+}
+
+var bitlistType = reflect.TypeOf(bitfield.Bitlist{})
+
+type hashRoot interface {
+	HashTreeRoot() ([32]byte, error)
+}
+
+func (tb treeBuilder) objectTree(obj interface{}) (*merkleTree, error) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%T is not an SSZ container", obj)
+	}
+	return tb.containerTree(v, nil, nil)
+}
+
+// containerTree returns the tree of a pointer to a generated SSZ container, whose fields are the exported fields
+// carrying a protobuf tag. The field roots are computed unless given, and the trees of the fields whose layers
+// are given are taken from their layers.
+func (tb treeBuilder) containerTree(v reflect.Value, fieldRoots [][32]byte, fieldLayers map[int][][]*[32]byte) (*merkleTree, error) {
+	if v.IsNil() {
+		v = reflect.New(v.Type().Elem())
+	}
+	s := v.Elem()
+	var fields []reflect.StructField
+	var values []reflect.Value
+	var names []string
+	for i := 0; i < s.NumField(); i++ {
+		f := s.Type().Field(i)
+		if f.PkgPath != "" || f.Tag.Get("protobuf") == "" {
+			continue
+		}
+		fields = append(fields, f)
+		values = append(values, s.Field(i))
+		name := f.Tag.Get("spec-name")
+		if name == "" {
+			name = strings.Split(f.Tag.Get("json"), ",")[0]
+		}
+		names = append(names, name)
+	}
+	child := func(i int) (*merkleTree, error) {
+		switch k := values[i].Kind(); {
+		case k == reflect.Bool, k >= reflect.Uint8 && k <= reflect.Uint64:
+			return nil, nil
+		}
+		if layers, ok := fieldLayers[i]; ok {
+			return treeBuilder{shapeOnly: tb.shapeOnly, layers: layers}.valueTree(values[i], fields[i].Tag)
+		}
+		return tb.elements().valueTree(values[i], fields[i].Tag)
+	}
+	chunks := fieldRoots
+	if chunks == nil && tb.shapeOnly {
+		chunks = make([][32]byte, len(fields))
+	}
+	if chunks == nil {
+		chunks = make([][32]byte, len(fields))
+		for i, f := range fields {
+			r, err := tb.valueRoot(values[i], strings.Split(f.Tag.Get("ssz-size"), ","), strings.Split(f.Tag.Get("ssz-max"), ","))
+			if err != nil {
+				return nil, errors.Wrapf(err, "field %s", names[i])
+			}
+			chunks[i] = r
+		}
+	}
+	if len(chunks) != len(fields) {
+		return nil, fmt.Errorf("%d field roots for a container of %d fields", len(chunks), len(fields))
+	}
+	t, err := tb.newMerkleTree(chunks, uint64(len(fields)))
+	if err != nil {
+		return nil, err
+	}
+	t.names = names
+	t.child = func(i int) (*merkleTree, error) {
+		c, err := child(i)
+		if err != nil {
+			return nil, errors.Wrapf(err, "field %s", names[i])
+		}
+		return c, nil
+	}
+	return t, nil
+}
+
+// valueTree returns the tree of a field of a container, described by its ssz-size and ssz-max tags.
+func (tb treeBuilder) valueTree(v reflect.Value, tag reflect.StructTag) (*merkleTree, error) {
+	return tb.typedTree(v, strings.Split(tag.Get("ssz-size"), ","), strings.Split(tag.Get("ssz-max"), ","))
+}
+
+func (tb treeBuilder) typedTree(v reflect.Value, sizes, maxes []string) (*merkleTree, error) {
+	size, max := dimension(sizes), dimension(maxes)
+	switch {
+	case v.Type() == bitlistType:
+		bl := bitfield.Bitlist(v.Bytes())
+		if max == 0 {
+			return nil, errors.New("bitlist without a maximum size")
+		}
+		t, err := tb.newMerkleTree(packBytes(bl.Bytes()), (max+255)/256)
+		if err != nil {
+			return nil, err
+		}
+		t.mixin = lengthChunk(bl.Len())
+		t.length = bl.Len()
+		t.elemBits = 1
+		return t, nil
+	case v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Struct:
+		return tb.containerTree(v, nil, nil)
+	case v.Kind() == reflect.Bool:
+		var c [32]byte
+		if v.Bool() {
+			c[0] = 1
+		}
+		return tb.newMerkleTree([][32]byte{c}, 1)
+	case v.Kind() >= reflect.Uint8 && v.Kind() <= reflect.Uint64:
+		var c [32]byte
+		binary.LittleEndian.PutUint64(c[:8], v.Uint())
+		return tb.newMerkleTree([][32]byte{c}, 1)
+	case v.Kind() != reflect.Slice:
+		return nil, fmt.Errorf("unsupported SSZ type %s", v.Type())
+	}
+
+	isList := size == 0
+	if isList && max == 0 {
+		return nil, fmt.Errorf("%s without a size or a maximum size", v.Type())
+	}
+	n := uint64(v.Len())
+	if !isList && n == 0 {
+		n = size
+	}
+	elem := v.Type().Elem()
+	var t *merkleTree
+	var err error
+	switch {
+	case elem.Kind() == reflect.Uint8:
+		limit := (n + 31) / 32
+		if isList {
+			limit = (max + 31) / 32
+		}
+		t, err = tb.newMerkleTree(packBytes(v.Bytes()), limit)
+		if err != nil {
+			return nil, err
+		}
+		t.elemBits = 8
+	case elem.Kind() == reflect.Uint64:
+		b := make([]byte, 8*v.Len())
+		for i := 0; i < v.Len(); i++ {
+			binary.LittleEndian.PutUint64(b[8*i:], v.Index(i).Uint())
+		}
+		limit := (8*n + 31) / 32
+		if isList {
+			limit = (8*max + 31) / 32
+		}
+		t, err = tb.newMerkleTree(packBytes(b), limit)
+		if err != nil {
+			return nil, err
+		}
+		t.elemBits = 64
+	case elem.Kind() == reflect.Slice || elem.Kind() == reflect.Ptr:
+		limit := n
+		if isList {
+			limit = max
+		}
+		var chunks [][32]byte
+		if tb.layers == nil {
+			chunks = make([][32]byte, v.Len())
+		}
+		for i := 0; i < len(chunks) && !tb.shapeOnly; i++ {
+			if chunks[i], err = tb.valueRoot(v.Index(i), sizes[1:], maxes[1:]); err != nil {
+				return nil, err
+			}
+		}
+		t, err = tb.newMerkleTree(chunks, limit)
+		if err != nil {
+			return nil, err
+		}
+		t.child = func(i int) (*merkleTree, error) {
+			if i >= v.Len() {
+				// Elements of an unset vector have their default value.
+				return tb.elements().typedTree(reflect.Zero(elem), sizes[1:], maxes[1:])
+			}
+			return tb.elements().typedTree(v.Index(i), sizes[1:], maxes[1:])
+		}
+	default:
+		return nil, fmt.Errorf("unsupported SSZ type %s", v.Type())
+	}
+	t.length = n
+	if isList {
+		t.mixin = lengthChunk(uint64(v.Len()))
+	}
+	return t, nil
+}
+
+// valueRoot returns the root of a value, using the generated hash tree root of containers when available.
+func (tb treeBuilder) valueRoot(v reflect.Value, sizes, maxes []string) ([32]byte, error) {
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		if h, ok := v.Interface().(hashRoot); ok {
+			return h.HashTreeRoot()
+		}
+	}
+	t, err := tb.typedTree(v, sizes, maxes)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return t.root(), nil
+}
+
+// dimension returns the first dimension of an ssz-size or ssz-max tag, or zero when it is unknown.
+func dimension(dims []string) uint64 {
+	if len(dims) == 0 {
+		return 0
+	}
+	d, err := strconv.ParseUint(dims[0], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+func packBytes(b []byte) [][32]byte {
+	chunks := make([][32]byte, (len(b)+31)/32)
+	for i := range chunks {
+		copy(chunks[i][:], b[32*i:])
+	}
+	return chunks
+}
+
+func lengthChunk(length uint64) *[32]byte {
+	var c [32]byte
+	binary.LittleEndian.PutUint64(c[:8], length)
+	return &c
+}
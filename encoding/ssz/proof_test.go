@@ -0,0 +1,383 @@
+package ssz_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func proofTestState(t *testing.T) *ethpb.BeaconStateCapella {
+	roots := func(n int) [][]byte {
+		r := make([][]byte, n)
+		for i := range r {
+			r[i] = bytesutil.PadTo([]byte{byte(i), byte(i >> 8)}, 32)
+		}
+		return r
+	}
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = bytesutil.PadTo([]byte{byte(i), byte(i >> 8)}, fieldparams.BLSPubkeyLength)
+	}
+	validators := make([]*ethpb.Validator, 10)
+	balances := make([]uint64, len(validators))
+	participation := make([]byte, len(validators))
+	for i := range validators {
+		validators[i] = &ethpb.Validator{
+			PublicKey:             bytesutil.PadTo([]byte{byte(i)}, fieldparams.BLSPubkeyLength),
+			WithdrawalCredentials: bytesutil.PadTo([]byte{0x01, byte(i)}, 32),
+			EffectiveBalance:      uint64(i) * 1e9,
+			ExitEpoch:             100,
+			WithdrawableEpoch:     200,
+		}
+		balances[i] = uint64(i)*1e9 + 1
+		participation[i] = byte(i % 8)
+	}
+	st := &ethpb.BeaconStateCapella{
+		GenesisTime:           1606824023,
+		GenesisValidatorsRoot: bytesutil.PadTo([]byte{0x4b}, 32),
+		Slot:                  123,
+		Fork: &ethpb.Fork{
+			PreviousVersion: []byte{3, 0, 0, 0},
+			CurrentVersion:  []byte{3, 0, 0, 0},
+		},
+		LatestBlockHeader: &ethpb.BeaconBlockHeader{
+			ParentRoot: make([]byte, 32),
+			StateRoot:  make([]byte, 32),
+			BodyRoot:   make([]byte, 32),
+		},
+		BlockRoots:      roots(fieldparams.BlockRootsLength),
+		StateRoots:      roots(fieldparams.StateRootsLength),
+		HistoricalRoots: roots(3),
+		Eth1Data: &ethpb.Eth1Data{
+			DepositRoot: make([]byte, 32),
+			BlockHash:   make([]byte, 32),
+		},
+		Validators:                 validators,
+		Balances:                   balances,
+		RandaoMixes:                roots(fieldparams.RandaoMixesLength),
+		Slashings:                  make([]uint64, fieldparams.SlashingsLength),
+		PreviousEpochParticipation: participation,
+		CurrentEpochParticipation:  participation,
+		JustificationBits:          bitfield.Bitvector4{0x0f},
+		PreviousJustifiedCheckpoint: &ethpb.Checkpoint{
+			Root: make([]byte, 32),
+		},
+		CurrentJustifiedCheckpoint: &ethpb.Checkpoint{
+			Root: make([]byte, 32),
+		},
+		FinalizedCheckpoint: &ethpb.Checkpoint{
+			Epoch: 2,
+			Root:  bytesutil.PadTo([]byte{0xf1}, 32),
+		},
+		InactivityScores: make([]uint64, len(validators)),
+		CurrentSyncCommittee: &ethpb.SyncCommittee{
+			Pubkeys:         pubkeys,
+			AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+		},
+		NextSyncCommittee: &ethpb.SyncCommittee{
+			Pubkeys:         pubkeys,
+			AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+		},
+		LatestExecutionPayloadHeader: &enginev1.ExecutionPayloadHeaderCapella{
+			ParentHash:       make([]byte, 32),
+			FeeRecipient:     make([]byte, 20),
+			StateRoot:        make([]byte, 32),
+			ReceiptsRoot:     make([]byte, 32),
+			LogsBloom:        make([]byte, 256),
+			PrevRandao:       make([]byte, 32),
+			BlockNumber:      77,
+			ExtraData:        []byte("extra"),
+			BaseFeePerGas:    make([]byte, 32),
+			BlockHash:        bytesutil.PadTo([]byte{0xb1}, 32),
+			TransactionsRoot: make([]byte, 32),
+			WithdrawalsRoot:  make([]byte, 32),
+		},
+		HistoricalSummaries: []*ethpb.HistoricalSummary{
+			{
+				BlockSummaryRoot: bytesutil.PadTo([]byte{0xaa}, 32),
+				StateSummaryRoot: bytesutil.PadTo([]byte{0xbb}, 32),
+			},
+		},
+	}
+	return st
+}
+
+func TestGeneralizedIndex(t *testing.T) {
+	st := proofTestState(t)
+	tests := []struct {
+		path   string
+		gindex uint64
+	}{
+		// Fields of a state of 28 fields are at depth 5.
+		{path: "genesis_time", gindex: 32},
+		{path: "finalized_checkpoint", gindex: 52},
+		{path: "finalized_checkpoint.root", gindex: 105},
+		{path: "current_sync_committee", gindex: 54},
+		{path: "next_sync_committee", gindex: 55},
+		{path: "historical_roots.__len__", gindex: 79},
+		{path: "historical_roots.2", gindex: 78<<24 | 2},
+		{path: "block_roots.3", gindex: 37<<13 | 3},
+		{path: "validators.1.pubkey", gindex: (43<<41 | 1) << 3},
+		{path: "validators.1.effective_balance", gindex: (43<<41|1)<<3 | 2},
+		// Four balances share a chunk.
+		{path: "balances.5", gindex: 44<<39 | 1},
+		{path: "latest_execution_payload_header.block_hash", gindex: 56<<4 | 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			gindex, err := ssz.GeneralizedIndex(st, tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.gindex, gindex)
+		})
+	}
+}
+
+func TestGeneralizedIndex_Errors(t *testing.T) {
+	st := proofTestState(t)
+	tests := []struct {
+		path string
+		err  string
+	}{
+		{path: "foo", err: "unknown field foo"},
+		{path: "validators.10", err: "index 10 is out of range, length is 10"},
+		{path: "validators.x", err: "invalid index x"},
+		{path: "slot.0", err: "slot is a basic value"},
+		{path: "balances.1.foo", err: "balances.1 is a basic value"},
+		{path: "block_roots.__len__", err: "block_roots is not a list"},
+		{path: "historical_roots.__len__.0", err: "__len__ must end the path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			_, err := ssz.GeneralizedIndex(st, tt.path)
+			require.ErrorContains(t, tt.err, err)
+		})
+	}
+}
+
+func TestProveGeneralizedIndex(t *testing.T) {
+	st := proofTestState(t)
+	root, err := st.HashTreeRoot()
+	require.NoError(t, err)
+	finalizedRoot, err := st.FinalizedCheckpoint.HashTreeRoot()
+	require.NoError(t, err)
+	validatorRoot, err := st.Validators[1].HashTreeRoot()
+	require.NoError(t, err)
+	summaryRoot, err := st.HistoricalSummaries[0].HashTreeRoot()
+	require.NoError(t, err)
+	var balancesChunk [32]byte
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(balancesChunk[8*i:], st.Balances[4+i])
+	}
+
+	tests := []struct {
+		path string
+		leaf [32]byte
+	}{
+		{path: "slot", leaf: ssz.Uint64Root(123)},
+		{path: "finalized_checkpoint", leaf: finalizedRoot},
+		{path: "finalized_checkpoint.root", leaf: bytesutil.ToBytes32(st.FinalizedCheckpoint.Root)},
+		{path: "validators.1", leaf: validatorRoot},
+		{path: "validators.1.effective_balance", leaf: ssz.Uint64Root(1e9)},
+		{path: "validators.__len__", leaf: ssz.Uint64Root(10)},
+		{path: "balances.5", leaf: balancesChunk},
+		{path: "block_roots.300", leaf: bytesutil.ToBytes32(st.BlockRoots[300])},
+		{path: "historical_roots.2", leaf: bytesutil.ToBytes32(st.HistoricalRoots[2])},
+		{path: "historical_summaries.0", leaf: summaryRoot},
+		{path: "current_sync_committee.pubkeys.7.0", leaf: bytesutil.ToBytes32(st.CurrentSyncCommittee.Pubkeys[7][:32])},
+		{path: "latest_execution_payload_header.block_hash", leaf: bytesutil.ToBytes32(st.LatestExecutionPayloadHeader.BlockHash)},
+		{path: "latest_execution_payload_header.extra_data.__len__", leaf: ssz.Uint64Root(5)},
+		{path: "justification_bits", leaf: bytesutil.ToBytes32([]byte{0x0f})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			gindex, err := ssz.GeneralizedIndex(st, tt.path)
+			require.NoError(t, err)
+			proof, err := ssz.ProveGeneralizedIndex(st, gindex)
+			require.NoError(t, err)
+			assert.Equal(t, gindex, proof.GeneralizedIndex)
+			assert.Equal(t, tt.leaf, proof.Leaf)
+			assert.Equal(t, true, proof.Verify(root))
+			proof.Leaf[0] ^= 1
+			assert.Equal(t, false, proof.Verify(root))
+		})
+	}
+}
+
+func TestProveGeneralizedIndex_Root(t *testing.T) {
+	st := proofTestState(t)
+	root, err := st.HashTreeRoot()
+	require.NoError(t, err)
+	proof, err := ssz.ProveGeneralizedIndex(st, 1)
+	require.NoError(t, err)
+	assert.Equal(t, root, proof.Leaf)
+	assert.Equal(t, 0, len(proof.Branch))
+	assert.Equal(t, true, proof.Verify(root))
+}
+
+func TestProveGeneralizedIndex_AllNodes(t *testing.T) {
+	v := proofTestState(t).Validators[3]
+	root, err := v.HashTreeRoot()
+	require.NoError(t, err)
+	// Nodes of the validator container, and chunks of its pubkey.
+	for gindex := uint64(1); gindex < 16; gindex++ {
+		proof, err := ssz.ProveGeneralizedIndex(v, gindex)
+		require.NoError(t, err)
+		assert.Equal(t, true, proof.Verify(root), "gindex %d", gindex)
+	}
+	for gindex := uint64(16); gindex < 18; gindex++ {
+		proof, err := ssz.ProveGeneralizedIndex(v, gindex)
+		require.NoError(t, err)
+		assert.Equal(t, true, proof.Verify(root), "gindex %d", gindex)
+	}
+}
+
+func TestProveGeneralizedIndex_Errors(t *testing.T) {
+	st := proofTestState(t)
+	_, err := ssz.ProveGeneralizedIndex(st, 0)
+	require.ErrorContains(t, "generalized index must be positive", err)
+	// Below the slot.
+	_, err = ssz.ProveGeneralizedIndex(st, 34<<1)
+	require.ErrorContains(t, "goes below a basic value", err)
+	// Below the length of the historical roots.
+	_, err = ssz.ProveGeneralizedIndex(st, 79<<1)
+	require.ErrorContains(t, "goes below the length of a list", err)
+	// Below the empty chunk of a validator which does not exist.
+	_, err = ssz.ProveGeneralizedIndex(st, (43<<41|20)<<3)
+	require.ErrorContains(t, "goes below an empty chunk", err)
+	_, err = ssz.ProveGeneralizedIndex(st.Balances, 1)
+	require.ErrorContains(t, "is not an SSZ container", err)
+}
+
+func TestProveGeneralizedIndexWithFieldRoots(t *testing.T) {
+	st := proofTestState(t)
+	root, err := st.HashTreeRoot()
+	require.NoError(t, err)
+	gindex, err := ssz.GeneralizedIndex(st, "validators.4.withdrawal_credentials")
+	require.NoError(t, err)
+
+	fieldRoots := make([][32]byte, 28)
+	for i := range fieldRoots {
+		proof, err := ssz.ProveGeneralizedIndex(st, 32+uint64(i))
+		require.NoError(t, err)
+		fieldRoots[i] = proof.Leaf
+	}
+	proof, err := ssz.ProveGeneralizedIndexWithFieldRoots(st, fieldRoots, nil, gindex)
+	require.NoError(t, err)
+	assert.Equal(t, bytesutil.ToBytes32(st.Validators[4].WithdrawalCredentials), proof.Leaf)
+	assert.Equal(t, true, proof.Verify(root))
+
+	// Roots which do not match the fields are caught when the proof goes through them.
+	fieldRoots[11][0] ^= 1
+	_, err = ssz.ProveGeneralizedIndexWithFieldRoots(st, fieldRoots, nil, gindex)
+	require.ErrorContains(t, "could not reproduce the root of a composite value", err)
+
+	_, err = ssz.ProveGeneralizedIndexWithFieldRoots(st, fieldRoots[:3], nil, gindex)
+	require.ErrorContains(t, "3 field roots for a container of 28 fields", err)
+}
+
+func TestProveGeneralizedIndexWithFieldRoots_FieldLayers(t *testing.T) {
+	st := proofTestState(t)
+	root, err := st.HashTreeRoot()
+	require.NoError(t, err)
+	gindex, err := ssz.GeneralizedIndex(st, "validators.4.withdrawal_credentials")
+	require.NoError(t, err)
+	fieldRoots := make([][32]byte, 28)
+	for i := range fieldRoots {
+		proof, err := ssz.ProveGeneralizedIndex(st, 32+uint64(i))
+		require.NoError(t, err)
+		fieldRoots[i] = proof.Leaf
+	}
+	validatorRoots := make([][32]byte, len(st.Validators))
+	for i, v := range st.Validators {
+		validatorRoots[i], err = v.HashTreeRoot()
+		require.NoError(t, err)
+	}
+	layers := proofTestLayers(validatorRoots, 40)
+
+	proof, err := ssz.ProveGeneralizedIndexWithFieldRoots(st, fieldRoots, map[int][][]*[32]byte{11: layers}, gindex)
+	require.NoError(t, err)
+	assert.Equal(t, bytesutil.ToBytes32(st.Validators[4].WithdrawalCredentials), proof.Leaf)
+	assert.Equal(t, true, proof.Verify(root))
+
+	// The validators are not hashed, so that stale layers are only caught through the root of the field.
+	layers[0][4] = &[32]byte{}
+	_, err = ssz.ProveGeneralizedIndexWithFieldRoots(st, fieldRoots, map[int][][]*[32]byte{11: layers}, gindex)
+	require.ErrorContains(t, "could not reproduce the root of a composite value", err)
+
+	_, err = ssz.ProveGeneralizedIndexWithFieldRoots(st, fieldRoots, map[int][][]*[32]byte{11: layers[:3]}, gindex)
+	require.ErrorContains(t, "3 layers for a tree of depth 40", err)
+}
+
+func TestProveGeneralizedIndex_InvalidGeneralizedIndex(t *testing.T) {
+	st := proofTestState(t)
+	gindex, err := ssz.GeneralizedIndex(st, "slot")
+	require.NoError(t, err)
+	_, err = ssz.ProveGeneralizedIndex(st, gindex<<1)
+	assert.Equal(t, true, errors.Is(err, ssz.ErrInvalidGeneralizedIndex))
+	_, err = ssz.ProveGeneralizedIndexWithFieldRoots(st, make([][32]byte, 3), nil, gindex)
+	assert.Equal(t, false, errors.Is(err, ssz.ErrInvalidGeneralizedIndex))
+}
+
+// proofTestLayers returns the layers of the Merkle tree of the chunks, from the chunks up to the root, as the field
+// tries of the beacon state hold them.
+func proofTestLayers(chunks [][32]byte, depth int) [][]*[32]byte {
+	layers := make([][]*[32]byte, depth+1)
+	layers[0] = make([]*[32]byte, len(chunks))
+	for i := range chunks {
+		layers[0][i] = &chunks[i]
+	}
+	for l := 0; l < depth; l++ {
+		below := layers[l]
+		layers[l+1] = make([]*[32]byte, (len(below)+1)/2)
+		for j := range layers[l+1] {
+			right := trie.ZeroHashes[l]
+			if 2*j+1 < len(below) {
+				right = *below[2*j+1]
+			}
+			h := hash.Hash(append(below[2*j][:], right[:]...))
+			layers[l+1][j] = &h
+		}
+	}
+	return layers
+}
+
+func TestGeneralizedIndex_UnsetVector(t *testing.T) {
+	gindex, err := ssz.GeneralizedIndex(&ethpb.BeaconStateCapella{}, "randao_mixes.5.0")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(45<<16|5), gindex)
+}
+
+func TestConcatProofs(t *testing.T) {
+	st := proofTestState(t)
+	root, err := st.HashTreeRoot()
+	require.NoError(t, err)
+	outer, err := ssz.GeneralizedIndex(st, "historical_summaries.0")
+	require.NoError(t, err)
+	inner, err := ssz.GeneralizedIndex(st.HistoricalSummaries[0], "state_summary_root")
+	require.NoError(t, err)
+	full, err := ssz.GeneralizedIndex(st, "historical_summaries.0.state_summary_root")
+	require.NoError(t, err)
+	assert.Equal(t, full, ssz.ConcatGeneralizedIndices(outer, inner))
+	assert.Equal(t, full, ssz.ConcatGeneralizedIndices(1, outer, 1, inner))
+
+	outerProof, err := ssz.ProveGeneralizedIndex(st, outer)
+	require.NoError(t, err)
+	innerProof, err := ssz.ProveGeneralizedIndex(st.HistoricalSummaries[0], inner)
+	require.NoError(t, err)
+	proof := ssz.ConcatProofs(outerProof, innerProof)
+	fullProof, err := ssz.ProveGeneralizedIndex(st, full)
+	require.NoError(t, err)
+	assert.DeepEqual(t, fullProof, proof)
+	assert.Equal(t, true, proof.Verify(root))
+}
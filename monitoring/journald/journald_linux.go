@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/coreos/go-systemd/journal"
+	"github.com/prysmaticlabs/prysm/v4/runtime/logging"
 	"github.com/sirupsen/logrus"
 )
 
@@ -15,7 +16,7 @@ func Enable() error {
 	if !journal.Enabled() {
 		logrus.Warning("Journal not available but user requests we log to it. Ignoring")
 	} else {
-		logrus.AddHook(&JournalHook{})
+		logrus.AddHook(&logging.ModuleHook{Hook: &JournalHook{}})
 		logrus.SetOutput(io.Discard)
 	}
 	return nil
@@ -0,0 +1,7 @@
+/*
+Package resources defines a runtime service which periodically checks the free disk space
+of the data directory, the growth rate of the database, the open file descriptors and the
+memory of the process. It emits metrics, warns before a limit is hit and, when configured,
+reports the disk as low so that non-critical work writing to disk can be paused.
+*/
+package resources
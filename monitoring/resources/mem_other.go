@@ -0,0 +1,9 @@
+//go:build !linux
+
+package resources
+
+import "errors"
+
+func residentMemory() (uint64, error) {
+	return 0, errors.New("resident memory is only available on linux")
+}
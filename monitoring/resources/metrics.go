@@ -0,0 +1,40 @@
+package resources
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	log = logrus.WithField("prefix", "resources")
+
+	diskFreeBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "resources_disk_free_bytes",
+		Help: "Free disk space available to the data directory, in bytes.",
+	})
+	dbSizeBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "resources_db_size_bytes",
+		Help: "Size of the database directory, in bytes.",
+	})
+	dbGrowthBytesPerHour = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "resources_db_growth_bytes_per_hour",
+		Help: "Growth rate of the database directory over the last hour, in bytes per hour.",
+	})
+	openFileDescriptors = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "resources_open_file_descriptors",
+		Help: "Number of file descriptors opened by the process.",
+	})
+	fileDescriptorLimit = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "resources_file_descriptor_limit",
+		Help: "Maximum number of file descriptors the process may open.",
+	})
+	processMemoryBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "resources_process_memory_bytes",
+		Help: "Resident set size of the process, in bytes.",
+	})
+	lowDiskGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "resources_low_disk",
+		Help: "1 when the free disk space is below the critical threshold and non-critical work is paused, 0 otherwise.",
+	})
+)
@@ -0,0 +1,273 @@
+package resources
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/fdlimit"
+	"github.com/pbnjay/memory"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	gigabyte      = 1 << 30
+	checkInterval = time.Minute
+	// warnInterval limits how often a resource which stays close to its limit is warned about.
+	warnInterval = 15 * time.Minute
+	// growthWindow is the period over which the growth rate of the database is measured.
+	growthWindow = time.Hour
+	// diskFullWarnPeriod is how long before the disk is expected to be full, at the current growth rate
+	// of the database, a warning is logged.
+	diskFullWarnPeriod = 24 * time.Hour
+	// fileDescriptorWarnRatio is the share of the file descriptor limit above which a warning is logged.
+	fileDescriptorWarnRatio = 0.9
+	// memoryWarnRatio is the share of the system memory above which a warning is logged.
+	memoryWarnRatio = 0.9
+)
+
+// Config of the resource monitor. Thresholds of zero are disabled.
+type Config struct {
+	// DataDir is the directory whose file system is checked for free space.
+	DataDir string
+	// DBPath is the directory of the database whose growth rate is tracked.
+	DBPath string
+	// WarnFreeDisk is the free disk space, in bytes, below which a warning is logged.
+	WarnFreeDisk uint64
+	// CriticalFreeDisk is the free disk space, in bytes, below which the disk is reported as low.
+	CriticalFreeDisk uint64
+	// PauseOnLowDisk pauses non-critical work, such as archiving states, while the disk is low.
+	PauseOnLowDisk bool
+}
+
+// LowDiskChecker reports whether the free disk space is low enough that non-critical work writing to disk,
+// such as archiving states, should be paused. It is implemented by Service.
+type LowDiskChecker interface {
+	LowDisk() bool
+}
+
+var errLowDisk = errors.New("free disk space is critically low")
+
+type sample struct {
+	time time.Time
+	size uint64
+}
+
+// Service checks the resources of the node at a regular interval.
+type Service struct {
+	cfg        *Config
+	ctx        context.Context
+	cancel     context.CancelFunc
+	lock       sync.RWMutex
+	lowDisk    bool
+	samples    []sample
+	lastWarned map[string]time.Time
+	now        func() time.Time
+}
+
+// New creates a resource monitor with the given configuration.
+func New(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		cfg:        cfg,
+		ctx:        ctx,
+		cancel:     cancel,
+		lastWarned: make(map[string]time.Time),
+		now:        time.Now,
+	}
+}
+
+// Start the resource monitor.
+func (s *Service) Start() {
+	log.WithFields(logrus.Fields{
+		"warnFreeDiskGB":     s.cfg.WarnFreeDisk / gigabyte,
+		"criticalFreeDiskGB": s.cfg.CriticalFreeDisk / gigabyte,
+		"pauseOnLowDisk":     s.cfg.PauseOnLowDisk,
+	}).Info("Monitoring disk, memory and file descriptors")
+	go s.run()
+}
+
+// Stop the resource monitor.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the resource monitor. It returns an error when the disk is low.
+func (s *Service) Status() error {
+	if s.LowDisk() {
+		return errLowDisk
+	}
+	return nil
+}
+
+// LowDisk returns true when the free disk space is below the critical threshold and non-critical
+// work writing to disk should be paused.
+func (s *Service) LowDisk() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.lowDisk && s.cfg.PauseOnLowDisk
+}
+
+func (s *Service) run() {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	s.check()
+	for {
+		select {
+		case <-ticker.C:
+			s.check()
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Service) check() {
+	s.checkDisk()
+	s.checkFileDescriptors()
+	s.checkMemory()
+}
+
+func (s *Service) checkDisk() {
+	free, err := freeDiskSpace(s.cfg.DataDir)
+	if err != nil {
+		log.WithError(err).Debug("Could not get free disk space")
+		return
+	}
+	diskFreeBytes.Set(float64(free))
+
+	var growth float64
+	if s.cfg.DBPath != "" {
+		size, err := dirSize(s.cfg.DBPath)
+		if err != nil {
+			log.WithError(err).Debug("Could not get database size")
+		} else {
+			dbSizeBytes.Set(float64(size))
+			growth = s.recordSize(size)
+			dbGrowthBytesPerHour.Set(growth)
+		}
+	}
+
+	fields := logrus.Fields{
+		"freeGB": float64(free) / gigabyte,
+		"path":   s.cfg.DataDir,
+	}
+	low := s.cfg.CriticalFreeDisk > 0 && free < s.cfg.CriticalFreeDisk
+	s.setLowDisk(low, fields)
+	switch {
+	case low:
+		s.warn("disk", fields, "Free disk space is critically low, the node will stop when the disk is full")
+	case s.cfg.WarnFreeDisk > 0 && free < s.cfg.WarnFreeDisk:
+		s.warn("disk", fields, "Free disk space is low")
+	case growth > 0:
+		remaining := time.Duration(float64(free) / growth * float64(time.Hour))
+		if remaining < diskFullWarnPeriod {
+			fields["growthGBPerHour"] = growth / gigabyte
+			fields["timeRemaining"] = remaining.Round(time.Minute)
+			s.warn("disk", fields, "Disk is expected to be full soon at the current database growth rate")
+		}
+	}
+}
+
+func (s *Service) setLowDisk(low bool, fields logrus.Fields) {
+	s.lock.Lock()
+	changed := s.lowDisk != low
+	s.lowDisk = low
+	s.lock.Unlock()
+	if low {
+		lowDiskGauge.Set(1)
+	} else {
+		lowDiskGauge.Set(0)
+	}
+	if !changed || !s.cfg.PauseOnLowDisk {
+		return
+	}
+	if low {
+		log.WithFields(fields).Warn("Pausing non-critical work writing to disk")
+	} else {
+		log.WithFields(fields).Info("Resuming non-critical work writing to disk")
+	}
+}
+
+// recordSize records the size of the database and returns its growth rate, in bytes per hour, over the
+// growth window. The rate is zero until the database has been sampled for a tenth of the window.
+func (s *Service) recordSize(size uint64) float64 {
+	now := s.now()
+	s.samples = append(s.samples, sample{time: now, size: size})
+	for len(s.samples) > 1 && now.Sub(s.samples[0].time) > growthWindow {
+		s.samples = s.samples[1:]
+	}
+	first := s.samples[0]
+	elapsed := now.Sub(first.time)
+	if elapsed < growthWindow/10 || size <= first.size {
+		return 0
+	}
+	return float64(size-first.size) / elapsed.Hours()
+}
+
+func (s *Service) checkFileDescriptors() {
+	open, err := openFiles()
+	if err != nil {
+		log.WithError(err).Debug("Could not get open file descriptors")
+		return
+	}
+	openFileDescriptors.Set(float64(open))
+	limit, err := fdlimit.Current()
+	if err != nil {
+		log.WithError(err).Debug("Could not get file descriptor limit")
+		return
+	}
+	fileDescriptorLimit.Set(float64(limit))
+	if limit > 0 && float64(open) > fileDescriptorWarnRatio*float64(limit) {
+		s.warn("fd", logrus.Fields{"open": open, "limit": limit}, "Open file descriptors are close to the limit")
+	}
+}
+
+func (s *Service) checkMemory() {
+	rss, err := residentMemory()
+	if err != nil {
+		log.WithError(err).Debug("Could not get resident memory")
+		return
+	}
+	processMemoryBytes.Set(float64(rss))
+	total := memory.TotalMemory()
+	if total > 0 && float64(rss) > memoryWarnRatio*float64(total) {
+		s.warn("memory", logrus.Fields{
+			"usedGB":  float64(rss) / gigabyte,
+			"totalGB": float64(total) / gigabyte,
+		}, "Memory used by the process is close to the system memory")
+	}
+}
+
+// warn logs the message unless a warning about the same resource was logged within the warn interval.
+func (s *Service) warn(resource string, fields logrus.Fields, msg string) {
+	now := s.now()
+	if last, ok := s.lastWarned[resource]; ok && now.Sub(last) < warnInterval {
+		return
+	}
+	s.lastWarned[resource] = now
+	log.WithFields(fields).Warn(msg)
+}
+
+func dirSize(path string) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += uint64(info.Size()) // lint:ignore uintcast -- file sizes are never negative.
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,68 @@
+package resources
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestService_checkDisk(t *testing.T) {
+	hook := logTest.NewGlobal()
+	s := New(context.Background(), &Config{DataDir: t.TempDir(), CriticalFreeDisk: math.MaxUint64, PauseOnLowDisk: true})
+	s.checkDisk()
+	assert.Equal(t, true, s.LowDisk())
+	require.ErrorIs(t, s.Status(), errLowDisk)
+	require.LogsContain(t, hook, "Pausing non-critical work writing to disk")
+	require.LogsContain(t, hook, "Free disk space is critically low")
+
+	s.cfg.CriticalFreeDisk = 0
+	s.checkDisk()
+	assert.Equal(t, false, s.LowDisk())
+	assert.NoError(t, s.Status())
+	require.LogsContain(t, hook, "Resuming non-critical work writing to disk")
+}
+
+func TestService_LowDisk_NotPaused(t *testing.T) {
+	s := New(context.Background(), &Config{DataDir: t.TempDir(), CriticalFreeDisk: math.MaxUint64})
+	s.checkDisk()
+	assert.Equal(t, false, s.LowDisk())
+}
+
+func TestService_recordSize(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := New(context.Background(), &Config{})
+	s.now = func() time.Time { return now }
+
+	assert.Equal(t, float64(0), s.recordSize(1000))
+	now = now.Add(time.Minute)
+	// Not sampled for long enough.
+	assert.Equal(t, float64(0), s.recordSize(2000))
+	now = now.Add(29 * time.Minute)
+	assert.Equal(t, float64(2000), s.recordSize(2000))
+	now = now.Add(time.Hour)
+	// The first samples are out of the window.
+	assert.Equal(t, float64(0), s.recordSize(2000))
+	assert.Equal(t, 2, len(s.samples))
+}
+
+func TestService_warn(t *testing.T) {
+	hook := logTest.NewGlobal()
+	now := time.Unix(0, 0)
+	s := New(context.Background(), &Config{})
+	s.now = func() time.Time { return now }
+
+	s.warn("disk", nil, "first")
+	s.warn("disk", nil, "second")
+	s.warn("fd", nil, "third")
+	now = now.Add(warnInterval)
+	s.warn("disk", nil, "fourth")
+	require.LogsContain(t, hook, "first")
+	require.LogsDoNotContain(t, hook, "second")
+	require.LogsContain(t, hook, "third")
+	require.LogsContain(t, hook, "fourth")
+}
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin
+
+package resources
+
+import "errors"
+
+var errUnsupported = errors.New("not supported on this platform")
+
+func freeDiskSpace(_ string) (uint64, error) {
+	return 0, errUnsupported
+}
+
+func openFiles() (int, error) {
+	return 0, errUnsupported
+}
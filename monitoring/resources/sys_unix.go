@@ -0,0 +1,31 @@
+//go:build linux || darwin
+
+package resources
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// freeDiskSpace returns the disk space available to unprivileged users on the file system of the path.
+func freeDiskSpace(path string) (uint64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil // lint:ignore uintcast -- block size is never negative.
+}
+
+// openFiles returns the number of file descriptors opened by the process.
+func openFiles() (int, error) {
+	dir := "/proc/self/fd"
+	if _, err := os.Stat(dir); err != nil {
+		dir = "/dev/fd"
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
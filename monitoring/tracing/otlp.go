@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	otlpTracesPath = "/v1/traces"
+	otlpBufferSize = 10000
+	otlpTracerName = "github.com/prysmaticlabs/prysm"
+)
+
+// newOTLPTracerProvider creates an OpenTelemetry tracer provider which exports spans in batches to the OTLP/HTTP
+// endpoint, such as http://127.0.0.1:4318, with the given headers. The attributes describe the process which
+// records the spans. Spans which do not fit in the export queue are dropped.
+func newOTLPTracerProvider(
+	ctx context.Context, endpoint string, headers map[string]string, sampleFraction float64, attributes map[string]string,
+) (*sdktrace.TracerProvider, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse OTLP endpoint %s", endpoint)
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host), otlptracehttp.WithHeaders(headers)}
+	switch u.Scheme {
+	case "http":
+		opts = append(opts, otlptracehttp.WithInsecure())
+	case "https":
+	default:
+		return nil, fmt.Errorf("OTLP endpoint %s must be an http or https URL", endpoint)
+	}
+	if p := strings.TrimSuffix(u.Path, "/"); p != "" {
+		if !strings.HasSuffix(p, otlpTracesPath) {
+			p += otlpTracesPath
+		}
+		opts = append(opts, otlptracehttp.WithURLPath(p))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create OTLP exporter")
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(attributes))
+	for k, v := range attributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, sdktrace.WithMaxQueueSize(otlpBufferSize)),
+		sdktrace.WithResource(resource.NewSchemaless(attrs...)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleFraction))),
+	), nil
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"go.opencensus.io/trace"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSetup_OTLP(t *testing.T) {
+	prevTracer := trace.DefaultTracer
+	defer func() {
+		trace.DefaultTracer = prevTracer
+		shutdown = nil
+	}()
+
+	requests := make(chan *collectortrace.ExportTraceServiceRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := &collectortrace.ExportTraceServiceRequest{}
+		require.NoError(t, proto.Unmarshal(body, req))
+		requests <- req
+	}))
+	defer srv.Close()
+
+	require.NoError(t, Setup("beacon-chain", "beacon-node", "", 1, true, srv.URL, []string{"Authorization=Bearer secret"}))
+	_, span := trace.StartSpan(context.Background(), "blockChain.ReceiveBlock")
+	span.AddAttributes(trace.Int64Attribute("slot", 5))
+	span.End()
+	// Shutting down exports the buffered span.
+	require.NoError(t, Shutdown(context.Background()))
+
+	var req *collectortrace.ExportTraceServiceRequest
+	select {
+	case req = <-requests:
+	default:
+		t.Fatal("No spans exported")
+	}
+	require.Equal(t, 1, len(req.ResourceSpans))
+	rs := req.ResourceSpans[0]
+	attributes := make(map[string]string)
+	for _, a := range rs.Resource.Attributes {
+		attributes[a.Key] = a.Value.GetStringValue()
+	}
+	assert.Equal(t, "beacon-chain", attributes["service.name"])
+	assert.Equal(t, "beacon-node", attributes["process_name"])
+	require.Equal(t, 1, len(rs.InstrumentationLibrarySpans))
+	require.Equal(t, 1, len(rs.InstrumentationLibrarySpans[0].Spans))
+	exported := rs.InstrumentationLibrarySpans[0].Spans[0]
+	assert.Equal(t, "blockChain.ReceiveBlock", exported.Name)
+	require.Equal(t, 1, len(exported.Attributes))
+	assert.Equal(t, "slot", exported.Attributes[0].Key)
+	assert.Equal(t, int64(5), exported.Attributes[0].Value.GetIntValue())
+}
+
+func TestNewOTLPTracerProvider_InvalidEndpoint(t *testing.T) {
+	_, err := newOTLPTracerProvider(context.Background(), "localhost:4318", nil, 1, nil)
+	assert.ErrorContains(t, "must be an http or https URL", err)
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := parseHeaders([]string{"Authorization=Bearer a=b", " X-Scope = team "})
+	require.NoError(t, err)
+	assert.DeepEqual(t, map[string]string{"Authorization": "Bearer a=b", "X-Scope": "team"}, headers)
+
+	_, err = parseHeaders([]string{"Authorization"})
+	assert.ErrorContains(t, "not formatted as key=value", err)
+}
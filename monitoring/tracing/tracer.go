@@ -1,20 +1,28 @@
-// Package tracing sets up jaeger as an opentracing tool
-// for services in Prysm.
+// Package tracing sets up jaeger or an OpenTelemetry collector
+// as an opentracing tool for services in Prysm.
 package tracing
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"contrib.go.opencensus.io/exporter/jaeger"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/bridge/opencensus"
 )
 
 var log = logrus.WithField("prefix", "tracing")
 
-// Setup creates and initializes a new tracing configuration..
-func Setup(serviceName, processName, endpoint string, sampleFraction float64, enable bool) error {
+// shutdown flushes and stops the exporter configured in Setup, if any.
+var shutdown func(context.Context) error
+
+// Setup creates and initializes a new tracing configuration. Spans are exported to the OTLP/HTTP endpoint
+// with the given key=value headers when otlpEndpoint is set, or to the Jaeger endpoint otherwise.
+func Setup(serviceName, processName, endpoint string, sampleFraction float64, enable bool, otlpEndpoint string, otlpHeaders []string) error {
 	if !enable {
 		trace.ApplyConfig(trace.Config{DefaultSampler: trace.NeverSample()})
 		return nil
@@ -29,6 +37,26 @@ func Setup(serviceName, processName, endpoint string, sampleFraction float64, en
 		MaxMessageEventsPerSpan: 500,
 	})
 
+	if otlpEndpoint != "" {
+		headers, err := parseHeaders(otlpHeaders)
+		if err != nil {
+			return err
+		}
+		log.Infof("Starting OTLP exporter endpoint at address = %s", otlpEndpoint)
+		provider, err := newOTLPTracerProvider(context.Background(), otlpEndpoint, headers, sampleFraction, map[string]string{
+			"service.name":    serviceName,
+			"service.version": version.Version(),
+			"process_name":    processName,
+		})
+		if err != nil {
+			return err
+		}
+		// The spans recorded with OpenCensus are bridged to the OpenTelemetry provider, which samples them.
+		trace.DefaultTracer = opencensus.NewTracer(provider.Tracer(otlpTracerName))
+		shutdown = provider.Shutdown
+		return nil
+	}
+
 	log.Infof("Starting Jaeger exporter endpoint at address = %s", endpoint)
 	exporter, err := jaeger.NewExporter(jaeger.Options{
 		CollectorEndpoint: endpoint,
@@ -48,6 +76,32 @@ func Setup(serviceName, processName, endpoint string, sampleFraction float64, en
 		return err
 	}
 	trace.RegisterExporter(exporter)
+	shutdown = func(context.Context) error {
+		exporter.Flush()
+		return nil
+	}
 
 	return nil
 }
+
+// Shutdown exports the spans which are still buffered by the exporter configured in Setup. It is
+// called when the process stops, so that the last spans are not lost.
+func Shutdown(ctx context.Context) error {
+	if shutdown == nil {
+		return nil
+	}
+	return shutdown(ctx)
+}
+
+// parseHeaders parses headers given as key=value.
+func parseHeaders(headers []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(headers))
+	for _, h := range headers {
+		k, v, ok := strings.Cut(h, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("tracing header %s is not formatted as key=value", h)
+		}
+		parsed[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return parsed, nil
+}
@@ -24,24 +24,34 @@ const DefaultRPCHTTPTimeout = time.Second * 30
 type jwtTransport struct {
 	underlyingTransport http.RoundTripper
 	jwtSecret           []byte
+	observer            JWTObserver
 }
 
+// JWTObserver is notified of the issued-at claim of every JWT sent by an HTTP client, along with the response or
+// the error of the request, which is used to diagnose authentication failures with execution clients.
+type JWTObserver func(issuedAt time.Time, resp *http.Response, err error)
+
 // RoundTrip ensures our transport implements http.RoundTripper interface from the
 // standard library. When used as the transport for an HTTP client, the code below
 // will run every time our client makes an HTTP request. This is used to inject
 // an JWT bearer token in the Authorization request header of every outgoing request
 // our HTTP client makes.
 func (t *jwtTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	issuedAt := time.Now()
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		// Required claim for engine API auth. "iat" stands for issued at
 		// and it must be a unix timestamp that is +/- 5 seconds from the current
 		// timestamp at the moment the server verifies this value.
-		"iat": time.Now().Unix(),
+		"iat": issuedAt.Unix(),
 	})
 	tokenString, err := token.SignedString(t.jwtSecret)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not produce signed JWT token")
 	}
 	req.Header.Set("Authorization", "Bearer "+tokenString)
-	return t.underlyingTransport.RoundTrip(req)
+	resp, err := t.underlyingTransport.RoundTrip(req)
+	if t.observer != nil {
+		t.observer(time.Unix(issuedAt.Unix(), 0), resp, err)
+	}
+	return resp, err
 }
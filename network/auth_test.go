@@ -51,3 +51,24 @@ func TestJWTAuthTransport(t *testing.T) {
 	_, err := client.Get(srv.URL)
 	require.NoError(t, err)
 }
+
+func TestJWTAuthTransport_Observer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	var issuedAt time.Time
+	var status int
+	client := NewHttpClientWithSecretAndObserver(string(bytesutil.PadTo([]byte("foo"), 32)), func(iat time.Time, resp *http.Response, err error) {
+		require.NoError(t, err)
+		issuedAt = iat
+		status = resp.StatusCode
+	})
+	start := time.Now().Truncate(time.Second)
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusUnauthorized, status)
+	require.Equal(t, true, !issuedAt.Before(start) && time.Since(issuedAt) <= time.Second*5)
+}
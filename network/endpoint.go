@@ -113,9 +113,16 @@ func Method(auth string) authorization.AuthorizationMethod {
 // NewHttpClientWithSecret returns a http client that utilizes
 // jwt authentication.
 func NewHttpClientWithSecret(secret string) *http.Client {
+	return NewHttpClientWithSecretAndObserver(secret, nil)
+}
+
+// NewHttpClientWithSecretAndObserver returns a http client that utilizes
+// jwt authentication, and notifies the observer of every request it sends.
+func NewHttpClientWithSecretAndObserver(secret string, observer JWTObserver) *http.Client {
 	authTransport := &jwtTransport{
 		underlyingTransport: http.DefaultTransport,
 		jwtSecret:           []byte(secret),
+		observer:            observer,
 	}
 	return &http.Client{
 		Timeout:   DefaultRPCHTTPTimeout,
@@ -124,6 +131,12 @@ func NewHttpClientWithSecret(secret string) *http.Client {
 }
 
 func NewExecutionRPCClient(ctx context.Context, endpoint Endpoint) (*gethRPC.Client, error) {
+	return NewExecutionRPCClientWithObserver(ctx, endpoint, nil)
+}
+
+// NewExecutionRPCClientWithObserver creates an execution RPC client which, for HTTP endpoints with jwt
+// authentication, notifies the observer of every request it sends.
+func NewExecutionRPCClientWithObserver(ctx context.Context, endpoint Endpoint, observer JWTObserver) (*gethRPC.Client, error) {
 	// Need to handle ipc and http
 	var client *gethRPC.Client
 	u, err := url.Parse(endpoint.Url)
@@ -132,7 +145,11 @@ func NewExecutionRPCClient(ctx context.Context, endpoint Endpoint) (*gethRPC.Cli
 	}
 	switch u.Scheme {
 	case "http", "https":
-		client, err = gethRPC.DialOptions(ctx, endpoint.Url, gethRPC.WithHTTPClient(endpoint.HttpClient()))
+		httpClient := endpoint.HttpClient()
+		if endpoint.Auth.Method == authorization.Bearer && observer != nil {
+			httpClient = NewHttpClientWithSecretAndObserver(endpoint.Auth.Value, observer)
+		}
+		client, err = gethRPC.DialOptions(ctx, endpoint.Url, gethRPC.WithHTTPClient(httpClient))
 		if err != nil {
 			return nil, err
 		}
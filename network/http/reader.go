@@ -1,6 +1,7 @@
 package http
 
 import (
+	"mime"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -18,11 +19,11 @@ func SszRequested(req *http.Request) (bool, error) {
 	if len(accept) == 0 {
 		return false, nil
 	}
-	types := strings.Split(accept[0], ",")
+	types := strings.Split(strings.Join(accept, ","), ",")
 	currentType, currentPriority := "", 0.0
 	for _, t := range types {
 		values := strings.Split(t, ";")
-		name := values[0]
+		name := strings.TrimSpace(values[0])
 		if name != api.JsonMediaType && name != api.OctetStreamMediaType {
 			continue
 		}
@@ -51,3 +52,16 @@ func SszRequested(req *http.Request) (bool, error) {
 
 	return currentType == api.OctetStreamMediaType, nil
 }
+
+// SszPosted takes a http request and checks to see if its body is SSZ-encoded, as indicated by its content type.
+func SszPosted(req *http.Request) bool {
+	ct := req.Header.Get("Content-Type")
+	if ct == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+	return mediaType == api.OctetStreamMediaType
+}
@@ -42,6 +42,22 @@ func TestSSZRequested(t *testing.T) {
 		assert.Equal(t, true, result)
 	})
 
+	t.Run("ssz_content_type_with_spaces", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example", nil)
+		request.Header["Accept"] = []string{fmt.Sprintf("%s;q=0.9, %s", jsonMediaType, octetStreamMediaType)}
+		result, err := SszRequested(request)
+		require.NoError(t, err)
+		assert.Equal(t, true, result)
+	})
+
+	t.Run("ssz_content_type_in_second_header", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example", nil)
+		request.Header["Accept"] = []string{fmt.Sprintf("%s;q=0.9", jsonMediaType), octetStreamMediaType}
+		result, err := SszRequested(request)
+		require.NoError(t, err)
+		assert.Equal(t, true, result)
+	})
+
 	t.Run("other_content_type_preferred", func(t *testing.T) {
 		request := httptest.NewRequest("GET", "http://foo.example", nil)
 		request.Header["Accept"] = []string{fmt.Sprintf("%s,%s;q=0.9", jsonMediaType, octetStreamMediaType)}
@@ -97,3 +113,34 @@ func TestSSZRequested(t *testing.T) {
 		assert.Equal(t, false, result)
 	})
 }
+
+func TestSSZPosted(t *testing.T) {
+	t.Run("ssz_posted", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example", nil)
+		request.Header.Set("Content-Type", octetStreamMediaType)
+		assert.Equal(t, true, SszPosted(request))
+	})
+
+	t.Run("ssz_posted_with_params", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example", nil)
+		request.Header.Set("Content-Type", octetStreamMediaType+"; charset=binary")
+		assert.Equal(t, true, SszPosted(request))
+	})
+
+	t.Run("json_posted", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example", nil)
+		request.Header.Set("Content-Type", jsonMediaType)
+		assert.Equal(t, false, SszPosted(request))
+	})
+
+	t.Run("no_header", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example", nil)
+		assert.Equal(t, false, SszPosted(request))
+	})
+
+	t.Run("garbage", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example", nil)
+		request.Header.Set("Content-Type", "This is Sparta!!!")
+		assert.Equal(t, false, SszPosted(request))
+	})
+}
@@ -31,8 +31,13 @@ func (e *DefaultErrorJson) StatusCode() int {
 
 // WriteJson writes the response message in JSON format.
 func WriteJson(w http.ResponseWriter, v any) {
+	WriteJsonWithCode(w, v, http.StatusOK)
+}
+
+// WriteJsonWithCode writes the response message in JSON format with the given status code.
+func WriteJsonWithCode(w http.ResponseWriter, v any, code int) {
 	w.Header().Set("Content-Type", jsonMediaType)
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(code)
 	if err := json.NewEncoder(w).Encode(v); err != nil {
 		log.WithError(err).Error("Could not write response message")
 	}
@@ -0,0 +1,42 @@
+package attutil
+
+import (
+	"github.com/prysmaticlabs/go-bitfield"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/attestation/aggregation"
+	"github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/attestation/aggregation/attestations"
+)
+
+// ErrBitsOverlap is returned when merging aggregation bits which have attesters in common, as their signatures
+// can not be aggregated.
+var ErrBitsOverlap = aggregation.ErrBitsOverlap
+
+// IsSuperset returns whether the aggregation bits a include all the attesters of b, in which case an attestation
+// with bits b is redundant with one with bits a. The bits must be of the same committee.
+func IsSuperset(a, b bitfield.Bitlist) (bool, error) {
+	return a.Contains(b)
+}
+
+// Overlaps returns whether the aggregation bits a and b have attesters in common.
+func Overlaps(a, b bitfield.Bitlist) (bool, error) {
+	return a.Overlaps(b)
+}
+
+// MergeBits returns the union of the aggregation bits a and b, or ErrBitsOverlap when they have attesters in
+// common.
+func MergeBits(a, b bitfield.Bitlist) (bitfield.Bitlist, error) {
+	overlaps, err := a.Overlaps(b)
+	if err != nil {
+		return nil, err
+	}
+	if overlaps {
+		return nil, ErrBitsOverlap
+	}
+	return a.Or(b)
+}
+
+// MergeAttestations aggregates two attestations of the same data, merging their aggregation bits and signatures.
+// ErrBitsOverlap is returned when they have attesters in common. The attestations are not modified.
+func MergeAttestations(a, b *ethpb.Attestation) (*ethpb.Attestation, error) {
+	return attestations.AggregatePair(a, b)
+}
@@ -0,0 +1,63 @@
+package attutil_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/attestation/attutil"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestBitfieldOperations(t *testing.T) {
+	a := bitfield.Bitlist{0b00011011}
+	b := bitfield.Bitlist{0b00010011}
+	c := bitfield.Bitlist{0b00010100}
+
+	superset, err := attutil.IsSuperset(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, true, superset)
+	superset, err = attutil.IsSuperset(b, a)
+	require.NoError(t, err)
+	assert.Equal(t, false, superset)
+
+	overlaps, err := attutil.Overlaps(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, true, overlaps)
+	overlaps, err = attutil.Overlaps(a, c)
+	require.NoError(t, err)
+	assert.Equal(t, false, overlaps)
+
+	_, err = attutil.MergeBits(a, b)
+	require.ErrorIs(t, err, attutil.ErrBitsOverlap)
+	merged, err := attutil.MergeBits(a, c)
+	require.NoError(t, err)
+	assert.DeepEqual(t, bitfield.Bitlist{0b00011111}, merged)
+
+	_, err = attutil.MergeBits(a, bitfield.Bitlist{0b00000001, 0b1})
+	require.NotNil(t, err)
+}
+
+func TestMergeAttestations(t *testing.T) {
+	sk1, err := bls.RandKey()
+	require.NoError(t, err)
+	sk2, err := bls.RandKey()
+	require.NoError(t, err)
+	msg := [32]byte{'a'}
+	a := &ethpb.Attestation{AggregationBits: bitfield.Bitlist{0b00010001}, Data: &ethpb.AttestationData{}, Signature: sk1.Sign(msg[:]).Marshal()}
+	b := &ethpb.Attestation{AggregationBits: bitfield.Bitlist{0b00010010}, Data: &ethpb.AttestationData{}, Signature: sk2.Sign(msg[:]).Marshal()}
+
+	merged, err := attutil.MergeAttestations(a, b)
+	require.NoError(t, err)
+	assert.DeepEqual(t, bitfield.Bitlist{0b00010011}, merged.AggregationBits)
+	sig, err := bls.SignatureFromBytes(merged.Signature)
+	require.NoError(t, err)
+	assert.Equal(t, true, sig.FastAggregateVerify([]bls.PublicKey{sk1.PublicKey(), sk2.PublicKey()}, msg))
+	// The inputs are not modified.
+	assert.DeepEqual(t, bitfield.Bitlist{0b00010001}, a.AggregationBits)
+
+	_, err = attutil.MergeAttestations(a, a)
+	require.ErrorIs(t, err, attutil.ErrBitsOverlap)
+}
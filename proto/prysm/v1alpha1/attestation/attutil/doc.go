@@ -0,0 +1,6 @@
+// Package attutil exposes the attestation utilities needed by software built around a beacon node, such as
+// attestation pools and relays: aggregation bitfield operations and attestation deduplication keys. It does not
+// depend on the beacon node packages and its API is kept backwards compatible, so it can be depended on instead
+// of copying Prysm internals. Committees are computed by the caller, for instance with the BeaconCommittee and
+// EpochCommittees functions of the beacon-chain/core/helpers package.
+package attutil
@@ -0,0 +1,29 @@
+package attutil
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+// DataKey returns the key of the attestation data, the hash tree root of the data. Attestations with the same data
+// key can be aggregated together.
+func DataKey(data *ethpb.AttestationData) ([32]byte, error) {
+	if data == nil {
+		return [32]byte{}, errors.New("nil attestation data")
+	}
+	return data.HashTreeRoot()
+}
+
+// Key returns the deduplication key of the attestation, derived from its data and aggregation bits. The
+// signature is left out, as attestations with the same data and attesters are duplicates of each other.
+func Key(att *ethpb.Attestation) ([32]byte, error) {
+	if att == nil {
+		return [32]byte{}, errors.New("nil attestation")
+	}
+	root, err := DataKey(att.Data)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return hash.Hash(append(root[:], att.AggregationBits...)), nil
+}
@@ -0,0 +1,44 @@
+package attutil_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/attestation/attutil"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestKey(t *testing.T) {
+	att := util.HydrateAttestation(&ethpb.Attestation{AggregationBits: bitfield.Bitlist{0b00010001}})
+	key, err := attutil.Key(att)
+	require.NoError(t, err)
+
+	// The signature is not part of the key.
+	dup := ethpb.CopyAttestation(att)
+	dup.Signature = make([]byte, 96)
+	dup.Signature[0] = 1
+	dupKey, err := attutil.Key(dup)
+	require.NoError(t, err)
+	assert.Equal(t, key, dupKey)
+
+	other := ethpb.CopyAttestation(att)
+	other.AggregationBits = bitfield.Bitlist{0b00010010}
+	otherKey, err := attutil.Key(other)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherKey)
+
+	// Attestations with different bits share the same data key.
+	dataKey, err := attutil.DataKey(att.Data)
+	require.NoError(t, err)
+	otherDataKey, err := attutil.DataKey(other.Data)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, otherDataKey)
+
+	_, err = attutil.Key(nil)
+	require.ErrorContains(t, "nil attestation", err)
+	_, err = attutil.Key(&ethpb.Attestation{})
+	require.ErrorContains(t, "nil attestation data", err)
+}
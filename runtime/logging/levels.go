@@ -0,0 +1,111 @@
+// Package logging allows changing the verbosity of the logs of the whole process,
+// or of single modules identified by their log prefix, while it is running.
+package logging
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// prefixField is the field under which modules record their name in their log entries.
+const prefixField = "prefix"
+
+var levels = &moduleLevels{base: logrus.InfoLevel, modules: make(map[string]logrus.Level)}
+
+type moduleLevels struct {
+	sync.RWMutex
+	base    logrus.Level
+	modules map[string]logrus.Level
+}
+
+// SetLevel sets the log level of all modules which do not have their own level.
+func SetLevel(level logrus.Level) {
+	levels.Lock()
+	defer levels.Unlock()
+	levels.base = level
+	levels.apply()
+}
+
+// SetModuleLevel sets the log level of the module with the given log prefix, overriding the base level.
+func SetModuleLevel(module string, level logrus.Level) {
+	levels.Lock()
+	defer levels.Unlock()
+	levels.modules[module] = level
+	levels.apply()
+}
+
+// ResetModuleLevel makes the module with the given log prefix log at the base level again.
+func ResetModuleLevel(module string) {
+	levels.Lock()
+	defer levels.Unlock()
+	delete(levels.modules, module)
+	levels.apply()
+}
+
+// Levels returns the base log level and the levels of the modules which override it.
+func Levels() (logrus.Level, map[string]logrus.Level) {
+	levels.RLock()
+	defer levels.RUnlock()
+	modules := make(map[string]logrus.Level, len(levels.modules))
+	for m, l := range levels.modules {
+		modules[m] = l
+	}
+	return levels.base, modules
+}
+
+// apply sets the level of the standard logger to the most verbose of the configured levels, so that logrus
+// does not drop entries before they reach the module formatter and hooks. The lock must be held by the caller.
+func (l *moduleLevels) apply() {
+	level := l.base
+	for _, ml := range l.modules {
+		if ml > level {
+			level = ml
+		}
+	}
+	logrus.SetLevel(level)
+}
+
+func (l *moduleLevels) enabled(entry *logrus.Entry) bool {
+	l.RLock()
+	defer l.RUnlock()
+	// Without module levels, the level of the standard logger already filters the entries.
+	if len(l.modules) == 0 {
+		return true
+	}
+	level := l.base
+	if module, ok := entry.Data[prefixField].(string); ok {
+		if ml, ok := l.modules[module]; ok {
+			level = ml
+		}
+	}
+	return entry.Level <= level
+}
+
+// ModuleFormatter wraps a formatter and drops the entries which are more verbose than the level of the module they
+// were logged by. It has to be set as the formatter of the standard logger for module levels to take effect.
+type ModuleFormatter struct {
+	logrus.Formatter
+}
+
+// Format formats the entry with the wrapped formatter, or returns no output if the entry's module does not log at its level.
+func (f *ModuleFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if !levels.enabled(entry) {
+		return nil, nil
+	}
+	return f.Formatter.Format(entry)
+}
+
+// ModuleHook wraps a hook and only fires it for the entries which are logged at the level of their module, as hooks
+// are fired before, and regardless of, the formatter.
+type ModuleHook struct {
+	logrus.Hook
+}
+
+// Fire fires the wrapped hook, unless the entry's module does not log at its level.
+func (h *ModuleHook) Fire(entry *logrus.Entry) error {
+	if !levels.enabled(entry) {
+		return nil
+	}
+	return h.Hook.Fire(entry)
+}
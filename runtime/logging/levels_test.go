@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/sirupsen/logrus"
+)
+
+func TestModuleLevels(t *testing.T) {
+	prevFormatter, prevOut, prevLevel := logrus.StandardLogger().Formatter, logrus.StandardLogger().Out, logrus.GetLevel()
+	defer func() {
+		logrus.SetFormatter(prevFormatter)
+		logrus.SetOutput(prevOut)
+		SetLevel(prevLevel)
+		ResetModuleLevel("sync")
+		ResetModuleLevel("p2p")
+	}()
+
+	var buf bytes.Buffer
+	logrus.SetOutput(&buf)
+	logrus.SetFormatter(&ModuleFormatter{Formatter: &logrus.TextFormatter{DisableTimestamp: true}})
+	SetLevel(logrus.InfoLevel)
+	SetModuleLevel("sync", logrus.DebugLevel)
+	SetModuleLevel("p2p", logrus.ErrorLevel)
+	assert.Equal(t, logrus.DebugLevel, logrus.GetLevel())
+
+	logrus.WithField("prefix", "sync").Debug("sync debug")
+	logrus.WithField("prefix", "p2p").Warn("p2p warning")
+	logrus.WithField("prefix", "p2p").Error("p2p error")
+	logrus.WithField("prefix", "rpc").Debug("rpc debug")
+	logrus.WithField("prefix", "rpc").Info("rpc info")
+	logrus.Debug("no prefix debug")
+
+	out := buf.String()
+	assert.StringContains(t, "sync debug", out)
+	assert.StringNotContains(t, "p2p warning", out)
+	assert.StringContains(t, "p2p error", out)
+	assert.StringNotContains(t, "rpc debug", out)
+	assert.StringContains(t, "rpc info", out)
+	assert.StringNotContains(t, "no prefix debug", out)
+
+	base, modules := Levels()
+	assert.Equal(t, logrus.InfoLevel, base)
+	require.Equal(t, 2, len(modules))
+	assert.Equal(t, logrus.DebugLevel, modules["sync"])
+
+	ResetModuleLevel("sync")
+	assert.Equal(t, logrus.InfoLevel, logrus.GetLevel())
+}
+
+type recordingHook struct {
+	messages []string
+}
+
+func (h *recordingHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *recordingHook) Fire(entry *logrus.Entry) error {
+	h.messages = append(h.messages, entry.Message)
+	return nil
+}
+
+func TestModuleHook(t *testing.T) {
+	prevHooks, prevOut, prevLevel := logrus.StandardLogger().Hooks, logrus.StandardLogger().Out, logrus.GetLevel()
+	defer func() {
+		logrus.StandardLogger().ReplaceHooks(prevHooks)
+		logrus.SetOutput(prevOut)
+		SetLevel(prevLevel)
+		ResetModuleLevel("sync")
+	}()
+
+	hook := &recordingHook{}
+	logrus.StandardLogger().ReplaceHooks(make(logrus.LevelHooks))
+	logrus.AddHook(&ModuleHook{Hook: hook})
+	logrus.SetOutput(&bytes.Buffer{})
+	SetLevel(logrus.InfoLevel)
+
+	logrus.WithField("prefix", "rpc").Info("rpc info")
+	SetModuleLevel("sync", logrus.DebugLevel)
+	logrus.WithField("prefix", "sync").Debug("sync debug")
+	logrus.WithField("prefix", "rpc").Debug("rpc debug")
+	logrus.WithField("prefix", "rpc").Info("rpc info again")
+
+	assert.DeepEqual(t, []string{"rpc info", "sync debug", "rpc info again"}, hook.messages)
+}
@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -17,16 +18,23 @@ var buildDate = "Moments ago"
 var buildDateUnix = "0"
 var gitTag = "Unknown"
 
+// The build info which is not stamped by the linker is resolved once, as it may run git.
+var resolveBuildInfo sync.Once
+
 // Version returns the version string of this build.
 func Version() string {
-	if buildDate == "{DATE}" {
-		now := time.Now().Format(time.RFC3339)
-		buildDate = now
-	}
-	if buildDateUnix == "{DATE_UNIX}" {
-		buildDateUnix = strconv.Itoa(int(time.Now().Unix()))
+	date := BuildDate()
+	if date == "" {
+		date = "unknown"
 	}
-	return fmt.Sprintf("%s. Built at: %s", BuildData(), buildDate)
+	return fmt.Sprintf("%s. Built at: %s", BuildData(), date)
+}
+
+// BuildDate returns the date of this build in RFC 3339, or an empty string when the build is not stamped with its
+// date.
+func BuildDate() string {
+	resolveBuildInfo.Do(resolve)
+	return buildDate
 }
 
 // SemanticVersion returns the Major.Minor.Patch version of this build.
@@ -36,6 +44,16 @@ func SemanticVersion() string {
 
 // BuildData returns the git tag and commit of the current build.
 func BuildData() string {
+	return fmt.Sprintf("Prysm/%s/%s", gitTag, GitCommit())
+}
+
+// GitCommit returns the git commit of the current build.
+func GitCommit() string {
+	resolveBuildInfo.Do(resolve)
+	return gitCommit
+}
+
+func resolve() {
 	// if doing a local build, these values are not interpolated
 	if gitCommit == "{STABLE_GIT_COMMIT}" {
 		commit, err := exec.Command("git", "rev-parse", "HEAD").Output()
@@ -45,5 +63,9 @@ func BuildData() string {
 			gitCommit = strings.TrimRight(string(commit), "\r\n")
 		}
 	}
-	return fmt.Sprintf("Prysm/%s/%s", gitTag, gitCommit)
+	// The stamped date separates the date and the time with a space, so the stamped timestamp is formatted instead.
+	buildDate = ""
+	if sec, err := strconv.ParseInt(buildDateUnix, 10, 64); err == nil && sec > 0 {
+		buildDate = time.Unix(sec, 0).UTC().Format(time.RFC3339)
+	}
 }
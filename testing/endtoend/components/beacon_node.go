@@ -285,7 +285,11 @@ func (node *BeaconNode) Start(ctx context.Context) error {
 		args = append(args, features.E2EBeaconChainFlags...)
 	}
 	if config.UseBuilder {
-		args = append(args, fmt.Sprintf("--%s=%s:%d", flags.MevRelayEndpoint.Name, "http://127.0.0.1", e2e.TestParams.Ports.Eth1ProxyPort+index))
+		sk, err := builderSecretKey(index)
+		if err != nil {
+			return err
+		}
+		args = append(args, fmt.Sprintf("--%s=http://%#x@127.0.0.1:%d", flags.MevRelayEndpoint.Name, sk.PublicKey().Marshal(), e2e.TestParams.Ports.Eth1ProxyPort+index))
 	}
 	args = append(args, config.BeaconFlags...)
 
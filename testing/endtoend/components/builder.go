@@ -10,7 +10,9 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v4/io/file"
+	"github.com/prysmaticlabs/prysm/v4/runtime/interop"
 	"github.com/prysmaticlabs/prysm/v4/testing/endtoend/helpers"
 	e2e "github.com/prysmaticlabs/prysm/v4/testing/endtoend/params"
 	e2etypes "github.com/prysmaticlabs/prysm/v4/testing/endtoend/types"
@@ -116,6 +118,19 @@ func (s *BuilderSet) ComponentAtIndex(i int) (e2etypes.ComponentRunner, error) {
 	return s.builders[i], nil
 }
 
+// builderKeyOffset is the interop key index of the first builder key, beyond any validator key of the tests.
+const builderKeyOffset = 1 << 20
+
+// builderSecretKey returns the deterministic key the builder at the given index signs its bids with, so that beacon
+// nodes can pin it in the relay URL.
+func builderSecretKey(index int) (bls.SecretKey, error) {
+	keys, _, err := interop.DeterministicallyGenerateKeys(uint64(builderKeyOffset+index), 1)
+	if err != nil {
+		return nil, err
+	}
+	return keys[0], nil
+}
+
 // Builder represents a block builder.
 type Builder struct {
 	e2etypes.ComponentRunner
@@ -148,8 +163,13 @@ func (node *Builder) Start(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	sk, err := builderSecretKey(node.index)
+	if err != nil {
+		return err
+	}
 	opts := []builder.Option{
 		builder.WithDestinationAddress(fmt.Sprintf("http://127.0.0.1:%d", e2e.TestParams.Ports.Eth1AuthRPCPort+node.index)),
+		builder.WithSecretKey(sk),
 		builder.WithPort(e2e.TestParams.Ports.Eth1ProxyPort + node.index),
 		builder.WithLogger(logrus.New()),
 		builder.WithLogFile(f),
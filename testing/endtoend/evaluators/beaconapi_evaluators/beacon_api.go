@@ -13,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/beacon"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/node"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/validator"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
@@ -194,17 +195,17 @@ var beaconPathsAndObjects = map[string]metadata{
 			return []string{}
 		},
 		prysmResps: map[string]interface{}{
-			"json": &apimiddleware.IdentityResponseJson{},
+			"json": &node.GetIdentityResponse{},
 		},
 		lighthouseResps: map[string]interface{}{
-			"json": &apimiddleware.IdentityResponseJson{},
+			"json": &node.GetIdentityResponse{},
 		},
 		customEvaluation: func(prysmResp interface{}, lhouseResp interface{}) error {
-			castedp, ok := prysmResp.(*apimiddleware.IdentityResponseJson)
+			castedp, ok := prysmResp.(*node.GetIdentityResponse)
 			if !ok {
 				return errors.New("failed to cast type")
 			}
-			castedl, ok := lhouseResp.(*apimiddleware.IdentityResponseJson)
+			castedl, ok := lhouseResp.(*node.GetIdentityResponse)
 			if !ok {
 				return errors.New("failed to cast type")
 			}
@@ -121,6 +121,13 @@ func New(opts ...Option) (*Builder, error) {
 	if p.cfg.destinationUrl == nil {
 		return nil, errors.New("must provide a destination address for request proxying")
 	}
+	if p.cfg.secretKey == nil {
+		sk, err := bls.RandKey()
+		if err != nil {
+			return nil, err
+		}
+		p.cfg.secretKey = sk
+	}
 	endpoint := network.HttpEndpoint(p.cfg.destinationUrl.String())
 	endpoint.Auth.Method = authorization.Bearer
 	endpoint.Auth.Value = p.cfg.secret
@@ -286,12 +293,7 @@ func (p *Builder) handleHeaderRequest(w http.ResponseWriter, req *http.Request)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	secKey, err := bls.RandKey()
-	if err != nil {
-		p.cfg.logger.WithError(err).Error("Could not retrieve secret key")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	secKey := p.cfg.secretKey
 	wObj, err := blocks.WrappedExecutionPayload(b)
 	if err != nil {
 		p.cfg.logger.WithError(err).Error("Could not wrap execution payload")
@@ -362,12 +364,7 @@ func (p *Builder) handleHeaderRequestCapella(w http.ResponseWriter) {
 		return
 	}
 
-	secKey, err := bls.RandKey()
-	if err != nil {
-		p.cfg.logger.WithError(err).Error("Could not retrieve secret key")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	secKey := p.cfg.secretKey
 	v := big.NewInt(0).SetBytes(bytesutil.ReverseByteOrder(b.Value))
 	// we set the payload value as twice its actual one so that it always chooses builder payloads vs local payloads
 	v = v.Mul(v, big.NewInt(2))
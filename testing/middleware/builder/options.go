@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
 	"github.com/sirupsen/logrus"
 )
 
@@ -14,6 +15,7 @@ type config struct {
 	destinationUrl *url.URL
 	logger         *logrus.Logger
 	secret         string
+	secretKey      bls.SecretKey
 }
 
 type Option func(p *Builder) error
@@ -77,3 +79,11 @@ func WithLogFile(f *os.File) Option {
 		return nil
 	}
 }
+
+// WithSecretKey sets the key the builder signs its bids with. A random key is used otherwise.
+func WithSecretKey(sk bls.SecretKey) Option {
+	return func(p *Builder) error {
+		p.cfg.secretKey = sk
+		return nil
+	}
+}
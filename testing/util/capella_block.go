@@ -157,6 +157,12 @@ func GenerateFullBlockCapella(
 	if err != nil {
 		return nil, errors.Wrap(err, "could not hash the new header")
 	}
+	if conf.FullSyncAggregate {
+		newSyncAggregate, err = generateSyncAggregate(bState, privs, parentRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed generating syncAggregate")
+		}
+	}
 
 	if slot == currentSlot {
 		slot = currentSlot + 1
@@ -0,0 +1,78 @@
+package util
+
+import (
+	"testing"
+
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+// NewLightClientHeader returns a light client header at the given slot. From Capella on, it holds the
+// execution payload header of the fork at the slot, with the slot as block number, and an empty branch.
+func NewLightClientHeader(t testing.TB, slot primitives.Slot) *lightclient.Header {
+	h := &lightclient.Header{Beacon: HydrateBeaconHeader(&ethpb.BeaconBlockHeader{Slot: slot})}
+	var err error
+	switch h.Version() {
+	case version.Deneb:
+		e := HydrateBlindedBeaconBlockBodyDeneb(nil).ExecutionPayloadHeader
+		e.BlockNumber = uint64(slot)
+		h.Execution, err = blocks.WrappedExecutionPayloadHeaderDeneb(e, 0)
+	case version.Capella:
+		e := HydrateBlindedBeaconBlockBodyCapella(nil).ExecutionPayloadHeader
+		e.BlockNumber = uint64(slot)
+		h.Execution, err = blocks.WrappedExecutionPayloadHeaderCapella(e, 0)
+	default:
+		return h
+	}
+	require.NoError(t, err)
+	h.ExecutionBranch = make([][]byte, 4)
+	for i := range h.ExecutionBranch {
+		h.ExecutionBranch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return h
+}
+
+// HydrateLightClientUpdate hydrates a light client update with correct field length sizes
+// to comply with fssz marshalling and unmarshalling rules.
+func HydrateLightClientUpdate(u *lightclient.Update) *lightclient.Update {
+	if u == nil {
+		u = &lightclient.Update{}
+	}
+	u.AttestedHeader = hydrateLightClientHeader(u.AttestedHeader)
+	if u.NextSyncCommittee == nil {
+		u.NextSyncCommittee = ConvertToCommittee(nil)
+	}
+	if u.NextSyncCommitteeBranch == nil {
+		u.NextSyncCommitteeBranch = make([][]byte, 5)
+		for i := range u.NextSyncCommitteeBranch {
+			u.NextSyncCommitteeBranch[i] = make([]byte, fieldparams.RootLength)
+		}
+	}
+	u.FinalizedHeader = hydrateLightClientHeader(u.FinalizedHeader)
+	if u.FinalityBranch == nil {
+		u.FinalityBranch = make([][]byte, 6)
+		for i := range u.FinalityBranch {
+			u.FinalityBranch[i] = make([]byte, fieldparams.RootLength)
+		}
+	}
+	if u.SyncAggregate == nil {
+		u.SyncAggregate = &ethpb.SyncAggregate{
+			SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
+			SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
+		}
+	}
+	return u
+}
+
+func hydrateLightClientHeader(h *lightclient.Header) *lightclient.Header {
+	if h == nil {
+		h = &lightclient.Header{}
+	}
+	h.Beacon = HydrateBeaconHeader(h.Beacon)
+	return h
+}
@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	primitives "github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	iface "github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -67,6 +68,36 @@ func (mr *MockValidatorClientMockRecorder) DomainData(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DomainData", reflect.TypeOf((*MockValidatorClient)(nil).DomainData), arg0, arg1)
 }
 
+// GetAggregatedSelections mocks base method.
+func (m *MockValidatorClient) GetAggregatedSelections(arg0 context.Context, arg1 []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregatedSelections", arg0, arg1)
+	ret0, _ := ret[0].([]iface.BeaconCommitteeSelection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAggregatedSelections indicates an expected call of GetAggregatedSelections.
+func (mr *MockValidatorClientMockRecorder) GetAggregatedSelections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregatedSelections", reflect.TypeOf((*MockValidatorClient)(nil).GetAggregatedSelections), arg0, arg1)
+}
+
+// GetAggregatedSyncSelections mocks base method.
+func (m *MockValidatorClient) GetAggregatedSyncSelections(arg0 context.Context, arg1 []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregatedSyncSelections", arg0, arg1)
+	ret0, _ := ret[0].([]iface.SyncCommitteeSelection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAggregatedSyncSelections indicates an expected call of GetAggregatedSyncSelections.
+func (mr *MockValidatorClientMockRecorder) GetAggregatedSyncSelections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregatedSyncSelections", reflect.TypeOf((*MockValidatorClient)(nil).GetAggregatedSyncSelections), arg0, arg1)
+}
+
 // GetAttestationData mocks base method.
 func (m *MockValidatorClient) GetAttestationData(arg0 context.Context, arg1 *eth.AttestationDataRequest) (*eth.AttestationData, error) {
 	m.ctrl.T.Helper()
@@ -172,6 +203,21 @@ func (mr *MockValidatorClientMockRecorder) GetSyncSubcommitteeIndex(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncSubcommitteeIndex", reflect.TypeOf((*MockValidatorClient)(nil).GetSyncSubcommitteeIndex), arg0, arg1)
 }
 
+// GetValidatorsLiveness mocks base method.
+func (m *MockValidatorClient) GetValidatorsLiveness(arg0 context.Context, arg1 primitives.Epoch, arg2 []primitives.ValidatorIndex) ([]*iface.ValidatorLiveness, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorsLiveness", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*iface.ValidatorLiveness)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorsLiveness indicates an expected call of GetValidatorsLiveness.
+func (mr *MockValidatorClientMockRecorder) GetValidatorsLiveness(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorsLiveness", reflect.TypeOf((*MockValidatorClient)(nil).GetValidatorsLiveness), arg0, arg1, arg2)
+}
+
 // MultipleValidatorStatus mocks base method.
 func (m *MockValidatorClient) MultipleValidatorStatus(arg0 context.Context, arg1 *eth.MultipleValidatorStatusRequest) (*eth.MultipleValidatorStatusResponse, error) {
 	m.ctrl.T.Helper()
@@ -6,20 +6,28 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/beacon"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/io/file"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"github.com/prysmaticlabs/prysm/v4/validator/client"
 	beacon_api "github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	"github.com/prysmaticlabs/prysm/v4/validator/keymanager"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -33,6 +41,13 @@ type PerformExitCfg struct {
 	OutputDirectory  string
 }
 
+// OfflineExitCfg defines what is needed to sign voluntary exits without a beacon node.
+type OfflineExitCfg struct {
+	Epoch                 primitives.Epoch
+	GenesisValidatorsRoot []byte
+	ValidatorIndices      map[[fieldparams.BLSPubkeyLength]byte]primitives.ValidatorIndex
+}
+
 // ExitPassphrase exported for use in test.
 const ExitPassphrase = "Exit my validator"
 
@@ -43,6 +58,22 @@ func (acm *AccountsCLIManager) Exit(ctx context.Context) error {
 		return nil
 	}
 
+	if acm.offlineExit != nil {
+		if acm.exitJSONOutputPath == "" {
+			return errors.New("an output directory is required to sign voluntary exits offline")
+		}
+		rawSignedKeys, _, err := SignVoluntaryExitsOffline(ctx, acm.keymanager, acm.offlineExit, acm.rawPubKeys, acm.formattedPubKeys, acm.exitJSONOutputPath)
+		if err != nil {
+			return err
+		}
+		log.WithFields(logrus.Fields{
+			"signed": len(rawSignedKeys),
+			"failed": len(acm.rawPubKeys) - len(rawSignedKeys),
+		}).Infof("Signed voluntary exits were written to %s, they can be broadcast to any beacon node with "+
+			"POST /eth/v1/beacon/pool/voluntary_exits", acm.exitJSONOutputPath)
+		return nil
+	}
+
 	validatorClient, nodeClient, err := acm.prepareBeaconClients(ctx)
 	if err != nil {
 		return err
@@ -141,6 +172,112 @@ func PerformVoluntaryExit(
 	return rawExitedKeys, formattedExitedKeys, nil
 }
 
+// SignVoluntaryExitsOffline signs a voluntary exit for each of the keys and writes them to the output directory,
+// without querying a beacon node. Keys whose exit could not be signed are logged and skipped.
+func SignVoluntaryExitsOffline(
+	ctx context.Context,
+	km keymanager.IKeymanager,
+	cfg *OfflineExitCfg,
+	rawPubKeys [][]byte,
+	formattedPubKeys []string,
+	outputDirectory string,
+) (rawSignedKeys [][]byte, formattedSignedKeys []string, err error) {
+	domain, err := offlineExitDomain(cfg.Epoch, cfg.GenesisValidatorsRoot)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not compute voluntary exit domain")
+	}
+	slot, err := slots.EpochStart(cfg.Epoch)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve slot")
+	}
+
+	rawSignedKeys = make([][]byte, 0, len(rawPubKeys))
+	formattedSignedKeys = make([]string, 0, len(rawPubKeys))
+	for i, key := range rawPubKeys {
+		index, ok := cfg.ValidatorIndices[bytesutil.ToBytes48(key)]
+		if !ok {
+			log.Errorf("Could not find the validator index of account %s", formattedPubKeys[i])
+			continue
+		}
+		exit := &eth.VoluntaryExit{Epoch: cfg.Epoch, ValidatorIndex: index}
+		exitRoot, err := signing.ComputeSigningRoot(exit, domain)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "could not compute voluntary exit signing root")
+		}
+		sig, err := km.Sign(ctx, &validatorpb.SignRequest{
+			PublicKey:       key,
+			SigningRoot:     exitRoot[:],
+			SignatureDomain: domain,
+			Object:          &validatorpb.SignRequest_Exit{Exit: exit},
+			SigningSlot:     slot,
+		})
+		if err != nil {
+			log.WithError(err).Errorf("Could not sign voluntary exit for account %s", formattedPubKeys[i])
+			continue
+		}
+		sve := &eth.SignedVoluntaryExit{Exit: exit, Signature: sig.Marshal()}
+		if err := writeSignedVoluntaryExitJSON(ctx, sve, outputDirectory); err != nil {
+			log.WithError(err).Errorf("Could not write voluntary exit for account %s", formattedPubKeys[i])
+			continue
+		}
+		rawSignedKeys = append(rawSignedKeys, key)
+		formattedSignedKeys = append(formattedSignedKeys, formattedPubKeys[i])
+	}
+	return rawSignedKeys, formattedSignedKeys, nil
+}
+
+// offlineExitDomain computes the voluntary exit signature domain for the epoch from the fork schedule of the beacon
+// config. From Deneb onwards, exits are signed with the Capella fork version (EIP-7044).
+func offlineExitDomain(epoch primitives.Epoch, genesisValidatorsRoot []byte) ([]byte, error) {
+	if len(genesisValidatorsRoot) != fieldparams.RootLength {
+		return nil, errors.Errorf("genesis validators root must be %d bytes long", fieldparams.RootLength)
+	}
+	fork, err := forks.Fork(epoch)
+	if err != nil {
+		return nil, err
+	}
+	if epoch >= params.BeaconConfig().DenebForkEpoch {
+		fork = &eth.Fork{
+			PreviousVersion: params.BeaconConfig().CapellaForkVersion,
+			CurrentVersion:  params.BeaconConfig().CapellaForkVersion,
+			Epoch:           params.BeaconConfig().CapellaForkEpoch,
+		}
+	}
+	return signing.Domain(fork, epoch, params.BeaconConfig().DomainVoluntaryExit, genesisValidatorsRoot)
+}
+
+// ValidatorIndicesFromFile reads the indices of validators from a file holding a response of the
+// /eth/v1/beacon/states/{state_id}/validators Beacon API endpoint.
+func ValidatorIndicesFromFile(path string) (map[[fieldparams.BLSPubkeyLength]byte]primitives.ValidatorIndex, error) {
+	b, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read validator indices file")
+	}
+	var resp beacon.GetValidatorsResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, errors.Wrap(err, "could not decode validator indices file")
+	}
+	indices := make(map[[fieldparams.BLSPubkeyLength]byte]primitives.ValidatorIndex, len(resp.Data))
+	for _, v := range resp.Data {
+		if v == nil || v.Validator == nil {
+			continue
+		}
+		pubkey, err := hexutil.Decode(v.Validator.Pubkey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public key %s", v.Validator.Pubkey)
+		}
+		if len(pubkey) != fieldparams.BLSPubkeyLength {
+			return nil, errors.Errorf("invalid public key %s", v.Validator.Pubkey)
+		}
+		index, err := strconv.ParseUint(v.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid validator index %s", v.Index)
+		}
+		indices[bytesutil.ToBytes48(pubkey)] = primitives.ValidatorIndex(index)
+	}
+	return indices, nil
+}
+
 func prepareAllKeys(validatingKeys [][fieldparams.BLSPubkeyLength]byte) (raw [][]byte, formatted []string) {
 	raw = make([][]byte, len(validatingKeys))
 	formatted = make([]string, len(validatingKeys))
@@ -5,16 +5,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
 	"github.com/prysmaticlabs/prysm/v4/build/bazel"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/io/file"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/keymanager/local"
 	"github.com/sirupsen/logrus/hooks/test"
 )
 
@@ -65,3 +73,69 @@ func TestWriteSignedVoluntaryExitJSON(t *testing.T) {
 	require.Equal(t, fmt.Sprintf("%d", sve.Exit.ValidatorIndex), svej.Exit.ValidatorIndex)
 	require.Equal(t, "0x0102", svej.Signature)
 }
+
+func TestSignVoluntaryExitsOffline(t *testing.T) {
+	ctx := context.Background()
+	km, err := local.NewInteropKeymanager(ctx, 0, 2)
+	require.NoError(t, err)
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	raw, formatted := prepareAllKeys(keys)
+
+	gvr := bytesutil.PadTo([]byte("genesis validators root"), fieldparams.RootLength)
+	cfg := &OfflineExitCfg{
+		Epoch:                 5,
+		GenesisValidatorsRoot: gvr,
+		// The second key has no known index and is skipped.
+		ValidatorIndices: map[[fieldparams.BLSPubkeyLength]byte]primitives.ValidatorIndex{keys[0]: 300},
+	}
+	output := path.Join(t.TempDir(), "exits")
+	signedRaw, signedFormatted, err := SignVoluntaryExitsOffline(ctx, km, cfg, raw, formatted, output)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(signedRaw))
+	assert.DeepEqual(t, raw[0], signedRaw[0])
+	assert.DeepEqual(t, []string{formatted[0]}, signedFormatted)
+
+	b, err := file.ReadFileAsBytes(path.Join(output, "validator-exit-300.json"))
+	require.NoError(t, err)
+	sve := &apimiddleware.SignedVoluntaryExitJson{}
+	require.NoError(t, json.Unmarshal(b, sve))
+	assert.Equal(t, "5", sve.Exit.Epoch)
+	assert.Equal(t, "300", sve.Exit.ValidatorIndex)
+
+	fork, err := forks.Fork(5)
+	require.NoError(t, err)
+	domain, err := signing.Domain(fork, 5, params.BeaconConfig().DomainVoluntaryExit, gvr)
+	require.NoError(t, err)
+	root, err := signing.ComputeSigningRoot(&eth.VoluntaryExit{Epoch: 5, ValidatorIndex: 300}, domain)
+	require.NoError(t, err)
+	sig, err := bls.SignatureFromBytes(hexutil.MustDecode(sve.Signature))
+	require.NoError(t, err)
+	pubkey, err := bls.PublicKeyFromBytes(raw[0])
+	require.NoError(t, err)
+	assert.Equal(t, true, sig.Verify(pubkey, root[:]))
+}
+
+func TestSignVoluntaryExitsOffline_InvalidGenesisValidatorsRoot(t *testing.T) {
+	ctx := context.Background()
+	km, err := local.NewInteropKeymanager(ctx, 0, 1)
+	require.NoError(t, err)
+	_, _, err = SignVoluntaryExitsOffline(ctx, km, &OfflineExitCfg{GenesisValidatorsRoot: []byte{0x01}}, nil, nil, "")
+	assert.ErrorContains(t, "genesis validators root must be 32 bytes long", err)
+}
+
+func TestValidatorIndicesFromFile(t *testing.T) {
+	pubkey := "0x" + strings.Repeat("ab", fieldparams.BLSPubkeyLength)
+	p := path.Join(t.TempDir(), "validators.json")
+	content := fmt.Sprintf(`{"data":[{"index":"12","validator":{"pubkey":"%s"}}]}`, pubkey)
+	require.NoError(t, file.WriteFile(p, []byte(content)))
+
+	indices, err := ValidatorIndicesFromFile(p)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(indices))
+	assert.Equal(t, primitives.ValidatorIndex(12), indices[bytesutil.ToBytes48(hexutil.MustDecode(pubkey))])
+
+	require.NoError(t, file.WriteFile(p, []byte(`{"data":[{"index":"12","validator":{"pubkey":"0x01"}}]}`)))
+	_, err = ValidatorIndicesFromFile(p)
+	assert.ErrorContains(t, "invalid public key 0x01", err)
+}
@@ -198,6 +198,8 @@ func FilterExitAccountsFromUserInput(
 		fmt.Printf("About to perform a voluntary exit of %d accounts\n", len(rawPubKeys))
 	}
 
+	printExitSummary(cliCtx, formattedPubKeys)
+
 	if forceExit {
 		return rawPubKeys, formattedPubKeys, nil
 	}
@@ -220,3 +222,17 @@ func FilterExitAccountsFromUserInput(
 
 	return rawPubKeys, formattedPubKeys, nil
 }
+
+// printExitSummary lists the accounts about to be exited and what will be done with their signed exits, so that
+// batch exits can be reviewed before being confirmed.
+func printExitSummary(cliCtx *cli.Context, formattedPubKeys []string) {
+	action := "broadcast to the beacon node"
+	if dir := cliCtx.String(flags.VoluntaryExitJSONOutputPath.Name); dir != "" {
+		action = "written to " + dir
+	}
+	if cliCtx.Bool(flags.OfflineExitFlag.Name) {
+		action = fmt.Sprintf("signed offline for epoch %d and %s", cliCtx.Uint64(flags.ExitEpochFlag.Name), action)
+	}
+	fmt.Printf("%s\nAccounts to exit: %d\nSigned exits will be %s\n%s\n",
+		au.Bold("Voluntary exit summary"), len(formattedPubKeys), action, strings.Join(formattedPubKeys, "\n"))
+}
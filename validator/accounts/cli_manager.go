@@ -57,6 +57,7 @@ type AccountsCLIManager struct {
 	rawPubKeys           [][]byte
 	formattedPubKeys     []string
 	exitJSONOutputPath   string
+	offlineExit          *OfflineExitCfg
 	walletDir            string
 	walletPassword       string
 	mnemonic             string
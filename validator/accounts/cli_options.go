@@ -212,6 +212,14 @@ func WithExitJSONOutputPath(outputPath string) Option {
 	}
 }
 
+// WithOfflineExit signs voluntary exits without a beacon node, using the provided configuration.
+func WithOfflineExit(cfg *OfflineExitCfg) Option {
+	return func(acc *AccountsCLIManager) error {
+		acc.offlineExit = cfg
+		return nil
+	}
+}
+
 // WithWalletDir specifies the password for backups.
 func WithWalletDir(walletDir string) Option {
 	return func(acc *AccountsCLIManager) error {
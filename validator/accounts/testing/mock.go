@@ -88,8 +88,10 @@ func (_ *Wallet) InitializeKeymanager(_ context.Context, _ iface.InitKeymanagerC
 }
 
 type MockValidator struct {
-	Km               keymanager.IKeymanager
-	proposerSettings *validatorserviceconfig.ProposerSettings
+	Km                      keymanager.IKeymanager
+	DoppelgangerStatusesMap map[[48]byte]iface2.DoppelgangerStatus
+	EpochPerformances       []*iface2.EpochPerformance
+	proposerSettings        *validatorserviceconfig.ProposerSettings
 }
 
 func (_ *MockValidator) LogSyncCommitteeMessagesSubmitted() {}
@@ -182,6 +184,14 @@ func (_ *MockValidator) CheckDoppelGanger(_ context.Context) error {
 	panic("implement me")
 }
 
+func (m *MockValidator) DoppelgangerStatuses(_ context.Context) (map[[48]byte]iface2.DoppelgangerStatus, error) {
+	return m.DoppelgangerStatusesMap, nil
+}
+
+func (m *MockValidator) Performance() []*iface2.EpochPerformance {
+	return m.EpochPerformances
+}
+
 // HasProposerSettings for mocking
 func (*MockValidator) HasProposerSettings() bool {
 	panic("implement me")
@@ -0,0 +1,250 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// PagerDutyEventsURL is the endpoint of the PagerDuty Events API v2.
+	PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+	requestTimeout     = 10 * time.Second
+	alertBufferSize    = 100
+)
+
+// Kinds of alerts.
+const (
+	MissedProposal     = "missed_proposal"
+	MissedAttestations = "missed_attestations"
+	BalanceDecrease    = "balance_decrease"
+)
+
+// Config of the alerter. Thresholds of zero disable the corresponding alert.
+type Config struct {
+	// WebhookURLs receive every alert as a JSON object in a POST request.
+	WebhookURLs []string
+	// PagerDutyRoutingKey is the integration key alerts are triggered with on PagerDuty.
+	PagerDutyRoutingKey string
+	// MissedAttestations is the number of attestations missed in a row which triggers an alert.
+	MissedAttestations uint64
+	// BalanceDecreaseEpochs is the number of epochs over which net penalties trigger an alert.
+	BalanceDecreaseEpochs uint64
+}
+
+// Alert is the JSON object posted to the webhooks.
+type Alert struct {
+	Kind      string           `json:"kind"`
+	PublicKey string           `json:"pubkey"`
+	Epoch     primitives.Epoch `json:"epoch"`
+	Slot      primitives.Slot  `json:"slot,omitempty"`
+	Message   string           `json:"message"`
+	Time      time.Time        `json:"time"`
+}
+
+// Alerter fires webhooks when a validator key misses a proposal, misses several attestations in a row, or
+// loses balance over several epochs. Alerts are sent in the background, and dropped when too many are pending.
+type Alerter struct {
+	cfg                *Config
+	pagerDutyURL       string
+	client             *http.Client
+	alerts             chan *Alert
+	lock               sync.Mutex
+	missedAttestations map[[fieldparams.BLSPubkeyLength]byte]uint64
+	balanceChanges     map[[fieldparams.BLSPubkeyLength]byte][]int64
+}
+
+// New returns an alerter for the given configuration.
+func New(cfg *Config) (*Alerter, error) {
+	for _, u := range cfg.WebhookURLs {
+		parsed, err := url.ParseRequestURI(u)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid alert webhook URL")
+		}
+		if parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid alert webhook URL: %s", u)
+		}
+	}
+	return &Alerter{
+		cfg:                cfg,
+		pagerDutyURL:       PagerDutyEventsURL,
+		client:             &http.Client{Timeout: requestTimeout},
+		alerts:             make(chan *Alert, alertBufferSize),
+		missedAttestations: make(map[[fieldparams.BLSPubkeyLength]byte]uint64),
+		balanceChanges:     make(map[[fieldparams.BLSPubkeyLength]byte][]int64),
+	}, nil
+}
+
+// Start sends the alerts until the context is done.
+func (a *Alerter) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case alert := <-a.alerts:
+				a.send(ctx, alert)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// ProposalMissed alerts that the key could not propose its block at the slot.
+func (a *Alerter) ProposalMissed(pubKey [fieldparams.BLSPubkeyLength]byte, epoch primitives.Epoch, slot primitives.Slot) {
+	a.fire(&Alert{
+		Kind:      MissedProposal,
+		PublicKey: fmt.Sprintf("%#x", pubKey),
+		Epoch:     epoch,
+		Slot:      slot,
+		Message:   fmt.Sprintf("Validator %#x missed its block proposal at slot %d", bytesutil.Trunc(pubKey[:]), slot),
+	})
+}
+
+// AttestationRecorded records whether the attestation of the key during the epoch was included, and its balances
+// before and after the epoch transition. It alerts once the key missed the configured number of attestations in a
+// row, and when its epoch transitions decreased its balance over the configured number of epochs. Only the balance
+// changes of epoch transitions, which are the rewards net of penalties, are considered, so that withdrawals and
+// deposits processed in blocks do not trigger alerts.
+func (a *Alerter) AttestationRecorded(
+	pubKey [fieldparams.BLSPubkeyLength]byte, epoch primitives.Epoch, included bool, balanceBefore, balanceAfter uint64,
+) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.cfg.MissedAttestations > 0 {
+		if included {
+			a.missedAttestations[pubKey] = 0
+		} else {
+			a.missedAttestations[pubKey]++
+			if missed := a.missedAttestations[pubKey]; missed == a.cfg.MissedAttestations {
+				a.fire(&Alert{
+					Kind:      MissedAttestations,
+					PublicKey: fmt.Sprintf("%#x", pubKey),
+					Epoch:     epoch,
+					Message: fmt.Sprintf("Validator %#x missed %d attestations in a row up to epoch %d",
+						bytesutil.Trunc(pubKey[:]), missed, epoch),
+				})
+			}
+		}
+	}
+	if a.cfg.BalanceDecreaseEpochs > 0 {
+		changes := append(a.balanceChanges[pubKey], int64(balanceAfter)-int64(balanceBefore))
+		if uint64(len(changes)) >= a.cfg.BalanceDecreaseEpochs {
+			var total int64
+			for _, c := range changes {
+				total += c
+			}
+			if total < 0 {
+				a.fire(&Alert{
+					Kind:      BalanceDecrease,
+					PublicKey: fmt.Sprintf("%#x", pubKey),
+					Epoch:     epoch,
+					Message: fmt.Sprintf("Balance of validator %#x decreased by %d Gwei over the last %d epochs",
+						bytesutil.Trunc(pubKey[:]), -total, a.cfg.BalanceDecreaseEpochs),
+				})
+				// Start a new window so that a validator which keeps losing balance is not alerted every epoch.
+				changes = nil
+			} else {
+				changes = changes[1:]
+			}
+		}
+		a.balanceChanges[pubKey] = changes
+	}
+}
+
+func (a *Alerter) fire(alert *Alert) {
+	alert.Time = time.Now()
+	log.WithFields(logrus.Fields{
+		"kind":   alert.Kind,
+		"pubKey": alert.PublicKey,
+		"epoch":  alert.Epoch,
+	}).Warn(alert.Message)
+	select {
+	case a.alerts <- alert:
+	default:
+		log.WithField("kind", alert.Kind).Error("Dropped alert as too many alerts are pending")
+	}
+}
+
+func (a *Alerter) send(ctx context.Context, alert *Alert) {
+	for _, u := range a.cfg.WebhookURLs {
+		if err := a.post(ctx, u, alert); err != nil {
+			log.WithError(err).WithField("url", u).Error("Could not send alert to webhook")
+		}
+	}
+	if a.cfg.PagerDutyRoutingKey != "" {
+		if err := a.post(ctx, a.pagerDutyURL, a.pagerDutyEvent(alert)); err != nil {
+			log.WithError(err).Error("Could not send alert to PagerDuty")
+		}
+	}
+}
+
+func (a *Alerter) post(ctx context.Context, u string, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal alert")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "could not create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string `json:"summary"`
+	Source        string `json:"source"`
+	Severity      string `json:"severity"`
+	Timestamp     string `json:"timestamp"`
+	Component     string `json:"component"`
+	Class         string `json:"class"`
+	CustomDetails *Alert `json:"custom_details"`
+}
+
+// pagerDutyEvent triggers an incident per kind of alert and key, which PagerDuty groups until it is resolved.
+func (a *Alerter) pagerDutyEvent(alert *Alert) *pagerDutyEvent {
+	return &pagerDutyEvent{
+		RoutingKey:  a.cfg.PagerDutyRoutingKey,
+		EventAction: "trigger",
+		DedupKey:    alert.Kind + "-" + alert.PublicKey,
+		Payload: &pagerDutyPayload{
+			Summary:       alert.Message,
+			Source:        alert.PublicKey,
+			Severity:      "warning",
+			Timestamp:     alert.Time.UTC().Format(time.RFC3339),
+			Component:     "validator",
+			Class:         alert.Kind,
+			CustomDetails: alert,
+		},
+	}
+}
@@ -0,0 +1,99 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestNew_InvalidURL(t *testing.T) {
+	_, err := New(&Config{WebhookURLs: []string{"localhost"}})
+	assert.ErrorContains(t, "invalid alert webhook URL", err)
+}
+
+func TestAlerter_Webhooks(t *testing.T) {
+	webhook := make(chan *Alert, 10)
+	webhookSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		alert := &Alert{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(alert))
+		webhook <- alert
+	}))
+	defer webhookSrv.Close()
+	pagerDuty := make(chan *pagerDutyEvent, 10)
+	pagerDutySrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := &pagerDutyEvent{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(event))
+		pagerDuty <- event
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer pagerDutySrv.Close()
+
+	a, err := New(&Config{WebhookURLs: []string{webhookSrv.URL}, PagerDutyRoutingKey: "key"})
+	require.NoError(t, err)
+	a.pagerDutyURL = pagerDutySrv.URL
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	a.Start(ctx)
+
+	a.ProposalMissed([fieldparams.BLSPubkeyLength]byte{1}, 3, 100)
+	select {
+	case alert := <-webhook:
+		assert.Equal(t, MissedProposal, alert.Kind)
+		assert.Equal(t, "0x01", alert.PublicKey[:4])
+		assert.Equal(t, 100, int(alert.Slot))
+	case <-time.After(5 * time.Second):
+		t.Fatal("No alert sent to webhook")
+	}
+	select {
+	case event := <-pagerDuty:
+		assert.Equal(t, "key", event.RoutingKey)
+		assert.Equal(t, "trigger", event.EventAction)
+		assert.Equal(t, MissedProposal, event.Payload.Class)
+	case <-time.After(5 * time.Second):
+		t.Fatal("No alert sent to PagerDuty")
+	}
+}
+
+func TestAlerter_AttestationRecorded(t *testing.T) {
+	a, err := New(&Config{MissedAttestations: 2, BalanceDecreaseEpochs: 3})
+	require.NoError(t, err)
+	key := [fieldparams.BLSPubkeyLength]byte{1}
+
+	kinds := func() []string {
+		var kinds []string
+		for len(a.alerts) > 0 {
+			kinds = append(kinds, (<-a.alerts).Kind)
+		}
+		return kinds
+	}
+
+	// Missed attestations are only alerted once they are missed in a row.
+	a.AttestationRecorded(key, 1, false, 100, 100)
+	a.AttestationRecorded(key, 2, true, 100, 101)
+	a.AttestationRecorded(key, 3, false, 101, 102)
+	assert.DeepEqual(t, []string(nil), kinds())
+	a.AttestationRecorded(key, 4, false, 102, 101)
+	assert.DeepEqual(t, []string{MissedAttestations}, kinds())
+	// The epoch transitions decreased the balance from 101 at epoch 2 to 100 at epoch 5, the window then starts over.
+	a.AttestationRecorded(key, 5, false, 101, 100)
+	assert.DeepEqual(t, []string{BalanceDecrease}, kinds())
+	a.AttestationRecorded(key, 6, true, 100, 99)
+	a.AttestationRecorded(key, 7, true, 99, 98)
+	assert.DeepEqual(t, []string(nil), kinds())
+	a.AttestationRecorded(key, 8, true, 98, 99)
+	assert.DeepEqual(t, []string{BalanceDecrease}, kinds())
+	// Withdrawals lower the balance between epoch transitions, which earn rewards.
+	for epoch := primitives.Epoch(9); epoch < 12; epoch++ {
+		balance := uint64(100 - 10*(epoch-9))
+		a.AttestationRecorded(key, epoch, true, balance, balance+1)
+	}
+	assert.DeepEqual(t, []string(nil), kinds())
+}
@@ -0,0 +1,5 @@
+package alerting
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "alerting")
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
@@ -10,16 +11,22 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/validator-client"
 	prysmTime "github.com/prysmaticlabs/prysm/v4/time"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	"go.opencensus.io/trace"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// maxConcurrentSelectionProofs is the number of selection proofs signed concurrently when precomputing them.
+const maxConcurrentSelectionProofs = 16
+
 // SubmitAggregateAndProof submits the validator's signed slot signature to the beacon node
 // via gRPC. Beacon node will verify the slot signature and determine if the validator is also
 // an aggregator. If yes, then beacon node will broadcast aggregated signature and
@@ -50,7 +57,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot primitives
 	v.aggregatedSlotCommitteeIDCache.Add(k, true)
 	v.aggregatedSlotCommitteeIDCacheLock.Unlock()
 
-	slotSig, err := v.signSlotWithSelectionProof(ctx, pubKey, slot)
+	slotSig, err := v.attSelectionProof(ctx, slot, pubKey, duty.ValidatorIndex)
 	if err != nil {
 		log.WithError(err).Error("Could not sign slot")
 		if v.emitAccountMetrics {
@@ -142,6 +149,52 @@ func (v *validator) signSlotWithSelectionProof(ctx context.Context, pubKey [fiel
 	return sig.Marshal(), nil
 }
 
+// precomputeSelectionProofs signs and caches the selection proofs of the attester duties of the current and next
+// epochs ahead of time, concurrently, so that checking whether the validator is an aggregator and aggregating do
+// not wait for a signing round trip, which can be slow with a remote signer. A selection proof which cannot be
+// signed here is signed again when it is needed.
+func (v *validator) precomputeSelectionProofs(ctx context.Context, duties []*ethpb.DutiesResponse_Duty) {
+	ctx, span := trace.StartSpan(ctx, "validator.precomputeSelectionProofs")
+	defer span.End()
+
+	var missing []*ethpb.DutiesResponse_Duty
+	v.attSelectionsLock.Lock()
+	for _, duty := range duties {
+		if _, ok := v.attSelections[attSelectionKey{slot: duty.AttesterSlot, index: duty.ValidatorIndex}]; !ok {
+			missing = append(missing, duty)
+		}
+	}
+	v.attSelectionsLock.Unlock()
+
+	var (
+		g          errgroup.Group
+		lock       sync.Mutex
+		selections []iface.BeaconCommitteeSelection
+	)
+	g.SetLimit(maxConcurrentSelectionProofs)
+	for _, duty := range missing {
+		duty := duty
+		g.Go(func() error {
+			proof, err := v.signSlotWithSelectionProof(ctx, bytesutil.ToBytes48(duty.PublicKey), duty.AttesterSlot)
+			if err != nil {
+				log.WithError(err).WithField("slot", duty.AttesterSlot).Debug("Could not precompute selection proof")
+				return nil
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			selections = append(selections, iface.BeaconCommitteeSelection{
+				SelectionProof: proof,
+				Slot:           duty.AttesterSlot,
+				ValidatorIndex: duty.ValidatorIndex,
+			})
+			return nil
+		})
+	}
+	// The goroutines never fail, since a selection proof which cannot be signed here is signed again later.
+	_ = g.Wait()
+	v.cacheAttSelectionProofs(selections)
+}
+
 // waitToSlotTwoThirds waits until two third through the current slot period
 // such that any attestations from this slot have time to reach the beacon node
 // before creating the aggregated attestation.
@@ -151,7 +204,9 @@ func (v *validator) waitToSlotTwoThirds(ctx context.Context, slot primitives.Slo
 
 	oneThird := slots.DivideSlotBy(3 /* one third of slot duration */)
 	twoThird := oneThird + oneThird
-	delay := twoThird
+	// The aggregation offset gives a distributed validator middleware more time to reach consensus on the
+	// aggregate.
+	delay := twoThird + v.aggregationOffset
 
 	startTime := slots.StartTime(v.genesisTime, slot)
 	finalTime := startTime.Add(delay)
@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
@@ -169,3 +170,31 @@ func TestAggregateAndProofSignature_CanSignValidSignature(t *testing.T) {
 	_, err = bls.SignatureFromBytes(sig)
 	require.NoError(t, err)
 }
+
+func TestValidator_PrecomputeSelectionProofs(t *testing.T) {
+	v, m, validatorKey, finish := setup(t)
+	defer finish()
+	ctx := context.Background()
+	pubKey := validatorKey.PublicKey().Marshal()
+	duties := []*ethpb.DutiesResponse_Duty{
+		{PublicKey: pubKey, ValidatorIndex: 1, AttesterSlot: 10},
+		{PublicKey: pubKey, ValidatorIndex: 1, AttesterSlot: 40},
+	}
+
+	// The selection proofs are signed once, when they are precomputed.
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/).Times(2)
+	v.precomputeSelectionProofs(ctx, duties)
+	v.precomputeSelectionProofs(ctx, duties)
+
+	for _, duty := range duties {
+		proof, err := v.attSelectionProof(ctx, duty.AttesterSlot, bytesutil.ToBytes48(pubKey), duty.ValidatorIndex)
+		require.NoError(t, err)
+		assert.Equal(t, fieldparams.BLSSignatureLength, len(proof))
+		aggregator, err := v.isAggregator(ctx, []primitives.ValidatorIndex{1}, duty.AttesterSlot, bytesutil.ToBytes48(pubKey), duty.ValidatorIndex)
+		require.NoError(t, err)
+		assert.Equal(t, true, aggregator)
+	}
+}
@@ -155,6 +155,8 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 		return
 	}
 
+	v.recordAttestationSubmitted(pubKey, data, indexInCommittee)
+
 	if err := v.saveAttesterIndexToData(data, duty.ValidatorIndex); err != nil {
 		log.WithError(err).Error("Could not save validator index for logging")
 		if v.emitAccountMetrics {
@@ -264,7 +266,9 @@ func (v *validator) waitOneThirdOrValidBlock(ctx context.Context, slot primitive
 	}
 	v.highestValidSlotLock.Unlock()
 
-	delay := slots.DivideSlotBy(3 /* a third of the slot duration */)
+	// The attestation offset gives a distributed validator middleware more time to reach consensus on the
+	// attestation data.
+	delay := slots.DivideSlotBy(3 /* a third of the slot duration */) + v.attestationOffset
 	startTime := slots.StartTime(v.genesisTime, slot)
 	finalTime := startTime.Add(delay)
 	wait := prysmTime.Until(finalTime)
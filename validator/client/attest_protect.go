@@ -6,7 +6,6 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/prysm/v4/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/slashings"
@@ -82,7 +81,7 @@ func (v *validator) slashableAttestationCheck(
 		return errors.Wrap(err, "could not save attestation history for validator public key")
 	}
 
-	if features.Get().RemoteSlasherProtection {
+	if v.remoteSlasherProtection() {
 		slashing, err := v.slashingProtectionClient.IsSlashableAttestation(ctx, indexedAtt)
 		if err != nil {
 			return errors.Wrap(err, "could not check if attestation is slashable")
@@ -24,6 +24,7 @@ type beaconApiBeaconChainClient struct {
 	fallbackClient          iface.BeaconChainClient
 	jsonRestHandler         jsonRestHandler
 	stateValidatorsProvider stateValidatorsProvider
+	nodeFeatures            *nodeFeatures
 }
 
 const getValidatorPerformanceEndpoint = "/prysm/validators/performance"
@@ -149,12 +150,126 @@ func (c beaconApiBeaconChainClient) GetChainHead(ctx context.Context, _ *empty.E
 }
 
 func (c beaconApiBeaconChainClient) ListValidatorBalances(ctx context.Context, in *ethpb.ListValidatorBalancesRequest) (*ethpb.ValidatorBalances, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.ListValidatorBalances(ctx, in)
+	pageSize := in.PageSize
+
+	// We follow the gRPC behavior here, which returns a maximum of 250 results when pageSize == 0
+	if pageSize == 0 {
+		pageSize = 250
+	}
+
+	var pageToken uint64
+	var err error
+
+	if in.PageToken != "" {
+		if pageToken, err = strconv.ParseUint(in.PageToken, 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse page token `%s`", in.PageToken)
+		}
+	}
+
+	pubkeys := make([]string, len(in.PublicKeys))
+	for idx, pubkey := range in.PublicKeys {
+		pubkeys[idx] = hexutil.Encode(pubkey)
+	}
+
+	var stateValidators *beacon.GetValidatorsResponse
+	var epoch primitives.Epoch
+
+	switch queryFilter := in.QueryFilter.(type) {
+	case *ethpb.ListValidatorBalancesRequest_Epoch:
+		slot, err := slots.EpochStart(queryFilter.Epoch)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get first slot for epoch `%d`", queryFilter.Epoch)
+		}
+		if stateValidators, err = c.stateValidatorsProvider.GetStateValidatorsForSlot(ctx, slot, pubkeys, in.Indices, nil); err != nil {
+			return nil, errors.Wrapf(err, "failed to get state validators for slot `%d`", slot)
+		}
+		epoch = queryFilter.Epoch
+	case *ethpb.ListValidatorBalancesRequest_Genesis:
+		if stateValidators, err = c.stateValidatorsProvider.GetStateValidatorsForSlot(ctx, 0, pubkeys, in.Indices, nil); err != nil {
+			return nil, errors.Wrapf(err, "failed to get genesis state validators")
+		}
+		epoch = 0
+	case nil:
+		if stateValidators, err = c.stateValidatorsProvider.GetStateValidatorsForHead(ctx, pubkeys, in.Indices, nil); err != nil {
+			return nil, errors.Wrap(err, "failed to get head state validators")
+		}
+
+		blockHeader, err := c.getHeadBlockHeaders(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get head block headers")
+		}
+
+		slot, err := strconv.ParseUint(blockHeader.Data.Header.Message.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse header slot `%s`", blockHeader.Data.Header.Message.Slot)
+		}
+
+		epoch = slots.ToEpoch(primitives.Slot(slot))
+	default:
+		return nil, errors.Errorf("unsupported query filter type `%v`", reflect.TypeOf(queryFilter))
+	}
+
+	if stateValidators.Data == nil {
+		return nil, errors.New("state validators data is nil")
+	}
+
+	start := pageToken * uint64(pageSize)
+	if start > uint64(len(stateValidators.Data)) {
+		start = uint64(len(stateValidators.Data))
 	}
 
-	// TODO: Implement me
-	panic("beaconApiBeaconChainClient.ListValidatorBalances is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiBeaconChainClientWithFallback.")
+	end := start + uint64(pageSize)
+	if end > uint64(len(stateValidators.Data)) {
+		end = uint64(len(stateValidators.Data))
+	}
+
+	balances := make([]*ethpb.ValidatorBalances_Balance, end-start)
+	for idx := start; idx < end; idx++ {
+		stateValidator := stateValidators.Data[idx]
+
+		if stateValidator.Validator == nil {
+			return nil, errors.Errorf("state validator at index `%d` is nil", idx)
+		}
+
+		pubkey, err := hexutil.Decode(stateValidator.Validator.Pubkey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode validator pubkey `%s`", stateValidator.Validator.Pubkey)
+		}
+
+		validatorIndex, err := strconv.ParseUint(stateValidator.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse validator index `%s`", stateValidator.Index)
+		}
+
+		balance, err := strconv.ParseUint(stateValidator.Balance, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse validator balance `%s`", stateValidator.Balance)
+		}
+
+		status, ok := beaconAPITogRPCValidatorStatus[stateValidator.Status]
+		if !ok {
+			return nil, errors.Errorf("invalid validator status `%s`", stateValidator.Status)
+		}
+
+		balances[idx-start] = &ethpb.ValidatorBalances_Balance{
+			PublicKey: pubkey,
+			Index:     primitives.ValidatorIndex(validatorIndex),
+			Balance:   balance,
+			Status:    status.String(),
+		}
+	}
+
+	var nextPageToken string
+	if end < uint64(len(stateValidators.Data)) {
+		nextPageToken = strconv.FormatUint(pageToken+1, 10)
+	}
+
+	return &ethpb.ValidatorBalances{
+		Epoch:         epoch,
+		Balances:      balances,
+		NextPageToken: nextPageToken,
+		TotalSize:     int32(len(stateValidators.Data)),
+	}, nil
 }
 
 func (c beaconApiBeaconChainClient) ListValidators(ctx context.Context, in *ethpb.ListValidatorsRequest) (*ethpb.Validators, error) {
@@ -317,11 +432,20 @@ func (c beaconApiBeaconChainClient) GetValidatorQueue(ctx context.Context, in *e
 		return c.fallbackClient.GetValidatorQueue(ctx, in)
 	}
 
-	// TODO: Implement me
-	panic("beaconApiBeaconChainClient.GetValidatorQueue is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiBeaconChainClientWithFallback.")
+	return nil, errors.Wrap(iface.ErrNotSupported, "GetValidatorQueue is not served by the beacon REST API")
 }
 
+// GetValidatorPerformance queries the Prysm specific validator performance endpoint. iface.ErrNotSupported is
+// returned when the beacon node is not a Prysm node or does not serve the endpoint.
 func (c beaconApiBeaconChainClient) GetValidatorPerformance(ctx context.Context, in *ethpb.ValidatorPerformanceRequest) (*ethpb.ValidatorPerformanceResponse, error) {
+	isPrysm, err := c.nodeFeatures.isPrysm(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to detect beacon node client")
+	}
+	if !isPrysm {
+		return nil, errors.Wrap(iface.ErrNotSupported, "validator performance is only served by Prysm beacon nodes")
+	}
+
 	request, err := json.Marshal(validator.ValidatorPerformanceRequest{
 		PublicKeys: in.PublicKeys,
 		Indices:    in.Indices,
@@ -330,13 +454,17 @@ func (c beaconApiBeaconChainClient) GetValidatorPerformance(ctx context.Context,
 		return nil, errors.Wrap(err, "failed to marshal request")
 	}
 	resp := &validator.ValidatorPerformanceResponse{}
-	if _, err := c.jsonRestHandler.PostRestJson(
+	errJson, err := c.jsonRestHandler.PostRestJson(
 		ctx,
 		getValidatorPerformanceEndpoint,
 		nil,
 		bytes.NewBuffer(request),
 		resp,
-	); err != nil {
+	)
+	if err != nil {
+		if isNotSupported(errJson) {
+			return nil, errors.Wrap(iface.ErrNotSupported, err.Error())
+		}
 		return nil, errors.Wrap(err, "failed to get validator performance")
 	}
 
@@ -358,8 +486,7 @@ func (c beaconApiBeaconChainClient) GetValidatorParticipation(ctx context.Contex
 		return c.fallbackClient.GetValidatorParticipation(ctx, in)
 	}
 
-	// TODO: Implement me
-	panic("beaconApiBeaconChainClient.GetValidatorParticipation is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiBeaconChainClientWithFallback.")
+	return nil, errors.Wrap(iface.ErrNotSupported, "GetValidatorParticipation is not served by the beacon REST API")
 }
 
 func NewBeaconApiBeaconChainClientWithFallback(host string, timeout time.Duration, fallbackClient iface.BeaconChainClient) iface.BeaconChainClient {
@@ -372,5 +499,6 @@ func NewBeaconApiBeaconChainClientWithFallback(host string, timeout time.Duratio
 		jsonRestHandler:         jsonRestHandler,
 		fallbackClient:          fallbackClient,
 		stateValidatorsProvider: beaconApiStateValidatorsProvider{jsonRestHandler: jsonRestHandler},
+		nodeFeatures:            &nodeFeatures{jsonRestHandler: jsonRestHandler},
 	}
 }
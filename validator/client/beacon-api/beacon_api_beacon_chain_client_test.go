@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/http"
 	"strconv"
 	"testing"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api/mock"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -964,6 +966,7 @@ func Test_beaconApiBeaconChainClient_GetValidatorPerformance(t *testing.T) {
 
 	c := beaconApiBeaconChainClient{
 		jsonRestHandler: jsonRestHandler,
+		nodeFeatures:    &nodeFeatures{client: prysmClientName},
 	}
 
 	got, err := c.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{
@@ -972,3 +975,75 @@ func Test_beaconApiBeaconChainClient_GetValidatorPerformance(t *testing.T) {
 	require.NoError(t, err)
 	require.DeepEqual(t, want.PublicKeys, got.PublicKeys)
 }
+
+func Test_beaconApiBeaconChainClient_GetValidatorPerformance_NotSupported(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not a prysm node", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		c := beaconApiBeaconChainClient{
+			jsonRestHandler: mock.NewMockjsonRestHandler(ctrl),
+			nodeFeatures:    &nodeFeatures{client: "lighthouse"},
+		}
+		_, err := c.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{})
+		require.ErrorIs(t, err, iface.ErrNotSupported)
+	})
+	t.Run("endpoint not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		jsonRestHandler.EXPECT().PostRestJson(
+			ctx,
+			getValidatorPerformanceEndpoint,
+			nil,
+			gomock.Any(),
+			gomock.Any(),
+		).Return(
+			&gatewaymiddleware.DefaultErrorJson{Code: http.StatusNotFound, Message: "not found"},
+			errors.New("error 404: not found"),
+		)
+
+		c := beaconApiBeaconChainClient{
+			jsonRestHandler: jsonRestHandler,
+			nodeFeatures:    &nodeFeatures{client: prysmClientName},
+		}
+		_, err := c.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{})
+		require.ErrorIs(t, err, iface.ErrNotSupported)
+	})
+	t.Run("internal error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		jsonRestHandler.EXPECT().PostRestJson(
+			ctx,
+			getValidatorPerformanceEndpoint,
+			nil,
+			gomock.Any(),
+			gomock.Any(),
+		).Return(
+			&gatewaymiddleware.DefaultErrorJson{Code: http.StatusInternalServerError, Message: "foo error"},
+			errors.New("error 500: foo error"),
+		)
+
+		c := beaconApiBeaconChainClient{
+			jsonRestHandler: jsonRestHandler,
+			nodeFeatures:    &nodeFeatures{client: prysmClientName},
+		}
+		_, err := c.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{})
+		assert.ErrorContains(t, "failed to get validator performance", err)
+		assert.Equal(t, false, errors.Is(err, iface.ErrNotSupported))
+	})
+}
+
+func Test_beaconApiBeaconChainClient_NotImplemented(t *testing.T) {
+	ctx := context.Background()
+	beaconChainClient := beaconApiBeaconChainClient{}
+	_, err := beaconChainClient.GetValidatorQueue(ctx, &emptypb.Empty{})
+	require.ErrorIs(t, err, iface.ErrNotSupported)
+	_, err = beaconChainClient.GetValidatorParticipation(ctx, &ethpb.GetValidatorParticipationRequest{})
+	require.ErrorIs(t, err, iface.ErrNotSupported)
+}
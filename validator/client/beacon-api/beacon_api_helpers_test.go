@@ -5,10 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"net/url"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	gatewaymiddleware "github.com/prysmaticlabs/prysm/v4/api/gateway/apimiddleware"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/beacon"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
@@ -17,6 +19,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api/mock"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 )
 
 func TestBeaconApiHelpers(t *testing.T) {
@@ -299,6 +302,30 @@ func TestGetLiveness_Invalid(t *testing.T) {
 	require.ErrorContains(t, "failed to send POST data to `/eth/v1/validator/liveness/42` REST URL", err)
 }
 
+func TestGetLiveness_NotSupported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().PostRestJson(
+		ctx,
+		livenessEndpoint,
+		nil,
+		gomock.Any(),
+		gomock.Any(),
+	).Return(
+		&gatewaymiddleware.DefaultErrorJson{Code: http.StatusNotFound, Message: "not found"},
+		errors.New("error 404: not found"),
+	).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler}
+	_, err := validatorClient.getLiveness(ctx, 42, nil)
+
+	require.ErrorIs(t, err, iface.ErrNotSupported)
+}
+
 const syncingEnpoint = "/eth/v1/node/syncing"
 
 func TestGetIsSyncing_Nominal(t *testing.T) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -91,12 +92,37 @@ func (c *beaconApiNodeClient) GetVersion(ctx context.Context, _ *empty.Empty) (*
 }
 
 func (c *beaconApiNodeClient) ListPeers(ctx context.Context, in *empty.Empty) (*ethpb.Peers, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.ListPeers(ctx, in)
+	var peersResponse apimiddleware.PeersResponseJson
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, "/eth/v1/node/peers", &peersResponse); err != nil {
+		return nil, errors.Wrap(err, "failed to query node peers")
 	}
 
-	// TODO: Implement me
-	panic("beaconApiNodeClient.ListPeers is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiNodeClientWithFallback.")
+	peers := make([]*ethpb.Peer, len(peersResponse.Data))
+	for idx, peer := range peersResponse.Data {
+		if peer == nil {
+			return nil, errors.Errorf("peer at index `%d` is nil", idx)
+		}
+
+		connectionState, ok := ethpb.ConnectionState_value[strings.ToUpper(peer.State)]
+		if !ok {
+			return nil, errors.Errorf("invalid peer connection state `%s`", peer.State)
+		}
+
+		direction, ok := ethpb.PeerDirection_value[strings.ToUpper(peer.Direction)]
+		if !ok {
+			return nil, errors.Errorf("invalid peer direction `%s`", peer.Direction)
+		}
+
+		peers[idx] = &ethpb.Peer{
+			Address:         peer.Address,
+			Direction:       ethpb.PeerDirection(direction),
+			ConnectionState: ethpb.ConnectionState(connectionState),
+			PeerId:          peer.PeerId,
+			Enr:             peer.Enr,
+		}
+	}
+
+	return &ethpb.Peers{Peers: peers}, nil
 }
 
 func NewNodeClientWithFallback(host string, timeout time.Duration, fallbackClient iface.NodeClient) iface.NodeClient {
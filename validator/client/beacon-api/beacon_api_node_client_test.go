@@ -294,3 +294,86 @@ func TestGetVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestListPeers(t *testing.T) {
+	const peersEndpoint = "/eth/v1/node/peers"
+
+	testCases := []struct {
+		name                 string
+		restEndpointResponse apimiddleware.PeersResponseJson
+		restEndpointError    error
+		expectedResponse     *ethpb.Peers
+		expectedError        string
+	}{
+		{
+			name:              "fails to query REST endpoint",
+			restEndpointError: errors.New("foo error"),
+			expectedError:     "failed to query node peers",
+		},
+		{
+			name:                 "returns nil peer",
+			restEndpointResponse: apimiddleware.PeersResponseJson{Data: []*apimiddleware.PeerJson{nil}},
+			expectedError:        "peer at index `0` is nil",
+		},
+		{
+			name: "returns invalid connection state",
+			restEndpointResponse: apimiddleware.PeersResponseJson{
+				Data: []*apimiddleware.PeerJson{{State: "foo", Direction: "inbound"}},
+			},
+			expectedError: "invalid peer connection state `foo`",
+		},
+		{
+			name: "returns invalid direction",
+			restEndpointResponse: apimiddleware.PeersResponseJson{
+				Data: []*apimiddleware.PeerJson{{State: "connected", Direction: "bar"}},
+			},
+			expectedError: "invalid peer direction `bar`",
+		},
+		{
+			name: "returns proper peers response",
+			restEndpointResponse: apimiddleware.PeersResponseJson{
+				Data: []*apimiddleware.PeerJson{{PeerId: "foo", Enr: "bar", Address: "baz", State: "disconnecting", Direction: "outbound"}},
+			},
+			expectedResponse: &ethpb.Peers{
+				Peers: []*ethpb.Peer{{
+					Address:         "baz",
+					Direction:       ethpb.PeerDirection_OUTBOUND,
+					ConnectionState: ethpb.ConnectionState_DISCONNECTING,
+					PeerId:          "foo",
+					Enr:             "bar",
+				}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+
+			var peersResponse apimiddleware.PeersResponseJson
+			jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+			jsonRestHandler.EXPECT().GetRestJsonResponse(
+				ctx,
+				peersEndpoint,
+				&peersResponse,
+			).Return(
+				nil,
+				testCase.restEndpointError,
+			).SetArg(
+				2,
+				testCase.restEndpointResponse,
+			)
+
+			nodeClient := &beaconApiNodeClient{jsonRestHandler: jsonRestHandler}
+			peers, err := nodeClient.ListPeers(ctx, &emptypb.Empty{})
+			if testCase.expectedError != "" {
+				assert.ErrorContains(t, testCase.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.DeepEqual(t, testCase.expectedResponse, peers)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 )
@@ -19,8 +20,7 @@ func (c beaconApiSlasherClient) IsSlashableAttestation(ctx context.Context, in *
 		return c.fallbackClient.IsSlashableAttestation(ctx, in)
 	}
 
-	// TODO: Implement me
-	panic("beaconApiSlasherClient.IsSlashableAttestation is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiSlasherClientWithFallback.")
+	return nil, errors.Wrap(iface.ErrNotSupported, "IsSlashableAttestation is not served by the beacon REST API")
 }
 
 func (c beaconApiSlasherClient) IsSlashableBlock(ctx context.Context, in *ethpb.SignedBeaconBlockHeader) (*ethpb.ProposerSlashingResponse, error) {
@@ -28,8 +28,7 @@ func (c beaconApiSlasherClient) IsSlashableBlock(ctx context.Context, in *ethpb.
 		return c.fallbackClient.IsSlashableBlock(ctx, in)
 	}
 
-	// TODO: Implement me
-	panic("beaconApiSlasherClient.IsSlashableBlock is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiSlasherClientWithFallback.")
+	return nil, errors.Wrap(iface.ErrNotSupported, "IsSlashableBlock is not served by the beacon REST API")
 }
 
 func NewSlasherClientWithFallback(host string, timeout time.Duration, fallbackClient iface.SlasherClient) iface.SlasherClient {
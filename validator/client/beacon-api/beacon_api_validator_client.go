@@ -20,6 +20,7 @@ type beaconApiValidatorClient struct {
 	stateValidatorsProvider stateValidatorsProvider
 	jsonRestHandler         jsonRestHandler
 	beaconBlockConverter    beaconBlockConverter
+	nodeFeatures            *nodeFeatures
 }
 
 func NewBeaconApiValidatorClient(host string, timeout time.Duration) iface.ValidatorClient {
@@ -34,6 +35,7 @@ func NewBeaconApiValidatorClient(host string, timeout time.Duration) iface.Valid
 		stateValidatorsProvider: beaconApiStateValidatorsProvider{jsonRestHandler: jsonRestHandler},
 		jsonRestHandler:         jsonRestHandler,
 		beaconBlockConverter:    beaconApiBeaconBlockConverter{},
+		nodeFeatures:            &nodeFeatures{jsonRestHandler: jsonRestHandler},
 	}
 }
 
@@ -126,6 +128,18 @@ func (c *beaconApiValidatorClient) SubmitValidatorRegistrations(ctx context.Cont
 	return new(empty.Empty), c.submitValidatorRegistrations(ctx, in.Messages)
 }
 
+func (c *beaconApiValidatorClient) GetAggregatedSelections(ctx context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	return c.getAggregatedSelections(ctx, selections)
+}
+
+func (c *beaconApiValidatorClient) GetAggregatedSyncSelections(ctx context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	return c.getAggregatedSyncSelections(ctx, selections)
+}
+
+func (c *beaconApiValidatorClient) GetValidatorsLiveness(ctx context.Context, epoch primitives.Epoch, indices []primitives.ValidatorIndex) ([]*iface.ValidatorLiveness, error) {
+	return c.getValidatorsLiveness(ctx, epoch, indices)
+}
+
 func (c *beaconApiValidatorClient) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, validatorIndices []primitives.ValidatorIndex) (*empty.Empty, error) {
 	return new(empty.Empty), c.subscribeCommitteeSubnets(ctx, in, validatorIndices)
 }
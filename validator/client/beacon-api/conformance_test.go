@@ -0,0 +1,415 @@
+package beacon_api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// The responses below are the examples of the Beacon API specification, including the fields the validator client
+// does not use, so that the REST driver is checked to work against any beacon node implementing the specification.
+const (
+	specPubkey              = "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"
+	specRoot                = "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"
+	specSignature           = "0x1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505"
+	specFeeRecipient        = "0xabcf8e0d4e9587369b2301d0790347320302cc09"
+	specPeersJson           = `{"data":[{"peer_id":"QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N","enr":"enr:-IS4QHCYrYZbAKWCBRlAy5zzaDZXJBGkcnh4MHcBFZntXNFrdvJjX04jRzjzCBOonrkTfj499SZuOh8R33Ls8RRcy5wBgmlkgnY0gmlwhH8AAAGJc2VjcDI1NmsxoQPKY0yuDUmstAHYpMa2_oxVtw0RW_QAdpzBQA8yWM0xOIN1ZHCCdl8","last_seen_p2p_address":"/ip4/7.7.7.7/tcp/4242/p2p/QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N","state":"connected","direction":"inbound"}],"meta":{"count":1}}`
+	specSyncingJson         = `{"data":{"head_slot":"1","sync_distance":"1","is_syncing":true,"is_optimistic":true,"el_offline":true}}`
+	specHeaderJson          = `{"execution_optimistic":false,"finalized":false,"data":{"root":"` + specRoot + `","canonical":true,"header":{"message":{"slot":"1","proposer_index":"1","parent_root":"` + specRoot + `","state_root":"` + specRoot + `","body_root":"` + specRoot + `"},"signature":"` + specSignature + `"}}}`
+	specValidatorsJson      = `{"execution_optimistic":false,"finalized":false,"data":[{"index":"1","balance":"1","status":"active_ongoing","validator":{"pubkey":"` + specPubkey + `","withdrawal_credentials":"` + specRoot + `","effective_balance":"1","slashed":false,"activation_eligibility_epoch":"1","activation_epoch":"1","exit_epoch":"1","withdrawable_epoch":"1"}}]}`
+	specSyncedJson          = `{"data":{"head_slot":"1","sync_distance":"0","is_syncing":false,"is_optimistic":false,"el_offline":false}}`
+	specVersionJson         = `{"data":{"version":"Lighthouse/v4.5.0-441fc16/x86_64-linux"}}`
+	specAttestationData     = `{"slot":"1","index":"1","beacon_block_root":"` + specRoot + `","source":{"epoch":"1","root":"` + specRoot + `"},"target":{"epoch":"1","root":"` + specRoot + `"}}`
+	specAttestationDataJson = `{"data":` + specAttestationData + `}`
+	specAttestation         = `{"aggregation_bits":"0x01","signature":"` + specSignature + `","data":` + specAttestationData + `}`
+	specAggregateJson       = `{"data":` + specAttestation + `}`
+	specAttesterDutiesJson  = `{"dependent_root":"` + specRoot + `","execution_optimistic":false,"data":[{"pubkey":"` + specPubkey + `","validator_index":"1","committee_index":"1","committee_length":"1","committees_at_slot":"1","validator_committee_index":"0","slot":"1"}]}`
+	specProposerDutiesJson  = `{"dependent_root":"` + specRoot + `","execution_optimistic":false,"data":[{"pubkey":"` + specPubkey + `","validator_index":"1","slot":"1"}]}`
+	specSyncDutiesJson      = `{"execution_optimistic":false,"data":[{"pubkey":"` + specPubkey + `","validator_index":"1","validator_sync_committee_indices":["1"]}]}`
+	specCommitteesJson      = `{"execution_optimistic":false,"finalized":false,"data":[{"index":"1","slot":"1","validators":["1"]}]}`
+	specBlockRootJson       = `{"execution_optimistic":false,"finalized":false,"data":{"root":"` + specRoot + `"}}`
+	specContributionJson    = `{"data":{"slot":"1","beacon_block_root":"` + specRoot + `","subcommittee_index":"1","aggregation_bits":"0x01000000000000000000000000000000","signature":"` + specSignature + `"}}`
+	specPhase0Block         = `{"slot":"1","proposer_index":"1","parent_root":"` + specRoot + `","state_root":"` + specRoot + `","body":{"randao_reveal":"` + specSignature + `","eth1_data":{"deposit_root":"` + specRoot + `","deposit_count":"1","block_hash":"` + specRoot + `"},"graffiti":"` + specRoot + `","proposer_slashings":[],"attester_slashings":[],"attestations":[],"deposits":[],"voluntary_exits":[]}}`
+	specProduceBlockV3Json  = `{"version":"phase0","execution_payload_blinded":false,"execution_payload_value":"1","consensus_block_value":"1","data":` + specPhase0Block + `}`
+	specProduceBlockV2Json  = `{"version":"phase0","data":` + specPhase0Block + `}`
+)
+
+// specServer serves the example responses of the Beacon API specification and records the bodies posted to it.
+// Requests to endpoints without a response are answered with the error body of the specification.
+type specServer struct {
+	*httptest.Server
+	lock   sync.Mutex
+	bodies map[string][]byte
+}
+
+func newSpecServer(t *testing.T, responses map[string]string) *specServer {
+	s := &specServer{bodies: make(map[string][]byte)}
+	mux := http.NewServeMux()
+	for endpoint, response := range responses {
+		response := response
+		mux.HandleFunc(endpoint, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				s.lock.Lock()
+				s.bodies[r.URL.Path] = body
+				s.lock.Unlock()
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, err := w.Write([]byte(response))
+			require.NoError(t, err)
+		})
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(`{"code":404,"message":"Endpoint not found"}`))
+		require.NoError(t, err)
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// assertBody checks that the body posted to the endpoint is the JSON of the specification, regardless of the order
+// of the fields.
+func (s *specServer) assertBody(t *testing.T, endpoint string, expected string) {
+	s.lock.Lock()
+	body, ok := s.bodies[endpoint]
+	s.lock.Unlock()
+	require.Equal(t, true, ok, "nothing was posted to %s", endpoint)
+
+	var expectedJson, actualJson interface{}
+	require.NoError(t, json.Unmarshal([]byte(expected), &expectedJson))
+	require.NoError(t, json.Unmarshal(body, &actualJson))
+	assert.DeepEqual(t, expectedJson, actualJson)
+}
+
+func specBytes(t *testing.T, hex string) []byte {
+	b, err := hexutil.Decode(hex)
+	require.NoError(t, err)
+	return b
+}
+
+func TestConformance_NodeClient(t *testing.T) {
+	server := newSpecServer(t, map[string]string{
+		"/eth/v1/node/peers":   specPeersJson,
+		"/eth/v1/node/syncing": specSyncingJson,
+	})
+	nodeClient := NewNodeClientWithFallback(server.URL, 5*time.Second, nil)
+	ctx := context.Background()
+
+	peers, err := nodeClient.ListPeers(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, &ethpb.Peers{Peers: []*ethpb.Peer{{
+		Address:         "/ip4/7.7.7.7/tcp/4242/p2p/QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N",
+		Direction:       ethpb.PeerDirection_INBOUND,
+		ConnectionState: ethpb.ConnectionState_CONNECTED,
+		PeerId:          "QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N",
+		Enr:             "enr:-IS4QHCYrYZbAKWCBRlAy5zzaDZXJBGkcnh4MHcBFZntXNFrdvJjX04jRzjzCBOonrkTfj499SZuOh8R33Ls8RRcy5wBgmlkgnY0gmlwhH8AAAGJc2VjcDI1NmsxoQPKY0yuDUmstAHYpMa2_oxVtw0RW_QAdpzBQA8yWM0xOIN1ZHCCdl8",
+	}}}, peers)
+
+	syncStatus, err := nodeClient.GetSyncStatus(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, true, syncStatus.Syncing)
+}
+
+func TestConformance_BeaconChainClient(t *testing.T) {
+	server := newSpecServer(t, map[string]string{
+		"/eth/v1/beacon/headers/head":           specHeaderJson,
+		"/eth/v1/beacon/states/head/validators": specValidatorsJson,
+	})
+	beaconChainClient := NewBeaconApiBeaconChainClientWithFallback(server.URL, 5*time.Second, nil)
+	pubkey, err := hexutil.Decode(specPubkey)
+	require.NoError(t, err)
+
+	balances, err := beaconChainClient.ListValidatorBalances(context.Background(), &ethpb.ListValidatorBalancesRequest{
+		PublicKeys: [][]byte{pubkey},
+	})
+	require.NoError(t, err)
+	assert.DeepEqual(t, &ethpb.ValidatorBalances{
+		Balances: []*ethpb.ValidatorBalances_Balance{{
+			PublicKey: pubkey,
+			Index:     1,
+			Balance:   1,
+			Status:    ethpb.ValidatorStatus_ACTIVE.String(),
+		}},
+		TotalSize: 1,
+	}, balances)
+}
+
+func TestConformance_ValidatorClient(t *testing.T) {
+	server := newSpecServer(t, map[string]string{
+		"/eth/v1/validator/attestation_data": specAttestationDataJson,
+	})
+	validatorClient := NewBeaconApiValidatorClient(server.URL, 5*time.Second)
+	root, err := hexutil.Decode(specRoot)
+	require.NoError(t, err)
+
+	attestationData, err := validatorClient.GetAttestationData(context.Background(), &ethpb.AttestationDataRequest{
+		Slot:           1,
+		CommitteeIndex: 1,
+	})
+	require.NoError(t, err)
+	assert.DeepEqual(t, &ethpb.AttestationData{
+		Slot:            1,
+		CommitteeIndex:  1,
+		BeaconBlockRoot: root,
+		Source:          &ethpb.Checkpoint{Epoch: primitives.Epoch(1), Root: root},
+		Target:          &ethpb.Checkpoint{Epoch: primitives.Epoch(1), Root: root},
+	}, attestationData)
+}
+
+func TestConformance_BeaconChainClient_NonPrysm(t *testing.T) {
+	server := newSpecServer(t, map[string]string{
+		"/eth/v1/node/version": specVersionJson,
+	})
+	beaconChainClient := NewBeaconApiBeaconChainClientWithFallback(server.URL, 5*time.Second, nil)
+
+	_, err := beaconChainClient.GetValidatorPerformance(context.Background(), &ethpb.ValidatorPerformanceRequest{
+		PublicKeys: [][]byte{specBytes(t, specPubkey)},
+	})
+	require.ErrorIs(t, err, iface.ErrNotSupported)
+}
+
+func TestConformance_AttesterDuties(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	server := newSpecServer(t, map[string]string{
+		"/eth/v1/beacon/states/head/validators":            specValidatorsJson,
+		"/eth/v1/beacon/states/head/committees":            specCommitteesJson,
+		"/eth/v1/validator/duties/attester/0":              specAttesterDutiesJson,
+		"/eth/v1/validator/duties/attester/1":              specAttesterDutiesJson,
+		"/eth/v1/validator/duties/attester/2":              specAttesterDutiesJson,
+		"/eth/v1/validator/duties/proposer/1":              specProposerDutiesJson,
+		"/eth/v1/validator/duties/proposer/2":              specProposerDutiesJson,
+		"/eth/v1/validator/duties/sync/1":                  specSyncDutiesJson,
+		"/eth/v1/validator/duties/sync/2":                  specSyncDutiesJson,
+		"/eth/v1/beacon/pool/attestations":                 "",
+		"/eth/v1/validator/beacon_committee_subscriptions": "",
+	})
+	validatorClient := NewBeaconApiValidatorClient(server.URL, 5*time.Second)
+	ctx := context.Background()
+	pubkey := specBytes(t, specPubkey)
+	root := specBytes(t, specRoot)
+	signature := specBytes(t, specSignature)
+
+	duties, err := validatorClient.GetDuties(ctx, &ethpb.DutiesRequest{Epoch: 1, PublicKeys: [][]byte{pubkey}})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(duties.CurrentEpochDuties))
+	assert.DeepEqual(t, &ethpb.DutiesResponse_Duty{
+		Committee:       []primitives.ValidatorIndex{1},
+		CommitteeIndex:  1,
+		AttesterSlot:    1,
+		ProposerSlots:   []primitives.Slot{1},
+		PublicKey:       pubkey,
+		Status:          ethpb.ValidatorStatus_ACTIVE,
+		ValidatorIndex:  1,
+		IsSyncCommittee: true,
+	}, duties.CurrentEpochDuties[0])
+
+	_, err = validatorClient.SubscribeCommitteeSubnets(ctx, &ethpb.CommitteeSubnetsSubscribeRequest{
+		Slots:        []primitives.Slot{1},
+		CommitteeIds: []primitives.CommitteeIndex{1},
+		IsAggregator: []bool{true},
+	}, []primitives.ValidatorIndex{1})
+	require.NoError(t, err)
+	server.assertBody(t, "/eth/v1/validator/beacon_committee_subscriptions",
+		`[{"validator_index":"1","committee_index":"1","committees_at_slot":"1","slot":"1","is_aggregator":true}]`)
+
+	_, err = validatorClient.ProposeAttestation(ctx, &ethpb.Attestation{
+		AggregationBits: []byte{0x01},
+		Data: &ethpb.AttestationData{
+			Slot:            1,
+			CommitteeIndex:  1,
+			BeaconBlockRoot: root,
+			Source:          &ethpb.Checkpoint{Epoch: 1, Root: root},
+			Target:          &ethpb.Checkpoint{Epoch: 1, Root: root},
+		},
+		Signature: signature,
+	})
+	require.NoError(t, err)
+	server.assertBody(t, "/eth/v1/beacon/pool/attestations", `[`+specAttestation+`]`)
+}
+
+func TestConformance_ProposerDuties(t *testing.T) {
+	server := newSpecServer(t, map[string]string{
+		"/eth/v3/validator/blocks/1":                specProduceBlockV3Json,
+		"/eth/v1/beacon/blocks":                     "",
+		"/eth/v1/validator/prepare_beacon_proposer": "",
+		"/eth/v1/validator/register_validator":      "",
+	})
+	validatorClient := NewBeaconApiValidatorClient(server.URL, 5*time.Second)
+	ctx := context.Background()
+	pubkey := specBytes(t, specPubkey)
+	signature := specBytes(t, specSignature)
+
+	block, err := validatorClient.GetBeaconBlock(ctx, &ethpb.BlockRequest{
+		Slot:         1,
+		RandaoReveal: signature,
+		Graffiti:     specBytes(t, specRoot),
+	})
+	require.NoError(t, err)
+	phase0Block := block.GetPhase0()
+	require.NotNil(t, phase0Block)
+	assert.Equal(t, primitives.Slot(1), phase0Block.Slot)
+	assert.Equal(t, primitives.ValidatorIndex(1), phase0Block.ProposerIndex)
+
+	_, err = validatorClient.ProposeBeaconBlock(ctx, &ethpb.GenericSignedBeaconBlock{
+		Block: &ethpb.GenericSignedBeaconBlock_Phase0{
+			Phase0: &ethpb.SignedBeaconBlock{Block: phase0Block, Signature: signature},
+		},
+	})
+	require.NoError(t, err)
+	server.assertBody(t, "/eth/v1/beacon/blocks", `{"message":`+specPhase0Block+`,"signature":"`+specSignature+`"}`)
+
+	_, err = validatorClient.PrepareBeaconProposer(ctx, &ethpb.PrepareBeaconProposerRequest{
+		Recipients: []*ethpb.PrepareBeaconProposerRequest_FeeRecipientContainer{{
+			FeeRecipient:   specBytes(t, specFeeRecipient),
+			ValidatorIndex: 1,
+		}},
+	})
+	require.NoError(t, err)
+	server.assertBody(t, "/eth/v1/validator/prepare_beacon_proposer",
+		`[{"validator_index":"1","fee_recipient":"`+specFeeRecipient+`"}]`)
+
+	_, err = validatorClient.SubmitValidatorRegistrations(ctx, &ethpb.SignedValidatorRegistrationsV1{
+		Messages: []*ethpb.SignedValidatorRegistrationV1{{
+			Message: &ethpb.ValidatorRegistrationV1{
+				FeeRecipient: specBytes(t, specFeeRecipient),
+				GasLimit:     1,
+				Timestamp:    1,
+				Pubkey:       pubkey,
+			},
+			Signature: signature,
+		}},
+	})
+	require.NoError(t, err)
+	server.assertBody(t, "/eth/v1/validator/register_validator",
+		`[{"message":{"fee_recipient":"`+specFeeRecipient+`","gas_limit":"1","timestamp":"1","pubkey":"`+specPubkey+`"},"signature":"`+specSignature+`"}]`)
+}
+
+func TestConformance_ProposerDuties_ProduceBlockV2(t *testing.T) {
+	server := newSpecServer(t, map[string]string{
+		"/eth/v2/validator/blocks/1": specProduceBlockV2Json,
+	})
+	validatorClient := NewBeaconApiValidatorClient(server.URL, 5*time.Second)
+
+	// Beacon nodes which do not serve the v3 endpoint yet answer with a 404 and are asked for a v2 block.
+	block, err := validatorClient.GetBeaconBlock(context.Background(), &ethpb.BlockRequest{
+		Slot:         1,
+		RandaoReveal: specBytes(t, specSignature),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, block.GetPhase0())
+	assert.Equal(t, primitives.Slot(1), block.GetPhase0().Slot)
+}
+
+func TestConformance_SyncCommitteeDuties(t *testing.T) {
+	server := newSpecServer(t, map[string]string{
+		"/eth/v1/beacon/states/head/validators":         specValidatorsJson,
+		"/eth/v1/validator/duties/sync/0":               specSyncDutiesJson,
+		"/eth/v1/beacon/blocks/head/root":               specBlockRootJson,
+		"/eth/v1/beacon/pool/sync_committees":           "",
+		"/eth/v1/validator/sync_committee_contribution": specContributionJson,
+		"/eth/v1/validator/contribution_and_proofs":     "",
+	})
+	validatorClient := NewBeaconApiValidatorClient(server.URL, 5*time.Second)
+	ctx := context.Background()
+	pubkey := specBytes(t, specPubkey)
+	root := specBytes(t, specRoot)
+	signature := specBytes(t, specSignature)
+
+	blockRoot, err := validatorClient.GetSyncMessageBlockRoot(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, root, blockRoot.Root)
+
+	_, err = validatorClient.SubmitSyncMessage(ctx, &ethpb.SyncCommitteeMessage{
+		Slot:           1,
+		BlockRoot:      root,
+		ValidatorIndex: 1,
+		Signature:      signature,
+	})
+	require.NoError(t, err)
+	server.assertBody(t, "/eth/v1/beacon/pool/sync_committees",
+		`[{"slot":"1","beacon_block_root":"`+specRoot+`","validator_index":"1","signature":"`+specSignature+`"}]`)
+
+	subcommitteeIndices, err := validatorClient.GetSyncSubcommitteeIndex(ctx, &ethpb.SyncSubcommitteeIndexRequest{
+		PublicKey: pubkey,
+		Slot:      1,
+	})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []primitives.CommitteeIndex{1}, subcommitteeIndices.Indices)
+
+	contribution, err := validatorClient.GetSyncCommitteeContribution(ctx, &ethpb.SyncCommitteeContributionRequest{
+		Slot:      1,
+		PublicKey: pubkey,
+		SubnetId:  1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(1), contribution.Slot)
+	assert.Equal(t, uint64(1), contribution.SubcommitteeIndex)
+
+	_, err = validatorClient.SubmitSignedContributionAndProof(ctx, &ethpb.SignedContributionAndProof{
+		Message: &ethpb.ContributionAndProof{
+			AggregatorIndex: 1,
+			Contribution:    contribution,
+			SelectionProof:  signature,
+		},
+		Signature: signature,
+	})
+	require.NoError(t, err)
+	server.assertBody(t, "/eth/v1/validator/contribution_and_proofs",
+		`[{"message":{"aggregator_index":"1","contribution":{"slot":"1","beacon_block_root":"`+specRoot+`","subcommittee_index":"1","aggregation_bits":"0x01000000000000000000000000000000","signature":"`+specSignature+`"},"selection_proof":"`+specSignature+`"},"signature":"`+specSignature+`"}]`)
+}
+
+func TestConformance_AggregationDuties(t *testing.T) {
+	server := newSpecServer(t, map[string]string{
+		"/eth/v1/node/syncing":                    specSyncedJson,
+		"/eth/v1/beacon/states/head/validators":   specValidatorsJson,
+		"/eth/v1/validator/duties/attester/0":     specAttesterDutiesJson,
+		"/eth/v1/validator/attestation_data":      specAttestationDataJson,
+		"/eth/v1/validator/aggregate_attestation": specAggregateJson,
+		"/eth/v1/validator/aggregate_and_proofs":  "",
+	})
+	validatorClient := NewBeaconApiValidatorClient(server.URL, 5*time.Second)
+	ctx := context.Background()
+	signature := specBytes(t, specSignature)
+
+	aggregate, err := validatorClient.SubmitAggregateSelectionProof(ctx, &ethpb.AggregateSelectionRequest{
+		Slot:           1,
+		CommitteeIndex: 1,
+		PublicKey:      specBytes(t, specPubkey),
+		SlotSignature:  signature,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, primitives.ValidatorIndex(1), aggregate.AggregateAndProof.AggregatorIndex)
+	assert.DeepEqual(t, []byte{0x01}, []byte(aggregate.AggregateAndProof.Aggregate.AggregationBits))
+
+	_, err = validatorClient.SubmitSignedAggregateSelectionProof(ctx, &ethpb.SignedAggregateSubmitRequest{
+		SignedAggregateAndProof: &ethpb.SignedAggregateAttestationAndProof{
+			Message:   aggregate.AggregateAndProof,
+			Signature: signature,
+		},
+	})
+	require.NoError(t, err)
+	server.assertBody(t, "/eth/v1/validator/aggregate_and_proofs",
+		`[{"message":{"aggregator_index":"1","aggregate":`+specAttestation+`,"selection_proof":"`+specSignature+`"},"signature":"`+specSignature+`"}]`)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 )
 
 type dutiesProvider interface {
@@ -52,6 +53,14 @@ func (c beaconApiValidatorClient) getDuties(ctx context.Context, in *ethpb.Dutie
 		return nil, errors.Wrapf(err, "failed to get duties for next epoch `%d`", in.Epoch+1)
 	}
 
+	// The sync committee subnets of the next period are subscribed to one epoch before the period boundary, so that
+	// the beacon node has peers on these subnets by the first slot of the period.
+	if fetchSyncDuties && slots.SyncCommitteePeriod(in.Epoch+1) != slots.SyncCommitteePeriod(in.Epoch) {
+		if err := c.subscribeSyncCommitteeSubnets(ctx, in.Epoch+1, multipleValidatorStatus); err != nil {
+			log.WithError(err).Error("Failed to subscribe to the next period sync committee subnets")
+		}
+	}
+
 	return &ethpb.DutiesResponse{
 		Duties:             currentEpochDuties,
 		CurrentEpochDuties: currentEpochDuties,
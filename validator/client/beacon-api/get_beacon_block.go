@@ -16,8 +16,9 @@ import (
 )
 
 type abstractProduceBlockResponseJson struct {
-	Version string          `json:"version" enum:"true"`
-	Data    json.RawMessage `json:"data"`
+	Version                 string          `json:"version" enum:"true"`
+	ExecutionPayloadBlinded bool            `json:"execution_payload_blinded"`
+	Data                    json.RawMessage `json:"data"`
 }
 
 func (c beaconApiValidatorClient) getBeaconBlock(ctx context.Context, slot primitives.Slot, randaoReveal []byte, graffiti []byte) (*ethpb.GenericBeaconBlock, error) {
@@ -28,19 +29,22 @@ func (c beaconApiValidatorClient) getBeaconBlock(ctx context.Context, slot primi
 		queryParams.Add("graffiti", hexutil.Encode(graffiti))
 	}
 
-	queryUrl := buildURL(fmt.Sprintf("/eth/v2/validator/blocks/%d", slot), queryParams)
-
 	// Since we don't know yet what the json looks like, we unmarshal into an abstract structure that has only a version
 	// and a blob of data
 	produceBlockResponseJson := abstractProduceBlockResponseJson{}
-	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, queryUrl, &produceBlockResponseJson); err != nil {
-		return nil, errors.Wrap(err, "failed to query GET REST endpoint")
+	if err := c.produceBlock(ctx, slot, queryParams, &produceBlockResponseJson); err != nil {
+		return nil, err
 	}
 
 	// Once we know what the consensus version is, we can go ahead and unmarshal into the specific structs unique to each version
 	decoder := json.NewDecoder(bytes.NewReader(produceBlockResponseJson.Data))
 	decoder.DisallowUnknownFields()
 
+	// The beacon node returns a blinded block when the payload of a builder was chosen over the local payload
+	if produceBlockResponseJson.ExecutionPayloadBlinded {
+		return decodeBlindedBeaconBlock(produceBlockResponseJson.Version, decoder)
+	}
+
 	response := &ethpb.GenericBeaconBlock{}
 
 	switch produceBlockResponseJson.Version {
@@ -114,3 +118,63 @@ func (c beaconApiValidatorClient) getBeaconBlock(ctx context.Context, slot primi
 	}
 	return response, nil
 }
+
+func decodeBlindedBeaconBlock(consensusVersion string, decoder *json.Decoder) (*ethpb.GenericBeaconBlock, error) {
+	switch consensusVersion {
+	case "bellatrix":
+		jsonBellatrixBlock := shared.BlindedBeaconBlockBellatrix{}
+		if err := decoder.Decode(&jsonBellatrixBlock); err != nil {
+			return nil, errors.Wrap(err, "failed to decode blinded bellatrix block response json")
+		}
+		genericBlock, err := jsonBellatrixBlock.ToGeneric()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert blinded bellatrix block to generic block")
+		}
+		return genericBlock, nil
+	case "capella":
+		jsonCapellaBlock := shared.BlindedBeaconBlockCapella{}
+		if err := decoder.Decode(&jsonCapellaBlock); err != nil {
+			return nil, errors.Wrap(err, "failed to decode blinded capella block response json")
+		}
+		genericBlock, err := jsonCapellaBlock.ToGeneric()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert blinded capella block to generic block")
+		}
+		return genericBlock, nil
+	case "deneb":
+		jsonDenebBlockContents := shared.BlindedBeaconBlockContentsDeneb{}
+		if err := decoder.Decode(&jsonDenebBlockContents); err != nil {
+			return nil, errors.Wrap(err, "failed to decode blinded deneb block response json")
+		}
+		genericBlock, err := jsonDenebBlockContents.ToGeneric()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert blinded deneb block contents to generic block")
+		}
+		return genericBlock, nil
+	default:
+		return nil, errors.Errorf("unsupported blinded consensus version `%s`", consensusVersion)
+	}
+}
+
+// produceBlock requests a block from the v3 block production endpoint. Beacon nodes which do not serve it yet are
+// asked for a full block through the v2 endpoint instead, whose response is the v3 response without the blinded flag.
+func (c beaconApiValidatorClient) produceBlock(ctx context.Context, slot primitives.Slot, queryParams neturl.Values, responseJson *abstractProduceBlockResponseJson) error {
+	if c.nodeFeatures.supportsProduceBlockV3() {
+		queryUrl := buildURL(fmt.Sprintf("/eth/v3/validator/blocks/%d", slot), queryParams)
+		errJson, err := c.jsonRestHandler.GetRestJsonResponse(ctx, queryUrl, responseJson)
+		if err == nil {
+			return nil
+		}
+		if !isNotSupported(errJson) {
+			return errors.Wrap(err, "failed to query GET REST endpoint")
+		}
+		log.WithError(err).Warn("Beacon node does not serve the v3 block production endpoint, falling back to the v2 endpoint")
+		c.nodeFeatures.setProduceBlockV3Unsupported()
+	}
+
+	queryUrl := buildURL(fmt.Sprintf("/eth/v2/validator/blocks/%d", slot), queryParams)
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, queryUrl, responseJson); err != nil {
+		return errors.Wrap(err, "failed to query GET REST endpoint")
+	}
+	return nil
+}
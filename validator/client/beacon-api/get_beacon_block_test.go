@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/mock/gomock"
+	gatewaymiddleware "github.com/prysmaticlabs/prysm/v4/api/gateway/apimiddleware"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
 	rpctesting "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared/testing"
@@ -57,6 +59,7 @@ func TestGetBeaconBlock_Error(t *testing.T) {
 		beaconBlock          interface{}
 		expectedErrorMessage string
 		consensusVersion     string
+		blinded              bool
 		data                 json.RawMessage
 	}{
 		{
@@ -114,6 +117,19 @@ func TestGetBeaconBlock_Error(t *testing.T) {
 			expectedErrorMessage: "unsupported consensus version `foo`",
 			consensusVersion:     "foo",
 		},
+		{
+			name:                 "blinded deneb block decoding failed",
+			expectedErrorMessage: "failed to decode blinded deneb block response json",
+			consensusVersion:     "deneb",
+			blinded:              true,
+			data:                 []byte{},
+		},
+		{
+			name:                 "unsupported blinded consensus version",
+			expectedErrorMessage: "unsupported blinded consensus version `altair`",
+			consensusVersion:     "altair",
+			blinded:              true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -131,8 +147,9 @@ func TestGetBeaconBlock_Error(t *testing.T) {
 			).SetArg(
 				2,
 				abstractProduceBlockResponseJson{
-					Version: testCase.consensusVersion,
-					Data:    testCase.data,
+					Version:                 testCase.consensusVersion,
+					ExecutionPayloadBlinded: testCase.blinded,
+					Data:                    testCase.data,
 				},
 			).Return(
 				nil,
@@ -192,7 +209,7 @@ func TestGetBeaconBlock_Phase0Valid(t *testing.T) {
 	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
 	jsonRestHandler.EXPECT().GetRestJsonResponse(
 		ctx,
-		fmt.Sprintf("/eth/v2/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
+		fmt.Sprintf("/eth/v3/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
 		&abstractProduceBlockResponseJson{},
 	).SetArg(
 		2,
@@ -226,6 +243,93 @@ func TestGetBeaconBlock_Phase0Valid(t *testing.T) {
 	assert.DeepEqual(t, expectedBeaconBlock, beaconBlock)
 }
 
+func TestGetBeaconBlock_FallbackToV2(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	phase0ProtoBeaconBlock := test_helpers.GenerateProtoPhase0BeaconBlock()
+	phase0BeaconBlock := test_helpers.GenerateJsonPhase0BeaconBlock()
+	phase0BeaconBlockBytes, err := json.Marshal(phase0BeaconBlock)
+	require.NoError(t, err)
+
+	const slot = primitives.Slot(1)
+	randaoReveal := []byte{2}
+	graffiti := []byte{3}
+	ctx := context.Background()
+	query := fmt.Sprintf("blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal))
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	// The v3 endpoint is only queried once, later blocks are requested from the v2 endpoint straight away.
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		"/eth/v3/validator/"+query,
+		&abstractProduceBlockResponseJson{},
+	).Return(
+		&gatewaymiddleware.DefaultErrorJson{Code: http.StatusNotFound},
+		errors.New("not found"),
+	).Times(1)
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		"/eth/v2/validator/"+query,
+		&abstractProduceBlockResponseJson{},
+	).SetArg(
+		2,
+		abstractProduceBlockResponseJson{
+			Version: "phase0",
+			Data:    phase0BeaconBlockBytes,
+		},
+	).Return(
+		nil,
+		nil,
+	).Times(2)
+
+	beaconBlockConverter := mock.NewMockbeaconBlockConverter(ctrl)
+	beaconBlockConverter.EXPECT().ConvertRESTPhase0BlockToProto(
+		phase0BeaconBlock,
+	).Return(
+		phase0ProtoBeaconBlock,
+		nil,
+	).Times(2)
+
+	validatorClient := &beaconApiValidatorClient{
+		jsonRestHandler:      jsonRestHandler,
+		beaconBlockConverter: beaconBlockConverter,
+		nodeFeatures:         &nodeFeatures{jsonRestHandler: jsonRestHandler},
+	}
+	expectedBeaconBlock := &ethpb.GenericBeaconBlock{
+		Block: &ethpb.GenericBeaconBlock_Phase0{
+			Phase0: phase0ProtoBeaconBlock,
+		},
+	}
+	for i := 0; i < 2; i++ {
+		beaconBlock, err := validatorClient.getBeaconBlock(ctx, slot, randaoReveal, graffiti)
+		require.NoError(t, err)
+		assert.DeepEqual(t, expectedBeaconBlock, beaconBlock)
+	}
+}
+
+func TestGetBeaconBlock_V3ServerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		gomock.Any(),
+		gomock.Any(),
+	).Return(
+		&gatewaymiddleware.DefaultErrorJson{Code: http.StatusInternalServerError},
+		errors.New("foo error"),
+	).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler, nodeFeatures: &nodeFeatures{}}
+	_, err := validatorClient.getBeaconBlock(ctx, 1, []byte{1}, []byte{2})
+	assert.ErrorContains(t, "foo error", err)
+	assert.Equal(t, true, validatorClient.nodeFeatures.supportsProduceBlockV3())
+}
+
 func TestGetBeaconBlock_AltairValid(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -244,7 +348,7 @@ func TestGetBeaconBlock_AltairValid(t *testing.T) {
 	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
 	jsonRestHandler.EXPECT().GetRestJsonResponse(
 		ctx,
-		fmt.Sprintf("/eth/v2/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
+		fmt.Sprintf("/eth/v3/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
 		&abstractProduceBlockResponseJson{},
 	).SetArg(
 		2,
@@ -296,7 +400,7 @@ func TestGetBeaconBlock_BellatrixValid(t *testing.T) {
 	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
 	jsonRestHandler.EXPECT().GetRestJsonResponse(
 		ctx,
-		fmt.Sprintf("/eth/v2/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
+		fmt.Sprintf("/eth/v3/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
 		&abstractProduceBlockResponseJson{},
 	).SetArg(
 		2,
@@ -348,7 +452,7 @@ func TestGetBeaconBlock_CapellaValid(t *testing.T) {
 	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
 	jsonRestHandler.EXPECT().GetRestJsonResponse(
 		ctx,
-		fmt.Sprintf("/eth/v2/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
+		fmt.Sprintf("/eth/v3/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
 		&abstractProduceBlockResponseJson{},
 	).SetArg(
 		2,
@@ -402,7 +506,7 @@ func TestGetBeaconBlock_DenebValid(t *testing.T) {
 	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
 	jsonRestHandler.EXPECT().GetRestJsonResponse(
 		ctx,
-		fmt.Sprintf("/eth/v2/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
+		fmt.Sprintf("/eth/v3/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
 		&abstractProduceBlockResponseJson{},
 	).SetArg(
 		2,
@@ -425,3 +529,90 @@ func TestGetBeaconBlock_DenebValid(t *testing.T) {
 
 	assert.DeepEqual(t, expectedBeaconBlock, beaconBlock)
 }
+
+func TestGetBeaconBlock_BlindedCapellaValid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var signedBlock shared.SignedBlindedBeaconBlockCapella
+	err := json.Unmarshal([]byte(rpctesting.BlindedCapellaBlock), &signedBlock)
+	require.NoError(t, err)
+
+	blindedCapellaBeaconBlockBytes, err := json.Marshal(signedBlock.Message)
+	require.NoError(t, err)
+	ctx := context.Background()
+	const slot = primitives.Slot(1)
+	randaoReveal := []byte{2}
+	graffiti := []byte{3}
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		fmt.Sprintf("/eth/v3/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
+		&abstractProduceBlockResponseJson{},
+	).SetArg(
+		2,
+		abstractProduceBlockResponseJson{
+			Version:                 "capella",
+			ExecutionPayloadBlinded: true,
+			Data:                    blindedCapellaBeaconBlockBytes,
+		},
+	).Return(
+		nil,
+		nil,
+	).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler}
+
+	beaconBlock, err := validatorClient.getBeaconBlock(ctx, slot, randaoReveal, graffiti)
+	require.NoError(t, err)
+
+	expectedBeaconBlock, err := signedBlock.Message.ToGeneric()
+	require.NoError(t, err)
+
+	assert.DeepEqual(t, expectedBeaconBlock, beaconBlock)
+}
+
+func TestGetBeaconBlock_BlindedDenebValid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var blockContents shared.SignedBlindedBeaconBlockContentsDeneb
+	err := json.Unmarshal([]byte(rpctesting.BlindedDenebBlockContents), &blockContents)
+	require.NoError(t, err)
+
+	blindedDenebBeaconBlockBytes, err := json.Marshal(blockContents.ToUnsigned())
+	require.NoError(t, err)
+	ctx := context.Background()
+	const slot = primitives.Slot(1)
+	randaoReveal := []byte{2}
+	graffiti := []byte{3}
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		fmt.Sprintf("/eth/v3/validator/blocks/%d?graffiti=%s&randao_reveal=%s", slot, hexutil.Encode(graffiti), hexutil.Encode(randaoReveal)),
+		&abstractProduceBlockResponseJson{},
+	).SetArg(
+		2,
+		abstractProduceBlockResponseJson{
+			Version:                 "deneb",
+			ExecutionPayloadBlinded: true,
+			Data:                    blindedDenebBeaconBlockBytes,
+		},
+	).Return(
+		nil,
+		nil,
+	).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler}
+
+	beaconBlock, err := validatorClient.getBeaconBlock(ctx, slot, randaoReveal, graffiti)
+	require.NoError(t, err)
+
+	expectedBeaconBlock, err := blockContents.ToUnsigned().ToGeneric()
+	require.NoError(t, err)
+	require.Equal(t, true, beaconBlock.IsBlinded)
+
+	assert.DeepEqual(t, expectedBeaconBlock, beaconBlock)
+}
@@ -82,9 +82,10 @@ func (c beaconApiJsonRestHandler) PostRestJson(ctx context.Context, apiEndpoint
 	return decodeJsonResp(resp, responseJson)
 }
 
+// decodeJsonResp decodes the body of resp into responseJson. Unknown fields are ignored, as beacon nodes are free to
+// return more fields than the ones defined by the Beacon API specification (e.g. the block values of a produced block).
 func decodeJsonResp(resp *http.Response, responseJson interface{}) (*apimiddleware.DefaultErrorJson, error) {
 	decoder := json.NewDecoder(resp.Body)
-	decoder.DisallowUnknownFields()
 
 	if resp.StatusCode != http.StatusOK {
 		errorJson := &apimiddleware.DefaultErrorJson{}
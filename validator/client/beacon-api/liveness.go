@@ -0,0 +1,41 @@
+package beacon_api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+)
+
+func (c *beaconApiValidatorClient) getValidatorsLiveness(ctx context.Context, epoch primitives.Epoch, indices []primitives.ValidatorIndex) ([]*iface.ValidatorLiveness, error) {
+	stringIndices := make([]string, len(indices))
+	for i, index := range indices {
+		stringIndices[i] = strconv.FormatUint(uint64(index), 10)
+	}
+
+	resp, err := c.getLiveness(ctx, epoch, stringIndices)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errors.New("liveness response is nil")
+	}
+
+	liveness := make([]*iface.ValidatorLiveness, len(resp.Data))
+	for i, data := range resp.Data {
+		if data == nil {
+			return nil, errors.Errorf("liveness data at index %d is nil", i)
+		}
+		index, err := strconv.ParseUint(data.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse validator index %s", data.Index)
+		}
+		liveness[i] = &iface.ValidatorLiveness{
+			Index:  primitives.ValidatorIndex(index),
+			IsLive: data.IsLive,
+		}
+	}
+	return liveness, nil
+}
@@ -0,0 +1,69 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/validator"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api/mock"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+)
+
+func TestGetValidatorsLiveness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	body, err := json.Marshal([]string{"1", "2"})
+	require.NoError(t, err)
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().PostRestJson(
+		ctx,
+		"/eth/v1/validator/liveness/42",
+		nil,
+		bytes.NewBuffer(body),
+		&validator.GetLivenessResponse{},
+	).SetArg(
+		4,
+		validator.GetLivenessResponse{
+			Data: []*validator.ValidatorLiveness{
+				{Index: "1", IsLive: true},
+				{Index: "2", IsLive: false},
+			},
+		},
+	).Return(nil, nil).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler}
+	liveness, err := validatorClient.GetValidatorsLiveness(ctx, 42, []primitives.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []*iface.ValidatorLiveness{{Index: 1, IsLive: true}, {Index: 2, IsLive: false}}, liveness)
+}
+
+func TestGetValidatorsLiveness_InvalidIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().PostRestJson(
+		ctx,
+		"/eth/v1/validator/liveness/42",
+		nil,
+		gomock.Any(),
+		&validator.GetLivenessResponse{},
+	).SetArg(
+		4,
+		validator.GetLivenessResponse{Data: []*validator.ValidatorLiveness{{Index: "foo"}}},
+	).Return(nil, nil).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler}
+	_, err := validatorClient.GetValidatorsLiveness(ctx, 42, []primitives.ValidatorIndex{1})
+	require.ErrorContains(t, "failed to parse validator index foo", err)
+}
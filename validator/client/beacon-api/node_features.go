@@ -0,0 +1,91 @@
+package beacon_api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	gatewaymiddleware "github.com/prysmaticlabs/prysm/v4/api/gateway/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+)
+
+const prysmClientName = "prysm"
+
+// nodeFeatures detects the client implementation of the beacon node, so that calls to endpoints which are
+// specific to one implementation can be skipped instead of failing the duties relying on them.
+type nodeFeatures struct {
+	jsonRestHandler jsonRestHandler
+	lock            sync.Mutex
+	client          string
+	// produceBlockV3Unsupported is set once the beacon node answered that it does not serve the v3 block
+	// production endpoint.
+	produceBlockV3Unsupported bool
+}
+
+// clientName returns the lower case name of the beacon node implementation, as reported by the version endpoint,
+// such as "prysm" or "lighthouse". The name is queried once and cached.
+func (f *nodeFeatures) clientName(ctx context.Context) (string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.client != "" {
+		return f.client, nil
+	}
+
+	var versionResponse apimiddleware.VersionResponseJson
+	if _, err := f.jsonRestHandler.GetRestJsonResponse(ctx, "/eth/v1/node/version", &versionResponse); err != nil {
+		return "", errors.Wrap(err, "failed to query node version")
+	}
+	if versionResponse.Data == nil || versionResponse.Data.Version == "" {
+		return "", errors.New("empty version response")
+	}
+	// The version follows the "<client>/<version>/<platform>" format recommended by the beacon API.
+	name, _, _ := strings.Cut(versionResponse.Data.Version, "/")
+	f.client = strings.ToLower(name)
+	log.WithField("client", f.client).Info("Detected beacon node client")
+	return f.client, nil
+}
+
+// isPrysm returns whether the beacon node is a Prysm node, which serves the /prysm endpoints.
+func (f *nodeFeatures) isPrysm(ctx context.Context) (bool, error) {
+	name, err := f.clientName(ctx)
+	if err != nil {
+		return false, err
+	}
+	return name == prysmClientName, nil
+}
+
+// supportsProduceBlockV3 returns whether blocks should be requested from the v3 block production endpoint. A nil
+// nodeFeatures assumes the endpoint is served.
+func (f *nodeFeatures) supportsProduceBlockV3() bool {
+	if f == nil {
+		return true
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return !f.produceBlockV3Unsupported
+}
+
+// setProduceBlockV3Unsupported records that the beacon node does not serve the v3 block production endpoint.
+func (f *nodeFeatures) setProduceBlockV3Unsupported() {
+	if f == nil {
+		return
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.produceBlockV3Unsupported = true
+}
+
+// isNotSupported returns whether the error response means the beacon node does not serve the endpoint at all.
+func isNotSupported(errJson *gatewaymiddleware.DefaultErrorJson) bool {
+	if errJson == nil {
+		return false
+	}
+	switch errJson.Code {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,101 @@
+package beacon_api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gatewaymiddleware "github.com/prysmaticlabs/prysm/v4/api/gateway/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api/mock"
+)
+
+func TestNodeFeatures_ClientName(t *testing.T) {
+	const versionEndpoint = "/eth/v1/node/version"
+
+	testCases := []struct {
+		name          string
+		version       string
+		expectedName  string
+		expectedPrysm bool
+	}{
+		{
+			name:          "prysm",
+			version:       "Prysm/v4.0.8 (linux amd64)",
+			expectedName:  "prysm",
+			expectedPrysm: true,
+		},
+		{
+			name:         "lighthouse",
+			version:      "Lighthouse/v4.5.0-441fc16/x86_64-linux",
+			expectedName: "lighthouse",
+		},
+		{
+			name:         "no platform",
+			version:      "teku",
+			expectedName: "teku",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+
+			jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+			// The version is only queried once.
+			jsonRestHandler.EXPECT().GetRestJsonResponse(
+				ctx,
+				versionEndpoint,
+				&apimiddleware.VersionResponseJson{},
+			).Return(
+				nil,
+				nil,
+			).SetArg(
+				2,
+				apimiddleware.VersionResponseJson{Data: &apimiddleware.VersionJson{Version: testCase.version}},
+			).Times(1)
+
+			f := &nodeFeatures{jsonRestHandler: jsonRestHandler}
+			name, err := f.clientName(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedName, name)
+			isPrysm, err := f.isPrysm(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedPrysm, isPrysm)
+		})
+	}
+}
+
+func TestNodeFeatures_ClientName_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	gomock.InOrder(
+		jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, gomock.Any(), gomock.Any()).Return(nil, errors.New("foo error")),
+		jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, gomock.Any(), gomock.Any()).Return(nil, nil),
+	)
+
+	f := &nodeFeatures{jsonRestHandler: jsonRestHandler}
+	_, err := f.clientName(ctx)
+	assert.ErrorContains(t, "failed to query node version", err)
+	// Failures are not cached.
+	_, err = f.clientName(ctx)
+	assert.ErrorContains(t, "empty version response", err)
+}
+
+func TestIsNotSupported(t *testing.T) {
+	assert.Equal(t, false, isNotSupported(nil))
+	assert.Equal(t, true, isNotSupported(&gatewaymiddleware.DefaultErrorJson{Code: http.StatusNotFound}))
+	assert.Equal(t, true, isNotSupported(&gatewaymiddleware.DefaultErrorJson{Code: http.StatusMethodNotAllowed}))
+	assert.Equal(t, true, isNotSupported(&gatewaymiddleware.DefaultErrorJson{Code: http.StatusNotImplemented}))
+	assert.Equal(t, false, isNotSupported(&gatewaymiddleware.DefaultErrorJson{Code: http.StatusInternalServerError}))
+	assert.Equal(t, false, isNotSupported(&gatewaymiddleware.DefaultErrorJson{Code: http.StatusServiceUnavailable}))
+}
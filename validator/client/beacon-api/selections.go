@@ -0,0 +1,136 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+)
+
+type beaconCommitteeSelectionJson struct {
+	ValidatorIndex string `json:"validator_index"`
+	Slot           string `json:"slot"`
+	SelectionProof string `json:"selection_proof"`
+}
+
+type aggregatedSelectionsResponseJson struct {
+	Data []*beaconCommitteeSelectionJson `json:"data"`
+}
+
+type syncCommitteeSelectionJson struct {
+	ValidatorIndex    string `json:"validator_index"`
+	Slot              string `json:"slot"`
+	SubcommitteeIndex string `json:"subcommittee_index"`
+	SelectionProof    string `json:"selection_proof"`
+}
+
+type aggregatedSyncSelectionsResponseJson struct {
+	Data []*syncCommitteeSelectionJson `json:"data"`
+}
+
+func (c *beaconApiValidatorClient) getAggregatedSelections(ctx context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	jsonSelections := make([]*beaconCommitteeSelectionJson, len(selections))
+	for i, s := range selections {
+		jsonSelections[i] = &beaconCommitteeSelectionJson{
+			ValidatorIndex: strconv.FormatUint(uint64(s.ValidatorIndex), 10),
+			Slot:           strconv.FormatUint(uint64(s.Slot), 10),
+			SelectionProof: hexutil.Encode(s.SelectionProof),
+		}
+	}
+	body, err := json.Marshal(jsonSelections)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal selections")
+	}
+
+	var resp aggregatedSelectionsResponseJson
+	if _, err := c.jsonRestHandler.PostRestJson(ctx, "/eth/v1/validator/beacon_committee_selections", nil, bytes.NewBuffer(body), &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to send POST data to REST endpoint")
+	}
+	if len(resp.Data) == 0 {
+		return nil, errors.New("no aggregated selection returned")
+	}
+
+	aggregated := make([]iface.BeaconCommitteeSelection, len(resp.Data))
+	for i, s := range resp.Data {
+		if s == nil {
+			return nil, errors.Errorf("aggregated selection at index %d is nil", i)
+		}
+		validatorIndex, err := strconv.ParseUint(s.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse validator index `%s`", s.ValidatorIndex)
+		}
+		slot, err := strconv.ParseUint(s.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse slot `%s`", s.Slot)
+		}
+		proof, err := hexutil.Decode(s.SelectionProof)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode selection proof `%s`", s.SelectionProof)
+		}
+		aggregated[i] = iface.BeaconCommitteeSelection{
+			SelectionProof: proof,
+			Slot:           primitives.Slot(slot),
+			ValidatorIndex: primitives.ValidatorIndex(validatorIndex),
+		}
+	}
+	return aggregated, nil
+}
+
+func (c *beaconApiValidatorClient) getAggregatedSyncSelections(ctx context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	jsonSelections := make([]*syncCommitteeSelectionJson, len(selections))
+	for i, s := range selections {
+		jsonSelections[i] = &syncCommitteeSelectionJson{
+			ValidatorIndex:    strconv.FormatUint(uint64(s.ValidatorIndex), 10),
+			Slot:              strconv.FormatUint(uint64(s.Slot), 10),
+			SubcommitteeIndex: strconv.FormatUint(uint64(s.SubcommitteeIndex), 10),
+			SelectionProof:    hexutil.Encode(s.SelectionProof),
+		}
+	}
+	body, err := json.Marshal(jsonSelections)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal sync selections")
+	}
+
+	var resp aggregatedSyncSelectionsResponseJson
+	if _, err := c.jsonRestHandler.PostRestJson(ctx, "/eth/v1/validator/sync_committee_selections", nil, bytes.NewBuffer(body), &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to send POST data to REST endpoint")
+	}
+	if len(resp.Data) == 0 {
+		return nil, errors.New("no aggregated sync selection returned")
+	}
+
+	aggregated := make([]iface.SyncCommitteeSelection, len(resp.Data))
+	for i, s := range resp.Data {
+		if s == nil {
+			return nil, errors.Errorf("aggregated sync selection at index %d is nil", i)
+		}
+		validatorIndex, err := strconv.ParseUint(s.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse validator index `%s`", s.ValidatorIndex)
+		}
+		slot, err := strconv.ParseUint(s.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse slot `%s`", s.Slot)
+		}
+		subcommitteeIndex, err := strconv.ParseUint(s.SubcommitteeIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse subcommittee index `%s`", s.SubcommitteeIndex)
+		}
+		proof, err := hexutil.Decode(s.SelectionProof)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode selection proof `%s`", s.SelectionProof)
+		}
+		aggregated[i] = iface.SyncCommitteeSelection{
+			SelectionProof:    proof,
+			Slot:              primitives.Slot(slot),
+			SubcommitteeIndex: primitives.CommitteeIndex(subcommitteeIndex),
+			ValidatorIndex:    primitives.ValidatorIndex(validatorIndex),
+		}
+	}
+	return aggregated, nil
+}
@@ -0,0 +1,93 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api/mock"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+)
+
+func TestGetAggregatedSelections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	selections := []iface.BeaconCommitteeSelection{{SelectionProof: []byte{1, 2}, Slot: 3, ValidatorIndex: 4}}
+	body, err := json.Marshal([]*beaconCommitteeSelectionJson{{ValidatorIndex: "4", Slot: "3", SelectionProof: "0x0102"}})
+	require.NoError(t, err)
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().PostRestJson(
+		ctx,
+		"/eth/v1/validator/beacon_committee_selections",
+		nil,
+		bytes.NewBuffer(body),
+		&aggregatedSelectionsResponseJson{},
+	).SetArg(
+		4,
+		aggregatedSelectionsResponseJson{
+			Data: []*beaconCommitteeSelectionJson{{ValidatorIndex: "4", Slot: "3", SelectionProof: "0x0506"}},
+		},
+	).Return(nil, nil).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler}
+	aggregated, err := validatorClient.GetAggregatedSelections(ctx, selections)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []iface.BeaconCommitteeSelection{{SelectionProof: []byte{5, 6}, Slot: 3, ValidatorIndex: 4}}, aggregated)
+}
+
+func TestGetAggregatedSelections_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().PostRestJson(
+		ctx,
+		"/eth/v1/validator/beacon_committee_selections",
+		nil,
+		gomock.Any(),
+		&aggregatedSelectionsResponseJson{},
+	).Return(nil, errors.New("foo error")).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler}
+	_, err := validatorClient.GetAggregatedSelections(ctx, []iface.BeaconCommitteeSelection{{Slot: 1}})
+	assert.ErrorContains(t, "failed to send POST data to REST endpoint", err)
+	assert.ErrorContains(t, "foo error", err)
+}
+
+func TestGetAggregatedSyncSelections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	selections := []iface.SyncCommitteeSelection{{SelectionProof: []byte{1, 2}, Slot: 3, SubcommitteeIndex: 2, ValidatorIndex: 4}}
+	body, err := json.Marshal([]*syncCommitteeSelectionJson{{ValidatorIndex: "4", Slot: "3", SubcommitteeIndex: "2", SelectionProof: "0x0102"}})
+	require.NoError(t, err)
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().PostRestJson(
+		ctx,
+		"/eth/v1/validator/sync_committee_selections",
+		nil,
+		bytes.NewBuffer(body),
+		&aggregatedSyncSelectionsResponseJson{},
+	).SetArg(
+		4,
+		aggregatedSyncSelectionsResponseJson{
+			Data: []*syncCommitteeSelectionJson{{ValidatorIndex: "4", Slot: "3", SubcommitteeIndex: "2", SelectionProof: "0x0506"}},
+		},
+	).Return(nil, nil).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{jsonRestHandler: jsonRestHandler}
+	aggregated, err := validatorClient.GetAggregatedSyncSelections(ctx, selections)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []iface.SyncCommitteeSelection{{SelectionProof: []byte{5, 6}, Slot: 3, SubcommitteeIndex: 2, ValidatorIndex: 4}}, aggregated)
+}
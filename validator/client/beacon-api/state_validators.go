@@ -1,7 +1,9 @@
 package beacon_api
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	neturl "net/url"
 	"strconv"
@@ -11,6 +13,10 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 )
 
+// maxValidatorIdsInQuery is the number of validator IDs above which validators are queried with a POST request instead
+// of a GET request. Public keys take about 100 characters of the query each, and many servers reject URLs above 8KB.
+const maxValidatorIdsInQuery = 64
+
 type stateValidatorsProvider interface {
 	GetStateValidators(context.Context, []string, []int64, []string) (*beacon.GetValidatorsResponse, error)
 	GetStateValidatorsForSlot(context.Context, primitives.Slot, []string, []primitives.ValidatorIndex, []string) (*beacon.GetValidatorsResponse, error)
@@ -89,12 +95,26 @@ func (c beaconApiStateValidatorsProvider) getStateValidatorsHelper(
 		}
 	}
 
+	stateValidatorsJson := &beacon.GetValidatorsResponse{}
+
+	if len(params["id"]) > maxValidatorIdsInQuery {
+		supported, err := c.postStateValidators(ctx, endpoint, params["id"], statuses, stateValidatorsJson)
+		if err != nil {
+			return &beacon.GetValidatorsResponse{}, errors.Wrap(err, "failed to send POST data to REST endpoint")
+		}
+		if supported {
+			if stateValidatorsJson.Data == nil {
+				return &beacon.GetValidatorsResponse{}, errors.New("stateValidatorsJson.Data is nil")
+			}
+			return stateValidatorsJson, nil
+		}
+	}
+
 	for _, status := range statuses {
 		params.Add("status", status)
 	}
 
 	url := buildURL(endpoint, params)
-	stateValidatorsJson := &beacon.GetValidatorsResponse{}
 
 	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, url, stateValidatorsJson); err != nil {
 		return &beacon.GetValidatorsResponse{}, errors.Wrap(err, "failed to get json response")
@@ -106,3 +126,34 @@ func (c beaconApiStateValidatorsProvider) getStateValidatorsHelper(
 
 	return stateValidatorsJson, nil
 }
+
+// postStateValidators queries the validators with their IDs and statuses in the body of a POST request, so that the
+// number of IDs is not bound by the URL length limits of the beacon node and the proxies in front of it. It returns
+// false when the beacon node does not serve the POST variant of the endpoint, in which case the caller should fall
+// back to a GET request. Such nodes may answer with an empty body, so undecodable error responses are treated the same.
+func (c beaconApiStateValidatorsProvider) postStateValidators(
+	ctx context.Context,
+	endpoint string,
+	ids []string,
+	statuses []string,
+	responseJson *beacon.GetValidatorsResponse,
+) (bool, error) {
+	req := &beacon.GetValidatorsRequest{
+		Ids:      ids,
+		Statuses: statuses,
+	}
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to marshal request")
+	}
+
+	errJson, err := c.jsonRestHandler.PostRestJson(ctx, endpoint, nil, bytes.NewBuffer(reqBytes), responseJson)
+	if err != nil {
+		if errJson == nil || isNotSupported(errJson) {
+			log.WithError(err).Debug("Beacon node does not support querying validators with POST, falling back to GET")
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
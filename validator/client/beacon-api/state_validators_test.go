@@ -1,13 +1,20 @@
 package beacon_api
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"net/http"
+	neturl "net/url"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/gateway/apimiddleware"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/beacon"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api/mock"
@@ -159,3 +166,109 @@ func TestGetStateValidators_DataIsNil(t *testing.T) {
 	)
 	assert.ErrorContains(t, "stateValidatorsJson.Data is nil", err)
 }
+
+func TestGetStateValidators_ManyIdsPosted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	indices := make([]primitives.ValidatorIndex, maxValidatorIdsInQuery+1)
+	ids := make([]string, len(indices))
+	for i := range indices {
+		indices[i] = primitives.ValidatorIndex(i)
+		ids[i] = strconv.Itoa(i)
+	}
+	reqBytes, err := json.Marshal(&beacon.GetValidatorsRequest{Ids: ids, Statuses: []string{"active_ongoing"}})
+	require.NoError(t, err)
+
+	wanted := []*beacon.ValidatorContainer{{Index: "1", Status: "active_ongoing"}}
+	ctx := context.Background()
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().PostRestJson(
+		ctx,
+		"/eth/v1/beacon/states/head/validators",
+		nil,
+		bytes.NewBuffer(reqBytes),
+		&beacon.GetValidatorsResponse{},
+	).Return(
+		nil,
+		nil,
+	).SetArg(
+		4,
+		beacon.GetValidatorsResponse{Data: wanted},
+	).Times(1)
+
+	stateValidatorsProvider := beaconApiStateValidatorsProvider{jsonRestHandler: jsonRestHandler}
+	actual, err := stateValidatorsProvider.GetStateValidatorsForHead(ctx, nil, indices, []string{"active_ongoing"})
+	require.NoError(t, err)
+	assert.DeepEqual(t, wanted, actual.Data)
+}
+
+func TestGetStateValidators_ManyIdsFallBackToGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	indices := make([]primitives.ValidatorIndex, maxValidatorIdsInQuery+1)
+	params := neturl.Values{}
+	for i := range indices {
+		indices[i] = primitives.ValidatorIndex(i)
+		params.Add("id", strconv.Itoa(i))
+	}
+
+	wanted := []*beacon.ValidatorContainer{{Index: "1", Status: "active_ongoing"}}
+	ctx := context.Background()
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().PostRestJson(
+		ctx,
+		"/eth/v1/beacon/states/head/validators",
+		nil,
+		gomock.Any(),
+		&beacon.GetValidatorsResponse{},
+	).Return(
+		&apimiddleware.DefaultErrorJson{Code: http.StatusMethodNotAllowed},
+		errors.New("method not allowed"),
+	).Times(1)
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		buildURL("/eth/v1/beacon/states/head/validators", params),
+		&beacon.GetValidatorsResponse{},
+	).Return(
+		nil,
+		nil,
+	).SetArg(
+		2,
+		beacon.GetValidatorsResponse{Data: wanted},
+	).Times(1)
+
+	stateValidatorsProvider := beaconApiStateValidatorsProvider{jsonRestHandler: jsonRestHandler}
+	actual, err := stateValidatorsProvider.GetStateValidatorsForHead(ctx, nil, indices, nil)
+	require.NoError(t, err)
+	assert.DeepEqual(t, wanted, actual.Data)
+}
+
+func TestGetStateValidators_ManyIdsPostError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	indices := make([]primitives.ValidatorIndex, maxValidatorIdsInQuery+1)
+	for i := range indices {
+		indices[i] = primitives.ValidatorIndex(i)
+	}
+
+	ctx := context.Background()
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().PostRestJson(
+		ctx,
+		"/eth/v1/beacon/states/head/validators",
+		nil,
+		gomock.Any(),
+		&beacon.GetValidatorsResponse{},
+	).Return(
+		&apimiddleware.DefaultErrorJson{Code: http.StatusInternalServerError},
+		errors.New("an error"),
+	).Times(1)
+
+	stateValidatorsProvider := beaconApiStateValidatorsProvider{jsonRestHandler: jsonRestHandler}
+	_, err := stateValidatorsProvider.GetStateValidatorsForHead(ctx, nil, indices, nil)
+	assert.ErrorContains(t, "an error", err)
+	assert.ErrorContains(t, "failed to send POST data to REST endpoint", err)
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
@@ -79,3 +81,51 @@ func (c beaconApiValidatorClient) subscribeCommitteeSubnets(ctx context.Context,
 
 	return nil
 }
+
+// subscribeSyncCommitteeSubnets subscribes the active validators that are members of the sync committee of the
+// given epoch to their sync committee subnets, until the end of the sync committee period of the epoch.
+func (c beaconApiValidatorClient) subscribeSyncCommitteeSubnets(ctx context.Context, epoch primitives.Epoch, multipleValidatorStatus *ethpb.MultipleValidatorStatusResponse) error {
+	activeValidatorIndices := make([]primitives.ValidatorIndex, 0, len(multipleValidatorStatus.Indices))
+	for index, validatorStatus := range multipleValidatorStatus.Statuses {
+		if validatorStatus.Status == ethpb.ValidatorStatus_ACTIVE || validatorStatus.Status == ethpb.ValidatorStatus_EXITING {
+			activeValidatorIndices = append(activeValidatorIndices, multipleValidatorStatus.Indices[index])
+		}
+	}
+	if len(activeValidatorIndices) == 0 {
+		return nil
+	}
+
+	syncDuties, err := c.dutiesProvider.GetSyncDuties(ctx, epoch, activeValidatorIndices)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get sync duties for epoch `%d`", epoch)
+	}
+	if len(syncDuties) == 0 {
+		return nil
+	}
+
+	periodStartEpoch, err := slots.SyncCommitteePeriodStartEpoch(epoch)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get sync committee period start epoch of epoch `%d`", epoch)
+	}
+	untilEpoch := strconv.FormatUint(uint64(periodStartEpoch+params.BeaconConfig().EpochsPerSyncCommitteePeriod), 10)
+
+	jsonSyncCommitteeSubscriptions := make([]*shared.SyncCommitteeSubscription, len(syncDuties))
+	for index, syncDuty := range syncDuties {
+		jsonSyncCommitteeSubscriptions[index] = &shared.SyncCommitteeSubscription{
+			ValidatorIndex:       syncDuty.ValidatorIndex,
+			SyncCommitteeIndices: syncDuty.ValidatorSyncCommitteeIndices,
+			UntilEpoch:           untilEpoch,
+		}
+	}
+
+	syncCommitteeSubscriptionsBytes, err := json.Marshal(jsonSyncCommitteeSubscriptions)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal sync committee subscriptions")
+	}
+
+	if _, err := c.jsonRestHandler.PostRestJson(ctx, "/eth/v1/validator/sync_committee_subscriptions", nil, bytes.NewBuffer(syncCommitteeSubscriptionsBytes), nil); err != nil {
+		return errors.Wrap(err, "failed to send POST data to REST endpoint")
+	}
+
+	return nil
+}
@@ -10,7 +10,9 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/validator"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
@@ -294,3 +296,112 @@ func TestSubscribeCommitteeSubnets_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestSubscribeSyncCommitteeSubnets_Valid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	epoch := params.BeaconConfig().EpochsPerSyncCommitteePeriod + 1
+	multipleValidatorStatus := &ethpb.MultipleValidatorStatusResponse{
+		Indices: []primitives.ValidatorIndex{1, 2, 3},
+		Statuses: []*ethpb.ValidatorStatusResponse{
+			{Status: ethpb.ValidatorStatus_ACTIVE},
+			{Status: ethpb.ValidatorStatus_PENDING},
+			{Status: ethpb.ValidatorStatus_EXITING},
+		},
+	}
+
+	// Only the active validators are queried for sync duties.
+	dutiesProvider := mock.NewMockdutiesProvider(ctrl)
+	dutiesProvider.EXPECT().GetSyncDuties(
+		ctx,
+		epoch,
+		[]primitives.ValidatorIndex{1, 3},
+	).Return(
+		[]*validator.SyncCommitteeDuty{
+			{
+				ValidatorIndex:                "3",
+				ValidatorSyncCommitteeIndices: []string{"4", "5"},
+			},
+		},
+		nil,
+	).Times(1)
+
+	expectedSubscriptionsBytes, err := json.Marshal([]*shared.SyncCommitteeSubscription{
+		{
+			ValidatorIndex:       "3",
+			SyncCommitteeIndices: []string{"4", "5"},
+			UntilEpoch:           strconv.FormatUint(uint64(2*params.BeaconConfig().EpochsPerSyncCommitteePeriod), 10),
+		},
+	})
+	require.NoError(t, err)
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().PostRestJson(
+		ctx,
+		"/eth/v1/validator/sync_committee_subscriptions",
+		nil,
+		bytes.NewBuffer(expectedSubscriptionsBytes),
+		nil,
+	).Return(
+		nil,
+		nil,
+	).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{
+		jsonRestHandler: jsonRestHandler,
+		dutiesProvider:  dutiesProvider,
+	}
+	require.NoError(t, validatorClient.subscribeSyncCommitteeSubnets(ctx, epoch, multipleValidatorStatus))
+}
+
+func TestSubscribeSyncCommitteeSubnets_NoSyncDuties(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	dutiesProvider := mock.NewMockdutiesProvider(ctrl)
+	dutiesProvider.EXPECT().GetSyncDuties(
+		ctx,
+		primitives.Epoch(1),
+		[]primitives.ValidatorIndex{1},
+	).Return(
+		[]*validator.SyncCommitteeDuty{},
+		nil,
+	).Times(1)
+
+	// No subscription is sent when none of the validators is in the sync committee.
+	validatorClient := &beaconApiValidatorClient{
+		jsonRestHandler: mock.NewMockjsonRestHandler(ctrl),
+		dutiesProvider:  dutiesProvider,
+	}
+	require.NoError(t, validatorClient.subscribeSyncCommitteeSubnets(ctx, 1, &ethpb.MultipleValidatorStatusResponse{
+		Indices:  []primitives.ValidatorIndex{1},
+		Statuses: []*ethpb.ValidatorStatusResponse{{Status: ethpb.ValidatorStatus_ACTIVE}},
+	}))
+}
+
+func TestSubscribeSyncCommitteeSubnets_GetSyncDutiesFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	dutiesProvider := mock.NewMockdutiesProvider(ctrl)
+	dutiesProvider.EXPECT().GetSyncDuties(
+		ctx,
+		gomock.Any(),
+		gomock.Any(),
+	).Return(
+		nil,
+		errors.New("foo error"),
+	).Times(1)
+
+	validatorClient := &beaconApiValidatorClient{dutiesProvider: dutiesProvider}
+	err := validatorClient.subscribeSyncCommitteeSubnets(ctx, 1, &ethpb.MultipleValidatorStatusResponse{
+		Indices:  []primitives.ValidatorIndex{1},
+		Statuses: []*ethpb.ValidatorStatusResponse{{Status: ethpb.ValidatorStatus_ACTIVE}},
+	})
+	assert.ErrorContains(t, "failed to get sync duties for epoch `1`", err)
+	assert.ErrorContains(t, "foo error", err)
+}
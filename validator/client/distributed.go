@@ -0,0 +1,230 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// In distributed mode the validator client signs with a key share of a distributed validator. The selection
+// proofs it signs are partial, and the middleware it connects to as its beacon node combines them with the
+// partial selection proofs of the other nodes of the cluster. Aggregators are selected with the combined
+// selection proofs, so that all the nodes of the cluster agree on them.
+
+type attSelectionKey struct {
+	slot  primitives.Slot
+	index primitives.ValidatorIndex
+}
+
+type syncSelectionKey struct {
+	slot   primitives.Slot
+	index  primitives.ValidatorIndex
+	subnet uint64
+}
+
+// attSelectionProof returns the selection proof of the validator for aggregating the attestations of its
+// committee at the slot. In distributed mode, this is the selection proof combined by the middleware. Selection
+// proofs are cached, so that the aggregator selection and the aggregation itself do not sign the slot again.
+func (v *validator) attSelectionProof(
+	ctx context.Context,
+	slot primitives.Slot,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	validatorIndex primitives.ValidatorIndex,
+) ([]byte, error) {
+	key := attSelectionKey{slot: slot, index: validatorIndex}
+	v.attSelectionsLock.Lock()
+	proof, ok := v.attSelections[key]
+	v.attSelectionsLock.Unlock()
+	if ok {
+		return proof, nil
+	}
+	if !v.distributed {
+		proof, err := v.signSlotWithSelectionProof(ctx, pubKey, slot)
+		if err != nil {
+			return nil, err
+		}
+		v.cacheAttSelectionProofs([]iface.BeaconCommitteeSelection{{SelectionProof: proof, Slot: slot, ValidatorIndex: validatorIndex}})
+		return proof, nil
+	}
+	if err := v.aggregatedSelectionProofs(ctx, []*ethpb.DutiesResponse_Duty{{
+		PublicKey:      pubKey[:],
+		ValidatorIndex: validatorIndex,
+		AttesterSlot:   slot,
+	}}); err != nil {
+		return nil, err
+	}
+	v.attSelectionsLock.Lock()
+	defer v.attSelectionsLock.Unlock()
+	proof, ok = v.attSelections[key]
+	if !ok {
+		return nil, errors.Errorf("no aggregated selection proof returned for validator %d at slot %d", validatorIndex, slot)
+	}
+	return proof, nil
+}
+
+// aggregatorAt returns true if the validator aggregates the attestations of its committee at the attester slot
+// of the duty. In distributed mode, the validator does not aggregate if the middleware did not return the combined
+// selection proof, as indicated by selectionsFailed, so that a failing middleware does not prevent attesting.
+func (v *validator) aggregatorAt(
+	ctx context.Context,
+	duty *ethpb.DutiesResponse_Duty,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	selectionsFailed bool,
+) (bool, error) {
+	if v.distributed && selectionsFailed {
+		return false, nil
+	}
+	aggregator, err := v.isAggregator(ctx, duty.Committee, duty.AttesterSlot, pubKey, duty.ValidatorIndex)
+	if err != nil && v.distributed {
+		log.WithError(err).WithFields(logrus.Fields{
+			"slot":           duty.AttesterSlot,
+			"validatorIndex": duty.ValidatorIndex,
+		}).Warn("Could not get aggregated selection proof, not aggregating")
+		return false, nil
+	}
+	return aggregator, err
+}
+
+// aggregatedSelectionProofs requests the middleware to combine the partial selection proofs of the attester
+// duties in one request, and caches the combined selection proofs it returns. The middleware returns them
+// once enough nodes of the cluster submitted their partial selection proofs.
+func (v *validator) aggregatedSelectionProofs(ctx context.Context, duties []*ethpb.DutiesResponse_Duty) error {
+	ctx, span := trace.StartSpan(ctx, "validator.aggregatedSelectionProofs")
+	defer span.End()
+
+	var selections []iface.BeaconCommitteeSelection
+	v.attSelectionsLock.Lock()
+	for _, duty := range duties {
+		if _, ok := v.attSelections[attSelectionKey{slot: duty.AttesterSlot, index: duty.ValidatorIndex}]; ok {
+			continue
+		}
+		selections = append(selections, iface.BeaconCommitteeSelection{
+			Slot:           duty.AttesterSlot,
+			ValidatorIndex: duty.ValidatorIndex,
+		})
+	}
+	v.attSelectionsLock.Unlock()
+	if len(selections) == 0 {
+		return nil
+	}
+
+	pubKeys := make(map[primitives.ValidatorIndex][fieldparams.BLSPubkeyLength]byte, len(duties))
+	for _, duty := range duties {
+		pubKeys[duty.ValidatorIndex] = bytesutil.ToBytes48(duty.PublicKey)
+	}
+	for i, s := range selections {
+		proof, err := v.signSlotWithSelectionProof(ctx, pubKeys[s.ValidatorIndex], s.Slot)
+		if err != nil {
+			return errors.Wrap(err, "could not sign partial selection proof")
+		}
+		selections[i].SelectionProof = proof
+	}
+
+	aggregated, err := v.validatorClient.GetAggregatedSelections(ctx, selections)
+	if err != nil {
+		return errors.Wrap(err, "could not get aggregated selection proofs")
+	}
+	v.cacheAttSelectionProofs(aggregated)
+	return nil
+}
+
+// cacheAttSelectionProofs caches the selection proofs, and drops the cached selection proofs of the slots more
+// than two epochs before the earliest of them.
+func (v *validator) cacheAttSelectionProofs(selections []iface.BeaconCommitteeSelection) {
+	if len(selections) == 0 {
+		return
+	}
+	v.attSelectionsLock.Lock()
+	defer v.attSelectionsLock.Unlock()
+	if v.attSelections == nil {
+		v.attSelections = make(map[attSelectionKey][]byte)
+	}
+	earliest := selections[0].Slot
+	for _, s := range selections {
+		v.attSelections[attSelectionKey{slot: s.Slot, index: s.ValidatorIndex}] = s.SelectionProof
+		if s.Slot < earliest {
+			earliest = s.Slot
+		}
+	}
+	// Keep the selection proofs of the previous and following epochs only.
+	for k := range v.attSelections {
+		if k.slot+2*params.BeaconConfig().SlotsPerEpoch < earliest {
+			delete(v.attSelections, k)
+		}
+	}
+}
+
+// syncSelectionProof returns the selection proof of the validator for aggregating the sync committee messages
+// of the sync subcommittee at the slot. In distributed mode, this is the selection proof combined by the
+// middleware.
+func (v *validator) syncSelectionProof(
+	ctx context.Context,
+	slot primitives.Slot,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	subnet uint64,
+) ([]byte, error) {
+	if !v.distributed {
+		return v.signSyncSelectionData(ctx, pubKey, subnet, slot)
+	}
+	duty, err := v.duty(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	key := syncSelectionKey{slot: slot, index: duty.ValidatorIndex, subnet: subnet}
+	v.syncSelectionsLock.Lock()
+	proof, ok := v.syncSelections[key]
+	v.syncSelectionsLock.Unlock()
+	if ok {
+		return proof, nil
+	}
+
+	partial, err := v.signSyncSelectionData(ctx, pubKey, subnet, slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign partial sync selection proof")
+	}
+	aggregated, err := v.validatorClient.GetAggregatedSyncSelections(ctx, []iface.SyncCommitteeSelection{{
+		SelectionProof:    partial,
+		Slot:              slot,
+		SubcommitteeIndex: primitives.CommitteeIndex(subnet),
+		ValidatorIndex:    duty.ValidatorIndex,
+	}})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get aggregated sync selection proofs")
+	}
+
+	v.syncSelectionsLock.Lock()
+	defer v.syncSelectionsLock.Unlock()
+	if v.syncSelections == nil {
+		v.syncSelections = make(map[syncSelectionKey][]byte)
+	}
+	for _, s := range aggregated {
+		v.syncSelections[syncSelectionKey{slot: s.Slot, index: s.ValidatorIndex, subnet: uint64(s.SubcommitteeIndex)}] = s.SelectionProof
+	}
+	for k := range v.syncSelections {
+		if k.slot+params.BeaconConfig().SlotsPerEpoch < slot {
+			delete(v.syncSelections, k)
+		}
+	}
+	proof, ok = v.syncSelections[key]
+	if !ok {
+		return nil, errors.Errorf("no aggregated sync selection proof returned for validator %d at slot %d", duty.ValidatorIndex, slot)
+	}
+	return proof, nil
+}
+
+// remoteSlasherProtection returns true if the slashing protection of the beacon node slasher is used in addition to
+// the local slashing protection. It is not used in distributed mode, as the middleware does not serve the slasher
+// endpoints and the slasher cannot detect slashable partial signatures. The local slashing protection still applies
+// to the key share, and the middleware protects the combined signatures of the cluster.
+func (v *validator) remoteSlasherProtection() bool {
+	return features.Get().RemoteSlasherProtection && !v.distributed
+}
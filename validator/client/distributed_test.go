@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+)
+
+func TestRolesAt_Distributed(t *testing.T) {
+	v, m, validatorKey, finish := setup(t)
+	defer finish()
+	v.distributed = true
+	pubKey := bytesutil.ToBytes48(validatorKey.PublicKey().Marshal())
+	aggregatedProof := bytesutil.PadTo([]byte("aggregated"), 96)
+
+	v.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				CommitteeIndex: 1,
+				AttesterSlot:   1,
+				ValidatorIndex: 5,
+				PublicKey:      pubKey[:],
+			},
+		},
+	}
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+
+	m.validatorClient.EXPECT().GetAggregatedSelections(
+		gomock.Any(), // ctx
+		gomock.Any(), // selections
+	).DoAndReturn(func(_ context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+		require.Equal(t, 1, len(selections))
+		assert.Equal(t, primitives.Slot(1), selections[0].Slot)
+		assert.Equal(t, primitives.ValidatorIndex(5), selections[0].ValidatorIndex)
+		assert.NotEmpty(t, selections[0].SelectionProof)
+		return []iface.BeaconCommitteeSelection{{
+			SelectionProof: aggregatedProof,
+			Slot:           1,
+			ValidatorIndex: 5,
+		}}, nil
+	}).Times(1)
+
+	roleMap, err := v.RolesAt(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, iface.RoleAttester, roleMap[pubKey][0])
+	assert.Equal(t, iface.RoleAggregator, roleMap[pubKey][1])
+
+	// The aggregated selection proof is cached for the aggregation duty.
+	proof, err := v.attSelectionProof(context.Background(), 1, pubKey, 5)
+	require.NoError(t, err)
+	assert.DeepEqual(t, aggregatedProof, proof)
+}
+
+func TestRolesAt_Distributed_SelectionsError(t *testing.T) {
+	v, m, validatorKey, finish := setup(t)
+	defer finish()
+	v.distributed = true
+	pubKey := bytesutil.ToBytes48(validatorKey.PublicKey().Marshal())
+
+	v.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				CommitteeIndex: 1,
+				AttesterSlot:   1,
+				ValidatorIndex: 5,
+				PublicKey:      pubKey[:],
+			},
+		},
+	}
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+
+	m.validatorClient.EXPECT().GetAggregatedSelections(
+		gomock.Any(), // ctx
+		gomock.Any(), // selections
+	).Return(nil, errors.New("bad")).Times(1)
+
+	// The validator still attests, but does not aggregate.
+	roleMap, err := v.RolesAt(context.Background(), 1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []iface.ValidatorRole{iface.RoleAttester}, roleMap[pubKey])
+}
+
+func TestRemoteSlasherProtection_Distributed(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{RemoteSlasherProtection: true})
+	defer resetCfg()
+
+	v := &validator{}
+	assert.Equal(t, true, v.remoteSlasherProtection())
+	v.distributed = true
+	assert.Equal(t, false, v.remoteSlasherProtection())
+}
+
+func TestAttSelectionProof_Distributed_Error(t *testing.T) {
+	v, m, validatorKey, finish := setup(t)
+	defer finish()
+	v.distributed = true
+	pubKey := bytesutil.ToBytes48(validatorKey.PublicKey().Marshal())
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+
+	m.validatorClient.EXPECT().GetAggregatedSelections(
+		gomock.Any(), // ctx
+		gomock.Any(), // selections
+	).Return(nil, errors.New("bad"))
+
+	_, err := v.attSelectionProof(context.Background(), 1, pubKey, 5)
+	require.ErrorContains(t, "could not get aggregated selection proofs: bad", err)
+}
+
+func TestSyncSelectionProof_Distributed(t *testing.T) {
+	v, m, validatorKey, finish := setup(t)
+	defer finish()
+	v.distributed = true
+	pubKey := bytesutil.ToBytes48(validatorKey.PublicKey().Marshal())
+	aggregatedProof := bytesutil.PadTo([]byte("aggregated"), 96)
+
+	v.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				ValidatorIndex:  5,
+				PublicKey:       pubKey[:],
+				IsSyncCommittee: true,
+			},
+		},
+	}
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+
+	m.validatorClient.EXPECT().GetAggregatedSyncSelections(
+		gomock.Any(), // ctx
+		gomock.Any(), // selections
+	).DoAndReturn(func(_ context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+		require.Equal(t, 1, len(selections))
+		assert.Equal(t, primitives.CommitteeIndex(2), selections[0].SubcommitteeIndex)
+		return []iface.SyncCommitteeSelection{{
+			SelectionProof:    aggregatedProof,
+			Slot:              1,
+			SubcommitteeIndex: 2,
+			ValidatorIndex:    5,
+		}}, nil
+	}).Times(1)
+
+	for i := 0; i < 2; i++ {
+		proof, err := v.syncSelectionProof(context.Background(), 1, pubKey, 2)
+		require.NoError(t, err)
+		assert.DeepEqual(t, aggregatedProof, proof)
+	}
+}
+
+func TestValidator_CheckDoppelGanger_Distributed(t *testing.T) {
+	flgs := features.Get()
+	flgs.EnableDoppelGanger = true
+	reset := features.InitWithReset(flgs)
+	defer reset()
+
+	// No call is expected to the beacon node.
+	v, _, _, finish := setup(t)
+	defer finish()
+	v.distributed = true
+	require.NoError(t, v.CheckDoppelGanger(context.Background()))
+}
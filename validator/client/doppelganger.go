@@ -0,0 +1,355 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// Doppelganger protection keeps a validator key from performing duties until the key was found not live on the
+// network for doppelgangerEpochs consecutive epochs, so that a key already running in another validator client
+// is not slashed. The liveness of the keys in the previous epoch is checked at the last slot of each epoch with
+// the liveness endpoint of the beacon API, once the attestations of the previous epoch could have been included.
+// The state of the check is persisted, so that a quick restart of the validator client resumes the check instead
+// of starting it over.
+
+// startDoppelgangerCheck starts the doppelganger check of the keys, resuming the persisted state of the check,
+// and looks for keys live in the previous epoch right away. As the previous epoch may not be complete yet, keys
+// not found live are not counted as clean for it. An error is returned if a doppelganger is detected.
+func (v *validator) startDoppelgangerCheck(ctx context.Context, pubKeys [][fieldparams.BLSPubkeyLength]byte) error {
+	ctx, span := trace.StartSpan(ctx, "validator.startDoppelgangerCheck")
+	defer span.End()
+
+	epoch := slots.ToEpoch(slots.CurrentSlot(v.genesisTime))
+	records, err := v.db.DoppelgangerRecords(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get doppelganger records")
+	}
+	pendingKeys := make([][fieldparams.BLSPubkeyLength]byte, 0, len(pubKeys))
+	v.doppelgangerLock.Lock()
+	v.doppelgangerRecords = make(map[[fieldparams.BLSPubkeyLength]byte]*kv.DoppelgangerRecord, len(pubKeys))
+	v.doppelgangerDetected = make(map[[fieldparams.BLSPubkeyLength]byte]bool)
+	for _, pubKey := range pubKeys {
+		record := resumeDoppelgangerRecord(records[pubKey], epoch, v.doppelgangerEpochs)
+		v.doppelgangerRecords[pubKey] = record
+		if record.CleanEpochs < v.doppelgangerEpochs {
+			pendingKeys = append(pendingKeys, pubKey)
+		}
+	}
+	v.doppelgangerLock.Unlock()
+
+	indices, err := v.doppelgangerIndices(ctx, pendingKeys)
+	if err != nil {
+		return err
+	}
+	if err := v.checkDoppelgangerLiveness(ctx, epoch, indices, false /* complete */); err != nil {
+		if errors.Is(err, iface.ErrNotSupported) {
+			v.doppelgangerLock.Lock()
+			v.doppelgangerRecords = nil
+			v.doppelgangerLock.Unlock()
+		}
+		return err
+	}
+	if err := v.saveDoppelgangerRecords(ctx); err != nil {
+		return err
+	}
+
+	v.doppelgangerLock.RLock()
+	defer v.doppelgangerLock.RUnlock()
+	responses := make([]*ethpb.DoppelGangerResponse_ValidatorResponse, 0, len(v.doppelgangerDetected))
+	for pubKey := range v.doppelgangerDetected {
+		copiedKey := pubKey
+		responses = append(responses, &ethpb.DoppelGangerResponse_ValidatorResponse{
+			PublicKey:       copiedKey[:],
+			DuplicateExists: true,
+		})
+	}
+	return buildDuplicateError(responses)
+}
+
+// resumeDoppelgangerRecord returns the state of the doppelganger check of a key when the validator client
+// starts in epoch, given the state persisted before the restart.
+func resumeDoppelgangerRecord(record *kv.DoppelgangerRecord, epoch primitives.Epoch, requiredEpochs uint64) *kv.DoppelgangerRecord {
+	if record != nil {
+		// The key performed duties in the previous or the current epoch, so its liveness in these epochs may be
+		// its own and it stays cleared.
+		if record.CleanEpochs >= requiredEpochs && record.Epoch >= epoch {
+			return &kv.DoppelgangerRecord{Epoch: record.Epoch, CleanEpochs: record.CleanEpochs}
+		}
+		// The liveness of the key is still served for the next epoch to check.
+		if record.CleanEpochs < requiredEpochs && record.Epoch+1 >= epoch {
+			return &kv.DoppelgangerRecord{Epoch: record.Epoch, CleanEpochs: record.CleanEpochs}
+		}
+	}
+	// The check starts over with the previous epoch, the earliest one the liveness endpoint has to serve.
+	if epoch == 0 {
+		return &kv.DoppelgangerRecord{}
+	}
+	return &kv.DoppelgangerRecord{Epoch: epoch - 1}
+}
+
+// doppelgangerIndices returns the validator indices of the keys known to the beacon chain.
+func (v *validator) doppelgangerIndices(
+	ctx context.Context,
+	pubKeys [][fieldparams.BLSPubkeyLength]byte,
+) (map[[fieldparams.BLSPubkeyLength]byte]primitives.ValidatorIndex, error) {
+	indices := make(map[[fieldparams.BLSPubkeyLength]byte]primitives.ValidatorIndex, len(pubKeys))
+	if len(pubKeys) == 0 {
+		return indices, nil
+	}
+	resp, err := v.validatorClient.MultipleValidatorStatus(ctx, &ethpb.MultipleValidatorStatusRequest{
+		PublicKeys: bytesutil.FromBytes48Array(pubKeys),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validator statuses")
+	}
+	if len(resp.PublicKeys) != len(resp.Statuses) || len(resp.PublicKeys) != len(resp.Indices) {
+		return nil, errors.New("mismatched validator statuses in response")
+	}
+	for i, pubKey := range resp.PublicKeys {
+		if resp.Statuses[i].Status == ethpb.ValidatorStatus_UNKNOWN_STATUS {
+			continue
+		}
+		indices[bytesutil.ToBytes48(pubKey)] = resp.Indices[i]
+	}
+	return indices, nil
+}
+
+// updateDoppelgangerCheck adds the keys added since the validator client started to the doppelganger check at
+// the start of epoch, and records that the cleared keys perform duties in this epoch.
+func (v *validator) updateDoppelgangerCheck(ctx context.Context, epoch primitives.Epoch, duties []*ethpb.DutiesResponse_Duty) error {
+	ctx, span := trace.StartSpan(ctx, "validator.updateDoppelgangerCheck")
+	defer span.End()
+
+	v.doppelgangerLock.Lock()
+	if v.doppelgangerRecords == nil {
+		v.doppelgangerLock.Unlock()
+		return nil
+	}
+	for _, duty := range duties {
+		if duty == nil {
+			continue
+		}
+		pubKey := bytesutil.ToBytes48(duty.PublicKey)
+		if _, ok := v.doppelgangerRecords[pubKey]; !ok {
+			v.doppelgangerRecords[pubKey] = resumeDoppelgangerRecord(nil, epoch, v.doppelgangerEpochs)
+		}
+	}
+	// Cleared keys perform duties in this epoch.
+	for pubKey, record := range v.doppelgangerRecords {
+		if !v.doppelgangerDetected[pubKey] && record.CleanEpochs >= v.doppelgangerEpochs && record.Epoch <= epoch {
+			record.Epoch = epoch + 1
+		}
+	}
+	v.doppelgangerLock.Unlock()
+
+	return v.saveDoppelgangerRecords(ctx)
+}
+
+// completeDoppelgangerCheck checks the liveness in the previous epoch of the pending keys at the last slot of
+// epoch, with the validator indices of the keys taken from their duties. Keys whose liveness could not be checked
+// stay pending, rather than being cleared without a check.
+func (v *validator) completeDoppelgangerCheck(ctx context.Context, epoch primitives.Epoch) error {
+	ctx, span := trace.StartSpan(ctx, "validator.completeDoppelgangerCheck")
+	defer span.End()
+
+	v.doppelgangerLock.RLock()
+	started := v.doppelgangerRecords != nil
+	v.doppelgangerLock.RUnlock()
+	if !started {
+		return nil
+	}
+	v.dutiesLock.RLock()
+	var duties []*ethpb.DutiesResponse_Duty
+	if v.duties != nil {
+		duties = v.duties.CurrentEpochDuties
+	}
+	indices := make(map[[fieldparams.BLSPubkeyLength]byte]primitives.ValidatorIndex, len(duties))
+	for _, duty := range duties {
+		if duty != nil && duty.Status != ethpb.ValidatorStatus_UNKNOWN_STATUS {
+			indices[bytesutil.ToBytes48(duty.PublicKey)] = duty.ValidatorIndex
+		}
+	}
+	v.dutiesLock.RUnlock()
+
+	err := v.checkDoppelgangerLiveness(ctx, epoch, indices, true /* complete */)
+	if saveErr := v.saveDoppelgangerRecords(ctx); saveErr != nil {
+		return saveErr
+	}
+	return err
+}
+
+// checkDoppelgangerLiveness checks the liveness in the previous epoch of the keys due to be checked for that
+// epoch. A key found live is marked as having a doppelganger, and a key unknown to the beacon chain can not
+// have been live. Keys not found live are only counted as clean for the previous epoch if it is complete, that is
+// if all its attestations could have been included.
+func (v *validator) checkDoppelgangerLiveness(
+	ctx context.Context,
+	epoch primitives.Epoch,
+	indices map[[fieldparams.BLSPubkeyLength]byte]primitives.ValidatorIndex,
+	complete bool,
+) error {
+	if epoch == 0 {
+		return nil
+	}
+	previous := epoch - 1
+
+	v.doppelgangerLock.Lock()
+	pubKeys := make(map[primitives.ValidatorIndex][fieldparams.BLSPubkeyLength]byte)
+	var checkIndices []primitives.ValidatorIndex
+	for pubKey, record := range v.doppelgangerRecords {
+		if v.doppelgangerDetected[pubKey] || record.CleanEpochs >= v.doppelgangerEpochs {
+			continue
+		}
+		// The check missed an epoch, which can not be checked anymore, so it starts over.
+		if record.Epoch < previous {
+			record.Epoch = previous
+			record.CleanEpochs = 0
+		}
+		if record.Epoch != previous {
+			continue
+		}
+		index, ok := indices[pubKey]
+		if !ok {
+			if complete {
+				v.markDoppelgangerClean(pubKey, record)
+			}
+			continue
+		}
+		pubKeys[index] = pubKey
+		checkIndices = append(checkIndices, index)
+	}
+	v.doppelgangerLock.Unlock()
+	if len(checkIndices) == 0 {
+		return nil
+	}
+
+	liveness, err := v.validatorClient.GetValidatorsLiveness(ctx, previous, checkIndices)
+	if err != nil {
+		return errors.Wrap(err, "could not get the liveness of validators")
+	}
+
+	v.doppelgangerLock.Lock()
+	defer v.doppelgangerLock.Unlock()
+	for _, l := range liveness {
+		pubKey, ok := pubKeys[l.Index]
+		if !ok {
+			continue
+		}
+		record, ok := v.doppelgangerRecords[pubKey]
+		if !ok || record.Epoch != previous {
+			continue
+		}
+		if l.IsLive {
+			v.doppelgangerDetected[pubKey] = true
+			log.WithFields(logrus.Fields{
+				"publicKey": fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])),
+				"epoch":     previous,
+			}).Error("Doppelganger detected, another instance of the key is live on the network. " +
+				"The key will not perform duties until the validator client is restarted")
+			continue
+		}
+		if complete {
+			v.markDoppelgangerClean(pubKey, record)
+		}
+	}
+	return nil
+}
+
+// markDoppelgangerClean records that the key was not live in the epoch of its record. It has to be called with
+// the doppelganger lock held.
+func (v *validator) markDoppelgangerClean(pubKey [fieldparams.BLSPubkeyLength]byte, record *kv.DoppelgangerRecord) {
+	record.Epoch++
+	record.CleanEpochs++
+	if record.CleanEpochs == v.doppelgangerEpochs {
+		// The key performs duties from the last slot of the current epoch on.
+		record.Epoch++
+		log.WithField(
+			"publicKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])),
+		).Info("No doppelganger found, the key performs duties from now on")
+	}
+}
+
+// saveDoppelgangerRecords persists the state of the doppelganger check.
+func (v *validator) saveDoppelgangerRecords(ctx context.Context) error {
+	v.doppelgangerLock.RLock()
+	records := make(map[[fieldparams.BLSPubkeyLength]byte]*kv.DoppelgangerRecord, len(v.doppelgangerRecords))
+	for pubKey, record := range v.doppelgangerRecords {
+		if v.doppelgangerDetected[pubKey] {
+			continue
+		}
+		records[pubKey] = &kv.DoppelgangerRecord{Epoch: record.Epoch, CleanEpochs: record.CleanEpochs}
+	}
+	v.doppelgangerLock.RUnlock()
+	return errors.Wrap(v.db.SaveDoppelgangerRecords(ctx, records), "could not save doppelganger records")
+}
+
+// checkAddedKeys checks the keys added to the watched keystores directory for doppelgangers before they are
+// imported, and returns the keys with a doppelganger. With the liveness check, the added keys are not checked here
+// as they stay pending until found not live for doppelgangerEpochs epochs. Otherwise, they are checked once.
+func (v *validator) checkAddedKeys(
+	ctx context.Context,
+	pubKeys [][fieldparams.BLSPubkeyLength]byte,
+) (map[[fieldparams.BLSPubkeyLength]byte]bool, error) {
+	if !features.Get().EnableDoppelGanger || v.distributed || len(pubKeys) == 0 {
+		return nil, nil
+	}
+	v.doppelgangerLock.RLock()
+	liveness := v.doppelgangerRecords != nil
+	v.doppelgangerLock.RUnlock()
+	if liveness {
+		return nil, nil
+	}
+	responses, err := v.checkDoppelgangerOnce(ctx, pubKeys)
+	if err != nil {
+		return nil, err
+	}
+	doppelgangers := make(map[[fieldparams.BLSPubkeyLength]byte]bool)
+	for _, r := range responses {
+		if r.DuplicateExists {
+			doppelgangers[bytesutil.ToBytes48(r.PublicKey)] = true
+		}
+	}
+	return doppelgangers, nil
+}
+
+// doppelgangerStatus returns the status of the doppelganger check of the key.
+func (v *validator) doppelgangerStatus(pubKey [fieldparams.BLSPubkeyLength]byte) iface.DoppelgangerStatus {
+	v.doppelgangerLock.RLock()
+	defer v.doppelgangerLock.RUnlock()
+	if v.doppelgangerRecords == nil {
+		return iface.DoppelgangerCleared
+	}
+	if v.doppelgangerDetected[pubKey] {
+		return iface.DoppelgangerDetected
+	}
+	record, ok := v.doppelgangerRecords[pubKey]
+	if !ok || record.CleanEpochs < v.doppelgangerEpochs {
+		return iface.DoppelgangerPending
+	}
+	return iface.DoppelgangerCleared
+}
+
+// DoppelgangerStatuses returns the status of the doppelganger check of each validating key.
+func (v *validator) DoppelgangerStatuses(ctx context.Context) (map[[fieldparams.BLSPubkeyLength]byte]iface.DoppelgangerStatus, error) {
+	pubKeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, msgCouldNotFetchKeys)
+	}
+	statuses := make(map[[fieldparams.BLSPubkeyLength]byte]iface.DoppelgangerStatus, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		statuses[pubKey] = v.doppelgangerStatus(pubKey)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+)
+
+// setupDoppelganger returns a validator with doppelganger protection over 2 epochs, in the middle of epoch 10.
+func setupDoppelganger(t *testing.T) (*validator, *mocks, [fieldparams.BLSPubkeyLength]byte, func()) {
+	flgs := features.Get()
+	flgs.EnableDoppelGanger = true
+	reset := features.InitWithReset(flgs)
+
+	v, m, validatorKey, finish := setup(t)
+	v.doppelgangerEpochs = 2
+	epochDuration := uint64(params.BeaconConfig().SlotsPerEpoch) * params.BeaconConfig().SecondsPerSlot
+	v.genesisTime = uint64(time.Now().Unix()) - 10*epochDuration - epochDuration/2
+	pubKey := bytesutil.ToBytes48(validatorKey.PublicKey().Marshal())
+	return v, m, pubKey, func() {
+		finish()
+		reset()
+	}
+}
+
+func expectValidatorIndex(m *mocks, pubKey [fieldparams.BLSPubkeyLength]byte, index primitives.ValidatorIndex) {
+	m.validatorClient.EXPECT().MultipleValidatorStatus(
+		gomock.Any(), // ctx
+		&ethpb.MultipleValidatorStatusRequest{PublicKeys: [][]byte{pubKey[:]}},
+	).Return(&ethpb.MultipleValidatorStatusResponse{
+		PublicKeys: [][]byte{pubKey[:]},
+		Statuses:   []*ethpb.ValidatorStatusResponse{{Status: ethpb.ValidatorStatus_ACTIVE}},
+		Indices:    []primitives.ValidatorIndex{index},
+	}, nil)
+}
+
+func TestValidator_CheckDoppelGanger_Liveness(t *testing.T) {
+	v, m, pubKey, finish := setupDoppelganger(t)
+	defer finish()
+	ctx := context.Background()
+
+	expectValidatorIndex(m, pubKey, 5)
+	m.validatorClient.EXPECT().GetValidatorsLiveness(
+		gomock.Any(), // ctx
+		primitives.Epoch(9),
+		[]primitives.ValidatorIndex{5},
+	).Return([]*iface.ValidatorLiveness{{Index: 5, IsLive: false}}, nil)
+	require.NoError(t, v.CheckDoppelGanger(ctx))
+	assert.Equal(t, iface.DoppelgangerPending, v.doppelgangerStatus(pubKey))
+	// The previous epoch is not complete when the validator client starts, so it is checked again at the end of
+	// the current epoch.
+	records, err := v.db.DoppelgangerRecords(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &kv.DoppelgangerRecord{Epoch: 9}, records[pubKey])
+
+	// The key does not perform duties until it is cleared.
+	duties := []*ethpb.DutiesResponse_Duty{{PublicKey: pubKey[:], ValidatorIndex: 5, AttesterSlot: 352, Status: ethpb.ValidatorStatus_ACTIVE}}
+	v.duties = &ethpb.DutiesResponse{Duties: duties, CurrentEpochDuties: duties}
+	roles, err := v.RolesAt(ctx, 352)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(roles))
+
+	m.validatorClient.EXPECT().GetValidatorsLiveness(
+		gomock.Any(), // ctx
+		primitives.Epoch(9),
+		[]primitives.ValidatorIndex{5},
+	).Return([]*iface.ValidatorLiveness{{Index: 5, IsLive: false}}, nil)
+	require.NoError(t, v.completeDoppelgangerCheck(ctx, 10))
+	assert.Equal(t, iface.DoppelgangerPending, v.doppelgangerStatus(pubKey))
+
+	require.NoError(t, v.updateDoppelgangerCheck(ctx, 11, duties))
+	m.validatorClient.EXPECT().GetValidatorsLiveness(
+		gomock.Any(), // ctx
+		primitives.Epoch(10),
+		[]primitives.ValidatorIndex{5},
+	).Return([]*iface.ValidatorLiveness{{Index: 5, IsLive: false}}, nil)
+	require.NoError(t, v.completeDoppelgangerCheck(ctx, 11))
+	assert.Equal(t, iface.DoppelgangerCleared, v.doppelgangerStatus(pubKey))
+
+	// The check is only done once per epoch.
+	require.NoError(t, v.completeDoppelgangerCheck(ctx, 11))
+
+	records, err = v.db.DoppelgangerRecords(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &kv.DoppelgangerRecord{Epoch: 12, CleanEpochs: 2}, records[pubKey])
+
+	statuses, err := v.DoppelgangerStatuses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, iface.DoppelgangerCleared, statuses[pubKey])
+}
+
+func TestValidator_CheckDoppelGanger_Detected(t *testing.T) {
+	v, m, pubKey, finish := setupDoppelganger(t)
+	defer finish()
+
+	expectValidatorIndex(m, pubKey, 5)
+	m.validatorClient.EXPECT().GetValidatorsLiveness(
+		gomock.Any(), // ctx
+		primitives.Epoch(9),
+		[]primitives.ValidatorIndex{5},
+	).Return([]*iface.ValidatorLiveness{{Index: 5, IsLive: true}}, nil)
+	require.ErrorContains(t, "Duplicate instances exists in the network for validator keys", v.CheckDoppelGanger(context.Background()))
+	assert.Equal(t, iface.DoppelgangerDetected, v.doppelgangerStatus(pubKey))
+}
+
+func TestValidator_CheckDoppelGanger_QuickRestart(t *testing.T) {
+	v, _, pubKey, finish := setupDoppelganger(t)
+	defer finish()
+	ctx := context.Background()
+
+	// The key performed duties in the current epoch before the restart, so it is cleared without checking its liveness.
+	require.NoError(t, v.db.SaveDoppelgangerRecords(ctx, map[[fieldparams.BLSPubkeyLength]byte]*kv.DoppelgangerRecord{
+		pubKey: {Epoch: 11, CleanEpochs: 2},
+	}))
+	require.NoError(t, v.CheckDoppelGanger(ctx))
+	assert.Equal(t, iface.DoppelgangerCleared, v.doppelgangerStatus(pubKey))
+}
+
+func TestValidator_CheckDoppelGanger_LivenessNotSupported(t *testing.T) {
+	v, m, pubKey, finish := setupDoppelganger(t)
+	defer finish()
+
+	expectValidatorIndex(m, pubKey, 5)
+	m.validatorClient.EXPECT().GetValidatorsLiveness(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+		gomock.Any(), // indices
+	).Return(nil, iface.ErrNotSupported)
+	// The check falls back to checking for doppelgangers once.
+	m.validatorClient.EXPECT().CheckDoppelGanger(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(&ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{
+		{PublicKey: pubKey[:], DuplicateExists: false},
+	}}, nil)
+	require.NoError(t, v.CheckDoppelGanger(context.Background()))
+	assert.Equal(t, iface.DoppelgangerCleared, v.doppelgangerStatus(pubKey))
+}
+
+func TestValidator_CheckAddedKeys(t *testing.T) {
+	v, m, pubKey, finish := setupDoppelganger(t)
+	defer finish()
+	ctx := context.Background()
+
+	// Without the liveness check, the added keys are checked once.
+	m.validatorClient.EXPECT().CheckDoppelGanger(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(&ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{
+		{PublicKey: pubKey[:], DuplicateExists: true},
+	}}, nil)
+	doppelgangers, err := v.checkAddedKeys(ctx, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	require.NoError(t, err)
+	assert.Equal(t, true, doppelgangers[pubKey])
+
+	// With the liveness check, the added keys stay pending until they are checked.
+	v.doppelgangerRecords = make(map[[fieldparams.BLSPubkeyLength]byte]*kv.DoppelgangerRecord)
+	doppelgangers, err = v.checkAddedKeys(ctx, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(doppelgangers))
+	assert.Equal(t, iface.DoppelgangerPending, v.doppelgangerStatus(pubKey))
+}
+
+func TestResumeDoppelgangerRecord(t *testing.T) {
+	tests := []struct {
+		name   string
+		record *kv.DoppelgangerRecord
+		want   *kv.DoppelgangerRecord
+	}{
+		{
+			name: "no record",
+			want: &kv.DoppelgangerRecord{Epoch: 9},
+		},
+		{
+			name:   "cleared key active in the current epoch",
+			record: &kv.DoppelgangerRecord{Epoch: 11, CleanEpochs: 2},
+			want:   &kv.DoppelgangerRecord{Epoch: 11, CleanEpochs: 2},
+		},
+		{
+			name:   "cleared key active in the previous epoch",
+			record: &kv.DoppelgangerRecord{Epoch: 10, CleanEpochs: 2},
+			want:   &kv.DoppelgangerRecord{Epoch: 10, CleanEpochs: 2},
+		},
+		{
+			name:   "cleared key inactive in the previous epoch",
+			record: &kv.DoppelgangerRecord{Epoch: 9, CleanEpochs: 2},
+			want:   &kv.DoppelgangerRecord{Epoch: 9},
+		},
+		{
+			name:   "pending key resumed",
+			record: &kv.DoppelgangerRecord{Epoch: 9, CleanEpochs: 1},
+			want:   &kv.DoppelgangerRecord{Epoch: 9, CleanEpochs: 1},
+		},
+		{
+			name:   "pending key missed an epoch",
+			record: &kv.DoppelgangerRecord{Epoch: 8, CleanEpochs: 1},
+			want:   &kv.DoppelgangerRecord{Epoch: 9},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.DeepEqual(t, tt.want, resumeDoppelgangerRecord(tt.record, 10, 2))
+		})
+	}
+}
@@ -5,14 +5,19 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	"google.golang.org/grpc"
 )
 
 type grpcValidatorClient struct {
 	beaconNodeValidatorClient ethpb.BeaconNodeValidatorClient
+	beaconChainClient         ethpb.BeaconChainClient
+	nodeClient                ethpb.NodeClient
 }
 
 func (c *grpcValidatorClient) GetDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
@@ -95,6 +100,66 @@ func (c *grpcValidatorClient) SubmitValidatorRegistrations(ctx context.Context,
 	return c.beaconNodeValidatorClient.SubmitValidatorRegistrations(ctx, in)
 }
 
+func (c *grpcValidatorClient) GetAggregatedSelections(context.Context, []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	return nil, errors.New("GetAggregatedSelections is not supported by the gRPC validator client")
+}
+
+func (c *grpcValidatorClient) GetAggregatedSyncSelections(context.Context, []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	return nil, errors.New("GetAggregatedSyncSelections is not supported by the gRPC validator client")
+}
+
+// GetValidatorsLiveness derives the liveness of the validators from their performance, which the beacon node
+// reports for the previous epoch only. A validator is live if any of its votes was included in the previous epoch
+// participation. Validators not active in the current epoch are left out of the response.
+func (c *grpcValidatorClient) GetValidatorsLiveness(ctx context.Context, epoch primitives.Epoch, indices []primitives.ValidatorIndex) ([]*iface.ValidatorLiveness, error) {
+	genesis, err := c.nodeClient.GetGenesis(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get genesis")
+	}
+	currentEpoch := slots.ToEpoch(slots.CurrentSlot(uint64(genesis.GenesisTime.AsTime().Unix())))
+	if epoch+1 != currentEpoch {
+		return nil, errors.Errorf("only the liveness in the epoch before the current epoch %d is served over gRPC, not in epoch %d", currentEpoch, epoch)
+	}
+
+	statusIndices := make([]int64, len(indices))
+	for i, index := range indices {
+		statusIndices[i] = int64(index)
+	}
+	statuses, err := c.beaconNodeValidatorClient.MultipleValidatorStatus(ctx, &ethpb.MultipleValidatorStatusRequest{Indices: statusIndices})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validator statuses")
+	}
+	if len(statuses.PublicKeys) != len(statuses.Indices) {
+		return nil, errors.New("mismatched validator statuses in response")
+	}
+	pubKeyIndices := make(map[[fieldparams.BLSPubkeyLength]byte]primitives.ValidatorIndex, len(statuses.PublicKeys))
+	for i, pubKey := range statuses.PublicKeys {
+		pubKeyIndices[bytesutil.ToBytes48(pubKey)] = statuses.Indices[i]
+	}
+
+	performance, err := c.beaconChainClient.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{Indices: indices})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validator performance")
+	}
+	if len(performance.CorrectlyVotedSource) != len(performance.PublicKeys) ||
+		len(performance.CorrectlyVotedTarget) != len(performance.PublicKeys) ||
+		len(performance.CorrectlyVotedHead) != len(performance.PublicKeys) {
+		return nil, errors.New("mismatched validator performance in response")
+	}
+	liveness := make([]*iface.ValidatorLiveness, 0, len(performance.PublicKeys))
+	for i, pubKey := range performance.PublicKeys {
+		index, ok := pubKeyIndices[bytesutil.ToBytes48(pubKey)]
+		if !ok {
+			continue
+		}
+		liveness = append(liveness, &iface.ValidatorLiveness{
+			Index:  index,
+			IsLive: performance.CorrectlyVotedSource[i] || performance.CorrectlyVotedTarget[i] || performance.CorrectlyVotedHead[i],
+		})
+	}
+	return liveness, nil
+}
+
 func (c *grpcValidatorClient) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, _ []primitives.ValidatorIndex) (*empty.Empty, error) {
 	return c.beaconNodeValidatorClient.SubscribeCommitteeSubnets(ctx, in)
 }
@@ -135,5 +200,9 @@ func (c *grpcValidatorClient) AggregatedSigAndAggregationBits(
 }
 
 func NewGrpcValidatorClient(cc grpc.ClientConnInterface) iface.ValidatorClient {
-	return &grpcValidatorClient{ethpb.NewBeaconNodeValidatorClient(cc)}
+	return &grpcValidatorClient{
+		beaconNodeValidatorClient: ethpb.NewBeaconNodeValidatorClient(cc),
+		beaconChainClient:         ethpb.NewBeaconChainClient(cc),
+		nodeClient:                ethpb.NewNodeClient(cc),
+	}
 }
@@ -4,11 +4,19 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	mock2 "github.com/prysmaticlabs/prysm/v4/testing/mock"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestWaitForChainStart_StreamSetupFails(t *testing.T) {
@@ -21,8 +29,56 @@ func TestWaitForChainStart_StreamSetupFails(t *testing.T) {
 		gomock.Any(),
 	).Return(nil, errors.New("failed stream"))
 
-	validatorClient := &grpcValidatorClient{beaconNodeValidatorClient}
+	validatorClient := &grpcValidatorClient{beaconNodeValidatorClient: beaconNodeValidatorClient}
 	_, err := validatorClient.WaitForChainStart(context.Background(), &emptypb.Empty{})
 	want := "could not setup beacon chain ChainStart streaming client"
 	assert.ErrorContains(t, want, err)
 }
+
+func TestGetValidatorsLiveness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	epochDuration := time.Duration(uint64(params.BeaconConfig().SlotsPerEpoch)*params.BeaconConfig().SecondsPerSlot) * time.Second
+	genesis := time.Now().Add(-10*epochDuration - epochDuration/2)
+	nodeClient := mock2.NewMockNodeClient(ctrl)
+	nodeClient.EXPECT().GetGenesis(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&ethpb.Genesis{GenesisTime: timestamppb.New(genesis)}, nil).Times(2)
+
+	pubKey1 := bytesutil.PadTo([]byte("key1"), 48)
+	pubKey2 := bytesutil.PadTo([]byte("key2"), 48)
+	beaconNodeValidatorClient := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	beaconNodeValidatorClient.EXPECT().MultipleValidatorStatus(
+		gomock.Any(),
+		&ethpb.MultipleValidatorStatusRequest{Indices: []int64{1, 2, 3}},
+	).Return(&ethpb.MultipleValidatorStatusResponse{
+		PublicKeys: [][]byte{pubKey1, pubKey2},
+		Statuses:   []*ethpb.ValidatorStatusResponse{{Status: ethpb.ValidatorStatus_ACTIVE}, {Status: ethpb.ValidatorStatus_ACTIVE}},
+		Indices:    []primitives.ValidatorIndex{1, 2},
+	}, nil)
+	beaconChainClient := mock2.NewMockBeaconChainClient(ctrl)
+	beaconChainClient.EXPECT().GetValidatorPerformance(
+		gomock.Any(),
+		&ethpb.ValidatorPerformanceRequest{Indices: []primitives.ValidatorIndex{1, 2, 3}},
+	).Return(&ethpb.ValidatorPerformanceResponse{
+		PublicKeys:           [][]byte{pubKey1, pubKey2},
+		CorrectlyVotedSource: []bool{false, false},
+		CorrectlyVotedTarget: []bool{true, false},
+		CorrectlyVotedHead:   []bool{false, false},
+	}, nil)
+
+	validatorClient := &grpcValidatorClient{
+		beaconNodeValidatorClient: beaconNodeValidatorClient,
+		beaconChainClient:         beaconChainClient,
+		nodeClient:                nodeClient,
+	}
+	liveness, err := validatorClient.GetValidatorsLiveness(context.Background(), 9, []primitives.ValidatorIndex{1, 2, 3})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []*iface.ValidatorLiveness{{Index: 1, IsLive: true}, {Index: 2, IsLive: false}}, liveness)
+
+	// Only the previous epoch is served.
+	_, err = validatorClient.GetValidatorsLiveness(context.Background(), 10, []primitives.ValidatorIndex{1})
+	assert.ErrorContains(t, "only the liveness in the epoch before the current epoch 10 is served over gRPC", err)
+}
@@ -17,6 +17,10 @@ import (
 // ErrConnectionIssue represents a connection problem.
 var ErrConnectionIssue = errors.New("could not connect")
 
+// ErrNotSupported represents a request the beacon node does not support, such as an endpoint specific to
+// another client implementation.
+var ErrNotSupported = errors.New("not supported by the beacon node")
+
 // ValidatorRole defines the validator role.
 type ValidatorRole int8
 
@@ -35,6 +39,73 @@ const (
 	RoleSyncCommitteeAggregator
 )
 
+// DoppelgangerStatus defines the status of the doppelganger protection of a validator key.
+type DoppelgangerStatus int8
+
+const (
+	// DoppelgangerPending means that the liveness of the key is still being checked, and that the key does not
+	// perform duties until the check is over.
+	DoppelgangerPending DoppelgangerStatus = iota
+	// DoppelgangerCleared means that no doppelganger was found for the key, or that doppelganger protection is
+	// disabled, and that the key performs duties.
+	DoppelgangerCleared
+	// DoppelgangerDetected means that another instance of the key was found live on the network, and that the key
+	// does not perform duties.
+	DoppelgangerDetected
+)
+
+// String returns the name of the doppelganger status.
+func (s DoppelgangerStatus) String() string {
+	switch s {
+	case DoppelgangerPending:
+		return "pending"
+	case DoppelgangerCleared:
+		return "cleared"
+	case DoppelgangerDetected:
+		return "detected"
+	default:
+		return "unknown"
+	}
+}
+
+// EpochPerformance is the performance of a validator key during an epoch. The attestation performance is reported by
+// the beacon node at the end of the next epoch, except for the inclusion distance after Altair, which is recorded as
+// soon as the attestation is seen in a block. The proposals and sync committee messages are recorded by the
+// validator client as they are made.
+type EpochPerformance struct {
+	PublicKey   [fieldparams.BLSPubkeyLength]byte
+	Epoch       primitives.Epoch
+	Attestation *AttestationPerformance
+	Proposals   []*ProposalPerformance
+	// SyncCommitteeMessages is the number of sync committee messages submitted during the epoch, and
+	// SyncCommitteeMessagesFailed the number of those which could not be submitted.
+	SyncCommitteeMessages       uint64
+	SyncCommitteeMessagesFailed uint64
+}
+
+// AttestationPerformance is the performance of the attestation of a validator key during an epoch, as reported by
+// the beacon node.
+type AttestationPerformance struct {
+	// InclusionDistance is the number of slots between the attestation and the first block including it. It is
+	// reported by the beacon node before Altair, and derived from the blocks received from the beacon node
+	// afterwards, in which case it is 0 if the attestation was not seen in a block.
+	InclusionDistance    primitives.Slot
+	CorrectlyVotedSource bool
+	CorrectlyVotedTarget bool
+	CorrectlyVotedHead   bool
+	BalanceBefore        uint64
+	BalanceAfter         uint64
+	InactivityScore      uint64
+}
+
+// ProposalPerformance is the outcome of a block proposal of a validator key. The block root is empty when the block
+// could not be proposed.
+type ProposalPerformance struct {
+	Slot      primitives.Slot
+	Proposed  bool
+	BlockRoot []byte
+}
+
 // Validator interface defines the primary methods of a validator client.
 type Validator interface {
 	Done()
@@ -60,6 +131,8 @@ type Validator interface {
 	ReceiveBlocks(ctx context.Context, connectionErrorChannel chan<- error)
 	HandleKeyReload(ctx context.Context, currentKeys [][fieldparams.BLSPubkeyLength]byte) (bool, error)
 	CheckDoppelGanger(ctx context.Context) error
+	DoppelgangerStatuses(ctx context.Context) (map[[fieldparams.BLSPubkeyLength]byte]DoppelgangerStatus, error)
+	Performance() []*EpochPerformance
 	PushProposerSettings(ctx context.Context, km keymanager.IKeymanager, slot primitives.Slot, deadline time.Time) error
 	SignValidatorRegistrationRequest(ctx context.Context, signer SigningFunc, newValidatorRegistration *ethpb.ValidatorRegistrationV1) (*ethpb.SignedValidatorRegistrationV1, error)
 	ProposerSettings() *validatorserviceconfig.ProposerSettings
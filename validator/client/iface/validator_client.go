@@ -8,6 +8,29 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 )
 
+// BeaconCommitteeSelection is the selection proof of a validator for aggregating the attestations of its
+// committee at a slot.
+type BeaconCommitteeSelection struct {
+	SelectionProof []byte
+	Slot           primitives.Slot
+	ValidatorIndex primitives.ValidatorIndex
+}
+
+// SyncCommitteeSelection is the selection proof of a validator for aggregating the sync committee messages of
+// a sync subcommittee at a slot.
+type SyncCommitteeSelection struct {
+	SelectionProof    []byte
+	Slot              primitives.Slot
+	SubcommitteeIndex primitives.CommitteeIndex
+	ValidatorIndex    primitives.ValidatorIndex
+}
+
+// ValidatorLiveness tells whether a validator was seen performing its duties in an epoch.
+type ValidatorLiveness struct {
+	Index  primitives.ValidatorIndex
+	IsLive bool
+}
+
 type ValidatorClient interface {
 	GetDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error)
 	DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error)
@@ -34,4 +57,14 @@ type ValidatorClient interface {
 	SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) (*empty.Empty, error)
 	StreamBlocksAltair(ctx context.Context, in *ethpb.StreamBlocksRequest) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error)
 	SubmitValidatorRegistrations(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1) (*empty.Empty, error)
+	// GetAggregatedSelections returns the selection proofs combined by a distributed validator middleware from the
+	// partial selection proofs of the nodes of the cluster.
+	GetAggregatedSelections(ctx context.Context, selections []BeaconCommitteeSelection) ([]BeaconCommitteeSelection, error)
+	// GetAggregatedSyncSelections returns the sync committee selection proofs combined by a distributed validator
+	// middleware from the partial selection proofs of the nodes of the cluster.
+	GetAggregatedSyncSelections(ctx context.Context, selections []SyncCommitteeSelection) ([]SyncCommitteeSelection, error)
+	// GetValidatorsLiveness returns whether the validators were seen performing their duties in the epoch, which
+	// has to be the current or the previous epoch. Over gRPC, only the previous epoch is served. ErrNotSupported
+	// is returned if the beacon node does not serve the liveness of validators.
+	GetValidatorsLiveness(ctx context.Context, epoch primitives.Epoch, indices []primitives.ValidatorIndex) ([]*ValidatorLiveness, error)
 }
@@ -0,0 +1,264 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpbservice "github.com/prysmaticlabs/prysm/v4/proto/eth/service"
+	vdb "github.com/prysmaticlabs/prysm/v4/validator/db"
+	"github.com/prysmaticlabs/prysm/v4/validator/keymanager"
+	"github.com/sirupsen/logrus"
+)
+
+// keystoreWatcher keeps the keymanager in sync with a directory of EIP-2335 keystores, so that keys can be added
+// and removed without restarting the validator client. The directory is scanned in the background when the duties
+// of a new epoch are fetched, so that decrypting the added keystores does not delay the duties. Added keys go
+// through the doppelganger check before performing duties, as any key unknown when the validator client started.
+// The keys imported by the watcher are persisted in the database, so that they are still deleted when their file is
+// removed after a restart.
+type keystoreWatcher struct {
+	dir      string
+	password string
+	db       vdb.Database
+	// keystores are the keystore files found in the directory, by file name. They are loaded from the database
+	// before the first reload.
+	keystores map[string]*watchedKeystore
+	loaded    bool
+	// reloads carries the keymanager to reload the directory into to the watcher goroutine.
+	reloads chan keymanager.IKeymanager
+	// checkKeys returns the keys among the added ones which have a doppelganger, so that they are not imported.
+	checkKeys func(ctx context.Context, pubKeys [][fieldparams.BLSPubkeyLength]byte) (map[[fieldparams.BLSPubkeyLength]byte]bool, error)
+}
+
+type watchedKeystore struct {
+	modTime time.Time
+	// pubKey is only set when the keystore was imported from this file, so that only the keys imported by the
+	// watcher are deleted from the keymanager when their file is removed.
+	pubKey []byte
+}
+
+func newKeystoreWatcher(dir, password string, db vdb.Database) *keystoreWatcher {
+	return &keystoreWatcher{
+		dir:       dir,
+		password:  password,
+		db:        db,
+		keystores: make(map[string]*watchedKeystore),
+		reloads:   make(chan keymanager.IKeymanager, 1),
+	}
+}
+
+// run reloads the directory into the keymanager whenever requested, until the context is done.
+func (w *keystoreWatcher) run(ctx context.Context) {
+	for {
+		select {
+		case km := <-w.reloads:
+			if err := w.reload(ctx, km); err != nil {
+				log.WithError(err).Error("Could not reload the keystores directory")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// requestReload requests the watcher goroutine to reload the directory into the keymanager, unless a reload is
+// already pending.
+func (w *keystoreWatcher) requestReload(km keymanager.IKeymanager) {
+	select {
+	case w.reloads <- km:
+	default:
+	}
+}
+
+// reload imports the keystores added to the directory into the keymanager and deletes the keys whose keystore was
+// removed from the directory. A keystore which could not be imported is tried again once its file is modified.
+func (w *keystoreWatcher) reload(ctx context.Context, km keymanager.IKeymanager) error {
+	importer, ok := km.(keymanager.Importer)
+	if !ok {
+		return errors.New("keymanager does not support importing keystores")
+	}
+	if !w.loaded {
+		imported, err := w.db.WatchedKeystores(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not read the keystores imported from the directory")
+		}
+		for name, pubKey := range imported {
+			w.keystores[name] = &watchedKeystore{pubKey: pubKey}
+		}
+		w.loaded = true
+	}
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return errors.Wrap(err, "could not read keystores directory")
+	}
+
+	found := make(map[string]bool, len(entries))
+	var names []string
+	var keystores []*keymanager.Keystore
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		found[entry.Name()] = true
+		info, err := entry.Info()
+		if err != nil {
+			log.WithError(err).WithField("file", entry.Name()).Error("Could not read keystore file info")
+			continue
+		}
+		if watched, ok := w.keystores[entry.Name()]; ok && (watched.pubKey != nil || watched.modTime.Equal(info.ModTime())) {
+			continue
+		}
+		w.keystores[entry.Name()] = &watchedKeystore{modTime: info.ModTime()}
+		ks, err := readKeystore(filepath.Join(w.dir, entry.Name()))
+		if err != nil {
+			log.WithError(err).WithField("file", entry.Name()).Error("Could not read keystore file")
+			continue
+		}
+		names = append(names, entry.Name())
+		keystores = append(keystores, ks)
+	}
+
+	names, keystores, err = w.withoutDoppelgangers(ctx, names, keystores)
+	if err != nil {
+		return err
+	}
+	if len(keystores) > 0 {
+		passwords := make([]string, len(keystores))
+		for i := range passwords {
+			passwords[i] = w.password
+		}
+		statuses, err := importer.ImportKeystores(ctx, keystores, passwords)
+		if err != nil {
+			return errors.Wrap(err, "could not import keystores")
+		}
+		for i, status := range statuses {
+			log := log.WithField("file", names[i])
+			switch status.Status {
+			case ethpbservice.ImportedKeystoreStatus_IMPORTED:
+				pubKey, err := keystorePubKey(keystores[i])
+				if err != nil {
+					log.Warn("Imported keystore has no valid public key, it will not be removed with its file")
+					continue
+				}
+				w.keystores[names[i]].pubKey = pubKey
+				if err := w.db.SaveWatchedKeystore(ctx, names[i], pubKey); err != nil {
+					log.WithError(err).Error("Could not save imported keystore, it will not be removed with its file after a restart")
+				}
+				log.Info("Imported keystore added to the keystores directory")
+			case ethpbservice.ImportedKeystoreStatus_DUPLICATE:
+				log.Debug("Keystore added to the keystores directory is already imported")
+			default:
+				log.WithField("message", status.Message).Error("Could not import keystore added to the keystores directory")
+			}
+		}
+	}
+
+	var removed [][]byte
+	for name, watched := range w.keystores {
+		if found[name] {
+			continue
+		}
+		delete(w.keystores, name)
+		if watched.pubKey != nil {
+			removed = append(removed, watched.pubKey)
+			if err := w.db.DeleteWatchedKeystore(ctx, name); err != nil {
+				return errors.Wrap(err, "could not delete removed keystore from the database")
+			}
+		}
+	}
+	if len(removed) > 0 {
+		statuses, err := km.DeleteKeystores(ctx, removed)
+		if err != nil {
+			return errors.Wrap(err, "could not delete keystores")
+		}
+		for i, status := range statuses {
+			log.WithFields(logrus.Fields{
+				"publicKey": fmt.Sprintf("%#x", bytesutil.Trunc(removed[i])),
+				"status":    status.Status,
+			}).Info("Deleted keystore removed from the keystores directory")
+		}
+	}
+	return nil
+}
+
+// withoutDoppelgangers returns the keystores to import, leaving out the keys with a doppelganger. Keystores
+// without a public key are left out as well when the keys are checked, as they can not be checked before being
+// imported. If the check fails, the keystores are read again at the next reload.
+func (w *keystoreWatcher) withoutDoppelgangers(
+	ctx context.Context,
+	names []string,
+	keystores []*keymanager.Keystore,
+) ([]string, []*keymanager.Keystore, error) {
+	if w.checkKeys == nil || len(keystores) == 0 {
+		return names, keystores, nil
+	}
+	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, 0, len(keystores))
+	checkedNames := make([]string, 0, len(keystores))
+	checkedKeystores := make([]*keymanager.Keystore, 0, len(keystores))
+	for i, ks := range keystores {
+		pubKey, err := keystorePubKey(ks)
+		if err != nil {
+			log.WithField("file", names[i]).Error("Keystore added to the keystores directory has no valid public key " +
+				"to check for doppelgangers, it is not imported")
+			continue
+		}
+		pubKeys = append(pubKeys, bytesutil.ToBytes48(pubKey))
+		checkedNames = append(checkedNames, names[i])
+		checkedKeystores = append(checkedKeystores, ks)
+	}
+	if len(pubKeys) == 0 {
+		return nil, nil, nil
+	}
+	doppelgangers, err := w.checkKeys(ctx, pubKeys)
+	if err != nil {
+		for _, name := range checkedNames {
+			delete(w.keystores, name)
+		}
+		return nil, nil, errors.Wrap(err, "could not check the added keys for doppelgangers")
+	}
+	names, keystores = names[:0], keystores[:0]
+	for i, pubKey := range pubKeys {
+		if doppelgangers[pubKey] {
+			log.WithFields(logrus.Fields{
+				"file":      checkedNames[i],
+				"publicKey": fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])),
+			}).Error("Doppelganger detected for a keystore added to the keystores directory, it is not imported")
+			continue
+		}
+		names = append(names, checkedNames[i])
+		keystores = append(keystores, checkedKeystores[i])
+	}
+	return names, keystores, nil
+}
+
+func keystorePubKey(ks *keymanager.Keystore) ([]byte, error) {
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(ks.Pubkey, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(pubKey) != fieldparams.BLSPubkeyLength {
+		return nil, errors.Errorf("public key has %d bytes", len(pubKey))
+	}
+	return pubKey, nil
+}
+
+func readKeystore(path string) (*keymanager.Keystore, error) {
+	enc, err := os.ReadFile(path) // #nosec G304 -- the path is in the keystores directory set by the operator
+	if err != nil {
+		return nil, err
+	}
+	ks := &keymanager.Keystore{}
+	if err := json.Unmarshal(enc, ks); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
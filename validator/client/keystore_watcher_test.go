@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	mock "github.com/prysmaticlabs/prysm/v4/validator/accounts/testing"
+	dbTest "github.com/prysmaticlabs/prysm/v4/validator/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v4/validator/keymanager/local"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+func writeTestKeystore(t *testing.T, path, password string) []byte {
+	encryptor := keystorev4.New()
+	id, err := uuid.NewRandom()
+	require.NoError(t, err)
+	validatingKey, err := bls.RandKey()
+	require.NoError(t, err)
+	pubKey := validatingKey.PublicKey().Marshal()
+	cryptoFields, err := encryptor.Encrypt(validatingKey.Marshal(), password)
+	require.NoError(t, err)
+	enc, err := json.Marshal(&keymanager.Keystore{
+		Crypto:      cryptoFields,
+		Pubkey:      fmt.Sprintf("%x", pubKey),
+		ID:          id.String(),
+		Version:     encryptor.Version(),
+		Description: encryptor.Name(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, enc, 0600))
+	return pubKey
+}
+
+func TestKeystoreWatcher_Reload(t *testing.T) {
+	ctx := context.Background()
+	km, err := local.NewKeymanager(ctx, &local.SetupConfig{
+		Wallet: &mock.Wallet{
+			Files:          make(map[string]map[string][]byte),
+			WalletPassword: "Passwordz0202$",
+		},
+	})
+	require.NoError(t, err)
+	dir := t.TempDir()
+	w := newKeystoreWatcher(dir, "keystorePassword", dbTest.SetupDB(t, nil))
+
+	first := writeTestKeystore(t, filepath.Join(dir, "first.json"), "keystorePassword")
+	second := writeTestKeystore(t, filepath.Join(dir, "second.json"), "keystorePassword")
+	// A keystore with another password is not imported, and other files are ignored.
+	writeTestKeystore(t, filepath.Join(dir, "bad.json"), "otherPassword")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("keystores"), 0600))
+	require.NoError(t, w.reload(ctx, km))
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(keys))
+
+	// A removed keystore is deleted, and an added one is imported.
+	require.NoError(t, os.Remove(filepath.Join(dir, "first.json")))
+	third := writeTestKeystore(t, filepath.Join(dir, "third.json"), "keystorePassword")
+	require.NoError(t, w.reload(ctx, km))
+	keys, err = km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(keys))
+	imported := make(map[string]bool)
+	for _, k := range keys {
+		imported[string(k[:])] = true
+	}
+	assert.Equal(t, false, imported[string(first)])
+	assert.Equal(t, true, imported[string(second)])
+	assert.Equal(t, true, imported[string(third)])
+
+	// After a restart, a keystore imported before is still deleted when its file is removed.
+	w = newKeystoreWatcher(dir, "keystorePassword", w.db)
+	require.NoError(t, os.Remove(filepath.Join(dir, "second.json")))
+	require.NoError(t, w.reload(ctx, km))
+	keys, err = km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(keys))
+	assert.DeepEqual(t, bytesutil.ToBytes48(third), keys[0])
+}
+
+func TestKeystoreWatcher_Reload_Doppelgangers(t *testing.T) {
+	ctx := context.Background()
+	km, err := local.NewKeymanager(ctx, &local.SetupConfig{
+		Wallet: &mock.Wallet{
+			Files:          make(map[string]map[string][]byte),
+			WalletPassword: "Passwordz0202$",
+		},
+	})
+	require.NoError(t, err)
+	dir := t.TempDir()
+	w := newKeystoreWatcher(dir, "keystorePassword", dbTest.SetupDB(t, nil))
+
+	clean := writeTestKeystore(t, filepath.Join(dir, "clean.json"), "keystorePassword")
+	duplicate := writeTestKeystore(t, filepath.Join(dir, "duplicate.json"), "keystorePassword")
+	checkErr := errors.New("beacon node unavailable")
+	w.checkKeys = func(_ context.Context, pubKeys [][fieldparams.BLSPubkeyLength]byte) (map[[fieldparams.BLSPubkeyLength]byte]bool, error) {
+		require.Equal(t, 2, len(pubKeys))
+		if checkErr != nil {
+			return nil, checkErr
+		}
+		return map[[fieldparams.BLSPubkeyLength]byte]bool{bytesutil.ToBytes48(duplicate): true}, nil
+	}
+
+	// Nothing is imported if the keys could not be checked, and they are checked again at the next reload.
+	require.ErrorContains(t, "could not check the added keys for doppelgangers", w.reload(ctx, km))
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(keys))
+
+	// A key with a doppelganger is not imported.
+	checkErr = nil
+	require.NoError(t, w.reload(ctx, km))
+	keys, err = km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(keys))
+	assert.DeepEqual(t, bytesutil.ToBytes48(clean), keys[0])
+}
+
+func TestKeystoreWatcher_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	km, err := local.NewKeymanager(ctx, &local.SetupConfig{
+		Wallet: &mock.Wallet{
+			Files:          make(map[string]map[string][]byte),
+			WalletPassword: "Passwordz0202$",
+		},
+	})
+	require.NoError(t, err)
+	dir := t.TempDir()
+	w := newKeystoreWatcher(dir, "keystorePassword", dbTest.SetupDB(t, nil))
+	writeTestKeystore(t, filepath.Join(dir, "first.json"), "keystorePassword")
+	go w.run(ctx)
+
+	// The reload happens in the watcher goroutine.
+	w.requestReload(km)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		keys, err := km.FetchValidatingPublicKeys(ctx)
+		require.NoError(t, err)
+		if len(keys) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Keystores directory was not reloaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
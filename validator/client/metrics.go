@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
@@ -12,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	"github.com/sirupsen/logrus"
 )
 
@@ -67,6 +69,40 @@ var (
 			"pubkey",
 		},
 	)
+	// relayRegistrationsCount used to count the validator registrations submitted to each relay.
+	relayRegistrationsCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "relay_registrations_total",
+			Help:      "The number of validator registrations submitted to each relay, by result",
+		},
+		[]string{
+			"relay",
+			"result",
+		},
+	)
+	// beaconNodeHealthyGauge used to track whether each beacon node is responsive and synced.
+	beaconNodeHealthyGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_healthy",
+			Help:      "1 if the beacon node is responsive and synced, 0 otherwise",
+		},
+		[]string{
+			"endpoint",
+		},
+	)
+	// beaconNodeLatencySeconds used to track the average latency of each beacon node.
+	beaconNodeLatencySeconds = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_latency_seconds",
+			Help:      "The average latency of the health checks of the beacon node",
+		},
+		[]string{
+			"endpoint",
+		},
+	)
 	// ValidatorProposeFailVecSlasher used to count failed proposals by slashing protection.
 	ValidatorProposeFailVecSlasher = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -250,6 +286,11 @@ func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot primiti
 		PublicKeys: pubKeys,
 	}
 	resp, err := v.beaconClient.GetValidatorPerformance(ctx, req)
+	if errors.Is(err, iface.ErrNotSupported) {
+		log.WithError(err).Warn("Beacon node does not report validator performance, disabling balance logging")
+		v.logValidatorBalances = false
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -270,6 +311,8 @@ func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot primiti
 			v.voteStats.startEpoch = prevEpoch
 		}
 	}
+	v.recordAttestationPerformance(resp, prevEpoch)
+
 	v.prevBalanceLock.Lock()
 	for i, pubKey := range resp.PublicKeys {
 		v.logForEachValidator(i, pubKey, resp, slot, prevEpoch)
@@ -1,15 +1,19 @@
 package client
 
 import (
+	"context"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/v4/testing/validator-mock"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 )
 
@@ -163,3 +167,27 @@ func TestUpdateLogAltairAggregateStats(t *testing.T) {
 		"correctlyVotedHeadPct=\"86%\" correctlyVotedSourcePct=\"100%\" "+
 		"correctlyVotedTargetPct=\"71%\" numberOfEpochs=3 pctChangeCombinedBalance=\"0.20555%\"")
 }
+
+func TestLogValidatorGainsAndLosses_NotSupported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	hook := logTest.NewGlobal()
+
+	client := validatormock.NewMockBeaconChainClient(ctrl)
+	v := &validator{
+		beaconClient:         client,
+		keyManager:           genMockKeymanager(t, 1),
+		logValidatorBalances: true,
+	}
+	client.EXPECT().GetValidatorPerformance(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(nil, iface.ErrNotSupported).Times(1)
+
+	slot := 2*params.BeaconConfig().SlotsPerEpoch - 1
+	require.NoError(t, v.LogValidatorGainsAndLosses(context.Background(), slot))
+	require.LogsContain(t, hook, "Beacon node does not report validator performance")
+	require.Equal(t, false, v.logValidatorBalances)
+	// The performance is not queried anymore.
+	require.NoError(t, v.LogValidatorGainsAndLosses(context.Background(), slot+params.BeaconConfig().SlotsPerEpoch))
+}
@@ -0,0 +1,431 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// beaconNodeHealthTimeout is how long a beacon node has to answer a health check.
+	beaconNodeHealthTimeout = 2 * time.Second
+	// beaconNodeLatencyWeight is the weight of the latest health check in the average latency of a beacon node.
+	beaconNodeLatencyWeight = 0.3
+)
+
+// beaconNode is one of the beacon nodes the validator client is connected to.
+type beaconNode struct {
+	endpoint        string
+	validatorClient iface.ValidatorClient
+	nodeClient      iface.NodeClient
+	beaconClient    iface.BeaconChainClient
+	slasherClient   iface.SlasherClient
+
+	// The result of the latest health check.
+	responsive bool
+	syncing    bool
+	latency    time.Duration
+}
+
+// healthy returns true if the beacon node answered the latest health check and is synced.
+func (n *beaconNode) healthy() bool {
+	return n.responsive && !n.syncing
+}
+
+// multipleBeaconNodesClient is a validator client connected to several beacon nodes. It monitors the sync status
+// and the responsiveness of each of them, and routes the duties to the healthiest one. The blocks, attestations,
+// aggregates and sync committee messages it produces, as well as the subscriptions and registrations of the
+// validators, are broadcast to all the beacon nodes for redundancy.
+type multipleBeaconNodesClient struct {
+	lock  sync.RWMutex
+	nodes []*beaconNode
+	best  *beaconNode
+}
+
+func newMultipleBeaconNodesClient(nodes []*beaconNode) *multipleBeaconNodesClient {
+	c := &multipleBeaconNodesClient{nodes: nodes}
+	if len(nodes) > 0 {
+		c.best = nodes[0]
+	}
+	return c
+}
+
+// monitor checks the health of the beacon nodes every slot until the context is canceled.
+func (c *multipleBeaconNodesClient) monitor(ctx context.Context) {
+	c.checkHealth(ctx)
+	ticker := time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.checkHealth(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkHealth requests the sync status of all the beacon nodes concurrently, and selects the healthiest one as
+// each of them answers, so that a slow beacon node does not delay switching to a healthy one. Healthy beacon nodes
+// are preferred over syncing ones, which are preferred over unresponsive ones. Among beacon nodes equally healthy,
+// the one answering the fastest is selected.
+func (c *multipleBeaconNodesClient) checkHealth(ctx context.Context) {
+	type result struct {
+		node    *beaconNode
+		status  *ethpb.SyncStatus
+		err     error
+		latency time.Duration
+	}
+	results := make(chan result, len(c.nodes))
+	for _, n := range c.nodes {
+		go func(n *beaconNode) {
+			ctx, cancel := context.WithTimeout(ctx, beaconNodeHealthTimeout)
+			defer cancel()
+			start := time.Now()
+			status, err := n.nodeClient.GetSyncStatus(ctx, &empty.Empty{})
+			results <- result{node: n, status: status, err: err, latency: time.Since(start)}
+		}(n)
+	}
+
+	for range c.nodes {
+		r := <-results
+		c.lock.Lock()
+		n := r.node
+		n.responsive = r.err == nil && r.status != nil
+		if n.responsive {
+			n.syncing = r.status.Syncing
+			if n.latency == 0 {
+				n.latency = r.latency
+			} else {
+				n.latency = time.Duration(beaconNodeLatencyWeight*float64(r.latency) + (1-beaconNodeLatencyWeight)*float64(n.latency))
+			}
+			beaconNodeLatencySeconds.WithLabelValues(n.endpoint).Set(n.latency.Seconds())
+		} else {
+			log.WithError(r.err).WithField("endpoint", n.endpoint).Debug("Beacon node health check failed")
+		}
+		if n.healthy() {
+			beaconNodeHealthyGauge.WithLabelValues(n.endpoint).Set(1)
+		} else {
+			beaconNodeHealthyGauge.WithLabelValues(n.endpoint).Set(0)
+		}
+		c.selectBest()
+		c.lock.Unlock()
+	}
+}
+
+// selectBest selects the healthiest beacon node. The caller must hold the lock.
+func (c *multipleBeaconNodesClient) selectBest() {
+	ranked := make([]*beaconNode, len(c.nodes))
+	copy(ranked, c.nodes)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].healthy() != ranked[j].healthy() {
+			return ranked[i].healthy()
+		}
+		if ranked[i].responsive != ranked[j].responsive {
+			return ranked[i].responsive
+		}
+		return ranked[i].responsive && ranked[i].latency < ranked[j].latency
+	})
+	if ranked[0] != c.best {
+		log.WithFields(logrus.Fields{
+			"previous": c.best.endpoint,
+			"endpoint": ranked[0].endpoint,
+			"healthy":  ranked[0].healthy(),
+		}).Info("Switching to another beacon node")
+		c.best = ranked[0]
+	}
+}
+
+// bestNode returns the healthiest beacon node.
+func (c *multipleBeaconNodesClient) bestNode() *beaconNode {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.best
+}
+
+// bestClient returns the validator client of the healthiest beacon node.
+func (c *multipleBeaconNodesClient) bestClient() iface.ValidatorClient {
+	return c.bestNode().validatorClient
+}
+
+// broadcast calls f with the validator client of each beacon node concurrently. It returns the result of the first
+// beacon node the call succeeds on, and lets the calls to the other beacon nodes finish in the background, for at
+// most a slot. An error is only returned if the call failed on all the beacon nodes, which is the error of the
+// healthiest beacon node if it failed there.
+func broadcast[T any](ctx context.Context, c *multipleBeaconNodesClient, method string, f func(context.Context, iface.ValidatorClient) (T, error)) (T, error) {
+	c.lock.RLock()
+	nodes := make([]*beaconNode, len(c.nodes))
+	copy(nodes, c.nodes)
+	best := c.best
+	c.lock.RUnlock()
+
+	type result struct {
+		node  *beaconNode
+		value T
+		err   error
+	}
+	// The calls outlive the caller once one of them succeeds, so they must not be canceled with its context.
+	callCtx, cancel := context.WithTimeout(detachedContext{ctx}, time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second)
+	// The channel is buffered so that the calls finishing after the first success do not block.
+	results := make(chan result, len(nodes))
+	var wg sync.WaitGroup
+	for _, n := range nodes {
+		wg.Add(1)
+		go func(n *beaconNode) {
+			defer wg.Done()
+			v, err := f(callCtx, n.validatorClient)
+			if err != nil {
+				log.WithError(err).WithFields(logrus.Fields{
+					"endpoint": n.endpoint,
+					"method":   method,
+				}).Debug("Could not broadcast to beacon node")
+			}
+			results <- result{node: n, value: v, err: err}
+		}(n)
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+	}()
+
+	var value T
+	var err error
+	for range nodes {
+		var r result
+		select {
+		case r = <-results:
+		case <-ctx.Done():
+			return value, ctx.Err()
+		}
+		if r.err == nil {
+			return r.value, nil
+		}
+		if err == nil || r.node == best {
+			err = r.err
+		}
+	}
+	return value, errors.Wrapf(err, "%s failed on all beacon nodes", method)
+}
+
+// detachedContext carries the values of its parent context, such as gRPC headers, without being canceled with it.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (d detachedContext) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
+
+func (c *multipleBeaconNodesClient) GetDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+	return c.bestClient().GetDuties(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
+	return c.bestClient().DomainData(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) WaitForChainStart(ctx context.Context, in *empty.Empty) (*ethpb.ChainStartResponse, error) {
+	return c.bestClient().WaitForChainStart(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	return c.bestClient().WaitForActivation(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest) (*ethpb.ValidatorIndexResponse, error) {
+	return c.bestClient().ValidatorIndex(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) ValidatorStatus(ctx context.Context, in *ethpb.ValidatorStatusRequest) (*ethpb.ValidatorStatusResponse, error) {
+	return c.bestClient().ValidatorStatus(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest) (*ethpb.MultipleValidatorStatusResponse, error) {
+	return c.bestClient().MultipleValidatorStatus(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) GetBeaconBlock(ctx context.Context, in *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
+	return c.bestClient().GetBeaconBlock(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+	return broadcast(ctx, c, "ProposeBeaconBlock", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.ProposeResponse, error) {
+		return vc.ProposeBeaconBlock(ctx, in)
+	})
+}
+
+func (c *multipleBeaconNodesClient) PrepareBeaconProposer(ctx context.Context, in *ethpb.PrepareBeaconProposerRequest) (*empty.Empty, error) {
+	return broadcast(ctx, c, "PrepareBeaconProposer", func(ctx context.Context, vc iface.ValidatorClient) (*empty.Empty, error) {
+		return vc.PrepareBeaconProposer(ctx, in)
+	})
+}
+
+func (c *multipleBeaconNodesClient) GetFeeRecipientByPubKey(ctx context.Context, in *ethpb.FeeRecipientByPubKeyRequest) (*ethpb.FeeRecipientByPubKeyResponse, error) {
+	return c.bestClient().GetFeeRecipientByPubKey(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) GetAttestationData(ctx context.Context, in *ethpb.AttestationDataRequest) (*ethpb.AttestationData, error) {
+	return c.bestClient().GetAttestationData(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) ProposeAttestation(ctx context.Context, in *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+	return broadcast(ctx, c, "ProposeAttestation", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.AttestResponse, error) {
+		return vc.ProposeAttestation(ctx, in)
+	})
+}
+
+func (c *multipleBeaconNodesClient) SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest) (*ethpb.AggregateSelectionResponse, error) {
+	return c.bestClient().SubmitAggregateSelectionProof(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	return broadcast(ctx, c, "SubmitSignedAggregateSelectionProof", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.SignedAggregateSubmitResponse, error) {
+		return vc.SubmitSignedAggregateSelectionProof(ctx, in)
+	})
+}
+
+func (c *multipleBeaconNodesClient) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit) (*ethpb.ProposeExitResponse, error) {
+	return broadcast(ctx, c, "ProposeExit", func(ctx context.Context, vc iface.ValidatorClient) (*ethpb.ProposeExitResponse, error) {
+		return vc.ProposeExit(ctx, in)
+	})
+}
+
+func (c *multipleBeaconNodesClient) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, validatorIndices []primitives.ValidatorIndex) (*empty.Empty, error) {
+	return broadcast(ctx, c, "SubscribeCommitteeSubnets", func(ctx context.Context, vc iface.ValidatorClient) (*empty.Empty, error) {
+		return vc.SubscribeCommitteeSubnets(ctx, in, validatorIndices)
+	})
+}
+
+func (c *multipleBeaconNodesClient) CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest) (*ethpb.DoppelGangerResponse, error) {
+	return c.bestClient().CheckDoppelGanger(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) GetSyncMessageBlockRoot(ctx context.Context, in *empty.Empty) (*ethpb.SyncMessageBlockRootResponse, error) {
+	return c.bestClient().GetSyncMessageBlockRoot(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage) (*empty.Empty, error) {
+	return broadcast(ctx, c, "SubmitSyncMessage", func(ctx context.Context, vc iface.ValidatorClient) (*empty.Empty, error) {
+		return vc.SubmitSyncMessage(ctx, in)
+	})
+}
+
+func (c *multipleBeaconNodesClient) GetSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	return c.bestClient().GetSyncSubcommitteeIndex(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) GetSyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest) (*ethpb.SyncCommitteeContribution, error) {
+	return c.bestClient().GetSyncCommitteeContribution(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) (*empty.Empty, error) {
+	return broadcast(ctx, c, "SubmitSignedContributionAndProof", func(ctx context.Context, vc iface.ValidatorClient) (*empty.Empty, error) {
+		return vc.SubmitSignedContributionAndProof(ctx, in)
+	})
+}
+
+func (c *multipleBeaconNodesClient) StreamBlocksAltair(ctx context.Context, in *ethpb.StreamBlocksRequest) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+	return c.bestClient().StreamBlocksAltair(ctx, in)
+}
+
+func (c *multipleBeaconNodesClient) SubmitValidatorRegistrations(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1) (*empty.Empty, error) {
+	return broadcast(ctx, c, "SubmitValidatorRegistrations", func(ctx context.Context, vc iface.ValidatorClient) (*empty.Empty, error) {
+		return vc.SubmitValidatorRegistrations(ctx, in)
+	})
+}
+
+func (c *multipleBeaconNodesClient) GetAggregatedSelections(ctx context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	return c.bestClient().GetAggregatedSelections(ctx, selections)
+}
+
+func (c *multipleBeaconNodesClient) GetAggregatedSyncSelections(ctx context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	return c.bestClient().GetAggregatedSyncSelections(ctx, selections)
+}
+
+func (c *multipleBeaconNodesClient) GetValidatorsLiveness(ctx context.Context, epoch primitives.Epoch, indices []primitives.ValidatorIndex) ([]*iface.ValidatorLiveness, error) {
+	return c.bestClient().GetValidatorsLiveness(ctx, epoch, indices)
+}
+
+// multipleBeaconNodesNodeClient routes the node requests to the healthiest beacon node.
+type multipleBeaconNodesNodeClient struct {
+	c *multipleBeaconNodesClient
+}
+
+func (nc *multipleBeaconNodesNodeClient) GetSyncStatus(ctx context.Context, in *empty.Empty) (*ethpb.SyncStatus, error) {
+	return nc.c.bestNode().nodeClient.GetSyncStatus(ctx, in)
+}
+
+func (nc *multipleBeaconNodesNodeClient) GetGenesis(ctx context.Context, in *empty.Empty) (*ethpb.Genesis, error) {
+	return nc.c.bestNode().nodeClient.GetGenesis(ctx, in)
+}
+
+func (nc *multipleBeaconNodesNodeClient) GetVersion(ctx context.Context, in *empty.Empty) (*ethpb.Version, error) {
+	return nc.c.bestNode().nodeClient.GetVersion(ctx, in)
+}
+
+func (nc *multipleBeaconNodesNodeClient) ListPeers(ctx context.Context, in *empty.Empty) (*ethpb.Peers, error) {
+	return nc.c.bestNode().nodeClient.ListPeers(ctx, in)
+}
+
+// multipleBeaconNodesBeaconChainClient routes the beacon chain requests to the healthiest beacon node.
+type multipleBeaconNodesBeaconChainClient struct {
+	c *multipleBeaconNodesClient
+}
+
+func (bc *multipleBeaconNodesBeaconChainClient) GetChainHead(ctx context.Context, in *empty.Empty) (*ethpb.ChainHead, error) {
+	return bc.c.bestNode().beaconClient.GetChainHead(ctx, in)
+}
+
+func (bc *multipleBeaconNodesBeaconChainClient) ListValidatorBalances(ctx context.Context, in *ethpb.ListValidatorBalancesRequest) (*ethpb.ValidatorBalances, error) {
+	return bc.c.bestNode().beaconClient.ListValidatorBalances(ctx, in)
+}
+
+func (bc *multipleBeaconNodesBeaconChainClient) ListValidators(ctx context.Context, in *ethpb.ListValidatorsRequest) (*ethpb.Validators, error) {
+	return bc.c.bestNode().beaconClient.ListValidators(ctx, in)
+}
+
+func (bc *multipleBeaconNodesBeaconChainClient) GetValidatorQueue(ctx context.Context, in *empty.Empty) (*ethpb.ValidatorQueue, error) {
+	return bc.c.bestNode().beaconClient.GetValidatorQueue(ctx, in)
+}
+
+func (bc *multipleBeaconNodesBeaconChainClient) GetValidatorPerformance(ctx context.Context, in *ethpb.ValidatorPerformanceRequest) (*ethpb.ValidatorPerformanceResponse, error) {
+	return bc.c.bestNode().beaconClient.GetValidatorPerformance(ctx, in)
+}
+
+func (bc *multipleBeaconNodesBeaconChainClient) GetValidatorParticipation(ctx context.Context, in *ethpb.GetValidatorParticipationRequest) (*ethpb.ValidatorParticipationResponse, error) {
+	return bc.c.bestNode().beaconClient.GetValidatorParticipation(ctx, in)
+}
+
+// multipleBeaconNodesSlasherClient routes the slashing protection requests to the healthiest beacon node.
+type multipleBeaconNodesSlasherClient struct {
+	c *multipleBeaconNodesClient
+}
+
+func (sc *multipleBeaconNodesSlasherClient) IsSlashableAttestation(ctx context.Context, in *ethpb.IndexedAttestation) (*ethpb.AttesterSlashingResponse, error) {
+	return sc.c.bestNode().slasherClient.IsSlashableAttestation(ctx, in)
+}
+
+func (sc *multipleBeaconNodesSlasherClient) IsSlashableBlock(ctx context.Context, in *ethpb.SignedBeaconBlockHeader) (*ethpb.ProposerSlashingResponse, error) {
+	return sc.c.bestNode().slasherClient.IsSlashableBlock(ctx, in)
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/empty"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/v4/testing/validator-mock"
+)
+
+func newTestBeaconNodes(ctrl *gomock.Controller, n int) ([]*beaconNode, []*validatormock.MockValidatorClient, []*validatormock.MockNodeClient) {
+	nodes := make([]*beaconNode, n)
+	validatorClients := make([]*validatormock.MockValidatorClient, n)
+	nodeClients := make([]*validatormock.MockNodeClient, n)
+	for i := range nodes {
+		validatorClients[i] = validatormock.NewMockValidatorClient(ctrl)
+		nodeClients[i] = validatormock.NewMockNodeClient(ctrl)
+		nodes[i] = &beaconNode{
+			endpoint:        string(rune('a' + i)),
+			validatorClient: validatorClients[i],
+			nodeClient:      nodeClients[i],
+		}
+	}
+	return nodes, validatorClients, nodeClients
+}
+
+func TestMultipleBeaconNodesClient_CheckHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	nodes, validatorClients, nodeClients := newTestBeaconNodes(ctrl, 3)
+	c := newMultipleBeaconNodesClient(nodes)
+
+	// The first beacon node is syncing, the second one is healthy and the third one is unresponsive.
+	nodeClients[0].EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{Syncing: true}, nil)
+	nodeClients[1].EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{Syncing: false}, nil)
+	nodeClients[2].EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	c.checkHealth(ctx)
+	assert.Equal(t, nodes[1], c.best)
+
+	validatorClients[1].EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(&ethpb.DutiesResponse{}, nil)
+	_, err := c.GetDuties(ctx, &ethpb.DutiesRequest{})
+	require.NoError(t, err)
+	nodeClients[1].EXPECT().GetGenesis(gomock.Any(), gomock.Any()).Return(&ethpb.Genesis{}, nil)
+	_, err = (&multipleBeaconNodesNodeClient{c: c}).GetGenesis(ctx, &empty.Empty{})
+	require.NoError(t, err)
+
+	// A syncing beacon node is preferred over an unresponsive one.
+	nodeClients[0].EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{Syncing: true}, nil)
+	nodeClients[1].EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	nodeClients[2].EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	c.checkHealth(ctx)
+	assert.Equal(t, nodes[0], c.best)
+}
+
+func TestMultipleBeaconNodesClient_CheckHealth_Latency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	nodes, _, nodeClients := newTestBeaconNodes(ctrl, 2)
+	c := newMultipleBeaconNodesClient(nodes)
+
+	nodeClients[0].EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, *empty.Empty) (*ethpb.SyncStatus, error) {
+			time.Sleep(50 * time.Millisecond)
+			return &ethpb.SyncStatus{}, nil
+		})
+	nodeClients[1].EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{}, nil)
+	c.checkHealth(context.Background())
+	assert.Equal(t, nodes[1], c.best)
+}
+
+func TestMultipleBeaconNodesClient_Broadcast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	nodes, validatorClients, _ := newTestBeaconNodes(ctrl, 3)
+	c := newMultipleBeaconNodesClient(nodes)
+	c.best = nodes[1]
+	att := &ethpb.Attestation{}
+
+	// The first successful submission is returned without waiting for the slower beacon nodes, whose calls are not
+	// canceled with the context of the caller.
+	release, done := make(chan struct{}), make(chan error)
+	validatorClients[0].EXPECT().ProposeAttestation(gomock.Any(), att).DoAndReturn(
+		func(ctx context.Context, _ *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+			<-release
+			done <- ctx.Err()
+			return &ethpb.AttestResponse{AttestationDataRoot: []byte{0}}, nil
+		})
+	validatorClients[1].EXPECT().ProposeAttestation(gomock.Any(), att).Return(&ethpb.AttestResponse{AttestationDataRoot: []byte{1}}, nil)
+	validatorClients[2].EXPECT().ProposeAttestation(gomock.Any(), att).Return(nil, errors.New("bad"))
+	callCtx, cancel := context.WithCancel(ctx)
+	resp, err := c.ProposeAttestation(callCtx, att)
+	cancel()
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{1}, resp.AttestationDataRoot)
+	close(release)
+	require.NoError(t, <-done)
+
+	validatorClients[0].EXPECT().ProposeAttestation(gomock.Any(), att).Return(&ethpb.AttestResponse{AttestationDataRoot: []byte{0}}, nil)
+	validatorClients[1].EXPECT().ProposeAttestation(gomock.Any(), att).Return(nil, errors.New("bad"))
+	validatorClients[2].EXPECT().ProposeAttestation(gomock.Any(), att).Return(nil, errors.New("bad"))
+	resp, err = c.ProposeAttestation(ctx, att)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{0}, resp.AttestationDataRoot)
+
+	for _, vc := range validatorClients {
+		vc.EXPECT().ProposeAttestation(gomock.Any(), att).Return(nil, errors.New("bad"))
+	}
+	_, err = c.ProposeAttestation(ctx, att)
+	require.ErrorContains(t, "ProposeAttestation failed on all beacon nodes: bad", err)
+}
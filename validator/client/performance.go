@@ -0,0 +1,262 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	"github.com/sirupsen/logrus"
+)
+
+// performanceHistoryEpochs is the number of epochs the performance of the validating keys is kept for.
+const performanceHistoryEpochs = 64
+
+// epochPerformance returns the performance of the key during the epoch, creating it if needed, and drops the
+// performance older than performanceHistoryEpochs. The performance lock must be held.
+func (v *validator) epochPerformance(pubKey [fieldparams.BLSPubkeyLength]byte, epoch primitives.Epoch) *iface.EpochPerformance {
+	if v.performance == nil {
+		v.performance = make(map[primitives.Epoch]map[[fieldparams.BLSPubkeyLength]byte]*iface.EpochPerformance)
+	}
+	byKey, ok := v.performance[epoch]
+	if !ok {
+		byKey = make(map[[fieldparams.BLSPubkeyLength]byte]*iface.EpochPerformance)
+		v.performance[epoch] = byKey
+		for e := range v.performance {
+			if e+performanceHistoryEpochs <= epoch {
+				delete(v.performance, e)
+			}
+		}
+	}
+	p, ok := byKey[pubKey]
+	if !ok {
+		p = &iface.EpochPerformance{PublicKey: pubKey, Epoch: epoch}
+		byKey[pubKey] = p
+	}
+	return p
+}
+
+// recordProposal records the outcome of the block proposal of the key at the slot. The block root is empty when the
+// block could not be proposed.
+func (v *validator) recordProposal(pubKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot, blockRoot []byte) {
+	v.performanceLock.Lock()
+	defer v.performanceLock.Unlock()
+	p := v.epochPerformance(pubKey, slots.ToEpoch(slot))
+	p.Proposals = append(p.Proposals, &iface.ProposalPerformance{
+		Slot:      slot,
+		Proposed:  len(blockRoot) > 0,
+		BlockRoot: bytesutil.SafeCopyBytes(blockRoot),
+	})
+	if len(blockRoot) == 0 && v.alerter != nil {
+		v.alerter.ProposalMissed(pubKey, slots.ToEpoch(slot), slot)
+	}
+}
+
+// recordSyncCommitteeMessage records whether the sync committee message of the key at the slot was submitted.
+func (v *validator) recordSyncCommitteeMessage(pubKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot, submitted bool) {
+	v.performanceLock.Lock()
+	defer v.performanceLock.Unlock()
+	p := v.epochPerformance(pubKey, slots.ToEpoch(slot))
+	if submitted {
+		p.SyncCommitteeMessages++
+	} else {
+		p.SyncCommitteeMessagesFailed++
+	}
+}
+
+// pendingInclusion is an attestation of a key which has not been seen in a block yet.
+type pendingInclusion struct {
+	pubKey [fieldparams.BLSPubkeyLength]byte
+	slot   primitives.Slot
+	// bit is the index of the key in the committee, which is set in the aggregation bits of the including
+	// attestation.
+	bit uint64
+}
+
+// recordAttestationSubmitted records the attestation of the key, so that its inclusion distance is recorded once it is
+// seen in a block.
+func (v *validator) recordAttestationSubmitted(pubKey [fieldparams.BLSPubkeyLength]byte, data *ethpb.AttestationData, indexInCommittee uint64) {
+	root, err := data.HashTreeRoot()
+	if err != nil {
+		log.WithError(err).Debug("Could not compute attestation data root to track its inclusion")
+		return
+	}
+	v.performanceLock.Lock()
+	defer v.performanceLock.Unlock()
+	if v.pendingInclusions == nil {
+		v.pendingInclusions = make(map[[32]byte][]*pendingInclusion)
+	}
+	v.pendingInclusions[root] = append(v.pendingInclusions[root], &pendingInclusion{pubKey: pubKey, slot: data.Slot, bit: indexInCommittee})
+}
+
+// recordIncludedAttestations records the inclusion distance of the attestations of the keys included in the block,
+// and stops tracking the attestations which are too old to be included.
+func (v *validator) recordIncludedAttestations(blk interfaces.ReadOnlySignedBeaconBlock) {
+	v.performanceLock.Lock()
+	defer v.performanceLock.Unlock()
+	if len(v.pendingInclusions) == 0 {
+		return
+	}
+	slot := blk.Block().Slot()
+	for _, att := range blk.Block().Body().Attestations() {
+		if att == nil || att.Data == nil {
+			continue
+		}
+		root, err := att.Data.HashTreeRoot()
+		if err != nil {
+			continue
+		}
+		pending, ok := v.pendingInclusions[root]
+		if !ok {
+			continue
+		}
+		remaining := pending[:0]
+		for _, p := range pending {
+			if p.bit >= att.AggregationBits.Len() || !att.AggregationBits.BitAt(p.bit) {
+				remaining = append(remaining, p)
+				continue
+			}
+			ep := v.epochPerformance(p.pubKey, slots.ToEpoch(p.slot))
+			if ep.Attestation == nil {
+				ep.Attestation = &iface.AttestationPerformance{}
+			}
+			ep.Attestation.InclusionDistance = slot - p.slot
+		}
+		if len(remaining) == 0 {
+			delete(v.pendingInclusions, root)
+		} else {
+			v.pendingInclusions[root] = remaining
+		}
+	}
+	// Attestations can be included up to the end of the epoch after their own.
+	for root, pending := range v.pendingInclusions {
+		if len(pending) == 0 || slots.ToEpoch(pending[0].slot)+1 < slots.ToEpoch(slot) {
+			delete(v.pendingInclusions, root)
+		}
+	}
+}
+
+// recordAttestationPerformance records the attestation performance reported by the beacon node for the epoch, then
+// writes the performance of each key during the epoch to the performance log, since the epoch is over.
+func (v *validator) recordAttestationPerformance(resp *ethpb.ValidatorPerformanceResponse, epoch primitives.Epoch) {
+	v.performanceLock.Lock()
+	defer v.performanceLock.Unlock()
+	for i, pubKey := range resp.PublicKeys {
+		p := v.epochPerformance(bytesutil.ToBytes48(pubKey), epoch)
+		att := &iface.AttestationPerformance{}
+		if epoch < params.BeaconConfig().AltairForkEpoch {
+			if i < len(resp.InclusionDistances) {
+				att.InclusionDistance = resp.InclusionDistances[i]
+			}
+		} else if p.Attestation != nil {
+			// The beacon node does not track inclusion after Altair, so the distance seen in blocks is kept.
+			att.InclusionDistance = p.Attestation.InclusionDistance
+		}
+		if i < len(resp.CorrectlyVotedSource) {
+			att.CorrectlyVotedSource = resp.CorrectlyVotedSource[i]
+		}
+		if i < len(resp.CorrectlyVotedTarget) {
+			att.CorrectlyVotedTarget = resp.CorrectlyVotedTarget[i]
+		}
+		if i < len(resp.CorrectlyVotedHead) {
+			att.CorrectlyVotedHead = resp.CorrectlyVotedHead[i]
+		}
+		if i < len(resp.BalancesBeforeEpochTransition) {
+			att.BalanceBefore = resp.BalancesBeforeEpochTransition[i]
+		}
+		if i < len(resp.BalancesAfterEpochTransition) {
+			att.BalanceAfter = resp.BalancesAfterEpochTransition[i]
+		}
+		if i < len(resp.InactivityScores) {
+			att.InactivityScore = resp.InactivityScores[i]
+		}
+		p.Attestation = att
+		if v.alerter != nil {
+			included := att.CorrectlyVotedSource || att.CorrectlyVotedTarget
+			v.alerter.AttestationRecorded(bytesutil.ToBytes48(pubKey), epoch, included, att.BalanceBefore, att.BalanceAfter)
+		}
+	}
+	if v.performanceLog == nil {
+		return
+	}
+	for _, p := range sortedPerformance(v.performance[epoch]) {
+		v.performanceLog.WithFields(performanceLogFields(p)).Info("Epoch performance")
+	}
+}
+
+func performanceLogFields(p *iface.EpochPerformance) logrus.Fields {
+	fields := logrus.Fields{
+		"pubKey":                      fmt.Sprintf("%#x", p.PublicKey),
+		"epoch":                       p.Epoch,
+		"syncCommitteeMessages":       p.SyncCommitteeMessages,
+		"syncCommitteeMessagesFailed": p.SyncCommitteeMessagesFailed,
+	}
+	if att := p.Attestation; att != nil {
+		fields["inclusionDistance"] = att.InclusionDistance
+		fields["correctlyVotedSource"] = att.CorrectlyVotedSource
+		fields["correctlyVotedTarget"] = att.CorrectlyVotedTarget
+		fields["correctlyVotedHead"] = att.CorrectlyVotedHead
+		fields["balanceBefore"] = att.BalanceBefore
+		fields["balanceAfter"] = att.BalanceAfter
+		fields["inactivityScore"] = att.InactivityScore
+	}
+	var proposed, missed []primitives.Slot
+	for _, proposal := range p.Proposals {
+		if proposal.Proposed {
+			proposed = append(proposed, proposal.Slot)
+		} else {
+			missed = append(missed, proposal.Slot)
+		}
+	}
+	if len(proposed) > 0 {
+		fields["proposedSlots"] = proposed
+	}
+	if len(missed) > 0 {
+		fields["missedProposalSlots"] = missed
+	}
+	return fields
+}
+
+// Performance returns the performance of the validating keys during the last epochs, ordered by epoch and key.
+func (v *validator) Performance() []*iface.EpochPerformance {
+	v.performanceLock.RLock()
+	defer v.performanceLock.RUnlock()
+	var performance []*iface.EpochPerformance
+	for _, byKey := range v.performance {
+		for _, p := range byKey {
+			cp := *p
+			cp.Proposals = make([]*iface.ProposalPerformance, len(p.Proposals))
+			copy(cp.Proposals, p.Proposals)
+			if p.Attestation != nil {
+				att := *p.Attestation
+				cp.Attestation = &att
+			}
+			performance = append(performance, &cp)
+		}
+	}
+	sort.Slice(performance, func(i, j int) bool {
+		if performance[i].Epoch != performance[j].Epoch {
+			return performance[i].Epoch < performance[j].Epoch
+		}
+		return bytes.Compare(performance[i].PublicKey[:], performance[j].PublicKey[:]) < 0
+	})
+	return performance
+}
+
+func sortedPerformance(byKey map[[fieldparams.BLSPubkeyLength]byte]*iface.EpochPerformance) []*iface.EpochPerformance {
+	performance := make([]*iface.EpochPerformance, 0, len(byKey))
+	for _, p := range byKey {
+		performance = append(performance, p)
+	}
+	sort.Slice(performance, func(i, j int) bool {
+		return bytes.Compare(performance[i].PublicKey[:], performance[j].PublicKey[:]) < 0
+	})
+	return performance
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	"github.com/sirupsen/logrus"
+)
+
+func TestValidator_Performance(t *testing.T) {
+	buf := new(bytes.Buffer)
+	performanceLog := logrus.New()
+	performanceLog.SetOutput(buf)
+	performanceLog.SetFormatter(&logrus.JSONFormatter{})
+	v := &validator{performanceLog: performanceLog}
+	key1 := [fieldparams.BLSPubkeyLength]byte{1}
+	key2 := [fieldparams.BLSPubkeyLength]byte{2}
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	v.recordProposal(key2, 10*slotsPerEpoch+1, []byte{3})
+	v.recordProposal(key2, 10*slotsPerEpoch+5, nil)
+	v.recordSyncCommitteeMessage(key1, 10*slotsPerEpoch, true)
+	v.recordSyncCommitteeMessage(key1, 10*slotsPerEpoch+1, false)
+	v.recordAttestationPerformance(&ethpb.ValidatorPerformanceResponse{
+		PublicKeys:                    [][]byte{key1[:], key2[:]},
+		CorrectlyVotedSource:          []bool{true, true},
+		CorrectlyVotedTarget:          []bool{true, false},
+		CorrectlyVotedHead:            []bool{false, false},
+		BalancesBeforeEpochTransition: []uint64{32, 33},
+		BalancesAfterEpochTransition:  []uint64{34, 35},
+		InactivityScores:              []uint64{0, 4},
+	}, 10)
+
+	assert.DeepEqual(t, []*iface.EpochPerformance{
+		{
+			PublicKey: key1,
+			Epoch:     10,
+			Attestation: &iface.AttestationPerformance{
+				CorrectlyVotedSource: true,
+				CorrectlyVotedTarget: true,
+				BalanceBefore:        32,
+				BalanceAfter:         34,
+			},
+			Proposals:                   []*iface.ProposalPerformance{},
+			SyncCommitteeMessages:       1,
+			SyncCommitteeMessagesFailed: 1,
+		},
+		{
+			PublicKey: key2,
+			Epoch:     10,
+			Attestation: &iface.AttestationPerformance{
+				CorrectlyVotedSource: true,
+				BalanceBefore:        33,
+				BalanceAfter:         35,
+				InactivityScore:      4,
+			},
+			Proposals: []*iface.ProposalPerformance{
+				{Slot: 10*slotsPerEpoch + 1, Proposed: true, BlockRoot: []byte{3}},
+				{Slot: 10*slotsPerEpoch + 5},
+			},
+		},
+	}, v.Performance())
+
+	// The performance of each key is logged as a JSON object once the epoch is over.
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Equal(t, 2, len(lines))
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(lines[1], &entry))
+	assert.Equal(t, "Epoch performance", entry["msg"])
+	assert.Equal(t, false, entry["correctlyVotedTarget"])
+	assert.DeepEqual(t, []interface{}{float64(10*slotsPerEpoch + 1)}, entry["proposedSlots"])
+	assert.DeepEqual(t, []interface{}{float64(10*slotsPerEpoch + 5)}, entry["missedProposalSlots"])
+
+	// The performance of old epochs is dropped.
+	v.recordSyncCommitteeMessage(key1, primitives.Slot(10+performanceHistoryEpochs)*slotsPerEpoch, true)
+	performance := v.Performance()
+	require.Equal(t, 1, len(performance))
+	assert.Equal(t, primitives.Epoch(10+performanceHistoryEpochs), performance[0].Epoch)
+}
+
+func TestValidator_Performance_InclusionDistanceAfterAltair(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+	v := &validator{}
+	key1 := [fieldparams.BLSPubkeyLength]byte{1}
+	key2 := [fieldparams.BLSPubkeyLength]byte{2}
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	data := util.HydrateAttestationData(&ethpb.AttestationData{Slot: 10*slotsPerEpoch + 3})
+	v.recordAttestationSubmitted(key1, data, 2)
+	v.recordAttestationSubmitted(key2, data, 5)
+	bits := bitfield.NewBitlist(8)
+	bits.SetBitAt(2, true)
+	b := util.NewBeaconBlockAltair()
+	b.Block.Slot = 10*slotsPerEpoch + 5
+	b.Block.Body.Attestations = []*ethpb.Attestation{util.HydrateAttestation(&ethpb.Attestation{Data: data, AggregationBits: bits})}
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	v.recordIncludedAttestations(blk)
+	// Only the attestation of the second key is still waiting to be included.
+	require.Equal(t, 1, len(v.pendingInclusions))
+
+	// The inclusion distance seen in the block is kept when the beacon node reports the epoch performance.
+	v.recordAttestationPerformance(&ethpb.ValidatorPerformanceResponse{
+		PublicKeys:           [][]byte{key1[:], key2[:]},
+		InclusionDistances:   []primitives.Slot{7, 7},
+		CorrectlyVotedSource: []bool{true, false},
+	}, 10)
+	performance := v.Performance()
+	require.Equal(t, 2, len(performance))
+	assert.DeepEqual(t, &iface.AttestationPerformance{InclusionDistance: 2, CorrectlyVotedSource: true}, performance[0].Attestation)
+	assert.DeepEqual(t, &iface.AttestationPerformance{}, performance[1].Attestation)
+
+	// Attestations which can no longer be included are not tracked anymore.
+	b = util.NewBeaconBlockAltair()
+	b.Block.Slot = 12 * slotsPerEpoch
+	blk, err = blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	v.recordIncludedAttestations(blk)
+	assert.Equal(t, 0, len(v.pendingInclusions))
+}
@@ -24,6 +24,7 @@ import (
 	prysmTime "github.com/prysmaticlabs/prysm/v4/time"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/v4/validator/graffiti"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
@@ -49,6 +50,11 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 	lock.Lock()
 	defer lock.Unlock()
 
+	var blockRoot []byte
+	defer func() {
+		v.recordProposal(pubKey, slot, blockRoot)
+	}()
+
 	fmtKey := fmt.Sprintf("%#x", pubKey[:])
 	span.AddAttributes(trace.StringAttribute("validator", fmtKey))
 	log := log.WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])))
@@ -64,7 +70,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 		return
 	}
 
-	g, err := v.getGraffiti(ctx, pubKey)
+	g, err := v.getGraffiti(ctx, pubKey, slot)
 	if err != nil {
 		// Graffiti is not a critical enough to fail block production and cause
 		// validator to miss block reward. When failed, validator should continue
@@ -77,6 +83,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 		Slot:         slot,
 		RandaoReveal: randaoReveal,
 		Graffiti:     g,
+		Relays:       v.relaysForKey(pubKey),
 	})
 	if err != nil {
 		log.WithField("blockSlot", slot).WithError(err).Error("Failed to request block from beacon node")
@@ -181,6 +188,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 		}
 		return
 	}
+	blockRoot = blkResp.BlockRoot
 
 	span.AddAttributes(
 		trace.StringAttribute("blockRoot", fmt.Sprintf("%#x", blkResp.BlockRoot)),
@@ -407,9 +415,20 @@ func signVoluntaryExit(
 	return sig.Marshal(), nil
 }
 
-// Gets the graffiti from cli or file for the validator public key.
-func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, error) {
-	// When specified, default graffiti from the command line takes the first priority.
+// Gets the graffiti from the graffiti provider, cli or file for the validator public key.
+func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot) ([]byte, error) {
+	// When specified, the graffiti supplied by the graffiti provider at proposal time takes the first priority.
+	// The other sources are used when the provider fails to answer in time or has no graffiti for the proposal.
+	if v.graffitiProvider != nil {
+		g, err := v.graffitiProvider.Graffiti(ctx, pubKey, slot)
+		if err != nil {
+			log.WithError(err).Warn("Could not get graffiti from the graffiti provider")
+		} else if len(g) != 0 {
+			return g, nil
+		}
+	}
+
+	// When specified, default graffiti from the command line takes the second priority.
 	if len(v.graffiti) != 0 {
 		return v.graffiti, nil
 	}
@@ -418,7 +437,14 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubk
 		return nil, errors.New("graffitiStruct can't be nil")
 	}
 
-	// When specified, individual validator specified graffiti takes the second priority.
+	// When specified, the graffiti rotation of the validator key in the file takes the third priority.
+	if kg := v.graffitiStruct.ForKey(pubKey); kg != nil {
+		if g, ok := v.keyGraffiti(pubKey, kg); ok {
+			return []byte(g), nil
+		}
+	}
+
+	// When specified, individual validator specified graffiti takes the fourth priority.
 	idx, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]})
 	if err != nil {
 		return []byte{}, err
@@ -428,7 +454,7 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubk
 		return []byte(g), nil
 	}
 
-	// When specified, a graffiti from the ordered list in the file take third priority.
+	// When specified, a graffiti from the ordered list in the file take fifth priority.
 	if v.graffitiOrderedIndex < uint64(len(v.graffitiStruct.Ordered)) {
 		graffiti := v.graffitiStruct.Ordered[v.graffitiOrderedIndex]
 		v.graffitiOrderedIndex = v.graffitiOrderedIndex + 1
@@ -439,7 +465,7 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubk
 		return []byte(graffiti), nil
 	}
 
-	// When specified, a graffiti from the random list in the file take fourth priority.
+	// When specified, a graffiti from the random list in the file take sixth priority.
 	if len(v.graffitiStruct.Random) != 0 {
 		r := rand.NewGenerator()
 		r.Seed(time.Now().Unix())
@@ -454,3 +480,23 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubk
 
 	return []byte{}, nil
 }
+
+// keyGraffiti returns the next graffiti of the rotation of a validator key. The ordered graffiti are used one after
+// the other, starting over once all of them were used, else a random graffiti is picked.
+func (v *validator) keyGraffiti(pubKey [fieldparams.BLSPubkeyLength]byte, kg *graffiti.KeyGraffiti) (string, bool) {
+	if len(kg.Ordered) != 0 {
+		v.graffitiKeysLock.Lock()
+		defer v.graffitiKeysLock.Unlock()
+		if v.graffitiKeyOrderedIndex == nil {
+			v.graffitiKeyOrderedIndex = make(map[[fieldparams.BLSPubkeyLength]byte]int)
+		}
+		i := v.graffitiKeyOrderedIndex[pubKey] % len(kg.Ordered)
+		v.graffitiKeyOrderedIndex[pubKey] = i + 1
+		return kg.Ordered[i], true
+	}
+	if len(kg.Random) != 0 {
+		r := rand.NewGenerator()
+		return kg.Random[r.Intn(len(kg.Random))], true
+	}
+	return "", false
+}
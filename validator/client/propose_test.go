@@ -3,15 +3,21 @@ package client
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/mock/gomock"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	lruwrpr "github.com/prysmaticlabs/prysm/v4/cache/lru"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	blocktest "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks/testing"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
@@ -190,6 +196,37 @@ func TestProposeBlock_RequestBlockFailed(t *testing.T) {
 	}
 }
 
+func TestProposeBlock_RequestsBlockFromConfiguredRelays(t *testing.T) {
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+	var pubKey [fieldparams.BLSPubkeyLength]byte
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	relays := []string{"https://relay.example.com"}
+	validator.proposerSettings = &validatorserviceconfig.ProposerSettings{
+		DefaultConfig: &validatorserviceconfig.ProposerOption{
+			BuilderConfig: &validatorserviceconfig.BuilderConfig{Enabled: true, Relays: relays},
+		},
+	}
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+
+	var req *ethpb.BlockRequest
+	m.validatorClient.EXPECT().GetBeaconBlock(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.BlockRequest{}),
+	).DoAndReturn(func(_ context.Context, r *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
+		req = r
+		return nil, errors.New("uh oh")
+	})
+
+	validator.ProposeBlock(context.Background(), 1, pubKey)
+	require.NotNil(t, req)
+	assert.DeepEqual(t, relays, req.Relays)
+}
+
 func TestProposeBlock_ProposeBlockFailed(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -1017,7 +1054,7 @@ func TestGetGraffiti_Ok(t *testing.T) {
 					ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
 					Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
 			}
-			got, err := tt.v.getGraffiti(context.Background(), pubKey)
+			got, err := tt.v.getGraffiti(context.Background(), pubKey, 0)
 			require.NoError(t, err)
 			require.DeepEqual(t, tt.want, got)
 		})
@@ -1045,8 +1082,62 @@ func TestGetGraffitiOrdered_Ok(t *testing.T) {
 		},
 	}
 	for _, want := range [][]byte{{'a'}, {'b'}, {'c'}, {'d'}, {'d'}} {
-		got, err := v.getGraffiti(context.Background(), pubKey)
+		got, err := v.getGraffiti(context.Background(), pubKey, 0)
 		require.NoError(t, err)
 		require.DeepEqual(t, want, got)
 	}
 }
+
+func TestGetGraffiti_KeyRotation(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
+	otherPubKey := [fieldparams.BLSPubkeyLength]byte{'b'}
+	v := &validator{
+		graffitiStruct: &graffiti.Graffiti{
+			Default: "d",
+			Keys: map[string]*graffiti.KeyGraffiti{
+				hexutil.Encode(pubKey[:]):      {Ordered: []string{"a", "b"}, Random: []string{"r"}},
+				hexutil.Encode(otherPubKey[:]): {Random: []string{"r"}},
+			},
+		},
+	}
+	// The ordered graffiti of a key are rotated.
+	for _, want := range [][]byte{{'a'}, {'b'}, {'a'}} {
+		got, err := v.getGraffiti(context.Background(), pubKey, 0)
+		require.NoError(t, err)
+		require.DeepEqual(t, want, got)
+	}
+	got, err := v.getGraffiti(context.Background(), otherPubKey, 0)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte{'r'}, got)
+}
+
+func TestGetGraffiti_Provider(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
+	providerGraffiti := "provided"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "5", r.URL.Query().Get("slot"))
+		if providerGraffiti == "" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(&graffiti.ProviderResponse{Graffiti: providerGraffiti}))
+	}))
+	defer srv.Close()
+	provider, err := graffiti.NewProvider(srv.URL, time.Second)
+	require.NoError(t, err)
+
+	v := &validator{
+		graffiti:         []byte("cli"),
+		graffitiStruct:   &graffiti.Graffiti{},
+		graffitiProvider: provider,
+	}
+	got, err := v.getGraffiti(context.Background(), pubKey, 5)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte("provided"), got)
+
+	// The other graffiti sources are used when the provider fails.
+	providerGraffiti = ""
+	got, err = v.getGraffiti(context.Background(), pubKey, 5)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte("cli"), got)
+}
@@ -16,11 +16,13 @@ import (
 	"go.opencensus.io/trace"
 )
 
-// SubmitValidatorRegistrations signs validator registration objects and submits it to the beacon node.
+// SubmitValidatorRegistrations signs validator registration objects and submits it to the beacon node,
+// in batches of at most validatorRegsBatchSize registrations if the batch size is positive.
 func SubmitValidatorRegistrations(
 	ctx context.Context,
 	validatorClient iface.ValidatorClient,
 	signedRegs []*ethpb.SignedValidatorRegistrationV1,
+	validatorRegsBatchSize int,
 ) error {
 	ctx, span := trace.StartSpan(ctx, "validator.SubmitValidatorRegistrations")
 	defer span.End()
@@ -29,19 +31,46 @@ func SubmitValidatorRegistrations(
 		return nil
 	}
 
-	if _, err := validatorClient.SubmitValidatorRegistrations(ctx, &ethpb.SignedValidatorRegistrationsV1{
-		Messages: signedRegs,
-	}); err != nil {
-		if strings.Contains(err.Error(), builder.ErrNoBuilder.Error()) {
-			log.Warnln("Beacon node does not utilize a custom builder via the --http-mev-relay flag. Validator registration skipped.")
-			return nil
+	var failed int
+	for _, batch := range chunkSignedValidatorRegistrations(signedRegs, validatorRegsBatchSize) {
+		if _, err := validatorClient.SubmitValidatorRegistrations(ctx, &ethpb.SignedValidatorRegistrationsV1{
+			Messages: batch,
+		}); err != nil {
+			if strings.Contains(err.Error(), builder.ErrNoBuilder.Error()) {
+				log.Warnln("Beacon node does not utilize a custom builder via the --http-mev-relay flag. Validator registration skipped.")
+				return nil
+			}
+			if len(batch) == len(signedRegs) {
+				return errors.Wrap(err, "could not submit signed registrations to beacon node")
+			}
+			log.WithError(err).WithField("registrations", len(batch)).Warn("Could not submit a batch of signed registrations to beacon node")
+			failed += len(batch)
 		}
-		return errors.Wrap(err, "could not submit signed registrations to beacon node")
+	}
+	if failed > 0 {
+		return errors.Errorf("could not submit %d of %d signed registrations to beacon node", failed, len(signedRegs))
 	}
 	log.Infoln("Submitted builder validator registration settings for custom builders")
 	return nil
 }
 
+// chunkSignedValidatorRegistrations splits the registrations into batches of at most size registrations.
+// A non-positive size returns all the registrations in a single batch.
+func chunkSignedValidatorRegistrations(regs []*ethpb.SignedValidatorRegistrationV1, size int) [][]*ethpb.SignedValidatorRegistrationV1 {
+	if size <= 0 || len(regs) <= size {
+		return [][]*ethpb.SignedValidatorRegistrationV1{regs}
+	}
+	batches := make([][]*ethpb.SignedValidatorRegistrationV1, 0, (len(regs)+size-1)/size)
+	for i := 0; i < len(regs); i += size {
+		end := i + size
+		if end > len(regs) {
+			end = len(regs)
+		}
+		batches = append(batches, regs[i:end])
+	}
+	return batches
+}
+
 // Sings validator registration obj with the proposer domain and private key.
 func signValidatorRegistration(ctx context.Context, signer iface.SigningFunc, reg *ethpb.ValidatorRegistrationV1) ([]byte, error) {
 	// Per spec, we want the fork version and genesis validator to be nil.
@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 )
 
@@ -21,7 +22,7 @@ func TestSubmitValidatorRegistrations(t *testing.T) {
 	defer finish()
 
 	ctx := context.Background()
-	require.NoError(t, nil, SubmitValidatorRegistrations(ctx, m.validatorClient, []*ethpb.SignedValidatorRegistrationV1{}))
+	require.NoError(t, nil, SubmitValidatorRegistrations(ctx, m.validatorClient, []*ethpb.SignedValidatorRegistrationV1{}, 0))
 
 	reg := &ethpb.ValidatorRegistrationV1{
 		FeeRecipient: bytesutil.PadTo([]byte("fee"), 20),
@@ -41,7 +42,54 @@ func TestSubmitValidatorRegistrations(t *testing.T) {
 	require.NoError(t, nil, SubmitValidatorRegistrations(ctx, m.validatorClient, []*ethpb.SignedValidatorRegistrationV1{
 		{Message: reg,
 			Signature: params.BeaconConfig().ZeroHash[:]},
-	}))
+	}, 0))
+}
+
+func TestSubmitValidatorRegistrations_Batches(t *testing.T) {
+	_, m, _, finish := setup(t)
+	defer finish()
+
+	ctx := context.Background()
+	regs := make([]*ethpb.SignedValidatorRegistrationV1, 3)
+	for i := range regs {
+		regs[i] = &ethpb.SignedValidatorRegistrationV1{
+			Message: &ethpb.ValidatorRegistrationV1{
+				FeeRecipient: bytesutil.PadTo([]byte("fee"), 20),
+				GasLimit:     uint64(i),
+				Pubkey:       bytesutil.PadTo([]byte{byte(i)}, fieldparams.BLSPubkeyLength),
+			},
+			Signature: params.BeaconConfig().ZeroHash[:],
+		}
+	}
+	m.validatorClient.EXPECT().
+		SubmitValidatorRegistrations(gomock.Any(), &ethpb.SignedValidatorRegistrationsV1{Messages: regs[:2]}).
+		Return(nil, nil)
+	m.validatorClient.EXPECT().
+		SubmitValidatorRegistrations(gomock.Any(), &ethpb.SignedValidatorRegistrationsV1{Messages: regs[2:]}).
+		Return(nil, errors.New("bad batch"))
+	require.ErrorContains(t, "could not submit 1 of 3 signed registrations", SubmitValidatorRegistrations(ctx, m.validatorClient, regs, 2))
+}
+
+func TestChunkSignedValidatorRegistrations(t *testing.T) {
+	regs := make([]*ethpb.SignedValidatorRegistrationV1, 5)
+	tests := []struct {
+		size int
+		want []int
+	}{
+		{size: 0, want: []int{5}},
+		{size: -1, want: []int{5}},
+		{size: 5, want: []int{5}},
+		{size: 10, want: []int{5}},
+		{size: 2, want: []int{2, 2, 1}},
+		{size: 1, want: []int{1, 1, 1, 1, 1}},
+	}
+	for _, tt := range tests {
+		batches := chunkSignedValidatorRegistrations(regs, tt.size)
+		require.Equal(t, len(tt.want), len(batches))
+		for i, b := range batches {
+			assert.Equal(t, tt.want[i], len(b))
+		}
+	}
 }
 
 func TestSubmitValidatorRegistration_CantSign(t *testing.T) {
@@ -67,7 +115,7 @@ func TestSubmitValidatorRegistration_CantSign(t *testing.T) {
 	require.ErrorContains(t, "could not sign", SubmitValidatorRegistrations(ctx, m.validatorClient, []*ethpb.SignedValidatorRegistrationV1{
 		{Message: reg,
 			Signature: params.BeaconConfig().ZeroHash[:]},
-	}))
+	}, 0))
 }
 
 func Test_signValidatorRegistration(t *testing.T) {
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// relayRegistrar submits validator registrations to a relay through the builder API.
+type relayRegistrar interface {
+	RegisterValidator(ctx context.Context, svr []*ethpb.SignedValidatorRegistrationV1) error
+}
+
+// relaysForKey returns the relays the registration of the validator key is submitted to, and the builder payloads of
+// its blocks are requested from, as set in the builder configuration of the key in the proposer settings or else in
+// the default builder configuration.
+func (v *validator) relaysForKey(pubkey [fieldparams.BLSPubkeyLength]byte) []string {
+	settings := v.ProposerSettings()
+	if settings == nil {
+		return nil
+	}
+	if settings.ProposeConfig != nil {
+		option, ok := settings.ProposeConfig[pubkey]
+		if ok && option != nil && option.BuilderConfig != nil && len(option.BuilderConfig.Relays) > 0 {
+			return option.BuilderConfig.Relays
+		}
+	}
+	if settings.DefaultConfig != nil && settings.DefaultConfig.BuilderConfig != nil {
+		return settings.DefaultConfig.BuilderConfig.Relays
+	}
+	return nil
+}
+
+// submitRegistrationsToRelays fans the signed validator registrations out to the relays configured for each
+// validator key, submitting to the relays concurrently. Registrations which could not be submitted to a relay
+// are retried on the next registration round.
+func (v *validator) submitRegistrationsToRelays(ctx context.Context, signedRegs []*ethpb.SignedValidatorRegistrationV1) error {
+	ctx, span := trace.StartSpan(ctx, "validator.submitRegistrationsToRelays")
+	defer span.End()
+
+	regsByRelay := make(map[string][]*ethpb.SignedValidatorRegistrationV1)
+	var relays []string
+	for _, reg := range signedRegs {
+		for _, relay := range v.relaysForKey(bytesutil.ToBytes48(reg.Message.Pubkey)) {
+			if _, ok := regsByRelay[relay]; !ok {
+				relays = append(relays, relay)
+			}
+			regsByRelay[relay] = append(regsByRelay[relay], reg)
+		}
+	}
+	if len(relays) == 0 {
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(relays))
+	for i, relay := range relays {
+		wg.Add(1)
+		go func(i int, relay string) {
+			defer wg.Done()
+			errs[i] = v.submitRegistrationsToRelay(ctx, relay, regsByRelay[relay])
+		}(i, relay)
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, relayLabel(relays[i]))
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("could not submit signed registrations to relays %v", failed)
+	}
+	return nil
+}
+
+// submitRegistrationsToRelay submits the signed validator registrations to the relay in batches, recording
+// the number of registrations the relay accepted and rejected.
+func (v *validator) submitRegistrationsToRelay(ctx context.Context, relay string, signedRegs []*ethpb.SignedValidatorRegistrationV1) error {
+	label := relayLabel(relay)
+	c, err := v.relayClient(relay)
+	if err != nil {
+		relayRegistrationsCount.WithLabelValues(label, "failure").Add(float64(len(signedRegs)))
+		log.WithError(err).WithField("relay", label).Error("Could not create relay client")
+		return err
+	}
+	var failed int
+	for _, batch := range chunkSignedValidatorRegistrations(signedRegs, v.validatorsRegBatchSize) {
+		if err := c.RegisterValidator(ctx, batch); err != nil {
+			relayRegistrationsCount.WithLabelValues(label, "failure").Add(float64(len(batch)))
+			log.WithError(err).WithFields(logrus.Fields{
+				"relay":         label,
+				"registrations": len(batch),
+			}).Warn("Could not submit signed registrations to relay")
+			failed += len(batch)
+			continue
+		}
+		relayRegistrationsCount.WithLabelValues(label, "success").Add(float64(len(batch)))
+	}
+	if failed > 0 {
+		return errors.Errorf("could not submit %d of %d signed registrations to relay %s", failed, len(signedRegs), label)
+	}
+	log.WithFields(logrus.Fields{
+		"relay":         label,
+		"registrations": len(signedRegs),
+	}).Debug("Submitted signed registrations to relay")
+	return nil
+}
+
+// relayClient returns the builder API client of the relay, creating it on first use.
+func (v *validator) relayClient(relay string) (relayRegistrar, error) {
+	v.relayClientsLock.Lock()
+	defer v.relayClientsLock.Unlock()
+	if c, ok := v.relayClients[relay]; ok {
+		return c, nil
+	}
+	c, err := builder.NewClient(relay)
+	if err != nil {
+		return nil, err
+	}
+	if v.relayClients == nil {
+		v.relayClients = make(map[string]relayRegistrar)
+	}
+	v.relayClients[relay] = c
+	return c, nil
+}
+
+// relayLabel identifies the relay by its host in logs and metrics, leaving out the relay public key and any
+// credentials set in the relay URL.
+func relayLabel(relay string) string {
+	u, err := url.Parse(relay)
+	if err != nil || u.Host == "" {
+		return "unknown"
+	}
+	return u.Host
+}
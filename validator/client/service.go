@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"os"
 	"strings"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	grpcutil "github.com/prysmaticlabs/prysm/v4/api/grpc"
 	"github.com/prysmaticlabs/prysm/v4/async/event"
 	lruwrpr "github.com/prysmaticlabs/prysm/v4/cache/lru"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
@@ -21,6 +23,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/v4/validator/alerting"
 	beaconChainClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/beacon-chain-client-factory"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	nodeClientFactory "github.com/prysmaticlabs/prysm/v4/validator/client/node-client-factory"
@@ -32,6 +35,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/v4/validator/keymanager/local"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v4/validator/keymanager/remote-web3signer"
+	"github.com/sirupsen/logrus"
 	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -53,28 +57,42 @@ type GenesisFetcher interface {
 // ValidatorService represents a service to manage the validator client
 // routine.
 type ValidatorService struct {
-	useWeb                bool
-	emitAccountMetrics    bool
-	logValidatorBalances  bool
-	interopKeysConfig     *local.InteropKeymanagerConfig
-	conn                  validatorHelpers.NodeConnection
-	grpcRetryDelay        time.Duration
-	grpcRetries           uint
-	maxCallRecvMsgSize    int
-	cancel                context.CancelFunc
-	walletInitializedFeed *event.Feed
-	wallet                *wallet.Wallet
-	graffitiStruct        *graffiti.Graffiti
-	dataDir               string
-	withCert              string
-	endpoint              string
-	ctx                   context.Context
-	validator             iface.Validator
-	db                    db.Database
-	grpcHeaders           []string
-	graffiti              []byte
-	Web3SignerConfig      *remoteweb3signer.SetupConfig
-	proposerSettings      *validatorserviceconfig.ProposerSettings
+	useWeb                 bool
+	emitAccountMetrics     bool
+	logValidatorBalances   bool
+	interopKeysConfig      *local.InteropKeymanagerConfig
+	conn                   validatorHelpers.NodeConnection
+	grpcRetryDelay         time.Duration
+	grpcRetries            uint
+	maxCallRecvMsgSize     int
+	cancel                 context.CancelFunc
+	walletInitializedFeed  *event.Feed
+	wallet                 *wallet.Wallet
+	graffitiStruct         *graffiti.Graffiti
+	graffitiProvider       *graffiti.Provider
+	dataDir                string
+	withCert               string
+	endpoint               string
+	ctx                    context.Context
+	validator              iface.Validator
+	db                     db.Database
+	grpcHeaders            []string
+	graffiti               []byte
+	Web3SignerConfig       *remoteweb3signer.SetupConfig
+	proposerSettings       *validatorserviceconfig.ProposerSettings
+	validatorsRegBatchSize int
+	distributed            bool
+	attestationOffset      time.Duration
+	aggregationOffset      time.Duration
+	doppelgangerEpochs     uint64
+	performanceLog         *os.File
+	alerter                *alerting.Alerter
+	keystoresWatchDir      string
+	keystoresWatchPassword string
+	// nodeEndpoints and nodeConns are the endpoints of the beacon nodes and the connections to each of them,
+	// when the validator client is connected to several beacon nodes.
+	nodeEndpoints []string
+	nodeConns     []validatorHelpers.NodeConnection
 }
 
 // Config for the validator service.
@@ -89,6 +107,7 @@ type Config struct {
 	GrpcMaxCallRecvMsgSizeFlag int
 	GrpcRetryDelay             time.Duration
 	GraffitiStruct             *graffiti.Graffiti
+	GraffitiProvider           *graffiti.Provider
 	Validator                  iface.Validator
 	ValDB                      db.Database
 	CertFlag                   string
@@ -100,6 +119,15 @@ type Config struct {
 	ProposerSettings           *validatorserviceconfig.ProposerSettings
 	BeaconApiEndpoint          string
 	BeaconApiTimeout           time.Duration
+	ValidatorsRegBatchSize     int
+	Distributed                bool
+	AttestationOffset          time.Duration
+	AggregationOffset          time.Duration
+	DoppelgangerEpochs         uint64
+	PerformanceLogFile         string
+	Alerter                    *alerting.Alerter
+	KeystoresWatchDir          string
+	KeystoresWatchPassword     string
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -107,27 +135,45 @@ type Config struct {
 func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	s := &ValidatorService{
-		ctx:                   ctx,
-		cancel:                cancel,
-		endpoint:              cfg.Endpoint,
-		withCert:              cfg.CertFlag,
-		dataDir:               cfg.DataDir,
-		graffiti:              []byte(cfg.GraffitiFlag),
-		logValidatorBalances:  cfg.LogValidatorBalances,
-		emitAccountMetrics:    cfg.EmitAccountMetrics,
-		maxCallRecvMsgSize:    cfg.GrpcMaxCallRecvMsgSizeFlag,
-		grpcRetries:           cfg.GrpcRetriesFlag,
-		grpcRetryDelay:        cfg.GrpcRetryDelay,
-		grpcHeaders:           strings.Split(cfg.GrpcHeadersFlag, ","),
-		validator:             cfg.Validator,
-		db:                    cfg.ValDB,
-		wallet:                cfg.Wallet,
-		walletInitializedFeed: cfg.WalletInitializedFeed,
-		useWeb:                cfg.UseWeb,
-		interopKeysConfig:     cfg.InteropKeysConfig,
-		graffitiStruct:        cfg.GraffitiStruct,
-		Web3SignerConfig:      cfg.Web3SignerConfig,
-		proposerSettings:      cfg.ProposerSettings,
+		ctx:                    ctx,
+		cancel:                 cancel,
+		endpoint:               cfg.Endpoint,
+		withCert:               cfg.CertFlag,
+		dataDir:                cfg.DataDir,
+		graffiti:               []byte(cfg.GraffitiFlag),
+		logValidatorBalances:   cfg.LogValidatorBalances,
+		emitAccountMetrics:     cfg.EmitAccountMetrics,
+		maxCallRecvMsgSize:     cfg.GrpcMaxCallRecvMsgSizeFlag,
+		grpcRetries:            cfg.GrpcRetriesFlag,
+		grpcRetryDelay:         cfg.GrpcRetryDelay,
+		grpcHeaders:            strings.Split(cfg.GrpcHeadersFlag, ","),
+		validator:              cfg.Validator,
+		db:                     cfg.ValDB,
+		wallet:                 cfg.Wallet,
+		walletInitializedFeed:  cfg.WalletInitializedFeed,
+		useWeb:                 cfg.UseWeb,
+		interopKeysConfig:      cfg.InteropKeysConfig,
+		graffitiStruct:         cfg.GraffitiStruct,
+		graffitiProvider:       cfg.GraffitiProvider,
+		Web3SignerConfig:       cfg.Web3SignerConfig,
+		proposerSettings:       cfg.ProposerSettings,
+		validatorsRegBatchSize: cfg.ValidatorsRegBatchSize,
+		distributed:            cfg.Distributed,
+		attestationOffset:      cfg.AttestationOffset,
+		aggregationOffset:      cfg.AggregationOffset,
+		doppelgangerEpochs:     cfg.DoppelgangerEpochs,
+		alerter:                cfg.Alerter,
+		keystoresWatchDir:      cfg.KeystoresWatchDir,
+		keystoresWatchPassword: cfg.KeystoresWatchPassword,
+	}
+
+	if cfg.PerformanceLogFile != "" {
+		f, err := os.OpenFile(cfg.PerformanceLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions)
+		if err != nil {
+			cancel()
+			return nil, errors.Wrap(err, "could not open performance log file")
+		}
+		s.performanceLog = f
 	}
 
 	dialOpts := ConstructDialOptions(
@@ -142,18 +188,32 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 
 	s.ctx = grpcutil.AppendHeaders(ctx, s.grpcHeaders)
 
-	grpcConn, err := grpc.DialContext(ctx, s.endpoint, dialOpts...)
-	if err != nil {
-		return s, err
+	// Each beacon node gets its own connection, so that the duties can be routed to one of them. Other requests go
+	// through the first beacon node until the healthiest one is selected.
+	endpoints := strings.Split(s.endpoint, ",")
+	if len(endpoints) > 1 && features.Get().EnableBeaconRESTApi {
+		log.WithField("endpoint", endpoints[0]).Warn("Several beacon node endpoints are not supported with the beacon REST API, " +
+			"only the first one is used")
+		endpoints = endpoints[:1]
+	}
+	for _, endpoint := range endpoints {
+		endpoint = strings.TrimSpace(endpoint)
+		grpcConn, err := grpc.DialContext(ctx, endpoint, dialOpts...)
+		if err != nil {
+			return s, errors.Wrapf(err, "could not dial beacon node %s", endpoint)
+		}
+		conn := validatorHelpers.NewNodeConnection(grpcConn, cfg.BeaconApiEndpoint, cfg.BeaconApiTimeout)
+		if s.conn == nil {
+			s.conn = conn
+		}
+		if len(endpoints) > 1 {
+			s.nodeEndpoints = append(s.nodeEndpoints, endpoint)
+			s.nodeConns = append(s.nodeConns, conn)
+		}
 	}
 	if s.withCert != "" {
 		log.Info("Established secure gRPC connection")
 	}
-	s.conn = validatorHelpers.NewNodeConnection(
-		grpcConn,
-		cfg.BeaconApiEndpoint,
-		cfg.BeaconApiTimeout,
-	)
 
 	return s, nil
 }
@@ -190,13 +250,51 @@ func (v *ValidatorService) Start() {
 
 	validatorClient := validatorClientFactory.NewValidatorClient(v.conn)
 	beaconClient := beaconChainClientFactory.NewBeaconChainClient(v.conn)
+	slasherClient := slasherClientFactory.NewSlasherClient(v.conn)
+	nodeClient := nodeClientFactory.NewNodeClient(v.conn)
+	if len(v.nodeConns) > 1 {
+		nodes := make([]*beaconNode, len(v.nodeConns))
+		for i, conn := range v.nodeConns {
+			nodes[i] = &beaconNode{
+				endpoint:        v.nodeEndpoints[i],
+				validatorClient: validatorClientFactory.NewValidatorClient(conn),
+				nodeClient:      nodeClientFactory.NewNodeClient(conn),
+				beaconClient:    beaconChainClientFactory.NewBeaconChainClient(conn),
+				slasherClient:   slasherClientFactory.NewSlasherClient(conn),
+			}
+		}
+		c := newMultipleBeaconNodesClient(nodes)
+		go c.monitor(v.ctx)
+		validatorClient = c
+		beaconClient = &multipleBeaconNodesBeaconChainClient{c: c}
+		slasherClient = &multipleBeaconNodesSlasherClient{c: c}
+		nodeClient = &multipleBeaconNodesNodeClient{c: c}
+		log.WithField("endpoints", v.nodeEndpoints).Info("Routing duties to the healthiest of several beacon nodes")
+	}
+
+	var performanceLog *logrus.Logger
+	if v.performanceLog != nil {
+		performanceLog = logrus.New()
+		performanceLog.SetOutput(v.performanceLog)
+		performanceLog.SetFormatter(&logrus.JSONFormatter{})
+	}
+
+	if v.alerter != nil {
+		v.alerter.Start(v.ctx)
+	}
+
+	var watcher *keystoreWatcher
+	if v.keystoresWatchDir != "" {
+		watcher = newKeystoreWatcher(v.keystoresWatchDir, v.keystoresWatchPassword, v.db)
+		log.WithField("dir", v.keystoresWatchDir).Info("Watching keystores directory for added and removed keys")
+	}
 
 	valStruct := &validator{
 		db:                             v.db,
 		validatorClient:                validatorClient,
 		beaconClient:                   beaconClient,
-		slashingProtectionClient:       slasherClientFactory.NewSlasherClient(v.conn),
-		node:                           nodeClientFactory.NewNodeClient(v.conn),
+		slashingProtectionClient:       slasherClient,
+		node:                           nodeClient,
 		graffiti:                       v.graffiti,
 		logValidatorBalances:           v.logValidatorBalances,
 		emitAccountMetrics:             v.emitAccountMetrics,
@@ -216,10 +314,19 @@ func (v *ValidatorService) Start() {
 		blockFeed:                      new(event.Feed),
 		graffitiStruct:                 v.graffitiStruct,
 		graffitiOrderedIndex:           graffitiOrderedIndex,
+		graffitiProvider:               v.graffitiProvider,
 		eipImportBlacklistedPublicKeys: slashablePublicKeys,
 		Web3SignerConfig:               v.Web3SignerConfig,
 		proposerSettings:               v.proposerSettings,
 		walletInitializedChannel:       make(chan *wallet.Wallet, 1),
+		validatorsRegBatchSize:         v.validatorsRegBatchSize,
+		distributed:                    v.distributed,
+		attestationOffset:              v.attestationOffset,
+		aggregationOffset:              v.aggregationOffset,
+		doppelgangerEpochs:             v.doppelgangerEpochs,
+		performanceLog:                 performanceLog,
+		alerter:                        v.alerter,
+		keystoreWatcher:                watcher,
 	}
 
 	// To resolve a race condition at startup due to the interface
@@ -232,6 +339,11 @@ func (v *ValidatorService) Start() {
 	sub.Unsubscribe()
 	close(tempChan)
 
+	if watcher != nil {
+		watcher.checkKeys = valStruct.checkAddedKeys
+		go watcher.run(v.ctx)
+	}
+
 	v.validator = valStruct
 	go run(v.ctx, v.validator)
 }
@@ -240,6 +352,20 @@ func (v *ValidatorService) Start() {
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
+	for _, conn := range v.nodeConns {
+		// The connection of the first beacon node is closed last.
+		if conn == v.conn {
+			continue
+		}
+		if err := conn.GetGrpcClientConn().Close(); err != nil {
+			log.WithError(err).Error("Could not close beacon node connection")
+		}
+	}
+	if v.performanceLog != nil {
+		if err := v.performanceLog.Close(); err != nil {
+			log.WithError(err).Error("Could not close performance log file")
+		}
+	}
 	if v.conn != nil {
 		return v.conn.GetGrpcClientConn().Close()
 	}
@@ -264,6 +390,22 @@ func (v *ValidatorService) Keymanager() (keymanager.IKeymanager, error) {
 	return v.validator.Keymanager()
 }
 
+// DoppelgangerStatuses returns the status of the doppelganger check of each validating key.
+func (v *ValidatorService) DoppelgangerStatuses(ctx context.Context) (map[[fieldparams.BLSPubkeyLength]byte]iface.DoppelgangerStatus, error) {
+	if v.validator == nil {
+		return nil, errors.New("validator not started")
+	}
+	return v.validator.DoppelgangerStatuses(ctx)
+}
+
+// Performance returns the performance of the validating keys during the last epochs.
+func (v *ValidatorService) Performance() ([]*iface.EpochPerformance, error) {
+	if v.validator == nil {
+		return nil, errors.New("validator not started")
+	}
+	return v.validator.Performance(), nil
+}
+
 // ProposerSettings returns a deep copy of the underlying proposer settings in the validator
 func (v *ValidatorService) ProposerSettings() *validatorserviceconfig.ProposerSettings {
 	settings := v.validator.ProposerSettings()
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -39,6 +40,17 @@ func TestNew_Insecure(t *testing.T) {
 	require.LogsContain(t, hook, "You are using an insecure gRPC connection")
 }
 
+func TestNew_PerformanceLogFile(t *testing.T) {
+	dir := t.TempDir()
+	_, err := NewValidatorService(context.Background(), &Config{PerformanceLogFile: filepath.Join(dir, "missing", "performance.log")})
+	require.ErrorContains(t, "could not open performance log file", err)
+
+	s, err := NewValidatorService(context.Background(), &Config{PerformanceLogFile: filepath.Join(dir, "performance.log")})
+	require.NoError(t, err)
+	require.NotNil(t, s.performanceLog)
+	require.NoError(t, s.Stop())
+}
+
 func TestStatus_NoConnectionError(t *testing.T) {
 	validatorService := &ValidatorService{}
 	assert.ErrorContains(t, "no connection", validatorService.Status())
@@ -52,6 +52,8 @@ type FakeValidator struct {
 	IndexToPubkeyMap                  map[uint64][fieldparams.BLSPubkeyLength]byte
 	PubkeyToIndexMap                  map[[fieldparams.BLSPubkeyLength]byte]uint64
 	PubkeysToStatusesMap              map[[fieldparams.BLSPubkeyLength]byte]ethpb.ValidatorStatus
+	DoppelgangerStatusesMap           map[[fieldparams.BLSPubkeyLength]byte]iface.DoppelgangerStatus
+	EpochPerformances                 []*iface.EpochPerformance
 	proposerSettings                  *validatorserviceconfig.ProposerSettings
 	ProposerSettingWait               time.Duration
 	Km                                keymanager.IKeymanager
@@ -217,6 +219,16 @@ func (_ *FakeValidator) CheckDoppelGanger(_ context.Context) error {
 	return nil
 }
 
+// DoppelgangerStatuses for mocking
+func (fv *FakeValidator) DoppelgangerStatuses(_ context.Context) (map[[fieldparams.BLSPubkeyLength]byte]iface.DoppelgangerStatus, error) {
+	return fv.DoppelgangerStatusesMap, nil
+}
+
+// Performance for mocking
+func (fv *FakeValidator) Performance() []*iface.EpochPerformance {
+	return fv.EpochPerformances
+}
+
 // ReceiveBlocks for mocking
 func (fv *FakeValidator) ReceiveBlocks(_ context.Context, connectionErrorChannel chan<- error) {
 	fv.ReceiveBlocksCalled++
@@ -35,6 +35,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	accountsiface "github.com/prysmaticlabs/prysm/v4/validator/accounts/iface"
 	"github.com/prysmaticlabs/prysm/v4/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/v4/validator/alerting"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
 	vdb "github.com/prysmaticlabs/prysm/v4/validator/db"
 	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
@@ -105,6 +106,29 @@ type validator struct {
 	Web3SignerConfig                   *remoteweb3signer.SetupConfig
 	proposerSettings                   *validatorserviceconfig.ProposerSettings
 	walletInitializedChannel           chan *wallet.Wallet
+	validatorsRegBatchSize             int
+	relayClientsLock                   sync.Mutex
+	relayClients                       map[string]relayRegistrar
+	distributed                        bool
+	attestationOffset                  time.Duration
+	aggregationOffset                  time.Duration
+	attSelectionsLock                  sync.Mutex
+	attSelections                      map[attSelectionKey][]byte
+	syncSelectionsLock                 sync.Mutex
+	syncSelections                     map[syncSelectionKey][]byte
+	doppelgangerEpochs                 uint64
+	doppelgangerLock                   sync.RWMutex
+	doppelgangerRecords                map[[fieldparams.BLSPubkeyLength]byte]*kv.DoppelgangerRecord
+	doppelgangerDetected               map[[fieldparams.BLSPubkeyLength]byte]bool
+	performanceLock                    sync.RWMutex
+	performance                        map[primitives.Epoch]map[[fieldparams.BLSPubkeyLength]byte]*iface.EpochPerformance
+	pendingInclusions                  map[[32]byte][]*pendingInclusion
+	performanceLog                     *logrus.Logger
+	alerter                            *alerting.Alerter
+	keystoreWatcher                    *keystoreWatcher
+	graffitiProvider                   *graffiti.Provider
+	graffitiKeysLock                   sync.Mutex
+	graffitiKeyOrderedIndex            map[[fieldparams.BLSPubkeyLength]byte]int
 }
 
 type validatorStatus struct {
@@ -366,6 +390,7 @@ func (v *validator) ReceiveBlocks(ctx context.Context, connectionErrorChannel ch
 			v.highestValidSlot = blk.Block().Slot()
 		}
 		v.highestValidSlotLock.Unlock()
+		v.recordIncludedAttestations(blk)
 		v.blockFeed.Send(blk)
 	}
 }
@@ -463,26 +488,50 @@ func (v *validator) SlotDeadline(slot primitives.Slot) time.Time {
 }
 
 // CheckDoppelGanger checks if the current actively provided keys have
-// any duplicates active in the network.
+// any duplicates active in the network. The check is skipped in distributed
+// mode, as the other nodes of the cluster sign with the same validator keys.
 func (v *validator) CheckDoppelGanger(ctx context.Context) error {
 	if !features.Get().EnableDoppelGanger {
 		return nil
 	}
+	if v.distributed {
+		return nil
+	}
 	pubkeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
 	if err != nil {
 		return err
 	}
 	log.WithField("keys", len(pubkeys)).Info("Running doppelganger check")
+	if v.doppelgangerEpochs > 0 {
+		err := v.startDoppelgangerCheck(ctx, pubkeys)
+		if !errors.Is(err, iface.ErrNotSupported) {
+			return err
+		}
+		log.WithError(err).Warn("Beacon node does not serve the liveness of validators, checking for doppelgangers only once")
+	}
 	// Exit early if no validating pub keys are found.
 	if len(pubkeys) == 0 {
 		return nil
 	}
+	responses, err := v.checkDoppelgangerOnce(ctx, pubkeys)
+	if err != nil {
+		return err
+	}
+	return buildDuplicateError(responses)
+}
+
+// checkDoppelgangerOnce asks the beacon node whether the keys have duplicates active in the network, given the
+// latest attestation of each key.
+func (v *validator) checkDoppelgangerOnce(
+	ctx context.Context,
+	pubkeys [][fieldparams.BLSPubkeyLength]byte,
+) ([]*ethpb.DoppelGangerResponse_ValidatorResponse, error) {
 	req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
 	for _, pkey := range pubkeys {
 		copiedKey := pkey
 		attRec, err := v.db.AttestationHistoryForPubKey(ctx, copiedKey)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if len(attRec) == 0 {
 			// If no history exists we simply send in a zero
@@ -497,7 +546,7 @@ func (v *validator) CheckDoppelGanger(ctx context.Context) error {
 		}
 		r := retrieveLatestRecord(attRec)
 		if copiedKey != r.PubKey {
-			return errors.New("attestation record mismatched public key")
+			return nil, errors.New("attestation record mismatched public key")
 		}
 		req.ValidatorRequests = append(req.ValidatorRequests,
 			&ethpb.DoppelGangerRequest_ValidatorRequest{
@@ -508,14 +557,15 @@ func (v *validator) CheckDoppelGanger(ctx context.Context) error {
 	}
 	resp, err := v.validatorClient.CheckDoppelGanger(ctx, req)
 	if err != nil {
-		return err
+		// Fail closed: without the check, the keys could be slashed for running in two places at once.
+		return nil, errors.Wrap(err, "could not check for doppelgangers")
 	}
 	// If nothing is returned by the beacon node, we return an
 	// error as it is unsafe for us to proceed.
 	if resp == nil || resp.Responses == nil || len(resp.Responses) == 0 {
-		return errors.New("beacon node returned 0 responses for doppelganger check")
+		return nil, errors.New("beacon node returned 0 responses for doppelganger check")
 	}
-	return buildDuplicateError(resp.Responses)
+	return resp.Responses, nil
 }
 
 func buildDuplicateError(response []*ethpb.DoppelGangerResponse_ValidatorResponse) error {
@@ -560,6 +610,13 @@ func retrieveLatestRecord(recs []*kv.AttestationRecord) *kv.AttestationRecord {
 // list of upcoming assignments needs to be updated. For example, at the
 // beginning of a new epoch.
 func (v *validator) UpdateDuties(ctx context.Context, slot primitives.Slot) error {
+	if slots.IsEpochEnd(slot) && v.duties != nil {
+		checkCtx, cancel := context.WithDeadline(ctx, v.SlotDeadline(slot))
+		if err := v.completeDoppelgangerCheck(checkCtx, slots.ToEpoch(slot)); err != nil {
+			log.WithError(err).Error("Could not check validator keys for doppelgangers")
+		}
+		cancel()
+	}
 	if slot%params.BeaconConfig().SlotsPerEpoch != 0 && v.duties != nil {
 		// Do nothing if not epoch start AND assignments already exist.
 		return nil
@@ -574,6 +631,12 @@ func (v *validator) UpdateDuties(ctx context.Context, slot primitives.Slot) erro
 	ctx, span := trace.StartSpan(ctx, "validator.UpdateAssignments")
 	defer span.End()
 
+	// Keys added to or removed from the watched keystores directory are reloaded in the background from the start
+	// of the epoch, and the duties of the added keys are fetched at the start of the next epoch.
+	if v.keystoreWatcher != nil {
+		v.keystoreWatcher.requestReload(v.keyManager)
+	}
+
 	validatingKeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
 	if err != nil {
 		return err
@@ -624,6 +687,10 @@ func (v *validator) UpdateDuties(ctx context.Context, slot primitives.Slot) erro
 	v.logDuties(slot, v.duties.CurrentEpochDuties, v.duties.NextEpochDuties)
 	v.dutiesLock.Unlock()
 
+	if err := v.updateDoppelgangerCheck(ctx, req.Epoch, resp.CurrentEpochDuties); err != nil {
+		log.WithError(err).Error("Could not update the doppelganger check of validator keys")
+	}
+
 	// Non-blocking call for beacon node to start subscriptions for aggregators.
 	// Make sure to copy metadata into a new context
 	md, exists := metadata.FromOutgoingContext(ctx)
@@ -649,6 +716,26 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 	subscribeValidatorIndices := make([]primitives.ValidatorIndex, 0, len(res.CurrentEpochDuties)+len(res.NextEpochDuties))
 	alreadySubscribed := make(map[[64]byte]bool)
 
+	duties := make([]*ethpb.DutiesResponse_Duty, 0, len(res.CurrentEpochDuties)+len(res.NextEpochDuties))
+	for _, epochDuties := range [][]*ethpb.DutiesResponse_Duty{res.CurrentEpochDuties, res.NextEpochDuties} {
+		for _, duty := range epochDuties {
+			if duty.Status == ethpb.ValidatorStatus_ACTIVE || duty.Status == ethpb.ValidatorStatus_EXITING {
+				duties = append(duties, duty)
+			}
+		}
+	}
+	selectionsFailed := false
+	if v.distributed {
+		// Get the aggregated selection proofs of all the duties at once, rather than for each duty separately.
+		if err := v.aggregatedSelectionProofs(ctx, duties); err != nil {
+			log.WithError(err).Warn("Could not get aggregated selection proofs, subscribing to subnets as a non-aggregator")
+			selectionsFailed = true
+		}
+	} else {
+		// Sign the selection proofs of all the duties concurrently, rather than one after the other.
+		v.precomputeSelectionProofs(ctx, duties)
+	}
+
 	for _, duty := range res.CurrentEpochDuties {
 		pk := bytesutil.ToBytes48(duty.PublicKey)
 		if duty.Status == ethpb.ValidatorStatus_ACTIVE || duty.Status == ethpb.ValidatorStatus_EXITING {
@@ -661,7 +748,7 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 				continue
 			}
 
-			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, pk)
+			aggregator, err := v.aggregatorAt(ctx, duty, pk, selectionsFailed)
 			if err != nil {
 				return errors.Wrap(err, "could not check if a validator is an aggregator")
 			}
@@ -687,7 +774,7 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 				continue
 			}
 
-			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, bytesutil.ToBytes48(duty.PublicKey))
+			aggregator, err := v.aggregatorAt(ctx, duty, bytesutil.ToBytes48(duty.PublicKey), selectionsFailed)
 			if err != nil {
 				return errors.Wrap(err, "could not check if a validator is an aggregator")
 			}
@@ -721,12 +808,34 @@ func (v *validator) RolesAt(ctx context.Context, slot primitives.Slot) (map[[fie
 	v.dutiesLock.RLock()
 	defer v.dutiesLock.RUnlock()
 	rolesAt := make(map[[fieldparams.BLSPubkeyLength]byte][]iface.ValidatorRole)
+
+	selectionsFailed := false
+	if v.distributed {
+		var attesterDuties []*ethpb.DutiesResponse_Duty
+		for _, duty := range v.duties.Duties {
+			if duty != nil && duty.AttesterSlot == slot {
+				attesterDuties = append(attesterDuties, duty)
+			}
+		}
+		if err := v.aggregatedSelectionProofs(ctx, attesterDuties); err != nil {
+			log.WithError(err).WithField("slot", slot).Warn("Could not get aggregated selection proofs, not aggregating at this slot")
+			selectionsFailed = true
+		}
+	}
+
 	for validator, duty := range v.duties.Duties {
 		var roles []iface.ValidatorRole
 
 		if duty == nil {
 			continue
 		}
+		if status := v.doppelgangerStatus(bytesutil.ToBytes48(duty.PublicKey)); status != iface.DoppelgangerCleared {
+			log.WithFields(logrus.Fields{
+				"publicKey":          fmt.Sprintf("%#x", bytesutil.Trunc(duty.PublicKey)),
+				"doppelgangerStatus": status,
+			}).Debug("Skipping duties of a key not cleared by the doppelganger check")
+			continue
+		}
 		if len(duty.ProposerSlots) > 0 {
 			for _, proposerSlot := range duty.ProposerSlots {
 				if proposerSlot != 0 && proposerSlot == slot {
@@ -738,7 +847,7 @@ func (v *validator) RolesAt(ctx context.Context, slot primitives.Slot) (map[[fie
 		if duty.AttesterSlot == slot {
 			roles = append(roles, iface.RoleAttester)
 
-			aggregator, err := v.isAggregator(ctx, duty.Committee, slot, bytesutil.ToBytes48(duty.PublicKey))
+			aggregator, err := v.aggregatorAt(ctx, duty, bytesutil.ToBytes48(duty.PublicKey), selectionsFailed)
 			if err != nil {
 				return nil, errors.Wrap(err, "could not check if a validator is an aggregator")
 			}
@@ -793,13 +902,19 @@ func (v *validator) Keymanager() (keymanager.IKeymanager, error) {
 
 // isAggregator checks if a validator is an aggregator of a given slot and committee,
 // it uses a modulo calculated by validator count in committee and samples randomness around it.
-func (v *validator) isAggregator(ctx context.Context, committee []primitives.ValidatorIndex, slot primitives.Slot, pubKey [fieldparams.BLSPubkeyLength]byte) (bool, error) {
+func (v *validator) isAggregator(
+	ctx context.Context,
+	committee []primitives.ValidatorIndex,
+	slot primitives.Slot,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	validatorIndex primitives.ValidatorIndex,
+) (bool, error) {
 	modulo := uint64(1)
 	if len(committee)/int(params.BeaconConfig().TargetAggregatorsPerCommittee) > 1 {
 		modulo = uint64(len(committee)) / params.BeaconConfig().TargetAggregatorsPerCommittee
 	}
 
-	slotSig, err := v.signSlotWithSelectionProof(ctx, pubKey, slot)
+	slotSig, err := v.attSelectionProof(ctx, slot, pubKey, validatorIndex)
 	if err != nil {
 		return false, err
 	}
@@ -829,7 +944,7 @@ func (v *validator) isSyncCommitteeAggregator(ctx context.Context, slot primitiv
 	for _, index := range res.Indices {
 		subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
 		subnet := uint64(index) / subCommitteeSize
-		sig, err := v.signSyncSelectionData(ctx, pubKey, subnet, slot)
+		sig, err := v.syncSelectionProof(ctx, slot, pubKey, subnet)
 		if err != nil {
 			return false, err
 		}
@@ -999,7 +1114,8 @@ func (v *validator) SetProposerSettings(ctx context.Context, settings *validator
 	return nil
 }
 
-// PushProposerSettings calls the prepareBeaconProposer RPC to set the fee recipient and also the register validator API if using a custom builder.
+// PushProposerSettings calls the prepareBeaconProposer RPC to set the fee recipient and also the register validator API if using a custom builder,
+// submitting the registrations to the beacon node and to the relays configured for each validator key.
 func (v *validator) PushProposerSettings(ctx context.Context, km keymanager.IKeymanager, slot primitives.Slot, deadline time.Time) error {
 	if km == nil {
 		return errors.New("keymanager is nil when calling PrepareBeaconProposer")
@@ -1044,8 +1160,15 @@ func (v *validator) PushProposerSettings(ctx context.Context, km keymanager.IKey
 	if err != nil {
 		return err
 	}
-	if err := SubmitValidatorRegistrations(ctx, v.validatorClient, signedRegReqs); err != nil {
-		return errors.Wrap(ErrBuilderValidatorRegistration, err.Error())
+	var regErrs []string
+	if err := SubmitValidatorRegistrations(ctx, v.validatorClient, signedRegReqs, v.validatorsRegBatchSize); err != nil {
+		regErrs = append(regErrs, err.Error())
+	}
+	if err := v.submitRegistrationsToRelays(ctx, signedRegReqs); err != nil {
+		regErrs = append(regErrs, err.Error())
+	}
+	if len(regErrs) > 0 {
+		return errors.Wrap(ErrBuilderValidatorRegistration, strings.Join(regErrs, "; "))
 	}
 
 	return nil
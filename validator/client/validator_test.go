@@ -998,6 +998,33 @@ func (m *doppelGangerRequestMatcher) String() string {
 	return fmt.Sprintf("%#v", m.req.ValidatorRequests)
 }
 
+func TestValidator_CheckDoppelGanger_NotSupported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	flgs := features.Get()
+	flgs.EnableDoppelGanger = true
+	reset := features.InitWithReset(flgs)
+	defer reset()
+
+	client := validatormock.NewMockValidatorClient(ctrl)
+	km := genMockKeymanager(t, 1)
+	keys, err := km.FetchValidatingPublicKeys(context.Background())
+	require.NoError(t, err)
+	v := &validator{
+		validatorClient: client,
+		keyManager:      km,
+		db:              dbTest.SetupDB(t, keys),
+	}
+	client.EXPECT().CheckDoppelGanger(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(nil, iface.ErrNotSupported)
+
+	// The validator does not start without the check.
+	err = v.CheckDoppelGanger(context.Background())
+	require.ErrorIs(t, err, iface.ErrNotSupported)
+}
+
 func TestValidator_CheckDoppelGanger(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -70,4 +70,13 @@ type ValidatorDB interface {
 	UpdateProposerSettingsDefault(context.Context, *validatorServiceConfig.ProposerOption) error
 	UpdateProposerSettingsForPubkey(context.Context, [fieldparams.BLSPubkeyLength]byte, *validatorServiceConfig.ProposerOption) error
 	SaveProposerSettings(ctx context.Context, settings *validatorServiceConfig.ProposerSettings) error
+
+	// Doppelganger protection related methods.
+	DoppelgangerRecords(ctx context.Context) (map[[fieldparams.BLSPubkeyLength]byte]*kv.DoppelgangerRecord, error)
+	SaveDoppelgangerRecords(ctx context.Context, records map[[fieldparams.BLSPubkeyLength]byte]*kv.DoppelgangerRecord) error
+
+	// Watched keystores directory related methods.
+	WatchedKeystores(ctx context.Context) (map[string][]byte, error)
+	SaveWatchedKeystore(ctx context.Context, name string, pubKey []byte) error
+	DeleteWatchedKeystore(ctx context.Context, name string) error
 }
@@ -0,0 +1,50 @@
+package kv
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	bolt "go.etcd.io/bbolt"
+)
+
+// compactTxMaxSize is the maximum size of the transactions used to copy the database when compacting it.
+const compactTxMaxSize = 64 * 1024 * 1024
+
+// Compact rewrites the validator database in the directory path into a new file holding only its live data,
+// since bolt never shrinks its file when data is deleted, for example by pruning. The database must not be open.
+func Compact(dirPath string) error {
+	datafile := filepath.Join(dirPath, ProtectionDbFileName)
+	compactedFile := datafile + ".compacted"
+	src, err := bolt.Open(datafile, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{
+		Timeout:  params.BeaconIoConfig().BoltTimeout,
+		ReadOnly: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	dst, err := bolt.Open(compactedFile, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{
+		Timeout: params.BeaconIoConfig().BoltTimeout,
+	})
+	if err != nil {
+		if closeErr := src.Close(); closeErr != nil {
+			log.WithError(closeErr).Error("Could not close database")
+		}
+		return errors.Wrap(err, "could not create compacted database")
+	}
+	compactErr := bolt.Compact(dst, src, compactTxMaxSize)
+	if err := src.Close(); err != nil {
+		log.WithError(err).Error("Could not close database")
+	}
+	if err := dst.Close(); err != nil && compactErr == nil {
+		compactErr = err
+	}
+	if compactErr != nil {
+		if err := os.Remove(compactedFile); err != nil {
+			log.WithError(err).Error("Could not remove compacted database")
+		}
+		return errors.Wrap(compactErr, "could not compact database")
+	}
+	return os.Rename(compactedFile, datafile)
+}
@@ -0,0 +1,42 @@
+package kv
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_Compact(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	validatorDB := setupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	validatorDB.pruningEpochs = 10
+	require.NoError(t, setupAttestationsForEveryEpoch(validatorDB, pubKey, 5000))
+	require.NoError(t, validatorDB.PruneAttestations(ctx))
+	dirPath := validatorDB.databasePath
+	require.NoError(t, validatorDB.Close())
+
+	datafile := filepath.Join(dirPath, ProtectionDbFileName)
+	before, err := os.Stat(datafile)
+	require.NoError(t, err)
+	require.NoError(t, Compact(dirPath))
+	after, err := os.Stat(datafile)
+	require.NoError(t, err)
+	require.Equal(t, true, after.Size() < before.Size())
+
+	// The data left after pruning is kept.
+	validatorDB, err = NewKVStore(ctx, dirPath, &Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, validatorDB.Close())
+	}()
+	lowestSource, exists, err := validatorDB.LowestSignedSourceEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, true, exists)
+	require.Equal(t, primitives.Epoch(4989), lowestSource)
+}
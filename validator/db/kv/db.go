@@ -15,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/io/file"
 	bolt "go.etcd.io/bbolt"
 )
@@ -29,6 +30,9 @@ const (
 	// Time interval after which we flush attestation records to the database
 	// from a batch kept in memory for slashing protection.
 	attestationBatchWriteInterval = time.Millisecond * 100
+	// Time interval after which we prune the attestation history kept for
+	// slashing protection, when pruning is enabled.
+	attestationPruningInterval = time.Hour
 	// Specifies the initial mmap size of bolt.
 	mmapSize = 536870912
 )
@@ -56,6 +60,9 @@ var blockedBuckets = [][]byte{
 // Config represents store's config object.
 type Config struct {
 	PubKeys [][fieldparams.BLSPubkeyLength]byte
+	// PruningEpochs is the number of epochs of attestation history kept for slashing protection when
+	// pruning. It defaults to SLASHING_PROTECTION_PRUNING_EPOCHS.
+	PruningEpochs primitives.Epoch
 }
 
 // Store defines an implementation of the Prysm Database interface
@@ -67,10 +74,17 @@ type Store struct {
 	batchedAttestationsChan            chan *AttestationRecordSaveRequest
 	batchAttestationsFlushedFeed       *event.Feed
 	batchedAttestationsFlushInProgress abool.AtomicBool
+	pruningEpochs                      primitives.Epoch
+	cancelPruning                      context.CancelFunc
+	pruningDone                        chan struct{}
 }
 
-// Close closes the underlying boltdb database.
+// Close stops the periodic pruning of the attestation history and closes the underlying boltdb database.
 func (s *Store) Close() error {
+	if s.cancelPruning != nil {
+		s.cancelPruning()
+		<-s.pruningDone
+	}
 	prometheus.Unregister(createBoltCollector(s.db))
 	return s.db.Close()
 }
@@ -136,6 +150,10 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		batchedAttestations:          NewQueuedAttestationRecords(),
 		batchedAttestationsChan:      make(chan *AttestationRecordSaveRequest, attestationBatchCapacity),
 		batchAttestationsFlushedFeed: new(event.Feed),
+		pruningEpochs:                params.BeaconConfig().SlashingProtectionPruningEpochs,
+	}
+	if config != nil && config.PruningEpochs > 0 {
+		kv.pruningEpochs = config.PruningEpochs
 	}
 
 	if err := kv.db.Update(func(tx *bolt.Tx) error {
@@ -153,6 +171,8 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 			migrationsBucket,
 			graffitiBucket,
 			proposerSettingsBucket,
+			doppelgangerBucket,
+			watchedKeystoresBucket,
 		)
 	}); err != nil {
 		return nil, err
@@ -170,6 +190,10 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		if err := kv.PruneAttestations(ctx); err != nil {
 			return nil, errors.Wrap(err, "could not prune old attestations from DB")
 		}
+		pruningCtx, cancel := context.WithCancel(ctx)
+		kv.cancelPruning = cancel
+		kv.pruningDone = make(chan struct{})
+		go kv.pruneAttestationsPeriodically(pruningCtx)
 	}
 
 	// Batch save attestation records for slashing protection at timed
@@ -0,0 +1,59 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// DoppelgangerRecord is the state of the doppelganger check of a validator key, persisted so that a restart
+// of the validator client can resume the check instead of starting it over.
+type DoppelgangerRecord struct {
+	// Epoch is the next epoch the liveness of the key has to be checked in. Once the key is cleared, it is
+	// the epoch following the last epoch the key performed duties in.
+	Epoch primitives.Epoch
+	// CleanEpochs is the number of consecutive epochs the key was found not live in.
+	CleanEpochs uint64
+}
+
+// DoppelgangerRecords returns the doppelganger check state of the validator keys.
+func (s *Store) DoppelgangerRecords(ctx context.Context) (map[[fieldparams.BLSPubkeyLength]byte]*DoppelgangerRecord, error) {
+	_, span := trace.StartSpan(ctx, "Validator.DoppelgangerRecords")
+	defer span.End()
+	records := make(map[[fieldparams.BLSPubkeyLength]byte]*DoppelgangerRecord)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(doppelgangerBucket)
+		return bkt.ForEach(func(k, v []byte) error {
+			if len(k) != fieldparams.BLSPubkeyLength || len(v) != 16 {
+				return errors.Errorf("invalid doppelganger record for key %#x", k)
+			}
+			records[bytesutil.ToBytes48(k)] = &DoppelgangerRecord{
+				Epoch:       primitives.Epoch(bytesutil.BytesToUint64BigEndian(v[:8])),
+				CleanEpochs: bytesutil.BytesToUint64BigEndian(v[8:]),
+			}
+			return nil
+		})
+	})
+	return records, err
+}
+
+// SaveDoppelgangerRecords stores the doppelganger check state of the validator keys.
+func (s *Store) SaveDoppelgangerRecords(ctx context.Context, records map[[fieldparams.BLSPubkeyLength]byte]*DoppelgangerRecord) error {
+	_, span := trace.StartSpan(ctx, "Validator.SaveDoppelgangerRecords")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(doppelgangerBucket)
+		for pubKey, record := range records {
+			v := append(bytesutil.EpochToBytesBigEndian(record.Epoch), bytesutil.Uint64ToBytesBigEndian(record.CleanEpochs)...)
+			if err := bkt.Put(pubKey[:], v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,36 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_DoppelgangerRecords_ReadAndWrite(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
+
+	records, err := db.DoppelgangerRecords(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(records))
+
+	want := map[[fieldparams.BLSPubkeyLength]byte]*DoppelgangerRecord{
+		{1}: {Epoch: 10, CleanEpochs: 0},
+		{2}: {Epoch: 12, CleanEpochs: 2},
+	}
+	require.NoError(t, db.SaveDoppelgangerRecords(ctx, want))
+	records, err = db.DoppelgangerRecords(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, want, records)
+
+	// Records of other keys are kept when saving.
+	require.NoError(t, db.SaveDoppelgangerRecords(ctx, map[[fieldparams.BLSPubkeyLength]byte]*DoppelgangerRecord{
+		{2}: {Epoch: 13, CleanEpochs: 3},
+	}))
+	records, err = db.DoppelgangerRecords(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, &DoppelgangerRecord{Epoch: 10}, records[[fieldparams.BLSPubkeyLength]byte{1}])
+	require.DeepEqual(t, &DoppelgangerRecord{Epoch: 13, CleanEpochs: 3}, records[[fieldparams.BLSPubkeyLength]byte{2}])
+}
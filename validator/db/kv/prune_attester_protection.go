@@ -2,8 +2,8 @@ package kv
 
 import (
 	"context"
+	"time"
 
-	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	bolt "go.etcd.io/bbolt"
@@ -13,7 +13,9 @@ import (
 // PruneAttestations loops through every public key in the public keys bucket
 // and prunes all attestation data that has target epochs older the highest
 // target epoch minus some constant of how many epochs we keep track of for slashing
-// protection. This routine is meant to run on startup.
+// protection. As required by EIP-3076, the lowest signed source and target epochs
+// of each public key are raised to the lowest ones left in its attestation history,
+// so that no attestation slashable with a pruned one can be signed.
 func (s *Store) PruneAttestations(ctx context.Context) error {
 	ctx, span := trace.StartSpan(ctx, "Validator.PruneAttestations")
 	defer span.End()
@@ -37,13 +39,16 @@ func (s *Store) PruneAttestations(ctx context.Context) error {
 			if pkBucket == nil {
 				return nil
 			}
-			if err := pruneSourceEpochsBucket(pkBucket); err != nil {
+			if err := pruneSourceEpochsBucket(pkBucket, s.pruningEpochs); err != nil {
 				return err
 			}
-			if err := pruneTargetEpochsBucket(pkBucket); err != nil {
+			if err := pruneTargetEpochsBucket(pkBucket, s.pruningEpochs); err != nil {
 				return err
 			}
-			return pruneSigningRootsBucket(pkBucket)
+			if err := pruneSigningRootsBucket(pkBucket, s.pruningEpochs); err != nil {
+				return err
+			}
+			return raiseLowestSignedEpochs(tx, k, pkBucket)
 		})
 		if err != nil {
 			return err
@@ -52,36 +57,89 @@ func (s *Store) PruneAttestations(ctx context.Context) error {
 	return nil
 }
 
-func pruneSourceEpochsBucket(bucket *bolt.Bucket) error {
+// pruneAttestationsPeriodically prunes the attestation history at regular intervals, so that
+// the database does not grow with the attestation history of a long running validator client.
+// It returns when the store is closed.
+func (s *Store) pruneAttestationsPeriodically(ctx context.Context) {
+	defer close(s.pruningDone)
+	ticker := time.NewTicker(attestationPruningInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.PruneAttestations(ctx); err != nil {
+				log.WithError(err).Error("Could not prune old attestations from DB")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// raiseLowestSignedEpochs raises the lowest signed source and target epochs of the public key to the
+// lowest source and target epochs left in its pruned attestation history.
+func raiseLowestSignedEpochs(tx *bolt.Tx, pubKey []byte, pkBucket *bolt.Bucket) error {
+	for _, b := range []struct {
+		history   []byte
+		watermark []byte
+	}{
+		{history: attestationSourceEpochsBucket, watermark: lowestSignedSourceBucket},
+		{history: attestationSigningRootsBucket, watermark: lowestSignedTargetBucket},
+	} {
+		historyBucket := pkBucket.Bucket(b.history)
+		if historyBucket == nil {
+			continue
+		}
+		lowestEpochBytes, _ := historyBucket.Cursor().First()
+		if lowestEpochBytes == nil {
+			continue
+		}
+		watermarkBucket, err := tx.CreateBucketIfNotExists(b.watermark)
+		if err != nil {
+			return err
+		}
+		watermarkBytes := watermarkBucket.Get(pubKey)
+		if len(watermarkBytes) >= 8 &&
+			bytesutil.BytesToEpochBigEndian(watermarkBytes) >= bytesutil.BytesToEpochBigEndian(lowestEpochBytes) {
+			continue
+		}
+		if err := watermarkBucket.Put(pubKey, lowestEpochBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pruneSourceEpochsBucket(bucket *bolt.Bucket, pruningEpochs primitives.Epoch) error {
 	sourceEpochsBucket := bucket.Bucket(attestationSourceEpochsBucket)
 	if sourceEpochsBucket == nil {
 		return nil
 	}
 
-	return pruneBucket(sourceEpochsBucket)
+	return pruneBucket(sourceEpochsBucket, pruningEpochs)
 }
 
-func pruneTargetEpochsBucket(bucket *bolt.Bucket) error {
+func pruneTargetEpochsBucket(bucket *bolt.Bucket, pruningEpochs primitives.Epoch) error {
 	targetEpochsBucket := bucket.Bucket(attestationTargetEpochsBucket)
 	if targetEpochsBucket == nil {
 		return nil
 	}
 
-	return pruneBucket(targetEpochsBucket)
+	return pruneBucket(targetEpochsBucket, pruningEpochs)
 }
 
-func pruneSigningRootsBucket(bucket *bolt.Bucket) error {
+func pruneSigningRootsBucket(bucket *bolt.Bucket, pruningEpochs primitives.Epoch) error {
 	signingRootsBucket := bucket.Bucket(attestationSigningRootsBucket)
 	if signingRootsBucket == nil {
 		return nil
 	}
 
-	return pruneBucket(signingRootsBucket)
+	return pruneBucket(signingRootsBucket, pruningEpochs)
 }
 
 // pruneBucket iterates through epoch keys and deletes any key/value lower than
 // the pruning cut off epoch as determined by the highest key in the bucket.
-func pruneBucket(bkt *bolt.Bucket) error {
+func pruneBucket(bkt *bolt.Bucket, pruningEpochs primitives.Epoch) error {
 	if bkt == nil {
 		return nil
 	}
@@ -89,7 +147,7 @@ func pruneBucket(bkt *bolt.Bucket) error {
 	// We obtain the highest target epoch from the signing roots bucket.
 	highestEpochBytes, _ := bkt.Cursor().Last()
 	highestEpoch := bytesutil.BytesToEpochBigEndian(highestEpochBytes)
-	upperBounds := pruningEpochCutoff(highestEpoch)
+	upperBounds := pruningEpochCutoff(highestEpoch, pruningEpochs)
 
 	c := bkt.Cursor()
 	for k, _ := c.First(); k != nil; k, _ = c.Next() {
@@ -106,13 +164,13 @@ func pruneBucket(bkt *bolt.Bucket) error {
 }
 
 // This helper function determines the cutoff epoch where, for all epochs before it, we should prune
-// the slashing protection database. This is computed by taking in an epoch and subtracting
-// SLASHING_PROTECTION_PRUNING_EPOCHS from the value. For example, if we are keeping track of 512 epochs
+// the slashing protection database. This is computed by taking in an epoch and subtracting the
+// number of pruning epochs from the value. For example, if we are keeping track of 512 epochs
 // in the database, if we pass in epoch 612, then we want to prune all epochs before epoch 100.
-func pruningEpochCutoff(epoch primitives.Epoch) primitives.Epoch {
+func pruningEpochCutoff(epoch, pruningEpochs primitives.Epoch) primitives.Epoch {
 	minEpoch := primitives.Epoch(0)
-	if epoch > params.BeaconConfig().SlashingProtectionPruningEpochs {
-		minEpoch = epoch - params.BeaconConfig().SlashingProtectionPruningEpochs
+	if epoch > pruningEpochs {
+		minEpoch = epoch - pruningEpochs
 	}
 	return minEpoch
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
@@ -13,6 +14,21 @@ import (
 	bolt "go.etcd.io/bbolt"
 )
 
+func TestPruneAttestationsPeriodically_StopsOnClose(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableSlashingProtectionPruning: true})
+	defer resetCfg()
+
+	db, err := NewKVStore(context.Background(), t.TempDir(), &Config{})
+	require.NoError(t, err)
+	require.NotNil(t, db.cancelPruning)
+	require.NoError(t, db.Close())
+	select {
+	case <-db.pruningDone:
+	default:
+		t.Fatal("Attestation pruning did not stop when closing the store")
+	}
+}
+
 func TestPruneAttestations_NoPruning(t *testing.T) {
 	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
 	validatorDB := setupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
@@ -186,3 +202,33 @@ func checkAttestingHistoryAfterPruning(
 		return nil
 	})
 }
+
+func TestPruneAttestations_RaisesLowestSignedEpochs(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	validatorDB := setupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	validatorDB.pruningEpochs = 10
+	ctx := context.Background()
+
+	// Attest with (source, target) = (0, 1), ..., (49, 50).
+	require.NoError(t, setupAttestationsForEveryEpoch(validatorDB, pubKey, 50))
+	require.NoError(t, validatorDB.update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(lowestSignedSourceBucket).Put(pubKey[:], bytesutil.EpochToBytesBigEndian(0)); err != nil {
+			return err
+		}
+		return tx.Bucket(lowestSignedTargetBucket).Put(pubKey[:], bytesutil.EpochToBytesBigEndian(1))
+	}))
+
+	require.NoError(t, validatorDB.PruneAttestations(ctx))
+	require.NoError(t, checkAttestingHistoryAfterPruning(t, validatorDB, pubKey, 0, 38, true /* should be pruned */))
+	require.NoError(t, checkAttestingHistoryAfterPruning(t, validatorDB, pubKey, 40, 49, false /* should not be pruned */))
+
+	// The lowest signed epochs are the lowest ones left in the attestation history.
+	lowestSource, exists, err := validatorDB.LowestSignedSourceEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, true, exists)
+	require.Equal(t, primitives.Epoch(39), lowestSource)
+	lowestTarget, exists, err := validatorDB.LowestSignedTargetEpoch(ctx, pubKey)
+	require.NoError(t, err)
+	require.Equal(t, true, exists)
+	require.Equal(t, primitives.Epoch(40), lowestTarget)
+}
@@ -41,4 +41,10 @@ var (
 	// ProposerSettings stores the encoded proposer settings file
 	proposerSettingsBucket = []byte("proposer-settings-bucket")
 	proposerSettingsKey    = []byte("proposer-settings")
+
+	// Doppelganger protection state of each validator key.
+	doppelgangerBucket = []byte("doppelganger-bucket")
+
+	// Public keys imported from the watched keystores directory, by keystore file name.
+	watchedKeystoresBucket = []byte("watched-keystores-bucket")
 )
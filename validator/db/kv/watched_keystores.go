@@ -0,0 +1,51 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// WatchedKeystores returns the public keys imported from the watched keystores directory, by keystore file name, so
+// that a restart of the validator client still deletes them when their file is removed.
+func (s *Store) WatchedKeystores(ctx context.Context) (map[string][]byte, error) {
+	_, span := trace.StartSpan(ctx, "Validator.WatchedKeystores")
+	defer span.End()
+	keystores := make(map[string][]byte)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(watchedKeystoresBucket)
+		return bkt.ForEach(func(k, v []byte) error {
+			if len(v) != fieldparams.BLSPubkeyLength {
+				return errors.Errorf("invalid public key for watched keystore %s", k)
+			}
+			keystores[string(k)] = bytesutil.SafeCopyBytes(v)
+			return nil
+		})
+	})
+	return keystores, err
+}
+
+// SaveWatchedKeystore stores the public key imported from the keystore file of the watched keystores directory.
+func (s *Store) SaveWatchedKeystore(ctx context.Context, name string, pubKey []byte) error {
+	_, span := trace.StartSpan(ctx, "Validator.SaveWatchedKeystore")
+	defer span.End()
+	if len(pubKey) != fieldparams.BLSPubkeyLength {
+		return errors.Errorf("invalid public key length %d", len(pubKey))
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(watchedKeystoresBucket).Put([]byte(name), pubKey)
+	})
+}
+
+// DeleteWatchedKeystore deletes the public key imported from the keystore file of the watched keystores directory.
+func (s *Store) DeleteWatchedKeystore(ctx context.Context, name string) error {
+	_, span := trace.StartSpan(ctx, "Validator.DeleteWatchedKeystore")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(watchedKeystoresBucket).Delete([]byte(name))
+	})
+}
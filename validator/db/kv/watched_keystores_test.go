@@ -0,0 +1,32 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_WatchedKeystores_ReadAndWrite(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
+
+	keystores, err := db.WatchedKeystores(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(keystores))
+
+	first, second := make([]byte, fieldparams.BLSPubkeyLength), make([]byte, fieldparams.BLSPubkeyLength)
+	first[0], second[0] = 1, 2
+	require.NoError(t, db.SaveWatchedKeystore(ctx, "first.json", first))
+	require.NoError(t, db.SaveWatchedKeystore(ctx, "second.json", second))
+	require.ErrorContains(t, "invalid public key length", db.SaveWatchedKeystore(ctx, "third.json", []byte{3}))
+	keystores, err = db.WatchedKeystores(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, map[string][]byte{"first.json": first, "second.json": second}, keystores)
+
+	require.NoError(t, db.DeleteWatchedKeystore(ctx, "first.json"))
+	keystores, err = db.WatchedKeystores(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, map[string][]byte{"second.json": second}, keystores)
+}
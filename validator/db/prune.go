@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/cmd"
+	"github.com/prysmaticlabs/prysm/v4/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// Prune the attestation history of a validator database beyond the configured number of epochs,
+// then compact the database file to reclaim the space freed.
+func Prune(cliCtx *cli.Context) error {
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+	datafile := path.Join(dataDir, kv.ProtectionDbFileName)
+
+	if !file.FileExists(datafile) {
+		return errors.New("No validator db found at path, nothing to prune")
+	}
+	before, err := os.Stat(datafile)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	log.Info("Opening DB")
+	validatorDB, err := kv.NewKVStore(ctx, dataDir, &kv.Config{
+		PruningEpochs: primitives.Epoch(cliCtx.Uint64(flags.SlashingProtectionPruningEpochsFlag.Name)),
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Pruning attestation history")
+	if err := validatorDB.PruneAttestations(ctx); err != nil {
+		return err
+	}
+	if err := validatorDB.Close(); err != nil {
+		return err
+	}
+
+	log.Info("Compacting DB")
+	if err := kv.Compact(dataDir); err != nil {
+		return err
+	}
+	after, err := os.Stat(datafile)
+	if err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"sizeBefore": before.Size(),
+		"sizeAfter":  after.Size(),
+	}).Info("Pruned validator database")
+	return nil
+}
@@ -2,9 +2,12 @@ package graffiti
 
 import (
 	"encoding/hex"
+	"fmt"
 	"os"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
 	"gopkg.in/yaml.v2"
@@ -22,6 +25,19 @@ type Graffiti struct {
 	Ordered  []string                             `yaml:"ordered,omitempty"`
 	Random   []string                             `yaml:"random,omitempty"`
 	Specific map[primitives.ValidatorIndex]string `yaml:"specific,omitempty"`
+	Keys     map[string]*KeyGraffiti              `yaml:"keys,omitempty"`
+}
+
+// KeyGraffiti is the graffiti rotation of a single validator key. The ordered graffiti are used one after the
+// other, starting over once all of them were used, and take priority over the random graffiti.
+type KeyGraffiti struct {
+	Ordered []string `yaml:"ordered,omitempty"`
+	Random  []string `yaml:"random,omitempty"`
+}
+
+// ForKey returns the graffiti rotation of the given validator key, or nil if the key has none.
+func (g *Graffiti) ForKey(pubKey [fieldparams.BLSPubkeyLength]byte) *KeyGraffiti {
+	return g.Keys[hexutil.Encode(pubKey[:])]
 }
 
 // ParseGraffitiFile parses the graffiti file and returns the graffiti struct.
@@ -51,6 +67,28 @@ func ParseGraffitiFile(f string) (*Graffiti, error) {
 		g.Random[i] = ParseHexGraffiti(v)
 	}
 
+	// Public keys are normalized to lower case hex so that they can be looked up whatever their case in the file.
+	if len(g.Keys) != 0 {
+		keys := make(map[string]*KeyGraffiti, len(g.Keys))
+		for k, kg := range g.Keys {
+			pubKey, err := hexutil.Decode(k)
+			if err != nil || len(pubKey) != fieldparams.BLSPubkeyLength {
+				return nil, fmt.Errorf("invalid validator public key %s in graffiti file", k)
+			}
+			if kg == nil {
+				continue
+			}
+			for i, v := range kg.Ordered {
+				kg.Ordered[i] = ParseHexGraffiti(v)
+			}
+			for i, v := range kg.Random {
+				kg.Random[i] = ParseHexGraffiti(v)
+			}
+			keys[hexutil.Encode(pubKey)] = kg
+		}
+		g.Keys = keys
+	}
+
 	g.Default = ParseHexGraffiti(g.Default)
 	g.Hash = hash.Hash(yamlFile)
 
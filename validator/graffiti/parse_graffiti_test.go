@@ -5,8 +5,11 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 )
@@ -218,3 +221,59 @@ func TestParseHexGraffiti(t *testing.T) {
 		})
 	}
 }
+
+func TestParseGraffitiFile_Keys(t *testing.T) {
+	input := []byte(`
+keys:
+  "0xA99A76ED7796F7BE22D5B7E85DEEB7C5677E88E511E0B337618F8C4EB61349B4BF2D153F649F7B53359FE8B94A38E44C":
+    ordered:
+      - "Mr G was here"
+      - "hex:4d722048207761732068657265"
+  "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b":
+    random:
+      - "Mr I was here"`)
+
+	dirName := t.TempDir() + "somedir"
+	err := os.MkdirAll(dirName, os.ModePerm)
+	require.NoError(t, err)
+	someFileName := filepath.Join(dirName, "somefile.txt")
+	require.NoError(t, os.WriteFile(someFileName, input, os.ModePerm))
+
+	got, err := ParseGraffitiFile(someFileName)
+	require.NoError(t, err)
+
+	wanted := &Graffiti{
+		Hash: hash.Hash(input),
+		Keys: map[string]*KeyGraffiti{
+			"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c": {
+				Ordered: []string{"Mr G was here", "Mr H was here"},
+			},
+			"0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b": {
+				Random: []string{"Mr I was here"},
+			},
+		},
+	}
+	require.DeepEqual(t, wanted, got)
+
+	pubKey, err := hexutil.Decode("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c")
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{"Mr G was here", "Mr H was here"}, got.ForKey(bytesutil.ToBytes48(pubKey)).Ordered)
+	assert.Equal(t, (*KeyGraffiti)(nil), got.ForKey([fieldparams.BLSPubkeyLength]byte{}))
+}
+
+func TestParseGraffitiFile_InvalidKey(t *testing.T) {
+	input := []byte(`
+keys:
+  "0x1234":
+    ordered:
+      - "Mr G was here"`)
+
+	dirName := t.TempDir() + "somedir"
+	err := os.MkdirAll(dirName, os.ModePerm)
+	require.NoError(t, err)
+	someFileName := filepath.Join(dirName, "somefile.txt")
+	require.NoError(t, os.WriteFile(someFileName, input, os.ModePerm))
+
+	_, err = ParseGraffitiFile(someFileName)
+	require.ErrorContains(t, "invalid validator public key 0x1234", err)
+}
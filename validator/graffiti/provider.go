@@ -0,0 +1,94 @@
+package graffiti
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+// ProviderResponse is the response of the graffiti provider.
+type ProviderResponse struct {
+	Graffiti string `json:"graffiti"`
+}
+
+// Provider supplies the graffiti of block proposals from an external HTTP endpoint. The endpoint is called with
+// a GET request for every proposal, with the public key of the proposer and the slot of the proposal as the
+// `pubkey` and `slot` query parameters, and answers with a JSON object such as {"graffiti": "..."}.
+type Provider struct {
+	url     string
+	timeout time.Duration
+	client  *http.Client
+}
+
+// NewProvider returns a graffiti provider calling the given URL, which gives up on a request after the timeout, so
+// that an unresponsive provider does not delay block proposals.
+func NewProvider(providerURL string, timeout time.Duration) (*Provider, error) {
+	u, err := url.ParseRequestURI(providerURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid graffiti provider URL")
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid graffiti provider URL: %s", providerURL)
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("graffiti provider timeout must be positive, got %s", timeout)
+	}
+	return &Provider{
+		url:     providerURL,
+		timeout: timeout,
+		client:  &http.Client{},
+	}, nil
+}
+
+// Graffiti requests the graffiti of the proposal of the given validator key at the given slot. An empty graffiti
+// means the provider has no graffiti for the proposal.
+func (p *Provider) Graffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	u, err := url.Parse(p.url)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid graffiti provider URL")
+	}
+	query := u.Query()
+	query.Set("pubkey", hexutil.Encode(pubKey[:]))
+	query.Set("slot", strconv.FormatUint(uint64(slot), 10))
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create http request")
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send http request")
+	}
+	defer func(Body io.ReadCloser) {
+		if err := Body.Close(); err != nil {
+			log.WithError(err).Error("Failed to close response body")
+		}
+	}(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("graffiti provider request failed with status code %d", resp.StatusCode)
+	}
+	var res ProviderResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, errors.Wrap(err, "failed to decode graffiti provider response")
+	}
+	graffiti := ParseHexGraffiti(res.Graffiti)
+	if len(graffiti) > fieldparams.RootLength {
+		return nil, errors.Errorf("graffiti of %d bytes is longer than %d bytes", len(graffiti), fieldparams.RootLength)
+	}
+	return []byte(graffiti), nil
+}
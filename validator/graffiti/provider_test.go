@@ -0,0 +1,92 @@
+package graffiti
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestProvider_Graffiti(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{0xaa}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graffiti", r.URL.Path)
+		assert.Equal(t, "pool", r.URL.Query().Get("tag"))
+		assert.Equal(t, "0xaa"+strings.Repeat("00", fieldparams.BLSPubkeyLength-1), r.URL.Query().Get("pubkey"))
+		assert.Equal(t, "123", r.URL.Query().Get("slot"))
+		require.NoError(t, json.NewEncoder(w).Encode(&ProviderResponse{Graffiti: "hex:0x6869"}))
+	}))
+	defer srv.Close()
+
+	p, err := NewProvider(srv.URL+"/graffiti?tag=pool", time.Second)
+	require.NoError(t, err)
+	g, err := p.Graffiti(context.Background(), pubKey, 123)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte("hi"), g)
+}
+
+func TestProvider_Graffiti_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr string
+	}{
+		{
+			name: "timeout",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(200 * time.Millisecond)
+			},
+			wantErr: "context deadline exceeded",
+		},
+		{
+			name: "error status",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantErr: "graffiti provider request failed with status code 500",
+		},
+		{
+			name: "invalid response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, err := w.Write([]byte("graffiti"))
+				require.NoError(t, err)
+			},
+			wantErr: "failed to decode graffiti provider response",
+		},
+		{
+			name: "graffiti too long",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, json.NewEncoder(w).Encode(&ProviderResponse{Graffiti: strings.Repeat("a", 33)}))
+			},
+			wantErr: "graffiti of 33 bytes is longer than 32 bytes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.handler)
+			defer srv.Close()
+
+			p, err := NewProvider(srv.URL, 50*time.Millisecond)
+			require.NoError(t, err)
+			_, err = p.Graffiti(context.Background(), [fieldparams.BLSPubkeyLength]byte{}, 0)
+			require.ErrorContains(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestNewProvider_InvalidURL(t *testing.T) {
+	_, err := NewProvider("localhost", time.Second)
+	require.ErrorContains(t, "invalid graffiti provider URL", err)
+}
+
+func TestNewProvider_InvalidTimeout(t *testing.T) {
+	_, err := NewProvider("http://localhost:8080", 0)
+	require.ErrorContains(t, "graffiti provider timeout must be positive", err)
+}
@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -25,6 +27,18 @@ import (
 
 const (
 	ethApiNamespace = "/api/v1/eth2/sign/"
+	// Reasons of failed requests, used to label metrics.
+	timeoutFailure            = "timeout"
+	canceledFailure           = "canceled"
+	connectionFailure         = "connection"
+	serverErrorFailure        = "server_error"
+	badRequestFailure         = "bad_request"
+	keyNotFoundFailure        = "key_not_found"
+	slashingProtectionFailure = "slashing_protection"
+	unexpectedStatusFailure   = "unexpected_status"
+	invalidResponseFailure    = "invalid_response"
+	// retryInterval is how long to wait before retrying a failed sign request.
+	retryInterval = 100 * time.Millisecond
 )
 
 type SignRequestJson []byte
@@ -44,6 +58,25 @@ type HttpSignerClient interface {
 type ApiClient struct {
 	BaseURL    *url.URL
 	RestClient *http.Client
+	// MaxRetries is the number of times a sign request is retried after a network or server error.
+	MaxRetries uint64
+	// BackupURL is the URL of a backup web3signer, switched to after FailoverThreshold consecutive sign requests
+	// failed due to a network or server error. The client switches back the same way.
+	BackupURL         *url.URL
+	FailoverThreshold uint64
+
+	failoverLock        sync.Mutex
+	consecutiveFailures uint64
+	backupActive        bool
+}
+
+// retryableError is returned for requests which failed due to a network or server error, and may succeed if retried.
+type retryableError struct {
+	error
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.error
 }
 
 // NewApiClient method instantiates a new ApiClient object.
@@ -61,10 +94,13 @@ func NewApiClient(baseEndpoint string) (*ApiClient, error) {
 	}, nil
 }
 
-// Sign is a wrapper method around the web3signer sign api.
+// Sign is a wrapper method around the web3signer sign api. Requests which fail due to a network or server error
+// are retried up to MaxRetries times, as web3signer signs the same signing root again without a slashing violation.
 func (client *ApiClient) Sign(ctx context.Context, pubKey string, request SignRequestJson) (bls.Signature, error) {
 	requestPath := ethApiNamespace + pubKey
-	resp, err := client.doRequest(ctx, http.MethodPost, client.BaseURL.String()+requestPath, bytes.NewBuffer(request))
+	signerURL := client.signerURL()
+	resp, err := client.doRequestWithRetries(ctx, http.MethodPost, signerURL.String()+requestPath, request)
+	client.recordSignResult(err)
 	if err != nil {
 		return nil, err
 	}
@@ -72,17 +108,65 @@ func (client *ApiClient) Sign(ctx context.Context, pubKey string, request SignRe
 		return nil, fmt.Errorf("public key not found")
 	}
 	if resp.StatusCode == http.StatusPreconditionFailed {
-		return nil, fmt.Errorf("signing operation failed due to slashing protection rules,  Signing Request URL: %v, Status: %v", client.BaseURL.String()+requestPath, resp.StatusCode)
+		return nil, fmt.Errorf("signing operation failed due to slashing protection rules,  Signing Request URL: %v, Status: %v", signerURL.String()+requestPath, resp.StatusCode)
 	}
+	var sig bls.Signature
 	contentType := resp.Header.Get("Content-Type")
 	if strings.HasPrefix(contentType, "application/json") {
 		var sigResp SignatureResponse
-		if err := unmarshalResponse(resp.Body, &sigResp); err != nil {
-			return nil, err
+		if err = unmarshalResponse(resp.Body, &sigResp); err == nil {
+			sig, err = bls.SignatureFromBytes(sigResp.Signature)
 		}
-		return bls.SignatureFromBytes(sigResp.Signature)
 	} else {
-		return unmarshalSignatureResponse(resp.Body)
+		sig, err = unmarshalSignatureResponse(resp.Body)
+	}
+	if err != nil {
+		requestFailuresTotal.WithLabelValues(invalidResponseFailure).Inc()
+		return nil, err
+	}
+	return sig, nil
+}
+
+// signerURL returns the URL of the web3signer currently in use, which is the backup one after a failover.
+func (client *ApiClient) signerURL() *url.URL {
+	client.failoverLock.Lock()
+	defer client.failoverLock.Unlock()
+	if client.backupActive {
+		return client.BackupURL
+	}
+	return client.BaseURL
+}
+
+// recordSignResult counts the consecutive sign requests which failed due to a network or server error, and switches
+// to the other web3signer once they reach the failover threshold.
+func (client *ApiClient) recordSignResult(err error) {
+	client.failoverLock.Lock()
+	defer client.failoverLock.Unlock()
+	var retryable *retryableError
+	if !errors.As(err, &retryable) {
+		client.consecutiveFailures = 0
+		return
+	}
+	client.consecutiveFailures++
+	if client.BackupURL == nil || client.FailoverThreshold == 0 || client.consecutiveFailures < client.FailoverThreshold {
+		return
+	}
+	from, to := client.BaseURL, client.BackupURL
+	if client.backupActive {
+		from, to = to, from
+	}
+	log.WithFields(logrus.Fields{
+		"from":                from.Redacted(),
+		"to":                  to.Redacted(),
+		"consecutiveFailures": client.consecutiveFailures,
+	}).Warn("Web3signer failed too many consecutive sign requests, switching to the other web3signer")
+	client.backupActive = !client.backupActive
+	client.consecutiveFailures = 0
+	signerFailoversTotal.Inc()
+	if client.backupActive {
+		backupSignerActive.Set(1)
+	} else {
+		backupSignerActive.Set(0)
 	}
 }
 
@@ -115,7 +199,7 @@ func (client *ApiClient) GetPublicKeys(ctx context.Context, url string) ([][fiel
 // ReloadSignerKeys is a wrapper method around the web3signer reload api.
 func (client *ApiClient) ReloadSignerKeys(ctx context.Context) error {
 	const requestPath = "/reload"
-	if _, err := client.doRequest(ctx, http.MethodPost, client.BaseURL.String()+requestPath, nil); err != nil {
+	if _, err := client.doRequest(ctx, http.MethodPost, client.signerURL().String()+requestPath, nil); err != nil {
 		return err
 	}
 	return nil
@@ -124,7 +208,7 @@ func (client *ApiClient) ReloadSignerKeys(ctx context.Context) error {
 // GetServerStatus is a wrapper method around the web3signer upcheck api
 func (client *ApiClient) GetServerStatus(ctx context.Context) (string, error) {
 	const requestPath = "/upcheck"
-	resp, err := client.doRequest(ctx, http.MethodGet, client.BaseURL.String()+requestPath, nil /* no body needed on get request */)
+	resp, err := client.doRequest(ctx, http.MethodGet, client.signerURL().String()+requestPath, nil /* no body needed on get request */)
 	if err != nil {
 		return "", err
 	}
@@ -135,6 +219,24 @@ func (client *ApiClient) GetServerStatus(ctx context.Context) (string, error) {
 	return status, nil
 }
 
+// doRequestWithRetries sends the request, and sends it again after a network or server error up to MaxRetries times.
+func (client *ApiClient) doRequestWithRetries(ctx context.Context, httpMethod, fullPath string, body []byte) (*http.Response, error) {
+	for attempt := uint64(0); ; attempt++ {
+		resp, err := client.doRequest(ctx, httpMethod, fullPath, bytes.NewBuffer(body))
+		var retryable *retryableError
+		if err == nil || !errors.As(err, &retryable) || attempt >= client.MaxRetries {
+			return resp, err
+		}
+		signRequestRetriesTotal.Inc()
+		log.WithError(err).WithField("attempt", attempt+1).Debug("Retrying web3signer request")
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
 // doRequest is a utility method for requests.
 func (client *ApiClient) doRequest(ctx context.Context, httpMethod, fullPath string, body io.Reader) (*http.Response, error) {
 	var requestDump []byte
@@ -156,13 +258,25 @@ func (client *ApiClient) doRequest(ctx context.Context, httpMethod, fullPath str
 	duration := time.Since(start)
 	if err != nil {
 		signRequestDurationSeconds.WithLabelValues(req.Method, "error").Observe(duration.Seconds())
+		reason := transportFailureReason(err)
+		requestFailuresTotal.WithLabelValues(reason).Inc()
+		log.WithError(err).WithFields(logrus.Fields{
+			"url":      req.URL.Redacted(),
+			"reason":   reason,
+			"duration": duration,
+		}).Debug("web3signer request failed")
 		err = errors.Wrap(err, "failed to execute json request")
 		tracing.AnnotateError(span, err)
+		if ctx.Err() == nil {
+			err = &retryableError{err}
+		}
 		return resp, err
 	} else {
 		signRequestDurationSeconds.WithLabelValues(req.Method, strconv.Itoa(resp.StatusCode)).Observe(duration.Seconds())
 	}
 	if resp.StatusCode != http.StatusOK {
+		reason := statusFailureReason(resp.StatusCode)
+		requestFailuresTotal.WithLabelValues(reason).Inc()
 		requestDump, err = httputil.DumpRequestOut(req, true)
 		if err != nil {
 			return nil, err
@@ -173,14 +287,17 @@ func (client *ApiClient) doRequest(ctx context.Context, httpMethod, fullPath str
 		}
 		log.WithFields(logrus.Fields{
 			"status":   resp.StatusCode,
+			"reason":   reason,
+			"duration": duration,
 			"request":  string(requestDump),
 			"response": string(responseDump),
 		}).Error("web3signer request failed")
 	}
 	if resp.StatusCode == http.StatusInternalServerError {
+		closeBody(resp.Body)
 		err = fmt.Errorf("internal Web3Signer server error, Signing Request URL: %v Status: %v", fullPath, resp.StatusCode)
 		tracing.AnnotateError(span, err)
-		return nil, err
+		return nil, &retryableError{err}
 	} else if resp.StatusCode == http.StatusBadRequest {
 		err = fmt.Errorf("bad request format, Signing Request URL: %v Status: %v", fullPath, resp.StatusCode)
 		tracing.AnnotateError(span, err)
@@ -189,6 +306,34 @@ func (client *ApiClient) doRequest(ctx context.Context, httpMethod, fullPath str
 	return resp, nil
 }
 
+// transportFailureReason classifies a request which failed without a response from web3signer.
+func transportFailureReason(err error) string {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return timeoutFailure
+	}
+	if errors.Is(err, context.Canceled) {
+		return canceledFailure
+	}
+	return connectionFailure
+}
+
+// statusFailureReason classifies a request which web3signer answered with an error status.
+func statusFailureReason(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return badRequestFailure
+	case http.StatusNotFound:
+		return keyNotFoundFailure
+	case http.StatusPreconditionFailed:
+		return slashingProtectionFailure
+	}
+	if statusCode >= http.StatusInternalServerError {
+		return serverErrorFailure
+	}
+	return unexpectedStatusFailure
+}
+
 // unmarshalResponse is a utility method for unmarshalling responses.
 func unmarshalResponse(responseBody io.ReadCloser, unmarshalledResponseObject interface{}) error {
 	defer closeBody(responseBody)
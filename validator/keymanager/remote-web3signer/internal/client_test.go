@@ -99,6 +99,108 @@ func TestClient_Sign_500(t *testing.T) {
 
 }
 
+// flakyTransport fails the first requests with a server error before returning the response.
+type flakyTransport struct {
+	failures     int
+	requests     int
+	mockResponse func() *http.Response
+}
+
+func (m *flakyTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	m.requests++
+	if m.requests <= m.failures {
+		return &http.Response{StatusCode: 500, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+	}
+	return m.mockResponse(), nil
+}
+
+func TestClient_Sign_Retries(t *testing.T) {
+	jsonSig := `0xb3baa751d0a9132cfe93e4e3d5ff9075111100e3789dca219ade5a24d27e19d16b3353149da1833e9b691bb38634e8dc04469be7032132906c927d7e1a49b414730612877bc6b2810c8f202daf793d1ab0d6b5cb21d52f9e52e883859887a5d9`
+	newResponse := func() *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(jsonSig)))}
+	}
+	u, err := url.Parse("http://example.com")
+	require.NoError(t, err)
+	jsonRequest, err := json.Marshal(`{message: "hello"}`)
+	require.NoError(t, err)
+
+	mock := &flakyTransport{failures: 2, mockResponse: newResponse}
+	cl := internal.ApiClient{BaseURL: u, RestClient: &http.Client{Transport: mock}, MaxRetries: 2}
+	resp, err := cl.Sign(context.Background(), "a2b5aaad9c6efefe7bb9b1243a043404f3362937cfb6b31833929833173f476630ea2cfeb0d9ddf15f97ca8685948820", jsonRequest)
+	require.NoError(t, err)
+	assert.EqualValues(t, jsonSig, fmt.Sprintf("%#x", resp.Marshal()))
+	assert.Equal(t, 3, mock.requests)
+
+	mock = &flakyTransport{failures: 2, mockResponse: newResponse}
+	cl = internal.ApiClient{BaseURL: u, RestClient: &http.Client{Transport: mock}, MaxRetries: 1}
+	_, err = cl.Sign(context.Background(), "a2b5aaad9c6efefe7bb9b1243a043404f3362937cfb6b31833929833173f476630ea2cfeb0d9ddf15f97ca8685948820", jsonRequest)
+	require.ErrorContains(t, "internal Web3Signer server error", err)
+	assert.Equal(t, 2, mock.requests)
+}
+
+// hostTransport answers the requests with the response of their host, and counts the requests per host.
+type hostTransport struct {
+	responses map[string]func() *http.Response
+	requests  map[string]int
+}
+
+func (m *hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	m.requests[req.URL.Host]++
+	return m.responses[req.URL.Host](), nil
+}
+
+func TestClient_Sign_Failover(t *testing.T) {
+	jsonSig := `0xb3baa751d0a9132cfe93e4e3d5ff9075111100e3789dca219ade5a24d27e19d16b3353149da1833e9b691bb38634e8dc04469be7032132906c927d7e1a49b414730612877bc6b2810c8f202daf793d1ab0d6b5cb21d52f9e52e883859887a5d9`
+	primaryUp := false
+	mock := &hostTransport{
+		responses: map[string]func() *http.Response{
+			"primary.com": func() *http.Response {
+				if primaryUp {
+					return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(jsonSig)))}
+				}
+				return &http.Response{StatusCode: 500, Body: io.NopCloser(bytes.NewReader(nil))}
+			},
+			"backup.com": func() *http.Response {
+				return &http.Response{StatusCode: 500, Body: io.NopCloser(bytes.NewReader(nil))}
+			},
+		},
+		requests: make(map[string]int),
+	}
+	primary, err := url.Parse("http://primary.com")
+	require.NoError(t, err)
+	backup, err := url.Parse("http://backup.com")
+	require.NoError(t, err)
+	jsonRequest, err := json.Marshal(`{message: "hello"}`)
+	require.NoError(t, err)
+	pubKey := "a2b5aaad9c6efefe7bb9b1243a043404f3362937cfb6b31833929833173f476630ea2cfeb0d9ddf15f97ca8685948820"
+	cl := &internal.ApiClient{
+		BaseURL:           primary,
+		BackupURL:         backup,
+		FailoverThreshold: 2,
+		RestClient:        &http.Client{Transport: mock},
+	}
+
+	// The client switches to the backup web3signer after 2 consecutive failures.
+	for i := 0; i < 2; i++ {
+		_, err = cl.Sign(context.Background(), pubKey, jsonRequest)
+		require.ErrorContains(t, "internal Web3Signer server error", err)
+	}
+	assert.Equal(t, 2, mock.requests["primary.com"])
+	_, err = cl.Sign(context.Background(), pubKey, jsonRequest)
+	require.ErrorContains(t, "internal Web3Signer server error", err)
+	assert.Equal(t, 1, mock.requests["backup.com"])
+
+	// And switches back once the backup web3signer failed as many times.
+	primaryUp = true
+	_, err = cl.Sign(context.Background(), pubKey, jsonRequest)
+	require.ErrorContains(t, "internal Web3Signer server error", err)
+	assert.Equal(t, 2, mock.requests["backup.com"])
+	resp, err := cl.Sign(context.Background(), pubKey, jsonRequest)
+	require.NoError(t, err)
+	assert.EqualValues(t, jsonSig, fmt.Sprintf("%#x", resp.Marshal()))
+	assert.Equal(t, 3, mock.requests["primary.com"])
+}
+
 func TestClient_Sign_412(t *testing.T) {
 	jsonSig := `0xb3baa751d0a9132cfe93e4e3d5ff9075111100e3789dca219ade5a24d27e19d16b3353149da1833e9b691bb38634e8dc04469be7032132906c927d7e1a49b414730612877bc6b2810c8f202daf793d1ab0d6b5cb21d52f9e52e883859887a5d9`
 	// create a new reader with that JSON
@@ -14,4 +14,23 @@ var (
 		},
 		[]string{"method", "status_code"},
 	)
+	signRequestRetriesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "remote_web3signer_internal_client_request_retries_total",
+		Help: "Total number of client HTTP requests retried after a network or server error",
+	})
+	requestFailuresTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "remote_web3signer_internal_client_request_failures_total",
+			Help: "Total number of failed client HTTP requests by reason of the failure",
+		},
+		[]string{"reason"},
+	)
+	signerFailoversTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "remote_web3signer_internal_client_failovers_total",
+		Help: "Total number of switches between the web3signer and the backup web3signer",
+	})
+	backupSignerActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "remote_web3signer_internal_client_backup_active",
+		Help: "1 if sign requests are sent to the backup web3signer, 0 otherwise",
+	})
 )
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/go-playground/validator/v10"
@@ -40,6 +42,15 @@ type SetupConfig struct {
 	// a static list of public keys to be passed by the user to determine what accounts should sign.
 	// This will provide a layer of safety against slashing if the web3signer is shared across validators.
 	ProvidedPublicKeys [][48]byte
+
+	// Timeout is the timeout of a single request to web3signer, no timeout if zero.
+	Timeout time.Duration
+	// MaxRetries is the number of times a sign request is retried after a network or server error.
+	MaxRetries uint64
+	// BackupEndpoint is the URL of a backup web3signer, switched to after FailoverThreshold consecutive sign
+	// requests failed due to a network or server error. No failover if empty.
+	BackupEndpoint    string
+	FailoverThreshold uint64
 }
 
 // Keymanager defines the web3signer keymanager.
@@ -62,6 +73,16 @@ func NewKeymanager(_ context.Context, cfg *SetupConfig) (*Keymanager, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create apiClient")
 	}
+	client.RestClient.Timeout = cfg.Timeout
+	client.MaxRetries = cfg.MaxRetries
+	if cfg.BackupEndpoint != "" {
+		backup, err := internal.NewApiClient(cfg.BackupEndpoint)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid backup web3signer url")
+		}
+		client.BackupURL = backup.BaseURL
+		client.FailoverThreshold = cfg.FailoverThreshold
+	}
 	return &Keymanager{
 		client:                internal.HttpSignerClient(client),
 		genesisValidatorsRoot: cfg.GenesisValidatorsRoot,
@@ -92,15 +113,31 @@ func (km *Keymanager) FetchValidatingPublicKeys(ctx context.Context) ([][fieldpa
 
 // Sign signs the message by using a remote web3signer server.
 func (km *Keymanager) Sign(ctx context.Context, request *validatorpb.SignRequest) (bls.Signature, error) {
+	requestType := signRequestType(request)
 	signRequest, err := getSignRequestJson(ctx, km.validator, request, km.genesisValidatorsRoot)
 	if err != nil {
 		erroredResponsesTotal.Inc()
+		signRequestErrorsTotal.WithLabelValues(requestType).Inc()
 		return nil, err
 	}
 
 	signRequestsTotal.Inc()
 
-	return km.client.Sign(ctx, hexutil.Encode(request.PublicKey), signRequest)
+	start := time.Now()
+	sig, err := km.client.Sign(ctx, hexutil.Encode(request.PublicKey), signRequest)
+	signRequestDurationSeconds.WithLabelValues(requestType).Observe(time.Since(start).Seconds())
+	if err != nil {
+		signRequestErrorsTotal.WithLabelValues(requestType).Inc()
+	}
+	return sig, err
+}
+
+// signRequestType returns the type of object to sign of the request, used to label metrics.
+func signRequestType(request *validatorpb.SignRequest) string {
+	if request == nil || request.Object == nil {
+		return "unknown"
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", request.Object), "*validatorpb.SignRequest_")
 }
 
 // getSignRequestJson returns a json request based on the SignRequest type.
@@ -202,6 +239,36 @@ func getSignRequestJson(ctx context.Context, validator *validator.Validate, requ
 		}
 		blindedBlockCapellaSignRequestsTotal.Inc()
 		return json.Marshal(blindedBlockv2CapellaSignRequest)
+	case *validatorpb.SignRequest_BlockDeneb:
+		blockv2DenebSignRequest, err := web3signerv1.GetBlockV2BlindedSignRequest(request, genesisValidatorsRoot)
+		if err != nil {
+			return nil, err
+		}
+		if err = validator.StructCtx(ctx, blockv2DenebSignRequest); err != nil {
+			return nil, err
+		}
+		blockDenebSignRequestsTotal.Inc()
+		return json.Marshal(blockv2DenebSignRequest)
+	case *validatorpb.SignRequest_BlindedBlockDeneb:
+		blindedBlockv2DenebSignRequest, err := web3signerv1.GetBlockV2BlindedSignRequest(request, genesisValidatorsRoot)
+		if err != nil {
+			return nil, err
+		}
+		if err = validator.StructCtx(ctx, blindedBlockv2DenebSignRequest); err != nil {
+			return nil, err
+		}
+		blindedBlockDenebSignRequestsTotal.Inc()
+		return json.Marshal(blindedBlockv2DenebSignRequest)
+	case *validatorpb.SignRequest_Blob, *validatorpb.SignRequest_BlindedBlob:
+		blobSidecarSignRequest, err := web3signerv1.GetBlobSidecarSignRequest(request, genesisValidatorsRoot)
+		if err != nil {
+			return nil, err
+		}
+		if err = validator.StructCtx(ctx, blobSidecarSignRequest); err != nil {
+			return nil, err
+		}
+		blobSidecarSignRequestsTotal.Inc()
+		return json.Marshal(blobSidecarSignRequest)
 	// We do not support "DEPOSIT" type.
 	/*
 		case *validatorpb.:
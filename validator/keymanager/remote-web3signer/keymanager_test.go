@@ -123,6 +123,38 @@ func TestKeymanager_Sign(t *testing.T) {
 			want:    desiredSig,
 			wantErr: false,
 		},
+		{
+			name: "BLOCK_V2_DENEB",
+			args: args{
+				request: mock.GetMockSignRequest("BLOCK_V2_DENEB"),
+			},
+			want:    desiredSig,
+			wantErr: false,
+		},
+		{
+			name: "BLOCK_V2_BLINDED_DENEB",
+			args: args{
+				request: mock.GetMockSignRequest("BLOCK_V2_BLINDED_DENEB"),
+			},
+			want:    desiredSig,
+			wantErr: false,
+		},
+		{
+			name: "BLOB_SIDECAR",
+			args: args{
+				request: mock.GetMockSignRequest("BLOB_SIDECAR"),
+			},
+			want:    desiredSig,
+			wantErr: false,
+		},
+		{
+			name: "BLINDED_BLOB_SIDECAR",
+			args: args{
+				request: mock.GetMockSignRequest("BLINDED_BLOB_SIDECAR"),
+			},
+			want:    desiredSig,
+			wantErr: false,
+		},
 		{
 			name: "RANDAO_REVEAL",
 			args: args{
@@ -50,6 +50,18 @@ var (
 		Name: "remote_web3signer_blinded_block_capella_sign_requests_total",
 		Help: "Total number of block capella sign requests",
 	})
+	blockDenebSignRequestsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "remote_web3signer_block_deneb_sign_requests_total",
+		Help: "Total number of block deneb sign requests",
+	})
+	blindedBlockDenebSignRequestsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "remote_web3signer_blinded_block_deneb_sign_requests_total",
+		Help: "Total number of blinded block deneb sign requests",
+	})
+	blobSidecarSignRequestsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "remote_web3signer_blob_sidecar_sign_requests_total",
+		Help: "Total number of blob sidecar sign requests",
+	})
 	randaoRevealSignRequestsTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "remote_web3signer_randao_reveal_sign_requests_total",
 		Help: "Total number of randao reveal sign requests",
@@ -74,4 +86,19 @@ var (
 		Name: "remote_web3signer_validator_registration_sign_requests_total",
 		Help: "Total number of validator registration sign requests",
 	})
+	signRequestDurationSeconds = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "remote_web3signer_sign_request_duration_seconds",
+			Help:    "Time (in seconds) spent signing with web3signer by type of signed object",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"type"},
+	)
+	signRequestErrorsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "remote_web3signer_sign_request_errors_total",
+			Help: "Total number of failed sign requests by type of signed object",
+		},
+		[]string{"type"},
+	)
 )
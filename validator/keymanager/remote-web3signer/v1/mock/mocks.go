@@ -363,6 +363,44 @@ func GetMockSignRequest(t string) *validatorpb.SignRequest {
 				BlindedBlockCapella: util.HydrateBlindedBeaconBlockCapella(&eth.BlindedBeaconBlockCapella{}),
 			},
 		}
+	case "BLOCK_V2_DENEB":
+		return &validatorpb.SignRequest{
+			PublicKey:       make([]byte, fieldparams.BLSPubkeyLength),
+			SigningRoot:     make([]byte, fieldparams.RootLength),
+			SignatureDomain: make([]byte, 4),
+			Object: &validatorpb.SignRequest_BlockDeneb{
+				BlockDeneb: util.HydrateBeaconBlockDeneb(&eth.BeaconBlockDeneb{}),
+			},
+		}
+	case "BLOCK_V2_BLINDED_DENEB":
+		return &validatorpb.SignRequest{
+			PublicKey:       make([]byte, fieldparams.BLSPubkeyLength),
+			SigningRoot:     make([]byte, fieldparams.RootLength),
+			SignatureDomain: make([]byte, 4),
+			Object: &validatorpb.SignRequest_BlindedBlockDeneb{
+				BlindedBlockDeneb: util.HydrateBlindedBeaconBlockDeneb(&eth.BlindedBeaconBlockDeneb{}),
+			},
+		}
+	case "BLOB_SIDECAR":
+		return &validatorpb.SignRequest{
+			PublicKey:       make([]byte, fieldparams.BLSPubkeyLength),
+			SigningRoot:     make([]byte, fieldparams.RootLength),
+			SignatureDomain: make([]byte, 4),
+			Object: &validatorpb.SignRequest_Blob{
+				Blob: util.HydrateBlobSidecar(&eth.BlobSidecar{}),
+			},
+			SigningSlot: 0,
+		}
+	case "BLINDED_BLOB_SIDECAR":
+		return &validatorpb.SignRequest{
+			PublicKey:       make([]byte, fieldparams.BLSPubkeyLength),
+			SigningRoot:     make([]byte, fieldparams.RootLength),
+			SignatureDomain: make([]byte, 4),
+			Object: &validatorpb.SignRequest_BlindedBlob{
+				BlindedBlob: util.HydrateBlindedBlobSidecar(&eth.BlindedBlobSidecar{}),
+			},
+			SigningSlot: 0,
+		}
 	case "RANDAO_REVEAL":
 		return &validatorpb.SignRequest{
 			PublicKey:       make([]byte, fieldparams.BLSPubkeyLength),
@@ -531,6 +569,25 @@ func MockBlockV2BlindedSignRequest(bodyRoot []byte, version string) *v1.BlockV2B
 	}
 }
 
+// MockBlobSidecarSignRequest is a mock implementation of the BlobSidecarSignRequest.
+func MockBlobSidecarSignRequest(blobRoot []byte) *v1.BlobSidecarSignRequest {
+	return &v1.BlobSidecarSignRequest{
+		Type:        "BLOB_SIDECAR",
+		ForkInfo:    MockForkInfo(),
+		SigningRoot: make([]byte, fieldparams.RootLength),
+		BlobSidecar: &v1.BlobSidecar{
+			BlockRoot:       make([]byte, fieldparams.RootLength),
+			Index:           "0",
+			Slot:            "0",
+			BlockParentRoot: make([]byte, fieldparams.RootLength),
+			ProposerIndex:   "0",
+			BlobRoot:        blobRoot,
+			KzgCommitment:   make([]byte, fieldparams.BLSPubkeyLength),
+			KzgProof:        make([]byte, fieldparams.BLSPubkeyLength),
+		},
+	}
+}
+
 // MockRandaoRevealSignRequest is a mock implementation of the RandaoRevealSignRequest.
 func MockRandaoRevealSignRequest() *v1.RandaoRevealSignRequest {
 	return &v1.RandaoRevealSignRequest{
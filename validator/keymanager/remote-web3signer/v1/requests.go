@@ -4,8 +4,10 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
 	validatorpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/validator-client"
 )
 
@@ -337,6 +339,34 @@ func GetBlockV2BlindedSignRequest(request *validatorpb.SignRequest, genesisValid
 			return nil, err
 		}
 		b = beaconBlock
+	case *validatorpb.SignRequest_BlockDeneb:
+		version = "DENEB"
+		blockDeneb, ok := request.Object.(*validatorpb.SignRequest_BlockDeneb)
+		if !ok {
+			return nil, errors.New("failed to cast request object to deneb block")
+		}
+		if blockDeneb == nil {
+			return nil, errors.New("invalid sign request: deneb block is nil")
+		}
+		beaconBlock, err := blocks.NewBeaconBlock(blockDeneb.BlockDeneb)
+		if err != nil {
+			return nil, err
+		}
+		b = beaconBlock
+	case *validatorpb.SignRequest_BlindedBlockDeneb:
+		version = "DENEB"
+		blindedBlockDeneb, ok := request.Object.(*validatorpb.SignRequest_BlindedBlockDeneb)
+		if !ok {
+			return nil, errors.New("failed to cast request object to blinded deneb block")
+		}
+		if blindedBlockDeneb == nil {
+			return nil, errors.New("invalid sign request: blinded deneb block is nil")
+		}
+		beaconBlock, err := blocks.NewBeaconBlock(blindedBlockDeneb.BlindedBlockDeneb)
+		if err != nil {
+			return nil, err
+		}
+		b = beaconBlock
 	default:
 		return nil, errors.New("invalid sign request - invalid object type")
 	}
@@ -386,3 +416,70 @@ func GetValidatorRegistrationSignRequest(request *validatorpb.SignRequest) (*Val
 		},
 	}, nil
 }
+
+// GetBlobSidecarSignRequest maps the request for signing type BLOB_SIDECAR. Blob sidecars are sent to web3signer
+// blinded, with the root of the blob in place of the blob itself.
+func GetBlobSidecarSignRequest(request *validatorpb.SignRequest, genesisValidatorsRoot []byte) (*BlobSidecarSignRequest, error) {
+	if request == nil {
+		return nil, errors.New("nil sign request provided")
+	}
+	var sidecar *BlobSidecar
+	switch object := request.Object.(type) {
+	case *validatorpb.SignRequest_Blob:
+		if object.Blob == nil {
+			return nil, errors.New("invalid sign request: blob sidecar is nil")
+		}
+		blobRoot, err := blobRoot(object.Blob.Blob)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute blob root")
+		}
+		sidecar = &BlobSidecar{
+			BlockRoot:       object.Blob.BlockRoot,
+			Index:           fmt.Sprint(object.Blob.Index),
+			Slot:            fmt.Sprint(object.Blob.Slot),
+			BlockParentRoot: object.Blob.BlockParentRoot,
+			ProposerIndex:   fmt.Sprint(object.Blob.ProposerIndex),
+			BlobRoot:        blobRoot[:],
+			KzgCommitment:   object.Blob.KzgCommitment,
+			KzgProof:        object.Blob.KzgProof,
+		}
+	case *validatorpb.SignRequest_BlindedBlob:
+		if object.BlindedBlob == nil {
+			return nil, errors.New("invalid sign request: blinded blob sidecar is nil")
+		}
+		sidecar = &BlobSidecar{
+			BlockRoot:       object.BlindedBlob.BlockRoot,
+			Index:           fmt.Sprint(object.BlindedBlob.Index),
+			Slot:            fmt.Sprint(object.BlindedBlob.Slot),
+			BlockParentRoot: object.BlindedBlob.BlockParentRoot,
+			ProposerIndex:   fmt.Sprint(object.BlindedBlob.ProposerIndex),
+			BlobRoot:        object.BlindedBlob.BlobRoot,
+			KzgCommitment:   object.BlindedBlob.KzgCommitment,
+			KzgProof:        object.BlindedBlob.KzgProof,
+		}
+	default:
+		return nil, errors.New("failed to cast request object to blob sidecar")
+	}
+	fork, err := MapForkInfo(request.SigningSlot, genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+	return &BlobSidecarSignRequest{
+		Type:        "BLOB_SIDECAR",
+		ForkInfo:    fork,
+		SigningRoot: request.SigningRoot,
+		BlobSidecar: sidecar,
+	}, nil
+}
+
+// blobRoot returns the hash tree root of the blob, as committed to by a blinded blob sidecar.
+func blobRoot(blob []byte) ([32]byte, error) {
+	if len(blob) != fieldparams.BlobLength {
+		return [32]byte{}, errors.Errorf("blob length %d is not %d", len(blob), fieldparams.BlobLength)
+	}
+	chunks, err := ssz.PackByChunk([][]byte{blob})
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return ssz.BitwiseMerkleize(chunks, uint64(len(chunks)), uint64(len(chunks)))
+}
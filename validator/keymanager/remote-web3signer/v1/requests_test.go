@@ -6,8 +6,10 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
 	v1 "github.com/prysmaticlabs/prysm/v4/validator/keymanager/remote-web3signer/v1"
 	"github.com/prysmaticlabs/prysm/v4/validator/keymanager/remote-web3signer/v1/mock"
 )
@@ -424,6 +426,32 @@ func TestGetBlockV2BlindedSignRequest(t *testing.T) {
 			}(t), "CAPELLA"),
 			wantErr: false,
 		},
+		{
+			name: "Happy Path Test non blinded Deneb",
+			args: args{
+				request:               mock.GetMockSignRequest("BLOCK_V2_DENEB"),
+				genesisValidatorsRoot: make([]byte, fieldparams.RootLength),
+			},
+			want: mock.MockBlockV2BlindedSignRequest(func(t *testing.T) []byte {
+				root, err := mock.GetMockSignRequest("BLOCK_V2_DENEB").GetBlockDeneb().Body.HashTreeRoot()
+				require.NoError(t, err)
+				return root[:]
+			}(t), "DENEB"),
+			wantErr: false,
+		},
+		{
+			name: "Happy Path Test blinded Deneb",
+			args: args{
+				request:               mock.GetMockSignRequest("BLOCK_V2_BLINDED_DENEB"),
+				genesisValidatorsRoot: make([]byte, fieldparams.RootLength),
+			},
+			want: mock.MockBlockV2BlindedSignRequest(func(t *testing.T) []byte {
+				root, err := mock.GetMockSignRequest("BLOCK_V2_BLINDED_DENEB").GetBlindedBlockDeneb().Body.HashTreeRoot()
+				require.NoError(t, err)
+				return root[:]
+			}(t), "DENEB"),
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -471,3 +499,85 @@ func TestGetValidatorRegistrationSignRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlobSidecarSignRequest(t *testing.T) {
+	type args struct {
+		request               *validatorpb.SignRequest
+		genesisValidatorsRoot []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *v1.BlobSidecarSignRequest
+		wantErr bool
+	}{
+		{
+			name: "Happy Path Test blinded blob sidecar",
+			args: args{
+				request:               mock.GetMockSignRequest("BLINDED_BLOB_SIDECAR"),
+				genesisValidatorsRoot: make([]byte, fieldparams.RootLength),
+			},
+			want:    mock.MockBlobSidecarSignRequest(make([]byte, fieldparams.RootLength)),
+			wantErr: false,
+		},
+		{
+			name: "Blob sidecar with invalid blob length",
+			args: args{
+				request: &validatorpb.SignRequest{
+					Object: &validatorpb.SignRequest_Blob{Blob: &ethpb.BlobSidecar{Blob: []byte{1}}},
+				},
+				genesisValidatorsRoot: make([]byte, fieldparams.RootLength),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid object type",
+			args: args{
+				request:               mock.GetMockSignRequest("VOLUNTARY_EXIT"),
+				genesisValidatorsRoot: make([]byte, fieldparams.RootLength),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v1.GetBlobSidecarSignRequest(tt.args.request, tt.args.genesisValidatorsRoot)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetBlobSidecarSignRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBlobSidecarSignRequest() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetBlobSidecarSignRequest_BlobRoot(t *testing.T) {
+	sidecar := util.HydrateBlobSidecar(&ethpb.BlobSidecar{Index: 1, Slot: 2, ProposerIndex: 3})
+	for i := range sidecar.Blob {
+		sidecar.Blob[i] = byte(i)
+	}
+	request := mock.GetMockSignRequest("BLOB_SIDECAR")
+	request.Object = &validatorpb.SignRequest_Blob{Blob: sidecar}
+	got, err := v1.GetBlobSidecarSignRequest(request, make([]byte, fieldparams.RootLength))
+	require.NoError(t, err)
+	require.Equal(t, "BLOB_SIDECAR", got.Type)
+
+	// The blob sidecar sent to web3signer is blinded, and must have the same root as the full blob sidecar.
+	blinded := &ethpb.BlindedBlobSidecar{
+		BlockRoot:       sidecar.BlockRoot,
+		Index:           sidecar.Index,
+		Slot:            sidecar.Slot,
+		BlockParentRoot: sidecar.BlockParentRoot,
+		ProposerIndex:   sidecar.ProposerIndex,
+		BlobRoot:        got.BlobSidecar.BlobRoot,
+		KzgCommitment:   sidecar.KzgCommitment,
+		KzgProof:        sidecar.KzgProof,
+	}
+	wantRoot, err := sidecar.HashTreeRoot()
+	require.NoError(t, err)
+	blindedRoot, err := blinded.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, wantRoot, blindedRoot)
+}
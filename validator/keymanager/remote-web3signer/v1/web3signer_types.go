@@ -47,7 +47,7 @@ type BlockAltairSignRequest struct {
 }
 
 // BlockV2BlindedSignRequest is a request object for web3signer sign api
-// Supports Bellatrix(merge) and Capella
+// Supports Bellatrix(merge), Capella and Deneb
 type BlockV2BlindedSignRequest struct {
 	Type        string                `json:"type" validate:"required"`
 	ForkInfo    *ForkInfo             `json:"fork_info" validate:"required"`
@@ -105,6 +105,14 @@ type ValidatorRegistrationSignRequest struct {
 	ValidatorRegistration *ValidatorRegistration `json:"validator_registration" validate:"required"`
 }
 
+// BlobSidecarSignRequest is a request object for web3signer sign api.
+type BlobSidecarSignRequest struct {
+	Type        string        `json:"type" validate:"required"`
+	ForkInfo    *ForkInfo     `json:"fork_info" validate:"required"`
+	SigningRoot hexutil.Bytes `json:"signingRoot"`
+	BlobSidecar *BlobSidecar  `json:"blob_sidecar" validate:"required"`
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // sub properties of Sign Requests /////////////////////////////////////////////
 ////////////////////////////////////////////////////////////////////////////////
@@ -277,7 +285,7 @@ type BeaconBlockBodyAltair struct {
 }
 
 // BeaconBlockV2Blinded a field of BlockV2BlindedSignRequest.
-// Supports Bellatrix(merge) and Capella
+// Supports Bellatrix(merge), Capella and Deneb
 type BeaconBlockV2Blinded struct {
 	Version     string             `json:"version" enum:"true"`
 	BlockHeader *BeaconBlockHeader `json:"block_header"`
@@ -329,6 +337,18 @@ type SyncCommitteeContribution struct {
 	Signature         hexutil.Bytes `json:"signature"`          /* 96 byte hexadecimal string */
 }
 
+// BlobSidecar a sub property of BlobSidecarSignRequest.
+type BlobSidecar struct {
+	BlockRoot       hexutil.Bytes `json:"block_root"`        /* 32 bytes */
+	Index           string        `json:"index"`             /* uint64 */
+	Slot            string        `json:"slot"`              /* uint64 */
+	BlockParentRoot hexutil.Bytes `json:"block_parent_root"` /* 32 bytes */
+	ProposerIndex   string        `json:"proposer_index"`    /* uint64 */
+	BlobRoot        hexutil.Bytes `json:"blob_root"`         /* 32 bytes */
+	KzgCommitment   hexutil.Bytes `json:"kzg_commitment"`    /* 48 bytes */
+	KzgProof        hexutil.Bytes `json:"kzg_proof"`         /* 48 bytes */
+}
+
 // ValidatorRegistration a sub property of ValidatorRegistrationSignRequest
 type ValidatorRegistration struct {
 	FeeRecipient hexutil.Bytes `json:"fee_recipient" validate:"required"` /* 42 hexadecimal string */
@@ -35,6 +35,7 @@ import (
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	validatorServiceConfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/validator"
 	"github.com/prysmaticlabs/prysm/v4/container/slice"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
@@ -49,7 +50,9 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/runtime/debug"
 	"github.com/prysmaticlabs/prysm/v4/runtime/prereqs"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	"github.com/prysmaticlabs/prysm/v4/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/v4/validator/alerting"
 	"github.com/prysmaticlabs/prysm/v4/validator/client"
 	"github.com/prysmaticlabs/prysm/v4/validator/db/iface"
 	"github.com/prysmaticlabs/prysm/v4/validator/db/kv"
@@ -65,6 +68,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
+// tracingShutdownTimeout bounds the time spent exporting the last trace spans when the client stops.
+const tracingShutdownTimeout = 5 * time.Second
+
 // ValidatorClient defines an instance of an Ethereum validator that manages
 // the entire lifecycle of services attached to it participating in proof of stake.
 type ValidatorClient struct {
@@ -88,6 +94,8 @@ func NewValidatorClient(cliCtx *cli.Context) (*ValidatorClient, error) {
 		cliCtx.String(cmd.TracingEndpointFlag.Name),
 		cliCtx.Float64(cmd.TraceSampleFractionFlag.Name),
 		cliCtx.Bool(cmd.EnableTracingFlag.Name),
+		cliCtx.String(cmd.TracingOTLPEndpointFlag.Name),
+		cliCtx.StringSlice(cmd.TracingOTLPHeadersFlag.Name),
 	); err != nil {
 		return nil, err
 	}
@@ -190,6 +198,11 @@ func (c *ValidatorClient) Close() {
 	defer c.lock.Unlock()
 
 	c.services.StopAll()
+	tracingCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	if err := tracing2.Shutdown(tracingCtx); err != nil {
+		log.WithError(err).Error("Failed to export the remaining trace spans")
+	}
+	cancel()
 	log.Info("Stopping Prysm validator")
 	c.cancel()
 	close(c.stop)
@@ -248,7 +261,8 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context) error {
 	log.WithField("databasePath", dataDir).Info("Checking DB")
 
 	valDB, err := kv.NewKVStore(cliCtx.Context, dataDir, &kv.Config{
-		PubKeys: nil,
+		PubKeys:       nil,
+		PruningEpochs: primitives.Epoch(cliCtx.Uint64(flags.SlashingProtectionPruningEpochsFlag.Name)),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize db")
@@ -267,10 +281,11 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context) error {
 		return err
 	}
 	if cliCtx.Bool(flags.EnableRPCFlag.Name) {
-		if err := c.registerRPCService(cliCtx); err != nil {
+		router := mux.NewRouter()
+		if err := c.registerRPCService(cliCtx, router); err != nil {
 			return err
 		}
-		if err := c.registerRPCGatewayService(cliCtx); err != nil {
+		if err := c.registerRPCGatewayService(cliCtx, router); err != nil {
 			return err
 		}
 	}
@@ -324,7 +339,8 @@ func (c *ValidatorClient) initializeForWeb(cliCtx *cli.Context) error {
 	}
 	log.WithField("databasePath", dataDir).Info("Checking DB")
 	valDB, err := kv.NewKVStore(cliCtx.Context, dataDir, &kv.Config{
-		PubKeys: nil,
+		PubKeys:       nil,
+		PruningEpochs: primitives.Epoch(cliCtx.Uint64(flags.SlashingProtectionPruningEpochsFlag.Name)),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize db")
@@ -342,10 +358,11 @@ func (c *ValidatorClient) initializeForWeb(cliCtx *cli.Context) error {
 	if err := c.registerValidatorService(cliCtx); err != nil {
 		return err
 	}
-	if err := c.registerRPCService(cliCtx); err != nil {
+	router := mux.NewRouter()
+	if err := c.registerRPCService(cliCtx, router); err != nil {
 		return err
 	}
-	if err := c.registerRPCGatewayService(cliCtx); err != nil {
+	if err := c.registerRPCGatewayService(cliCtx, router); err != nil {
 		return err
 	}
 	gatewayHost := cliCtx.String(flags.GRPCGatewayHost.Name)
@@ -404,6 +421,21 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 			log.WithError(err).Warn("Could not parse graffiti file")
 		}
 	}
+	var graffitiProvider *g.Provider
+	if c.cliCtx.IsSet(flags.GraffitiProviderURLFlag.Name) {
+		graffitiProvider, err = g.NewProvider(
+			c.cliCtx.String(flags.GraffitiProviderURLFlag.Name),
+			c.cliCtx.Duration(flags.GraffitiProviderTimeoutFlag.Name),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	alerter, err := newAlerter(c.cliCtx)
+	if err != nil {
+		return err
+	}
 
 	wsc, err := Web3SignerConfig(c.cliCtx)
 	if err != nil {
@@ -415,6 +447,16 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		return err
 	}
 
+	distributed := c.cliCtx.Bool(flags.DistributedFlag.Name)
+	attestationOffset, aggregationOffset, err := distributedOffsets(c.cliCtx)
+	if err != nil {
+		return err
+	}
+	keystoresWatchPassword, err := keystoresWatchPassword(c.cliCtx)
+	if err != nil {
+		return err
+	}
+
 	v, err := client.NewValidatorService(c.cliCtx.Context, &client.Config{
 		Endpoint:                   endpoint,
 		DataDir:                    dataDir,
@@ -432,10 +474,20 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		Wallet:                     c.wallet,
 		WalletInitializedFeed:      c.walletInitialized,
 		GraffitiStruct:             gStruct,
+		GraffitiProvider:           graffitiProvider,
 		Web3SignerConfig:           wsc,
 		ProposerSettings:           bpc,
 		BeaconApiTimeout:           time.Second * 30,
 		BeaconApiEndpoint:          c.cliCtx.String(flags.BeaconRESTApiProviderFlag.Name),
+		ValidatorsRegBatchSize:     c.cliCtx.Int(flags.ValidatorsRegistrationBatchSizeFlag.Name),
+		Distributed:                distributed,
+		AttestationOffset:          attestationOffset,
+		AggregationOffset:          aggregationOffset,
+		DoppelgangerEpochs:         c.cliCtx.Uint64(flags.DoppelgangerEpochsFlag.Name),
+		PerformanceLogFile:         c.cliCtx.String(flags.PerformanceLogFileFlag.Name),
+		Alerter:                    alerter,
+		KeystoresWatchDir:          c.cliCtx.String(flags.KeystoresWatchDirFlag.Name),
+		KeystoresWatchPassword:     keystoresWatchPassword,
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize validator service")
@@ -444,6 +496,71 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 	return c.services.RegisterService(v)
 }
 
+// newAlerter returns the alerter of missed duties, or nil when no webhook is configured.
+func newAlerter(cliCtx *cli.Context) (*alerting.Alerter, error) {
+	cfg := &alerting.Config{
+		WebhookURLs:           cliCtx.StringSlice(flags.AlertWebhookURLFlag.Name),
+		PagerDutyRoutingKey:   cliCtx.String(flags.AlertPagerDutyRoutingKeyFlag.Name),
+		MissedAttestations:    cliCtx.Uint64(flags.AlertMissedAttestationsFlag.Name),
+		BalanceDecreaseEpochs: cliCtx.Uint64(flags.AlertBalanceDecreaseEpochsFlag.Name),
+	}
+	if len(cfg.WebhookURLs) == 0 && cfg.PagerDutyRoutingKey == "" {
+		return nil, nil
+	}
+	return alerting.New(cfg)
+}
+
+// distributedOffsets returns the offsets the attestation and aggregation duties are delayed by in distributed
+// mode. Each offset must leave at least a third of the slot for the duty.
+func distributedOffsets(cliCtx *cli.Context) (attestation, aggregation time.Duration, err error) {
+	if !cliCtx.Bool(flags.DistributedFlag.Name) {
+		if cliCtx.IsSet(flags.DistributedAttestationOffsetFlag.Name) || cliCtx.IsSet(flags.DistributedAggregationOffsetFlag.Name) {
+			return 0, 0, fmt.Errorf("--%s and --%s require --%s", flags.DistributedAttestationOffsetFlag.Name,
+				flags.DistributedAggregationOffsetFlag.Name, flags.DistributedFlag.Name)
+		}
+		return 0, 0, nil
+	}
+	if !features.Get().EnableBeaconRESTApi {
+		return 0, 0, fmt.Errorf("--%s requires --%s", flags.DistributedFlag.Name, features.EnableBeaconRESTApi.Name)
+	}
+	if features.Get().EnableDoppelGanger {
+		log.Warn("Doppelganger protection is not run in distributed mode, as the other nodes of the cluster attest with the same keys")
+	}
+	if features.Get().RemoteSlasherProtection {
+		log.Warn("Remote slasher protection is not used in distributed mode, only the local slashing protection of the key share applies")
+	}
+	third := slots.DivideSlotBy(3 /* a third of the slot duration */)
+	attestation = cliCtx.Duration(flags.DistributedAttestationOffsetFlag.Name)
+	aggregation = cliCtx.Duration(flags.DistributedAggregationOffsetFlag.Name)
+	if attestation < 0 || attestation >= third {
+		return 0, 0, fmt.Errorf("--%s must be at least 0 and less than a third of the slot duration %s",
+			flags.DistributedAttestationOffsetFlag.Name, third)
+	}
+	if aggregation < 0 || aggregation >= third {
+		return 0, 0, fmt.Errorf("--%s must be at least 0 and less than a third of the slot duration %s",
+			flags.DistributedAggregationOffsetFlag.Name, third)
+	}
+	return attestation, aggregation, nil
+}
+
+// keystoresWatchPassword returns the password of the keystores in the watched keystores directory, if any.
+func keystoresWatchPassword(cliCtx *cli.Context) (string, error) {
+	if !cliCtx.IsSet(flags.KeystoresWatchDirFlag.Name) {
+		return "", nil
+	}
+	if cliCtx.IsSet(flags.Web3SignerURLFlag.Name) {
+		return "", fmt.Errorf("--%s is not supported with --%s", flags.KeystoresWatchDirFlag.Name, flags.Web3SignerURLFlag.Name)
+	}
+	if !cliCtx.IsSet(flags.KeystoresWatchPasswordFileFlag.Name) {
+		return "", fmt.Errorf("--%s requires --%s", flags.KeystoresWatchDirFlag.Name, flags.KeystoresWatchPasswordFileFlag.Name)
+	}
+	data, err := file.ReadFileAsBytes(cliCtx.String(flags.KeystoresWatchPasswordFileFlag.Name))
+	if err != nil {
+		return "", errors.Wrap(err, "could not read keystores password file")
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
 func Web3SignerConfig(cliCtx *cli.Context) (*remoteweb3signer.SetupConfig, error) {
 	var web3signerConfig *remoteweb3signer.SetupConfig
 	if cliCtx.IsSet(flags.Web3SignerURLFlag.Name) {
@@ -458,6 +575,20 @@ func Web3SignerConfig(cliCtx *cli.Context) (*remoteweb3signer.SetupConfig, error
 		web3signerConfig = &remoteweb3signer.SetupConfig{
 			BaseEndpoint:          u.String(),
 			GenesisValidatorsRoot: nil,
+			Timeout:               cliCtx.Duration(flags.Web3SignerTimeoutFlag.Name),
+			MaxRetries:            cliCtx.Uint64(flags.Web3SignerMaxRetriesFlag.Name),
+			FailoverThreshold:     cliCtx.Uint64(flags.Web3SignerFailoverThresholdFlag.Name),
+		}
+		if cliCtx.IsSet(flags.Web3SignerBackupURLFlag.Name) {
+			backupStr := cliCtx.String(flags.Web3SignerBackupURLFlag.Name)
+			backup, err := url.ParseRequestURI(backupStr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "web3signer backup url %s is invalid", backupStr)
+			}
+			if backup.Scheme == "" || backup.Host == "" {
+				return nil, fmt.Errorf("web3signer backup url must be in the format of http(s)://host:port url used: %v", backupStr)
+			}
+			web3signerConfig.BackupEndpoint = backup.String()
 		}
 		if cliCtx.IsSet(flags.WalletPasswordFileFlag.Name) {
 			log.Warnf("%s was provided while using web3signer and will be ignored", flags.WalletPasswordFileFlag.Name)
@@ -558,12 +689,16 @@ func proposerSettings(cliCtx *cli.Context, db iface.ValidatorDB) (*validatorServ
 		BuilderConfig: validatorServiceConfig.ToBuilderConfig(fileConfig.DefaultConfig.Builder),
 	}
 
+	if err := verifyRelays(vpSettings.DefaultConfig.BuilderConfig); err != nil {
+		return nil, err
+	}
+
 	if builderConfigFromFlag != nil {
 		config := builderConfigFromFlag.Clone()
 		if config.GasLimit == validator.Uint64(params.BeaconConfig().DefaultBuilderGasLimit) && vpSettings.DefaultConfig.BuilderConfig != nil {
 			config.GasLimit = vpSettings.DefaultConfig.BuilderConfig.GasLimit
 		}
-		vpSettings.DefaultConfig.BuilderConfig = config
+		vpSettings.DefaultConfig.BuilderConfig = keepRelays(config, vpSettings.DefaultConfig.BuilderConfig)
 	} else if vpSettings.DefaultConfig.BuilderConfig != nil {
 		vpSettings.DefaultConfig.BuilderConfig.GasLimit = reviewGasLimit(vpSettings.DefaultConfig.BuilderConfig.GasLimit)
 	}
@@ -589,12 +724,15 @@ func proposerSettings(cliCtx *cli.Context, db iface.ValidatorDB) (*validatorServ
 				return nil, err
 			}
 			currentBuilderConfig := validatorServiceConfig.ToBuilderConfig(option.Builder)
+			if err := verifyRelays(currentBuilderConfig); err != nil {
+				return nil, errors.Wrapf(err, "invalid builder config for proposer %s", key)
+			}
 			if builderConfigFromFlag != nil {
 				config := builderConfigFromFlag.Clone()
 				if config.GasLimit == validator.Uint64(params.BeaconConfig().DefaultBuilderGasLimit) && currentBuilderConfig != nil {
 					config.GasLimit = currentBuilderConfig.GasLimit
 				}
-				currentBuilderConfig = config
+				currentBuilderConfig = keepRelays(config, currentBuilderConfig)
 			} else if currentBuilderConfig != nil {
 				currentBuilderConfig.GasLimit = reviewGasLimit(currentBuilderConfig.GasLimit)
 			}
@@ -636,6 +774,23 @@ func verifyOption(key string, option *validatorpb.ProposerOptionPayload) error {
 	return nil
 }
 
+// verifyRelays checks that the relays of the builder config are http(s) URLs.
+func verifyRelays(config *validatorServiceConfig.BuilderConfig) error {
+	if config == nil {
+		return nil
+	}
+	for _, relay := range config.Relays {
+		u, err := url.ParseRequestURI(relay)
+		if err != nil {
+			return errors.Wrapf(err, "relay url %s is invalid", relay)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("relay url must be in the format of http(s)://host:port url used: %v", relay)
+		}
+	}
+	return nil
+}
+
 func handleNoProposerSettingsFlagsProvided(cliCtx *cli.Context,
 	db iface.ValidatorDB,
 	builderConfigFromFlag *validatorServiceConfig.BuilderConfig) (*validatorServiceConfig.ProposerSettings, error) {
@@ -671,12 +826,23 @@ func overrideBuilderSettings(settings *validatorServiceConfig.ProposerSettings,
 	}
 	if settings.ProposeConfig != nil {
 		for key := range settings.ProposeConfig {
-			settings.ProposeConfig[key].BuilderConfig = builderConfigFromFlag
+			settings.ProposeConfig[key].BuilderConfig = keepRelays(builderConfigFromFlag, settings.ProposeConfig[key].BuilderConfig)
 		}
 	}
 	if settings.DefaultConfig != nil {
-		settings.DefaultConfig.BuilderConfig = builderConfigFromFlag
+		settings.DefaultConfig.BuilderConfig = keepRelays(builderConfigFromFlag, settings.DefaultConfig.BuilderConfig)
+	}
+}
+
+// keepRelays returns a copy of the builder config with the relays of the existing builder config, so that the
+// relays set in the proposer settings survive the builder settings overrides of the flags.
+func keepRelays(config, existing *validatorServiceConfig.BuilderConfig) *validatorServiceConfig.BuilderConfig {
+	if config == nil || existing == nil || len(existing.Relays) == 0 {
+		return config
 	}
+	c := config.Clone()
+	c.Relays = existing.Relays
+	return c
 }
 
 func BuilderSettingsFromFlags(cliCtx *cli.Context) (*validatorServiceConfig.BuilderConfig, error) {
@@ -722,7 +888,7 @@ func reviewGasLimit(gasLimit validator.Uint64) validator.Uint64 {
 	return gasLimit
 }
 
-func (c *ValidatorClient) registerRPCService(cliCtx *cli.Context) error {
+func (c *ValidatorClient) registerRPCService(cliCtx *cli.Context, router *mux.Router) error {
 	var vs *client.ValidatorService
 	if err := c.services.FetchService(&vs); err != nil {
 		return err
@@ -762,11 +928,12 @@ func (c *ValidatorClient) registerRPCService(cliCtx *cli.Context) error {
 		ClientGrpcRetryDelay:     grpcRetryDelay,
 		ClientGrpcHeaders:        strings.Split(grpcHeaders, ","),
 		ClientWithCert:           clientCert,
+		Router:                   router,
 	})
 	return c.services.RegisterService(server)
 }
 
-func (c *ValidatorClient) registerRPCGatewayService(cliCtx *cli.Context) error {
+func (c *ValidatorClient) registerRPCGatewayService(cliCtx *cli.Context, router *mux.Router) error {
 	gatewayHost := cliCtx.String(flags.GRPCGatewayHost.Name)
 	if gatewayHost != flags.DefaultGatewayHost {
 		log.WithField("web-host", gatewayHost).Warn(
@@ -841,7 +1008,7 @@ func (c *ValidatorClient) registerRPCGatewayService(cliCtx *cli.Context) error {
 		Mux:           gwmux,
 	}
 	opts := []gateway.Option{
-		gateway.WithRouter(mux.NewRouter()),
+		gateway.WithRouter(router),
 		gateway.WithRemoteAddr(rpcAddr),
 		gateway.WithGatewayAddr(gatewayAddress),
 		gateway.WithMaxCallRecvMsgSize(maxCallSize),
@@ -9,10 +9,12 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/prysmaticlabs/prysm/v4/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
@@ -87,6 +89,7 @@ func TestWeb3SignerConfig(t *testing.T) {
 
 	type args struct {
 		baseURL          string
+		backupURL        string
 		publicKeysOrURLs []string
 	}
 	tests := []struct {
@@ -125,6 +128,31 @@ func TestWeb3SignerConfig(t *testing.T) {
 				ProvidedPublicKeys:    nil,
 			},
 		},
+		{
+			name: "happy path with backup url",
+			args: &args{
+				baseURL:          "http://localhost:8545",
+				backupURL:        "http://localhost:8546",
+				publicKeysOrURLs: []string{"http://localhost:8545/api/v1/eth2/publicKeys"},
+			},
+			want: &remoteweb3signer.SetupConfig{
+				BaseEndpoint:          "http://localhost:8545",
+				GenesisValidatorsRoot: nil,
+				PublicKeysURL:         "http://localhost:8545/api/v1/eth2/publicKeys",
+				ProvidedPublicKeys:    nil,
+				BackupEndpoint:        "http://localhost:8546",
+			},
+		},
+		{
+			name: "Backup URL missing scheme or host",
+			args: &args{
+				baseURL:          "http://localhost:8545",
+				backupURL:        "localhost:8546",
+				publicKeysOrURLs: []string{"http://localhost:8545/api/v1/eth2/publicKeys"},
+			},
+			want:       nil,
+			wantErrMsg: "web3signer backup url must be in the format of http(s)://host:port url used: localhost:8546",
+		},
 		{
 			name: "Bad base URL",
 			args: &args{
@@ -194,6 +222,10 @@ func TestWeb3SignerConfig(t *testing.T) {
 			err := c.Apply(set)
 			require.NoError(t, err)
 			require.NoError(t, set.Set(flags.Web3SignerURLFlag.Name, tt.args.baseURL))
+			if tt.args.backupURL != "" {
+				set.String(flags.Web3SignerBackupURLFlag.Name, tt.args.backupURL, "")
+				require.NoError(t, set.Set(flags.Web3SignerBackupURLFlag.Name, tt.args.backupURL))
+			}
 			for _, key := range tt.args.publicKeysOrURLs {
 				require.NoError(t, set.Set(flags.Web3SignerPublicValidatorKeysFlag.Name, key))
 			}
@@ -208,6 +240,75 @@ func TestWeb3SignerConfig(t *testing.T) {
 	}
 }
 
+func TestDistributedOffsets(t *testing.T) {
+	tests := []struct {
+		name        string
+		distributed bool
+		restApi     bool
+		attestation string
+		aggregation string
+		wantAtt     time.Duration
+		wantAgg     time.Duration
+		wantErrMsg  string
+	}{
+		{
+			name: "not distributed",
+		},
+		{
+			name:        "offset without distributed",
+			attestation: "1s",
+			wantErrMsg:  "require --distributed",
+		},
+		{
+			name:        "distributed without REST API",
+			distributed: true,
+			wantErrMsg:  "--distributed requires --enable-beacon-rest-api",
+		},
+		{
+			name:        "distributed with offsets",
+			distributed: true,
+			restApi:     true,
+			attestation: "500ms",
+			aggregation: "1s",
+			wantAtt:     500 * time.Millisecond,
+			wantAgg:     time.Second,
+		},
+		{
+			name:        "offset too large",
+			distributed: true,
+			restApi:     true,
+			aggregation: "1h",
+			wantErrMsg:  "--distributed-aggregation-offset must be at least 0 and less than a third of the slot duration",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetCfg := features.InitWithReset(&features.Flags{EnableBeaconRESTApi: tt.restApi})
+			defer resetCfg()
+			app := cli.App{}
+			set := flag.NewFlagSet(tt.name, 0)
+			set.Bool(flags.DistributedFlag.Name, tt.distributed, "")
+			set.Duration(flags.DistributedAttestationOffsetFlag.Name, 0, "")
+			set.Duration(flags.DistributedAggregationOffsetFlag.Name, 0, "")
+			if tt.attestation != "" {
+				require.NoError(t, set.Set(flags.DistributedAttestationOffsetFlag.Name, tt.attestation))
+			}
+			if tt.aggregation != "" {
+				require.NoError(t, set.Set(flags.DistributedAggregationOffsetFlag.Name, tt.aggregation))
+			}
+			cliCtx := cli.NewContext(&app, set, nil)
+			att, agg, err := distributedOffsets(cliCtx)
+			if tt.wantErrMsg != "" {
+				require.ErrorContains(t, tt.wantErrMsg, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAtt, att)
+			assert.Equal(t, tt.wantAgg, agg)
+		})
+	}
+}
+
 func TestProposerSettings(t *testing.T) {
 	hook := logtest.NewGlobal()
 
@@ -848,6 +949,59 @@ func TestProposerSettings(t *testing.T) {
 			},
 			wantErr: "failed to unmarshal yaml file",
 		},
+		{
+			name: "Happy Path Config file with relays and builder flag",
+			args: args{
+				proposerSettingsFlagValues: &proposerSettingsFlag{
+					dir:        "./testdata/good-prepare-beacon-proposer-config-relays.json",
+					url:        "",
+					defaultfee: "",
+				},
+			},
+			want: func() *validatorserviceconfig.ProposerSettings {
+				key1, err := hexutil.Decode("0xa057816155ad77931185101128655c0191bd0214c201ca48ed887f6c4c6adf334070efcd75140eada5ac83a92506dd7a")
+				require.NoError(t, err)
+				return &validatorserviceconfig.ProposerSettings{
+					ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*validatorserviceconfig.ProposerOption{
+						bytesutil.ToBytes48(key1): {
+							FeeRecipientConfig: &validatorserviceconfig.FeeRecipientConfig{
+								FeeRecipient: common.HexToAddress("0x50155530FCE8a85ec7055A5F8b2bE214B3DaeFd3"),
+							},
+							BuilderConfig: &validatorserviceconfig.BuilderConfig{
+								Enabled:  true,
+								GasLimit: validator.Uint64(35000000),
+								Relays:   []string{"https://relay-a.example.com"},
+							},
+						},
+					},
+					DefaultConfig: &validatorserviceconfig.ProposerOption{
+						FeeRecipientConfig: &validatorserviceconfig.FeeRecipientConfig{
+							FeeRecipient: common.HexToAddress("0x6e35733c5af9B61374A128e6F85f553aF09ff89A"),
+						},
+						BuilderConfig: &validatorserviceconfig.BuilderConfig{
+							Enabled:  true,
+							GasLimit: validator.Uint64(40000000),
+							Relays:   []string{"https://relay-a.example.com", "https://relay-b.example.com"},
+						},
+					},
+				}
+			},
+			validatorRegistrationEnabled: true,
+		},
+		{
+			name: "Bad relay url",
+			args: args{
+				proposerSettingsFlagValues: &proposerSettingsFlag{
+					dir:        "./testdata/bad-relay-proposer-settings.json",
+					url:        "",
+					defaultfee: "",
+				},
+			},
+			want: func() *validatorserviceconfig.ProposerSettings {
+				return nil
+			},
+			wantErr: "relay url",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
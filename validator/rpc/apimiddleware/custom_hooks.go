@@ -7,12 +7,16 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api"
 	"github.com/prysmaticlabs/prysm/v4/api/gateway/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/api/grpc"
 )
 
-// "/eth/v1/validator/{pubkey}/voluntary_exit" POST expects epoch as a query param.
-// This hook adds the query param to the body so that it is a valid POST request as
-// grpc-gateway does not handle query params in POST requests.
+// "/eth/v1/validator/{pubkey}/voluntary_exit" POST expects epoch and broadcast as query params.
+// This hook adds the epoch to the body so that it is a valid POST request as
+// grpc-gateway does not handle query params in POST requests, and passes broadcast
+// on as gRPC metadata.
 func setVoluntaryExitEpoch(
 	endpoint *apimiddleware.Endpoint,
 	_ http.ResponseWriter,
@@ -26,7 +30,10 @@ func setVoluntaryExitEpoch(
 		}
 		_, err := strconv.ParseUint(epoch, 10, 64)
 		if err != nil {
-			return false, apimiddleware.InternalServerErrorWithMessage(err, "invalid epoch")
+			return false, &apimiddleware.DefaultErrorJson{
+				Message: errors.Wrap(err, "invalid epoch").Error(),
+				Code:    http.StatusBadRequest,
+			}
 		}
 		j := &SetVoluntaryExitRequestJson{Epoch: epoch}
 		b, err := json.Marshal(j)
@@ -34,6 +41,18 @@ func setVoluntaryExitEpoch(
 			return false, apimiddleware.InternalServerErrorWithMessage(err, "could not marshal epoch")
 		}
 		req.Body = io.NopCloser(bytes.NewReader(b))
+		if broadcast := req.URL.Query().Get("broadcast"); broadcast != "" {
+			ok, err := strconv.ParseBool(broadcast)
+			if err != nil {
+				return false, &apimiddleware.DefaultErrorJson{
+					Message: errors.Wrap(err, "invalid broadcast").Error(),
+					Code:    http.StatusBadRequest,
+				}
+			}
+			if ok {
+				req.Header.Set(grpc.WithPrefix(api.BroadcastExitHeader), "true")
+			}
+		}
 	}
 	return true, nil
 }
@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api"
 	"github.com/prysmaticlabs/prysm/v4/api/gateway/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/api/grpc"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 )
@@ -31,6 +34,33 @@ func TestSetVoluntaryExitEpoch(t *testing.T) {
 		err := json.NewDecoder(request.Body).Decode(&b)
 		require.NoError(t, err)
 		require.Equal(t, epoch, b.Epoch)
+		assert.Equal(t, "", request.Header.Get(grpc.WithPrefix(api.BroadcastExitHeader)))
+	})
+	t.Run("broadcast", func(t *testing.T) {
+		endpoint := &apimiddleware.Endpoint{
+			PostRequest: &SetVoluntaryExitRequestJson{},
+		}
+		var body bytes.Buffer
+		request := httptest.NewRequest("POST", "http://foo.example?epoch=300&broadcast=true", &body)
+
+		runDefault, errJson := setVoluntaryExitEpoch(endpoint, nil, request)
+		require.Equal(t, true, errJson == nil)
+		assert.Equal(t, apimiddleware.RunDefault(true), runDefault)
+		assert.Equal(t, "true", request.Header.Get(grpc.WithPrefix(api.BroadcastExitHeader)))
+	})
+	t.Run("invalid broadcast returns error", func(t *testing.T) {
+		endpoint := &apimiddleware.Endpoint{
+			PostRequest: &SetVoluntaryExitRequestJson{},
+		}
+		var body bytes.Buffer
+		request := httptest.NewRequest("POST", "http://foo.example?epoch=300&broadcast=foo", &body)
+
+		runDefault, errJson := setVoluntaryExitEpoch(endpoint, nil, request)
+		assert.NotNil(t, errJson)
+		assert.Equal(t, apimiddleware.RunDefault(false), runDefault)
+		err := errors.New(errJson.Msg())
+		assert.ErrorContains(t, "invalid broadcast", err)
+		assert.Equal(t, http.StatusBadRequest, errJson.StatusCode())
 	})
 	t.Run("invalid query returns error", func(t *testing.T) {
 		endpoint := &apimiddleware.Endpoint{
@@ -45,5 +75,6 @@ func TestSetVoluntaryExitEpoch(t *testing.T) {
 		assert.Equal(t, apimiddleware.RunDefault(false), runDefault)
 		err := errors.New(errJson.Msg())
 		assert.ErrorContains(t, "invalid epoch", err)
+		assert.Equal(t, http.StatusBadRequest, errJson.StatusCode())
 	})
 }
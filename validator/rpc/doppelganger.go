@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"go.opencensus.io/trace"
+)
+
+// DoppelgangerStatusesResponse is the response of GetDoppelgangerStatuses.
+type DoppelgangerStatusesResponse struct {
+	Data []*DoppelgangerStatus `json:"data"`
+}
+
+// DoppelgangerStatus is the status of the doppelganger check of a validator key. The status is one of pending,
+// cleared and detected, and only a cleared key performs duties.
+type DoppelgangerStatus struct {
+	Pubkey string `json:"pubkey"`
+	Status string `json:"status"`
+}
+
+// GetDoppelgangerStatuses returns the status of the doppelganger check of each validating key, telling whether the
+// key is cleared to perform duties.
+func (s *Server) GetDoppelgangerStatuses(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetDoppelgangerStatuses")
+	defer span.End()
+
+	if err := s.authorizeRequest(r); err != nil {
+		http2.HandleError(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if s.validatorService == nil {
+		http2.HandleError(w, "Validator service not ready", http.StatusServiceUnavailable)
+		return
+	}
+	statuses, err := s.validatorService.DoppelgangerStatuses(ctx)
+	if err != nil {
+		http2.HandleError(w, "Could not get doppelganger statuses: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*DoppelgangerStatus, 0, len(statuses))
+	for pubkey, status := range statuses {
+		copiedKey := pubkey
+		data = append(data, &DoppelgangerStatus{
+			Pubkey: hexutil.Encode(copiedKey[:]),
+			Status: status.String(),
+		})
+	}
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].Pubkey < data[j].Pubkey
+	})
+	http2.WriteJson(w, &DoppelgangerStatusesResponse{Data: data})
+}
@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	mock "github.com/prysmaticlabs/prysm/v4/validator/accounts/testing"
+	"github.com/prysmaticlabs/prysm/v4/validator/client"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+)
+
+func TestServer_GetDoppelgangerStatuses(t *testing.T) {
+	ctx := context.Background()
+	vs, err := client.NewValidatorService(ctx, &client.Config{
+		Validator: &mock.MockValidator{DoppelgangerStatusesMap: map[[fieldparams.BLSPubkeyLength]byte]iface.DoppelgangerStatus{
+			{1}: iface.DoppelgangerCleared,
+			{2}: iface.DoppelgangerPending,
+			{3}: iface.DoppelgangerDetected,
+		}},
+	})
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	s := NewServer(ctx, &Config{ValidatorService: vs, Router: router})
+	s.jwtSecret = []byte("testKey")
+	token, err := createTokenString(s.jwtSecret)
+	require.NoError(t, err)
+
+	request := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/eth/v1/validator/doppelganger", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, req)
+		return writer
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		writer := request(token)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &DoppelgangerStatusesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		pubkey := func(b byte) string {
+			var k [fieldparams.BLSPubkeyLength]byte
+			k[0] = b
+			return hexutil.Encode(k[:])
+		}
+		assert.DeepEqual(t, []*DoppelgangerStatus{
+			{Pubkey: pubkey(1), Status: "cleared"},
+			{Pubkey: pubkey(2), Status: "pending"},
+			{Pubkey: pubkey(3), Status: "detected"},
+		}, resp.Data)
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		writer := request("")
+		assert.Equal(t, http.StatusUnauthorized, writer.Code)
+	})
+}
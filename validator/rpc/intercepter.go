@@ -3,9 +3,11 @@ package rpc
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -61,3 +63,16 @@ func (s *Server) validateJWT(token *jwt.Token) (interface{}, error) {
 	}
 	return s.jwtSecret, nil
 }
+
+// authorizeRequest authorizes a request served by an HTTP handler rather than by the gRPC server, with the same
+// bearer token as the gRPC requests.
+func (s *Server) authorizeRequest(r *http.Request) error {
+	authHeader := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authHeader, "Bearer ") {
+		return errors.New("invalid auth header, needs Bearer {token}")
+	}
+	if _, err := jwt.Parse(strings.TrimPrefix(authHeader, "Bearer "), s.validateJWT); err != nil {
+		return errors.Wrap(err, "could not parse JWT token")
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	validatorpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/validator-client"
+	"go.opencensus.io/trace"
+)
+
+// maxOwnershipProofMessageLength is the largest message which can be signed as a proof of ownership.
+const maxOwnershipProofMessageLength = 1024
+
+// SignOwnershipProofRequest is the request body of SignOwnershipProof.
+type SignOwnershipProofRequest struct {
+	Message string `json:"message"`
+}
+
+// SignOwnershipProofResponse is the response of SignOwnershipProof.
+type SignOwnershipProofResponse struct {
+	Data *OwnershipProof `json:"data"`
+}
+
+// OwnershipProof is a signature of an operator chosen message proving the control of a validator key.
+type OwnershipProof struct {
+	Pubkey    string `json:"pubkey"`
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+}
+
+// SignOwnershipProof signs an operator chosen message with the key of a validator, for instance a challenge from a
+// staking marketplace which needs to verify that the operator controls the key. The message is signed in the
+// ownership proof domain, which no consensus object is signed in, so the signature can safely be shared and can
+// never be used as the signature of a block, an attestation or any other consensus object.
+func (s *Server) SignOwnershipProof(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.SignOwnershipProof")
+	defer span.End()
+
+	if err := s.authorizeRequest(r); err != nil {
+		http2.HandleError(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if s.validatorService == nil {
+		http2.HandleError(w, "Validator service not ready", http.StatusServiceUnavailable)
+		return
+	}
+	if s.wallet == nil {
+		http2.HandleError(w, "No wallet found", http.StatusBadRequest)
+		return
+	}
+	pubkey, err := hexutil.Decode(mux.Vars(r)["pubkey"])
+	if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+		http2.HandleError(w, "Invalid public key in path", http.StatusBadRequest)
+		return
+	}
+	var req SignOwnershipProofRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http2.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	message, err := hexutil.Decode(req.Message)
+	if err != nil {
+		http2.HandleError(w, "Invalid message: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(message) == 0 || len(message) > maxOwnershipProofMessageLength {
+		http2.HandleError(w, "Message must not be empty nor exceed 1024 bytes", http.StatusBadRequest)
+		return
+	}
+
+	km, err := s.validatorService.Keymanager()
+	if err != nil {
+		http2.HandleError(w, "Could not get keymanager: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	pubkeys, err := km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		http2.HandleError(w, "Could not fetch validating public keys: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !containsPubkey(pubkeys, bytesutil.ToBytes48(pubkey)) {
+		http2.HandleError(w, "Validator public key not found", http.StatusNotFound)
+		return
+	}
+	root, domain, err := signing.OwnershipProofSigningRoot(message)
+	if err != nil {
+		http2.HandleError(w, "Could not compute signing root: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sig, err := km.Sign(ctx, &validatorpb.SignRequest{
+		PublicKey:       pubkey,
+		SigningRoot:     root[:],
+		SignatureDomain: domain,
+	})
+	if err != nil {
+		http2.HandleError(w, "Could not sign ownership proof: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http2.WriteJson(w, &SignOwnershipProofResponse{
+		Data: &OwnershipProof{
+			Pubkey:    hexutil.Encode(pubkey),
+			Message:   hexutil.Encode(message),
+			Signature: hexutil.Encode(sig.Marshal()),
+		},
+	})
+}
+
+func containsPubkey(pubkeys [][fieldparams.BLSPubkeyLength]byte, pubkey [fieldparams.BLSPubkeyLength]byte) bool {
+	for _, k := range pubkeys {
+		if k == pubkey {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/accounts"
+	"github.com/prysmaticlabs/prysm/v4/validator/accounts/iface"
+	mock "github.com/prysmaticlabs/prysm/v4/validator/accounts/testing"
+	"github.com/prysmaticlabs/prysm/v4/validator/client"
+	"github.com/prysmaticlabs/prysm/v4/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v4/validator/keymanager/derived"
+	mocks "github.com/prysmaticlabs/prysm/v4/validator/testing"
+)
+
+func TestServer_SignOwnershipProof(t *testing.T) {
+	ctx := context.Background()
+	defaultWalletPath = setupWalletDir(t)
+	acc, err := accounts.NewCLIManager(
+		accounts.WithWalletDir(defaultWalletPath),
+		accounts.WithKeymanagerType(keymanager.Derived),
+		accounts.WithWalletPassword(strongPass),
+		accounts.WithSkipMnemonicConfirm(true),
+	)
+	require.NoError(t, err)
+	w, err := acc.WalletCreate(ctx)
+	require.NoError(t, err)
+	km, err := w.InitializeKeymanager(ctx, iface.InitKeymanagerConfig{ListenForChanges: false})
+	require.NoError(t, err)
+	dr, ok := km.(*derived.Keymanager)
+	require.Equal(t, true, ok)
+	require.NoError(t, dr.RecoverAccountsFromMnemonic(ctx, mocks.TestMnemonic, derived.DefaultMnemonicLanguage, "", 1))
+	pubKeys, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	vs, err := client.NewValidatorService(ctx, &client.Config{
+		Validator: &mock.MockValidator{Km: km},
+	})
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	s := NewServer(ctx, &Config{ValidatorService: vs, Wallet: w, Router: router})
+	s.jwtSecret = []byte("testKey")
+	token, err := createTokenString(s.jwtSecret)
+	require.NoError(t, err)
+
+	request := func(pubkey string, body string, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/eth/v1/validator/"+pubkey+"/ownership_proof", bytes.NewBufferString(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, req)
+		return writer
+	}
+	pubkey := hexutil.Encode(pubKeys[0][:])
+	message := []byte("marketplace challenge")
+
+	t.Run("ok", func(t *testing.T) {
+		writer := request(pubkey, `{"message":"`+hexutil.Encode(message)+`"}`, token)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &SignOwnershipProofResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, pubkey, resp.Data.Pubkey)
+		assert.Equal(t, hexutil.Encode(message), resp.Data.Message)
+		sig, err := hexutil.Decode(resp.Data.Signature)
+		require.NoError(t, err)
+		require.NoError(t, signing.VerifyOwnershipProof(pubKeys[0][:], message, sig))
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		badToken, err := createTokenString([]byte("otherKey"))
+		require.NoError(t, err)
+		for _, tok := range []string{"", badToken} {
+			writer := request(pubkey, `{"message":"`+hexutil.Encode(message)+`"}`, tok)
+			assert.Equal(t, http.StatusUnauthorized, writer.Code)
+		}
+	})
+	t.Run("unknown public key", func(t *testing.T) {
+		writer := request(hexutil.Encode(make([]byte, 48)), `{"message":"`+hexutil.Encode(message)+`"}`, token)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("invalid requests", func(t *testing.T) {
+		tests := []struct {
+			pubkey string
+			body   string
+			want   string
+		}{
+			{pubkey: "0x1234", body: `{"message":"0x12"}`, want: "Invalid public key"},
+			{pubkey: pubkey, body: `{"message":"foo"}`, want: "Invalid message"},
+			{pubkey: pubkey, body: `{"message":""}`, want: "Invalid message"},
+			{pubkey: pubkey, body: `{"message":"0x"}`, want: "must not be empty"},
+			{pubkey: pubkey, body: `{"message":"` + hexutil.Encode(make([]byte, maxOwnershipProofMessageLength+1)) + `"}`, want: "must not be empty"},
+		}
+		for _, tt := range tests {
+			writer := request(tt.pubkey, tt.body, token)
+			require.Equal(t, http.StatusBadRequest, writer.Code)
+			e := &http2.DefaultErrorJson{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			assert.StringContains(t, tt.want, e.Message)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package rpc
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	"go.opencensus.io/trace"
+)
+
+// KeysPerformanceResponse is the response of GetKeysPerformance.
+type KeysPerformanceResponse struct {
+	Data []*EpochPerformance `json:"data"`
+}
+
+// EpochPerformance is the performance of a validator key during an epoch. The attestation is null until the beacon
+// node reports the performance of the epoch, at the end of the next epoch.
+type EpochPerformance struct {
+	Pubkey        string                    `json:"pubkey"`
+	Epoch         string                    `json:"epoch"`
+	Attestation   *AttestationPerformance   `json:"attestation"`
+	Proposals     []*ProposalPerformance    `json:"proposals"`
+	SyncCommittee *SyncCommitteePerformance `json:"sync_committee"`
+}
+
+// AttestationPerformance is the performance of the attestation of a validator key during an epoch. The inclusion
+// distance is only reported before Altair.
+type AttestationPerformance struct {
+	InclusionDistance    string `json:"inclusion_distance"`
+	CorrectlyVotedSource bool   `json:"correctly_voted_source"`
+	CorrectlyVotedTarget bool   `json:"correctly_voted_target"`
+	CorrectlyVotedHead   bool   `json:"correctly_voted_head"`
+	BalanceBefore        string `json:"balance_before"`
+	BalanceAfter         string `json:"balance_after"`
+	InactivityScore      string `json:"inactivity_score"`
+}
+
+// ProposalPerformance is the outcome of a block proposal of a validator key.
+type ProposalPerformance struct {
+	Slot      string `json:"slot"`
+	Proposed  bool   `json:"proposed"`
+	BlockRoot string `json:"block_root,omitempty"`
+}
+
+// SyncCommitteePerformance counts the sync committee messages of a validator key submitted during an epoch, and
+// those which could not be submitted.
+type SyncCommitteePerformance struct {
+	Submitted string `json:"submitted"`
+	Failed    string `json:"failed"`
+}
+
+// GetKeysPerformance returns the performance of the validating keys during the last epochs, for operators
+// without an external monitoring stack. The performance can be limited to an epoch with the epoch query parameter,
+// and to some keys with the pubkey query parameter.
+func (s *Server) GetKeysPerformance(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "validator.GetKeysPerformance")
+	defer span.End()
+
+	if err := s.authorizeRequest(r); err != nil {
+		http2.HandleError(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if s.validatorService == nil {
+		http2.HandleError(w, "Validator service not ready", http.StatusServiceUnavailable)
+		return
+	}
+	query := r.URL.Query()
+	var epoch *uint64
+	if rawEpoch := query.Get("epoch"); rawEpoch != "" {
+		e, err := strconv.ParseUint(rawEpoch, 10, 64)
+		if err != nil {
+			http2.HandleError(w, "Invalid epoch: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		epoch = &e
+	}
+	pubkeys, err := decodePubkeys(query["pubkey"])
+	if err != nil {
+		http2.HandleError(w, "Invalid public key: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	filteredKeys := make(map[string]bool, len(pubkeys))
+	for _, k := range pubkeys {
+		filteredKeys[string(k)] = true
+	}
+
+	performance, err := s.validatorService.Performance()
+	if err != nil {
+		http2.HandleError(w, "Could not get validator performance: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*EpochPerformance, 0, len(performance))
+	for _, p := range performance {
+		if epoch != nil && uint64(p.Epoch) != *epoch {
+			continue
+		}
+		if len(filteredKeys) > 0 && !filteredKeys[string(p.PublicKey[:])] {
+			continue
+		}
+		item := &EpochPerformance{
+			Pubkey:    hexutil.Encode(p.PublicKey[:]),
+			Epoch:     strconv.FormatUint(uint64(p.Epoch), 10),
+			Proposals: make([]*ProposalPerformance, len(p.Proposals)),
+			SyncCommittee: &SyncCommitteePerformance{
+				Submitted: strconv.FormatUint(p.SyncCommitteeMessages, 10),
+				Failed:    strconv.FormatUint(p.SyncCommitteeMessagesFailed, 10),
+			},
+		}
+		if att := p.Attestation; att != nil {
+			item.Attestation = &AttestationPerformance{
+				InclusionDistance:    strconv.FormatUint(uint64(att.InclusionDistance), 10),
+				CorrectlyVotedSource: att.CorrectlyVotedSource,
+				CorrectlyVotedTarget: att.CorrectlyVotedTarget,
+				CorrectlyVotedHead:   att.CorrectlyVotedHead,
+				BalanceBefore:        strconv.FormatUint(att.BalanceBefore, 10),
+				BalanceAfter:         strconv.FormatUint(att.BalanceAfter, 10),
+				InactivityScore:      strconv.FormatUint(att.InactivityScore, 10),
+			}
+		}
+		for i, proposal := range p.Proposals {
+			item.Proposals[i] = &ProposalPerformance{
+				Slot:     strconv.FormatUint(uint64(proposal.Slot), 10),
+				Proposed: proposal.Proposed,
+			}
+			if proposal.Proposed {
+				item.Proposals[i].BlockRoot = hexutil.Encode(proposal.BlockRoot)
+			}
+		}
+		data = append(data, item)
+	}
+	http2.WriteJson(w, &KeysPerformanceResponse{Data: data})
+}
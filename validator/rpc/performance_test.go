@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	mock "github.com/prysmaticlabs/prysm/v4/validator/accounts/testing"
+	"github.com/prysmaticlabs/prysm/v4/validator/client"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+)
+
+func TestServer_GetKeysPerformance(t *testing.T) {
+	ctx := context.Background()
+	key1 := [fieldparams.BLSPubkeyLength]byte{1}
+	key2 := [fieldparams.BLSPubkeyLength]byte{2}
+	vs, err := client.NewValidatorService(ctx, &client.Config{
+		Validator: &mock.MockValidator{EpochPerformances: []*iface.EpochPerformance{
+			{
+				PublicKey: key1,
+				Epoch:     9,
+				Attestation: &iface.AttestationPerformance{
+					CorrectlyVotedSource: true,
+					BalanceBefore:        32,
+					BalanceAfter:         33,
+				},
+			},
+			{
+				PublicKey:                   key2,
+				Epoch:                       10,
+				Proposals:                   []*iface.ProposalPerformance{{Slot: 321, Proposed: true, BlockRoot: []byte{3}}, {Slot: 325}},
+				SyncCommitteeMessages:       2,
+				SyncCommitteeMessagesFailed: 1,
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	s := NewServer(ctx, &Config{ValidatorService: vs, Router: router})
+	s.jwtSecret = []byte("testKey")
+	token, err := createTokenString(s.jwtSecret)
+	require.NoError(t, err)
+
+	request := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, req)
+		return writer
+	}
+
+	t.Run("all epochs", func(t *testing.T) {
+		writer := request("/eth/v1/validator/performance")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &KeysPerformanceResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.DeepEqual(t, []*EpochPerformance{
+			{
+				Pubkey: hexutil.Encode(key1[:]),
+				Epoch:  "9",
+				Attestation: &AttestationPerformance{
+					InclusionDistance:    "0",
+					CorrectlyVotedSource: true,
+					BalanceBefore:        "32",
+					BalanceAfter:         "33",
+					InactivityScore:      "0",
+				},
+				Proposals:     []*ProposalPerformance{},
+				SyncCommittee: &SyncCommitteePerformance{Submitted: "0", Failed: "0"},
+			},
+			{
+				Pubkey: hexutil.Encode(key2[:]),
+				Epoch:  "10",
+				Proposals: []*ProposalPerformance{
+					{Slot: "321", Proposed: true, BlockRoot: "0x03"},
+					{Slot: "325"},
+				},
+				SyncCommittee: &SyncCommitteePerformance{Submitted: "2", Failed: "1"},
+			},
+		}, resp.Data)
+	})
+	t.Run("filtered", func(t *testing.T) {
+		writer := request("/eth/v1/validator/performance?epoch=10")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &KeysPerformanceResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "10", resp.Data[0].Epoch)
+
+		writer = request("/eth/v1/validator/performance?pubkey=" + hexutil.Encode(key1[:]))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp = &KeysPerformanceResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, hexutil.Encode(key1[:]), resp.Data[0].Pubkey)
+	})
+	t.Run("invalid epoch", func(t *testing.T) {
+		writer := request("/eth/v1/validator/performance?epoch=foo")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"path/filepath"
 	"time"
 
+	"github.com/gorilla/mux"
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpcopentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
@@ -52,6 +54,7 @@ type Config struct {
 	WalletInitializedFeed    *event.Feed
 	NodeGatewayEndpoint      string
 	Wallet                   *wallet.Wallet
+	Router                   *mux.Router
 }
 
 // Server defining a gRPC server for the remote signer API.
@@ -98,7 +101,7 @@ type Server struct {
 // NewServer instantiates a new gRPC server.
 func NewServer(ctx context.Context, cfg *Config) *Server {
 	ctx, cancel := context.WithCancel(ctx)
-	return &Server{
+	server := &Server{
 		ctx:                      ctx,
 		cancel:                   cancel,
 		logsStreamer:             logs.NewStreamServer(),
@@ -127,6 +130,14 @@ func NewServer(ctx context.Context, cfg *Config) *Server {
 		validatorGatewayHost:     cfg.ValidatorGatewayHost,
 		validatorGatewayPort:     cfg.ValidatorGatewayPort,
 	}
+	if cfg.Router != nil {
+		cfg.Router.HandleFunc("/eth/v1/validator/{pubkey}/ownership_proof", server.SignOwnershipProof).Methods(http.MethodPost)
+		cfg.Router.HandleFunc("/eth/v1/validator/doppelganger", server.GetDoppelgangerStatuses).Methods(http.MethodGet)
+		cfg.Router.HandleFunc("/eth/v1/validator/performance", server.GetKeysPerformance).Methods(http.MethodGet)
+		cfg.Router.HandleFunc("/eth/v1/validator/slashing_protection", server.ExportSlashingProtectionJSON).Methods(http.MethodGet)
+		cfg.Router.HandleFunc("/eth/v1/validator/slashing_protection", server.ImportSlashingProtectionJSON).Methods(http.MethodPost)
+	}
+	return server
 }
 
 // Start the gRPC server.
@@ -0,0 +1,111 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	slashingprotection "github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history"
+	"go.opencensus.io/trace"
+)
+
+// ExportSlashingProtectionResponse is the response of ExportSlashingProtectionJSON.
+type ExportSlashingProtectionResponse struct {
+	SlashingProtection string `json:"slashing_protection"`
+}
+
+// ImportSlashingProtectionRequest is the request body of ImportSlashingProtectionJSON.
+type ImportSlashingProtectionRequest struct {
+	SlashingProtection string   `json:"slashing_protection"`
+	Pubkeys            []string `json:"pubkeys"`
+}
+
+// ExportSlashingProtectionJSON exports the slashing protection history of the validator database in the EIP-3076
+// interchange format, in the same field as the history returned when deleting keystores. The history can be limited
+// to some keys with the pubkey query parameter.
+func (s *Server) ExportSlashingProtectionJSON(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.ExportSlashingProtectionJSON")
+	defer span.End()
+
+	if err := s.authorizeRequest(r); err != nil {
+		http2.HandleError(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if s.valDB == nil {
+		http2.HandleError(w, "Validator database not found", http.StatusServiceUnavailable)
+		return
+	}
+	pubkeys, err := decodePubkeys(r.URL.Query()["pubkey"])
+	if err != nil {
+		http2.HandleError(w, "Invalid public key: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	eipJSON, err := slashingprotection.ExportStandardProtectionJSON(ctx, s.valDB, pubkeys...)
+	if err != nil {
+		http2.HandleError(w, "Could not export slashing protection history: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	encoded, err := json.Marshal(eipJSON)
+	if err != nil {
+		http2.HandleError(w, "Could not JSON marshal slashing protection history: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http2.WriteJson(w, &ExportSlashingProtectionResponse{SlashingProtection: string(encoded)})
+}
+
+// ImportSlashingProtectionJSON imports a slashing protection history in the EIP-3076 interchange format into the
+// validator database, for instance the history exported by another validator client before migrating keys. When
+// public keys are given, only the history of those keys is imported.
+func (s *Server) ImportSlashingProtectionJSON(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.ImportSlashingProtectionJSON")
+	defer span.End()
+
+	if err := s.authorizeRequest(r); err != nil {
+		http2.HandleError(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if s.valDB == nil {
+		http2.HandleError(w, "Validator database not found", http.StatusServiceUnavailable)
+		return
+	}
+	var req ImportSlashingProtectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http2.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.SlashingProtection == "" {
+		http2.HandleError(w, "Empty slashing_protection JSON", http.StatusBadRequest)
+		return
+	}
+	pubkeys, err := decodePubkeys(req.Pubkeys)
+	if err != nil {
+		http2.HandleError(w, "Invalid public key: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	buf := bytes.NewBufferString(req.SlashingProtection)
+	if err := slashingprotection.ImportStandardProtectionJSON(ctx, s.valDB, buf, pubkeys...); err != nil {
+		http2.HandleError(w, "Could not import slashing protection history: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	log.Info("Slashing protection JSON successfully imported")
+	w.WriteHeader(http.StatusOK)
+}
+
+func decodePubkeys(hexKeys []string) ([][]byte, error) {
+	pubkeys := make([][]byte, len(hexKeys))
+	for i, k := range hexKeys {
+		pubkey, err := hexutil.Decode(k)
+		if err != nil {
+			return nil, err
+		}
+		if len(pubkey) != fieldparams.BLSPubkeyLength {
+			return nil, fmt.Errorf("%s is not %d bytes long", k, fieldparams.BLSPubkeyLength)
+		}
+		pubkeys[i] = pubkey
+	}
+	return pubkeys, nil
+}
@@ -0,0 +1,94 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	dbtest "github.com/prysmaticlabs/prysm/v4/validator/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history/format"
+	mocks "github.com/prysmaticlabs/prysm/v4/validator/testing"
+)
+
+func TestServer_ImportExportSlashingProtectionJSON(t *testing.T) {
+	ctx := context.Background()
+	router := mux.NewRouter()
+	s := NewServer(ctx, &Config{ValDB: dbtest.SetupDB(t, nil), Router: router})
+	s.jwtSecret = []byte("testKey")
+	token, err := createTokenString(s.jwtSecret)
+	require.NoError(t, err)
+
+	request := func(method, target string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, req)
+		return writer
+	}
+
+	pubKeys, err := mocks.CreateRandomPubKeys(3)
+	require.NoError(t, err)
+	attestingHistory, proposalHistory := mocks.MockAttestingAndProposalHistories(pubKeys)
+	eipJSON, err := mocks.MockSlashingProtectionJSON(pubKeys, attestingHistory, proposalHistory)
+	require.NoError(t, err)
+	encoded, err := json.Marshal(eipJSON)
+	require.NoError(t, err)
+
+	t.Run("unauthorized", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/eth/v1/validator/slashing_protection", nil)
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, req)
+		assert.Equal(t, http.StatusUnauthorized, writer.Code)
+	})
+	t.Run("invalid public key", func(t *testing.T) {
+		body, err := json.Marshal(&ImportSlashingProtectionRequest{SlashingProtection: string(encoded), Pubkeys: []string{"0x01"}})
+		require.NoError(t, err)
+		writer := request(http.MethodPost, "/eth/v1/validator/slashing_protection", body)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Invalid public key", writer.Body.String())
+	})
+	t.Run("empty slashing protection", func(t *testing.T) {
+		writer := request(http.MethodPost, "/eth/v1/validator/slashing_protection", []byte("{}"))
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Empty slashing_protection JSON", writer.Body.String())
+	})
+	t.Run("partial import and export", func(t *testing.T) {
+		// Only the history of the first two keys is imported.
+		body, err := json.Marshal(&ImportSlashingProtectionRequest{
+			SlashingProtection: string(encoded),
+			Pubkeys:            []string{hexutil.Encode(pubKeys[0][:]), hexutil.Encode(pubKeys[1][:])},
+		})
+		require.NoError(t, err)
+		writer := request(http.MethodPost, "/eth/v1/validator/slashing_protection", body)
+		require.Equal(t, http.StatusOK, writer.Code)
+
+		exported := func(target string) map[string]bool {
+			writer := request(http.MethodGet, target, nil)
+			require.Equal(t, http.StatusOK, writer.Code)
+			resp := &ExportSlashingProtectionResponse{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+			exportedJSON := &format.EIPSlashingProtectionFormat{}
+			require.NoError(t, json.Unmarshal([]byte(resp.SlashingProtection), exportedJSON))
+			assert.Equal(t, eipJSON.Metadata.GenesisValidatorsRoot, exportedJSON.Metadata.GenesisValidatorsRoot)
+			keys := make(map[string]bool)
+			for _, item := range exportedJSON.Data {
+				keys[item.Pubkey] = true
+			}
+			return keys
+		}
+		assert.DeepEqual(t, map[string]bool{
+			hexutil.Encode(pubKeys[0][:]): true,
+			hexutil.Encode(pubKeys[1][:]): true,
+		}, exported("/eth/v1/validator/slashing_protection"))
+		assert.DeepEqual(t, map[string]bool{
+			hexutil.Encode(pubKeys[1][:]): true,
+		}, exported("/eth/v1/validator/slashing_protection?pubkey="+hexutil.Encode(pubKeys[1][:])))
+	})
+}
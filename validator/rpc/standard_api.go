@@ -5,10 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/prysmaticlabs/prysm/v4/api"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	validatorServiceConfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
@@ -21,6 +23,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/validator/keymanager/derived"
 	slashingprotection "github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history"
 	"github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-history/format"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -149,7 +152,17 @@ func groupImportErrors(req *ethpbservice.ImportKeystoresRequest, errorMessage st
 	return statuses
 }
 
+// deleteKeystoresBatchSize is the number of keys DeleteKeystores deletes at once. The slashing protection
+// history of a batch is exported before the next batch is deleted, so that a request running out of time
+// still returns the history of every key it deleted.
+var deleteKeystoresBatchSize = 256
+
 // DeleteKeystores allows for deleting specified public keys from Prysm.
+//
+// Keys are deleted in batches. When the request deadline does not leave enough time for another batch,
+// the remaining keys are left untouched and reported with an error status, and the response contains the
+// slashing protection history of the keys deleted so far. Retrying the request with the same keys is safe:
+// keys which were already deleted are reported as NOT_ACTIVE and their history is exported again.
 func (s *Server) DeleteKeystores(
 	ctx context.Context, req *ethpbservice.DeleteKeystoresRequest,
 ) (*ethpbservice.DeleteKeystoresResponse, error) {
@@ -168,21 +181,78 @@ func (s *Server) DeleteKeystores(
 	if len(req.Pubkeys) == 0 {
 		return &ethpbservice.DeleteKeystoresResponse{Data: make([]*ethpbservice.DeletedKeystoreStatus, 0)}, nil
 	}
-	statuses, err := km.DeleteKeystores(ctx, req.Pubkeys)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not delete keys: %v", err)
-	}
 
-	statuses, err = s.transformDeletedKeysStatuses(ctx, req.Pubkeys, statuses)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not transform deleted keys statuses: %v", err)
+	statuses := make([]*ethpbservice.DeletedKeystoreStatus, len(req.Pubkeys))
+	var exportedHistory *format.EIPSlashingProtectionFormat
+	exportedKeys := make(map[[fieldparams.BLSPubkeyLength]byte]bool)
+	var batchDuration time.Duration
+	processedAll := true
+	for start := 0; start < len(req.Pubkeys); start += deleteKeystoresBatchSize {
+		end := start + deleteKeystoresBatchSize
+		if end > len(req.Pubkeys) {
+			end = len(req.Pubkeys)
+		}
+		if start > 0 && !fitsDeadline(ctx, batchDuration) {
+			log.WithField("remainingKeys", len(req.Pubkeys)-start).Warn("Request deadline reached before all keys were deleted")
+			markUnprocessed(statuses[start:], "Key was not deleted before the request deadline. Please retry the request.")
+			processedAll = false
+			break
+		}
+		batchStart := time.Now()
+		pubKeys := req.Pubkeys[start:end]
+		batchStatuses, err := km.DeleteKeystores(ctx, pubKeys)
+		if err != nil {
+			if start == 0 {
+				return nil, status.Errorf(codes.Internal, "Could not delete keys: %v", err)
+			}
+			log.WithError(err).Error("Could not delete keys")
+			markUnprocessed(statuses[start:], "Could not delete key. Please retry the request.")
+			processedAll = false
+			break
+		}
+		batchStatuses, err = s.transformDeletedKeysStatuses(ctx, pubKeys, batchStatuses)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not transform deleted keys statuses: %v", err)
+		}
+		// Keys repeated across batches are only exported once.
+		deleted := make([][]byte, 0, len(pubKeys))
+		for _, pk := range deletedKeys(pubKeys, batchStatuses) {
+			if !exportedKeys[bytesutil.ToBytes48(pk)] {
+				deleted = append(deleted, pk)
+			}
+		}
+		if len(deleted) > 0 {
+			history, err := slashingprotection.ExportStandardProtectionJSON(ctx, s.valDB, deleted...)
+			if err != nil {
+				log.WithError(err).Warn("Could not get slashing protection history for deleted keys")
+				markUnprocessed(statuses[start:], "Non duplicate keys that were existing were deleted, but could not export slashing protection history.")
+				processedAll = false
+				break
+			}
+			if exportedHistory == nil {
+				exportedHistory = history
+			} else {
+				exportedHistory.Data = append(exportedHistory.Data, history.Data...)
+			}
+			for _, pk := range deleted {
+				exportedKeys[bytesutil.ToBytes48(pk)] = true
+			}
+		}
+		copy(statuses[start:end], batchStatuses)
+		batchDuration = time.Since(batchStart)
 	}
 
-	exportedHistory, err := s.slashingProtectionHistoryForDeletedKeys(ctx, req.Pubkeys, statuses)
-	if err != nil {
-		log.WithError(err).Warn("Could not get slashing protection history for deleted keys")
-		statuses := groupExportErrors(req, "Non duplicate keys that were existing were deleted, but could not export slashing protection history.")
-		return &ethpbservice.DeleteKeystoresResponse{Data: statuses}, nil
+	if exportedHistory == nil {
+		if !processedAll {
+			return &ethpbservice.DeleteKeystoresResponse{Data: statuses}, nil
+		}
+		// No key was deleted, in which case the export is not filtered by key.
+		exportedHistory, err = s.slashingProtectionHistoryForDeletedKeys(ctx, req.Pubkeys, statuses)
+		if err != nil {
+			log.WithError(err).Warn("Could not get slashing protection history for deleted keys")
+			statuses := groupExportErrors(req, "Non duplicate keys that were existing were deleted, but could not export slashing protection history.")
+			return &ethpbservice.DeleteKeystoresResponse{Data: statuses}, nil
+		}
 	}
 	jsonHist, err := json.Marshal(exportedHistory)
 	if err != nil {
@@ -198,6 +268,28 @@ func (s *Server) DeleteKeystores(
 	}, nil
 }
 
+// fitsDeadline returns whether the context deadline leaves enough time to process another batch of keys,
+// given the duration of the previous batch.
+func fitsDeadline(ctx context.Context, batchDuration time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return true
+	}
+	return time.Until(deadline) > 2*batchDuration
+}
+
+func markUnprocessed(statuses []*ethpbservice.DeletedKeystoreStatus, errorMessage string) {
+	for i := range statuses {
+		statuses[i] = &ethpbservice.DeletedKeystoreStatus{
+			Status:  ethpbservice.DeletedKeystoreStatus_ERROR,
+			Message: errorMessage,
+		}
+	}
+}
+
 func groupExportErrors(req *ethpbservice.DeleteKeystoresRequest, errorMessage string) []*ethpbservice.DeletedKeystoreStatus {
 	statuses := make([]*ethpbservice.DeletedKeystoreStatus, len(req.Pubkeys))
 	for i := 0; i < len(req.Pubkeys); i++ {
@@ -255,14 +347,19 @@ func (s *Server) slashingProtectionHistoryForDeletedKeys(
 ) (*format.EIPSlashingProtectionFormat, error) {
 	// We select the keys that were DELETED or NOT_ACTIVE from the previous action
 	// and use that to filter our slashing protection export.
-	filteredKeys := make([][]byte, 0, len(pubKeys))
+	return slashingprotection.ExportStandardProtectionJSON(ctx, s.valDB, deletedKeys(pubKeys, statuses)...)
+}
+
+// deletedKeys returns the keys which were DELETED or NOT_ACTIVE according to the given statuses.
+func deletedKeys(pubKeys [][]byte, statuses []*ethpbservice.DeletedKeystoreStatus) [][]byte {
+	keys := make([][]byte, 0, len(pubKeys))
 	for i, pk := range pubKeys {
 		if statuses[i].Status == ethpbservice.DeletedKeystoreStatus_DELETED ||
 			statuses[i].Status == ethpbservice.DeletedKeystoreStatus_NOT_ACTIVE {
-			filteredKeys = append(filteredKeys, pk)
+			keys = append(keys, pk)
 		}
 	}
-	return slashingprotection.ExportStandardProtectionJSON(ctx, s.valDB, filteredKeys...)
+	return keys
 }
 
 // ListRemoteKeys returns a list of all public keys defined for web3signer keymanager type.
@@ -412,13 +509,13 @@ func (s *Server) GetGasLimit(_ context.Context, req *ethpbservice.PubkeyRequest)
 	settings := s.validatorService.ProposerSettings()
 	if settings != nil {
 		proposerOption, found := settings.ProposeConfig[bytesutil.ToBytes48(validatorKey)]
-		if found {
-			if proposerOption.BuilderConfig != nil {
-				resp.Data.GasLimit = uint64(proposerOption.BuilderConfig.GasLimit)
-				return resp, nil
-			}
-		} else if settings.DefaultConfig != nil && settings.DefaultConfig.BuilderConfig != nil {
-			resp.Data.GasLimit = uint64(s.validatorService.ProposerSettings().DefaultConfig.BuilderConfig.GasLimit)
+		if found && proposerOption != nil && proposerOption.BuilderConfig != nil {
+			resp.Data.GasLimit = uint64(proposerOption.BuilderConfig.GasLimit)
+			return resp, nil
+		}
+		// Keys without a builder config of their own use the gas limit of the default builder config.
+		if settings.DefaultConfig != nil && settings.DefaultConfig.BuilderConfig != nil {
+			resp.Data.GasLimit = uint64(settings.DefaultConfig.BuilderConfig.GasLimit)
 			return resp, nil
 		}
 	}
@@ -456,7 +553,7 @@ func (s *Server) SetGasLimit(ctx context.Context, req *ethpbservice.SetGasLimitR
 				proposerOption.BuilderConfig.GasLimit = validator.Uint64(req.GasLimit)
 			}
 		} else {
-			if settings.DefaultConfig == nil {
+			if settings.DefaultConfig == nil || settings.DefaultConfig.BuilderConfig == nil || !settings.DefaultConfig.BuilderConfig.Enabled {
 				return &empty.Empty{}, status.Errorf(codes.FailedPrecondition, "gas limit changes only apply when builder is enabled")
 			}
 			option := settings.DefaultConfig.Clone()
@@ -682,7 +779,8 @@ func validatePublicKey(pubkey []byte) error {
 	return nil
 }
 
-// SetVoluntaryExit creates a signed voluntary exit message and returns a VoluntaryExit object.
+// SetVoluntaryExit creates a signed voluntary exit message and returns a VoluntaryExit object. The exit is also
+// broadcast through the beacon node when requested with the broadcast exit header.
 func (s *Server) SetVoluntaryExit(ctx context.Context, req *ethpbservice.SetVoluntaryExitRequest) (*ethpbservice.SetVoluntaryExitResponse, error) {
 	if s.validatorService == nil {
 		return nil, status.Error(codes.FailedPrecondition, "Validator service not ready")
@@ -718,6 +816,16 @@ func (s *Server) SetVoluntaryExit(ctx context.Context, req *ethpbservice.SetVolu
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not create voluntary exit: %v", err)
 	}
+	if broadcastExitRequested(ctx) {
+		if _, err := s.beaconNodeValidatorClient.ProposeExit(ctx, sve); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not broadcast voluntary exit: %v", err)
+		}
+		log.WithFields(logrus.Fields{
+			"pubkey":         fmt.Sprintf("%#x", req.Pubkey),
+			"validatorIndex": sve.Exit.ValidatorIndex,
+			"epoch":          sve.Exit.Epoch,
+		}).Info("Broadcast voluntary exit")
+	}
 
 	return &ethpbservice.SetVoluntaryExitResponse{
 		Data: &ethpbservice.SetVoluntaryExitResponse_SignedVoluntaryExit{
@@ -729,3 +837,13 @@ func (s *Server) SetVoluntaryExit(ctx context.Context, req *ethpbservice.SetVolu
 		},
 	}, nil
 }
+
+// broadcastExitRequested returns true if the request asks for the voluntary exit to be broadcast.
+func broadcastExitRequested(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	v := md.Get(api.BroadcastExitHeader)
+	return len(v) > 0 && v[0] == "true"
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prysmaticlabs/prysm/v4/api"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
@@ -44,6 +45,7 @@ import (
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -429,6 +431,83 @@ func TestServer_DeleteKeystores(t *testing.T) {
 	}
 }
 
+func TestServer_DeleteKeystores_Batches(t *testing.T) {
+	defer func(size int) { deleteKeystoresBatchSize = size }(deleteKeystoresBatchSize)
+	deleteKeystoresBatchSize = 1
+
+	ctx := context.Background()
+	srv := setupServerWithWallet(t)
+
+	// We recover 3 accounts from a test mnemonic.
+	numAccounts := 3
+	km, er := srv.validatorService.Keymanager()
+	require.NoError(t, er)
+	dr, ok := km.(*derived.Keymanager)
+	require.Equal(t, true, ok)
+	err := dr.RecoverAccountsFromMnemonic(ctx, mocks.TestMnemonic, derived.DefaultMnemonicLanguage, "", numAccounts)
+	require.NoError(t, err)
+	publicKeys, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+
+	// Create a validator database.
+	validatorDB, err := kv.NewKVStore(ctx, defaultWalletPath, &kv.Config{
+		PubKeys: publicKeys,
+	})
+	require.NoError(t, err)
+	srv.valDB = validatorDB
+	defer func() {
+		require.NoError(t, validatorDB.Close())
+	}()
+
+	// Generate mock slashing history.
+	attestingHistory := make([][]*kv.AttestationRecord, 0)
+	proposalHistory := make([]kv.ProposalHistoryForPubkey, len(publicKeys))
+	for i := 0; i < len(publicKeys); i++ {
+		proposalHistory[i].Proposals = make([]kv.Proposal, 0)
+	}
+	mockJSON, err := mocks.MockSlashingProtectionJSON(publicKeys, attestingHistory, proposalHistory)
+	require.NoError(t, err)
+	encoded, err := json.Marshal(mockJSON)
+	require.NoError(t, err)
+	_, err = srv.ImportSlashingProtection(ctx, &validatorpb.ImportSlashingProtectionRequest{
+		SlashingProtectionJson: string(encoded),
+	})
+	require.NoError(t, err)
+
+	exportedKeys := func(resp *ethpbservice.DeleteKeystoresResponse) []string {
+		data := &format.EIPSlashingProtectionFormat{}
+		require.NoError(t, json.Unmarshal([]byte(resp.SlashingProtection), data))
+		keys := make([]string, len(data.Data))
+		for i, d := range data.Data {
+			keys[i] = d.Pubkey
+		}
+		return keys
+	}
+
+	t.Run("every batch is exported once", func(t *testing.T) {
+		keys := [][]byte{publicKeys[0][:], publicKeys[0][:], publicKeys[1][:]}
+		resp, err := srv.DeleteKeystores(ctx, &ethpbservice.DeleteKeystoresRequest{Pubkeys: keys})
+		require.NoError(t, err)
+		require.Equal(t, 3, len(resp.Data))
+		require.Equal(t, ethpbservice.DeletedKeystoreStatus_DELETED, resp.Data[0].Status)
+		require.Equal(t, ethpbservice.DeletedKeystoreStatus_NOT_ACTIVE, resp.Data[1].Status)
+		require.Equal(t, ethpbservice.DeletedKeystoreStatus_DELETED, resp.Data[2].Status)
+		require.DeepEqual(t, []string{fmt.Sprintf("%#x", keys[0]), fmt.Sprintf("%#x", keys[2])}, exportedKeys(resp))
+	})
+	t.Run("stops at the request deadline", func(t *testing.T) {
+		deadlineCtx, cancel := context.WithDeadline(ctx, time.Now())
+		defer cancel()
+		keys := [][]byte{publicKeys[2][:], publicKeys[0][:]}
+		resp, err := srv.DeleteKeystores(deadlineCtx, &ethpbservice.DeleteKeystoresRequest{Pubkeys: keys})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(resp.Data))
+		require.Equal(t, ethpbservice.DeletedKeystoreStatus_DELETED, resp.Data[0].Status)
+		require.Equal(t, ethpbservice.DeletedKeystoreStatus_ERROR, resp.Data[1].Status)
+		require.Equal(t, "Key was not deleted before the request deadline. Please retry the request.", resp.Data[1].Message)
+		require.DeepEqual(t, []string{fmt.Sprintf("%#x", keys[0])}, exportedKeys(resp))
+	})
+}
+
 func TestServer_DeleteKeystores_FailedSlashingProtectionExport(t *testing.T) {
 	ctx := context.Background()
 	srv := setupServerWithWallet(t)
@@ -1195,6 +1274,22 @@ func TestServer_GetGasLimit(t *testing.T) {
 			pubkey: bytesutil.ToBytes48(byteval2),
 			want:   987654321,
 		},
+		{
+			name: "ProposerSetting for specific pubkey has no builder config",
+			args: &validatorserviceconfig.ProposerSettings{
+				ProposeConfig: map[[48]byte]*validatorserviceconfig.ProposerOption{
+					bytesutil.ToBytes48(byteval): {
+						FeeRecipientConfig: &validatorserviceconfig.FeeRecipientConfig{},
+					},
+				},
+				DefaultConfig: &validatorserviceconfig.ProposerOption{
+					BuilderConfig: &validatorserviceconfig.BuilderConfig{GasLimit: 987654321},
+				},
+			},
+			// the validator has settings without a builder config, so the gaslimit returned is the default value.
+			pubkey: bytesutil.ToBytes48(byteval),
+			want:   987654321,
+		},
 		{
 			name:   "No proposerSetting at all",
 			args:   nil,
@@ -1329,6 +1424,22 @@ func TestServer_SetGasLimit(t *testing.T) {
 			},
 			},
 		},
+		{
+			name:        "ProposerSettings.ProposeConfig is NOT defined for pubkey AND ProposerSettings.DefaultConfig.BuilderConfig is nil",
+			pubkey:      pubkey1,
+			newGasLimit: 9999,
+			proposerSettings: &validatorserviceconfig.ProposerSettings{
+				ProposeConfig: map[[48]byte]*validatorserviceconfig.ProposerOption{
+					bytesutil.ToBytes48(pubkey2): {
+						BuilderConfig: nil,
+					},
+				},
+				DefaultConfig: &validatorserviceconfig.ProposerOption{
+					FeeRecipientConfig: &validatorserviceconfig.FeeRecipientConfig{},
+				},
+			},
+			wantErr: "gas limit changes only apply when builder is enabled",
+		},
 		{
 			name:        "ProposerSettings.ProposeConfig is defined for pubkey, BuilderConfig is nil AND ProposerSettings.DefaultConfig.BuilderConfig is defined",
 			pubkey:      pubkey1,
@@ -1592,15 +1703,21 @@ func TestServer_SetVoluntaryExit(t *testing.T) {
 	}
 
 	beaconClient.EXPECT().ValidatorIndex(gomock.Any(), &eth.ValidatorIndexRequest{PublicKey: pubKeys[0][:]}).
-		Times(3).
+		Times(4).
 		Return(&eth.ValidatorIndexResponse{Index: 2}, nil)
 
 	beaconClient.EXPECT().DomainData(
 		gomock.Any(), // ctx
 		gomock.Any(), // epoch
-	).Times(3).
+	).Times(4).
 		Return(&eth.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
 
+	beaconClient.EXPECT().ProposeExit(
+		gomock.Any(), // ctx
+		gomock.Any(), // signed exit
+	).Times(1).
+		Return(&eth.ProposeExitResponse{}, nil /*err*/)
+
 	mockNodeClient.EXPECT().
 		GetGenesis(gomock.Any(), gomock.Any()).
 		Times(3).
@@ -1620,10 +1737,11 @@ func TestServer_SetVoluntaryExit(t *testing.T) {
 	}
 
 	tests := []struct {
-		name   string
-		pubkey []byte
-		epoch  primitives.Epoch
-		w      want
+		name      string
+		pubkey    []byte
+		epoch     primitives.Epoch
+		broadcast bool
+		w         want
 	}{
 		{
 			name:  "Ok: with epoch",
@@ -1634,6 +1752,16 @@ func TestServer_SetVoluntaryExit(t *testing.T) {
 				signature:      []uint8{175, 157, 5, 134, 253, 2, 193, 35, 176, 43, 217, 36, 39, 240, 24, 79, 207, 133, 150, 7, 237, 16, 54, 244, 64, 27, 244, 17, 8, 225, 140, 1, 172, 24, 35, 95, 178, 116, 172, 213, 113, 182, 193, 61, 192, 65, 162, 253, 19, 202, 111, 164, 195, 215, 0, 205, 95, 7, 30, 251, 244, 157, 210, 155, 238, 30, 35, 219, 177, 232, 174, 62, 218, 69, 23, 249, 180, 140, 60, 29, 190, 249, 229, 95, 235, 236, 81, 33, 60, 4, 201, 227, 70, 239, 167, 2},
 			},
 		},
+		{
+			name:      "Ok: with broadcast",
+			epoch:     30000000,
+			broadcast: true,
+			w: want{
+				epoch:          30000000,
+				validatorIndex: 2,
+				signature:      []uint8{175, 157, 5, 134, 253, 2, 193, 35, 176, 43, 217, 36, 39, 240, 24, 79, 207, 133, 150, 7, 237, 16, 54, 244, 64, 27, 244, 17, 8, 225, 140, 1, 172, 24, 35, 95, 178, 116, 172, 213, 113, 182, 193, 61, 192, 65, 162, 253, 19, 202, 111, 164, 195, 215, 0, 205, 95, 7, 30, 251, 244, 157, 210, 155, 238, 30, 35, 219, 177, 232, 174, 62, 218, 69, 23, 249, 180, 140, 60, 29, 190, 249, 229, 95, 235, 236, 81, 33, 60, 4, 201, 227, 70, 239, 167, 2},
+			},
+		},
 		{
 			name: "Ok: epoch not set",
 			w: want{
@@ -1645,7 +1773,11 @@ func TestServer_SetVoluntaryExit(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			resp, err := s.SetVoluntaryExit(ctx, &ethpbservice.SetVoluntaryExitRequest{Pubkey: pubKeys[0][:], Epoch: tt.epoch})
+			reqCtx := ctx
+			if tt.broadcast {
+				reqCtx = metadata.NewIncomingContext(ctx, metadata.Pairs(api.BroadcastExitHeader, "true"))
+			}
+			resp, err := s.SetVoluntaryExit(reqCtx, &ethpbservice.SetVoluntaryExitRequest{Pubkey: pubKeys[0][:], Epoch: tt.epoch})
 			require.NoError(t, err)
 			if tt.w.epoch == 0 {
 				genesisResponse, err := s.beaconNodeClient.GetGenesis(ctx, &emptypb.Empty{})
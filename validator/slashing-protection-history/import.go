@@ -21,8 +21,8 @@ import (
 // ImportStandardProtectionJSON takes in EIP-3076 compliant JSON file used for slashing protection
 // by Ethereum validators and imports its data into Prysm's internal representation of slashing
 // protection in the validator client's database. For more information, see the EIP document here:
-// https://eips.ethereum.org/EIPS/eip-3076.
-func ImportStandardProtectionJSON(ctx context.Context, validatorDB db.Database, r io.Reader) error {
+// https://eips.ethereum.org/EIPS/eip-3076. If keys are given, only the data of those keys is imported.
+func ImportStandardProtectionJSON(ctx context.Context, validatorDB db.Database, r io.Reader, filteredKeys ...[]byte) error {
 	encodedJSON, err := io.ReadAll(r)
 	if err != nil {
 		return errors.Wrap(err, "could not read slashing protection JSON file")
@@ -31,6 +31,15 @@ func ImportStandardProtectionJSON(ctx context.Context, validatorDB db.Database,
 	if err := json.Unmarshal(encodedJSON, interchangeJSON); err != nil {
 		return errors.Wrap(err, "could not unmarshal slashing protection JSON file")
 	}
+
+	// Allow for filtering data for the keys we wish to import.
+	if len(filteredKeys) > 0 {
+		interchangeJSON.Data, err = filterProtectionData(interchangeJSON.Data, filteredKeys)
+		if err != nil {
+			return errors.Wrap(err, "could not filter slashing protection data by public key")
+		}
+	}
+
 	if interchangeJSON.Data == nil {
 		log.Warn("No slashing protection data to import")
 		return nil
@@ -137,6 +146,24 @@ func ImportStandardProtectionJSON(ctx context.Context, validatorDB db.Database,
 	return nil
 }
 
+func filterProtectionData(data []*format.ProtectionData, filteredKeys [][]byte) ([]*format.ProtectionData, error) {
+	filteredKeysMap := make(map[string]bool, len(filteredKeys))
+	for _, k := range filteredKeys {
+		filteredKeysMap[string(k)] = true
+	}
+	filtered := make([]*format.ProtectionData, 0, len(filteredKeys))
+	for _, item := range data {
+		pubKey, err := PubKeyFromHex(item.Pubkey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s is not a valid public key", item.Pubkey)
+		}
+		if filteredKeysMap[string(pubKey[:])] {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
+
 func validateMetadata(ctx context.Context, validatorDB db.Database, interchangeJSON *format.EIPSlashingProtectionFormat) error {
 	// We need to ensure the version in the metadata field matches the one we support.
 	version := interchangeJSON.Metadata.InterchangeFormatVersion
@@ -168,6 +168,30 @@ func TestStore_ImportInterchangeData_OK(t *testing.T) {
 	}
 }
 
+func TestStore_ImportInterchangeData_FilteredKeys(t *testing.T) {
+	ctx := context.Background()
+	numValidators := 4
+	publicKeys, err := valtest.CreateRandomPubKeys(numValidators)
+	require.NoError(t, err)
+	validatorDB := dbtest.SetupDB(t, nil)
+
+	attestingHistory, proposalHistory := valtest.MockAttestingAndProposalHistories(publicKeys)
+	standardProtectionFormat, err := valtest.MockSlashingProtectionJSON(publicKeys, attestingHistory, proposalHistory)
+	require.NoError(t, err)
+	blob, err := json.Marshal(standardProtectionFormat)
+	require.NoError(t, err)
+
+	// Only the data of the first key is imported.
+	err = ImportStandardProtectionJSON(ctx, validatorDB, bytes.NewBuffer(blob), publicKeys[0][:])
+	require.NoError(t, err)
+	attestedPublicKeys, err := validatorDB.AttestedPublicKeys(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{publicKeys[0]}, attestedPublicKeys)
+	proposedPublicKeys, err := validatorDB.ProposedPublicKeys(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{publicKeys[0]}, proposedPublicKeys)
+}
+
 func Test_validateMetadata(t *testing.T) {
 	goodRoot := [32]byte{1}
 	goodStr := make([]byte, hex.EncodedLen(len(goodRoot)))